@@ -26,6 +26,12 @@ type AgentPolicySelector struct {
 	// If empty, the policy applies to all agents in the namespace.
 	// +optional
 	Agents []string `json:"agents,omitempty"`
+
+	// workspaces is a list of workspace names this policy applies to, matched
+	// against the workspace each tool call is made on behalf of.
+	// If empty, the policy applies to tool calls from every workspace.
+	// +optional
+	Workspaces []string `json:"workspaces,omitempty"`
 }
 
 // AgentPolicyMode defines how the agent policy is applied.
@@ -57,9 +63,12 @@ type ToolAccessRule struct {
 	// +kubebuilder:validation:MinLength=1
 	Registry string `json:"registry"`
 
-	// tools is the list of tool names within the registry.
+	// tools is the list of tool names within the registry. An entry may be an
+	// exact name, a prefix pattern ("search_*"), a suffix pattern ("*_delete"),
+	// or "*" for every tool in the registry.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Pattern=`^(\*|\*?[^*]+|[^*]+\*)$`
 	Tools []string `json:"tools"`
 }
 
@@ -75,6 +84,32 @@ type ToolAccessConfig struct {
 	Rules []ToolAccessRule `json:"rules"`
 }
 
+// TransformConfig rewrites the model traffic of the selected agents, so
+// platform teams can enforce organisation-wide prompts and request shaping
+// without editing each agent's PromptPack or Provider. The agent's runtime
+// reads its transforms when it starts.
+type TransformConfig struct {
+	// systemPromptPrefix is prepended to the agent's system prompt.
+	// +optional
+	SystemPromptPrefix string `json:"systemPromptPrefix,omitempty"`
+
+	// headers are set on every request to the agent's model provider,
+	// overriding the Provider's headers of the same name.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// stripParams lists model request parameters (e.g. "temperature", "seed")
+	// omitted from every request to the agent's model provider. Honored by the
+	// claude, openai and openai-compatible provider types.
+	// +optional
+	StripParams []string `json:"stripParams,omitempty"`
+
+	// modelRewrites maps a model the agent's Provider selects to the model
+	// requested in its place.
+	// +optional
+	ModelRewrites map[string]string `json:"modelRewrites,omitempty"`
+}
+
 // OnFailureAction defines behavior when policy evaluation fails.
 // +kubebuilder:validation:Enum=deny;allow
 type OnFailureAction string
@@ -96,6 +131,11 @@ type AgentPolicySpec struct {
 	// +optional
 	ToolAccess *ToolAccessConfig `json:"toolAccess,omitempty"`
 
+	// transform rewrites the model traffic of the selected agents. Transforms
+	// are applied in both enforce and permissive mode.
+	// +optional
+	Transform *TransformConfig `json:"transform,omitempty"`
+
 	// mode is the enforcement mode: "enforce" (default) or "permissive".
 	// In permissive mode, policy decisions are logged but traffic is not blocked.
 	// +kubebuilder:default="enforce"
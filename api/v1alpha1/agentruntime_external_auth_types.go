@@ -57,6 +57,31 @@ type AgentExternalAuth struct {
 	// chart's authentication.enabled=true setup).
 	// +optional
 	EdgeTrust *EdgeTrustAuth `json:"edgeTrust,omitempty"`
+
+	// authorization restricts which agents an admitted data-plane caller
+	// may use. Without it, any credential the validators above accept
+	// may open sessions with this agent. Applies to every data-plane
+	// validator; management-plane callers are authorized by the
+	// dashboard and are not affected.
+	// +optional
+	Authorization *ExternalAuthorization `json:"authorization,omitempty"`
+}
+
+// ExternalAuthorization scopes data-plane credentials to agents.
+type ExternalAuthorization struct {
+	// agentsClaim names the identity claim listing the agents a caller
+	// may use, separated by commas or spaces (a JSON array claim works
+	// too). Each entry is "*", "<namespace>/*", "<namespace>/<agent>",
+	// or a bare agent name matching in any namespace. A caller whose
+	// identity lacks the claim, or whose claim does not name this agent,
+	// is refused with 403.
+	//
+	// The claim comes from wherever the admitting validator sources
+	// claims: the JWT for oidc, the key's claims for clientKeys, and
+	// claimsFromHeaders for edgeTrust.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	AgentsClaim string `json:"agentsClaim"`
 }
 
 // ClientKeysAuth turns on per-caller client key validation for this agent.
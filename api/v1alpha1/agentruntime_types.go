@@ -115,12 +115,25 @@ type FacadeConfig struct {
 	Port *int32 `json:"port,omitempty"`
 
 	// drainTimeout is how long the facade keeps serving active realtime calls
-	// after receiving SIGTERM (rollout/drain/scale-down) before cleanly tearing
-	// down the remainder. Duration format (e.g. "30s", "2m"). New calls stop
-	// immediately on drain regardless. Defaults to 30s when unset.
+	// and in-flight turns after receiving SIGTERM (rollout/drain/scale-down)
+	// before cleanly tearing down the remainder and handing sessions off for
+	// resume on another replica. Duration format (e.g. "30s", "2m"). New calls
+	// stop immediately on drain regardless. Defaults to 30s when unset.
 	// +optional
 	DrainTimeout *string `json:"drainTimeout,omitempty"`
 
+	// sendQueue bounds each WebSocket connection's outbound queue so a client
+	// that stops reading mid-stream cannot grow the facade's memory. Only
+	// meaningful on a type=websocket facade.
+	// +optional
+	SendQueue *FacadeSendQueueConfig `json:"sendQueue,omitempty"`
+
+	// payload tunes compression and fragmentation of large WebSocket
+	// messages, such as big tool outputs. Only meaningful on a
+	// type=websocket facade.
+	// +optional
+	Payload *FacadePayloadConfig `json:"payload,omitempty"`
+
 	// handler specifies the message handler mode.
 	// "echo" returns input messages back (for testing connectivity).
 	// "demo" provides streaming responses with simulated tool calls (for demos).
@@ -208,6 +221,81 @@ type FacadeExposeConfig struct {
 	Host string `json:"host,omitempty"`
 }
 
+// SlowConsumerPolicy is what the facade does with a client whose send queue
+// is full.
+// +kubebuilder:validation:Enum=close;drop
+type SlowConsumerPolicy string
+
+const (
+	// SlowConsumerPolicyClose closes the connection; the client can resume the
+	// session and be replayed what it missed.
+	SlowConsumerPolicyClose SlowConsumerPolicy = "close"
+	// SlowConsumerPolicyDrop sheds media, typing, progress, and presence
+	// messages while the queue is full and closes the connection for anything
+	// else.
+	SlowConsumerPolicyDrop SlowConsumerPolicy = "drop"
+)
+
+// FacadeSendQueueConfig bounds a WebSocket connection's outbound queue. See
+// FacadeConfig.sendQueue.
+type FacadeSendQueueConfig struct {
+	// maxMessages is the most messages queued for one connection.
+	// Defaults to 1024.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxMessages *int32 `json:"maxMessages,omitempty"`
+
+	// maxBytes is the most encoded bytes queued for one connection. A single
+	// message larger than this is still sent when the queue is empty.
+	// Defaults to 8388608 (8 MiB).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxBytes *int32 `json:"maxBytes,omitempty"`
+
+	// policy is what happens once the queue stays full for the write timeout.
+	// "close" disconnects the client (it can resume and be replayed); "drop"
+	// sheds media, typing, progress, and presence messages first. Defaults to "close".
+	// +kubebuilder:default="close"
+	// +optional
+	Policy SlowConsumerPolicy `json:"policy,omitempty"`
+}
+
+// FacadePayloadConfig tunes how large messages travel over a WebSocket
+// facade. See FacadeConfig.payload.
+type FacadePayloadConfig struct {
+	// compression negotiates permessage-deflate with clients that offer it.
+	// Binary media frames are never compressed. Defaults to true.
+	// +optional
+	Compression *bool `json:"compression,omitempty"`
+
+	// compressionLevel is the deflate level, from 1 (fastest) to 9
+	// (smallest). Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=9
+	// +optional
+	CompressionLevel *int32 `json:"compressionLevel,omitempty"`
+
+	// compressionThreshold is the smallest message, in bytes, that is
+	// compressed. Defaults to 1024.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CompressionThreshold *int32 `json:"compressionThreshold,omitempty"`
+
+	// maxFragmentSize is the largest piece of a message sent in one frame to
+	// a client that negotiated the fragments feature; larger messages are
+	// split into fragment messages. Defaults to 1048576 (1 MiB).
+	// +kubebuilder:validation:Minimum=1024
+	// +optional
+	MaxFragmentSize *int32 `json:"maxFragmentSize,omitempty"`
+
+	// maxFragmentedSize is the largest message a client may send as
+	// fragments, for payloads beyond the single-frame limit such as large
+	// client tool results. Defaults to 67108864 (64 MiB).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxFragmentedSize *int32 `json:"maxFragmentedSize,omitempty"`
+}
+
 // ToolRegistryRef references a ToolRegistry resource.
 type ToolRegistryRef struct {
 	// name is the name of the ToolRegistry resource.
@@ -261,6 +349,505 @@ type ContextConfig struct {
 	TTL *string `json:"ttl,omitempty"`
 }
 
+// ResponseCacheConfig configures the runtime's response cache, which answers a
+// repeated prompt with the response cached for it instead of calling the
+// provider. Responses are cached per agent, prompt and model, and per
+// conversation history, so only turns asked in the same context share them.
+// The cache lives in the context store's Redis when spec.context.type is
+// redis (shared by every replica), and in runtime memory otherwise.
+type ResponseCacheConfig struct {
+	// enabled turns the response cache on for this agent.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ttl is how long a cached response is served, in duration format (e.g., "1h", "30m").
+	// +kubebuilder:default="1h"
+	// +optional
+	TTL *string `json:"ttl,omitempty"`
+
+	// similarityThreshold also serves near-identical prompts: a prompt whose
+	// embedding has at least this cosine similarity (e.g., "0.95") to a cached
+	// prompt gets its response. Requires an embedding-role provider in
+	// spec.providers. Empty means exact matches only, ignoring case and
+	// whitespace.
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	SimilarityThreshold string `json:"similarityThreshold,omitempty"`
+}
+
+// StructuredOutputConfig makes the agent answer every turn with JSON that
+// satisfies the schema declared by the active prompt's json_schema validator
+// in the PromptPack. The runtime requests schema-constrained output from
+// providers that support it, validates each response, and asks the model to
+// repair a response that does not validate. A turn that still does not
+// validate fails with a STRUCTURED_OUTPUT_INVALID error instead of returning
+// the invalid response.
+type StructuredOutputConfig struct {
+	// enabled turns structured output on for this agent.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// maxRepairAttempts is how many times the model is asked to fix a
+	// response that does not satisfy the schema before the turn fails.
+	// 0 fails the turn on the first invalid response.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=5
+	// +optional
+	MaxRepairAttempts *int32 `json:"maxRepairAttempts,omitempty"`
+}
+
+// KnowledgeConfig grounds the agent's answers in documents indexed in a
+// vector store. Before each conversation turn the runtime embeds the user's
+// message with the embedding-role provider in spec.providers, retrieves the
+// most similar chunks and adds them to the system prompt. The chunks used are
+// recorded on the session as citations.
+type KnowledgeConfig struct {
+	// enabled turns retrieval on for this agent.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// vectorStore is where the indexed chunks live.
+	// +kubebuilder:validation:Required
+	VectorStore VectorStoreConfig `json:"vectorStore"`
+
+	// topK is the maximum number of chunks added to the prompt per turn.
+	// +kubebuilder:default=4
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	// +optional
+	TopK *int32 `json:"topK,omitempty"`
+
+	// minScore drops chunks whose similarity to the user's message is below
+	// this value (e.g., "0.7"). Empty keeps the topK best matches.
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	MinScore string `json:"minScore,omitempty"`
+}
+
+// VectorStoreType identifies a vector store backend.
+// +kubebuilder:validation:Enum=pgvector;qdrant
+type VectorStoreType string
+
+const (
+	// VectorStoreTypePgvector stores chunks in a PostgreSQL table with the
+	// pgvector extension.
+	VectorStoreTypePgvector VectorStoreType = "pgvector"
+	// VectorStoreTypeQdrant stores chunks in a Qdrant collection.
+	VectorStoreTypeQdrant VectorStoreType = "qdrant"
+)
+
+// VectorStoreConfig locates the chunks a knowledge-grounded agent retrieves.
+type VectorStoreConfig struct {
+	// type is the vector store backend.
+	// +kubebuilder:validation:Required
+	Type VectorStoreType `json:"type"`
+
+	// collection is the table (pgvector) or collection (qdrant) holding the
+	// chunks. A pgvector table must have the columns id text primary key,
+	// content text, metadata jsonb and embedding vector.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]{0,62}$`
+	Collection string `json:"collection"`
+
+	// secretRef references a Secret with the connection details: "url" holds
+	// the PostgreSQL connection URL (pgvector) or the HTTP(S) base URL
+	// (qdrant), and the optional "apiKey" holds the Qdrant API key.
+	// +kubebuilder:validation:Required
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
+// GuardrailsConfig enables built-in guardrail hooks on the agent's traffic.
+// Each list runs in order and the first blocking hook that rejects stops the
+// check.
+// Hooks declared in the PromptPack's metadata.guardrails run before these.
+type GuardrailsConfig struct {
+	// input hooks check the user's message before the model sees it.
+	// A rejected message fails the turn with a GUARDRAIL_REJECTED error.
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=atomic
+	// +optional
+	Input []GuardrailHook `json:"input,omitempty"`
+
+	// output hooks check the model's response before it is sent. Response
+	// text is held back until the checks pass, or only its newest characters
+	// with outputStreaming; a rejected response fails the turn with a
+	// GUARDRAIL_REJECTED error.
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=atomic
+	// +optional
+	Output []GuardrailHook `json:"output,omitempty"`
+
+	// outputStreaming has the output hooks check the response while it
+	// streams, instead of holding it back until it is complete.
+	// +optional
+	OutputStreaming *GuardrailStreaming `json:"outputStreaming,omitempty"`
+
+	// toolCalls hooks check the arguments of each tool call the model makes.
+	// A rejected call is not executed; the model receives the rejection as
+	// the tool's error result.
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=atomic
+	// +optional
+	ToolCalls []GuardrailHook `json:"toolCalls,omitempty"`
+}
+
+// GuardrailStreaming configures output hooks that check the response while it
+// streams.
+type GuardrailStreaming struct {
+	// windowChars is how many of the newest characters of the response are
+	// held back while it streams. Each time text is released, the output
+	// hooks check it with the last windowChars characters already sent, so
+	// a match up to this long is rejected or redacted before any of it is
+	// sent. A rejection ends the response where it is. The complete response
+	// is checked again when it ends.
+	// +kubebuilder:default=200
+	// +kubebuilder:validation:Minimum=16
+	// +kubebuilder:validation:Maximum=8192
+	// +optional
+	WindowChars *int32 `json:"windowChars,omitempty"`
+}
+
+// GuardrailHookName identifies a built-in guardrail hook.
+// +kubebuilder:validation:Enum=regexBlocklist;maxLength;language;promptInjection;moderation
+type GuardrailHookName string
+
+const (
+	// GuardrailHookRegexBlocklist rejects text matching any of patterns.
+	GuardrailHookRegexBlocklist GuardrailHookName = "regexBlocklist"
+	// GuardrailHookMaxLength rejects text longer than maxChars characters.
+	GuardrailHookMaxLength GuardrailHookName = "maxLength"
+	// GuardrailHookLanguage rejects text whose letters are mostly outside
+	// allowedScripts.
+	GuardrailHookLanguage GuardrailHookName = "language"
+	// GuardrailHookPromptInjection rejects text scoring at or above threshold
+	// as a prompt injection or jailbreak attempt, by heuristics and an
+	// optional classifier.
+	GuardrailHookPromptInjection GuardrailHookName = "promptInjection"
+	// GuardrailHookModeration rejects text a content moderation provider
+	// scores at or above threshold in any of the configured categories.
+	GuardrailHookModeration GuardrailHookName = "moderation"
+)
+
+// GuardrailAction is what a guardrail does with text its hook rejects.
+// +kubebuilder:validation:Enum=block;flag;annotate;redact
+type GuardrailAction string
+
+const (
+	// GuardrailActionBlock rejects the text.
+	GuardrailActionBlock GuardrailAction = "block"
+	// GuardrailActionFlag records a guardrail.flagged session event and lets
+	// the text through.
+	GuardrailActionFlag GuardrailAction = "flag"
+	// GuardrailActionAnnotate flags the text and, for the user's message,
+	// marks it to the model as untrusted.
+	GuardrailActionAnnotate GuardrailAction = "annotate"
+	// GuardrailActionRedact replaces the matches in the text with
+	// [REDACTED] and lets the rest through. Only regexBlocklist can redact.
+	GuardrailActionRedact GuardrailAction = "redact"
+)
+
+// GuardrailHook enables a built-in guardrail hook with its parameters.
+// +kubebuilder:validation:XValidation:rule="self.name != 'regexBlocklist' || (has(self.patterns) && size(self.patterns) > 0)",message="regexBlocklist requires patterns"
+// +kubebuilder:validation:XValidation:rule="self.name != 'maxLength' || has(self.maxChars)",message="maxLength requires maxChars"
+// +kubebuilder:validation:XValidation:rule="self.name != 'language' || (has(self.allowedScripts) && size(self.allowedScripts) > 0)",message="language requires allowedScripts"
+// +kubebuilder:validation:XValidation:rule="!has(self.action) || self.action != 'redact' || self.name == 'regexBlocklist'",message="only regexBlocklist can redact"
+// +kubebuilder:validation:XValidation:rule="self.name != 'moderation' || has(self.moderation)",message="moderation requires moderation settings"
+type GuardrailHook struct {
+	// name is the built-in hook to run.
+	// +kubebuilder:validation:Required
+	Name GuardrailHookName `json:"name"`
+
+	// patterns are the RE2 regular expressions regexBlocklist rejects.
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=atomic
+	// +optional
+	Patterns []string `json:"patterns,omitempty"`
+
+	// caseInsensitive makes regexBlocklist patterns ignore case.
+	// +optional
+	CaseInsensitive bool `json:"caseInsensitive,omitempty"`
+
+	// maxChars is the longest text, in characters, maxLength allows.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxChars *int32 `json:"maxChars,omitempty"`
+
+	// allowedScripts are the Unicode scripts (e.g., "Latin", "Cyrillic",
+	// "Han") language accepts.
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=atomic
+	// +optional
+	AllowedScripts []string `json:"allowedScripts,omitempty"`
+
+	// minRatio is the share of letters that must be in allowedScripts for
+	// language to accept the text (e.g., "0.9"). Defaults to 0.8.
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	MinRatio string `json:"minRatio,omitempty"`
+
+	// threshold is the score, between 0 and 1, at or above which
+	// promptInjection or moderation rejects the text (e.g., "0.8"). Defaults
+	// to 0.7 for promptInjection and 0.5 for moderation.
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	Threshold string `json:"threshold,omitempty"`
+
+	// classifierURL is an HTTP endpoint promptInjection asks to score the
+	// text alongside its heuristics. It is sent {"text": "..."} and answers
+	// {"score": <0..1>}; the higher score counts. A classifier that fails to
+	// answer leaves the heuristics to decide.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	ClassifierURL string `json:"classifierURL,omitempty"`
+
+	// moderation configures the content moderation provider the moderation
+	// hook asks to score the text.
+	// +optional
+	Moderation *GuardrailModeration `json:"moderation,omitempty"`
+
+	// action is what happens to text the hook rejects: block fails it,
+	// flag records a guardrail.flagged session event and lets it through,
+	// annotate also marks the user's message to the model as untrusted, and
+	// redact (regexBlocklist only) also replaces the matches with
+	// [REDACTED]. Tool calls are flagged, not annotated or redacted;
+	// function-mode input is not annotated.
+	// +kubebuilder:default=block
+	// +optional
+	Action GuardrailAction `json:"action,omitempty"`
+
+	// message replaces the rejection message sent to the client.
+	// +kubebuilder:validation:MaxLength=512
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GuardrailModerationProvider is a content moderation service.
+// +kubebuilder:validation:Enum=openai;comprehend;http
+type GuardrailModerationProvider string
+
+const (
+	// GuardrailModerationOpenAI is the OpenAI moderation API.
+	GuardrailModerationOpenAI GuardrailModerationProvider = "openai"
+	// GuardrailModerationComprehend is AWS Comprehend toxicity detection.
+	GuardrailModerationComprehend GuardrailModerationProvider = "comprehend"
+	// GuardrailModerationHTTP is a custom HTTP classifier.
+	GuardrailModerationHTTP GuardrailModerationProvider = "http"
+)
+
+// GuardrailModeration configures the provider a moderation hook asks to score
+// text by category. Hooks with the same provider settings share its answers,
+// so several hooks mapping different categories to different actions score
+// each text once. Recent answers are cached for a few minutes.
+// +kubebuilder:validation:XValidation:rule="self.provider != 'http' || has(self.url)",message="the http provider requires url"
+// +kubebuilder:validation:XValidation:rule="self.provider != 'openai' || has(self.secretRef)",message="the openai provider requires secretRef"
+type GuardrailModeration struct {
+	// provider is the moderation service. openai calls the OpenAI moderation
+	// API; comprehend calls AWS Comprehend toxicity detection (English only)
+	// with the pod's AWS credentials; http posts {"text": "..."} to url,
+	// which answers {"categories": {"<name>": <0..1>}}.
+	// +kubebuilder:validation:Required
+	Provider GuardrailModerationProvider `json:"provider"`
+
+	// url overrides the openai endpoint or names the http classifier.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// model is the openai moderation model. Defaults to
+	// omni-moderation-latest.
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// region is the AWS region of comprehend. Defaults to the pod's AWS
+	// configuration.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// secretRef names a Secret in the agent's namespace whose api-key is
+	// sent as a bearer token to openai or the http classifier.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// categories are the provider's categories the hook rejects, each at its
+	// own threshold or the hook's. When empty, any category at or above the
+	// hook's threshold is rejected.
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Categories []GuardrailModerationCategory `json:"categories,omitempty"`
+
+	// failClosed rejects the text when the provider cannot be reached or
+	// answers with an error. By default such text is let through.
+	// +optional
+	FailClosed bool `json:"failClosed,omitempty"`
+}
+
+// GuardrailModerationCategory is a moderation category the hook rejects.
+type GuardrailModerationCategory struct {
+	// name is the provider's category, e.g. "violence" or
+	// "harassment/threatening" for openai and "HATE_SPEECH" or "TOXICITY"
+	// for comprehend.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	Name string `json:"name"`
+
+	// threshold is the category score, between 0 and 1, at or above which
+	// the text is rejected. Defaults to the hook's threshold.
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	Threshold string `json:"threshold,omitempty"`
+}
+
+// BudgetConfig caps the tokens and cost the agent may spend, per session and
+// across all of its sessions. Usage counts every provider call, including the
+// calls of a tool loop, and is checked before each call, so a runaway loop is
+// stopped mid-turn rather than at its end.
+// +kubebuilder:validation:XValidation:rule="has(self.session) || has(self.agent)",message="budget requires a session or agent limit"
+type BudgetConfig struct {
+	// session limits what a single session may spend over its lifetime,
+	// including turns served by other replicas before a restart.
+	// +optional
+	Session *BudgetLimit `json:"session,omitempty"`
+
+	// agent limits what all of the agent's sessions together may spend per
+	// window. With a Redis context store the usage is shared by every
+	// replica; otherwise each replica enforces the limit on its own.
+	// +optional
+	Agent *AgentBudgetLimit `json:"agent,omitempty"`
+
+	// onExceeded is what happens to a session over its budget. reject fails
+	// the turn with a BUDGET_EXCEEDED error. summarize replaces the session's
+	// history with a summary, resets the session's usage and answers the
+	// turn; a turn that exceeds the budget part way through is still
+	// rejected. The agent budget always rejects.
+	// +kubebuilder:default=reject
+	// +optional
+	OnExceeded BudgetAction `json:"onExceeded,omitempty"`
+}
+
+// BudgetAction defines what happens to a session that exceeds its budget.
+// +kubebuilder:validation:Enum=reject;summarize
+type BudgetAction string
+
+const (
+	// BudgetActionReject fails the session's turns with BUDGET_EXCEEDED.
+	BudgetActionReject BudgetAction = "reject"
+	// BudgetActionSummarize summarizes the session's history and continues.
+	BudgetActionSummarize BudgetAction = "summarize"
+)
+
+// BudgetLimit caps tokens, cost or both. A limit is exceeded once usage
+// reaches either cap.
+// +kubebuilder:validation:XValidation:rule="has(self.maxTokens) || has(self.maxCostUSD)",message="a budget limit requires maxTokens or maxCostUSD"
+type BudgetLimit struct {
+	// maxTokens caps input plus output tokens.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTokens *int64 `json:"maxTokens,omitempty"`
+
+	// maxCostUSD caps the estimated provider cost in USD (e.g., "5.00").
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	MaxCostUSD string `json:"maxCostUSD,omitempty"`
+}
+
+// AgentBudgetLimit caps the agent's usage per window.
+// +kubebuilder:validation:XValidation:rule="has(self.maxTokens) || has(self.maxCostUSD)",message="a budget limit requires maxTokens or maxCostUSD"
+type AgentBudgetLimit struct {
+	// maxTokens caps input plus output tokens per window.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTokens *int64 `json:"maxTokens,omitempty"`
+
+	// maxCostUSD caps the estimated provider cost in USD per window (e.g., "100.00").
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	MaxCostUSD string `json:"maxCostUSD,omitempty"`
+
+	// window is the period usage is counted over, in duration format (e.g.,
+	// "1h", "24h"). Windows are aligned to the epoch, so a 24h window resets
+	// at midnight UTC.
+	// +kubebuilder:default="24h"
+	// +optional
+	Window *string `json:"window,omitempty"`
+}
+
+// AgentSLOs declares service-level objectives for the agent. The operator turns
+// each objective into a Prometheus alert (a PrometheusRule, when the Prometheus
+// Operator is installed) and reports whether any is currently breached in the
+// SLOBreached condition.
+// +kubebuilder:validation:XValidation:rule="has(self.maxP95Latency) || has(self.maxErrorRate) || has(self.maxCostPerHourUSD)",message="slos requires at least one of maxP95Latency, maxErrorRate or maxCostPerHourUSD"
+type AgentSLOs struct {
+	// maxP95Latency is the highest acceptable 95th-percentile request latency
+	// at the facade, in duration format (e.g., "2s", "500ms").
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +optional
+	MaxP95Latency string `json:"maxP95Latency,omitempty"`
+
+	// maxErrorRate is the highest acceptable fraction of facade requests that
+	// fail, between 0 and 1 (e.g., "0.05" for 5%).
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	MaxErrorRate string `json:"maxErrorRate,omitempty"`
+
+	// maxCostPerHourUSD is the highest acceptable estimated provider spend of
+	// the agent's own LLM calls, in USD per hour (e.g., "10.00"). Judge and
+	// self-play calls are not counted.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	MaxCostPerHourUSD string `json:"maxCostPerHourUSD,omitempty"`
+
+	// window is the period each objective is measured over, in duration
+	// format (e.g., "5m", "1h").
+	// +kubebuilder:default="5m"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +optional
+	Window string `json:"window,omitempty"`
+
+	// for is how long an objective must stay breached before its alert
+	// fires, in duration format (e.g., "10m"). The SLOBreached condition does
+	// not wait for it.
+	// +kubebuilder:default="5m"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +optional
+	For string `json:"for,omitempty"`
+}
+
+// AgentDelegate offers another AgentRuntime to the model as a tool. The
+// model calls it as a2a__<name> with a query; the query is sent to the agent
+// over its A2A facade and the agent's reply is the tool's result.
+type AgentDelegate struct {
+	// name is the tool's name. The model sees it as a2a__<name>.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z][a-zA-Z0-9_-]{0,47}$`
+	Name string `json:"name"`
+
+	// agentRuntimeRef references the AgentRuntime to delegate to. It must be
+	// in the same namespace and serve an a2a facade.
+	// +kubebuilder:validation:Required
+	AgentRuntimeRef corev1.LocalObjectReference `json:"agentRuntimeRef"`
+
+	// description tells the model what the agent does and when to call it.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	Description string `json:"description"`
+
+	// timeout bounds each call to the agent, in duration format (e.g.,
+	// "30s"). A call that times out fails the tool call, not the turn.
+	// +kubebuilder:default="90s"
+	// +optional
+	Timeout *string `json:"timeout,omitempty"`
+
+	// authentication configures the bearer token sent to the agent's a2a
+	// facade, for agents that require one.
+	// +optional
+	Authentication *A2AClientAuthConfig `json:"authentication,omitempty"`
+}
+
 // AutoscalerType defines the type of autoscaler to use.
 // +kubebuilder:validation:Enum=hpa;keda
 type AutoscalerType string
@@ -378,7 +965,7 @@ type AutoscalingConfig struct {
 // Hyperscaler hosting (Bedrock/Vertex/Azure) is expressed via spec.platform
 // on the Provider CRD, not as a provider type. The provider type describes
 // the wire protocol the runtime uses; the role (spec.role) is orthogonal.
-// +kubebuilder:validation:Enum=claude;openai;gemini;ollama;mock;vllm;voyageai;cartesia;elevenlabs;imagen;huggingface
+// +kubebuilder:validation:Enum=claude;openai;gemini;ollama;mock;vllm;openai-compatible;voyageai;cartesia;elevenlabs;imagen;huggingface
 type ProviderType string
 
 const (
@@ -401,6 +988,11 @@ const (
 	// ProviderTypeVLLM uses a vLLM-served OpenAI-compatible endpoint.
 	// Requires baseURL. Auth is typically via custom headers (spec.headers).
 	ProviderTypeVLLM ProviderType = "vllm"
+	// ProviderTypeOpenAICompatible speaks the OpenAI chat completions protocol
+	// to any self-hosted server (vLLM, Ollama's /v1, LM Studio, TGI...).
+	// Requires baseURL. An API key via credential is optional; request-shape
+	// quirks are tuned with spec.openAICompatible.
+	ProviderTypeOpenAICompatible ProviderType = "openai-compatible"
 	// ProviderTypeVoyageAI uses Voyage AI embedding models. Embedding-role only.
 	// Requires an API key via secretRef (VOYAGE_API_KEY).
 	ProviderTypeVoyageAI ProviderType = "voyageai"
@@ -415,7 +1007,7 @@ const (
 )
 
 // TruncationStrategy defines how to handle context overflow.
-// +kubebuilder:validation:Enum=sliding;summarize;custom
+// +kubebuilder:validation:Enum=sliding;summarize;relevance;custom
 type TruncationStrategy string
 
 const (
@@ -423,6 +1015,9 @@ const (
 	TruncationStrategySliding TruncationStrategy = "sliding"
 	// TruncationStrategySummarize summarizes old messages before removing.
 	TruncationStrategySummarize TruncationStrategy = "summarize"
+	// TruncationStrategyRelevance drops the messages least similar to the
+	// latest user turn, scored by the agent's embedding-role provider.
+	TruncationStrategyRelevance TruncationStrategy = "relevance"
 	// TruncationStrategyCustom delegates to custom runtime implementation.
 	TruncationStrategyCustom TruncationStrategy = "custom"
 )
@@ -445,14 +1040,20 @@ type ProviderDefaults struct {
 	MaxTokens *int32 `json:"maxTokens,omitempty"`
 
 	// contextWindow is the model's maximum context size in tokens.
-	// When conversation history exceeds this budget, truncation is applied.
-	// If not specified, no automatic truncation is performed.
+	// When conversation history exceeds this budget, truncation is applied
+	// before the provider call. If not specified, the budget comes from the
+	// provider when it reports its context window; otherwise no automatic
+	// truncation is performed.
 	// +optional
 	ContextWindow *int32 `json:"contextWindow,omitempty"`
 
 	// truncationStrategy defines how to handle context overflow.
 	// - sliding: Remove oldest messages first (default)
-	// - summarize: Summarize old messages before removing
+	// - summarize: Replace old messages with a summary message written by
+	//   this provider
+	// - relevance: Remove the messages least relevant to the latest user turn,
+	//   scored by the AgentRuntime's embedding-role provider (falls back to
+	//   sliding when it has none)
 	// - custom: Delegate to custom runtime implementation
 	// +kubebuilder:default=sliding
 	// +optional
@@ -1188,6 +1789,12 @@ type EvalConfig struct {
 	// +optional
 	RateLimit *EvalRateLimit `json:"rateLimit,omitempty"`
 
+	// judge selects the LLM judges that score the worker's judge evals and
+	// caps their spend. When unset, the worker judges with one of the
+	// agent's spec.providers and spend is uncapped.
+	// +optional
+	Judge *EvalJudgeConfig `json:"judge,omitempty"`
+
 	// sessionCompletion configures how session completion is detected
 	// for on_session_complete evals.
 	// +optional
@@ -1236,8 +1843,35 @@ type EvalSampling struct {
 	// +kubebuilder:default=10
 	// +optional
 	ExtendedRate *int32 `json:"extendedRate,omitempty"`
+
+	// maxSessionsPerHour caps how many of the agent's sessions the eval
+	// worker evaluates per clock hour, across all worker replicas. Sessions
+	// over the cap are skipped unless an alwaysEvalOn signal applies. Unset
+	// means no cap.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSessionsPerHour *int32 `json:"maxSessionsPerHour,omitempty"`
+
+	// alwaysEvalOn lists session signals that make the eval worker evaluate
+	// a session regardless of the sampling rates and the hourly cap.
+	// +optional
+	AlwaysEvalOn []EvalSamplingSignal `json:"alwaysEvalOn,omitempty"`
 }
 
+// EvalSamplingSignal is a session signal that forces evaluation of a
+// session that sampling would otherwise skip.
+// +kubebuilder:validation:Enum=negativeFeedback;policyViolation
+type EvalSamplingSignal string
+
+const (
+	// EvalSamplingSignalNegativeFeedback forces evaluation of sessions with
+	// a thumbs-down annotation.
+	EvalSamplingSignalNegativeFeedback EvalSamplingSignal = "negativeFeedback"
+	// EvalSamplingSignalPolicyViolation forces evaluation of sessions in
+	// which a validator failed or a ToolPolicy denied a tool call.
+	EvalSamplingSignalPolicyViolation EvalSamplingSignal = "policyViolation"
+)
+
 // EvalRateLimit configures rate limits for eval execution.
 type EvalRateLimit struct {
 	// maxEvalsPerSecond is the maximum number of evals to execute per second.
@@ -1253,6 +1887,30 @@ type EvalRateLimit struct {
 	MaxConcurrentJudgeCalls *int32 `json:"maxConcurrentJudgeCalls,omitempty"`
 }
 
+// EvalJudgeConfig configures the LLM judges of the eval worker.
+type EvalJudgeConfig struct {
+	// providers lists the names of spec.providers entries to judge with, in
+	// order of preference. The first is the primary judge; the rest are
+	// fallbacks, typically cheaper models, each used when every judge before
+	// it is rate-limited or over budget.
+	// +kubebuilder:validation:MinItems=1
+	Providers []string `json:"providers"`
+
+	// maxCostPerJobUSD caps what each judge may spend on one eval job (the
+	// evals of one turn, or of one completed session), in USD (e.g., "0.05").
+	// A judge that reaches it hands the rest of the job to the next judge.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	MaxCostPerJobUSD string `json:"maxCostPerJobUSD,omitempty"`
+
+	// maxCostPerDayUSD caps what each judge may spend per UTC day across all
+	// eval worker replicas, in USD (e.g., "20.00"). A judge that reaches it
+	// hands the rest of the day to the next judge.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	MaxCostPerDayUSD string `json:"maxCostPerDayUSD,omitempty"`
+}
+
 // SessionCompletionConfig configures session completion detection for evals.
 type SessionCompletionConfig struct {
 	// inactivityTimeout is the duration after the last message before a session
@@ -1336,6 +1994,8 @@ type MemoryToolsConfig struct {
 // +kubebuilder:validation:XValidation:rule="self.mode == 'function' || !has(self.inputSchema)",message="spec.inputSchema is only valid when spec.mode is 'function'"
 // +kubebuilder:validation:XValidation:rule="self.mode == 'function' || !has(self.outputSchema)",message="spec.outputSchema is only valid when spec.mode is 'function'"
 // +kubebuilder:validation:XValidation:rule="self.mode == 'function' || !has(self.outputFormat)",message="spec.outputFormat is only valid when spec.mode is 'function'"
+// +kubebuilder:validation:XValidation:rule="self.mode != 'function' || !has(self.structuredOutput)",message="spec.structuredOutput is not valid when spec.mode is 'function'; use spec.outputSchema"
+// +kubebuilder:validation:XValidation:rule="self.mode != 'function' || !has(self.knowledge)",message="spec.knowledge is not valid when spec.mode is 'function'"
 // Facade composition validations (#1576). Each rule is guarded by
 // has(self.facades) so a CR without spec.facades short-circuits to valid (#1815):
 // MinItems=1 + Required already reject absent facades on create/update, but the
@@ -1424,6 +2084,46 @@ type AgentRuntimeSpec struct {
 	// +optional
 	Context *ContextConfig `json:"context,omitempty"`
 
+	// responseCache serves repeated prompts from cached responses instead of
+	// calling the provider.
+	// +optional
+	ResponseCache *ResponseCacheConfig `json:"responseCache,omitempty"`
+
+	// structuredOutput makes the agent answer with JSON validated against the
+	// PromptPack's response schema. Function mode uses spec.outputSchema
+	// instead.
+	// +optional
+	StructuredOutput *StructuredOutputConfig `json:"structuredOutput,omitempty"`
+
+	// knowledge grounds the agent's answers in documents retrieved from a
+	// vector store.
+	// +optional
+	Knowledge *KnowledgeConfig `json:"knowledge,omitempty"`
+
+	// guardrails checks the user's messages, the model's responses and its
+	// tool calls with built-in hooks.
+	// +optional
+	Guardrails *GuardrailsConfig `json:"guardrails,omitempty"`
+
+	// budget caps the tokens and cost each session and the agent as a whole
+	// may spend.
+	// +optional
+	Budget *BudgetConfig `json:"budget,omitempty"`
+
+	// slos declares latency, error-rate and cost objectives for the agent.
+	// The operator generates Prometheus alerts from them and reports breaches
+	// in the SLOBreached condition.
+	// +optional
+	SLOs *AgentSLOs `json:"slos,omitempty"`
+
+	// delegates are other AgentRuntimes the agent may call as tools. Each
+	// exchange is recorded as a nested session linked to the calling one.
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Delegates []AgentDelegate `json:"delegates,omitempty"`
+
 	// runtime configures deployment settings like replicas and resources.
 	// +optional
 	Runtime *RuntimeConfig `json:"runtime,omitempty"`
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -123,6 +124,154 @@ type EmbeddingConfig struct {
 	Distance string `json:"distance,omitempty"`
 }
 
+// TokenLimitParam names the request field an OpenAI-compatible server reads
+// the output token limit from.
+// +kubebuilder:validation:Enum=max_tokens;max_completion_tokens
+type TokenLimitParam string
+
+const (
+	// TokenLimitParamMaxTokens sends the legacy "max_tokens" field, which
+	// every OpenAI-compatible server accepts.
+	TokenLimitParamMaxTokens TokenLimitParam = "max_tokens"
+	// TokenLimitParamMaxCompletionTokens sends "max_completion_tokens", the
+	// field current OpenAI models require.
+	TokenLimitParamMaxCompletionTokens TokenLimitParam = "max_completion_tokens"
+)
+
+// OpenAICompatibleConfig tunes the request shape for a self-hosted
+// OpenAI-compatible server. Only valid when spec.type is "openai-compatible"
+// (CEL-gated on ProviderSpec). Declared model features (vision, tools, ...)
+// come from spec.capabilities.
+type OpenAICompatibleConfig struct {
+	// tokenLimitParam selects the request field carrying the output token
+	// limit. Defaults to max_tokens, which vLLM, Ollama and most other
+	// servers accept; servers tracking the current OpenAI API may need
+	// max_completion_tokens.
+	// +optional
+	TokenLimitParam TokenLimitParam `json:"tokenLimitParam,omitempty"`
+
+	// unsupportedParams lists sampling parameters the server rejects.
+	// They are omitted from every request instead of sent with defaults.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:items:Enum=temperature;top_p
+	UnsupportedParams []string `json:"unsupportedParams,omitempty"`
+}
+
+// ProviderResilienceConfig tunes how the runtime rides out transient
+// failures of an llm provider. Only valid when spec.role is "llm"
+// (CEL-gated on ProviderSpec).
+// +kubebuilder:validation:XValidation:rule="!has(self.failoverURL) || has(self.circuitBreaker)",message="failoverURL requires circuitBreaker"
+type ProviderResilienceConfig struct {
+	// retry re-sends provider calls that fail with a transient error
+	// (connection failures, HTTP 429, 502, 503, 504, 529). A Retry-After header
+	// on the failed response overrides the backoff delay.
+	// +optional
+	Retry *ProviderRetryConfig `json:"retry,omitempty"`
+
+	// hedgeAfter sends a second, identical request when the first has not
+	// returned response headers within this duration; whichever answers
+	// first is used and the other is cancelled. Cuts tail latency at the
+	// cost of paying for some requests twice. Go duration string, e.g.
+	// "3s". Unset disables hedging.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	HedgeAfter string `json:"hedgeAfter,omitempty"`
+
+	// circuitBreaker stops calling a provider that keeps failing, so
+	// requests fail fast instead of waiting on a dead endpoint.
+	// +optional
+	CircuitBreaker *ProviderCircuitBreakerConfig `json:"circuitBreaker,omitempty"`
+
+	// failoverURL is a secondary endpoint serving the same API, e.g. a
+	// replica in another region. While the circuit is open, calls go to
+	// it instead, with the same credentials and headers; only the scheme
+	// and host of the request are replaced. The failover endpoint has its
+	// own circuit breaker with the same settings. Requires circuitBreaker.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://[^/]+/?$`
+	FailoverURL string `json:"failoverURL,omitempty"`
+}
+
+// ProviderTLSConfig configures the client certificate the runtime presents
+// to an llm provider, for endpoints that require mutual TLS. Only valid when
+// spec.role is "llm" (CEL-gated on ProviderSpec).
+// +kubebuilder:validation:XValidation:rule="has(self.secretRef) != has(self.spiffe)",message="exactly one of secretRef or spiffe must be set"
+type ProviderTLSConfig struct {
+	// secretRef names a kubernetes.io/tls Secret in the Provider's namespace.
+	// Its tls.crt and tls.key are the client certificate; an optional ca.crt
+	// replaces the system roots for verifying the provider. The runtime reads
+	// the Secret at startup, and agents using the Provider roll when a
+	// Secret labelled omnia.altairalabs.ai/type=credentials changes.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// spiffe takes the client certificate and trust bundle from the SPIFFE
+	// Workload API. Both are rotated in place, without restarting the agent.
+	// +optional
+	SPIFFE *ProviderSPIFFEConfig `json:"spiffe,omitempty"`
+}
+
+// ProviderSPIFFEConfig configures a SPIFFE X.509 identity for provider calls.
+type ProviderSPIFFEConfig struct {
+	// socketPath is the path of the Workload API socket in the runtime
+	// container. The socket must be mounted there, e.g. from the SPIFFE CSI
+	// driver through spec.runtime.volumes on the AgentRuntime.
+	// +kubebuilder:default="/spiffe-workload-api/spire-agent.sock"
+	// +kubebuilder:validation:Pattern=`^/.*`
+	// +optional
+	SocketPath string `json:"socketPath,omitempty"`
+
+	// serverID is the SPIFFE ID the provider must present, e.g.
+	// "spiffe://example.org/ns/llm/sa/vllm". Unset accepts any ID the
+	// trust bundle vouches for.
+	// +kubebuilder:validation:Pattern=`^spiffe://[^/]+(/.*)?$`
+	// +optional
+	ServerID string `json:"serverID,omitempty"`
+}
+
+// ProviderRetryConfig configures retries of failed provider calls.
+type ProviderRetryConfig struct {
+	// maxAttempts is the total number of attempts per call, including the
+	// first. 1 disables retries.
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+
+	// initialDelay is the backoff before the first retry; each further
+	// retry doubles it, with jitter. Go duration string.
+	// +kubebuilder:default="500ms"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +optional
+	InitialDelay *string `json:"initialDelay,omitempty"`
+
+	// maxDelay caps the backoff between attempts. A Retry-After longer
+	// than maxDelay ends the retries instead of waiting. Go duration string.
+	// +kubebuilder:default="30s"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +optional
+	MaxDelay *string `json:"maxDelay,omitempty"`
+}
+
+// ProviderCircuitBreakerConfig configures the per-provider circuit breaker.
+type ProviderCircuitBreakerConfig struct {
+	// failureThreshold is the number of consecutive failed calls, after
+	// retries, that opens the circuit.
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// openDuration is how long an open circuit rejects calls before
+	// letting a single trial call through. Go duration string.
+	// +kubebuilder:default="30s"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +optional
+	OpenDuration *string `json:"openDuration,omitempty"`
+}
+
 // ProviderCapability defines a capability that a provider supports.
 // +kubebuilder:validation:Enum=text;streaming;vision;tools;json;audio;video;documents;duplex
 type ProviderCapability string
@@ -206,6 +355,7 @@ const (
 // PlatformConfig defines hyperscaler-specific configuration.
 // +kubebuilder:validation:XValidation:rule="self.type != 'vertex' || size(self.project) > 0",message="project is required when platform.type is vertex"
 // +kubebuilder:validation:XValidation:rule="self.type != 'azure' || size(self.endpoint) > 0",message="endpoint is required when platform.type is azure"
+// +kubebuilder:validation:XValidation:rule="self.type == 'azure' || (!has(self.deployment) && !has(self.apiVersion))",message="deployment and apiVersion are only valid when platform.type is azure"
 type PlatformConfig struct {
 	// type is the hyperscaler hosting platform.
 	// +kubebuilder:validation:Required
@@ -224,6 +374,20 @@ type PlatformConfig struct {
 	// Required for azure (the Azure OpenAI resource URL).
 	// +optional
 	Endpoint string `json:"endpoint,omitempty"`
+
+	// deployment is the Azure OpenAI deployment name requests are routed to.
+	// Defaults to spec.model, so it is only needed when the deployment is
+	// named differently from the model. Only valid for openai on azure.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Deployment string `json:"deployment,omitempty"`
+
+	// apiVersion is the Azure OpenAI api-version query parameter
+	// (e.g., 2024-10-21). Defaults to PromptKit's pinned version.
+	// Only valid for azure.
+	// +kubebuilder:validation:Pattern=`^\d{4}-\d{2}-\d{2}(-preview)?$`
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
 }
 
 // AuthMethod defines the authentication method for hyperscaler providers.
@@ -274,7 +438,7 @@ type AuthConfig struct {
 //
 // Role-block + (role × type) validations. The vendor list per role mirrors
 // the PromptKit factory registrations that Omnia binaries link in:
-//   - llm:        claude | openai | gemini | ollama | mock | vllm
+//   - llm:        claude | openai | gemini | ollama | mock | vllm | openai-compatible
 //   - embedding:  openai | voyageai | gemini | ollama
 //   - tts:        openai | cartesia | elevenlabs
 //   - stt:        openai
@@ -290,7 +454,7 @@ type AuthConfig struct {
 // +kubebuilder:validation:XValidation:rule="!has(self.tts) || self.role == 'tts'",message="spec.tts is only valid when spec.role is 'tts'"
 // +kubebuilder:validation:XValidation:rule="!has(self.stt) || self.role == 'stt'",message="spec.stt is only valid when spec.role is 'stt'"
 // +kubebuilder:validation:XValidation:rule="!has(self.embedding) || self.role == 'embedding'",message="spec.embedding is only valid when spec.role is 'embedding'"
-// +kubebuilder:validation:XValidation:rule="self.role != 'llm' || self.type in ['claude', 'openai', 'gemini', 'ollama', 'mock', 'vllm']",message="role 'llm' requires type in [claude, openai, gemini, ollama, mock, vllm]"
+// +kubebuilder:validation:XValidation:rule="self.role != 'llm' || self.type in ['claude', 'openai', 'gemini', 'ollama', 'mock', 'vllm', 'openai-compatible']",message="role 'llm' requires type in [claude, openai, gemini, ollama, mock, vllm, openai-compatible]"
 // +kubebuilder:validation:XValidation:rule="self.role != 'embedding' || self.type in ['openai', 'voyageai', 'gemini', 'ollama']",message="role 'embedding' requires type in [openai, voyageai, gemini, ollama]"
 // +kubebuilder:validation:XValidation:rule="self.role != 'tts' || self.type in ['openai', 'cartesia', 'elevenlabs']",message="role 'tts' requires type in [openai, cartesia, elevenlabs]"
 // +kubebuilder:validation:XValidation:rule="self.role != 'stt' || self.type in ['openai']",message="role 'stt' requires type in [openai]"
@@ -301,6 +465,13 @@ type AuthConfig struct {
 // +kubebuilder:validation:XValidation:rule="self.type != 'cartesia' || self.role == 'tts'",message="cartesia is a tts-only vendor; set spec.role to 'tts'"
 // +kubebuilder:validation:XValidation:rule="self.type != 'elevenlabs' || self.role == 'tts'",message="elevenlabs is a tts-only vendor; set spec.role to 'tts'"
 // +kubebuilder:validation:XValidation:rule="self.type != 'imagen' || self.role == 'image'",message="imagen is an image-only vendor; set spec.role to 'image'"
+// +kubebuilder:validation:XValidation:rule="self.type != 'openai-compatible' || self.role == 'llm'",message="openai-compatible is an llm-only provider type; set spec.role to 'llm'"
+//
+// Self-hosted OpenAI-compatible endpoints have no default URL:
+// +kubebuilder:validation:XValidation:rule="self.type != 'openai-compatible' || (has(self.baseURL) && size(self.baseURL) > 0)",message="spec.baseURL is required for openai-compatible providers"
+// +kubebuilder:validation:XValidation:rule="!has(self.openAICompatible) || self.type == 'openai-compatible'",message="spec.openAICompatible is only valid when spec.type is 'openai-compatible'"
+// +kubebuilder:validation:XValidation:rule="!has(self.resilience) || self.role == 'llm'",message="spec.resilience is only valid when spec.role is 'llm'"
+// +kubebuilder:validation:XValidation:rule="!has(self.tls) || self.role == 'llm'",message="spec.tls is only valid when spec.role is 'llm'"
 //
 // Hyperscaler-platform validations (apply when spec.role is 'llm' or 'embedding'):
 // +kubebuilder:validation:XValidation:rule="!has(self.platform) || self.role in ['llm', 'embedding']",message="spec.platform is only valid when spec.role is 'llm' or 'embedding'"
@@ -313,6 +484,7 @@ type AuthConfig struct {
 // +kubebuilder:validation:XValidation:rule="!has(self.platform) || self.platform.type != 'vertex' || self.type != 'openai'",message="openai on vertex is not supported: Vertex AI does not host OpenAI as a partner"
 // +kubebuilder:validation:XValidation:rule="!has(self.platform) || self.platform.type != 'bedrock' || self.type != 'gemini'",message="gemini on bedrock is not supported: AWS Bedrock does not host Gemini"
 // +kubebuilder:validation:XValidation:rule="!has(self.platform) || self.platform.type != 'azure' || self.type != 'gemini'",message="gemini on azure is not supported: Azure AI Foundry does not host Gemini"
+// +kubebuilder:validation:XValidation:rule="!has(self.platform) || !has(self.platform.deployment) || self.type == 'openai'",message="platform.deployment is only supported for openai on azure"
 //
 // A usable provider needs a model to serve requests; only mock providers
 // (which reply from fixtures) may omit it. Enforced here at admission and
@@ -391,9 +563,15 @@ type ProviderSpec struct {
 	// +optional
 	Auth *AuthConfig `json:"auth,omitempty"`
 
+	// openAICompatible tunes the request shape for a self-hosted
+	// OpenAI-compatible server. Only valid when spec.type is
+	// 'openai-compatible' (CEL-gated).
+	// +optional
+	OpenAICompatible *OpenAICompatibleConfig `json:"openAICompatible,omitempty"`
+
 	// credential defines how to obtain credentials for this provider.
 	// Optional for providers that don't require credentials (e.g., mock,
-	// ollama, vllm).
+	// ollama, vllm, openai-compatible).
 	// +optional
 	Credential *CredentialConfig `json:"credential,omitempty"`
 
@@ -401,6 +579,16 @@ type ProviderSpec struct {
 	// +optional
 	Defaults *ProviderDefaults `json:"defaults,omitempty"`
 
+	// resilience configures retries, hedged requests and circuit breaking
+	// for calls to this provider. Unset keeps PromptKit's built-in retries.
+	// +optional
+	Resilience *ProviderResilienceConfig `json:"resilience,omitempty"`
+
+	// tls configures mutual TLS on the runtime's connection to this
+	// provider. Unset presents no client certificate.
+	// +optional
+	TLS *ProviderTLSConfig `json:"tls,omitempty"`
+
 	// pricing configures cost tracking for this provider.
 	// If not specified, PromptKit's built-in pricing is used.
 	// +optional
@@ -532,6 +720,8 @@ func ExpectedKeysForProvider(role ProviderRole, t ProviderType) []string {
 		return []string{secretKeyGeminiAPIKey, "GOOGLE_API_KEY", providerSecretKeyAPIKey}
 	case ProviderTypeVoyageAI:
 		return []string{"VOYAGE_API_KEY", providerSecretKeyAPIKey}
+	case ProviderTypeOpenAICompatible:
+		return []string{secretKeyOpenAIAPIKey, providerSecretKeyAPIKey}
 	default:
 		return []string{providerSecretKeyAPIKey, secretKeyAnthropicAPIKey, secretKeyOpenAIAPIKey, secretKeyGeminiAPIKey}
 	}
@@ -174,6 +174,31 @@ func (in *A2ATaskStoreConfig) DeepCopy() *A2ATaskStoreConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentBudgetLimit) DeepCopyInto(out *AgentBudgetLimit) {
+	*out = *in
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentBudgetLimit.
+func (in *AgentBudgetLimit) DeepCopy() *AgentBudgetLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentBudgetLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentCapabilitiesSpec) DeepCopyInto(out *AgentCapabilitiesSpec) {
 	*out = *in
@@ -226,6 +251,32 @@ func (in *AgentCardSpec) DeepCopy() *AgentCardSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentDelegate) DeepCopyInto(out *AgentDelegate) {
+	*out = *in
+	out.AgentRuntimeRef = in.AgentRuntimeRef
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(string)
+		**out = **in
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(A2AClientAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentDelegate.
+func (in *AgentDelegate) DeepCopy() *AgentDelegate {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentDelegate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentExternalAuth) DeepCopyInto(out *AgentExternalAuth) {
 	*out = *in
@@ -244,6 +295,11 @@ func (in *AgentExternalAuth) DeepCopyInto(out *AgentExternalAuth) {
 		*out = new(EdgeTrustAuth)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(ExternalAuthorization)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentExternalAuth.
@@ -323,6 +379,11 @@ func (in *AgentPolicySelector) DeepCopyInto(out *AgentPolicySelector) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPolicySelector.
@@ -348,6 +409,11 @@ func (in *AgentPolicySpec) DeepCopyInto(out *AgentPolicySpec) {
 		*out = new(ToolAccessConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Transform != nil {
+		in, out := &in.Transform, &out.Transform
+		*out = new(TransformConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPolicySpec.
@@ -497,6 +563,43 @@ func (in *AgentRuntimeSpec) DeepCopyInto(out *AgentRuntimeSpec) {
 		*out = new(ContextConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResponseCache != nil {
+		in, out := &in.ResponseCache, &out.ResponseCache
+		*out = new(ResponseCacheConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StructuredOutput != nil {
+		in, out := &in.StructuredOutput, &out.StructuredOutput
+		*out = new(StructuredOutputConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Knowledge != nil {
+		in, out := &in.Knowledge, &out.Knowledge
+		*out = new(KnowledgeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Guardrails != nil {
+		in, out := &in.Guardrails, &out.Guardrails
+		*out = new(GuardrailsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(BudgetConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SLOs != nil {
+		in, out := &in.SLOs, &out.SLOs
+		*out = new(AgentSLOs)
+		**out = **in
+	}
+	if in.Delegates != nil {
+		in, out := &in.Delegates, &out.Delegates
+		*out = make([]AgentDelegate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Runtime != nil {
 		in, out := &in.Runtime, &out.Runtime
 		*out = new(RuntimeConfig)
@@ -630,6 +733,21 @@ func (in *AgentRuntimeStatus) DeepCopy() *AgentRuntimeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentSLOs) DeepCopyInto(out *AgentSLOs) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSLOs.
+func (in *AgentSLOs) DeepCopy() *AgentSLOs {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentSLOs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentSkillSpec) DeepCopyInto(out *AgentSkillSpec) {
 	*out = *in
@@ -816,6 +934,51 @@ func (in *AzureMediaBackend) DeepCopy() *AzureMediaBackend {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BudgetConfig) DeepCopyInto(out *BudgetConfig) {
+	*out = *in
+	if in.Session != nil {
+		in, out := &in.Session, &out.Session
+		*out = new(BudgetLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(AgentBudgetLimit)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BudgetConfig.
+func (in *BudgetConfig) DeepCopy() *BudgetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BudgetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BudgetLimit) DeepCopyInto(out *BudgetLimit) {
+	*out = *in
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BudgetLimit.
+func (in *BudgetLimit) DeepCopy() *BudgetLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(BudgetLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CandidateOverrides) DeepCopyInto(out *CandidateOverrides) {
 	*out = *in
@@ -1268,6 +1431,11 @@ func (in *EvalConfig) DeepCopyInto(out *EvalConfig) {
 		*out = new(EvalRateLimit)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Judge != nil {
+		in, out := &in.Judge, &out.Judge
+		*out = new(EvalJudgeConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SessionCompletion != nil {
 		in, out := &in.SessionCompletion, &out.SessionCompletion
 		*out = new(SessionCompletionConfig)
@@ -1290,6 +1458,26 @@ func (in *EvalConfig) DeepCopy() *EvalConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvalJudgeConfig) DeepCopyInto(out *EvalJudgeConfig) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvalJudgeConfig.
+func (in *EvalJudgeConfig) DeepCopy() *EvalJudgeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EvalJudgeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EvalPathConfig) DeepCopyInto(out *EvalPathConfig) {
 	*out = *in
@@ -1348,6 +1536,16 @@ func (in *EvalSampling) DeepCopyInto(out *EvalSampling) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MaxSessionsPerHour != nil {
+		in, out := &in.MaxSessionsPerHour, &out.MaxSessionsPerHour
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AlwaysEvalOn != nil {
+		in, out := &in.AlwaysEvalOn, &out.AlwaysEvalOn
+		*out = make([]EvalSamplingSignal, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvalSampling.
@@ -1360,6 +1558,21 @@ func (in *EvalSampling) DeepCopy() *EvalSampling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAuthorization) DeepCopyInto(out *ExternalAuthorization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAuthorization.
+func (in *ExternalAuthorization) DeepCopy() *ExternalAuthorization {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAuthorization)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalEndpoints) DeepCopyInto(out *ExternalEndpoints) {
 	*out = *in
@@ -1388,6 +1601,16 @@ func (in *FacadeConfig) DeepCopyInto(out *FacadeConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SendQueue != nil {
+		in, out := &in.SendQueue, &out.SendQueue
+		*out = new(FacadeSendQueueConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Payload != nil {
+		in, out := &in.Payload, &out.Payload
+		*out = new(FacadePayloadConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Handler != nil {
 		in, out := &in.Handler, &out.Handler
 		*out = new(HandlerMode)
@@ -1467,6 +1690,71 @@ func (in *FacadeExposeConfig) DeepCopy() *FacadeExposeConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FacadePayloadConfig) DeepCopyInto(out *FacadePayloadConfig) {
+	*out = *in
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CompressionLevel != nil {
+		in, out := &in.CompressionLevel, &out.CompressionLevel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CompressionThreshold != nil {
+		in, out := &in.CompressionThreshold, &out.CompressionThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxFragmentSize != nil {
+		in, out := &in.MaxFragmentSize, &out.MaxFragmentSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxFragmentedSize != nil {
+		in, out := &in.MaxFragmentedSize, &out.MaxFragmentedSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FacadePayloadConfig.
+func (in *FacadePayloadConfig) DeepCopy() *FacadePayloadConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FacadePayloadConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FacadeSendQueueConfig) DeepCopyInto(out *FacadeSendQueueConfig) {
+	*out = *in
+	if in.MaxMessages != nil {
+		in, out := &in.MaxMessages, &out.MaxMessages
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBytes != nil {
+		in, out := &in.MaxBytes, &out.MaxBytes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FacadeSendQueueConfig.
+func (in *FacadeSendQueueConfig) DeepCopy() *FacadeSendQueueConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FacadeSendQueueConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FacadeStatus) DeepCopyInto(out *FacadeStatus) {
 	*out = *in
@@ -1627,6 +1915,142 @@ func (in *GitSource) DeepCopy() *GitSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailHook) DeepCopyInto(out *GuardrailHook) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxChars != nil {
+		in, out := &in.MaxChars, &out.MaxChars
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowedScripts != nil {
+		in, out := &in.AllowedScripts, &out.AllowedScripts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Moderation != nil {
+		in, out := &in.Moderation, &out.Moderation
+		*out = new(GuardrailModeration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailHook.
+func (in *GuardrailHook) DeepCopy() *GuardrailHook {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailModeration) DeepCopyInto(out *GuardrailModeration) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]GuardrailModerationCategory, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailModeration.
+func (in *GuardrailModeration) DeepCopy() *GuardrailModeration {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailModeration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailModerationCategory) DeepCopyInto(out *GuardrailModerationCategory) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailModerationCategory.
+func (in *GuardrailModerationCategory) DeepCopy() *GuardrailModerationCategory {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailModerationCategory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailStreaming) DeepCopyInto(out *GuardrailStreaming) {
+	*out = *in
+	if in.WindowChars != nil {
+		in, out := &in.WindowChars, &out.WindowChars
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailStreaming.
+func (in *GuardrailStreaming) DeepCopy() *GuardrailStreaming {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailStreaming)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailsConfig) DeepCopyInto(out *GuardrailsConfig) {
+	*out = *in
+	if in.Input != nil {
+		in, out := &in.Input, &out.Input
+		*out = make([]GuardrailHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
+		*out = make([]GuardrailHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OutputStreaming != nil {
+		in, out := &in.OutputStreaming, &out.OutputStreaming
+		*out = new(GuardrailStreaming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ToolCalls != nil {
+		in, out := &in.ToolCalls, &out.ToolCalls
+		*out = make([]GuardrailHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailsConfig.
+func (in *GuardrailsConfig) DeepCopy() *GuardrailsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPConfig) DeepCopyInto(out *HTTPConfig) {
 	*out = *in
@@ -2004,6 +2428,27 @@ func (in *KEDATrigger) DeepCopy() *KEDATrigger {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KnowledgeConfig) DeepCopyInto(out *KnowledgeConfig) {
+	*out = *in
+	out.VectorStore = in.VectorStore
+	if in.TopK != nil {
+		in, out := &in.TopK, &out.TopK
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KnowledgeConfig.
+func (in *KnowledgeConfig) DeepCopy() *KnowledgeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KnowledgeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LabelSelector) DeepCopyInto(out *LabelSelector) {
 	*out = *in
@@ -3408,6 +3853,26 @@ func (in *OIDCClaimMapping) DeepCopy() *OIDCClaimMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAICompatibleConfig) DeepCopyInto(out *OpenAICompatibleConfig) {
+	*out = *in
+	if in.UnsupportedParams != nil {
+		in, out := &in.UnsupportedParams, &out.UnsupportedParams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAICompatibleConfig.
+func (in *OpenAICompatibleConfig) DeepCopy() *OpenAICompatibleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAICompatibleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenAPIConfig) DeepCopyInto(out *OpenAPIConfig) {
 	*out = *in
@@ -3777,6 +4242,31 @@ func (in *Provider) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderCircuitBreakerConfig) DeepCopyInto(out *ProviderCircuitBreakerConfig) {
+	*out = *in
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.OpenDuration != nil {
+		in, out := &in.OpenDuration, &out.OpenDuration
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCircuitBreakerConfig.
+func (in *ProviderCircuitBreakerConfig) DeepCopy() *ProviderCircuitBreakerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderCircuitBreakerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderDefaults) DeepCopyInto(out *ProviderDefaults) {
 	*out = *in
@@ -3894,6 +4384,76 @@ func (in *ProviderRef) DeepCopy() *ProviderRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderResilienceConfig) DeepCopyInto(out *ProviderResilienceConfig) {
+	*out = *in
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(ProviderRetryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(ProviderCircuitBreakerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderResilienceConfig.
+func (in *ProviderResilienceConfig) DeepCopy() *ProviderResilienceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderResilienceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderRetryConfig) DeepCopyInto(out *ProviderRetryConfig) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InitialDelay != nil {
+		in, out := &in.InitialDelay, &out.InitialDelay
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderRetryConfig.
+func (in *ProviderRetryConfig) DeepCopy() *ProviderRetryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderRetryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderSPIFFEConfig) DeepCopyInto(out *ProviderSPIFFEConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderSPIFFEConfig.
+func (in *ProviderSPIFFEConfig) DeepCopy() *ProviderSPIFFEConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderSPIFFEConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
 	*out = *in
@@ -3929,6 +4489,11 @@ func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
 		*out = new(AuthConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OpenAICompatible != nil {
+		in, out := &in.OpenAICompatible, &out.OpenAICompatible
+		*out = new(OpenAICompatibleConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Credential != nil {
 		in, out := &in.Credential, &out.Credential
 		*out = new(CredentialConfig)
@@ -3939,6 +4504,16 @@ func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
 		*out = new(ProviderDefaults)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Resilience != nil {
+		in, out := &in.Resilience, &out.Resilience
+		*out = new(ProviderResilienceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ProviderTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Pricing != nil {
 		in, out := &in.Pricing, &out.Pricing
 		*out = new(ProviderPricing)
@@ -3983,6 +4558,31 @@ func (in *ProviderStatus) DeepCopy() *ProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderTLSConfig) DeepCopyInto(out *ProviderTLSConfig) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SPIFFE != nil {
+		in, out := &in.SPIFFE, &out.SPIFFE
+		*out = new(ProviderSPIFFEConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderTLSConfig.
+func (in *ProviderTLSConfig) DeepCopy() *ProviderTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisConfig) DeepCopyInto(out *RedisConfig) {
 	*out = *in
@@ -4053,6 +4653,26 @@ func (in *ReplicaStatus) DeepCopy() *ReplicaStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseCacheConfig) DeepCopyInto(out *ResponseCacheConfig) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseCacheConfig.
+func (in *ResponseCacheConfig) DeepCopy() *ResponseCacheConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseCacheConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleBinding) DeepCopyInto(out *RoleBinding) {
 	*out = *in
@@ -4880,6 +5500,26 @@ func (in *StickySessionConfig) DeepCopy() *StickySessionConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StructuredOutputConfig) DeepCopyInto(out *StructuredOutputConfig) {
+	*out = *in
+	if in.MaxRepairAttempts != nil {
+		in, out := &in.MaxRepairAttempts, &out.MaxRepairAttempts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StructuredOutputConfig.
+func (in *StructuredOutputConfig) DeepCopy() *StructuredOutputConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StructuredOutputConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TTSConfig) DeepCopyInto(out *TTSConfig) {
 	*out = *in
@@ -5207,6 +5847,56 @@ func (in *TrafficRoutingConfig) DeepCopy() *TrafficRoutingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransformConfig) DeepCopyInto(out *TransformConfig) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StripParams != nil {
+		in, out := &in.StripParams, &out.StripParams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ModelRewrites != nil {
+		in, out := &in.ModelRewrites, &out.ModelRewrites
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformConfig.
+func (in *TransformConfig) DeepCopy() *TransformConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TransformConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VectorStoreConfig) DeepCopyInto(out *VectorStoreConfig) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VectorStoreConfig.
+func (in *VectorStoreConfig) DeepCopy() *VectorStoreConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VectorStoreConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VideoRequirements) DeepCopyInto(out *VideoRequirements) {
 	*out = *in
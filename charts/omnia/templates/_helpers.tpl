@@ -204,6 +204,48 @@ it on any default install. Empty disables the deploy API.
 {{- end -}}
 {{- end -}}
 
+{{/*
+omnia.mcpGateway.bindAddress — operator MCP gateway bind address. Callers
+authenticate with dashboard-minted workspace identity JWTs, verified via
+--mgmt-plane-jwks-url, which is only emitted when the dashboard is enabled.
+Defaults to ":8086", clear of the tool-test, content and deploy APIs. Empty
+disables the gateway.
+*/}}
+{{- define "omnia.mcpGateway.bindAddress" -}}
+{{- $gw := .Values.operator.mcpGateway | default dict -}}
+{{- if and .Values.dashboard.enabled $gw.enabled -}}
+{{- $gw.bindAddress | default ":8086" -}}
+{{- end -}}
+{{- end -}}
+
+{{/*
+omnia.prometheus.url — Prometheus base URL for server-side queries. An
+explicit dashboard.prometheus.url wins; otherwise the bundled Prometheus,
+served under server.prefixURL (default /prometheus), so its API lives at
+<prefix>/api/v1/... Empty when neither is configured.
+*/}}
+{{- define "omnia.prometheus.url" -}}
+{{- if .Values.dashboard.prometheus.url -}}
+{{- .Values.dashboard.prometheus.url -}}
+{{- else if .Values.prometheus.enabled -}}
+{{- printf "http://%s-prometheus-server:80%s" .Release.Name (.Values.prometheus.server.prefixURL | default "") -}}
+{{- end -}}
+{{- end -}}
+
+{{/*
+omnia.workspaceMetrics.bindAddress — operator workspace metrics API bind
+address. Callers authenticate with dashboard-minted workspace identity JWTs
+(--mgmt-plane-jwks-url, only emitted when the dashboard is enabled), and the
+API needs a Prometheus to query. Defaults to ":8087", clear of the tool-test,
+content, deploy and MCP gateway APIs. Empty disables the API.
+*/}}
+{{- define "omnia.workspaceMetrics.bindAddress" -}}
+{{- $wm := .Values.operator.workspaceMetrics | default dict -}}
+{{- if and .Values.dashboard.enabled $wm.enabled (include "omnia.prometheus.url" .) -}}
+{{- $wm.bindAddress | default ":8087" -}}
+{{- end -}}
+{{- end -}}
+
 {{/*
 Compaction image
 */}}
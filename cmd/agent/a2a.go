@@ -198,17 +198,9 @@ const a2aExternalURLCacheTTL = 30 * time.Second
 // HTTPRoutes (status.facade.endpoints, protocol=a2a), falling back to the
 // in-cluster Service URL when no external route is observed (#1576).
 func buildCardProvider(cfg *agent.Config, log logr.Logger) a2aserver.AgentCardProvider {
-	log.V(1).Info("building default agent card", "agentName", cfg.AgentName)
+	log.V(1).Info("building agent card", "agentName", cfg.AgentName, "configured", cfg.A2AAgentCard != nil)
 
-	spec := &omniav1alpha1.AgentCardSpec{
-		Name:        cfg.AgentName,
-		Description: fmt.Sprintf("Omnia agent: %s", cfg.AgentName),
-	}
-
-	endpoint := fmt.Sprintf("http://%s.%s.svc.cluster.local:%d",
-		cfg.AgentName, cfg.Namespace, cfg.FacadePort)
-
-	provider := facadea2a.NewCRDCardProvider(spec, endpoint)
+	provider := facadea2a.NewCRDCardProvider(a2aCardSpec(cfg), a2aServiceEndpoint(cfg))
 
 	c := buildK8sClient()
 	if c == nil {
@@ -220,6 +212,31 @@ func buildCardProvider(cfg *agent.Config, log logr.Logger) a2aserver.AgentCardPr
 	return provider.WithInterfaceURLFn(resolver.get)
 }
 
+// a2aCardSpec returns the Agent Card configured on the a2a facade, or a
+// default card naming the agent. The facade always serves message/stream, so
+// the default card advertises streaming.
+func a2aCardSpec(cfg *agent.Config) *omniav1alpha1.AgentCardSpec {
+	if cfg.A2AAgentCard != nil {
+		return cfg.A2AAgentCard
+	}
+	return &omniav1alpha1.AgentCardSpec{
+		Name:         cfg.AgentName,
+		Description:  fmt.Sprintf("Omnia agent: %s", cfg.AgentName),
+		Capabilities: &omniav1alpha1.AgentCapabilitiesSpec{Streaming: true},
+	}
+}
+
+// a2aServiceEndpoint returns the in-cluster URL of the A2A listener: the
+// facade port when a2a is the primary facade, the a2a port when it is served
+// alongside websocket. It matches the operator's status.a2a.endpoint.
+func a2aServiceEndpoint(cfg *agent.Config) string {
+	port := cfg.FacadePort
+	if cfg.A2AEnabled {
+		port = cfg.A2APort
+	}
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", cfg.AgentName, cfg.Namespace, port)
+}
+
 // resolveA2AExternalURL returns the agent's externally-reachable A2A interface
 // URL from status.facade.endpoints (protocol=a2a, valid), or "" when no external
 // route is observed (the card then keeps its in-cluster URL).
@@ -14,6 +14,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/agent"
 	facadea2a "github.com/altairalabs/omnia/internal/facade/a2a"
 )
 
@@ -182,3 +183,34 @@ func TestCRDCardProvider_InterfaceURLFnOverridesEndpoint(t *testing.T) {
 		t.Errorf("interface URL = %q, want %q (in-cluster fallback)", got, want)
 	}
 }
+
+func TestA2ACardSpec(t *testing.T) {
+	t.Parallel()
+	cfg := &agent.Config{AgentName: testA2AName, Namespace: testA2ANamespace}
+
+	def := a2aCardSpec(cfg)
+	if def.Name != testA2AName {
+		t.Errorf("default card name = %q, want %q", def.Name, testA2AName)
+	}
+	if def.Capabilities == nil || !def.Capabilities.Streaming {
+		t.Error("default card should advertise streaming (message/stream is always served)")
+	}
+
+	configured := &omniav1alpha1.AgentCardSpec{Name: "RAG Hero", Version: "2.1.0"}
+	cfg.A2AAgentCard = configured
+	if got := a2aCardSpec(cfg); got != configured {
+		t.Errorf("a2aCardSpec = %+v, want the configured card %+v", got, configured)
+	}
+}
+
+func TestA2AServiceEndpoint(t *testing.T) {
+	t.Parallel()
+	cfg := &agent.Config{AgentName: testA2AName, Namespace: testA2ANamespace, FacadePort: 8080, A2APort: 9999}
+	if got, want := a2aServiceEndpoint(cfg), "http://rag-hero.demo.svc.cluster.local:8080"; got != want {
+		t.Errorf("standalone endpoint = %q, want %q", got, want)
+	}
+	cfg.A2AEnabled = true
+	if got, want := a2aServiceEndpoint(cfg), "http://rag-hero.demo.svc.cluster.local:9999"; got != want {
+		t.Errorf("dual-protocol endpoint = %q, want %q (the a2a listener port)", got, want)
+	}
+}
@@ -110,6 +110,32 @@ func buildDataPlaneValidators(
 	if v := buildEdgeTrustValidator(log, ar); v != nil {
 		out = append(out, v)
 	}
+	return authorizeValidators(log, ar, out)
+}
+
+// authorizeValidators wraps each data-plane validator with the agent-scope
+// authorizer when spec.externalAuth.authorization is set, so a credential
+// the validator admits must also name this agent in its agents claim. The
+// management-plane validator is never wrapped — the dashboard authorizes
+// its own callers.
+func authorizeValidators(
+	log logr.Logger,
+	ar *omniav1alpha1.AgentRuntime,
+	validators []auth.Validator,
+) ([]auth.Validator, error) {
+	authz := ar.Spec.ExternalAuth.Authorization
+	if authz == nil || len(validators) == 0 {
+		return validators, nil
+	}
+	scope, err := auth.NewAgentScopeAuthorizer(authz.AgentsClaim, ar.Namespace, ar.Name)
+	if err != nil {
+		return nil, fmt.Errorf("spec.externalAuth.authorization: %w", err)
+	}
+	out := make([]auth.Validator, len(validators))
+	for i, v := range validators {
+		out[i] = auth.Authorized(v, scope)
+	}
+	log.Info("data-plane authorization enabled", "agentsClaim", authz.AgentsClaim)
 	return out, nil
 }
 
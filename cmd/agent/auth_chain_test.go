@@ -83,6 +83,53 @@ func TestBuildExternalChain_AgentRuntimeFoundBuildsValidators(t *testing.T) {
 	}
 }
 
+// TestBuildExternalChain_AuthorizationScopesValidators proves
+// spec.externalAuth.authorization wraps the data-plane validators: a caller
+// whose agents claim names this agent is admitted, any other is refused
+// with ErrForbidden.
+func TestBuildExternalChain_AuthorizationScopesValidators(t *testing.T) {
+	t.Parallel()
+	scheme := newTestScheme(t)
+	ar := &omniav1alpha1.AgentRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "ns"},
+		Spec: omniav1alpha1.AgentRuntimeSpec{
+			ExternalAuth: &omniav1alpha1.AgentExternalAuth{
+				EdgeTrust: &omniav1alpha1.EdgeTrustAuth{
+					ClaimsFromHeaders: map[string]string{"x-user-agents": "agents"},
+				},
+				Authorization: &omniav1alpha1.ExternalAuthorization{AgentsClaim: "agents"},
+			},
+		},
+	}
+	fc := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ar).Build()
+
+	chain, err := buildExternalChain(context.Background(), fc, logr.Discard(), "agent", "ns")
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+
+	tests := []struct {
+		agents  string
+		wantErr error
+	}{
+		{"ns/agent", nil},
+		{"other/*,ns/*", nil},
+		{"other/agent", auth.ErrForbidden},
+		{"", auth.ErrForbidden},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+		r.Header.Set(auth.DefaultEdgeSubjectHeader, "alice")
+		if tt.agents != "" {
+			r.Header.Set("x-user-agents", tt.agents)
+		}
+		_, err := chain.Run(context.Background(), r)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("agents %q: err = %v, want %v", tt.agents, err, tt.wantErr)
+		}
+	}
+}
+
 // TestBuildExternalChain_ClientKeysBuildsValidator covers buildClientKeyValidator:
 // a spec with clientKeys (defaultRole set) builds a SecretBackedKeyStore-backed
 // validator, an unknown bearer falls through it, AND a known key admits with the
@@ -32,6 +32,7 @@ import (
 	"github.com/altairalabs/omnia/internal/facade"
 	"github.com/altairalabs/omnia/internal/tracing"
 	"github.com/altairalabs/omnia/pkg/facade/auth"
+	"github.com/altairalabs/omnia/pkg/readiness"
 )
 
 // runFunctionsFacade starts the HTTP facade for a function-mode
@@ -198,17 +199,11 @@ func buildFunctionRegistry(cfg *agent.Config) (facade.FunctionRegistry, error) {
 // newFunctionsHealthServer mounts /healthz + /readyz on the health
 // port. Readiness is "the runtime sidecar's gRPC Health says ok".
 func newFunctionsHealthServer(cfg *agent.Config, rc *facade.RuntimeClient) *http.Server {
-	return newHealthServer(cfg, func(w http.ResponseWriter, r *http.Request) {
-		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
-		defer cancel()
-		if _, err := rc.Health(ctx); err != nil {
-			w.WriteHeader(http.StatusServiceUnavailable)
-			_, _ = w.Write([]byte("runtime unavailable"))
-			return
-		}
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
-	})
+	checker := readiness.NewChecker(readiness.Critical("runtime", func(ctx context.Context) error {
+		_, err := rc.Health(ctx)
+		return err
+	}))
+	return newHealthServer(cfg, checker.ServeHTTP)
 }
 
 // startFunctionsAndServe runs the facade + health (+ optional MCP +
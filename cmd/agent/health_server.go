@@ -11,9 +11,9 @@ import (
 	"net/http"
 
 	"github.com/go-logr/logr"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/altairalabs/omnia/internal/agent"
+	"github.com/altairalabs/omnia/pkg/metrics"
 )
 
 // newHealthServer builds the shared health endpoint surface used by facade modes.
@@ -22,7 +22,7 @@ func newHealthServer(cfg *agent.Config, readyHandler http.HandlerFunc) *http.Ser
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz", healthzHandler)
 	mux.HandleFunc("/readyz", readyHandler)
-	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/metrics", metrics.Handler())
 
 	return &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.HealthPort),
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
@@ -41,6 +42,9 @@ import (
 	"github.com/altairalabs/omnia/internal/tracing"
 	omniak8s "github.com/altairalabs/omnia/pkg/k8s"
 	"github.com/altairalabs/omnia/pkg/logging"
+	"github.com/altairalabs/omnia/pkg/metrics"
+	"github.com/altairalabs/omnia/pkg/profiling"
+	"github.com/altairalabs/omnia/pkg/readiness"
 	"github.com/altairalabs/omnia/pkg/servicediscovery"
 	"github.com/altairalabs/omnia/pkg/session/httpclient"
 )
@@ -50,6 +54,11 @@ const (
 	readTimeout     = 10 * time.Second
 	writeTimeout    = 10 * time.Second
 	idleTimeout     = 120 * time.Second
+	// relayStartTimeout bounds subscribing to the session relay at startup.
+	relayStartTimeout = 5 * time.Second
+	// envSessionRelayKey holds the key the agent's replicas seal session relay
+	// envelopes with.
+	envSessionRelayKey = "OMNIA_SESSION_RELAY_KEY"
 )
 
 func main() {
@@ -137,6 +146,42 @@ func main() {
 		}
 	}
 
+	// Push metrics to an OTLP collector when the standard OTEL_* env vars ask
+	// for it; the Prometheus endpoint keeps serving either way.
+	initCtx, initCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	shutdownMetrics, err := metrics.StartOTLPExport(initCtx,
+		fmt.Sprintf("omnia-facade-%s", cfg.AgentName), prometheus.DefaultGatherer)
+	initCancel()
+	if err != nil {
+		log.Error(err, "failed to initialize OTLP metric export")
+	} else {
+		defer func() {
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer shutdownCancel()
+			if err := shutdownMetrics(shutdownCtx); err != nil {
+				log.Error(err, "failed to shutdown OTLP metric export")
+			}
+		}()
+	}
+
+	// pprof on an internal port, plus Pyroscope push when configured. The
+	// runtime sidecar shares the pod network namespace and listens on 6061.
+	stopProfiling, err := profiling.Start(profiling.Options{
+		ServiceName: "omnia-facade",
+		PprofAddr:   profiling.DefaultPprofAddr,
+		Tags:        map[string]string{"agent": cfg.AgentName},
+	})
+	if err != nil {
+		log.Error(err, "failed to start profiling")
+	}
+	defer func() {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		if err := stopProfiling(shutdownCtx); err != nil {
+			log.Error(err, "failed to stop profiling")
+		}
+	}()
+
 	// Branch on AgentRuntime.spec.mode first: function-mode pods run a
 	// one-shot HTTP facade alongside the same runtime sidecar; agent-mode
 	// pods continue with the existing WebSocket / A2A flows.
@@ -291,69 +336,73 @@ func checkRuntimeReady(ctx context.Context, handler facade.MessageHandler) error
 	return nil
 }
 
+// readyzHandler reports the facade's readiness: not ready while draining, and
+// otherwise the session store and, in runtime mode, the runtime sidecar are
+// checked as critical dependencies.
 func readyzHandler(store session.Store, handler facade.MessageHandler, wsServer *facade.Server) http.HandlerFunc {
+	checker := readiness.NewChecker()
+	if store != nil {
+		checker.Add(readiness.Critical("session-store", func(ctx context.Context) error {
+			return checkStoreReady(ctx, store)
+		}))
+	}
+	if _, ok := handler.(*agent.RuntimeHandler); ok {
+		checker.Add(readiness.Critical("runtime", func(ctx context.Context) error {
+			return checkRuntimeReady(ctx, handler)
+		}))
+	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Report not-ready as soon as the facade enters drain mode so that
 		// the load balancer stops sending new traffic before we tear down.
 		if wsServer != nil && wsServer.IsDraining() {
-			w.WriteHeader(http.StatusServiceUnavailable)
-			_, _ = w.Write([]byte("draining"))
-			return
-		}
-
-		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-		defer cancel()
-
-		if err := checkStoreReady(ctx, store); err != nil {
-			w.WriteHeader(http.StatusServiceUnavailable)
-			_, _ = fmt.Fprintf(w, "session store unavailable: %v", err)
+			readiness.WriteReport(w, readiness.Report{
+				Status: readiness.StatusUnavailable,
+				Dependencies: []readiness.Dependency{{
+					Name: "drain", Status: readiness.StatusUnavailable, Critical: true, Error: "draining",
+				}},
+			})
 			return
 		}
-
-		if err := checkRuntimeReady(ctx, handler); err != nil {
-			w.WriteHeader(http.StatusServiceUnavailable)
-			_, _ = fmt.Fprint(w, err.Error())
-			return
-		}
-
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
+		readiness.WriteReport(w, checker.Evaluate(r.Context()))
 	}
 }
 
 // createHandler creates the appropriate message handler based on configuration.
-// Returns the handler and an optional cleanup function.
+// Returns the handler and an optional cleanup function, or an error when the
+// runtime handler cannot be created.
 func createHandler(
 	cfg *agent.Config, log logr.Logger, tp *tracing.Provider,
 	store session.Store, pool *facade.RecordingPool, policy *facade.RecordingPolicyCache,
-) (facade.MessageHandler, func()) {
+) (facade.MessageHandler, func(), error) {
 	switch cfg.HandlerMode {
 	case agent.HandlerModeEcho:
 		log.Info("using echo handler mode")
-		return agent.NewEchoHandler(), nil
+		return agent.NewEchoHandler(), nil, nil
 	case agent.HandlerModeDemo:
 		log.Info("using demo handler mode")
 		var demoOpts []agent.DemoHandlerOption
 		if tp != nil {
 			demoOpts = append(demoOpts, agent.WithDemoTracing(tp))
 		}
-		return agent.NewDemoHandler(demoOpts...), nil
+		return agent.NewDemoHandler(demoOpts...), nil, nil
 	case agent.HandlerModeRuntime:
 		return createRuntimeHandler(cfg, log, tp, store, pool, policy)
 	default:
 		log.Info("unknown handler mode, using nil handler", "mode", cfg.HandlerMode)
-		return nil, nil
+		return nil, nil, nil
 	}
 }
 
 // createRuntimeHandler dials the runtime gRPC sidecar (with retry/backoff) and
 // wraps it in a RuntimeHandler. store/pool/policy enable the RuntimeClient bus
 // recorder — recording conversation messages off the gRPC bus, protocol- and
-// runtime-agnostically. Returns (nil, nil) if the runtime never becomes ready.
+// runtime-agnostically. Returns an error if the client cannot be created: the
+// agent then exits rather than serving without a handler while /readyz, which
+// only probes a *agent.RuntimeHandler, reports it ready.
 func createRuntimeHandler(
 	cfg *agent.Config, log logr.Logger, tp *tracing.Provider,
 	store session.Store, pool *facade.RecordingPool, policy *facade.RecordingPolicyCache,
-) (facade.MessageHandler, func()) {
+) (facade.MessageHandler, func(), error) {
 	log.Info("using runtime handler mode", "address", cfg.RuntimeAddress)
 
 	// Build RuntimeClient config with optional tracing. policy is guarded to
@@ -375,8 +424,7 @@ func createRuntimeHandler(
 
 	rc := dialRuntimeWithRetry(runtimeCfg, log)
 	if rc == nil {
-		log.Error(nil, "failed to connect to runtime after retries, falling back to nil handler")
-		return nil, nil
+		return nil, nil, fmt.Errorf("failed to create runtime client for %s", cfg.RuntimeAddress)
 	}
 
 	handler := agent.NewRuntimeHandler(rc)
@@ -391,7 +439,7 @@ func createRuntimeHandler(
 			log.Error(err, "error closing runtime client")
 		}
 	}
-	return handler, cleanup
+	return handler, cleanup, nil
 }
 
 // dialRuntimeWithRetry connects to the runtime sidecar with exponential backoff
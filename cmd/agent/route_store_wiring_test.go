@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"testing"
 
 	"github.com/alicebob/miniredis/v2"
@@ -43,6 +44,7 @@ func TestBuildWebSocketServer_WiresRedisRouteStore(t *testing.T) {
 	defer mr.Close()
 
 	t.Setenv("OMNIA_ROUTE_REDIS_URL", "redis://"+mr.Addr())
+	t.Setenv(envSessionRelayKey, "0123456789abcdef0123456789abcdef")
 	t.Setenv("POD_IP", "10.0.0.1")
 
 	store := sessiontest.NewStore()
@@ -67,6 +69,57 @@ func TestBuildWebSocketServer_WiresRedisRouteStore(t *testing.T) {
 			"is not forwarding the Redis RouteStore via facade.WithRouteStore — " +
 			"blip-resume parked sessions will not publish pod-address hints")
 	}
+	if !srv.HasHandoffStore() {
+		t.Error("facade reports no HandoffStore wired; sessions on a draining pod " +
+			"cannot be resumed with replay on another replica")
+	}
+	if !srv.HasSessionRelay() {
+		t.Error("facade reports no SessionRelay wired; a client resuming on another " +
+			"replica cannot reach its live session")
+	}
+	t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+}
+
+// TestBuildWebSocketServer_NoRelayWithoutKey verifies that the session relay
+// stays off when OMNIA_ROUTE_REDIS_URL is set but no relay key is, since
+// relay envelopes carry client credentials and must be sealed.
+func TestBuildWebSocketServer_NoRelayWithoutKey(t *testing.T) {
+	freshPromRegistry(t)
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	t.Setenv("OMNIA_ROUTE_REDIS_URL", "redis://"+mr.Addr())
+	t.Setenv(envSessionRelayKey, "")
+	t.Setenv("POD_IP", "10.0.0.1")
+
+	store := sessiontest.NewStore()
+	t.Cleanup(func() { _ = store.Close() })
+
+	cfg := &agent.Config{
+		AgentName:  probeAgentName,
+		Namespace:  "ns",
+		FacadePort: 8080,
+	}
+	metrics := agent.NewMetrics(cfg.AgentName, cfg.Namespace)
+	handler := &captureHandler{name: probeAgentName}
+
+	servers, err := buildWebSocketServer(cfg, logr.Discard(), store, handler, metrics, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("buildWebSocketServer: %v", err)
+	}
+	srv := servers.external
+	t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+
+	if !srv.HasRouteStore() {
+		t.Error("route store should still be wired without a relay key")
+	}
+	if srv.HasSessionRelay() {
+		t.Error("facade relays sessions without a relay key")
+	}
 }
 
 // TestBuildWebSocketServer_NoopRouteStoreWhenEnvUnset verifies that when
@@ -100,4 +153,11 @@ func TestBuildWebSocketServer_NoopRouteStoreWhenEnvUnset(t *testing.T) {
 		t.Error("facade reports a real RouteStore wired when OMNIA_ROUTE_REDIS_URL is unset; " +
 			"expected noop store")
 	}
+	if srv.HasHandoffStore() {
+		t.Error("facade reports a real HandoffStore wired when OMNIA_ROUTE_REDIS_URL is unset; " +
+			"expected noop store")
+	}
+	if srv.HasSessionRelay() {
+		t.Error("facade reports a SessionRelay wired when OMNIA_ROUTE_REDIS_URL is unset")
+	}
 }
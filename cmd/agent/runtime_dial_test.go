@@ -27,6 +27,7 @@ import (
 	"google.golang.org/grpc/health"
 	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
 
+	"github.com/altairalabs/omnia/internal/agent"
 	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
 )
 
@@ -140,3 +141,24 @@ func TestDialRuntime_TestSleepHookIsInvokedBetweenAttempts(t *testing.T) {
 		t.Errorf("sleeps = %d, want %d (one sleep between each pair of attempts)", sleeps, 4)
 	}
 }
+
+func TestCreateHandler_RuntimeModeBridgesToRuntime(t *testing.T) {
+	addr, stop := startStubRuntimeOnTCP(t, &stubRuntimeServer{})
+	defer stop()
+
+	cfg := &agent.Config{HandlerMode: agent.HandlerModeRuntime, RuntimeAddress: addr}
+	handler, cleanup, err := createHandler(cfg, logr.Discard(), nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("createHandler: %v", err)
+	}
+	if cleanup == nil {
+		t.Fatalf("expected a cleanup func closing the runtime client")
+	}
+	defer cleanup()
+	if _, ok := handler.(*agent.RuntimeHandler); !ok {
+		t.Fatalf("handler = %T, want *agent.RuntimeHandler", handler)
+	}
+	if err := checkRuntimeReady(context.Background(), handler); err != nil {
+		t.Errorf("checkRuntimeReady: %v", err)
+	}
+}
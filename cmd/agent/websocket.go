@@ -69,7 +69,11 @@ func runWebSocketFacade(cfg *agent.Config, log logr.Logger, tracingProvider *tra
 	}
 
 	// Create message handler based on mode
-	handler, handlerCleanup := createHandler(cfg, log, tracingProvider, store, recordingPool, recordingPolicy)
+	handler, handlerCleanup, err := createHandler(cfg, log, tracingProvider, store, recordingPool, recordingPolicy)
+	if err != nil {
+		log.Error(err, "failed to create message handler", "mode", cfg.HandlerMode)
+		os.Exit(1)
+	}
 	if handlerCleanup != nil {
 		defer handlerCleanup()
 	}
@@ -145,11 +149,14 @@ type facadeServerSet struct {
 	a2aCleanup            func()
 }
 
-// newWSMux mounts the WebSocket routes onto a fresh mux for a facade server.
+// newWSMux mounts the WebSocket routes, and the HTTP chat endpoint sharing
+// the server's session store, handler and metrics, onto a fresh mux for a
+// facade server.
 func newWSMux(server *facade.Server) *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.Handle("/ws", server)
 	mux.Handle("/api/agents/", server)
+	mux.Handle(facade.ChatPath, server.ChatHandler())
 	return mux
 }
 
@@ -187,6 +194,33 @@ func buildWebSocketServer(
 	if cfg.DrainTimeout > 0 {
 		wsConfig.DrainTimeout = cfg.DrainTimeout
 	}
+	// Where the drain reconnect hint sends clients; unset means the URL they
+	// connected with, which the load balancer routes to a ready replica.
+	wsConfig.ReconnectEndpoint = os.Getenv("OMNIA_RECONNECT_ENDPOINT")
+	if cfg.SendQueueSize > 0 {
+		wsConfig.SendQueueSize = cfg.SendQueueSize
+	}
+	if cfg.SendQueueMaxBytes > 0 {
+		wsConfig.SendQueueMaxBytes = cfg.SendQueueMaxBytes
+	}
+	if cfg.SlowConsumerPolicy != "" {
+		wsConfig.SlowConsumerPolicy = facade.SlowConsumerPolicy(cfg.SlowConsumerPolicy)
+	}
+	if cfg.DisableCompression {
+		wsConfig.EnableCompression = false
+	}
+	if cfg.CompressionLevel > 0 {
+		wsConfig.CompressionLevel = cfg.CompressionLevel
+	}
+	if cfg.CompressionThreshold > 0 {
+		wsConfig.CompressionThreshold = cfg.CompressionThreshold
+	}
+	if cfg.MaxFragmentSize > 0 {
+		wsConfig.MaxFragmentSize = cfg.MaxFragmentSize
+	}
+	if cfg.MaxFragmentedSize > 0 {
+		wsConfig.MaxFragmentedSize = cfg.MaxFragmentedSize
+	}
 	serverOpts := []facade.ServerOption{
 		facade.WithMetrics(metrics),
 		facade.WithRecordingPool(recordingPool),
@@ -207,7 +241,11 @@ func buildWebSocketServer(
 			func(sessionID string, w facade.ResponseWriter) facade.DuplexSink {
 				return agent.NewGRPCDuplexSink(sessionID, runtimeClient, w)
 			},
-		))
+		), facade.WithAudioFormat(&facade.SessionConfigInfo{
+			Codec:      cfg.DuplexAudioFormat,
+			SampleRate: cfg.DuplexAudioSampleRate,
+			Channels:   cfg.DuplexAudioChannels,
+		}))
 	}
 	// Wire the route store for blip-resume: parked sessions need a Redis-backed
 	// hint so a peer can redirect reconnecting clients to the pod holding the
@@ -223,12 +261,29 @@ func buildWebSocketServer(
 		}
 	}
 	serverOpts = append(serverOpts, facade.WithGraceWindow(graceWindowDuration(graceWindowSecs)))
+	var sessionRelay facade.SessionRelay
 	if routeURL := os.Getenv("OMNIA_ROUTE_REDIS_URL"); routeURL != "" {
 		ropts, parseErr := redis.ParseURL(routeURL)
 		if parseErr != nil {
 			return nil, fmt.Errorf("parse route redis url: %w", parseErr)
 		}
-		serverOpts = append(serverOpts, facade.WithRouteStore(agent.NewRedisRouteStore(redis.NewClient(ropts))))
+		// The same Redis carries the replay state a draining pod hands off,
+		// so a client resuming on another replica is replayed what it missed.
+		routeClient := redis.NewClient(ropts)
+		serverOpts = append(serverOpts,
+			facade.WithRouteStore(agent.NewRedisRouteStore(routeClient)),
+			facade.WithHandoffStore(agent.NewRedisHandoffStore(routeClient)),
+		)
+		sessionRelay = agent.NewRedisSessionRelay(routeClient)
+	}
+	// Relay envelopes carry the client's identity and Authorization header,
+	// so they are sealed with a key the agent's replicas share. Without one
+	// the relay stays off and a resume on another replica starts fresh.
+	relayKey := []byte(os.Getenv(envSessionRelayKey))
+	if sessionRelay != nil && len(relayKey) < facade.MinSessionRelayKeyLen {
+		log.Info("session relay disabled: relay key unset or too short",
+			"env", envSessionRelayKey, "minLength", facade.MinSessionRelayKeyLen)
+		sessionRelay = nil
 	}
 
 	// Build the auth chain: data-plane validators (clientKeys/oidc/edgeTrust,
@@ -262,7 +317,20 @@ func buildWebSocketServer(
 	// in dev/CI.
 	extOpts = append(extOpts,
 		facade.WithAllowUnauthenticated(allowUnauthenticatedFallback(log)))
+	// Only the external listener relays sessions across replicas: the
+	// internal twin shares the pod address, so both cannot own its relay
+	// channel.
+	if sessionRelay != nil {
+		extOpts = append(extOpts, facade.WithSessionRelay(sessionRelay, relayKey))
+	}
 	external := facade.NewServer(wsConfig, store, handler, log, extOpts...)
+	// Best-effort like the route store: without the relay a resume on another
+	// replica starts a fresh session instead of reaching this one.
+	relayCtx, relayCancel := context.WithTimeout(context.Background(), relayStartTimeout)
+	if err := external.StartRelay(relayCtx); err != nil {
+		log.Error(err, "session relay start failed; cross-replica resume disabled")
+	}
+	relayCancel()
 
 	servers := &webSocketServers{external: external, externalMux: newWSMux(external)}
 
@@ -279,6 +347,10 @@ func buildWebSocketServer(
 		internal := facade.NewServer(wsConfig, store, handler, log, intOpts...)
 		servers.internal = internal
 		servers.internalMux = newWSMux(internal)
+		// The admin API is for operators, who reach it through the dashboard,
+		// so it is served only behind the management-plane chain. It acts on
+		// both listeners' connections.
+		servers.internalMux.Handle(facade.AdminPath, internal.AdminHandler(external, internal))
 	}
 
 	return servers, nil
@@ -10,6 +10,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -123,3 +124,23 @@ func TestBuildWebSocketServer_DefaultDrainTimeoutWhenCRDUnset(t *testing.T) {
 			got, defaultDrainTimeout)
 	}
 }
+
+// TestNewWSMux_MountsChat verifies the HTTP chat endpoint is served next to
+// the WebSocket routes, so clients without a WebSocket reach the same server.
+func TestNewWSMux_MountsChat(t *testing.T) {
+	t.Parallel()
+
+	s := facade.NewServer(facade.DefaultServerConfig(), nil, nil, logr.Discard())
+	mux := newWSMux(s)
+
+	r := httptest.NewRequest(http.MethodPost, facade.ChatPath+"?agent="+probeAgentName,
+		strings.NewReader(`{"content":"hi"}`))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("POST %s = %d, want 200 from the chat handler", facade.ChatPath, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "Handler not configured") {
+		t.Errorf("body = %q, want the facade's reply", w.Body.String())
+	}
+}
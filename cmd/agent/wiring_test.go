@@ -314,6 +314,18 @@ func TestBuildWebSocketServer_InternalTwinEnabled(t *testing.T) {
 	if rr.Code == http.StatusNotFound {
 		t.Error("/ws route not registered on the internal management-plane mux")
 	}
+	req = httptest.NewRequest(http.MethodGet, facade.AdminPath+"sessions", nil)
+	rr = httptest.NewRecorder()
+	servers.internalMux.ServeHTTP(rr, req)
+	if rr.Code == http.StatusNotFound {
+		t.Error("admin API not registered on the internal management-plane mux")
+	}
+	req = httptest.NewRequest(http.MethodGet, facade.AdminPath+"sessions", nil)
+	rr = httptest.NewRecorder()
+	servers.externalMux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("external mux answered the admin API with %d; want it served only internally", rr.Code)
+	}
 }
 
 // TestBuildWebSocketServer_InternalTwinDisabled verifies that without an internal
@@ -36,6 +36,7 @@ import (
 	"github.com/altairalabs/omnia/internal/session/providers/postgres"
 	"github.com/altairalabs/omnia/internal/session/providers/redis"
 	"github.com/altairalabs/omnia/pkg/metrics"
+	"github.com/altairalabs/omnia/pkg/profiling"
 )
 
 // flags groups all CLI flags for the compaction binary.
@@ -136,6 +137,34 @@ func runWithFlags(f *flags) error {
 		_ = srv.Shutdown(shutCtx)
 	}()
 
+	// --- OTLP metric export (opt-in via the standard OTEL_* env vars) ---
+	// A CronJob run ends before most scrapes would reach it; the deferred
+	// shutdown pushes the run's final metrics.
+	shutdownMetrics, err := metrics.StartOTLPExport(ctx, "omnia-compaction", reg)
+	if err != nil {
+		return fmt.Errorf("starting OTLP metric export: %w", err)
+	}
+	defer func() {
+		shutCtx, shutCancel := context.WithTimeout(
+			context.Background(), 5*time.Second,
+		)
+		defer shutCancel()
+		if shutErr := shutdownMetrics(shutCtx); shutErr != nil {
+			log.Errorw("OTLP metric export shutdown failed", "error", shutErr)
+		}
+	}()
+
+	// --- Profiling (pprof on an internal port; Pyroscope push opt-in) ---
+	// Stopping the profiler uploads the final profile before the run exits.
+	stopProfiling, err := profiling.Start(profiling.Options{
+		ServiceName: "omnia-compaction",
+		PprofAddr:   profiling.DefaultPprofAddr,
+	})
+	if err != nil {
+		log.Errorw("profiling failed to start", "error", err)
+	}
+	defer func() { _ = stopProfiling(context.Background()) }()
+
 	// --- Retention config ---
 	retentionCfg, err := compaction.LoadRetentionConfig(f.retentionConfigPath)
 	if err != nil {
@@ -30,10 +30,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -47,11 +50,16 @@ import (
 	"github.com/altairalabs/omnia/internal/api/authz"
 	"github.com/altairalabs/omnia/internal/api/content"
 	"github.com/altairalabs/omnia/internal/api/deploy"
+	"github.com/altairalabs/omnia/internal/api/mcpgateway"
+	"github.com/altairalabs/omnia/internal/api/workspacemetrics"
+	"github.com/altairalabs/omnia/internal/canary"
 	"github.com/altairalabs/omnia/internal/controller"
+	"github.com/altairalabs/omnia/internal/mgmtplane"
 	"github.com/altairalabs/omnia/internal/schema"
 	"github.com/altairalabs/omnia/internal/tooltest"
 	omniawebhook "github.com/altairalabs/omnia/internal/webhook"
 	"github.com/altairalabs/omnia/pkg/metrics"
+	"github.com/altairalabs/omnia/pkg/profiling"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -102,10 +110,17 @@ func main() {
 	var defaultExposureGatewayName string
 	var defaultExposureGatewayNamespace string
 	var defaultExposureGatewaySection string
+	var podMonitorsEnabled bool
+	var podMonitorInterval string
+	var podMonitorLabels string
+	var prometheusRuleLabels string
 	var apiBindAddress string
 	var toolTestAllowedSubjects string
 	var contentAPIBindAddress string
 	var deployAPIBindAddress string
+	var mcpGatewayBindAddress string
+	var workspaceMetricsAPIBindAddress string
+	var prometheusURL string
 	var sessionAPIAuthEnabled bool
 	var sessionAPIAuthAudience string
 	var sessionAPIAuthTokenExpirationSeconds int64
@@ -118,8 +133,11 @@ func main() {
 	var licenseServerURL string
 	var licenseAPIURL string
 	var clusterName string
+	var licenseFile string
+	var licenseOfflineActivation bool
 	var mgmtPlaneJWKSURL string
 	var meshEnabled bool
+	var canaryOpts canaryOptions
 	var tlsOpts []func(*tls.Config)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
@@ -218,6 +236,17 @@ func main() {
 		"Default-exposure Gateway namespace (cross-ns needs a ReferenceGrant). Empty = agent's namespace.")
 	flag.StringVar(&defaultExposureGatewaySection, "default-exposure-gateway-section", "",
 		"Optional listener sectionName on the default-exposure Gateway.")
+	flag.BoolVar(&podMonitorsEnabled, "pod-monitors-enabled", false,
+		"Create Prometheus Operator PodMonitors for agent, session-api, memory-api and eval-worker pods. "+
+			"Ignored when the PodMonitor CRD is not installed.")
+	flag.StringVar(&podMonitorInterval, "pod-monitor-interval", "30s",
+		"Scrape interval for operator-managed PodMonitors.")
+	flag.StringVar(&podMonitorLabels, "pod-monitor-labels", "",
+		"Comma-separated key=value labels added to operator-managed PodMonitors so Prometheus selects them "+
+			"(e.g. release=kube-prometheus-stack).")
+	flag.StringVar(&prometheusRuleLabels, "prometheus-rule-labels", "",
+		"Comma-separated key=value labels added to the PrometheusRules generated from AgentRuntime spec.slos "+
+			"so Prometheus selects them (e.g. release=kube-prometheus-stack).")
 	flag.StringVar(&apiBindAddress, "api-bind-address", "",
 		"Address for the tool test API server (e.g., :8083). If empty, the API server is not started.")
 	flag.StringVar(&contentAPIBindAddress, "content-api-bind-address", "",
@@ -227,6 +256,16 @@ func main() {
 			"root). If empty, the content API server is not started.")
 	flag.StringVar(&deployAPIBindAddress, "deploy-api-bind-address", "",
 		"Address for the deploy-intent API server (e.g. :8083). Empty disables it. Requires --mgmt-plane-jwks-url.")
+	flag.StringVar(&mcpGatewayBindAddress, "mcp-gateway-bind-address", "",
+		"Address for the MCP gateway (e.g. :8086) that serves each workspace's ToolRegistry tools to "+
+			"external MCP clients. Empty disables it. Requires --mgmt-plane-jwks-url.")
+	flag.StringVar(&workspaceMetricsAPIBindAddress, "workspace-metrics-api-bind-address", "",
+		"Address for the workspace metrics API (e.g. :8087) that serves per-workspace aggregates of "+
+			"Prometheus metrics to the dashboard. Empty disables it. Requires --mgmt-plane-jwks-url "+
+			"and --prometheus-url.")
+	flag.StringVar(&prometheusURL, "prometheus-url", "",
+		"Prometheus base URL the workspace metrics API and AgentRuntime SLO evaluation query "+
+			"(e.g. http://prometheus-server/prometheus).")
 	flag.StringVar(&toolTestAllowedSubjects, "tool-test-allowed-subjects", "",
 		"Comma-separated list of authenticated usernames allowed to call the tool-test API "+
 			"(e.g. system:serviceaccount:omnia-system:omnia-dashboard). Each request must present a "+
@@ -293,6 +332,10 @@ func main() {
 			"http://omnia-arena-controller.omnia-system:8082)")
 	flag.StringVar(&clusterName, "cluster-name", "",
 		"Human-readable name for this cluster in license records")
+	flag.StringVar(&licenseFile, "license-file", "",
+		"Path of a mounted license file, read in place of the arena-license Secret when it exists")
+	flag.BoolVar(&licenseOfflineActivation, "license-offline-activation", false,
+		"Activate the license with a signed offline activation response instead of contacting the license server")
 	flag.StringVar(&mgmtPlaneJWKSURL, "mgmt-plane-jwks-url", "",
 		"URL of the dashboard's JWKS endpoint, set on every facade container "+
 			"as OMNIA_MGMT_PLANE_JWKS_URL so cmd/agent can build a JWKS-backed "+
@@ -302,6 +345,21 @@ func main() {
 			"headless installs).")
 	flag.BoolVar(&meshEnabled, "mesh-enabled", false,
 		"Istio ambient mesh is enabled; allows rollout trafficRouting mode=mesh (operator-owned VS/DR).")
+	flag.BoolVar(&canaryOpts.enabled, "canary-enabled", false,
+		"Periodically run a scripted probe conversation against every Ready AgentRuntime and export "+
+			"omnia_canary_* metrics. Probes call the agent's provider, so real providers incur cost.")
+	flag.DurationVar(&canaryOpts.interval, "canary-interval", canary.DefaultInterval,
+		"Time between canary probe rounds.")
+	flag.DurationVar(&canaryOpts.timeout, "canary-timeout", canary.DefaultTimeout,
+		"Upper bound on each agent's canary probe conversation.")
+	flag.IntVar(&canaryOpts.concurrency, "canary-concurrency", canary.DefaultConcurrency,
+		"Number of agents probed at once.")
+	flag.StringVar(&canaryOpts.scriptPath, "canary-script", "",
+		"Path of a YAML canary script (turns of message/expect). Empty uses a single health-check turn.")
+	flag.StringVar(&canaryOpts.mgmtPlaneTokenURL, "canary-mgmt-plane-token-url",
+		os.Getenv("OMNIA_MGMT_PLANE_TOKEN_URL"),
+		"Dashboard service-token URL used to mint the mgmt-plane JWT canary probes present to facades "+
+			"that require one. Empty dials facades without a token.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -386,6 +444,26 @@ func main() {
 		os.Exit(1)
 	}
 
+	podMonitors, err := resolvePodMonitorConfig(mgr, podMonitorsEnabled, podMonitorInterval, podMonitorLabels)
+	if err != nil {
+		setupLog.Error(err, "invalid PodMonitor configuration")
+		os.Exit(1)
+	}
+	prometheusRules, err := resolvePrometheusRuleConfig(mgr, prometheusRuleLabels)
+	if err != nil {
+		setupLog.Error(err, "invalid PrometheusRule configuration")
+		os.Exit(1)
+	}
+	var sloQuerier controller.MetricsQuerier
+	if prometheusURL != "" {
+		querier, qerr := workspacemetrics.NewPrometheusQuerier(prometheusURL)
+		if qerr != nil {
+			setupLog.Error(qerr, "unable to build Prometheus client for SLO evaluation")
+			os.Exit(1)
+		}
+		sloQuerier = querier
+	}
+
 	// Internal service-to-service auth config (SEC-1/SEC-5), shared by the
 	// session-api server side (ServiceBuilder) and the facade / eval-worker
 	// caller side (AgentRuntimeReconciler). Zero value = disabled.
@@ -421,6 +499,9 @@ func main() {
 			GatewayNamespace: defaultExposureGatewayNamespace,
 			GatewaySection:   defaultExposureGatewaySection,
 		},
+		PodMonitors:          podMonitors,
+		PrometheusRules:      prometheusRules,
+		SLOQuerier:           sloQuerier,
 		LicenseAPIURL:        licenseAPIURL,
 		PolicyBrokerImage:    policyBrokerImageForEnterprise(enterpriseEnabled, policyBrokerImage),
 		RolloutMetrics:       controller.NewRolloutMetrics(prometheus.DefaultRegisterer),
@@ -493,6 +574,7 @@ func main() {
 		SessionAPITokenReviewClusterRole:     sessionAPITokenReviewClusterRole,
 		PrivacyDefaultReaderClusterRole:      privacyDefaultReaderClusterRole,
 		MemoryConsolidationReaderClusterRole: memoryConsolidationReaderClusterRole,
+		PodMonitors:                          podMonitors,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, errUnableToCreateController, logKeyController, "Workspace")
 		os.Exit(1)
@@ -563,9 +645,11 @@ func main() {
 	// Enterprise controllers — gated behind --enterprise flag
 	if enterpriseEnabled {
 		eeOpts := eesetup.EnterpriseOptions{
-			LicenseServerURL: licenseServerURL,
-			ClusterName:      clusterName,
-			EnableWebhooks:   len(webhookCertPath) > 0,
+			LicenseServerURL:  licenseServerURL,
+			ClusterName:       clusterName,
+			LicenseFile:       licenseFile,
+			OfflineActivation: licenseOfflineActivation,
+			EnableWebhooks:    len(webhookCertPath) > 0,
 		}
 		if err := eesetup.RegisterEnterpriseControllers(mgr, eeOpts); err != nil {
 			setupLog.Error(err, "unable to register enterprise controllers")
@@ -648,7 +732,8 @@ func main() {
 		deployLog := ctrl.Log.WithName("deploy-api")
 		authorizer := authz.NewAuthorizer(verifier, authz.NewClientWorkspaceResolver(mgr.GetClient()))
 		handler := deploy.NewHandler(deploy.NewApplier(mgr.GetClient(), deployLog), deployLog)
-		deployServer = deploy.NewServer(deployAPIBindAddress, handler, authorizer, deployLog)
+		snapshots := deploy.NewSnapshotHandler(mgr.GetClient(), deployLog)
+		deployServer = deploy.NewServer(deployAPIBindAddress, handler, snapshots, authorizer, deployLog)
 		go func() {
 			if err := deployServer.Start(ctx); err != nil {
 				setupLog.Error(err, "deploy API server stopped")
@@ -656,6 +741,85 @@ func main() {
 		}()
 	}
 
+	// Start the MCP gateway if configured. It serves each workspace's
+	// ToolRegistry tools over MCP to agents outside the cluster, through the
+	// same executor, ToolPolicy enforcement and credential handling as agents.
+	var mcpGatewayServer *mcpgateway.Server
+	if mcpGatewayBindAddress != "" {
+		if mgmtPlaneJWKSURL == "" {
+			setupLog.Error(fmt.Errorf("mgmt-plane-jwks-url required"),
+				"mcp-gateway-bind-address requires --mgmt-plane-jwks-url")
+			os.Exit(1)
+		}
+		verifier, verr := authz.NewIdentityVerifierFromJWKS(mgmtPlaneJWKSURL)
+		if verr != nil {
+			setupLog.Error(verr, "unable to build identity verifier for MCP gateway")
+			os.Exit(1)
+		}
+		gatewayLog := ctrl.Log.WithName("mcp-gateway")
+		authorizer := authz.NewAuthorizer(verifier, authz.NewClientWorkspaceResolver(mgr.GetClient()))
+		gateway := mcpgateway.NewGateway(mgr.GetClient(), tooltest.NewTester(mgr.GetClient(), gatewayLog), gatewayLog)
+		mcpGatewayServer = mcpgateway.NewServer(mcpGatewayBindAddress, gateway, authorizer, gatewayLog)
+		go func() {
+			if err := mcpGatewayServer.Start(ctx); err != nil {
+				setupLog.Error(err, "MCP gateway server stopped")
+			}
+		}()
+	}
+
+	// Start the workspace metrics API if configured. It aggregates each
+	// workspace's Prometheus series server-side, so the dashboard and teams
+	// never need cluster-wide Prometheus or Grafana access.
+	var workspaceMetricsServer *workspacemetrics.Server
+	if workspaceMetricsAPIBindAddress != "" {
+		if mgmtPlaneJWKSURL == "" || prometheusURL == "" {
+			setupLog.Error(fmt.Errorf("mgmt-plane-jwks-url and prometheus-url required"),
+				"workspace-metrics-api-bind-address requires --mgmt-plane-jwks-url and --prometheus-url")
+			os.Exit(1)
+		}
+		verifier, verr := authz.NewIdentityVerifierFromJWKS(mgmtPlaneJWKSURL)
+		if verr != nil {
+			setupLog.Error(verr, "unable to build identity verifier for workspace metrics API")
+			os.Exit(1)
+		}
+		querier, qerr := workspacemetrics.NewPrometheusQuerier(prometheusURL)
+		if qerr != nil {
+			setupLog.Error(qerr, "unable to build Prometheus client for workspace metrics API")
+			os.Exit(1)
+		}
+		metricsLog := ctrl.Log.WithName("workspace-metrics-api")
+		authorizer := authz.NewAuthorizer(verifier, authz.NewClientWorkspaceResolver(mgr.GetClient()))
+		workspaceMetricsServer = workspacemetrics.NewServer(workspaceMetricsAPIBindAddress,
+			workspacemetrics.NewAggregator(querier), authorizer, metricsLog)
+		go func() {
+			if err := workspaceMetricsServer.Start(ctx); err != nil {
+				setupLog.Error(err, "workspace metrics API server stopped")
+			}
+		}()
+	}
+
+	if canaryOpts.enabled {
+		runner, cerr := newCanaryRunner(mgr.GetClient(), canaryOpts)
+		if cerr != nil {
+			setupLog.Error(cerr, "unable to set up canary probes")
+			os.Exit(1)
+		}
+		if err := mgr.Add(runner); err != nil {
+			setupLog.Error(err, "unable to add canary runner")
+			os.Exit(1)
+		}
+	}
+
+	// pprof on an internal port, plus Pyroscope push when configured.
+	stopProfiling, err := profiling.Start(profiling.Options{
+		ServiceName: "omnia-operator",
+		PprofAddr:   profiling.DefaultPprofAddr,
+	})
+	if err != nil {
+		setupLog.Error(err, "profiling failed to start")
+	}
+	defer func() { _ = stopProfiling(context.Background()) }()
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -684,6 +848,20 @@ func main() {
 			setupLog.Error(err, "deploy API server shutdown error")
 		}
 	}
+	if mcpGatewayServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := mcpGatewayServer.Shutdown(shutdownCtx); err != nil {
+			setupLog.Error(err, "MCP gateway server shutdown error")
+		}
+	}
+	if workspaceMetricsServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := workspaceMetricsServer.Shutdown(shutdownCtx); err != nil {
+			setupLog.Error(err, "workspace metrics API server shutdown error")
+		}
+	}
 }
 
 // policyBrokerImageForEnterprise returns the policy broker image when enterprise
@@ -756,6 +934,107 @@ func (f *frameworkImagesFlag) images() map[string]string {
 }
 
 // splitAndTrim splits a comma-separated list into non-empty, trimmed entries.
+// canaryOptions holds the --canary-* flags.
+type canaryOptions struct {
+	enabled           bool
+	interval          time.Duration
+	timeout           time.Duration
+	concurrency       int
+	scriptPath        string
+	mgmtPlaneTokenURL string
+}
+
+// newCanaryRunner builds the canary runner from opts. Its metrics register
+// with the controller-runtime registry so the operator's metrics endpoint
+// serves them.
+func newCanaryRunner(c client.Client, opts canaryOptions) (*canary.Runner, error) {
+	script := canary.DefaultScript()
+	if opts.scriptPath != "" {
+		loaded, err := canary.LoadScript(opts.scriptPath)
+		if err != nil {
+			return nil, err
+		}
+		script = loaded
+	}
+	var tokenSource mgmtplane.TokenSource
+	if opts.mgmtPlaneTokenURL != "" {
+		fetcher, err := mgmtplane.NewTokenFetcher(mgmtplane.FetcherOptions{Endpoint: opts.mgmtPlaneTokenURL})
+		if err != nil {
+			return nil, err
+		}
+		tokenSource = fetcher
+	}
+	cfg := canary.Config{Interval: opts.interval, Timeout: opts.timeout, Concurrency: opts.concurrency}
+	return canary.NewRunner(c, canary.NewProber(script, tokenSource),
+		canary.NewMetrics(ctrlmetrics.Registry), cfg, ctrl.Log), nil
+}
+
+// resolvePodMonitorConfig builds the PodMonitor config from the --pod-monitor*
+// flags. PodMonitors stay disabled when the Prometheus Operator CRD is absent,
+// which is detected once here: installing it later needs an operator restart.
+func resolvePodMonitorConfig(mgr ctrl.Manager, enabled bool, interval, labels string) (controller.PodMonitorConfig, error) {
+	if !enabled {
+		return controller.PodMonitorConfig{}, nil
+	}
+	parsed, err := parseLabelPairs(labels)
+	if err != nil {
+		return controller.PodMonitorConfig{}, fmt.Errorf("--pod-monitor-labels: %w", err)
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		return controller.PodMonitorConfig{}, fmt.Errorf("create discovery client: %w", err)
+	}
+	installed, err := controller.PodMonitorCRDInstalled(dc)
+	if err != nil {
+		return controller.PodMonitorConfig{}, fmt.Errorf("detect PodMonitor CRD: %w", err)
+	}
+	if !installed {
+		setupLog.Info("PodMonitor CRD not found; operator-managed PodMonitors are disabled",
+			"fix", "install the Prometheus Operator CRDs and restart the operator")
+		return controller.PodMonitorConfig{}, nil
+	}
+	setupLog.Info("operator-managed PodMonitors enabled", "interval", interval, "labels", parsed)
+	return controller.PodMonitorConfig{Enabled: true, Interval: interval, Labels: parsed}, nil
+}
+
+// resolvePrometheusRuleConfig builds the SLO PrometheusRule config. Rules are
+// generated whenever the PrometheusRule CRD is installed, since only agents
+// that declare spec.slos get one. Detection failures disable them.
+func resolvePrometheusRuleConfig(mgr ctrl.Manager, labels string) (controller.PrometheusRuleConfig, error) {
+	parsed, err := parseLabelPairs(labels)
+	if err != nil {
+		return controller.PrometheusRuleConfig{}, fmt.Errorf("--prometheus-rule-labels: %w", err)
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		return controller.PrometheusRuleConfig{}, fmt.Errorf("create discovery client: %w", err)
+	}
+	installed, err := controller.PrometheusRuleCRDInstalled(dc)
+	if err != nil {
+		setupLog.Error(err, "unable to detect the PrometheusRule CRD; SLO alert rules are disabled")
+		return controller.PrometheusRuleConfig{}, nil
+	}
+	if !installed {
+		setupLog.Info("PrometheusRule CRD not found; SLO alert rules are disabled")
+		return controller.PrometheusRuleConfig{}, nil
+	}
+	return controller.PrometheusRuleConfig{Enabled: true, Labels: parsed}, nil
+}
+
+// parseLabelPairs parses a comma-separated key=value list into a label map.
+func parseLabelPairs(s string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, pair := range splitAndTrim(s) {
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("label %q is not key=value", pair)
+		}
+		out[k] = strings.TrimSpace(v)
+	}
+	return out, nil
+}
+
 func splitAndTrim(s string) []string {
 	out := make([]string, 0)
 	for _, part := range strings.Split(s, ",") {
@@ -92,6 +92,24 @@ func TestSplitAndTrim(t *testing.T) {
 	}
 }
 
+func TestParseLabelPairs(t *testing.T) {
+	got, err := parseLabelPairs(" release = kube-prometheus-stack ,team=ml,")
+	if err != nil {
+		t.Fatalf("parseLabelPairs: %v", err)
+	}
+	if len(got) != 2 || got["release"] != "kube-prometheus-stack" || got["team"] != "ml" {
+		t.Fatalf("parseLabelPairs = %v", got)
+	}
+	if got, err := parseLabelPairs(""); err != nil || len(got) != 0 {
+		t.Fatalf("parseLabelPairs(\"\") = %v, %v; want empty", got, err)
+	}
+	for _, bad := range []string{"release", "=x"} {
+		if _, err := parseLabelPairs(bad); err == nil {
+			t.Fatalf("parseLabelPairs(%q) = nil error, want error", bad)
+		}
+	}
+}
+
 func TestSchemeKnowsGatewayAPI(t *testing.T) {
 	gvBase := schema.GroupVersion{Group: gatewayv1.GroupVersion.Group, Version: gatewayv1.GroupVersion.Version}
 	if !scheme.Recognizes(gvBase.WithKind("HTTPRoute")) {
@@ -101,3 +119,17 @@ func TestSchemeKnowsGatewayAPI(t *testing.T) {
 		t.Fatal("scheme does not recognize Gateway")
 	}
 }
+
+func TestNewCanaryRunnerRejectsBadConfig(t *testing.T) {
+	cases := map[string]canaryOptions{
+		"missing script":    {scriptPath: "/nonexistent/canary.yaml"},
+		"invalid token url": {mgmtPlaneTokenURL: "http://dashboard.example.com/api/auth/service-token"},
+	}
+	for name, opts := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newCanaryRunner(nil, opts); err == nil {
+				t.Fatalf("newCanaryRunner(%+v) succeeded, want error", opts)
+			}
+		})
+	}
+}
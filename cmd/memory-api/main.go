@@ -34,6 +34,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	goredis "github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
@@ -79,6 +80,9 @@ import (
 	omniak8s "github.com/altairalabs/omnia/pkg/k8s"
 	"github.com/altairalabs/omnia/pkg/logctx"
 	"github.com/altairalabs/omnia/pkg/logging"
+	pkgmetrics "github.com/altairalabs/omnia/pkg/metrics"
+	"github.com/altairalabs/omnia/pkg/profiling"
+	"github.com/altairalabs/omnia/pkg/readiness"
 	"github.com/altairalabs/omnia/pkg/servicediscovery"
 )
 
@@ -683,6 +687,24 @@ func run() error {
 		}
 	}
 
+	// --- OTLP metric export (opt-in via the standard OTEL_* env vars) ---
+	shutdownMetrics, err := pkgmetrics.StartOTLPExport(ctx, "omnia-memory-api", prometheus.DefaultGatherer)
+	if err != nil {
+		log.Error(err, "OTLP metric export failed to start")
+	} else {
+		defer func() { _ = shutdownMetrics(context.Background()) }()
+	}
+
+	// --- Profiling (pprof on an internal port; Pyroscope push opt-in) ---
+	stopProfiling, err := profiling.Start(profiling.Options{
+		ServiceName: "omnia-memory-api",
+		PprofAddr:   profiling.DefaultPprofAddr,
+	})
+	if err != nil {
+		log.Error(err, "profiling failed to start")
+	}
+	defer func() { _ = stopProfiling(context.Background()) }()
+
 	// --- Event publisher (optional) ---
 	// Reuses the shared Redis client built above so we don't create a
 	// second TCP pool against the same target.
@@ -755,7 +777,7 @@ func run() error {
 	}
 
 	// --- Servers ---
-	healthSrv := newHealthServer(f.healthAddr, pool)
+	healthSrv := newHealthServer(f.healthAddr, readinessChecker(pool, redisClient))
 	metricsSrv := newMetricsServer(f.metricsAddr)
 	apiSrv := &http.Server{
 		Addr:         f.apiAddr,
@@ -1936,20 +1958,26 @@ func newMetricsServer(addr string) *http.Server {
 }
 
 // newHealthServer creates an HTTP server for health and readiness probes.
-func newHealthServer(addr string, pool *pgxpool.Pool) *http.Server {
+func newHealthServer(addr string, ready http.Handler) *http.Server {
 	healthMux := http.NewServeMux()
 	healthMux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
-	healthMux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
-		if err := pool.Ping(r.Context()); err != nil {
-			w.WriteHeader(http.StatusServiceUnavailable)
-			_, _ = w.Write([]byte("postgres unavailable"))
-			return
-		}
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
-	})
+	healthMux.Handle("GET /readyz", ready)
 	return &http.Server{Addr: addr, Handler: healthMux}
 }
+
+// readinessChecker returns the dependencies /readyz checks. Postgres is
+// critical; Redis, when configured, only backs the read-through cache and the
+// event publisher, so losing it degrades memory-api without taking it out of
+// service.
+func readinessChecker(pool *pgxpool.Pool, redisClient *goredis.Client) *readiness.Checker {
+	checker := readiness.NewChecker(readiness.Critical("postgres", pool.Ping))
+	if redisClient != nil {
+		checker.Add(readiness.Optional("redis", func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		}))
+	}
+	return checker
+}
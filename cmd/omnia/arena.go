@@ -0,0 +1,474 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	eev1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
+)
+
+const (
+	defaultArenaAPI     = "http://localhost:8082"
+	defaultArenaTimeout = time.Hour
+
+	// arenaAPIEnv supplies --arena-api when the flag is unset.
+	arenaAPIEnv = "OMNIA_ARENA_API_URL"
+	// arenaTokenEnv supplies --arena-api-token when the flag is unset.
+	arenaTokenEnv = "OMNIA_ARENA_API_TOKEN"
+
+	// defaultGenerateName names submitted jobs whose manifest sets no name.
+	defaultGenerateName = "arena-run-"
+)
+
+// arenaStatusInterval is how often the ArenaJob's status is polled while
+// watching. A var so tests can shorten it.
+var arenaStatusInterval = 5 * time.Second
+
+// errJobFailed marks an ArenaJob that finished without succeeding, i.e. one
+// of its regression gates (failed items, thresholds, budget) tripped.
+var errJobFailed = errors.New("arena job did not succeed")
+
+// newArenaClient returns a Kubernetes client for ArenaJobs and the namespace
+// of the selected kubeconfig context. A var so tests can substitute a fake.
+var newArenaClient = func(kubeconfig, kubeContext string) (client.Client, string, error) {
+	cfg, namespace, err := loadKubeConfig(kubeconfig, kubeContext)
+	if err != nil {
+		return nil, "", err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := eev1alpha1.AddToScheme(scheme); err != nil {
+		return nil, "", err
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, "", fmt.Errorf("create kubernetes client: %w", err)
+	}
+	return c, namespace, nil
+}
+
+// runArena dispatches the arena subcommands.
+func runArena(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: arena requires a subcommand", errUsage)
+	}
+	switch args[0] {
+	case "run":
+		return runArenaRun(ctx, args[1:], stdout, stderr)
+	default:
+		return fmt.Errorf("%w: unknown arena subcommand %q", errUsage, args[0])
+	}
+}
+
+// runArenaRun implements "omnia arena run". It submits the ArenaJob in the
+// manifest and, with --watch, streams its progress, renders the scenario ×
+// provider result matrix and returns errJobFailed unless the job succeeded.
+func runArenaRun(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("arena run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("f", "", "ArenaJob manifest to submit (- for stdin)")
+	var kube kubeFlags
+	kube.register(fs, "namespace to create the job in (default: manifest, then kubeconfig context)")
+	watch := fs.Bool("watch", false, "stream progress until the job finishes and exit non-zero if it fails")
+	arenaAPI := fs.String("arena-api", "",
+		"arena controller API base URL; env "+arenaAPIEnv+" (default "+defaultArenaAPI+")")
+	arenaToken := fs.String("arena-api-token", "",
+		"ServiceAccount token for an arena controller API that requires auth; env "+arenaTokenEnv)
+	timeout := fs.Duration("timeout", defaultArenaTimeout, "give up watching after this long")
+	output := fs.String("output", outputTable, "output format: table or json")
+	fs.StringVar(output, "o", outputTable, "shorthand for --output")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(positional) > 0:
+		return fmt.Errorf("%w: arena run takes no arguments", errUsage)
+	case *file == "":
+		return fmt.Errorf("%w: -f is required", errUsage)
+	case *output != outputTable && *output != outputJSON:
+		return fmt.Errorf("%w: --output must be %q or %q, got %q", errUsage, outputTable, outputJSON, *output)
+	}
+	if *arenaAPI == "" {
+		*arenaAPI = os.Getenv(arenaAPIEnv)
+	}
+	if *arenaAPI == "" {
+		*arenaAPI = defaultArenaAPI
+	}
+	if *arenaToken == "" {
+		*arenaToken = os.Getenv(arenaTokenEnv)
+	}
+
+	job, err := readArenaJob(*file)
+	if err != nil {
+		return err
+	}
+	if *watch && job.Spec.Schedule != nil {
+		return fmt.Errorf("%w: --watch cannot follow a scheduled ArenaJob", errUsage)
+	}
+
+	c, contextNamespace, err := newArenaClient(kube.kubeconfig, kube.context)
+	if err != nil {
+		return err
+	}
+	switch {
+	case kube.namespace != "":
+		job.Namespace = kube.namespace
+	case job.Namespace == "":
+		job.Namespace = contextNamespace
+	}
+	if job.Name == "" && job.GenerateName == "" {
+		job.GenerateName = defaultGenerateName
+	}
+
+	if err := c.Create(ctx, job); err != nil {
+		return fmt.Errorf("create ArenaJob: %w", err)
+	}
+	_, _ = fmt.Fprintf(stderr, "arenajob %s/%s created\n", job.Namespace, job.Name)
+	if !*watch {
+		return nil
+	}
+
+	watchCtx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	w := &arenaWatcher{
+		client:   c,
+		arenaAPI: strings.TrimSuffix(*arenaAPI, "/"),
+		token:    *arenaToken,
+		json:     *output == outputJSON,
+		stdout:   stdout,
+		stderr:   stderr,
+		matrix:   newResultMatrix(),
+	}
+	final, err := w.watch(watchCtx, job.Namespace, job.Name)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("arenajob %s/%s still running after %s", job.Namespace, job.Name, *timeout)
+		}
+		return err
+	}
+	if err := w.report(final); err != nil {
+		return err
+	}
+	if final.Status.Phase != eev1alpha1.ArenaJobPhaseSucceeded {
+		return fmt.Errorf("%w: arenajob %s/%s %s", errJobFailed, job.Namespace, job.Name,
+			strings.ToLower(string(final.Status.Phase)))
+	}
+	return nil
+}
+
+// readArenaJob reads and checks an ArenaJob manifest.
+func readArenaJob(file string) (*eev1alpha1.ArenaJob, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", file, err)
+	}
+
+	job := &eev1alpha1.ArenaJob{}
+	if err := yaml.UnmarshalStrict(data, job); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", file, err)
+	}
+	if job.Kind != "ArenaJob" {
+		return nil, fmt.Errorf("%s: kind is %q, want ArenaJob", file, job.Kind)
+	}
+	if job.APIVersion != eev1alpha1.GroupVersion.String() {
+		return nil, fmt.Errorf("%s: apiVersion is %q, want %s", file, job.APIVersion, eev1alpha1.GroupVersion)
+	}
+	// A re-submitted export must not carry server-owned state
+	job.ResourceVersion = ""
+	job.UID = ""
+	job.Status = eev1alpha1.ArenaJobStatus{}
+	return job, nil
+}
+
+// arenaWatcher follows one ArenaJob until it reaches a terminal phase.
+type arenaWatcher struct {
+	client   client.Client
+	arenaAPI string
+	token    string
+	json     bool
+	stdout   io.Writer
+	stderr   io.Writer
+
+	mu     sync.Mutex // guards matrix and writes to stdout
+	matrix *resultMatrix
+	events bool // whether any item event has been received
+}
+
+// watch streams item events while polling the job's status, and returns the
+// job once its phase is terminal. Without the event stream (e.g. an
+// in-memory queue) progress is reported from status alone.
+func (w *arenaWatcher) watch(ctx context.Context, namespace, name string) (*eev1alpha1.ArenaJob, error) {
+	streamCtx, stopStream := context.WithCancel(ctx)
+	streamDone := make(chan struct{})
+	go func() {
+		defer close(streamDone)
+		url := fmt.Sprintf("%s/api/v1/namespaces/%s/arenajobs/%s/events", w.arenaAPI, namespace, name)
+		if err := streamJobEvents(streamCtx, url, w.token, w.onEvent); err != nil && streamCtx.Err() == nil {
+			_, _ = fmt.Fprintf(w.stderr, "warning: live progress unavailable (%v); following job status only\n", err)
+		}
+	}()
+	defer func() {
+		stopStream()
+		<-streamDone
+	}()
+
+	ticker := time.NewTicker(arenaStatusInterval)
+	defer ticker.Stop()
+	var lastProgress string
+	for {
+		job := &eev1alpha1.ArenaJob{}
+		if err := w.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, job); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("get arenajob %s/%s: %w", namespace, name, err)
+		}
+		if isTerminalPhase(job.Status.Phase) {
+			// Give the stream a moment to deliver the last items
+			select {
+			case <-streamDone:
+			case <-time.After(arenaStatusInterval):
+			}
+			return job, nil
+		}
+		if progress := formatProgress(job); progress != lastProgress {
+			lastProgress = progress
+			w.mu.Lock()
+			if !w.events && !w.json {
+				_, _ = fmt.Fprintln(w.stderr, progress)
+			}
+			w.mu.Unlock()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// onEvent records an item event and prints it.
+func (w *arenaWatcher) onEvent(event queue.ItemEvent) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.events = true
+	w.matrix.add(event)
+
+	if w.json {
+		b, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w.stdout, string(b))
+		return err
+	}
+	line := fmt.Sprintf("%-4s %s × %s (%s)", strings.ToUpper(event.Status), event.ScenarioID, event.ProviderID,
+		(time.Duration(event.DurationMs) * time.Millisecond).Round(time.Millisecond))
+	if event.Error != "" {
+		line += ": " + event.Error
+	}
+	_, err := fmt.Fprintln(w.stdout, line)
+	return err
+}
+
+// arenaRunResult is the final JSON document written with -o json.
+type arenaRunResult struct {
+	Namespace string                       `json:"namespace"`
+	Name      string                       `json:"name"`
+	Phase     eev1alpha1.ArenaJobPhase     `json:"phase"`
+	Progress  *eev1alpha1.JobProgress      `json:"progress,omitempty"`
+	Summary   map[string]string            `json:"summary,omitempty"`
+	ReportURL string                       `json:"reportUrl,omitempty"`
+	Matrix    map[string]map[string]*tally `json:"matrix,omitempty"`
+}
+
+// report writes the result matrix and the job's final status.
+func (w *arenaWatcher) report(job *eev1alpha1.ArenaJob) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	result := arenaRunResult{
+		Namespace: job.Namespace,
+		Name:      job.Name,
+		Phase:     job.Status.Phase,
+		Progress:  job.Status.Progress,
+	}
+	if job.Status.Result != nil {
+		result.Summary = job.Status.Result.Summary
+		result.ReportURL = job.Status.Result.URL
+	}
+	if w.json {
+		result.Matrix = w.matrix.cells
+		b, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w.stdout, string(b))
+		return err
+	}
+
+	if len(w.matrix.cells) > 0 {
+		_, _ = fmt.Fprintln(w.stdout)
+		w.matrix.render(w.stdout)
+	}
+	_, _ = fmt.Fprintf(w.stdout, "\nPhase: %s  %s\n", result.Phase, formatProgress(job))
+	keys := make([]string, 0, len(result.Summary))
+	for k := range result.Summary {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		_, _ = fmt.Fprintf(w.stdout, "  %s: %s\n", k, result.Summary[k])
+	}
+	if result.ReportURL != "" {
+		_, _ = fmt.Fprintf(w.stdout, "Report: %s\n", result.ReportURL)
+	}
+	return nil
+}
+
+// isTerminalPhase reports whether an ArenaJob phase is final.
+func isTerminalPhase(phase eev1alpha1.ArenaJobPhase) bool {
+	switch phase {
+	case eev1alpha1.ArenaJobPhaseSucceeded, eev1alpha1.ArenaJobPhaseFailed, eev1alpha1.ArenaJobPhaseCancelled:
+		return true
+	}
+	return false
+}
+
+// formatProgress summarises a job's phase and item counts.
+func formatProgress(job *eev1alpha1.ArenaJob) string {
+	p := job.Status.Progress
+	if p == nil {
+		return fmt.Sprintf("phase %s", orPending(job.Status.Phase))
+	}
+	return fmt.Sprintf("%d/%d items done, %d failed", p.Completed+p.Failed, p.Total, p.Failed)
+}
+
+// orPending treats a job without a phase yet as Pending.
+func orPending(phase eev1alpha1.ArenaJobPhase) eev1alpha1.ArenaJobPhase {
+	if phase == "" {
+		return eev1alpha1.ArenaJobPhasePending
+	}
+	return phase
+}
+
+// tally counts item outcomes for one scenario × provider cell.
+type tally struct {
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+}
+
+// resultMatrix tallies item events by scenario and provider.
+type resultMatrix struct {
+	// cells is keyed by scenario, then provider.
+	cells map[string]map[string]*tally
+}
+
+func newResultMatrix() *resultMatrix {
+	return &resultMatrix{cells: make(map[string]map[string]*tally)}
+}
+
+// add counts one finished item.
+func (m *resultMatrix) add(event queue.ItemEvent) {
+	row := m.cells[event.ScenarioID]
+	if row == nil {
+		row = make(map[string]*tally)
+		m.cells[event.ScenarioID] = row
+	}
+	t := row[event.ProviderID]
+	if t == nil {
+		t = &tally{}
+		row[event.ProviderID] = t
+	}
+	if event.Status == queue.EventStatusPass {
+		t.Passed++
+	} else {
+		t.Failed++
+	}
+}
+
+// render writes the matrix with scenarios as rows and providers as columns.
+// A cell is PASS or FAIL for a single item and passed/total otherwise, with
+// a trailing ! when any item in it failed.
+func (m *resultMatrix) render(w io.Writer) {
+	scenarios := make([]string, 0, len(m.cells))
+	providerSet := make(map[string]bool)
+	for scenario, row := range m.cells {
+		scenarios = append(scenarios, scenario)
+		for provider := range row {
+			providerSet[provider] = true
+		}
+	}
+	providers := make([]string, 0, len(providerSet))
+	for p := range providerSet {
+		providers = append(providers, p)
+	}
+	sort.Strings(scenarios)
+	sort.Strings(providers)
+
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "SCENARIO\t%s\n", strings.Join(providers, "\t"))
+	for _, scenario := range scenarios {
+		cells := make([]string, len(providers))
+		for i, provider := range providers {
+			cells[i] = m.cells[scenario][provider].String()
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\n", scenario, strings.Join(cells, "\t"))
+	}
+	_ = tw.Flush()
+}
+
+// String formats a cell; a nil tally is a combination that did not run.
+func (t *tally) String() string {
+	switch {
+	case t == nil:
+		return "-"
+	case t.Passed+t.Failed == 1 && t.Failed == 0:
+		return "PASS"
+	case t.Passed+t.Failed == 1:
+		return "FAIL"
+	case t.Failed > 0:
+		return fmt.Sprintf("%d/%d!", t.Passed, t.Passed+t.Failed)
+	default:
+		return fmt.Sprintf("%d/%d", t.Passed, t.Passed+t.Failed)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
+)
+
+// maxStreamReconnects bounds consecutive failed reconnects to the event
+// stream before progress falls back to status polling.
+const maxStreamReconnects = 5
+
+// streamReconnectDelay is the pause before reconnecting a dropped stream.
+// A var so tests can shorten it.
+var streamReconnectDelay = 2 * time.Second
+
+// errStreamUnavailable marks a response that reconnecting will not fix,
+// e.g. a controller without a Redis-backed queue.
+var errStreamUnavailable = errors.New("event stream unavailable")
+
+// streamJobEvents reads the arena controller's server-sent events for a job
+// and calls onEvent for each finished item. A dropped connection is resumed
+// from the last event ID, so no item is reported twice. It returns nil once
+// the controller signals completion. A non-empty token is sent as a bearer
+// token.
+func streamJobEvents(ctx context.Context, url, token string, onEvent func(queue.ItemEvent) error) error {
+	var lastID string
+	failures := 0
+	for {
+		done, err := readEventStream(ctx, url, token, &lastID, onEvent)
+		switch {
+		case done:
+			return nil
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case errors.Is(err, errStreamUnavailable):
+			return err
+		case err != nil:
+			failures++
+			if failures > maxStreamReconnects {
+				return err
+			}
+		default:
+			failures = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(streamReconnectDelay):
+		}
+	}
+}
+
+// readEventStream consumes one SSE connection, advancing lastID as events
+// arrive. done reports whether the stream ended with a complete event.
+func readEventStream(
+	ctx context.Context, url, token string, lastID *string, onEvent func(queue.ItemEvent) error,
+) (done bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if *lastID != "" {
+		req.Header.Set("Last-Event-ID", *lastID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusServiceUnavailable, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return false, fmt.Errorf("%w: status %d: %s", errStreamUnavailable, resp.StatusCode,
+			strings.TrimSpace(string(body)))
+	default:
+		return false, fmt.Errorf("event stream: status %d", resp.StatusCode)
+	}
+
+	var id, event string
+	var data strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// Blank line dispatches the buffered event
+			if event == "" && data.Len() == 0 {
+				continue
+			}
+			done, err := dispatchEvent(event, data.String(), onEvent)
+			if err != nil || done {
+				return done, err
+			}
+			if id != "" {
+				*lastID = id
+			}
+			id, event = "", ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// Comment, e.g. keepalive
+		case strings.HasPrefix(line, "id:"):
+			id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, err
+	}
+	return false, io.ErrUnexpectedEOF
+}
+
+// dispatchEvent handles one complete SSE event.
+func dispatchEvent(event, data string, onEvent func(queue.ItemEvent) error) (done bool, err error) {
+	switch event {
+	case "complete":
+		return true, nil
+	case "error":
+		var payload struct {
+			Error string `json:"error"`
+		}
+		_ = json.Unmarshal([]byte(data), &payload)
+		return false, fmt.Errorf("event stream: %s", payload.Error)
+	case "item", "":
+		var item queue.ItemEvent
+		if err := json.Unmarshal([]byte(data), &item); err != nil {
+			return false, fmt.Errorf("decode item event: %w", err)
+		}
+		return false, onEvent(item)
+	}
+	return false, nil
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	eev1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
+)
+
+const testArenaJob = `apiVersion: omnia.altairalabs.ai/v1alpha1
+kind: ArenaJob
+metadata:
+  name: nightly-eval
+spec:
+  sourceRef:
+    name: support-evals
+  type: evaluation
+`
+
+func writeManifest(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "job.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// useFakeArenaClient serves ArenaJobs from a fake client whose reads report
+// the given final status once the job has been read a few times.
+func useFakeArenaClient(t *testing.T, final eev1alpha1.ArenaJobStatus) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, eev1alpha1.AddToScheme(scheme))
+
+	var mu sync.Mutex
+	reads := 0
+	c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object,
+			opts ...client.GetOption) error {
+			if err := c.Get(ctx, key, obj, opts...); err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			reads++
+			job := obj.(*eev1alpha1.ArenaJob)
+			job.Status.Phase = eev1alpha1.ArenaJobPhaseRunning
+			job.Status.Progress = &eev1alpha1.JobProgress{Total: 3, Completed: int32(reads - 1)}
+			if reads >= 3 {
+				job.Status = final
+			}
+			return nil
+		},
+	}).Build()
+
+	orig, origInterval, origDelay := newArenaClient, arenaStatusInterval, streamReconnectDelay
+	newArenaClient = func(string, string) (client.Client, string, error) { return c, "arena-ns", nil }
+	arenaStatusInterval, streamReconnectDelay = 10*time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() {
+		newArenaClient, arenaStatusInterval, streamReconnectDelay = orig, origInterval, origDelay
+	})
+	return c
+}
+
+// arenaEventServer serves the given item events on the job's SSE route,
+// then signals completion.
+func arenaEventServer(t *testing.T, events ...queue.ItemEvent) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/namespaces/arena-ns/arenajobs/nightly-eval/events",
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = fmt.Fprint(w, ": keepalive\n\n")
+			for _, e := range events {
+				b, _ := json.Marshal(e)
+				_, _ = fmt.Fprintf(w, "id: %s\nevent: item\ndata: %s\n\n", e.ID, b)
+			}
+			_, _ = fmt.Fprint(w, "event: complete\ndata: {}\n\n")
+		})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func runArgs(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+var testItemEvents = []queue.ItemEvent{
+	{ID: "1-0", ScenarioID: "refund", ProviderID: "gpt-4o", Status: queue.EventStatusPass, DurationMs: 1200},
+	{ID: "2-0", ScenarioID: "refund", ProviderID: "claude", Status: queue.EventStatusFail, Error: "assertion failed"},
+	{ID: "3-0", ScenarioID: "order-status", ProviderID: "gpt-4o", Status: queue.EventStatusPass},
+}
+
+func TestArenaRun_SubmitOnly(t *testing.T) {
+	c := useFakeArenaClient(t, eev1alpha1.ArenaJobStatus{})
+	manifest := writeManifest(t, strings.Replace(testArenaJob, "  name: nightly-eval\n", "", 1))
+
+	code, _, errOut := runArgs("arena", "run", "-f", manifest, "--namespace", "ci")
+	require.Equal(t, exitOK, code, errOut)
+	assert.Contains(t, errOut, "arenajob ci/arena-run-")
+
+	var jobs eev1alpha1.ArenaJobList
+	require.NoError(t, c.List(context.Background(), &jobs))
+	require.Len(t, jobs.Items, 1)
+	assert.Equal(t, "ci", jobs.Items[0].Namespace)
+	assert.Equal(t, "support-evals", jobs.Items[0].Spec.SourceRef.Name)
+}
+
+func TestArenaRun_WatchSucceeded(t *testing.T) {
+	useFakeArenaClient(t, eev1alpha1.ArenaJobStatus{
+		Phase:    eev1alpha1.ArenaJobPhaseSucceeded,
+		Progress: &eev1alpha1.JobProgress{Total: 3, Completed: 3},
+		Result: &eev1alpha1.JobResult{
+			URL:     "s3://results/nightly-eval",
+			Summary: map[string]string{"passRate": "100.0"},
+		},
+	})
+	srv := arenaEventServer(t, testItemEvents[0], testItemEvents[2])
+
+	code, out, errOut := runArgs("arena", "run", "-f", writeManifest(t, testArenaJob), "--watch",
+		"--arena-api", srv.URL)
+	require.Equal(t, exitOK, code, errOut)
+	assert.Contains(t, errOut, "arenajob arena-ns/nightly-eval created")
+	assert.Contains(t, out, "PASS refund × gpt-4o (1.2s)")
+	assert.Contains(t, out, "SCENARIO")
+	assert.Contains(t, out, "Phase: Succeeded  3/3 items done, 0 failed")
+	assert.Contains(t, out, "passRate: 100.0")
+	assert.Contains(t, out, "Report: s3://results/nightly-eval")
+}
+
+func TestArenaRun_WatchFailedExitsNonZero(t *testing.T) {
+	useFakeArenaClient(t, eev1alpha1.ArenaJobStatus{
+		Phase:    eev1alpha1.ArenaJobPhaseFailed,
+		Progress: &eev1alpha1.JobProgress{Total: 3, Completed: 2, Failed: 1},
+		Result:   &eev1alpha1.JobResult{Summary: map[string]string{"thresholds_passed": "false"}},
+	})
+	srv := arenaEventServer(t, testItemEvents...)
+
+	code, out, errOut := runArgs("arena", "run", "-f", writeManifest(t, testArenaJob), "--watch",
+		"--arena-api", srv.URL, "-o", "json")
+	assert.Equal(t, exitJobFailed, code)
+	assert.Contains(t, errOut, "arenajob arena-ns/nightly-eval failed")
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, len(testItemEvents)+1, "one line per item then the result")
+	var result arenaRunResult
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &result))
+	assert.Equal(t, eev1alpha1.ArenaJobPhaseFailed, result.Phase)
+	assert.Equal(t, 1, result.Matrix["refund"]["claude"].Failed)
+	assert.Equal(t, "false", result.Summary["thresholds_passed"])
+}
+
+func TestArenaRun_WatchWithoutEventStream(t *testing.T) {
+	useFakeArenaClient(t, eev1alpha1.ArenaJobStatus{Phase: eev1alpha1.ArenaJobPhaseSucceeded})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "live progress requires a Redis-backed queue", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	code, _, errOut := runArgs("arena", "run", "-f", writeManifest(t, testArenaJob), "--watch",
+		"--arena-api", srv.URL)
+	require.Equal(t, exitOK, code, errOut)
+	assert.Contains(t, errOut, "live progress unavailable")
+	assert.Contains(t, errOut, "1/3 items done, 0 failed", "progress comes from the job status")
+}
+
+func TestArenaRun_UsageErrors(t *testing.T) {
+	useFakeArenaClient(t, eev1alpha1.ArenaJobStatus{})
+	scheduled := writeManifest(t, testArenaJob+"  schedule:\n    cron: \"0 2 * * *\"\n")
+
+	code, _, _ := runArgs("arena", "run")
+	assert.Equal(t, exitUsage, code)
+	code, _, errOut := runArgs("arena", "run", "-f", scheduled, "--watch")
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, errOut, "scheduled")
+
+	code, _, errOut = runArgs("arena", "run", "-f", writeManifest(t, "apiVersion: v1\nkind: ConfigMap\n"))
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, errOut, "want ArenaJob")
+}
+
+func TestStreamJobEvents_ResumesFromLastEventID(t *testing.T) {
+	var mu sync.Mutex
+	var resumedFrom []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		resumedFrom = append(resumedFrom, r.Header.Get("Last-Event-ID"))
+		first := len(resumedFrom) == 1
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/event-stream")
+		if first {
+			// Drop the connection after one event
+			_, _ = fmt.Fprint(w, "id: 1-0\nevent: item\ndata: {\"id\":\"1-0\",\"status\":\"pass\"}\n\n")
+			return
+		}
+		_, _ = fmt.Fprint(w, "id: 2-0\nevent: item\ndata: {\"id\":\"2-0\",\"status\":\"fail\"}\n\n")
+		_, _ = fmt.Fprint(w, "event: complete\ndata: {}\n\n")
+	}))
+	t.Cleanup(srv.Close)
+	orig := streamReconnectDelay
+	streamReconnectDelay = time.Millisecond
+	t.Cleanup(func() { streamReconnectDelay = orig })
+
+	var got []string
+	err := streamJobEvents(context.Background(), srv.URL, "", func(e queue.ItemEvent) error {
+		got = append(got, e.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1-0", "2-0"}, got)
+	assert.Equal(t, []string{"", "1-0"}, resumedFrom)
+}
+
+func TestStreamJobEvents_Auth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sa-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "event: complete\ndata: {}\n\n")
+	}))
+	t.Cleanup(srv.Close)
+
+	noop := func(queue.ItemEvent) error { return nil }
+	require.NoError(t, streamJobEvents(context.Background(), srv.URL, "sa-token", noop))
+	// A rejected token is not retried
+	err := streamJobEvents(context.Background(), srv.URL, "", noop)
+	assert.ErrorIs(t, err, errStreamUnavailable)
+}
+
+func TestResultMatrix_Render(t *testing.T) {
+	m := newResultMatrix()
+	for _, e := range testItemEvents {
+		m.add(e)
+	}
+	m.add(queue.ItemEvent{ScenarioID: "refund", ProviderID: "gpt-4o", Status: queue.EventStatusFail})
+
+	var out bytes.Buffer
+	m.render(&out)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"SCENARIO", "claude", "gpt-4o"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"order-status", "-", "PASS"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"refund", "FAIL", "1/2!"}, strings.Fields(lines[2]))
+}
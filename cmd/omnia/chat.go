@@ -0,0 +1,532 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/altairalabs/omnia/internal/facade"
+)
+
+const (
+	// agentURLEnv and agentTokenEnv supply --url and --token when unset.
+	agentURLEnv   = "OMNIA_AGENT_URL"
+	agentTokenEnv = "OMNIA_AGENT_TOKEN"
+
+	chatHandshakeTimeout = 30 * time.Second
+
+	// maxToolPreview bounds how much of a tool's arguments or result is
+	// printed inline.
+	maxToolPreview = 200
+)
+
+// chatFeatures are the protocol features the REPL renders. Streaming
+// delivers the reply as it is generated; progress reports server-side tools.
+var chatFeatures = []facade.Feature{facade.FeatureStreaming, facade.FeatureProgress, facade.FeatureNotice}
+
+const chatHelp = `Commands:
+  /save [file]   write the transcript (JSON when the file ends in .json)
+  /session       print the session ID
+  /quit          end the chat (also Ctrl-D)
+`
+
+// runChat implements "omnia chat <agent>": an interactive conversation with
+// an AgentRuntime over the facade WebSocket.
+func runChat(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("chat", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var kube kubeFlags
+	kube.register(fs, "namespace of the agent (default: kubeconfig context)")
+	agentURL := fs.String("url", "",
+		"facade URL to connect to instead of port-forwarding, e.g. an Ingress; env "+agentURLEnv)
+	ingress := fs.Bool("ingress", false,
+		"connect through the agent's external WebSocket endpoint instead of port-forwarding")
+	token := fs.String("token", "", "bearer token (client key or OIDC token) for the facade; env "+agentTokenEnv)
+	session := fs.String("session", "", "session ID to continue")
+	save := fs.String("save", "", "write the transcript to this file on exit (JSON when it ends in .json)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("%w: chat requires exactly one agent name", errUsage)
+	}
+	agent := positional[0]
+	if *agentURL == "" {
+		*agentURL = os.Getenv(agentURLEnv)
+	}
+	if *token == "" {
+		*token = os.Getenv(agentTokenEnv)
+	}
+	if *agentURL != "" && *ingress {
+		return fmt.Errorf("%w: --url and --ingress are mutually exclusive", errUsage)
+	}
+
+	target, err := resolveAgent(ctx, agentTarget{
+		kube: kube, agent: agent, url: *agentURL, ingress: *ingress, stderr: stderr,
+	})
+	if err != nil {
+		return err
+	}
+	defer target.close()
+
+	headers := http.Header{}
+	if *token != "" {
+		headers.Set("Authorization", "Bearer "+*token)
+	}
+	dialer := websocket.Dialer{HandshakeTimeout: chatHandshakeTimeout}
+	conn, resp, err := dialer.DialContext(ctx, chatSocketURL(target.url, agent, target.namespace), headers)
+	if err != nil {
+		return dialError(err, resp)
+	}
+	defer func() { _ = conn.Close() }()
+
+	c := &chatREPL{
+		conn:   conn,
+		agent:  agent,
+		stdout: stdout,
+		stderr: stderr,
+		transcript: transcript{
+			Agent: agent, Namespace: target.namespace, SessionID: *session,
+		},
+	}
+	err = c.run(ctx)
+	if *save != "" {
+		if saveErr := c.transcript.save(*save); saveErr != nil {
+			return errors.Join(err, saveErr)
+		}
+		_, _ = fmt.Fprintf(stderr, "transcript saved to %s\n", *save)
+	}
+	return err
+}
+
+// chatSocketURL builds the facade WebSocket URL from a facade base URL,
+// which may be http(s) or ws(s) and may already end in /ws.
+func chatSocketURL(base, agent, namespace string) string {
+	base = strings.TrimSuffix(base, "/")
+	switch {
+	case strings.HasPrefix(base, "http://"):
+		base = "ws://" + strings.TrimPrefix(base, "http://")
+	case strings.HasPrefix(base, "https://"):
+		base = "wss://" + strings.TrimPrefix(base, "https://")
+	}
+	if !strings.HasSuffix(base, "/ws") {
+		base += "/ws"
+	}
+
+	features := make([]string, len(chatFeatures))
+	for i, f := range chatFeatures {
+		features[i] = string(f)
+	}
+	query := url.Values{}
+	query.Set("agent", agent)
+	if namespace != "" {
+		query.Set("namespace", namespace)
+	}
+	query.Set("protocol", strconv.Itoa(facade.ProtocolVersion))
+	query.Set("features", strings.Join(features, ","))
+	return base + "?" + query.Encode()
+}
+
+// dialError describes a failed WebSocket upgrade, with a hint for the auth
+// failures a developer is most likely to hit.
+func dialError(err error, resp *http.Response) error {
+	if resp == nil {
+		return fmt.Errorf("connect to agent: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return errors.New("connect to agent: unauthorized (status 401); pass a client key with --token")
+	case http.StatusForbidden:
+		return errors.New("connect to agent: forbidden (status 403); the credential may not use this agent")
+	}
+	return fmt.Errorf("connect to agent: status %d", resp.StatusCode)
+}
+
+// chatREPL runs the read-send-render loop over one connection.
+type chatREPL struct {
+	conn       *websocket.Conn
+	agent      string
+	stdout     io.Writer
+	stderr     io.Writer
+	transcript transcript
+
+	// streamed is the reply so far in the current turn, from chunks.
+	streamed strings.Builder
+}
+
+// run reads lines from stdin and sends each as a message, rendering the
+// agent's reply before reading the next. It returns nil when stdin ends or
+// the user quits.
+func (c *chatREPL) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	frames := make(chan facade.ServerMessage)
+	readErr := make(chan error, 1)
+	go func() {
+		for {
+			var msg facade.ServerMessage
+			if err := c.conn.ReadJSON(&msg); err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case frames <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(stdin)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Input is read only between turns: a nil channel blocks its case.
+	var input <-chan string
+	connected := false
+	for {
+		select {
+		case <-ctx.Done():
+			c.close()
+			return nil
+		case err := <-readErr:
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				_, _ = fmt.Fprintln(c.stderr, "connection closed by the agent")
+				return nil
+			}
+			return fmt.Errorf("read from agent: %w", err)
+		case msg := <-frames:
+			done, err := c.handle(msg)
+			if err != nil {
+				return err
+			}
+			if msg.Type == facade.MessageTypeConnected && !connected {
+				connected = true
+				_, _ = fmt.Fprintf(c.stderr, "connected to %s (session %s); /help for commands\n",
+					c.agent, c.transcript.SessionID)
+				input = lines
+			}
+			if done {
+				input = lines
+			}
+		case line, ok := <-input:
+			if !ok {
+				c.close()
+				return nil
+			}
+			send, quit, err := c.command(strings.TrimSpace(line))
+			switch {
+			case err != nil:
+				_, _ = fmt.Fprintf(c.stderr, "error: %v\n", err)
+			case quit:
+				c.close()
+				return nil
+			case send:
+				if err := c.send(line); err != nil {
+					return err
+				}
+				input = nil
+			}
+		}
+	}
+}
+
+// command handles a REPL command. send reports that line is a message for
+// the agent rather than a command.
+func (c *chatREPL) command(line string) (send, quit bool, err error) {
+	if line == "" {
+		return false, false, nil
+	}
+	if !strings.HasPrefix(line, "/") {
+		return true, false, nil
+	}
+	name, arg, _ := strings.Cut(line, " ")
+	switch name {
+	case "/quit", "/exit":
+		return false, true, nil
+	case "/session":
+		_, _ = fmt.Fprintln(c.stderr, c.transcript.SessionID)
+	case "/save":
+		path := strings.TrimSpace(arg)
+		if path == "" {
+			path = fmt.Sprintf("%s-%s.json", c.agent, time.Now().UTC().Format("20060102-150405"))
+		}
+		if err := c.transcript.save(path); err != nil {
+			return false, false, err
+		}
+		_, _ = fmt.Fprintf(c.stderr, "transcript saved to %s\n", path)
+	case "/help":
+		_, _ = fmt.Fprint(c.stderr, chatHelp)
+	default:
+		return false, false, fmt.Errorf("unknown command %s; /help lists commands", name)
+	}
+	return false, false, nil
+}
+
+// send writes a user message and records it.
+func (c *chatREPL) send(content string) error {
+	msg := facade.ClientMessage{
+		Type: facade.MessageTypeMessage, SessionID: c.transcript.SessionID, Content: content,
+	}
+	if err := c.conn.WriteJSON(msg); err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+	c.transcript.add(transcriptEntry{Role: "user", Content: content})
+	c.streamed.Reset()
+	return nil
+}
+
+// handle renders one server message. done reports the end of a turn.
+func (c *chatREPL) handle(msg facade.ServerMessage) (done bool, err error) {
+	switch msg.Type {
+	case facade.MessageTypeConnected:
+		// A session named by --session is continued by sending its ID
+		if c.transcript.SessionID == "" {
+			c.transcript.SessionID = msg.SessionID
+		}
+	case facade.MessageTypeChunk:
+		if msg.Role == "user" {
+			return false, nil
+		}
+		if c.streamed.Len() == 0 {
+			_, _ = fmt.Fprintf(c.stdout, "%s: ", c.agent)
+		}
+		text := messageText(msg)
+		c.streamed.WriteString(text)
+		_, _ = fmt.Fprint(c.stdout, text)
+	case facade.MessageTypeDone:
+		reply := messageText(msg)
+		if c.streamed.Len() == 0 {
+			_, _ = fmt.Fprintf(c.stdout, "%s: %s", c.agent, reply)
+		}
+		if reply == "" {
+			reply = c.streamed.String()
+		}
+		_, _ = fmt.Fprintln(c.stdout)
+		c.transcript.add(transcriptEntry{Role: "assistant", Content: reply})
+		c.streamed.Reset()
+		return true, nil
+	case facade.MessageTypeError:
+		c.endStream()
+		info := msg.Error
+		if info == nil {
+			info = &facade.ErrorInfo{Message: msg.Content}
+		}
+		_, _ = fmt.Fprintf(c.stdout, "error [%s]: %s\n", info.Code, info.Message)
+		c.transcript.add(transcriptEntry{Role: "error", Content: info.Message, Error: info})
+		return true, nil
+	case facade.MessageTypeToolCall:
+		return false, c.handleToolCall(msg.ToolCall)
+	case facade.MessageTypeToolResult:
+		if r := msg.ToolResult; r != nil {
+			c.endStream()
+			if r.Error != "" {
+				_, _ = fmt.Fprintf(c.stdout, "  ← %s failed: %s\n", r.ID, r.Error)
+			} else {
+				_, _ = fmt.Fprintf(c.stdout, "  ← %s: %s\n", r.ID, preview(r.Result))
+			}
+			c.transcript.add(transcriptEntry{Role: "tool", ToolResult: r})
+		}
+	case facade.MessageTypeProgress:
+		c.handleProgress(msg.Progress)
+	case facade.MessageTypeNotice:
+		if msg.Notice != nil {
+			_, _ = fmt.Fprintf(c.stderr, "notice: %s\n", msg.Notice.Message)
+		}
+	}
+	return false, nil
+}
+
+// handleToolCall shows a client-side tool call. The CLI implements no
+// client tools, so the call is declined and the agent carries on without
+// it; a call paused for step-through debugging is left to the debugger.
+func (c *chatREPL) handleToolCall(call *facade.ToolCallInfo) error {
+	if call == nil {
+		return nil
+	}
+	c.endStream()
+	_, _ = fmt.Fprintf(c.stdout, "  → %s(%s)\n", call.Name, preview(call.Arguments))
+	c.transcript.add(transcriptEntry{Role: "tool_call", ToolCall: call})
+	if call.AwaitingDebug {
+		return nil
+	}
+	nack := facade.ClientMessage{
+		Type:         facade.MessageTypeToolCallNack,
+		SessionID:    c.transcript.SessionID,
+		ToolCallNack: &facade.ToolCallNackInfo{CallID: call.ID, Reason: "client tools are not supported by omnia chat"},
+	}
+	if err := c.conn.WriteJSON(nack); err != nil {
+		return fmt.Errorf("decline tool call: %w", err)
+	}
+	return nil
+}
+
+// handleProgress shows server-side tool calls as they start and finish.
+func (c *chatREPL) handleProgress(p *facade.ProgressInfo) {
+	if p == nil || p.Stage != facade.ProgressStageTool {
+		return
+	}
+	switch p.Status {
+	case facade.ProgressStatusStarted:
+		c.endStream()
+		_, _ = fmt.Fprintf(c.stdout, "  ⚙ %s running\n", p.ToolName)
+	case facade.ProgressStatusCompleted, facade.ProgressStatusFailed:
+		c.endStream()
+		_, _ = fmt.Fprintf(c.stdout, "  ⚙ %s %s (%s)\n", p.ToolName, p.Status,
+			time.Duration(p.ElapsedMs)*time.Millisecond)
+	}
+}
+
+// endStream breaks a partially streamed reply so an event line starts on a
+// line of its own; the reply continues with a fresh prefix.
+func (c *chatREPL) endStream() {
+	if c.streamed.Len() > 0 {
+		_, _ = fmt.Fprintln(c.stdout)
+		c.transcript.add(transcriptEntry{Role: "assistant", Content: c.streamed.String()})
+		c.streamed.Reset()
+	}
+}
+
+// close ends the connection with a normal close frame.
+func (c *chatREPL) close() {
+	_ = c.conn.WriteMessage(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
+
+// messageText returns a message's text content.
+func messageText(msg facade.ServerMessage) string {
+	if len(msg.Parts) == 0 {
+		return msg.Content
+	}
+	var b strings.Builder
+	for _, p := range msg.Parts {
+		if p.Type == facade.ContentPartTypeText {
+			b.WriteString(p.Text)
+		}
+	}
+	return b.String()
+}
+
+// preview renders a tool argument or result compactly for inline display.
+func preview(v any) string {
+	if v == nil {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if len(b) > maxToolPreview {
+		return string(b[:maxToolPreview]) + "…"
+	}
+	return string(b)
+}
+
+// transcript is the conversation as written by --save and /save.
+type transcript struct {
+	Agent     string            `json:"agent"`
+	Namespace string            `json:"namespace,omitempty"`
+	SessionID string            `json:"sessionId,omitempty"`
+	Entries   []transcriptEntry `json:"entries"`
+}
+
+// transcriptEntry is one message or event of the conversation.
+type transcriptEntry struct {
+	Role       string                 `json:"role"`
+	Content    string                 `json:"content,omitempty"`
+	ToolCall   *facade.ToolCallInfo   `json:"toolCall,omitempty"`
+	ToolResult *facade.ToolResultInfo `json:"toolResult,omitempty"`
+	Error      *facade.ErrorInfo      `json:"error,omitempty"`
+	Timestamp  time.Time              `json:"timestamp"`
+}
+
+func (t *transcript) add(e transcriptEntry) {
+	e.Timestamp = time.Now().UTC()
+	t.Entries = append(t.Entries, e)
+}
+
+// save writes the transcript as JSON when path ends in .json and as plain
+// text otherwise.
+func (t *transcript) save(path string) error {
+	var data []byte
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		b, err := json.MarshalIndent(t, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(b, '\n')
+	} else {
+		data = []byte(t.text())
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("save transcript: %w", err)
+	}
+	return nil
+}
+
+// text renders the transcript for reading.
+func (t *transcript) text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s", t.Agent)
+	if t.Namespace != "" {
+		fmt.Fprintf(&b, " (%s)", t.Namespace)
+	}
+	if t.SessionID != "" {
+		fmt.Fprintf(&b, " session %s", t.SessionID)
+	}
+	b.WriteString("\n\n")
+	for _, e := range t.Entries {
+		ts := e.Timestamp.Format(time.TimeOnly)
+		switch {
+		case e.ToolCall != nil:
+			fmt.Fprintf(&b, "[%s] tool call %s(%s)\n", ts, e.ToolCall.Name, preview(e.ToolCall.Arguments))
+		case e.ToolResult != nil:
+			fmt.Fprintf(&b, "[%s] tool result %s: %s%s\n", ts, e.ToolResult.ID, preview(e.ToolResult.Result),
+				e.ToolResult.Error)
+		default:
+			fmt.Fprintf(&b, "[%s] %s: %s\n", ts, e.Role, e.Content)
+		}
+	}
+	return b.String()
+}
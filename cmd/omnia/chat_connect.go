@@ -0,0 +1,241 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+)
+
+// facadePortName names the agent Service port and container port of the
+// WebSocket facade.
+const facadePortName = "facade"
+
+// agentTarget describes how to reach an agent's facade.
+type agentTarget struct {
+	kube    kubeFlags
+	agent   string
+	url     string // explicit facade URL; skips the cluster lookup
+	ingress bool
+	stderr  io.Writer
+}
+
+// agentConnection is a reachable facade base URL, with whatever keeps it
+// reachable (a port-forward) released by close.
+type agentConnection struct {
+	url       string
+	namespace string
+	stop      func()
+}
+
+func (a *agentConnection) close() {
+	if a.stop != nil {
+		a.stop()
+	}
+}
+
+// resolveAgent finds the facade of the AgentRuntime named by t: the given
+// URL, the agent's external WebSocket endpoint with --ingress, or otherwise
+// a port-forward to one of its ready pods.
+func resolveAgent(ctx context.Context, t agentTarget) (*agentConnection, error) {
+	if t.url != "" {
+		return &agentConnection{url: t.url, namespace: t.kube.namespace}, nil
+	}
+
+	cfg, namespace, err := loadKubeConfig(t.kube.kubeconfig, t.kube.context)
+	if err != nil {
+		return nil, err
+	}
+	if t.kube.namespace != "" {
+		namespace = t.kube.namespace
+	}
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := omniav1alpha1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	agent := &omniav1alpha1.AgentRuntime{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: t.agent}, agent); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("agent %q not found in namespace %q", t.agent, namespace)
+		}
+		return nil, fmt.Errorf("get agent %s/%s: %w", namespace, t.agent, err)
+	}
+
+	if t.ingress {
+		url, err := externalSocketURL(agent)
+		if err != nil {
+			return nil, err
+		}
+		return &agentConnection{url: url, namespace: namespace}, nil
+	}
+
+	pod, port, err := facadePod(ctx, c, namespace, t.agent)
+	if err != nil {
+		return nil, err
+	}
+	local, stop, err := portForward(cfg, pod, port)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = fmt.Fprintf(t.stderr, "forwarding localhost:%d -> pod/%s:%d\n", local, pod.Name, port)
+	return &agentConnection{
+		url:       fmt.Sprintf("ws://localhost:%d", local),
+		namespace: namespace,
+		stop:      stop,
+	}, nil
+}
+
+// externalSocketURL returns the agent's first valid external WebSocket
+// endpoint, as published from its HTTPRoutes.
+func externalSocketURL(agent *omniav1alpha1.AgentRuntime) (string, error) {
+	if agent.Status.Facade != nil {
+		for _, e := range agent.Status.Facade.Endpoints {
+			if e.Protocol == omniav1alpha1.FacadeProtocolWebSocket && e.Valid {
+				return e.URL, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("agent %s/%s has no external WebSocket endpoint; omit --ingress to port-forward",
+		agent.Namespace, agent.Name)
+}
+
+// facadePod picks a ready pod behind the agent's Service and the container
+// port its facade listens on.
+func facadePod(ctx context.Context, c client.Client, namespace, name string) (*corev1.Pod, int32, error) {
+	svc := &corev1.Service{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, svc); err != nil {
+		return nil, 0, fmt.Errorf("get service %s/%s: %w", namespace, name, err)
+	}
+	var svcPort *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if svc.Spec.Ports[i].Name == facadePortName {
+			svcPort = &svc.Spec.Ports[i]
+		}
+	}
+	if svcPort == nil {
+		return nil, 0, fmt.Errorf("service %s/%s has no %q port", namespace, name, facadePortName)
+	}
+
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabels(svc.Spec.Selector)); err != nil {
+		return nil, 0, fmt.Errorf("list pods of agent %s/%s: %w", namespace, name, err)
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !podReady(pod) {
+			continue
+		}
+		if port := containerPort(pod, svcPort); port > 0 {
+			return pod, port, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("agent %s/%s has no ready pods", namespace, name)
+}
+
+// podReady reports whether a pod is running and passing its readiness checks.
+func podReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// containerPort resolves a Service port's target on pod, or 0 when the pod
+// does not expose it.
+func containerPort(pod *corev1.Pod, port *corev1.ServicePort) int32 {
+	if port.TargetPort.StrVal == "" {
+		if port.TargetPort.IntVal != 0 {
+			return port.TargetPort.IntVal
+		}
+		return port.Port
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, p := range container.Ports {
+			if p.Name == port.TargetPort.StrVal {
+				return p.ContainerPort
+			}
+		}
+	}
+	return 0
+}
+
+// portForward forwards a free local port to port on pod, like kubectl
+// port-forward, and returns the local port and a function that stops it.
+func portForward(cfg *rest.Config, pod *corev1.Pod, port int32) (uint16, func(), error) {
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return 0, nil, fmt.Errorf("create kubernetes client: %w", err)
+	}
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return 0, nil, fmt.Errorf("port-forward: %w", err)
+	}
+	url := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("portforward").URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stopCh, readyCh := make(chan struct{}), make(chan struct{})
+	fw, err := portforward.New(dialer, []string{fmt.Sprintf("0:%d", port)}, stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		return 0, nil, fmt.Errorf("port-forward: %w", err)
+	}
+	errCh := make(chan error, 1)
+	go func() { errCh <- fw.ForwardPorts() }()
+
+	select {
+	case <-readyCh:
+	case err := <-errCh:
+		if err == nil {
+			err = errors.New("stopped before it was ready")
+		}
+		return 0, nil, fmt.Errorf("port-forward to pod %s: %w", pod.Name, err)
+	}
+	ports, err := fw.GetPorts()
+	if err != nil || len(ports) == 0 {
+		close(stopCh)
+		return 0, nil, fmt.Errorf("port-forward to pod %s: no local port", pod.Name)
+	}
+	return ports[0].Local, func() { close(stopCh) }, nil
+}
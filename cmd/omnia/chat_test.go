@@ -0,0 +1,237 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/facade"
+)
+
+// fakeFacade answers each message with a server-side tool, a client-side
+// tool call and a streamed reply, recording what the client sent.
+type fakeFacade struct {
+	mu       sync.Mutex
+	query    string
+	auth     string
+	received []facade.ClientMessage
+}
+
+func (f *fakeFacade) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.query, f.auth = r.URL.RawQuery, r.Header.Get("Authorization")
+	f.mu.Unlock()
+	upgrader := websocket.Upgrader{}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	_ = conn.WriteJSON(facade.ServerMessage{Type: facade.MessageTypeConnected, SessionID: "sess-1"})
+	for {
+		var msg facade.ClientMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.received = append(f.received, msg)
+		f.mu.Unlock()
+		if msg.Type != facade.MessageTypeMessage {
+			continue
+		}
+		for _, reply := range []facade.ServerMessage{
+			{Type: facade.MessageTypeProgress, Progress: &facade.ProgressInfo{
+				Stage: facade.ProgressStageTool, Status: facade.ProgressStatusStarted, ToolName: "lookup_order"}},
+			{Type: facade.MessageTypeProgress, Progress: &facade.ProgressInfo{
+				Stage: facade.ProgressStageTool, Status: facade.ProgressStatusCompleted, ToolName: "lookup_order",
+				ElapsedMs: 40}},
+			{Type: facade.MessageTypeToolCall, ToolCall: &facade.ToolCallInfo{
+				ID: "call-1", Name: "get_location", Arguments: map[string]any{"precise": true}}},
+			{Type: facade.MessageTypeChunk, Content: "Your order "},
+			{Type: facade.MessageTypeChunk, Content: "has shipped."},
+			{Type: facade.MessageTypeDone, Content: "Your order has shipped."},
+		} {
+			_ = conn.WriteJSON(reply)
+		}
+	}
+}
+
+func (f *fakeFacade) messages() []facade.ClientMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]facade.ClientMessage(nil), f.received...)
+}
+
+func runChatCLI(t *testing.T, input string, args ...string) (int, string, string) {
+	t.Helper()
+	orig := stdin
+	stdin = strings.NewReader(input)
+	t.Cleanup(func() { stdin = orig })
+	return runArgs(append([]string{"chat"}, args...)...)
+}
+
+func TestChat_StreamsReplyAndToolEvents(t *testing.T) {
+	api := &fakeFacade{}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+	transcriptPath := filepath.Join(t.TempDir(), "chat.json")
+
+	code, out, errOut := runChatCLI(t, "where is my order?\n/session\n",
+		"support", "-n", "acme", "--url", srv.URL, "--token", "ck_123", "--save", transcriptPath)
+	require.Equal(t, exitOK, code, errOut)
+
+	assert.Contains(t, errOut, "connected to support (session sess-1)")
+	assert.Contains(t, out, "⚙ lookup_order running\n")
+	assert.Contains(t, out, "⚙ lookup_order completed (40ms)\n")
+	assert.Contains(t, out, `→ get_location({"precise":true})`)
+	assert.Contains(t, out, "support: Your order has shipped.\n")
+	assert.Contains(t, errOut, "sess-1\n", "/session prints the session ID")
+
+	api.mu.Lock()
+	assert.Equal(t, "Bearer ck_123", api.auth)
+	assert.Contains(t, api.query, "agent=support")
+	assert.Contains(t, api.query, "namespace=acme")
+	assert.Contains(t, api.query, "features=streaming%2Cprogress%2Cnotice")
+	api.mu.Unlock()
+
+	require.Eventually(t, func() bool { return len(api.messages()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	sent := api.messages()
+	assert.Equal(t, "where is my order?", sent[0].Content)
+	assert.Equal(t, "sess-1", sent[0].SessionID)
+	require.NotNil(t, sent[1].ToolCallNack, "client tool calls are declined")
+	assert.Equal(t, "call-1", sent[1].ToolCallNack.CallID)
+
+	data, err := os.ReadFile(transcriptPath)
+	require.NoError(t, err)
+	var saved transcript
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, "sess-1", saved.SessionID)
+	roles := make([]string, len(saved.Entries))
+	for i, e := range saved.Entries {
+		roles[i] = e.Role
+	}
+	assert.Equal(t, []string{"user", "tool_call", "assistant"}, roles)
+	assert.Equal(t, "Your order has shipped.", saved.Entries[2].Content)
+}
+
+func TestChat_ContinuesSession(t *testing.T) {
+	api := &fakeFacade{}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	code, _, errOut := runChatCLI(t, "and now?\n", "support", "--url", srv.URL, "--session", "earlier")
+	require.Equal(t, exitOK, code, errOut)
+	assert.Contains(t, errOut, "(session earlier)")
+	require.Eventually(t, func() bool { return len(api.messages()) > 0 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "earlier", api.messages()[0].SessionID)
+}
+
+func TestChat_Unauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(srv.Close)
+
+	code, _, errOut := runChatCLI(t, "", "support", "--url", srv.URL)
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, errOut, "--token")
+
+	code, _, _ = runChatCLI(t, "", "support", "--url", srv.URL, "--ingress")
+	assert.Equal(t, exitUsage, code)
+}
+
+func TestChatSocketURL(t *testing.T) {
+	tests := []struct {
+		base, want string
+	}{
+		{"http://localhost:8080", "ws://localhost:8080/ws?"},
+		{"https://agents.example.com/support/ws", "wss://agents.example.com/support/ws?"},
+		{"wss://agents.example.com/", "wss://agents.example.com/ws?"},
+	}
+	for _, tc := range tests {
+		got := chatSocketURL(tc.base, "support", "")
+		assert.True(t, strings.HasPrefix(got, tc.want), "%s -> %s", tc.base, got)
+		assert.NotContains(t, got, "namespace=")
+	}
+}
+
+func TestExternalSocketURL(t *testing.T) {
+	agent := &omniav1alpha1.AgentRuntime{ObjectMeta: metav1.ObjectMeta{Name: "support", Namespace: "acme"}}
+	_, err := externalSocketURL(agent)
+	assert.ErrorContains(t, err, "omit --ingress")
+
+	agent.Status.Facade = &omniav1alpha1.FacadeStatus{Endpoints: []omniav1alpha1.FacadeEndpoint{
+		{Protocol: omniav1alpha1.FacadeProtocolWebSocket, URL: "wss://bad.example.com/support/ws"},
+		{Protocol: omniav1alpha1.FacadeProtocolA2A, URL: "https://a2a.example.com", Valid: true},
+		{Protocol: omniav1alpha1.FacadeProtocolWebSocket, URL: "wss://agents.example.com/ws", Valid: true},
+	}}
+	url, err := externalSocketURL(agent)
+	require.NoError(t, err)
+	assert.Equal(t, "wss://agents.example.com/ws", url)
+}
+
+func TestFacadePod(t *testing.T) {
+	selector := map[string]string{"app.kubernetes.io/instance": "support"}
+	pod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "acme", Labels: selector},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  "facade",
+				Ports: []corev1.ContainerPort{{Name: facadePortName, ContainerPort: 8080}},
+			}}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "support", Namespace: "acme"},
+		Spec: corev1.ServiceSpec{Selector: selector, Ports: []corev1.ServicePort{
+			{Name: facadePortName, Port: 80, TargetPort: intstr.FromString(facadePortName)},
+		}},
+	}
+	c := fake.NewClientBuilder().WithObjects(svc, pod("support-a", corev1.ConditionFalse),
+		pod("support-b", corev1.ConditionTrue)).Build()
+
+	got, port, err := facadePod(context.Background(), c, "acme", "support")
+	require.NoError(t, err)
+	assert.Equal(t, "support-b", got.Name)
+	assert.Equal(t, int32(8080), port)
+
+	_, _, err = facadePod(context.Background(), c, "acme", "billing")
+	assert.Error(t, err)
+}
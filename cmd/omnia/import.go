@@ -0,0 +1,197 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/altairalabs/omnia/internal/session/importer"
+)
+
+// importPath is session-api's import endpoint.
+const importPath = "/api/v1/import"
+
+// runImport implements "omnia import". It uploads an export to session-api,
+// which writes each conversation in it as a completed session. Conversations
+// already imported are skipped, so a failed import can be re-run.
+func runImport(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var cf clientFlags
+	cf.register(fs)
+	file := fs.String("f", "", "export to import (- for stdin)")
+	format := fs.String("format", "", "export format: langsmith, openai or jsonl")
+	namespace := fs.String("namespace", "", "workspace namespace the sessions belong to")
+	fs.StringVar(namespace, "n", "", "shorthand for --namespace")
+	agent := fs.String("agent", "", "agent the sessions are attributed to")
+	workspace := fs.String("workspace", "", "workspace display name recorded on the sessions")
+	var tags []string
+	fs.Func("tag", "extra tag for every imported session (repeatable)", func(v string) error {
+		tags = append(tags, v)
+		return nil
+	})
+	dryRun := fs.Bool("dry-run", false, "parse the export and report what would be imported without uploading it")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(positional) > 0:
+		return fmt.Errorf("%w: import takes no arguments", errUsage)
+	case *file == "":
+		return fmt.Errorf("%w: -f is required", errUsage)
+	case !*dryRun && (*namespace == "" || *agent == ""):
+		return fmt.Errorf("%w: --namespace and --agent are required", errUsage)
+	}
+	f, err := importer.ParseFormat(*format)
+	if err != nil {
+		return fmt.Errorf("%w: --format: %v", errUsage, err)
+	}
+	if err := cf.validate(); err != nil {
+		return err
+	}
+
+	data, err := readImportFile(*file)
+	if err != nil {
+		return err
+	}
+	if *dryRun {
+		return dryRunImport(stdout, f, data, cf.output)
+	}
+
+	query := url.Values{"format": {string(f)}, "namespace": {*namespace}, "agent": {*agent}}
+	if *workspace != "" {
+		query.Set("workspace", *workspace)
+	}
+	if len(tags) > 0 {
+		query["tag"] = tags
+	}
+	res, err := postImport(ctx, &cf, query, data)
+	if err != nil {
+		return err
+	}
+
+	if cf.output == outputJSON {
+		if err := writeJSON(stdout, res); err != nil {
+			return err
+		}
+	} else {
+		_, _ = fmt.Fprintf(stdout, "imported %d sessions (%d messages), skipped %d already imported or empty\n",
+			res.Imported, res.Messages, res.Skipped)
+		for _, e := range res.Errors {
+			_, _ = fmt.Fprintf(stderr, "failed: %s\n", e)
+		}
+	}
+	if len(res.Errors) > 0 {
+		return fmt.Errorf("%d conversations failed to import; re-run to retry them", len(res.Errors))
+	}
+	return nil
+}
+
+// readImportFile reads the export from a file or stdin.
+func readImportFile(file string) ([]byte, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", file, err)
+	}
+	return data, nil
+}
+
+// dryRunImport parses the export locally and prints its conversations.
+func dryRunImport(w io.Writer, format importer.Format, data []byte, output string) error {
+	convs, err := importer.Parse(format, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("parse export: %w", err)
+	}
+	if output == outputJSON {
+		type conv struct {
+			SourceID string `json:"sourceId"`
+			Title    string `json:"title,omitempty"`
+			Messages int    `json:"messages"`
+		}
+		out := make([]conv, 0, len(convs))
+		for _, c := range convs {
+			out = append(out, conv{SourceID: c.SourceID, Title: c.Title, Messages: len(c.Messages)})
+		}
+		return writeJSON(w, out)
+	}
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "SOURCE ID\tMESSAGES\tTITLE")
+	for _, c := range convs {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\n", c.SourceID, len(c.Messages), c.Title)
+	}
+	return tw.Flush()
+}
+
+// postImport uploads the export gzip-compressed and returns session-api's
+// import summary.
+func postImport(ctx context.Context, cf *clientFlags, query url.Values, data []byte) (*importer.Result, error) {
+	var body bytes.Buffer
+	gz := gzip.NewWriter(&body)
+	if _, err := gz.Write(data); err != nil {
+		return nil, fmt.Errorf("compress export: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("compress export: %w", err)
+	}
+
+	endpoint := strings.TrimSuffix(cf.server, "/") + importPath + "?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if err != nil {
+		return nil, fmt.Errorf("import: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "gzip")
+	if err := cf.authorizer()(ctx, req); err != nil {
+		return nil, fmt.Errorf("import: %w", err)
+	}
+
+	resp, err := (&http.Client{Timeout: cf.timeout}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("import: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("import: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("import", resp.StatusCode, respBody)
+	}
+	var res importer.Result
+	if err := json.Unmarshal(respBody, &res); err != nil {
+		return nil, fmt.Errorf("import: decode response: %w", err)
+	}
+	return &res, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testExport = `{"id":"c1","messages":[{"role":"user","content":"hi"},{"role":"assistant","content":"hello"}]}
+{"id":"c2","messages":[{"role":"user","content":"bye"}]}
+`
+
+func writeExport(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "export.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(testExport), 0o600))
+	return path
+}
+
+func TestImport_UploadsExport(t *testing.T) {
+	var gotQuery map[string][]string
+	var gotBody, gotAuth string
+	api := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST /api/v1/import", r.Method+" "+r.URL.Path)
+		require.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+		gotQuery = r.URL.Query()
+		gotAuth = r.Header.Get("Authorization")
+		gz, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		b, _ := io.ReadAll(gz)
+		gotBody = string(b)
+		_ = json.NewEncoder(w).Encode(map[string]any{"imported": 2, "skipped": 0, "messages": 3})
+	})
+
+	code, stdout, stderr := runCLI(t, api, "import", "-f", writeExport(t), "--format", "jsonl",
+		"-n", "acme-ns", "--agent", "support", "--tag", "migrated", "--token", "secret")
+	require.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, testExport, gotBody)
+	assert.Equal(t, "Bearer secret", gotAuth)
+	assert.Equal(t, []string{"jsonl"}, gotQuery["format"])
+	assert.Equal(t, []string{"acme-ns"}, gotQuery["namespace"])
+	assert.Equal(t, []string{"support"}, gotQuery["agent"])
+	assert.Equal(t, []string{"migrated"}, gotQuery["tag"])
+	assert.Contains(t, stdout, "imported 2 sessions (3 messages)")
+}
+
+func TestImport_PartialFailure(t *testing.T) {
+	api := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"imported": 1, "errors": []string{"c2: db down"}})
+	})
+	code, _, stderr := runCLI(t, api, "import", "-f", writeExport(t), "--format", "jsonl",
+		"-n", "acme-ns", "--agent", "support")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "failed: c2: db down")
+	assert.Contains(t, stderr, "1 conversations failed to import")
+}
+
+func TestImport_DryRun(t *testing.T) {
+	api := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("dry run must not call session-api")
+	})
+	code, stdout, stderr := runCLI(t, api, "import", "-f", writeExport(t), "--format", "jsonl", "--dry-run")
+	require.Equal(t, exitOK, code, stderr)
+	assert.Contains(t, stdout, "SOURCE ID")
+	assert.Regexp(t, `c1\s+2`, stdout)
+	assert.Regexp(t, `c2\s+1`, stdout)
+}
+
+func TestImport_Usage(t *testing.T) {
+	path := writeExport(t)
+	for _, args := range [][]string{
+		{"import", "--format", "jsonl", "-n", "ns", "--agent", "a"},
+		{"import", "-f", path, "--format", "csv", "-n", "ns", "--agent", "a"},
+		{"import", "-f", path, "--format", "jsonl", "--agent", "a"},
+	} {
+		code, _, _ := runCLI(t, http.NotFoundHandler(), args...)
+		assert.Equal(t, exitUsage, code, args)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// stdin is read by commands that take input from the terminal or "-f -".
+// A var so tests can supply input.
+var stdin io.Reader = os.Stdin
+
+// kubeFlags select the cluster for commands that talk to the Kubernetes API.
+type kubeFlags struct {
+	kubeconfig string
+	context    string
+	namespace  string
+}
+
+// register adds the kubeconfig flags to fs. nsHelp describes what
+// --namespace applies to.
+func (f *kubeFlags) register(fs *flag.FlagSet, nsHelp string) {
+	fs.StringVar(&f.kubeconfig, "kubeconfig", "", "path to the kubeconfig file (default: KUBECONFIG or ~/.kube/config)")
+	fs.StringVar(&f.context, "context", "", "kubeconfig context to use")
+	fs.StringVar(&f.namespace, "namespace", "", nsHelp)
+	fs.StringVar(&f.namespace, "n", "", "shorthand for --namespace")
+}
+
+// loadKubeConfig returns the REST config and namespace of the selected
+// kubeconfig context.
+func loadKubeConfig(kubeconfig, kubeContext string) (*rest.Config, string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfig
+	loader := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules,
+		&clientcmd.ConfigOverrides{CurrentContext: kubeContext})
+
+	cfg, err := loader.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("load kubeconfig: %w", err)
+	}
+	namespace, _, err := loader.Namespace()
+	if err != nil {
+		return nil, "", fmt.Errorf("resolve namespace: %w", err)
+	}
+	return cfg, namespace, nil
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command omnia is a terminal client for Omnia. It talks to a workspace's
+// session-api, usually through a port-forward, so a conversation can be
+// followed during an incident without the dashboard:
+//
+//	kubectl port-forward -n <workspace-ns> svc/<session-api> 8080:8080
+//	omnia sessions list --agent support --status active
+//	omnia sessions tail --follow <session-id>
+//
+// The same connection imports history exported from other tools, so it can
+// be browsed and evaluated like any other session:
+//
+//	omnia import -f runs.jsonl --format langsmith -n acme --agent support
+//
+// It also submits ArenaJobs and gates on their results, which makes an
+// evaluation a single CI step:
+//
+//	kubectl port-forward -n omnia-system svc/omnia-arena-controller 8082:8082
+//	omnia arena run -f job.yaml --watch
+//
+// and chats with an agent, port-forwarding to its facade:
+//
+//	omnia chat support -n acme
+//
+// and packages a PromptPack or arena project for OCI sources:
+//
+//	omnia pack push ./support-pack oci://ghcr.io/acme/support-pack:1.0.0 --sign
+//
+// and checks manifests and pack content before they reach a cluster:
+//
+//	omnia validate -f deploy/
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/altairalabs/omnia/internal/serviceauth"
+	"github.com/altairalabs/omnia/pkg/sessionapi"
+)
+
+// Exit codes.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+	// exitJobFailed reports an ArenaJob that finished without succeeding.
+	exitJobFailed = 3
+)
+
+const (
+	defaultServer      = "http://localhost:8080"
+	defaultHTTPTimeout = 30 * time.Second
+
+	// serverEnv and tokenEnv supply --server and --token when the flags are unset.
+	serverEnv = "OMNIA_SESSION_API_URL"
+	tokenEnv  = "OMNIA_SESSION_API_TOKEN"
+)
+
+// Output formats.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// errUsage marks an error caused by how the command was invoked.
+var errUsage = errors.New("usage error")
+
+const usage = `Usage: omnia <command> [flags]
+
+Commands:
+  sessions list             List sessions
+  sessions get <id>         Show a session and its messages
+  sessions tail <id>        Print a session's latest messages (--follow to stream)
+  import -f <file>          Import LangSmith, OpenAI or JSONL conversations as sessions
+  arena run -f <file>       Submit an ArenaJob (--watch to follow it and gate on the result)
+  chat <agent>              Chat with an agent in the terminal (-n <namespace>)
+  pack build <dir>          Validate a PromptPack or arena project and write it as a .tar.gz
+  pack push <dir> <ref>     Validate and push a pack as an OCI artifact (--sign to sign it with cosign)
+  validate -f <path>        Check manifests and pack content offline (CRD schemas, cross-references, license)
+
+Run "omnia <group> <command> -h" for the flags of a command.
+`
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command in args and returns the process exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		_, _ = fmt.Fprint(stderr, usage)
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+
+	var err error
+	switch args[0] {
+	case "sessions":
+		err = runSessions(ctx, args[1:], stdout, stderr)
+	case "import":
+		err = runImport(ctx, args[1:], stdout, stderr)
+	case "arena":
+		err = runArena(ctx, args[1:], stdout, stderr)
+	case "chat":
+		err = runChat(ctx, args[1:], stdout, stderr)
+	case "pack":
+		err = runPack(ctx, args[1:], stdout, stderr)
+	case "validate":
+		err = runValidate(ctx, args[1:], stdout, stderr)
+	default:
+		err = fmt.Errorf("%w: unknown command %q", errUsage, args[0])
+	}
+
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errUsage):
+		_, _ = fmt.Fprintf(stderr, "error: %v\n\n%s", err, usage)
+		return exitUsage
+	case errors.Is(err, errJobFailed):
+		_, _ = fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitJobFailed
+	default:
+		_, _ = fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+}
+
+// clientFlags are the connection and output flags shared by every command.
+type clientFlags struct {
+	server    string
+	token     string
+	tokenFile string
+	output    string
+	timeout   time.Duration
+}
+
+// register adds the shared flags to fs.
+func (f *clientFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.server, "server", "", "session-api base URL; env "+serverEnv+" (default "+defaultServer+")")
+	fs.StringVar(&f.token, "token", "", "bearer token for session-api auth; env "+tokenEnv)
+	fs.StringVar(&f.tokenFile, "token-file", "",
+		"file holding a ServiceAccount token, re-read as it rotates (overrides --token)")
+	fs.StringVar(&f.output, "output", outputTable, "output format: table or json")
+	fs.StringVar(&f.output, "o", outputTable, "shorthand for --output")
+	fs.DurationVar(&f.timeout, "timeout", defaultHTTPTimeout, "timeout for each session-api request")
+}
+
+// validate applies env fallbacks and checks the output format.
+func (f *clientFlags) validate() error {
+	if f.server == "" {
+		f.server = os.Getenv(serverEnv)
+	}
+	if f.server == "" {
+		f.server = defaultServer
+	}
+	if f.token == "" {
+		f.token = os.Getenv(tokenEnv)
+	}
+	if f.output != outputTable && f.output != outputJSON {
+		return fmt.Errorf("%w: --output must be %q or %q, got %q", errUsage, outputTable, outputJSON, f.output)
+	}
+	return nil
+}
+
+// newClient builds a session-api client that authenticates with the
+// configured token. session-api validates it with a TokenReview, so any
+// ServiceAccount token on its allowlist works (e.g. from kubectl create token).
+func (f *clientFlags) newClient() (*sessionapi.ClientWithResponses, error) {
+	client, err := sessionapi.NewClientWithResponses(strings.TrimSuffix(f.server, "/"),
+		sessionapi.WithHTTPClient(&http.Client{Timeout: f.timeout}),
+		sessionapi.WithRequestEditorFn(f.authorizer()))
+	if err != nil {
+		return nil, fmt.Errorf("create session-api client: %w", err)
+	}
+	return client, nil
+}
+
+// authorizer returns a request editor that adds the configured token.
+func (f *clientFlags) authorizer() sessionapi.RequestEditorFn {
+	if f.tokenFile != "" {
+		tokenSource := serviceauth.NewTokenSource(f.tokenFile, 0)
+		return func(_ context.Context, req *http.Request) error {
+			return tokenSource.Authorize(req)
+		}
+	}
+	return func(_ context.Context, req *http.Request) error {
+		if f.token != "" {
+			req.Header.Set("Authorization", "Bearer "+f.token)
+		}
+		return nil
+	}
+}
+
+// parseArgs parses flags that may appear before or after positional
+// arguments ("get <id> -o json") and returns the positional arguments.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// statusError describes a non-200 session-api response, with a hint for the
+// auth failures an engineer is most likely to hit.
+func statusError(op string, status int, body []byte) error {
+	msg := strings.TrimSpace(string(body))
+	switch status {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%s: unauthorized (status 401); pass --token or --token-file", op)
+	case http.StatusForbidden:
+		return fmt.Errorf("%s: forbidden (status 403); the token's ServiceAccount is not allowed by session-api", op)
+	case http.StatusNotFound:
+		return fmt.Errorf("%s: not found", op)
+	}
+	if msg == "" {
+		return fmt.Errorf("%s: status %d", op, status)
+	}
+	return fmt.Errorf("%s: status %d: %s", op, status, msg)
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSessionID = "3f2b8c1e-4d5a-4e6f-9a7b-1c2d3e4f5a6b"
+
+type fakeMessage struct {
+	ID          string `json:"id"`
+	Role        string `json:"role"`
+	Content     string `json:"content"`
+	SequenceNum int32  `json:"sequenceNum"`
+}
+
+// fakeSessionAPI serves a single session whose messages and status tests can
+// change while a command is running.
+type fakeSessionAPI struct {
+	mu       sync.Mutex
+	status   string
+	messages []fakeMessage
+	requests []*http.Request
+	// onMessages runs after each messages read, e.g. to end the session.
+	onMessages func(f *fakeSessionAPI)
+}
+
+func (f *fakeSessionAPI) addMessages(n int) {
+	for i := 0; i < n; i++ {
+		seq := int32(len(f.messages) + 1)
+		f.messages = append(f.messages, fakeMessage{
+			ID: "m" + strconv.Itoa(int(seq)), Role: "user", Content: "message " + strconv.Itoa(int(seq)), SequenceNum: seq,
+		})
+	}
+}
+
+func (f *fakeSessionAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r)
+	w.Header().Set("Content-Type", "application/json")
+
+	session := map[string]any{
+		"id": testSessionID, "agentName": "support", "namespace": "acme-ns",
+		"status": f.status, "messageCount": len(f.messages),
+		"createdAt": "2026-10-17T09:00:00Z",
+	}
+	switch r.URL.Path {
+	case "/api/v1/sessions":
+		_ = json.NewEncoder(w).Encode(map[string]any{"sessions": []any{session}, "total": 3, "hasMore": true})
+	case "/api/v1/sessions/" + testSessionID:
+		_ = json.NewEncoder(w).Encode(map[string]any{"session": session})
+	case "/api/v1/sessions/" + testSessionID + "/messages":
+		after, _ := strconv.Atoi(r.URL.Query().Get("after"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var page []fakeMessage
+		for _, m := range f.messages {
+			if int(m.SequenceNum) > after && len(page) < limit {
+				page = append(page, m)
+			}
+		}
+		hasMore := after+len(page) < len(f.messages)
+		_ = json.NewEncoder(w).Encode(map[string]any{"messages": page, "hasMore": hasMore})
+		if f.onMessages != nil {
+			f.onMessages(f)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"session not found"}`))
+	}
+}
+
+func runCLI(t *testing.T, api http.Handler, args ...string) (int, string, string) {
+	t.Helper()
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), append(args, "--server", srv.URL), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitUsage, run(context.Background(), nil, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "sessions tail")
+
+	stderr.Reset()
+	assert.Equal(t, exitUsage, run(context.Background(), []string{"agents"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), `unknown command "agents"`)
+
+	assert.Equal(t, exitUsage, run(context.Background(), []string{"sessions", "get"}, &stdout, &stderr))
+	assert.Equal(t, exitUsage, run(context.Background(), []string{"sessions", "get", "not-a-uuid"}, &stdout, &stderr))
+	assert.Equal(t, exitUsage, run(context.Background(), []string{"sessions", "list", "-o", "yaml"}, &stdout, &stderr))
+}
+
+func TestParseArgs_FlagsAfterPositional(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	output := fs.String("o", "table", "")
+	follow := fs.Bool("f", false, "")
+
+	positional, err := parseArgs(fs, []string{"-f", testSessionID, "-o", "json"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{testSessionID}, positional)
+	assert.Equal(t, "json", *output)
+	assert.True(t, *follow)
+}
+
+func TestSessionsList(t *testing.T) {
+	api := &fakeSessionAPI{status: "active"}
+	api.addMessages(4)
+
+	code, out, _ := runCLI(t, api, "sessions", "list", "--agent", "support", "--status", "active",
+		"--since", "1h", "--token", "sa-token")
+	require.Equal(t, exitOK, code)
+
+	assert.Contains(t, out, "ID")
+	assert.Contains(t, out, testSessionID)
+	assert.Contains(t, out, "acme-ns")
+	assert.Contains(t, out, "showing 1 of 3; use --offset 1 for more")
+
+	req := api.requests[0]
+	assert.Equal(t, "Bearer sa-token", req.Header.Get("Authorization"))
+	assert.Equal(t, "support", req.URL.Query().Get("agent"))
+	assert.Equal(t, "active", req.URL.Query().Get("status"))
+	assert.NotEmpty(t, req.URL.Query().Get("from"))
+}
+
+func TestSessionsList_JSON(t *testing.T) {
+	code, out, _ := runCLI(t, &fakeSessionAPI{status: "active"}, "sessions", "list", "-o", "json")
+	require.Equal(t, exitOK, code)
+
+	var resp struct {
+		Sessions []map[string]any `json:"sessions"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &resp))
+	require.Len(t, resp.Sessions, 1)
+	assert.Equal(t, testSessionID, resp.Sessions[0]["id"])
+}
+
+func TestSessionsGet(t *testing.T) {
+	api := &fakeSessionAPI{status: "completed"}
+	api.addMessages(messagePageSize + 2)
+
+	code, out, _ := runCLI(t, api, "sessions", "get", testSessionID)
+	require.Equal(t, exitOK, code)
+	assert.Contains(t, out, "Agent:")
+	assert.Contains(t, out, "completed")
+	assert.Contains(t, out, "#1 user: message 1\n")
+	assert.Contains(t, out, "#502 user: message 502\n", "history is read past the first page")
+}
+
+func TestSessionsGet_NotFound(t *testing.T) {
+	code, _, errOut := runCLI(t, &fakeSessionAPI{}, "sessions", "get", "00000000-0000-0000-0000-000000000001")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, errOut, "not found")
+}
+
+func TestSessionsTail_LastLines(t *testing.T) {
+	api := &fakeSessionAPI{status: "active"}
+	api.addMessages(10)
+
+	code, out, _ := runCLI(t, api, "sessions", "tail", testSessionID, "--lines", "3")
+	require.Equal(t, exitOK, code)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "#8 user: message 8")
+	assert.Contains(t, lines[2], "#10 user: message 10")
+}
+
+func TestSessionsTail_FollowUntilSessionEnds(t *testing.T) {
+	api := &fakeSessionAPI{status: "active"}
+	api.addMessages(2)
+	reads := 0
+	api.onMessages = func(f *fakeSessionAPI) {
+		reads++
+		if reads == 1 {
+			// A reply arrives and the session ends while the CLI is waiting
+			f.addMessages(1)
+			f.status = "completed"
+		}
+	}
+
+	done := make(chan struct{})
+	var code int
+	var out, errOut string
+	go func() {
+		defer close(done)
+		code, out, errOut = runCLI(t, api, "sessions", "tail", "-f", "--interval", "10ms", "-o", "json", testSessionID)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tail --follow did not exit after the session ended")
+	}
+
+	require.Equal(t, exitOK, code)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3, "each message is one JSON line")
+	var last fakeMessage
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &last))
+	assert.Equal(t, int32(3), last.SequenceNum)
+	assert.Contains(t, errOut, "is completed")
+}
+
+func TestStatusError(t *testing.T) {
+	assert.ErrorContains(t, statusError("list sessions", http.StatusUnauthorized, nil), "--token")
+	assert.ErrorContains(t, statusError("list sessions", http.StatusForbidden, nil), "not allowed")
+	assert.EqualError(t, statusError("list sessions", http.StatusInternalServerError, []byte("boom\n")),
+		"list sessions: status 500: boom")
+}
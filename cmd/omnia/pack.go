@@ -0,0 +1,205 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// ociScheme prefixes artifact URLs in ArenaSource and PromptPackSource specs.
+const ociScheme = "oci://"
+
+// OCI annotations recorded on pushed packs.
+const (
+	annotationTitle   = "org.opencontainers.image.title"
+	annotationVersion = "org.opencontainers.image.version"
+)
+
+// cosignCommand is the cosign binary used by --sign. A var so tests can
+// substitute a stub.
+var cosignCommand = "cosign"
+
+// runPack dispatches the pack subcommands.
+func runPack(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: pack requires a subcommand", errUsage)
+	}
+	switch args[0] {
+	case "build":
+		return runPackBuild(args[1:], stderr)
+	case "push":
+		return runPackPush(ctx, args[1:], stdout, stderr)
+	default:
+		return fmt.Errorf("%w: unknown pack subcommand %q", errUsage, args[0])
+	}
+}
+
+// runPackBuild implements "omnia pack build". It validates the pack
+// directory and writes it as a reproducible .tar.gz.
+func runPackBuild(args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("pack build", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	out := fs.String("out", "", "tarball to write (default <name>-<version>.tar.gz in the current directory)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("%w: pack build takes exactly one directory", errUsage)
+	}
+
+	pack, err := loadPack(positional[0])
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		*out = pack.name + ".tar.gz"
+		if pack.version != "" {
+			*out = pack.name + "-" + pack.version + ".tar.gz"
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pack.writeTarball(&buf, *out); err != nil {
+		return fmt.Errorf("build %s: %w", pack.dir, err)
+	}
+	if err := os.WriteFile(*out, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(stderr, "%s pack %s validated; wrote %s (sha256:%x)\n",
+		strings.Join(pack.kinds, "+"), pack.name, *out, sha256.Sum256(buf.Bytes()))
+	return nil
+}
+
+// runPackPush implements "omnia pack push". It validates the pack directory,
+// pushes it as a single-layer OCI artifact that ArenaSource and
+// PromptPackSource OCI sources extract as-is, optionally signs the pushed
+// digest with cosign, and prints the digest reference.
+func runPackPush(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("pack push", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	insecure := fs.Bool("insecure", false, "allow a registry without TLS")
+	sign := fs.Bool("sign", false, "sign the pushed digest with cosign (keyless unless --key is set)")
+	key := fs.String("key", "", "cosign private key or KMS URI for --sign")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("%w: pack push takes a directory and a reference, e.g. oci://ghcr.io/acme/support:1.0.0",
+			errUsage)
+	}
+	if *key != "" && !*sign {
+		return fmt.Errorf("%w: --key requires --sign", errUsage)
+	}
+
+	var opts []name.Option
+	if *insecure {
+		opts = append(opts, name.Insecure)
+	}
+	ref, err := name.ParseReference(strings.TrimPrefix(positional[1], ociScheme), opts...)
+	if err != nil {
+		return fmt.Errorf("%w: invalid reference %q: %v", errUsage, positional[1], err)
+	}
+
+	pack, err := loadPack(positional[0])
+	if err != nil {
+		return err
+	}
+	img, err := pack.image()
+	if err != nil {
+		return fmt.Errorf("build %s: %w", pack.dir, err)
+	}
+	if err := remote.Write(ref, img, remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain)); err != nil {
+		return fmt.Errorf("push %s: %w", ref, err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return err
+	}
+	pushed := ref.Context().Digest(digest.String())
+	_, _ = fmt.Fprintf(stderr, "%s pack %s pushed to %s\n", strings.Join(pack.kinds, "+"), pack.name, ref)
+
+	if *sign {
+		if err := cosignSign(ctx, pushed.String(), *key, stderr); err != nil {
+			return err
+		}
+	}
+	_, _ = fmt.Fprintln(stdout, ociScheme+pushed.String())
+	return nil
+}
+
+// image wraps the pack's tarball as the single layer of an OCI image, so
+// that extracting the image's filesystem yields the pack directory.
+func (b *packBundle) image() (v1.Image, error) {
+	var buf bytes.Buffer
+	if err := b.writeTarball(&buf); err != nil {
+		return nil, err
+	}
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	}, tarball.WithMediaType(types.OCILayer))
+	if err != nil {
+		return nil, err
+	}
+	img := mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), types.OCIConfigJSON)
+	if img, err = mutate.AppendLayers(img, layer); err != nil {
+		return nil, err
+	}
+	annotations := map[string]string{annotationTitle: b.name}
+	if b.version != "" {
+		annotations[annotationVersion] = b.version
+	}
+	return mutate.Annotations(img, annotations).(v1.Image), nil
+}
+
+// cosignSign signs ref with the cosign CLI, passing its prompts and output
+// through to the terminal.
+func cosignSign(ctx context.Context, ref, key string, stderr io.Writer) error {
+	args := []string{"sign", "--yes"}
+	if key != "" {
+		args = append(args, "--key", key)
+	}
+	cmd := exec.CommandContext(ctx, cosignCommand, append(args, ref)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, stderr, stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return fmt.Errorf("sign %s: cosign not found on PATH; install it or omit --sign", ref)
+		}
+		return fmt.Errorf("sign %s: %w", ref, err)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/altairalabs/omnia/internal/schema"
+)
+
+// Files that mark a directory as a pack. PromptPackSources read packJSONFile
+// from the artifact root; ArenaSources read arenaConfigFile.
+const (
+	packJSONFile    = "pack.json"
+	arenaConfigFile = "config.arena.yaml"
+)
+
+// packBundle is a validated pack directory.
+type packBundle struct {
+	dir     string
+	name    string
+	version string
+	kinds   []string // "promptpack", "arena"
+}
+
+// loadPack validates the pack in dir the way its consumers will: pack.json
+// against the PromptPack schema with a required version, as the PromptPack
+// and PromptPackSource controllers do, and config.arena.yaml as an Arena
+// config whose file references resolve inside the directory.
+func loadPack(dir string) (*packBundle, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	b := &packBundle{dir: dir, name: filepath.Base(abs)}
+
+	data, err := os.ReadFile(filepath.Join(dir, packJSONFile))
+	switch {
+	case err == nil:
+		if err := b.validatePromptPack(data); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+
+	data, err = os.ReadFile(filepath.Join(dir, arenaConfigFile))
+	switch {
+	case err == nil:
+		if err := b.validateArena(data); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+
+	if len(b.kinds) == 0 {
+		return nil, fmt.Errorf("%s has neither %s nor %s", dir, packJSONFile, arenaConfigFile)
+	}
+	return b, nil
+}
+
+func (b *packBundle) validatePromptPack(data []byte) error {
+	meta, err := checkPackJSON(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", packJSONFile, err)
+	}
+	if meta.ID != "" {
+		b.name = meta.ID
+	}
+	b.version = meta.Version
+	b.kinds = append(b.kinds, "promptpack")
+	return nil
+}
+
+func (b *packBundle) validateArena(data []byte) error {
+	var cfg struct {
+		Kind string         `json:"kind"`
+		Spec map[string]any `json:"spec"`
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("%s: %w", arenaConfigFile, err)
+	}
+	if cfg.Kind != "Arena" {
+		return fmt.Errorf("%s: kind is %q, want Arena", arenaConfigFile, cfg.Kind)
+	}
+	if err := checkFileRefs(b.dir, cfg.Spec); err != nil {
+		return fmt.Errorf("%s: %w", arenaConfigFile, err)
+	}
+	b.kinds = append(b.kinds, "arena")
+	return nil
+}
+
+// packMeta is the identity of a PromptPack.
+type packMeta struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// checkPackJSON validates pack.json against the PromptPack schema and
+// requires the version PromptPackSources materialize by.
+func checkPackJSON(data []byte) (*packMeta, error) {
+	if err := schema.NewSchemaValidatorWithOptions(logr.Discard(), nil, 0).Validate(data); err != nil {
+		return nil, err
+	}
+	var meta packMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	if meta.Version == "" {
+		return nil, errors.New("version is required")
+	}
+	return &meta, nil
+}
+
+// checkFileRefs checks that every file an arena config spec references
+// exists inside dir, the config's directory.
+func checkFileRefs(dir string, spec map[string]any) error {
+	var missing []string
+	for _, ref := range fileRefs(spec) {
+		if !filepath.IsLocal(ref) {
+			return fmt.Errorf("file %q is outside the pack directory", ref)
+		}
+		if _, err := os.Stat(filepath.Join(dir, ref)); err != nil {
+			missing = append(missing, ref)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("referenced files not found: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// fileRefs returns the "file" values anywhere under v — prompt configs,
+// providers, scenarios, tools, personas and evals all reference their
+// definitions that way.
+func fileRefs(v any) []string {
+	var refs []string
+	switch t := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if s, ok := t[k].(string); ok && k == "file" && s != "" {
+				refs = append(refs, s)
+				continue
+			}
+			refs = append(refs, fileRefs(t[k])...)
+		}
+	case []any:
+		for _, item := range t {
+			refs = append(refs, fileRefs(item)...)
+		}
+	}
+	return refs
+}
+
+// writeTarball writes the pack directory as a gzipped tar. Entries are
+// sorted and carry no timestamps or ownership, so the same directory always
+// produces the same bytes — and, pushed, the same digest. VCS metadata and
+// paths in skip are left out.
+func (b *packBundle) writeTarball(w io.Writer, skip ...string) error {
+	skipped := make(map[string]bool, len(skip))
+	for _, p := range skip {
+		if abs, err := filepath.Abs(p); err == nil {
+			skipped[abs] = true
+		}
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	err := filepath.WalkDir(b.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(b.dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if abs, err := filepath.Abs(path); err == nil && skipped[abs] {
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		return addTarEntry(tw, path, filepath.ToSlash(rel), d)
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func addTarEntry(tw *tar.Writer, path, name string, d fs.DirEntry) error {
+	info, err := d.Info()
+	if err != nil {
+		return err
+	}
+	hdr := &tar.Header{Name: name, Mode: int64(info.Mode().Perm()), Format: tar.FormatPAX}
+	switch {
+	case d.IsDir():
+		hdr.Typeflag, hdr.Name = tar.TypeDir, name+"/"
+	case info.Mode()&fs.ModeSymlink != 0:
+		target, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		hdr.Typeflag, hdr.Linkname = tar.TypeSymlink, target
+	case info.Mode().IsRegular():
+		hdr.Typeflag, hdr.Size = tar.TypeReg, info.Size()
+	default:
+		return fmt.Errorf("%s: unsupported file type %s", name, info.Mode().Type())
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if hdr.Typeflag != tar.TypeReg {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	_, err = io.Copy(tw, f)
+	return err
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/sourcesync"
+)
+
+const testPackJSON = `{
+  "id": "support-pack",
+  "name": "Support Pack",
+  "version": "1.2.0",
+  "template_engine": {"version": "v1", "syntax": "{{variable}}"},
+  "prompts": {
+    "default": {
+      "id": "default",
+      "name": "Default",
+      "version": "1.0.0",
+      "system_template": "You are a helpful support agent."
+    }
+  }
+}`
+
+const testArenaConfig = `apiVersion: promptkit.altairalabs.ai/v1alpha1
+kind: Arena
+metadata:
+  name: support-evals
+spec:
+  prompt_configs:
+    - id: support
+      file: prompts/support.yaml
+  scenarios:
+    - file: scenarios/refund.scenario.yaml
+`
+
+// writePackDir creates a pack directory holding the given files.
+func writePackDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "pack")
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func tarNames(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return names
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+	}
+}
+
+func TestPackBuild_PromptPack(t *testing.T) {
+	dir := writePackDir(t, map[string]string{
+		"pack.json":          testPackJSON,
+		"prompts/default.md": "# Default",
+		".git/HEAD":          "ref: refs/heads/main",
+	})
+	out := filepath.Join(t.TempDir(), "pack.tar.gz")
+
+	code, _, errOut := runArgs("pack", "build", dir, "--out", out)
+	require.Equal(t, exitOK, code, errOut)
+	assert.Contains(t, errOut, "promptpack pack support-pack validated")
+	assert.Equal(t, []string{"pack.json", "prompts/", "prompts/default.md"}, tarNames(t, out))
+
+	first, err := os.ReadFile(out)
+	require.NoError(t, err)
+	code, _, errOut = runArgs("pack", "build", dir, "--out", out)
+	require.Equal(t, exitOK, code, errOut)
+	second, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "builds are reproducible")
+}
+
+func TestPackBuild_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"empty directory", map[string]string{"README.md": "hi"}, "has neither pack.json nor config.arena.yaml"},
+		{"schema violation", map[string]string{"pack.json": `{"id": "x", "version": "1.0.0"}`}, "invalid pack.json"},
+		{"missing version", map[string]string{
+			"pack.json": strings.Replace(testPackJSON, `"version": "1.2.0",`, "", 1),
+		}, "pack.json"},
+		{"arena missing file", map[string]string{
+			"config.arena.yaml":    testArenaConfig,
+			"prompts/support.yaml": "kind: PromptConfig",
+		}, "referenced files not found: scenarios/refund.scenario.yaml"},
+		{"arena wrong kind", map[string]string{"config.arena.yaml": "kind: Scenario\n"}, "want Arena"},
+		{"arena escaping file", map[string]string{
+			"config.arena.yaml": "kind: Arena\nspec:\n  tools:\n    - file: ../secrets.yaml\n",
+		}, "outside the pack directory"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writePackDir(t, tc.files)
+			code, _, errOut := runArgs("pack", "build", dir, "--out", filepath.Join(t.TempDir(), "out.tar.gz"))
+			assert.Equal(t, exitError, code)
+			assert.Contains(t, errOut, tc.want)
+		})
+	}
+}
+
+func TestPackPush_ConsumableByOCISource(t *testing.T) {
+	srv := httptest.NewServer(registry.New())
+	t.Cleanup(srv.Close)
+	host := strings.TrimPrefix(srv.URL, "http://")
+	dir := writePackDir(t, map[string]string{
+		"config.arena.yaml":              testArenaConfig,
+		"prompts/support.yaml":           "kind: PromptConfig",
+		"scenarios/refund.scenario.yaml": "kind: Scenario",
+	})
+
+	// A stub cosign records how it was invoked
+	stubDir := t.TempDir()
+	argsFile := filepath.Join(stubDir, "args")
+	stub := filepath.Join(stubDir, "cosign")
+	require.NoError(t, os.WriteFile(stub, []byte("#!/bin/sh\necho \"$@\" > "+argsFile+"\n"), 0o755))
+	orig := cosignCommand
+	cosignCommand = stub
+	t.Cleanup(func() { cosignCommand = orig })
+
+	code, out, errOut := runArgs("pack", "push", dir, "oci://"+host+"/acme/support-evals:v1",
+		"--insecure", "--sign", "--key", "cosign.key")
+	require.Equal(t, exitOK, code, errOut)
+	pushed := strings.TrimSpace(out)
+	assert.True(t, strings.HasPrefix(pushed, "oci://"+host+"/acme/support-evals@sha256:"), pushed)
+
+	signed, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	assert.Equal(t, "sign --yes --key cosign.key "+strings.TrimPrefix(pushed, "oci://"),
+		strings.TrimSpace(string(signed)))
+
+	fetcher := sourcesync.NewOCIFetcher(sourcesync.OCIFetcherConfig{
+		URL:      "oci://" + host + "/acme/support-evals:v1",
+		Insecure: true,
+		Options:  sourcesync.Options{WorkDir: t.TempDir()},
+	})
+	artifact, err := fetcher.Fetch(context.Background(), "v1")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(artifact.Path, "config.arena.yaml"))
+	assert.FileExists(t, filepath.Join(artifact.Path, "scenarios", "refund.scenario.yaml"))
+}
+
+func TestPackPush_UsageErrors(t *testing.T) {
+	dir := writePackDir(t, map[string]string{"pack.json": testPackJSON})
+
+	code, _, _ := runArgs("pack", "push", dir)
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runArgs("pack", "push", dir, "oci://ghcr.io/acme/pack:1.0.0", "--key", "cosign.key")
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runArgs("pack", "push", dir, "oci://Not A Ref")
+	assert.Equal(t, exitUsage, code)
+}
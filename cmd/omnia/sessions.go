@@ -0,0 +1,427 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/altairalabs/omnia/pkg/intconv"
+	"github.com/altairalabs/omnia/pkg/sessionapi"
+)
+
+const (
+	// messagePageSize is the page size for message reads (session-api's max).
+	messagePageSize = 500
+
+	defaultListLimit    = 20
+	defaultTailLines    = 20
+	defaultPollInterval = 2 * time.Second
+
+	// timeLayout formats timestamps in table output.
+	timeLayout = "2006-01-02 15:04:05"
+)
+
+// runSessions dispatches the sessions subcommands.
+func runSessions(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: sessions requires a subcommand", errUsage)
+	}
+	switch args[0] {
+	case "list":
+		return runSessionsList(ctx, args[1:], stdout, stderr)
+	case "get":
+		return runSessionsGet(ctx, args[1:], stdout, stderr)
+	case "tail":
+		return runSessionsTail(ctx, args[1:], stdout, stderr)
+	default:
+		return fmt.Errorf("%w: unknown sessions subcommand %q", errUsage, args[0])
+	}
+}
+
+// runSessionsList implements "omnia sessions list".
+func runSessionsList(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("sessions list", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var cf clientFlags
+	cf.register(fs)
+	workspace := fs.String("workspace", "", "filter by workspace namespace")
+	agent := fs.String("agent", "", "filter by agent name")
+	status := fs.String("status", "", "filter by status: active, completed, error or expired")
+	since := fs.Duration("since", 0, "only sessions created within this duration (e.g. 1h)")
+	limit := fs.Int("limit", defaultListLimit, "maximum sessions to return (max 100)")
+	offset := fs.Int("offset", 0, "number of sessions to skip")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("%w: sessions list takes no arguments", errUsage)
+	}
+	if err := cf.validate(); err != nil {
+		return err
+	}
+
+	params := &sessionapi.ListSessionsParams{Limit: limit, Offset: offset}
+	if *workspace != "" {
+		params.Workspace = workspace
+	}
+	if *agent != "" {
+		params.Agent = agent
+	}
+	if *status != "" {
+		s := sessionapi.SessionStatus(*status)
+		params.Status = &s
+	}
+	if *since > 0 {
+		from := time.Now().Add(-*since)
+		params.From = &from
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	resp, err := client.ListSessionsWithResponse(ctx, params)
+	if err != nil {
+		return fmt.Errorf("list sessions: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return statusError("list sessions", resp.StatusCode(), resp.Body)
+	}
+
+	if cf.output == outputJSON {
+		return writeJSON(stdout, resp.JSON200)
+	}
+	sessions := deref(resp.JSON200.Sessions)
+	printSessionTable(stdout, sessions)
+	if deref(resp.JSON200.HasMore) {
+		_, _ = fmt.Fprintf(stdout, "\nshowing %d of %d; use --offset %d for more\n",
+			len(sessions), deref(resp.JSON200.Total), *offset+len(sessions))
+	}
+	return nil
+}
+
+// runSessionsGet implements "omnia sessions get".
+func runSessionsGet(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("sessions get", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var cf clientFlags
+	cf.register(fs)
+	noMessages := fs.Bool("no-messages", false, "show only session metadata")
+	id, err := parseSessionArg(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := cf.validate(); err != nil {
+		return err
+	}
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+
+	sess, err := getSession(ctx, client, id)
+	if err != nil {
+		return err
+	}
+	var msgs []sessionapi.Message
+	if !*noMessages {
+		// GetSession only embeds the first page, so read the history in full
+		for after := int32(0); ; {
+			page, hasMore, err := getMessages(ctx, client, id, after)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, page...)
+			if !hasMore || len(page) == 0 {
+				break
+			}
+			after = deref(page[len(page)-1].SequenceNum)
+		}
+	}
+
+	if cf.output == outputJSON {
+		out := sessionapi.SessionResponse{Session: sess}
+		if !*noMessages {
+			out.Messages = &msgs
+		}
+		return writeJSON(stdout, out)
+	}
+	printSessionDetails(stdout, sess)
+	if !*noMessages {
+		_, _ = fmt.Fprintln(stdout)
+		for _, m := range msgs {
+			printMessage(stdout, m)
+		}
+	}
+	return nil
+}
+
+// runSessionsTail implements "omnia sessions tail". Without --follow it
+// prints the latest messages and exits; with it, it polls for new messages
+// until the session ends or the command is interrupted.
+func runSessionsTail(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("sessions tail", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var cf clientFlags
+	cf.register(fs)
+	var follow bool
+	fs.BoolVar(&follow, "follow", false, "keep printing new messages until the session ends")
+	fs.BoolVar(&follow, "f", false, "shorthand for --follow")
+	lines := fs.Int("lines", defaultTailLines, "number of recent messages to print first (0 for the whole history)")
+	interval := fs.Duration("interval", defaultPollInterval, "poll interval with --follow")
+	id, err := parseSessionArg(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := cf.validate(); err != nil {
+		return err
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("%w: --interval must be positive", errUsage)
+	}
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+
+	sess, err := getSession(ctx, client, id)
+	if err != nil {
+		return err
+	}
+	// Sequence numbers count up from 1, so the message count locates the tail
+	after := int32(0)
+	if count := deref(sess.MessageCount); *lines > 0 && int(count) > *lines {
+		after = count - intconv.ClampInt32(int64(*lines))
+	}
+
+	emit := func(m sessionapi.Message) error {
+		if cf.output == outputJSON {
+			// One object per line so the stream can be piped to jq
+			b, err := json.Marshal(m)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(stdout, string(b))
+			return err
+		}
+		printMessage(stdout, m)
+		return nil
+	}
+
+	for {
+		page, hasMore, err := getMessages(ctx, client, id, after)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for _, m := range page {
+			if err := emit(m); err != nil {
+				return err
+			}
+			after = deref(m.SequenceNum)
+		}
+		if hasMore {
+			continue
+		}
+		if !follow || !isLive(sess) {
+			if follow {
+				_, _ = fmt.Fprintf(stderr, "session %s is %s\n", id, deref(sess.Status))
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(*interval):
+		}
+		// Refresh the status before the next read, so messages written as the
+		// session ends are still printed before exiting.
+		if sess, err = getSession(ctx, client, id); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// parseSessionArg parses fs and returns the single session ID argument.
+func parseSessionArg(fs *flag.FlagSet, args []string) (sessionapi.SessionID, error) {
+	var id sessionapi.SessionID
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return id, err
+	}
+	if len(positional) != 1 {
+		return id, fmt.Errorf("%w: %s requires exactly one session ID", errUsage, fs.Name())
+	}
+	if err := id.UnmarshalText([]byte(positional[0])); err != nil {
+		return id, fmt.Errorf("%w: invalid session ID %q", errUsage, positional[0])
+	}
+	return id, nil
+}
+
+// getSession reads a session's metadata.
+func getSession(
+	ctx context.Context, client *sessionapi.ClientWithResponses, id sessionapi.SessionID,
+) (*sessionapi.Session, error) {
+	resp, err := client.GetSessionWithResponse(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get session %s: %w", id, err)
+	}
+	if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil || resp.JSON200.Session == nil {
+		return nil, statusError("get session "+id.String(), resp.StatusCode(), resp.Body)
+	}
+	return resp.JSON200.Session, nil
+}
+
+// getMessages reads one page of messages with sequence numbers after after.
+func getMessages(
+	ctx context.Context, client *sessionapi.ClientWithResponses, id sessionapi.SessionID, after int32,
+) ([]sessionapi.Message, bool, error) {
+	limit := messagePageSize
+	params := &sessionapi.GetMessagesParams{Limit: &limit}
+	if after > 0 {
+		params.After = &after
+	}
+	resp, err := client.GetMessagesWithResponse(ctx, id, params)
+	if err != nil {
+		return nil, false, fmt.Errorf("get messages for %s: %w", id, err)
+	}
+	if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return nil, false, statusError("get messages for "+id.String(), resp.StatusCode(), resp.Body)
+	}
+	return deref(resp.JSON200.Messages), deref(resp.JSON200.HasMore), nil
+}
+
+// isLive reports whether a session can still receive messages: it is active,
+// idle or escalated rather than ended.
+func isLive(s *sessionapi.Session) bool {
+	switch deref(s.Status) {
+	case sessionapi.SessionStatusActive, sessionapi.SessionStatusIdle, sessionapi.SessionStatusEscalated:
+		return true
+	}
+	return false
+}
+
+// printSessionTable writes one row per session.
+func printSessionTable(w io.Writer, sessions []sessionapi.Session) {
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tAGENT\tWORKSPACE\tSTATUS\tMESSAGES\tCREATED")
+	for _, s := range sessions {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\n",
+			idString(s.Id), deref(s.AgentName), workspaceOf(s), deref(s.Status),
+			deref(s.MessageCount), formatTime(s.CreatedAt))
+	}
+	_ = tw.Flush()
+}
+
+// printSessionDetails writes a session's metadata as aligned key/value lines.
+func printSessionDetails(w io.Writer, s *sessionapi.Session) {
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	row := func(k, v string) {
+		if v != "" {
+			_, _ = fmt.Fprintf(tw, "%s:\t%s\n", k, v)
+		}
+	}
+	row("ID", idString(s.Id))
+	row("Agent", deref(s.AgentName))
+	row("Workspace", workspaceOf(*s))
+	row("Status", string(deref(s.Status)))
+	row("Created", formatTime(s.CreatedAt))
+	row("Updated", formatTime(s.UpdatedAt))
+	row("Ended", formatTime(s.EndedAt))
+	if pack := deref(s.PromptPackName); pack != "" {
+		row("PromptPack", strings.TrimSuffix(pack+"@"+deref(s.PromptPackVersion), "@"))
+	}
+	row("Messages", fmt.Sprint(deref(s.MessageCount)))
+	row("Tool calls", fmt.Sprint(deref(s.ToolCallCount)))
+	row("Tokens", fmt.Sprintf("%d in / %d out", deref(s.TotalInputTokens), deref(s.TotalOutputTokens)))
+	if s.EstimatedCostUSD != nil {
+		row("Cost", fmt.Sprintf("$%.4f", *s.EstimatedCostUSD))
+	}
+	if s.Tags != nil {
+		row("Tags", strings.Join(*s.Tags, ", "))
+	}
+	_ = tw.Flush()
+}
+
+// printMessage writes a message as "time #seq role: content", indenting
+// continuation lines of multi-line content under the first.
+func printMessage(w io.Writer, m sessionapi.Message) {
+	ts := ""
+	if m.Timestamp != nil {
+		ts = m.Timestamp.Local().Format("15:04:05")
+	}
+	content := strings.ReplaceAll(strings.TrimRight(deref(m.Content), "\n"), "\n", "\n    ")
+	if content == "" && deref(m.HasMedia) {
+		content = "[media: " + strings.Join(deref(m.MediaTypes), ", ") + "]"
+	}
+	_, _ = fmt.Fprintf(w, "%s #%d %s: %s\n", ts, deref(m.SequenceNum), deref(m.Role), content)
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// workspaceOf returns a session's workspace, falling back to its namespace.
+func workspaceOf(s sessionapi.Session) string {
+	if ws := deref(s.WorkspaceName); ws != "" {
+		return ws
+	}
+	return deref(s.Namespace)
+}
+
+// idString formats an optional session ID.
+func idString(id *sessionapi.SessionID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// formatTime formats an optional timestamp in local time.
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(timeLayout)
+}
+
+// deref returns the value p points to, or the zero value when p is nil.
+func deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/license"
+)
+
+// API groups of the resources validate understands.
+const (
+	omniaGroup     = "omnia.altairalabs.ai"
+	promptKitGroup = "promptkit.altairalabs.ai"
+)
+
+// Checks a finding can come from.
+const (
+	checkParse          = "parse"
+	checkSchema         = "schema"
+	checkPromptPack     = "promptpack"
+	checkCrossReference = "cross-reference"
+	checkLicense        = "license"
+)
+
+// License tiers accepted by --license-tier.
+const (
+	licenseTierOpenCore   = string(license.TierOpenCore)
+	licenseTierEnterprise = string(license.TierEnterprise)
+)
+
+// finding is one problem validate found.
+type finding struct {
+	File    string `json:"file"`
+	Object  string `json:"object,omitempty"` // Kind/name
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
+
+// validateReport is the -o json output of validate.
+type validateReport struct {
+	Files     int       `json:"files"`
+	Resources int       `json:"resources"`
+	Findings  []finding `json:"findings"`
+}
+
+// validator checks manifests and pack content.
+type validator struct {
+	crds    *crdSchemas
+	license *license.License
+	report  validateReport
+}
+
+// runValidate implements "omnia validate". It checks Omnia resources against
+// their CRD schemas and the license tier, pack.json files against the
+// PromptPack schema, and Arena projects for broken cross-references, so
+// that misconfigurations fail CI rather than surfacing in status conditions.
+func runValidate(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var paths []string
+	fs.Func("f", "file or directory to validate (repeatable; directories are walked)", func(v string) error {
+		paths = append(paths, v)
+		return nil
+	})
+	tier := fs.String("license-tier", licenseTierOpenCore,
+		"license tier to check feasibility against: "+licenseTierOpenCore+" or "+licenseTierEnterprise)
+	licenseFile := fs.String("license-file", "",
+		"license JSON to check against, as served by the arena controller's /api/v1/license")
+	output := fs.String("output", outputTable, "output format: table or json")
+	fs.StringVar(output, "o", outputTable, "shorthand for --output")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(positional) > 0:
+		return fmt.Errorf("%w: validate takes no arguments; pass files with -f", errUsage)
+	case len(paths) == 0:
+		return fmt.Errorf("%w: -f is required", errUsage)
+	case *output != outputTable && *output != outputJSON:
+		return fmt.Errorf("%w: --output must be %q or %q, got %q", errUsage, outputTable, outputJSON, *output)
+	}
+
+	lic, err := selectLicense(*tier, *licenseFile)
+	if err != nil {
+		return err
+	}
+	crds, err := embeddedCRDSchemas()
+	if err != nil {
+		return err
+	}
+	v := &validator{crds: crds, license: lic, report: validateReport{Findings: []finding{}}}
+	for _, path := range paths {
+		if err := v.validatePath(ctx, path); err != nil {
+			return err
+		}
+	}
+
+	r := v.report
+	if *output == outputJSON {
+		if err := writeJSON(stdout, r); err != nil {
+			return err
+		}
+	} else {
+		for _, f := range r.Findings {
+			where := f.File
+			if f.Object != "" {
+				where += " " + f.Object
+			}
+			_, _ = fmt.Fprintf(stdout, "%s: [%s] %s\n", where, f.Check, f.Message)
+		}
+	}
+	if len(r.Findings) > 0 {
+		return fmt.Errorf("%d problem(s) in %d file(s) checked", len(r.Findings), r.Files)
+	}
+	_, _ = fmt.Fprintf(stderr, "%d file(s), %d resource(s) valid\n", r.Files, r.Resources)
+	return nil
+}
+
+// selectLicense returns the license to check feasibility against.
+func selectLicense(tier, file string) (*license.License, error) {
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var lic license.License
+		if err := json.Unmarshal(data, &lic); err != nil {
+			return nil, fmt.Errorf("parse license %s: %w", file, err)
+		}
+		return &lic, nil
+	}
+	switch tier {
+	case licenseTierOpenCore:
+		return license.OpenCoreLicense(), nil
+	case licenseTierEnterprise:
+		// Every feature, no limits
+		return license.DevLicense(), nil
+	default:
+		return nil, fmt.Errorf("%w: --license-tier must be %q or %q, got %q",
+			errUsage, licenseTierOpenCore, licenseTierEnterprise, tier)
+	}
+}
+
+// validatePath validates a file, or every YAML and pack.json file under a
+// directory.
+func (v *validator) validatePath(ctx context.Context, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return v.validateFile(ctx, path)
+	}
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != path && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch ext := filepath.Ext(p); {
+		case ext == ".yaml", ext == ".yml", d.Name() == packJSONFile:
+			return v.validateFile(ctx, p)
+		}
+		return nil
+	})
+}
+
+func (v *validator) validateFile(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	v.report.Files++
+	if filepath.Base(path) == packJSONFile {
+		if _, err := checkPackJSON(data); err != nil {
+			v.add(path, "", checkPromptPack, err.Error())
+		}
+		return nil
+	}
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			v.add(path, "", checkParse, err.Error())
+			return nil
+		}
+		if err := v.validateDocument(ctx, path, doc); err != nil {
+			return err
+		}
+	}
+}
+
+func (v *validator) validateDocument(ctx context.Context, path string, doc []byte) error {
+	jsonDoc, err := yaml.YAMLToJSON(doc)
+	if err != nil {
+		v.add(path, "", checkParse, err.Error())
+		return nil
+	}
+	var obj map[string]any
+	if err := utiljson.Unmarshal(jsonDoc, &obj); err != nil || obj == nil {
+		return nil // empty document or not an object
+	}
+	u := &unstructured.Unstructured{Object: obj}
+	gvk := u.GroupVersionKind()
+	object := gvk.Kind + "/" + u.GetName()
+	if u.GetName() == "" {
+		object = gvk.Kind + "/" + u.GetGenerateName()
+	}
+
+	switch {
+	case gvk.Group == omniaGroup:
+		v.report.Resources++
+		return v.validateResource(ctx, path, object, gvk, u)
+	case gvk.Group == "" && gvk.Kind == "ConfigMap":
+		if packJSON, ok, _ := unstructured.NestedString(obj, "data", packJSONFile); ok {
+			v.report.Resources++
+			if _, err := checkPackJSON([]byte(packJSON)); err != nil {
+				v.add(path, object, checkPromptPack, err.Error())
+			}
+		}
+	case gvk.Group == promptKitGroup && gvk.Kind == "Arena":
+		v.report.Resources++
+		problems, scenarios := checkArenaProject(path, doc)
+		for _, p := range problems {
+			v.add(path, object, checkCrossReference, p)
+		}
+		if err := v.license.CheckScenarioCount(len(scenarios)); err != nil {
+			v.add(path, object, checkLicense, err.Error())
+		}
+	}
+	return nil
+}
+
+// validateResource checks an Omnia custom resource against its CRD and the
+// license gates its admission webhook enforces.
+func (v *validator) validateResource(
+	ctx context.Context, path, object string, gvk schema.GroupVersionKind, u *unstructured.Unstructured,
+) error {
+	if !v.crds.has(gvk) {
+		v.add(path, object, checkSchema, fmt.Sprintf("no CRD defines %s in %s", gvk.Kind, gvk.GroupVersion()))
+		return nil
+	}
+	if u.GetName() == "" && u.GetGenerateName() == "" {
+		v.add(path, object, checkSchema, "metadata.name or metadata.generateName is required")
+	}
+	problems, err := v.crds.validate(ctx, gvk, u.Object)
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		v.add(path, object, checkSchema, p)
+	}
+	if err := v.checkLicense(gvk.Kind, u.Object); err != nil {
+		v.add(path, object, checkLicense, err.Error())
+	}
+	return nil
+}
+
+// checkLicense applies the license gates of the EE admission webhooks,
+// reading the same fields they do.
+func (v *validator) checkLicense(kind string, obj map[string]any) error {
+	switch kind {
+	case "ArenaJob":
+		jobType, _, _ := unstructured.NestedString(obj, "spec", "type")
+		if jobType == "" {
+			jobType = "evaluation"
+		}
+		replicas := 1
+		if n, ok, _ := unstructured.NestedInt64(obj, "spec", "workers", "replicas"); ok && n > 0 {
+			replicas = int(n)
+		}
+		cron, _, _ := unstructured.NestedString(obj, "spec", "schedule", "cron")
+		return v.license.CheckArenaJob(jobType, replicas, cron != "")
+	case "ArenaSource", "ArenaTemplateSource", "PromptPackSource":
+		sourceType, _, _ := unstructured.NestedString(obj, "spec", "type")
+		return v.license.CheckSourceType(sourceType)
+	case "AgentRuntime":
+		facades, _, _ := unstructured.NestedSlice(obj, "spec", "facades")
+		for _, f := range facades {
+			if m, ok := f.(map[string]any); ok && m["type"] == string(omniav1alpha1.FacadeTypeCustom) {
+				return v.license.CheckCustomFacade()
+			}
+		}
+	}
+	return nil
+}
+
+func (v *validator) add(file, object, check, message string) {
+	v.report.Findings = append(v.report.Findings, finding{File: file, Object: object, Check: check, Message: message})
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/altairalabs/omnia/ee/pkg/arena/partitioner"
+)
+
+// arenaConfig holds the parts of an Arena config that other project files
+// refer to.
+type arenaConfig struct {
+	Spec struct {
+		PromptConfigs []struct {
+			File string `json:"file"`
+		} `json:"prompt_configs"`
+		Scenarios []struct {
+			File string `json:"file"`
+		} `json:"scenarios"`
+		Judges []struct {
+			Name string `json:"name"`
+		} `json:"judges"`
+		SelfPlay *struct {
+			Personas []struct {
+				File string `json:"file"`
+			} `json:"personas"`
+			Roles []struct {
+				ID string `json:"id"`
+			} `json:"roles"`
+		} `json:"self_play"`
+	} `json:"spec"`
+}
+
+// scenarioDoc holds the parts of a Scenario that refer to other project files.
+type scenarioDoc struct {
+	Spec struct {
+		TaskType string `json:"task_type"`
+		Turns    []struct {
+			Role       string           `json:"role"`
+			Persona    string           `json:"persona"`
+			Assertions []assertionParam `json:"assertions"`
+		} `json:"turns"`
+		ConversationAssertions []assertionParam `json:"conversation_assertions"`
+	} `json:"spec"`
+}
+
+type assertionParam struct {
+	Type   string `json:"type"`
+	Params struct {
+		Judge string `json:"judge"`
+	} `json:"params"`
+}
+
+// Turn roles PromptArena plays without a self-play role.
+var builtinTurnRoles = map[string]bool{"user": true, "assistant": true, "system": true}
+
+// arenaProject is an Arena config and the definitions it pulls in.
+type arenaProject struct {
+	dir       string
+	taskTypes map[string]bool
+	personas  map[string]bool
+	roles     map[string]bool
+	judges    map[string]bool
+	scenarios []partitioner.Scenario
+}
+
+// checkArenaProject checks the cross-references PromptArena only resolves
+// when a job runs: file references, each scenario's task_type against the
+// prompt configs, self-play roles and personas, and llm_judge judges — the
+// same judge check the editor's language server makes. It returns the
+// problems found and the project's scenarios.
+func checkArenaProject(configPath string, data []byte) ([]string, []partitioner.Scenario) {
+	var spec struct {
+		Spec map[string]any `json:"spec"`
+	}
+	var cfg arenaConfig
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return []string{err.Error()}, nil
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return []string{err.Error()}, nil
+	}
+	p := &arenaProject{dir: filepath.Dir(configPath)}
+	var problems []string
+	if err := checkFileRefs(p.dir, spec.Spec); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	p.taskTypes = make(map[string]bool)
+	for _, pc := range cfg.Spec.PromptConfigs {
+		if taskType := p.readString(pc.File, "spec", "task_type"); taskType != "" {
+			p.taskTypes[taskType] = true
+		}
+	}
+	p.judges = make(map[string]bool)
+	for _, j := range cfg.Spec.Judges {
+		p.judges[j.Name] = true
+	}
+	p.personas, p.roles = make(map[string]bool), make(map[string]bool)
+	if sp := cfg.Spec.SelfPlay; sp != nil {
+		for _, persona := range sp.Personas {
+			if id := p.readString(persona.File, "spec", "id"); id != "" {
+				p.personas[id] = true
+			}
+		}
+		for _, role := range sp.Roles {
+			p.roles[role.ID] = true
+		}
+	}
+
+	scenarios, err := partitioner.ListScenariosFromConfig(configPath)
+	if err != nil {
+		return append(problems, err.Error()), nil
+	}
+	seen := make(map[string]string, len(scenarios))
+	for _, s := range scenarios {
+		if first, ok := seen[s.ID]; ok {
+			problems = append(problems, fmt.Sprintf("scenario ID %q is used by both %s and %s", s.ID, first, s.Path))
+			continue
+		}
+		seen[s.ID] = s.Path
+		problems = append(problems, p.checkScenario(s)...)
+	}
+	return problems, scenarios
+}
+
+// checkScenario checks one scenario's references against the project.
+func (p *arenaProject) checkScenario(s partitioner.Scenario) []string {
+	data, err := os.ReadFile(filepath.Join(p.dir, s.Path))
+	if err != nil {
+		return nil // reported as a missing file
+	}
+	var doc scenarioDoc
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return []string{fmt.Sprintf("%s: %v", s.Path, err)}
+	}
+
+	var problems []string
+	report := func(format string, args ...any) {
+		problems = append(problems, s.Path+": "+fmt.Sprintf(format, args...))
+	}
+	if taskType := doc.Spec.TaskType; len(p.taskTypes) > 0 && taskType != "" && !p.taskTypes[taskType] {
+		report("task_type %q matches no prompt config (have %s)", taskType, setList(p.taskTypes))
+	}
+	assertions := doc.Spec.ConversationAssertions
+	for i, turn := range doc.Spec.Turns {
+		if turn.Persona != "" && !p.personas[turn.Persona] {
+			report("turn %d: persona %q is not declared in self_play.personas", i+1, turn.Persona)
+		}
+		if turn.Role != "" && !builtinTurnRoles[turn.Role] && !p.roles[turn.Role] {
+			report("turn %d: role %q is not a self_play role", i+1, turn.Role)
+		}
+		assertions = append(assertions, turn.Assertions...)
+	}
+	for _, a := range assertions {
+		judge := a.Params.Judge
+		if strings.HasPrefix(a.Type, "llm_judge") && judge != "" && len(p.judges) > 0 && !p.judges[judge] {
+			report("unknown judge %q; arena config defines: %s", judge, setList(p.judges))
+		}
+	}
+	return problems
+}
+
+// readString returns the string at path in a project YAML file, or "" when
+// the file or field is missing.
+func (p *arenaProject) readString(file string, path ...string) string {
+	if file == "" || !filepath.IsLocal(file) {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(p.dir, file))
+	if err != nil {
+		return ""
+	}
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return ""
+	}
+	for _, key := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return ""
+		}
+		v = m[key]
+	}
+	s, _ := v.(string)
+	return s
+}
+
+// setList renders a set as a sorted, comma-separated list.
+func setList(set map[string]bool) string {
+	items := make([]string, 0, len(set))
+	for item := range set {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ", ")
+}
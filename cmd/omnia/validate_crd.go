@@ -0,0 +1,150 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"sigs.k8s.io/yaml"
+
+	crdbases "github.com/altairalabs/omnia/config/crd/bases"
+)
+
+// crdSchemas validates custom resources against the embedded CRDs the way
+// the API server does on create: defaulting, unknown-field pruning, OpenAPI
+// schema validation and x-kubernetes-validations rules.
+type crdSchemas struct {
+	props    map[schema.GroupVersionKind]*apiextensions.JSONSchemaProps
+	compiled map[schema.GroupVersionKind]*compiledSchema
+}
+
+type compiledSchema struct {
+	structural *structuralschema.Structural
+	validator  validation.SchemaValidator
+	rules      *cel.Validator // nil when the schema has no CEL rules
+}
+
+// loadCRDSchemas reads every CRD manifest in crds.
+func loadCRDSchemas(crds fs.FS) (*crdSchemas, error) {
+	files, err := fs.Glob(crds, "*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	s := &crdSchemas{
+		props:    make(map[schema.GroupVersionKind]*apiextensions.JSONSchemaProps),
+		compiled: make(map[schema.GroupVersionKind]*compiledSchema),
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(crds, file)
+		if err != nil {
+			return nil, err
+		}
+		var crd apiextensionsv1.CustomResourceDefinition
+		if err := yaml.Unmarshal(data, &crd); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", file, err)
+		}
+		for _, v := range crd.Spec.Versions {
+			if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			props := &apiextensions.JSONSchemaProps{}
+			err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(
+				v.Schema.OpenAPIV3Schema, props, nil)
+			if err != nil {
+				return nil, fmt.Errorf("convert %s %s schema: %w", crd.Name, v.Name, err)
+			}
+			gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Spec.Names.Kind}
+			s.props[gvk] = props
+		}
+	}
+	return s, nil
+}
+
+// embeddedCRDSchemas loads the CRDs built into the binary.
+func embeddedCRDSchemas() (*crdSchemas, error) {
+	return loadCRDSchemas(crdbases.CRDs)
+}
+
+// has reports whether a CRD defines gvk.
+func (s *crdSchemas) has(gvk schema.GroupVersionKind) bool {
+	_, ok := s.props[gvk]
+	return ok
+}
+
+// compile builds the validators for gvk on first use; compiling CEL rules
+// for every CRD up front would slow down runs that touch one kind.
+func (s *crdSchemas) compile(gvk schema.GroupVersionKind) (*compiledSchema, error) {
+	if c, ok := s.compiled[gvk]; ok {
+		return c, nil
+	}
+	props := s.props[gvk]
+	structural, err := structuralschema.NewStructural(props)
+	if err != nil {
+		return nil, fmt.Errorf("%s schema is not structural: %w", gvk.Kind, err)
+	}
+	validator, _, err := validation.NewSchemaValidator(props)
+	if err != nil {
+		return nil, fmt.Errorf("%s schema: %w", gvk.Kind, err)
+	}
+	c := &compiledSchema{
+		structural: structural,
+		validator:  validator,
+		rules:      cel.NewValidator(structural, true, celconfig.PerCallLimit),
+	}
+	s.compiled[gvk] = c
+	return c, nil
+}
+
+// validate returns the problems the API server would reject obj for. obj is
+// defaulted in place.
+func (s *crdSchemas) validate(ctx context.Context, gvk schema.GroupVersionKind, obj map[string]any) ([]string, error) {
+	c, err := s.compile(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	pruned := runtime.DeepCopyJSON(obj)
+	for _, path := range pruning.PruneWithOptions(pruned, c.structural, true,
+		structuralschema.UnknownFieldPathOptions{TrackUnknownFieldPaths: true}) {
+		problems = append(problems, fmt.Sprintf("unknown field %q", path))
+	}
+
+	structuraldefaulting.Default(obj, c.structural)
+	for _, e := range validation.ValidateCustomResource(nil, obj, c.validator) {
+		problems = append(problems, e.Error())
+	}
+	if c.rules != nil {
+		errs, _ := c.rules.Validate(ctx, nil, c.structural, obj, nil, celconfig.RuntimeCELCostBudget)
+		for _, e := range errs {
+			problems = append(problems, e.Error())
+		}
+	}
+	return problems, nil
+}
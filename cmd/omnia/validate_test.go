@@ -0,0 +1,214 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPackSource = `apiVersion: omnia.altairalabs.ai/v1alpha1
+kind: PromptPackSource
+metadata:
+  name: support
+spec:
+  type: git
+  git:
+    url: https://github.com/acme/packs
+  packName: support
+  interval: 5m
+`
+
+const testScaledArenaJob = `apiVersion: omnia.altairalabs.ai/v1alpha1
+kind: ArenaJob
+metadata:
+  generateName: nightly-
+spec:
+  sourceRef:
+    name: support-evals
+  workers:
+    replicas: 4
+  retries: 2
+`
+
+func runValidateJSON(t *testing.T, args ...string) (int, validateReport) {
+	t.Helper()
+	code, out, errOut := runArgs(append([]string{"validate", "-o", "json"}, args...)...)
+	var report validateReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report), errOut)
+	return code, report
+}
+
+func findingsByCheck(report validateReport) map[string][]string {
+	byCheck := make(map[string][]string)
+	for _, f := range report.Findings {
+		byCheck[f.Check] = append(byCheck[f.Check], f.Message)
+	}
+	return byCheck
+}
+
+func TestValidate_ValidTree(t *testing.T) {
+	dir := writePackDir(t, map[string]string{
+		"deploy/source.yaml":         testPackSource + "---\n",
+		"packs/support/pack.json":    testPackJSON,
+		"evals/config.arena.yaml":    testArenaConfig,
+		"evals/prompts/support.yaml": "kind: PromptConfig\nspec:\n  task_type: support\n",
+		"evals/scenarios/refund.scenario.yaml": "kind: Scenario\nspec:\n  id: refund\n  task_type: support\n" +
+			"  turns:\n    - role: user\n      content: I want a refund\n",
+		".github/workflows/ci.yaml": "not: [valid",
+	})
+
+	code, out, errOut := runArgs("validate", "-f", dir)
+	require.Equal(t, exitOK, code, out+errOut)
+	assert.Contains(t, errOut, "5 file(s), 2 resource(s) valid")
+}
+
+func TestValidate_CRDSchemaAndLicense(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "job.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testScaledArenaJob), 0o600))
+
+	code, report := runValidateJSON(t, "-f", path)
+	assert.Equal(t, exitError, code)
+	assert.Equal(t, 1, report.Resources)
+	byCheck := findingsByCheck(report)
+	assert.Equal(t, []string{`unknown field "spec.retries"`}, byCheck[checkSchema])
+	require.Len(t, byCheck[checkLicense], 1)
+	assert.Contains(t, byCheck[checkLicense][0], "Requested 4 worker replicas exceeds the open-core limit of 1")
+	assert.Equal(t, "ArenaJob/nightly-", report.Findings[0].Object)
+
+	_, report = runValidateJSON(t, "-f", path, "--license-tier", "enterprise")
+	assert.Empty(t, findingsByCheck(report)[checkLicense])
+
+	licenseFile := filepath.Join(t.TempDir(), "license.json")
+	require.NoError(t, os.WriteFile(licenseFile,
+		[]byte(`{"tier":"enterprise","limits":{"maxWorkerReplicas":2}}`), 0o600))
+	_, report = runValidateJSON(t, "-f", path, "--license-file", licenseFile)
+	require.Len(t, findingsByCheck(report)[checkLicense], 1)
+	assert.Contains(t, findingsByCheck(report)[checkLicense][0], "limit of 2")
+}
+
+func TestValidate_CELRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "source.yaml")
+	content := testPackSource + "---\n" + `apiVersion: omnia.altairalabs.ai/v1alpha1
+kind: PromptPackSource
+metadata:
+  name: mismatched
+spec:
+  type: oci
+  git:
+    url: https://github.com/acme/packs
+  packName: support
+  interval: 5m
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	_, report := runValidateJSON(t, "-f", path, "--license-tier", "enterprise")
+	assert.Equal(t, 2, report.Resources)
+	require.Len(t, report.Findings, 1)
+	assert.Equal(t, "PromptPackSource/mismatched", report.Findings[0].Object)
+	assert.Contains(t, report.Findings[0].Message, "exactly the source block matching type must be set")
+}
+
+func TestValidate_ArenaCrossReferences(t *testing.T) {
+	dir := writePackDir(t, map[string]string{
+		"config.arena.yaml": `apiVersion: promptkit.altairalabs.ai/v1alpha1
+kind: Arena
+metadata:
+  name: support-evals
+spec:
+  prompt_configs:
+    - id: support
+      file: prompts/support.yaml
+  judges:
+    - name: strict
+  self_play:
+    enabled: true
+    personas:
+      - file: personas/angry.persona.yaml
+    roles:
+      - id: customer
+        provider: selfplay
+  scenarios:
+    - file: scenarios/refund.scenario.yaml
+    - file: scenarios/refund-copy.scenario.yaml
+    - file: scenarios/missing.scenario.yaml
+`,
+		"prompts/support.yaml":        "kind: PromptConfig\nspec:\n  task_type: support\n",
+		"personas/angry.persona.yaml": "kind: Persona\nspec:\n  id: angry-customer\n",
+		"scenarios/refund.scenario.yaml": `kind: Scenario
+spec:
+  id: refund
+  task_type: billing
+  turns:
+    - role: customer
+      persona: calm-customer
+    - role: shopper
+      assertions:
+        - type: llm_judge
+          params:
+            judge: lenient
+`,
+		"scenarios/refund-copy.scenario.yaml": "kind: Scenario\nspec:\n  id: refund\n  task_type: support\n",
+	})
+
+	code, report := runValidateJSON(t, "-f", dir)
+	assert.Equal(t, exitError, code)
+	assert.ElementsMatch(t, []string{
+		"referenced files not found: scenarios/missing.scenario.yaml",
+		`scenarios/refund.scenario.yaml: task_type "billing" matches no prompt config (have support)`,
+		`scenarios/refund.scenario.yaml: turn 1: persona "calm-customer" is not declared in self_play.personas`,
+		`scenarios/refund.scenario.yaml: turn 2: role "shopper" is not a self_play role`,
+		`scenarios/refund.scenario.yaml: unknown judge "lenient"; arena config defines: strict`,
+		`scenario ID "refund" is used by both scenarios/refund.scenario.yaml and ` +
+			`scenarios/refund-copy.scenario.yaml`,
+	}, findingsByCheck(report)[checkCrossReference])
+}
+
+func TestValidate_ScenarioLimit(t *testing.T) {
+	files := map[string]string{"prompts/support.yaml": "spec:\n  task_type: support\n"}
+	config := "apiVersion: promptkit.altairalabs.ai/v1alpha1\nkind: Arena\nspec:\n  scenarios:\n"
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"} {
+		config += "    - file: scenarios/" + id + ".scenario.yaml\n"
+		files["scenarios/"+id+".scenario.yaml"] = "spec:\n  id: " + id + "\n"
+	}
+	files["config.arena.yaml"] = config
+	dir := writePackDir(t, files)
+
+	_, report := runValidateJSON(t, "-f", dir)
+	require.Len(t, report.Findings, 1)
+	assert.Equal(t, checkLicense, report.Findings[0].Check)
+	assert.Contains(t, report.Findings[0].Message, "Scenario count 11 exceeds the open-core limit of 10")
+}
+
+func TestValidate_UnknownKindAndUsage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("apiVersion: omnia.altairalabs.ai/v1alpha1\nkind: Widget\n"+
+		"metadata:\n  name: w\n"), 0o600))
+	_, report := runValidateJSON(t, "-f", path)
+	require.Len(t, report.Findings, 1)
+	assert.Contains(t, report.Findings[0].Message, "no CRD defines Widget")
+
+	code, _, _ := runArgs("validate")
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runArgs("validate", "-f", path, "--license-tier", "gold")
+	assert.Equal(t, exitUsage, code)
+}
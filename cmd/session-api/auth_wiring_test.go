@@ -59,7 +59,7 @@ func TestBuildAPIMux_AuthMiddlewareWired(t *testing.T) {
 
 	reviewer := fakeReviewer{authenticated: true, username: allowedSubject}
 	handler, _, cleanup := buildAPIMux(
-		pool, registry, f, logr.Discard(),
+		pool, nil, registry, f, logr.Discard(),
 		reviewer, []string{allowedSubject}, nil,
 	)
 	defer cleanup()
@@ -118,7 +118,7 @@ func TestBuildAPIMux_NonAllowlistedSubjectIs403(t *testing.T) {
 
 	otherReviewer := fakeReviewer{authenticated: true, username: "system:serviceaccount:ns:other"}
 	handler, _, cleanup := buildAPIMux(
-		pool, registry, f, logr.Discard(),
+		pool, nil, registry, f, logr.Discard(),
 		otherReviewer, []string{allowedSubject}, nil,
 	)
 	defer cleanup()
@@ -147,7 +147,7 @@ func TestBuildAPIMux_NamespaceAllowedFacadeReachesHandler(t *testing.T) {
 	// Facade subject in the workspace namespace; not in allowedSubjects.
 	facade := fakeReviewer{authenticated: true, username: "system:serviceaccount:ws-ns:foo-facade"}
 	handler, _, cleanup := buildAPIMux(
-		pool, registry, f, logr.Discard(),
+		pool, nil, registry, f, logr.Discard(),
 		facade, []string{allowedSubject}, []string{"ws-ns"},
 	)
 	defer cleanup()
@@ -175,7 +175,7 @@ func TestBuildAPIMux_NilReviewer_NoAuth(t *testing.T) {
 	registry := providers.NewRegistry()
 	f := &flags{apiAddr: ":0", healthAddr: ":0", metricsAddr: ":0"}
 
-	handler, _, cleanup := buildAPIMux(pool, registry, f, logr.Discard(), nil, nil, nil)
+	handler, _, cleanup := buildAPIMux(pool, nil, registry, f, logr.Discard(), nil, nil, nil)
 	defer cleanup()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
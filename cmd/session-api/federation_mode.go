@@ -0,0 +1,120 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/altairalabs/omnia/internal/serviceauth"
+	"github.com/altairalabs/omnia/internal/session/api"
+	"github.com/altairalabs/omnia/internal/session/federation"
+	"github.com/altairalabs/omnia/pkg/apierror"
+	"github.com/altairalabs/omnia/pkg/readiness"
+)
+
+// federationClusterTimeout bounds a proxied call to a cluster's session-api.
+// Fan-out and lookup calls are bounded more tightly by the gateway itself.
+const federationClusterTimeout = 60 * time.Second
+
+// runFederation serves the session API as a federation gateway over the
+// clusters in f.federationConfig. The gateway holds no sessions of its own, so
+// it needs no Postgres, Redis or background tasks.
+func runFederation(ctx context.Context, f *flags, log logr.Logger) error {
+	cfg, err := federation.LoadConfig(f.federationConfig)
+	if err != nil {
+		return err
+	}
+	clusters, err := federation.NewRegistry(cfg)
+	if err != nil {
+		return err
+	}
+
+	reviewer, allowedSubjects, allowedNamespaces, err := buildServiceAuth(f, log)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: federationClusterTimeout}
+	apiHandler := buildFederationMux(clusters, client, log, reviewer, allowedSubjects, allowedNamespaces)
+
+	healthSrv := newHealthServer(f.healthAddr, federationReadinessChecker(clusters, client))
+	metricsSrv := newMetricsServer(f.metricsAddr)
+	apiSrv := &http.Server{
+		Addr:         f.apiAddr,
+		Handler:      apiHandler,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: federationClusterTimeout + 10*time.Second,
+		IdleTimeout:  120 * time.Second,
+	}
+	startHTTPServer(log, "health", f.healthAddr, healthSrv)
+	startHTTPServer(log, "metrics", f.metricsAddr, metricsSrv)
+	startHTTPServer(log, "federation gateway", f.apiAddr, apiSrv)
+
+	names := make([]string, 0, len(clusters.Clusters()))
+	for _, c := range clusters.Clusters() {
+		names = append(names, c.Name)
+	}
+	log.Info("session-api ready in federation mode",
+		"api", f.apiAddr,
+		"health", f.healthAddr,
+		"metrics", f.metricsAddr,
+		"clusters", strings.Join(names, ","),
+	)
+
+	<-ctx.Done()
+	log.Info("shutting down")
+	shutdownServers(log, apiSrv, healthSrv, metricsSrv, nil, nil)
+	return nil
+}
+
+// buildFederationMux assembles the federation gateway's API handler with the
+// same correlation-ID, auth, tracing and metrics chain as the session API.
+// Callers authenticate to the gateway; the gateway authenticates to each
+// cluster with that cluster's own token.
+func buildFederationMux(clusters *federation.Registry, client *http.Client, log logr.Logger, reviewer serviceauth.TokenReviewer, allowedSubjects, allowedNamespaces []string) http.Handler {
+	mux := http.NewServeMux()
+	federation.NewGateway(clusters, client, log).RegisterRoutes(mux)
+
+	httpMetrics := api.NewHTTPMetrics(nil)
+	httpMetrics.Initialize()
+	instrumented := api.MetricsMiddleware(httpMetrics, mux)
+	traced := otelhttp.NewHandler(api.TraceLogMiddleware(instrumented), "session-api-federation",
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			return r.URL.Path != "/healthz"
+		}),
+	)
+	authMW := serviceauth.RequireServiceAccount(reviewer, allowedSubjects, allowedNamespaces, "/healthz")
+	return apierror.Middleware(authMW(traced))
+}
+
+// federationReadinessChecker reports each cluster's session-api health. Every
+// cluster is optional: the gateway serves the clusters it can reach and
+// reports the rest in failedClusters.
+func federationReadinessChecker(clusters *federation.Registry, client *http.Client) *readiness.Checker {
+	checker := readiness.NewChecker()
+	for _, c := range clusters.Clusters() {
+		healthURL := strings.TrimSuffix(c.URL.String(), "/") + "/healthz"
+		checker.Add(readiness.Optional(fmt.Sprintf("cluster-%s", c.Name), readiness.HTTPGet(client, healthURL)))
+	}
+	return checker
+}
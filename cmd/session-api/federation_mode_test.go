@@ -0,0 +1,127 @@
+/*
+Copyright 2026 Altaira Labs.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/federation"
+	"github.com/altairalabs/omnia/pkg/readiness"
+)
+
+// fakeClusterAPI serves one cluster's session list and its sessions by ID.
+func fakeClusterAPI(t *testing.T, sessions ...*session.Session) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /api/v1/sessions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"sessions": sessions, "total": len(sessions), "hasMore": false,
+		})
+	})
+	mux.HandleFunc("GET /api/v1/sessions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		for _, s := range sessions {
+			if s.ID == r.PathValue("id") {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{"session": s})
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func writeFederationConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "federation.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+	return path
+}
+
+func TestBuildFederationMux_ProxiesToClusters(t *testing.T) {
+	freshPromRegistry(t)
+	now := time.Now().UTC()
+	east := fakeClusterAPI(t, &session.Session{ID: "s-east", AgentName: "a", CreatedAt: now})
+	west := fakeClusterAPI(t, &session.Session{ID: "s-west", AgentName: "a", CreatedAt: now.Add(-time.Hour)})
+
+	cfg, err := federation.LoadConfig(writeFederationConfig(t,
+		"clusters:\n  - name: us-east\n    url: "+east.URL+"\n    default: true\n  - name: eu-west\n    url: "+west.URL+"\n"))
+	require.NoError(t, err)
+	clusters, err := federation.NewRegistry(cfg)
+	require.NoError(t, err)
+
+	handler := buildFederationMux(clusters, east.Client(), logr.Discard(), nil, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/sessions", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var list federation.ListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.Len(t, list.Sessions, 2)
+	assert.Equal(t, "s-east", list.Sessions[0].ID)
+	assert.Equal(t, "us-east", list.Sessions[0].Cluster)
+	assert.Equal(t, "eu-west", list.Sessions[1].Cluster)
+	assert.Equal(t, int64(2), list.Total)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/sessions/s-west", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "s-west")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/sessions/missing", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestFederationReadinessChecker_ClusterDownIsDegraded(t *testing.T) {
+	up := fakeClusterAPI(t)
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(down.Close)
+
+	cfg := &federation.Config{Clusters: []federation.ClusterConfig{
+		{Name: "up", URL: up.URL},
+		{Name: "down", URL: down.URL},
+	}}
+	clusters, err := federation.NewRegistry(cfg)
+	require.NoError(t, err)
+
+	report := federationReadinessChecker(clusters, nil).Evaluate(context.Background())
+	assert.Equal(t, http.StatusOK, report.HTTPStatus())
+	assert.Equal(t, readiness.StatusDegraded, report.Status)
+	require.Len(t, report.Dependencies, 2)
+}
+
+func TestRun_FederationConfigInvalid(t *testing.T) {
+	f := &flags{federationConfig: writeFederationConfig(t, "clusters: []\n")}
+	err := runFederation(context.Background(), f, logr.Discard())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no clusters configured")
+}
@@ -38,7 +38,6 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	goredis "github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	corev1 "k8s.io/api/core/v1"
@@ -57,6 +56,7 @@ import (
 	"github.com/altairalabs/omnia/ee/pkg/redaction"
 	"github.com/altairalabs/omnia/internal/serviceauth"
 	"github.com/altairalabs/omnia/internal/session/api"
+	"github.com/altairalabs/omnia/internal/session/importer"
 	"github.com/altairalabs/omnia/internal/session/otlp"
 	sessionpg "github.com/altairalabs/omnia/internal/session/postgres"
 	"github.com/altairalabs/omnia/internal/session/providers"
@@ -64,7 +64,11 @@ import (
 	pgprovider "github.com/altairalabs/omnia/internal/session/providers/postgres"
 	"github.com/altairalabs/omnia/internal/session/providers/redis"
 	"github.com/altairalabs/omnia/internal/tracing"
+	"github.com/altairalabs/omnia/pkg/apierror"
 	"github.com/altairalabs/omnia/pkg/logging"
+	pkgmetrics "github.com/altairalabs/omnia/pkg/metrics"
+	"github.com/altairalabs/omnia/pkg/profiling"
+	"github.com/altairalabs/omnia/pkg/readiness"
 	"github.com/altairalabs/omnia/pkg/servicediscovery"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -99,6 +103,20 @@ type flags struct {
 	tracingInsecure bool
 	workspace       string
 	serviceGroup    string
+	replicaMode     string
+
+	// postgresReadConn, when set, points at a read replica of the warm
+	// store. Stale-tolerant list, search and analytics reads go there (see
+	// api.StaleTolerantRoutes); everything else stays on postgresConn.
+	postgresReadConn string
+
+	// messageCompression stores large tool outputs zstd-compressed with a
+	// per-namespace dictionary (MESSAGE_COMPRESSION_MIN_BYTES sets the size).
+	messageCompression bool
+
+	// federationConfig, when set, runs session-api as a federation gateway
+	// over the clusters it lists instead of serving a warm store.
+	federationConfig string
 
 	// ServiceAccount auth (opt-in). When authEnabled is true, the JSON API
 	// requires a Kubernetes ServiceAccount bearer token whose TokenReview
@@ -116,6 +134,10 @@ func parseFlags() *flags {
 	flag.StringVar(&f.healthAddr, "health-addr", ":8081", "Health probe listen address")
 	flag.StringVar(&f.metricsAddr, "metrics-addr", ":9090", "Metrics server listen address")
 	flag.StringVar(&f.postgresConn, "postgres-conn", "", "Postgres connection string")
+	flag.StringVar(&f.postgresReadConn, "postgres-read-conn", "",
+		"Postgres read replica connection string (optional); list, search and analytics reads are served from it")
+	flag.BoolVar(&f.messageCompression, "message-compression", false,
+		"Store large tool outputs zstd-compressed with per-namespace dictionaries, and compress existing ones in the background")
 	flag.StringVar(&f.redisURL, "redis-url", "", "Redis URL (redis:// or rediss://); env REDIS_URL fallback")
 	flag.StringVar(&f.coldBackend, "cold-backend", "", "Cold archive backend (s3, gcs, azure)")
 	flag.StringVar(&f.coldBucket, "cold-bucket", "", "Cold archive bucket name")
@@ -127,6 +149,12 @@ func parseFlags() *flags {
 	flag.StringVar(&f.otlpHTTPAddr, "otlp-http-addr", ":4318", "OTLP HTTP listen address")
 	flag.StringVar(&f.workspace, "workspace", "", "Workspace name (K8s CRD resolution mode)")
 	flag.StringVar(&f.serviceGroup, "service-group", "", "Service group name within workspace")
+	flag.StringVar(&f.replicaMode, "replica-mode", replicaModeSingle,
+		"Replica mode: "+replicaModeSingle+" (runs background tasks unconditionally; one replica only) or "+
+			replicaModeHA+" (background tasks run on the replica elected via a Postgres advisory lock)")
+	flag.StringVar(&f.federationConfig, "federation-config", "",
+		"Path to a federation config listing per-cluster session-apis; when set, serve as a "+
+			"federation gateway over them instead of a warm store")
 	flag.BoolVar(&f.authEnabled, "auth-enabled", false,
 		"Require Kubernetes ServiceAccount bearer-token auth on the JSON API (opt-in)")
 	flag.StringVar(&f.authAllowedSubjects, "auth-allowed-subjects", "",
@@ -148,6 +176,7 @@ func parseFlags() *flags {
 // applyEnvFallbacks applies environment variable overrides to flag defaults.
 func (f *flags) applyEnvFallbacks() {
 	envFallback(&f.postgresConn, "", "POSTGRES_CONN")
+	envFallback(&f.postgresReadConn, "", "POSTGRES_READ_CONN")
 	envFallback(&f.redisURL, "", "REDIS_URL")
 	envFallback(&f.coldBackend, "", "COLD_BACKEND")
 	envFallback(&f.coldBucket, "", "COLD_BUCKET")
@@ -158,9 +187,12 @@ func (f *flags) applyEnvFallbacks() {
 	envFallback(&f.metricsAddr, ":9090", "METRICS_ADDR")
 	envFallback(&f.otlpGRPCAddr, ":4317", "OTLP_GRPC_ADDR")
 	envFallback(&f.otlpHTTPAddr, ":4318", "OTLP_HTTP_ADDR")
+	envFallback(&f.replicaMode, replicaModeSingle, "REPLICA_MODE")
+	envFallback(&f.federationConfig, "", "FEDERATION_CONFIG")
 
 	envBoolFallback(&f.enterprise, "ENTERPRISE_ENABLED")
 	envBoolFallback(&f.otlpEnabled, "OTLP_ENABLED")
+	envBoolFallback(&f.messageCompression, "MESSAGE_COMPRESSION_ENABLED")
 
 	envBoolFallback(&f.authEnabled, "SESSION_API_AUTH_ENABLED")
 	envFallback(&f.authAllowedSubjects, "", "SESSION_API_AUTH_ALLOWED_SUBJECTS")
@@ -229,6 +261,15 @@ func run() error {
 	}
 	defer syncLog()
 
+	// --- Federation mode: no warm store, proxy to per-cluster session-apis ---
+	if f.federationConfig != "" {
+		ctx, cancel := signal.NotifyContext(
+			context.Background(), syscall.SIGINT, syscall.SIGTERM,
+		)
+		defer cancel()
+		return runFederation(ctx, f, log)
+	}
+
 	// --- Workspace CRD config resolution ---
 	if f.workspace != "" && f.serviceGroup != "" {
 		if err := f.resolveConfigFromWorkspace(log); err != nil {
@@ -243,6 +284,9 @@ func run() error {
 	if f.postgresConn == "" {
 		return fmt.Errorf("--postgres-conn or POSTGRES_CONN is required")
 	}
+	if err := validateReplicaMode(f.replicaMode); err != nil {
+		return err
+	}
 
 	// --- Signal context ---
 	ctx, cancel := signal.NotifyContext(
@@ -251,7 +295,8 @@ func run() error {
 	defer cancel()
 
 	// --- Postgres pool (shared) ---
-	pool, err := initPool(ctx, f.postgresConn)
+	queryMetrics := pgprovider.NewQueryMetrics(prometheus.DefaultRegisterer)
+	pool, err := initPool(ctx, f.postgresConn, pgprovider.PoolPrimary, queryMetrics, log)
 	if err != nil {
 		return err
 	}
@@ -259,16 +304,28 @@ func run() error {
 	log.V(1).Info("postgres pool created",
 		"maxConns", envInt32("PG_MAX_CONNS", defaultMaxConns),
 		"minConns", envInt32("PG_MIN_CONNS", defaultMinConns),
+		"slowQueryThreshold", envDuration("PG_SLOW_QUERY_THRESHOLD", defaultSlowQueryThreshold),
 	)
 
+	// --- Postgres read replica pool (optional) ---
+	var readPool *pgxpool.Pool
+	if f.postgresReadConn != "" {
+		readPool, err = initPool(ctx, f.postgresReadConn, pgprovider.PoolReplica, queryMetrics, log)
+		if err != nil {
+			return fmt.Errorf("read replica: %w", err)
+		}
+		defer readPool.Close()
+		log.Info("postgres read replica enabled", "routes", api.StaleTolerantRoutes)
+	}
+
 	// --- Migrations ---
-	if err := runMigrations(f.postgresConn, log); err != nil {
+	if err := runMigrations(ctx, pool, f.postgresConn, log); err != nil {
 		return err
 	}
 	log.V(1).Info("migrations complete")
 
 	// --- Providers ---
-	registry, providerCleanup, err := initProviders(ctx, f, pool, log)
+	registry, providerCleanup, err := initProviders(ctx, f, pool, readPool, log)
 	if err != nil {
 		return err
 	}
@@ -278,7 +335,19 @@ func run() error {
 	// The initial migration seeds partitions only ~2 weeks ahead and nothing
 	// else rolls the window forward, so inserts would eventually fail with
 	// SQLSTATE 23514. Keep several weeks of partitions ahead, on startup + daily.
-	startPartitionMaintenance(ctx, pgprovider.NewFromPool(pool), 24*time.Hour, log)
+	backgroundTasks := []backgroundTask{
+		partitionMaintenanceTask(pgprovider.NewFromPool(pool), 24*time.Hour, log),
+	}
+
+	// --- Message compression (optional) ---
+	// Trains per-namespace dictionaries and compresses tool outputs written
+	// before compression was enabled.
+	if f.messageCompression {
+		compressor := pgprovider.NewFromPool(pool)
+		compressor.SetMessageCompression(compressionMinBytes())
+		backgroundTasks = append(backgroundTasks, messageCompressionTask(compressor, compressionInterval, log))
+		log.Info("message compression enabled", "minBytes", compressionMinBytes())
+	}
 
 	// --- Tracing ---
 	// Set propagator so incoming trace context (e.g. from facade httpclient)
@@ -305,6 +374,24 @@ func run() error {
 		}
 	}
 
+	// --- OTLP metric export (opt-in via the standard OTEL_* env vars) ---
+	shutdownMetrics, err := pkgmetrics.StartOTLPExport(ctx, "omnia-session-api", prometheus.DefaultGatherer)
+	if err != nil {
+		log.Error(err, "OTLP metric export failed to start")
+	} else {
+		defer func() { _ = shutdownMetrics(context.Background()) }()
+	}
+
+	// --- Profiling (pprof on an internal port; Pyroscope push opt-in) ---
+	stopProfiling, err := profiling.Start(profiling.Options{
+		ServiceName: "omnia-session-api",
+		PprofAddr:   profiling.DefaultPprofAddr,
+	})
+	if err != nil {
+		log.Error(err, "profiling failed to start")
+	}
+	defer func() { _ = stopProfiling(context.Background()) }()
+
 	// --- ServiceAccount auth (opt-in) ---
 	reviewer, allowedSubjects, allowedNamespaces, err := buildServiceAuth(f, log)
 	if err != nil {
@@ -312,7 +399,7 @@ func run() error {
 	}
 
 	// --- Build API mux ---
-	apiMux, sessionService, auditCleanup := buildAPIMux(pool, registry, f, log, reviewer, allowedSubjects, allowedNamespaces)
+	apiMux, sessionService, auditCleanup := buildAPIMux(pool, readPool, registry, f, log, reviewer, allowedSubjects, allowedNamespaces)
 	defer auditCleanup()
 
 	// --- Audit drain-forwarder (#1673) ---
@@ -322,14 +409,28 @@ func run() error {
 	if fwd := buildAuditForwarder(f.enterprise, pool,
 		resolvePrivacyURL(ctx, f.workspace, f.serviceGroup, log),
 		prometheus.DefaultRegisterer, log); fwd != nil {
-		go fwd.Run(ctx)
-		log.Info("audit forwarder started", "sourceService", auditSourceService)
+		backgroundTasks = append(backgroundTasks, fwd.Run)
+		log.Info("audit forwarder enabled", "sourceService", auditSourceService)
 	} else if f.enterprise {
 		log.Info("audit forwarder skipped", "reason", "no privacy URL")
 	}
 
+	// --- Idle session reaper ---
+	// Moves quiet sessions to idle and then completed, so every session ends
+	// even when its client never closes it.
+	if reaper := sessionReaperConfigFromEnv(); reaper.enabled() {
+		backgroundTasks = append(backgroundTasks,
+			sessionReaperTask(sessionService, reaper, sessionReapInterval, log))
+		log.Info("idle session reaper enabled",
+			"idleAfter", reaper.idleAfter, "completeAfter", reaper.completeAfter)
+	}
+
+	// --- Background tasks (one replica at a time; see replicaModeHA) ---
+	startBackgroundTasks(ctx, f.replicaMode,
+		sessionpg.NewLeaderElector(pool, leaderRetryInterval, log), backgroundTasks, log)
+
 	// --- Servers ---
-	healthSrv := newHealthServer(f.healthAddr, pool)
+	healthSrv := newHealthServer(f.healthAddr, readinessChecker(pool, readPool, registry))
 	metricsSrv := newMetricsServer(f.metricsAddr)
 	apiSrv := &http.Server{
 		Addr:         f.apiAddr,
@@ -364,6 +465,7 @@ func run() error {
 		"metrics", f.metricsAddr,
 		"enterprise", f.enterprise,
 		"otlp", f.otlpEnabled,
+		"replicaMode", f.replicaMode,
 	)
 
 	// --- Wait for shutdown ---
@@ -468,14 +570,22 @@ const (
 	defaultMinConns        = 2
 	defaultMaxConnLifetime = time.Hour
 	defaultMaxConnIdleTime = 30 * time.Minute
+	// defaultSlowQueryThreshold is the statement duration above which the
+	// warm store logs a slow query.
+	defaultSlowQueryThreshold = 500 * time.Millisecond
 )
 
 // initPool creates and returns a pgxpool connection pool with configured limits.
 // Pool settings are read from environment variables with sensible defaults:
 //
 //	PG_MAX_CONNS (default 8), PG_MIN_CONNS (default 2),
-//	PG_MAX_CONN_LIFETIME (default 1h), PG_MAX_CONN_IDLE_TIME (default 30m).
-func initPool(ctx context.Context, connStr string) (*pgxpool.Pool, error) {
+//	PG_MAX_CONN_LIFETIME (default 1h), PG_MAX_CONN_IDLE_TIME (default 30m),
+//	PG_SLOW_QUERY_THRESHOLD (default 500ms, 0 disables slow-query logging).
+//
+// Every statement is timed per query family into queryMetrics, which the
+// primary and replica pools share, and the pool's saturation is exported
+// labelled with name; see pgprovider.QueryTracer and pgprovider.PoolCollector.
+func initPool(ctx context.Context, connStr, name string, queryMetrics *pgprovider.QueryMetrics, log logr.Logger) (*pgxpool.Pool, error) {
 	poolCfg, err := pgxpool.ParseConfig(connStr)
 	if err != nil {
 		return nil, fmt.Errorf("parsing postgres connection string: %w", err)
@@ -485,11 +595,17 @@ func initPool(ctx context.Context, connStr string) (*pgxpool.Pool, error) {
 	poolCfg.MinConns = envInt32("PG_MIN_CONNS", defaultMinConns)
 	poolCfg.MaxConnLifetime = envDuration("PG_MAX_CONN_LIFETIME", defaultMaxConnLifetime)
 	poolCfg.MaxConnIdleTime = envDuration("PG_MAX_CONN_IDLE_TIME", defaultMaxConnIdleTime)
+	poolCfg.ConnConfig.Tracer = pgprovider.NewQueryTracer(
+		queryMetrics,
+		envDuration("PG_SLOW_QUERY_THRESHOLD", defaultSlowQueryThreshold),
+		log.WithName("warm-store").WithValues("pool", name),
+	)
 
 	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
 		return nil, fmt.Errorf("creating postgres pool: %w", err)
 	}
+	prometheus.MustRegister(pgprovider.NewPoolCollector(pool, name))
 	return pool, nil
 }
 
@@ -519,13 +635,16 @@ func envDuration(key string, def time.Duration) time.Duration {
 	return d
 }
 
-// runMigrations applies database schema migrations.
-func runMigrations(connStr string, log logr.Logger) error {
+// runMigrations applies database schema migrations, holding the migration
+// advisory lock so replicas starting together apply them one at a time.
+func runMigrations(ctx context.Context, pool *pgxpool.Pool, connStr string, log logr.Logger) error {
 	migrator, err := sessionpg.NewMigrator(connStr, log)
 	if err != nil {
 		return fmt.Errorf("creating migrator: %w", err)
 	}
-	if err := migrator.Up(); err != nil {
+	lockCtx, cancel := context.WithTimeout(ctx, migrationLockTimeout)
+	defer cancel()
+	if err := migrator.UpLocked(lockCtx, pool); err != nil {
 		_ = migrator.Close()
 		return fmt.Errorf("running migrations: %w", err)
 	}
@@ -554,6 +673,9 @@ func (f *flags) resolveConfigFromWorkspace(log logr.Logger) error {
 		return fmt.Errorf("resolving session config: %w", err)
 	}
 	f.postgresConn = sessCfg.PostgresConn
+	if sessCfg.PostgresReadConn != "" {
+		f.postgresReadConn = sessCfg.PostgresReadConn
+	}
 	log.V(1).Info("session config resolved",
 		"workspace", f.workspace,
 		"serviceGroup", f.serviceGroup)
@@ -630,13 +752,16 @@ func buildServiceAuth(f *flags, log logr.Logger) (serviceauth.TokenReviewer, []s
 // Prometheus metrics middleware. Returns the handler and a cleanup function
 // for the audit logger (no-op when enterprise is disabled).
 //
+// readPool, when non-nil, is the read replica the eval, provider-call and
+// annotation stores serve stale-tolerant reads from.
+//
 // reviewer, allowedSubjects and allowedNamespaces wire ServiceAccount auth: when
 // reviewer is non-nil the JSON API requires a ServiceAccount bearer token whose
 // subject is in allowedSubjects OR whose namespace is in allowedNamespaces
 // (/healthz exempt). A nil reviewer leaves the API unauthenticated. These are
 // parameters (rather than read from f) so wiring tests can inject a fake
 // reviewer.
-func buildAPIMux(pool *pgxpool.Pool, registry *providers.Registry, f *flags, log logr.Logger, reviewer serviceauth.TokenReviewer, allowedSubjects, allowedNamespaces []string) (http.Handler, *api.SessionService, func()) {
+func buildAPIMux(pool, readPool *pgxpool.Pool, registry *providers.Registry, f *flags, log logr.Logger, reviewer serviceauth.TokenReviewer, allowedSubjects, allowedNamespaces []string) (http.Handler, *api.SessionService, func()) {
 	svcCfg := api.ServiceConfig{}
 	// Default cleanup is a no-op; only the enterprise audit-logger path below
 	// replaces it with a real Close() call. Keeping cleanup non-nil lets the
@@ -657,32 +782,67 @@ func buildAPIMux(pool *pgxpool.Pool, registry *providers.Registry, f *flags, log
 
 	// Event publisher (reuses the same Redis used for hot cache, if configured).
 	svcCfg.EventPublisher = initEventPublisher(registry, log, httpMetrics)
+	svcCfg.UsageMetrics = api.NewUsageMetrics(prometheus.DefaultRegisterer)
+
+	// Process-local read cache for hot session reads (opt-in via READ_CACHE_SIZE).
+	readCache, invalidator, stopInvalidations := initReadCache(registry, prometheus.DefaultRegisterer, log)
+	svcCfg.ReadCache = readCache
+	svcCfg.CacheInvalidator = invalidator
+	auditCleanup := cleanup
+	cleanup = func() {
+		stopInvalidations()
+		auditCleanup()
+	}
+
+	// Attachment references resolve to download URLs through the agents'
+	// media storage backend, when one is configured.
+	resolver, closeResolver := buildAttachmentResolver(log)
+	svcCfg.AttachmentResolver = resolver
+	if closeResolver != nil {
+		resolverCleanup := cleanup
+		cleanup = func() {
+			closeResolver()
+			resolverCleanup()
+		}
+	}
 
 	sessionService := api.NewSessionService(registry, svcCfg, log)
 	maxBody := int64(envInt32("MAX_BODY_SIZE", int32(api.DefaultMaxBodySize)))
 	handler := api.NewHandler(sessionService, log, maxBody)
 
-	// Wire up eval result + provider call endpoints when Postgres is available.
+	// Wire up eval result, provider call and annotation endpoints when Postgres
+	// is available.
 	if pool != nil {
 		evalStore := pgprovider.NewEvalStore(pool)
+		evalStore.SetReadReplica(readPool)
 		evalService := api.NewEvalService(evalStore, log)
+		evalService.SetUsageMetrics(svcCfg.UsageMetrics)
 		handler.SetEvalService(evalService)
 
 		providerCallsStore := pgprovider.NewProviderCallsStore(pool)
+		providerCallsStore.SetReadReplica(readPool)
 		providerCallsService := api.NewProviderCallsService(providerCallsStore, log)
 		handler.SetProviderCallsService(providerCallsService)
 
 		providerUsageStore := pgprovider.NewProviderUsageStore(pool)
 		providerUsageService := api.NewProviderUsageService(providerUsageStore, log)
 		handler.SetProviderUsageService(providerUsageService)
+
+		annotationStore := pgprovider.NewAnnotationStore(pool)
+		annotationStore.SetReadReplica(readPool)
+		handler.SetAnnotationService(api.NewAnnotationService(annotationStore, log))
 	}
 
 	mux := http.NewServeMux()
 	handler.RegisterRoutes(mux)
+	importMaxBody := int64(envInt32("IMPORT_MAX_BODY_SIZE", importer.DefaultMaxBodySize))
+	importer.NewHandler(importer.NewImporter(sessionService, log), log, importMaxBody).RegisterRoutes(mux)
 	registerEnterpriseRoutes(mux, registry, auditLogger, f, log)
 
+	// Stale-tolerant reads go to the read replica when one is configured.
+	var apiHandler http.Handler = api.ReadRoutingMiddleware(mux)
+
 	// Privacy middleware (enterprise only): PII redaction + user opt-out.
-	var apiHandler http.Handler = mux
 	if f.enterprise {
 		wrapped, watcher, k8sClient := wrapPrivacyMiddleware(apiHandler, registry, f.workspace, f.serviceGroup, auditLogger, log)
 		apiHandler = wrapped
@@ -712,17 +872,22 @@ func buildAPIMux(pool *pgxpool.Pool, registry *providers.Registry, f *flags, log
 	}
 	log.V(1).Info("rate limiter initialized", "rps", rlCfg.RPS, "burst", rlCfg.Burst)
 
-	traced := otelhttp.NewHandler(api.TraceLogMiddleware(apiHandler), "session-api",
+	// Metrics run inside the otel handler so request observations can carry
+	// the trace ID as an exemplar.
+	instrumented := api.MetricsMiddleware(httpMetrics, apiHandler)
+	traced := otelhttp.NewHandler(api.TraceLogMiddleware(instrumented), "session-api",
 		otelhttp.WithFilter(func(r *http.Request) bool {
 			return r.URL.Path != "/healthz"
 		}),
 	)
 
 	// ServiceAccount auth runs after rate-limiting but around the
-	// metrics/trace/handler chain. /healthz is exempt so liveness probes are
+	// trace/metrics/handler chain. /healthz is exempt so liveness probes are
 	// never gated. A nil reviewer makes this a pass-through (unauthenticated).
 	authMW := serviceauth.RequireServiceAccount(reviewer, allowedSubjects, allowedNamespaces, "/healthz")
-	return rlMiddleware(authMW(api.MetricsMiddleware(httpMetrics, traced))), sessionService, cleanup
+	// Every response, rejections included, carries the request's correlation
+	// ID, so the middleware assigning it runs first.
+	return apierror.Middleware(rlMiddleware(authMW(traced))), sessionService, cleanup
 }
 
 // registerEnterpriseRoutes adds audit and the session-tier DSAR erasure endpoint
@@ -748,37 +913,52 @@ func registerEnterpriseRoutes(mux *http.ServeMux, registry *providers.Registry,
 // newMetricsServer creates a dedicated HTTP server for Prometheus metrics.
 func newMetricsServer(addr string) *http.Server {
 	metricsMux := http.NewServeMux()
-	metricsMux.Handle("GET /metrics", promhttp.Handler())
+	metricsMux.Handle("GET /metrics", pkgmetrics.Handler())
 	return &http.Server{Addr: addr, Handler: metricsMux}
 }
 
 // newHealthServer creates an HTTP server for health and readiness probes.
-func newHealthServer(addr string, pool *pgxpool.Pool) *http.Server {
+func newHealthServer(addr string, ready http.Handler) *http.Server {
 	healthMux := http.NewServeMux()
 	healthMux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
-	healthMux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
-		if err := pool.Ping(r.Context()); err != nil {
-			w.WriteHeader(http.StatusServiceUnavailable)
-			_, _ = w.Write([]byte("postgres unavailable"))
-			return
-		}
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
-	})
+	healthMux.Handle("GET /readyz", ready)
 	return &http.Server{Addr: addr, Handler: healthMux}
 }
 
+// readinessChecker returns the dependencies /readyz checks. Postgres (the warm
+// store) is critical; the hot cache and cold archive are optional, since reads
+// fall back to the warm store without them. The read replica, when configured,
+// is optional too: losing it only fails the stale-tolerant list and analytics
+// reads, which is better than taking every replica out of service.
+func readinessChecker(pool, readPool *pgxpool.Pool, registry *providers.Registry) *readiness.Checker {
+	checker := readiness.NewChecker(readiness.Critical("postgres", pool.Ping))
+	if readPool != nil {
+		checker.Add(readiness.Optional("postgres-replica", readPool.Ping))
+	}
+	if hot, err := registry.HotCache(); err == nil {
+		checker.Add(readiness.Optional("redis", hot.Ping))
+	}
+	if coldArchive, err := registry.ColdArchive(); err == nil {
+		checker.Add(readiness.Optional("cold-archive", coldArchive.Ping))
+	}
+	return checker
+}
+
 // initProviders creates the tiered storage registry (warm/hot/cold) and returns
 // a cleanup function that closes all providers in reverse order.
-func initProviders(ctx context.Context, f *flags, pool *pgxpool.Pool, log logr.Logger) (*providers.Registry, func(), error) {
+func initProviders(ctx context.Context, f *flags, pool, readPool *pgxpool.Pool, log logr.Logger) (*providers.Registry, func(), error) {
 	registry := providers.NewRegistry()
 	var cleanups []func()
 
 	// Warm store (postgres, using shared pool).
 	warmProvider := pgprovider.NewFromPool(pool)
+	warmProvider.SetReadReplica(readPool)
+	if f.messageCompression {
+		warmProvider.SetMessageCompression(compressionMinBytes())
+	}
 	registry.SetWarmStore(warmProvider)
 	cleanups = append(cleanups, func() { _ = warmProvider.Close() })
 	log.V(1).Info("warm store initialized")
@@ -17,6 +17,8 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -24,7 +26,11 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/altairalabs/omnia/internal/session/providers"
+	"github.com/altairalabs/omnia/pkg/readiness"
 )
 
 func TestEnvInt32(t *testing.T) {
@@ -157,6 +163,7 @@ func TestPoolConfigDefaults(t *testing.T) {
 
 func TestApplyEnvFallbacks_AllOverrides(t *testing.T) {
 	t.Setenv("POSTGRES_CONN", "postgres://test:5432/db")
+	t.Setenv("POSTGRES_READ_CONN", "postgres://replica:5432/db")
 	t.Setenv("REDIS_URL", "redis://localhost:6379/0")
 	t.Setenv("COLD_BACKEND", "s3")
 	t.Setenv("COLD_BUCKET", "my-bucket")
@@ -185,6 +192,7 @@ func TestApplyEnvFallbacks_AllOverrides(t *testing.T) {
 		want string
 	}{
 		{"postgresConn", f.postgresConn, "postgres://test:5432/db"},
+		{"postgresReadConn", f.postgresReadConn, "postgres://replica:5432/db"},
 		{"redisURL", f.redisURL, "redis://localhost:6379/0"},
 		{"coldBackend", f.coldBackend, "s3"},
 		{"coldBucket", f.coldBucket, "my-bucket"},
@@ -271,6 +279,47 @@ func TestNewHealthServer_Healthz(t *testing.T) {
 	}
 }
 
+func TestNewHealthServer_ReadyzReportsDependencies(t *testing.T) {
+	// A lazily-connecting pool against a closed port: Ping fails fast.
+	pool, err := pgxpool.New(context.Background(), "postgres://omnia@127.0.0.1:1/omnia?connect_timeout=1")
+	if err != nil {
+		t.Fatalf("pgxpool.New: %v", err)
+	}
+	defer pool.Close()
+
+	srv := newHealthServer(":0", readinessChecker(pool, nil, providers.NewRegistry()))
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("readyz: expected 503 with postgres down, got %d", rec.Code)
+	}
+	var report readiness.Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("readyz: body is not a readiness report: %v", err)
+	}
+	if len(report.Dependencies) != 1 || report.Dependencies[0].Name != "postgres" || report.Dependencies[0].Error == "" {
+		t.Fatalf("readyz: expected a failed postgres dependency only, got %+v", report.Dependencies)
+	}
+}
+
+func TestReadinessChecker_ReadReplicaIsOptional(t *testing.T) {
+	pool, err := pgxpool.New(context.Background(), "postgres://omnia@127.0.0.1:1/omnia?connect_timeout=1")
+	if err != nil {
+		t.Fatalf("pgxpool.New: %v", err)
+	}
+	defer pool.Close()
+
+	report := readinessChecker(pool, pool, providers.NewRegistry()).Evaluate(context.Background())
+	if len(report.Dependencies) != 2 {
+		t.Fatalf("expected postgres and postgres-replica, got %+v", report.Dependencies)
+	}
+	replica := report.Dependencies[1]
+	if replica.Name != "postgres-replica" || replica.Critical {
+		t.Fatalf("expected an optional postgres-replica dependency, got %+v", replica)
+	}
+}
+
 // TestEnterpriseRoutesConsentOptOutNotHosted guards that session-api no longer
 // hosts the consent/opt-out handlers. privacy-api is the sole owner of those
 // routes (#1642 Slice B), and now of the full DSAR deletion-request lifecycle too
@@ -0,0 +1,100 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/internal/media"
+	"github.com/altairalabs/omnia/internal/session/api"
+)
+
+// envMediaStorageBaseURL is the base URL download links of the "local" media
+// backend are built on. session-api does not serve media itself, so it must
+// point at whatever does (usually the agent facade).
+const envMediaStorageBaseURL = "OMNIA_MEDIA_STORAGE_BASE_URL"
+
+// mediaAttachmentResolver resolves attachment storage references through the
+// media storage backend the agents upload to.
+type mediaAttachmentResolver struct {
+	storage media.Storage
+}
+
+// GetDownloadURL returns a presigned download URL for storageURI. Missing,
+// expired and malformed references are reported as unavailable attachments.
+func (r mediaAttachmentResolver) GetDownloadURL(ctx context.Context, storageURI string) (string, error) {
+	url, err := r.storage.GetDownloadURL(ctx, storageURI)
+	if errors.Is(err, media.ErrMediaNotFound) || errors.Is(err, media.ErrMediaExpired) ||
+		errors.Is(err, media.ErrInvalidStorageRef) {
+		return "", fmt.Errorf("%w: %w", api.ErrAttachmentUnavailable, err)
+	}
+	return url, err
+}
+
+// buildAttachmentResolver builds the media storage backend selected by the
+// OMNIA_MEDIA_* environment contract (internal/media/env.go) — the same one
+// the agents use — so attachment references can be resolved to download
+// URLs. It returns a nil resolver and cleanup when media storage is not
+// configured or cannot be initialized.
+func buildAttachmentResolver(log logr.Logger) (api.AttachmentResolver, func()) {
+	storageType := media.BackendType(os.Getenv(media.EnvStorageType))
+	if storageType == media.BackendTypeNone || storageType == "" {
+		log.V(1).Info("attachment resolver skipped", "reason", "no media storage configured")
+		return nil, nil
+	}
+
+	store, err := media.Build(context.Background(), media.BuilderConfig{
+		Type:           storageType,
+		DownloadURLTTL: envDuration(media.EnvDownloadURLTTL, media.DefaultDownloadURLTTL),
+		LocalPath:      envOrDefault(media.EnvStoragePath, media.DefaultStoragePath),
+		LocalBaseURL:   os.Getenv(envMediaStorageBaseURL),
+		S3Bucket:       os.Getenv(media.EnvS3Bucket),
+		S3Region:       os.Getenv(media.EnvS3Region),
+		S3Prefix:       os.Getenv(media.EnvS3Prefix),
+		S3Endpoint:     os.Getenv(media.EnvS3Endpoint),
+		GCSBucket:      os.Getenv(media.EnvGCSBucket),
+		GCSPrefix:      os.Getenv(media.EnvGCSPrefix),
+		AzureAccount:   os.Getenv(media.EnvAzureAccount),
+		AzureContainer: os.Getenv(media.EnvAzureContainer),
+		AzurePrefix:    os.Getenv(media.EnvAzurePrefix),
+		AzureKey:       os.Getenv(media.EnvAzureKey),
+	})
+	if err != nil {
+		log.Error(err, "media storage initialization failed, attachments will not resolve", "type", storageType)
+		return nil, nil
+	}
+
+	log.Info("attachment resolver enabled", "type", storageType)
+	return mediaAttachmentResolver{storage: store}, func() {
+		if err := store.Close(); err != nil {
+			log.Error(err, "closing media storage failed")
+		}
+	}
+}
+
+// envOrDefault reads an environment variable, returning def when it is unset.
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
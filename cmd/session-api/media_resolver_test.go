@@ -0,0 +1,58 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/internal/media"
+	"github.com/altairalabs/omnia/internal/session/api"
+)
+
+func TestBuildAttachmentResolver_NotConfigured(t *testing.T) {
+	t.Setenv(media.EnvStorageType, "")
+	resolver, cleanup := buildAttachmentResolver(logr.Discard())
+	if resolver != nil || cleanup != nil {
+		t.Fatal("expected no resolver without media storage")
+	}
+}
+
+func TestBuildAttachmentResolver_Local(t *testing.T) {
+	t.Setenv(media.EnvStorageType, string(media.BackendTypeLocal))
+	t.Setenv(media.EnvStoragePath, t.TempDir())
+	t.Setenv(envMediaStorageBaseURL, "http://agent:8080")
+
+	resolver, cleanup := buildAttachmentResolver(logr.Discard())
+	if resolver == nil || cleanup == nil {
+		t.Fatal("expected a resolver for local media storage")
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	_, err := resolver.GetDownloadURL(ctx, "omnia://sessions/s-1/media/m-1")
+	if !errors.Is(err, api.ErrAttachmentUnavailable) {
+		t.Fatalf("missing media: err = %v, want ErrAttachmentUnavailable", err)
+	}
+	_, err = resolver.GetDownloadURL(ctx, "s3://bucket/key")
+	if !errors.Is(err, api.ErrAttachmentUnavailable) {
+		t.Fatalf("foreign reference: err = %v, want ErrAttachmentUnavailable", err)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	pgprovider "github.com/altairalabs/omnia/internal/session/providers/postgres"
+)
+
+// Message compression maintenance cadence.
+const (
+	// compressionInterval is how often dictionaries are checked for
+	// retraining and plain tool outputs are compressed.
+	compressionInterval = time.Hour
+	// recompressBatchSize is how many messages one recompression statement
+	// rewrites.
+	recompressBatchSize = 500
+	// recompressPause spaces recompression batches so the migration does not
+	// compete with request traffic for the pool.
+	recompressPause = 100 * time.Millisecond
+)
+
+// compressionMinBytes is the smallest tool output stored compressed, from
+// MESSAGE_COMPRESSION_MIN_BYTES (default pgprovider.DefaultCompressionMinBytes).
+func compressionMinBytes() int {
+	return int(envInt32("MESSAGE_COMPRESSION_MIN_BYTES", pgprovider.DefaultCompressionMinBytes))
+}
+
+// messageCompressor is the part of the Postgres warm store the compression
+// task drives; declared here so the task is unit-testable with a fake.
+type messageCompressor interface {
+	TrainCompressionDictionaries(ctx context.Context) (int, error)
+	RecompressMessages(ctx context.Context, batchSize int) (int, error)
+}
+
+// messageCompressionTask trains per-namespace dictionaries and compresses
+// tool outputs stored as plain text, on startup and every interval. It runs
+// as a backgroundTask so only one replica rewrites messages at a time.
+func messageCompressionTask(c messageCompressor, interval time.Duration, log logr.Logger) backgroundTask {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			runMessageCompression(ctx, c, log)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// runMessageCompression runs one maintenance pass. Training runs first so
+// the recompression pass can use a fresh dictionary.
+func runMessageCompression(ctx context.Context, c messageCompressor, log logr.Logger) {
+	trained, err := c.TrainCompressionDictionaries(ctx)
+	if err != nil {
+		log.Error(err, "compression dictionary training failed")
+	} else if trained > 0 {
+		log.Info("compression dictionaries trained", "namespaces", trained)
+	}
+
+	total := 0
+	for ctx.Err() == nil {
+		n, err := c.RecompressMessages(ctx, recompressBatchSize)
+		total += n
+		if err != nil {
+			log.Error(err, "message recompression failed", "compressed", total)
+			return
+		}
+		if n < recompressBatchSize {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(recompressPause):
+		}
+	}
+	if total > 0 {
+		log.Info("messages recompressed", "compressed", total)
+	}
+}
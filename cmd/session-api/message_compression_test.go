@@ -0,0 +1,76 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+type fakeCompressor struct {
+	trainCalls int
+	trainErr   error
+	backlog    int
+	batches    int
+}
+
+func (f *fakeCompressor) TrainCompressionDictionaries(context.Context) (int, error) {
+	f.trainCalls++
+	return 1, f.trainErr
+}
+
+func (f *fakeCompressor) RecompressMessages(_ context.Context, batchSize int) (int, error) {
+	f.batches++
+	n := min(f.backlog, batchSize)
+	f.backlog -= n
+	return n, nil
+}
+
+func TestRunMessageCompression_DrainsBacklog(t *testing.T) {
+	fake := &fakeCompressor{backlog: 2*recompressBatchSize + 7}
+	runMessageCompression(context.Background(), fake, logr.Discard())
+
+	if fake.trainCalls != 1 {
+		t.Fatalf("TrainCompressionDictionaries called %d times, want 1", fake.trainCalls)
+	}
+	if fake.backlog != 0 || fake.batches != 3 {
+		t.Fatalf("backlog = %d after %d batches, want 0 after 3", fake.backlog, fake.batches)
+	}
+}
+
+func TestRunMessageCompression_TrainingErrorNotFatal(t *testing.T) {
+	fake := &fakeCompressor{trainErr: errors.New("boom"), backlog: 3}
+	runMessageCompression(context.Background(), fake, logr.Discard())
+
+	if fake.backlog != 0 {
+		t.Fatalf("recompression should still run after a training error, backlog = %d", fake.backlog)
+	}
+}
+
+func TestRunMessageCompression_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fake := &fakeCompressor{backlog: 10 * recompressBatchSize}
+	runMessageCompression(ctx, fake, logr.Discard())
+
+	if fake.batches != 0 {
+		t.Fatalf("a cancelled pass should not recompress, ran %d batches", fake.batches)
+	}
+}
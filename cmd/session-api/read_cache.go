@@ -0,0 +1,61 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/altairalabs/omnia/internal/session/api"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+// initReadCache creates the process-local read cache from READ_CACHE_SIZE
+// (sessions held; 0, the default, disables it) and READ_CACHE_TTL. When the
+// hot cache exposes a Redis client, invalidations are shared with the other
+// replicas through it. Returns nil values when the cache is disabled; the
+// returned stop function is always non-nil.
+func initReadCache(registry *providers.Registry, reg prometheus.Registerer, log logr.Logger) (*api.ReadCache, api.CacheInvalidationPublisher, func()) {
+	noop := func() { /* no-op — read cache disabled or process-local only */ }
+	size := int(envInt32("READ_CACHE_SIZE", 0))
+	if size <= 0 {
+		return nil, nil, noop
+	}
+	ttl := envDuration("READ_CACHE_TTL", api.DefaultReadCacheTTL)
+	cache := api.NewReadCache(size, ttl, api.NewReadCacheMetrics(reg))
+
+	hot, err := registry.HotCache()
+	if err != nil {
+		log.Info("read cache enabled without cross-replica invalidation; other replicas' writes show after the TTL",
+			"size", size, "ttl", ttl, "reason", "no hot cache")
+		return cache, nil, noop
+	}
+	rp, ok := hot.(redisClientProvider)
+	if !ok {
+		log.Info("read cache enabled without cross-replica invalidation; other replicas' writes show after the TTL",
+			"size", size, "ttl", ttl, "reason", "hot cache does not expose Redis client")
+		return cache, nil, noop
+	}
+
+	invalidator := api.NewRedisCacheInvalidator(rp.RedisClient(), cache, log)
+	ctx, cancel := context.WithCancel(context.Background())
+	go invalidator.Run(ctx)
+	log.Info("read cache enabled", "size", size, "ttl", ttl)
+	return cache, invalidator, cancel
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+func TestInitReadCache(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		t.Setenv("READ_CACHE_SIZE", "")
+		cache, inv, stop := initReadCache(providers.NewRegistry(), prometheus.NewRegistry(), logr.Discard())
+		defer stop()
+		if cache != nil || inv != nil {
+			t.Fatalf("expected no read cache, got cache=%v invalidator=%v", cache, inv)
+		}
+	})
+
+	t.Run("process-local without redis", func(t *testing.T) {
+		t.Setenv("READ_CACHE_SIZE", "100")
+		cache, inv, stop := initReadCache(providers.NewRegistry(), prometheus.NewRegistry(), logr.Discard())
+		defer stop()
+		if cache == nil {
+			t.Fatal("expected a read cache")
+		}
+		if inv != nil {
+			t.Fatalf("expected no invalidator without a hot cache, got %v", inv)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Replica modes accepted by --replica-mode.
+//
+// The request path is stateless — sessions live in Postgres and the Redis hot
+// cache — so any number of replicas can serve behind one Service. What is not
+// safe to run on every replica is the background work: partition maintenance
+// (concurrent DDL on the same partitions) and the audit forwarder (every
+// replica would ship the same rows). Migrations are serialized by an advisory
+// lock in both modes, since a rolling update overlaps pods even at one replica.
+const (
+	// replicaModeSingle runs background tasks on this process unconditionally.
+	// Only correct when one replica runs against the database.
+	replicaModeSingle = "single"
+	// replicaModeHA runs background tasks only on the replica holding the
+	// session-api leader lock in Postgres; the others take over if it goes.
+	replicaModeHA = "ha"
+)
+
+// leaderRetryInterval is how often a follower retries the leader lock and the
+// leader checks its lock connection, and so bounds failover time.
+const leaderRetryInterval = 15 * time.Second
+
+// migrationLockTimeout bounds how long startup waits for another replica's
+// migration run before giving up (the pod restarts and tries again).
+const migrationLockTimeout = 10 * time.Minute
+
+// validateReplicaMode rejects an unknown --replica-mode.
+func validateReplicaMode(mode string) error {
+	switch mode {
+	case replicaModeSingle, replicaModeHA:
+		return nil
+	default:
+		return fmt.Errorf("--replica-mode must be %q or %q, got %q", replicaModeSingle, replicaModeHA, mode)
+	}
+}
+
+// backgroundTask is work that must run on one replica at a time. It blocks
+// until ctx is cancelled.
+type backgroundTask func(ctx context.Context)
+
+// leaderRunner campaigns for leadership and runs lead while holding it;
+// satisfied by *sessionpg.LeaderElector and declared here so the dispatch is
+// unit-testable with a fake.
+type leaderRunner interface {
+	Run(ctx context.Context, lead func(ctx context.Context))
+}
+
+// startBackgroundTasks starts tasks in the background: directly in
+// replicaModeSingle, or under elector's leadership in replicaModeHA, where
+// they are cancelled when this replica loses the lock.
+func startBackgroundTasks(
+	ctx context.Context, mode string, elector leaderRunner, tasks []backgroundTask, log logr.Logger,
+) {
+	lead := func(ctx context.Context) {
+		var wg sync.WaitGroup
+		for _, task := range tasks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				task(ctx)
+			}()
+		}
+		wg.Wait()
+	}
+	if mode == replicaModeHA {
+		log.Info("background tasks run on the elected leader", "replicaMode", mode, "tasks", len(tasks))
+		go elector.Run(ctx, lead)
+		return
+	}
+	go lead(ctx)
+}
+
+// partitionMaintenanceTask wraps startPartitionMaintenance as a backgroundTask.
+func partitionMaintenanceTask(m partitionMaintainer, interval time.Duration, log logr.Logger) backgroundTask {
+	return func(ctx context.Context) {
+		startPartitionMaintenance(ctx, m, interval, log)
+		<-ctx.Done()
+	}
+}
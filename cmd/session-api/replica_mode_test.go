@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// fakeLeaderRunner leads only once elected is closed, like an elector that
+// wins the lock after another replica gives it up.
+type fakeLeaderRunner struct {
+	elected chan struct{}
+	runs    atomic.Int32
+}
+
+func (f *fakeLeaderRunner) Run(ctx context.Context, lead func(ctx context.Context)) {
+	f.runs.Add(1)
+	select {
+	case <-ctx.Done():
+	case <-f.elected:
+		lead(ctx)
+	}
+}
+
+func countingTask(started *atomic.Int32) backgroundTask {
+	return func(ctx context.Context) {
+		started.Add(1)
+		<-ctx.Done()
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+}
+
+func TestValidateReplicaMode(t *testing.T) {
+	for _, mode := range []string{replicaModeSingle, replicaModeHA} {
+		if err := validateReplicaMode(mode); err != nil {
+			t.Errorf("validateReplicaMode(%q) = %v, want nil", mode, err)
+		}
+	}
+	for _, mode := range []string{"", "HA", "multi"} {
+		if err := validateReplicaMode(mode); err == nil {
+			t.Errorf("validateReplicaMode(%q) = nil, want error", mode)
+		}
+	}
+}
+
+func TestStartBackgroundTasks_SingleRunsWithoutElection(t *testing.T) {
+	elector := &fakeLeaderRunner{elected: make(chan struct{})}
+	var started atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	startBackgroundTasks(ctx, replicaModeSingle, elector,
+		[]backgroundTask{countingTask(&started), countingTask(&started)}, logr.Discard())
+
+	waitFor(t, func() bool { return started.Load() == 2 })
+	if got := elector.runs.Load(); got != 0 {
+		t.Fatalf("elector ran %d times in single mode, want 0", got)
+	}
+}
+
+func TestStartBackgroundTasks_HAWaitsForLeadership(t *testing.T) {
+	elector := &fakeLeaderRunner{elected: make(chan struct{})}
+	var started atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	startBackgroundTasks(ctx, replicaModeHA, elector,
+		[]backgroundTask{countingTask(&started), countingTask(&started)}, logr.Discard())
+
+	waitFor(t, func() bool { return elector.runs.Load() == 1 })
+	time.Sleep(20 * time.Millisecond)
+	if got := started.Load(); got != 0 {
+		t.Fatalf("%d tasks started before leadership, want 0", got)
+	}
+
+	close(elector.elected)
+	waitFor(t, func() bool { return started.Load() == 2 })
+}
+
+func TestPartitionMaintenanceTask_BlocksUntilCancelled(t *testing.T) {
+	fake := &fakePartitionMaintainer{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		partitionMaintenanceTask(fake, time.Hour, logr.Discard())(ctx)
+	}()
+
+	waitFor(t, func() bool { return fake.calls.Load() >= 1 })
+	select {
+	case <-done:
+		t.Fatal("task returned before its context was cancelled")
+	default:
+	}
+	cancel()
+	<-done
+}
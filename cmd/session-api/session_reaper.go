@@ -0,0 +1,111 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Idle session reaper cadence and defaults.
+const (
+	// sessionReapInterval is how often quiet sessions are moved on.
+	sessionReapInterval = time.Minute
+	// sessionReapBatchSize is how many sessions one reaper statement moves.
+	sessionReapBatchSize = 500
+	// defaultSessionIdleAfter is how long an active session may go without
+	// activity before it becomes idle.
+	defaultSessionIdleAfter = 30 * time.Minute
+	// defaultSessionCompleteAfter is how long a session may go without
+	// activity before an idle session is completed.
+	defaultSessionCompleteAfter = 24 * time.Hour
+)
+
+// sessionLifecycle is the part of the session service the reaper drives;
+// declared here so the task is unit-testable with a fake.
+type sessionLifecycle interface {
+	ReapIdleSessions(ctx context.Context, idleBefore, completeBefore time.Time, limit int) (int, int, error)
+}
+
+// sessionReaperConfig is how long a session may go without activity before
+// it becomes idle and before it is completed. Zero skips that step.
+type sessionReaperConfig struct {
+	idleAfter     time.Duration
+	completeAfter time.Duration
+}
+
+// sessionReaperConfigFromEnv reads SESSION_IDLE_AFTER and
+// SESSION_COMPLETE_AFTER (0 disables the step).
+func sessionReaperConfigFromEnv() sessionReaperConfig {
+	return sessionReaperConfig{
+		idleAfter:     envDuration("SESSION_IDLE_AFTER", defaultSessionIdleAfter),
+		completeAfter: envDuration("SESSION_COMPLETE_AFTER", defaultSessionCompleteAfter),
+	}
+}
+
+// enabled reports whether the reaper has any step to run.
+func (c sessionReaperConfig) enabled() bool {
+	return c.idleAfter > 0 || c.completeAfter > 0
+}
+
+// sessionReaperTask moves quiet active sessions to idle and quiet idle
+// sessions to completed, on startup and every interval. It runs as a
+// backgroundTask so only one replica reaps at a time.
+func sessionReaperTask(l sessionLifecycle, cfg sessionReaperConfig, interval time.Duration, log logr.Logger) backgroundTask {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			runSessionReaper(ctx, l, cfg, time.Now(), log)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// runSessionReaper runs one pass, in batches until both steps run dry.
+func runSessionReaper(ctx context.Context, l sessionLifecycle, cfg sessionReaperConfig, now time.Time, log logr.Logger) {
+	var idleBefore, completeBefore time.Time
+	if cfg.idleAfter > 0 {
+		idleBefore = now.Add(-cfg.idleAfter)
+	}
+	if cfg.completeAfter > 0 {
+		completeBefore = now.Add(-cfg.completeAfter)
+	}
+
+	totalIdled, totalCompleted := 0, 0
+	for ctx.Err() == nil {
+		idled, completed, err := l.ReapIdleSessions(ctx, idleBefore, completeBefore, sessionReapBatchSize)
+		totalIdled += idled
+		totalCompleted += completed
+		if err != nil {
+			log.Error(err, "idle session reaping failed", "idled", totalIdled, "completed", totalCompleted)
+			return
+		}
+		if idled < sessionReapBatchSize && completed < sessionReapBatchSize {
+			break
+		}
+	}
+	if totalIdled > 0 || totalCompleted > 0 {
+		log.Info("idle sessions reaped", "idled", totalIdled, "completed", totalCompleted)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+type fakeLifecycle struct {
+	active, idle   int
+	calls          int
+	err            error
+	idleBefore     time.Time
+	completeBefore time.Time
+}
+
+func (f *fakeLifecycle) ReapIdleSessions(_ context.Context, idleBefore, completeBefore time.Time, limit int) (int, int, error) {
+	f.calls++
+	f.idleBefore, f.completeBefore = idleBefore, completeBefore
+	if f.err != nil {
+		return 0, 0, f.err
+	}
+	idled, completed := 0, 0
+	if !idleBefore.IsZero() {
+		idled = min(f.active, limit)
+		f.active -= idled
+	}
+	if !completeBefore.IsZero() {
+		completed = min(f.idle, limit)
+		f.idle -= completed
+	}
+	return idled, completed, nil
+}
+
+func TestRunSessionReaper_DrainsBacklog(t *testing.T) {
+	fake := &fakeLifecycle{active: 2*sessionReapBatchSize + 3, idle: 5}
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	cfg := sessionReaperConfig{idleAfter: 30 * time.Minute, completeAfter: 24 * time.Hour}
+
+	runSessionReaper(context.Background(), fake, cfg, now, logr.Discard())
+
+	if fake.active != 0 || fake.idle != 0 || fake.calls != 3 {
+		t.Fatalf("active=%d idle=%d after %d calls, want 0, 0 after 3", fake.active, fake.idle, fake.calls)
+	}
+	if want := now.Add(-30 * time.Minute); !fake.idleBefore.Equal(want) {
+		t.Fatalf("idleBefore = %v, want %v", fake.idleBefore, want)
+	}
+	if want := now.Add(-24 * time.Hour); !fake.completeBefore.Equal(want) {
+		t.Fatalf("completeBefore = %v, want %v", fake.completeBefore, want)
+	}
+}
+
+func TestRunSessionReaper_ZeroSkipsStep(t *testing.T) {
+	fake := &fakeLifecycle{active: 4, idle: 4}
+	runSessionReaper(context.Background(), fake, sessionReaperConfig{completeAfter: time.Hour}, time.Now(), logr.Discard())
+
+	if !fake.idleBefore.IsZero() || fake.active != 4 {
+		t.Fatalf("idle step should be skipped, idleBefore=%v active=%d", fake.idleBefore, fake.active)
+	}
+	if fake.idle != 0 {
+		t.Fatalf("complete step should run, idle=%d", fake.idle)
+	}
+}
+
+func TestRunSessionReaper_StopsOnError(t *testing.T) {
+	fake := &fakeLifecycle{err: errors.New("boom")}
+	runSessionReaper(context.Background(), fake,
+		sessionReaperConfig{idleAfter: time.Minute}, time.Now(), logr.Discard())
+
+	if fake.calls != 1 {
+		t.Fatalf("ReapIdleSessions called %d times after an error, want 1", fake.calls)
+	}
+}
+
+func TestSessionReaperConfigFromEnv(t *testing.T) {
+	cfg := sessionReaperConfigFromEnv()
+	if cfg.idleAfter != defaultSessionIdleAfter || cfg.completeAfter != defaultSessionCompleteAfter {
+		t.Fatalf("defaults = %+v", cfg)
+	}
+
+	t.Setenv("SESSION_IDLE_AFTER", "0")
+	t.Setenv("SESSION_COMPLETE_AFTER", "0")
+	if sessionReaperConfigFromEnv().enabled() {
+		t.Fatal("reaper should be disabled when both durations are 0")
+	}
+}
@@ -75,7 +75,7 @@ func TestBuildAPIMux_EnterpriseConsentRoutesNotHosted(t *testing.T) {
 		metricsAddr: ":0",
 	}
 
-	handler, _, cleanup := buildAPIMux(pool, registry, f, logr.Discard(), nil, nil, nil)
+	handler, _, cleanup := buildAPIMux(pool, nil, registry, f, logr.Discard(), nil, nil, nil)
 	defer cleanup()
 
 	req := httptest.NewRequest(
@@ -106,7 +106,7 @@ func TestBuildAPIMux_NoEnterprise_ConsentRoutesNotWired(t *testing.T) {
 		metricsAddr: ":0",
 	}
 
-	handler, _, cleanup := buildAPIMux(pool, registry, f, logr.Discard(), nil, nil, nil)
+	handler, _, cleanup := buildAPIMux(pool, nil, registry, f, logr.Discard(), nil, nil, nil)
 	defer cleanup()
 
 	req := httptest.NewRequest(
@@ -142,7 +142,7 @@ func TestBuildAPIMux_PrivacyPolicyRouteWired(t *testing.T) {
 		metricsAddr: ":0",
 	}
 
-	handler, _, cleanup := buildAPIMux(pool, registry, f, logr.Discard(), nil, nil, nil)
+	handler, _, cleanup := buildAPIMux(pool, nil, registry, f, logr.Discard(), nil, nil, nil)
 	defer cleanup()
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/privacy-policy?namespace=default&agent=x", nil)
@@ -176,7 +176,7 @@ func TestBuildAPIMux_EnterpriseAuditRoutesWired(t *testing.T) {
 		metricsAddr: ":0",
 	}
 
-	handler, _, cleanup := buildAPIMux(pool, registry, f, logr.Discard(), nil, nil, nil)
+	handler, _, cleanup := buildAPIMux(pool, nil, registry, f, logr.Discard(), nil, nil, nil)
 	defer cleanup()
 
 	// Invalid 'to' parameter forces handleQuery to short-circuit with 400
@@ -206,7 +206,7 @@ func TestBuildAPIMux_NonEnterprise_AuditRoutesAbsent(t *testing.T) {
 		metricsAddr: ":0",
 	}
 
-	handler, _, cleanup := buildAPIMux(pool, registry, f, logr.Discard(), nil, nil, nil)
+	handler, _, cleanup := buildAPIMux(pool, nil, registry, f, logr.Discard(), nil, nil, nil)
 	defer cleanup()
 
 	req := httptest.NewRequest(http.MethodGet,
@@ -235,7 +235,7 @@ func TestBuildAPIMux_EnterpriseEraseRouteWired(t *testing.T) {
 		metricsAddr: ":0",
 	}
 
-	handler, _, cleanup := buildAPIMux(pool, registry, f, logr.Discard(), nil, nil, nil)
+	handler, _, cleanup := buildAPIMux(pool, nil, registry, f, logr.Discard(), nil, nil, nil)
 	defer cleanup()
 
 	// Empty body → missing virtual_user_id → the eraser fails closed with 400
@@ -269,7 +269,7 @@ func TestBuildAPIMux_DeletionRequestRouteRemoved(t *testing.T) {
 		metricsAddr: ":0",
 	}
 
-	handler, _, cleanup := buildAPIMux(pool, registry, f, logr.Discard(), nil, nil, nil)
+	handler, _, cleanup := buildAPIMux(pool, nil, registry, f, logr.Discard(), nil, nil, nil)
 	defer cleanup()
 
 	for _, path := range []string{
@@ -298,7 +298,7 @@ func TestBuildAPIMux_NonEnterprise_EraseRouteAbsent(t *testing.T) {
 		metricsAddr: ":0",
 	}
 
-	handler, _, cleanup := buildAPIMux(pool, registry, f, logr.Discard(), nil, nil, nil)
+	handler, _, cleanup := buildAPIMux(pool, nil, registry, f, logr.Discard(), nil, nil, nil)
 	defer cleanup()
 
 	req := httptest.NewRequest(http.MethodPost,
@@ -327,7 +327,7 @@ func TestBuildAPIMux_NonEnterprise_PrivacyPolicyReturns204(t *testing.T) {
 		metricsAddr: ":0",
 	}
 
-	handler, _, cleanup := buildAPIMux(pool, registry, f, logr.Discard(), nil, nil, nil)
+	handler, _, cleanup := buildAPIMux(pool, nil, registry, f, logr.Discard(), nil, nil, nil)
 	defer cleanup()
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/privacy-policy?namespace=default&agent=x", nil)
@@ -358,7 +358,7 @@ func TestBuildAPIMux_SessionRoutesWiredRegardlessOfEnterprise(t *testing.T) {
 		metricsAddr: ":0",
 	}
 
-	handler, _, cleanup := buildAPIMux(pool, registry, f, logr.Discard(), nil, nil, nil)
+	handler, _, cleanup := buildAPIMux(pool, nil, registry, f, logr.Discard(), nil, nil, nil)
 	defer cleanup()
 
 	// GET /api/v1/sessions should be registered (part of the core API handler).
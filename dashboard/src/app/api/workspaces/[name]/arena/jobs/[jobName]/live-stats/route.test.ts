@@ -0,0 +1,143 @@
+/**
+ * Tests for Arena job live-stats SSE route.
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+import { NextRequest } from "next/server";
+
+// Mock dependencies before imports
+vi.mock("@/lib/auth", () => ({
+  getUser: vi.fn(),
+}));
+
+vi.mock("@/lib/auth/workspace-authz", () => ({
+  checkWorkspaceAccess: vi.fn(),
+}));
+
+vi.mock("@/lib/k8s/workspace-route-helpers", async (importOriginal) => {
+  const actual = await importOriginal<typeof import("@/lib/k8s/workspace-route-helpers")>();
+  return {
+    ...actual,
+    getWorkspaceResource: vi.fn(),
+    createAuditContext: vi.fn(() => ({
+      workspace: "test-ws",
+      namespace: "test-ns",
+      user: { username: "testuser" },
+      role: "viewer",
+      resourceType: "ArenaJob",
+    })),
+    auditSuccess: vi.fn(),
+    auditError: vi.fn(),
+  };
+});
+
+vi.mock("@/lib/redis/client", () => ({
+  getArenaRedisClient: vi.fn(),
+}));
+
+vi.mock("@/lib/audit", () => ({
+  logError: vi.fn(),
+  logCrdSuccess: vi.fn(),
+  logCrdDenied: vi.fn(),
+  logCrdError: vi.fn(),
+}));
+
+const mockUser = {
+  id: "testuser-id",
+  provider: "oauth" as const,
+  username: "testuser",
+  email: "test@example.com",
+  groups: ["users"],
+  role: "viewer" as const,
+};
+
+const viewerPermissions = { read: true, write: false, delete: false, manageMembers: false };
+
+const mockWorkspace = {
+  metadata: { name: "test-ws", namespace: "omnia-system" },
+  spec: { namespace: { name: "test-ns" } },
+};
+
+const mockJob = {
+  metadata: { name: "eval-job-1", namespace: "test-ns" },
+  spec: { type: "evaluation" },
+};
+
+function createMockRequest(signal?: AbortSignal): NextRequest {
+  const url = "http://localhost:3000/api/workspaces/test-ws/arena/jobs/eval-job-1/live-stats";
+  return new NextRequest(url, { method: "GET", signal });
+}
+
+function createMockContext() {
+  return {
+    params: Promise.resolve({ name: "test-ws", jobName: "eval-job-1" }),
+  };
+}
+
+async function grantAccess() {
+  const { getUser } = await import("@/lib/auth");
+  const { checkWorkspaceAccess } = await import("@/lib/auth/workspace-authz");
+  const { getWorkspaceResource } = await import("@/lib/k8s/workspace-route-helpers");
+
+  vi.mocked(getUser).mockResolvedValue(mockUser);
+  vi.mocked(checkWorkspaceAccess).mockResolvedValue({ granted: true, role: "viewer", permissions: viewerPermissions });
+  vi.mocked(getWorkspaceResource).mockResolvedValue({
+    ok: true,
+    resource: mockJob,
+    workspace: mockWorkspace as any,
+    clientOptions: { workspace: "test-ws", namespace: "test-ns", role: "viewer" },
+  });
+}
+
+describe("GET /api/workspaces/[name]/arena/jobs/[jobName]/live-stats", () => {
+  beforeEach(() => {
+    vi.resetModules();
+  });
+
+  afterEach(() => {
+    vi.resetAllMocks();
+  });
+
+  it("returns 501 when Redis is not configured", async () => {
+    await grantAccess();
+    const { getArenaRedisClient } = await import("@/lib/redis/client");
+    vi.mocked(getArenaRedisClient).mockReturnValue(null);
+
+    const { GET } = await import("./route");
+    const response = await GET(createMockRequest(), createMockContext());
+
+    expect(response.status).toBe(501);
+  });
+
+  it("reads stats under the namespace-scoped job key", async () => {
+    await grantAccess();
+    const redis = {
+      hgetall: vi.fn((key: string) =>
+        Promise.resolve(
+          key === "arena:job:test-ns/eval-job-1:stats"
+            ? { total: "3", passed: "2", failed: "1", totalDurationMs: "300", totalTokens: "30", totalCost: "0.03" }
+            : {}
+        )
+      ),
+      scan: vi.fn().mockResolvedValue(["0", []]),
+    };
+    const { getArenaRedisClient } = await import("@/lib/redis/client");
+    vi.mocked(getArenaRedisClient).mockReturnValue(redis as never);
+
+    const abort = new AbortController();
+    const { GET } = await import("./route");
+    const response = await GET(createMockRequest(abort.signal), createMockContext());
+
+    expect(response.status).toBe(200);
+    expect(response.headers.get("Content-Type")).toBe("text/event-stream");
+
+    const reader = response.body!.getReader();
+    const { value } = await reader.read();
+    abort.abort();
+    const frame = new TextDecoder().decode(value);
+
+    expect(redis.hgetall).toHaveBeenCalledWith("arena:job:test-ns/eval-job-1:stats");
+    expect(frame.startsWith("data: ")).toBe(true);
+    expect(JSON.parse(frame.slice("data: ".length)).total).toBe(3);
+  });
+});
@@ -27,7 +27,7 @@ import type { WorkspaceAccess } from "@/types/workspace";
 import type { User } from "@/lib/auth/types";
 import type { ArenaJob } from "@/types/arena";
 import { getArenaRedisClient } from "@/lib/redis/client";
-import { readArenaStats } from "@/lib/redis/arena-stats";
+import { readArenaStats, scopedJobID } from "@/lib/redis/arena-stats";
 
 type RouteParams = { name: string; jobName: string };
 type RouteContext = WorkspaceRouteContext<RouteParams>;
@@ -76,6 +76,9 @@ export const GET = withWorkspaceAccess<RouteParams>(
 
       auditSuccess(auditCtx, "get", jobName, { subresource: "live-stats" });
 
+      // Workers key stats by namespace-scoped job ID (queue.ScopedJobID).
+      const jobID = scopedJobID(result.workspace.spec.namespace.name, jobName);
+
       const encoder = new TextEncoder();
       const stream = new ReadableStream({
         async start(controller) {
@@ -95,7 +98,7 @@ export const GET = withWorkspaceAccess<RouteParams>(
 
           // Send initial data frame immediately
           try {
-            const initialStats = await readArenaStats(redis, jobName);
+            const initialStats = await readArenaStats(redis, jobID);
             if (initialStats) {
               controller.enqueue(encoder.encode(`data: ${JSON.stringify(initialStats)}\n\n`));
             }
@@ -106,7 +109,7 @@ export const GET = withWorkspaceAccess<RouteParams>(
           const interval = setInterval(async () => {
             if (closed) return;
             try {
-              const stats = await readArenaStats(redis, jobName);
+              const stats = await readArenaStats(redis, jobID);
               if (stats) {
                 controller.enqueue(encoder.encode(`data: ${JSON.stringify(stats)}\n\n`));
                 consecutiveErrors = 0;
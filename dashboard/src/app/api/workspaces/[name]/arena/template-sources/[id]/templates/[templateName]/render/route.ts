@@ -12,6 +12,7 @@
 
 import { NextRequest, NextResponse } from "next/server";
 import { withWorkspaceAccess } from "@/lib/auth/workspace-guard";
+import { operatorAuthToken } from "@/lib/tooltest/operator-client";
 import { getCrd } from "@/lib/k8s/crd-operations";
 import {
   validateWorkspace,
@@ -83,9 +84,14 @@ async function renderTemplateViaController(
   projectName: string,
   variables: Record<string, unknown>
 ): Promise<{ success: boolean; filesCreated: string[]; errors: string[]; warnings: string[] }> {
+  const headers: Record<string, string> = { "Content-Type": "application/json" };
+  const token = await operatorAuthToken();
+  if (token) {
+    headers.Authorization = `Bearer ${token}`;
+  }
   const response = await fetch(`${ARENA_CONTROLLER_URL}/api/render-template`, {
     method: "POST",
-    headers: { "Content-Type": "application/json" },
+    headers,
     body: JSON.stringify({
       templatePath,
       outputPath,
@@ -0,0 +1,91 @@
+/**
+ * Tests for the MCP gateway proxy route.
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+import { NextRequest } from "next/server";
+
+vi.mock("@/lib/auth", () => ({ getUser: vi.fn() }));
+vi.mock("@/lib/auth/workspace-authz", () => ({ checkWorkspaceAccess: vi.fn() }));
+vi.mock("@/lib/data/mcp-gateway-service", async (importOriginal) => {
+  const actual = await importOriginal<typeof import("@/lib/data/mcp-gateway-service")>();
+  return { ...actual, forwardMCPRequest: vi.fn() };
+});
+
+const mockUser = {
+  id: "u1",
+  provider: "oauth" as const,
+  username: "u",
+  email: "u@example.com",
+  groups: ["users"],
+  role: "editor" as const,
+};
+const editorPerms = { read: true, write: true, delete: false, manageMembers: false };
+const noPerms = { read: false, write: false, delete: false, manageMembers: false };
+const toolsList = JSON.stringify({ jsonrpc: "2.0", id: 1, method: "tools/list" });
+
+function req(body: string): NextRequest {
+  return new NextRequest("http://localhost:3000/api/workspaces/test-ws/mcp", {
+    method: "POST",
+    headers: { "content-type": "application/json" },
+    body,
+  });
+}
+
+function ctx() {
+  return { params: Promise.resolve({ name: "test-ws" }) };
+}
+
+async function setupAccess(granted: boolean) {
+  const { getUser } = await import("@/lib/auth");
+  const { checkWorkspaceAccess } = await import("@/lib/auth/workspace-authz");
+  vi.mocked(getUser).mockResolvedValue(mockUser);
+  vi.mocked(checkWorkspaceAccess).mockResolvedValue({
+    granted,
+    role: granted ? "editor" : "viewer",
+    permissions: granted ? editorPerms : noPerms,
+  });
+}
+
+describe("POST /api/workspaces/[name]/mcp", () => {
+  beforeEach(() => vi.resetModules());
+  afterEach(() => vi.resetAllMocks());
+
+  it("forwards the JSON-RPC body and returns the gateway's response verbatim", async () => {
+    await setupAccess(true);
+    const { forwardMCPRequest } = await import("@/lib/data/mcp-gateway-service");
+    const reply = JSON.stringify({ jsonrpc: "2.0", id: 1, result: { tools: [] } });
+    vi.mocked(forwardMCPRequest).mockResolvedValue(reply);
+
+    const { POST } = await import("./route");
+    const res = await POST(req(toolsList), ctx());
+
+    expect(forwardMCPRequest).toHaveBeenCalledWith("test-ws", mockUser, toolsList);
+    expect(res.status).toBe(200);
+    expect(res.headers.get("content-type")).toBe("application/json");
+    expect(await res.text()).toBe(reply);
+  });
+
+  it("passes through the gateway's error status", async () => {
+    await setupAccess(true);
+    const { forwardMCPRequest, MCPGatewayError } = await import("@/lib/data/mcp-gateway-service");
+    vi.mocked(forwardMCPRequest).mockRejectedValue(new MCPGatewayError("forbidden", 403));
+
+    const { POST } = await import("./route");
+    const res = await POST(req(toolsList), ctx());
+
+    expect(res.status).toBe(403);
+    expect(await res.json()).toEqual({ error: "forbidden" });
+  });
+
+  it("returns 403 for a viewer and never forwards", async () => {
+    await setupAccess(false);
+    const { forwardMCPRequest } = await import("@/lib/data/mcp-gateway-service");
+
+    const { POST } = await import("./route");
+    const res = await POST(req(toolsList), ctx());
+
+    expect(res.status).toBe(403);
+    expect(forwardMCPRequest).not.toHaveBeenCalled();
+  });
+});
@@ -0,0 +1,31 @@
+/**
+ * MCP gateway endpoint.
+ *
+ * POST /api/workspaces/:name/mcp
+ *
+ * Streamable HTTP MCP endpoint for agents outside the cluster. Callers
+ * authenticate with a workspace API key (`Authorization: Bearer omnia_sk_...`)
+ * and need the editor role; each JSON-RPC message is forwarded opaquely to
+ * the operator's MCP gateway (via mcp-gateway-service), which serves the
+ * workspace's ToolRegistry tools.
+ */
+
+import { NextResponse, type NextRequest } from "next/server";
+import { withWorkspaceAccess } from "@/lib/auth/workspace-guard";
+import { forwardMCPRequest, MCPGatewayError } from "@/lib/data/mcp-gateway-service";
+
+export const POST = withWorkspaceAccess<{ name: string }>(
+  "editor",
+  async (request: NextRequest, context, _access, user) => {
+    const { name: workspace } = await context.params;
+    try {
+      const body = await forwardMCPRequest(workspace, user, await request.text());
+      return new NextResponse(body, { status: 200, headers: { "Content-Type": "application/json" } });
+    } catch (err) {
+      if (err instanceof MCPGatewayError) {
+        return NextResponse.json({ error: err.message }, { status: err.status });
+      }
+      throw err;
+    }
+  },
+);
@@ -82,9 +82,12 @@ import {
 function getStatusBadge(status: Session["status"]) {
   const variants: Record<Session["status"], { variant: "default" | "secondary" | "destructive" | "outline"; label: string }> = {
     active: { variant: "default", label: "Active" },
+    idle: { variant: "outline", label: "Idle" },
+    escalated: { variant: "default", label: "Escalated" },
     completed: { variant: "secondary", label: "Completed" },
     error: { variant: "destructive", label: "Error" },
     expired: { variant: "outline", label: "Expired" },
+    archived: { variant: "outline", label: "Archived" },
   };
   const { variant, label } = variants[status];
   return <Badge variant={variant}>{label}</Badge>;
@@ -98,9 +98,12 @@ function formatCost(cost?: number): string {
 function getStatusBadge(status: SessionSummary["status"]) {
   const variants: Record<Session["status"], { variant: "default" | "secondary" | "destructive" | "outline"; label: string }> = {
     active: { variant: "default", label: "Active" },
+    idle: { variant: "outline", label: "Idle" },
+    escalated: { variant: "default", label: "Escalated" },
     completed: { variant: "secondary", label: "Completed" },
     error: { variant: "destructive", label: "Error" },
     expired: { variant: "outline", label: "Expired" },
+    archived: { variant: "outline", label: "Archived" },
   };
   const { variant, label } = variants[status];
   return <Badge variant={variant}>{label}</Badge>;
@@ -333,9 +336,12 @@ export default function SessionsPage() {
             <SelectContent>
               <SelectItem value="all">All Status</SelectItem>
               <SelectItem value="active">Active</SelectItem>
+              <SelectItem value="idle">Idle</SelectItem>
+              <SelectItem value="escalated">Escalated</SelectItem>
               <SelectItem value="completed">Completed</SelectItem>
               <SelectItem value="error">Error</SelectItem>
               <SelectItem value="expired">Expired</SelectItem>
+              <SelectItem value="archived">Archived</SelectItem>
             </SelectContent>
           </Select>
           <Select value={agentFilter} onValueChange={(v) => { setAgentFilter(v); resetPage(); }}>
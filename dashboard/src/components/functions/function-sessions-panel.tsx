@@ -42,16 +42,22 @@ const STATUS_VARIANT: Record<
   "default" | "secondary" | "destructive" | "outline"
 > = {
   active: "secondary",
+  idle: "outline",
+  escalated: "secondary",
   completed: "default",
   error: "destructive",
   expired: "outline",
+  archived: "outline",
 };
 
 const STATUS_LABEL: Record<SessionSummary["status"], string> = {
   active: "Active",
+  idle: "Idle",
+  escalated: "Escalated",
   completed: "Completed",
   error: "Error",
   expired: "Expired",
+  archived: "Archived",
 };
 
 /** durationMs computes the runtime of a session from its start/end
@@ -1010,7 +1010,7 @@ describe("ProviderDialog", () => {
 
       const typeSelect = screen.getByLabelText("Provider Type");
       fireEvent.click(typeSelect);
-      const openaiOption = screen.getByRole("option", { name: /OpenAI/i });
+      const openaiOption = screen.getByRole("option", { name: /^OpenAI$/i });
       fireEvent.click(openaiOption);
 
       expect(screen.getByText("Credential Source")).toBeInTheDocument();
@@ -1152,6 +1152,36 @@ describe("ProviderDialog", () => {
       expect(mockCreateProvider).not.toHaveBeenCalled();
     });
 
+    it("rejects openai-compatible without a base URL", async () => {
+      vi.useRealTimers();
+      const user = userEvent.setup();
+
+      render(
+        <TestWrapper>
+          <ProviderDialog open={true} onOpenChange={vi.fn()} />
+        </TestWrapper>
+      );
+
+      await user.type(screen.getByLabelText("Name"), "local-llm");
+
+      fireEvent.click(screen.getByLabelText("Provider Type"));
+      fireEvent.click(await screen.findByRole("option", { name: /OpenAI-compatible/i }));
+
+      // Keys are optional for self-hosted servers, like other local types.
+      expect(screen.queryByText("Credential Source")).not.toBeInTheDocument();
+
+      await user.type(screen.getByLabelText("Model"), "llama3.1");
+
+      fireEvent.click(screen.getByRole("button", { name: /create provider/i }));
+
+      await waitFor(() => {
+        expect(
+          screen.getByText(/Base URL is required for openai-compatible providers/i)
+        ).toBeInTheDocument();
+      });
+      expect(mockCreateProvider).not.toHaveBeenCalled();
+    });
+
     it("only shows bedrock-compatible auth options for bedrock", async () => {
       render(
         <TestWrapper>
@@ -105,6 +105,7 @@ const PROVIDER_TYPES: { value: ProviderSpec["type"]; label: string }[] = [
   { value: "openai", label: "OpenAI" },
   { value: "gemini", label: "Gemini (Google)" },
   { value: "vllm", label: "vLLM" },
+  { value: "openai-compatible", label: "OpenAI-compatible (self-hosted)" },
   { value: "voyageai", label: "Voyage AI" },
   { value: "cartesia", label: "Cartesia" },
   { value: "elevenlabs", label: "ElevenLabs" },
@@ -114,7 +115,7 @@ const PROVIDER_TYPES: { value: ProviderSpec["type"]; label: string }[] = [
   { value: "mock", label: "Mock (Testing)" },
 ];
 
-const LOCAL_TYPES: Set<ProviderSpec["type"]> = new Set(["ollama", "mock", "vllm"]);
+const LOCAL_TYPES: Set<ProviderSpec["type"]> = new Set(["ollama", "mock", "vllm", "openai-compatible"]);
 
 const PLATFORM_ELIGIBLE_TYPES: Set<ProviderSpec["type"]> = new Set([
   "claude",
@@ -134,7 +135,7 @@ const ROLE_OPTIONS: { value: ProviderRole; label: string; description: string }[
 // Mirrors the CRD CEL matrix (api/v1alpha1/provider_types.go). Keep in sync
 // when PromptKit adds vendor support for additional roles.
 const VENDORS_BY_ROLE: Record<ProviderRole, readonly ProviderSpec["type"][]> = {
-  llm: ["claude", "openai", "gemini", "ollama", "mock", "vllm"],
+  llm: ["claude", "openai", "gemini", "ollama", "mock", "vllm", "openai-compatible"],
   embedding: ["openai", "voyageai", "gemini", "ollama"],
   tts: ["openai", "cartesia", "elevenlabs"],
   stt: ["openai"],
@@ -417,6 +418,10 @@ function validateCrossFields(form: FormState): string | null {
   if (form.role !== "llm" && form.platformType) {
     return "Hosting platform is only valid when role is llm";
   }
+  // Self-hosted OpenAI-compatible servers have no default endpoint.
+  if (form.providerType === "openai-compatible" && !form.baseURL.trim()) {
+    return "Base URL is required for openai-compatible providers";
+  }
 
   const credentialError = validateActiveCredential(form);
   if (credentialError) return credentialError;
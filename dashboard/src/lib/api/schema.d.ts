@@ -832,7 +832,7 @@ export interface components {
             maxTokens?: number;
             contextWindow?: number;
             /** @enum {string} */
-            truncationStrategy?: "sliding" | "summarize" | "custom";
+            truncationStrategy?: "sliding" | "summarize" | "relevance" | "custom";
         };
         ProviderPricing: {
             inputCostPer1K?: string;
@@ -857,6 +857,7 @@ export interface components {
         AgentPolicySpec: {
             selector?: {
                 agents?: string[];
+                workspaces?: string[];
             };
             claimMapping?: {
                 forwardClaims?: {
@@ -872,6 +873,16 @@ export interface components {
                     tools: string[];
                 }[];
             };
+            transform?: {
+                systemPromptPrefix?: string;
+                headers?: {
+                    [key: string]: string;
+                };
+                stripParams?: string[];
+                modelRewrites?: {
+                    [key: string]: string;
+                };
+            };
             /**
              * @default enforce
              * @enum {string}
@@ -902,7 +913,18 @@ export interface components {
                 registry: string;
                 tools?: string[];
             };
-            rules: {
+            /**
+             * @default cel
+             * @enum {string}
+             */
+            engine: "cel" | "opa";
+            opa?: {
+                url: string;
+                path: string;
+                /** @default 2s */
+                timeout: string;
+            };
+            rules?: {
                 name: string;
                 description?: string;
                 deny: {
@@ -918,7 +940,7 @@ export interface components {
              * @default enforce
              * @enum {string}
              */
-            mode: "enforce" | "audit";
+            mode: "enforce" | "shadow" | "audit";
             /**
              * @default deny
              * @enum {string}
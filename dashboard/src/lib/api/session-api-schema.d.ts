@@ -93,6 +93,52 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
+    "/api/v1/sessions/{sessionID}/attachments": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List a session's attachments
+         * @description Returns the attachment references carried by the session's messages,
+         *     in the order they were attached. Sessions that have moved to the cold
+         *     archive keep their references.
+         */
+        get: operations["listAttachments"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/api/v1/sessions/{sessionID}/attachments/{attachmentID}/url": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Resolve an attachment to a download URL
+         * @description Returns the attachment with a URL its content can be downloaded from.
+         *     http(s) storage URIs are returned as they are; media storage
+         *     references are resolved to a short-lived presigned URL. 404 when the
+         *     attachment is unknown or its content has expired; 503 when the
+         *     attachment lives in media storage and none is configured.
+         */
+        get: operations["getAttachmentURL"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
     "/api/v1/sessions/{sessionID}/status": {
         parameters: {
             query?: never;
@@ -110,6 +156,53 @@ export interface paths {
         patch: operations["updateStatus"];
         trace?: never;
     };
+    "/api/v1/sessions/{sessionID}/transition": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Move a session to a new lifecycle status
+         * @description Applies one lifecycle transition. A transition the session's current
+         *     status does not allow is rejected with 409, unlike the status update,
+         *     which ignores it. Moving to completed, error or expired ends the session.
+         */
+        post: operations["transitionStatus"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/api/v1/sessions/{sessionID}/fork": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Fork a session
+         * @description Creates an active session that continues this one: it starts with
+         *     copies of its messages up to and including fromMessage (all of them
+         *     when omitted) and takes its agent, workspace, PromptPack, user, tags
+         *     and state. The original session is not modified. Tool calls, provider
+         *     calls, runtime events and eval results are not copied. List a
+         *     session's forks with the parentSessionId filter.
+         */
+        post: operations["forkSession"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
     "/api/v1/sessions/{sessionID}/ttl": {
         parameters: {
             query?: never;
@@ -253,6 +346,63 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
+    "/api/v1/sessions/{sessionID}/messages/{messageID}/annotations": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Annotate a message
+         * @description Records end-user feedback (a thumbs up or down score), reviewer labels and/or a suggested correction on one message. At least one of score, labels or correction is required.
+         */
+        post: operations["recordAnnotation"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/api/v1/sessions/{sessionID}/annotations": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /** Get the annotations on a session's messages */
+        get: operations["getSessionAnnotations"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/api/v1/annotations": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List annotations with filters
+         * @description Lists a namespace's annotations, newest first. Filter on label and hasCorrection to collect corrected answers for eval datasets.
+         */
+        get: operations["listAnnotations"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
     "/api/v1/privacy-policy": {
         parameters: {
             query?: never;
@@ -280,10 +430,17 @@ export type webhooks = Record<string, never>;
 export interface components {
     schemas: {
         ErrorResponse: {
+            /** @description Error message, safe to show a user */
             error: string;
+            /** @description Machine-readable error code (e.g. SESSION_NOT_FOUND, INVALID_REQUEST, RATE_LIMITED) */
+            code: string;
+            /** @description Whether sending the request again, after a backoff, may succeed */
+            retryable: boolean;
+            /** @description Identifies the request in server logs; echoed in the X-Omnia-Request-ID response header */
+            correlation_id?: string;
         };
         /** @enum {string} */
-        SessionStatus: "active" | "completed" | "error" | "expired";
+        SessionStatus: "active" | "idle" | "escalated" | "completed" | "error" | "expired" | "archived";
         /** @enum {string} */
         MessageRole: "user" | "assistant" | "system";
         /** @enum {string} */
@@ -329,6 +486,10 @@ export interface components {
             variant?: string;
             /** @description Virtual (synthetic) user the session is attributed to */
             virtualUserId?: string;
+            /** @description Session this one was forked from */
+            parentSessionId?: string;
+            /** @description Last parent message copied into this fork; absent when the whole history was copied */
+            forkedFromMessageId?: string;
         };
         Message: {
             id?: string;
@@ -350,6 +511,54 @@ export interface components {
             sequenceNum?: number;
             hasMedia?: boolean;
             mediaTypes?: string[];
+            /**
+             * @description References to files carried by the message. Only the reference
+             *     is stored; resolve it with the attachment URL endpoint.
+             */
+            attachments?: components["schemas"]["Attachment"][];
+        };
+        Attachment: {
+            /** Format: uuid */
+            id?: string;
+            /** Format: uuid */
+            messageId?: string;
+            /** Format: uuid */
+            sessionId?: string;
+            /**
+             * @description One of image, audio, video, document or file. Derived from
+             *     mimeType when omitted.
+             */
+            type?: string;
+            mimeType: string;
+            /** @description Media storage reference (omnia://...) or http(s) URL */
+            storageUri: string;
+            /** Format: int64 */
+            sizeBytes?: number;
+            filename?: string;
+            /** @description SHA-256 checksum of the content */
+            checksum?: string;
+            metadata?: {
+                [key: string]: string;
+            };
+            /** Format: int32 */
+            width?: number;
+            /** Format: int32 */
+            height?: number;
+            /** Format: int32 */
+            durationMs?: number;
+            /** Format: int32 */
+            channels?: number;
+            /** Format: int32 */
+            sampleRate?: number;
+            /** Format: date-time */
+            createdAt?: string;
+        };
+        AttachmentsResponse: {
+            attachments?: components["schemas"]["Attachment"][];
+        };
+        AttachmentURLResponse: {
+            attachment?: components["schemas"]["Attachment"];
+            url?: string;
         };
         ToolCall: {
             id?: string;
@@ -490,6 +699,15 @@ export interface components {
             /** @description Virtual (synthetic) user the session is attributed to (required, non-empty) */
             virtualUserId?: string;
         };
+        TransitionSessionRequest: {
+            status: components["schemas"]["SessionStatus"];
+        };
+        TransitionSessionResponse: {
+            /** Format: uuid */
+            sessionId?: string;
+            status?: components["schemas"]["SessionStatus"];
+            previousStatus?: components["schemas"]["SessionStatus"];
+        };
         RefreshTTLRequest: {
             ttlSeconds: number;
         };
@@ -521,6 +739,38 @@ export interface components {
             sessionId?: string;
             message?: string;
         };
+        MessageAnnotation: {
+            /** Format: uuid */
+            id?: string;
+            /** Format: uuid */
+            sessionId?: string;
+            /** Format: uuid */
+            messageId?: string;
+            namespace?: string;
+            agentName?: string;
+            /** @description user (end-user feedback, the default) or reviewer */
+            source?: string;
+            author?: string;
+            /** @description Thumbs down (-1) or up (1) */
+            score?: number;
+            labels?: string[];
+            /** @description Suggested replacement for the message content */
+            correction?: string;
+            /** Format: date-time */
+            createdAt?: string;
+        };
+        AnnotationResponse: {
+            annotation?: components["schemas"]["MessageAnnotation"];
+        };
+        AnnotationListResponse: {
+            annotations?: components["schemas"]["MessageAnnotation"][];
+            /**
+             * Format: int64
+             * @description Matching annotations (namespace listing only)
+             */
+            total?: number;
+            hasMore?: boolean;
+        };
         /**
          * @description Facade-visible subset of the effective SessionPrivacyPolicy. Only
          *     recording flags are exposed; PII, retention, and encryption fields
@@ -561,6 +811,15 @@ export interface components {
                 "application/json": components["schemas"]["ErrorResponse"];
             };
         };
+        /** @description Request conflicts with the resource's current state */
+        Conflict: {
+            headers: {
+                [name: string]: unknown;
+            };
+            content: {
+                "application/json": components["schemas"]["ErrorResponse"];
+            };
+        };
         /** @description Request body too large */
         BodyTooLarge: {
             headers: {
@@ -591,6 +850,8 @@ export interface components {
         Agent: string;
         /** @description Filter by session status */
         StatusFilter: components["schemas"]["SessionStatus"];
+        /** @description List only the forks of this session */
+        ParentSessionFilter: string;
         /** @description Filter sessions created after this time (RFC3339) */
         From: string;
         /** @description Filter sessions created before this time (RFC3339) */
@@ -637,6 +898,8 @@ export interface operations {
                 agent?: components["parameters"]["Agent"];
                 /** @description Filter by session status */
                 status?: components["parameters"]["StatusFilter"];
+                /** @description List only the forks of this session */
+                parentSessionId?: components["parameters"]["ParentSessionFilter"];
                 /** @description Filter sessions created after this time (RFC3339) */
                 from?: components["parameters"]["From"];
                 /** @description Filter sessions created before this time (RFC3339) */
@@ -845,6 +1108,66 @@ export interface operations {
             500: components["responses"]["InternalError"];
         };
     };
+    listAttachments: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Session UUID */
+                sessionID: components["parameters"]["SessionID"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Attachments */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AttachmentsResponse"];
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            404: components["responses"]["NotFound"];
+            500: components["responses"]["InternalError"];
+        };
+    };
+    getAttachmentURL: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Session UUID */
+                sessionID: components["parameters"]["SessionID"];
+                attachmentID: string;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Resolved attachment */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AttachmentURLResponse"];
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            404: components["responses"]["NotFound"];
+            500: components["responses"]["InternalError"];
+            /** @description Media storage not configured */
+            503: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+        };
+    };
     updateStatus: {
         parameters: {
             query?: never;
@@ -874,6 +1197,74 @@ export interface operations {
             500: components["responses"]["InternalError"];
         };
     };
+    transitionStatus: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Session UUID */
+                sessionID: components["parameters"]["SessionID"];
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["TransitionSessionRequest"];
+            };
+        };
+        responses: {
+            /** @description Session transitioned */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TransitionSessionResponse"];
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            404: components["responses"]["NotFound"];
+            409: components["responses"]["Conflict"];
+            413: components["responses"]["BodyTooLarge"];
+            500: components["responses"]["InternalError"];
+        };
+    };
+    forkSession: {
+        parameters: {
+            query?: {
+                /** @description ID of the last message to copy into the fork */
+                fromMessage?: string;
+            };
+            header?: never;
+            path: {
+                /** @description Session UUID */
+                sessionID: components["parameters"]["SessionID"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Fork created */
+            201: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["SessionResponse"];
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            404: components["responses"]["NotFound"];
+            500: components["responses"]["InternalError"];
+            /** @description Warm store does not support forking */
+            503: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+        };
+    };
     refreshTTL: {
         parameters: {
             query?: never;
@@ -1220,6 +1611,125 @@ export interface operations {
             };
         };
     };
+    recordAnnotation: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Session UUID */
+                sessionID: components["parameters"]["SessionID"];
+                /** @description Message UUID */
+                messageID: string;
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["MessageAnnotation"];
+            };
+        };
+        responses: {
+            /** @description Annotation recorded */
+            201: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AnnotationResponse"];
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            404: components["responses"]["NotFound"];
+            /** @description Annotation store not configured */
+            503: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            500: components["responses"]["InternalError"];
+        };
+    };
+    getSessionAnnotations: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Session UUID */
+                sessionID: components["parameters"]["SessionID"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Session annotations, oldest first */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AnnotationListResponse"];
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            /** @description Annotation store not configured */
+            503: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            500: components["responses"]["InternalError"];
+        };
+    };
+    listAnnotations: {
+        parameters: {
+            query: {
+                /** @description Kubernetes namespace */
+                namespace: string;
+                /** @description Filter by agent name */
+                agentName?: string;
+                /** @description Filter by annotation source (user or reviewer) */
+                source?: string;
+                /** @description Only annotations carrying this label */
+                label?: string;
+                /** @description Only annotations with a suggested correction */
+                hasCorrection?: boolean;
+                /** @description Filter annotations created at or after this time (RFC3339) */
+                from?: string;
+                /** @description Filter annotations created before this time (RFC3339) */
+                to?: string;
+                /** @description Maximum items to return (default 20, max 100) */
+                limit?: components["parameters"]["Limit"];
+                /** @description Number of items to skip (max 10000) */
+                offset?: components["parameters"]["Offset"];
+            };
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Annotation list */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AnnotationListResponse"];
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            /** @description Annotation store not configured */
+            503: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            500: components["responses"]["InternalError"];
+        };
+    };
     getPrivacyPolicy: {
         parameters: {
             query: {
@@ -0,0 +1,45 @@
+/**
+ * Client for the operator's MCP gateway.
+ *
+ * External MCP clients (IDE agents, other agent frameworks) authenticate to
+ * the dashboard with a workspace API key; this service forwards their
+ * JSON-RPC request body opaquely to the operator's
+ * `POST /api/v1/workspaces/{workspace}/mcp` endpoint with a short-lived
+ * identity JWT for the authenticated user. The operator recomputes the
+ * workspace role, enforces ToolPolicy and audits each tool call.
+ *
+ * Server-only: reads the signing key off disk and never runs in the browser.
+ */
+
+import type { User } from "@/lib/auth/types";
+import { OperatorApiError, asOperatorError, mintOperatorIdentityToken, operatorBaseURL } from "./operator-identity";
+
+/** Error carrying the operator's HTTP status so the route can pass it through. */
+export class MCPGatewayError extends OperatorApiError {
+  constructor(message: string, status: number) {
+    super(message, status);
+    this.name = "MCPGatewayError";
+  }
+}
+
+const asGatewayError = <T>(fn: () => T): T =>
+  asOperatorError(fn, (message, status) => new MCPGatewayError(message, status));
+
+/**
+ * POST one MCP JSON-RPC message to the operator gateway for the given
+ * workspace and return its JSON-RPC response body verbatim.
+ */
+export async function forwardMCPRequest(workspace: string, user: User, body: string): Promise<string> {
+  const token = asGatewayError(() => mintOperatorIdentityToken(workspace, user));
+  const base = asGatewayError(() => operatorBaseURL("OPERATOR_MCP_GATEWAY_URL"));
+  const url = `${base}/api/v1/workspaces/${encodeURIComponent(workspace)}/mcp`;
+  const res = await fetch(url, {
+    method: "POST",
+    headers: { Authorization: `Bearer ${token}`, "Content-Type": "application/json" },
+    body,
+  });
+  if (!res.ok) {
+    throw new MCPGatewayError(`MCP gateway POST ${url} -> ${res.status}`, res.status);
+  }
+  return res.text();
+}
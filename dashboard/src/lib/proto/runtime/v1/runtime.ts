@@ -143,7 +143,14 @@ export interface ClientMessage {
     | DuplexStart
     | undefined;
   /** audio_input carries one inbound audio frame during a duplex session. */
-  audioInput?: AudioInputChunk | undefined;
+  audioInput?:
+    | AudioInputChunk
+    | undefined;
+  /**
+   * progress asks the runtime to send Progress frames during this turn. The
+   * facade sets it only when its client displays them.
+   */
+  progress: boolean;
 }
 
 export interface ClientMessage_MetadataEntry {
@@ -206,6 +213,12 @@ export interface ServerMessage {
      * facade proceeds with today's unilateral DuplexStart behaviour.
      */
     { $case: "runtimeHello"; runtimeHello: RuntimeHello }
+    | //
+    /**
+     * progress reports what the runtime is doing during a turn. Sent only
+     * when the turn's ClientMessage set progress.
+     */
+    { $case: "progress"; progress: Progress }
     | undefined;
 }
 
@@ -332,6 +345,30 @@ export interface MediaChunk {
   data: Uint8Array;
 }
 
+/**
+ * Progress reports a step of a turn, so clients can show what the agent is
+ * doing instead of a spinner. It is informational: the outcome of the turn is
+ * still carried by chunk, done and error.
+ */
+export interface Progress {
+  /**
+   * stage is the kind of step: "thinking" (waiting on the model), "tool"
+   * (running a server-side tool) or "retrieval" (querying knowledge).
+   */
+  stage: string;
+  /**
+   * status is "started", "running" (sent periodically while a tool runs),
+   * "completed" or "failed".
+   */
+  status: string;
+  /** tool_name names the tool, for the tool stage. */
+  toolName: string;
+  /** call_id identifies the tool call, for the tool stage. */
+  callId: string;
+  /** elapsed_ms is how long the step has run, in milliseconds. */
+  elapsedMs: number;
+}
+
 /** InvocationRequest carries a Function call from the facade. */
 export interface InvocationRequest {
   /**
@@ -477,6 +514,34 @@ export interface AudioInputChunk {
   isLast: boolean;
 }
 
+/** EmbedRequest asks for one embedding vector per text. */
+export interface EmbedRequest {
+  /**
+   * texts to embed. Must be non-empty; the runtime batches to the provider's
+   * limits itself.
+   */
+  texts: string[];
+  /** model optionally overrides the embedding provider's configured model. */
+  model: string;
+}
+
+/** Embedding is one embedding vector. */
+export interface Embedding {
+  values: number[];
+}
+
+/** EmbedResponse carries the vectors for an EmbedRequest. */
+export interface EmbedResponse {
+  /** embeddings holds one vector per request text, in request order. */
+  embeddings: Embedding[];
+  /** model is the embedding model that produced the vectors. */
+  model: string;
+  /** dimensions is the length of each vector. */
+  dimensions: number;
+  /** total_tokens is the provider-reported token usage, when available. */
+  totalTokens: number;
+}
+
 function createBaseClientMessage(): ClientMessage {
   return {
     sessionId: "",
@@ -487,6 +552,7 @@ function createBaseClientMessage(): ClientMessage {
     consentGrants: [],
     duplexStart: undefined,
     audioInput: undefined,
+    progress: false,
   };
 }
 
@@ -516,6 +582,9 @@ export const ClientMessage: MessageFns<ClientMessage> = {
     if (message.audioInput !== undefined) {
       AudioInputChunk.encode(message.audioInput, writer.uint32(66).fork()).join();
     }
+    if (message.progress !== false) {
+      writer.uint32(72).bool(message.progress);
+    }
     return writer;
   },
 
@@ -593,6 +662,14 @@ export const ClientMessage: MessageFns<ClientMessage> = {
           message.audioInput = AudioInputChunk.decode(reader, reader.uint32());
           continue;
         }
+        case 9: {
+          if (tag !== 72) {
+            break;
+          }
+
+          message.progress = reader.bool();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -624,6 +701,7 @@ export const ClientMessage: MessageFns<ClientMessage> = {
         : [],
       duplexStart: isSet(object.duplex_start) ? DuplexStart.fromJSON(object.duplex_start) : undefined,
       audioInput: isSet(object.audio_input) ? AudioInputChunk.fromJSON(object.audio_input) : undefined,
+      progress: isSet(object.progress) ? globalThis.Boolean(object.progress) : false,
     };
   },
 
@@ -659,6 +737,9 @@ export const ClientMessage: MessageFns<ClientMessage> = {
     if (message.audioInput !== undefined) {
       obj.audio_input = AudioInputChunk.toJSON(message.audioInput);
     }
+    if (message.progress !== false) {
+      obj.progress = message.progress;
+    }
     return obj;
   },
 
@@ -689,6 +770,7 @@ export const ClientMessage: MessageFns<ClientMessage> = {
     message.audioInput = (object.audioInput !== undefined && object.audioInput !== null)
       ? AudioInputChunk.fromPartial(object.audioInput)
       : undefined;
+    message.progress = object.progress ?? false;
     return message;
   },
 };
@@ -905,6 +987,9 @@ export const ServerMessage: MessageFns<ServerMessage> = {
       case "runtimeHello":
         RuntimeHello.encode(message.message.runtimeHello, writer.uint32(66).fork()).join();
         break;
+      case "progress":
+        Progress.encode(message.message.progress, writer.uint32(74).fork()).join();
+        break;
     }
     return writer;
   },
@@ -972,6 +1057,14 @@ export const ServerMessage: MessageFns<ServerMessage> = {
           message.message = { $case: "runtimeHello", runtimeHello: RuntimeHello.decode(reader, reader.uint32()) };
           continue;
         }
+        case 9: {
+          if (tag !== 74) {
+            break;
+          }
+
+          message.message = { $case: "progress", progress: Progress.decode(reader, reader.uint32()) };
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -997,6 +1090,8 @@ export const ServerMessage: MessageFns<ServerMessage> = {
         ? { $case: "interruption", interruption: Interruption.fromJSON(object.interruption) }
         : isSet(object.runtime_hello)
         ? { $case: "runtimeHello", runtimeHello: RuntimeHello.fromJSON(object.runtime_hello) }
+        : isSet(object.progress)
+        ? { $case: "progress", progress: Progress.fromJSON(object.progress) }
         : undefined,
     };
   },
@@ -1017,6 +1112,8 @@ export const ServerMessage: MessageFns<ServerMessage> = {
       obj.interruption = Interruption.toJSON(message.message.interruption);
     } else if (message.message?.$case === "runtimeHello") {
       obj.runtime_hello = RuntimeHello.toJSON(message.message.runtimeHello);
+    } else if (message.message?.$case === "progress") {
+      obj.progress = Progress.toJSON(message.message.progress);
     }
     return obj;
   },
@@ -1075,6 +1172,12 @@ export const ServerMessage: MessageFns<ServerMessage> = {
         }
         break;
       }
+      case "progress": {
+        if (object.message?.progress !== undefined && object.message?.progress !== null) {
+          message.message = { $case: "progress", progress: Progress.fromPartial(object.message.progress) };
+        }
+        break;
+      }
     }
     return message;
   },
@@ -2001,6 +2104,130 @@ export const MediaChunk: MessageFns<MediaChunk> = {
   },
 };
 
+function createBaseProgress(): Progress {
+  return { stage: "", status: "", toolName: "", callId: "", elapsedMs: 0 };
+}
+
+export const Progress: MessageFns<Progress> = {
+  encode(message: Progress, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.stage !== "") {
+      writer.uint32(10).string(message.stage);
+    }
+    if (message.status !== "") {
+      writer.uint32(18).string(message.status);
+    }
+    if (message.toolName !== "") {
+      writer.uint32(26).string(message.toolName);
+    }
+    if (message.callId !== "") {
+      writer.uint32(34).string(message.callId);
+    }
+    if (message.elapsedMs !== 0) {
+      writer.uint32(40).int64(message.elapsedMs);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): Progress {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseProgress();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.stage = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.status = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.toolName = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.callId = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.elapsedMs = longToNumber(reader.int64());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Progress {
+    return {
+      stage: isSet(object.stage) ? globalThis.String(object.stage) : "",
+      status: isSet(object.status) ? globalThis.String(object.status) : "",
+      toolName: isSet(object.tool_name) ? globalThis.String(object.tool_name) : "",
+      callId: isSet(object.call_id) ? globalThis.String(object.call_id) : "",
+      elapsedMs: isSet(object.elapsed_ms) ? globalThis.Number(object.elapsed_ms) : 0,
+    };
+  },
+
+  toJSON(message: Progress): unknown {
+    const obj: any = {};
+    if (message.stage !== "") {
+      obj.stage = message.stage;
+    }
+    if (message.status !== "") {
+      obj.status = message.status;
+    }
+    if (message.toolName !== "") {
+      obj.tool_name = message.toolName;
+    }
+    if (message.callId !== "") {
+      obj.call_id = message.callId;
+    }
+    if (message.elapsedMs !== 0) {
+      obj.elapsed_ms = Math.round(message.elapsedMs);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<Progress>, I>>(base?: I): Progress {
+    return Progress.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<Progress>, I>>(object: I): Progress {
+    const message = createBaseProgress();
+    message.stage = object.stage ?? "";
+    message.status = object.status ?? "";
+    message.toolName = object.toolName ?? "";
+    message.callId = object.callId ?? "";
+    message.elapsedMs = object.elapsedMs ?? 0;
+    return message;
+  },
+};
+
 function createBaseInvocationRequest(): InvocationRequest {
   return { inputJson: "", invocationId: "", metadata: {} };
 }
@@ -2995,6 +3222,264 @@ export const AudioInputChunk: MessageFns<AudioInputChunk> = {
   },
 };
 
+function createBaseEmbedRequest(): EmbedRequest {
+  return { texts: [], model: "" };
+}
+
+export const EmbedRequest: MessageFns<EmbedRequest> = {
+  encode(message: EmbedRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.texts) {
+      writer.uint32(10).string(v!);
+    }
+    if (message.model !== "") {
+      writer.uint32(18).string(message.model);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): EmbedRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEmbedRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.texts.push(reader.string());
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.model = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): EmbedRequest {
+    return {
+      texts: globalThis.Array.isArray(object?.texts) ? object.texts.map((e: any) => globalThis.String(e)) : [],
+      model: isSet(object.model) ? globalThis.String(object.model) : "",
+    };
+  },
+
+  toJSON(message: EmbedRequest): unknown {
+    const obj: any = {};
+    if (message.texts?.length) {
+      obj.texts = message.texts;
+    }
+    if (message.model !== "") {
+      obj.model = message.model;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<EmbedRequest>, I>>(base?: I): EmbedRequest {
+    return EmbedRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<EmbedRequest>, I>>(object: I): EmbedRequest {
+    const message = createBaseEmbedRequest();
+    message.texts = object.texts?.map((e) => e) || [];
+    message.model = object.model ?? "";
+    return message;
+  },
+};
+
+function createBaseEmbedding(): Embedding {
+  return { values: [] };
+}
+
+export const Embedding: MessageFns<Embedding> = {
+  encode(message: Embedding, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    writer.uint32(10).fork();
+    for (const v of message.values) {
+      writer.float(v);
+    }
+    writer.join();
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): Embedding {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEmbedding();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag === 13) {
+            message.values.push(reader.float());
+
+            continue;
+          }
+
+          if (tag === 10) {
+            const end2 = reader.uint32() + reader.pos;
+            while (reader.pos < end2) {
+              message.values.push(reader.float());
+            }
+
+            continue;
+          }
+
+          break;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Embedding {
+    return {
+      values: globalThis.Array.isArray(object?.values) ? object.values.map((e: any) => globalThis.Number(e)) : [],
+    };
+  },
+
+  toJSON(message: Embedding): unknown {
+    const obj: any = {};
+    if (message.values?.length) {
+      obj.values = message.values;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<Embedding>, I>>(base?: I): Embedding {
+    return Embedding.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<Embedding>, I>>(object: I): Embedding {
+    const message = createBaseEmbedding();
+    message.values = object.values?.map((e) => e) || [];
+    return message;
+  },
+};
+
+function createBaseEmbedResponse(): EmbedResponse {
+  return { embeddings: [], model: "", dimensions: 0, totalTokens: 0 };
+}
+
+export const EmbedResponse: MessageFns<EmbedResponse> = {
+  encode(message: EmbedResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.embeddings) {
+      Embedding.encode(v!, writer.uint32(10).fork()).join();
+    }
+    if (message.model !== "") {
+      writer.uint32(18).string(message.model);
+    }
+    if (message.dimensions !== 0) {
+      writer.uint32(24).int32(message.dimensions);
+    }
+    if (message.totalTokens !== 0) {
+      writer.uint32(32).int32(message.totalTokens);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): EmbedResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEmbedResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.embeddings.push(Embedding.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.model = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.dimensions = reader.int32();
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.totalTokens = reader.int32();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): EmbedResponse {
+    return {
+      embeddings: globalThis.Array.isArray(object?.embeddings)
+        ? object.embeddings.map((e: any) => Embedding.fromJSON(e))
+        : [],
+      model: isSet(object.model) ? globalThis.String(object.model) : "",
+      dimensions: isSet(object.dimensions) ? globalThis.Number(object.dimensions) : 0,
+      totalTokens: isSet(object.total_tokens) ? globalThis.Number(object.total_tokens) : 0,
+    };
+  },
+
+  toJSON(message: EmbedResponse): unknown {
+    const obj: any = {};
+    if (message.embeddings?.length) {
+      obj.embeddings = message.embeddings.map((e) => Embedding.toJSON(e));
+    }
+    if (message.model !== "") {
+      obj.model = message.model;
+    }
+    if (message.dimensions !== 0) {
+      obj.dimensions = Math.round(message.dimensions);
+    }
+    if (message.totalTokens !== 0) {
+      obj.total_tokens = Math.round(message.totalTokens);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<EmbedResponse>, I>>(base?: I): EmbedResponse {
+    return EmbedResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<EmbedResponse>, I>>(object: I): EmbedResponse {
+    const message = createBaseEmbedResponse();
+    message.embeddings = object.embeddings?.map((e) => Embedding.fromPartial(e)) || [];
+    message.model = object.model ?? "";
+    message.dimensions = object.dimensions ?? 0;
+    message.totalTokens = object.totalTokens ?? 0;
+    return message;
+  },
+};
+
 function bytesFromBase64(b64: string): Uint8Array {
   if ((globalThis as any).Buffer) {
     return Uint8Array.from(globalThis.Buffer.from(b64, "base64"));
@@ -3033,6 +3518,17 @@ type KeysOfUnion<T> = T extends T ? keyof T : never;
 type Exact<P, I extends P> = P extends Builtin ? P
   : P & { [K in keyof P]: Exact<P[K], I[K]> } & { [K in Exclude<keyof I, KeysOfUnion<P>>]: never };
 
+function longToNumber(int64: { toString(): string }): number {
+  const num = globalThis.Number(int64.toString());
+  if (num > globalThis.Number.MAX_SAFE_INTEGER) {
+    throw new globalThis.Error("Value is larger than Number.MAX_SAFE_INTEGER");
+  }
+  if (num < globalThis.Number.MIN_SAFE_INTEGER) {
+    throw new globalThis.Error("Value is smaller than Number.MIN_SAFE_INTEGER");
+  }
+  return num;
+}
+
 function isObject(value: any): boolean {
   return typeof value === "object" && value !== null;
 }
@@ -1,5 +1,5 @@
 import { describe, it, expect, vi, beforeEach } from "vitest";
-import { parseMainHash, parseGroupHash, readArenaStats } from "./arena-stats";
+import { parseMainHash, parseGroupHash, readArenaStats, scopedJobID } from "./arena-stats";
 
 describe("parseMainHash", () => {
   it("parses a full stats hash", () => {
@@ -240,4 +240,36 @@ describe("readArenaStats", () => {
     expect(result).not.toBeNull();
     expect(Object.keys(result!.byProvider)).toHaveLength(0);
   });
+
+  it("reads namespace-scoped job keys", async () => {
+    const redis = {
+      hgetall: vi.fn((key: string) =>
+        Promise.resolve(
+          key === "arena:job:team-a/test-job:stats"
+            ? { total: "4", passed: "4", failed: "0", totalDurationMs: "400", totalTokens: "40", totalCost: "0.04" }
+            : key === "arena:job:team-a/test-job:stats:provider:p1"
+              ? { total: "4", passed: "4", failed: "0", totalDurationMs: "400", totalTokens: "40", totalCost: "0.04" }
+              : {}
+        )
+      ),
+      scan: vi.fn().mockResolvedValue(["0", ["arena:job:team-a/test-job:stats:provider:p1"]]),
+    };
+
+    const result = await readArenaStats(redis as never, scopedJobID("team-a", "test-job"));
+
+    expect(result).not.toBeNull();
+    expect(result!.total).toBe(4);
+    expect(result!.byProvider["p1"].total).toBe(4);
+    expect(redis.scan).toHaveBeenCalledWith("0", "MATCH", "arena:job:team-a/test-job:stats:provider:*", "COUNT", 100);
+  });
+});
+
+describe("scopedJobID", () => {
+  it("prefixes the job name with its namespace", () => {
+    expect(scopedJobID("team-a", "eval-1")).toBe("team-a/eval-1");
+  });
+
+  it("returns the bare name without a namespace", () => {
+    expect(scopedJobID("", "eval-1")).toBe("eval-1");
+  });
 });
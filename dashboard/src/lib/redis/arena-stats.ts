@@ -5,6 +5,8 @@
  * - arena:job:{jobID}:stats            — main stats hash
  * - arena:job:{jobID}:stats:provider:{id} — per-provider stats
  *
+ * jobID is namespace-scoped ("{namespace}/{jobName}"); see scopedJobID.
+ *
  * Hash fields: total, passed, failed, totalDurationMs, totalTokens, totalCost
  */
 
@@ -39,6 +41,15 @@ const JOB_KEY_PREFIX = "arena:job:";
 const STATS_SUFFIX = ":stats";
 const PROVIDER_INFIX = ":stats:provider:";
 
+/**
+ * Returns the queue identifier for an ArenaJob, mirroring queue.ScopedJobID
+ * in Go. Job names are only unique within a namespace, so the writers fold
+ * the namespace into every job key.
+ */
+export function scopedJobID(namespace: string, jobName: string): string {
+  return namespace ? `${namespace}/${jobName}` : jobName;
+}
+
 // ---- Hash field names ----
 
 const FIELD_TOTAL = "total";
@@ -11,6 +11,7 @@ export type AgentPolicyPhase = "Active" | "Error";
 
 export interface AgentPolicySelector {
   agents?: string[];
+  workspaces?: string[];
 }
 
 export interface ToolAccessRule {
@@ -23,9 +24,17 @@ export interface ToolAccessConfig {
   rules: ToolAccessRule[];
 }
 
+export interface TransformConfig {
+  systemPromptPrefix?: string;
+  headers?: Record<string, string>;
+  stripParams?: string[];
+  modelRewrites?: Record<string, string>;
+}
+
 export interface AgentPolicySpec {
   selector?: AgentPolicySelector;
   toolAccess?: ToolAccessConfig;
+  transform?: TransformConfig;
   mode?: AgentPolicyMode;
   onFailure?: OnFailureAction;
 }
@@ -4,6 +4,37 @@
 import type { ObjectMeta } from "../common";
 
 export interface AgentRuntimeSpec {
+  /** budget caps the tokens and cost each session and the agent as a whole
+   * may spend. */
+  budget?: {
+    /** agent limits what all of the agent's sessions together may spend per
+     * window. With a Redis context store the usage is shared by every
+     * replica; otherwise each replica enforces the limit on its own. */
+    agent?: {
+      /** maxCostUSD caps the estimated provider cost in USD per window (e.g., "100.00"). */
+      maxCostUSD?: string;
+      /** maxTokens caps input plus output tokens per window. */
+      maxTokens?: number;
+      /** window is the period usage is counted over, in duration format (e.g.,
+       * "1h", "24h"). Windows are aligned to the epoch, so a 24h window resets
+       * at midnight UTC. */
+      window?: string;
+    };
+    /** onExceeded is what happens to a session over its budget. reject fails
+     * the turn with a BUDGET_EXCEEDED error. summarize replaces the session's
+     * history with a summary, resets the session's usage and answers the
+     * turn; a turn that exceeds the budget part way through is still
+     * rejected. The agent budget always rejects. */
+    onExceeded?: "reject" | "summarize";
+    /** session limits what a single session may spend over its lifetime,
+     * including turns served by other replicas before a restart. */
+    session?: {
+      /** maxCostUSD caps the estimated provider cost in USD (e.g., "5.00"). */
+      maxCostUSD?: string;
+      /** maxTokens caps input plus output tokens. */
+      maxTokens?: number;
+    };
+  };
   /** console configures the dashboard console UI settings.
    * Use this to customize allowed file attachment types and size limits. */
   console?: {
@@ -104,6 +135,40 @@ export interface AgentRuntimeSpec {
     /** type specifies the context store backend. */
     type: "memory" | "redis";
   };
+  /** delegates are other AgentRuntimes the agent may call as tools. Each
+   * exchange is recorded as a nested session linked to the calling one. */
+  delegates?: {
+    /** agentRuntimeRef references the AgentRuntime to delegate to. It must be
+     * in the same namespace and serve an a2a facade. */
+    agentRuntimeRef: {
+      /** Name of the referent.
+       * This field is effectively required, but due to backwards compatibility is
+       * allowed to be empty. Instances of this type with an empty value here are
+       * almost certainly wrong.
+       * More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names */
+      name?: string;
+    };
+    /** authentication configures the bearer token sent to the agent's a2a
+     * facade, for agents that require one. */
+    authentication?: {
+      /** secretRef references a Secret containing a bearer token (key: "token"). */
+      secretRef?: {
+        /** Name of the referent.
+         * This field is effectively required, but due to backwards compatibility is
+         * allowed to be empty. Instances of this type with an empty value here are
+         * almost certainly wrong.
+         * More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names */
+        name?: string;
+      };
+    };
+    /** description tells the model what the agent does and when to call it. */
+    description: string;
+    /** name is the tool's name. The model sees it as a2a__<name>. */
+    name: string;
+    /** timeout bounds each call to the agent, in duration format (e.g.,
+     * "30s"). A call that times out fails the tool call, not the turn. */
+    timeout?: string;
+  }[];
   /** duplex configures the realtime voice/duplex console for this agent. */
   duplex?: {
     /** audio declares the realtime audio format the runtime requires for this
@@ -150,6 +215,24 @@ export interface AgentRuntimeSpec {
        * (see EvalConfig.Inline and EvalConfig.Worker). */
       groups?: string[];
     };
+    /** judge selects the LLM judges that score the worker's judge evals and
+     * caps their spend. When unset, the worker judges with one of the
+     * agent's spec.providers and spend is uncapped. */
+    judge?: {
+      /** maxCostPerDayUSD caps what each judge may spend per UTC day across all
+       * eval worker replicas, in USD (e.g., "20.00"). A judge that reaches it
+       * hands the rest of the day to the next judge. */
+      maxCostPerDayUSD?: string;
+      /** maxCostPerJobUSD caps what each judge may spend on one eval job (the
+       * evals of one turn, or of one completed session), in USD (e.g., "0.05").
+       * A judge that reaches it hands the rest of the job to the next judge. */
+      maxCostPerJobUSD?: string;
+      /** providers lists the names of spec.providers entries to judge with, in
+       * order of preference. The first is the primary judge; the rest are
+       * fallbacks, typically cheaper models, each used when every judge before
+       * it is rate-limited or over budget. */
+      providers: string[];
+    };
     /** podOverrides customizes the namespace-level eval-worker Pod. Last
      * AgentRuntime to reconcile wins (eval-worker is per-namespace, not
      * per-CRD). */
@@ -327,11 +410,19 @@ export interface AgentRuntimeSpec {
     };
     /** sampling configures eval sampling rates to control cost. */
     sampling?: {
+      /** alwaysEvalOn lists session signals that make the eval worker evaluate
+       * a session regardless of the sampling rates and the hourly cap. */
+      alwaysEvalOn?: ("negativeFeedback" | "policyViolation")[];
       /** defaultRate is the default sampling percentage (0-100) for all evals. */
       defaultRate?: number;
       /** extendedRate is the sampling percentage (0-100) for extended evals
        * (model-powered evaluations that call an external service). */
       extendedRate?: number;
+      /** maxSessionsPerHour caps how many of the agent's sessions the eval
+       * worker evaluates per clock hour, across all worker replicas. Sessions
+       * over the cap are skipped unless an alwaysEvalOn signal applies. Unset
+       * means no cap. */
+      maxSessionsPerHour?: number;
     };
     /** sessionCompletion configures how session completion is detected
      * for on_session_complete evals. */
@@ -358,6 +449,24 @@ export interface AgentRuntimeSpec {
    * one validator is filled in. Applied to each facade's external auth
    * chain. */
   externalAuth?: {
+    /** authorization restricts which agents an admitted data-plane caller
+     * may use. Without it, any credential the validators above accept
+     * may open sessions with this agent. Applies to every data-plane
+     * validator; management-plane callers are authorized by the
+     * dashboard and are not affected. */
+    authorization?: {
+      /** agentsClaim names the identity claim listing the agents a caller
+       * may use, separated by commas or spaces (a JSON array claim works
+       * too). Each entry is "*", "<namespace>/*", "<namespace>/<agent>",
+       * or a bare agent name matching in any namespace. A caller whose
+       * identity lacks the claim, or whose claim does not name this agent,
+       * is refused with 403.
+       * 
+       * The claim comes from wherever the admitting validator sources
+       * claims: the JWT for oidc, the key's claims for clientKeys, and
+       * claimsFromHeaders for edgeTrust. */
+      agentsClaim: string;
+    };
     /** clientKeys configures per-caller client keys for this agent. Each
      * key is stored as a Kubernetes Secret in the agent's namespace with
      * a sha256 hash of the raw value, claims, and expiry. Created via the
@@ -574,9 +683,10 @@ export interface AgentRuntimeSpec {
      * Defaults to 60s. */
     clientToolTimeout?: string;
     /** drainTimeout is how long the facade keeps serving active realtime calls
-     * after receiving SIGTERM (rollout/drain/scale-down) before cleanly tearing
-     * down the remainder. Duration format (e.g. "30s", "2m"). New calls stop
-     * immediately on drain regardless. Defaults to 30s when unset. */
+     * and in-flight turns after receiving SIGTERM (rollout/drain/scale-down)
+     * before cleanly tearing down the remainder and handing sessions off for
+     * resume on another replica. Duration format (e.g. "30s", "2m"). New calls
+     * stop immediately on drain regardless. Defaults to 30s when unset. */
     drainTimeout?: string;
     /** expose opts this agent into operator-provisioned external exposure.
      * Opt-in: an agent is never externally reachable unless this is set AND the
@@ -708,8 +818,46 @@ export interface AgentRuntimeSpec {
       /** port is the listen port for the MCP server. Default 9998. */
       port?: number;
     };
+    /** payload tunes compression and fragmentation of large WebSocket
+     * messages, such as big tool outputs. Only meaningful on a
+     * type=websocket facade. */
+    payload?: {
+      /** compression negotiates permessage-deflate with clients that offer it.
+       * Binary media frames are never compressed. Defaults to true. */
+      compression?: boolean;
+      /** compressionLevel is the deflate level, from 1 (fastest) to 9
+       * (smallest). Defaults to 1. */
+      compressionLevel?: number;
+      /** compressionThreshold is the smallest message, in bytes, that is
+       * compressed. Defaults to 1024. */
+      compressionThreshold?: number;
+      /** maxFragmentSize is the largest piece of a message sent in one frame to
+       * a client that negotiated the fragments feature; larger messages are
+       * split into fragment messages. Defaults to 1048576 (1 MiB). */
+      maxFragmentSize?: number;
+      /** maxFragmentedSize is the largest message a client may send as
+       * fragments, for payloads beyond the single-frame limit such as large
+       * client tool results. Defaults to 67108864 (64 MiB). */
+      maxFragmentedSize?: number;
+    };
     /** port is the port number for the facade service. */
     port?: number;
+    /** sendQueue bounds each WebSocket connection's outbound queue so a client
+     * that stops reading mid-stream cannot grow the facade's memory. Only
+     * meaningful on a type=websocket facade. */
+    sendQueue?: {
+      /** maxBytes is the most encoded bytes queued for one connection. A single
+       * message larger than this is still sent when the queue is empty.
+       * Defaults to 8388608 (8 MiB). */
+      maxBytes?: number;
+      /** maxMessages is the most messages queued for one connection.
+       * Defaults to 1024. */
+      maxMessages?: number;
+      /** policy is what happens once the queue stays full for the write timeout.
+       * "close" disconnects the client (it can resume and be replayed); "drop"
+       * sheds media, typing, progress, and presence messages first. Defaults to "close". */
+      policy?: "close" | "drop";
+    };
     /** type specifies the facade protocol type. */
     type: "websocket" | "a2a" | "rest" | "mcp" | "custom";
   }[];
@@ -729,11 +877,294 @@ export interface AgentRuntimeSpec {
      * If not specified, the latest supported version is used. */
     version?: string;
   };
+  /** guardrails checks the user's messages, the model's responses and its
+   * tool calls with built-in hooks. */
+  guardrails?: {
+    /** input hooks check the user's message before the model sees it.
+     * A rejected message fails the turn with a GUARDRAIL_REJECTED error. */
+    input?: {
+      /** action is what happens to text the hook rejects: block fails it,
+       * flag records a guardrail.flagged session event and lets it through,
+       * annotate also marks the user's message to the model as untrusted, and
+       * redact (regexBlocklist only) also replaces the matches with
+       * [REDACTED]. Tool calls are flagged, not annotated or redacted;
+       * function-mode input is not annotated. */
+      action?: "block" | "flag" | "annotate" | "redact";
+      /** allowedScripts are the Unicode scripts (e.g., "Latin", "Cyrillic",
+       * "Han") language accepts. */
+      allowedScripts?: string[];
+      /** caseInsensitive makes regexBlocklist patterns ignore case. */
+      caseInsensitive?: boolean;
+      /** classifierURL is an HTTP endpoint promptInjection asks to score the
+       * text alongside its heuristics. It is sent {"text": "..."} and answers
+       * {"score": <0..1>}; the higher score counts. A classifier that fails to
+       * answer leaves the heuristics to decide. */
+      classifierURL?: string;
+      /** maxChars is the longest text, in characters, maxLength allows. */
+      maxChars?: number;
+      /** message replaces the rejection message sent to the client. */
+      message?: string;
+      /** minRatio is the share of letters that must be in allowedScripts for
+       * language to accept the text (e.g., "0.9"). Defaults to 0.8. */
+      minRatio?: string;
+      /** moderation configures the content moderation provider the moderation
+       * hook asks to score the text. */
+      moderation?: {
+        /** categories are the provider's categories the hook rejects, each at its
+         * own threshold or the hook's. When empty, any category at or above the
+         * hook's threshold is rejected. */
+        categories?: {
+          /** name is the provider's category, e.g. "violence" or
+           * "harassment/threatening" for openai and "HATE_SPEECH" or "TOXICITY"
+           * for comprehend. */
+          name: string;
+          /** threshold is the category score, between 0 and 1, at or above which
+           * the text is rejected. Defaults to the hook's threshold. */
+          threshold?: string;
+        }[];
+        /** failClosed rejects the text when the provider cannot be reached or
+         * answers with an error. By default such text is let through. */
+        failClosed?: boolean;
+        /** model is the openai moderation model. Defaults to
+         * omni-moderation-latest. */
+        model?: string;
+        /** provider is the moderation service. openai calls the OpenAI moderation
+         * API; comprehend calls AWS Comprehend toxicity detection (English only)
+         * with the pod's AWS credentials; http posts {"text": "..."} to url,
+         * which answers {"categories": {"<name>": <0..1>}}. */
+        provider: "openai" | "comprehend" | "http";
+        /** region is the AWS region of comprehend. Defaults to the pod's AWS
+         * configuration. */
+        region?: string;
+        /** secretRef names a Secret in the agent's namespace whose api-key is
+         * sent as a bearer token to openai or the http classifier. */
+        secretRef?: {
+          /** Name of the referent.
+           * This field is effectively required, but due to backwards compatibility is
+           * allowed to be empty. Instances of this type with an empty value here are
+           * almost certainly wrong.
+           * More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names */
+          name?: string;
+        };
+        /** url overrides the openai endpoint or names the http classifier. */
+        url?: string;
+      };
+      /** name is the built-in hook to run. */
+      name: "regexBlocklist" | "maxLength" | "language" | "promptInjection" | "moderation";
+      /** patterns are the RE2 regular expressions regexBlocklist rejects. */
+      patterns?: string[];
+      /** threshold is the score, between 0 and 1, at or above which
+       * promptInjection or moderation rejects the text (e.g., "0.8"). Defaults
+       * to 0.7 for promptInjection and 0.5 for moderation. */
+      threshold?: string;
+    }[];
+    /** output hooks check the model's response before it is sent. Response
+     * text is held back until the checks pass, or only its newest characters
+     * with outputStreaming; a rejected response fails the turn with a
+     * GUARDRAIL_REJECTED error. */
+    output?: {
+      /** action is what happens to text the hook rejects: block fails it,
+       * flag records a guardrail.flagged session event and lets it through,
+       * annotate also marks the user's message to the model as untrusted, and
+       * redact (regexBlocklist only) also replaces the matches with
+       * [REDACTED]. Tool calls are flagged, not annotated or redacted;
+       * function-mode input is not annotated. */
+      action?: "block" | "flag" | "annotate" | "redact";
+      /** allowedScripts are the Unicode scripts (e.g., "Latin", "Cyrillic",
+       * "Han") language accepts. */
+      allowedScripts?: string[];
+      /** caseInsensitive makes regexBlocklist patterns ignore case. */
+      caseInsensitive?: boolean;
+      /** classifierURL is an HTTP endpoint promptInjection asks to score the
+       * text alongside its heuristics. It is sent {"text": "..."} and answers
+       * {"score": <0..1>}; the higher score counts. A classifier that fails to
+       * answer leaves the heuristics to decide. */
+      classifierURL?: string;
+      /** maxChars is the longest text, in characters, maxLength allows. */
+      maxChars?: number;
+      /** message replaces the rejection message sent to the client. */
+      message?: string;
+      /** minRatio is the share of letters that must be in allowedScripts for
+       * language to accept the text (e.g., "0.9"). Defaults to 0.8. */
+      minRatio?: string;
+      /** moderation configures the content moderation provider the moderation
+       * hook asks to score the text. */
+      moderation?: {
+        /** categories are the provider's categories the hook rejects, each at its
+         * own threshold or the hook's. When empty, any category at or above the
+         * hook's threshold is rejected. */
+        categories?: {
+          /** name is the provider's category, e.g. "violence" or
+           * "harassment/threatening" for openai and "HATE_SPEECH" or "TOXICITY"
+           * for comprehend. */
+          name: string;
+          /** threshold is the category score, between 0 and 1, at or above which
+           * the text is rejected. Defaults to the hook's threshold. */
+          threshold?: string;
+        }[];
+        /** failClosed rejects the text when the provider cannot be reached or
+         * answers with an error. By default such text is let through. */
+        failClosed?: boolean;
+        /** model is the openai moderation model. Defaults to
+         * omni-moderation-latest. */
+        model?: string;
+        /** provider is the moderation service. openai calls the OpenAI moderation
+         * API; comprehend calls AWS Comprehend toxicity detection (English only)
+         * with the pod's AWS credentials; http posts {"text": "..."} to url,
+         * which answers {"categories": {"<name>": <0..1>}}. */
+        provider: "openai" | "comprehend" | "http";
+        /** region is the AWS region of comprehend. Defaults to the pod's AWS
+         * configuration. */
+        region?: string;
+        /** secretRef names a Secret in the agent's namespace whose api-key is
+         * sent as a bearer token to openai or the http classifier. */
+        secretRef?: {
+          /** Name of the referent.
+           * This field is effectively required, but due to backwards compatibility is
+           * allowed to be empty. Instances of this type with an empty value here are
+           * almost certainly wrong.
+           * More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names */
+          name?: string;
+        };
+        /** url overrides the openai endpoint or names the http classifier. */
+        url?: string;
+      };
+      /** name is the built-in hook to run. */
+      name: "regexBlocklist" | "maxLength" | "language" | "promptInjection" | "moderation";
+      /** patterns are the RE2 regular expressions regexBlocklist rejects. */
+      patterns?: string[];
+      /** threshold is the score, between 0 and 1, at or above which
+       * promptInjection or moderation rejects the text (e.g., "0.8"). Defaults
+       * to 0.7 for promptInjection and 0.5 for moderation. */
+      threshold?: string;
+    }[];
+    /** outputStreaming has the output hooks check the response while it
+     * streams, instead of holding it back until it is complete. */
+    outputStreaming?: {
+      /** windowChars is how many of the newest characters of the response are
+       * held back while it streams. Each time text is released, the output
+       * hooks check it with the last windowChars characters already sent, so
+       * a match up to this long is rejected or redacted before any of it is
+       * sent. A rejection ends the response where it is. The complete response
+       * is checked again when it ends. */
+      windowChars?: number;
+    };
+    /** toolCalls hooks check the arguments of each tool call the model makes.
+     * A rejected call is not executed; the model receives the rejection as
+     * the tool's error result. */
+    toolCalls?: {
+      /** action is what happens to text the hook rejects: block fails it,
+       * flag records a guardrail.flagged session event and lets it through,
+       * annotate also marks the user's message to the model as untrusted, and
+       * redact (regexBlocklist only) also replaces the matches with
+       * [REDACTED]. Tool calls are flagged, not annotated or redacted;
+       * function-mode input is not annotated. */
+      action?: "block" | "flag" | "annotate" | "redact";
+      /** allowedScripts are the Unicode scripts (e.g., "Latin", "Cyrillic",
+       * "Han") language accepts. */
+      allowedScripts?: string[];
+      /** caseInsensitive makes regexBlocklist patterns ignore case. */
+      caseInsensitive?: boolean;
+      /** classifierURL is an HTTP endpoint promptInjection asks to score the
+       * text alongside its heuristics. It is sent {"text": "..."} and answers
+       * {"score": <0..1>}; the higher score counts. A classifier that fails to
+       * answer leaves the heuristics to decide. */
+      classifierURL?: string;
+      /** maxChars is the longest text, in characters, maxLength allows. */
+      maxChars?: number;
+      /** message replaces the rejection message sent to the client. */
+      message?: string;
+      /** minRatio is the share of letters that must be in allowedScripts for
+       * language to accept the text (e.g., "0.9"). Defaults to 0.8. */
+      minRatio?: string;
+      /** moderation configures the content moderation provider the moderation
+       * hook asks to score the text. */
+      moderation?: {
+        /** categories are the provider's categories the hook rejects, each at its
+         * own threshold or the hook's. When empty, any category at or above the
+         * hook's threshold is rejected. */
+        categories?: {
+          /** name is the provider's category, e.g. "violence" or
+           * "harassment/threatening" for openai and "HATE_SPEECH" or "TOXICITY"
+           * for comprehend. */
+          name: string;
+          /** threshold is the category score, between 0 and 1, at or above which
+           * the text is rejected. Defaults to the hook's threshold. */
+          threshold?: string;
+        }[];
+        /** failClosed rejects the text when the provider cannot be reached or
+         * answers with an error. By default such text is let through. */
+        failClosed?: boolean;
+        /** model is the openai moderation model. Defaults to
+         * omni-moderation-latest. */
+        model?: string;
+        /** provider is the moderation service. openai calls the OpenAI moderation
+         * API; comprehend calls AWS Comprehend toxicity detection (English only)
+         * with the pod's AWS credentials; http posts {"text": "..."} to url,
+         * which answers {"categories": {"<name>": <0..1>}}. */
+        provider: "openai" | "comprehend" | "http";
+        /** region is the AWS region of comprehend. Defaults to the pod's AWS
+         * configuration. */
+        region?: string;
+        /** secretRef names a Secret in the agent's namespace whose api-key is
+         * sent as a bearer token to openai or the http classifier. */
+        secretRef?: {
+          /** Name of the referent.
+           * This field is effectively required, but due to backwards compatibility is
+           * allowed to be empty. Instances of this type with an empty value here are
+           * almost certainly wrong.
+           * More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names */
+          name?: string;
+        };
+        /** url overrides the openai endpoint or names the http classifier. */
+        url?: string;
+      };
+      /** name is the built-in hook to run. */
+      name: "regexBlocklist" | "maxLength" | "language" | "promptInjection" | "moderation";
+      /** patterns are the RE2 regular expressions regexBlocklist rejects. */
+      patterns?: string[];
+      /** threshold is the score, between 0 and 1, at or above which
+       * promptInjection or moderation rejects the text (e.g., "0.8"). Defaults
+       * to 0.7 for promptInjection and 0.5 for moderation. */
+      threshold?: string;
+    }[];
+  };
   /** inputSchema is the JSON Schema that incoming Function payloads are
    * validated against. Required when spec.mode is 'function'; forbidden
    * otherwise (CEL-gated). Stored as a raw JSON object; consumers
    * validate via santhosh-tekuri/jsonschema. */
   inputSchema?: Record<string, unknown>;
+  /** knowledge grounds the agent's answers in documents retrieved from a
+   * vector store. */
+  knowledge?: {
+    /** enabled turns retrieval on for this agent. */
+    enabled?: boolean;
+    /** minScore drops chunks whose similarity to the user's message is below
+     * this value (e.g., "0.7"). Empty keeps the topK best matches. */
+    minScore?: string;
+    /** topK is the maximum number of chunks added to the prompt per turn. */
+    topK?: number;
+    /** vectorStore is where the indexed chunks live. */
+    vectorStore: {
+      /** collection is the table (pgvector) or collection (qdrant) holding the
+       * chunks. A pgvector table must have the columns id text primary key,
+       * content text, metadata jsonb and embedding vector. */
+      collection: string;
+      /** secretRef references a Secret with the connection details: "url" holds
+       * the PostgreSQL connection URL (pgvector) or the HTTP(S) base URL
+       * (qdrant), and the optional "apiKey" holds the Qdrant API key. */
+      secretRef: {
+        /** Name of the referent.
+         * This field is effectively required, but due to backwards compatibility is
+         * allowed to be empty. Instances of this type with an empty value here are
+         * almost certainly wrong.
+         * More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names */
+        name?: string;
+      };
+      /** type is the vector store backend. */
+      type: "pgvector" | "qdrant";
+    };
+  };
   /** media configures media file resolution for mock provider responses. */
   media?: {
     /** basePath is the base directory for resolving mock:// URLs.
@@ -1080,6 +1511,20 @@ export interface AgentRuntimeSpec {
      * (which were authored before per-ref roles existed). */
     role?: "llm" | "embedding" | "tts" | "stt" | "image" | "inference";
   }[];
+  /** responseCache serves repeated prompts from cached responses instead of
+   * calling the provider. */
+  responseCache?: {
+    /** enabled turns the response cache on for this agent. */
+    enabled?: boolean;
+    /** similarityThreshold also serves near-identical prompts: a prompt whose
+     * embedding has at least this cosine similarity (e.g., "0.95") to a cached
+     * prompt gets its response. Requires an embedding-role provider in
+     * spec.providers. Empty means exact matches only, ignoring case and
+     * whitespace. */
+    similarityThreshold?: string;
+    /** ttl is how long a cached response is served, in duration format (e.g., "1h", "30m"). */
+    ttl?: string;
+  };
   /** rollout configures a progressive delivery rollout for this AgentRuntime.
    * When nil, no rollout is active and all traffic goes to the current spec. */
   rollout?: {
@@ -3066,6 +3511,39 @@ export interface AgentRuntimeSpec {
    * The controller resolves the session-api and memory-api endpoints from that group.
    * Defaults to "default". */
   serviceGroup?: string;
+  /** slos declares latency, error-rate and cost objectives for the agent.
+   * The operator generates Prometheus alerts from them and reports breaches
+   * in the SLOBreached condition. */
+  slos?: {
+    /** for is how long an objective must stay breached before its alert
+     * fires, in duration format (e.g., "10m"). The SLOBreached condition does
+     * not wait for it. */
+    for?: string;
+    /** maxCostPerHourUSD is the highest acceptable estimated provider spend of
+     * the agent's own LLM calls, in USD per hour (e.g., "10.00"). Judge and
+     * self-play calls are not counted. */
+    maxCostPerHourUSD?: string;
+    /** maxErrorRate is the highest acceptable fraction of facade requests that
+     * fail, between 0 and 1 (e.g., "0.05" for 5%). */
+    maxErrorRate?: string;
+    /** maxP95Latency is the highest acceptable 95th-percentile request latency
+     * at the facade, in duration format (e.g., "2s", "500ms"). */
+    maxP95Latency?: string;
+    /** window is the period each objective is measured over, in duration
+     * format (e.g., "5m", "1h"). */
+    window?: string;
+  };
+  /** structuredOutput makes the agent answer with JSON validated against the
+   * PromptPack's response schema. Function mode uses spec.outputSchema
+   * instead. */
+  structuredOutput?: {
+    /** enabled turns structured output on for this agent. */
+    enabled?: boolean;
+    /** maxRepairAttempts is how many times the model is asked to fix a
+     * response that does not satisfy the schema before the turn fails.
+     * 0 fails the turn on the first invalid response. */
+    maxRepairAttempts?: number;
+  };
   /** toolRegistryRef optionally references a ToolRegistry for available tools. */
   toolRegistryRef?: {
     /** name is the name of the ToolRegistry resource. */
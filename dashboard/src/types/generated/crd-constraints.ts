@@ -6,6 +6,32 @@ import type { FieldConstraint } from "@/lib/validation/constraint-types";
 export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
   {
   "AgentRuntime": {
+    "spec.budget.agent.maxCostUSD": {
+      "type": "string",
+      "pattern": "^[0-9]+(\\.[0-9]+)?$"
+    },
+    "spec.budget.agent.maxTokens": {
+      "type": "integer",
+      "minimum": 1
+    },
+    "spec.budget.agent.window": {
+      "type": "string"
+    },
+    "spec.budget.onExceeded": {
+      "type": "string",
+      "enum": [
+        "reject",
+        "summarize"
+      ]
+    },
+    "spec.budget.session.maxCostUSD": {
+      "type": "string",
+      "pattern": "^[0-9]+(\\.[0-9]+)?$"
+    },
+    "spec.budget.session.maxTokens": {
+      "type": "integer",
+      "minimum": 1
+    },
     "spec.console.allowedAttachmentTypes[]": {
       "type": "string"
     },
@@ -123,6 +149,29 @@ export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
       ],
       "required": true
     },
+    "spec.delegates[].agentRuntimeRef": {
+      "required": true
+    },
+    "spec.delegates[].agentRuntimeRef.name": {
+      "type": "string"
+    },
+    "spec.delegates[].authentication.secretRef.name": {
+      "type": "string"
+    },
+    "spec.delegates[].description": {
+      "type": "string",
+      "minLength": 1,
+      "maxLength": 1024,
+      "required": true
+    },
+    "spec.delegates[].name": {
+      "type": "string",
+      "pattern": "^[a-zA-Z][a-zA-Z0-9_-]{0,47}$",
+      "required": true
+    },
+    "spec.delegates[].timeout": {
+      "type": "string"
+    },
     "spec.duplex.audio.channels": {
       "type": "integer"
     },
@@ -160,6 +209,17 @@ export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
     "spec.evals.inline.groups[]": {
       "type": "string"
     },
+    "spec.evals.judge.maxCostPerDayUSD": {
+      "type": "string",
+      "pattern": "^[0-9]+(\\.[0-9]+)?$"
+    },
+    "spec.evals.judge.maxCostPerJobUSD": {
+      "type": "string",
+      "pattern": "^[0-9]+(\\.[0-9]+)?$"
+    },
+    "spec.evals.judge.providers[]": {
+      "type": "string"
+    },
     "spec.evals.podOverrides.extraEnv[].name": {
       "type": "string",
       "required": true
@@ -266,6 +326,13 @@ export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
       "type": "integer",
       "minimum": 1
     },
+    "spec.evals.sampling.alwaysEvalOn[]": {
+      "type": "string",
+      "enum": [
+        "negativeFeedback",
+        "policyViolation"
+      ]
+    },
     "spec.evals.sampling.defaultRate": {
       "type": "integer",
       "minimum": 0,
@@ -276,12 +343,21 @@ export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
       "minimum": 0,
       "maximum": 100
     },
+    "spec.evals.sampling.maxSessionsPerHour": {
+      "type": "integer",
+      "minimum": 1
+    },
     "spec.evals.sessionCompletion.inactivityTimeout": {
       "type": "string"
     },
     "spec.evals.worker.groups[]": {
       "type": "string"
     },
+    "spec.externalAuth.authorization.agentsClaim": {
+      "type": "string",
+      "minLength": 1,
+      "required": true
+    },
     "spec.externalAuth.clientKeys.defaultRole": {
       "type": "string"
     },
@@ -497,11 +573,46 @@ export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
       "minimum": 1,
       "maximum": 65535
     },
+    "spec.facades[].payload.compression": {
+      "type": "boolean"
+    },
+    "spec.facades[].payload.compressionLevel": {
+      "type": "integer",
+      "minimum": 1,
+      "maximum": 9
+    },
+    "spec.facades[].payload.compressionThreshold": {
+      "type": "integer",
+      "minimum": 1
+    },
+    "spec.facades[].payload.maxFragmentSize": {
+      "type": "integer",
+      "minimum": 1024
+    },
+    "spec.facades[].payload.maxFragmentedSize": {
+      "type": "integer",
+      "minimum": 1
+    },
     "spec.facades[].port": {
       "type": "integer",
       "minimum": 1,
       "maximum": 65535
     },
+    "spec.facades[].sendQueue.maxBytes": {
+      "type": "integer",
+      "minimum": 1
+    },
+    "spec.facades[].sendQueue.maxMessages": {
+      "type": "integer",
+      "minimum": 1
+    },
+    "spec.facades[].sendQueue.policy": {
+      "type": "string",
+      "enum": [
+        "close",
+        "drop"
+      ]
+    },
     "spec.facades[].type": {
       "type": "string",
       "enum": [
@@ -528,6 +639,297 @@ export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
     "spec.framework.version": {
       "type": "string"
     },
+    "spec.guardrails.input[].action": {
+      "type": "string",
+      "enum": [
+        "block",
+        "flag",
+        "annotate",
+        "redact"
+      ]
+    },
+    "spec.guardrails.input[].allowedScripts[]": {
+      "type": "string"
+    },
+    "spec.guardrails.input[].caseInsensitive": {
+      "type": "boolean"
+    },
+    "spec.guardrails.input[].classifierURL": {
+      "type": "string",
+      "pattern": "^https?://"
+    },
+    "spec.guardrails.input[].maxChars": {
+      "type": "integer",
+      "minimum": 1
+    },
+    "spec.guardrails.input[].message": {
+      "type": "string",
+      "maxLength": 512
+    },
+    "spec.guardrails.input[].minRatio": {
+      "type": "string",
+      "pattern": "^(0(\\.[0-9]+)?|1(\\.0+)?)$"
+    },
+    "spec.guardrails.input[].moderation.categories[].name": {
+      "type": "string",
+      "minLength": 1,
+      "maxLength": 128,
+      "required": true
+    },
+    "spec.guardrails.input[].moderation.categories[].threshold": {
+      "type": "string",
+      "pattern": "^(0(\\.[0-9]+)?|1(\\.0+)?)$"
+    },
+    "spec.guardrails.input[].moderation.failClosed": {
+      "type": "boolean"
+    },
+    "spec.guardrails.input[].moderation.model": {
+      "type": "string"
+    },
+    "spec.guardrails.input[].moderation.provider": {
+      "type": "string",
+      "enum": [
+        "openai",
+        "comprehend",
+        "http"
+      ],
+      "required": true
+    },
+    "spec.guardrails.input[].moderation.region": {
+      "type": "string"
+    },
+    "spec.guardrails.input[].moderation.secretRef.name": {
+      "type": "string"
+    },
+    "spec.guardrails.input[].moderation.url": {
+      "type": "string",
+      "pattern": "^https?://"
+    },
+    "spec.guardrails.input[].name": {
+      "type": "string",
+      "enum": [
+        "regexBlocklist",
+        "maxLength",
+        "language",
+        "promptInjection",
+        "moderation"
+      ],
+      "required": true
+    },
+    "spec.guardrails.input[].patterns[]": {
+      "type": "string"
+    },
+    "spec.guardrails.input[].threshold": {
+      "type": "string",
+      "pattern": "^(0(\\.[0-9]+)?|1(\\.0+)?)$"
+    },
+    "spec.guardrails.output[].action": {
+      "type": "string",
+      "enum": [
+        "block",
+        "flag",
+        "annotate",
+        "redact"
+      ]
+    },
+    "spec.guardrails.output[].allowedScripts[]": {
+      "type": "string"
+    },
+    "spec.guardrails.output[].caseInsensitive": {
+      "type": "boolean"
+    },
+    "spec.guardrails.output[].classifierURL": {
+      "type": "string",
+      "pattern": "^https?://"
+    },
+    "spec.guardrails.output[].maxChars": {
+      "type": "integer",
+      "minimum": 1
+    },
+    "spec.guardrails.output[].message": {
+      "type": "string",
+      "maxLength": 512
+    },
+    "spec.guardrails.output[].minRatio": {
+      "type": "string",
+      "pattern": "^(0(\\.[0-9]+)?|1(\\.0+)?)$"
+    },
+    "spec.guardrails.output[].moderation.categories[].name": {
+      "type": "string",
+      "minLength": 1,
+      "maxLength": 128,
+      "required": true
+    },
+    "spec.guardrails.output[].moderation.categories[].threshold": {
+      "type": "string",
+      "pattern": "^(0(\\.[0-9]+)?|1(\\.0+)?)$"
+    },
+    "spec.guardrails.output[].moderation.failClosed": {
+      "type": "boolean"
+    },
+    "spec.guardrails.output[].moderation.model": {
+      "type": "string"
+    },
+    "spec.guardrails.output[].moderation.provider": {
+      "type": "string",
+      "enum": [
+        "openai",
+        "comprehend",
+        "http"
+      ],
+      "required": true
+    },
+    "spec.guardrails.output[].moderation.region": {
+      "type": "string"
+    },
+    "spec.guardrails.output[].moderation.secretRef.name": {
+      "type": "string"
+    },
+    "spec.guardrails.output[].moderation.url": {
+      "type": "string",
+      "pattern": "^https?://"
+    },
+    "spec.guardrails.output[].name": {
+      "type": "string",
+      "enum": [
+        "regexBlocklist",
+        "maxLength",
+        "language",
+        "promptInjection",
+        "moderation"
+      ],
+      "required": true
+    },
+    "spec.guardrails.output[].patterns[]": {
+      "type": "string"
+    },
+    "spec.guardrails.output[].threshold": {
+      "type": "string",
+      "pattern": "^(0(\\.[0-9]+)?|1(\\.0+)?)$"
+    },
+    "spec.guardrails.outputStreaming.windowChars": {
+      "type": "integer",
+      "minimum": 16,
+      "maximum": 8192
+    },
+    "spec.guardrails.toolCalls[].action": {
+      "type": "string",
+      "enum": [
+        "block",
+        "flag",
+        "annotate",
+        "redact"
+      ]
+    },
+    "spec.guardrails.toolCalls[].allowedScripts[]": {
+      "type": "string"
+    },
+    "spec.guardrails.toolCalls[].caseInsensitive": {
+      "type": "boolean"
+    },
+    "spec.guardrails.toolCalls[].classifierURL": {
+      "type": "string",
+      "pattern": "^https?://"
+    },
+    "spec.guardrails.toolCalls[].maxChars": {
+      "type": "integer",
+      "minimum": 1
+    },
+    "spec.guardrails.toolCalls[].message": {
+      "type": "string",
+      "maxLength": 512
+    },
+    "spec.guardrails.toolCalls[].minRatio": {
+      "type": "string",
+      "pattern": "^(0(\\.[0-9]+)?|1(\\.0+)?)$"
+    },
+    "spec.guardrails.toolCalls[].moderation.categories[].name": {
+      "type": "string",
+      "minLength": 1,
+      "maxLength": 128,
+      "required": true
+    },
+    "spec.guardrails.toolCalls[].moderation.categories[].threshold": {
+      "type": "string",
+      "pattern": "^(0(\\.[0-9]+)?|1(\\.0+)?)$"
+    },
+    "spec.guardrails.toolCalls[].moderation.failClosed": {
+      "type": "boolean"
+    },
+    "spec.guardrails.toolCalls[].moderation.model": {
+      "type": "string"
+    },
+    "spec.guardrails.toolCalls[].moderation.provider": {
+      "type": "string",
+      "enum": [
+        "openai",
+        "comprehend",
+        "http"
+      ],
+      "required": true
+    },
+    "spec.guardrails.toolCalls[].moderation.region": {
+      "type": "string"
+    },
+    "spec.guardrails.toolCalls[].moderation.secretRef.name": {
+      "type": "string"
+    },
+    "spec.guardrails.toolCalls[].moderation.url": {
+      "type": "string",
+      "pattern": "^https?://"
+    },
+    "spec.guardrails.toolCalls[].name": {
+      "type": "string",
+      "enum": [
+        "regexBlocklist",
+        "maxLength",
+        "language",
+        "promptInjection",
+        "moderation"
+      ],
+      "required": true
+    },
+    "spec.guardrails.toolCalls[].patterns[]": {
+      "type": "string"
+    },
+    "spec.guardrails.toolCalls[].threshold": {
+      "type": "string",
+      "pattern": "^(0(\\.[0-9]+)?|1(\\.0+)?)$"
+    },
+    "spec.knowledge.enabled": {
+      "type": "boolean"
+    },
+    "spec.knowledge.minScore": {
+      "type": "string",
+      "pattern": "^(0(\\.[0-9]+)?|1(\\.0+)?)$"
+    },
+    "spec.knowledge.topK": {
+      "type": "integer",
+      "minimum": 1,
+      "maximum": 20
+    },
+    "spec.knowledge.vectorStore": {
+      "required": true
+    },
+    "spec.knowledge.vectorStore.collection": {
+      "type": "string",
+      "pattern": "^[a-zA-Z_][a-zA-Z0-9_]{0,62}$",
+      "required": true
+    },
+    "spec.knowledge.vectorStore.secretRef": {
+      "required": true
+    },
+    "spec.knowledge.vectorStore.secretRef.name": {
+      "type": "string"
+    },
+    "spec.knowledge.vectorStore.type": {
+      "type": "string",
+      "enum": [
+        "pgvector",
+        "qdrant"
+      ],
+      "required": true
+    },
     "spec.media.basePath": {
       "type": "string"
     },
@@ -802,6 +1204,16 @@ export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
         "inference"
       ]
     },
+    "spec.responseCache.enabled": {
+      "type": "boolean"
+    },
+    "spec.responseCache.similarityThreshold": {
+      "type": "string",
+      "pattern": "^(0(\\.[0-9]+)?|1(\\.0+)?)$"
+    },
+    "spec.responseCache.ttl": {
+      "type": "string"
+    },
     "spec.rollout.candidate.promptPackRef.name": {
       "type": "string",
       "minLength": 1,
@@ -1937,6 +2349,34 @@ export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
       "pattern": "^[a-z0-9]([a-z0-9-]*[a-z0-9])?$",
       "maxLength": 63
     },
+    "spec.slos.for": {
+      "type": "string",
+      "pattern": "^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+    },
+    "spec.slos.maxCostPerHourUSD": {
+      "type": "string",
+      "pattern": "^[0-9]+(\\.[0-9]+)?$"
+    },
+    "spec.slos.maxErrorRate": {
+      "type": "string",
+      "pattern": "^(0(\\.[0-9]+)?|1(\\.0+)?)$"
+    },
+    "spec.slos.maxP95Latency": {
+      "type": "string",
+      "pattern": "^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+    },
+    "spec.slos.window": {
+      "type": "string",
+      "pattern": "^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+    },
+    "spec.structuredOutput.enabled": {
+      "type": "boolean"
+    },
+    "spec.structuredOutput.maxRepairAttempts": {
+      "type": "integer",
+      "minimum": 0,
+      "maximum": 5
+    },
     "spec.toolRegistryRef.name": {
       "type": "string",
       "minLength": 1,
@@ -2363,6 +2803,7 @@ export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
       "enum": [
         "sliding",
         "summarize",
+        "relevance",
         "custom"
       ]
     },
@@ -2382,6 +2823,28 @@ export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
     "spec.model": {
       "type": "string"
     },
+    "spec.openAICompatible.tokenLimitParam": {
+      "type": "string",
+      "enum": [
+        "max_tokens",
+        "max_completion_tokens"
+      ]
+    },
+    "spec.openAICompatible.unsupportedParams[]": {
+      "type": "string",
+      "enum": [
+        "temperature",
+        "top_p"
+      ]
+    },
+    "spec.platform.apiVersion": {
+      "type": "string",
+      "pattern": "^\\d{4}-\\d{2}-\\d{2}(-preview)?$"
+    },
+    "spec.platform.deployment": {
+      "type": "string",
+      "minLength": 1
+    },
     "spec.platform.endpoint": {
       "type": "string"
     },
@@ -2409,6 +2872,35 @@ export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
     "spec.pricing.outputCostPer1K": {
       "type": "string"
     },
+    "spec.resilience.circuitBreaker.failureThreshold": {
+      "type": "integer",
+      "minimum": 1
+    },
+    "spec.resilience.circuitBreaker.openDuration": {
+      "type": "string",
+      "pattern": "^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+    },
+    "spec.resilience.failoverURL": {
+      "type": "string",
+      "pattern": "^https?://[^/]+/?$"
+    },
+    "spec.resilience.hedgeAfter": {
+      "type": "string",
+      "pattern": "^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+    },
+    "spec.resilience.retry.initialDelay": {
+      "type": "string",
+      "pattern": "^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+    },
+    "spec.resilience.retry.maxAttempts": {
+      "type": "integer",
+      "minimum": 1,
+      "maximum": 10
+    },
+    "spec.resilience.retry.maxDelay": {
+      "type": "string",
+      "pattern": "^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+    },
     "spec.role": {
       "type": "string",
       "enum": [
@@ -2429,6 +2921,17 @@ export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
       "minimum": 8000,
       "maximum": 48000
     },
+    "spec.tls.secretRef.name": {
+      "type": "string"
+    },
+    "spec.tls.spiffe.serverID": {
+      "type": "string",
+      "pattern": "^spiffe://[^/]+(/.*)?$"
+    },
+    "spec.tls.spiffe.socketPath": {
+      "type": "string",
+      "pattern": "^/.*"
+    },
     "spec.tts.audioFiles[]": {
       "type": "string"
     },
@@ -2459,6 +2962,7 @@ export const crdConstraints: Record<string, Record<string, FieldConstraint>> =
         "ollama",
         "mock",
         "vllm",
+        "openai-compatible",
         "voyageai",
         "cartesia",
         "elevenlabs",
@@ -42,7 +42,7 @@ export interface ProviderSpec {
   capabilities?: ("text" | "streaming" | "vision" | "tools" | "json" | "audio" | "video" | "documents" | "duplex")[];
   /** credential defines how to obtain credentials for this provider.
    * Optional for providers that don't require credentials (e.g., mock,
-   * ollama, vllm). */
+   * ollama, vllm, openai-compatible). */
   credential?: {
     /** envVar specifies an environment variable name containing the credential.
      * The variable must be available in the runtime pod. */
@@ -65,8 +65,10 @@ export interface ProviderSpec {
   /** defaults contains provider tuning parameters. */
   defaults?: {
     /** contextWindow is the model's maximum context size in tokens.
-     * When conversation history exceeds this budget, truncation is applied.
-     * If not specified, no automatic truncation is performed. */
+     * When conversation history exceeds this budget, truncation is applied
+     * before the provider call. If not specified, the budget comes from the
+     * provider when it reports its context window; otherwise no automatic
+     * truncation is performed. */
     contextWindow?: number;
     /** maxTokens limits the maximum number of tokens in the response. */
     maxTokens?: number;
@@ -91,9 +93,13 @@ export interface ProviderSpec {
     topP?: string;
     /** truncationStrategy defines how to handle context overflow.
      * - sliding: Remove oldest messages first (default)
-     * - summarize: Summarize old messages before removing
+     * - summarize: Replace old messages with a summary message written by
+     *   this provider
+     * - relevance: Remove the messages least relevant to the latest user turn,
+     *   scored by the AgentRuntime's embedding-role provider (falls back to
+     *   sliding when it has none)
      * - custom: Delegate to custom runtime implementation */
-    truncationStrategy?: "sliding" | "summarize" | "custom";
+    truncationStrategy?: "sliding" | "summarize" | "relevance" | "custom";
   };
   /** embedding is the embedding-role config block. Required when spec.role
    * is 'embedding'; forbidden otherwise (CEL-gated). */
@@ -120,6 +126,19 @@ export interface ProviderSpec {
    * When platform.type is bedrock, a claude release name is auto-mapped to the
    * corresponding Bedrock model ID by PromptKit. */
   model?: string;
+  /** openAICompatible tunes the request shape for a self-hosted
+   * OpenAI-compatible server. Only valid when spec.type is
+   * 'openai-compatible' (CEL-gated). */
+  openAICompatible?: {
+    /** tokenLimitParam selects the request field carrying the output token
+     * limit. Defaults to max_tokens, which vLLM, Ollama and most other
+     * servers accept; servers tracking the current OpenAI API may need
+     * max_completion_tokens. */
+    tokenLimitParam?: "max_tokens" | "max_completion_tokens";
+    /** unsupportedParams lists sampling parameters the server rejects.
+     * They are omitted from every request instead of sent with defaults. */
+    unsupportedParams?: ("temperature" | "top_p")[];
+  };
   /** platform defines hyperscaler hosting configuration.
    * Supported provider × platform pairs (PromptKit v1.4.6+):
    *   claude:  bedrock, vertex, azure
@@ -136,6 +155,14 @@ export interface ProviderSpec {
    * OpenAI embeddings, gemini × vertex); voyageai and ollama embedding
    * providers have no hyperscaler hosting and are rejected with a platform. */
   platform?: {
+    /** apiVersion is the Azure OpenAI api-version query parameter
+     * (e.g., 2024-10-21). Defaults to PromptKit's pinned version.
+     * Only valid for azure. */
+    apiVersion?: string;
+    /** deployment is the Azure OpenAI deployment name requests are routed to.
+     * Defaults to spec.model, so it is only needed when the deployment is
+     * named differently from the model. Only valid for openai on azure. */
+    deployment?: string;
     /** endpoint overrides the default platform API endpoint.
      * Required for azure (the Azure OpenAI resource URL). */
     endpoint?: string;
@@ -158,6 +185,46 @@ export interface ProviderSpec {
     /** outputCostPer1K is the cost per 1000 output tokens (e.g., "0.015"). */
     outputCostPer1K?: string;
   };
+  /** resilience configures retries, hedged requests and circuit breaking
+   * for calls to this provider. Unset keeps PromptKit's built-in retries. */
+  resilience?: {
+    /** circuitBreaker stops calling a provider that keeps failing, so
+     * requests fail fast instead of waiting on a dead endpoint. */
+    circuitBreaker?: {
+      /** failureThreshold is the number of consecutive failed calls, after
+       * retries, that opens the circuit. */
+      failureThreshold?: number;
+      /** openDuration is how long an open circuit rejects calls before
+       * letting a single trial call through. Go duration string. */
+      openDuration?: string;
+    };
+    /** failoverURL is a secondary endpoint serving the same API, e.g. a
+     * replica in another region. While the circuit is open, calls go to
+     * it instead, with the same credentials and headers; only the scheme
+     * and host of the request are replaced. The failover endpoint has its
+     * own circuit breaker with the same settings. Requires circuitBreaker. */
+    failoverURL?: string;
+    /** hedgeAfter sends a second, identical request when the first has not
+     * returned response headers within this duration; whichever answers
+     * first is used and the other is cancelled. Cuts tail latency at the
+     * cost of paying for some requests twice. Go duration string, e.g.
+     * "3s". Unset disables hedging. */
+    hedgeAfter?: string;
+    /** retry re-sends provider calls that fail with a transient error
+     * (connection failures, HTTP 429, 502, 503, 504, 529). A Retry-After header
+     * on the failed response overrides the backoff delay. */
+    retry?: {
+      /** initialDelay is the backoff before the first retry; each further
+       * retry doubles it, with jitter. Go duration string. */
+      initialDelay?: string;
+      /** maxAttempts is the total number of attempts per call, including the
+       * first. 1 disables retries. */
+      maxAttempts?: number;
+      /** maxDelay caps the backoff between attempts. A Retry-After longer
+       * than maxDelay ends the retries instead of waiting. Go duration string. */
+      maxDelay?: string;
+    };
+  };
   /** role declares which kind of provider this is — selects the factory
    * registry the provider plugs into. Defaults to 'llm' for back-compat;
    * existing Providers continue to work without YAML changes. */
@@ -172,6 +239,35 @@ export interface ProviderSpec {
      * expect. */
     sampleRate?: number;
   };
+  /** tls configures mutual TLS on the runtime's connection to this
+   * provider. Unset presents no client certificate. */
+  tls?: {
+    /** secretRef names a kubernetes.io/tls Secret in the Provider's namespace.
+     * Its tls.crt and tls.key are the client certificate; an optional ca.crt
+     * replaces the system roots for verifying the provider. The runtime reads
+     * the Secret at startup, and agents using the Provider roll when a
+     * Secret labelled omnia.altairalabs.ai/type=credentials changes. */
+    secretRef?: {
+      /** Name of the referent.
+       * This field is effectively required, but due to backwards compatibility is
+       * allowed to be empty. Instances of this type with an empty value here are
+       * almost certainly wrong.
+       * More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names */
+      name?: string;
+    };
+    /** spiffe takes the client certificate and trust bundle from the SPIFFE
+     * Workload API. Both are rotated in place, without restarting the agent. */
+    spiffe?: {
+      /** serverID is the SPIFFE ID the provider must present, e.g.
+       * "spiffe://example.org/ns/llm/sa/vllm". Unset accepts any ID the
+       * trust bundle vouches for. */
+      serverID?: string;
+      /** socketPath is the path of the Workload API socket in the runtime
+       * container. The socket must be mounted there, e.g. from the SPIFFE CSI
+       * driver through spec.runtime.volumes on the AgentRuntime. */
+      socketPath?: string;
+    };
+  };
   /** tts is the TTS-role config block. Required when spec.role is 'tts';
    * forbidden otherwise (CEL-gated). */
   tts?: {
@@ -188,7 +284,7 @@ export interface ProviderSpec {
     voice?: string;
   };
   /** type specifies the provider wire protocol / vendor. */
-  type: "claude" | "openai" | "gemini" | "ollama" | "mock" | "vllm" | "voyageai" | "cartesia" | "elevenlabs" | "imagen" | "huggingface";
+  type: "claude" | "openai" | "gemini" | "ollama" | "mock" | "vllm" | "openai-compatible" | "voyageai" | "cartesia" | "elevenlabs" | "imagen" | "huggingface";
 }
 
 export interface ProviderStatus {
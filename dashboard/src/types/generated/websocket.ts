@@ -121,12 +121,34 @@ export const MessageTypeToolCallNack: MessageType = "tool_call_nack";
  * cleanupConnection does not park the realtime audio session.
  */
 export const MessageTypeHangup: MessageType = "hangup";
+/**
+ * MessageTypeToolDebug carries a step-through debugging decision for a
+ * tool call the handler has paused on (arena dev console debug mode).
+ */
+export const MessageTypeToolDebug: MessageType = "tool_debug";
 /**
  * Bidirectional message types
  * Server → Client: tool execution result (informational)
  * Client → Server: client-side tool result (response to a client ToolCall)
  */
 export const MessageTypeToolResult: MessageType = "tool_result";
+/**
+ * MessageTypeAck acknowledges messages by seq.
+ * Client → Server: every server message up to ack.seq was received, so
+ * it is never redelivered on resume.
+ * Server → Client: the client message numbered ack.seq was accepted (or
+ * was a duplicate and dropped). Sent only to clients that negotiated the
+ * ack feature.
+ */
+export const MessageTypeAck: MessageType = "ack";
+/**
+ * MessageTypeFragment carries one piece of a message too large to send
+ * in one frame. The receiver concatenates the data of fragments 0 to
+ * count-1 with the same id and decodes the result as the original
+ * message. The server fragments only for clients that negotiated the
+ * fragments feature; clients may fragment what they send at any time.
+ */
+export const MessageTypeFragment: MessageType = "fragment";
 /**
  * Server to Client message types
  */
@@ -148,6 +170,34 @@ export const MessageTypeInterrupt: MessageType = "interrupt";
  * at that codec / sample_rate / channels.
  */
 export const MessageTypeSessionConfig: MessageType = "session_config";
+/**
+ * MessageTypePresence lists the participants attached to a collaborative
+ * session. Sent whenever someone joins, leaves, or changes role.
+ */
+export const MessageTypePresence: MessageType = "presence";
+/**
+ * MessageTypeTyping tells the client the agent has started on a turn.
+ * Sent only to clients that negotiated the typing feature.
+ */
+export const MessageTypeTyping: MessageType = "typing";
+/**
+ * MessageTypeReconnect tells the client the server is shutting down and
+ * where and when to resume its session. Sent only to clients that
+ * negotiated the reconnect feature.
+ */
+export const MessageTypeReconnect: MessageType = "reconnect";
+/**
+ * MessageTypeNotice carries an operator's announcement to the client,
+ * sent through the admin API. Sent only to clients that negotiated the
+ * notice feature.
+ */
+export const MessageTypeNotice: MessageType = "notice";
+/**
+ * MessageTypeProgress reports a step of a turn in progress: the agent
+ * thinking, a tool running, knowledge being retrieved. Sent only to
+ * clients that negotiated the progress feature.
+ */
+export const MessageTypeProgress: MessageType = "progress";
 /**
  * ToolCallAckInfo contains acknowledgement of a client-side tool call.
  * Sent by the client to indicate it received the tool call and is working on it.
@@ -172,6 +222,46 @@ export interface ToolCallNackInfo {
    */
   reason: string;
 }
+/**
+ * ToolDebugAction is the developer's decision for a paused tool call.
+ */
+export type ToolDebugAction = string;
+/**
+ * ToolDebugActionApprove executes the tool with the model's arguments.
+ */
+export const ToolDebugActionApprove: ToolDebugAction = "approve";
+/**
+ * ToolDebugActionEdit executes the tool with replacement arguments, or
+ * skips execution and returns the supplied result to the model.
+ */
+export const ToolDebugActionEdit: ToolDebugAction = "edit";
+/**
+ * ToolDebugActionSkip does not execute the tool; the model is told the
+ * call was skipped.
+ */
+export const ToolDebugActionSkip: ToolDebugAction = "skip";
+/**
+ * ToolDebugInfo is a step-through debugging command for a paused tool call.
+ */
+export interface ToolDebugInfo {
+  /**
+   * CallID matches the ToolCallInfo.ID the handler is paused on.
+   */
+  call_id: string;
+  /**
+   * Action is approve, edit, or skip.
+   */
+  action: ToolDebugAction;
+  /**
+   * Arguments replaces the tool arguments (edit only).
+   */
+  arguments?: { [key: string]: any};
+  /**
+   * Result, when set, is returned to the model instead of executing the
+   * tool (edit only).
+   */
+  result?: any;
+}
 /**
  * ClientToolResultInfo contains the client's response to a client-side tool call.
  */
@@ -231,6 +321,10 @@ export interface ClientMessage {
    * ToolCallNack rejects a client-side tool call.
    */
   tool_call_nack?: ToolCallNackInfo;
+  /**
+   * ToolDebug resolves a tool call paused in debug mode (for type "tool_debug").
+   */
+  tool_debug?: ToolDebugInfo;
   /**
    * ConsentGrants carries per-message consent category grants from the client.
    * When present, these override stored consent for this request.
@@ -247,6 +341,21 @@ export interface ClientMessage {
    * preferences API; per-session grants are additive only.
    */
   session_consent_grants?: string[];
+  /**
+   * Seq is the client's sequence number for the message within its
+   * session. A message whose seq is not greater than the last one the
+   * server accepted for the session is a resend: it is acknowledged and
+   * not processed again. Zero opts the message out of deduplication.
+   */
+  seq?: number /* uint64 */;
+  /**
+   * Ack acknowledges server messages (for ack type).
+   */
+  ack?: AckInfo;
+  /**
+   * Fragment is one piece of a larger message (for fragment type).
+   */
+  fragment?: FragmentInfo;
 }
 /**
  * ServerMessage represents a message sent from server to client.
@@ -309,6 +418,39 @@ export interface ServerMessage {
    * Connected contains connection info (for connected type).
    */
   connected?: ConnectedInfo;
+  /**
+   * Presence lists the participants of a collaborative session (for
+   * presence type).
+   */
+  presence?: PresenceInfo;
+  /**
+   * Reconnect tells the client how to resume on another replica (for
+   * reconnect type).
+   */
+  reconnect?: ReconnectInfo;
+  /**
+   * Ack acknowledges a client message (for ack type).
+   */
+  ack?: AckInfo;
+  /**
+   * Notice is an operator announcement (for notice type).
+   */
+  notice?: NoticeInfo;
+  /**
+   * Progress reports a step of the turn (for progress type).
+   */
+  progress?: ProgressInfo;
+  /**
+   * Fragment is one piece of a larger message (for fragment type).
+   */
+  fragment?: FragmentInfo;
+  /**
+   * Seq is the message's sequence number within its session, starting at 1.
+   * A client that reconnects with ?resume=<session_id>&last_seq=<seq> is
+   * replayed every message after seq. Unset on connected messages and on
+   * errors that belong to no session.
+   */
+  seq?: number /* uint64 */;
   /**
    * Timestamp is when the message was created.
    */
@@ -341,6 +483,11 @@ export interface ToolCallInfo {
    * Categories are semantic consent categories (e.g., "location", "filesystem").
    */
   categories?: string[];
+  /**
+   * AwaitingDebug is set when the handler has paused on this call and waits
+   * for a tool_debug command before executing it.
+   */
+  awaiting_debug?: boolean;
 }
 /**
  * ToolResultInfo contains information about a tool result.
@@ -371,6 +518,16 @@ export interface ErrorInfo {
    * Message is the error message.
    */
   message: string;
+  /**
+   * Retryable reports whether sending the request again, after a backoff,
+   * may succeed.
+   */
+  retryable: boolean;
+  /**
+   * CorrelationID identifies the connection or request in server logs and
+   * traces; quote it when reporting the error.
+   */
+  correlation_id?: string;
   /**
    * Details contains additional error details.
    */
@@ -478,6 +635,166 @@ export interface SessionConfigInfo {
    */
   channels: number /* int */;
 }
+/**
+ * ParticipantRole is a participant's permission level in a collaborative session.
+ */
+export type ParticipantRole = string;
+/**
+ * ParticipantRoleDriver may send messages and commands.
+ */
+export const ParticipantRoleDriver: ParticipantRole = "driver";
+/**
+ * ParticipantRoleObserver receives everything but may not send.
+ */
+export const ParticipantRoleObserver: ParticipantRole = "observer";
+/**
+ * ParticipantInfo describes one connection attached to a collaborative session.
+ */
+export interface ParticipantInfo {
+  /**
+   * ID is the connection identifier, unique per WebSocket connection.
+   */
+  id: string;
+  /**
+   * UserID is the participant's pseudonymous user ID, when known.
+   */
+  user_id?: string;
+  /**
+   * Email is the participant's email, when the auth identity carries one.
+   */
+  email?: string;
+  /**
+   * Role is the participant's current role.
+   */
+  role: ParticipantRole;
+  /**
+   * JoinedAt is when the participant attached to the session.
+   */
+  joined_at: string;
+}
+/**
+ * PresenceInfo lists the participants attached to a collaborative session.
+ */
+export interface PresenceInfo {
+  /**
+   * SelfID is the recipient's own participant ID.
+   */
+  self_id?: string;
+  /**
+   * Participants are the attached connections in join order.
+   */
+  participants: ParticipantInfo[];
+}
+/**
+ * ReconnectInfo is the reconnect hint sent when the server starts draining.
+ * The client finishes the turn in progress, closes, and resumes its session
+ * with ?resume=<session_id>&last_seq=<n> at Endpoint, or at the URL it used,
+ * after RetryAfterMs.
+ */
+export interface ReconnectInfo {
+  /**
+   * Reason is why the client should reconnect; "draining" for a server
+   * shutting down.
+   */
+  reason: string;
+  /**
+   * RetryAfterMs is how long to wait before reconnecting, in milliseconds.
+   */
+  retry_after_ms: number /* int64 */;
+  /**
+   * Endpoint is the URL to reconnect to, when the server knows one that
+   * does not route back to itself.
+   */
+  endpoint?: string;
+}
+/**
+ * NoticeInfo is an announcement an operator broadcast to connected clients,
+ * such as planned maintenance.
+ */
+export interface NoticeInfo {
+  /**
+   * Message is the text to show the user.
+   */
+  message: string;
+}
+/**
+ * FragmentInfo is one piece of a message split across several frames.
+ */
+export interface FragmentInfo {
+  /**
+   * ID identifies the message being fragmented. Fragments of different
+   * messages may interleave.
+   */
+  id: string;
+  /**
+   * Index is the position of this fragment, starting at 0.
+   */
+  index: number /* int */;
+  /**
+   * Count is how many fragments make up the message.
+   */
+  count: number /* int */;
+  /**
+   * Data is this fragment's slice of the message's JSON encoding.
+   */
+  data: string;
+}
+/**
+ * ProgressStageThinking is the agent waiting on the model.
+ */
+export const ProgressStageThinking = "thinking";
+/**
+ * ProgressStageTool is a server-side tool running.
+ */
+export const ProgressStageTool = "tool";
+/**
+ * ProgressStageRetrieval is knowledge being retrieved for the turn.
+ */
+export const ProgressStageRetrieval = "retrieval";
+/**
+ * Progress statuses.
+ */
+export const ProgressStatusStarted = "started";
+/**
+ * ProgressStatusRunning is repeated while a tool runs, with the time so
+ * far.
+ */
+export const ProgressStatusRunning = "running";
+/**
+ * Progress statuses.
+ */
+export const ProgressStatusCompleted = "completed";
+/**
+ * Progress statuses.
+ */
+export const ProgressStatusFailed = "failed";
+/**
+ * ProgressInfo reports a step of a turn, so a client can show what the agent
+ * is doing rather than a spinner. The outcome of the turn is still carried by
+ * its chunks, done and error.
+ */
+export interface ProgressInfo {
+  /**
+   * Stage is the kind of step, one of the ProgressStage constants.
+   */
+  stage: string;
+  /**
+   * Status is one of the ProgressStatus constants.
+   */
+  status: string;
+  /**
+   * ToolName names the tool, for the tool stage.
+   */
+  tool_name?: string;
+  /**
+   * CallID identifies the tool call, for the tool stage.
+   */
+  call_id?: string;
+  /**
+   * ElapsedMs is how long the step has run, in milliseconds.
+   */
+  elapsed_ms?: number /* int64 */;
+}
 /**
  * ConnectionCapabilities represents negotiated connection features.
  * Sent in the connected message to inform the client of available capabilities.
@@ -492,9 +809,21 @@ export interface ConnectionCapabilities {
    */
   max_payload_size?: number /* int */;
   /**
-   * ProtocolVersion is the binary protocol version supported.
+   * ProtocolVersion is the binary frame version supported. The JSON
+   * message schema is versioned separately; see ConnectedInfo.Protocol.
    */
   protocol_version?: number /* int */;
+  /**
+   * MaxFragmentedSize is the largest message the client may send as
+   * fragments. Zero means the server does not accept fragments.
+   */
+  max_fragmented_size?: number /* int */;
+  /**
+   * Audio is the format to capture voice in, present when the agent accepts
+   * duplex audio. The runtime may still counter-offer a different format
+   * with session_config.
+   */
+  audio?: SessionConfigInfo;
 }
 /**
  * ConnectedInfo contains additional information sent with the connected message.
@@ -505,11 +834,58 @@ export interface ConnectedInfo {
    */
   capabilities?: ConnectionCapabilities;
   /**
-   * Resumed is true when this connection re-attached to a parked realtime
-   * session (T1 blip-resume) rather than starting fresh. The client keeps its
-   * sequence counter on resume and resets it on a fresh session.
+   * Resumed is true when this connection re-attached to the session named
+   * by ?resume= — a parked realtime session (T1 blip-resume) or a session
+   * whose missed messages are replayed — rather than starting fresh. The
+   * client keeps its sequence counters on resume and resets them on a fresh
+   * session.
    */
   resumed?: boolean;
+  /**
+   * Protocol is the outcome of the connect-time handshake: the message
+   * schema version and the features this connection uses.
+   */
+  protocol?: ProtocolInfo;
+  /**
+   * ClientSeq is, on a resumed session, the seq of the last client message
+   * the server accepted. The client resends the messages after it.
+   */
+  client_seq?: number /* uint64 */;
+}
+/**
+ * AckInfo acknowledges messages up to a sequence number.
+ */
+export interface AckInfo {
+  /**
+   * Seq is the sequence number acknowledged: a server message seq in a
+   * client ack, a client message seq in a server ack.
+   */
+  seq: number /* uint64 */;
+  /**
+   * Duplicate is set on a server ack for a client message that was already
+   * accepted and was dropped rather than processed again.
+   */
+  duplicate?: boolean;
+}
+/**
+ * ProtocolInfo acknowledges the protocol version and features requested with
+ * ?protocol= and ?features= on connect.
+ */
+export interface ProtocolInfo {
+  /**
+   * Version is the message schema version in use: the lower of the
+   * client's and the server's.
+   */
+  version: number /* int */;
+  /**
+   * MinVersion is the oldest schema version the server still serves.
+   */
+  min_version: number /* int */;
+  /**
+   * Features are the features both sides support, sorted. Messages that
+   * need a feature not listed are not sent.
+   */
+  features: string[];
 }
 /**
  * Error codes.
@@ -553,6 +929,56 @@ export const ErrorCodeRateLimited = "RATE_LIMITED";
  * which this audio-only path does not implement).
  */
 export const ErrorCodeUnsatisfiableFormat = "UNSATISFIABLE_FORMAT";
+/**
+ * ErrorCodeStructuredOutputInvalid is relayed from the runtime when an
+ * agent with spec.structuredOutput could not produce a response that
+ * satisfies its response schema, even after repair attempts.
+ */
+export const ErrorCodeStructuredOutputInvalid = "STRUCTURED_OUTPUT_INVALID";
+/**
+ * ErrorCodeGuardrailRejected is relayed from the runtime when an input or
+ * output guardrail hook rejected the user's message or the agent's
+ * response.
+ */
+export const ErrorCodeGuardrailRejected = "GUARDRAIL_REJECTED";
+/**
+ * ErrorCodeBudgetExceeded is relayed from the runtime when a turn was
+ * rejected because its session or the agent is over its token or cost
+ * budget.
+ */
+export const ErrorCodeBudgetExceeded = "BUDGET_EXCEEDED";
+/**
+ * ErrorCodeServerShutdown is sent when a turn is cut off because the
+ * server finished draining before it completed. The session can be
+ * resumed on another replica.
+ */
+export const ErrorCodeServerShutdown = "SERVER_SHUTDOWN";
+/**
+ * ErrorCodeSessionTerminated is sent when an operator ended the session
+ * through the admin API. The session cannot be resumed.
+ */
+export const ErrorCodeSessionTerminated = "SESSION_TERMINATED";
+/**
+ * ErrorCodeMessageTooLarge is sent when a message the client sent as
+ * fragments is larger than ConnectionCapabilities.MaxFragmentedSize. The
+ * message is discarded.
+ */
+export const ErrorCodeMessageTooLarge = "MESSAGE_TOO_LARGE";
+/**
+ * ErrorCodeUnauthenticated is returned by HTTP chat when the request's
+ * credentials are missing or invalid.
+ */
+export const ErrorCodeUnauthenticated = "UNAUTHENTICATED";
+/**
+ * ErrorCodePermissionDenied is returned by HTTP chat when the caller is
+ * not allowed to talk to the agent.
+ */
+export const ErrorCodePermissionDenied = "PERMISSION_DENIED";
+/**
+ * ErrorCodeUnavailable is sent when a service the turn needs, such as
+ * the agent's runtime, is temporarily unavailable.
+ */
+export const ErrorCodeUnavailable = "UNAVAILABLE";
 /**
  * RoleUser marks a chunk as the caller's transcribed speech (duplex path).
  */
@@ -75,7 +75,7 @@ export interface Session {
   id: string;
   agentName: string;
   agentNamespace: string;
-  status: "active" | "completed" | "error" | "expired";
+  status: "active" | "idle" | "escalated" | "completed" | "error" | "expired" | "archived";
   startedAt: string; // ISO date string
   endedAt?: string; // ISO date string
   messages: Message[];
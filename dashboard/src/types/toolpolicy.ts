@@ -1,12 +1,13 @@
 /**
  * TypeScript types for the ToolPolicy CRD.
  *
- * ToolPolicy is an Enterprise CRD for CEL-based tool parameter validation.
+ * ToolPolicy is an Enterprise CRD for CEL- or OPA-based tool parameter validation.
  * It enables platform operators to enforce constraints on tool calls at runtime
  * (e.g., deny queries containing sensitive patterns, inject audit headers).
  */
 
-export type PolicyMode = "enforce" | "audit";
+export type PolicyMode = "enforce" | "shadow" | "audit";
+export type ToolPolicyEngine = "cel" | "opa";
 export type ToolPolicyOnFailureAction = "deny" | "allow";
 export type ToolPolicyPhase = "Active" | "Error";
 
@@ -37,6 +38,12 @@ export interface HeaderInjectionRule {
   cel?: string;
 }
 
+export interface OPAPolicyConfig {
+  url: string;
+  path: string;
+  timeout?: string;
+}
+
 export interface ToolPolicyAuditConfig {
   logDecisions?: boolean;
   redactFields?: string[];
@@ -44,7 +51,9 @@ export interface ToolPolicyAuditConfig {
 
 export interface ToolPolicySpec {
   selector: ToolPolicySelector;
-  rules: PolicyRule[];
+  engine?: ToolPolicyEngine;
+  rules?: PolicyRule[];
+  opa?: OPAPolicyConfig;
   requiredClaims?: RequiredClaim[];
   mode?: PolicyMode;
   onFailure?: ToolPolicyOnFailureAction;
@@ -29,6 +29,39 @@ const (
 	ArenaJobTypeDataGen ArenaJobType = "datagen"
 )
 
+// ParameterSet is a named combination of model parameters applied to every
+// provider and prompt in the arena config. Unset fields keep the arena file's values.
+type ParameterSet struct {
+	// name identifies the parameter set in results.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// temperature controls randomness in responses (0.0-2.0).
+	// Specified as a string to support decimal values (e.g., "0.7").
+	// +kubebuilder:validation:Pattern=`^([01](\.[0-9]+)?|2(\.0+)?)$`
+	// +optional
+	Temperature *string `json:"temperature,omitempty"`
+
+	// topP controls nucleus sampling (0.0-1.0).
+	// Specified as a string to support decimal values (e.g., "0.9").
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	TopP *string `json:"topP,omitempty"`
+
+	// maxTokens limits the maximum number of tokens in the response.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTokens *int32 `json:"maxTokens,omitempty"`
+
+	// systemPrompt replaces the system template of every prompt in the arena
+	// config, for comparing system-prompt variants.
+	// +optional
+	SystemPrompt string `json:"systemPrompt,omitempty"`
+}
+
 // ScenarioFilter defines include/exclude patterns for scenario selection.
 type ScenarioFilter struct {
 	// include specifies glob patterns for scenarios to include.
@@ -191,6 +224,76 @@ type WorkerConfig struct {
 	// secret-stores, custom envFrom for provider credentials, etc.).
 	// +optional
 	PodOverrides *corev1alpha1.PodOverrides `json:"podOverrides,omitempty"`
+
+	// security hardens the sandbox the worker pods run in. Eval scenarios can
+	// execute semi-trusted tool code, so workers are already locked down by
+	// default; this block adjusts that profile and can move the pods onto a
+	// sandboxed container runtime.
+	// +optional
+	Security *WorkerSecurityConfig `json:"security,omitempty"`
+}
+
+// WorkerSeccompProfileType selects the seccomp profile applied to workers.
+// Unconfined is deliberately not offered.
+// +kubebuilder:validation:Enum=RuntimeDefault;Localhost
+type WorkerSeccompProfileType string
+
+const (
+	// WorkerSeccompProfileRuntimeDefault uses the container runtime's default profile.
+	WorkerSeccompProfileRuntimeDefault WorkerSeccompProfileType = "RuntimeDefault"
+	// WorkerSeccompProfileLocalhost uses a profile file on the node.
+	WorkerSeccompProfileLocalhost WorkerSeccompProfileType = "Localhost"
+)
+
+// WorkerSeccompProfile configures the seccomp profile of the worker pods.
+// +kubebuilder:validation:XValidation:rule="self.type != 'Localhost' || has(self.localhostProfile)",message="localhostProfile is required when type is Localhost"
+type WorkerSeccompProfile struct {
+	// type is the kind of seccomp profile to apply.
+	// +kubebuilder:default=RuntimeDefault
+	Type WorkerSeccompProfileType `json:"type"`
+
+	// localhostProfile is the path of the profile relative to the kubelet's
+	// configured seccomp root. Required when type is Localhost.
+	// +optional
+	LocalhostProfile *string `json:"localhostProfile,omitempty"`
+}
+
+// WorkerSecurityConfig is the sandbox hardening profile for worker pods.
+// Unset fields keep the operator defaults: RuntimeDefault seccomp, a
+// read-only root filesystem, UID/GID 65532 and all capabilities dropped.
+type WorkerSecurityConfig struct {
+	// runtimeClassName runs the worker pods under the named RuntimeClass,
+	// e.g. "gvisor" or "kata", for kernel-level isolation. The RuntimeClass
+	// must exist in the cluster or the pods will not be scheduled.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// seccompProfile overrides the pod seccomp profile.
+	// Defaults to RuntimeDefault.
+	// +optional
+	SeccompProfile *WorkerSeccompProfile `json:"seccompProfile,omitempty"`
+
+	// readOnlyRootFilesystem mounts the worker container's root filesystem
+	// read-only. Defaults to true.
+	// +optional
+	ReadOnlyRootFilesystem *bool `json:"readOnlyRootFilesystem,omitempty"`
+
+	// runAsUser is the non-root UID the worker runs as. Defaults to 65532.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+
+	// runAsGroup is the primary GID of the worker and the pod fsGroup.
+	// Defaults to 65532.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+
+	// dropCapabilities lists Linux capabilities dropped from the worker
+	// container in addition to ALL, which is always dropped.
+	// +optional
+	DropCapabilities []string `json:"dropCapabilities,omitempty"`
 }
 
 // OutputType represents the type of output destination.
@@ -320,6 +423,16 @@ type ArenaJobSpec struct {
 	// +optional
 	Scenarios *ScenarioFilter `json:"scenarios,omitempty"`
 
+	// parameterSets sweeps model parameters as an additional matrix dimension:
+	// every scenario × provider combination runs once per parameter set, and
+	// results are grouped by set name. Requires scenario enumeration; ignored
+	// when the job falls back to per-provider work items.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	ParameterSets []ParameterSet `json:"parameterSets,omitempty"`
+
 	// evaluation configures evaluation-specific settings.
 	// Used when type is "evaluation".
 	// +optional
@@ -389,6 +502,13 @@ type ArenaJobSpec struct {
 	// Telemetry and traces are unaffected.
 	// +optional
 	SessionRecording bool `json:"sessionRecording,omitempty"`
+
+	// goldenDatasets lists GoldenDataset CRDs whose expected outputs are
+	// asserted against matching scenarios. Each dataset is resolved to its
+	// current version when the worker Job is created, and results are
+	// reported per dataset version.
+	// +optional
+	GoldenDatasets []corev1alpha1.LocalObjectReference `json:"goldenDatasets,omitempty"`
 }
 
 // ArenaJobPhase represents the current phase of the ArenaJob.
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+)
+
+// GoldenDatasetSpec defines the desired state of GoldenDataset.
+type GoldenDatasetSpec struct {
+	// sourceRef references the ArenaSource whose content holds the dataset file.
+	// +kubebuilder:validation:Required
+	SourceRef corev1alpha1.LocalObjectReference `json:"sourceRef"`
+
+	// path is the path to the dataset file within the source content.
+	// The file lists expected outputs keyed by scenario ID.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[^/].*\.ya?ml$`
+	Path string `json:"path"`
+
+	// version pins the dataset to a specific content-addressable ArenaSource
+	// version. When empty, the dataset tracks the source's head version.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// GoldenDatasetPhase represents the current phase of the GoldenDataset.
+// +kubebuilder:validation:Enum=Pending;Ready;Error
+type GoldenDatasetPhase string
+
+const (
+	// GoldenDatasetPhasePending indicates the source has not produced content yet.
+	GoldenDatasetPhasePending GoldenDatasetPhase = "Pending"
+	// GoldenDatasetPhaseReady indicates the dataset version is resolved and loadable.
+	GoldenDatasetPhaseReady GoldenDatasetPhase = "Ready"
+	// GoldenDatasetPhaseError indicates the dataset could not be resolved or parsed.
+	GoldenDatasetPhaseError GoldenDatasetPhase = "Error"
+)
+
+// GoldenDatasetStatus defines the observed state of GoldenDataset.
+type GoldenDatasetStatus struct {
+	// phase represents the current lifecycle phase of the GoldenDataset.
+	// +optional
+	Phase GoldenDatasetPhase `json:"phase,omitempty"`
+
+	// conditions represent the current state of the GoldenDataset resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// observedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// resolvedVersion is the ArenaSource version the dataset currently resolves to.
+	// +optional
+	ResolvedVersion string `json:"resolvedVersion,omitempty"`
+
+	// contentPath is the workspace-relative path of the resolved version's
+	// content directory.
+	// +optional
+	ContentPath string `json:"contentPath,omitempty"`
+
+	// entryCount is the number of expected-output entries in the resolved version.
+	// +optional
+	EntryCount int32 `json:"entryCount,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=gd
+// +kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.spec.sourceRef.name`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.resolvedVersion`,priority=1
+// +kubebuilder:printcolumn:name="Entries",type=integer,JSONPath=`.status.entryCount`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// GoldenDataset is the Schema for the goldendatasets API.
+// It references versioned expected outputs stored in ArenaSource content so
+// evaluation jobs can assert against managed, diffable golden answers.
+type GoldenDataset struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of GoldenDataset
+	// +required
+	Spec GoldenDatasetSpec `json:"spec"`
+
+	// status defines the observed state of GoldenDataset
+	// +optional
+	Status GoldenDatasetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GoldenDatasetList contains a list of GoldenDataset.
+type GoldenDatasetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GoldenDataset `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GoldenDataset{}, &GoldenDatasetList{})
+}
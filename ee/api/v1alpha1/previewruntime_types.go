@@ -0,0 +1,130 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+)
+
+// PreviewRuntimePhase is the lifecycle phase of a PreviewRuntime.
+// +kubebuilder:validation:Enum=Pending;Ready;Error
+type PreviewRuntimePhase string
+
+const (
+	PreviewRuntimePhasePending PreviewRuntimePhase = "Pending"
+	PreviewRuntimePhaseReady   PreviewRuntimePhase = "Ready"
+	PreviewRuntimePhaseError   PreviewRuntimePhase = "Error"
+)
+
+// PreviewRuntimeConditionReady is the ready condition type.
+const PreviewRuntimeConditionReady = "Ready"
+
+// PreviewRuntimeSpec describes a temporary AgentRuntime built from a Git ref's
+// PromptPack, typically the head branch of a pull request.
+type PreviewRuntimeSpec struct {
+	// runtimeRef names the AgentRuntime in this namespace the preview is cloned
+	// from. The preview keeps its providers, tools and facades and swaps in the
+	// PromptPack built from git.
+	// +kubebuilder:validation:Required
+	RuntimeRef corev1.LocalObjectReference `json:"runtimeRef"`
+
+	// git points at the ref whose configured path holds pack.json.
+	// +kubebuilder:validation:Required
+	Git corev1alpha1.GitSource `json:"git"`
+
+	// ttl is how long the preview lives, measured from creation. When it
+	// elapses the PreviewRuntime is deleted along with everything it created.
+	// Defaults to "72h".
+	// +kubebuilder:default="72h"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +optional
+	TTL string `json:"ttl,omitempty"`
+
+	// interval is how often the ref is re-polled so new pushes reach the
+	// preview, e.g. "5m". Defaults to "5m".
+	// +kubebuilder:default="5m"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// timeout bounds a single fetch. Defaults to "60s".
+	// +kubebuilder:default="60s"
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// PreviewRuntimeStatus is the observed state.
+type PreviewRuntimeStatus struct {
+	// +optional
+	Phase PreviewRuntimePhase `json:"phase,omitempty"`
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// runtimeName is the AgentRuntime serving the preview.
+	// +optional
+	RuntimeName string `json:"runtimeName,omitempty"`
+	// packName is the logical pack the preview's PromptPacks are published under.
+	// +optional
+	PackName string `json:"packName,omitempty"`
+	// version is the PromptPack version the preview runtime is pinned to.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// +optional
+	Artifact *corev1alpha1.Artifact `json:"artifact,omitempty"`
+	// url is the first valid external endpoint of the preview runtime, for
+	// posting back to the pull request.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// endpoints mirrors the preview runtime's status.facade.endpoints.
+	// +optional
+	Endpoints []corev1alpha1.FacadeEndpoint `json:"endpoints,omitempty"`
+	// serviceEndpoint mirrors the preview runtime's in-cluster endpoint.
+	// +optional
+	ServiceEndpoint string `json:"serviceEndpoint,omitempty"`
+	// expiresAt is when the preview is garbage-collected.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// +optional
+	LastFetchTime *metav1.Time `json:"lastFetchTime,omitempty"`
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=prt
+// +kubebuilder:printcolumn:name="Runtime",type=string,JSONPath=`.spec.runtimeRef.name`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`
+// +kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.status.expiresAt`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type PreviewRuntime struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PreviewRuntimeSpec   `json:"spec,omitempty"`
+	Status            PreviewRuntimeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+type PreviewRuntimeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PreviewRuntime `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PreviewRuntime{}, &PreviewRuntimeList{})
+}
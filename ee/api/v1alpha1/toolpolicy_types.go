@@ -13,16 +13,25 @@ import (
 )
 
 // PolicyMode defines how the policy is applied.
-// +kubebuilder:validation:Enum=enforce;audit
+// +kubebuilder:validation:Enum=enforce;shadow;audit
 type PolicyMode string
 
 const (
 	// PolicyModeEnforce blocks requests that violate the policy.
 	PolicyModeEnforce PolicyMode = "enforce"
-	// PolicyModeAudit logs violations but allows requests through.
+	// PolicyModeShadow evaluates the policy and records the requests it would
+	// have denied, but allows them through.
+	PolicyModeShadow PolicyMode = "shadow"
+	// PolicyModeAudit is the earlier name of PolicyModeShadow.
 	PolicyModeAudit PolicyMode = "audit"
 )
 
+// Enforces reports whether the policy's denials block requests. Shadow and
+// audit policies only record them.
+func (m PolicyMode) Enforces() bool {
+	return m != PolicyModeShadow && m != PolicyModeAudit
+}
+
 // OnFailureAction defines what happens when policy evaluation fails.
 // +kubebuilder:validation:Enum=deny;allow
 type OnFailureAction string
@@ -34,6 +43,40 @@ const (
 	OnFailureAllow OnFailureAction = "allow"
 )
 
+// PolicyEngine defines the language a policy's rules are written in.
+// +kubebuilder:validation:Enum=cel;opa
+type PolicyEngine string
+
+const (
+	// PolicyEngineCEL evaluates the policy's CEL rules in the broker.
+	PolicyEngineCEL PolicyEngine = "cel"
+	// PolicyEngineOPA queries an Open Policy Agent server for a decision
+	// from Rego policies.
+	PolicyEngineOPA PolicyEngine = "opa"
+)
+
+// OPAPolicyConfig defines the OPA decision a policy queries.
+type OPAPolicyConfig struct {
+	// url is the base URL of the OPA server's REST API, e.g. http://opa.policy:8181.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// path is the path of the decision document under /v1/data, e.g. omnia/tools/decision.
+	// The document is queried with input {headers, body, identity} and must
+	// be a boolean (true allows the call) or an object with a boolean allow
+	// field and an optional message returned when the call is denied.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// timeout is how long a query may take before it counts as an evaluation failure (see onFailure).
+	// Defaults to 2s.
+	// +kubebuilder:default="2s"
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+}
+
 // ToolPolicySelector defines which tools this policy applies to.
 type ToolPolicySelector struct {
 	// registry is the name of the ToolRegistry to match.
@@ -108,22 +151,36 @@ type HeaderInjectionRule struct {
 }
 
 // ToolPolicySpec defines the desired state of ToolPolicy.
+// +kubebuilder:validation:XValidation:rule="has(self.engine) && self.engine == 'opa' ? has(self.opa) : (has(self.rules) && size(self.rules) > 0)",message="opa is required when engine is opa; otherwise at least one rule is required"
 type ToolPolicySpec struct {
 	// selector defines which tools this policy applies to.
 	// +kubebuilder:validation:Required
 	Selector ToolPolicySelector `json:"selector"`
 
+	// engine selects how the policy decides: cel evaluates rules, opa queries an OPA server.
+	// requiredClaims, mode, onFailure and headerInjection apply with either engine.
+	// +kubebuilder:default="cel"
+	// +optional
+	Engine PolicyEngine `json:"engine,omitempty"`
+
 	// rules defines the CEL-based policy rules to evaluate.
 	// Rules are evaluated in order; the first deny stops evaluation.
-	// +kubebuilder:validation:MinItems=1
-	// +kubebuilder:validation:Required
-	Rules []PolicyRule `json:"rules"`
+	// Required when engine is cel; ignored when engine is opa.
+	// +optional
+	Rules []PolicyRule `json:"rules,omitempty"`
+
+	// opa defines the OPA decision to query. Required when engine is opa.
+	// +optional
+	OPA *OPAPolicyConfig `json:"opa,omitempty"`
 
 	// requiredClaims defines claims that must be present in request headers.
 	// +optional
 	RequiredClaims []RequiredClaim `json:"requiredClaims,omitempty"`
 
-	// mode determines whether the policy enforces or only audits violations.
+	// mode determines whether the policy enforces or only records violations.
+	// A shadow policy is evaluated on every matching call and its would-be
+	// denials are recorded, in metrics and as session events, without blocking
+	// the call. audit is the earlier name of shadow.
 	// +kubebuilder:default="enforce"
 	// +optional
 	Mode PolicyMode `json:"mode,omitempty"`
@@ -174,13 +231,14 @@ type ToolPolicyStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=tp
 // +kubebuilder:printcolumn:name="Registry",type=string,JSONPath=`.spec.selector.registry`
+// +kubebuilder:printcolumn:name="Engine",type=string,JSONPath=`.spec.engine`
 // +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.mode`
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Rules",type=integer,JSONPath=`.status.ruleCount`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // ToolPolicy is the Schema for the toolpolicies API.
-// It defines CEL-based access control rules for tool invocations.
+// It defines CEL- or OPA-based access control rules for tool invocations.
 type ToolPolicy struct {
 	metav1.TypeMeta `json:",inline"`
 
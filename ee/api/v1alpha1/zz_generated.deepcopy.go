@@ -236,6 +236,13 @@ func (in *ArenaJobSpec) DeepCopyInto(out *ArenaJobSpec) {
 		*out = new(ScenarioFilter)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ParameterSets != nil {
+		in, out := &in.ParameterSets, &out.ParameterSets
+		*out = make([]ParameterSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Evaluation != nil {
 		in, out := &in.Evaluation, &out.Evaluation
 		*out = new(EvaluationSettings)
@@ -283,6 +290,11 @@ func (in *ArenaJobSpec) DeepCopyInto(out *ArenaJobSpec) {
 		*out = make([]apiv1alpha1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.GoldenDatasets != nil {
+		in, out := &in.GoldenDatasets, &out.GoldenDatasets
+		*out = make([]apiv1alpha1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArenaJobSpec.
@@ -706,6 +718,103 @@ func (in *EvaluationSettings) DeepCopy() *EvaluationSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenDataset) DeepCopyInto(out *GoldenDataset) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenDataset.
+func (in *GoldenDataset) DeepCopy() *GoldenDataset {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenDataset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GoldenDataset) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenDatasetList) DeepCopyInto(out *GoldenDatasetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GoldenDataset, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenDatasetList.
+func (in *GoldenDatasetList) DeepCopy() *GoldenDatasetList {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenDatasetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GoldenDatasetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenDatasetSpec) DeepCopyInto(out *GoldenDatasetSpec) {
+	*out = *in
+	out.SourceRef = in.SourceRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenDatasetSpec.
+func (in *GoldenDatasetSpec) DeepCopy() *GoldenDatasetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenDatasetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenDatasetStatus) DeepCopyInto(out *GoldenDatasetStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenDatasetStatus.
+func (in *GoldenDatasetStatus) DeepCopy() *GoldenDatasetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenDatasetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HeaderInjectionRule) DeepCopyInto(out *HeaderInjectionRule) {
 	*out = *in
@@ -877,6 +986,21 @@ func (in *MetricProvider) DeepCopy() *MetricProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPAPolicyConfig) DeepCopyInto(out *OPAPolicyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPAPolicyConfig.
+func (in *OPAPolicyConfig) DeepCopy() *OPAPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OPAPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OutputConfig) DeepCopyInto(out *OutputConfig) {
 	*out = *in
@@ -937,6 +1061,36 @@ func (in *PVCOutputConfig) DeepCopy() *PVCOutputConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterSet) DeepCopyInto(out *ParameterSet) {
+	*out = *in
+	if in.Temperature != nil {
+		in, out := &in.Temperature, &out.Temperature
+		*out = new(string)
+		**out = **in
+	}
+	if in.TopP != nil {
+		in, out := &in.TopP, &out.TopP
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterSet.
+func (in *ParameterSet) DeepCopy() *ParameterSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyRule) DeepCopyInto(out *PolicyRule) {
 	*out = *in
@@ -968,6 +1122,122 @@ func (in *PolicyRuleDeny) DeepCopy() *PolicyRuleDeny {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewRuntime) DeepCopyInto(out *PreviewRuntime) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewRuntime.
+func (in *PreviewRuntime) DeepCopy() *PreviewRuntime {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewRuntime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreviewRuntime) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewRuntimeList) DeepCopyInto(out *PreviewRuntimeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PreviewRuntime, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewRuntimeList.
+func (in *PreviewRuntimeList) DeepCopy() *PreviewRuntimeList {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewRuntimeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreviewRuntimeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewRuntimeSpec) DeepCopyInto(out *PreviewRuntimeSpec) {
+	*out = *in
+	out.RuntimeRef = in.RuntimeRef
+	in.Git.DeepCopyInto(&out.Git)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewRuntimeSpec.
+func (in *PreviewRuntimeSpec) DeepCopy() *PreviewRuntimeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewRuntimeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewRuntimeStatus) DeepCopyInto(out *PreviewRuntimeStatus) {
+	*out = *in
+	if in.Artifact != nil {
+		in, out := &in.Artifact, &out.Artifact
+		*out = new(apiv1alpha1.Artifact)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]apiv1alpha1.FacadeEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFetchTime != nil {
+		in, out := &in.LastFetchTime, &out.LastFetchTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewRuntimeStatus.
+func (in *PreviewRuntimeStatus) DeepCopy() *PreviewRuntimeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewRuntimeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivacyRetentionConfig) DeepCopyInto(out *PrivacyRetentionConfig) {
 	*out = *in
@@ -1708,6 +1978,11 @@ func (in *ToolPolicySpec) DeepCopyInto(out *ToolPolicySpec) {
 		*out = make([]PolicyRule, len(*in))
 		copy(*out, *in)
 	}
+	if in.OPA != nil {
+		in, out := &in.OPA, &out.OPA
+		*out = new(OPAPolicyConfig)
+		**out = **in
+	}
 	if in.RequiredClaims != nil {
 		in, out := &in.RequiredClaims, &out.RequiredClaims
 		*out = make([]RequiredClaim, len(*in))
@@ -1817,6 +2092,11 @@ func (in *WorkerConfig) DeepCopyInto(out *WorkerConfig) {
 		*out = new(apiv1alpha1.PodOverrides)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(WorkerSecurityConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerConfig.
@@ -1828,3 +2108,68 @@ func (in *WorkerConfig) DeepCopy() *WorkerConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerSeccompProfile) DeepCopyInto(out *WorkerSeccompProfile) {
+	*out = *in
+	if in.LocalhostProfile != nil {
+		in, out := &in.LocalhostProfile, &out.LocalhostProfile
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerSeccompProfile.
+func (in *WorkerSeccompProfile) DeepCopy() *WorkerSeccompProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerSeccompProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerSecurityConfig) DeepCopyInto(out *WorkerSecurityConfig) {
+	*out = *in
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SeccompProfile != nil {
+		in, out := &in.SeccompProfile, &out.SeccompProfile
+		*out = new(WorkerSeccompProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadOnlyRootFilesystem != nil {
+		in, out := &in.ReadOnlyRootFilesystem, &out.ReadOnlyRootFilesystem
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RunAsGroup != nil {
+		in, out := &in.RunAsGroup, &out.RunAsGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DropCapabilities != nil {
+		in, out := &in.DropCapabilities, &out.DropCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerSecurityConfig.
+func (in *WorkerSecurityConfig) DeepCopy() *WorkerSecurityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerSecurityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -24,6 +24,8 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"net/http"
@@ -37,8 +39,11 @@ import (
 	"github.com/AltairaLabs/promptarena/arena/arenaconfig"
 	"github.com/altairalabs/omnia/ee/cmd/arena-dev-console/server"
 	"github.com/altairalabs/omnia/internal/facade"
+	"github.com/altairalabs/omnia/internal/session"
 	"github.com/altairalabs/omnia/pkg/facade/auth"
 	"github.com/altairalabs/omnia/pkg/k8s"
+	// Register the openai-compatible provider factory for Provider CRDs.
+	_ "github.com/altairalabs/omnia/pkg/provider/openaicompatible"
 	"github.com/altairalabs/omnia/pkg/servicediscovery"
 	"github.com/altairalabs/omnia/pkg/session/httpclient"
 	"github.com/go-logr/logr"
@@ -155,6 +160,7 @@ func main() {
 		defer cleanup()
 	}
 	handler.SetReloadBasePath(*workspacePath)
+	handler.SetRecorder(store)
 
 	mgmtPlaneValidator, err := loadMgmtPlaneValidator(log)
 	if err != nil {
@@ -177,7 +183,7 @@ func main() {
 	}
 	wsServer := facade.NewServer(wsConfig, store, handler, log, serverOpts...)
 
-	mux := buildFacadeMux(wsServer, handler, log, authChain, allowUnauthenticated)
+	mux := buildFacadeMux(wsServer, handler, store, log, authChain, allowUnauthenticated)
 
 	// Create facade HTTP server
 	facadeServer := &http.Server{
@@ -271,16 +277,18 @@ func createHandler(log logr.Logger, configFile string) (*server.PromptKitHandler
 	return handler, cleanup, nil
 }
 
-// buildFacadeMux registers the dev console's three HTTP routes:
+// buildFacadeMux registers the dev console's HTTP routes:
 //   - /ws         — WebSocket endpoint backed by the facade server
 //   - /api/providers — list configured providers (GET only)
 //   - /api/reload    — hot-reload config from disk (POST only)
+//   - /api/sessions/{sessionID}/timeline — replay a recorded session (GET only)
 //
-// Extracted so a wiring test can assert all three routes are registered
+// Extracted so a wiring test can assert all routes are registered
 // without spinning up a real listener or PromptKit handler.
 func buildFacadeMux(
 	wsServer http.Handler,
 	handler *server.PromptKitHandler,
+	timelines server.TimelineSource,
 	log logr.Logger,
 	authChain auth.Chain,
 	allowUnauthenticated bool,
@@ -300,8 +308,15 @@ func buildFacadeMux(
 		auth.WithMiddlewareLogger(log),
 		auth.WithMiddlewareAllowUnauthenticated(allowUnauthenticated),
 	)
+	timelineHandler := auth.Middleware(
+		authChain,
+		handleTimeline(timelines, log),
+		auth.WithMiddlewareLogger(log),
+		auth.WithMiddlewareAllowUnauthenticated(allowUnauthenticated),
+	)
 	mux.Handle("/api/providers", providersHandler)
 	mux.Handle("/api/reload", reloadHandler)
+	mux.Handle("/api/sessions/{sessionID}/timeline", timelineHandler)
 	return mux
 }
 
@@ -393,6 +408,38 @@ func handleReload(handler *server.PromptKitHandler, log logr.Logger) http.Handle
 	}
 }
 
+// handleTimeline returns the recorded timeline of a dev console session —
+// messages, tool calls with their results, and config reloads — in the order
+// they happened, so a misbehaving conversation can be shared and replayed.
+func handleTimeline(source server.TimelineSource, log logr.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if source == nil {
+			http.Error(w, "session recording not configured", http.StatusServiceUnavailable)
+			return
+		}
+
+		sessionID := r.PathValue("sessionID")
+		timeline, err := server.BuildTimeline(r.Context(), source, sessionID)
+		if errors.Is(err, session.ErrSessionNotFound) {
+			http.Error(w, "session not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			log.Error(err, "timeline build failed", "sessionID", sessionID)
+			http.Error(w, fmt.Sprintf("timeline build failed: %v", err), http.StatusBadGateway)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(timeline)
+	}
+}
+
 // startHealthServer starts a minimal health endpoint so Kubernetes liveness
 // probes pass while the main server is still initialising (e.g. during
 // service-discovery retry). The full readyz handler is added later.
@@ -0,0 +1,404 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/altairalabs/omnia/internal/facade"
+)
+
+// Error codes for collaborative sessions.
+const (
+	errCodeObserverReadOnly = "OBSERVER_READ_ONLY"
+	errCodeInvalidHandoff   = "INVALID_HANDOFF"
+)
+
+// participant is one WebSocket connection attached to a dev console session.
+type participant struct {
+	info   facade.ParticipantInfo
+	writer facade.ResponseWriter
+	// owner is set when the participant's user owns the session.
+	owner bool
+}
+
+// sessionRoom is one session's participants in join order, and the users its owner
+// invited to drive.
+type sessionRoom struct {
+	participants []*participant
+	invited      map[string]bool // user ID -> invited by the owner
+}
+
+// mayDrive reports whether p may hold the driver role: the session's owner,
+// or a participant whose user the owner handed the role to.
+func (r *sessionRoom) mayDrive(p *participant) bool {
+	return p.owner || (p.info.UserID != "" && r.invited[p.info.UserID])
+}
+
+// promote makes the longest-attached participant allowed to drive the
+// driver when nobody drives.
+func (r *sessionRoom) promote() {
+	if driverOf(r.participants) != nil {
+		return
+	}
+	for _, p := range r.participants {
+		if r.mayDrive(p) {
+			p.info.Role = facade.ParticipantRoleDriver
+			return
+		}
+	}
+}
+
+// collaboration tracks the participants of each dev console session so
+// several developers can watch (observers) while one drives. The zero value
+// is ready to use.
+//
+// Every session has at most one driver, and only its owner or a user the
+// owner invited may drive. The owner drives on joining while nobody does; an
+// invited participant asks for ?role=driver. When the driver leaves, the
+// longest-attached participant allowed to drive takes over, and otherwise
+// nobody drives until the owner returns.
+type collaboration struct {
+	mu     sync.Mutex
+	rooms  map[string]*sessionRoom
+	byConn map[string]string // connection ID -> session ID
+}
+
+// join attaches a connection to its session's room and returns its role.
+func (c *collaboration) join(conn facade.ConnectionInfo, writer facade.ResponseWriter) facade.ParticipantRole {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rooms == nil {
+		c.rooms = make(map[string]*sessionRoom)
+		c.byConn = make(map[string]string)
+	}
+	r := c.roomLocked(conn.SessionID)
+	p := &participant{
+		info: facade.ParticipantInfo{
+			ID:       conn.ID,
+			UserID:   conn.UserID,
+			Email:    conn.UserEmail,
+			Role:     facade.ParticipantRoleObserver,
+			JoinedAt: time.Now(),
+		},
+		writer: writer,
+		owner:  conn.Owner,
+	}
+	if driverOf(r.participants) == nil && r.mayDrive(p) &&
+		(p.owner || conn.RequestedRole == facade.ParticipantRoleDriver) {
+		p.info.Role = facade.ParticipantRoleDriver
+	}
+	r.participants = append(r.participants, p)
+	c.byConn[conn.ID] = conn.SessionID
+	return p.info.Role
+}
+
+// roomLocked returns sessionID's room, creating it. Callers hold c.mu.
+func (c *collaboration) roomLocked(sessionID string) *sessionRoom {
+	r, ok := c.rooms[sessionID]
+	if !ok {
+		r = &sessionRoom{invited: make(map[string]bool)}
+		c.rooms[sessionID] = r
+	}
+	return r
+}
+
+// leave detaches a connection and returns the session it was in and how
+// many participants remain there.
+func (c *collaboration) leave(connID string) (string, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sessionID, ok := c.byConn[connID]
+	if !ok {
+		return "", 0
+	}
+	delete(c.byConn, connID)
+	c.detachLocked(sessionID, connID)
+	if r, ok := c.rooms[sessionID]; ok {
+		return sessionID, len(r.participants)
+	}
+	return sessionID, 0
+}
+
+// detachLocked removes connID from sessionID's room, dropping the room once
+// empty, and returns the participant. Callers hold c.mu.
+func (c *collaboration) detachLocked(sessionID, connID string) *participant {
+	r, ok := c.rooms[sessionID]
+	if !ok {
+		return nil
+	}
+	var removed *participant
+	r.participants = slices.DeleteFunc(r.participants, func(p *participant) bool {
+		if p.info.ID == connID {
+			removed = p
+			return true
+		}
+		return false
+	})
+	if len(r.participants) == 0 {
+		delete(c.rooms, sessionID)
+		return removed
+	}
+	r.promote()
+	return removed
+}
+
+// rebind moves a connection to sessionID when the facade re-keyed its
+// session (a client that omits session_id on its first message is given a
+// new one). Returns the previous session ID when a move happened.
+func (c *collaboration) rebind(connID, sessionID string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	prev, ok := c.byConn[connID]
+	if !ok || prev == sessionID {
+		return "", false
+	}
+	moved := c.detachLocked(prev, connID)
+	if moved == nil {
+		return "", false
+	}
+	target := c.roomLocked(sessionID)
+	moved.info.Role = facade.ParticipantRoleObserver
+	target.participants = append(target.participants, moved)
+	target.promote()
+	c.byConn[connID] = sessionID
+	return prev, true
+}
+
+// role returns a connection's role in a session. ok is false when the
+// connection is not a tracked participant (e.g. HTTP callers, tests).
+func (c *collaboration) role(sessionID, connID string) (facade.ParticipantRole, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if p := c.participantLocked(sessionID, connID); p != nil {
+		return p.info.Role, true
+	}
+	return "", false
+}
+
+// participantLocked returns connID's participant in sessionID, or nil.
+// Callers hold c.mu.
+func (c *collaboration) participantLocked(sessionID, connID string) *participant {
+	r, ok := c.rooms[sessionID]
+	if !ok {
+		return nil
+	}
+	idx := slices.IndexFunc(r.participants, func(p *participant) bool { return p.info.ID == connID })
+	if idx < 0 {
+		return nil
+	}
+	return r.participants[idx]
+}
+
+// handoff makes target the session's driver on behalf of the participant
+// from; the current driver observes. A handoff by the owner invites the
+// target's user to drive; anyone else can only hand the role to the owner or
+// a user the owner invited.
+func (c *collaboration) handoff(sessionID, from, target string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := c.participantLocked(sessionID, target)
+	if t == nil {
+		return false
+	}
+	r := c.rooms[sessionID]
+	if sender := c.participantLocked(sessionID, from); sender != nil && sender.owner {
+		if t.info.UserID != "" {
+			r.invited[t.info.UserID] = true
+		}
+	} else if !r.mayDrive(t) {
+		return false
+	}
+	if driver := driverOf(r.participants); driver != nil {
+		driver.info.Role = facade.ParticipantRoleObserver
+	}
+	t.info.Role = facade.ParticipantRoleDriver
+	return true
+}
+
+// others returns the writers of every participant except connID.
+func (c *collaboration) others(sessionID, connID string) []facade.ResponseWriter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var writers []facade.ResponseWriter
+	for _, p := range c.participantsLocked(sessionID) {
+		if p.info.ID != connID {
+			writers = append(writers, p.writer)
+		}
+	}
+	return writers
+}
+
+// snapshot returns a copy of a session's participants in join order.
+func (c *collaboration) snapshot(sessionID string) []participant {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	room := c.participantsLocked(sessionID)
+	out := make([]participant, len(room))
+	for i, p := range room {
+		out[i] = *p
+	}
+	return out
+}
+
+// participantsLocked returns sessionID's participants in join order.
+// Callers hold c.mu.
+func (c *collaboration) participantsLocked(sessionID string) []*participant {
+	if r, ok := c.rooms[sessionID]; ok {
+		return r.participants
+	}
+	return nil
+}
+
+func driverOf(room []*participant) *participant {
+	for _, p := range room {
+		if p.info.Role == facade.ParticipantRoleDriver {
+			return p
+		}
+	}
+	return nil
+}
+
+// ConnectionOpened attaches a WebSocket client to its session and tells
+// every participant who is present.
+func (h *PromptKitHandler) ConnectionOpened(_ context.Context, conn facade.ConnectionInfo, writer facade.ResponseWriter) {
+	role := h.collab.join(conn, writer)
+	h.log.V(1).Info("participant joined", "sessionID", conn.SessionID, "connectionID", conn.ID,
+		"role", role, "joined", conn.Joined)
+	h.broadcastPresence(conn.SessionID)
+}
+
+// ConnectionClosed detaches a WebSocket client and updates presence for
+// whoever remains.
+func (h *PromptKitHandler) ConnectionClosed(conn facade.ConnectionInfo) int {
+	sessionID, remaining := h.collab.leave(conn.ID)
+	if remaining > 0 {
+		h.broadcastPresence(sessionID)
+	}
+	return remaining
+}
+
+// broadcastPresence sends the participant list to everyone in a session.
+func (h *PromptKitHandler) broadcastPresence(sessionID string) {
+	room := h.collab.snapshot(sessionID)
+	list := make([]facade.ParticipantInfo, len(room))
+	for i, p := range room {
+		list[i] = p.info
+	}
+	for _, p := range room {
+		pw, ok := p.writer.(facade.PresenceWriter)
+		if !ok {
+			continue
+		}
+		if err := pw.WritePresence(&facade.PresenceInfo{SelfID: p.info.ID, Participants: list}); err != nil {
+			h.log.V(1).Info("presence write failed", "sessionID", sessionID, "connectionID", p.info.ID,
+				"error", err.Error())
+		}
+	}
+}
+
+// admitParticipant rebinds the sender to sessionID if needed and rejects
+// observers. Returns false when the message must not be handled.
+func (h *PromptKitHandler) admitParticipant(ctx context.Context, sessionID string, writer facade.ResponseWriter) (bool, error) {
+	connID := facade.ConnectionIDFromContext(ctx)
+	if connID == "" {
+		return true, nil
+	}
+	if prev, moved := h.collab.rebind(connID, sessionID); moved {
+		h.broadcastPresence(prev)
+		h.broadcastPresence(sessionID)
+	}
+	role, ok := h.collab.role(sessionID, connID)
+	if ok && role == facade.ParticipantRoleObserver {
+		return false, writer.WriteError(errCodeObserverReadOnly, "observers cannot send messages or commands")
+	}
+	return true, nil
+}
+
+// handleHandoff passes the driver role to another participant.
+func (h *PromptKitHandler) handleHandoff(ctx context.Context, sessionID, target string, writer facade.ResponseWriter) error {
+	if !h.collab.handoff(sessionID, facade.ConnectionIDFromContext(ctx), target) {
+		return writer.WriteError(errCodeInvalidHandoff,
+			"no participant "+target+" in this session that the owner invited to drive")
+	}
+	h.broadcastPresence(sessionID)
+	return writer.WriteDone("Driver handed off")
+}
+
+// fanoutWriter forwards everything written for the driver to the other
+// participants of the session. Errors go to the sender only: they are
+// usually about the sender's request, and the facade records each written
+// error against the session, which must happen once.
+type fanoutWriter struct {
+	facade.ResponseWriter
+	others []facade.ResponseWriter
+}
+
+// withFanout wraps writer so the session's other participants see the turn.
+func (h *PromptKitHandler) withFanout(ctx context.Context, sessionID string, writer facade.ResponseWriter) facade.ResponseWriter {
+	others := h.collab.others(sessionID, facade.ConnectionIDFromContext(ctx))
+	if len(others) == 0 {
+		return writer
+	}
+	return &fanoutWriter{ResponseWriter: writer, others: others}
+}
+
+// each writes to the sender, then best-effort to every other participant.
+func (w *fanoutWriter) each(write func(facade.ResponseWriter) error) error {
+	err := write(w.ResponseWriter)
+	for _, o := range w.others {
+		_ = write(o)
+	}
+	return err
+}
+
+func (w *fanoutWriter) WriteChunk(content string) error {
+	return w.each(func(rw facade.ResponseWriter) error { return rw.WriteChunk(content) })
+}
+
+func (w *fanoutWriter) WriteUserTranscript(content string) error {
+	return w.each(func(rw facade.ResponseWriter) error { return rw.WriteUserTranscript(content) })
+}
+
+func (w *fanoutWriter) WriteChunkWithParts(parts []facade.ContentPart) error {
+	return w.each(func(rw facade.ResponseWriter) error { return rw.WriteChunkWithParts(parts) })
+}
+
+func (w *fanoutWriter) WriteDone(content string) error {
+	return w.each(func(rw facade.ResponseWriter) error { return rw.WriteDone(content) })
+}
+
+func (w *fanoutWriter) WriteDoneWithParts(parts []facade.ContentPart) error {
+	return w.each(func(rw facade.ResponseWriter) error { return rw.WriteDoneWithParts(parts) })
+}
+
+func (w *fanoutWriter) WriteToolCall(toolCall *facade.ToolCallInfo) error {
+	return w.each(func(rw facade.ResponseWriter) error { return rw.WriteToolCall(toolCall) })
+}
+
+func (w *fanoutWriter) WriteToolResult(result *facade.ToolResultInfo) error {
+	return w.each(func(rw facade.ResponseWriter) error { return rw.WriteToolResult(result) })
+}
+
+func (w *fanoutWriter) WriteMediaChunk(mediaChunk *facade.MediaChunkInfo) error {
+	return w.each(func(rw facade.ResponseWriter) error { return rw.WriteMediaChunk(mediaChunk) })
+}
+
+// echoUserMessage shows the driver's message to the other participants.
+func (h *PromptKitHandler) echoUserMessage(ctx context.Context, sessionID, content string) {
+	if content == "" {
+		return
+	}
+	for _, o := range h.collab.others(sessionID, facade.ConnectionIDFromContext(ctx)) {
+		_ = o.WriteUserTranscript(content)
+	}
+}
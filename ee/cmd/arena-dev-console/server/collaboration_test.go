@@ -0,0 +1,223 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/facade"
+)
+
+// presenceRecorder is a MockResponseWriter that also receives presence.
+type presenceRecorder struct {
+	MockResponseWriter
+	Presence []*facade.PresenceInfo
+}
+
+func (p *presenceRecorder) WritePresence(presence *facade.PresenceInfo) error {
+	p.Presence = append(p.Presence, presence)
+	return nil
+}
+
+func (p *presenceRecorder) lastPresence() *facade.PresenceInfo {
+	if len(p.Presence) == 0 {
+		return nil
+	}
+	return p.Presence[len(p.Presence)-1]
+}
+
+// openParticipant attaches a connection of the session's owner.
+func openParticipant(h *PromptKitHandler, connID, sessionID string, role facade.ParticipantRole) *presenceRecorder {
+	w := &presenceRecorder{}
+	h.ConnectionOpened(context.Background(), facade.ConnectionInfo{
+		ID: connID, SessionID: sessionID, RequestedRole: role, Owner: true,
+	}, w)
+	return w
+}
+
+// joinGuest attaches a connection of another user who joined the session.
+func joinGuest(h *PromptKitHandler, connID, sessionID, userID string, role facade.ParticipantRole) *presenceRecorder {
+	w := &presenceRecorder{}
+	h.ConnectionOpened(context.Background(), facade.ConnectionInfo{
+		ID: connID, SessionID: sessionID, UserID: userID, RequestedRole: role, Joined: true,
+	}, w)
+	return w
+}
+
+func rolesOf(p *facade.PresenceInfo) map[string]facade.ParticipantRole {
+	roles := make(map[string]facade.ParticipantRole, len(p.Participants))
+	for _, part := range p.Participants {
+		roles[part.ID] = part.Role
+	}
+	return roles
+}
+
+func TestCollaborationFirstParticipantDrives(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	a := openParticipant(h, "a", "sess", "")
+	b := openParticipant(h, "b", "sess", facade.ParticipantRoleDriver)
+
+	// b asked to drive but a already does.
+	assert.Equal(t, map[string]facade.ParticipantRole{
+		"a": facade.ParticipantRoleDriver,
+		"b": facade.ParticipantRoleObserver,
+	}, rolesOf(b.lastPresence()))
+	assert.Equal(t, "b", b.lastPresence().SelfID)
+	assert.Equal(t, "a", a.lastPresence().SelfID)
+	assert.Len(t, a.Presence, 2, "a sees its own join and b's")
+}
+
+func TestCollaborationDriverLeavingPromotesNext(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	openParticipant(h, "a", "sess", "")
+	b := openParticipant(h, "b", "sess", "")
+	openParticipant(h, "c", "sess", "")
+
+	remaining := h.ConnectionClosed(facade.ConnectionInfo{ID: "a", SessionID: "sess"})
+	assert.Equal(t, 2, remaining)
+	assert.Equal(t, map[string]facade.ParticipantRole{
+		"b": facade.ParticipantRoleDriver,
+		"c": facade.ParticipantRoleObserver,
+	}, rolesOf(b.lastPresence()))
+
+	assert.Equal(t, 1, h.ConnectionClosed(facade.ConnectionInfo{ID: "b", SessionID: "sess"}))
+	assert.Equal(t, 0, h.ConnectionClosed(facade.ConnectionInfo{ID: "c", SessionID: "sess"}))
+	assert.Equal(t, 0, h.ConnectionClosed(facade.ConnectionInfo{ID: "unknown"}))
+}
+
+func TestCollaborationGuestNeverDrivesUninvited(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	guest := joinGuest(h, "g", "sess", "bob", facade.ParticipantRoleDriver)
+	assert.Equal(t, map[string]facade.ParticipantRole{
+		"g": facade.ParticipantRoleObserver,
+	}, rolesOf(guest.lastPresence()), "?role=driver needs the owner's invitation")
+
+	openParticipant(h, "a", "sess", "")
+	h.ConnectionClosed(facade.ConnectionInfo{ID: "a", SessionID: "sess"})
+	assert.Equal(t, map[string]facade.ParticipantRole{
+		"g": facade.ParticipantRoleObserver,
+	}, rolesOf(guest.lastPresence()), "the owner leaving does not promote a guest")
+}
+
+func TestCollaborationObserverCannotSend(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	openParticipant(h, "a", "sess", "")
+	openParticipant(h, "b", "sess", "")
+
+	writer := &MockResponseWriter{}
+	ok, err := h.admitParticipant(facade.WithConnectionID(context.Background(), "b"), "sess", writer)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, errCodeObserverReadOnly, writer.ErrorCode)
+
+	ok, err = h.admitParticipant(facade.WithConnectionID(context.Background(), "a"), "sess", &MockResponseWriter{})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Callers outside a WebSocket connection are not participants.
+	ok, err = h.admitParticipant(context.Background(), "sess", &MockResponseWriter{})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestCollaborationObserverCannotResumeToolCall(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	openParticipant(h, "a", "sess", "")
+	openParticipant(h, "b", "sess", "")
+	h.debugger.pause("sess", "call-1")
+
+	cmd := &facade.ToolDebugInfo{CallID: "call-1", Action: facade.ToolDebugActionApprove}
+	assert.False(t, h.SendToolDebug(facade.WithConnectionID(context.Background(), "b"), "sess", cmd))
+	assert.True(t, h.SendToolDebug(facade.WithConnectionID(context.Background(), "a"), "sess", cmd))
+}
+
+func TestCollaborationHandoff(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	openParticipant(h, "a", "sess", "")
+	b := joinGuest(h, "b", "sess", "bob", "")
+	joinGuest(h, "c", "sess", "carol", "")
+	fromOwner := facade.WithConnectionID(context.Background(), "a")
+	fromBob := facade.WithConnectionID(context.Background(), "b")
+
+	writer := &MockResponseWriter{}
+	require.NoError(t, h.handleHandoff(fromOwner, "sess", "b", writer))
+	assert.Equal(t, "Driver handed off", writer.DoneContent)
+	assert.Equal(t, map[string]facade.ParticipantRole{
+		"a": facade.ParticipantRoleObserver,
+		"b": facade.ParticipantRoleDriver,
+		"c": facade.ParticipantRoleObserver,
+	}, rolesOf(b.lastPresence()))
+
+	writer = &MockResponseWriter{}
+	require.NoError(t, h.handleHandoff(fromBob, "sess", "c", writer))
+	assert.Equal(t, errCodeInvalidHandoff, writer.ErrorCode, "only the owner invites new drivers")
+
+	writer = &MockResponseWriter{}
+	require.NoError(t, h.handleHandoff(fromOwner, "sess", "nobody", writer))
+	assert.Equal(t, errCodeInvalidHandoff, writer.ErrorCode)
+
+	// The invitation outlives the handoff: bob drives again after the owner leaves.
+	writer = &MockResponseWriter{}
+	require.NoError(t, h.handleHandoff(fromBob, "sess", "a", writer))
+	assert.Equal(t, "Driver handed off", writer.DoneContent)
+	h.ConnectionClosed(facade.ConnectionInfo{ID: "a", SessionID: "sess"})
+	assert.Equal(t, map[string]facade.ParticipantRole{
+		"b": facade.ParticipantRoleDriver,
+		"c": facade.ParticipantRoleObserver,
+	}, rolesOf(b.lastPresence()))
+}
+
+func TestCollaborationRebindFollowsSessionChange(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	a := openParticipant(h, "a", "announced", "")
+
+	ok, err := h.admitParticipant(facade.WithConnectionID(context.Background(), "a"), "persisted", &MockResponseWriter{})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	role, found := h.collab.role("persisted", "a")
+	assert.True(t, found)
+	assert.Equal(t, facade.ParticipantRoleDriver, role)
+	_, found = h.collab.role("announced", "a")
+	assert.False(t, found)
+	assert.Len(t, a.lastPresence().Participants, 1)
+}
+
+func TestFanoutWriterMirrorsTurnToOthers(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	driver := openParticipant(h, "a", "sess", "")
+	observer := openParticipant(h, "b", "sess", "")
+	ctx := facade.WithConnectionID(context.Background(), "a")
+
+	writer := h.withFanout(ctx, "sess", &driver.MockResponseWriter)
+	h.echoUserMessage(ctx, "sess", "hello")
+	require.NoError(t, writer.WriteChunk("hi"))
+	require.NoError(t, writer.WriteToolCall(&facade.ToolCallInfo{ID: "call-1"}))
+	require.NoError(t, writer.WriteDone("hi there"))
+	require.NoError(t, writer.WriteError("EXECUTION_ERROR", "boom"))
+
+	assert.Equal(t, []string{"hi"}, driver.Chunks)
+	assert.Equal(t, []string{"hello", "hi"}, observer.Chunks, "user echo then assistant chunk")
+	assert.Len(t, observer.ToolCalls, 1)
+	assert.Equal(t, "hi there", observer.DoneContent)
+	assert.Equal(t, "EXECUTION_ERROR", driver.ErrorCode)
+	assert.Empty(t, observer.ErrorCode, "errors go to the sender only")
+}
+
+func TestWithFanoutAloneIsPassthrough(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	solo := openParticipant(h, "a", "sess", "")
+	writer := h.withFanout(facade.WithConnectionID(context.Background(), "a"), "sess", solo)
+	assert.Same(t, solo, writer)
+}
@@ -158,9 +158,15 @@ func (l *K8sProviderLoader) convertProvider(p *corev1alpha1.Provider) *config.Pr
 		Role:    string(p.EffectiveRole()),
 	}
 
-	// HuggingFace inference providers carry dedicated-endpoint config.
-	if p.Spec.Type == corev1alpha1.ProviderTypeHuggingFace {
+	// HuggingFace inference providers carry dedicated-endpoint config;
+	// openai-compatible providers carry their request-shape flags.
+	switch p.Spec.Type {
+	case corev1alpha1.ProviderTypeHuggingFace:
 		provider.AdditionalConfig = omniaprovider.HuggingFaceAdditionalConfig(p.Spec.BaseURL)
+	case corev1alpha1.ProviderTypeOpenAICompatible:
+		oc := omniaprovider.OpenAICompatible(&p.Spec)
+		provider.UnsupportedParams = oc.UnsupportedParams
+		provider.Capabilities = oc.Capabilities
 	}
 
 	// Set defaults if specified
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+
+This file implements Kubernetes ToolRegistry loading, so interactive sessions
+can call the same tool backends an agent in the namespace would.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	pktools "github.com/AltairaLabs/PromptKit/runtime/tools"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/runtime/tools"
+	"github.com/altairalabs/omnia/internal/tooltest"
+)
+
+// K8sToolEntries is the set of handlers loaded from the namespace's ToolRegistries.
+type K8sToolEntries struct {
+	// Entries are the runtime handler entries, with secret-backed auth applied.
+	Entries []tools.HandlerEntry
+	// Version fingerprints the registries the entries came from; it changes
+	// whenever a registry is added, removed or edited.
+	Version string
+	// Skipped maps handlers that cannot run in the dev console to the reason.
+	Skipped map[string]string
+}
+
+// LoadToolEntries loads the handlers of every usable ToolRegistry in this dev
+// console's namespace. Registries that are not Ready or Degraded are ignored.
+func (l *K8sProviderLoader) LoadToolEntries(ctx context.Context) (*K8sToolEntries, error) {
+	list := &corev1alpha1.ToolRegistryList{}
+	if err := l.client.List(ctx, list, client.InNamespace(l.namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list tool registries in namespace %s: %w", l.namespace, err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+
+	out := &K8sToolEntries{Skipped: make(map[string]string)}
+	tester := tooltest.NewTester(l.client, l.log)
+	seen := make(map[string]string)
+	var version strings.Builder
+	for i := range list.Items {
+		reg := &list.Items[i]
+		if reg.Status.Phase != corev1alpha1.ToolRegistryPhaseReady &&
+			reg.Status.Phase != corev1alpha1.ToolRegistryPhaseDegraded {
+			l.log.V(1).Info("skipping tool registry not ready", "name", reg.Name, "phase", reg.Status.Phase)
+			continue
+		}
+		fmt.Fprintf(&version, "%s@%s;", reg.Name, reg.ResourceVersion)
+
+		entries, skipped := tester.HandlerEntries(ctx, reg)
+		for handler, reason := range skipped {
+			out.Skipped[handler] = reason
+		}
+		for _, e := range entries {
+			if reason := unsupportedHandler(e); reason != "" {
+				out.Skipped[e.Name] = reason
+				continue
+			}
+			if owner, dup := seen[e.Name]; dup {
+				out.Skipped[e.Name] = fmt.Sprintf("handler name already loaded from ToolRegistry %s", owner)
+				continue
+			}
+			seen[e.Name] = reg.Name
+			out.Entries = append(out.Entries, e)
+		}
+	}
+	out.Version = version.String()
+
+	l.log.Info("loaded tool registries", "namespace", l.namespace,
+		"handlers", len(out.Entries), "skipped", len(out.Skipped))
+	return out, nil
+}
+
+// unsupportedHandler returns why a handler cannot be called from the dev
+// console, or "" when it can. Client tools need the agent's end-user client,
+// and a stdio MCP server's binary is only present in the agent's own image.
+func unsupportedHandler(e tools.HandlerEntry) string {
+	switch {
+	case e.Type == string(corev1alpha1.HandlerTypeClient):
+		return "client tools run in the end user's client"
+	case e.MCPConfig != nil && e.MCPConfig.Transport == string(corev1alpha1.MCPTransportStdio):
+		return "stdio MCP servers are not started by the dev console"
+	}
+	return ""
+}
+
+// k8sToolSet is an initialized executor for the namespace's ToolRegistry
+// handlers, plus the tool descriptors it discovered.
+type k8sToolSet struct {
+	version     string
+	executor    *tools.OmniaExecutor
+	descriptors []*pktools.ToolDescriptor
+}
+
+// merge returns a registry holding base's tools plus the K8s tools. Tools
+// declared in both are dispatched to the K8s backend, as an agent's pack tools
+// are. base itself is never modified, and is returned as is when there are no
+// K8s tools.
+func (s *k8sToolSet) merge(base *pktools.Registry) *pktools.Registry {
+	if s == nil || len(s.descriptors) == 0 {
+		return base
+	}
+	var merged *pktools.Registry
+	if base != nil {
+		merged = base.Fork()
+	} else {
+		merged = pktools.NewRegistry()
+	}
+	merged.RegisterExecutor(s.executor)
+	for _, d := range s.descriptors {
+		desc := d
+		if existing := merged.Get(d.Name); existing != nil {
+			// Keep the config's description and schemas, but route the call
+			// to the live backend. Copy so base's descriptor is untouched.
+			routed := *existing
+			routed.Mode = s.executor.Name()
+			desc = &routed
+		}
+		_ = merged.Register(desc)
+	}
+	return merged
+}
+
+// refreshK8sTools reloads the namespace's ToolRegistry handlers when they
+// changed since the last load, or always when force is set. On failure the
+// previously loaded tools stay in use.
+func (h *PromptKitHandler) refreshK8sTools(ctx context.Context, force bool) {
+	if h.k8sLoader == nil {
+		return
+	}
+	loaded, err := h.k8sLoader.LoadToolEntries(ctx)
+	if err != nil {
+		h.log.Error(err, "failed to load tool registries; keeping previous tools")
+		return
+	}
+
+	h.mu.RLock()
+	current := h.k8sTools
+	h.mu.RUnlock()
+	if !force && current != nil && current.version == loaded.Version {
+		return
+	}
+	for handler, reason := range loaded.Skipped {
+		h.log.Info("tool handler not available in the dev console", "handler", handler, "reason", reason)
+	}
+
+	next := &k8sToolSet{version: loaded.Version}
+	if len(loaded.Entries) > 0 {
+		executor := tools.NewOmniaExecutor(h.log, nil)
+		if err := executor.LoadConfigFromEntries(loaded.Entries); err != nil {
+			h.log.Error(err, "failed to load tool handlers; keeping previous tools")
+			return
+		}
+		// MCP sessions outlive the message that triggered the load.
+		if err := executor.Initialize(context.WithoutCancel(ctx)); err != nil {
+			_ = executor.Close()
+			h.log.Error(err, "failed to initialize tool handlers; keeping previous tools")
+			return
+		}
+		next.executor = executor
+		next.descriptors = executor.ToolDescriptors()
+	}
+
+	h.mu.Lock()
+	previous := h.k8sTools
+	h.k8sTools = next
+	h.mu.Unlock()
+	// A tool loop still holding the previous set may see its calls fail once
+	// it closes; that only happens while a registry is being edited.
+	_ = h.closeK8sTools(previous)
+
+	h.log.Info("tool registries loaded", "tools", len(next.descriptors))
+}
+
+// closeK8sTools shuts down a tool set's executor. Safe on a nil set.
+func (h *PromptKitHandler) closeK8sTools(s *k8sToolSet) error {
+	if s == nil || s.executor == nil {
+		return nil
+	}
+	err := s.executor.Close()
+	if err != nil {
+		h.log.Error(err, "failed to close tool executor")
+	}
+	return err
+}
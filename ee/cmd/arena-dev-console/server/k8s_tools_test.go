@@ -0,0 +1,156 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pktools "github.com/AltairaLabs/PromptKit/runtime/tools"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	corev1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/runtime/tools"
+)
+
+// echoToolBackend is an HTTP tool backend that echoes the arguments it received.
+func echoToolBackend(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"echo": string(body)})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func httpToolHandler(name, tool, endpoint string) corev1alpha1.HandlerDefinition {
+	return corev1alpha1.HandlerDefinition{
+		Name:       name,
+		Type:       corev1alpha1.HandlerTypeHTTP,
+		HTTPConfig: &corev1alpha1.HTTPConfig{Endpoint: endpoint, Method: http.MethodPost},
+		Tool: &corev1alpha1.ToolDefinition{
+			Name:        tool,
+			Description: "tool " + tool,
+			InputSchema: apiextensionsv1.JSON{Raw: []byte(`{"type":"object"}`)},
+		},
+	}
+}
+
+func testToolRegistry(
+	name string, phase corev1alpha1.ToolRegistryPhase, handlers ...corev1alpha1.HandlerDefinition,
+) *corev1alpha1.ToolRegistry {
+	return &corev1alpha1.ToolRegistry{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace", ResourceVersion: "1"},
+		Spec:       corev1alpha1.ToolRegistrySpec{Handlers: handlers},
+		Status:     corev1alpha1.ToolRegistryStatus{Phase: phase},
+	}
+}
+
+func TestLoadToolEntries(t *testing.T) {
+	loader := newTestK8sProviderLoader(t, "test-namespace",
+		testToolRegistry("a-tools", corev1alpha1.ToolRegistryPhaseReady,
+			httpToolHandler("orders", "lookup_order", "http://orders.invalid"),
+			corev1alpha1.HandlerDefinition{Name: "browser", Type: corev1alpha1.HandlerTypeClient},
+			corev1alpha1.HandlerDefinition{
+				Name:      "local-mcp",
+				Type:      corev1alpha1.HandlerTypeMCP,
+				MCPConfig: &corev1alpha1.MCPClientConfig{Transport: corev1alpha1.MCPTransportStdio, Command: ptr.To("srv")},
+			},
+		),
+		testToolRegistry("b-tools", corev1alpha1.ToolRegistryPhaseDegraded,
+			httpToolHandler("orders", "lookup_order_v2", "http://orders.invalid"),
+			httpToolHandler("billing", "charge", "http://billing.invalid"),
+		),
+		testToolRegistry("c-tools", corev1alpha1.ToolRegistryPhasePending,
+			httpToolHandler("pending", "pending_tool", "http://pending.invalid"),
+		),
+	)
+
+	loaded, err := loader.LoadToolEntries(context.Background())
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(loaded.Entries))
+	for _, e := range loaded.Entries {
+		names = append(names, e.Name)
+	}
+	assert.Equal(t, []string{"orders", "billing"}, names)
+	assert.Contains(t, loaded.Skipped, "browser")
+	assert.Contains(t, loaded.Skipped, "local-mcp")
+	assert.Contains(t, loaded.Skipped["orders"], "a-tools")
+	assert.Equal(t, "a-tools@1;b-tools@1;", loaded.Version)
+}
+
+func TestK8sToolSetMerge(t *testing.T) {
+	var empty *k8sToolSet
+	base := pktools.NewRegistry()
+	assert.Same(t, base, empty.merge(base))
+
+	require.NoError(t, base.Register(&pktools.ToolDescriptor{
+		Name: "lookup_order", Description: "from config", Mode: "mock",
+		InputSchema: json.RawMessage(`{"type":"object"}`),
+	}))
+	set := &k8sToolSet{
+		executor: tools.NewOmniaExecutor(logr.Discard(), nil),
+		descriptors: []*pktools.ToolDescriptor{
+			{Name: "lookup_order", Description: "from registry", Mode: "omnia"},
+			{Name: "charge", Description: "from registry", Mode: "omnia"},
+		},
+	}
+
+	merged := set.merge(base)
+	require.NotSame(t, base, merged)
+	assert.ElementsMatch(t, []string{"lookup_order", "charge"}, merged.List())
+	routed := merged.Get("lookup_order")
+	assert.Equal(t, "omnia", routed.Mode)
+	assert.Equal(t, "from config", routed.Description)
+	assert.Equal(t, "mock", base.Get("lookup_order").Mode, "base registry must not change")
+
+	assert.ElementsMatch(t, []string{"lookup_order", "charge"}, set.merge(nil).List())
+}
+
+func TestRefreshK8sTools(t *testing.T) {
+	backend := echoToolBackend(t)
+	reg := testToolRegistry("tools", corev1alpha1.ToolRegistryPhaseReady,
+		httpToolHandler("orders", "lookup_order", backend.URL))
+	loader := newTestK8sProviderLoader(t, "test-namespace", reg)
+	h := &PromptKitHandler{log: logr.Discard(), k8sLoader: loader}
+	t.Cleanup(func() { _ = h.Close() })
+
+	h.refreshK8sTools(context.Background(), false)
+	toolReg := h.currentToolRegistry()
+	require.True(t, hasTools(toolReg))
+	res, err := toolReg.Execute(context.Background(), "lookup_order", json.RawMessage(`{"id":"42"}`))
+	require.NoError(t, err)
+	assert.Contains(t, string(res.Result), `{\"id\":\"42\"}`)
+
+	// An unchanged registry keeps the loaded set; force rebuilds it.
+	first := h.k8sTools
+	h.refreshK8sTools(context.Background(), false)
+	assert.Same(t, first, h.k8sTools)
+	h.refreshK8sTools(context.Background(), true)
+	assert.NotSame(t, first, h.k8sTools)
+}
+
+func TestRefreshK8sToolsWithoutLoader(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	h.refreshK8sTools(context.Background(), true)
+	assert.Nil(t, h.k8sTools)
+	assert.Nil(t, h.currentToolRegistry())
+}
@@ -19,11 +19,13 @@ import (
 	"time"
 
 	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/PromptKit/runtime/tools"
 	"github.com/AltairaLabs/PromptKit/runtime/types"
 	"github.com/AltairaLabs/promptarena/arena/arenaconfig"
 	"github.com/AltairaLabs/promptarena/arena/engine"
 
 	"github.com/altairalabs/omnia/internal/facade"
+	"github.com/altairalabs/omnia/internal/session"
 	"github.com/altairalabs/omnia/pkg/logctx"
 
 	"github.com/go-logr/logr"
@@ -40,6 +42,7 @@ type PromptKitHandler struct {
 	mu               sync.RWMutex
 	config           *arenaconfig.Config
 	providerRegistry *providers.Registry
+	toolRegistry     *tools.Registry
 	log              logr.Logger
 	reloadBasePath   string
 
@@ -50,12 +53,26 @@ type PromptKitHandler struct {
 	k8sLoader *K8sProviderLoader
 	// Cache of provider registries per namespace
 	nsRegistries map[string]*providers.Registry
+	// Tools loaded from the namespace's ToolRegistries (nil until loaded)
+	k8sTools *k8sToolSet
+
+	// Session recording (optional) and the config revision stamped on
+	// recorded messages so replays show which config produced each turn.
+	recorder SessionRecorder
+	revision configRevision
+
+	// Tool calls paused in debug mode, awaiting a tool_debug command.
+	debugger toolDebugger
+
+	// Participants of each session (driver / observers).
+	collab collaboration
 }
 
 // SessionState holds conversation state for a session.
 type SessionState struct {
 	Messages   []types.Message
 	ProviderID string // Selected provider for this session
+	Debug      bool   // Pause on each tool call until the client decides
 	mu         sync.Mutex
 }
 
@@ -106,23 +123,30 @@ func (h *PromptKitHandler) handleMetadataCommand(
 	ctx context.Context,
 	sessionID string,
 	msg *facade.ClientMessage,
-	session *SessionState,
+	state *SessionState,
 	writer facade.ResponseWriter,
 ) (bool, error) {
 	if msg.Metadata == nil {
 		return false, nil
 	}
 	if _, isReload := msg.Metadata["reload"]; isReload {
-		return true, h.handleReload(ctx, msg, writer)
+		return true, h.handleReload(ctx, sessionID, msg, writer)
 	}
 	if _, isReset := msg.Metadata["reset"]; isReset {
 		h.ResetSession(sessionID)
+		h.recordEvent(ctx, sessionID, EventTypeSessionReset, nil)
 		return true, writer.WriteDone("Session reset")
 	}
+	if mode, isDebug := msg.Metadata["debug"]; isDebug {
+		return true, h.setDebugMode(state, mode, writer)
+	}
+	if target, isHandoff := msg.Metadata["driver"]; isHandoff {
+		return true, h.handleHandoff(ctx, sessionID, target, writer)
+	}
 	if providerID, ok := msg.Metadata["provider"]; ok {
-		session.mu.Lock()
-		session.ProviderID = providerID
-		session.mu.Unlock()
+		state.mu.Lock()
+		state.ProviderID = providerID
+		state.mu.Unlock()
 	}
 	return false, nil
 }
@@ -166,10 +190,24 @@ func (h *PromptKitHandler) HandleMessage(
 		return writer.WriteError("ENGINE_NOT_READY", "PromptKit engine is not initialized. No providers available.")
 	}
 
-	session := h.getOrCreateSession(sessionID)
+	// Only the driver of a shared session may talk to the model; everything
+	// written from here on is mirrored to the other participants.
+	if ok, err := h.admitParticipant(ctx, sessionID, writer); !ok {
+		return err
+	}
+	writer = h.withFanout(ctx, sessionID, writer)
+
+	state := h.getOrCreateSession(sessionID)
+	state.mu.Lock()
+	starting := len(state.Messages) == 0
+	state.mu.Unlock()
+	if starting {
+		// Pick up ToolRegistry edits made since the last session started.
+		h.refreshK8sTools(ctx, false)
+	}
 
 	// Handle special commands in metadata
-	handled, err := h.handleMetadataCommand(ctx, sessionID, msg, session, writer)
+	handled, err := h.handleMetadataCommand(ctx, sessionID, msg, state, writer)
 	if handled || err != nil {
 		return err
 	}
@@ -181,25 +219,39 @@ func (h *PromptKitHandler) HandleMessage(
 	}
 
 	// Get session state
-	session.mu.Lock()
-	session.Messages = append(session.Messages, userMsg)
-	messages := make([]types.Message, len(session.Messages))
-	copy(messages, session.Messages)
-	providerID := session.ProviderID
-	session.mu.Unlock()
+	state.mu.Lock()
+	state.Messages = append(state.Messages, userMsg)
+	messages := make([]types.Message, len(state.Messages))
+	copy(messages, state.Messages)
+	providerID := state.ProviderID
+	state.mu.Unlock()
 
 	// Select and validate provider
 	provider, providerID, err := h.selectProvider(providerID, cfg, registry)
 	if err != nil {
 		return writer.WriteError("PROVIDER_ERROR", err.Error())
 	}
+	h.recordMessage(ctx, sessionID, session.RoleUser, msg.Content, providerID)
+	h.echoUserMessage(ctx, sessionID, msg.Content)
+	writer = h.withRecording(ctx, sessionID, writer)
 
 	// Build prediction request with provider defaults
 	req := h.buildPredictionRequest(messages, providerID, cfg)
 	predictionStart := time.Now()
 
-	// Execute with streaming
-	response, _, err := h.executeStreamingWithCost(ctx, provider, req, writer)
+	state.mu.Lock()
+	debug := state.Debug
+	state.mu.Unlock()
+
+	// Execute with streaming, or run the tool loop when the provider can
+	// call tools and the config registers any.
+	var response string
+	toolReg := h.currentToolRegistry()
+	if toolProvider, ok := provider.(providers.ToolSupport); ok && hasTools(toolReg) {
+		response, err = h.runToolLoop(ctx, sessionID, state, toolProvider, toolReg, req, debug, writer)
+	} else {
+		response, _, err = h.executeStreamingWithCost(ctx, provider, req, writer)
+	}
 	if err != nil {
 		h.log.Error(err, "prediction failed", "sessionID", sessionID)
 		return writer.WriteError("EXECUTION_ERROR", err.Error())
@@ -207,9 +259,10 @@ func (h *PromptKitHandler) HandleMessage(
 
 	_ = predictionStart // reserved for future metrics
 
-	session.mu.Lock()
-	session.Messages = append(session.Messages, types.NewAssistantMessage(response))
-	session.mu.Unlock()
+	state.mu.Lock()
+	state.Messages = append(state.Messages, types.NewAssistantMessage(response))
+	state.mu.Unlock()
+	h.recordMessage(ctx, sessionID, session.RoleAssistant, response, providerID)
 	return nil
 }
 
@@ -379,9 +432,11 @@ func (h *PromptKitHandler) convertToPKMessage(role string, parts []facade.Conten
 	return msg
 }
 
-// handleReload reloads the engine configuration.
+// handleReload reloads the engine configuration and records the new config
+// revision against the session that requested it.
 func (h *PromptKitHandler) handleReload(
-	_ context.Context,
+	ctx context.Context,
+	sessionID string,
 	msg *facade.ClientMessage,
 	writer facade.ResponseWriter,
 ) error {
@@ -405,7 +460,9 @@ func (h *PromptKitHandler) handleReload(
 		if err := h.buildComponents(); err != nil {
 			return writer.WriteError("RELOAD_ERROR", fmt.Sprintf("failed to rebuild components: %v", err))
 		}
+		h.refreshK8sTools(ctx, true)
 
+		h.recordReload(ctx, sessionID, h.bumpRevision("inline", []byte(content)))
 		h.log.Info("configuration reloaded successfully")
 		return writer.WriteDone("Configuration reloaded successfully")
 	}
@@ -414,20 +471,33 @@ func (h *PromptKitHandler) handleReload(
 		return writer.WriteError("RELOAD_ERROR", fmt.Sprintf("failed to reload from path: %v", err))
 	}
 
+	h.recordReload(ctx, sessionID, h.currentRevision())
 	h.log.Info("configuration reloaded successfully", "path", content)
 	return writer.WriteDone("Configuration reloaded successfully")
-
-	// Parse new configuration from message content
 }
 
 // Reload updates the configuration and rebuilds components.
 // This is called externally (e.g., from file watcher).
 func (h *PromptKitHandler) Reload(cfg *arenaconfig.Config) error {
+	if err := h.reload(cfg); err != nil {
+		return err
+	}
+	h.bumpRevision("external", nil)
+	return nil
+}
+
+func (h *PromptKitHandler) reload(cfg *arenaconfig.Config) error {
 	h.mu.Lock()
 	h.config = cfg
 	h.mu.Unlock()
 
-	return h.buildComponents()
+	if err := h.buildComponents(); err != nil {
+		return err
+	}
+	// A reload also re-discovers tools from the namespace's ToolRegistries,
+	// whose MCP servers may have changed without the registry changing.
+	h.refreshK8sTools(context.Background(), true)
+	return nil
 }
 
 // ReloadFromPath loads configuration from a file path and reloads.
@@ -440,7 +510,14 @@ func (h *PromptKitHandler) ReloadFromPath(configPath string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
-	return h.Reload(cfg)
+	if err := h.reload(cfg); err != nil {
+		return err
+	}
+	// The digest covers the top-level arena file only; it is a fingerprint
+	// for comparing shared traces, not a full bundle hash.
+	content, _ := os.ReadFile(safePath)
+	h.bumpRevision(safePath, content)
+	return nil
 }
 
 func (h *PromptKitHandler) resolveReloadPath(configPath string) (string, error) {
@@ -592,7 +669,7 @@ func (h *PromptKitHandler) getOrLoadK8sRegistry(ctx context.Context) (*providers
 		"outputDir", cfg.Defaults.Output.Dir,
 		"outDir", cfg.Defaults.OutDir,
 		"configDir", cfg.Defaults.ConfigDir)
-	registry, _, _, _, _, a2aCleanup, _, _, err := engine.BuildEngineComponents(cfg, nil)
+	registry, _, _, _, _, a2aCleanup, toolRegistry, _, err := engine.BuildEngineComponents(cfg, nil)
 	if err != nil {
 		h.log.Error(err, "getOrLoadK8sRegistry: BuildEngineComponents failed",
 			"outputDir", cfg.Defaults.Output.Dir,
@@ -606,6 +683,7 @@ func (h *PromptKitHandler) getOrLoadK8sRegistry(ctx context.Context) (*providers
 	// Cache the registry
 	h.mu.Lock()
 	h.nsRegistries[namespace] = registry
+	h.toolRegistry = toolRegistry
 	h.mu.Unlock()
 
 	h.log.Info("loaded providers from K8s", "namespace", namespace, "count", totalProviders)
@@ -677,7 +755,7 @@ func (h *PromptKitHandler) buildComponents() error {
 	h.log.Info("buildComponents: calling BuildEngineComponents",
 		"outputDir", cfg.Defaults.Output.Dir,
 		"outDir", cfg.Defaults.OutDir)
-	providerRegistry, _, _, _, _, a2aCleanup, _, _, err := engine.BuildEngineComponents(cfg, nil)
+	providerRegistry, _, _, _, _, a2aCleanup, toolRegistry, _, err := engine.BuildEngineComponents(cfg, nil)
 	if err != nil {
 		h.log.Error(err, "buildComponents: BuildEngineComponents failed",
 			"outputDir", cfg.Defaults.Output.Dir,
@@ -689,6 +767,7 @@ func (h *PromptKitHandler) buildComponents() error {
 	}
 
 	h.providerRegistry = providerRegistry
+	h.toolRegistry = toolRegistry
 	h.log.Info("components built successfully")
 	return nil
 }
@@ -792,6 +871,11 @@ func (h *PromptKitHandler) Close() error {
 	}
 	h.nsRegistries = make(map[string]*providers.Registry)
 
+	if err := h.closeK8sTools(h.k8sTools); err != nil {
+		lastErr = err
+	}
+	h.k8sTools = nil
+
 	// Close main registry
 	if h.providerRegistry != nil {
 		if err := h.providerRegistry.Close(); err != nil {
@@ -801,5 +885,9 @@ func (h *PromptKitHandler) Close() error {
 	return lastErr
 }
 
-// Interface assertion
-var _ facade.MessageHandler = (*PromptKitHandler)(nil)
+// Interface assertions
+var (
+	_ facade.MessageHandler     = (*PromptKitHandler)(nil)
+	_ facade.ToolDebugRouter    = (*PromptKitHandler)(nil)
+	_ facade.ConnectionObserver = (*PromptKitHandler)(nil)
+)
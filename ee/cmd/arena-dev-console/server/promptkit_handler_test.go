@@ -765,7 +765,7 @@ func TestHandleReloadInvalidJSON(t *testing.T) {
 		},
 	}
 
-	err := handler.handleReload(context.Background(), msg, writer)
+	err := handler.handleReload(context.Background(), "test-session", msg, writer)
 	assert.NoError(t, err) // Error written to writer, not returned
 	assert.Equal(t, "INVALID_CONFIG", writer.ErrorCode)
 	assert.Contains(t, writer.ErrorMessage, "failed to parse config")
@@ -1149,7 +1149,7 @@ func TestHandleReloadValidConfig(t *testing.T) {
 		},
 	}
 
-	err := handler.handleReload(context.Background(), msg, writer)
+	err := handler.handleReload(context.Background(), "test-session", msg, writer)
 	assert.NoError(t, err)
 
 	// Check if config was successfully reloaded
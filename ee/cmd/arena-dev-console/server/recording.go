@@ -0,0 +1,225 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/altairalabs/omnia/internal/facade"
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// Runtime event types written by the dev console. They sit alongside the
+// PromptKit pipeline events in session-api and are what the replay timeline
+// uses to attribute each message to the config version that produced it.
+const (
+	EventTypeConfigReloaded = "devconsole.config.reloaded"
+	EventTypeSessionReset   = "devconsole.session.reset"
+)
+
+// Metadata keys stamped on every message the dev console records.
+const (
+	MetadataConfigVersion = "configVersion"
+	MetadataConfigDigest  = "configDigest"
+	MetadataProviderID    = "providerId"
+)
+
+// recordTimeout bounds each session-api write so a slow archive never stalls
+// the conversation. The httpclient store buffers failed writes for retry.
+const recordTimeout = 5 * time.Second
+
+// SessionRecorder is the subset of the session-api client the dev console
+// needs to record a replayable timeline.
+type SessionRecorder interface {
+	AppendMessage(ctx context.Context, sessionID string, msg session.Message) error
+	RecordToolCall(ctx context.Context, sessionID string, tc session.ToolCall) error
+	RecordRuntimeEvent(ctx context.Context, sessionID string, evt session.RuntimeEvent) error
+}
+
+// configRevision identifies the configuration a message was produced under.
+// Version increments on every successful reload; Digest is a content hash of
+// the reloaded source so two shared traces can be compared for equality.
+type configRevision struct {
+	Version int
+	Digest  string
+	Source  string
+}
+
+// SetRecorder enables session recording. A nil recorder disables it.
+func (h *PromptKitHandler) SetRecorder(rec SessionRecorder) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.recorder = rec
+}
+
+// recordingState returns the recorder and the active config revision.
+func (h *PromptKitHandler) recordingState() (SessionRecorder, configRevision) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.recorder, h.revision
+}
+
+// currentRevision returns the active config revision.
+func (h *PromptKitHandler) currentRevision() configRevision {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.revision
+}
+
+// bumpRevision records that a new configuration has been applied.
+func (h *PromptKitHandler) bumpRevision(source string, content []byte) configRevision {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.revision = configRevision{
+		Version: h.revision.Version + 1,
+		Digest:  contentDigest(content),
+		Source:  source,
+	}
+	return h.revision
+}
+
+// contentDigest returns a short sha256 hex digest, or "" for empty content.
+func contentDigest(content []byte) string {
+	if len(content) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:8])
+}
+
+// revisionMetadata returns the message metadata for a config revision.
+func revisionMetadata(rev configRevision, providerID string) map[string]string {
+	md := map[string]string{MetadataConfigVersion: strconv.Itoa(rev.Version)}
+	if rev.Digest != "" {
+		md[MetadataConfigDigest] = rev.Digest
+	}
+	if providerID != "" {
+		md[MetadataProviderID] = providerID
+	}
+	return md
+}
+
+// recordMessage appends a conversation message to the session archive.
+func (h *PromptKitHandler) recordMessage(
+	ctx context.Context, sessionID string, role session.MessageRole, content, providerID string,
+) {
+	rec, rev := h.recordingState()
+	if rec == nil || sessionID == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancel()
+	msg := session.Message{
+		ID:        uuid.New().String(),
+		Role:      role,
+		Content:   content,
+		Timestamp: time.Now(),
+		Metadata:  revisionMetadata(rev, providerID),
+	}
+	if err := rec.AppendMessage(ctx, sessionID, msg); err != nil {
+		h.log.Error(err, "failed to record message", "sessionID", sessionID, "role", role)
+	}
+}
+
+// recordEvent writes a dev console lifecycle event to the session archive.
+func (h *PromptKitHandler) recordEvent(ctx context.Context, sessionID, eventType string, data map[string]any) {
+	rec, _ := h.recordingState()
+	if rec == nil || sessionID == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancel()
+	evt := session.RuntimeEvent{
+		ID:        uuid.New().String(),
+		SessionID: sessionID,
+		EventType: eventType,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+	if err := rec.RecordRuntimeEvent(ctx, sessionID, evt); err != nil {
+		h.log.Error(err, "failed to record event", "sessionID", sessionID, "eventType", eventType)
+	}
+}
+
+// recordReload writes a config-reloaded event carrying the new revision.
+func (h *PromptKitHandler) recordReload(ctx context.Context, sessionID string, rev configRevision) {
+	h.recordEvent(ctx, sessionID, EventTypeConfigReloaded, map[string]any{
+		MetadataConfigVersion: rev.Version,
+		MetadataConfigDigest:  rev.Digest,
+		"source":              rev.Source,
+	})
+}
+
+// recordToolCall writes one tool-call lifecycle row to the session archive.
+func (h *PromptKitHandler) recordToolCall(ctx context.Context, sessionID string, tc session.ToolCall) {
+	rec, rev := h.recordingState()
+	if rec == nil || sessionID == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancel()
+	tc.ID = uuid.New().String()
+	tc.SessionID = sessionID
+	tc.CreatedAt = time.Now()
+	tc.Labels = revisionMetadata(rev, "")
+	if err := rec.RecordToolCall(ctx, sessionID, tc); err != nil {
+		h.log.Error(err, "failed to record tool call", "sessionID", sessionID, "callID", tc.CallID)
+	}
+}
+
+// recordingWriter wraps a ResponseWriter so tool calls and results streamed
+// to the client are also written to the session archive.
+type recordingWriter struct {
+	facade.ResponseWriter
+	ctx       context.Context
+	handler   *PromptKitHandler
+	sessionID string
+}
+
+// withRecording wraps writer when recording is enabled.
+func (h *PromptKitHandler) withRecording(
+	ctx context.Context, sessionID string, writer facade.ResponseWriter,
+) facade.ResponseWriter {
+	if rec, _ := h.recordingState(); rec == nil {
+		return writer
+	}
+	return &recordingWriter{ResponseWriter: writer, ctx: ctx, handler: h, sessionID: sessionID}
+}
+
+// WriteToolCall records the call as pending before forwarding it.
+func (w *recordingWriter) WriteToolCall(toolCall *facade.ToolCallInfo) error {
+	w.handler.recordToolCall(w.ctx, w.sessionID, session.ToolCall{
+		CallID:    toolCall.ID,
+		Name:      toolCall.Name,
+		Arguments: toolCall.Arguments,
+		Status:    session.ToolCallStatusPending,
+	})
+	return w.ResponseWriter.WriteToolCall(toolCall)
+}
+
+// WriteToolResult records the resolved call before forwarding the result.
+func (w *recordingWriter) WriteToolResult(result *facade.ToolResultInfo) error {
+	tc := session.ToolCall{
+		CallID: result.ID,
+		Result: result.Result,
+		Status: session.ToolCallStatusSuccess,
+	}
+	if result.Error != "" {
+		tc.Status = session.ToolCallStatusError
+		tc.ErrorMessage = result.Error
+	}
+	w.handler.recordToolCall(w.ctx, w.sessionID, tc)
+	return w.ResponseWriter.WriteToolResult(result)
+}
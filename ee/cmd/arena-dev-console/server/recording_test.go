@@ -0,0 +1,167 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/AltairaLabs/PromptKit/pkg/config"
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/promptarena/arena/arenaconfig"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/facade"
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// fakeRecorder captures everything the handler records, in memory.
+type fakeRecorder struct {
+	mu        sync.Mutex
+	messages  []session.Message
+	toolCalls []session.ToolCall
+	events    []session.RuntimeEvent
+}
+
+func (f *fakeRecorder) AppendMessage(_ context.Context, _ string, msg session.Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.messages = append(f.messages, msg)
+	return nil
+}
+
+func (f *fakeRecorder) RecordToolCall(_ context.Context, _ string, tc session.ToolCall) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.toolCalls = append(f.toolCalls, tc)
+	return nil
+}
+
+func (f *fakeRecorder) RecordRuntimeEvent(_ context.Context, _ string, evt session.RuntimeEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, evt)
+	return nil
+}
+
+func newRecordingTestHandler(t *testing.T) (*PromptKitHandler, *fakeRecorder) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "output")
+	cfg := &arenaconfig.Config{
+		Defaults: arenaconfig.Defaults{
+			Output:    arenaconfig.OutputConfig{Dir: outputDir},
+			OutDir:    outputDir,
+			ConfigDir: tmpDir,
+		},
+		LoadedProviders: map[string]*config.Provider{
+			"mock": {ID: "mock", Type: "mock", Model: "mock-model"},
+		},
+	}
+	handler := &PromptKitHandler{
+		config:         cfg,
+		log:            logr.Discard(),
+		sessions:       make(map[string]*SessionState),
+		nsRegistries:   make(map[string]*providers.Registry),
+		reloadBasePath: tmpDir,
+	}
+	require.NoError(t, handler.buildComponents())
+	t.Cleanup(func() { _ = handler.Close() })
+
+	rec := &fakeRecorder{}
+	handler.SetRecorder(rec)
+	return handler, rec
+}
+
+func TestHandleMessageRecordsConversation(t *testing.T) {
+	handler, rec := newRecordingTestHandler(t)
+
+	writer := &MockResponseWriter{}
+	err := handler.HandleMessage(context.Background(), "s1", &facade.ClientMessage{Content: "hello"}, writer)
+	require.NoError(t, err)
+
+	require.Len(t, rec.messages, 2)
+	assert.Equal(t, session.RoleUser, rec.messages[0].Role)
+	assert.Equal(t, "hello", rec.messages[0].Content)
+	assert.Equal(t, session.RoleAssistant, rec.messages[1].Role)
+	assert.Equal(t, writer.DoneContent, rec.messages[1].Content)
+	assert.Equal(t, "0", rec.messages[1].Metadata[MetadataConfigVersion])
+	assert.Equal(t, "mock", rec.messages[1].Metadata[MetadataProviderID])
+}
+
+func TestHandleReloadRecordsConfigRevision(t *testing.T) {
+	handler, rec := newRecordingTestHandler(t)
+
+	content := `{"defaults":{}}`
+	writer := &MockResponseWriter{}
+	msg := &facade.ClientMessage{Content: content, Metadata: map[string]string{"reload": "true"}}
+	require.NoError(t, handler.handleReload(context.Background(), "s1", msg, writer))
+	require.Empty(t, writer.ErrorCode)
+
+	require.Len(t, rec.events, 1)
+	assert.Equal(t, EventTypeConfigReloaded, rec.events[0].EventType)
+	assert.Equal(t, 1, rec.events[0].Data[MetadataConfigVersion])
+	assert.Equal(t, "inline", rec.events[0].Data["source"])
+	assert.Equal(t, contentDigest([]byte(content)), rec.events[0].Data[MetadataConfigDigest])
+}
+
+func TestReloadFromPathBumpsRevision(t *testing.T) {
+	handler, _ := newRecordingTestHandler(t)
+
+	path := filepath.Join(handler.reloadBasePath, "config.arena.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("not: [valid"), 0o600))
+	require.Error(t, handler.ReloadFromPath(path))
+	assert.Equal(t, 0, handler.currentRevision().Version, "failed reload must not bump the revision")
+
+	require.NoError(t, handler.Reload(handler.config))
+	assert.Equal(t, 1, handler.currentRevision().Version)
+	assert.Equal(t, "external", handler.currentRevision().Source)
+}
+
+func TestResetRecordsEvent(t *testing.T) {
+	handler, rec := newRecordingTestHandler(t)
+
+	writer := &MockResponseWriter{}
+	msg := &facade.ClientMessage{Metadata: map[string]string{"reset": "true"}}
+	require.NoError(t, handler.HandleMessage(context.Background(), "s1", msg, writer))
+
+	require.Len(t, rec.events, 1)
+	assert.Equal(t, EventTypeSessionReset, rec.events[0].EventType)
+}
+
+func TestRecordingWriterRecordsToolLifecycle(t *testing.T) {
+	handler, rec := newRecordingTestHandler(t)
+
+	inner := &MockResponseWriter{}
+	writer := handler.withRecording(context.Background(), "s1", inner)
+	require.NoError(t, writer.WriteToolCall(&facade.ToolCallInfo{
+		ID: "call-1", Name: "lookup", Arguments: map[string]any{"q": "x"},
+	}))
+	require.NoError(t, writer.WriteToolResult(&facade.ToolResultInfo{ID: "call-1", Error: "boom"}))
+
+	require.Len(t, inner.ToolCalls, 1)
+	require.Len(t, inner.ToolResults, 1)
+	require.Len(t, rec.toolCalls, 2)
+	assert.Equal(t, session.ToolCallStatusPending, rec.toolCalls[0].Status)
+	assert.Equal(t, "lookup", rec.toolCalls[0].Name)
+	assert.Equal(t, session.ToolCallStatusError, rec.toolCalls[1].Status)
+	assert.Equal(t, "boom", rec.toolCalls[1].ErrorMessage)
+	assert.Equal(t, "call-1", rec.toolCalls[1].CallID)
+}
+
+func TestWithRecordingDisabledReturnsWriter(t *testing.T) {
+	handler := &PromptKitHandler{log: logr.Discard()}
+	inner := &MockResponseWriter{}
+	assert.Same(t, facade.ResponseWriter(inner), handler.withRecording(context.Background(), "s1", inner))
+}
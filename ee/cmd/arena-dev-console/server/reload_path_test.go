@@ -56,7 +56,7 @@ func TestHandleReload_PathModeReturnsReloadError(t *testing.T) {
 		},
 	}
 
-	err := h.handleReload(context.Background(), msg, writer)
+	err := h.handleReload(context.Background(), "test-session", msg, writer)
 	if err != nil {
 		t.Fatalf("handleReload returned unexpected error: %v", err)
 	}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// TimelineEntry kinds.
+const (
+	TimelineKindMessage  = "message"
+	TimelineKindToolCall = "tool_call"
+	TimelineKindEvent    = "event"
+)
+
+// TimelineSource reads back what the dev console recorded for a session.
+// The session-api httpclient store satisfies it.
+type TimelineSource interface {
+	GetMessages(ctx context.Context, sessionID string) ([]session.Message, error)
+	GetToolCalls(ctx context.Context, sessionID string, limit, offset int) ([]session.ToolCall, error)
+	GetRuntimeEvents(ctx context.Context, sessionID string, limit, offset int) ([]session.RuntimeEvent, error)
+}
+
+// TimelineEntry is one step of a replayed dev console session.
+type TimelineEntry struct {
+	// Kind is one of message, tool_call or event.
+	Kind string `json:"kind"`
+	// Timestamp orders entries within the timeline.
+	Timestamp time.Time `json:"timestamp"`
+	// ConfigVersion is the config revision that was active at this step.
+	ConfigVersion int `json:"configVersion"`
+	// Message is set for message entries.
+	Message *session.Message `json:"message,omitempty"`
+	// ToolCall is set for tool_call entries. It holds the latest lifecycle
+	// state of the call, including its result once resolved.
+	ToolCall *session.ToolCall `json:"toolCall,omitempty"`
+	// Event is set for event entries (config reloads, resets, pipeline events).
+	Event *session.RuntimeEvent `json:"event,omitempty"`
+}
+
+// Timeline is a replayable, time-ordered view of a recorded session.
+type Timeline struct {
+	SessionID string          `json:"sessionId"`
+	Entries   []TimelineEntry `json:"entries"`
+}
+
+// BuildTimeline reads a session's messages, tool calls and runtime events
+// and merges them into a single time-ordered timeline. Tool call lifecycle
+// rows are collapsed per call ID so each call appears once, at the time it
+// was issued, with its final status and result.
+func BuildTimeline(ctx context.Context, src TimelineSource, sessionID string) (*Timeline, error) {
+	msgs, err := src.GetMessages(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("get messages: %w", err)
+	}
+	calls, err := src.GetToolCalls(ctx, sessionID, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("get tool calls: %w", err)
+	}
+	events, err := src.GetRuntimeEvents(ctx, sessionID, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("get runtime events: %w", err)
+	}
+
+	entries := make([]TimelineEntry, 0, len(msgs)+len(calls)+len(events))
+	for i := range msgs {
+		entries = append(entries, TimelineEntry{
+			Kind:          TimelineKindMessage,
+			Timestamp:     msgs[i].Timestamp,
+			ConfigVersion: atoiOrZero(msgs[i].Metadata[MetadataConfigVersion]),
+			Message:       &msgs[i],
+		})
+	}
+	for _, tc := range collapseToolCalls(calls) {
+		entries = append(entries, TimelineEntry{
+			Kind:          TimelineKindToolCall,
+			Timestamp:     tc.CreatedAt,
+			ConfigVersion: atoiOrZero(tc.Labels[MetadataConfigVersion]),
+			ToolCall:      tc,
+		})
+	}
+	for i := range events {
+		entries = append(entries, TimelineEntry{
+			Kind:          TimelineKindEvent,
+			Timestamp:     events[i].Timestamp,
+			ConfigVersion: eventConfigVersion(&events[i]),
+			Event:         &events[i],
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+	fillConfigVersions(entries)
+
+	return &Timeline{SessionID: sessionID, Entries: entries}, nil
+}
+
+// collapseToolCalls merges the pending/resolved rows recorded for each call
+// into one record: identity and arguments from the first row, status and
+// result from the last. Order of first appearance is preserved.
+func collapseToolCalls(rows []session.ToolCall) []*session.ToolCall {
+	byID := make(map[string]*session.ToolCall, len(rows))
+	var order []*session.ToolCall
+	for i := range rows {
+		row := rows[i]
+		existing, ok := byID[row.CallID]
+		if !ok || row.CallID == "" {
+			merged := row
+			order = append(order, &merged)
+			if row.CallID != "" {
+				byID[row.CallID] = &merged
+			}
+			continue
+		}
+		if row.CreatedAt.Before(existing.CreatedAt) {
+			existing.CreatedAt = row.CreatedAt
+		}
+		if existing.Name == "" {
+			existing.Name = row.Name
+		}
+		if existing.Arguments == nil {
+			existing.Arguments = row.Arguments
+		}
+		existing.Status = row.Status
+		existing.Result = row.Result
+		existing.ErrorMessage = row.ErrorMessage
+		if row.DurationMs > 0 {
+			existing.DurationMs = row.DurationMs
+		}
+	}
+	return order
+}
+
+// eventConfigVersion returns the config version carried by a reload event.
+func eventConfigVersion(evt *session.RuntimeEvent) int {
+	if evt.EventType != EventTypeConfigReloaded {
+		return 0
+	}
+	switch v := evt.Data[MetadataConfigVersion].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case string:
+		return atoiOrZero(v)
+	}
+	return 0
+}
+
+// fillConfigVersions carries the most recent config version forward onto
+// entries that did not record one (e.g. client tool results written by the
+// facade, or PromptKit pipeline events).
+func fillConfigVersions(entries []TimelineEntry) {
+	current := 0
+	for i := range entries {
+		if entries[i].ConfigVersion > 0 {
+			current = entries[i].ConfigVersion
+			continue
+		}
+		entries[i].ConfigVersion = current
+	}
+}
+
+func atoiOrZero(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+type fakeTimelineSource struct {
+	messages  []session.Message
+	toolCalls []session.ToolCall
+	events    []session.RuntimeEvent
+	err       error
+}
+
+func (f *fakeTimelineSource) GetMessages(context.Context, string) ([]session.Message, error) {
+	return f.messages, f.err
+}
+
+func (f *fakeTimelineSource) GetToolCalls(context.Context, string, int, int) ([]session.ToolCall, error) {
+	return f.toolCalls, nil
+}
+
+func (f *fakeTimelineSource) GetRuntimeEvents(context.Context, string, int, int) ([]session.RuntimeEvent, error) {
+	return f.events, nil
+}
+
+func TestBuildTimelineMergesAndOrders(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(s int) time.Time { return t0.Add(time.Duration(s) * time.Second) }
+
+	src := &fakeTimelineSource{
+		messages: []session.Message{
+			{ID: "u1", Role: session.RoleUser, Timestamp: at(0), Metadata: map[string]string{MetadataConfigVersion: "0"}},
+			{ID: "tr", Role: session.RoleSystem, ToolCallID: "call-1", Timestamp: at(3)},
+			{ID: "a1", Role: session.RoleAssistant, Timestamp: at(4), Metadata: map[string]string{MetadataConfigVersion: "0"}},
+			{ID: "u2", Role: session.RoleUser, Timestamp: at(10), Metadata: map[string]string{MetadataConfigVersion: "1"}},
+		},
+		toolCalls: []session.ToolCall{
+			{CallID: "call-1", Name: "lookup", Status: session.ToolCallStatusPending, CreatedAt: at(1)},
+			{CallID: "call-1", Status: session.ToolCallStatusSuccess, Result: "42", CreatedAt: at(2)},
+		},
+		events: []session.RuntimeEvent{
+			{EventType: EventTypeConfigReloaded, Timestamp: at(5), Data: map[string]any{MetadataConfigVersion: float64(1)}},
+			{EventType: "pipeline.started", Timestamp: at(11)},
+		},
+	}
+
+	tl, err := BuildTimeline(context.Background(), src, "s1")
+	require.NoError(t, err)
+	require.Len(t, tl.Entries, 7)
+
+	kinds := make([]string, 0, len(tl.Entries))
+	for _, e := range tl.Entries {
+		kinds = append(kinds, e.Kind)
+	}
+	assert.Equal(t, []string{
+		TimelineKindMessage, TimelineKindToolCall, TimelineKindMessage, TimelineKindMessage,
+		TimelineKindEvent, TimelineKindMessage, TimelineKindEvent,
+	}, kinds)
+
+	call := tl.Entries[1].ToolCall
+	assert.Equal(t, "lookup", call.Name)
+	assert.Equal(t, session.ToolCallStatusSuccess, call.Status)
+	assert.Equal(t, "42", call.Result)
+	assert.Equal(t, at(1), call.CreatedAt)
+
+	assert.Equal(t, 0, tl.Entries[3].ConfigVersion)
+	assert.Equal(t, 1, tl.Entries[4].ConfigVersion)
+	assert.Equal(t, 1, tl.Entries[6].ConfigVersion, "pipeline events inherit the active version")
+}
+
+func TestBuildTimelinePropagatesErrors(t *testing.T) {
+	src := &fakeTimelineSource{err: session.ErrSessionNotFound}
+	_, err := BuildTimeline(context.Background(), src, "missing")
+	assert.True(t, errors.Is(err, session.ErrSessionNotFound))
+}
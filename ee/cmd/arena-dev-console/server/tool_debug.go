@@ -0,0 +1,166 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/tools"
+	"github.com/AltairaLabs/PromptKit/runtime/types"
+
+	"github.com/altairalabs/omnia/internal/facade"
+)
+
+// debugPauseTimeout bounds how long a paused tool call waits for the
+// developer. A call that times out is returned to the model as an error so
+// the conversation does not hang forever on an abandoned tab.
+var debugPauseTimeout = 15 * time.Minute
+
+// skippedToolResult is the error returned to the model for skipped calls.
+const skippedToolResult = "tool call skipped by developer"
+
+// toolDebugger tracks tool calls paused in debug mode. The zero value is
+// ready to use.
+type toolDebugger struct {
+	mu     sync.Mutex
+	paused map[string]chan *facade.ToolDebugInfo
+}
+
+func pausedKey(sessionID, callID string) string {
+	return sessionID + "/" + callID
+}
+
+// pause registers a paused call and returns the channel its command will
+// arrive on. Registration happens before the tool_call is sent so a fast
+// client cannot race the handler.
+func (d *toolDebugger) pause(sessionID, callID string) chan *facade.ToolDebugInfo {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.paused == nil {
+		d.paused = make(map[string]chan *facade.ToolDebugInfo)
+	}
+	ch := make(chan *facade.ToolDebugInfo, 1)
+	d.paused[pausedKey(sessionID, callID)] = ch
+	return ch
+}
+
+// release forgets a paused call.
+func (d *toolDebugger) release(sessionID, callID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.paused, pausedKey(sessionID, callID))
+}
+
+// deliver hands a command to the paused call. Returns false when no call
+// with that ID is paused for the session.
+func (d *toolDebugger) deliver(sessionID string, cmd *facade.ToolDebugInfo) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	key := pausedKey(sessionID, cmd.CallID)
+	ch, ok := d.paused[key]
+	if !ok {
+		return false
+	}
+	delete(d.paused, key)
+	ch <- cmd
+	return true
+}
+
+// SendToolDebug delivers a tool_debug command to the call paused on it.
+// Observers of a shared session cannot resume calls.
+func (h *PromptKitHandler) SendToolDebug(ctx context.Context, sessionID string, cmd *facade.ToolDebugInfo) bool {
+	if cmd == nil {
+		return false
+	}
+	if role, ok := h.collab.role(sessionID, facade.ConnectionIDFromContext(ctx)); ok && role == facade.ParticipantRoleObserver {
+		return false
+	}
+	return h.debugger.deliver(sessionID, cmd)
+}
+
+// setDebugMode turns step-through debugging on or off for a session.
+func (h *PromptKitHandler) setDebugMode(state *SessionState, mode string, writer facade.ResponseWriter) error {
+	enabled, err := strconv.ParseBool(mode)
+	if err != nil {
+		return writer.WriteError("INVALID_DEBUG_MODE", fmt.Sprintf("debug must be true or false, got %q", mode))
+	}
+	state.mu.Lock()
+	state.Debug = enabled
+	state.mu.Unlock()
+	if enabled {
+		return writer.WriteDone("Debug mode enabled")
+	}
+	return writer.WriteDone("Debug mode disabled")
+}
+
+// awaitToolDebug blocks until the developer decides on a paused call.
+func awaitToolDebug(ctx context.Context, ch <-chan *facade.ToolDebugInfo) (*facade.ToolDebugInfo, error) {
+	timer := time.NewTimer(debugPauseTimeout)
+	defer timer.Stop()
+	select {
+	case cmd := <-ch:
+		return cmd, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+		return nil, fmt.Errorf("no debug decision within %s", debugPauseTimeout)
+	}
+}
+
+// applyToolDecision executes (or short-circuits) a tool call according to
+// the developer's decision. A nil decision means debug mode is off and the
+// call runs as the model requested.
+func (h *PromptKitHandler) applyToolDecision(
+	ctx context.Context, toolReg *tools.Registry, call types.MessageToolCall, cmd *facade.ToolDebugInfo,
+) types.MessageToolResult {
+	if cmd == nil {
+		return h.executeTool(ctx, toolReg, call.ID, call.Name, call.Args)
+	}
+
+	switch cmd.Action {
+	case facade.ToolDebugActionApprove:
+		return h.executeTool(ctx, toolReg, call.ID, call.Name, call.Args)
+	case facade.ToolDebugActionSkip:
+		return types.MessageToolResult{ID: call.ID, Name: call.Name, Error: skippedToolResult}
+	case facade.ToolDebugActionEdit:
+		if cmd.Result != nil {
+			return types.NewTextToolResult(call.ID, call.Name, resultText(cmd.Result))
+		}
+		args := call.Args
+		if cmd.Arguments != nil {
+			edited, err := json.Marshal(cmd.Arguments)
+			if err != nil {
+				return types.MessageToolResult{ID: call.ID, Name: call.Name, Error: fmt.Sprintf("invalid edited arguments: %v", err)}
+			}
+			args = edited
+		}
+		return h.executeTool(ctx, toolReg, call.ID, call.Name, args)
+	default:
+		return types.MessageToolResult{
+			ID: call.ID, Name: call.Name, Error: fmt.Sprintf("unknown debug action %q", cmd.Action),
+		}
+	}
+}
+
+// resultText renders a developer-supplied result as the text the model sees.
+func resultText(result any) string {
+	if s, ok := result.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Sprintf("%v", result)
+	}
+	return string(data)
+}
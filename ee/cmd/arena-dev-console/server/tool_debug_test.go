@@ -0,0 +1,257 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/PromptKit/runtime/tools"
+	"github.com/AltairaLabs/PromptKit/runtime/types"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/facade"
+)
+
+const testToolName = "lookup"
+
+// scriptedToolProvider returns one scripted round per PredictWithTools call
+// and remembers the requests it saw.
+type scriptedToolProvider struct {
+	providers.Provider
+	mu       sync.Mutex
+	rounds   [][]types.MessageToolCall
+	requests []providers.PredictionRequest
+}
+
+func (p *scriptedToolProvider) BuildTooling(_ []*providers.ToolDescriptor) (providers.ProviderTools, error) {
+	return nil, nil
+}
+
+func (p *scriptedToolProvider) PredictWithTools(
+	_ context.Context, req providers.PredictionRequest, _ providers.ProviderTools, _ string,
+) (providers.PredictionResponse, []types.MessageToolCall, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = append(p.requests, req)
+	round := len(p.requests) - 1
+	if round < len(p.rounds) {
+		return providers.PredictionResponse{}, p.rounds[round], nil
+	}
+	return providers.PredictionResponse{Content: "final answer"}, nil, nil
+}
+
+func (p *scriptedToolProvider) PredictStreamWithTools(
+	context.Context, providers.PredictionRequest, providers.ProviderTools, string,
+) (<-chan providers.StreamChunk, error) {
+	return nil, nil
+}
+
+func (p *scriptedToolProvider) lastRequest() providers.PredictionRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.requests[len(p.requests)-1]
+}
+
+func newToolTestRegistry(t *testing.T) *tools.Registry {
+	t.Helper()
+	reg := tools.NewRegistry()
+	require.NoError(t, reg.Register(&tools.ToolDescriptor{
+		Name:         testToolName,
+		Description:  "Looks things up",
+		InputSchema:  json.RawMessage(`{"type":"object"}`),
+		OutputSchema: json.RawMessage(`{"type":"object"}`),
+		Mode:         "mock",
+		MockResult:   json.RawMessage(`{"value":42}`),
+	}))
+	return reg
+}
+
+func oneToolCallRound(callID string) [][]types.MessageToolCall {
+	return [][]types.MessageToolCall{{
+		{ID: callID, Name: testToolName, Args: json.RawMessage(`{"q":"original"}`)},
+	}}
+}
+
+// deliverWhenPaused retries until the handler has paused on callID.
+func deliverWhenPaused(t *testing.T, h *PromptKitHandler, sessionID string, cmd *facade.ToolDebugInfo) {
+	t.Helper()
+	go func() {
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if h.SendToolDebug(context.Background(), sessionID, cmd) {
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+}
+
+func runTestToolLoop(
+	t *testing.T, h *PromptKitHandler, provider *scriptedToolProvider, debug bool,
+) (*MockResponseWriter, *SessionState, string) {
+	t.Helper()
+	state := &SessionState{}
+	writer := &MockResponseWriter{}
+	resp, err := h.runToolLoop(context.Background(), "sess", state, provider, newToolTestRegistry(t),
+		providers.PredictionRequest{Messages: []types.Message{{Role: "user", Content: "hi"}}}, debug, writer)
+	require.NoError(t, err)
+	return writer, state, resp
+}
+
+func TestRunToolLoopExecutesToolsWithoutDebug(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	provider := &scriptedToolProvider{rounds: oneToolCallRound("call-1")}
+
+	writer, state, resp := runTestToolLoop(t, h, provider, false)
+
+	assert.Equal(t, "final answer", resp)
+	assert.Equal(t, "final answer", writer.DoneContent)
+	require.Len(t, writer.ToolCalls, 1)
+	assert.False(t, writer.ToolCalls[0].AwaitingDebug)
+	assert.Equal(t, "original", writer.ToolCalls[0].Arguments["q"])
+	require.Len(t, writer.ToolResults, 1)
+	assert.Empty(t, writer.ToolResults[0].Error)
+	assert.Equal(t, map[string]any{"value": float64(42)}, writer.ToolResults[0].Result)
+
+	// The assistant tool-call turn and the tool result are fed back.
+	last := provider.lastRequest()
+	require.Len(t, last.Messages, 3)
+	assert.Len(t, last.Messages[1].ToolCalls, 1)
+	require.NotNil(t, last.Messages[2].ToolResult)
+	assert.Equal(t, "call-1", last.Messages[2].ToolResult.ID)
+	assert.Len(t, state.Messages, 2)
+}
+
+func TestRunToolLoopDebugApprove(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	provider := &scriptedToolProvider{rounds: oneToolCallRound("call-1")}
+	deliverWhenPaused(t, h, "sess", &facade.ToolDebugInfo{CallID: "call-1", Action: facade.ToolDebugActionApprove})
+
+	writer, _, resp := runTestToolLoop(t, h, provider, true)
+
+	assert.Equal(t, "final answer", resp)
+	require.Len(t, writer.ToolCalls, 1)
+	assert.True(t, writer.ToolCalls[0].AwaitingDebug)
+	require.Len(t, writer.ToolResults, 1)
+	assert.Empty(t, writer.ToolResults[0].Error)
+}
+
+func TestRunToolLoopDebugSkip(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	provider := &scriptedToolProvider{rounds: oneToolCallRound("call-1")}
+	deliverWhenPaused(t, h, "sess", &facade.ToolDebugInfo{CallID: "call-1", Action: facade.ToolDebugActionSkip})
+
+	writer, _, _ := runTestToolLoop(t, h, provider, true)
+
+	require.Len(t, writer.ToolResults, 1)
+	assert.Equal(t, skippedToolResult, writer.ToolResults[0].Error)
+	result := provider.lastRequest().Messages[2].ToolResult
+	require.NotNil(t, result)
+	assert.Equal(t, skippedToolResult, result.Error)
+}
+
+func TestRunToolLoopDebugEditResult(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	provider := &scriptedToolProvider{rounds: oneToolCallRound("call-1")}
+	deliverWhenPaused(t, h, "sess", &facade.ToolDebugInfo{
+		CallID: "call-1",
+		Action: facade.ToolDebugActionEdit,
+		Result: map[string]any{"value": "patched"},
+	})
+
+	writer, _, _ := runTestToolLoop(t, h, provider, true)
+
+	require.Len(t, writer.ToolResults, 1)
+	assert.Equal(t, map[string]any{"value": "patched"}, writer.ToolResults[0].Result)
+	result := provider.lastRequest().Messages[2].ToolResult
+	require.NotNil(t, result)
+	assert.JSONEq(t, `{"value":"patched"}`, result.GetTextContent())
+}
+
+func TestRunToolLoopDebugEditArguments(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	provider := &scriptedToolProvider{rounds: oneToolCallRound("call-1")}
+	deliverWhenPaused(t, h, "sess", &facade.ToolDebugInfo{
+		CallID:    "call-1",
+		Action:    facade.ToolDebugActionEdit,
+		Arguments: map[string]any{"q": "edited"},
+	})
+
+	writer, _, _ := runTestToolLoop(t, h, provider, true)
+
+	require.Len(t, writer.ToolResults, 1)
+	assert.Empty(t, writer.ToolResults[0].Error)
+	assert.Equal(t, map[string]any{"value": float64(42)}, writer.ToolResults[0].Result)
+}
+
+func TestRunToolLoopDebugCancelled(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	provider := &scriptedToolProvider{rounds: oneToolCallRound("call-1")}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	writer := &MockResponseWriter{}
+	_, err := h.runToolLoop(ctx, "sess", &SessionState{}, provider, newToolTestRegistry(t),
+		providers.PredictionRequest{}, true, writer)
+	require.NoError(t, err)
+	require.Len(t, writer.ToolResults, 1)
+	assert.Contains(t, writer.ToolResults[0].Error, "context canceled")
+	assert.False(t, h.SendToolDebug(context.Background(), "sess", &facade.ToolDebugInfo{CallID: "call-1"}))
+}
+
+func TestRunToolLoopStopsAfterMaxRounds(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	rounds := make([][]types.MessageToolCall, maxToolRounds)
+	for i := range rounds {
+		rounds[i] = oneToolCallRound("call")[0]
+	}
+	provider := &scriptedToolProvider{rounds: rounds}
+
+	_, err := h.runToolLoop(context.Background(), "sess", &SessionState{}, provider, newToolTestRegistry(t),
+		providers.PredictionRequest{}, false, &MockResponseWriter{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rounds")
+}
+
+func TestSendToolDebugWithoutPausedCall(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	assert.False(t, h.SendToolDebug(context.Background(), "sess", &facade.ToolDebugInfo{CallID: "nope"}))
+	assert.False(t, h.SendToolDebug(context.Background(), "sess", nil))
+}
+
+func TestSetDebugMode(t *testing.T) {
+	h := &PromptKitHandler{log: logr.Discard()}
+	state := &SessionState{}
+
+	writer := &MockResponseWriter{}
+	require.NoError(t, h.setDebugMode(state, "true", writer))
+	assert.True(t, state.Debug)
+	assert.Equal(t, "Debug mode enabled", writer.DoneContent)
+
+	writer = &MockResponseWriter{}
+	require.NoError(t, h.setDebugMode(state, "false", writer))
+	assert.False(t, state.Debug)
+	assert.Equal(t, "Debug mode disabled", writer.DoneContent)
+
+	writer = &MockResponseWriter{}
+	require.NoError(t, h.setDebugMode(state, "maybe", writer))
+	assert.Equal(t, "INVALID_DEBUG_MODE", writer.ErrorCode)
+}
+
+func TestHasTools(t *testing.T) {
+	assert.False(t, hasTools(nil))
+	assert.True(t, hasTools(newToolTestRegistry(t)))
+}
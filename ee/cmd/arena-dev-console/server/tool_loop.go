@@ -0,0 +1,175 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/PromptKit/runtime/tools"
+	"github.com/AltairaLabs/PromptKit/runtime/types"
+
+	"github.com/altairalabs/omnia/internal/facade"
+)
+
+// maxToolRounds caps provider round trips per user message so a model that
+// keeps calling tools cannot loop forever.
+const maxToolRounds = 10
+
+// toolChoiceAuto lets the model decide whether to call tools.
+const toolChoiceAuto = "auto"
+
+// currentToolRegistry returns the tool registry built with the active config,
+// extended with the tools of the namespace's ToolRegistries when loaded.
+func (h *PromptKitHandler) currentToolRegistry() *tools.Registry {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.k8sTools.merge(h.toolRegistry)
+}
+
+func hasTools(toolReg *tools.Registry) bool {
+	return toolReg != nil && len(toolReg.List()) > 0
+}
+
+// toolDescriptors converts the registry's tools into provider descriptors.
+func toolDescriptors(toolReg *tools.Registry) []*providers.ToolDescriptor {
+	all := toolReg.GetTools()
+	descriptors := make([]*providers.ToolDescriptor, 0, len(all))
+	for _, name := range toolReg.List() {
+		t := all[name]
+		if t == nil {
+			continue
+		}
+		descriptors = append(descriptors, &providers.ToolDescriptor{
+			Name:         t.Name,
+			Description:  t.Description,
+			InputSchema:  t.InputSchema,
+			OutputSchema: t.OutputSchema,
+		})
+	}
+	return descriptors
+}
+
+// runToolLoop predicts with tools, executes the requested calls, and feeds
+// the results back to the model until it answers without calling a tool.
+// In debug mode every call pauses until the client sends a tool_debug
+// command. Tool rounds use non-streaming predictions so the complete set of
+// calls is known before any of them runs.
+func (h *PromptKitHandler) runToolLoop(
+	ctx context.Context,
+	sessionID string,
+	state *SessionState,
+	provider providers.ToolSupport,
+	toolReg *tools.Registry,
+	req providers.PredictionRequest,
+	debug bool,
+	writer facade.ResponseWriter,
+) (string, error) {
+	tooling, err := provider.BuildTooling(toolDescriptors(toolReg))
+	if err != nil {
+		return "", fmt.Errorf("failed to build tooling: %w", err)
+	}
+
+	for range maxToolRounds {
+		resp, calls, err := provider.PredictWithTools(ctx, req, tooling, toolChoiceAuto)
+		if err != nil {
+			return "", err
+		}
+		if len(calls) == 0 {
+			if err := writer.WriteDone(resp.Content); err != nil {
+				return "", fmt.Errorf("failed to write done: %w", err)
+			}
+			return resp.Content, nil
+		}
+
+		assistant := types.Message{Role: "assistant", Content: resp.Content, ToolCalls: calls}
+		turn := []types.Message{assistant}
+		for _, call := range calls {
+			result := h.resolveToolCall(ctx, sessionID, toolReg, call, debug, writer)
+			turn = append(turn, types.NewToolResultMessage(result))
+		}
+
+		req.Messages = append(req.Messages, turn...)
+		state.mu.Lock()
+		state.Messages = append(state.Messages, turn...)
+		state.mu.Unlock()
+	}
+	return "", fmt.Errorf("model requested tools for more than %d rounds", maxToolRounds)
+}
+
+// resolveToolCall announces a tool call to the client, waits for a debug
+// decision when debugging, runs the call, and reports its result.
+func (h *PromptKitHandler) resolveToolCall(
+	ctx context.Context,
+	sessionID string,
+	toolReg *tools.Registry,
+	call types.MessageToolCall,
+	debug bool,
+	writer facade.ResponseWriter,
+) types.MessageToolResult {
+	info := &facade.ToolCallInfo{ID: call.ID, Name: call.Name, AwaitingDebug: debug}
+	if len(call.Args) > 0 {
+		_ = json.Unmarshal(call.Args, &info.Arguments)
+	}
+
+	var cmd *facade.ToolDebugInfo
+	if debug {
+		ch := h.debugger.pause(sessionID, call.ID)
+		defer h.debugger.release(sessionID, call.ID)
+		if err := writer.WriteToolCall(info); err != nil {
+			h.log.Error(err, "failed to write tool call")
+		}
+		var err error
+		if cmd, err = awaitToolDebug(ctx, ch); err != nil {
+			result := types.MessageToolResult{ID: call.ID, Name: call.Name, Error: err.Error()}
+			h.writeToolResult(result, writer)
+			return result
+		}
+		h.log.V(1).Info("tool call resumed", "sessionID", sessionID, "tool", call.Name, "action", cmd.Action)
+	} else if err := writer.WriteToolCall(info); err != nil {
+		h.log.Error(err, "failed to write tool call")
+	}
+
+	result := h.applyToolDecision(ctx, toolReg, call, cmd)
+	h.writeToolResult(result, writer)
+	return result
+}
+
+// executeTool runs a tool through the registry and converts the outcome to
+// the message form returned to the model.
+func (h *PromptKitHandler) executeTool(
+	ctx context.Context, toolReg *tools.Registry, callID, name string, args json.RawMessage,
+) types.MessageToolResult {
+	res, err := toolReg.Execute(ctx, name, args)
+	if err != nil {
+		return types.MessageToolResult{ID: callID, Name: name, Error: err.Error()}
+	}
+	result := types.NewTextToolResult(callID, name, string(res.Result))
+	result.Error = res.Error
+	result.LatencyMs = res.LatencyMs
+	return result
+}
+
+// writeToolResult reports a resolved tool call to the client.
+func (h *PromptKitHandler) writeToolResult(result types.MessageToolResult, writer facade.ResponseWriter) {
+	info := &facade.ToolResultInfo{ID: result.ID, Error: result.Error}
+	if text := result.GetTextContent(); text != "" {
+		var decoded any
+		if json.Unmarshal([]byte(text), &decoded) == nil {
+			info.Result = decoded
+		} else {
+			info.Result = text
+		}
+	}
+	if err := writer.WriteToolResult(info); err != nil {
+		h.log.Error(err, "failed to write tool result")
+	}
+}
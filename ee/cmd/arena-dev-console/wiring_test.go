@@ -16,7 +16,7 @@ import (
 	"github.com/go-logr/logr"
 )
 
-// TestBuildFacadeMux_RoutesRegistered asserts the dev console's three
+// TestBuildFacadeMux_RoutesRegistered asserts the dev console's
 // documented HTTP routes are registered on the mux returned by
 // buildFacadeMux. Each route is the contract between the dev console and
 // the dashboard's reload/test workflow — if a Handle/HandleFunc call is
@@ -29,7 +29,7 @@ func TestBuildFacadeMux_RoutesRegistered(t *testing.T) {
 	wsStub := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusSwitchingProtocols)
 	})
-	mux := buildFacadeMux(wsStub, nil, logr.Discard(), nil, true)
+	mux := buildFacadeMux(wsStub, nil, nil, logr.Discard(), nil, true)
 
 	tests := []struct {
 		name   string
@@ -39,6 +39,7 @@ func TestBuildFacadeMux_RoutesRegistered(t *testing.T) {
 		{"websocket endpoint", http.MethodGet, "/ws"},
 		{"providers endpoint", http.MethodGet, "/api/providers"},
 		{"reload endpoint", http.MethodPost, "/api/reload?path=ignored"},
+		{"timeline endpoint", http.MethodGet, "/api/sessions/s1/timeline"},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -105,6 +106,29 @@ func TestHandleReload_MethodNotAllowed(t *testing.T) {
 	}
 }
 
+// TestHandleTimeline_NoSource verifies the replay endpoint responds 503
+// rather than crashing when session recording is not configured.
+func TestHandleTimeline_NoSource(t *testing.T) {
+	h := handleTimeline(nil, logr.Discard())
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions/s1/timeline", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 when source nil, got %d", rr.Code)
+	}
+}
+
+// TestHandleTimeline_MethodNotAllowed verifies the replay endpoint is read-only.
+func TestHandleTimeline_MethodNotAllowed(t *testing.T) {
+	h := handleTimeline(nil, logr.Discard())
+	req := httptest.NewRequest(http.MethodPost, "/api/sessions/s1/timeline", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for POST, got %d", rr.Code)
+	}
+}
+
 // TestHealthzHandler verifies the early-boot health endpoint returns 200
 // with a plain "ok" body. The startHealthServer goroutine launches before
 // service discovery, so liveness probes pass during the retry loop.
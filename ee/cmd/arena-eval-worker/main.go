@@ -33,11 +33,15 @@ import (
 	redisprovider "github.com/altairalabs/omnia/internal/session/providers/redis"
 	"github.com/altairalabs/omnia/internal/tracing"
 	"github.com/altairalabs/omnia/pkg/k8s"
+	"github.com/altairalabs/omnia/pkg/metrics"
+	"github.com/altairalabs/omnia/pkg/profiling"
+	"github.com/altairalabs/omnia/pkg/readiness"
 	"github.com/altairalabs/omnia/pkg/servicediscovery"
 
 	// Register PromptKit provider factories for LLM judge eval execution.
 	_ "github.com/AltairaLabs/PromptKit/runtime/providers/claude"
 	_ "github.com/AltairaLabs/PromptKit/runtime/providers/openai"
+	_ "github.com/altairalabs/omnia/pkg/provider/openaicompatible"
 )
 
 // Environment variable names for worker configuration.
@@ -103,11 +107,33 @@ func main() {
 	// promhttp gatherer reads it live at scrape time.
 	evalRegistry := prometheus.NewRegistry()
 
+	// Push the same metrics to an OTLP collector when the standard OTEL_*
+	// env vars ask for it.
+	shutdownMetrics, err := metrics.StartOTLPExport(context.Background(), "omnia-arena-eval-worker",
+		prometheus.Gatherers{prometheus.DefaultGatherer, evalRegistry})
+	if err != nil {
+		logger.Error("OTLP metric export failed to start", "error", err)
+	} else {
+		defer func() { _ = shutdownMetrics(context.Background()) }()
+	}
+
+	// pprof on an internal port, plus Pyroscope push when configured.
+	stopProfiling, err := profiling.Start(profiling.Options{
+		ServiceName: "omnia-arena-eval-worker",
+		PprofAddr:   profiling.DefaultPprofAddr,
+	})
+	if err != nil {
+		logger.Error("profiling failed to start", "error", err)
+	}
+	defer func() { _ = stopProfiling(context.Background()) }()
+
 	// Start the health/metrics server early so Kubernetes liveness probes
 	// pass while we wait for service discovery. Without this, the retry
 	// loop below blocks main() and the pod gets killed for failing the
-	// liveness check before resolution succeeds.
-	go startHTTPServer(cfg.MetricsAddr, logger, evalRegistry)
+	// liveness check before resolution succeeds. Readiness checks are added
+	// once the Redis and session-api clients exist.
+	ready := readiness.NewChecker()
+	go startHTTPServer(cfg.MetricsAddr, logger, evalRegistry, ready)
 
 	// Resolve the session-api URL with retry from Workspace.status.services.
 	// Retries because per-workspace services (session-api, memory-api) may
@@ -155,6 +181,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Redis carries the eval streams, so the worker cannot work without it.
+	// session-api writes are retried, so losing it only degrades the worker.
+	ready.Add(
+		readiness.Critical("redis", func(ctx context.Context) error { return redisClient.Ping(ctx).Err() }),
+		readiness.Optional("session-api", readiness.HTTPGet(nil, strings.TrimSuffix(cfg.SessionAPIURL, "/")+"/healthz")),
+	)
+
 	msgStore := redisprovider.NewFromClient(redisClient, redisprovider.DefaultOptions())
 
 	evalCollector := sdkmetrics.NewEvalOnlyCollector(sdkmetrics.CollectorOpts{
@@ -177,6 +210,7 @@ func main() {
 	workerCfg := evals.WorkerConfig{
 		RedisClient:   redisClient,
 		ResultWriter:  sessionClient,
+		SignalReader:  sessionClient,
 		MessageStore:  msgStore,
 		Namespaces:    cfg.Namespaces,
 		Logger:        logger,
@@ -308,10 +342,10 @@ func parseNamespaces() []string {
 // startHTTPServer starts the metrics and health probe HTTP server.
 // The evalRegistry holds per-eval-name metrics (e.g., omnia_eval_helpfulness)
 // that are merged with the default Prometheus registry for /metrics.
-func startHTTPServer(addr string, logger *slog.Logger, evalRegistry *prometheus.Registry) {
+func startHTTPServer(addr string, logger *slog.Logger, evalRegistry *prometheus.Registry, ready http.Handler) {
 	server := &http.Server{
 		Addr:              addr,
-		Handler:           buildMetricsHealthMux(evalRegistry),
+		Handler:           buildMetricsHealthMux(evalRegistry, ready),
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 	logger.Info("starting metrics/health server", "addr", addr)
@@ -321,9 +355,9 @@ func startHTTPServer(addr string, logger *slog.Logger, evalRegistry *prometheus.
 }
 
 // buildMetricsHealthMux registers /metrics (merged default + eval registry),
-// /healthz, and /readyz. Extracted so a wiring test can assert all three
-// routes are registered without spinning up a real listener.
-func buildMetricsHealthMux(evalRegistry *prometheus.Registry) *http.ServeMux {
+// /healthz, and /readyz served by ready. Extracted so a wiring test can assert
+// all three routes are registered without spinning up a real listener.
+func buildMetricsHealthMux(evalRegistry *prometheus.Registry, ready http.Handler) *http.ServeMux {
 	mux := http.NewServeMux()
 	// Only add a non-nil eval registry to the gatherers: a nil *Registry
 	// passed to prometheus.Gatherers panics at scrape time (its Gather method
@@ -338,10 +372,7 @@ func buildMetricsHealthMux(evalRegistry *prometheus.Registry) *http.ServeMux {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
-	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
-	})
+	mux.Handle("/readyz", ready)
 	return mux
 }
 
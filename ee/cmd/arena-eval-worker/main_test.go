@@ -25,6 +25,7 @@ import (
 
 	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
 	"github.com/altairalabs/omnia/pkg/k8s"
+	"github.com/altairalabs/omnia/pkg/readiness"
 	"github.com/altairalabs/omnia/pkg/servicediscovery"
 )
 
@@ -133,7 +134,7 @@ func TestStartHTTPServer(t *testing.T) {
 	t.Setenv(envLogLevel, "error")
 	logger := buildLogger()
 
-	go startHTTPServer("127.0.0.1:0", logger, nil)
+	go startHTTPServer("127.0.0.1:0", logger, nil, readiness.NewChecker())
 	time.Sleep(50 * time.Millisecond)
 }
 
@@ -144,7 +145,7 @@ func TestStartHTTPServer(t *testing.T) {
 // This test builds the same mux startHTTPServer assembles and ServeHTTP-tests
 // each documented route.
 func TestBuildMetricsHealthMux(t *testing.T) {
-	mux := buildMetricsHealthMux(prometheus.NewRegistry())
+	mux := buildMetricsHealthMux(prometheus.NewRegistry(), readiness.NewChecker())
 	tests := []struct {
 		name string
 		path string
@@ -171,7 +172,7 @@ func TestBuildMetricsHealthMux(t *testing.T) {
 // panics at scrape time inside (*Registry).Gather, closing the connection so
 // Prometheus records an EOF / down target. /metrics must return 200, not panic.
 func TestBuildMetricsHealthMux_NilRegistry(t *testing.T) {
-	mux := buildMetricsHealthMux(nil)
+	mux := buildMetricsHealthMux(nil, readiness.NewChecker())
 	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
 	rr := httptest.NewRecorder()
 	mux.ServeHTTP(rr, req)
@@ -192,7 +193,7 @@ func TestBuildMetricsHealthMux_ExposesEvalRegistry(t *testing.T) {
 	reg.MustRegister(c)
 	c.Inc()
 
-	mux := buildMetricsHealthMux(reg)
+	mux := buildMetricsHealthMux(reg, readiness.NewChecker())
 	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
 	rr := httptest.NewRecorder()
 	mux.ServeHTTP(rr, req)
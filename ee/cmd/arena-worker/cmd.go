@@ -23,6 +23,7 @@ import (
 	"github.com/AltairaLabs/PromptKit/runtime/logger"
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 
@@ -30,6 +31,11 @@ import (
 	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
 	"github.com/altairalabs/omnia/internal/tracing"
 	"github.com/altairalabs/omnia/pkg/logging"
+	"github.com/altairalabs/omnia/pkg/metrics"
+	"github.com/altairalabs/omnia/pkg/profiling"
+	"github.com/altairalabs/omnia/pkg/readiness"
+	// Register the openai-compatible provider factory for Provider CRDs.
+	_ "github.com/altairalabs/omnia/pkg/provider/openaicompatible"
 )
 
 func main() {
@@ -130,21 +136,51 @@ func run(ctx context.Context) error {
 	log.Info("connected to redis")
 
 	// Initialize metrics and wrap queue with instrumentation
-	queueMetrics := queue.NewQueueMetrics(queue.QueueMetricsConfig{})
+	queueMetrics := queue.NewQueueMetrics(queue.QueueMetricsConfig{JobName: cfg.JobName})
 	queueMetrics.Initialize()
 	q := queue.NewInstrumentedQueue(rawQ, queueMetrics)
+	go reportQueueProgress(ctx, q, queue.ScopedJobID(cfg.JobNamespace, cfg.JobName), queueProgressInterval)
 
 	workerMetrics := NewWorkerMetrics()
 
 	metricsAddr := getEnvOrDefault("METRICS_ADDR", defaultMetricsAddr)
-	go startMetricsServer(metricsAddr, log)
+	// The work queue is the worker's only hard dependency: without it no
+	// work items can be claimed or completed.
+	go startMetricsServer(metricsAddr, log, readiness.NewChecker(readiness.Critical("redis", rawQ.Ping)))
+
+	// Push the same metrics to an OTLP collector when the standard OTEL_*
+	// env vars ask for it. The deferred shutdown pushes the final values, so
+	// no scrape delay is needed for them.
+	shutdownMetrics, err := metrics.StartOTLPExport(ctx, "omnia-arena-worker", prometheus.DefaultGatherer)
+	if err != nil {
+		log.Error(err, "OTLP metric export failed to start")
+	} else {
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = shutdownMetrics(shutdownCtx)
+		}()
+	}
+
+	// pprof on an internal port, plus Pyroscope push when configured. Stopping
+	// the profiler uploads the final profile before the Job exits.
+	stopProfiling, err := profiling.Start(profiling.Options{
+		ServiceName: "omnia-arena-worker",
+		PprofAddr:   profiling.DefaultPprofAddr,
+	})
+	if err != nil {
+		log.Error(err, "profiling failed to start")
+	}
+	defer func() { _ = stopProfiling(context.Background()) }()
 
 	// Process work items
 	err = processWorkItems(ctx, log, cfg, q, bundlePath, workerMetrics)
 
-	// Persist output to S3 after all work items complete (best-effort, non-fatal).
+	// Render the job report into the output dir, then persist output to S3
+	// after all work items complete (best-effort, non-fatal).
 	// For PVC output, the engine writes directly to the mounted path — no extra step needed.
 	if err == nil {
+		writeJobReport(ctx, log, cfg, rawQ)
 		persistOutputToS3(ctx, log, cfg)
 	}
 
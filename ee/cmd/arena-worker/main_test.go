@@ -15,6 +15,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,7 +29,11 @@ import (
 
 	pkproviders "github.com/AltairaLabs/PromptKit/runtime/providers"
 	"github.com/AltairaLabs/PromptKit/runtime/statestore"
+	"github.com/AltairaLabs/PromptKit/runtime/types"
+	"github.com/AltairaLabs/promptarena/arena/arenaconfig"
+	arenastatestore "github.com/AltairaLabs/promptarena/arena/statestore"
 	v1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/arena/golden"
 	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -972,6 +977,27 @@ func TestRunAggregatorProcessAssertions(t *testing.T) {
 			t.Errorf("expected failCount=1, got %d", agg.failCount)
 		}
 	})
+
+	t.Run("tags golden dataset results with their dataset", func(t *testing.T) {
+		agg := &runAggregator{passCount: 1, log: testLog()}
+		scenario := &arenaconfig.Scenario{ConversationAssertions: []arenaconfig.AssertionConfig{
+			{Type: "json_valid"},
+			{Type: "contains", Params: map[string]any{golden.ParamDataset: "support@v1"}},
+		}}
+
+		// One arena-wide assertion is evaluated ahead of the scenario's two.
+		agg.processAssertions("run-1", scenario, []arenastatestore.ConversationValidationResult{
+			{Type: "llm_judge", Passed: true},
+			{Type: "json_valid", Passed: true},
+			{Type: "contains", Passed: false, Message: "missing: refund"},
+		})
+
+		require.Len(t, agg.assertions, 3)
+		assert.Nil(t, agg.assertions[0].Params)
+		assert.Nil(t, agg.assertions[1].Params)
+		assert.Equal(t, map[string]any{golden.ParamDataset: "support@v1"}, agg.assertions[2].Params)
+		assert.Equal(t, "missing: refund", agg.assertions[2].Message, "the result keeps its own message")
+	})
 }
 
 func TestBuildExecutionResult(t *testing.T) {
@@ -982,7 +1008,7 @@ func TestBuildExecutionResult(t *testing.T) {
 		runIDs := []string{"run-1"}
 		startTime := time.Now()
 
-		result := buildExecutionResult(testLog(), mockStore, runIDs, startTime, nil)
+		result := buildExecutionResult(testLog(), mockStore, runIDs, startTime, nil, nil)
 
 		// Should return fail — unable to read run state means results are unknown
 		if result.Status != statusFail {
@@ -998,7 +1024,7 @@ func TestBuildExecutionResult(t *testing.T) {
 		runIDs := []string{}
 		startTime := time.Now()
 
-		result := buildExecutionResult(testLog(), mockStore, runIDs, startTime, nil)
+		result := buildExecutionResult(testLog(), mockStore, runIDs, startTime, nil, nil)
 
 		if result.Status != statusFail {
 			t.Errorf("expected status %s, got %s", statusFail, result.Status)
@@ -1290,3 +1316,25 @@ func TestFleetModeScenarioFilter(t *testing.T) {
 		assert.Empty(t, scenarioFilter, "empty scenario should produce empty filter")
 	})
 }
+
+func TestAppendTranscript(t *testing.T) {
+	long := strings.Repeat("é", maxTranscriptContentLen+10)
+	transcript := appendTranscript(nil, []types.Message{
+		{Role: "user", Content: "hello"},
+		{Role: "assistant", Content: long},
+	})
+	if len(transcript) != 2 || transcript[0].Role != "user" || transcript[0].Content != "hello" {
+		t.Fatalf("unexpected transcript %+v", transcript)
+	}
+	if got := []rune(transcript[1].Content); len(got) != maxTranscriptContentLen+1 {
+		t.Errorf("long content should be truncated to %d runes plus an ellipsis, got %d", maxTranscriptContentLen, len(got))
+	}
+
+	many := make([]types.Message, maxTranscriptMessages+5)
+	for i := range many {
+		many[i] = types.Message{Role: "user", Content: "x"}
+	}
+	if got := appendTranscript(transcript, many); len(got) != maxTranscriptMessages {
+		t.Errorf("transcript should be capped at %d messages, got %d", maxTranscriptMessages, len(got))
+	}
+}
@@ -9,6 +9,7 @@ Functional Source License. See ee/LICENSE for details.
 package main
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -16,10 +17,16 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
 )
 
 const defaultMetricsAddr = ":9090"
 
+// queueProgressInterval is how often the worker refreshes the job item gauges
+// from the shared queue.
+const queueProgressInterval = 15 * time.Second
+
 // Label key constants used across arena worker metrics.
 const (
 	labelJobName   = "job_name"
@@ -163,26 +170,42 @@ func (m *WorkerMetrics) SetActiveVUs(count float64) {
 	m.ActiveVUs.Set(count)
 }
 
-// newMetricsMux creates the HTTP handler mux with /metrics, /healthz, and /readyz endpoints.
-func newMetricsMux() *http.ServeMux {
+// reportQueueProgress reads the job's progress from q every interval until ctx
+// ends. An instrumented queue turns each read into the
+// omnia_arena_queue_job_items gauges, so backlog alerts see the whole job,
+// not just this worker's share. Read errors are skipped: the job may not be
+// enqueued yet.
+func reportQueueProgress(ctx context.Context, q queue.WorkQueue, jobID string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, _ = q.Progress(ctx, jobID)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// newMetricsMux creates the HTTP handler mux with /metrics, /healthz, and /readyz
+// endpoints, with /readyz served by ready.
+func newMetricsMux(ready http.Handler) *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.Handler())
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
-	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
-	})
+	mux.Handle("/readyz", ready)
 	return mux
 }
 
 // startMetricsServer starts the Prometheus metrics and health probe HTTP server.
-func startMetricsServer(addr string, log logr.Logger) {
+func startMetricsServer(addr string, log logr.Logger, ready http.Handler) {
 	server := &http.Server{
 		Addr:              addr,
-		Handler:           newMetricsMux(),
+		Handler:           newMetricsMux(ready),
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
@@ -9,9 +9,11 @@ Functional Source License. See ee/LICENSE for details.
 package main
 
 import (
+	"context"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -20,6 +22,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	dto "github.com/prometheus/client_model/go"
+
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
+	"github.com/altairalabs/omnia/pkg/readiness"
 )
 
 func TestNewWorkerMetrics(t *testing.T) {
@@ -177,7 +182,7 @@ func TestStartMetricsServer(t *testing.T) {
 	// Close the listener so startMetricsServer can bind to the same port.
 	_ = listener.Close()
 
-	go startMetricsServer(addr, testLog())
+	go startMetricsServer(addr, testLog(), readiness.NewChecker())
 
 	// Give the server time to start.
 	time.Sleep(50 * time.Millisecond)
@@ -189,7 +194,7 @@ func TestStartMetricsServer(t *testing.T) {
 }
 
 func TestNewMetricsMux(t *testing.T) {
-	mux := newMetricsMux()
+	mux := newMetricsMux(readiness.NewChecker())
 
 	tests := []struct {
 		path       string
@@ -197,7 +202,7 @@ func TestNewMetricsMux(t *testing.T) {
 		wantBody   string
 	}{
 		{"/healthz", http.StatusOK, "ok"},
-		{"/readyz", http.StatusOK, "ok"},
+		{"/readyz", http.StatusOK, `"status":"ok"`},
 		{"/metrics", http.StatusOK, "go_goroutines"},
 	}
 
@@ -212,3 +217,32 @@ func TestNewMetricsMux(t *testing.T) {
 		})
 	}
 }
+
+// progressCountingQueue counts Progress calls on the wrapped queue.
+type progressCountingQueue struct {
+	queue.WorkQueue
+	calls atomic.Int32
+}
+
+func (q *progressCountingQueue) Progress(ctx context.Context, jobID string) (*queue.JobProgress, error) {
+	q.calls.Add(1)
+	return q.WorkQueue.Progress(ctx, jobID)
+}
+
+func TestReportQueueProgress(t *testing.T) {
+	q := &progressCountingQueue{WorkQueue: queue.NewMemoryQueueWithDefaults()}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		reportQueueProgress(ctx, q, "ns/job", 5*time.Millisecond)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return q.calls.Load() >= 2 }, time.Second, 5*time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reportQueueProgress did not stop after cancel")
+	}
+}
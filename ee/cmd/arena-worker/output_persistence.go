@@ -14,6 +14,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path"
@@ -26,6 +27,10 @@ import (
 	"github.com/go-logr/logr"
 
 	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/arena/aggregator"
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
+	"github.com/altairalabs/omnia/ee/pkg/arena/report"
+	"github.com/altairalabs/omnia/ee/pkg/arena/storage"
 )
 
 // defaultOutputDir is the fallback output directory used when no OutputConfig is present.
@@ -160,3 +165,73 @@ func newS3UploadFunc(ctx context.Context, s3Cfg *omniav1alpha1.S3OutputConfig) (
 		return err
 	}, nil
 }
+
+// writeJobReport renders the HTML and PDF reports for the completed job into
+// the output directory so it is persisted alongside the engine output. Every
+// worker sees the job complete and writes the same report; the file is
+// replaced atomically so concurrent writers on a shared PVC never leave a
+// partial report behind. It is a no-op when the job has no output config.
+func writeJobReport(ctx context.Context, log logr.Logger, cfg *Config, q queue.WorkQueue) {
+	if cfg.OutputConfig == nil {
+		return
+	}
+	jobID := queue.ScopedJobID(cfg.JobNamespace, cfg.JobName)
+	summary, results, err := aggregator.New(q).AggregateWithResults(ctx, jobID)
+	if err != nil {
+		log.Error(err, "failed to aggregate results — report not written")
+		return
+	}
+	jobResults := &storage.JobResults{
+		JobID:     cfg.JobName,
+		Namespace: cfg.JobNamespace,
+		Summary:   summary,
+		Results:   results,
+	}
+	if progress, progressErr := q.Progress(ctx, jobID); progressErr == nil {
+		if progress.StartedAt != nil {
+			jobResults.StartedAt = *progress.StartedAt
+		}
+		if progress.CompletedAt != nil {
+			jobResults.CompletedAt = *progress.CompletedAt
+		}
+	}
+
+	outputDir := resolveOutputDir(cfg)
+	for _, r := range []struct {
+		name   string
+		render func(io.Writer, *storage.JobResults) error
+	}{
+		{report.FileName(cfg.JobName), report.Render},
+		{report.PDFFileName(cfg.JobName), report.RenderPDF},
+	} {
+		if err := writeReportFile(outputDir, r.name, jobResults, r.render); err != nil {
+			log.Error(err, "failed to write job report", "file", r.name)
+			continue
+		}
+		log.Info("job report written", "file", filepath.Join(outputDir, r.name))
+	}
+}
+
+// writeReportFile renders a report to a temp file in dir and renames it into place.
+func writeReportFile(dir, name string, results *storage.JobResults, render func(io.Writer, *storage.JobResults) error) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+name+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if err := render(tmp, results); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to set report permissions: %w", err)
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, name))
+}
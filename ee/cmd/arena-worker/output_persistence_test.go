@@ -11,15 +11,18 @@ Functional Source License. See ee/LICENSE for details.
 package main
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
 )
 
 // --- newS3UploadFunc ---
@@ -267,3 +270,55 @@ func TestUploadOutputToS3(t *testing.T) {
 		assert.Equal(t, "myjob/report.json", keys[0])
 	})
 }
+
+// --- writeJobReport ---
+
+func TestWriteJobReport(t *testing.T) {
+	ctx := context.Background()
+	q := queue.NewMemoryQueueWithDefaults()
+	jobID := queue.ScopedJobID("ns", "eval-job")
+	require.NoError(t, q.Push(ctx, jobID, []queue.WorkItem{
+		{ID: "item-1", ScenarioID: "refund", ProviderID: "openai"},
+	}))
+	_, err := q.Pop(ctx, jobID)
+	require.NoError(t, err)
+	require.NoError(t, q.CompleteItem(ctx, jobID, "item-1", &queue.ItemResult{
+		Status: "pass", DurationMs: 25,
+		Transcript: []queue.TranscriptMessage{
+			{Role: "user", Content: "I want a refund"},
+			{Role: "assistant", Content: "Your refund is on its way."},
+		},
+	}))
+
+	dir := t.TempDir()
+	cfg := &Config{
+		JobName:      "eval-job",
+		JobNamespace: "ns",
+		OutputDir:    dir,
+		OutputConfig: &omniav1alpha1.OutputConfig{
+			Type: omniav1alpha1.OutputTypePVC,
+			PVC:  &omniav1alpha1.PVCOutputConfig{ClaimName: "results"},
+		},
+	}
+
+	writeJobReport(ctx, logr.Discard(), cfg, q)
+
+	data, err := os.ReadFile(filepath.Join(dir, "eval-job-report.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "refund")
+	assert.Contains(t, string(data), "Your refund is on its way.", "the report carries the run's transcript")
+
+	pdf, err := os.ReadFile(filepath.Join(dir, "eval-job-report.pdf"))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-")))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "temp files should be renamed into place")
+}
+
+func TestWriteJobReport_NoOutputConfig(t *testing.T) {
+	cfg := &Config{JobName: "eval-job", JobNamespace: "ns"}
+	// Must not touch the queue or the filesystem.
+	writeJobReport(context.Background(), logr.Discard(), cfg, nil)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package main
+
+import (
+	"github.com/AltairaLabs/PromptKit/runtime/prompt"
+	"github.com/AltairaLabs/promptarena/arena/arenaconfig"
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
+)
+
+// applyParameterSet overrides the loaded provider defaults and prompt system
+// templates with the work item's parameter set. Unset fields keep the values
+// from the bundle, so a parameter set only needs to declare what it varies.
+func applyParameterSet(log logr.Logger, arenaCfg *arenaconfig.Config, params *queue.ParameterSet) {
+	if params == nil {
+		return
+	}
+
+	for _, p := range arenaCfg.LoadedProviders {
+		if p == nil {
+			continue
+		}
+		if params.Temperature != nil {
+			p.Defaults.Temperature = float32(*params.Temperature)
+		}
+		if params.TopP != nil {
+			p.Defaults.TopP = float32(*params.TopP)
+		}
+		if params.MaxTokens != nil {
+			p.Defaults.MaxTokens = *params.MaxTokens
+		}
+	}
+
+	if params.SystemPrompt != "" {
+		for _, pc := range arenaCfg.LoadedPromptConfigs {
+			if cfg, ok := pc.Config.(*prompt.Config); ok && cfg != nil {
+				cfg.Spec.SystemTemplate = params.SystemPrompt
+			}
+		}
+	}
+
+	log.V(1).Info("applied parameter set", "parameterSet", params.Name)
+}
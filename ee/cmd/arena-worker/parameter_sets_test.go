@@ -0,0 +1,73 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/AltairaLabs/PromptKit/pkg/config"
+	"github.com/AltairaLabs/PromptKit/runtime/prompt"
+	"github.com/AltairaLabs/promptarena/arena/arenaconfig"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
+)
+
+func newParameterSetFixture() *arenaconfig.Config {
+	return &arenaconfig.Config{
+		LoadedProviders: map[string]*config.Provider{
+			"openai": {ID: "openai", Defaults: config.ProviderDefaults{Temperature: 0.7, TopP: 1, MaxTokens: 1024}},
+		},
+		LoadedPromptConfigs: map[string]*arenaconfig.PromptConfigData{
+			"support": {Config: &prompt.Config{Spec: prompt.Spec{SystemTemplate: "You are helpful."}}},
+		},
+	}
+}
+
+func TestApplyParameterSet_Nil(t *testing.T) {
+	arenaCfg := newParameterSetFixture()
+	applyParameterSet(logr.Discard(), arenaCfg, nil)
+
+	assert.InDelta(t, 0.7, arenaCfg.LoadedProviders["openai"].Defaults.Temperature, 1e-6)
+	assert.Equal(t, "You are helpful.", systemTemplate(arenaCfg, "support"))
+}
+
+func TestApplyParameterSet_AllFields(t *testing.T) {
+	arenaCfg := newParameterSetFixture()
+	applyParameterSet(logr.Discard(), arenaCfg, &queue.ParameterSet{
+		Name:         "precise",
+		Temperature:  ptr.To(0.1),
+		TopP:         ptr.To(0.5),
+		MaxTokens:    ptr.To(256),
+		SystemPrompt: "Answer in one sentence.",
+	})
+
+	defaults := arenaCfg.LoadedProviders["openai"].Defaults
+	assert.InDelta(t, 0.1, defaults.Temperature, 1e-6)
+	assert.InDelta(t, 0.5, defaults.TopP, 1e-6)
+	assert.Equal(t, 256, defaults.MaxTokens)
+	assert.Equal(t, "Answer in one sentence.", systemTemplate(arenaCfg, "support"))
+}
+
+func TestApplyParameterSet_PartialKeepsBundleValues(t *testing.T) {
+	arenaCfg := newParameterSetFixture()
+	applyParameterSet(logr.Discard(), arenaCfg, &queue.ParameterSet{Name: "hot", Temperature: ptr.To(1.5)})
+
+	defaults := arenaCfg.LoadedProviders["openai"].Defaults
+	assert.InDelta(t, 1.5, defaults.Temperature, 1e-6)
+	assert.InDelta(t, 1.0, defaults.TopP, 1e-6)
+	assert.Equal(t, 1024, defaults.MaxTokens)
+	assert.Equal(t, "You are helpful.", systemTemplate(arenaCfg, "support"))
+}
+
+func systemTemplate(arenaCfg *arenaconfig.Config, id string) string {
+	return arenaCfg.LoadedPromptConfigs[id].Config.(*prompt.Config).Spec.SystemTemplate
+}
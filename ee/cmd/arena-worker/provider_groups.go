@@ -294,8 +294,13 @@ func buildProviderConfig(provider *v1alpha1.Provider, id string) *config.Provide
 	}
 
 	pkProvider.Role = string(provider.EffectiveRole())
-	if provider.Spec.Type == v1alpha1.ProviderTypeHuggingFace {
+	switch provider.Spec.Type {
+	case v1alpha1.ProviderTypeHuggingFace:
 		pkProvider.AdditionalConfig = omniaprovider.HuggingFaceAdditionalConfig(provider.Spec.BaseURL)
+	case v1alpha1.ProviderTypeOpenAICompatible:
+		oc := omniaprovider.OpenAICompatible(&provider.Spec)
+		pkProvider.UnsupportedParams = oc.UnsupportedParams
+		pkProvider.Capabilities = oc.Capabilities
 	}
 
 	return pkProvider
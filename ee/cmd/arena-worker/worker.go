@@ -23,12 +23,14 @@ import (
 	"time"
 
 	pkproviders "github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/promptarena/arena/arenaconfig"
 	"github.com/go-logr/logr"
 	"go.opentelemetry.io/otel/trace"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/arena/golden"
 	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
 	"github.com/altairalabs/omnia/internal/session"
 )
@@ -123,6 +125,11 @@ type Config struct {
 	// Populated from the ARENA_OUTPUT_DIR env var.
 	OutputDir string
 
+	// GoldenDatasets are the dataset versions resolved by the controller,
+	// parsed from ARENA_GOLDEN_DATASETS. Their expected outputs are applied
+	// to matching scenarios as conversation assertions.
+	GoldenDatasets []golden.Ref
+
 	// Override configurations (resolved from CRDs)
 	ToolOverrides map[string]ToolOverrideConfig // Tool name -> override config
 
@@ -149,13 +156,17 @@ type ExecutionResult struct {
 	Metrics    map[string]float64 `json:"metrics,omitempty"`
 	Assertions []AssertionResult  `json:"assertions,omitempty"`
 	SessionID  string             `json:"sessionId,omitempty"`
+	// Transcript is the runs' conversation for the job report, capped by
+	// appendTranscript.
+	Transcript []queue.TranscriptMessage `json:"transcript,omitempty"`
 }
 
 // AssertionResult represents a single assertion result.
 type AssertionResult struct {
-	Name    string `json:"name"`
-	Passed  bool   `json:"passed"`
-	Message string `json:"message,omitempty"`
+	Name    string         `json:"name"`
+	Passed  bool           `json:"passed"`
+	Message string         `json:"message,omitempty"`
+	Params  map[string]any `json:"params,omitempty"`
 }
 
 func loadConfig() (*Config, error) {
@@ -192,6 +203,12 @@ func loadConfig() (*Config, error) {
 		cfg.OutputConfig = &outCfg
 	}
 
+	refs, err := golden.ParseRefs(os.Getenv(golden.EnvDatasets))
+	if err != nil {
+		return nil, err
+	}
+	cfg.GoldenDatasets = refs
+
 	if cfg.JobName == "" {
 		return nil, errors.New("ARENA_JOB_NAME is required")
 	}
@@ -263,6 +280,8 @@ type runAggregator struct {
 	assertions    []AssertionResult
 	inputTokens   int
 	outputTokens  int
+	transcript    []queue.TranscriptMessage
+	scenarios     map[string]*arenaconfig.Scenario
 	log           logr.Logger
 }
 
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/altairalabs/omnia/ee/pkg/arena/golden"
 	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
 	"github.com/altairalabs/omnia/pkg/k8s"
 	"github.com/altairalabs/omnia/pkg/session/httpclient"
@@ -35,7 +36,7 @@ func processWorkItems(
 	ctx context.Context, log logr.Logger, cfg *Config,
 	q queue.WorkQueue, bundlePath string, wm *WorkerMetrics,
 ) error {
-	jobID := cfg.JobName
+	jobID := queue.ScopedJobID(cfg.JobNamespace, cfg.JobName)
 
 	// No job-level root span — each work item gets its own trace.
 	// This prevents massive traces for load tests with 100+ items.
@@ -304,6 +305,7 @@ func toItemResult(result *ExecutionResult) *queue.ItemResult {
 			Name:    a.Name,
 			Passed:  a.Passed,
 			Message: a.Message,
+			Params:  a.Params,
 		}
 	}
 	return &queue.ItemResult{
@@ -313,6 +315,7 @@ func toItemResult(result *ExecutionResult) *queue.ItemResult {
 		Metrics:    result.Metrics,
 		Assertions: assertions,
 		SessionID:  result.SessionID,
+		Transcript: result.Transcript,
 	}
 }
 
@@ -339,6 +342,8 @@ func executeWorkItem(
 		return nil, err
 	}
 
+	applyParameterSet(log, arenaCfg, item.Parameters)
+
 	// Build registries and executors from the config.
 	// Fleet providers in LoadedProviders are handled by the registered fleet factory
 	// (ee/pkg/arena/fleet/factory.go) — no special ordering needed.
@@ -416,7 +421,8 @@ func executeWorkItem(
 	}
 
 	// Build result from state store, with cost calculation from provider pricing.
-	result = buildExecutionResult(log, eng.GetStateStore(), runIDs, start, pricingMap[item.ProviderID])
+	result = buildExecutionResult(log, eng.GetStateStore(), runIDs, start, pricingMap[item.ProviderID],
+		arenaCfg.LoadedScenarios)
 
 	// Extract TTFT from fleet providers — the engine doesn't propagate this,
 	// so we read it directly from the provider after execution completes.
@@ -467,6 +473,16 @@ func loadArenaConfig(log logr.Logger, cfg *Config, bundlePath string) (*arenacon
 	// to /tmp/arena-output (uploaded after all items complete), nil uses the fallback.
 	arenaCfg.Defaults.Output.Dir = resolveOutputDir(cfg)
 
+	// Apply golden dataset expectations before the engine snapshots scenarios.
+	if len(cfg.GoldenDatasets) > 0 {
+		datasets, err := golden.Load(golden.MountRoot, cfg.GoldenDatasets)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to load golden datasets: %w", err)
+		}
+		added := golden.Apply(arenaCfg.LoadedScenarios, datasets)
+		log.Info("applied golden datasets", "datasets", len(datasets), "assertions", added)
+	}
+
 	return arenaCfg, configPath, nil
 }
 
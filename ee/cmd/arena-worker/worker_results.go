@@ -20,12 +20,14 @@ import (
 	"time"
 
 	"github.com/AltairaLabs/PromptKit/runtime/statestore"
+	"github.com/AltairaLabs/PromptKit/runtime/types"
 	"github.com/AltairaLabs/promptarena/arena/arenaconfig"
 	arenastatestore "github.com/AltairaLabs/promptarena/arena/statestore"
 	"github.com/go-logr/logr"
 	"gopkg.in/yaml.v3"
 
 	v1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/arena/golden"
 	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
 )
 
@@ -95,6 +97,8 @@ func (a *runAggregator) processRun(runID string, state *arenastatestore.ArenaCon
 		}
 	}
 
+	a.transcript = appendTranscript(a.transcript, state.Messages)
+
 	if meta.Error != "" {
 		a.errors = append(a.errors, fmt.Sprintf("run %s: %s", runID, meta.Error))
 		a.failCount++
@@ -104,16 +108,47 @@ func (a *runAggregator) processRun(runID string, state *arenastatestore.ArenaCon
 		a.log.V(1).Info("run passed", "runID", runID, "duration", meta.Duration)
 	}
 
-	a.processAssertions(runID, meta.ConversationAssertionResults)
+	a.processAssertions(runID, a.scenarios[meta.ScenarioID], meta.ConversationAssertionResults)
+}
+
+// Transcript caps. A work item's result is stored in Redis and read back in
+// full by the aggregator, so transcripts are bounded rather than complete; the
+// recorded session holds the full conversation.
+const (
+	maxTranscriptMessages   = 50
+	maxTranscriptContentLen = 2000
+)
+
+// appendTranscript appends a run's messages to transcript, up to
+// maxTranscriptMessages, truncating each message's text to
+// maxTranscriptContentLen runes.
+func appendTranscript(transcript []queue.TranscriptMessage, messages []types.Message) []queue.TranscriptMessage {
+	for i := range messages {
+		if len(transcript) >= maxTranscriptMessages {
+			break
+		}
+		content := messages[i].GetContent()
+		if runes := []rune(content); len(runes) > maxTranscriptContentLen {
+			content = string(runes[:maxTranscriptContentLen]) + "…"
+		}
+		transcript = append(transcript, queue.TranscriptMessage{Role: messages[i].Role, Content: content})
+	}
+	return transcript
 }
 
 // processAssertions extracts assertion results and adjusts pass/fail counts.
-func (a *runAggregator) processAssertions(runID string, assertions []arenastatestore.ConversationValidationResult) {
-	for _, assertion := range assertions {
+// scenario is the run's scenario, used to tag results of golden dataset
+// assertions with their dataset.
+func (a *runAggregator) processAssertions(
+	runID string, scenario *arenaconfig.Scenario, assertions []arenastatestore.ConversationValidationResult,
+) {
+	params := golden.ResultParams(scenario, len(assertions))
+	for i, assertion := range assertions {
 		a.assertions = append(a.assertions, AssertionResult{
 			Name:    assertion.Type,
 			Passed:  assertion.Passed,
 			Message: assertion.Message,
+			Params:  params[i],
 		})
 		a.log.V(1).Info("assertion result",
 			"runID", runID,
@@ -135,9 +170,10 @@ func (a *runAggregator) processAssertions(runID string, assertions []arenastates
 
 // buildExecutionResult constructs an ExecutionResult from the engine's state store.
 // If pricing is non-nil, cost is computed from token counts and written to metrics.
+// scenarios are the loaded scenarios the runs executed.
 func buildExecutionResult(
 	log logr.Logger, store statestore.Store, runIDs []string, startTime time.Time,
-	pricing *providerPricing,
+	pricing *providerPricing, scenarios map[string]*arenaconfig.Scenario,
 ) *ExecutionResult {
 	result := &ExecutionResult{
 		DurationMs: float64(time.Since(startTime).Milliseconds()),
@@ -150,7 +186,7 @@ func buildExecutionResult(
 		return buildFallbackResult(result, runIDs)
 	}
 
-	agg := &runAggregator{log: log}
+	agg := &runAggregator{log: log, scenarios: scenarios}
 	for _, runID := range runIDs {
 		state, err := arenaStore.GetArenaState(context.Background(), runID)
 		if err != nil {
@@ -162,6 +198,7 @@ func buildExecutionResult(
 	}
 
 	result.Assertions = agg.assertions
+	result.Transcript = agg.transcript
 	populateMetrics(result, agg, len(runIDs), pricing)
 	setResultStatus(result, agg)
 
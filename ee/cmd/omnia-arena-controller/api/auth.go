@@ -0,0 +1,92 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package api
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/altairalabs/omnia/ee/pkg/license"
+	"github.com/altairalabs/omnia/internal/httputil"
+	"github.com/altairalabs/omnia/internal/serviceauth"
+)
+
+// publicPaths stay reachable without a token when service auth is on: the
+// health check, and the license every Omnia component reads at startup
+// (it carries no secrets).
+var publicPaths = []string{"/healthz", license.LicensePath}
+
+// callerAuth is the ServiceAccount allowlist the API authorizes callers
+// against.
+type callerAuth struct {
+	reviewer   serviceauth.TokenReviewer
+	subjects   []string
+	namespaces []string
+}
+
+// SetServiceAuth requires callers to present a ServiceAccount token that
+// TokenReview authenticates to an allowed subject. The two allowlists grant
+// different reach, since workers and the dashboard are not equally trusted:
+//
+//   - allowedSubjects are platform components (e.g. the dashboard). They may
+//     call every route, for any namespace.
+//   - allowedNamespaces admit any ServiceAccount in those namespaces (e.g.
+//     arena workers and dev consoles). They may only call
+//     /api/v1/namespaces/{namespace}/ routes for their own namespace, and
+//     never the path-based render endpoints, which read and write the
+//     controller's filesystem.
+//
+// A nil reviewer leaves the API unauthenticated.
+func (s *Server) SetServiceAuth(reviewer serviceauth.TokenReviewer, allowedSubjects, allowedNamespaces []string) {
+	if reviewer == nil {
+		s.auth = nil
+		return
+	}
+	s.auth = &callerAuth{reviewer: reviewer, subjects: allowedSubjects, namespaces: allowedNamespaces}
+}
+
+// isPlatform reports whether subject is on the exact-subject allowlist.
+func (a *callerAuth) isPlatform(subject string) bool {
+	return subject != "" && slices.Contains(a.subjects, subject)
+}
+
+// platformOnly admits only platform callers to h when service auth is on.
+func (s *Server) platformOnly(h http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.auth != nil && !s.auth.isPlatform(serviceauth.SubjectFromContext(r.Context())) {
+			writeForbidden(w)
+			return
+		}
+		h(w, r)
+	})
+}
+
+// namespaceScoped admits platform callers, and namespace-admitted callers
+// whose ServiceAccount lives in the route's {namespace}, to h when service
+// auth is on.
+func (s *Server) namespaceScoped(h http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.auth != nil {
+			subject := serviceauth.SubjectFromContext(r.Context())
+			ns, _, ok := serviceauth.ParseServiceAccount(subject)
+			if !s.auth.isPlatform(subject) && (!ok || ns != r.PathValue("namespace")) {
+				s.log.V(1).Info("caller denied access to namespace",
+					"subject", subject, "namespace", r.PathValue("namespace"))
+				writeForbidden(w)
+				return
+			}
+		}
+		h(w, r)
+	})
+}
+
+// writeForbidden writes the same generic 403 body the auth middleware does.
+func writeForbidden(w http.ResponseWriter) {
+	_ = httputil.WriteJSON(w, http.StatusForbidden, map[string]string{"error": "forbidden"})
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	testDashboardSA = "system:serviceaccount:omnia-system:omnia-dashboard"
+	testWorkerSA    = "system:serviceaccount:team-a:arena-worker"
+)
+
+// tokenReviewer authenticates tokens that are keys of its map, as the
+// subject they map to.
+type tokenReviewer map[string]string
+
+func (r tokenReviewer) ReviewToken(_ context.Context, token string) (bool, string, error) {
+	subject, ok := r[token]
+	return ok, subject, nil
+}
+
+func newAuthServer() *Server {
+	s := NewServer(":8080", logr.Discard(), nil)
+	s.SetServiceAuth(tokenReviewer{"dashboard": testDashboardSA, "worker": testWorkerSA},
+		[]string{testDashboardSA}, []string{"team-a"})
+	return s
+}
+
+func serveAuth(s *Server, method, path, token string) int {
+	req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	s.handler().ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestHandler_ServiceAuth(t *testing.T) {
+	s := newAuthServer()
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		{"health check is open", http.MethodGet, "/healthz", "", http.StatusOK},
+		{"license is open", http.MethodGet, "/api/v1/license", "", http.StatusOK},
+		{"missing token", http.MethodGet, "/api/v1/namespaces/team-a/arenajobs/job/events", "",
+			http.StatusUnauthorized},
+		{"unknown token", http.MethodGet, "/api/v1/namespaces/team-a/arenajobs/job/events", "forged",
+			http.StatusUnauthorized},
+		// No aggregator is set, so a request that passes auth gets 503
+		{"worker in its own namespace", http.MethodGet, "/api/v1/namespaces/team-a/arenajobs/job/events",
+			"worker", http.StatusServiceUnavailable},
+		{"worker in another namespace", http.MethodGet, "/api/v1/namespaces/team-b/arenajobs/job/events",
+			"worker", http.StatusForbidden},
+		{"worker in another namespace's catalog", http.MethodGet, "/api/v1/namespaces/team-b/templates",
+			"worker", http.StatusForbidden},
+		{"dashboard in any namespace", http.MethodGet, "/api/v1/namespaces/team-b/arenajobs/job/events",
+			"dashboard", http.StatusServiceUnavailable},
+		{"worker on path-based render", http.MethodPost, "/api/render-template", "worker", http.StatusForbidden},
+		{"worker on path-based preview", http.MethodPost, "/api/preview-template", "worker", http.StatusForbidden},
+		// An empty request body fails validation, so a request that passes auth gets 400
+		{"dashboard on path-based render", http.MethodPost, "/api/render-template", "dashboard",
+			http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serveAuth(s, tt.method, tt.path, tt.token); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_NoServiceAuth(t *testing.T) {
+	s := NewServer(":8080", logr.Discard(), nil)
+	if got := serveAuth(s, http.MethodGet, "/api/v1/namespaces/team-b/arenajobs/job/events", ""); got !=
+		http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", got, http.StatusServiceUnavailable)
+	}
+
+	s = newAuthServer()
+	s.SetServiceAuth(nil, nil, nil)
+	if got := serveAuth(s, http.MethodPost, "/api/render-template", ""); got != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", got, http.StatusBadRequest)
+	}
+}
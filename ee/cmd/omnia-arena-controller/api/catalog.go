@@ -0,0 +1,494 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package api
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/internal/controller"
+	arenaTemplate "github.com/altairalabs/omnia/ee/pkg/arena/template"
+	"github.com/altairalabs/omnia/internal/httputil"
+)
+
+const (
+	// templateCatalogPattern is the route for searching the template catalog.
+	templateCatalogPattern = "/api/v1/namespaces/{namespace}/templates"
+
+	// templateCatalogRenderPattern is the route for creating a project from a template.
+	templateCatalogRenderPattern = "/api/v1/namespaces/{namespace}/templates/{source}/{template}/render"
+
+	// projectsDir is the workspace-relative directory Arena projects live in.
+	projectsDir = "arena/projects"
+
+	// projectMetaFile is the per-project metadata file the dashboard reads.
+	projectMetaFile = ".project.json"
+
+	// previewReadmeMaxBytes caps the README excerpt included in catalog entries.
+	previewReadmeMaxBytes = 4096
+)
+
+// templateCatalog resolves templates discovered by ArenaTemplateSources. It
+// reads the index files the ArenaTemplateSource controller writes to the
+// workspace content volume, and the source status for the synced content path.
+type templateCatalog struct {
+	client      client.Reader
+	contentPath string
+}
+
+// SetTemplateCatalog enables the template catalog endpoints. workspaceContentPath
+// is the workspace content volume mount shared with the ArenaTemplateSource
+// controller. Without it the catalog endpoints return 503.
+func (s *Server) SetTemplateCatalog(c client.Reader, workspaceContentPath string) {
+	s.catalog = &templateCatalog{client: c, contentPath: workspaceContentPath}
+}
+
+// CatalogTemplate is a template in the catalog with its parsed metadata.
+type CatalogTemplate struct {
+	// Source is the ArenaTemplateSource the template came from.
+	Source string `json:"source"`
+	// Name is the unique name of the template within its source.
+	Name string `json:"name"`
+	// Version is the semantic version of the template.
+	Version string `json:"version,omitempty"`
+	// DisplayName is the human-readable name.
+	DisplayName string `json:"displayName,omitempty"`
+	// Description explains what the template does.
+	Description string `json:"description,omitempty"`
+	// Category groups templates by type.
+	Category string `json:"category,omitempty"`
+	// Tags are searchable labels for the template.
+	Tags []string `json:"tags,omitempty"`
+	// Parameters are the variables a caller can set when rendering.
+	Parameters []arenaTemplate.Variable `json:"parameters,omitempty"`
+	// Preview summarizes what the template produces.
+	Preview *CatalogPreview `json:"preview,omitempty"`
+}
+
+// CatalogPreview summarizes a template without rendering it.
+type CatalogPreview struct {
+	// Files lists the files and directories the template produces.
+	Files []string `json:"files,omitempty"`
+	// Readme is the start of the template's README.md, when it has one.
+	Readme string `json:"readme,omitempty"`
+}
+
+// CatalogResponse is the response for GET /api/v1/namespaces/{namespace}/templates.
+type CatalogResponse struct {
+	// Templates are the templates matching the query.
+	Templates []CatalogTemplate `json:"templates"`
+	// Categories are the distinct categories of the listed sources' templates,
+	// before query, category and tag filtering.
+	Categories []string `json:"categories"`
+	// Tags are the distinct tags of the listed sources' templates, before
+	// query, category and tag filtering.
+	Tags []string `json:"tags"`
+}
+
+// CatalogFilter narrows a catalog listing. Empty fields match everything.
+type CatalogFilter struct {
+	// Query matches the name, display name or description (case-insensitive).
+	Query string
+	// Category matches the template category (case-insensitive).
+	Category string
+	// Tags match templates carrying any of the tags.
+	Tags []string
+	// Source limits the listing to one ArenaTemplateSource.
+	Source string
+}
+
+// CatalogRenderRequest is the request body for
+// POST /api/v1/namespaces/{namespace}/templates/{source}/{template}/render.
+type CatalogRenderRequest struct {
+	// ProjectName is the display name of the new project.
+	ProjectName string `json:"projectName"`
+	// ProjectDescription defaults to the template description.
+	ProjectDescription string `json:"projectDescription,omitempty"`
+	// ProjectTags are stored on the project metadata.
+	ProjectTags []string `json:"projectTags,omitempty"`
+	// Variables are the parameter values; unset parameters take their defaults.
+	Variables map[string]any `json:"variables,omitempty"`
+	// CreatedBy identifies the user creating the project.
+	CreatedBy string `json:"createdBy,omitempty"`
+}
+
+// CatalogRenderResponse is the response for a successful catalog render.
+type CatalogRenderResponse struct {
+	// ProjectID is the ID (and directory name) of the new project.
+	ProjectID string `json:"projectId"`
+	// ProjectName is the display name of the new project.
+	ProjectName string `json:"projectName"`
+	// Files lists the created files relative to the projects directory.
+	Files []string `json:"files,omitempty"`
+	// Warnings lists any rendering warnings.
+	Warnings []string `json:"warnings,omitempty"`
+	// Template identifies the template the project was created from.
+	Template ProjectTemplateRef `json:"template"`
+}
+
+// ProjectTemplateRef identifies the template a project was created from.
+type ProjectTemplateRef struct {
+	Source  string `json:"source"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// projectMeta is the .project.json written next to a rendered project. Its
+// shape matches what the dashboard writes for projects it creates.
+type projectMeta struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Tags        []string           `json:"tags"`
+	Template    ProjectTemplateRef `json:"template"`
+	CreatedAt   string             `json:"createdAt"`
+	UpdatedAt   string             `json:"updatedAt"`
+	CreatedBy   string             `json:"createdBy"`
+}
+
+// errSourceNotReady is returned when rendering from a source that has not synced.
+var errSourceNotReady = errors.New("template source is not ready")
+
+// namespaceRoot returns the workspace content directory of a namespace.
+func (c *templateCatalog) namespaceRoot(ctx context.Context, namespace string) string {
+	workspace := controller.GetWorkspaceForNamespace(ctx, c.client, namespace)
+	return filepath.Join(c.contentPath, workspace, namespace)
+}
+
+// readIndex reads the template index the controller wrote for a source. A
+// missing index (source never synced) yields no templates.
+func readIndex(root, source string) ([]arenaTemplate.Template, error) {
+	path := filepath.Join(root, controller.TemplateIndexDir, source+".json")
+	if err := validatePathWithinBase(root, path); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // path validated within the namespace root
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template index for %s: %w", source, err)
+	}
+	var templates []arenaTemplate.Template
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return nil, fmt.Errorf("failed to parse template index for %s: %w", source, err)
+	}
+	return templates, nil
+}
+
+// templateDir returns the synced directory of a template, or "" when the
+// source has no synced content.
+func templateDir(root string, source *omniav1alpha1.ArenaTemplateSource, tmpl *arenaTemplate.Template) (string, error) {
+	if source.Status.Artifact == nil || source.Status.Artifact.ContentPath == "" {
+		return "", nil
+	}
+	dir := filepath.Join(root, source.Status.Artifact.ContentPath, tmpl.Path)
+	if err := validatePathWithinBase(root, dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// List returns the templates of every ready source in the namespace that
+// match the filter, with category and tag facets over each listed source's
+// full template set.
+func (c *templateCatalog) List(ctx context.Context, namespace string, filter CatalogFilter) (*CatalogResponse, error) {
+	var sources omniav1alpha1.ArenaTemplateSourceList
+	if err := c.client.List(ctx, &sources, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list template sources: %w", err)
+	}
+	root := c.namespaceRoot(ctx, namespace)
+
+	resp := &CatalogResponse{Templates: []CatalogTemplate{}, Categories: []string{}, Tags: []string{}}
+	for i := range sources.Items {
+		source := &sources.Items[i]
+		if source.Status.Phase != omniav1alpha1.ArenaTemplateSourcePhaseReady {
+			continue
+		}
+		if filter.Source != "" && source.Name != filter.Source {
+			continue
+		}
+		all, err := readIndex(root, source.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range all {
+			if t.Category != "" && !slices.Contains(resp.Categories, t.Category) {
+				resp.Categories = append(resp.Categories, t.Category)
+			}
+			for _, tag := range t.Tags {
+				if !slices.Contains(resp.Tags, tag) {
+					resp.Tags = append(resp.Tags, tag)
+				}
+			}
+		}
+
+		matched := arenaTemplate.SearchTemplates(all, filter.Query)
+		matched = arenaTemplate.FilterByCategory(matched, filter.Category)
+		matched = arenaTemplate.FilterByTags(matched, filter.Tags)
+		for j := range matched {
+			resp.Templates = append(resp.Templates, catalogEntry(root, source, &matched[j]))
+		}
+	}
+
+	slices.SortFunc(resp.Templates, func(a, b CatalogTemplate) int {
+		return cmp.Or(strings.Compare(a.Source, b.Source), strings.Compare(a.Name, b.Name))
+	})
+	slices.Sort(resp.Categories)
+	slices.Sort(resp.Tags)
+	return resp, nil
+}
+
+// catalogEntry converts an indexed template into its catalog form.
+func catalogEntry(root string, source *omniav1alpha1.ArenaTemplateSource, t *arenaTemplate.Template) CatalogTemplate {
+	entry := CatalogTemplate{
+		Source:      source.Name,
+		Name:        t.Name,
+		Version:     t.Version,
+		DisplayName: t.DisplayName,
+		Description: t.Description,
+		Category:    t.Category,
+		Tags:        t.Tags,
+		Parameters:  t.Variables,
+	}
+	preview := &CatalogPreview{}
+	for _, f := range t.Files {
+		preview.Files = append(preview.Files, f.Path)
+	}
+	if dir, err := templateDir(root, source, t); err == nil && dir != "" {
+		preview.Readme = readReadme(dir)
+	}
+	if len(preview.Files) > 0 || preview.Readme != "" {
+		entry.Preview = preview
+	}
+	return entry
+}
+
+// readReadme returns up to previewReadmeMaxBytes of a template's README.md.
+func readReadme(dir string) string {
+	f, err := os.Open(filepath.Join(dir, "README.md")) //nolint:gosec // dir validated within the namespace root
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+	data, err := io.ReadAll(io.LimitReader(f, previewReadmeMaxBytes))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// catalogRenderError is a render failure that maps to a client error.
+type catalogRenderError struct {
+	status  int
+	message string
+	errors  []string
+}
+
+func (e *catalogRenderError) Error() string { return e.message }
+
+// Render creates a new project in the namespace's workspace from a catalog
+// template: it validates the parameters, renders the template into
+// arena/projects/{id} and writes the project metadata.
+func (c *templateCatalog) Render(
+	ctx context.Context, namespace, sourceName, templateName string, req *CatalogRenderRequest,
+) (*CatalogRenderResponse, error) {
+	var source omniav1alpha1.ArenaTemplateSource
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: sourceName}, &source); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, &catalogRenderError{status: http.StatusNotFound, message: "template source not found: " + sourceName}
+		}
+		return nil, fmt.Errorf("failed to get template source: %w", err)
+	}
+	if source.Status.Phase != omniav1alpha1.ArenaTemplateSourcePhaseReady {
+		return nil, &catalogRenderError{status: http.StatusConflict, message: errSourceNotReady.Error()}
+	}
+
+	root := c.namespaceRoot(ctx, namespace)
+	templates, err := readIndex(root, source.Name)
+	if err != nil {
+		return nil, err
+	}
+	tmpl := arenaTemplate.GetTemplateByName(templates, templateName)
+	if tmpl == nil {
+		return nil, &catalogRenderError{status: http.StatusNotFound, message: "template not found: " + templateName}
+	}
+	dir, err := templateDir(root, &source, tmpl)
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return nil, &catalogRenderError{status: http.StatusConflict, message: errSourceNotReady.Error()}
+	}
+
+	variables := arenaTemplate.ApplyDefaults(tmpl, req.Variables)
+	variables["projectName"] = req.ProjectName
+	if errs := arenaTemplate.ValidateVariables(tmpl, variables); len(errs) > 0 {
+		msgs := make([]string, len(errs))
+		for i, e := range errs {
+			msgs[i] = e.Error()
+		}
+		return nil, &catalogRenderError{status: http.StatusBadRequest, message: "variable validation failed", errors: msgs}
+	}
+
+	projectID := uuid.NewString()
+	projectsPath := filepath.Join(root, projectsDir)
+	result, err := RenderTemplate(dir, projectsPath, projectID, variables)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		return nil, &catalogRenderError{
+			status: http.StatusUnprocessableEntity, message: "template rendering failed", errors: result.Errors,
+		}
+	}
+
+	ref := ProjectTemplateRef{Source: source.Name, Name: tmpl.Name, Version: tmpl.Version}
+	if err := writeProjectMeta(filepath.Join(projectsPath, projectID), projectID, tmpl, ref, req); err != nil {
+		return nil, err
+	}
+
+	return &CatalogRenderResponse{
+		ProjectID:   projectID,
+		ProjectName: req.ProjectName,
+		Files:       result.FilesCreated,
+		Warnings:    result.Warnings,
+		Template:    ref,
+	}, nil
+}
+
+// writeProjectMeta writes the .project.json for a newly rendered project.
+func writeProjectMeta(
+	projectPath, projectID string, tmpl *arenaTemplate.Template, ref ProjectTemplateRef, req *CatalogRenderRequest,
+) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	meta := projectMeta{
+		ID:          projectID,
+		Name:        req.ProjectName,
+		Description: req.ProjectDescription,
+		Tags:        req.ProjectTags,
+		Template:    ref,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		CreatedBy:   req.CreatedBy,
+	}
+	if meta.Description == "" {
+		meta.Description = tmpl.Description
+	}
+	if meta.Tags == nil {
+		meta.Tags = []string{}
+	}
+	if meta.CreatedBy == "" {
+		meta.CreatedBy = "unknown"
+	}
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal project metadata: %w", err)
+	}
+	// #nosec G306 - project files are workspace content shared with the dashboard
+	if err := os.WriteFile(filepath.Join(projectPath, projectMetaFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write project metadata: %w", err)
+	}
+	return nil
+}
+
+// handleTemplateCatalog handles GET /api/v1/namespaces/{namespace}/templates.
+// Query parameters: q (search), category, tag (repeatable, any-of), source.
+func (s *Server) handleTemplateCatalog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, msgMethodNotAllowed, http.StatusMethodNotAllowed)
+		return
+	}
+	if s.catalog == nil {
+		http.Error(w, "template catalog requires workspace content storage", http.StatusServiceUnavailable)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := CatalogFilter{
+		Query:    query.Get("q"),
+		Category: query.Get("category"),
+		Tags:     query["tag"],
+		Source:   query.Get("source"),
+	}
+	namespace := r.PathValue("namespace")
+	resp, err := s.catalog.List(r.Context(), namespace, filter)
+	if err != nil {
+		s.log.Error(err, "failed to list template catalog", "namespace", namespace)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.log.Error(err, "failed to encode response")
+	}
+}
+
+// handleTemplateCatalogRender handles
+// POST /api/v1/namespaces/{namespace}/templates/{source}/{template}/render.
+func (s *Server) handleTemplateCatalogRender(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, msgMethodNotAllowed, http.StatusMethodNotAllowed)
+		return
+	}
+	if s.catalog == nil {
+		http.Error(w, "template catalog requires workspace content storage", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req CatalogRenderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.ProjectName == "" {
+		http.Error(w, "projectName is required", http.StatusBadRequest)
+		return
+	}
+
+	namespace, source, name := r.PathValue("namespace"), r.PathValue("source"), r.PathValue("template")
+	resp, err := s.catalog.Render(r.Context(), namespace, source, name, &req)
+	w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
+	if err != nil {
+		status := http.StatusInternalServerError
+		body := RenderTemplateResponse{Errors: []string{err.Error()}}
+		var renderErr *catalogRenderError
+		if errors.As(err, &renderErr) {
+			status = renderErr.status
+			body.Errors = append(body.Errors, renderErr.errors...)
+		} else {
+			s.log.Error(err, "failed to render catalog template",
+				"namespace", namespace, "source", source, "template", name)
+		}
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.log.Error(err, "failed to encode response")
+	}
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/internal/controller"
+	arenaTemplate "github.com/altairalabs/omnia/ee/pkg/arena/template"
+)
+
+const catalogNamespace = "team-a"
+
+// newCatalogServer builds a server whose catalog holds one ready source
+// ("starters") with two templates, and one source that has not synced yet.
+// The namespace has no Workspace label, so its content root is
+// {contentPath}/team-a/team-a.
+func newCatalogServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	contentPath := t.TempDir()
+	root := filepath.Join(contentPath, catalogNamespace, catalogNamespace)
+
+	templateDir := filepath.Join(root, "arena/template-sources/starters/v1/templates/simple")
+	if err := os.MkdirAll(templateDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	src, err := os.ReadFile(filepath.Join(getTestdataPath(), "simple-template", "template.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(templateDir, "template.yaml"), src, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(templateDir, "README.md"), []byte("# Simple\nA starter."), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	index := []arenaTemplate.Template{
+		{
+			Name: "simple-test", Version: "1.0.0", DisplayName: "Simple", Description: "Minimal chatbot",
+			Category: "chatbot", Tags: []string{"starter", "chat"}, Path: "templates/simple",
+			Variables: []arenaTemplate.Variable{
+				{Name: "project_name", Type: arenaTemplate.VariableTypeString, Required: true, Default: "my-project"},
+				{Name: "greeting", Type: arenaTemplate.VariableTypeString, Default: "Hello"},
+			},
+			Files: []arenaTemplate.FileSpec{{Path: "config.yaml", Render: true}},
+		},
+		{
+			Name: "tool-agent", Description: "Agent that calls tools", Category: "agent",
+			Tags: []string{"tools"}, Path: "templates/tool-agent",
+		},
+	}
+	indexDir := filepath.Join(root, controller.TemplateIndexDir)
+	if err := os.MkdirAll(indexDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(index)
+	if err := os.WriteFile(filepath.Join(indexDir, "starters.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := omniav1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	ready := &omniav1alpha1.ArenaTemplateSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "starters", Namespace: catalogNamespace},
+		Status: omniav1alpha1.ArenaTemplateSourceStatus{
+			Phase:    omniav1alpha1.ArenaTemplateSourcePhaseReady,
+			Artifact: &omniav1alpha1.Artifact{ContentPath: "arena/template-sources/starters/v1"},
+		},
+	}
+	pending := &omniav1alpha1.ArenaTemplateSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: catalogNamespace},
+		Status:     omniav1alpha1.ArenaTemplateSourceStatus{Phase: omniav1alpha1.ArenaTemplateSourcePhasePending},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ready, pending).Build()
+
+	s := NewServer(":8080", logr.Discard(), nil)
+	s.SetTemplateCatalog(c, contentPath)
+	return s, root
+}
+
+func serveCatalog(s *Server, req *http.Request) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc(templateCatalogPattern, s.handleTemplateCatalog)
+	mux.HandleFunc(templateCatalogRenderPattern, s.handleTemplateCatalogRender)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func listCatalog(t *testing.T, s *Server, query string) CatalogResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/team-a/templates"+query, nil)
+	w := serveCatalog(s, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var resp CatalogResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestHandleTemplateCatalog_NotConfigured(t *testing.T) {
+	s := NewServer(":8080", logr.Discard(), nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/team-a/templates", nil)
+
+	w := serveCatalog(s, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestHandleTemplateCatalog_ListsReadySources(t *testing.T) {
+	s, _ := newCatalogServer(t)
+
+	resp := listCatalog(t, s, "")
+
+	if len(resp.Templates) != 2 {
+		t.Fatalf("templates = %d, want 2", len(resp.Templates))
+	}
+	simple := resp.Templates[0]
+	if simple.Source != "starters" || simple.Name != "simple-test" {
+		t.Errorf("first template = %s/%s, want starters/simple-test", simple.Source, simple.Name)
+	}
+	if len(simple.Parameters) != 2 {
+		t.Errorf("parameters = %d, want 2", len(simple.Parameters))
+	}
+	if simple.Preview == nil || simple.Preview.Readme != "# Simple\nA starter." {
+		t.Errorf("preview = %+v, want README excerpt", simple.Preview)
+	}
+	if simple.Preview != nil && (len(simple.Preview.Files) != 1 || simple.Preview.Files[0] != "config.yaml") {
+		t.Errorf("preview files = %v, want [config.yaml]", simple.Preview.Files)
+	}
+	if strings.Join(resp.Categories, ",") != "agent,chatbot" {
+		t.Errorf("categories = %v", resp.Categories)
+	}
+	if strings.Join(resp.Tags, ",") != "chat,starter,tools" {
+		t.Errorf("tags = %v", resp.Tags)
+	}
+}
+
+func TestHandleTemplateCatalog_Filters(t *testing.T) {
+	s, _ := newCatalogServer(t)
+
+	tests := []struct {
+		query      string
+		want       []string
+		categories int
+	}{
+		{"?q=tools", []string{"tool-agent"}, 2},
+		{"?category=CHATBOT", []string{"simple-test"}, 2},
+		{"?tag=chat&tag=tools", []string{"simple-test", "tool-agent"}, 2},
+		{"?q=chatbot&tag=tools", nil, 2},
+		{"?source=pending", nil, 0},
+	}
+	for _, tt := range tests {
+		resp := listCatalog(t, s, tt.query)
+		var got []string
+		for _, tmpl := range resp.Templates {
+			got = append(got, tmpl.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%s: templates = %v, want %v", tt.query, got, tt.want)
+		}
+		if len(resp.Categories) != tt.categories {
+			t.Errorf("%s: categories = %v, want %d facets", tt.query, resp.Categories, tt.categories)
+		}
+	}
+}
+
+func TestHandleTemplateCatalogRender_CreatesProject(t *testing.T) {
+	s, root := newCatalogServer(t)
+	body := `{"projectName":"My Bot","variables":{"project_name":"my-bot"},"createdBy":"dev@example.com"}`
+	req := httptest.NewRequest(http.MethodPost,
+		"/api/v1/namespaces/team-a/templates/starters/simple-test/render", strings.NewReader(body))
+
+	w := serveCatalog(s, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var resp CatalogRenderResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.ProjectID == "" || resp.Template.Name != "simple-test" || resp.Template.Version != "1.0.0" {
+		t.Errorf("response = %+v", resp)
+	}
+
+	projectPath := filepath.Join(root, projectsDir, resp.ProjectID)
+	config, err := os.ReadFile(filepath.Join(projectPath, "config.yaml"))
+	if err != nil {
+		t.Fatalf("rendered config missing: %v", err)
+	}
+	if !strings.Contains(string(config), "name: my-bot") {
+		t.Errorf("config.yaml = %q, want rendered project_name", config)
+	}
+
+	data, err := os.ReadFile(filepath.Join(projectPath, projectMetaFile))
+	if err != nil {
+		t.Fatalf("project metadata missing: %v", err)
+	}
+	var meta projectMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		t.Fatal(err)
+	}
+	if meta.Name != "My Bot" || meta.Description != "Minimal chatbot" || meta.CreatedBy != "dev@example.com" {
+		t.Errorf("metadata = %+v", meta)
+	}
+	if meta.Template.Source != "starters" {
+		t.Errorf("metadata template source = %q, want starters", meta.Template.Source)
+	}
+}
+
+func TestHandleTemplateCatalogRender_Errors(t *testing.T) {
+	s, _ := newCatalogServer(t)
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+	}{
+		{"missing project name", "starters/simple-test", `{}`, http.StatusBadRequest},
+		{"unknown source", "nope/simple-test", `{"projectName":"p"}`, http.StatusNotFound},
+		{"source not ready", "pending/simple-test", `{"projectName":"p"}`, http.StatusConflict},
+		{"unknown template", "starters/nope", `{"projectName":"p"}`, http.StatusNotFound},
+		{"invalid variables", "starters/simple-test", `{"projectName":"p","variables":{"project_name":""}}`,
+			http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost,
+			"/api/v1/namespaces/team-a/templates/"+tt.path+"/render", strings.NewReader(tt.body))
+		w := serveCatalog(s, req)
+		if w.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d (body %s)", tt.name, w.Code, tt.status, w.Body.String())
+		}
+	}
+}
+
+func TestHandleTemplateCatalogRender_MethodNotAllowed(t *testing.T) {
+	s, _ := newCatalogServer(t)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/team-a/templates/starters/simple-test/render", nil)
+
+	w := serveCatalog(s, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
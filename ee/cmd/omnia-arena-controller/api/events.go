@@ -0,0 +1,101 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/altairalabs/omnia/ee/pkg/arena/aggregator"
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
+	"github.com/altairalabs/omnia/internal/httputil"
+)
+
+const (
+	mimeSSE = "text/event-stream"
+
+	// jobEventsPattern is the route for live ArenaJob item events.
+	jobEventsPattern = "/api/v1/namespaces/{namespace}/arenajobs/{name}/events"
+)
+
+// jobEventsKeepalive is how often an SSE comment is sent while no items finish,
+// keeping proxies from closing the idle connection. A var so tests can shorten it.
+var jobEventsKeepalive = 15 * time.Second
+
+// handleJobEvents handles GET /api/v1/namespaces/{namespace}/arenajobs/{name}/events.
+// It streams one "item" SSE event per finished work item as the aggregator
+// observes it, followed by a "complete" event once every item has finished.
+// Clients reconnecting with Last-Event-ID resume after that event.
+func (s *Server) handleJobEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, msgMethodNotAllowed, http.StatusMethodNotAllowed)
+		return
+	}
+	if s.aggregator == nil {
+		http.Error(w, "job event streaming requires a Redis queue", http.StatusServiceUnavailable)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+	jobID := queue.ScopedJobID(namespace, name)
+	afterID := r.Header.Get("Last-Event-ID")
+
+	// The server-wide WriteTimeout would cut long-running streams short.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set(httputil.HeaderContentType, mimeSSE)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	err := s.aggregator.Watch(r.Context(), jobID, afterID, jobEventsKeepalive,
+		func(events []queue.ItemEvent) error {
+			if len(events) == 0 {
+				_, err := fmt.Fprint(w, ": keepalive\n\n")
+				flusher.Flush()
+				return err
+			}
+			for _, event := range events {
+				data, err := json.Marshal(event)
+				if err != nil {
+					s.log.Error(err, "failed to encode item event", "job", jobID)
+					continue
+				}
+				if _, err := fmt.Fprintf(w, "id: %s\nevent: item\ndata: %s\n\n", event.ID, data); err != nil {
+					return err
+				}
+			}
+			flusher.Flush()
+			return nil
+		})
+
+	switch {
+	case err == nil:
+		_, _ = fmt.Fprint(w, "event: complete\ndata: {}\n\n")
+	case r.Context().Err() != nil:
+		return
+	default:
+		if !errors.Is(err, aggregator.ErrEventsUnsupported) {
+			s.log.Error(err, "job event stream failed", "job", jobID)
+		}
+		data, _ := json.Marshal(map[string]string{"error": err.Error()})
+		_, _ = fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+	}
+	flusher.Flush()
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/ee/pkg/arena/aggregator"
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
+)
+
+func serveJobEvents(s *Server, req *http.Request) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc(jobEventsPattern, s.handleJobEvents)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandleJobEvents_NoAggregator(t *testing.T) {
+	s := NewServer(":8080", logr.Discard(), nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/ns/arenajobs/job/events", nil)
+
+	w := serveJobEvents(s, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestHandleJobEvents_MethodNotAllowed(t *testing.T) {
+	s := NewServer(":8080", logr.Discard(), nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/ns/arenajobs/job/events", nil)
+
+	w := serveJobEvents(s, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHandleJobEvents_StreamsItemsAndComplete(t *testing.T) {
+	q := queue.NewMemoryQueueWithDefaults()
+	ctx := context.Background()
+	jobID := queue.ScopedJobID("ns", "job")
+	_ = q.Push(ctx, jobID, []queue.WorkItem{
+		{ID: "item-1", ScenarioID: "refund", ProviderID: "openai"},
+		{ID: "item-2", ScenarioID: "greet", ProviderID: "openai"},
+	})
+	_, _ = q.Pop(ctx, jobID)
+	_, _ = q.Pop(ctx, jobID)
+	_ = q.CompleteItem(ctx, jobID, "item-1", &queue.ItemResult{Status: "pass", DurationMs: 12})
+	_ = q.FailItem(ctx, jobID, "item-2", errors.New("timeout"))
+
+	s := NewServer(":8080", logr.Discard(), nil)
+	s.SetAggregator(aggregator.New(q))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/ns/arenajobs/job/events", nil)
+	w := serveJobEvents(s, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != mimeSSE {
+		t.Errorf("Content-Type = %q, want %q", ct, mimeSSE)
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		"id: 1\nevent: item\n",
+		`"scenarioId":"refund"`,
+		`"status":"fail"`,
+		"event: complete\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestHandleJobEvents_ResumesFromLastEventID(t *testing.T) {
+	q := queue.NewMemoryQueueWithDefaults()
+	ctx := context.Background()
+	jobID := queue.ScopedJobID("ns", "job")
+	_ = q.Push(ctx, jobID, []queue.WorkItem{{ID: "item-1"}, {ID: "item-2"}})
+	for _, id := range []string{"item-1", "item-2"} {
+		_, _ = q.Pop(ctx, jobID)
+		_ = q.CompleteItem(ctx, jobID, id, &queue.ItemResult{Status: "pass"})
+	}
+
+	s := NewServer(":8080", logr.Discard(), nil)
+	s.SetAggregator(aggregator.New(q))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/ns/arenajobs/job/events", nil)
+	req.Header.Set("Last-Event-ID", "1")
+	body := serveJobEvents(s, req).Body.String()
+
+	if strings.Contains(body, `"itemId":"item-1"`) {
+		t.Errorf("resumed stream replayed item-1:\n%s", body)
+	}
+	if !strings.Contains(body, `"itemId":"item-2"`) {
+		t.Errorf("resumed stream missing item-2:\n%s", body)
+	}
+}
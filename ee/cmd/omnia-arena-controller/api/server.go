@@ -17,8 +17,10 @@ import (
 
 	"github.com/go-logr/logr"
 
+	"github.com/altairalabs/omnia/ee/pkg/arena/aggregator"
 	"github.com/altairalabs/omnia/ee/pkg/license"
 	"github.com/altairalabs/omnia/internal/httputil"
+	"github.com/altairalabs/omnia/internal/serviceauth"
 )
 
 // msgMethodNotAllowed is the plaintext body returned for HTTP 405 responses.
@@ -31,6 +33,9 @@ type Server struct {
 	log              logr.Logger
 	server           *http.Server
 	licenseValidator *license.Validator
+	aggregator       *aggregator.Aggregator
+	catalog          *templateCatalog
+	auth             *callerAuth
 }
 
 // NewServer creates a new API server.
@@ -42,17 +47,17 @@ func NewServer(addr string, log logr.Logger, licenseValidator *license.Validator
 	}
 }
 
+// SetAggregator enables live ArenaJob event streaming backed by the given
+// aggregator. Without it the events endpoint returns 503.
+func (s *Server) SetAggregator(agg *aggregator.Aggregator) {
+	s.aggregator = agg
+}
+
 // Start starts the HTTP server.
 func (s *Server) Start(ctx context.Context) error {
-	mux := http.NewServeMux()
-	mux.HandleFunc("/api/v1/license", s.handleGetLicense)
-	mux.HandleFunc("/api/render-template", s.handleRenderTemplate)
-	mux.HandleFunc("/api/preview-template", s.handlePreviewTemplate)
-	mux.HandleFunc("/healthz", s.handleHealthz)
-
 	s.server = &http.Server{
 		Addr:         s.addr,
-		Handler:      mux,
+		Handler:      s.handler(),
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 60 * time.Second,
 		IdleTimeout:  120 * time.Second,
@@ -62,6 +67,25 @@ func (s *Server) Start(ctx context.Context) error {
 	return s.server.ListenAndServe()
 }
 
+// handler returns the API's routes. With service auth set, every route but
+// the health check and the license requires an authorized caller, and
+// namespaced routes are scoped to the caller's namespace.
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(license.LicensePath, s.handleGetLicense)
+	mux.Handle("/api/render-template", s.platformOnly(s.handleRenderTemplate))
+	mux.Handle("/api/preview-template", s.platformOnly(s.handlePreviewTemplate))
+	mux.Handle(jobEventsPattern, s.namespaceScoped(s.handleJobEvents))
+	mux.Handle(templateCatalogPattern, s.namespaceScoped(s.handleTemplateCatalog))
+	mux.Handle(templateCatalogRenderPattern, s.namespaceScoped(s.handleTemplateCatalogRender))
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	if s.auth == nil {
+		return mux
+	}
+	return serviceauth.RequireServiceAccount(s.auth.reviewer, s.auth.subjects, s.auth.namespaces,
+		publicPaths...)(mux)
+}
+
 // Shutdown gracefully shuts down the server.
 func (s *Server) Shutdown(ctx context.Context) error {
 	if s.server == nil {
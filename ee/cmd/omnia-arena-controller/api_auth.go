@@ -0,0 +1,63 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/rest"
+
+	"github.com/altairalabs/omnia/ee/cmd/omnia-arena-controller/api"
+	"github.com/altairalabs/omnia/internal/serviceauth"
+)
+
+// apiAuthOptions are the --api-auth-* flags.
+type apiAuthOptions struct {
+	enabled           bool
+	allowedSubjects   []string
+	allowedNamespaces []string
+	audiences         []string
+}
+
+// configureAPIAuth turns on ServiceAccount auth for the API server when
+// enabled. Disabled auth is logged loudly, matching the other Omnia APIs.
+func configureAPIAuth(server *api.Server, cfg *rest.Config, opts apiAuthOptions, log logr.Logger) error {
+	if !opts.enabled {
+		log.Info("WARNING: arena controller API is UNAUTHENTICATED " +
+			"(api-auth-enabled=false); set --api-auth-enabled to require ServiceAccount tokens")
+		return nil
+	}
+	if len(opts.allowedSubjects) == 0 && len(opts.allowedNamespaces) == 0 {
+		return errors.New("--api-auth-enabled is set but both --api-auth-allowed-subjects and " +
+			"--api-auth-allowed-namespaces are empty; refusing to start (would reject every caller)")
+	}
+	reviewer, err := serviceauth.NewK8sTokenReviewer(cfg, opts.audiences)
+	if err != nil {
+		return fmt.Errorf("building token reviewer: %w", err)
+	}
+	server.SetServiceAuth(reviewer, opts.allowedSubjects, opts.allowedNamespaces)
+	log.Info("API server ServiceAccount auth enabled",
+		"allowedSubjects", opts.allowedSubjects,
+		"allowedNamespaces", opts.allowedNamespaces,
+		"audiences", opts.audiences)
+	return nil
+}
+
+func splitAndTrim(s string) []string {
+	out := make([]string, 0)
+	for _, part := range strings.Split(s, ",") {
+		if p := strings.TrimSpace(part); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+
+	"github.com/altairalabs/omnia/ee/cmd/omnia-arena-controller/api"
+)
+
+func TestConfigureAPIAuth(t *testing.T) {
+	cfg := &rest.Config{Host: "https://127.0.0.1:6443"}
+	server := api.NewServer(":0", logr.Discard(), nil)
+
+	require.NoError(t, configureAPIAuth(server, cfg, apiAuthOptions{}, logr.Discard()))
+	require.NoError(t, configureAPIAuth(server, cfg, apiAuthOptions{
+		enabled:           true,
+		allowedNamespaces: []string{"team-a"},
+	}, logr.Discard()))
+
+	err := configureAPIAuth(server, cfg, apiAuthOptions{enabled: true}, logr.Discard())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "refusing to start")
+}
+
+func TestSplitAndTrim(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, splitAndTrim(" a, ,b ,"))
+	assert.Empty(t, splitAndTrim(""))
+}
@@ -28,6 +28,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -42,6 +43,7 @@ import (
 	"github.com/altairalabs/omnia/ee/pkg/license"
 	"github.com/altairalabs/omnia/ee/pkg/workspace"
 	"github.com/altairalabs/omnia/internal/session/providers/postgres"
+	"github.com/altairalabs/omnia/pkg/profiling"
 )
 
 const logKeyController = "controller"
@@ -66,6 +68,10 @@ func init() {
 func main() {
 	var metricsAddr string
 	var apiAddr string
+	var apiAuthEnabled bool
+	var apiAuthAllowedSubjects string
+	var apiAuthAllowedNamespaces string
+	var apiAuthAudiences string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
 	var enableLeaderElection bool
@@ -90,15 +96,29 @@ func main() {
 	var redisURL string
 	var redisURLSecretName string
 	var redisURLSecretKey string
+	var workerRedisSecretName string
 	var enableWebhooks bool
 	var enableLicenseWebhooks bool
 	var devMode bool
+	var licenseGracePeriod time.Duration
+	var licenseFile string
 	var tracingEnabled bool
 	var tracingEndpoint string
 	var tlsOpts []func(*tls.Config)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to.")
 	flag.StringVar(&apiAddr, "api-bind-address", ":8082", "The address the template API server binds to.")
+	flag.BoolVar(&apiAuthEnabled, "api-auth-enabled", false,
+		"Require a ServiceAccount bearer token, validated via TokenReview, on the API server. "+
+			"/healthz and /api/v1/license stay open.")
+	flag.StringVar(&apiAuthAllowedSubjects, "api-auth-allowed-subjects", "",
+		"Comma-separated ServiceAccount subjects (system:serviceaccount:<ns>:<name>) trusted with "+
+			"every API route and namespace, e.g. the dashboard.")
+	flag.StringVar(&apiAuthAllowedNamespaces, "api-auth-allowed-namespaces", "",
+		"Comma-separated namespaces whose ServiceAccounts (e.g. arena workers) may call the "+
+			"API's namespaced routes for their own namespace only.")
+	flag.StringVar(&apiAuthAudiences, "api-auth-audiences", "",
+		"Comma-separated audiences API caller tokens must be bound to. Empty uses the API server default.")
 	flag.StringVar(&arenaWorkerImage, "arena-worker-image", "",
 		"The image to use for Arena worker containers.")
 	flag.StringVar(&arenaWorkerImagePullPolicy, "arena-worker-image-pull-policy", "",
@@ -158,12 +178,23 @@ func main() {
 			"plain env var.")
 	flag.StringVar(&redisURLSecretKey, "redis-url-secret-key", "",
 		"Key within --redis-url-secret-name whose value is the Redis URL.")
+	flag.StringVar(&workerRedisSecretName, "worker-redis-secret-name", "",
+		"Name of an optional per-namespace Secret whose \"url\" key holds a "+
+			"tenant-scoped Redis URL (e.g. an ACL user limited to "+
+			"arena:job:<namespace>/*). Workers in namespaces that have the "+
+			"Secret use it instead of the operator-wide URL. Empty disables "+
+			"per-namespace Redis credentials.")
 	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
 		"Enable webhook server for admission webhooks (requires TLS certificates).")
 	flag.BoolVar(&enableLicenseWebhooks, "enable-license-webhooks", false,
 		"Enable license validation webhooks for Arena resources.")
 	flag.BoolVar(&devMode, "dev-mode", false,
 		"Enable development mode with a full-featured license. DO NOT USE IN PRODUCTION.")
+	flag.DurationVar(&licenseGracePeriod, "license-grace-period", license.DefaultGracePeriod,
+		"How long an expired license keeps covering existing Enterprise resources read-only. "+
+			"New or changed resources are refused during the grace period. 0 disables it.")
+	flag.StringVar(&licenseFile, "license-file", "",
+		"Path of a mounted license file, read in place of the arena-license Secret when it exists.")
 	flag.BoolVar(&tracingEnabled, "tracing-enabled", false,
 		"Enable OTel tracing for arena worker pods.")
 	flag.StringVar(&tracingEndpoint, "tracing-endpoint", "",
@@ -255,7 +286,10 @@ func main() {
 
 	// Create license validator
 	var licenseValidator *license.Validator
-	validatorOpts := []license.ValidatorOption{}
+	validatorOpts := []license.ValidatorOption{license.WithGracePeriod(licenseGracePeriod)}
+	if licenseFile != "" {
+		validatorOpts = append(validatorOpts, license.WithLicenseFile(licenseFile))
+	}
 	if devMode {
 		validatorOpts = append(validatorOpts, license.WithDevMode())
 	}
@@ -267,6 +301,10 @@ func main() {
 	// Nag once at startup when this deployment isn't backed by a valid license
 	// (open-core, absent, or expired) — gated on the license, not on dev-mode.
 	license.NagIfUnlicensed(licenseValidator.GetLicenseOrDefault(context.Background()), setupLog)
+	if err := licenseValidator.RegisterMetrics(metrics.Registry); err != nil {
+		setupLog.Error(err, "unable to register license metrics")
+		os.Exit(1)
+	}
 
 	// Create storage manager for lazy PVC creation (only used when NFS is not configured)
 	var storageManager *workspace.StorageManager
@@ -312,6 +350,7 @@ func main() {
 			RedisURL:                 redisURL,
 			RedisURLSecretName:       redisURLSecretName,
 			RedisURLSecretKey:        redisURLSecretKey,
+			WorkerRedisSecretName:    workerRedisSecretName,
 			TracingEnabled:           tracingEnabled,
 			TracingEndpoint:          tracingEndpoint,
 			MgmtPlaneTokenURL:        mgmtPlaneTokenURL,
@@ -340,8 +379,21 @@ func main() {
 
 	ctx := ctrl.SetupSignalHandler()
 
-	// Start API server for template rendering
+	// Start API server for template rendering and live job events
 	apiServer := api.NewServer(apiAddr, ctrl.Log, licenseValidator)
+	apiServer.SetAggregator(arenaAggregator)
+	if err := configureAPIAuth(apiServer, mgr.GetConfig(), apiAuthOptions{
+		enabled:           apiAuthEnabled,
+		allowedSubjects:   splitAndTrim(apiAuthAllowedSubjects),
+		allowedNamespaces: splitAndTrim(apiAuthAllowedNamespaces),
+		audiences:         splitAndTrim(apiAuthAudiences),
+	}, setupLog); err != nil {
+		setupLog.Error(err, "unable to configure API server auth")
+		os.Exit(1)
+	}
+	if workspaceContentPath != "" {
+		apiServer.SetTemplateCatalog(mgr.GetClient(), workspaceContentPath)
+	}
 	go func() {
 		if err := apiServer.Start(ctx); err != nil && err != http.ErrServerClosed {
 			setupLog.Error(err, "API server error")
@@ -366,6 +418,16 @@ func main() {
 		}()
 	}
 
+	// pprof on an internal port, plus Pyroscope push when configured.
+	stopProfiling, err := profiling.Start(profiling.Options{
+		ServiceName: "omnia-arena-controller",
+		PprofAddr:   profiling.DefaultPprofAddr,
+	})
+	if err != nil {
+		setupLog.Error(err, "profiling failed to start")
+	}
+	defer func() { _ = stopProfiling(context.Background()) }()
+
 	setupLog.Info("starting arena controller manager")
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -33,8 +33,10 @@ const (
 	controllerArenaTemplateSource = "ArenaTemplateSource"
 	controllerArenaJob            = "ArenaJob"
 	controllerArenaDevSession     = "ArenaDevSession"
+	controllerGoldenDataset       = "GoldenDataset"
 	controllerKeyRotation         = "KeyRotation"
 	controllerPromptPackSource    = "PromptPackSource"
+	controllerPreviewRuntime      = "PreviewRuntime"
 	// webhookAgentRuntimeCustomFacade is the license webhook gating
 	// spec.facades[].type == "custom" on AgentRuntimes (#1774). Webhook-only —
 	// it has no reconciler; core AgentRuntime reconciliation is not
@@ -108,6 +110,7 @@ type setupOptions struct {
 	RedisURL                 string
 	RedisURLSecretName       string
 	RedisURLSecretKey        string
+	WorkerRedisSecretName    string
 	TracingEnabled           bool
 	TracingEndpoint          string
 	MgmtPlaneTokenURL        string
@@ -117,7 +120,7 @@ type setupOptions struct {
 }
 
 // namedReconciler pairs a reconciler with its display name so the
-// wiring test can assert "all six reconcilers are registered" rather
+// wiring test can assert "all seven reconcilers are registered" rather
 // than parsing reconciler-runtime internals (which expose no public
 // introspection of the registered controller set).
 type namedReconciler struct {
@@ -125,7 +128,7 @@ type namedReconciler struct {
 	Setup func(ctrl.Manager) error
 }
 
-// buildReconcilers returns the canonical 6-reconciler list the binary
+// buildReconcilers returns the canonical 7-reconciler list the binary
 // registers. Pure function — no manager interaction — so the wiring
 // test can assert the name set without spinning up envtest.
 //
@@ -177,6 +180,7 @@ func buildReconcilers(opts setupOptions) []namedReconciler {
 					RedisURL:               opts.RedisURL,
 					RedisURLSecretName:     opts.RedisURLSecretName,
 					RedisURLSecretKey:      opts.RedisURLSecretKey,
+					WorkerRedisSecretName:  opts.WorkerRedisSecretName,
 					WorkspaceContentPath:   opts.WorkspaceContentPath,
 					WorkspaceContentScoped: opts.WorkspaceContentScoped,
 					NFSServer:              opts.NFSServer,
@@ -201,6 +205,17 @@ func buildReconcilers(opts setupOptions) []namedReconciler {
 				}).SetupWithManager(mgr)
 			},
 		},
+		{
+			Name: controllerGoldenDataset,
+			Setup: func(mgr ctrl.Manager) error {
+				return (&controller.GoldenDatasetReconciler{
+					Client:               mgr.GetClient(),
+					Scheme:               mgr.GetScheme(),
+					Recorder:             mgr.GetEventRecorderFor("goldendataset-controller"),
+					WorkspaceContentPath: opts.WorkspaceContentPath,
+				}).SetupWithManager(mgr)
+			},
+		},
 		{
 			Name: controllerKeyRotation,
 			Setup: func(mgr ctrl.Manager) error {
@@ -226,6 +241,17 @@ func buildReconcilers(opts setupOptions) []namedReconciler {
 				}).SetupWithManager(mgr)
 			},
 		},
+		{
+			Name: controllerPreviewRuntime,
+			Setup: func(mgr ctrl.Manager) error {
+				return (&controller.PreviewRuntimeReconciler{
+					Client:           mgr.GetClient(),
+					Scheme:           mgr.GetScheme(),
+					Recorder:         mgr.GetEventRecorderFor("previewruntime-controller"),
+					LicenseValidator: opts.LicenseValidator,
+				}).SetupWithManager(mgr)
+			},
+		},
 	}
 }
 
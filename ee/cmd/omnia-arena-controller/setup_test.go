@@ -29,7 +29,7 @@ import (
 	"github.com/altairalabs/omnia/ee/pkg/metrics"
 )
 
-// expectedReconcilers is the canonical 6-reconciler set the binary
+// expectedReconcilers is the canonical 7-reconciler set the binary
 // must register. Each entry corresponds to one SetupWithManager call
 // that was previously inline in main(). A regression that removes a
 // reconciler from buildReconcilers fails this test.
@@ -41,12 +41,14 @@ var expectedReconcilers = []string{
 	controllerArenaTemplateSource,
 	controllerArenaJob,
 	controllerArenaDevSession,
+	controllerGoldenDataset,
 	controllerKeyRotation,
 	controllerPromptPackSource,
+	controllerPreviewRuntime,
 }
 
 // TestBuildReconcilers_RegistersAllExpected asserts that buildReconcilers
-// produces the six reconciler entries the binary must register. This is
+// produces the eight reconciler entries the binary must register. This is
 // the wiring contract: a removed entry here means production silently
 // stops reconciling its CRD. setupOptions can be zero-valued because
 // buildReconcilers doesn't dereference the options at construction —
@@ -31,13 +31,17 @@ import (
 	eelicense "github.com/altairalabs/omnia/ee/pkg/license"
 	"github.com/altairalabs/omnia/ee/pkg/policy"
 	"github.com/altairalabs/omnia/pkg/logging"
+	"github.com/altairalabs/omnia/pkg/profiling"
 )
 
 const (
 	defaultListenAddr = ":8090"
 	defaultHealthAddr = ":8091"
-	decisionPath      = "/v1/decision"
-	shutdownTimeout   = 5 * time.Second
+	// defaultPprofAddr sits beside the facade (6060) and runtime (6061),
+	// which share the agent pod's network namespace with the broker.
+	defaultPprofAddr = "localhost:6062"
+	decisionPath     = "/v1/decision"
+	shutdownTimeout  = 5 * time.Second
 
 	envListenAddr = "POLICY_BROKER_LISTEN_ADDR"
 	envHealthAddr = "POLICY_BROKER_HEALTH_ADDR"
@@ -52,8 +56,32 @@ const (
 	// endpoint. When set and the license is not valid, the broker logs a
 	// startup reminder. Never blocks.
 	envOperatorAPIURL = "OPERATOR_API_URL"
+	// envOPADecisionLogs set to "console" writes a decision log event, in
+	// OPA's decision log format, to stdout for every decision an OPA-engine
+	// ToolPolicy makes.
+	envOPADecisionLogs     = "POLICY_BROKER_OPA_DECISION_LOGS"
+	opaDecisionLogsConsole = "console"
 )
 
+// newOPADecisionLogger returns the OPA decision logger envOPADecisionLogs
+// selects, or nil when it is unset. Events are labelled with the pod name
+// (as OPA labels them with its instance ID), agent and namespace.
+func newOPADecisionLogger(agentName, namespace string) (*policy.OPADecisionLogger, error) {
+	switch mode := os.Getenv(envOPADecisionLogs); mode {
+	case "":
+		return nil, nil
+	case opaDecisionLogsConsole:
+		id, _ := os.Hostname()
+		return policy.NewOPADecisionLogger(os.Stdout, map[string]string{
+			"id":        id,
+			"agent":     agentName,
+			"namespace": namespace,
+		}), nil
+	default:
+		return nil, fmt.Errorf("%s=%q: only %q is supported", envOPADecisionLogs, mode, opaDecisionLogsConsole)
+	}
+}
+
 // nagLicenseAtStartup fetches the operator license once and logs a reminder when
 // the policy-broker sidecar runs without a valid license. The broker is
 // enterprise-only, so any non-valid license (open-core, absent, or expired)
@@ -112,6 +140,13 @@ func run(logger logr.Logger) error {
 	if err != nil {
 		return fmt.Errorf("failed to create evaluator: %w", err)
 	}
+	opaLog, err := newOPADecisionLogger(agentName, namespace)
+	if err != nil {
+		return err
+	}
+	if opaLog != nil {
+		evaluator.SetOPADecisionLogger(opaLog)
+	}
 
 	k8sClient, scheme, err := createK8sClient()
 	if err != nil {
@@ -144,6 +179,16 @@ func run(logger logr.Logger) error {
 	// License-awareness nag (#1682): remind if enterprise runs unlicensed.
 	nagLicenseAtStartup(ctx, logger)
 
+	stopProfiling, err := profiling.Start(profiling.Options{
+		ServiceName: "omnia-policy-broker",
+		PprofAddr:   defaultPprofAddr,
+		Tags:        map[string]string{"agent": agentName},
+	})
+	if err != nil {
+		logger.Error(err, "profiling failed to start")
+	}
+	defer func() { _ = stopProfiling(context.Background()) }()
+
 	go func() {
 		if watchErr := watcher.Start(ctx); watchErr != nil && !errors.Is(watchErr, context.Canceled) {
 			logger.Error(watchErr, "watcher error")
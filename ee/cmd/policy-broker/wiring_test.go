@@ -94,3 +94,21 @@ func TestDefaultListenAddr_NotProxyPort(t *testing.T) {
 		t.Error("defaultListenAddr must not reuse the retired policy-proxy port :8082")
 	}
 }
+
+// TestNewOPADecisionLogger covers the POLICY_BROKER_OPA_DECISION_LOGS values:
+// unset disables OPA decision logs, "console" enables them, and anything
+// else is a startup error rather than a silently dropped audit trail.
+func TestNewOPADecisionLogger(t *testing.T) {
+	t.Setenv(envOPADecisionLogs, "")
+	if l, err := newOPADecisionLogger("agent", "ns"); l != nil || err != nil {
+		t.Errorf("unset: got (%v, %v), want (nil, nil)", l, err)
+	}
+	t.Setenv(envOPADecisionLogs, opaDecisionLogsConsole)
+	if l, err := newOPADecisionLogger("agent", "ns"); l == nil || err != nil {
+		t.Errorf("console: got (%v, %v), want a logger", l, err)
+	}
+	t.Setenv(envOPADecisionLogs, "http://collector")
+	if _, err := newOPADecisionLogger("agent", "ns"); err == nil {
+		t.Error("unsupported value: want an error")
+	}
+}
@@ -35,9 +35,12 @@ import (
 	"github.com/altairalabs/omnia/ee/cmd/privacy-api/migrations"
 	eelicense "github.com/altairalabs/omnia/ee/pkg/license"
 	"github.com/altairalabs/omnia/ee/pkg/privacy"
+	"github.com/altairalabs/omnia/ee/pkg/redaction"
 	"github.com/altairalabs/omnia/internal/serviceauth"
 	"github.com/altairalabs/omnia/internal/tracing"
 	"github.com/altairalabs/omnia/pkg/logging"
+	"github.com/altairalabs/omnia/pkg/profiling"
+	"github.com/altairalabs/omnia/pkg/readiness"
 	"github.com/altairalabs/omnia/pkg/servicediscovery"
 
 	"go.opentelemetry.io/otel"
@@ -286,6 +289,16 @@ func run() error {
 		}
 	}
 
+	// --- Profiling (pprof on an internal port; Pyroscope push opt-in) ---
+	stopProfiling, err := profiling.Start(profiling.Options{
+		ServiceName: "omnia-privacy-api",
+		PprofAddr:   profiling.DefaultPprofAddr,
+	})
+	if err != nil {
+		log.Error(err, "profiling failed to start")
+	}
+	defer func() { _ = stopProfiling(context.Background()) }()
+
 	// --- Postgres pool ---
 	pool, err := initPool(ctx, f.postgresConn)
 	if err != nil {
@@ -384,7 +397,8 @@ func run() error {
 	apiHandler := buildHandler(reviewer, allowedSubjects, allowedNamespaces, apiMux)
 
 	// --- Servers ---
-	healthSrv := newHealthServer(f.healthAddr, pool)
+	healthSrv := newHealthServer(f.healthAddr,
+		readiness.NewChecker(readiness.Critical("postgres", pool.Ping)))
 	metricsSrv := newMetricsServer(f.metricsAddr)
 	apiSrv := &http.Server{
 		Addr:         f.apiAddr,
@@ -434,6 +448,7 @@ func registerRoutes(
 	privacy.NewConsentHandler(concrete, nil, log).WithConsentNotifier(notifier).RegisterRoutes(mux)
 	privacy.NewConsentStatsHandler(concrete, log).RegisterRoutes(mux)
 	privacy.NewEnforcementStatsHandler(concrete, log).RegisterRoutes(mux)
+	privacy.NewPolicySimulationHandler(redaction.NewRedactor(), log).RegisterRoutes(mux)
 	// Audit hub (#1673): ingest enforcement events forwarded by memory/session-api.
 	privacy.NewAuditIngestHandler(auditStore, log).RegisterRoutes(mux)
 	// DSAR lifecycle (#1676): privacy-api owns deletion-request[s]; nil when the
@@ -749,20 +764,12 @@ func newMetricsServer(addr string) *http.Server {
 }
 
 // newHealthServer creates an HTTP server for health and readiness probes.
-func newHealthServer(addr string, pool *pgxpool.Pool) *http.Server {
+func newHealthServer(addr string, ready http.Handler) *http.Server {
 	healthMux := http.NewServeMux()
 	healthMux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
-	healthMux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
-		if err := pool.Ping(r.Context()); err != nil {
-			w.WriteHeader(http.StatusServiceUnavailable)
-			_, _ = w.Write([]byte("postgres unavailable"))
-			return
-		}
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
-	})
+	healthMux.Handle("GET /readyz", ready)
 	return &http.Server{Addr: addr, Handler: healthMux}
 }
@@ -45,6 +45,7 @@ func TestRegisterRoutes_AllMounted(t *testing.T) {
 		{http.MethodGet, "/api/v1/privacy/consent/stats"},
 		{http.MethodGet, "/api/v1/privacy/enforcement-stats"},
 		{http.MethodPost, "/api/v1/privacy/audit-events"},
+		{http.MethodPost, "/api/v1/privacy/policies/simulate"},
 	} {
 		req := httptest.NewRequest(tc.method, tc.path, nil)
 		_, pattern := mux.Handler(req)
@@ -21,24 +21,37 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
 	"github.com/altairalabs/omnia/ee/cmd/promptkit-lsp/server"
 )
 
-// parseFlagsIntoConfig parses the four binary-level flags into a
-// server.Config. Extracted from main() so a wiring test can assert
-// each flag flows into the correct Config field with the documented
-// default. The fs argument lets tests supply a fresh FlagSet without
-// touching the global default.
-func parseFlagsIntoConfig(fs *flag.FlagSet, args []string) server.Config {
+// flags holds the binary-level settings that are not part of server.Config.
+type flags struct {
+	clusterCatalog bool
+}
+
+// parseFlagsIntoConfig parses the binary-level flags into a
+// server.Config plus the flags that only affect wiring. Extracted from
+// main() so a wiring test can assert each flag flows into the correct
+// field with the documented default. The fs argument lets tests supply
+// a fresh FlagSet without touching the global default.
+func parseFlagsIntoConfig(fs *flag.FlagSet, args []string) (server.Config, flags) {
 	var cfg server.Config
+	var f flags
 	fs.StringVar(&cfg.Addr, "addr", ":8080", "Address for HTTP/WebSocket server")
 	fs.StringVar(&cfg.HealthAddr, "health-addr", ":8081", "Address for health probes")
 	fs.StringVar(&cfg.DashboardAPIURL, "dashboard-api-url",
 		"http://omnia-dashboard:3000", "Dashboard API base URL for file access")
 	fs.BoolVar(&cfg.DevMode, "dev-mode", false, "Enable development mode (disables license validation)")
+	fs.BoolVar(&f.clusterCatalog, "cluster-catalog", true,
+		"Complete tools, providers and PromptPack variables from the cluster")
 	_ = fs.Parse(args)
-	return cfg
+	return cfg, f
 }
 
 // setupServer is the binary-level wiring contract: parse args into a
@@ -47,11 +60,37 @@ func parseFlagsIntoConfig(fs *flag.FlagSet, args []string) server.Config {
 // Config field and (b) the resulting Config builds a non-nil server,
 // without spinning up a real listener or signal loop.
 func setupServer(args []string, log logr.Logger) (*server.Server, server.Config, error) {
-	cfg := parseFlagsIntoConfig(flag.NewFlagSet("promptkit-lsp", flag.ContinueOnError), args)
+	cfg, f := parseFlagsIntoConfig(flag.NewFlagSet("promptkit-lsp", flag.ContinueOnError), args)
+	if f.clusterCatalog {
+		if c, ok := newCatalogClient(log); ok {
+			cfg.Catalog = server.NewClusterCatalog(c, log)
+		}
+	}
 	srv, err := server.New(cfg, log)
 	return srv, cfg, err
 }
 
+// newCatalogClient builds a controller-runtime client for reading the
+// workspace catalog. Outside a cluster (local dev, tests) there is no
+// config, so the server runs without cluster completions rather than
+// failing to start.
+func newCatalogClient(log logr.Logger) (client.Client, bool) {
+	restCfg, err := ctrl.GetConfig()
+	if err != nil {
+		log.Info("cluster catalog disabled", "reason", "no K8s config", "error", err.Error())
+		return nil, false
+	}
+	scheme := k8sruntime.NewScheme()
+	_ = omniav1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	c, err := client.New(restCfg, client.Options{Scheme: scheme})
+	if err != nil {
+		log.Info("cluster catalog disabled", "reason", "K8s client error", "error", err.Error())
+		return nil, false
+	}
+	return c, true
+}
+
 func main() {
 	// Setup logger
 	zapLog, err := zap.NewProduction()
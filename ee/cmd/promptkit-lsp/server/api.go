@@ -226,28 +226,5 @@ func (s *Server) validateFileOnly(_ context.Context, doc *Document) []Diagnostic
 
 // fetchFileContent fetches a file's content from the dashboard API.
 func (s *Server) fetchFileContent(ctx context.Context, workspace, project, path string) (string, error) {
-	url := s.config.DashboardAPIURL + "/api/workspaces/" + workspace + "/arena/projects/" + project + "/files/" + path
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := s.validator.httpClient.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", err
-	}
-
-	var result struct {
-		Content string `json:"content"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
-	}
-
-	return result.Content, nil
+	return s.validator.fetchFileContent(ctx, workspace, project, path)
 }
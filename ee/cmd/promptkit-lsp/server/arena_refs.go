@@ -0,0 +1,250 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileKindSuffixes maps PromptArena file naming conventions to the kind whose
+// schema applies, so a half-written scenario is checked before its kind line
+// has been typed.
+var fileKindSuffixes = map[string]string{
+	".arena.yaml":    "Arena",
+	".scenario.yaml": "Scenario",
+	".provider.yaml": "Provider",
+	".tool.yaml":     "Tool",
+	".persona.yaml":  "Persona",
+	".eval.yaml":     "Eval",
+}
+
+// kindFromFileName returns the kind implied by a document's file name, or ""
+// when the name follows no convention.
+func kindFromFileName(uri string) string {
+	name := uri
+	if strings.HasSuffix(name, ".yml") {
+		name = strings.TrimSuffix(name, ".yml") + ".yaml"
+	}
+	for suffix, kind := range fileKindSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return kind
+		}
+	}
+	return ""
+}
+
+// projectPath returns a document's path relative to the project root. The
+// dashboard opens files as promptkit://<workspace>/<project>/<path>, while
+// /api/compile passes the project path itself.
+func projectPath(uri string) string {
+	if rest, ok := strings.CutPrefix(uri, "promptkit://"); ok {
+		if parts := strings.SplitN(rest, "/", 3); len(parts) == 3 {
+			return parts[2]
+		}
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(uri, "file://"), "/")
+}
+
+// projectHasFile reports whether ref names a project file, either from the
+// project root or relative to the referencing document, which is how
+// PromptArena resolves paths in an arena config.
+func projectHasFile(fileSet map[string]bool, docURI, ref string) bool {
+	return resolveProjectFile(fileSet, docURI, ref) != ""
+}
+
+// resolveProjectFile returns the project path ref names, or "" when it names
+// no project file. See projectHasFile for the resolution rules.
+func resolveProjectFile(fileSet map[string]bool, docURI, ref string) string {
+	for _, candidate := range []string{ref, path.Clean(ref), path.Join(path.Dir(projectPath(docURI)), ref)} {
+		if fileSet[candidate] {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// mappingValue returns the value node for key in a YAML mapping node.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// sequenceItems returns the items of a YAML sequence node.
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return node.Content
+}
+
+// parseDocumentNode parses content and returns its top-level mapping.
+func parseDocumentNode(content string) *yaml.Node {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil || len(root.Content) == 0 {
+		return nil
+	}
+	return root.Content[0]
+}
+
+// arenaScenarioLines returns the 0-indexed lines holding spec.scenarios[].file
+// in an arena config, or nil for any other document.
+func arenaScenarioLines(doc *Document) map[int]bool {
+	top := parseDocumentNode(doc.Content)
+	if kind := mappingValue(top, "kind"); kind == nil || kind.Value != "Arena" {
+		return nil
+	}
+	lines := make(map[int]bool)
+	for _, item := range sequenceItems(mappingValue(mappingValue(top, "spec"), "scenarios")) {
+		if file := mappingValue(item, "file"); file != nil {
+			lines[file.Line-1] = true
+		}
+	}
+	return lines
+}
+
+// judgeRef is a judge name used by an llm_judge assertion.
+type judgeRef struct {
+	name string
+	node *yaml.Node
+}
+
+// scenarioJudgeRefs collects the judges named by llm_judge assertions in a
+// scenario's turns and conversation assertions.
+func scenarioJudgeRefs(spec *yaml.Node) []judgeRef {
+	assertions := sequenceItems(mappingValue(spec, "conversation_assertions"))
+	for _, turn := range sequenceItems(mappingValue(spec, "turns")) {
+		assertions = append(assertions, sequenceItems(mappingValue(turn, "assertions"))...)
+	}
+
+	var refs []judgeRef
+	for _, a := range assertions {
+		typ := mappingValue(a, "type")
+		if typ == nil || !strings.HasPrefix(typ.Value, "llm_judge") {
+			continue
+		}
+		if judge := mappingValue(mappingValue(a, "params"), "judge"); judge != nil && judge.Value != "" {
+			refs = append(refs, judgeRef{name: judge.Value, node: judge})
+		}
+	}
+	return refs
+}
+
+// isArenaConfigFile reports whether a project file is an arena config.
+func isArenaConfigFile(file string) bool {
+	return kindFromFileName(file) == "Arena"
+}
+
+// validateJudgeReferences flags llm_judge assertions in a scenario that name a
+// judge no arena config in the project defines. PromptArena only resolves the
+// judge when the ArenaJob runs, so without this a typo costs a whole job.
+func (v *Validator) validateJudgeReferences(
+	ctx context.Context, doc *Document, workspace, projectID string,
+) []Diagnostic {
+	top := parseDocumentNode(doc.Content)
+	if kind := mappingValue(top, "kind"); kind == nil || kind.Value != "Scenario" {
+		return nil
+	}
+	refs := scenarioJudgeRefs(mappingValue(top, "spec"))
+	if len(refs) == 0 {
+		return nil
+	}
+
+	judges, err := v.getProjectJudges(ctx, workspace, projectID)
+	if err != nil {
+		v.log.V(1).Info("failed to load arena judges, skipping judge validation", "error", err.Error())
+		return nil
+	}
+	if len(judges) == 0 {
+		// No arena config declares judges; the default judge applies.
+		return nil
+	}
+
+	known := make(map[string]bool, len(judges))
+	for _, name := range judges {
+		known[name] = true
+	}
+
+	var diagnostics []Diagnostic
+	for _, ref := range refs {
+		if known[ref.name] {
+			continue
+		}
+		line, col := ref.node.Line-1, ref.node.Column-1
+		diagnostics = append(diagnostics, Diagnostic{
+			Range: Range{
+				Start: Position{Line: line, Character: col},
+				End:   Position{Line: line, Character: col + len(ref.name)},
+			},
+			Severity: SeverityError,
+			Source:   "cross-reference",
+			Message: fmt.Sprintf("unknown judge '%s'; arena config defines: %s",
+				ref.name, strings.Join(judges, ", ")),
+		})
+	}
+	return diagnostics
+}
+
+// getProjectJudges returns the judge names declared by the project's arena
+// configs, sorted and de-duplicated.
+func (v *Validator) getProjectJudges(ctx context.Context, workspace, projectID string) ([]string, error) {
+	cacheKey := fmt.Sprintf("%s/%s", workspace, projectID)
+
+	v.fileCacheMu.RLock()
+	cached, ok := v.judgeCache[cacheKey]
+	v.fileCacheMu.RUnlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.files, nil
+	}
+
+	files, err := v.getProjectFiles(ctx, workspace, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var judges []string
+	for _, file := range files {
+		if !isArenaConfigFile(file) {
+			continue
+		}
+		content, err := v.fetchFileContent(ctx, workspace, projectID, strings.TrimPrefix(file, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", file, err)
+		}
+		spec := mappingValue(parseDocumentNode(content), "spec")
+		for _, judge := range sequenceItems(mappingValue(spec, "judges")) {
+			if name := mappingValue(judge, "name"); name != nil && !seen[name.Value] {
+				seen[name.Value] = true
+				judges = append(judges, name.Value)
+			}
+		}
+	}
+	sort.Strings(judges)
+
+	v.fileCacheMu.Lock()
+	v.judgeCache[cacheKey] = &cachedFiles{files: judges, expiresAt: time.Now().Add(v.cacheTTL)}
+	v.fileCacheMu.Unlock()
+
+	return judges, nil
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+const testArenaConfig = `apiVersion: promptkit.altairalabs.ai/v1alpha1
+kind: Arena
+spec:
+  providers:
+    - file: providers/gone.yaml
+  judges:
+    - name: strict
+      provider: openai
+    - name: lenient
+      provider: openai
+  scenarios:
+    - file: scenarios/ok.scenario.yaml
+    - file: ./scenarios/ok.scenario.yaml
+    - file: scenarios/missing.scenario.yaml
+  defaults: {}
+`
+
+// newProjectServer serves a project's file list and file contents the way
+// the dashboard API does, counting content fetches.
+func newProjectServer(t *testing.T, files map[string]string, fetches *int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == testFilesPath {
+			names := make([]string, 0, len(files))
+			for name := range files {
+				names = append(names, name)
+			}
+			_ = json.NewEncoder(w).Encode(map[string][]string{"files": names})
+			return
+		}
+		content, ok := files[strings.TrimPrefix(r.URL.Path, testFilesPath+"/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if fetches != nil {
+			atomic.AddInt32(fetches, 1)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"content": content})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestKindFromFileName(t *testing.T) {
+	tests := map[string]string{
+		"promptkit://ws/proj/scenarios/greet.scenario.yaml": "Scenario",
+		"scenarios/greet.scenario.yml":                      "Scenario",
+		"config.arena.yaml":                                 "Arena",
+		"providers/openai.provider.yaml":                    "Provider",
+		"tools/search.yaml":                                 "",
+	}
+	for uri, want := range tests {
+		if got := kindFromFileName(uri); got != want {
+			t.Errorf("kindFromFileName(%q) = %q, want %q", uri, got, want)
+		}
+	}
+}
+
+func TestProjectHasFile(t *testing.T) {
+	fileSet := map[string]bool{"scenarios/a.scenario.yaml": true, "suite/scenarios/b.scenario.yaml": true}
+
+	tests := []struct {
+		uri, ref string
+		want     bool
+	}{
+		{"promptkit://ws/proj/config.arena.yaml", "scenarios/a.scenario.yaml", true},
+		{"promptkit://ws/proj/config.arena.yaml", "./scenarios/a.scenario.yaml", true},
+		{"promptkit://ws/proj/suite/config.arena.yaml", "scenarios/b.scenario.yaml", true},
+		{"suite/config.arena.yaml", "./scenarios/b.scenario.yaml", true},
+		{"promptkit://ws/proj/config.arena.yaml", "scenarios/b.scenario.yaml", false},
+	}
+	for _, tc := range tests {
+		if got := projectHasFile(fileSet, tc.uri, tc.ref); got != tc.want {
+			t.Errorf("projectHasFile(%q, %q) = %v, want %v", tc.uri, tc.ref, got, tc.want)
+		}
+	}
+}
+
+func TestValidateJSONSchema_KindFromFileName(t *testing.T) {
+	v, _ := NewValidator("http://localhost:3000", logr.Discard())
+	content := "spec:\n  id: greet\n  bogus: true\n"
+	doc := &Document{
+		URI:     "promptkit://ws/proj/scenarios/greet.scenario.yaml",
+		Content: content,
+		Lines:   splitLines(content),
+	}
+
+	diags := v.validateJSONSchema(doc, map[string]any{"spec": map[string]any{"id": "greet", "bogus": true}})
+
+	var missingKind, schemaErrs int
+	for _, d := range diags {
+		switch {
+		case d.Message == "missing or invalid 'kind' field":
+			missingKind++
+		case strings.Contains(d.Message, "kind is required"):
+			t.Errorf("missing kind reported twice: %q", d.Message)
+		default:
+			schemaErrs++
+		}
+	}
+	if missingKind != 1 {
+		t.Errorf("expected one missing-kind diagnostic, got %d", missingKind)
+	}
+	if schemaErrs == 0 {
+		t.Error("expected Scenario schema violations for a *.scenario.yaml without a kind")
+	}
+}
+
+func TestValidateJSONSchema_KindMismatchesFileName(t *testing.T) {
+	v, _ := NewValidator("http://localhost:3000", logr.Discard())
+	content := "kind: Tool\nspec:\n  name: t\n"
+	doc := &Document{URI: "scenarios/greet.scenario.yaml", Content: content, Lines: splitLines(content)}
+
+	diags := v.validateJSONSchema(doc, map[string]any{"kind": "Tool", "spec": map[string]any{"name": "t"}})
+
+	for _, d := range diags {
+		if d.Message == "file name implies kind 'Scenario' but kind is 'Tool'" {
+			if d.Severity != SeverityWarning {
+				t.Errorf("expected warning severity, got %d", d.Severity)
+			}
+			return
+		}
+	}
+	t.Errorf("expected kind mismatch warning, got %+v", diags)
+}
+
+func TestValidateCrossReferences_ArenaScenarioFiles(t *testing.T) {
+	srv := newProjectServer(t, map[string]string{
+		"config.arena.yaml":          testArenaConfig,
+		"scenarios/ok.scenario.yaml": "kind: Scenario",
+	}, nil)
+	v, _ := NewValidator(srv.URL, logr.Discard())
+	doc := &Document{
+		URI:     "promptkit://ws1/proj1/config.arena.yaml",
+		Content: testArenaConfig,
+		Lines:   splitLines(testArenaConfig),
+	}
+
+	diags := v.validateCrossReferences(context.Background(), doc, "ws1", "proj1")
+
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %+v", len(diags), diags)
+	}
+	if diags[0].Severity != SeverityWarning || diags[0].Range.Start.Line != 4 {
+		t.Errorf("expected warning for missing provider on line 4, got %+v", diags[0])
+	}
+	want := "scenario file 'scenarios/missing.scenario.yaml' referenced by arena config not found in project"
+	if diags[1].Severity != SeverityError || diags[1].Message != want || diags[1].Range.Start.Line != 13 {
+		t.Errorf("expected error for missing scenario on line 13, got %+v", diags[1])
+	}
+}
+
+func TestValidateJudgeReferences(t *testing.T) {
+	var fetches int32
+	srv := newProjectServer(t, map[string]string{
+		"config.arena.yaml":             testArenaConfig,
+		"scenarios/greet.scenario.yaml": "",
+	}, &fetches)
+	v, _ := NewValidator(srv.URL, logr.Discard())
+
+	content := `kind: Scenario
+spec:
+  id: greet
+  turns:
+    - role: user
+      content: Hi
+      assertions:
+        - type: llm_judge
+          params:
+            judge: strcit
+        - type: llm_judge
+          params:
+            judge: strict
+        - type: contains
+          params:
+            judge: ignored
+  conversation_assertions:
+    - type: llm_judge_conversation
+      params:
+        judge: lenient
+`
+	doc := &Document{URI: "scenarios/greet.scenario.yaml", Content: content, Lines: splitLines(content)}
+
+	diags := v.validateJudgeReferences(context.Background(), doc, "ws1", "proj1")
+
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(diags), diags)
+	}
+	d := diags[0]
+	if d.Severity != SeverityError || d.Message != "unknown judge 'strcit'; arena config defines: lenient, strict" {
+		t.Errorf("unexpected diagnostic: %+v", d)
+	}
+	if d.Range.Start != (Position{Line: 9, Character: 19}) || d.Range.End.Character != 25 {
+		t.Errorf("unexpected range: %+v", d.Range)
+	}
+
+	_ = v.validateJudgeReferences(context.Background(), doc, "ws1", "proj1")
+	if got := atomic.LoadInt32(&fetches); got != 1 {
+		t.Errorf("expected arena config fetched once within the cache TTL, got %d", got)
+	}
+}
+
+func TestValidateJudgeReferences_NoArenaJudges(t *testing.T) {
+	srv := newProjectServer(t, map[string]string{
+		"config.arena.yaml": "kind: Arena\nspec:\n  defaults: {}\n",
+	}, nil)
+	v, _ := NewValidator(srv.URL, logr.Discard())
+	content := "kind: Scenario\nspec:\n  conversation_assertions:\n" +
+		"    - type: llm_judge\n      params:\n        judge: any\n"
+	doc := &Document{URI: "s.scenario.yaml", Content: content, Lines: splitLines(content)}
+
+	if diags := v.validateJudgeReferences(context.Background(), doc, "ws1", "proj1"); len(diags) != 0 {
+		t.Errorf("expected no diagnostics without declared judges, got %+v", diags)
+	}
+}
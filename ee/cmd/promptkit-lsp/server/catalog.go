@@ -0,0 +1,259 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/promptpack"
+	"github.com/altairalabs/omnia/internal/promptpack/packselect"
+)
+
+// catalogTTL bounds how stale cluster completions can be. Tool discovery and
+// pack rollouts are minutes-scale, so a short cache keeps typing responsive
+// without hammering the API server on every keystroke.
+const catalogTTL = 30 * time.Second
+
+// CatalogTool is a tool discovered by a ToolRegistry in the workspace.
+type CatalogTool struct {
+	Name        string
+	Registry    string
+	Handler     string
+	Description string
+}
+
+// CatalogProvider is a Provider CRD in the workspace.
+type CatalogProvider struct {
+	Name  string
+	Type  string
+	Role  string
+	Model string
+}
+
+// CatalogVariable is a template variable declared by a PromptPack prompt.
+type CatalogVariable struct {
+	Name        string
+	Type        string
+	Required    bool
+	Description string
+	Pack        string
+	Prompt      string
+}
+
+// CatalogSnapshot is the set of cluster resources offered as completions for
+// one workspace.
+type CatalogSnapshot struct {
+	Tools     []CatalogTool
+	Providers []CatalogProvider
+	Variables []CatalogVariable
+}
+
+// Catalog supplies the tools, providers and PromptPack variables registered
+// for a workspace, so scenario and pack files can complete against what the
+// cluster will actually resolve at run time.
+type Catalog interface {
+	Snapshot(ctx context.Context, workspace string) (*CatalogSnapshot, error)
+}
+
+// ClusterCatalog reads the catalog from the Kubernetes API using the LSP
+// pod's service account.
+type ClusterCatalog struct {
+	client   client.Client
+	resolver *promptpack.Resolver
+	log      logr.Logger
+
+	mu    sync.Mutex
+	cache map[string]*catalogEntry
+}
+
+type catalogEntry struct {
+	snapshot  *CatalogSnapshot
+	expiresAt time.Time
+}
+
+// NewClusterCatalog creates a ClusterCatalog backed by c.
+func NewClusterCatalog(c client.Client, log logr.Logger) *ClusterCatalog {
+	return &ClusterCatalog{
+		client:   c,
+		resolver: promptpack.NewResolver(c),
+		log:      log.WithName("catalog"),
+		cache:    make(map[string]*catalogEntry),
+	}
+}
+
+// Snapshot returns the cached catalog for workspace, refreshing it from the
+// cluster once the TTL has elapsed.
+func (cc *ClusterCatalog) Snapshot(ctx context.Context, workspace string) (*CatalogSnapshot, error) {
+	cc.mu.Lock()
+	entry, ok := cc.cache[workspace]
+	cc.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.snapshot, nil
+	}
+
+	snapshot, err := cc.load(ctx, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	cc.mu.Lock()
+	cc.cache[workspace] = &catalogEntry{snapshot: snapshot, expiresAt: time.Now().Add(catalogTTL)}
+	cc.mu.Unlock()
+	return snapshot, nil
+}
+
+// load resolves the workspace namespace and lists its resources.
+func (cc *ClusterCatalog) load(ctx context.Context, workspace string) (*CatalogSnapshot, error) {
+	var ws omniav1alpha1.Workspace
+	if err := cc.client.Get(ctx, client.ObjectKey{Name: workspace}, &ws); err != nil {
+		return nil, fmt.Errorf("get workspace %s: %w", workspace, err)
+	}
+	namespace := ws.Spec.Namespace.Name
+
+	tools, err := cc.listTools(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	providers, err := cc.listProviders(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	variables, err := cc.listVariables(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &CatalogSnapshot{Tools: tools, Providers: providers, Variables: variables}, nil
+}
+
+func (cc *ClusterCatalog) listTools(ctx context.Context, namespace string) ([]CatalogTool, error) {
+	var list omniav1alpha1.ToolRegistryList
+	if err := cc.client.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("list ToolRegistries in %s: %w", namespace, err)
+	}
+	var tools []CatalogTool
+	for _, reg := range list.Items {
+		for _, t := range reg.Status.DiscoveredTools {
+			tools = append(tools, CatalogTool{
+				Name:        t.Name,
+				Registry:    reg.Name,
+				Handler:     t.HandlerName,
+				Description: t.Description,
+			})
+		}
+	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	return tools, nil
+}
+
+func (cc *ClusterCatalog) listProviders(ctx context.Context, namespace string) ([]CatalogProvider, error) {
+	var list omniav1alpha1.ProviderList
+	if err := cc.client.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("list Providers in %s: %w", namespace, err)
+	}
+	providers := make([]CatalogProvider, 0, len(list.Items))
+	for _, p := range list.Items {
+		providers = append(providers, CatalogProvider{
+			Name:  p.Name,
+			Type:  string(p.Spec.Type),
+			Role:  string(p.Spec.Role),
+			Model: p.Spec.Model,
+		})
+	}
+	sort.Slice(providers, func(i, j int) bool { return providers[i].Name < providers[j].Name })
+	return providers, nil
+}
+
+// packVariables is the subset of pack.json needed to list template variables.
+type packVariables struct {
+	Prompts map[string]struct {
+		Variables []struct {
+			Name        string `json:"name"`
+			Type        string `json:"type"`
+			Required    bool   `json:"required"`
+			Description string `json:"description"`
+		} `json:"variables"`
+	} `json:"prompts"`
+}
+
+// listVariables loads the stable version of every pack in namespace and
+// collects the variables its prompts declare. A pack that fails to load is
+// skipped so one broken ConfigMap does not blank out every completion.
+func (cc *ClusterCatalog) listVariables(ctx context.Context, namespace string) ([]CatalogVariable, error) {
+	var list omniav1alpha1.PromptPackList
+	if err := cc.client.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("list PromptPacks in %s: %w", namespace, err)
+	}
+
+	packNames := make(map[string]struct{})
+	for _, pp := range list.Items {
+		if name := pp.Labels[packselect.Label]; name != "" {
+			packNames[name] = struct{}{}
+		}
+	}
+
+	var variables []CatalogVariable
+	for packName := range packNames {
+		data, err := cc.resolver.Load(ctx, namespace, packName, "")
+		if err != nil {
+			cc.log.V(1).Info("skipping pack for completion", "pack", packName, "error", err.Error())
+			continue
+		}
+		var pack packVariables
+		if err := json.Unmarshal(data, &pack); err != nil {
+			cc.log.V(1).Info("skipping unparseable pack", "pack", packName, "error", err.Error())
+			continue
+		}
+		for promptName, prompt := range pack.Prompts {
+			for _, v := range prompt.Variables {
+				variables = append(variables, CatalogVariable{
+					Name:        v.Name,
+					Type:        v.Type,
+					Required:    v.Required,
+					Description: v.Description,
+					Pack:        packName,
+					Prompt:      promptName,
+				})
+			}
+		}
+	}
+	sort.Slice(variables, func(i, j int) bool {
+		if variables[i].Name != variables[j].Name {
+			return variables[i].Name < variables[j].Name
+		}
+		if variables[i].Pack != variables[j].Pack {
+			return variables[i].Pack < variables[j].Pack
+		}
+		return variables[i].Prompt < variables[j].Prompt
+	})
+	return variables, nil
+}
+
+// catalogSnapshot returns the workspace catalog, or nil when no catalog is
+// configured or the cluster lookup fails. Completion and hover degrade to
+// project-file results in that case rather than erroring.
+func (s *Server) catalogSnapshot(ctx context.Context, workspace string) *CatalogSnapshot {
+	if s.config.Catalog == nil || workspace == "" {
+		return nil
+	}
+	snapshot, err := s.config.Catalog.Snapshot(ctx, workspace)
+	if err != nil {
+		s.log.V(1).Info("failed to load cluster catalog", "workspace", workspace, "error", err.Error())
+		return nil
+	}
+	return snapshot
+}
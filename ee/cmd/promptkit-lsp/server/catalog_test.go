@@ -0,0 +1,247 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/promptpack/packselect"
+)
+
+const testPackJSON = `{
+  "id": "support",
+  "prompts": {
+    "triage": {"variables": [
+      {"name": "customer_name", "type": "string", "required": true, "description": "Who is asking"},
+      {"name": "tier", "type": "string", "required": false}
+    ]},
+    "escalate": {"variables": [
+      {"name": "customer_name", "type": "string", "required": true}
+    ]}
+  }
+}`
+
+func catalogObjects() []client.Object {
+	return []client.Object{
+		&omniav1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: "acme"},
+			Spec: omniav1alpha1.WorkspaceSpec{
+				Namespace: omniav1alpha1.NamespaceConfig{Name: "acme-ns"},
+			},
+		},
+		&omniav1alpha1.ToolRegistry{
+			ObjectMeta: metav1.ObjectMeta{Name: "crm", Namespace: "acme-ns"},
+			Status: omniav1alpha1.ToolRegistryStatus{
+				DiscoveredTools: []omniav1alpha1.DiscoveredTool{
+					{Name: "lookup_order", HandlerName: "orders", Description: "Find an order by ID"},
+					{Name: "create_ticket", HandlerName: "tickets"},
+				},
+			},
+		},
+		&omniav1alpha1.ToolRegistry{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other-ns"},
+			Status: omniav1alpha1.ToolRegistryStatus{
+				DiscoveredTools: []omniav1alpha1.DiscoveredTool{{Name: "not_mine"}},
+			},
+		},
+		&omniav1alpha1.Provider{
+			ObjectMeta: metav1.ObjectMeta{Name: "claude", Namespace: "acme-ns"},
+			Spec: omniav1alpha1.ProviderSpec{
+				Type:  omniav1alpha1.ProviderTypeClaude,
+				Role:  omniav1alpha1.ProviderRoleLLM,
+				Model: "claude-sonnet-4-20250514",
+			},
+		},
+		&omniav1alpha1.PromptPack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pp-1234",
+				Namespace: "acme-ns",
+				Labels:    map[string]string{packselect.Label: "support"},
+			},
+			Spec: omniav1alpha1.PromptPackSpec{
+				PackName: "support",
+				Version:  "1.0.0",
+				Source: omniav1alpha1.PromptPackContentSource{
+					Type:         omniav1alpha1.PromptPackSourceTypeConfigMap,
+					ConfigMapRef: &corev1.LocalObjectReference{Name: "support-pack"},
+				},
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "support-pack", Namespace: "acme-ns"},
+			Data:       map[string]string{"pack.json": testPackJSON},
+		},
+		// A pack whose ConfigMap is missing is skipped, not fatal.
+		&omniav1alpha1.PromptPack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pp-5678",
+				Namespace: "acme-ns",
+				Labels:    map[string]string{packselect.Label: "broken"},
+			},
+			Spec: omniav1alpha1.PromptPackSpec{
+				PackName: "broken",
+				Version:  "1.0.0",
+				Source: omniav1alpha1.PromptPackContentSource{
+					Type:         omniav1alpha1.PromptPackSourceTypeConfigMap,
+					ConfigMapRef: &corev1.LocalObjectReference{Name: "missing"},
+				},
+			},
+		},
+	}
+}
+
+func newTestCatalog(t *testing.T) *ClusterCatalog {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, omniav1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(catalogObjects()...).Build()
+	return NewClusterCatalog(c, logr.Discard())
+}
+
+func TestClusterCatalogSnapshot(t *testing.T) {
+	cat := newTestCatalog(t)
+
+	snapshot, err := cat.Snapshot(context.Background(), "acme")
+	require.NoError(t, err)
+
+	assert.Equal(t, []CatalogTool{
+		{Name: "create_ticket", Registry: "crm", Handler: "tickets"},
+		{Name: "lookup_order", Registry: "crm", Handler: "orders", Description: "Find an order by ID"},
+	}, snapshot.Tools)
+	assert.Equal(t, []CatalogProvider{
+		{Name: "claude", Type: "claude", Role: "llm", Model: "claude-sonnet-4-20250514"},
+	}, snapshot.Providers)
+	assert.Equal(t, []CatalogVariable{
+		{Name: "customer_name", Type: "string", Required: true, Pack: "support", Prompt: "escalate"},
+		{Name: "customer_name", Type: "string", Required: true, Description: "Who is asking",
+			Pack: "support", Prompt: "triage"},
+		{Name: "tier", Type: "string", Pack: "support", Prompt: "triage"},
+	}, snapshot.Variables)
+
+	cached, err := cat.Snapshot(context.Background(), "acme")
+	require.NoError(t, err)
+	assert.Same(t, snapshot, cached, "snapshot is served from cache within the TTL")
+}
+
+func TestClusterCatalogSnapshot_UnknownWorkspace(t *testing.T) {
+	_, err := newTestCatalog(t).Snapshot(context.Background(), "nope")
+	assert.Error(t, err)
+}
+
+// stubCatalog returns a fixed snapshot or error.
+type stubCatalog struct {
+	snapshot *CatalogSnapshot
+	err      error
+}
+
+func (s stubCatalog) Snapshot(context.Context, string) (*CatalogSnapshot, error) {
+	return s.snapshot, s.err
+}
+
+func newCatalogServer(t *testing.T, cat Catalog) *Server {
+	t.Helper()
+	s, err := New(Config{
+		Addr:            ":8080",
+		DashboardAPIURL: "http://127.0.0.1:1",
+		Catalog:         cat,
+	}, logr.Discard())
+	require.NoError(t, err)
+	return s
+}
+
+func testSnapshot() *CatalogSnapshot {
+	return &CatalogSnapshot{
+		Tools:     []CatalogTool{{Name: "lookup_order", Registry: "crm", Description: "Find an order by ID"}},
+		Providers: []CatalogProvider{{Name: "claude", Type: "claude", Model: "claude-sonnet-4-20250514"}},
+		Variables: []CatalogVariable{
+			{Name: "customer_name", Type: "string", Required: true, Pack: "support", Prompt: "escalate"},
+			{Name: "customer_name", Type: "string", Required: true, Pack: "support", Prompt: "triage"},
+			{Name: "tier", Type: "string", Pack: "support", Prompt: "triage"},
+		},
+	}
+}
+
+func TestCatalogCompletions(t *testing.T) {
+	s := newCatalogServer(t, stubCatalog{snapshot: testSnapshot()})
+	ctx := context.Background()
+
+	tools := s.getToolRefCompletions(ctx, "acme", "proj")
+	require.Len(t, tools, 1)
+	assert.Equal(t, "lookup_order", tools[0].Label)
+	assert.Equal(t, "Tool from ToolRegistry crm", tools[0].Detail)
+	assert.Contains(t, tools[0].Documentation.(MarkupContent).Value, "Find an order by ID")
+
+	providers := s.getProviderRefCompletions(ctx, "acme", "proj")
+	require.Len(t, providers, 1)
+	assert.Equal(t, "claude", providers[0].Label)
+	assert.Contains(t, providers[0].Documentation.(MarkupContent).Value, "claude-sonnet-4-20250514")
+
+	vars := s.getVariableCompletions(ctx, "acme")
+	require.Len(t, vars, 2, "a variable declared by two prompts is offered once")
+	assert.Equal(t, "customer_name", vars[0].Label)
+	assert.Equal(t, CompletionItemKindVariable, vars[0].Kind)
+	doc := vars[0].Documentation.(MarkupContent).Value
+	assert.Contains(t, doc, "support/escalate")
+	assert.Contains(t, doc, "support/triage")
+}
+
+func TestCatalogCompletions_Unavailable(t *testing.T) {
+	ctx := context.Background()
+	for name, cat := range map[string]Catalog{
+		"no catalog":    nil,
+		"catalog error": stubCatalog{err: errors.New("forbidden")},
+	} {
+		s := newCatalogServer(t, cat)
+		assert.Empty(t, s.getToolRefCompletions(ctx, "acme", "proj"), name)
+		assert.Empty(t, s.getVariableCompletions(ctx, "acme"), name)
+	}
+}
+
+func TestInTemplateVariable(t *testing.T) {
+	assert.True(t, inTemplateVariable("content: Hello {{"))
+	assert.True(t, inTemplateVariable("content: Hello {{cust"))
+	assert.False(t, inTemplateVariable("content: Hello {{name}} and"))
+	assert.False(t, inTemplateVariable("content: Hello"))
+}
+
+func TestGetCatalogHover(t *testing.T) {
+	s := newCatalogServer(t, stubCatalog{snapshot: testSnapshot()})
+	ctx := context.Background()
+	doc := NewDocumentStore().Open("file:///scenarios/a.yaml", "yaml", 1,
+		"  - tool: lookup_order\n    provider: claude\n    content: Hi {{customer_name}}\n    tool: unknown")
+
+	hover := s.getCatalogHover(ctx, "acme", doc, Position{Line: 0, Character: 14})
+	require.NotNil(t, hover)
+	assert.Contains(t, hover.Contents.Value, "**Tool** `lookup_order`")
+	assert.Equal(t, &Range{Start: Position{Character: 10}, End: Position{Character: 22}}, hover.Range)
+
+	hover = s.getCatalogHover(ctx, "acme", doc, Position{Line: 1, Character: 16})
+	require.NotNil(t, hover)
+	assert.Contains(t, hover.Contents.Value, "**Provider** `claude`")
+
+	hover = s.getCatalogHover(ctx, "acme", doc, Position{Line: 2, Character: 22})
+	require.NotNil(t, hover)
+	assert.Contains(t, hover.Contents.Value, "**Variable** `customer_name`")
+
+	assert.Nil(t, s.getCatalogHover(ctx, "acme", doc, Position{Line: 3, Character: 12}), "unknown tool")
+	assert.Nil(t, s.getCatalogHover(ctx, "acme", doc, Position{Line: 2, Character: 8}), "plain text")
+}
@@ -39,6 +39,8 @@ func (s *Server) handleCompletion(ctx context.Context, c *Connection, msg *Messa
 
 	// Determine completion context
 	switch {
+	case inTemplateVariable(prefix):
+		items = s.getVariableCompletions(ctx, c.workspace)
 	case prefix == "" || prefix == "-":
 		// At start of document or list item - suggest top-level fields
 		items = s.getTopLevelCompletions()
@@ -229,14 +231,80 @@ func (s *Server) getProviderTypeCompletions() []CompletionItem {
 	return items
 }
 
-// getToolRefCompletions returns completions for tool references.
+// getToolRefCompletions returns completions for tool references: tools
+// defined in the project plus tools discovered by the workspace's
+// ToolRegistries.
 func (s *Server) getToolRefCompletions(ctx context.Context, workspace, projectID string) []CompletionItem {
-	return s.getRefCompletions(ctx, workspace, projectID, "tools", "Tool")
+	items := s.getRefCompletions(ctx, workspace, projectID, "tools", "Tool")
+	snapshot := s.catalogSnapshot(ctx, workspace)
+	if snapshot == nil {
+		return items
+	}
+	for _, t := range snapshot.Tools {
+		items = appendUniqueItem(items, CompletionItem{
+			Label:         t.Name,
+			Kind:          CompletionItemKindReference,
+			Detail:        "Tool from ToolRegistry " + t.Registry,
+			Documentation: MarkupContent{Kind: MarkupKindMarkdown, Value: toolMarkdown(t)},
+		})
+	}
+	return items
 }
 
-// getProviderRefCompletions returns completions for provider references.
+// getProviderRefCompletions returns completions for provider references:
+// providers defined in the project plus the workspace's Provider CRDs.
 func (s *Server) getProviderRefCompletions(ctx context.Context, workspace, projectID string) []CompletionItem {
-	return s.getRefCompletions(ctx, workspace, projectID, "providers", "Provider")
+	items := s.getRefCompletions(ctx, workspace, projectID, "providers", "Provider")
+	snapshot := s.catalogSnapshot(ctx, workspace)
+	if snapshot == nil {
+		return items
+	}
+	for _, p := range snapshot.Providers {
+		items = appendUniqueItem(items, CompletionItem{
+			Label:         p.Name,
+			Kind:          CompletionItemKindReference,
+			Detail:        "Provider (" + p.Type + ")",
+			Documentation: MarkupContent{Kind: MarkupKindMarkdown, Value: providerMarkdown(p)},
+		})
+	}
+	return items
+}
+
+// getVariableCompletions returns the template variables declared by the
+// workspace's PromptPacks. A name declared by several prompts is offered
+// once, documented with every declaration.
+func (s *Server) getVariableCompletions(ctx context.Context, workspace string) []CompletionItem {
+	snapshot := s.catalogSnapshot(ctx, workspace)
+	if snapshot == nil {
+		return nil
+	}
+	var items []CompletionItem
+	for _, decls := range groupVariables(snapshot.Variables) {
+		items = append(items, CompletionItem{
+			Label:         decls[0].Name,
+			Kind:          CompletionItemKindVariable,
+			Detail:        "Variable (" + decls[0].Type + ")",
+			Documentation: MarkupContent{Kind: MarkupKindMarkdown, Value: variableMarkdown(decls)},
+		})
+	}
+	return items
+}
+
+// inTemplateVariable reports whether prefix ends inside an unclosed "{{".
+func inTemplateVariable(prefix string) bool {
+	idx := strings.LastIndex(prefix, "{{")
+	return idx != -1 && !strings.Contains(prefix[idx:], "}}")
+}
+
+// appendUniqueItem appends item unless an item with the same label exists,
+// so a tool defined both in the project and in the cluster is listed once.
+func appendUniqueItem(items []CompletionItem, item CompletionItem) []CompletionItem {
+	for _, existing := range items {
+		if existing.Label == item.Label {
+			return items
+		}
+	}
+	return append(items, item)
 }
 
 // getPromptRefCompletions returns completions for prompt references.
@@ -48,7 +48,20 @@ func (s *Server) handleDefinition(ctx context.Context, c *Connection, msg *Messa
 		return
 	}
 
-	// Find reference at position
+	// A file: or path: value opens the file it names
+	if location := s.findFileReferenceLocation(ctx, c.workspace, c.projectID, doc, params.Position); location != nil {
+		s.sendResponse(c, msg.ID, location)
+		return
+	}
+
+	// Scenario IDs, provider IDs, tools and variables resolve to their
+	// declarations anywhere in the project
+	if locations := s.findSymbolDefinitions(ctx, c.workspace, c.projectID, doc, params.Position); len(locations) > 0 {
+		s.sendResponse(c, msg.ID, locations)
+		return
+	}
+
+	// Fall back to the conventional <dir>/<name>.yaml layout
 	refType, refName := s.findReferenceAtPosition(doc, params.Position)
 	if refType == "" || refName == "" {
 		s.sendResponse(c, msg.ID, nil)
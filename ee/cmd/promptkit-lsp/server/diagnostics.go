@@ -59,10 +59,14 @@ type Validator struct {
 	schemaLoaders   map[string]gojsonschema.JSONLoader
 	refPatterns     []*regexp.Regexp
 
-	// Cache for project files
-	fileCache   map[string]*cachedFiles
-	fileCacheMu sync.RWMutex
-	cacheTTL    time.Duration
+	// Cache for project files, for the judge names their arena configs
+	// declare (keyed the same way), and for file contents read while
+	// navigating (keyed by workspace/project/path)
+	fileCache    map[string]*cachedFiles
+	judgeCache   map[string]*cachedFiles
+	contentCache map[string]*cachedContent
+	fileCacheMu  sync.RWMutex
+	cacheTTL     time.Duration
 }
 
 type cachedFiles struct {
@@ -70,6 +74,11 @@ type cachedFiles struct {
 	expiresAt time.Time
 }
 
+type cachedContent struct {
+	content   string
+	expiresAt time.Time
+}
+
 // NewValidator creates a new Validator.
 func NewValidator(dashboardAPIURL string, log logr.Logger) (*Validator, error) {
 	// Load all embedded schemas keyed by kind
@@ -98,6 +107,8 @@ func NewValidator(dashboardAPIURL string, log logr.Logger) (*Validator, error) {
 		schemaLoaders: schemaLoaders,
 		refPatterns:   refPatterns,
 		fileCache:     make(map[string]*cachedFiles),
+		judgeCache:    make(map[string]*cachedFiles),
+		contentCache:  make(map[string]*cachedContent),
 		cacheTTL:      30 * time.Second,
 	}, nil
 }
@@ -133,6 +144,10 @@ func (v *Validator) ValidateDocument(ctx context.Context, doc *Document, workspa
 	semanticDiags := v.validateSemantics(doc, parsed)
 	diagnostics = append(diagnostics, semanticDiags...)
 
+	// 5. Judge references against the project's arena configs
+	judgeDiags := v.validateJudgeReferences(ctx, doc, workspace, projectID)
+	diagnostics = append(diagnostics, judgeDiags...)
+
 	return diagnostics
 }
 
@@ -189,7 +204,9 @@ func extractYAMLPosition(errStr string) *Position {
 func (v *Validator) validateJSONSchema(doc *Document, parsed map[string]any) []Diagnostic {
 	var diagnostics []Diagnostic
 
-	// Get the kind to select the appropriate schema
+	// Get the kind to select the appropriate schema, falling back to the
+	// kind implied by the file name (e.g. *.scenario.yaml)
+	fileKind := kindFromFileName(doc.URI)
 	kind, ok := parsed["kind"].(string)
 	if !ok {
 		diagnostics = append(diagnostics, Diagnostic{
@@ -201,7 +218,21 @@ func (v *Validator) validateJSONSchema(doc *Document, parsed map[string]any) []D
 			Source:   "schema",
 			Message:  "missing or invalid 'kind' field",
 		})
-		return diagnostics
+		if fileKind == "" {
+			return diagnostics
+		}
+		kind = fileKind
+	} else if fileKind != "" && fileKind != kind {
+		line, col := v.findFieldPosition(doc, "kind")
+		diagnostics = append(diagnostics, Diagnostic{
+			Range: Range{
+				Start: Position{Line: line, Character: col},
+				End:   Position{Line: line, Character: col + 20},
+			},
+			Severity: SeverityWarning,
+			Source:   "schema",
+			Message:  fmt.Sprintf("file name implies kind '%s' but kind is '%s'", fileKind, kind),
+		})
 	}
 
 	// Get the schema loader for this kind
@@ -236,6 +267,10 @@ func (v *Validator) validateJSONSchema(doc *Document, parsed map[string]any) []D
 
 	if !result.Valid() {
 		for _, schemaErr := range result.Errors() {
+			// A missing kind was already reported above
+			if schemaErr.Type() == "required" && schemaErr.Details()["property"] == "kind" {
+				continue
+			}
 			// Try to find the line for this error
 			line, col := v.findFieldPosition(doc, schemaErr.Field())
 
@@ -301,6 +336,10 @@ func (v *Validator) validateCrossReferences(
 		fileSet[strings.TrimPrefix(file, "/")] = true
 	}
 
+	// Scenario files listed by an arena config fail the ArenaJob outright,
+	// so a missing one is an error rather than a warning
+	scenarioLines := arenaScenarioLines(doc)
+
 	// Check file references in the document
 	for i, line := range doc.Lines {
 		for _, pattern := range v.refPatterns {
@@ -310,16 +349,22 @@ func (v *Validator) validateCrossReferences(
 				// Remove quotes if present
 				refPath = strings.Trim(refPath, `"'`)
 
-				if refPath != "" && !fileSet[refPath] {
+				if refPath != "" && !projectHasFile(fileSet, doc.URI, refPath) {
+					severity := SeverityWarning
+					message := fmt.Sprintf("file '%s' not found in project", refPath)
+					if scenarioLines[i] {
+						severity = SeverityError
+						message = fmt.Sprintf("scenario file '%s' referenced by arena config not found in project", refPath)
+					}
 					col := strings.Index(line, refPath)
 					diagnostics = append(diagnostics, Diagnostic{
 						Range: Range{
 							Start: Position{Line: i, Character: col},
 							End:   Position{Line: i, Character: col + len(refPath)},
 						},
-						Severity: SeverityWarning,
+						Severity: severity,
 						Source:   "cross-reference",
-						Message:  fmt.Sprintf("file '%s' not found in project", refPath),
+						Message:  message,
 					})
 				}
 			}
@@ -378,6 +423,60 @@ func (v *Validator) getProjectFiles(ctx context.Context, workspace, projectID st
 	return result.Files, nil
 }
 
+// getFileContent returns a file's content, served from cache within the TTL.
+// Use fetchFileContent where a stale read would be wrong, such as rename.
+func (v *Validator) getFileContent(ctx context.Context, workspace, projectID, filePath string) (string, error) {
+	cacheKey := fmt.Sprintf("%s/%s/%s", workspace, projectID, filePath)
+
+	v.fileCacheMu.RLock()
+	cached, ok := v.contentCache[cacheKey]
+	v.fileCacheMu.RUnlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.content, nil
+	}
+
+	content, err := v.fetchFileContent(ctx, workspace, projectID, filePath)
+	if err != nil {
+		return "", err
+	}
+
+	v.fileCacheMu.Lock()
+	v.contentCache[cacheKey] = &cachedContent{content: content, expiresAt: time.Now().Add(v.cacheTTL)}
+	v.fileCacheMu.Unlock()
+
+	return content, nil
+}
+
+// fetchFileContent fetches a file's content from the dashboard API.
+func (v *Validator) fetchFileContent(ctx context.Context, workspace, projectID, filePath string) (string, error) {
+	url := fmt.Sprintf("%s/api/workspaces/%s/arena/projects/%s/files/%s",
+		v.dashboardAPIURL, workspace, projectID, filePath)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch file: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("API error: %s - %s", resp.Status, string(body))
+	}
+
+	var result struct {
+		Content string `json:"content"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Content, nil
+}
+
 // validateSemantics performs PromptKit-specific semantic validation.
 func (v *Validator) validateSemantics(doc *Document, parsed map[string]any) []Diagnostic {
 	var diagnostics []Diagnostic
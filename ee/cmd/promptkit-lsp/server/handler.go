@@ -36,7 +36,9 @@ func (s *Server) handleInitialize(_ context.Context, c *Connection, msg *Message
 			},
 			HoverProvider:          true,
 			DefinitionProvider:     true,
+			ReferencesProvider:     true,
 			SemanticTokensProvider: GetSemanticTokensOptions(),
+			RenameProvider:         &RenameOptions{PrepareProvider: true},
 		},
 		ServerInfo: &ServerInfo{
 			Name:    "promptkit-lsp",
@@ -132,7 +132,7 @@ var fieldDocumentation = map[string]struct {
 }
 
 // handleHover handles the textDocument/hover request.
-func (s *Server) handleHover(_ context.Context, c *Connection, msg *Message) {
+func (s *Server) handleHover(ctx context.Context, c *Connection, msg *Message) {
 	var params HoverParams
 	if err := json.Unmarshal(msg.Params, &params); err != nil {
 		s.sendError(c, msg.ID, -32700, "Invalid params", err.Error())
@@ -148,6 +148,12 @@ func (s *Server) handleHover(_ context.Context, c *Connection, msg *Message) {
 	// Get the word at the cursor position
 	word := s.getFieldNameAtPosition(doc, params.Position)
 	if word == "" {
+		// Values of tool/provider fields and {{variables}} resolve against
+		// the cluster catalog.
+		if hover := s.getCatalogHover(ctx, c.workspace, doc, params.Position); hover != nil {
+			s.sendResponse(c, msg.ID, hover)
+			return
+		}
 		s.sendResponse(c, msg.ID, nil)
 		return
 	}
@@ -290,3 +296,145 @@ func (s *Server) getWordRange(doc *Document, pos Position, word string) *Range {
 		End:   Position{Line: pos.Line, Character: idx + len(word)},
 	}
 }
+
+// getCatalogHover provides hover info for a tool or provider reference, or a
+// template variable, by looking the word under the cursor up in the cluster
+// catalog.
+func (s *Server) getCatalogHover(ctx context.Context, workspace string, doc *Document, pos Position) *Hover {
+	if pos.Line >= len(doc.Lines) {
+		return nil
+	}
+	line := doc.Lines[pos.Line]
+	word, start, end := identifierAt(line, pos.Character)
+	if word == "" {
+		return nil
+	}
+	snapshot := s.catalogSnapshot(ctx, workspace)
+	if snapshot == nil {
+		return nil
+	}
+
+	var content string
+	if inTemplateVariable(line[:start]) {
+		for _, decls := range groupVariables(snapshot.Variables) {
+			if decls[0].Name == word {
+				content = variableMarkdown(decls)
+				break
+			}
+		}
+	} else {
+		colonIdx := strings.Index(line, ":")
+		if colonIdx == -1 || colonIdx > start {
+			return nil
+		}
+		switch strings.TrimPrefix(strings.TrimSpace(line[:colonIdx]), "- ") {
+		case "tool":
+			for _, t := range snapshot.Tools {
+				if t.Name == word {
+					content = toolMarkdown(t)
+					break
+				}
+			}
+		case "provider":
+			for _, p := range snapshot.Providers {
+				if p.Name == word {
+					content = providerMarkdown(p)
+					break
+				}
+			}
+		}
+	}
+	if content == "" {
+		return nil
+	}
+
+	return &Hover{
+		Contents: MarkupContent{
+			Kind:  MarkupKindMarkdown,
+			Value: content,
+		},
+		Range: &Range{
+			Start: Position{Line: pos.Line, Character: start},
+			End:   Position{Line: pos.Line, Character: end},
+		},
+	}
+}
+
+// identifierAt returns the name-like token around character ch in line along
+// with its start and end offsets.
+func identifierAt(line string, ch int) (string, int, int) {
+	if ch > len(line) {
+		ch = len(line)
+	}
+	isNameChar := func(b byte) bool {
+		return b == '_' || b == '-' || b == '.' ||
+			(b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+	}
+	start, end := ch, ch
+	for start > 0 && isNameChar(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isNameChar(line[end]) {
+		end++
+	}
+	return line[start:end], start, end
+}
+
+// groupVariables groups catalog variables by name. The catalog keeps
+// variables sorted by name, so each group is a contiguous run.
+func groupVariables(vars []CatalogVariable) [][]CatalogVariable {
+	var groups [][]CatalogVariable
+	for i := 0; i < len(vars); {
+		j := i + 1
+		for j < len(vars) && vars[j].Name == vars[i].Name {
+			j++
+		}
+		groups = append(groups, vars[i:j])
+		i = j
+	}
+	return groups
+}
+
+// toolMarkdown renders a discovered tool as markdown for completion and hover docs.
+func toolMarkdown(t CatalogTool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Tool** `%s`", t.Name)
+	if t.Description != "" {
+		fmt.Fprintf(&b, "\n\n%s", t.Description)
+	}
+	fmt.Fprintf(&b, "\n\nToolRegistry: `%s`", t.Registry)
+	if t.Handler != "" {
+		fmt.Fprintf(&b, ", handler: `%s`", t.Handler)
+	}
+	return b.String()
+}
+
+// providerMarkdown renders a Provider CRD for completion and hover docs.
+func providerMarkdown(p CatalogProvider) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Provider** `%s`\n\nType: `%s`", p.Name, p.Type)
+	if p.Role != "" {
+		fmt.Fprintf(&b, ", role: `%s`", p.Role)
+	}
+	if p.Model != "" {
+		fmt.Fprintf(&b, ", model: `%s`", p.Model)
+	}
+	return b.String()
+}
+
+// variableMarkdown renders every declaration of one template variable name.
+func variableMarkdown(decls []CatalogVariable) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Variable** `%s`", decls[0].Name)
+	for _, v := range decls {
+		required := "optional"
+		if v.Required {
+			required = "required"
+		}
+		fmt.Fprintf(&b, "\n\n- `%s/%s`: %s, %s", v.Pack, v.Prompt, v.Type, required)
+		if v.Description != "" {
+			fmt.Fprintf(&b, " — %s", v.Description)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// fileRefPattern matches a file: or path: value, which is how arena configs
+// pull in providers, scenarios and prompt configs and how prompt configs
+// include template fragments.
+var fileRefPattern = regexp.MustCompile(`^\s*-?\s*(?:file|path):\s*["']?([^"'\s#]+)`)
+
+// handleReferences handles the textDocument/references request.
+func (s *Server) handleReferences(ctx context.Context, c *Connection, msg *Message) {
+	var params ReferenceParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		s.sendError(c, msg.ID, -32700, "Invalid params", err.Error())
+		return
+	}
+
+	doc := s.documents.Get(params.TextDocument.URI)
+	if doc == nil {
+		s.sendResponse(c, msg.ID, nil)
+		return
+	}
+
+	sym, ok := symbolAt(doc, params.Position)
+	if !ok {
+		s.sendResponse(c, msg.ID, []Location{})
+		return
+	}
+
+	locations := s.symbolLocations(ctx, c.workspace, c.projectID, doc, sym, func(found symbol) bool {
+		return !found.decl || params.Context.IncludeDeclaration
+	})
+	s.log.V(1).Info("references",
+		"kind", sym.kind,
+		"name", sym.name,
+		"count", len(locations),
+	)
+	s.sendResponse(c, msg.ID, locations)
+}
+
+// findSymbolDefinitions returns where the symbol under the cursor is
+// declared anywhere in the project, or nil when there is no symbol there or
+// no declaration for it.
+func (s *Server) findSymbolDefinitions(
+	ctx context.Context, workspace, projectID string, doc *Document, pos Position,
+) []Location {
+	sym, ok := symbolAt(doc, pos)
+	if !ok {
+		return nil
+	}
+	return s.symbolLocations(ctx, workspace, projectID, doc, sym, func(found symbol) bool {
+		return found.decl
+	})
+}
+
+// symbolLocations returns every occurrence of sym across the project that
+// keep accepts. Navigation is best effort: files that cannot be read are
+// skipped, and reads are served from the validator's content cache.
+func (s *Server) symbolLocations(
+	ctx context.Context, workspace, projectID string, doc *Document, sym symbol, keep func(symbol) bool,
+) []Location {
+	docs, err := s.projectDocuments(ctx, workspace, projectID, doc,
+		func(ctx context.Context, workspace, projectID, path string) (string, error) {
+			content, err := s.validator.getFileContent(ctx, workspace, projectID, path)
+			if err != nil {
+				s.log.V(1).Info("skipping unreadable file", "path", path, "error", err.Error())
+			}
+			return content, nil
+		})
+	if err != nil {
+		// Without the file list only the current document can be searched
+		s.log.V(1).Info("failed to get project files", "error", err.Error())
+		docs = []projectDoc{{uri: doc.URI, content: doc.Content}}
+	}
+
+	locations := []Location{}
+	for _, pd := range docs {
+		for _, found := range findSymbols(pd.content) {
+			if found.kind == sym.kind && found.name == sym.name && keep(found) {
+				locations = append(locations, Location{URI: pd.uri, Range: found.rng})
+			}
+		}
+	}
+	return locations
+}
+
+// findFileReferenceLocation resolves a file: or path: value under the cursor
+// to the project file it names.
+func (s *Server) findFileReferenceLocation(
+	ctx context.Context, workspace, projectID string, doc *Document, pos Position,
+) *Location {
+	if pos.Line >= len(doc.Lines) {
+		return nil
+	}
+	m := fileRefPattern.FindStringSubmatchIndex(doc.Lines[pos.Line])
+	if m == nil || pos.Character < m[2] || pos.Character > m[3] {
+		return nil
+	}
+	ref := doc.Lines[pos.Line][m[2]:m[3]]
+
+	files, err := s.validator.getProjectFiles(ctx, workspace, projectID)
+	if err != nil {
+		s.log.V(1).Info("failed to get project files", "error", err.Error())
+		return nil
+	}
+	fileSet := make(map[string]bool, len(files))
+	for _, f := range files {
+		fileSet[strings.TrimPrefix(f, "/")] = true
+	}
+
+	target := resolveProjectFile(fileSet, doc.URI, ref)
+	if target == "" {
+		return nil
+	}
+	return &Location{URI: s.buildFileURI(workspace, projectID, target)}
+}
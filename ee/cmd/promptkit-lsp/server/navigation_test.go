@@ -0,0 +1,159 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	testProviderConfig = `kind: Provider
+spec:
+  id: openai-gpt4
+  type: openai
+`
+	testNavScenario = `kind: Scenario
+spec:
+  id: order-status
+  providers:
+    - openai-gpt4
+  turns:
+    - role: user
+      content: Where is my order?
+`
+	testNavArena = `kind: Arena
+spec:
+  prompt_configs:
+    - id: support
+      file: prompts/support.yaml
+  judges:
+    - name: strict
+      provider: openai-gpt4
+  self_play:
+    roles:
+      - id: user
+        persona: shopper
+        scenario: order-status
+`
+	testNavPrompt = `kind: PromptConfig
+spec:
+  task_type: support
+  fragments:
+    - name: greeting
+      path: ../fragments/greeting.yaml
+`
+)
+
+func newNavigationServer(t *testing.T, fetches *int32) (*Server, *Document) {
+	t.Helper()
+	mock := newProjectServer(t, map[string]string{
+		"config.arena.yaml":                    testNavArena,
+		"providers/openai.provider.yaml":       testProviderConfig,
+		"scenarios/order-status.scenario.yaml": testNavScenario,
+		"prompts/support.yaml":                 testNavPrompt,
+		"fragments/greeting.yaml":              "kind: Fragment\n",
+	}, fetches)
+	validator, _ := NewValidator(mock.URL, logr.Discard())
+	srv := &Server{
+		config:    Config{DashboardAPIURL: mock.URL},
+		validator: validator,
+		documents: NewDocumentStore(),
+		log:       logr.Discard(),
+	}
+	doc := srv.documents.Open("promptkit://ws1/proj1/config.arena.yaml", "yaml", 1, testNavArena)
+	return srv, doc
+}
+
+func TestFindSymbolDefinitions(t *testing.T) {
+	srv, doc := newNavigationServer(t, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		pos  Position
+		uri  string
+		line int
+	}{
+		{"scenario id", Position{Line: 12, Character: 20}, "promptkit://ws1/proj1/scenarios/order-status.scenario.yaml", 2},
+		{"provider id", Position{Line: 7, Character: 18}, "promptkit://ws1/proj1/providers/openai.provider.yaml", 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			locs := srv.findSymbolDefinitions(ctx, "ws1", "proj1", doc, tc.pos)
+			if len(locs) != 1 {
+				t.Fatalf("expected 1 definition, got %+v", locs)
+			}
+			if locs[0].URI != tc.uri || locs[0].Range.Start.Line != tc.line {
+				t.Errorf("unexpected definition %+v", locs[0])
+			}
+		})
+	}
+
+	if locs := srv.findSymbolDefinitions(ctx, "ws1", "proj1", doc, Position{Line: 11, Character: 18}); locs != nil {
+		t.Errorf("expected no definition for a persona, got %+v", locs)
+	}
+}
+
+func TestSymbolLocations_References(t *testing.T) {
+	srv, doc := newNavigationServer(t, nil)
+	sym := symbol{kind: symbolProvider, name: "openai-gpt4"}
+
+	refs := srv.symbolLocations(context.Background(), "ws1", "proj1", doc, sym, func(found symbol) bool {
+		return !found.decl
+	})
+	if len(refs) != 2 {
+		t.Fatalf("expected 2 references, got %+v", refs)
+	}
+	if refs[0].URI != doc.URI || refs[0].Range.Start.Line != 7 {
+		t.Errorf("expected the open arena config first, got %+v", refs[0])
+	}
+
+	all := srv.symbolLocations(context.Background(), "ws1", "proj1", doc, sym, func(symbol) bool { return true })
+	if len(all) != 3 {
+		t.Errorf("expected references plus the declaration, got %+v", all)
+	}
+}
+
+func TestSymbolLocations_CachesContent(t *testing.T) {
+	var fetches int32
+	srv, doc := newNavigationServer(t, &fetches)
+	sym := symbol{kind: symbolScenario, name: "order-status"}
+	keep := func(symbol) bool { return true }
+
+	_ = srv.symbolLocations(context.Background(), "ws1", "proj1", doc, sym, keep)
+	first := atomic.LoadInt32(&fetches)
+	_ = srv.symbolLocations(context.Background(), "ws1", "proj1", doc, sym, keep)
+	if got := atomic.LoadInt32(&fetches); got != first {
+		t.Errorf("expected file contents served from cache, fetched %d then %d", first, got)
+	}
+}
+
+func TestFindFileReferenceLocation(t *testing.T) {
+	srv, arena := newNavigationServer(t, nil)
+	prompt := srv.documents.Open("promptkit://ws1/proj1/prompts/support.yaml", "yaml", 1, testNavPrompt)
+	ctx := context.Background()
+
+	loc := srv.findFileReferenceLocation(ctx, "ws1", "proj1", arena, Position{Line: 4, Character: 15})
+	if loc == nil || loc.URI != "promptkit://ws1/proj1/prompts/support.yaml" {
+		t.Errorf("expected arena file ref to resolve to the prompt config, got %+v", loc)
+	}
+
+	loc = srv.findFileReferenceLocation(ctx, "ws1", "proj1", prompt, Position{Line: 5, Character: 20})
+	if loc == nil || loc.URI != "promptkit://ws1/proj1/fragments/greeting.yaml" {
+		t.Errorf("expected fragment path to resolve relative to the prompt config, got %+v", loc)
+	}
+
+	if loc := srv.findFileReferenceLocation(ctx, "ws1", "proj1", arena, Position{Line: 4, Character: 4}); loc != nil {
+		t.Errorf("expected no location with the cursor on the key, got %+v", loc)
+	}
+}
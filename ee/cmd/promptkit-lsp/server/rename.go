@@ -0,0 +1,325 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// symbolKind is the kind of a renameable PromptKit name.
+type symbolKind string
+
+const (
+	symbolVariable symbolKind = "variable"
+	symbolScenario symbolKind = "scenario"
+	symbolTool     symbolKind = "tool"
+	symbolProvider symbolKind = "provider"
+)
+
+// symbol is one occurrence of a renameable name. decl marks the occurrence
+// that defines the name (a tool's spec.name, a scenario's spec.id, ...).
+type symbol struct {
+	kind symbolKind
+	name string
+	rng  Range
+	decl bool
+}
+
+// templateVarPattern matches a {{variable}} reference in a template.
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// validSymbolNames constrains the new name for each kind. Variables follow
+// the PromptPack schema; tool, scenario and provider names must stay
+// YAML-plain.
+var validSymbolNames = map[symbolKind]*regexp.Regexp{
+	symbolVariable: regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`),
+	symbolScenario: regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`),
+	symbolTool:     regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`),
+	symbolProvider: regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`),
+}
+
+// toolListKeys are keys whose list items are tool names.
+var toolListKeys = map[string]bool{"tools": true, "allowed_tools": true, "blocklist": true}
+
+// toolChoiceModes are tool_choice values that are modes, not tool names.
+var toolChoiceModes = map[string]bool{"auto": true, "none": true, "required": true, "any": true}
+
+// handlePrepareRename handles the textDocument/prepareRename request.
+func (s *Server) handlePrepareRename(_ context.Context, c *Connection, msg *Message) {
+	var params PrepareRenameParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		s.sendError(c, msg.ID, -32700, "Invalid params", err.Error())
+		return
+	}
+
+	doc := s.documents.Get(params.TextDocument.URI)
+	if doc == nil {
+		s.sendResponse(c, msg.ID, nil)
+		return
+	}
+
+	sym, ok := symbolAt(doc, params.Position)
+	if !ok {
+		s.sendResponse(c, msg.ID, nil)
+		return
+	}
+
+	s.sendResponse(c, msg.ID, PrepareRenameResult{Range: sym.rng, Placeholder: sym.name})
+}
+
+// handleRename handles the textDocument/rename request.
+func (s *Server) handleRename(ctx context.Context, c *Connection, msg *Message) {
+	var params RenameParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		s.sendError(c, msg.ID, -32700, "Invalid params", err.Error())
+		return
+	}
+
+	doc := s.documents.Get(params.TextDocument.URI)
+	if doc == nil {
+		s.sendResponse(c, msg.ID, nil)
+		return
+	}
+
+	sym, ok := symbolAt(doc, params.Position)
+	if !ok {
+		// -32803 is RequestFailed in the LSP spec
+		s.sendError(c, msg.ID, -32803, "No renameable symbol at position", nil)
+		return
+	}
+	if !validSymbolNames[sym.kind].MatchString(params.NewName) {
+		s.sendError(c, msg.ID, -32602, "Invalid params",
+			fmt.Sprintf("'%s' is not a valid %s name", params.NewName, sym.kind))
+		return
+	}
+
+	edit, err := s.buildRenameEdit(ctx, c.workspace, c.projectID, doc, sym, params.NewName)
+	if err != nil {
+		s.log.Error(err, "rename failed", "kind", sym.kind, "name", sym.name)
+		s.sendError(c, msg.ID, -32803, "Rename failed", err.Error())
+		return
+	}
+
+	s.log.V(1).Info("rename",
+		"kind", sym.kind,
+		"from", sym.name,
+		"to", params.NewName,
+		"files", len(edit.Changes),
+	)
+	s.sendResponse(c, msg.ID, edit)
+}
+
+// buildRenameEdit renames sym in doc and in every YAML file of the project.
+// Files that are not open are read through the dashboard API; if any of them
+// cannot be read the rename is refused rather than applied partially.
+func (s *Server) buildRenameEdit(
+	ctx context.Context, workspace, projectID string, doc *Document, sym symbol, newName string,
+) (*WorkspaceEdit, error) {
+	docs, err := s.projectDocuments(ctx, workspace, projectID, doc, s.validator.fetchFileContent)
+	if err != nil {
+		return nil, err
+	}
+
+	edit := &WorkspaceEdit{Changes: make(map[string][]TextEdit)}
+	for _, pd := range docs {
+		if edits := renameEdits(pd.content, sym, newName); len(edits) > 0 {
+			edit.Changes[pd.uri] = edits
+		}
+	}
+	return edit, nil
+}
+
+// projectDoc is a project file's URI and current content.
+type projectDoc struct {
+	uri     string
+	content string
+}
+
+// projectDocuments returns doc followed by every other YAML file in the
+// project. Open documents contribute their unsaved editor content; the rest
+// are read with fetch, and the first read error is returned.
+func (s *Server) projectDocuments(
+	ctx context.Context, workspace, projectID string, doc *Document,
+	fetch func(ctx context.Context, workspace, projectID, path string) (string, error),
+) ([]projectDoc, error) {
+	files, err := s.validator.getProjectFiles(ctx, workspace, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project files: %w", err)
+	}
+
+	docs := []projectDoc{{uri: doc.URI, content: doc.Content}}
+	docPath := projectPath(doc.URI)
+	for _, file := range files {
+		file = strings.TrimPrefix(file, "/")
+		if file == docPath || !isYAMLFile(file) {
+			continue
+		}
+		uri := s.buildFileURI(workspace, projectID, file)
+		if open := s.documents.Get(uri); open != nil {
+			docs = append(docs, projectDoc{uri: uri, content: open.Content})
+			continue
+		}
+		content, err := fetch(ctx, workspace, projectID, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		docs = append(docs, projectDoc{uri: uri, content: content})
+	}
+	return docs, nil
+}
+
+// isYAMLFile reports whether a project file is YAML.
+func isYAMLFile(file string) bool {
+	return strings.HasSuffix(file, ".yaml") || strings.HasSuffix(file, ".yml")
+}
+
+// renameEdits returns the edits that rename every occurrence of sym in content.
+func renameEdits(content string, sym symbol, newName string) []TextEdit {
+	var edits []TextEdit
+	for _, found := range findSymbols(content) {
+		if found.kind == sym.kind && found.name == sym.name {
+			edits = append(edits, TextEdit{Range: found.rng, NewText: newName})
+		}
+	}
+	return edits
+}
+
+// symbolAt returns the renameable symbol under the cursor.
+func symbolAt(doc *Document, pos Position) (symbol, bool) {
+	for _, sym := range findSymbols(doc.Content) {
+		r := sym.rng
+		if r.Start.Line == pos.Line && pos.Character >= r.Start.Character && pos.Character <= r.End.Character {
+			return sym, true
+		}
+	}
+	return symbol{}, false
+}
+
+// findSymbols returns every renameable name in content: {{variable}}
+// references in any text, plus the YAML scalars that declare or reference a
+// variable, scenario, tool or provider.
+func findSymbols(content string) []symbol {
+	var symbols []symbol
+	for i, line := range splitLines(content) {
+		for _, m := range templateVarPattern.FindAllStringSubmatchIndex(line, -1) {
+			symbols = append(symbols, symbol{
+				kind: symbolVariable,
+				name: line[m[2]:m[3]],
+				rng:  Range{Start: Position{Line: i, Character: m[2]}, End: Position{Line: i, Character: m[3]}},
+			})
+		}
+	}
+
+	top := parseDocumentNode(content)
+	if top == nil {
+		return symbols
+	}
+	docKind := ""
+	if kind := mappingValue(top, "kind"); kind != nil {
+		docKind = kind.Value
+	}
+	walkScalars(top, nil, func(n *yaml.Node, path []string, isKey bool) {
+		if kind, decl := classifyScalar(docKind, n.Value, path, isKey); kind != "" {
+			symbols = append(symbols, symbol{kind: kind, name: n.Value, rng: scalarRange(n), decl: decl})
+		}
+	})
+	return symbols
+}
+
+// walkScalars calls fn for every scalar under node with the path of keys
+// leading to it ("[]" marks a sequence item). Mapping keys are reported with
+// the path of the mapping that holds them.
+func walkScalars(node *yaml.Node, path []string, fn func(n *yaml.Node, path []string, isKey bool)) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			fn(key, path, true)
+			walkScalars(node.Content[i+1], append(path[:len(path):len(path)], key.Value), fn)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			walkScalars(item, append(path[:len(path):len(path)], "[]"), fn)
+		}
+	case yaml.ScalarNode:
+		fn(node, path, false)
+	}
+}
+
+// classifyScalar decides whether a scalar at path names a variable, scenario,
+// tool or provider, and whether it is that name's declaration. It returns an
+// empty kind for any other scalar.
+func classifyScalar(docKind, value string, path []string, isKey bool) (symbolKind, bool) {
+	if len(path) == 0 || value == "" {
+		return "", false
+	}
+	last := path[len(path)-1]
+	if isKey {
+		// Keys of a vars/variables mapping supply values for template variables
+		if last == "vars" || last == "variables" {
+			return symbolVariable, false
+		}
+		return "", false
+	}
+
+	switch {
+	case last == "tool":
+		return symbolTool, false
+	case last == "tool_choice" && !toolChoiceModes[value]:
+		return symbolTool, false
+	case last == "[]" && len(path) >= 2 && toolListKeys[path[len(path)-2]]:
+		return symbolTool, false
+	case docKind == "Tool" && pathIs(path, "spec", "name"):
+		return symbolTool, true
+	case last == "scenario":
+		return symbolScenario, false
+	case docKind == "Scenario" && pathIs(path, "spec", "id"):
+		return symbolScenario, true
+	case last == "provider":
+		return symbolProvider, false
+	case last == "[]" && len(path) >= 2 && path[len(path)-2] == "providers":
+		return symbolProvider, false
+	case docKind == "Provider" && pathIs(path, "spec", "id"):
+		return symbolProvider, true
+	case last == "name" && len(path) >= 3 && path[len(path)-2] == "[]" && path[len(path)-3] == "variables":
+		return symbolVariable, true
+	}
+	return "", false
+}
+
+// pathIs reports whether path equals want.
+func pathIs(path []string, want ...string) bool {
+	if len(path) != len(want) {
+		return false
+	}
+	for i := range path {
+		if path[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// scalarRange returns the range of a scalar's value, excluding any quotes.
+func scalarRange(n *yaml.Node) Range {
+	line, col := n.Line-1, n.Column-1
+	if n.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		col++
+	}
+	return Range{
+		Start: Position{Line: line, Character: col},
+		End:   Position{Line: line, Character: col + len(n.Value)},
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	testPromptConfig = `kind: PromptConfig
+spec:
+  task_type: support
+  system_template: |
+    Help {{customer_name}} with their order.
+    Greet {{ customer_name }} by name.
+  variables:
+    - name: customer_name
+      required: true
+  allowed_tools:
+    - lookup_order
+`
+	testToolConfig = `kind: Tool
+spec:
+  name: "lookup_order"
+  description: Find an order
+`
+	testScenarioConfig = `kind: Scenario
+spec:
+  id: order-status
+  tool_policy:
+    tool_choice: lookup_order
+    blocklist: [refund]
+  turns:
+    - role: user
+      content: Where is my order?
+`
+	testArenaVars = `kind: Arena
+spec:
+  prompt_configs:
+    - file: prompts/support.yaml
+      vars:
+        customer_name: Ada
+`
+)
+
+func TestFindSymbols(t *testing.T) {
+	type found struct {
+		kind symbolKind
+		name string
+		line int
+		char int
+	}
+	tests := []struct {
+		name    string
+		content string
+		want    []found
+	}{
+		{"prompt config", testPromptConfig, []found{
+			{symbolVariable, "customer_name", 4, 11},
+			{symbolVariable, "customer_name", 5, 13},
+			{symbolVariable, "customer_name", 7, 12},
+			{symbolTool, "lookup_order", 10, 6},
+		}},
+		{"tool", testToolConfig, []found{
+			{symbolTool, "lookup_order", 2, 9},
+		}},
+		{"scenario", testScenarioConfig, []found{
+			{symbolScenario, "order-status", 2, 6},
+			{symbolTool, "lookup_order", 4, 17},
+			{symbolTool, "refund", 5, 16},
+		}},
+		{"arena vars", testArenaVars, []found{
+			{symbolVariable, "customer_name", 5, 8},
+		}},
+		{"tool_choice mode", "spec:\n  tool_policy:\n    tool_choice: auto\n", nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			symbols := findSymbols(tc.content)
+			if len(symbols) != len(tc.want) {
+				t.Fatalf("expected %d symbols, got %d: %+v", len(tc.want), len(symbols), symbols)
+			}
+			for i, want := range tc.want {
+				got := symbols[i]
+				if got.kind != want.kind || got.name != want.name ||
+					got.rng.Start.Line != want.line || got.rng.Start.Character != want.char ||
+					got.rng.End.Character != want.char+len(want.name) {
+					t.Errorf("symbol %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSymbolAt(t *testing.T) {
+	doc := &Document{Content: testPromptConfig, Lines: splitLines(testPromptConfig)}
+
+	sym, ok := symbolAt(doc, Position{Line: 4, Character: 15})
+	if !ok || sym.kind != symbolVariable || sym.name != "customer_name" {
+		t.Errorf("expected customer_name variable, got %+v (ok=%v)", sym, ok)
+	}
+	if _, ok := symbolAt(doc, Position{Line: 2, Character: 4}); ok {
+		t.Error("expected no symbol on a field name")
+	}
+}
+
+func TestBuildRenameEdit(t *testing.T) {
+	mock := newProjectServer(t, map[string]string{
+		"prompts/support.yaml":                 testPromptConfig,
+		"tools/lookup_order.yaml":              testToolConfig,
+		"scenarios/order-status.scenario.yaml": testScenarioConfig,
+		"config.arena.yaml":                    testArenaVars,
+		"README.md":                            "{{customer_name}}",
+	}, nil)
+	validator, _ := NewValidator(mock.URL, logr.Discard())
+	srv := &Server{
+		config:    Config{DashboardAPIURL: mock.URL},
+		validator: validator,
+		documents: NewDocumentStore(),
+		log:       logr.Discard(),
+	}
+
+	// The prompt config is open with an unsaved extra reference.
+	edited := testPromptConfig + "# ask {{customer_name}}\n"
+	doc := srv.documents.Open("promptkit://ws1/proj1/prompts/support.yaml", "yaml", 2, edited)
+
+	sym, _ := symbolAt(doc, Position{Line: 7, Character: 14})
+	edit, err := srv.buildRenameEdit(context.Background(), "ws1", "proj1", doc, sym, "client_name")
+	if err != nil {
+		t.Fatalf("buildRenameEdit returned error: %v", err)
+	}
+
+	if len(edit.Changes) != 2 {
+		t.Fatalf("expected edits in 2 files, got %v", edit.Changes)
+	}
+	if got := len(edit.Changes[doc.URI]); got != 4 {
+		t.Errorf("expected 4 edits in the open document, got %d", got)
+	}
+	arenaEdits := edit.Changes["promptkit://ws1/proj1/config.arena.yaml"]
+	if len(arenaEdits) != 1 || arenaEdits[0].NewText != "client_name" || arenaEdits[0].Range.Start.Line != 5 {
+		t.Errorf("unexpected arena edits: %+v", arenaEdits)
+	}
+
+	toolSym := symbol{kind: symbolTool, name: "lookup_order"}
+	edit, err = srv.buildRenameEdit(context.Background(), "ws1", "proj1", doc, toolSym, "find_order")
+	if err != nil {
+		t.Fatalf("buildRenameEdit returned error: %v", err)
+	}
+	for _, uri := range []string{
+		doc.URI,
+		"promptkit://ws1/proj1/tools/lookup_order.yaml",
+		"promptkit://ws1/proj1/scenarios/order-status.scenario.yaml",
+	} {
+		if len(edit.Changes[uri]) != 1 {
+			t.Errorf("expected one tool edit in %s, got %+v", uri, edit.Changes[uri])
+		}
+	}
+}
+
+func TestBuildRenameEdit_UnreadableFileFailsWholeRename(t *testing.T) {
+	mock := newProjectServer(t, map[string]string{"prompts/support.yaml": testPromptConfig}, nil)
+	validator, _ := NewValidator(mock.URL, logr.Discard())
+	// Seed the file list with a file the content endpoint cannot serve.
+	validator.fileCache["ws1/proj1"] = &cachedFiles{
+		files:     []string{"prompts/support.yaml", "prompts/missing.yaml"},
+		expiresAt: time.Now().Add(time.Hour),
+	}
+	srv := &Server{validator: validator, documents: NewDocumentStore(), log: logr.Discard()}
+	doc := &Document{URI: "promptkit://ws1/proj1/other.yaml", Content: "", Lines: []string{""}}
+
+	_, err := srv.buildRenameEdit(context.Background(), "ws1", "proj1", doc,
+		symbol{kind: symbolVariable, name: "customer_name"}, "client_name")
+	if err == nil {
+		t.Fatal("expected error when a project file cannot be read")
+	}
+}
+
+func TestHandleRenameInvalidName(t *testing.T) {
+	srv, _ := New(Config{Addr: ":8080", DashboardAPIURL: "http://127.0.0.1:1"}, logr.Discard())
+	srv.documents.Open("file:///p.yaml", "yaml", 1, testPromptConfig)
+	c := &Connection{workspace: "ws", projectID: "proj", closed: true, pendingReq: make(map[int]chan *Response)}
+
+	params, _ := json.Marshal(RenameParams{
+		TextDocument: TextDocumentIdentifier{URI: "file:///p.yaml"},
+		Position:     Position{Line: 4, Character: 15},
+		NewName:      "not valid",
+	})
+	// Should reject without touching the dashboard API, and not panic
+	srv.handleRename(context.Background(), c, &Message{JSONRPC: "2.0", ID: json.RawMessage("1"), Params: params})
+
+	if validSymbolNames[symbolVariable].MatchString("not valid") {
+		t.Error("expected variable names with spaces to be invalid")
+	}
+}
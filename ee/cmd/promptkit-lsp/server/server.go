@@ -30,6 +30,9 @@ type Config struct {
 	DashboardAPIURL string
 	// DevMode enables development mode (disables license validation).
 	DevMode bool
+	// Catalog supplies cluster-registered tools, providers and PromptPack
+	// variables for completion and hover. Nil disables cluster lookups.
+	Catalog Catalog
 }
 
 // Server is the promptkit-lsp server.
@@ -265,8 +268,14 @@ func (s *Server) handleMessage(ctx context.Context, c *Connection, msg *Message)
 		s.handleHover(ctx, c, msg)
 	case "textDocument/definition":
 		s.handleDefinition(ctx, c, msg)
+	case "textDocument/references":
+		s.handleReferences(ctx, c, msg)
 	case "textDocument/semanticTokens/full":
 		s.handleSemanticTokensFull(ctx, c, msg)
+	case "textDocument/prepareRename":
+		s.handlePrepareRename(ctx, c, msg)
+	case "textDocument/rename":
+		s.handleRename(ctx, c, msg)
 	default:
 		log.V(1).Info("unhandled method")
 		// Send method not found error for requests
@@ -113,8 +113,10 @@ type ServerCapabilities struct {
 	CompletionProvider     *CompletionOptions       `json:"completionProvider,omitempty"`
 	HoverProvider          bool                     `json:"hoverProvider,omitempty"`
 	DefinitionProvider     bool                     `json:"definitionProvider,omitempty"`
+	ReferencesProvider     bool                     `json:"referencesProvider,omitempty"`
 	DiagnosticProvider     *DiagnosticOptions       `json:"diagnosticProvider,omitempty"`
 	SemanticTokensProvider *SemanticTokensOptions   `json:"semanticTokensProvider,omitempty"`
+	RenameProvider         *RenameOptions           `json:"renameProvider,omitempty"`
 }
 
 // TextDocumentSyncOptions describes document sync options.
@@ -401,3 +403,44 @@ type DefinitionParams struct {
 	TextDocument TextDocumentIdentifier `json:"textDocument"`
 	Position     Position               `json:"position"`
 }
+
+// ReferenceParams is the params for textDocument/references.
+type ReferenceParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Position     Position               `json:"position"`
+	Context      ReferenceContext       `json:"context"`
+}
+
+// ReferenceContext controls which references are returned.
+type ReferenceContext struct {
+	IncludeDeclaration bool `json:"includeDeclaration"`
+}
+
+// RenameOptions describes rename options.
+type RenameOptions struct {
+	PrepareProvider bool `json:"prepareProvider,omitempty"`
+}
+
+// PrepareRenameParams is the params for textDocument/prepareRename.
+type PrepareRenameParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Position     Position               `json:"position"`
+}
+
+// PrepareRenameResult is the range and placeholder of a renameable symbol.
+type PrepareRenameResult struct {
+	Range       Range  `json:"range"`
+	Placeholder string `json:"placeholder"`
+}
+
+// RenameParams is the params for textDocument/rename.
+type RenameParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Position     Position               `json:"position"`
+	NewName      string                 `json:"newName"`
+}
+
+// WorkspaceEdit represents changes to many documents, applied as one edit.
+type WorkspaceEdit struct {
+	Changes map[string][]TextEdit `json:"changes,omitempty"`
+}
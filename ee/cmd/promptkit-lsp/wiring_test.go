@@ -15,13 +15,13 @@ import (
 	"github.com/go-logr/logr"
 )
 
-// TestParseFlagsIntoConfig_Defaults asserts the four binary-level flags
+// TestParseFlagsIntoConfig_Defaults asserts the binary-level flags
 // produce the documented defaults when no arguments are passed. A
 // regression that renames a flag or changes a default would silently
 // affect every deployment until someone tested it.
 func TestParseFlagsIntoConfig_Defaults(t *testing.T) {
 	fs := flag.NewFlagSet("test", flag.ContinueOnError)
-	cfg := parseFlagsIntoConfig(fs, nil)
+	cfg, f := parseFlagsIntoConfig(fs, nil)
 	if cfg.Addr != ":8080" {
 		t.Errorf("Addr default = %q, want :8080", cfg.Addr)
 	}
@@ -35,6 +35,9 @@ func TestParseFlagsIntoConfig_Defaults(t *testing.T) {
 	if cfg.DevMode {
 		t.Errorf("DevMode default = true, want false")
 	}
+	if !f.clusterCatalog {
+		t.Errorf("clusterCatalog default = false, want true")
+	}
 }
 
 // TestParseFlagsIntoConfig_AllOverrides asserts each flag actually writes
@@ -43,11 +46,12 @@ func TestParseFlagsIntoConfig_Defaults(t *testing.T) {
 // old variable.
 func TestParseFlagsIntoConfig_AllOverrides(t *testing.T) {
 	fs := flag.NewFlagSet("test", flag.ContinueOnError)
-	cfg := parseFlagsIntoConfig(fs, []string{
+	cfg, f := parseFlagsIntoConfig(fs, []string{
 		"--addr=:9000",
 		"--health-addr=:9001",
 		"--dashboard-api-url=http://custom-dashboard:8000",
 		"--dev-mode",
+		"--cluster-catalog=false",
 	})
 	if cfg.Addr != ":9000" {
 		t.Errorf("Addr = %q, want :9000", cfg.Addr)
@@ -62,6 +66,9 @@ func TestParseFlagsIntoConfig_AllOverrides(t *testing.T) {
 	if !cfg.DevMode {
 		t.Errorf("DevMode = false, want true")
 	}
+	if f.clusterCatalog {
+		t.Errorf("clusterCatalog = true, want false")
+	}
 }
 
 // TestSetupServer_FromDefaults asserts the binary-level wiring contract:
@@ -98,3 +105,15 @@ func TestSetupServer_FlagsFlowIntoConfig(t *testing.T) {
 		t.Errorf("cfg.DevMode = false, want true")
 	}
 }
+
+// TestSetupServer_ClusterCatalogDisabled asserts --cluster-catalog=false
+// leaves the server without a catalog.
+func TestSetupServer_ClusterCatalogDisabled(t *testing.T) {
+	_, cfg, err := setupServer([]string{"--cluster-catalog=false"}, logr.Discard())
+	if err != nil {
+		t.Fatalf("setupServer returned error: %v", err)
+	}
+	if cfg.Catalog != nil {
+		t.Errorf("cfg.Catalog = %T, want nil", cfg.Catalog)
+	}
+}
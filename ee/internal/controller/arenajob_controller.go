@@ -130,6 +130,14 @@ type ArenaJobReconciler struct {
 	// RedisURLSecretKey is the key within RedisURLSecretName whose
 	// value is the Redis URL.
 	RedisURLSecretKey string
+	// WorkerRedisSecretName is the name of an optional per-namespace Secret
+	// holding a tenant-scoped Redis URL under the "url" key. When the Secret
+	// exists in a job's namespace, that job's workers connect with it instead
+	// of the operator-wide URL, so a Redis ACL user restricted to
+	// "arena:job:<namespace>/*" keeps one tenant out of another's queue. The
+	// URL must address the same Redis database the controller uses, since
+	// the controller enqueues and aggregates with its own connection.
+	WorkerRedisSecretName string
 	// WorkspaceContentPath is the base path for workspace content volumes.
 	// When set, workers mount the workspace content PVC and access content directly.
 	// Structure: {WorkspaceContentPath}/{workspace}/{namespace}/{contentPath}
@@ -185,10 +193,12 @@ type ArenaJobReconciler struct {
 // +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=arenajobs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=arenajobs/finalizers,verbs=update
 // +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=arenasources,verbs=get;list;watch
+// +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=goldendatasets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=providers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=agentruntimes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
@@ -282,6 +292,7 @@ func (r *ArenaJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			r.Recorder.Event(arenaJob, corev1.EventTypeWarning, "DevModeLicense",
 				"Using development license - not licensed for production use")
 		}
+		reportLicenseExpiry(ctx, r.LicenseValidator, r.Recorder, arenaJob, arenaJob.Generation, &arenaJob.Status.Conditions)
 	}
 
 	// Check if we already have a K8s Job
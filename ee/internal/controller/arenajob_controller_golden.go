@@ -0,0 +1,84 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/arena/golden"
+)
+
+// resolveGoldenDatasets looks up each GoldenDataset referenced by the job and
+// returns the resolved version refs plus the content subPath of each version
+// (relative to the workspace content volume). A dataset that is not Ready
+// fails resolution so the job never runs against an unknown version.
+func (r *ArenaJobReconciler) resolveGoldenDatasets(
+	ctx context.Context, arenaJob *omniav1alpha1.ArenaJob,
+) ([]golden.Ref, []string, error) {
+	if len(arenaJob.Spec.GoldenDatasets) == 0 {
+		return nil, nil, nil
+	}
+	refs := make([]golden.Ref, 0, len(arenaJob.Spec.GoldenDatasets))
+	subPaths := make([]string, 0, len(arenaJob.Spec.GoldenDatasets))
+	for _, ref := range arenaJob.Spec.GoldenDatasets {
+		dataset := &omniav1alpha1.GoldenDataset{}
+		if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: arenaJob.Namespace}, dataset); err != nil {
+			return nil, nil, fmt.Errorf("failed to get goldenDataset %s: %w", ref.Name, err)
+		}
+		if dataset.Status.Phase != omniav1alpha1.GoldenDatasetPhaseReady || dataset.Status.ContentPath == "" {
+			return nil, nil, fmt.Errorf("goldenDataset %s is not ready (phase: %s)", ref.Name, dataset.Status.Phase)
+		}
+		refs = append(refs, golden.Ref{
+			Name:    dataset.Name,
+			Version: dataset.Status.ResolvedVersion,
+			Path:    dataset.Spec.Path,
+		})
+		subPaths = append(subPaths, r.goldenDatasetSubPath(ctx, arenaJob.Namespace, dataset.Status.ContentPath))
+	}
+	return refs, subPaths, nil
+}
+
+// goldenDatasetSubPath mirrors the job content subPath convention: scoped
+// volumes are rooted at the workspace subtree, legacy volumes at the share root.
+func (r *ArenaJobReconciler) goldenDatasetSubPath(ctx context.Context, namespace, contentPath string) string {
+	if r.WorkspaceContentScoped {
+		return contentPath
+	}
+	workspaceName := GetWorkspaceForNamespace(ctx, r.Client, namespace)
+	return path.Join(workspaceName, namespace, contentPath)
+}
+
+// buildGoldenDatasetConfig returns the env var and read-only mounts that
+// expose resolved dataset versions to the worker under golden.MountRoot.
+func buildGoldenDatasetConfig(refs []golden.Ref, subPaths []string) ([]corev1.EnvVar, []corev1.VolumeMount, error) {
+	if len(refs) == 0 {
+		return nil, nil, nil
+	}
+	raw, err := json.Marshal(refs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode golden datasets: %w", err)
+	}
+	mounts := make([]corev1.VolumeMount, 0, len(refs))
+	for i, ref := range refs {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "workspace-content",
+			MountPath: path.Join(golden.MountRoot, ref.Name),
+			SubPath:   subPaths[i],
+			ReadOnly:  true,
+		})
+	}
+	return []corev1.EnvVar{{Name: golden.EnvDatasets, Value: string(raw)}}, mounts, nil
+}
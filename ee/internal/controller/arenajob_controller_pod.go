@@ -15,11 +15,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"slices"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -69,6 +71,39 @@ func (r *ArenaJobReconciler) buildRedisURLEnvVar() []corev1.EnvVar {
 	return nil
 }
 
+// workerRedisSecretKey is the key within a WorkerRedisSecretName Secret whose
+// value is the tenant-scoped Redis URL.
+const workerRedisSecretKey = "url"
+
+// buildWorkerRedisURLEnvVar returns the REDIS_URL env var for a job's worker
+// pods. When WorkerRedisSecretName is configured and that Secret exists in the
+// job namespace, workers read the tenant-scoped URL from it (typically an ACL
+// user limited to the namespace's arena keys). Otherwise it falls back to the
+// operator-wide URL from buildRedisURLEnvVar.
+func (r *ArenaJobReconciler) buildWorkerRedisURLEnvVar(ctx context.Context, namespace string) []corev1.EnvVar {
+	if r.WorkerRedisSecretName != "" {
+		secret := &corev1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Name: r.WorkerRedisSecretName, Namespace: namespace}, secret)
+		if err == nil {
+			if _, ok := secret.Data[workerRedisSecretKey]; ok {
+				return []corev1.EnvVar{{
+					Name: "REDIS_URL",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+							Key:                  workerRedisSecretKey,
+						},
+					},
+				}}
+			}
+		} else if !apierrors.IsNotFound(err) {
+			logf.FromContext(ctx).Error(err, "failed to read tenant Redis secret, using operator-wide Redis URL",
+				"secret", r.WorkerRedisSecretName, "namespace", namespace)
+		}
+	}
+	return r.buildRedisURLEnvVar()
+}
+
 // buildMgmtPlaneTokenEnvVar returns the OMNIA_MGMT_PLANE_SERVICE_TOKEN_URL env
 // var for worker pods when the operator was configured with the dashboard's
 // service-token endpoint. The worker uses it to mint a mgmt-plane JWT (via its
@@ -323,7 +358,7 @@ func (r *ArenaJobReconciler) createWorkerJob(ctx context.Context, arenaJob *omni
 	// Add Redis URL config (literal value or secret-sourced env). The
 	// arena-worker binary picks the URL up via REDIS_URL env fallback
 	// on its --redis-url flag.
-	env = append(env, r.buildRedisURLEnvVar()...)
+	env = append(env, r.buildWorkerRedisURLEnvVar(ctx, arenaJob.Namespace)...)
 
 	// Authenticate fleet-mode WS dials (mgmt-plane token URL → worker env).
 	env = append(env, r.buildMgmtPlaneTokenEnvVar()...)
@@ -533,6 +568,25 @@ func (r *ArenaJobReconciler) createWorkerJob(ctx context.Context, arenaJob *omni
 			"subPath", contentSubPath, "workspaceScoped", r.WorkspaceContentScoped)
 	}
 
+	// Pin golden datasets to their resolved versions. Dataset content lives on
+	// the same workspace content volume, mounted per dataset under /golden.
+	if len(arenaJob.Spec.GoldenDatasets) > 0 {
+		if !useWorkspaceContent {
+			return fmt.Errorf("golden datasets require workspace content volumes")
+		}
+		refs, subPaths, err := r.resolveGoldenDatasets(ctx, arenaJob)
+		if err != nil {
+			return err
+		}
+		goldenEnv, goldenMounts, err := buildGoldenDatasetConfig(refs, subPaths)
+		if err != nil {
+			return err
+		}
+		env = append(env, goldenEnv...)
+		volumeMounts = append(volumeMounts, goldenMounts...)
+		log.Info("mounting golden datasets", "count", len(refs))
+	}
+
 	// Wire output destination — PVC mount or S3 env vars.
 	outputEnv, outputVolumes, outputVolumeMounts := r.buildOutputConfig(arenaJob)
 	env = append(env, outputEnv...)
@@ -617,7 +671,8 @@ func (r *ArenaJobReconciler) createWorkerJob(ctx context.Context, arenaJob *omni
 		job.Spec.Template.Labels[k] = v
 	}
 
-	// Apply user-supplied PodOverrides.
+	// Apply the sandbox hardening profile, then user-supplied PodOverrides.
+	applyWorkerSecurity(job, arenaJob)
 	applyWorkerPodOverrides(job, arenaJob)
 
 	// Set TTL for automatic cleanup after completion (default: 1 hour)
@@ -687,3 +742,68 @@ func applyWorkerPodOverrides(job *batchv1.Job, arenaJob *omniav1alpha1.ArenaJob)
 		podoverrides.ApplyContainer(&job.Spec.Template.Spec.Containers[i], overrides)
 	}
 }
+
+// applyWorkerSecurity translates ArenaJob.spec.workers.security onto the
+// worker Job's pod template. Only fields the user set replace the hardened
+// defaults built into the template; the pod always runs as non-root with
+// privilege escalation disabled.
+func applyWorkerSecurity(job *batchv1.Job, arenaJob *omniav1alpha1.ArenaJob) {
+	if arenaJob.Spec.Workers == nil || arenaJob.Spec.Workers.Security == nil {
+		return
+	}
+	sec := arenaJob.Spec.Workers.Security
+	podSpec := &job.Spec.Template.Spec
+
+	if sec.RuntimeClassName != nil {
+		podSpec.RuntimeClassName = ptr.To(*sec.RuntimeClassName)
+	}
+
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	podSC := podSpec.SecurityContext
+	if sec.SeccompProfile != nil {
+		podSC.SeccompProfile = &corev1.SeccompProfile{
+			Type:             corev1.SeccompProfileType(sec.SeccompProfile.Type),
+			LocalhostProfile: sec.SeccompProfile.LocalhostProfile,
+		}
+	}
+	if sec.RunAsUser != nil {
+		podSC.RunAsUser = ptr.To(*sec.RunAsUser)
+	}
+	if sec.RunAsGroup != nil {
+		podSC.RunAsGroup = ptr.To(*sec.RunAsGroup)
+		podSC.FSGroup = ptr.To(*sec.RunAsGroup)
+	}
+
+	for i := range podSpec.Containers {
+		c := &podSpec.Containers[i]
+		if c.SecurityContext == nil {
+			c.SecurityContext = &corev1.SecurityContext{
+				AllowPrivilegeEscalation: ptr.To(false),
+				RunAsNonRoot:             ptr.To(true),
+			}
+		}
+		if sec.ReadOnlyRootFilesystem != nil {
+			c.SecurityContext.ReadOnlyRootFilesystem = ptr.To(*sec.ReadOnlyRootFilesystem)
+		}
+		if c.SecurityContext.Capabilities == nil {
+			c.SecurityContext.Capabilities = &corev1.Capabilities{}
+		}
+		c.SecurityContext.Capabilities.Drop = workerDropCapabilities(
+			c.SecurityContext.Capabilities.Drop, sec.DropCapabilities)
+	}
+}
+
+// workerDropCapabilities returns drop with ALL and every extra capability
+// added once. ALL is always kept so a dropCapabilities list can only narrow
+// the worker further, never hand capabilities back.
+func workerDropCapabilities(drop []corev1.Capability, extra []string) []corev1.Capability {
+	out := slices.Clone(drop)
+	for _, capability := range append([]string{"ALL"}, extra...) {
+		if !slices.Contains(out, corev1.Capability(capability)) {
+			out = append(out, corev1.Capability(capability))
+		}
+	}
+	return out
+}
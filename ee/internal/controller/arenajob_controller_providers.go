@@ -15,6 +15,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -200,6 +201,12 @@ func (r *ArenaJobReconciler) buildProviderEnvVarsFromCRDs(providerCRDs []*corev1
 	return providers.BuildEnvVarsFromProviders(providerCRDs)
 }
 
+// queueJobID returns the namespace-scoped work queue ID for an ArenaJob.
+// Workers derive the same ID from ARENA_JOB_NAMESPACE and ARENA_JOB_NAME.
+func queueJobID(arenaJob *omniav1alpha1.ArenaJob) string {
+	return queue.ScopedJobID(arenaJob.Namespace, arenaJob.Name)
+}
+
 // getOrCreateQueue returns the work queue, creating it lazily if needed.
 func (r *ArenaJobReconciler) getOrCreateQueue() (queue.WorkQueue, error) {
 	// Return existing queue if already connected
@@ -277,13 +284,15 @@ func (r *ArenaJobReconciler) listScenarios(ctx context.Context, arenaJob *omniav
 	return scenarios, nil
 }
 
-// buildMatrixWorkItems creates scenario × provider × trial work items using the partitioner.
+// buildMatrixWorkItems creates scenario × provider × parameter set × trial work
+// items using the partitioner.
 // providerIDs are the array-mode provider IDs (from both providerRef and agentRef entries).
 // Returns nil if partitioning fails or inputs are empty.
 func (r *ArenaJobReconciler) buildMatrixWorkItems(
 	ctx context.Context, jobName, bundleURL string,
 	scenarios []partitioner.Scenario,
 	providerIDs []string,
+	paramSets []queue.ParameterSet,
 	jobTrials int, jobType omniav1alpha1.ArenaJobType,
 ) []queue.WorkItem {
 	log := logf.FromContext(ctx)
@@ -297,12 +306,13 @@ func (r *ArenaJobReconciler) buildMatrixWorkItems(
 	}
 
 	result, err := partitioner.Partition(partitioner.PartitionInput{
-		JobID:      jobName,
-		BundleURL:  bundleURL,
-		Scenarios:  scenarios,
-		Providers:  partProviders,
-		MaxRetries: 3,
-		JobTrials:  jobTrials,
+		JobID:         jobName,
+		BundleURL:     bundleURL,
+		Scenarios:     scenarios,
+		Providers:     partProviders,
+		ParameterSets: paramSets,
+		MaxRetries:    3,
+		JobTrials:     jobTrials,
 	})
 	if err != nil {
 		log.Error(err, "partitioning failed, falling back to per-provider mode")
@@ -326,6 +336,7 @@ func (r *ArenaJobReconciler) buildMatrixWorkItems(
 	log.Info("created scenario × provider × trial work items",
 		"scenarios", result.ScenarioCount,
 		"providers", result.ProviderCount,
+		"parameterSets", result.ParameterSetCount,
 		"trials", result.TrialCount,
 		"items", result.TotalCombinations)
 	return result.Items
@@ -417,25 +428,71 @@ func (r *ArenaJobReconciler) enqueueWorkItems(
 		jobTrials = int(*arenaJob.Spec.Trials)
 	}
 
+	paramSets, err := toQueueParameterSets(arenaJob.Spec.ParameterSets)
+	if err != nil {
+		return 0, err
+	}
+
 	var items []queue.WorkItem
 	if len(scenarios) > 0 && len(matrixProviderIDs) > 0 {
-		items = r.buildMatrixWorkItems(ctx, arenaJob.Name, bundleURL, scenarios, matrixProviderIDs, jobTrials, arenaJob.Spec.Type)
+		items = r.buildMatrixWorkItems(ctx, arenaJob.Name, bundleURL, scenarios, matrixProviderIDs,
+			paramSets, jobTrials, arenaJob.Spec.Type)
 	}
 	if len(items) == 0 {
 		if jobTrials > 0 {
 			log.Info("trial configuration ignored in fallback mode", "trials", jobTrials)
 		}
+		if len(paramSets) > 0 {
+			log.Info("parameter sets ignored in fallback mode", "parameterSets", len(paramSets))
+		}
 		items = buildFallbackWorkItems(arenaJob.Name, bundleURL, matrixProviderIDs)
 	}
 
 	log.Info("enqueueing work items", "count", len(items))
-	if err := q.Push(ctx, arenaJob.Name, items); err != nil {
+	if err := q.Push(ctx, queueJobID(arenaJob), items); err != nil {
 		return 0, fmt.Errorf("failed to push work items to queue: %w", err)
 	}
 
 	return len(items), nil
 }
 
+// toQueueParameterSets converts the spec's parameter sets into their queue
+// representation, parsing the decimal string fields.
+func toQueueParameterSets(specSets []omniav1alpha1.ParameterSet) ([]queue.ParameterSet, error) {
+	if len(specSets) == 0 {
+		return nil, nil
+	}
+	sets := make([]queue.ParameterSet, 0, len(specSets))
+	for _, ps := range specSets {
+		set := queue.ParameterSet{Name: ps.Name, SystemPrompt: ps.SystemPrompt}
+		var err error
+		if set.Temperature, err = parseOptionalFloat(ps.Temperature); err != nil {
+			return nil, fmt.Errorf("parameter set %q: invalid temperature: %w", ps.Name, err)
+		}
+		if set.TopP, err = parseOptionalFloat(ps.TopP); err != nil {
+			return nil, fmt.Errorf("parameter set %q: invalid topP: %w", ps.Name, err)
+		}
+		if ps.MaxTokens != nil {
+			maxTokens := int(*ps.MaxTokens)
+			set.MaxTokens = &maxTokens
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// parseOptionalFloat parses a decimal string, returning nil for a nil input.
+func parseOptionalFloat(s *string) (*float64, error) {
+	if s == nil {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(*s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
 // enumerateScenarios attempts to enumerate scenarios from the filesystem and
 // applies the job's include/exclude filters. Returns nil when enumeration is
 // unavailable (falls back to per-provider mode).
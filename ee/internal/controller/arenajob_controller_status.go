@@ -21,6 +21,7 @@ import (
 
 	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
 	"github.com/altairalabs/omnia/ee/pkg/arena/aggregator"
+	"github.com/altairalabs/omnia/ee/pkg/arena/report"
 	"github.com/altairalabs/omnia/ee/pkg/arena/threshold"
 	"github.com/altairalabs/omnia/pkg/intconv"
 )
@@ -69,8 +70,8 @@ func (r *ArenaJobReconciler) handleJobComplete(ctx context.Context, arenaJob *om
 	var hasAggregation bool
 	var passedItems, failedItems int
 	if r.Aggregator != nil {
-		log.V(1).Info("aggregating results", "jobID", arenaJob.Name)
-		result := r.aggregateJobResults(ctx, arenaJob.Name)
+		log.V(1).Info("aggregating results", "jobID", queueJobID(arenaJob))
+		result := r.aggregateJobResults(ctx, queueJobID(arenaJob))
 		if result != nil {
 			hasAggregation = true
 			log.V(1).Info("aggregation complete",
@@ -78,6 +79,8 @@ func (r *ArenaJobReconciler) handleJobComplete(ctx context.Context, arenaJob *om
 				"passedItems", result.PassedItems,
 				"failedItems", result.FailedItems)
 			arenaJob.Status.Result = r.Aggregator.ToJobResult(result)
+			// Workers render the report into the job output on completion.
+			arenaJob.Status.Result.URL = report.Location(arenaJob.Name, arenaJob.Spec.Output)
 			hasTestFailures = result.FailedItems > 0
 			passedItems = result.PassedItems
 			failedItems = result.FailedItems
@@ -99,7 +102,7 @@ func (r *ArenaJobReconciler) handleJobComplete(ctx context.Context, arenaJob *om
 		arenaJob.Status.Progress.Failed = int32(failedItems)
 		arenaJob.Status.Progress.Pending = 0
 	} else if r.Queue != nil {
-		if stats, err := r.Queue.GetStats(ctx, arenaJob.Name); err == nil && stats != nil {
+		if stats, err := r.Queue.GetStats(ctx, queueJobID(arenaJob)); err == nil && stats != nil {
 			arenaJob.Status.Progress.Completed = intconv.ClampInt32(stats.Passed)
 			arenaJob.Status.Progress.Failed = intconv.ClampInt32(stats.Failed)
 			arenaJob.Status.Progress.Pending = 0
@@ -179,7 +182,7 @@ func (r *ArenaJobReconciler) handleJobFailed(ctx context.Context, arenaJob *omni
 	// leave the zero values (which now serialize thanks to the
 	// JobProgress field tag change).
 	if r.Queue != nil {
-		if stats, err := r.Queue.GetStats(ctx, arenaJob.Name); err == nil && stats != nil {
+		if stats, err := r.Queue.GetStats(ctx, queueJobID(arenaJob)); err == nil && stats != nil {
 			arenaJob.Status.Progress.Completed = intconv.ClampInt32(stats.Passed)
 			arenaJob.Status.Progress.Failed = intconv.ClampInt32(stats.Failed)
 			arenaJob.Status.Progress.Pending = 0
@@ -211,7 +214,7 @@ func (r *ArenaJobReconciler) evaluateLoadTestThresholds(
 		return false
 	}
 
-	stats, err := r.Queue.GetStats(ctx, arenaJob.Name)
+	stats, err := r.Queue.GetStats(ctx, queueJobID(arenaJob))
 	if err != nil {
 		log.Error(err, "threshold evaluation failed to get stats")
 		return false
@@ -292,7 +295,7 @@ func (r *ArenaJobReconciler) checkBudgetLimit(ctx context.Context, arenaJob *omn
 	}
 
 	log := logf.FromContext(ctx)
-	stats, err := r.Queue.GetStats(ctx, arenaJob.Name)
+	stats, err := r.Queue.GetStats(ctx, queueJobID(arenaJob))
 	if err != nil {
 		log.V(1).Info("budget check skipped",
 			"reason", "failed to get stats",
@@ -2687,7 +2687,7 @@ spec:
 				providerIDs[i] = fmt.Sprintf("provider-%d", i)
 			}
 
-			items := reconciler.buildMatrixWorkItems(ctx, "test-job", "bundle-url", scenarios, providerIDs, 0, omniav1alpha1.ArenaJobTypeEvaluation)
+			items := reconciler.buildMatrixWorkItems(ctx, "test-job", "bundle-url", scenarios, providerIDs, nil, 0, omniav1alpha1.ArenaJobTypeEvaluation)
 			Expect(items).To(BeNil())
 		})
 
@@ -2704,9 +2704,26 @@ spec:
 
 			providerIDs := []string{"p1", "p2"}
 
-			items := reconciler.buildMatrixWorkItems(ctx, "test-job", "bundle-url", scenarios, providerIDs, 0, omniav1alpha1.ArenaJobTypeEvaluation)
+			items := reconciler.buildMatrixWorkItems(ctx, "test-job", "bundle-url", scenarios, providerIDs, nil, 0, omniav1alpha1.ArenaJobTypeEvaluation)
 			Expect(items).To(HaveLen(4)) // 2 scenarios x 2 providers
 		})
+
+		It("should multiply items by parameter sets", func() {
+			reconciler := &ArenaJobReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			scenarios := []partitioner.Scenario{{ID: "s1", Name: "Scenario 1", Path: "s1.yaml"}}
+			providerIDs := []string{"p1", "p2"}
+			paramSets := []queue.ParameterSet{{Name: "cold"}, {Name: "hot"}}
+
+			items := reconciler.buildMatrixWorkItems(ctx, "test-job", "bundle-url", scenarios, providerIDs, paramSets, 0, omniav1alpha1.ArenaJobTypeEvaluation)
+			Expect(items).To(HaveLen(4)) // 1 scenario x 2 providers x 2 parameter sets
+			for _, item := range items {
+				Expect(item.Parameters).NotTo(BeNil())
+			}
+		})
 	})
 
 	// --- Output config ---
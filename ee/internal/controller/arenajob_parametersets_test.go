@@ -0,0 +1,59 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	eev1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+)
+
+func TestToQueueParameterSets_Empty(t *testing.T) {
+	sets, err := toQueueParameterSets(nil)
+	require.NoError(t, err)
+	require.Nil(t, sets)
+}
+
+func TestToQueueParameterSets_AllFields(t *testing.T) {
+	sets, err := toQueueParameterSets([]eev1alpha1.ParameterSet{
+		{
+			Name:         "precise",
+			Temperature:  ptr.To("0.2"),
+			TopP:         ptr.To("0.9"),
+			MaxTokens:    ptr.To[int32](512),
+			SystemPrompt: "Answer tersely.",
+		},
+		{Name: "defaults"},
+	})
+	require.NoError(t, err)
+	require.Len(t, sets, 2)
+
+	precise := sets[0]
+	require.Equal(t, "precise", precise.Name)
+	require.InDelta(t, 0.2, *precise.Temperature, 1e-9)
+	require.InDelta(t, 0.9, *precise.TopP, 1e-9)
+	require.Equal(t, 512, *precise.MaxTokens)
+	require.Equal(t, "Answer tersely.", precise.SystemPrompt)
+
+	defaults := sets[1]
+	require.Equal(t, "defaults", defaults.Name)
+	require.Nil(t, defaults.Temperature)
+	require.Nil(t, defaults.TopP)
+	require.Nil(t, defaults.MaxTokens)
+}
+
+func TestToQueueParameterSets_InvalidFloat(t *testing.T) {
+	_, err := toQueueParameterSets([]eev1alpha1.ParameterSet{
+		{Name: "bad", Temperature: ptr.To("warm")},
+	})
+	require.ErrorContains(t, err, `parameter set "bad": invalid temperature`)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	eev1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+)
+
+func TestApplyWorkerSecurity_Nil(t *testing.T) {
+	job := newWorkerJobFixture()
+	applyWorkerSecurity(job, &eev1alpha1.ArenaJob{})
+	require.Nil(t, job.Spec.Template.Spec.SecurityContext, "nil Workers must not mutate")
+
+	aj := &eev1alpha1.ArenaJob{Spec: eev1alpha1.ArenaJobSpec{Workers: &eev1alpha1.WorkerConfig{}}}
+	applyWorkerSecurity(job, aj)
+	require.Nil(t, job.Spec.Template.Spec.SecurityContext, "Workers without Security must not mutate")
+}
+
+func TestApplyWorkerSecurity_KeepsDefaultsForUnsetFields(t *testing.T) {
+	job := newWorkerJobFixture()
+	job.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{
+		RunAsUser:      ptr.To(int64(65532)),
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+	job.Spec.Template.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{
+		ReadOnlyRootFilesystem: ptr.To(true),
+		Capabilities:           &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+	}
+	aj := &eev1alpha1.ArenaJob{Spec: eev1alpha1.ArenaJobSpec{Workers: &eev1alpha1.WorkerConfig{
+		Security: &eev1alpha1.WorkerSecurityConfig{RuntimeClassName: ptr.To("gvisor")},
+	}}}
+
+	applyWorkerSecurity(job, aj)
+
+	spec := job.Spec.Template.Spec
+	require.Equal(t, "gvisor", *spec.RuntimeClassName)
+	require.Equal(t, int64(65532), *spec.SecurityContext.RunAsUser)
+	require.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, spec.SecurityContext.SeccompProfile.Type)
+	require.True(t, *spec.Containers[0].SecurityContext.ReadOnlyRootFilesystem)
+	require.Equal(t, []corev1.Capability{"ALL"}, spec.Containers[0].SecurityContext.Capabilities.Drop)
+}
+
+func TestApplyWorkerSecurity_AllFields(t *testing.T) {
+	job := newWorkerJobFixture()
+	aj := &eev1alpha1.ArenaJob{Spec: eev1alpha1.ArenaJobSpec{Workers: &eev1alpha1.WorkerConfig{
+		Security: &eev1alpha1.WorkerSecurityConfig{
+			RuntimeClassName: ptr.To("kata"),
+			SeccompProfile: &eev1alpha1.WorkerSeccompProfile{
+				Type:             eev1alpha1.WorkerSeccompProfileLocalhost,
+				LocalhostProfile: ptr.To("profiles/arena-worker.json"),
+			},
+			ReadOnlyRootFilesystem: ptr.To(false),
+			RunAsUser:              ptr.To(int64(1000)),
+			RunAsGroup:             ptr.To(int64(2000)),
+			DropCapabilities:       []string{"NET_RAW", "SYS_ADMIN"},
+		},
+	}}}
+
+	applyWorkerSecurity(job, aj)
+
+	spec := job.Spec.Template.Spec
+	require.Equal(t, "kata", *spec.RuntimeClassName)
+	require.Equal(t, corev1.SeccompProfileTypeLocalhost, spec.SecurityContext.SeccompProfile.Type)
+	require.Equal(t, "profiles/arena-worker.json", *spec.SecurityContext.SeccompProfile.LocalhostProfile)
+	require.Equal(t, int64(1000), *spec.SecurityContext.RunAsUser)
+	require.Equal(t, int64(2000), *spec.SecurityContext.RunAsGroup)
+	require.Equal(t, int64(2000), *spec.SecurityContext.FSGroup)
+
+	csc := spec.Containers[0].SecurityContext
+	require.False(t, *csc.ReadOnlyRootFilesystem)
+	require.False(t, *csc.AllowPrivilegeEscalation, "privilege escalation stays disabled")
+	require.True(t, *csc.RunAsNonRoot, "non-root stays enforced")
+	require.Equal(t, []corev1.Capability{"ALL", "NET_RAW", "SYS_ADMIN"}, csc.Capabilities.Drop,
+		"ALL stays dropped alongside the listed capabilities")
+}
+
+func TestApplyWorkerSecurity_EmptyDropCapabilitiesKeepsAll(t *testing.T) {
+	job := newWorkerJobFixture()
+	aj := &eev1alpha1.ArenaJob{Spec: eev1alpha1.ArenaJobSpec{Workers: &eev1alpha1.WorkerConfig{
+		Security: &eev1alpha1.WorkerSecurityConfig{DropCapabilities: []string{}},
+	}}}
+
+	applyWorkerSecurity(job, aj)
+
+	require.Equal(t, []corev1.Capability{"ALL"}, job.Spec.Template.Spec.Containers[0].SecurityContext.Capabilities.Drop,
+		"an empty list must not restore capabilities")
+}
@@ -206,6 +206,7 @@ func (r *ArenaSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			r.Recorder.Event(source, corev1.EventTypeWarning, "DevModeLicense",
 				"Using development license - not licensed for production use")
 		}
+		reportLicenseExpiry(ctx, r.LicenseValidator, r.Recorder, source, source.Generation, &source.Status.Conditions)
 	}
 
 	// Validate spec.targetPath up front (defense-in-depth, all source types).
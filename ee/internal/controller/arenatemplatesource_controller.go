@@ -186,6 +186,7 @@ func (r *ArenaTemplateSourceReconciler) Reconcile(ctx context.Context, req ctrl.
 			}
 			return ctrl.Result{}, nil // Don't requeue - license must change
 		}
+		reportLicenseExpiry(ctx, r.LicenseValidator, r.Recorder, source, source.Generation, &source.Status.Conditions)
 	}
 
 	// Parse sync interval
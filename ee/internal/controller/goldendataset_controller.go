@@ -0,0 +1,194 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/arena/golden"
+	"github.com/altairalabs/omnia/pkg/intconv"
+)
+
+// GoldenDataset condition types
+const (
+	GoldenDatasetConditionTypeReady       = "Ready"
+	GoldenDatasetConditionTypeSourceReady = "SourceReady"
+)
+
+// GoldenDatasetReconciler reconciles a GoldenDataset object. It resolves the
+// dataset to a concrete ArenaSource version and validates the dataset file so
+// ArenaJobs can mount a known-good version at run time.
+type GoldenDatasetReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// WorkspaceContentPath is the base path for workspace content volumes.
+	// When set, the dataset file is parsed to validate it and count entries.
+	// Structure: {WorkspaceContentPath}/{workspace}/{namespace}/{contentPath}
+	WorkspaceContentPath string
+}
+
+// +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=goldendatasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=goldendatasets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=arenasources,verbs=get;list;watch
+
+// Reconcile resolves the dataset version and updates status.
+func (r *GoldenDatasetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	dataset := &omniav1alpha1.GoldenDataset{}
+	if err := r.Get(ctx, req.NamespacedName, dataset); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	dataset.Status.ObservedGeneration = dataset.Generation
+
+	source, err := r.readySource(ctx, dataset)
+	if err != nil {
+		log.Info("ArenaSource not ready for GoldenDataset, will retry",
+			"source", dataset.Spec.SourceRef.Name, "reason", err.Error())
+		dataset.Status.Phase = omniav1alpha1.GoldenDatasetPhasePending
+		SetCondition(&dataset.Status.Conditions, dataset.Generation, GoldenDatasetConditionTypeSourceReady,
+			metav1.ConditionFalse, "SourceNotReady", err.Error())
+		SetCondition(&dataset.Status.Conditions, dataset.Generation, GoldenDatasetConditionTypeReady,
+			metav1.ConditionFalse, "SourceNotReady", err.Error())
+		if statusErr := r.Status().Update(ctx, dataset); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{RequeueAfter: arenaSourceRetryInterval}, nil
+	}
+	SetCondition(&dataset.Status.Conditions, dataset.Generation, GoldenDatasetConditionTypeSourceReady,
+		metav1.ConditionTrue, "SourceReady", fmt.Sprintf("ArenaSource %s has content", source.Name))
+
+	version := dataset.Spec.Version
+	if version == "" {
+		version = source.Status.Artifact.Version
+	}
+	contentPath := versionContentPath(source.Status.Artifact.ContentPath, version)
+
+	entries, err := r.loadEntries(ctx, dataset, contentPath)
+	if err != nil {
+		dataset.Status.Phase = omniav1alpha1.GoldenDatasetPhaseError
+		SetCondition(&dataset.Status.Conditions, dataset.Generation, GoldenDatasetConditionTypeReady,
+			metav1.ConditionFalse, "InvalidDataset", err.Error())
+		if r.Recorder != nil {
+			r.Recorder.Event(dataset, corev1.EventTypeWarning, "InvalidDataset", err.Error())
+		}
+		return ctrl.Result{}, r.Status().Update(ctx, dataset)
+	}
+
+	dataset.Status.Phase = omniav1alpha1.GoldenDatasetPhaseReady
+	dataset.Status.ResolvedVersion = version
+	dataset.Status.ContentPath = contentPath
+	dataset.Status.EntryCount = intconv.ClampInt32(int64(entries))
+	SetCondition(&dataset.Status.Conditions, dataset.Generation, GoldenDatasetConditionTypeReady,
+		metav1.ConditionTrue, "Resolved", fmt.Sprintf("Resolved to version %s", version))
+
+	return ctrl.Result{}, r.Status().Update(ctx, dataset)
+}
+
+// readySource returns the referenced ArenaSource once it has fetched content.
+func (r *GoldenDatasetReconciler) readySource(
+	ctx context.Context, dataset *omniav1alpha1.GoldenDataset,
+) (*omniav1alpha1.ArenaSource, error) {
+	source := &omniav1alpha1.ArenaSource{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      dataset.Spec.SourceRef.Name,
+		Namespace: dataset.Namespace,
+	}, source); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("arenaSource %s not found", dataset.Spec.SourceRef.Name)
+		}
+		return nil, fmt.Errorf("failed to get arenaSource %s: %w", dataset.Spec.SourceRef.Name, err)
+	}
+	if source.Status.Artifact == nil || source.Status.Artifact.ContentPath == "" {
+		return nil, fmt.Errorf("arenaSource %s has no content (phase: %s)", source.Name, source.Status.Phase)
+	}
+	return source, nil
+}
+
+// loadEntries parses the dataset file for the resolved version and returns
+// its entry count. Without a content path the file cannot be inspected, so
+// zero entries are reported and validation is left to the worker.
+func (r *GoldenDatasetReconciler) loadEntries(
+	ctx context.Context, dataset *omniav1alpha1.GoldenDataset, contentPath string,
+) (int, error) {
+	if r.WorkspaceContentPath == "" {
+		return 0, nil
+	}
+	workspaceName := GetWorkspaceForNamespace(ctx, r.Client, dataset.Namespace)
+	file := filepath.Join(r.WorkspaceContentPath, workspaceName, dataset.Namespace,
+		filepath.FromSlash(contentPath), filepath.FromSlash(dataset.Spec.Path))
+	f, err := golden.LoadFile(file)
+	if err != nil {
+		return 0, err
+	}
+	return len(f.Entries), nil
+}
+
+// versionContentPath rewrites a source's head content path
+// ("<target>/.arena/versions/<head>") to point at the given version.
+func versionContentPath(headContentPath, version string) string {
+	if version == "" {
+		return headContentPath
+	}
+	return path.Join(path.Dir(headContentPath), version)
+}
+
+// findGoldenDatasetsForSource maps ArenaSource changes to GoldenDataset
+// reconcile requests so datasets tracking head re-resolve after a sync.
+func (r *GoldenDatasetReconciler) findGoldenDatasetsForSource(ctx context.Context, obj client.Object) []ctrl.Request {
+	source, ok := obj.(*omniav1alpha1.ArenaSource)
+	if !ok {
+		return nil
+	}
+	list := &omniav1alpha1.GoldenDatasetList{}
+	if err := r.List(ctx, list, client.InNamespace(source.Namespace)); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list GoldenDatasets for ArenaSource", "source", source.Name)
+		return nil
+	}
+	var requests []ctrl.Request
+	for _, ds := range list.Items {
+		if ds.Spec.SourceRef.Name == source.Name {
+			requests = append(requests, ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: ds.Name, Namespace: ds.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GoldenDatasetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&omniav1alpha1.GoldenDataset{}).
+		Watches(
+			&omniav1alpha1.ArenaSource{},
+			handler.EnqueueRequestsFromMapFunc(r.findGoldenDatasetsForSource),
+		).
+		Named("goldendataset").
+		Complete(r)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/arena/golden"
+)
+
+func TestVersionContentPath(t *testing.T) {
+	head := "arena/support/.arena/versions/abc123"
+	if got := versionContentPath(head, ""); got != head {
+		t.Errorf("empty version: got %q want %q", got, head)
+	}
+	if got := versionContentPath(head, "def456"); got != "arena/support/.arena/versions/def456" {
+		t.Errorf("pinned version: got %q", got)
+	}
+}
+
+func TestGoldenDatasetReconcile_ResolvesHeadVersion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := omniav1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	base := t.TempDir()
+	contentPath := "arena/support/.arena/versions/v1"
+	// Without a Namespace object the workspace falls back to the namespace name.
+	dir := filepath.Join(base, "ns", "ns", contentPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := "entries:\n- scenario: refund\n  contains: [refund]\n- scenario: greet\n  pattern: hello\n"
+	if err := os.WriteFile(filepath.Join(dir, "golden.yaml"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	source := &omniav1alpha1.ArenaSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "src", Namespace: "ns"},
+		Status: omniav1alpha1.ArenaSourceStatus{
+			Artifact: &omniav1alpha1.Artifact{Version: "v1", ContentPath: contentPath},
+		},
+	}
+	dataset := &omniav1alpha1.GoldenDataset{
+		ObjectMeta: metav1.ObjectMeta{Name: "support", Namespace: "ns"},
+		Spec: omniav1alpha1.GoldenDatasetSpec{
+			SourceRef: corev1alpha1.LocalObjectReference{Name: "src"},
+			Path:      "golden.yaml",
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(source, dataset).
+		WithStatusSubresource(&omniav1alpha1.GoldenDataset{}).
+		Build()
+
+	r := &GoldenDatasetReconciler{Client: c, Scheme: scheme, WorkspaceContentPath: base}
+	key := types.NamespacedName{Name: "support", Namespace: "ns"}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	got := &omniav1alpha1.GoldenDataset{}
+	if err := c.Get(context.Background(), key, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != omniav1alpha1.GoldenDatasetPhaseReady {
+		t.Fatalf("phase = %s, want Ready", got.Status.Phase)
+	}
+	if got.Status.ResolvedVersion != "v1" || got.Status.ContentPath != contentPath {
+		t.Errorf("unexpected resolution: version=%q contentPath=%q",
+			got.Status.ResolvedVersion, got.Status.ContentPath)
+	}
+	if got.Status.EntryCount != 2 {
+		t.Errorf("entryCount = %d, want 2", got.Status.EntryCount)
+	}
+}
+
+func TestBuildGoldenDatasetConfig(t *testing.T) {
+	env, mounts, err := buildGoldenDatasetConfig(nil, nil)
+	if err != nil || env != nil || mounts != nil {
+		t.Fatalf("expected no config for empty refs, got env=%v mounts=%v err=%v", env, mounts, err)
+	}
+
+	refs := []golden.Ref{{Name: "support", Version: "v1", Path: "golden.yaml"}}
+	env, mounts, err = buildGoldenDatasetConfig(refs, []string{"ws/ns/arena/.arena/versions/v1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(env) != 1 || env[0].Name != golden.EnvDatasets {
+		t.Fatalf("unexpected env: %+v", env)
+	}
+	var decoded []golden.Ref
+	if err := json.Unmarshal([]byte(env[0].Value), &decoded); err != nil || len(decoded) != 1 || decoded[0] != refs[0] {
+		t.Errorf("env value did not round-trip: %q (%v)", env[0].Value, err)
+	}
+	if len(mounts) != 1 {
+		t.Fatalf("expected 1 mount, got %d", len(mounts))
+	}
+	m := mounts[0]
+	if m.MountPath != "/golden/support" || m.SubPath != "ws/ns/arena/.arena/versions/v1" || !m.ReadOnly {
+		t.Errorf("unexpected mount: %+v", m)
+	}
+}
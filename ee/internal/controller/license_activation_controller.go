@@ -14,6 +14,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -25,9 +26,11 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/altairalabs/omnia/ee/pkg/license"
 )
@@ -63,6 +66,9 @@ type LicenseActivationReconciler struct {
 	LicenseValidator *license.Validator
 	ActivationClient *license.ActivationClient
 	ClusterName      string
+	// Offline activates with a signed offline activation response instead of
+	// the license server, for clusters with no egress to it.
+	Offline bool
 
 	mu                 sync.Mutex
 	activationFailures map[string]*activationBackoff
@@ -107,10 +113,84 @@ func (r *LicenseActivationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return r.handleHeartbeat(ctx, lic, activationState)
 	}
 
-	// Not activated - initiate activation
+	// Not activated - activate offline when configured to, or when an offline
+	// activation response has been supplied anyway
+	response, err := r.getActivationResponse(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if r.Offline || response != "" {
+		return r.activateOffline(ctx, lic, response)
+	}
 	return r.initiateActivation(ctx, lic)
 }
 
+// activateOffline completes an activation with the signed offline activation
+// response from the license Secret. Without one, it records an activation
+// request in the activation ConfigMap for the operator to take to the
+// licensing portal; adding the response to the Secret triggers a reconcile.
+func (r *LicenseActivationReconciler) activateOffline(ctx context.Context, lic *license.License, response string) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	fingerprint, err := license.ClusterFingerprint(ctx, r.Client)
+	if err != nil {
+		return r.handleActivationFailure(ctx, lic.ID, "FingerprintFailed",
+			fmt.Sprintf("Failed to generate cluster fingerprint: %v", err)), nil
+	}
+
+	pending, err := r.getActivationRequest(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if pending == nil || pending.LicenseID != lic.ID || pending.ClusterFingerprint != fingerprint {
+		pending, err = license.NewOfflineActivationRequest(license.ActivationRequest{
+			LicenseID:          lic.ID,
+			ClusterFingerprint: fingerprint,
+			ClusterName:        r.ClusterName,
+			Version:            Version,
+		})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		artifact, err := pending.Encode()
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.saveActivationData(ctx, map[string]string{license.ActivationRequestKey: artifact}); err != nil {
+			log.Error(err, "Failed to save offline activation request")
+			return ctrl.Result{}, err
+		}
+		log.Info("Offline license activation request created", "licenseID", lic.ID, "fingerprint", fingerprint)
+		r.recordEvent(ctx, "Warning", "OfflineActivationRequired", fmt.Sprintf(
+			"Submit the activation request in ConfigMap %s/%s (key %q) to the licensing portal and add the signed response to this Secret under key %q",
+			license.LicenseSecretNamespace, license.ActivationConfigMapName, license.ActivationRequestKey, license.ActivationSecretKey))
+	}
+
+	if response == "" {
+		return ctrl.Result{}, nil
+	}
+
+	state, err := r.LicenseValidator.VerifyOfflineActivation(response, pending)
+	if err != nil {
+		log.Info("Offline activation response rejected", "error", err)
+		r.recordEvent(ctx, "Warning", "OfflineActivationRejected",
+			fmt.Sprintf("Offline activation response rejected: %v", err))
+		// Don't requeue - a new response is required
+		return ctrl.Result{}, nil
+	}
+	if err := r.saveActivationState(ctx, state); err != nil {
+		log.Error(err, "Failed to save activation state")
+		return ctrl.Result{}, err
+	}
+
+	r.resetActivationBackoff(lic.ID)
+	log.Info("License activated offline", "activationID", state.ActivationID)
+	r.recordEvent(ctx, "Normal", "Activated", fmt.Sprintf("License activated offline (ID: %s)", state.ActivationID))
+
+	// Offline activations need no heartbeat
+	return ctrl.Result{}, nil
+}
+
 // initiateActivation activates the license on the license server.
 func (r *LicenseActivationReconciler) initiateActivation(ctx context.Context, lic *license.License) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
@@ -238,6 +318,11 @@ func (r *LicenseActivationReconciler) resetActivationBackoff(licenseID string) {
 func (r *LicenseActivationReconciler) handleHeartbeat(ctx context.Context, lic *license.License, state *license.ActivationState) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
+	// Offline activations never heartbeat
+	if state.Offline {
+		return ctrl.Result{}, nil
+	}
+
 	// Check if heartbeat is needed
 	if !state.NeedsHeartbeat(license.DefaultHeartbeatInterval) {
 		// Calculate time until next heartbeat
@@ -289,7 +374,9 @@ func (r *LicenseActivationReconciler) handleHeartbeat(ctx context.Context, lic *
 	return ctrl.Result{RequeueAfter: license.DefaultHeartbeatInterval}, nil
 }
 
-// getActivationState retrieves the activation state from the ConfigMap.
+// getActivationState retrieves the activation state from the ConfigMap. It
+// returns nil when the ConfigMap holds only a pending offline activation
+// request.
 func (r *LicenseActivationReconciler) getActivationState(ctx context.Context) (*license.ActivationState, error) {
 	cm := &corev1.ConfigMap{}
 	err := r.Get(ctx, types.NamespacedName{
@@ -302,6 +389,9 @@ func (r *LicenseActivationReconciler) getActivationState(ctx context.Context) (*
 
 	data, ok := cm.Data["state"]
 	if !ok {
+		if _, pending := cm.Data[license.ActivationRequestKey]; pending {
+			return nil, nil
+		}
 		return nil, fmt.Errorf("activation state not found in ConfigMap")
 	}
 
@@ -313,13 +403,55 @@ func (r *LicenseActivationReconciler) getActivationState(ctx context.Context) (*
 	return state, nil
 }
 
+// getActivationRequest retrieves the pending offline activation request from
+// the ConfigMap, or nil when there is none.
+func (r *LicenseActivationReconciler) getActivationRequest(ctx context.Context) (*license.OfflineActivationRequest, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      license.ActivationConfigMapName,
+		Namespace: license.LicenseSecretNamespace,
+	}, cm)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	artifact, ok := cm.Data[license.ActivationRequestKey]
+	if !ok {
+		return nil, nil
+	}
+	return license.DecodeOfflineActivationRequest(artifact)
+}
+
+// getActivationResponse returns the signed offline activation response from
+// the license Secret, or "" when there is none.
+func (r *LicenseActivationReconciler) getActivationResponse(ctx context.Context) (string, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      license.LicenseSecretName,
+		Namespace: license.LicenseSecretNamespace,
+	}, secret)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(secret.Data[license.ActivationSecretKey])), nil
+}
+
 // saveActivationState saves the activation state to a ConfigMap.
 func (r *LicenseActivationReconciler) saveActivationState(ctx context.Context, state *license.ActivationState) error {
 	data, err := json.Marshal(state)
 	if err != nil {
 		return fmt.Errorf("failed to marshal activation state: %w", err)
 	}
+	return r.saveActivationData(ctx, map[string]string{"state": string(data)})
+}
 
+// saveActivationData replaces the activation ConfigMap's data, creating the
+// ConfigMap if needed.
+func (r *LicenseActivationReconciler) saveActivationData(ctx context.Context, data map[string]string) error {
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      license.ActivationConfigMapName,
@@ -329,14 +461,12 @@ func (r *LicenseActivationReconciler) saveActivationState(ctx context.Context, s
 				"app.kubernetes.io/component": "license-activation",
 			},
 		},
-		Data: map[string]string{
-			"state": string(data),
-		},
+		Data: data,
 	}
 
 	// Try to get existing ConfigMap
 	existing := &corev1.ConfigMap{}
-	err = r.Get(ctx, types.NamespacedName{
+	err := r.Get(ctx, types.NamespacedName{
 		Name:      license.ActivationConfigMapName,
 		Namespace: license.LicenseSecretNamespace,
 	}, existing)
@@ -378,16 +508,22 @@ func (r *LicenseActivationReconciler) Deactivate(ctx context.Context) error {
 		}
 		return err
 	}
+	if state == nil {
+		return nil // Only an offline activation request is pending
+	}
 
 	log.Info("Deactivating license", "licenseID", state.LicenseID, "activationID", state.ActivationID)
 
-	// Call deactivation API
-	resp, err := r.ActivationClient.Deactivate(ctx, state.LicenseID, state.ClusterFingerprint)
-	if err != nil {
-		log.Error(err, "Failed to deactivate license on server")
-		// Continue to delete local state anyway
-	} else if !resp.Deactivated {
-		log.Info("Deactivation response", "message", resp.Message)
+	// Call deactivation API. An offline activation is released through the
+	// licensing portal instead.
+	if !state.Offline {
+		resp, err := r.ActivationClient.Deactivate(ctx, state.LicenseID, state.ClusterFingerprint)
+		if err != nil {
+			log.Error(err, "Failed to deactivate license on server")
+			// Continue to delete local state anyway
+		} else if !resp.Deactivated {
+			log.Info("Deactivation response", "message", resp.Message)
+		}
 	}
 
 	// Delete activation ConfigMap
@@ -409,8 +545,17 @@ func (r *LicenseActivationReconciler) Deactivate(ctx context.Context) error {
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *LicenseActivationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Reconcile once at startup, so that a file-mounted license activates even
+	// when there is no license Secret to watch.
+	startup := make(chan event.GenericEvent, 1)
+	startup <- event.GenericEvent{Object: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      license.LicenseSecretName,
+		Namespace: license.LicenseSecretNamespace,
+	}}}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("license-activation").
+		WatchesRawSource(source.Channel(startup, &handler.EnqueueRequestForObject{})).
 		// Watch the license Secret
 		Watches(
 			&corev1.Secret{},
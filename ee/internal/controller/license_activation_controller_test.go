@@ -1059,3 +1059,107 @@ func TestLicenseActivationReconciler_HeartbeatGracePeriodExpired(t *testing.T) {
 		t.Error("expected warning event for expired grace period")
 	}
 }
+
+func TestLicenseActivationReconciler_OfflineActivation(t *testing.T) {
+	reconciler, privateKey, server := setupLicenseActivationTest(t)
+	server.Close() // an offline cluster never reaches the license server
+	reconciler.Offline = true
+	recorder := record.NewFakeRecorder(10)
+	reconciler.Recorder = recorder
+
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      license.LicenseSecretName,
+			Namespace: license.LicenseSecretNamespace,
+		},
+		Data: map[string][]byte{
+			license.LicenseSecretKey: []byte(createTestLicenseJWT(t, privateKey, license.TierEnterprise, "lic_test_123")),
+		},
+	}
+	require.NoError(t, reconciler.Create(ctx, secret))
+	req := ctrl.Request{NamespacedName: types.NamespacedName{
+		Name:      license.LicenseSecretName,
+		Namespace: license.LicenseSecretNamespace,
+	}}
+
+	// Without a response, an activation request is recorded for the portal.
+	result, err := reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+	assert.Contains(t, <-recorder.Events, "OfflineActivationRequired")
+
+	cm := &corev1.ConfigMap{}
+	cmKey := types.NamespacedName{Name: license.ActivationConfigMapName, Namespace: license.LicenseSecretNamespace}
+	require.NoError(t, reconciler.Get(ctx, cmKey, cm))
+	pending, err := license.DecodeOfflineActivationRequest(cm.Data[license.ActivationRequestKey])
+	require.NoError(t, err)
+	assert.Equal(t, "lic_test_123", pending.LicenseID)
+
+	// Reconciling again keeps the same request.
+	_, err = reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, reconciler.Get(ctx, cmKey, cm))
+	again, err := license.DecodeOfflineActivationRequest(cm.Data[license.ActivationRequestKey])
+	require.NoError(t, err)
+	assert.Equal(t, pending.Challenge, again.Challenge)
+
+	// The portal signs a response to the request, which goes in the Secret.
+	response, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"jti": "act_offline_1",
+		"lid": pending.LicenseID,
+		"cfp": pending.ClusterFingerprint,
+		"chl": pending.Challenge,
+	}).SignedString(privateKey)
+	require.NoError(t, err)
+	secret.Data[license.ActivationSecretKey] = []byte(response)
+	require.NoError(t, reconciler.Update(ctx, secret))
+
+	result, err = reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result, "offline activations need no heartbeat")
+	assert.Contains(t, <-recorder.Events, "License activated offline")
+
+	require.NoError(t, reconciler.Get(ctx, cmKey, cm))
+	var state license.ActivationState
+	require.NoError(t, json.Unmarshal([]byte(cm.Data["state"]), &state))
+	assert.Equal(t, "act_offline_1", state.ActivationID)
+	assert.True(t, state.Offline)
+
+	result, err = reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+	require.NoError(t, reconciler.Deactivate(ctx))
+}
+
+func TestLicenseActivationReconciler_OfflineActivationRejected(t *testing.T) {
+	reconciler, privateKey, server := setupLicenseActivationTest(t)
+	defer server.Close()
+	recorder := record.NewFakeRecorder(10)
+	reconciler.Recorder = recorder
+
+	ctx := context.Background()
+	response, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"lid": "lic_test_123", "cfp": "another-cluster", "chl": "stale",
+	}).SignedString(privateKey)
+	require.NoError(t, err)
+	require.NoError(t, reconciler.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      license.LicenseSecretName,
+			Namespace: license.LicenseSecretNamespace,
+		},
+		Data: map[string][]byte{
+			license.LicenseSecretKey:    []byte(createTestLicenseJWT(t, privateKey, license.TierEnterprise, "lic_test_123")),
+			license.ActivationSecretKey: []byte(response),
+		},
+	}))
+
+	// A supplied response takes the offline path even when online.
+	_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{
+		Name:      license.LicenseSecretName,
+		Namespace: license.LicenseSecretNamespace,
+	}})
+	require.NoError(t, err)
+	assert.Contains(t, <-recorder.Events, "OfflineActivationRequired")
+	assert.Contains(t, <-recorder.Events, "OfflineActivationRejected")
+}
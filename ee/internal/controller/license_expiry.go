@@ -0,0 +1,42 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/altairalabs/omnia/ee/pkg/license"
+)
+
+// ConditionTypeLicenseExpiring is set on licensed resources while the license
+// is about to expire or is in its grace period, and removed otherwise.
+const ConditionTypeLicenseExpiring = "LicenseExpiring"
+
+// reportLicenseExpiry sets the LicenseExpiring condition and emits a Warning
+// Event on a resource that passed its license check while the license is about
+// to expire or in its grace period, and clears the condition otherwise. The
+// caller persists the condition with the rest of the resource's status.
+func reportLicenseExpiry(ctx context.Context, v *license.Validator, recorder record.EventRecorder,
+	obj runtime.Object, generation int64, conditions *[]metav1.Condition) {
+	reason, message := v.ExpiryWarning(ctx)
+	if reason == "" {
+		meta.RemoveStatusCondition(conditions, ConditionTypeLicenseExpiring)
+		return
+	}
+	SetCondition(conditions, generation, ConditionTypeLicenseExpiring, metav1.ConditionTrue, reason, message)
+	if recorder != nil {
+		recorder.Event(obj, corev1.EventTypeWarning, reason, message)
+	}
+}
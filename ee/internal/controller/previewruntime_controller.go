@@ -0,0 +1,477 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	corev1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/license"
+	"github.com/altairalabs/omnia/internal/promptpack/packselect"
+	"github.com/altairalabs/omnia/internal/sourcesync"
+)
+
+const (
+	// labelPreviewRuntime marks the AgentRuntime serving a preview with the
+	// owning PreviewRuntime's name.
+	labelPreviewRuntime = "omnia.altairalabs.ai/preview-runtime"
+	// previewRuntimeSuffix is appended to the PreviewRuntime name to form the
+	// preview AgentRuntime name; previewPackPrefix prefixes the logical pack
+	// name its PromptPacks are published under.
+	previewRuntimeSuffix = "-preview"
+	previewPackPrefix    = "preview-"
+
+	defaultPreviewTTL      = 72 * time.Hour
+	defaultPreviewInterval = 5 * time.Minute
+)
+
+// PreviewRuntime event reasons.
+const (
+	eventReasonPreviewUpdated = "PreviewUpdated"
+	eventReasonPreviewExpired = "Expired"
+)
+
+// PreviewRuntimeReconciler reconciles a PreviewRuntime: it builds a PromptPack
+// from the configured Git ref, runs a clone of the referenced AgentRuntime
+// pinned to it behind its own external host, and deletes the whole preview
+// once its TTL elapses.
+type PreviewRuntimeReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// LicenseValidator gates the git source type. Nil disables the check.
+	LicenseValidator *license.Validator
+
+	// FetcherFor lets tests inject a fake fetcher. When nil the real git
+	// fetcher is used.
+	FetcherFor func(ctx context.Context, pr *omniav1alpha1.PreviewRuntime) (sourcesync.Fetcher, error)
+}
+
+// +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=previewruntimes,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=previewruntimes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=previewruntimes/finalizers,verbs=update
+// +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=agentruntimes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=promptpacks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile expires, refreshes and observes one preview.
+func (r *PreviewRuntimeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	log.V(1).Info("reconciling PreviewRuntime", "name", req.Name, "namespace", req.Namespace)
+
+	pr := &omniav1alpha1.PreviewRuntime{}
+	if err := r.Get(ctx, req.NamespacedName, pr); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if !pr.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	expiresAt := pr.CreationTimestamp.Add(parseDurationOr(pr.Spec.TTL, defaultPreviewTTL))
+	if !time.Now().Before(expiresAt) {
+		return r.expire(ctx, pr)
+	}
+
+	if pr.Status.Phase == "" {
+		pr.Status.Phase = omniav1alpha1.PreviewRuntimePhasePending
+	}
+	expires := metav1.NewTime(expiresAt)
+	pr.Status.ExpiresAt = &expires
+	specChanged := pr.Status.ObservedGeneration != pr.Generation
+	pr.Status.ObservedGeneration = pr.Generation
+
+	if r.LicenseValidator != nil {
+		if err := r.LicenseValidator.ValidatePromptPackSource(ctx, string(omniav1alpha1.PromptPackSourceTypeGit)); err != nil {
+			// License must change — do not requeue.
+			return r.setErrorStatus(ctx, pr, reasonLicenseViolation, err, 0)
+		}
+		reportLicenseExpiry(ctx, r.LicenseValidator, r.Recorder, pr, pr.Generation, &pr.Status.Conditions)
+	}
+
+	interval := parseDurationOr(pr.Spec.Interval, defaultPreviewInterval)
+	requeue := min(interval, time.Until(expiresAt))
+
+	// Owned-runtime status changes also land here; only re-fetch the ref when
+	// the spec changed or the poll interval elapsed.
+	if specChanged || pr.Status.LastFetchTime == nil || time.Since(pr.Status.LastFetchTime.Time) >= interval {
+		failed, err := r.refresh(ctx, pr)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if failed {
+			return ctrl.Result{RequeueAfter: requeue}, nil
+		}
+	}
+
+	if err := r.observeRuntime(ctx, pr); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Status().Update(ctx, pr); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// expire deletes an expired preview. The AgentRuntime, PromptPacks and
+// ConfigMaps it created are owned by it and garbage-collected with it.
+func (r *PreviewRuntimeReconciler) expire(ctx context.Context, pr *omniav1alpha1.PreviewRuntime) (ctrl.Result, error) {
+	logf.FromContext(ctx).Info("PreviewRuntime TTL elapsed, deleting", "name", pr.Name)
+	if r.Recorder != nil {
+		r.Recorder.Event(pr, corev1.EventTypeNormal, eventReasonPreviewExpired,
+			fmt.Sprintf("ttl %s elapsed", pr.Spec.TTL))
+	}
+	if err := r.Delete(ctx, pr); err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// refresh fetches the ref, materializes its pack as a preview version and
+// points the preview AgentRuntime at it. failed reports that an error status
+// has been written and reconciliation should stop until the next requeue.
+func (r *PreviewRuntimeReconciler) refresh(ctx context.Context, pr *omniav1alpha1.PreviewRuntime) (failed bool, err error) {
+	fail := func(reason string, cause error) (bool, error) {
+		_, err := r.setErrorStatus(ctx, pr, reason, cause, 0)
+		return true, err
+	}
+
+	base := &corev1alpha1.AgentRuntime{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: pr.Namespace, Name: pr.Spec.RuntimeRef.Name}, base); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		return fail("RuntimeNotFound", err)
+	}
+
+	packJSON, artifact, reason, err := r.fetchPack(ctx, pr)
+	if err != nil {
+		return fail(reason, err)
+	}
+	packVersion, err := parsePackVersion(packJSON)
+	if err != nil {
+		return fail("InvalidPackJSON", err)
+	}
+	if packVersion == "" {
+		return fail(reasonMissingVersion, errMissingVersion)
+	}
+	version, err := previewVersion(packVersion, packJSON)
+	if err != nil {
+		return fail("InvalidVersion", err)
+	}
+
+	packName := previewPackPrefix + pr.Name
+	if err := r.materialize(ctx, pr, packName, version, packJSON); err != nil {
+		return fail("Materialize", err)
+	}
+	if err := r.ensureRuntime(ctx, pr, base, packName, version); err != nil {
+		return fail("RuntimeUpdate", err)
+	}
+	if err := r.pruneVersions(ctx, pr, packName, version); err != nil {
+		logf.FromContext(ctx).Error(err, "preview version prune failed")
+	}
+
+	if pr.Status.Version != version && r.Recorder != nil {
+		r.Recorder.Event(pr, corev1.EventTypeNormal, eventReasonPreviewUpdated,
+			fmt.Sprintf("preview pinned to version %s", version))
+	}
+	now := metav1.Now()
+	pr.Status.RuntimeName = pr.Name + previewRuntimeSuffix
+	pr.Status.PackName = packName
+	pr.Status.Version = version
+	pr.Status.LastFetchTime = &now
+	pr.Status.Artifact = &corev1alpha1.Artifact{
+		Revision:       artifact.Revision,
+		Version:        version,
+		Checksum:       artifact.Checksum,
+		Size:           artifact.Size,
+		LastUpdateTime: now,
+	}
+	return false, nil
+}
+
+// fetchPack fetches the ref and returns its pack.json, the fetched artifact
+// and, on failure, the condition reason to report.
+func (r *PreviewRuntimeReconciler) fetchPack(ctx context.Context, pr *omniav1alpha1.PreviewRuntime) ([]byte, *sourcesync.Artifact, string, error) {
+	fetcher, err := r.fetcherFor(ctx, pr)
+	if err != nil {
+		return nil, nil, "FetcherBuild", err
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, parseTimeout(pr.Spec.Timeout))
+	defer cancel()
+
+	rev, _ := fetcher.LatestRevision(fetchCtx)
+	artifact, err := fetcher.Fetch(fetchCtx, rev)
+	if err != nil {
+		return nil, nil, "Fetch", err
+	}
+	if artifact.Path != "" && !artifact.Preserve {
+		defer func() { _ = os.RemoveAll(artifact.Path) }()
+	}
+	if artifact.Revision == "" {
+		artifact.Revision = rev
+	}
+
+	packJSON, err := os.ReadFile(filepath.Join(artifact.Path, packJSONKey))
+	if err != nil {
+		return nil, nil, "ReadPackJSON", err
+	}
+	return packJSON, artifact, "", nil
+}
+
+// materialize creates the preview's backing ConfigMap and PromptPack
+// version-object, both owned by the PreviewRuntime. AlreadyExists is a benign
+// no-op: the object name is derived from the content-addressed version.
+func (r *PreviewRuntimeReconciler) materialize(ctx context.Context, pr *omniav1alpha1.PreviewRuntime, packName, version string, packJSON []byte) error {
+	objName := corev1alpha1.PromptPackObjectName(packName, version)
+	cmName := objName + contentSuffix
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmName,
+			Namespace: pr.Namespace,
+			Labels: map[string]string{
+				labelPromptPackManagedBy: managedByPromptPack,
+				labelPromptPackName:      packName,
+				labelPreviewRuntime:      pr.Name,
+			},
+		},
+		Data: map[string]string{packJSONKey: string(packJSON)},
+	}
+	if err := controllerutil.SetControllerReference(pr, cm, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, cm); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	pp := &corev1alpha1.PromptPack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      objName,
+			Namespace: pr.Namespace,
+			Labels: map[string]string{
+				labelPromptPackName: packName,
+				labelPreviewRuntime: pr.Name,
+			},
+		},
+		Spec: corev1alpha1.PromptPackSpec{
+			PackName: packName,
+			Version:  version,
+			Source: corev1alpha1.PromptPackContentSource{
+				Type:         corev1alpha1.PromptPackSourceTypeConfigMap,
+				ConfigMapRef: &corev1.LocalObjectReference{Name: cmName},
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(pr, pp, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, pp); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// ensureRuntime creates or updates the preview AgentRuntime as a copy of base
+// pinned to the preview version and exposed externally on its own host.
+func (r *PreviewRuntimeReconciler) ensureRuntime(ctx context.Context, pr *omniav1alpha1.PreviewRuntime, base *corev1alpha1.AgentRuntime, packName, version string) error {
+	ar := &corev1alpha1.AgentRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: pr.Name + previewRuntimeSuffix, Namespace: pr.Namespace},
+	}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, ar, func() error {
+		if ar.Labels == nil {
+			ar.Labels = map[string]string{}
+		}
+		ar.Labels[labelPreviewRuntime] = pr.Name
+		ar.Spec = previewRuntimeSpec(&base.Spec, packName, version)
+		return controllerutil.SetControllerReference(pr, ar, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+	logf.FromContext(ctx).V(1).Info("preview AgentRuntime reconciled", "name", ar.Name, "result", result)
+	return nil
+}
+
+// pruneVersions deletes the preview's PromptPack version-objects (and their
+// ConfigMaps) superseded by a newer push. Only the current version is kept:
+// nothing but the preview runtime references them.
+func (r *PreviewRuntimeReconciler) pruneVersions(ctx context.Context, pr *omniav1alpha1.PreviewRuntime, packName, version string) error {
+	var packs corev1alpha1.PromptPackList
+	if err := r.List(ctx, &packs,
+		client.InNamespace(pr.Namespace),
+		client.MatchingLabels{labelPromptPackName: packName, labelPreviewRuntime: pr.Name}); err != nil {
+		return err
+	}
+	for i := range packs.Items {
+		if packs.Items[i].Spec.Version == version {
+			continue
+		}
+		if err := deletePackAndContent(ctx, r.Client, &packs.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// observeRuntime mirrors the preview AgentRuntime's endpoints and readiness
+// onto the PreviewRuntime status.
+func (r *PreviewRuntimeReconciler) observeRuntime(ctx context.Context, pr *omniav1alpha1.PreviewRuntime) error {
+	if pr.Status.RuntimeName == "" {
+		return nil
+	}
+	ar := &corev1alpha1.AgentRuntime{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: pr.Namespace, Name: pr.Status.RuntimeName}, ar); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	pr.Status.ServiceEndpoint = ar.Status.ServiceEndpoint
+	pr.Status.Endpoints = nil
+	if ar.Status.Facade != nil {
+		pr.Status.Endpoints = ar.Status.Facade.Endpoints
+	}
+	pr.Status.URL = firstValidEndpoint(pr.Status.Endpoints)
+
+	switch ar.Status.Phase {
+	case corev1alpha1.AgentRuntimePhaseRunning:
+		pr.Status.Phase = omniav1alpha1.PreviewRuntimePhaseReady
+		SetCondition(&pr.Status.Conditions, pr.Generation, omniav1alpha1.PreviewRuntimeConditionReady,
+			metav1.ConditionTrue, "Ready", fmt.Sprintf("preview running version %s", pr.Status.Version))
+	case corev1alpha1.AgentRuntimePhaseFailed:
+		pr.Status.Phase = omniav1alpha1.PreviewRuntimePhaseError
+		SetCondition(&pr.Status.Conditions, pr.Generation, omniav1alpha1.PreviewRuntimeConditionReady,
+			metav1.ConditionFalse, "RuntimeFailed", fmt.Sprintf("AgentRuntime %s failed", ar.Name))
+	default:
+		pr.Status.Phase = omniav1alpha1.PreviewRuntimePhasePending
+		SetCondition(&pr.Status.Conditions, pr.Generation, omniav1alpha1.PreviewRuntimeConditionReady,
+			metav1.ConditionFalse, "RuntimePending", fmt.Sprintf("waiting for AgentRuntime %s", ar.Name))
+	}
+	return nil
+}
+
+// setErrorStatus records an error state and requeues after the given duration
+// (0 = no requeue). Returns nil error so the loop does not hot-cycle.
+func (r *PreviewRuntimeReconciler) setErrorStatus(ctx context.Context, pr *omniav1alpha1.PreviewRuntime, reason string, cause error, requeue time.Duration) (ctrl.Result, error) {
+	pr.Status.Phase = omniav1alpha1.PreviewRuntimePhaseError
+	SetCondition(&pr.Status.Conditions, pr.Generation, omniav1alpha1.PreviewRuntimeConditionReady,
+		metav1.ConditionFalse, reason, cause.Error())
+	if r.Recorder != nil {
+		r.Recorder.Event(pr, corev1.EventTypeWarning, reason, cause.Error())
+	}
+	if err := r.Status().Update(ctx, pr); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// fetcherFor returns the injected fetcher factory when set, else a real git fetcher.
+func (r *PreviewRuntimeReconciler) fetcherFor(ctx context.Context, pr *omniav1alpha1.PreviewRuntime) (sourcesync.Fetcher, error) {
+	if r.FetcherFor != nil {
+		return r.FetcherFor(ctx, pr)
+	}
+	opts := sourcesync.DefaultOptions()
+	opts.Timeout = parseTimeout(pr.Spec.Timeout)
+	return newGitFetcher(ctx, r.Client, pr.Namespace, &pr.Spec.Git, opts)
+}
+
+// previewRuntimeSpec copies base with the PromptPack pinned to the preview
+// version, every facade exposed on the generated per-agent host, and any
+// rollout dropped — a preview serves exactly one version.
+func previewRuntimeSpec(base *corev1alpha1.AgentRuntimeSpec, packName, version string) corev1alpha1.AgentRuntimeSpec {
+	spec := *base.DeepCopy()
+	spec.PromptPackRef = corev1alpha1.PromptPackRef{Name: packName, Version: &version}
+	spec.Rollout = nil
+	for i := range spec.Facades {
+		// A host override is the base agent's; the preview must get its own.
+		spec.Facades[i].Expose = &corev1alpha1.FacadeExposeConfig{Enabled: true}
+	}
+	return spec
+}
+
+// previewVersion derives the content-addressed preview version from the pack's
+// own version: the pack.json digest is appended as a prerelease identifier
+// (1.2.0 -> 1.2.0-preview.g<digest>), so every push that changes the pack gets
+// a new, immutable version-object while re-polls of an unchanged ref are no-ops.
+func previewVersion(packVersion string, packJSON []byte) (string, error) {
+	v, err := packselect.ParseVersion(packVersion)
+	if err != nil {
+		return "", fmt.Errorf("pack version %q is not semver: %w", packVersion, err)
+	}
+	sum := sha256.Sum256(packJSON)
+	pre := "preview.g" + hex.EncodeToString(sum[:])[:12]
+	if v.Prerelease() != "" {
+		pre = v.Prerelease() + "." + pre
+	}
+	nv, err := v.SetPrerelease(pre)
+	if err != nil {
+		return "", err
+	}
+	nv, err = nv.SetMetadata("")
+	if err != nil {
+		return "", err
+	}
+	return nv.String(), nil
+}
+
+// firstValidEndpoint returns the URL of the first valid endpoint, or "".
+func firstValidEndpoint(endpoints []corev1alpha1.FacadeEndpoint) string {
+	for _, ep := range endpoints {
+		if ep.Valid {
+			return ep.URL
+		}
+	}
+	return ""
+}
+
+// parseDurationOr parses s, returning def when s is empty or invalid.
+func parseDurationOr(s string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d
+	}
+	return def
+}
+
+// SetupWithManager registers the reconciler with a controller-runtime manager.
+// Status writes are filtered out of the PreviewRuntime watch; owned
+// AgentRuntime changes re-observe the preview's endpoints.
+func (r *PreviewRuntimeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&omniav1alpha1.PreviewRuntime{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&corev1alpha1.AgentRuntime{}).
+		Named("previewruntime").
+		Complete(r)
+}
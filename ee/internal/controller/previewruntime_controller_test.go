@@ -0,0 +1,279 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/sourcesync"
+)
+
+var _ = Describe("PreviewRuntime Controller", func() {
+	ctx := context.Background()
+	const (
+		ns       = "default"
+		baseName = "preview-base"
+	)
+
+	newReconciler := func(f sourcesync.Fetcher) *PreviewRuntimeReconciler {
+		return &PreviewRuntimeReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+			FetcherFor: func(context.Context, *omniav1alpha1.PreviewRuntime) (sourcesync.Fetcher, error) {
+				return f, nil
+			},
+		}
+	}
+
+	newPreview := func(name, ttl string) *omniav1alpha1.PreviewRuntime {
+		return &omniav1alpha1.PreviewRuntime{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Spec: omniav1alpha1.PreviewRuntimeSpec{
+				RuntimeRef: corev1.LocalObjectReference{Name: baseName},
+				Git: corev1alpha1.GitSource{
+					URL: "https://github.com/example/repo.git",
+					Ref: &corev1alpha1.GitReference{Branch: "feature"},
+				},
+				TTL: ttl,
+			},
+		}
+	}
+
+	reconcileOnce := func(r *PreviewRuntimeReconciler, name string) (reconcile.Result, error) {
+		return r.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: name, Namespace: ns},
+		})
+	}
+
+	getPreview := func(name string) *omniav1alpha1.PreviewRuntime {
+		pr := &omniav1alpha1.PreviewRuntime{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: ns}, pr)).To(Succeed())
+		return pr
+	}
+
+	BeforeEach(func() {
+		port := int32(8080)
+		base := &corev1alpha1.AgentRuntime{
+			ObjectMeta: metav1.ObjectMeta{Name: baseName, Namespace: ns},
+			Spec: corev1alpha1.AgentRuntimeSpec{
+				PromptPackRef: corev1alpha1.PromptPackRef{Name: "support", Track: ptr.To("stable")},
+				Facades: []corev1alpha1.FacadeConfig{{
+					Type:   corev1alpha1.FacadeType("websocket"),
+					Port:   &port,
+					Expose: &corev1alpha1.FacadeExposeConfig{Enabled: true, Host: "support.example.com"},
+				}},
+				Rollout: &corev1alpha1.RolloutConfig{
+					Steps: []corev1alpha1.RolloutStep{{SetWeight: ptr.To(int32(10))}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, base)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.DeleteAllOf(ctx, &omniav1alpha1.PreviewRuntime{}, client.InNamespace(ns))).To(Succeed())
+		Expect(k8sClient.DeleteAllOf(ctx, &corev1alpha1.AgentRuntime{}, client.InNamespace(ns))).To(Succeed())
+		Expect(k8sClient.DeleteAllOf(ctx, &corev1alpha1.PromptPack{},
+			client.InNamespace(ns), client.HasLabels{labelPreviewRuntime})).To(Succeed())
+		Expect(k8sClient.DeleteAllOf(ctx, &corev1.ConfigMap{},
+			client.InNamespace(ns), client.HasLabels{labelPreviewRuntime})).To(Succeed())
+	})
+
+	Context("When the ref holds a valid pack", func() {
+		const name = "pr-create"
+
+		It("materializes a preview version and a pinned, exposed AgentRuntime", func() {
+			Expect(k8sClient.Create(ctx, newPreview(name, ""))).To(Succeed())
+
+			pack := `{"name":"support","version":"1.2.0"}`
+			result, err := reconcileOnce(newReconciler(&fakeFetcher{rev: "feature@sha1:abc", pack: pack}), name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(defaultPreviewInterval))
+
+			version, err := previewVersion("1.2.0", []byte(pack))
+			Expect(err).NotTo(HaveOccurred())
+			packName := previewPackPrefix + name
+
+			By("checking the owned PromptPack version-object")
+			pp := &corev1alpha1.PromptPack{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name: corev1alpha1.PromptPackObjectName(packName, version), Namespace: ns}, pp)).To(Succeed())
+			Expect(pp.Spec.PackName).To(Equal(packName))
+			Expect(pp.Spec.Version).To(Equal(version))
+			Expect(metav1.GetControllerOf(pp).Name).To(Equal(name))
+
+			By("checking the preview AgentRuntime")
+			ar := &corev1alpha1.AgentRuntime{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name + previewRuntimeSuffix, Namespace: ns}, ar)).To(Succeed())
+			Expect(ar.Labels[labelPreviewRuntime]).To(Equal(name))
+			Expect(ar.Spec.PromptPackRef.Name).To(Equal(packName))
+			Expect(ar.Spec.PromptPackRef.Version).To(HaveValue(Equal(version)))
+			Expect(ar.Spec.Rollout).To(BeNil())
+			Expect(ar.Spec.Facades[0].Expose).To(Equal(&corev1alpha1.FacadeExposeConfig{Enabled: true}))
+			Expect(metav1.GetControllerOf(ar).Name).To(Equal(name))
+
+			By("checking status")
+			pr := getPreview(name)
+			Expect(pr.Status.Phase).To(Equal(omniav1alpha1.PreviewRuntimePhasePending))
+			Expect(pr.Status.RuntimeName).To(Equal(name + previewRuntimeSuffix))
+			Expect(pr.Status.Version).To(Equal(version))
+			Expect(pr.Status.Artifact.Revision).To(Equal("feature@sha1:abc"))
+			Expect(pr.Status.ExpiresAt.Time).To(BeTemporally("~", pr.CreationTimestamp.Add(defaultPreviewTTL), time.Second))
+		})
+	})
+
+	Context("When the ref advances", func() {
+		const name = "pr-advance"
+
+		It("repoints the runtime and prunes the superseded version", func() {
+			Expect(k8sClient.Create(ctx, newPreview(name, ""))).To(Succeed())
+			packName := previewPackPrefix + name
+
+			oldPack := `{"version":"1.2.0","prompts":{"a":"one"}}`
+			_, err := reconcileOnce(newReconciler(&fakeFetcher{rev: "r1", pack: oldPack}), name)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("forcing the next reconcile to re-fetch")
+			pr := getPreview(name)
+			pr.Status.LastFetchTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+			Expect(k8sClient.Status().Update(ctx, pr)).To(Succeed())
+
+			newPack := `{"version":"1.2.0","prompts":{"a":"two"}}`
+			_, err = reconcileOnce(newReconciler(&fakeFetcher{rev: "r2", pack: newPack}), name)
+			Expect(err).NotTo(HaveOccurred())
+
+			oldVersion, _ := previewVersion("1.2.0", []byte(oldPack))
+			newVersion, _ := previewVersion("1.2.0", []byte(newPack))
+			Expect(newVersion).NotTo(Equal(oldVersion))
+
+			var packs corev1alpha1.PromptPackList
+			Expect(k8sClient.List(ctx, &packs, client.InNamespace(ns),
+				client.MatchingLabels{labelPromptPackName: packName})).To(Succeed())
+			Expect(packs.Items).To(HaveLen(1))
+			Expect(packs.Items[0].Spec.Version).To(Equal(newVersion))
+
+			ar := &corev1alpha1.AgentRuntime{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name + previewRuntimeSuffix, Namespace: ns}, ar)).To(Succeed())
+			Expect(ar.Spec.PromptPackRef.Version).To(HaveValue(Equal(newVersion)))
+		})
+	})
+
+	Context("When the base runtime does not exist", func() {
+		const name = "pr-missing-base"
+
+		It("reports RuntimeNotFound", func() {
+			pr := newPreview(name, "")
+			pr.Spec.RuntimeRef.Name = "does-not-exist"
+			Expect(k8sClient.Create(ctx, pr)).To(Succeed())
+
+			_, err := reconcileOnce(newReconciler(&fakeFetcher{pack: `{"version":"1.0.0"}`}), name)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := getPreview(name)
+			Expect(updated.Status.Phase).To(Equal(omniav1alpha1.PreviewRuntimePhaseError))
+			cond := meta.FindStatusCondition(updated.Status.Conditions, omniav1alpha1.PreviewRuntimeConditionReady)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal("RuntimeNotFound"))
+		})
+	})
+
+	Context("When the TTL has elapsed", func() {
+		const name = "pr-expired"
+
+		It("deletes the PreviewRuntime", func() {
+			Expect(k8sClient.Create(ctx, newPreview(name, "1ms"))).To(Succeed())
+
+			_, err := reconcileOnce(newReconciler(&fakeFetcher{pack: `{"version":"1.0.0"}`}), name)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: ns}, &omniav1alpha1.PreviewRuntime{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
+
+func TestPreviewVersion(t *testing.T) {
+	pack := []byte(`{"version":"1.2.0"}`)
+	tests := []struct {
+		name, in, prefix string
+	}{
+		{"release", "1.2.0", "1.2.0-preview.g"},
+		{"v-prefixed", "v1.2.0", "1.2.0-preview.g"},
+		{"existing prerelease", "1.2.0-rc.1", "1.2.0-rc.1.preview.g"},
+		{"build metadata dropped", "1.2.0+build.7", "1.2.0-preview.g"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := previewVersion(tt.in, pack)
+			if err != nil {
+				t.Fatalf("previewVersion(%q) error: %v", tt.in, err)
+			}
+			if !strings.HasPrefix(got, tt.prefix) || len(got) != len(tt.prefix)+12 {
+				t.Errorf("previewVersion(%q) = %q, want %s<12 hex>", tt.in, got, tt.prefix)
+			}
+		})
+	}
+
+	a, _ := previewVersion("1.2.0", []byte(`{"version":"1.2.0","a":1}`))
+	b, _ := previewVersion("1.2.0", []byte(`{"version":"1.2.0","a":2}`))
+	if a == b {
+		t.Errorf("different pack contents produced the same version %q", a)
+	}
+
+	if _, err := previewVersion("latest", pack); err == nil {
+		t.Error("expected an error for a non-semver version")
+	}
+}
+
+func TestPreviewRuntimeSpec(t *testing.T) {
+	base := &corev1alpha1.AgentRuntimeSpec{
+		PromptPackRef: corev1alpha1.PromptPackRef{Name: "support", Track: ptr.To("stable")},
+		Facades: []corev1alpha1.FacadeConfig{
+			{Type: "websocket", Expose: &corev1alpha1.FacadeExposeConfig{Enabled: true, Host: "support.example.com"}},
+			{Type: "a2a"},
+		},
+		Rollout: &corev1alpha1.RolloutConfig{},
+	}
+
+	spec := previewRuntimeSpec(base, "preview-pr-1", "1.2.0-preview.gabc")
+
+	if spec.PromptPackRef.Name != "preview-pr-1" || spec.PromptPackRef.Track != nil ||
+		spec.PromptPackRef.Version == nil || *spec.PromptPackRef.Version != "1.2.0-preview.gabc" {
+		t.Errorf("promptPackRef = %+v, want pinned preview version", spec.PromptPackRef)
+	}
+	if spec.Rollout != nil {
+		t.Error("rollout should be dropped")
+	}
+	for i, f := range spec.Facades {
+		if f.Expose == nil || !f.Expose.Enabled || f.Expose.Host != "" {
+			t.Errorf("facade %d expose = %+v, want enabled without host", i, f.Expose)
+		}
+	}
+	if base.Facades[0].Expose.Host != "support.example.com" || base.Rollout == nil {
+		t.Error("base spec was mutated")
+	}
+}
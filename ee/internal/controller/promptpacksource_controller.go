@@ -120,6 +120,7 @@ func (r *PromptPackSourceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			// License must change — do not requeue.
 			return r.setErrorStatus(ctx, src, reasonLicenseViolation, err, 0)
 		}
+		reportLicenseExpiry(ctx, r.LicenseValidator, r.Recorder, src, src.Generation, &src.Status.Conditions)
 	}
 
 	interval, err := time.ParseDuration(src.Spec.Interval)
@@ -291,20 +292,26 @@ func (r *PromptPackSourceReconciler) gitFetcher(ctx context.Context, src *omniav
 	if src.Spec.Git == nil {
 		return nil, fmt.Errorf("git source missing spec.git")
 	}
+	return newGitFetcher(ctx, r.Client, src.Namespace, src.Spec.Git, opts)
+}
+
+// newGitFetcher builds a git fetcher for git, loading credentials from its
+// secretRef in namespace when set.
+func newGitFetcher(ctx context.Context, c client.Reader, namespace string, git *corev1alpha1.GitSource, opts sourcesync.Options) (sourcesync.Fetcher, error) {
 	cfg := sourcesync.GitFetcherConfig{
-		URL:     src.Spec.Git.URL,
-		Path:    src.Spec.Git.Path,
+		URL:     git.URL,
+		Path:    git.Path,
 		Options: opts,
 	}
-	if src.Spec.Git.Ref != nil {
+	if git.Ref != nil {
 		cfg.Ref = sourcesync.GitRef{
-			Branch: src.Spec.Git.Ref.Branch,
-			Tag:    src.Spec.Git.Ref.Tag,
-			Commit: src.Spec.Git.Ref.Commit,
+			Branch: git.Ref.Branch,
+			Tag:    git.Ref.Tag,
+			Commit: git.Ref.Commit,
 		}
 	}
-	if src.Spec.Git.SecretRef != nil {
-		creds, err := sourcesync.LoadGitCredentials(ctx, r.Client, src.Namespace, src.Spec.Git.SecretRef.Name)
+	if git.SecretRef != nil {
+		creds, err := sourcesync.LoadGitCredentials(ctx, c, namespace, git.SecretRef.Name)
 		if err != nil {
 			return nil, fmt.Errorf("load git credentials: %w", err)
 		}
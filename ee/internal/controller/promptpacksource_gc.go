@@ -97,7 +97,7 @@ func (r *PromptPackSourceReconciler) deleteCandidates(ctx context.Context, candi
 		if supersededAt(pp).After(cutoff) {
 			continue // superseded more recently than the min-age guard
 		}
-		if err := r.deletePackAndContent(ctx, pp); err != nil {
+		if err := deletePackAndContent(ctx, r.Client, pp); err != nil {
 			return err
 		}
 	}
@@ -117,14 +117,14 @@ func supersededAt(pp *corev1alpha1.PromptPack) time.Time {
 
 // deletePackAndContent deletes a PromptPack version-object and its backing
 // -content ConfigMap. A NotFound on either is treated as already-collected.
-func (r *PromptPackSourceReconciler) deletePackAndContent(ctx context.Context, pp *corev1alpha1.PromptPack) error {
+func deletePackAndContent(ctx context.Context, c client.Writer, pp *corev1alpha1.PromptPack) error {
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{Name: pp.Name + contentSuffix, Namespace: pp.Namespace},
 	}
-	if err := r.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+	if err := c.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
 		return err
 	}
-	if err := r.Delete(ctx, pp); err != nil && !apierrors.IsNotFound(err) {
+	if err := c.Delete(ctx, pp); err != nil && !apierrors.IsNotFound(err) {
 		return err
 	}
 	return nil
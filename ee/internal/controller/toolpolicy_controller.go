@@ -93,9 +93,13 @@ func (r *ToolPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 func (r *ToolPolicyReconciler) validateAndCompile(
 	_ context.Context, tp *omniav1alpha1.ToolPolicy,
 ) error {
-	for _, rule := range tp.Spec.Rules {
-		if err := r.Evaluator.ValidateCEL(rule.Deny.CEL); err != nil {
-			return fmt.Errorf("rule %q: %w", rule.Name, err)
+	// With the opa engine the rules are ignored, so they are not validated;
+	// CompilePolicy validates the OPA configuration instead.
+	if tp.Spec.Engine != omniav1alpha1.PolicyEngineOPA {
+		for _, rule := range tp.Spec.Rules {
+			if err := r.Evaluator.ValidateCEL(rule.Deny.CEL); err != nil {
+				return fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
 		}
 	}
 	if err := validateHeaderInjectionRules(r.Evaluator, tp.Spec.HeaderInjection); err != nil {
@@ -223,6 +227,11 @@ func (r *ToolPolicyReconciler) handleCompileError(
 func (r *ToolPolicyReconciler) setSuccessStatus(tp *omniav1alpha1.ToolPolicy) {
 	ruleCount := int32(len(tp.Spec.Rules))
 	msg := fmt.Sprintf("all %d rules compiled successfully", ruleCount)
+	if tp.Spec.Engine == omniav1alpha1.PolicyEngineOPA {
+		// OPA makes the decision; the rules, if any, are ignored.
+		ruleCount = 0
+		msg = fmt.Sprintf("OPA decision %s configured", tp.Spec.OPA.Path)
+	}
 
 	SetCondition(&tp.Status.Conditions, tp.Generation,
 		toolPolicyConditionCompiled, metav1.ConditionTrue,
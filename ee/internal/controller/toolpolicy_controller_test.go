@@ -102,6 +102,71 @@ var _ = Describe("ToolPolicy Controller", func() {
 		})
 	})
 
+	Context("When reconciling an OPA-engine ToolPolicy", func() {
+		It("should set Active phase without compiling rules", func() {
+			policyName := "test-opa-policy"
+			tp := &omniav1alpha1.ToolPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      policyName,
+					Namespace: "default",
+				},
+				Spec: omniav1alpha1.ToolPolicySpec{
+					Selector: omniav1alpha1.ToolPolicySelector{
+						Registry: "customer-tools",
+					},
+					Engine: omniav1alpha1.PolicyEngineOPA,
+					OPA: &omniav1alpha1.OPAPolicyConfig{
+						URL:  "http://opa.policy:8181",
+						Path: "omnia/tools/decision",
+					},
+					Mode:      omniav1alpha1.PolicyModeEnforce,
+					OnFailure: omniav1alpha1.OnFailureDeny,
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, tp)).To(Succeed())
+			DeferCleanup(func() {
+				_ = k8sClient.Delete(ctx, tp)
+			})
+
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      policyName,
+					Namespace: "default",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &omniav1alpha1.ToolPolicy{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name:      policyName,
+					Namespace: "default",
+				}, updated)).To(Succeed())
+				g.Expect(updated.Status.Phase).To(Equal(omniav1alpha1.ToolPolicyPhaseActive))
+				g.Expect(updated.Status.RuleCount).To(BeZero())
+			}, timeout, interval).Should(Succeed())
+
+			Expect(evaluator.PolicyCount()).To(Equal(1))
+		})
+
+		It("should be rejected by CRD validation without opa settings", func() {
+			tp := &omniav1alpha1.ToolPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-opa-missing-config",
+					Namespace: "default",
+				},
+				Spec: omniav1alpha1.ToolPolicySpec{
+					Selector: omniav1alpha1.ToolPolicySelector{
+						Registry: "customer-tools",
+					},
+					Engine: omniav1alpha1.PolicyEngineOPA,
+				},
+			}
+			Expect(k8sClient.Create(ctx, tp)).NotTo(Succeed())
+		})
+	})
+
 	Context("When reconciling a ToolPolicy with invalid CEL", func() {
 		It("should set Error phase", func() {
 			policyName := "test-invalid-cel"
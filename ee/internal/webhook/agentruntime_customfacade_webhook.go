@@ -57,13 +57,13 @@ var _ admission.Validator[*corev1alpha1.AgentRuntime] = &AgentRuntimeCustomFacad
 // ValidateCreate implements admission.Validator.
 func (v *AgentRuntimeCustomFacadeValidator) ValidateCreate(ctx context.Context, ar *corev1alpha1.AgentRuntime) (admission.Warnings, error) {
 	agentruntimecustomfacadelog.Info("validating create", "name", ar.Name, "namespace", ar.Namespace)
-	return v.validateLicense(ctx, ar)
+	return v.validateLicense(license.ForAdmission(ctx), ar)
 }
 
 // ValidateUpdate implements admission.Validator.
-func (v *AgentRuntimeCustomFacadeValidator) ValidateUpdate(ctx context.Context, _ *corev1alpha1.AgentRuntime, ar *corev1alpha1.AgentRuntime) (admission.Warnings, error) {
+func (v *AgentRuntimeCustomFacadeValidator) ValidateUpdate(ctx context.Context, old *corev1alpha1.AgentRuntime, ar *corev1alpha1.AgentRuntime) (admission.Warnings, error) {
 	agentruntimecustomfacadelog.Info("validating update", "name", ar.Name, "namespace", ar.Namespace)
-	return v.validateLicense(ctx, ar)
+	return v.validateLicense(updateLicenseContext(ctx, old.Spec, ar.Spec), ar)
 }
 
 // ValidateDelete implements admission.Validator. No license check on delete.
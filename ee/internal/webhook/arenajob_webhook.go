@@ -44,13 +44,13 @@ var _ admission.Validator[*omniav1alpha1.ArenaJob] = &ArenaJobValidator{}
 // ValidateCreate implements admission.Validator.
 func (v *ArenaJobValidator) ValidateCreate(ctx context.Context, job *omniav1alpha1.ArenaJob) (admission.Warnings, error) {
 	arenajoblog.Info("validating create", "name", job.Name)
-	return v.validateLicense(ctx, job)
+	return v.validateLicense(license.ForAdmission(ctx), job)
 }
 
 // ValidateUpdate implements admission.Validator.
-func (v *ArenaJobValidator) ValidateUpdate(ctx context.Context, _ *omniav1alpha1.ArenaJob, job *omniav1alpha1.ArenaJob) (admission.Warnings, error) {
+func (v *ArenaJobValidator) ValidateUpdate(ctx context.Context, old *omniav1alpha1.ArenaJob, job *omniav1alpha1.ArenaJob) (admission.Warnings, error) {
 	arenajoblog.Info("validating update", "name", job.Name)
-	return v.validateLicense(ctx, job)
+	return v.validateLicense(updateLicenseContext(ctx, old.Spec, job.Spec), job)
 }
 
 // ValidateDelete implements admission.Validator.
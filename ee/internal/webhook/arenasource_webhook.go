@@ -44,13 +44,13 @@ var _ admission.Validator[*omniav1alpha1.ArenaSource] = &ArenaSourceValidator{}
 // ValidateCreate implements admission.Validator.
 func (v *ArenaSourceValidator) ValidateCreate(ctx context.Context, source *omniav1alpha1.ArenaSource) (admission.Warnings, error) {
 	arenasourcelog.Info("validating create", "name", source.Name)
-	return v.validateLicense(ctx, source)
+	return v.validateLicense(license.ForAdmission(ctx), source)
 }
 
 // ValidateUpdate implements admission.Validator.
-func (v *ArenaSourceValidator) ValidateUpdate(ctx context.Context, _ *omniav1alpha1.ArenaSource, source *omniav1alpha1.ArenaSource) (admission.Warnings, error) {
+func (v *ArenaSourceValidator) ValidateUpdate(ctx context.Context, old *omniav1alpha1.ArenaSource, source *omniav1alpha1.ArenaSource) (admission.Warnings, error) {
 	arenasourcelog.Info("validating update", "name", source.Name)
-	return v.validateLicense(ctx, source)
+	return v.validateLicense(updateLicenseContext(ctx, old.Spec, source.Spec), source)
 }
 
 // ValidateDelete implements admission.Validator.
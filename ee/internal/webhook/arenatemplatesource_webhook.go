@@ -50,11 +50,11 @@ func (v *ArenaTemplateSourceValidator) ValidateCreate(ctx context.Context, sourc
 		return nil, fmt.Errorf("invalid spec: %v", errs)
 	}
 
-	return v.validateLicense(ctx, source)
+	return v.validateLicense(license.ForAdmission(ctx), source)
 }
 
 // ValidateUpdate implements admission.Validator.
-func (v *ArenaTemplateSourceValidator) ValidateUpdate(ctx context.Context, _ *omniav1alpha1.ArenaTemplateSource, source *omniav1alpha1.ArenaTemplateSource) (admission.Warnings, error) {
+func (v *ArenaTemplateSourceValidator) ValidateUpdate(ctx context.Context, old *omniav1alpha1.ArenaTemplateSource, source *omniav1alpha1.ArenaTemplateSource) (admission.Warnings, error) {
 	arenatemplatesourcelog.Info("validating update", "name", source.Name)
 
 	// Validate spec
@@ -62,7 +62,7 @@ func (v *ArenaTemplateSourceValidator) ValidateUpdate(ctx context.Context, _ *om
 		return nil, fmt.Errorf("invalid spec: %v", errs)
 	}
 
-	return v.validateLicense(ctx, source)
+	return v.validateLicense(updateLicenseContext(ctx, old.Spec, source.Spec), source)
 }
 
 // ValidateDelete implements admission.Validator.
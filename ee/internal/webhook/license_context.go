@@ -0,0 +1,28 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package webhook
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/altairalabs/omnia/ee/pkg/license"
+)
+
+// updateLicenseContext marks an update's license check as admitting a changed
+// resource unless the spec is unchanged. An expired license in its grace period
+// then still lets controllers add and remove finalizers on existing resources
+// while refusing spec changes.
+func updateLicenseContext(ctx context.Context, oldSpec, newSpec any) context.Context {
+	if equality.Semantic.DeepEqual(oldSpec, newSpec) {
+		return ctx
+	}
+	return license.ForAdmission(ctx)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/license"
+)
+
+// gracePeriodValidator returns a license validator whose enterprise license
+// expired a day ago and so is in its grace period.
+func gracePeriodValidator(t *testing.T) *license.Validator {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"exp":      time.Now().Add(-24 * time.Hour).Unix(),
+		"lid":      "lic-1",
+		"tier":     "enterprise",
+		"features": map[string]bool{"ociSource": true},
+	}).SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: license.LicenseSecretName, Namespace: license.LicenseSecretNamespace},
+		Data:       map[string][]byte{license.LicenseSecretKey: []byte(token)},
+	}).Build()
+	v, err := license.NewValidator(c, license.WithPublicKey(&key.PublicKey),
+		license.WithNamespace(license.LicenseSecretNamespace))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestArenaSourceValidator_GracePeriod(t *testing.T) {
+	validator := &ArenaSourceValidator{LicenseValidator: gracePeriodValidator(t)}
+	source := &omniav1alpha1.ArenaSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-source", Namespace: "default"},
+		Spec:       omniav1alpha1.ArenaSourceSpec{Type: omniav1alpha1.ArenaSourceTypeOCI},
+	}
+	ctx := context.Background()
+
+	if _, err := validator.ValidateCreate(ctx, source); err == nil {
+		t.Error("expected new resources to be refused during the grace period")
+	}
+
+	finalized := source.DeepCopy()
+	finalized.Finalizers = []string{"omnia.altairalabs.ai/finalizer"}
+	if _, err := validator.ValidateUpdate(ctx, source, finalized); err != nil {
+		t.Errorf("unexpected error for an update that leaves the spec unchanged: %v", err)
+	}
+
+	changed := source.DeepCopy()
+	changed.Spec.Interval = "5m"
+	if _, err := validator.ValidateUpdate(ctx, source, changed); err == nil {
+		t.Error("expected spec changes to be refused during the grace period")
+	}
+}
@@ -42,13 +42,13 @@ var _ admission.Validator[*omniav1alpha1.PromptPackSource] = &PromptPackSourceVa
 // ValidateCreate implements admission.Validator.
 func (v *PromptPackSourceValidator) ValidateCreate(ctx context.Context, source *omniav1alpha1.PromptPackSource) (admission.Warnings, error) {
 	promptpacksourcelog.Info("validating create", "name", source.Name)
-	return v.validateLicense(ctx, source)
+	return v.validateLicense(license.ForAdmission(ctx), source)
 }
 
 // ValidateUpdate implements admission.Validator.
-func (v *PromptPackSourceValidator) ValidateUpdate(ctx context.Context, _ *omniav1alpha1.PromptPackSource, source *omniav1alpha1.PromptPackSource) (admission.Warnings, error) {
+func (v *PromptPackSourceValidator) ValidateUpdate(ctx context.Context, old *omniav1alpha1.PromptPackSource, source *omniav1alpha1.PromptPackSource) (admission.Warnings, error) {
 	promptpacksourcelog.Info("validating update", "name", source.Name)
-	return v.validateLicense(ctx, source)
+	return v.validateLicense(updateLicenseContext(ctx, old.Spec, source.Spec), source)
 }
 
 // ValidateDelete implements admission.Validator.
@@ -14,9 +14,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/arena/golden"
 	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
 	"github.com/altairalabs/omnia/pkg/intconv"
 )
@@ -91,6 +93,23 @@ func StatsToResult(stats *queue.JobStats) *AggregatedResult {
 		return p
 	})
 
+	result.ByParameterSet = convertGroupStats(stats.ByParameterSet,
+		func(name string, gs *queue.GroupStats) *ParameterSetStats {
+			p := &ParameterSetStats{
+				Total:         int(gs.Total),
+				Passed:        int(gs.Passed),
+				Failed:        int(gs.Failed),
+				TotalDuration: time.Duration(gs.TotalDurationMs * float64(time.Millisecond)),
+				TotalTokens:   gs.TotalTokens,
+				TotalCost:     gs.TotalCost,
+			}
+			if gs.Total > 0 {
+				p.PassRate = float64(gs.Passed) / float64(gs.Total) * 100
+				p.AvgDuration = p.TotalDuration / time.Duration(gs.Total)
+			}
+			return p
+		})
+
 	return result
 }
 
@@ -112,26 +131,39 @@ func convertGroupStats[T any](
 // It retrieves all completed and failed work items from the queue,
 // parses their results, and produces an aggregated summary.
 func (a *Aggregator) Aggregate(ctx context.Context, jobID string) (*AggregatedResult, error) {
+	result, _, err := a.AggregateWithResults(ctx, jobID)
+	return result, err
+}
+
+// AggregateWithResults is like Aggregate but also returns the parsed
+// per-item execution results, completed items first, for callers that
+// render item-level detail such as reports.
+func (a *Aggregator) AggregateWithResults(
+	ctx context.Context, jobID string,
+) (*AggregatedResult, []ExecutionResult, error) {
 	// Get all completed items
 	completed, err := a.queue.GetCompletedItems(ctx, jobID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get completed items: %w", err)
+		return nil, nil, fmt.Errorf("failed to get completed items: %w", err)
 	}
 
 	// Get all failed items
 	failed, err := a.queue.GetFailedItems(ctx, jobID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get failed items: %w", err)
+		return nil, nil, fmt.Errorf("failed to get failed items: %w", err)
 	}
 
 	// Parse results and aggregate
 	result := &AggregatedResult{
-		ByScenario: make(map[string]*ScenarioStats),
-		ByProvider: make(map[string]*ProviderStats),
+		ByScenario:     make(map[string]*ScenarioStats),
+		ByProvider:     make(map[string]*ProviderStats),
+		ByParameterSet: make(map[string]*ParameterSetStats),
+		ByDataset:      make(map[string]*DatasetStats),
 	}
 
 	// Track errors for grouping
 	errorCounts := make(map[string]*ErrorSummary)
+	results := make([]ExecutionResult, 0, len(completed)+len(failed))
 
 	// Process completed items
 	for _, item := range completed {
@@ -140,6 +172,7 @@ func (a *Aggregator) Aggregate(ctx context.Context, jobID string) (*AggregatedRe
 			continue
 		}
 		a.aggregateResult(result, execResult, errorCounts)
+		results = append(results, *execResult)
 	}
 
 	// Process failed items
@@ -153,12 +186,13 @@ func (a *Aggregator) Aggregate(ctx context.Context, jobID string) (*AggregatedRe
 			continue
 		}
 		a.aggregateResult(result, execResult, errorCounts)
+		results = append(results, *execResult)
 	}
 
 	// Calculate averages and rates
 	a.finalizeResult(result, errorCounts)
 
-	return result, nil
+	return result, results, nil
 }
 
 // aggregateResult adds a single execution result to the aggregated result.
@@ -208,8 +242,40 @@ func (a *Aggregator) aggregateResult(
 		a.updateProviderStats(stats, execResult)
 	}
 
+	// Update parameter-set stats
+	if execResult.ParameterSetID != "" {
+		stats := result.ByParameterSet[execResult.ParameterSetID]
+		if stats == nil {
+			stats = &ParameterSetStats{}
+			result.ByParameterSet[execResult.ParameterSetID] = stats
+		}
+		a.updateParameterSetStats(stats, execResult)
+	}
+
 	// Collect assertions
 	result.Assertions = append(result.Assertions, execResult.Assertions...)
+	a.updateDatasetStats(result, execResult.Assertions)
+}
+
+// updateDatasetStats tallies golden-dataset assertions per dataset version.
+func (a *Aggregator) updateDatasetStats(result *AggregatedResult, assertions []AssertionResult) {
+	for _, assertion := range assertions {
+		key, ok := golden.DatasetKey(assertion.Params)
+		if !ok {
+			continue
+		}
+		stats := result.ByDataset[key]
+		if stats == nil {
+			stats = &DatasetStats{}
+			result.ByDataset[key] = stats
+		}
+		stats.Total++
+		if assertion.Passed {
+			stats.Passed++
+		} else {
+			stats.Failed++
+		}
+	}
 }
 
 // updateScenarioStats updates statistics for a scenario.
@@ -254,6 +320,27 @@ func (a *Aggregator) updateProviderStats(stats *ProviderStats, execResult *Execu
 	}
 }
 
+// updateParameterSetStats updates statistics for a parameter set.
+func (a *Aggregator) updateParameterSetStats(stats *ParameterSetStats, execResult *ExecutionResult) {
+	stats.Total++
+	stats.TotalDuration += execResult.Duration
+
+	if execResult.Status == StatusPass {
+		stats.Passed++
+	} else {
+		stats.Failed++
+	}
+
+	if execResult.Metrics != nil {
+		if tokens, ok := execResult.Metrics["tokens"]; ok {
+			stats.TotalTokens += int64(tokens)
+		}
+		if cost, ok := execResult.Metrics["cost"]; ok {
+			stats.TotalCost += cost
+		}
+	}
+}
+
 // trackError groups errors by message.
 func (a *Aggregator) trackError(errorCounts map[string]*ErrorSummary, errorMsg string, workItemID string) {
 	if errorMsg == "" {
@@ -296,6 +383,21 @@ func (a *Aggregator) finalizeResult(result *AggregatedResult, errorCounts map[st
 		}
 	}
 
+	// Calculate parameter-set averages
+	for _, stats := range result.ByParameterSet {
+		if stats.Total > 0 {
+			stats.PassRate = float64(stats.Passed) / float64(stats.Total) * 100
+			stats.AvgDuration = stats.TotalDuration / time.Duration(stats.Total)
+		}
+	}
+
+	// Calculate dataset pass rates
+	for _, stats := range result.ByDataset {
+		if stats.Total > 0 {
+			stats.PassRate = float64(stats.Passed) / float64(stats.Total) * 100
+		}
+	}
+
 	// Convert error map to slice
 	result.Errors = make([]ErrorSummary, 0, len(errorCounts))
 	for _, summary := range errorCounts {
@@ -309,6 +411,12 @@ func (a *Aggregator) finalizeResult(result *AggregatedResult, errorCounts map[st
 	if len(result.ByProvider) == 0 {
 		result.ByProvider = nil
 	}
+	if len(result.ByParameterSet) == 0 {
+		result.ByParameterSet = nil
+	}
+	if len(result.ByDataset) == 0 {
+		result.ByDataset = nil
+	}
 	if len(result.Errors) == 0 {
 		result.Errors = nil
 	}
@@ -354,15 +462,18 @@ func (a *Aggregator) ToJobResult(result *AggregatedResult) *omniav1alpha1.JobRes
 
 // resultDetails is the JSON-serializable breakdown stored in summary["details"].
 type resultDetails struct {
-	Scenarios  []scenarioDetail   `json:"scenarios,omitempty"`
-	Providers  []providerDetail   `json:"providers,omitempty"`
-	Assertions []assertionSummary `json:"assertions,omitempty"`
-	Errors     []ErrorSummary     `json:"errors,omitempty"`
+	Scenarios     []scenarioDetail     `json:"scenarios,omitempty"`
+	Providers     []providerDetail     `json:"providers,omitempty"`
+	ParameterSets []parameterSetDetail `json:"parameterSets,omitempty"`
+	Datasets      []datasetDetail      `json:"datasets,omitempty"`
+	Assertions    []assertionSummary   `json:"assertions,omitempty"`
+	Errors        []ErrorSummary       `json:"errors,omitempty"`
 }
 
-// assertionSummary groups assertion results by name.
+// assertionSummary groups assertion results by name and golden dataset.
 type assertionSummary struct {
 	Name     string   `json:"name"`
+	Dataset  string   `json:"dataset,omitempty"`
 	Total    int      `json:"total"`
 	Passed   int      `json:"passed"`
 	Failed   int      `json:"failed"`
@@ -392,24 +503,47 @@ type providerDetail struct {
 	TotalCost     float64 `json:"totalCost,omitempty"`
 }
 
-// summarizeAssertions groups raw assertion results by name and computes
-// pass/fail counts. Failure messages are collected (deduplicated) to help
-// diagnose what went wrong without flooding the dashboard with duplicates.
+type parameterSetDetail struct {
+	Name          string  `json:"name"`
+	Total         int     `json:"total"`
+	Passed        int     `json:"passed"`
+	Failed        int     `json:"failed"`
+	PassRate      float64 `json:"passRate"`
+	AvgDurationMs int64   `json:"avgDurationMs"`
+	TotalTokens   int64   `json:"totalTokens,omitempty"`
+	TotalCost     float64 `json:"totalCost,omitempty"`
+}
+
+type datasetDetail struct {
+	Name     string  `json:"name"`
+	Version  string  `json:"version"`
+	Total    int     `json:"total"`
+	Passed   int     `json:"passed"`
+	Failed   int     `json:"failed"`
+	PassRate float64 `json:"passRate"`
+}
+
+// summarizeAssertions groups raw assertion results by name and golden
+// dataset and computes pass/fail counts. Failure messages are collected
+// (deduplicated) to help diagnose what went wrong without flooding the
+// dashboard with duplicates.
 func summarizeAssertions(assertions []AssertionResult) []assertionSummary {
 	if len(assertions) == 0 {
 		return nil
 	}
 
-	// Preserve insertion order via a separate slice of names.
+	// Preserve insertion order via a separate slice of group keys.
 	order := make([]string, 0)
 	byName := make(map[string]*assertionSummary)
 
 	for _, a := range assertions {
-		s := byName[a.Name]
+		dataset, _ := golden.DatasetKey(a.Params)
+		group := a.Name + "|" + dataset
+		s := byName[group]
 		if s == nil {
-			s = &assertionSummary{Name: a.Name}
-			byName[a.Name] = s
-			order = append(order, a.Name)
+			s = &assertionSummary{Name: a.Name, Dataset: dataset}
+			byName[group] = s
+			order = append(order, group)
 		}
 		s.Total++
 		if a.Passed {
@@ -423,8 +557,8 @@ func summarizeAssertions(assertions []AssertionResult) []assertionSummary {
 	}
 
 	result := make([]assertionSummary, 0, len(order))
-	for _, name := range order {
-		s := byName[name]
+	for _, group := range order {
+		s := byName[group]
 		if s.Total > 0 {
 			s.PassRate = float64(s.Passed) / float64(s.Total) * 100
 		}
@@ -471,5 +605,28 @@ func buildResultDetails(result *AggregatedResult) resultDetails {
 			TotalCost:     p.TotalCost,
 		})
 	}
+	for name, p := range result.ByParameterSet {
+		d.ParameterSets = append(d.ParameterSets, parameterSetDetail{
+			Name:          name,
+			Total:         p.Total,
+			Passed:        p.Passed,
+			Failed:        p.Failed,
+			PassRate:      p.PassRate,
+			AvgDurationMs: p.AvgDuration.Milliseconds(),
+			TotalTokens:   p.TotalTokens,
+			TotalCost:     p.TotalCost,
+		})
+	}
+	for key, ds := range result.ByDataset {
+		name, version, _ := strings.Cut(key, "@")
+		d.Datasets = append(d.Datasets, datasetDetail{
+			Name:     name,
+			Version:  version,
+			Total:    ds.Total,
+			Passed:   ds.Passed,
+			Failed:   ds.Failed,
+			PassRate: ds.PassRate,
+		})
+	}
 	return d
 }
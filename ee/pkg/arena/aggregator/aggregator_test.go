@@ -188,6 +188,126 @@ func TestAggregator_Aggregate_ByScenario(t *testing.T) {
 	}
 }
 
+func TestAggregator_Aggregate_ByParameterSet(t *testing.T) {
+	q := queue.NewMemoryQueueWithDefaults()
+	agg := New(q)
+	ctx := context.Background()
+
+	items := []queue.WorkItem{
+		{ID: "item-1", ScenarioID: "refund", ProviderID: "openai", Parameters: &queue.ParameterSet{Name: "precise"}},
+		{ID: "item-2", ScenarioID: "refund", ProviderID: "openai", Parameters: &queue.ParameterSet{Name: "creative"}},
+		{ID: "item-3", ScenarioID: "refund", ProviderID: "openai", Parameters: &queue.ParameterSet{Name: "creative"}},
+	}
+	_ = q.Push(ctx, "job-1", items)
+
+	for _, r := range []string{`{"status": "pass"}`, `{"status": "pass"}`, `{"status": "fail"}`} {
+		item, _ := q.Pop(ctx, "job-1")
+		_ = q.Ack(ctx, "job-1", item.ID, []byte(r))
+	}
+
+	result, err := agg.Aggregate(ctx, "job-1")
+	if err != nil {
+		t.Fatalf("Aggregate() error = %v", err)
+	}
+
+	if len(result.ByParameterSet) != 2 {
+		t.Fatalf("ByParameterSet count = %d, want 2", len(result.ByParameterSet))
+	}
+	precise := result.ByParameterSet["precise"]
+	if precise == nil || precise.Total != 1 || precise.PassRate != 100 {
+		t.Errorf("unexpected precise stats: %+v", precise)
+	}
+	creative := result.ByParameterSet["creative"]
+	if creative == nil || creative.Total != 2 || creative.Passed != 1 || creative.PassRate != 50 {
+		t.Errorf("unexpected creative stats: %+v", creative)
+	}
+
+	jobResult := agg.ToJobResult(result)
+	var details resultDetails
+	if err := json.Unmarshal([]byte(jobResult.Summary["details"]), &details); err != nil {
+		t.Fatalf("Failed to parse details JSON: %v", err)
+	}
+	if len(details.ParameterSets) != 2 {
+		t.Errorf("expected 2 parameter set details, got %d", len(details.ParameterSets))
+	}
+}
+
+func TestAggregator_Aggregate_ByDataset(t *testing.T) {
+	q := queue.NewMemoryQueueWithDefaults()
+	agg := New(q)
+	ctx := context.Background()
+
+	items := []queue.WorkItem{
+		{ID: "item-1", ScenarioID: "refund"},
+		{ID: "item-2", ScenarioID: "refund"},
+	}
+	_ = q.Push(ctx, "job-1", items)
+
+	results := []string{
+		`{"status": "pass", "assertions": [
+			{"name": "contains", "passed": true, "params": {"golden_dataset": "support@v1"}},
+			{"name": "json_valid", "passed": true}]}`,
+		`{"status": "fail", "assertions": [
+			{"name": "contains", "passed": false, "message": "missing: refund",
+				"params": {"golden_dataset": "support@v1"}},
+			{"name": "contains", "passed": true},
+			{"name": "regex", "passed": true, "params": {"golden_dataset": "support@v2"}}]}`,
+	}
+	for _, r := range results {
+		item, _ := q.Pop(ctx, "job-1")
+		_ = q.Ack(ctx, "job-1", item.ID, []byte(r))
+	}
+
+	result, err := agg.Aggregate(ctx, "job-1")
+	if err != nil {
+		t.Fatalf("Aggregate() error = %v", err)
+	}
+
+	if len(result.ByDataset) != 2 {
+		t.Fatalf("ByDataset count = %d, want 2", len(result.ByDataset))
+	}
+	v1 := result.ByDataset["support@v1"]
+	if v1 == nil || v1.Total != 2 || v1.Passed != 1 || v1.Failed != 1 || v1.PassRate != 50 {
+		t.Errorf("unexpected support@v1 stats: %+v", v1)
+	}
+	v2 := result.ByDataset["support@v2"]
+	if v2 == nil || v2.Total != 1 || v2.PassRate != 100 {
+		t.Errorf("unexpected support@v2 stats: %+v", v2)
+	}
+
+	jobResult := agg.ToJobResult(result)
+	var details resultDetails
+	if err := json.Unmarshal([]byte(jobResult.Summary["details"]), &details); err != nil {
+		t.Fatalf("Failed to parse details JSON: %v", err)
+	}
+	if len(details.Datasets) != 2 {
+		t.Fatalf("expected 2 dataset details, got %d", len(details.Datasets))
+	}
+	for _, d := range details.Datasets {
+		if d.Name != "support" || (d.Version != "v1" && d.Version != "v2") {
+			t.Errorf("unexpected dataset detail: %+v", d)
+		}
+	}
+
+	// Golden "contains" results are summarized apart from the scenario's own,
+	// and keep the failure explanation the assertion produced.
+	var golden, own *assertionSummary
+	for i, a := range details.Assertions {
+		switch {
+		case a.Name == "contains" && a.Dataset == "support@v1":
+			golden = &details.Assertions[i]
+		case a.Name == "contains" && a.Dataset == "":
+			own = &details.Assertions[i]
+		}
+	}
+	if golden == nil || golden.Total != 2 || len(golden.Failures) != 1 || golden.Failures[0] != "missing: refund" {
+		t.Errorf("unexpected golden contains summary: %+v", golden)
+	}
+	if own == nil || own.Total != 1 || own.Failed != 0 {
+		t.Errorf("unexpected scenario contains summary: %+v", own)
+	}
+}
+
 func TestAggregator_Aggregate_ByProvider(t *testing.T) {
 	q := queue.NewMemoryQueueWithDefaults()
 	agg := New(q)
@@ -593,6 +713,31 @@ func TestStatsToResult_WithGroups(t *testing.T) {
 	}
 }
 
+func TestStatsToResult_ByParameterSet(t *testing.T) {
+	stats := &queue.JobStats{
+		Total:  3,
+		Passed: 2,
+		Failed: 1,
+		ByParameterSet: map[string]*queue.GroupStats{
+			"precise":  {Total: 1, Passed: 1, TotalDurationMs: 1000},
+			"creative": {Total: 2, Passed: 1, Failed: 1, TotalDurationMs: 3000, TotalTokens: 40},
+		},
+	}
+
+	result := StatsToResult(stats)
+
+	if len(result.ByParameterSet) != 2 {
+		t.Fatalf("ByParameterSet count = %d, want 2", len(result.ByParameterSet))
+	}
+	creative := result.ByParameterSet["creative"]
+	if creative.PassRate != 50 || creative.AvgDuration != 1500*time.Millisecond || creative.TotalTokens != 40 {
+		t.Errorf("creative stats = %+v", creative)
+	}
+	if precise := result.ByParameterSet["precise"]; precise.PassRate != 100 {
+		t.Errorf("precise stats = %+v", precise)
+	}
+}
+
 func TestStatsToResult_ZeroItems(t *testing.T) {
 	stats := &queue.JobStats{}
 	result := StatsToResult(stats)
@@ -618,3 +763,32 @@ type testError struct {
 func (e *testError) Error() string {
 	return e.msg
 }
+
+func TestAggregator_AggregateWithResults(t *testing.T) {
+	q := queue.NewMemoryQueueWithDefaults()
+	agg := New(q)
+	ctx := context.Background()
+
+	_ = q.Push(ctx, "job-1", []queue.WorkItem{
+		{ID: "item-1", ScenarioID: "s1", ProviderID: "p1"},
+		{ID: "item-2", ScenarioID: "s2", ProviderID: "p1"},
+	})
+	_, _ = q.Pop(ctx, "job-1")
+	_, _ = q.Pop(ctx, "job-1")
+	_ = q.CompleteItem(ctx, "job-1", "item-1", &queue.ItemResult{Status: "pass", SessionID: "sess-1"})
+	_ = q.FailItem(ctx, "job-1", "item-2", &testError{msg: "boom"})
+
+	result, results, err := agg.AggregateWithResults(ctx, "job-1")
+	if err != nil {
+		t.Fatalf("AggregateWithResults() error = %v", err)
+	}
+	if result.TotalItems != 2 || len(results) != 2 {
+		t.Fatalf("TotalItems = %d, results = %d, want 2 and 2", result.TotalItems, len(results))
+	}
+	if results[0].WorkItemID != "item-1" || results[0].SessionID != "sess-1" {
+		t.Errorf("unexpected first result: %+v", results[0])
+	}
+	if results[1].Status != StatusFail || results[1].Error != "boom" {
+		t.Errorf("unexpected second result: %+v", results[1])
+	}
+}
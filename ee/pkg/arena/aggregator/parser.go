@@ -32,10 +32,13 @@ type jsonResult struct {
 	Duration   string             `json:"duration,omitempty"`
 	Metrics    map[string]float64 `json:"metrics,omitempty"`
 	Assertions []struct {
-		Name    string `json:"name"`
-		Passed  bool   `json:"passed"`
-		Message string `json:"message,omitempty"`
+		Name    string         `json:"name"`
+		Passed  bool           `json:"passed"`
+		Message string         `json:"message,omitempty"`
+		Params  map[string]any `json:"params,omitempty"`
 	} `json:"assertions,omitempty"`
+	SessionID  string              `json:"sessionId,omitempty"`
+	Transcript []TranscriptMessage `json:"transcript,omitempty"`
 }
 
 // ParseExecutionResult parses a work item's result bytes into ExecutionResult.
@@ -73,6 +76,9 @@ func newResultFromWorkItem(item *queue.WorkItem) *ExecutionResult {
 		ScenarioID: item.ScenarioID,
 		ProviderID: item.ProviderID,
 	}
+	if item.Parameters != nil {
+		result.ParameterSetID = item.Parameters.Name
+	}
 
 	// Calculate duration from work item timestamps
 	if item.StartedAt != nil && item.CompletedAt != nil {
@@ -117,6 +123,9 @@ func populateResultFromJSON(result *ExecutionResult, jr *jsonResult, item *queue
 
 	// Copy assertions
 	copyAssertions(result, jr.Assertions)
+
+	result.SessionID = jr.SessionID
+	result.Transcript = jr.Transcript
 }
 
 // determineStatus returns the appropriate status string.
@@ -156,9 +165,10 @@ func copyMetrics(result *ExecutionResult, metrics map[string]float64) {
 
 // copyAssertions copies assertions from JSON result to execution result.
 func copyAssertions(result *ExecutionResult, assertions []struct {
-	Name    string `json:"name"`
-	Passed  bool   `json:"passed"`
-	Message string `json:"message,omitempty"`
+	Name    string         `json:"name"`
+	Passed  bool           `json:"passed"`
+	Message string         `json:"message,omitempty"`
+	Params  map[string]any `json:"params,omitempty"`
 }) {
 	if len(assertions) == 0 {
 		return
@@ -169,6 +179,7 @@ func copyAssertions(result *ExecutionResult, assertions []struct {
 			Name:    a.Name,
 			Passed:  a.Passed,
 			Message: a.Message,
+			Params:  a.Params,
 		}
 	}
 }
@@ -88,6 +88,10 @@ func TestParseExecutionResult_JSONResult(t *testing.T) {
 		"assertions": [
 			{"name": "check_output", "passed": true},
 			{"name": "check_format", "passed": false, "message": "invalid format"}
+		],
+		"transcript": [
+			{"role": "user", "content": "hi"},
+			{"role": "assistant", "content": "hello"}
 		]
 	}`)
 
@@ -123,6 +127,9 @@ func TestParseExecutionResult_JSONResult(t *testing.T) {
 	if result.Assertions[1].Passed {
 		t.Error("Second assertion should have failed")
 	}
+	if len(result.Transcript) != 2 || result.Transcript[1] != (TranscriptMessage{Role: "assistant", Content: "hello"}) {
+		t.Errorf("Transcript = %+v, want the worker's two messages", result.Transcript)
+	}
 }
 
 func TestParseExecutionResult_JSONWithDurationString(t *testing.T) {
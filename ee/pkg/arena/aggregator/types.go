@@ -38,6 +38,9 @@ type ExecutionResult struct {
 	// ProviderID identifies which provider was used.
 	ProviderID string `json:"providerId"`
 
+	// ParameterSetID identifies the swept parameter set, if any.
+	ParameterSetID string `json:"parameterSetId,omitempty"`
+
 	// Status indicates the execution outcome: "pass" or "fail".
 	Status string `json:"status"`
 
@@ -52,6 +55,22 @@ type ExecutionResult struct {
 
 	// Assertions contains individual assertion results if applicable.
 	Assertions []AssertionResult `json:"assertions,omitempty"`
+
+	// SessionID is the recorded session for this execution, if any.
+	SessionID string `json:"sessionId,omitempty"`
+
+	// Transcript is the conversation the execution produced, if the worker
+	// recorded one.
+	Transcript []TranscriptMessage `json:"transcript,omitempty"`
+}
+
+// TranscriptMessage is one message of an execution's conversation.
+type TranscriptMessage struct {
+	// Role is the message author: system, user, assistant or tool.
+	Role string `json:"role"`
+
+	// Content is the message text.
+	Content string `json:"content,omitempty"`
 }
 
 // AssertionResult represents the result of a single assertion.
@@ -64,6 +83,10 @@ type AssertionResult struct {
 
 	// Message contains additional details about the assertion result.
 	Message string `json:"message,omitempty"`
+
+	// Params carries the assertion params reports group by, such as the
+	// golden dataset the assertion came from.
+	Params map[string]any `json:"params,omitempty"`
 }
 
 // ScenarioStats contains aggregated statistics for a single scenario.
@@ -120,6 +143,50 @@ type ProviderStats struct {
 	TotalCost float64 `json:"totalCost,omitempty"`
 }
 
+// ParameterSetStats contains aggregated statistics for a single parameter set
+// in a parameter sweep.
+type ParameterSetStats struct {
+	// Total is the total number of executions with this parameter set.
+	Total int `json:"total"`
+
+	// Passed is the number of successful executions.
+	Passed int `json:"passed"`
+
+	// Failed is the number of failed executions.
+	Failed int `json:"failed"`
+
+	// PassRate is the success rate as a percentage (0-100).
+	PassRate float64 `json:"passRate"`
+
+	// TotalDuration is the sum of all execution durations.
+	TotalDuration time.Duration `json:"totalDuration"`
+
+	// AvgDuration is the average execution duration.
+	AvgDuration time.Duration `json:"avgDuration"`
+
+	// TotalTokens is the total token count if available.
+	TotalTokens int64 `json:"totalTokens,omitempty"`
+
+	// TotalCost is the total cost if available.
+	TotalCost float64 `json:"totalCost,omitempty"`
+}
+
+// DatasetStats contains aggregated golden-dataset assertion results for a
+// single dataset version.
+type DatasetStats struct {
+	// Total is the total number of golden assertions evaluated.
+	Total int `json:"total"`
+
+	// Passed is the number of golden assertions that passed.
+	Passed int `json:"passed"`
+
+	// Failed is the number of golden assertions that failed.
+	Failed int `json:"failed"`
+
+	// PassRate is the success rate as a percentage (0-100).
+	PassRate float64 `json:"passRate"`
+}
+
 // ErrorSummary groups errors by message for reporting.
 type ErrorSummary struct {
 	// Message is the error message.
@@ -164,6 +231,13 @@ type AggregatedResult struct {
 	// ByProvider contains per-provider statistics.
 	ByProvider map[string]*ProviderStats `json:"byProvider,omitempty"`
 
+	// ByParameterSet contains per-parameter-set statistics for parameter sweeps.
+	ByParameterSet map[string]*ParameterSetStats `json:"byParameterSet,omitempty"`
+
+	// ByDataset contains golden-dataset assertion statistics keyed by
+	// "<dataset>@<version>".
+	ByDataset map[string]*DatasetStats `json:"byDataset,omitempty"`
+
 	// Errors contains grouped error summaries.
 	Errors []ErrorSummary `json:"errors,omitempty"`
 
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
+)
+
+// ErrEventsUnsupported is returned by Watch when the underlying queue does
+// not record per-item events.
+var ErrEventsUnsupported = errors.New("queue does not support item events")
+
+// DefaultWatchInterval is how long Watch blocks waiting for new events before
+// invoking the callback with an empty batch.
+const DefaultWatchInterval = 5 * time.Second
+
+// Watch tails the item completion events of a job, starting after afterID
+// (empty for the beginning). fn is called with each batch of events, and with
+// an empty batch whenever interval passes without new events so callers can
+// emit keepalives. Watch returns nil once every item in the job has finished,
+// ctx.Err() when the context is cancelled, or the first error returned by fn.
+func (a *Aggregator) Watch(
+	ctx context.Context, jobID, afterID string, interval time.Duration,
+	fn func([]queue.ItemEvent) error,
+) error {
+	source, ok := a.queue.(queue.EventSource)
+	if !ok {
+		return ErrEventsUnsupported
+	}
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	cursor := afterID
+	for {
+		events, err := source.ReadEvents(ctx, jobID, cursor, interval)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 {
+			cursor = events[len(events)-1].ID
+		}
+		if err := fn(events); err != nil {
+			return err
+		}
+		if a.jobFinished(ctx, jobID) {
+			return a.drainEvents(ctx, source, jobID, cursor, fn)
+		}
+	}
+}
+
+// drainEvents delivers any events recorded after cursor without blocking.
+func (a *Aggregator) drainEvents(
+	ctx context.Context, source queue.EventSource, jobID, cursor string,
+	fn func([]queue.ItemEvent) error,
+) error {
+	for {
+		events, err := source.ReadEvents(ctx, jobID, cursor, 0)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		if err := fn(events); err != nil {
+			return err
+		}
+		cursor = events[len(events)-1].ID
+	}
+}
+
+// jobFinished reports whether the job has items and none are pending or
+// processing. Unknown jobs are treated as not yet started.
+func (a *Aggregator) jobFinished(ctx context.Context, jobID string) bool {
+	progress, err := a.queue.Progress(ctx, jobID)
+	if err != nil {
+		return false
+	}
+	return progress.Total > 0 && progress.IsComplete()
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
+)
+
+func TestAggregator_Watch_StreamsUntilComplete(t *testing.T) {
+	q := queue.NewMemoryQueueWithDefaults()
+	agg := New(q)
+	ctx := context.Background()
+
+	_ = q.Push(ctx, "job-1", []queue.WorkItem{
+		{ID: "item-1", ScenarioID: "s1", ProviderID: "p1"},
+		{ID: "item-2", ScenarioID: "s2", ProviderID: "p1"},
+	})
+	_, _ = q.Pop(ctx, "job-1")
+	_, _ = q.Pop(ctx, "job-1")
+	_ = q.CompleteItem(ctx, "job-1", "item-1", &queue.ItemResult{Status: "pass"})
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = q.FailItem(ctx, "job-1", "item-2", errors.New("boom"))
+	}()
+
+	var got []queue.ItemEvent
+	err := agg.Watch(ctx, "job-1", "", time.Second, func(events []queue.ItemEvent) error {
+		got = append(got, events...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d events, want 2", len(got))
+	}
+	if got[0].Status != queue.EventStatusPass || got[1].Status != queue.EventStatusFail {
+		t.Errorf("unexpected statuses: %s, %s", got[0].Status, got[1].Status)
+	}
+}
+
+func TestAggregator_Watch_ResumesAfterID(t *testing.T) {
+	q := queue.NewMemoryQueueWithDefaults()
+	agg := New(q)
+	ctx := context.Background()
+
+	_ = q.Push(ctx, "job-1", []queue.WorkItem{{ID: "item-1"}, {ID: "item-2"}})
+	for _, id := range []string{"item-1", "item-2"} {
+		_, _ = q.Pop(ctx, "job-1")
+		_ = q.CompleteItem(ctx, "job-1", id, &queue.ItemResult{Status: "pass"})
+	}
+
+	var got []queue.ItemEvent
+	err := agg.Watch(ctx, "job-1", "1", time.Second, func(events []queue.ItemEvent) error {
+		got = append(got, events...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ItemID != "item-2" {
+		t.Errorf("unexpected events after resume: %+v", got)
+	}
+}
+
+func TestAggregator_Watch_ContextCancelled(t *testing.T) {
+	q := queue.NewMemoryQueueWithDefaults()
+	agg := New(q)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	err := agg.Watch(ctx, "missing", "", 10*time.Millisecond, func([]queue.ItemEvent) error { return nil })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Watch() error = %v, want deadline exceeded", err)
+	}
+}
+
+type noEventsQueue struct{ queue.WorkQueue }
+
+func TestAggregator_Watch_Unsupported(t *testing.T) {
+	agg := New(noEventsQueue{queue.NewMemoryQueueWithDefaults()})
+	err := agg.Watch(context.Background(), "job-1", "", time.Second, func([]queue.ItemEvent) error { return nil })
+	if !errors.Is(err, ErrEventsUnsupported) {
+		t.Errorf("Watch() error = %v, want ErrEventsUnsupported", err)
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+// Package golden loads GoldenDataset files and applies their expected outputs
+// to Arena scenarios as conversation assertions. Each injected assertion
+// carries the dataset name and version in its params so results can be
+// reported per dataset version by the aggregator.
+package golden
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+
+	"github.com/AltairaLabs/promptarena/arena/arenaconfig"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// EnvDatasets is the worker env var carrying the JSON-encoded []Ref the
+	// controller resolved for the job.
+	EnvDatasets = "ARENA_GOLDEN_DATASETS"
+
+	// MountRoot is the directory golden dataset content is mounted under in
+	// worker pods, one subdirectory per dataset name.
+	MountRoot = "/golden"
+
+	// ParamDataset is the assertion param holding the "<name>@<version>" key
+	// of the dataset an assertion was injected from.
+	ParamDataset = "golden_dataset"
+
+	assertionTypeContains = "contains"
+	assertionTypeRegex    = "regex"
+)
+
+// Ref identifies a resolved dataset version for a worker to load.
+type Ref struct {
+	// Name is the GoldenDataset name.
+	Name string `json:"name"`
+	// Version is the resolved ArenaSource content version.
+	Version string `json:"version"`
+	// Path is the dataset file path relative to the dataset mount.
+	Path string `json:"path"`
+}
+
+// Key returns the "<name>@<version>" identifier used in reports.
+func (r Ref) Key() string {
+	return r.Name + "@" + r.Version
+}
+
+// Entry holds the expected output for a single scenario.
+type Entry struct {
+	// Scenario is the scenario ID the entry applies to.
+	Scenario string `yaml:"scenario" json:"scenario"`
+	// Description is a human-readable note about the expected answer.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Contains lists substrings the final response must include (case-insensitive).
+	Contains []string `yaml:"contains,omitempty" json:"contains,omitempty"`
+	// Pattern is a regular expression the final response must match.
+	Pattern string `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	// Assertions are additional raw assertions applied as-is.
+	Assertions []arenaconfig.AssertionConfig `yaml:"assertions,omitempty" json:"assertions,omitempty"`
+}
+
+// File is the on-disk dataset format.
+type File struct {
+	Entries []Entry `yaml:"entries" json:"entries"`
+}
+
+// Dataset is a loaded dataset bound to its resolved version.
+type Dataset struct {
+	Ref
+	Entries []Entry
+}
+
+// Parse decodes dataset file content and validates its entries.
+func Parse(data []byte) (*File, error) {
+	var f File
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse golden dataset: %w", err)
+	}
+	seen := make(map[string]bool, len(f.Entries))
+	for i, e := range f.Entries {
+		if e.Scenario == "" {
+			return nil, fmt.Errorf("entry %d: scenario is required", i)
+		}
+		if seen[e.Scenario] {
+			return nil, fmt.Errorf("entry %d: duplicate scenario %q", i, e.Scenario)
+		}
+		seen[e.Scenario] = true
+		if len(e.Contains) == 0 && e.Pattern == "" && len(e.Assertions) == 0 {
+			return nil, fmt.Errorf("entry %d (%s): no expected output", i, e.Scenario)
+		}
+	}
+	return &f, nil
+}
+
+// LoadFile reads and parses a dataset file from disk.
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read golden dataset: %w", err)
+	}
+	return Parse(data)
+}
+
+// ParseRefs decodes the EnvDatasets value. An empty value yields no refs.
+func ParseRefs(raw string) ([]Ref, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var refs []Ref
+	if err := json.Unmarshal([]byte(raw), &refs); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", EnvDatasets, err)
+	}
+	return refs, nil
+}
+
+// Load reads each referenced dataset from <root>/<name>/<path>.
+func Load(root string, refs []Ref) ([]*Dataset, error) {
+	datasets := make([]*Dataset, 0, len(refs))
+	for _, ref := range refs {
+		f, err := LoadFile(filepath.Join(root, ref.Name, filepath.FromSlash(ref.Path)))
+		if err != nil {
+			return nil, fmt.Errorf("dataset %s: %w", ref.Key(), err)
+		}
+		datasets = append(datasets, &Dataset{Ref: ref, Entries: f.Entries})
+	}
+	return datasets, nil
+}
+
+// Apply appends each dataset entry's expected output to the matching
+// scenario's conversation assertions. Entries for scenarios that are not
+// loaded are ignored. Returns the number of assertions added.
+func Apply(scenarios map[string]*arenaconfig.Scenario, datasets []*Dataset) int {
+	added := 0
+	for _, ds := range datasets {
+		for _, entry := range ds.Entries {
+			scenario, ok := scenarios[entry.Scenario]
+			if !ok || scenario == nil {
+				continue
+			}
+			assertions := entryAssertions(entry, ds.Key())
+			scenario.ConversationAssertions = append(scenario.ConversationAssertions, assertions...)
+			added += len(assertions)
+		}
+	}
+	return added
+}
+
+// entryAssertions converts an entry into assertion configs tagged with the
+// dataset key. Messages are left as written so results keep their own
+// explanation.
+func entryAssertions(entry Entry, key string) []arenaconfig.AssertionConfig {
+	var out []arenaconfig.AssertionConfig
+	if len(entry.Contains) > 0 {
+		patterns := make([]any, len(entry.Contains))
+		for i, c := range entry.Contains {
+			patterns[i] = c
+		}
+		out = append(out, arenaconfig.AssertionConfig{
+			Type:   assertionTypeContains,
+			Params: map[string]any{"patterns": patterns, ParamDataset: key},
+		})
+	}
+	if entry.Pattern != "" {
+		out = append(out, arenaconfig.AssertionConfig{
+			Type:   assertionTypeRegex,
+			Params: map[string]any{"pattern": entry.Pattern, ParamDataset: key},
+		})
+	}
+	for _, a := range entry.Assertions {
+		a.Params = maps.Clone(a.Params)
+		if a.Params == nil {
+			a.Params = make(map[string]any, 1)
+		}
+		a.Params[ParamDataset] = key
+		out = append(out, a)
+	}
+	return out
+}
+
+// DatasetKey extracts the "<name>@<version>" key from the params of an
+// assertion produced by Apply. ok is false for assertions not sourced from a
+// dataset.
+func DatasetKey(params map[string]any) (key string, ok bool) {
+	key, _ = params[ParamDataset].(string)
+	return key, key != ""
+}
+
+// ResultParams returns, for each of the n conversation assertion results of a
+// run of scenario, the dataset params of the assertion that produced it, or
+// nil. The engine evaluates arena-wide assertions before the scenario's own,
+// so results line up with the scenario's assertions from the end.
+func ResultParams(scenario *arenaconfig.Scenario, n int) []map[string]any {
+	out := make([]map[string]any, n)
+	if scenario == nil {
+		return out
+	}
+	assertions := scenario.ConversationAssertions
+	offset := n - len(assertions)
+	for i, a := range assertions {
+		if offset+i < 0 {
+			continue
+		}
+		if key, ok := DatasetKey(a.Params); ok {
+			out[offset+i] = map[string]any{ParamDataset: key}
+		}
+	}
+	return out
+}
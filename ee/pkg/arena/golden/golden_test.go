@@ -0,0 +1,131 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AltairaLabs/promptarena/arena/arenaconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleDataset = `entries:
+  - scenario: refund
+    contains: ["refund", "5 business days"]
+    pattern: "(?i)order #\\d+"
+  - scenario: greeting
+    assertions:
+      - type: content_excludes
+        message: no hello
+        params:
+          patterns: ["sorry"]
+  - scenario: not-loaded
+    contains: ["x"]
+`
+
+func TestParse(t *testing.T) {
+	f, err := Parse([]byte(sampleDataset))
+	require.NoError(t, err)
+	require.Len(t, f.Entries, 3)
+	assert.Equal(t, []string{"refund", "5 business days"}, f.Entries[0].Contains)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"missing scenario": "entries:\n  - contains: [a]\n",
+		"duplicate":        "entries:\n  - scenario: a\n    contains: [x]\n  - scenario: a\n    contains: [y]\n",
+		"no expectation":   "entries:\n  - scenario: a\n",
+		"bad yaml":         "entries: [",
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseRefs(t *testing.T) {
+	refs, err := ParseRefs("")
+	require.NoError(t, err)
+	assert.Nil(t, refs)
+
+	refs, err = ParseRefs(`[{"name":"support","version":"abc123","path":"golden/support.yaml"}]`)
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+	assert.Equal(t, "support@abc123", refs[0].Key())
+
+	_, err = ParseRefs("{")
+	assert.Error(t, err)
+}
+
+func TestLoadAndApply(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "support", "golden")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "support.yaml"), []byte(sampleDataset), 0o644))
+
+	datasets, err := Load(root, []Ref{{Name: "support", Version: "v1", Path: "golden/support.yaml"}})
+	require.NoError(t, err)
+	require.Len(t, datasets, 1)
+
+	scenarios := map[string]*arenaconfig.Scenario{
+		"refund": {
+			ID: "refund",
+			ConversationAssertions: []arenaconfig.AssertionConfig{
+				{Type: "json_valid"},
+			},
+		},
+		"greeting": {ID: "greeting"},
+	}
+	added := Apply(scenarios, datasets)
+	assert.Equal(t, 3, added)
+
+	refund := scenarios["refund"].ConversationAssertions
+	require.Len(t, refund, 3)
+	assert.Equal(t, "json_valid", refund[0].Type)
+	assert.Equal(t, "contains", refund[1].Type)
+	assert.Equal(t, "support@v1", refund[1].Params[ParamDataset])
+	assert.Empty(t, refund[1].Message)
+	assert.Equal(t, "regex", refund[2].Type)
+	assert.Equal(t, "support@v1", refund[2].Params[ParamDataset])
+
+	greeting := scenarios["greeting"].ConversationAssertions
+	require.Len(t, greeting, 1)
+	assert.Equal(t, "content_excludes", greeting[0].Type)
+	assert.Equal(t, "support@v1", greeting[0].Params[ParamDataset])
+	assert.Equal(t, "no hello", greeting[0].Message, "authored messages are kept")
+	assert.NotContains(t, datasets[0].Entries[1].Assertions[0].Params, ParamDataset,
+		"the dataset entry itself is not modified")
+
+	params := ResultParams(scenarios["refund"], 4)
+	assert.Equal(t, []map[string]any{nil, nil, {ParamDataset: "support@v1"}, {ParamDataset: "support@v1"}}, params)
+	assert.Equal(t, []map[string]any{nil}, ResultParams(nil, 1))
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	_, err := Load(t.TempDir(), []Ref{{Name: "x", Version: "v1", Path: "missing.yaml"}})
+	assert.ErrorContains(t, err, "x@v1")
+}
+
+func TestDatasetKey(t *testing.T) {
+	key, ok := DatasetKey(map[string]any{ParamDataset: "support@v1", "pattern": "x"})
+	assert.True(t, ok)
+	assert.Equal(t, "support@v1", key)
+
+	_, ok = DatasetKey(map[string]any{ParamDataset: ""})
+	assert.False(t, ok)
+	_, ok = DatasetKey(map[string]any{"pattern": "x"})
+	assert.False(t, ok)
+	_, ok = DatasetKey(nil)
+	assert.False(t, ok)
+}
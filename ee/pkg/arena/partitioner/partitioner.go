@@ -80,6 +80,11 @@ type PartitionInput struct {
 	// If > 0, overrides per-scenario Trials for all scenarios.
 	// If 0, per-scenario Trials is used (defaulting to 1 if unset).
 	JobTrials int
+
+	// ParameterSets are swept as an additional matrix dimension. When empty,
+	// each scenario × provider × trial combination runs once with the arena
+	// file's provider defaults.
+	ParameterSets []queue.ParameterSet
 }
 
 // PartitionResult contains the result of partitioning.
@@ -87,7 +92,7 @@ type PartitionResult struct {
 	// Items is the list of generated work items.
 	Items []queue.WorkItem
 
-	// TotalCombinations is the total number of scenario × provider × trial combinations.
+	// TotalCombinations is the total number of scenario × provider × parameter set × trial combinations.
 	TotalCombinations int
 
 	// ScenarioCount is the number of scenarios.
@@ -96,12 +101,16 @@ type PartitionResult struct {
 	// ProviderCount is the number of providers.
 	ProviderCount int
 
+	// ParameterSetCount is the number of parameter sets (0 when not sweeping).
+	ParameterSetCount int
+
 	// TrialCount is the total number of trials across all scenarios.
 	TrialCount int
 }
 
-// Partition creates work items for each scenario × provider × trial combination.
-// Each work item represents a single evaluation that can be independently executed.
+// Partition creates work items for each scenario × provider × parameter set ×
+// trial combination. Each work item represents a single evaluation that can be
+// independently executed.
 func Partition(input PartitionInput) (*PartitionResult, error) {
 	if len(input.Scenarios) == 0 {
 		return nil, fmt.Errorf("no scenarios provided")
@@ -110,31 +119,45 @@ func Partition(input PartitionInput) (*PartitionResult, error) {
 		return nil, fmt.Errorf("no providers provided")
 	}
 
+	// A nil entry stands for "no parameter override" so the loop below is
+	// the same whether or not the job sweeps parameters.
+	paramSets := []*queue.ParameterSet{nil}
+	if len(input.ParameterSets) > 0 {
+		paramSets = make([]*queue.ParameterSet, len(input.ParameterSets))
+		for i := range input.ParameterSets {
+			paramSets[i] = &input.ParameterSets[i]
+		}
+	}
+
 	totalTrials := 0
-	items := make([]queue.WorkItem, 0, len(input.Scenarios)*len(input.Providers)*max(input.JobTrials, 1))
+	items := make([]queue.WorkItem, 0,
+		len(input.Scenarios)*len(input.Providers)*len(paramSets)*max(input.JobTrials, 1))
 
 	for _, scenario := range input.Scenarios {
 		trialCount := resolveTrialCount(input.JobTrials, scenario.Trials)
 		totalTrials += trialCount
 
 		for _, provider := range input.Providers {
-			for trial := 0; trial < trialCount; trial++ {
-				config, err := buildTrialConfig(input.Config, scenario, provider, trial, trialCount)
-				if err != nil {
-					return nil, fmt.Errorf("failed to build config for %s/%s trial %d: %w",
-						scenario.ID, provider.ID, trial, err)
+			for _, params := range paramSets {
+				for trial := 0; trial < trialCount; trial++ {
+					config, err := buildTrialConfig(input.Config, scenario, provider, params, trial, trialCount)
+					if err != nil {
+						return nil, fmt.Errorf("failed to build config for %s/%s trial %d: %w",
+							scenario.ID, provider.ID, trial, err)
+					}
+
+					item := queue.WorkItem{
+						ID:          generateItemID(input.JobID, scenario.ID, provider.ID),
+						JobID:       input.JobID,
+						ScenarioID:  scenario.ID,
+						ProviderID:  provider.ID,
+						Parameters:  params,
+						BundleURL:   input.BundleURL,
+						Config:      config,
+						MaxAttempts: input.MaxRetries,
+					}
+					items = append(items, item)
 				}
-
-				item := queue.WorkItem{
-					ID:          generateItemID(input.JobID, scenario.ID, provider.ID),
-					JobID:       input.JobID,
-					ScenarioID:  scenario.ID,
-					ProviderID:  provider.ID,
-					BundleURL:   input.BundleURL,
-					Config:      config,
-					MaxAttempts: input.MaxRetries,
-				}
-				items = append(items, item)
 			}
 		}
 	}
@@ -144,6 +167,7 @@ func Partition(input PartitionInput) (*PartitionResult, error) {
 		TotalCombinations: len(items),
 		ScenarioCount:     len(input.Scenarios),
 		ProviderCount:     len(input.Providers),
+		ParameterSetCount: len(input.ParameterSets),
 		TrialCount:        totalTrials,
 	}, nil
 }
@@ -225,7 +249,7 @@ func generateItemID(_, scenarioID, _ string) string {
 
 // buildTrialConfig creates the config JSON for a work item including trial metadata.
 func buildTrialConfig(
-	base map[string]any, scenario Scenario, provider Provider,
+	base map[string]any, scenario Scenario, provider Provider, params *queue.ParameterSet,
 	trialIndex, totalTrials int,
 ) ([]byte, error) {
 	config := make(map[string]any)
@@ -251,6 +275,11 @@ func buildTrialConfig(
 		"namespace": provider.Namespace,
 	}
 
+	// Add parameter set name so results can be traced back to the sweep
+	if params != nil {
+		config["parameterSet"] = params.Name
+	}
+
 	// Add trial metadata
 	config["trialIndex"] = trialIndex
 	config["totalTrials"] = totalTrials
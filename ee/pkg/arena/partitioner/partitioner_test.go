@@ -13,6 +13,8 @@ package partitioner
 import (
 	"encoding/json"
 	"testing"
+
+	"github.com/altairalabs/omnia/ee/pkg/arena/queue"
 )
 
 func TestPartition(t *testing.T) {
@@ -216,6 +218,87 @@ func TestPartitionJobTrialsOverride(t *testing.T) {
 	}
 }
 
+func TestPartitionWithParameterSets(t *testing.T) {
+	temp := 0.2
+	input := PartitionInput{
+		JobID:     "job-1",
+		BundleURL: "http://example.com/bundle.tar.gz",
+		Scenarios: []Scenario{
+			{ID: "s1", Name: "S1", Path: "s1.yaml"},
+		},
+		Providers: []Provider{
+			{ID: "p1", Name: "p1", Namespace: "ns"},
+			{ID: "p2", Name: "p2", Namespace: "ns"},
+		},
+		ParameterSets: []queue.ParameterSet{
+			{Name: "precise", Temperature: &temp},
+			{Name: "creative"},
+		},
+		JobTrials: 2,
+	}
+
+	result, err := Partition(input)
+	if err != nil {
+		t.Fatalf("Partition() error = %v", err)
+	}
+
+	// 1 scenario × 2 providers × 2 parameter sets × 2 trials = 8
+	if len(result.Items) != 8 {
+		t.Errorf("len(Items) = %d, want 8", len(result.Items))
+	}
+	if result.ParameterSetCount != 2 {
+		t.Errorf("ParameterSetCount = %d, want 2", result.ParameterSetCount)
+	}
+
+	counts := make(map[string]int)
+	for i, item := range result.Items {
+		if item.Parameters == nil {
+			t.Fatalf("item[%d] has no parameter set", i)
+		}
+		counts[item.ProviderID+"/"+item.Parameters.Name]++
+
+		var cfg map[string]any
+		if err := json.Unmarshal(item.Config, &cfg); err != nil {
+			t.Fatalf("unmarshal config[%d]: %v", i, err)
+		}
+		if cfg["parameterSet"] != item.Parameters.Name {
+			t.Errorf("item[%d] parameterSet = %v, want %s", i, cfg["parameterSet"], item.Parameters.Name)
+		}
+	}
+	for _, key := range []string{"p1/precise", "p1/creative", "p2/precise", "p2/creative"} {
+		if counts[key] != 2 {
+			t.Errorf("items for %s = %d, want 2", key, counts[key])
+		}
+	}
+	if got := result.Items[0].Parameters.Temperature; got == nil || *got != temp {
+		t.Errorf("item[0] temperature = %v, want %v", got, temp)
+	}
+}
+
+func TestPartitionWithoutParameterSets(t *testing.T) {
+	result, err := Partition(PartitionInput{
+		JobID:     "job-1",
+		Scenarios: []Scenario{{ID: "s1", Name: "S1", Path: "s1.yaml"}},
+		Providers: []Provider{{ID: "p1", Name: "p1"}},
+	})
+	if err != nil {
+		t.Fatalf("Partition() error = %v", err)
+	}
+	if len(result.Items) != 1 {
+		t.Fatalf("len(Items) = %d, want 1", len(result.Items))
+	}
+	if result.Items[0].Parameters != nil {
+		t.Errorf("Parameters = %+v, want nil", result.Items[0].Parameters)
+	}
+	var cfg map[string]any
+	if err := json.Unmarshal(result.Items[0].Config, &cfg); err != nil {
+		t.Fatalf("unmarshal config: %v", err)
+	}
+	if _, ok := cfg["parameterSet"]; ok {
+		t.Errorf("config has parameterSet without a sweep")
+	}
+}
+
 func TestResolveTrialCount(t *testing.T) {
 	tests := []struct {
 		name           string
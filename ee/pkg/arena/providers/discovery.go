@@ -39,6 +39,8 @@ var ProviderCredentials = map[string][]string{
 	"together": {"TOGETHER_API_KEY"},
 	"ollama":   {}, // No API key required for local Ollama
 	"mock":     {}, // Mock provider doesn't need credentials
+	// Optional key via credential.envVar or custom headers; no standard env var
+	"openai-compatible": {},
 }
 
 // SecretRef contains information about where to find credentials.
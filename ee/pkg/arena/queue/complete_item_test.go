@@ -56,6 +56,43 @@ func TestCompleteItemUpdatesAccumulators(t *testing.T) {
 	assertFloat64(t, "TotalCost", stats.TotalCost, 0.05)
 }
 
+func TestCompleteItemGroupsByParameterSet(t *testing.T) {
+	q := NewMemoryQueueWithDefaults()
+	ctx := context.Background()
+
+	items := []WorkItem{
+		{ID: "item-1", ScenarioID: "scen-a", ProviderID: "prov-x", Parameters: &ParameterSet{Name: "precise"}},
+		{ID: "item-2", ScenarioID: "scen-a", ProviderID: "prov-x", Parameters: &ParameterSet{Name: "creative"}},
+		{ID: "item-3", ScenarioID: "scen-a", ProviderID: "prov-x"},
+	}
+	mustPush(t, q, ctx, items)
+	for range items {
+		mustPop(t, q, ctx)
+	}
+
+	pass := &ItemResult{Status: "pass", DurationMs: 100}
+	if err := q.CompleteItem(ctx, completeTestJobID, "item-1", pass); err != nil {
+		t.Fatalf("CompleteItem() error = %v", err)
+	}
+	if err := q.CompleteItem(ctx, completeTestJobID, "item-3", pass); err != nil {
+		t.Fatalf("CompleteItem() error = %v", err)
+	}
+	if err := q.FailItem(ctx, completeTestJobID, "item-2", errors.New("boom")); err != nil {
+		t.Fatalf("FailItem() error = %v", err)
+	}
+
+	stats, err := q.GetStats(ctx, completeTestJobID)
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+
+	if len(stats.ByParameterSet) != 2 {
+		t.Fatalf("ByParameterSet count = %d, want 2", len(stats.ByParameterSet))
+	}
+	assertInt64(t, "precise.Passed", stats.ByParameterSet["precise"].Passed, 1)
+	assertInt64(t, "creative.Failed", stats.ByParameterSet["creative"].Failed, 1)
+}
+
 func TestCompleteItemDoesAckBookkeeping(t *testing.T) {
 	q := NewMemoryQueueWithDefaults()
 	ctx := context.Background()
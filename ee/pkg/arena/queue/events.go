@@ -0,0 +1,82 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package queue
+
+import (
+	"context"
+	"time"
+)
+
+// ItemEvent is published when a work item reaches a terminal state.
+// Events are kept in a per-job log so consumers can render live progress
+// and resume from the last event they saw.
+type ItemEvent struct {
+	// ID is the cursor of this event within the job's event log.
+	ID string `json:"id"`
+
+	// ItemID is the ID of the work item that finished.
+	ItemID string `json:"itemId"`
+
+	// ScenarioID identifies the scenario the item executed.
+	ScenarioID string `json:"scenarioId"`
+
+	// ProviderID identifies the provider the item executed against.
+	ProviderID string `json:"providerId"`
+
+	// Status is the execution outcome: "pass" or "fail".
+	Status string `json:"status"`
+
+	// DurationMs is the execution time in milliseconds.
+	DurationMs float64 `json:"durationMs"`
+
+	// Error contains the failure reason, if any.
+	Error string `json:"error,omitempty"`
+
+	// Timestamp is when the item finished.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// EventSource is implemented by queues that record per-item completion events.
+type EventSource interface {
+	// ReadEvents returns events recorded for the job after afterID (an empty
+	// afterID reads from the beginning). If no events are available it waits
+	// up to block for new ones and returns an empty slice on timeout.
+	ReadEvents(ctx context.Context, jobID, afterID string, block time.Duration) ([]ItemEvent, error)
+}
+
+// Item event status values.
+const (
+	EventStatusPass = "pass"
+	EventStatusFail = "fail"
+)
+
+// newItemEvent builds the terminal event for an item.
+func newItemEvent(item *WorkItem, result *ItemResult) ItemEvent {
+	event := ItemEvent{
+		ItemID:     item.ID,
+		ScenarioID: item.ScenarioID,
+		ProviderID: item.ProviderID,
+		Status:     EventStatusFail,
+		Error:      item.Error,
+		Timestamp:  time.Now(),
+	}
+	if item.CompletedAt != nil {
+		event.Timestamp = *item.CompletedAt
+	}
+	if result != nil {
+		if result.Status == EventStatusPass {
+			event.Status = EventStatusPass
+		}
+		event.DurationMs = result.DurationMs
+		if result.Error != "" {
+			event.Error = result.Error
+		}
+	}
+	return event
+}
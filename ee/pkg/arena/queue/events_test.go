@@ -0,0 +1,121 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryQueue_ReadEvents(t *testing.T) {
+	q := NewMemoryQueueWithDefaults()
+	ctx := context.Background()
+
+	mustPush(t, q, ctx, []WorkItem{
+		{ID: "item-1", ScenarioID: "scen-a", ProviderID: "prov-x"},
+		{ID: "item-2", ScenarioID: "scen-b", ProviderID: "prov-x"},
+	})
+	mustPop(t, q, ctx)
+	mustPop(t, q, ctx)
+
+	events, err := q.ReadEvents(ctx, completeTestJobID, "", 0)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	require.NoError(t, q.CompleteItem(ctx, completeTestJobID, "item-1",
+		&ItemResult{Status: "pass", DurationMs: 42}))
+	require.NoError(t, q.FailItem(ctx, completeTestJobID, "item-2", errors.New("timeout")))
+
+	events, err = q.ReadEvents(ctx, completeTestJobID, "", 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "1", events[0].ID)
+	assert.Equal(t, "scen-a", events[0].ScenarioID)
+	assert.Equal(t, "prov-x", events[0].ProviderID)
+	assert.Equal(t, EventStatusPass, events[0].Status)
+	assert.Equal(t, 42.0, events[0].DurationMs)
+	assert.Equal(t, EventStatusFail, events[1].Status)
+	assert.Equal(t, "timeout", events[1].Error)
+
+	events, err = q.ReadEvents(ctx, completeTestJobID, "1", 0)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "item-2", events[0].ItemID)
+
+	_, err = q.ReadEvents(ctx, completeTestJobID, "bogus", 0)
+	assert.Error(t, err)
+}
+
+func TestMemoryQueue_ReadEvents_Blocks(t *testing.T) {
+	q := NewMemoryQueueWithDefaults()
+	ctx := context.Background()
+
+	mustPush(t, q, ctx, []WorkItem{{ID: "item-1"}})
+	mustPop(t, q, ctx)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = q.CompleteItem(ctx, completeTestJobID, "item-1", &ItemResult{Status: "pass"})
+	}()
+
+	events, err := q.ReadEvents(ctx, completeTestJobID, "", 5*time.Second)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "item-1", events[0].ItemID)
+
+	// Unknown jobs wait out the block and return nothing.
+	events, err = q.ReadEvents(ctx, "missing", "", 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestRedisQueue_ReadEvents(t *testing.T) {
+	client := getTestRedisClient(t)
+	defer cleanupRedisKeys(t, client)
+
+	q := NewRedisQueueFromClient(client, DefaultOptions())
+	ctx := context.Background()
+	jobID := ScopedJobID("ns", "events-job")
+
+	require.NoError(t, q.Push(ctx, jobID, []WorkItem{
+		{ID: "item-1", ScenarioID: "scen-a", ProviderID: "prov-x"},
+		{ID: "item-2", ScenarioID: "scen-b", ProviderID: "prov-y"},
+	}))
+	_, err := q.Pop(ctx, jobID)
+	require.NoError(t, err)
+	_, err = q.Pop(ctx, jobID)
+	require.NoError(t, err)
+
+	events, err := q.ReadEvents(ctx, jobID, "", 0)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	require.NoError(t, q.CompleteItem(ctx, jobID, "item-1", &ItemResult{Status: "pass", DurationMs: 10}))
+	require.NoError(t, q.FailItem(ctx, jobID, "item-2", errors.New("boom")))
+
+	events, err = q.ReadEvents(ctx, jobID, "", 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.NotEmpty(t, events[0].ID)
+	assert.Equal(t, "scen-a", events[0].ScenarioID)
+	assert.Equal(t, EventStatusPass, events[0].Status)
+	assert.Equal(t, "prov-y", events[1].ProviderID)
+	assert.Equal(t, EventStatusFail, events[1].Status)
+	assert.Equal(t, "boom", events[1].Error)
+
+	events, err = q.ReadEvents(ctx, jobID, events[0].ID, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "item-2", events[0].ItemID)
+}
@@ -64,6 +64,9 @@ func (q *InstrumentedQueue) Pop(ctx context.Context, jobID string) (*WorkItem, e
 	if err == nil && item != nil {
 		// Item moved from pending to processing
 		q.metrics.RecordItemStatusChange(jobID, ItemStatusPending, ItemStatusProcessing)
+		if !item.CreatedAt.IsZero() {
+			q.metrics.RecordClaimWait(jobID, time.Since(item.CreatedAt).Seconds())
+		}
 	}
 
 	return item, err
@@ -111,9 +114,13 @@ func (q *InstrumentedQueue) Nack(ctx context.Context, jobID string, itemID strin
 }
 
 // Progress returns the current progress for the specified job.
-// This is a read-only operation and does not record operation metrics.
+// It does not record operation metrics, but refreshes the job item gauges.
 func (q *InstrumentedQueue) Progress(ctx context.Context, jobID string) (*JobProgress, error) {
-	return q.queue.Progress(ctx, jobID)
+	progress, err := q.queue.Progress(ctx, jobID)
+	if err == nil {
+		q.metrics.RecordProgress(jobID, progress)
+	}
+	return progress, err
 }
 
 // Close releases any resources held by the queue.
@@ -25,6 +25,8 @@ type mockMetrics struct {
 	statusChanges    []statusChangeCall
 	itemsPushed      []itemsPushedCall
 	retries          []string
+	claimWaits       []float64
+	progress         []*JobProgress
 	activeJobsChange int
 }
 
@@ -76,6 +78,14 @@ func (m *mockMetrics) RecordRetry(jobID string) {
 	m.retries = append(m.retries, jobID)
 }
 
+func (m *mockMetrics) RecordClaimWait(_ string, waitSeconds float64) {
+	m.claimWaits = append(m.claimWaits, waitSeconds)
+}
+
+func (m *mockMetrics) RecordProgress(_ string, progress *JobProgress) {
+	m.progress = append(m.progress, progress)
+}
+
 func (m *mockMetrics) IncrementActiveJobs() {
 	m.activeJobsChange++
 }
@@ -751,3 +761,53 @@ func TestInstrumentedQueueGetStats(t *testing.T) {
 		t.Errorf("Expected 0 operations, got %d", len(metrics.operations))
 	}
 }
+
+func TestInstrumentedQueuePopRecordsClaimWait(t *testing.T) {
+	metrics := newMockMetrics()
+	q := NewInstrumentedQueue(NewMemoryQueueWithDefaults(), metrics)
+	ctx := context.Background()
+
+	if err := q.Push(ctx, testJobID, []WorkItem{{ID: "item-1"}}); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if _, err := q.Pop(ctx, testJobID); err != nil {
+		t.Fatalf("Pop() error = %v", err)
+	}
+	if len(metrics.claimWaits) != 1 {
+		t.Fatalf("Expected 1 claim wait, got %d", len(metrics.claimWaits))
+	}
+	if metrics.claimWaits[0] < 0 {
+		t.Errorf("Claim wait = %v, want >= 0", metrics.claimWaits[0])
+	}
+
+	// An empty pop claims nothing.
+	if _, err := q.Pop(ctx, testJobID); !errors.Is(err, ErrQueueEmpty) {
+		t.Fatalf("Pop() error = %v, want ErrQueueEmpty", err)
+	}
+	if len(metrics.claimWaits) != 1 {
+		t.Errorf("Expected claim waits unchanged, got %d", len(metrics.claimWaits))
+	}
+}
+
+func TestInstrumentedQueueProgressRecordsJobItems(t *testing.T) {
+	metrics := newMockMetrics()
+	q := NewInstrumentedQueue(NewMemoryQueueWithDefaults(), metrics)
+	ctx := context.Background()
+
+	if err := q.Push(ctx, testJobID, []WorkItem{{ID: "item-1"}, {ID: "item-2"}}); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if _, err := q.Progress(ctx, testJobID); err != nil {
+		t.Fatalf("Progress() error = %v", err)
+	}
+	if len(metrics.progress) != 1 || metrics.progress[0].Pending != 2 {
+		t.Fatalf("Expected one progress snapshot with 2 pending, got %+v", metrics.progress)
+	}
+
+	if _, err := q.Progress(ctx, "missing-job"); err == nil {
+		t.Fatal("Progress() of a missing job succeeded")
+	}
+	if len(metrics.progress) != 1 {
+		t.Errorf("Failed Progress() should not record, got %d snapshots", len(metrics.progress))
+	}
+}
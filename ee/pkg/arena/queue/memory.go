@@ -13,6 +13,8 @@ package queue
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
 	"sync"
 	"time"
 )
@@ -38,7 +40,9 @@ type jobState struct {
 	failed       map[string]*WorkItem // Failed items
 	statsCounted map[string]bool      // Item IDs already counted in stats (idempotency guard)
 	startedAt    *time.Time
-	stats        *JobStats // Accumulated statistics
+	stats        *JobStats     // Accumulated statistics
+	events       []ItemEvent   // Terminal item events, in completion order
+	eventsNotify chan struct{} // Closed and replaced whenever an event is recorded
 }
 
 // NewMemoryQueue creates a new in-memory work queue with the given options.
@@ -291,9 +295,11 @@ func (q *MemoryQueue) getOrCreateJobState(jobID string) *jobState {
 			completed:    make(map[string]*WorkItem),
 			failed:       make(map[string]*WorkItem),
 			statsCounted: make(map[string]bool),
+			eventsNotify: make(chan struct{}),
 			stats: &JobStats{
-				ByScenario: make(map[string]*GroupStats),
-				ByProvider: make(map[string]*GroupStats),
+				ByScenario:     make(map[string]*GroupStats),
+				ByProvider:     make(map[string]*GroupStats),
+				ByParameterSet: make(map[string]*GroupStats),
 			},
 		}
 		q.jobs[jobID] = state
@@ -386,6 +392,7 @@ func (q *MemoryQueue) CompleteItem(ctx context.Context, jobID string, itemID str
 
 	item := state.completed[itemID]
 	q.updateMemoryStats(state.stats, item, result)
+	state.recordEvent(newItemEvent(item, result))
 
 	return nil
 }
@@ -428,6 +435,7 @@ func (q *MemoryQueue) FailItem(ctx context.Context, jobID string, itemID string,
 	if !state.statsCounted[itemID] {
 		state.statsCounted[itemID] = true
 		q.incrementFailureStats(state.stats, item)
+		state.recordEvent(newItemEvent(item, nil))
 	}
 
 	return nil
@@ -447,8 +455,9 @@ func (q *MemoryQueue) GetStats(_ context.Context, jobID string) (*JobStats, erro
 	if !exists {
 		// Return zero stats for unknown jobs
 		return &JobStats{
-			ByScenario: make(map[string]*GroupStats),
-			ByProvider: make(map[string]*GroupStats),
+			ByScenario:     make(map[string]*GroupStats),
+			ByProvider:     make(map[string]*GroupStats),
+			ByParameterSet: make(map[string]*GroupStats),
 		}, nil
 	}
 
@@ -486,6 +495,12 @@ func (q *MemoryQueue) updateMemoryStats(stats *JobStats, item *WorkItem, result
 		gs := q.getOrCreateGroupStats(stats.ByProvider, item.ProviderID)
 		q.updateGroupStats(gs, result, tokens, cost)
 	}
+
+	// Update parameter-set stats
+	if item.Parameters != nil {
+		gs := q.getOrCreateGroupStats(stats.ByParameterSet, item.Parameters.Name)
+		q.updateGroupStats(gs, result, tokens, cost)
+	}
 }
 
 // incrementFailureStats updates accumulated stats for a failed item.
@@ -504,6 +519,12 @@ func (q *MemoryQueue) incrementFailureStats(stats *JobStats, item *WorkItem) {
 		gs.Total++
 		gs.Failed++
 	}
+
+	if item.Parameters != nil {
+		gs := q.getOrCreateGroupStats(stats.ByParameterSet, item.Parameters.Name)
+		gs.Total++
+		gs.Failed++
+	}
 }
 
 // getOrCreateGroupStats returns or creates a GroupStats entry in the given map.
@@ -541,6 +562,7 @@ func (q *MemoryQueue) copyJobStats(src *JobStats) *JobStats {
 		TotalCost:       src.TotalCost,
 		ByScenario:      make(map[string]*GroupStats, len(src.ByScenario)),
 		ByProvider:      make(map[string]*GroupStats, len(src.ByProvider)),
+		ByParameterSet:  make(map[string]*GroupStats, len(src.ByParameterSet)),
 	}
 	for k, v := range src.ByScenario {
 		cp := *v
@@ -550,8 +572,73 @@ func (q *MemoryQueue) copyJobStats(src *JobStats) *JobStats {
 		cp := *v
 		dst.ByProvider[k] = &cp
 	}
+	for k, v := range src.ByParameterSet {
+		cp := *v
+		dst.ByParameterSet[k] = &cp
+	}
 	return dst
 }
 
 // Ensure MemoryQueue implements WorkQueue interface.
 var _ WorkQueue = (*MemoryQueue)(nil)
+
+// recordEvent appends an item event and wakes blocked readers.
+// The caller must hold state.mu.
+func (s *jobState) recordEvent(event ItemEvent) {
+	event.ID = strconv.Itoa(len(s.events) + 1)
+	s.events = append(s.events, event)
+	close(s.eventsNotify)
+	s.eventsNotify = make(chan struct{})
+}
+
+// ReadEvents returns item events recorded for the job after afterID.
+// Event IDs are 1-based sequence numbers within the job.
+func (q *MemoryQueue) ReadEvents(
+	ctx context.Context, jobID, afterID string, block time.Duration,
+) ([]ItemEvent, error) {
+	after := 0
+	if afterID != "" {
+		n, err := strconv.Atoi(afterID)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid event ID %q", afterID)
+		}
+		after = n
+	}
+
+	q.mu.RLock()
+	if q.closed {
+		q.mu.RUnlock()
+		return nil, ErrQueueClosed
+	}
+	state := q.jobs[jobID]
+	q.mu.RUnlock()
+
+	var notify <-chan struct{}
+	if state != nil {
+		state.mu.Lock()
+		if after < len(state.events) {
+			events := append([]ItemEvent(nil), state.events[after:]...)
+			state.mu.Unlock()
+			return events, nil
+		}
+		notify = state.eventsNotify
+		state.mu.Unlock()
+	}
+	if block <= 0 {
+		return nil, nil
+	}
+
+	timer := time.NewTimer(block)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+		return nil, nil
+	case <-notify:
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return append([]ItemEvent(nil), state.events[after:]...), nil
+}
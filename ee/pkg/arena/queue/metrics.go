@@ -48,6 +48,15 @@ type QueueMetrics struct {
 
 	// ItemRetries tracks retry attempts.
 	ItemRetries prometheus.Counter
+
+	// ClaimWait tracks how long items waited in the queue before a worker
+	// claimed them.
+	ClaimWait prometheus.Histogram
+
+	// JobItems tracks the job's items by status as read from the shared queue.
+	// Unlike ItemsTotal, which counts only this worker's transitions, it is
+	// the same on every worker of the job.
+	JobItems *prometheus.GaugeVec
 }
 
 // QueueMetricsConfig configures the queue metrics.
@@ -55,6 +64,14 @@ type QueueMetricsConfig struct {
 	// Namespace is the namespace for the metrics (optional).
 	Namespace string
 
+	// JobName is the ArenaJob the metrics describe (optional). It is added as
+	// a job_name label so queue series join the arena worker's series.
+	JobName string
+
+	// ClaimWaitBuckets for the claim wait histogram.
+	// If nil, defaults to DefaultClaimWaitBuckets.
+	ClaimWaitBuckets []float64
+
 	// OperationDurationBuckets for operation duration histogram.
 	// If nil, defaults to DefaultOperationDurationBuckets.
 	OperationDurationBuckets []float64
@@ -64,19 +81,29 @@ type QueueMetricsConfig struct {
 // Queue operations are typically fast (Redis/memory operations).
 var DefaultOperationDurationBuckets = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
 
+// DefaultClaimWaitBuckets are the default histogram buckets for the time items
+// wait before a worker claims them, from an idle pool (100ms) to a backlog of
+// an hour.
+var DefaultClaimWaitBuckets = []float64{0.1, 0.5, 1, 5, 15, 30, 60, 300, 600, 1800, 3600}
+
 // NewQueueMetrics creates and registers all Prometheus metrics for queue operations.
 func NewQueueMetrics(cfg QueueMetricsConfig) *QueueMetrics {
-	var constLabels prometheus.Labels
+	constLabels := prometheus.Labels{}
 	if cfg.Namespace != "" {
-		constLabels = prometheus.Labels{
-			"namespace": cfg.Namespace,
-		}
+		constLabels["namespace"] = cfg.Namespace
+	}
+	if cfg.JobName != "" {
+		constLabels["job_name"] = cfg.JobName
 	}
 
 	durationBuckets := cfg.OperationDurationBuckets
 	if durationBuckets == nil {
 		durationBuckets = DefaultOperationDurationBuckets
 	}
+	claimWaitBuckets := cfg.ClaimWaitBuckets
+	if claimWaitBuckets == nil {
+		claimWaitBuckets = DefaultClaimWaitBuckets
+	}
 
 	return &QueueMetrics{
 		ItemsTotal: promauto.NewGaugeVec(prometheus.GaugeOpts{
@@ -109,6 +136,19 @@ func NewQueueMetrics(cfg QueueMetricsConfig) *QueueMetrics {
 			Help:        "Total number of item retry attempts",
 			ConstLabels: constLabels,
 		}),
+
+		ClaimWait: promauto.NewHistogram(prometheus.HistogramOpts{
+			Name:        "omnia_arena_queue_claim_wait_seconds",
+			Help:        "Time items waited in the queue before a worker claimed them",
+			ConstLabels: constLabels,
+			Buckets:     claimWaitBuckets,
+		}),
+
+		JobItems: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "omnia_arena_queue_job_items",
+			Help:        "Items of the job by status, read from the shared queue",
+			ConstLabels: constLabels,
+		}, []string{"status"}),
 	}
 }
 
@@ -157,6 +197,19 @@ func (m *QueueMetrics) RecordRetry(_ string) {
 	m.ItemRetries.Inc()
 }
 
+// RecordClaimWait records how long an item waited before it was claimed.
+func (m *QueueMetrics) RecordClaimWait(_ string, waitSeconds float64) {
+	m.ClaimWait.Observe(waitSeconds)
+}
+
+// RecordProgress sets the job item gauges from a progress snapshot.
+func (m *QueueMetrics) RecordProgress(_ string, progress *JobProgress) {
+	m.JobItems.WithLabelValues(string(ItemStatusPending)).Set(float64(progress.Pending))
+	m.JobItems.WithLabelValues(string(ItemStatusProcessing)).Set(float64(progress.Processing))
+	m.JobItems.WithLabelValues(string(ItemStatusCompleted)).Set(float64(progress.Completed))
+	m.JobItems.WithLabelValues(string(ItemStatusFailed)).Set(float64(progress.Failed))
+}
+
 // IncrementActiveJobs increments the active jobs count.
 func (m *QueueMetrics) IncrementActiveJobs() {
 	m.JobsActive.Inc()
@@ -174,6 +227,8 @@ type QueueMetricsRecorder interface {
 	RecordItemStatusChange(jobID string, oldStatus, newStatus ItemStatus)
 	RecordItemsPushed(jobID string, count int)
 	RecordRetry(jobID string)
+	RecordClaimWait(jobID string, waitSeconds float64)
+	RecordProgress(jobID string, progress *JobProgress)
 	IncrementActiveJobs()
 	DecrementActiveJobs()
 }
@@ -203,6 +258,16 @@ func (n *NoOpQueueMetrics) RecordRetry(_ string) {
 	// Intentionally empty: metrics are disabled
 }
 
+// RecordClaimWait is a no-op implementation for disabled metrics.
+func (n *NoOpQueueMetrics) RecordClaimWait(_ string, _ float64) {
+	// Intentionally empty: metrics are disabled
+}
+
+// RecordProgress is a no-op implementation for disabled metrics.
+func (n *NoOpQueueMetrics) RecordProgress(_ string, _ *JobProgress) {
+	// Intentionally empty: metrics are disabled
+}
+
 // IncrementActiveJobs is a no-op implementation for disabled metrics.
 func (n *NoOpQueueMetrics) IncrementActiveJobs() {
 	// Intentionally empty: metrics are disabled
@@ -320,6 +320,8 @@ func TestNoOpQueueMetricsAllMethods(t *testing.T) {
 	m.RecordItemStatusChange(testMetricsJobID, "", ItemStatusPending)
 	m.RecordItemsPushed(testMetricsJobID, 10)
 	m.RecordRetry(testMetricsJobID)
+	m.RecordClaimWait(testMetricsJobID, 1.5)
+	m.RecordProgress(testMetricsJobID, &JobProgress{Pending: 1})
 	m.IncrementActiveJobs()
 	m.DecrementActiveJobs()
 
@@ -327,6 +329,25 @@ func TestNoOpQueueMetricsAllMethods(t *testing.T) {
 	var _ QueueMetricsRecorder = m
 }
 
+func TestQueueMetricsRecordClaimWaitAndProgress(t *testing.T) {
+	metrics := createTestMetrics(t)
+
+	metrics.RecordClaimWait(testMetricsJobID, 2)
+	metrics.RecordProgress(testMetricsJobID, &JobProgress{Pending: 5, Processing: 2, Completed: 10, Failed: 1})
+
+	if got := testutil.CollectAndCount(metrics.ClaimWait); got != 1 {
+		t.Errorf("ClaimWait series = %d, want 1", got)
+	}
+	want := map[ItemStatus]float64{
+		ItemStatusPending: 5, ItemStatusProcessing: 2, ItemStatusCompleted: 10, ItemStatusFailed: 1,
+	}
+	for status, v := range want {
+		if got := testutil.ToFloat64(metrics.JobItems.WithLabelValues(string(status))); got != v {
+			t.Errorf("JobItems{status=%s} = %v, want %v", status, got, v)
+		}
+	}
+}
+
 func TestQueueMetricsImplementsRecorder(t *testing.T) {
 	metrics := createTestMetrics(t)
 
@@ -364,5 +385,16 @@ func createTestMetrics(t *testing.T) *QueueMetrics {
 			Name: "test_omnia_arena_queue_retries_total",
 			Help: "Total number of item retry attempts",
 		}),
+
+		ClaimWait: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "test_omnia_arena_queue_claim_wait_seconds",
+			Help:    "Time items waited in the queue before a worker claimed them",
+			Buckets: DefaultClaimWaitBuckets,
+		}),
+
+		JobItems: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "test_omnia_arena_queue_job_items",
+			Help: "Items of the job by status, read from the shared queue",
+		}, []string{"status"}),
 	}
 }
@@ -33,6 +33,17 @@ var (
 	ErrJobNotFound = errors.New("job not found")
 )
 
+// ScopedJobID returns the queue identifier for an ArenaJob. Job names are only
+// unique within a namespace, so the namespace is folded into the ID to keep
+// two tenants' jobs with the same name from sharing queue state on a shared
+// Redis. Namespaces cannot contain "/", which keeps the ID unambiguous.
+func ScopedJobID(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
 // ItemStatus represents the status of a work item.
 type ItemStatus string
 
@@ -64,6 +75,10 @@ type WorkItem struct {
 	// ProviderID identifies which provider to use for this scenario.
 	ProviderID string `json:"providerId"`
 
+	// Parameters is the model parameter set to apply, when the job sweeps
+	// parameter sets. Nil means the arena file's provider defaults are used.
+	Parameters *ParameterSet `json:"parameters,omitempty"`
+
 	// BundleURL is the URL to fetch the PromptKit bundle from.
 	BundleURL string `json:"bundleUrl"`
 
@@ -95,6 +110,25 @@ type WorkItem struct {
 	Result []byte `json:"result,omitempty"`
 }
 
+// ParameterSet is a named set of model parameters applied to every provider
+// and prompt for a work item. Unset fields keep the arena file's values.
+type ParameterSet struct {
+	// Name identifies the parameter set in results.
+	Name string `json:"name"`
+
+	// Temperature overrides the provider sampling temperature.
+	Temperature *float64 `json:"temperature,omitempty"`
+
+	// TopP overrides the provider nucleus sampling threshold.
+	TopP *float64 `json:"topP,omitempty"`
+
+	// MaxTokens overrides the provider response token limit.
+	MaxTokens *int `json:"maxTokens,omitempty"`
+
+	// SystemPrompt replaces the system template of every prompt.
+	SystemPrompt string `json:"systemPrompt,omitempty"`
+}
+
 // JobProgress represents the progress of an Arena job's work items.
 type JobProgress struct {
 	// JobID is the ID of the ArenaJob.
@@ -196,6 +230,10 @@ type ItemResult struct {
 
 	// SessionID is the optional session identifier for this execution.
 	SessionID string `json:"sessionId,omitempty"`
+
+	// Transcript is the conversation the execution produced, for the job
+	// report. Workers cap its length so results stay small.
+	Transcript []TranscriptMessage `json:"transcript,omitempty"`
 }
 
 // AssertionResult represents a single assertion outcome.
@@ -208,6 +246,19 @@ type AssertionResult struct {
 
 	// Message contains additional details about the assertion result.
 	Message string `json:"message,omitempty"`
+
+	// Params carries the assertion params reports group by, such as the
+	// golden dataset the assertion came from.
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// TranscriptMessage is one message of an execution's conversation.
+type TranscriptMessage struct {
+	// Role is the message author: system, user, assistant or tool.
+	Role string `json:"role"`
+
+	// Content is the message text.
+	Content string `json:"content,omitempty"`
 }
 
 // JobStats contains accumulated statistics readable at any time during or after execution.
@@ -235,6 +286,9 @@ type JobStats struct {
 
 	// ByProvider contains per-provider statistics.
 	ByProvider map[string]*GroupStats `json:"byProvider,omitempty"`
+
+	// ByParameterSet contains per-parameter-set statistics for parameter sweeps.
+	ByParameterSet map[string]*GroupStats `json:"byParameterSet,omitempty"`
 }
 
 // GroupStats contains accumulated statistics for a scenario or provider group.
@@ -22,16 +22,20 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-// Redis key prefixes and patterns.
+// Redis key prefixes and patterns. Every key is nested under its job key,
+// and job IDs are namespace-scoped (see ScopedJobID), so a Redis ACL user
+// restricted to "arena:job:<namespace>/*" can only reach its own tenant's
+// queue state.
 const (
 	keyPrefix        = "arena:"
 	jobKeyPrefix     = keyPrefix + "job:"
-	itemKeyPrefix    = keyPrefix + "item:"
+	itemKeyInfix     = ":item:"
 	pendingKeySuffix = ":pending"
 	processingKey    = ":processing"
 	completedKey     = ":completed"
 	failedKey        = ":failed"
 	metaKey          = ":meta"
+	eventsKeySuffix  = ":events"
 
 	// defaultItemTTL is the default TTL for queue items stored in Redis.
 	// Items older than this are automatically expired to prevent memory leaks.
@@ -144,6 +148,17 @@ func (q *RedisQueue) Close() error {
 	return q.client.Close()
 }
 
+// Ping checks connectivity to Redis.
+func (q *RedisQueue) Ping(ctx context.Context) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+	return q.client.Ping(ctx).Err()
+}
+
 func (q *RedisQueue) pendingKey(jobID string) string {
 	return jobKeyPrefix + jobID + pendingKeySuffix
 }
@@ -168,12 +183,12 @@ func (q *RedisQueue) metaKey(jobID string) string {
 	return jobKeyPrefix + jobID + metaKey
 }
 
-func (q *RedisQueue) itemKey(itemID string) string {
-	return itemKeyPrefix + itemID
+func (q *RedisQueue) itemKey(jobID, itemID string) string {
+	return jobKeyPrefix + jobID + itemKeyInfix + itemID
 }
 
-func (q *RedisQueue) getItem(ctx context.Context, itemID string) (*WorkItem, error) {
-	data, err := q.client.Get(ctx, q.itemKey(itemID)).Bytes()
+func (q *RedisQueue) getItem(ctx context.Context, jobID, itemID string) (*WorkItem, error) {
+	data, err := q.client.Get(ctx, q.itemKey(jobID, itemID)).Bytes()
 	if err == redis.Nil {
 		return nil, ErrItemNotFound
 	}
@@ -189,12 +204,12 @@ func (q *RedisQueue) getItem(ctx context.Context, itemID string) (*WorkItem, err
 	return &item, nil
 }
 
-func (q *RedisQueue) saveItem(ctx context.Context, item *WorkItem) error {
+func (q *RedisQueue) saveItem(ctx context.Context, jobID string, item *WorkItem) error {
 	data, err := json.Marshal(item)
 	if err != nil {
 		return err
 	}
-	return q.client.Set(ctx, q.itemKey(item.ID), data, q.itemTTL).Err()
+	return q.client.Set(ctx, q.itemKey(jobID, item.ID), data, q.itemTTL).Err()
 }
 
 // Redis key constants for accumulator stats.
@@ -202,6 +217,7 @@ const (
 	statsKeySuffix          = ":stats"
 	statsScenarioKeyInfix   = ":stats:scenario:"
 	statsProviderKeyInfix   = ":stats:provider:"
+	statsParamSetKeyInfix   = ":stats:params:"
 	statsFieldTotal         = "total"
 	statsFieldPassed        = "passed"
 	statsFieldFailed        = "failed"
@@ -225,18 +241,22 @@ func (q *RedisQueue) statsProviderKey(jobID, providerID string) string {
 	return jobKeyPrefix + jobID + statsProviderKeyInfix + providerID
 }
 
+func (q *RedisQueue) statsParamSetKey(jobID, paramSet string) string {
+	return jobKeyPrefix + jobID + statsParamSetKeyInfix + paramSet
+}
+
 // statsCountedKey returns the Redis key for the set of item IDs that have been counted in stats.
 func (q *RedisQueue) statsCountedKey(jobID string) string {
 	return jobKeyPrefix + jobID + ":stats:counted"
 }
 
 // saveItemPipe adds a SET command to a pipeline for saving a work item.
-func (q *RedisQueue) saveItemPipe(ctx context.Context, pipe redis.Pipeliner, item *WorkItem) {
+func (q *RedisQueue) saveItemPipe(ctx context.Context, pipe redis.Pipeliner, jobID string, item *WorkItem) {
 	data, err := json.Marshal(item)
 	if err != nil {
 		return
 	}
-	pipe.Set(ctx, q.itemKey(item.ID), data, q.itemTTL)
+	pipe.Set(ctx, q.itemKey(jobID, item.ID), data, q.itemTTL)
 }
 
 func parseInt64(s string) int64 {
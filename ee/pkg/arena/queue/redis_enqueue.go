@@ -58,7 +58,7 @@ func (q *RedisQueue) Push(ctx context.Context, jobID string, items []WorkItem) e
 		}
 
 		// Store item data with TTL
-		pipe.Set(ctx, q.itemKey(item.ID), itemData, q.itemTTL)
+		pipe.Set(ctx, q.itemKey(jobID, item.ID), itemData, q.itemTTL)
 
 		// Add to pending queue (LPUSH for FIFO with RPOP)
 		pipe.LPush(ctx, pendingKey, item.ID)
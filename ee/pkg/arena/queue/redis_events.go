@@ -0,0 +1,102 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// eventsStreamMaxLen caps the per-job event stream. It only needs to
+	// cover the job's item count; the cap protects against runaway jobs.
+	eventsStreamMaxLen = 100000
+
+	// eventsReadCount is the maximum number of events returned per read.
+	eventsReadCount = 500
+
+	// eventPayloadField is the stream entry field holding the JSON event.
+	eventPayloadField = "payload"
+)
+
+// eventsKey returns the Redis stream key for a job's item events.
+func (q *RedisQueue) eventsKey(jobID string) string {
+	return jobKeyPrefix + jobID + eventsKeySuffix
+}
+
+// publishEventPipe adds an XADD for the event to a pipeline. The event ID is
+// assigned by Redis, so event.ID is ignored.
+func (q *RedisQueue) publishEventPipe(ctx context.Context, pipe redis.Pipeliner, jobID string, event ItemEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	key := q.eventsKey(jobID)
+	pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: eventsStreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{eventPayloadField: string(payload)},
+	})
+	pipe.Expire(ctx, key, q.itemTTL)
+}
+
+// ReadEvents returns item events recorded for the job after afterID.
+func (q *RedisQueue) ReadEvents(
+	ctx context.Context, jobID, afterID string, block time.Duration,
+) ([]ItemEvent, error) {
+	q.mu.RLock()
+	if q.closed {
+		q.mu.RUnlock()
+		return nil, ErrQueueClosed
+	}
+	q.mu.RUnlock()
+
+	if afterID == "" {
+		afterID = "0"
+	}
+	// go-redis treats a zero Block as "wait forever"; a negative value omits it.
+	if block <= 0 {
+		block = -1
+	}
+
+	streams, err := q.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{q.eventsKey(jobID), afterID},
+		Count:   eventsReadCount,
+		Block:   block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read item events: %w", err)
+	}
+
+	var events []ItemEvent
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			payload, ok := msg.Values[eventPayloadField].(string)
+			if !ok {
+				continue
+			}
+			var event ItemEvent
+			if err := json.Unmarshal([]byte(payload), &event); err != nil {
+				continue
+			}
+			event.ID = msg.ID
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
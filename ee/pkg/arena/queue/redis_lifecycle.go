@@ -43,7 +43,7 @@ func (q *RedisQueue) Pop(ctx context.Context, jobID string) (*WorkItem, error) {
 	}
 
 	// Get and update the item
-	item, err := q.getItem(ctx, itemID)
+	item, err := q.getItem(ctx, jobID, itemID)
 	if err != nil {
 		// Item data missing, remove from processing and return error
 		q.client.LRem(ctx, processingKey, 1, itemID)
@@ -64,7 +64,7 @@ func (q *RedisQueue) Pop(ctx context.Context, jobID string) (*WorkItem, error) {
 	processingZKey := q.processingZSetKey(jobID)
 	score := float64(now.Add(q.opts.VisibilityTimeout).UnixNano())
 	pipe := q.client.Pipeline()
-	q.saveItemPipe(ctx, pipe, item)
+	q.saveItemPipe(ctx, pipe, jobID, item)
 	pipe.ZAdd(ctx, processingZKey, redis.Z{
 		Score:  score,
 		Member: itemID,
@@ -101,7 +101,7 @@ func (q *RedisQueue) Ack(ctx context.Context, jobID string, itemID string, resul
 	q.client.LRem(ctx, q.processingKey(jobID), 1, itemID)
 
 	// Get and update the item
-	item, err := q.getItem(ctx, itemID)
+	item, err := q.getItem(ctx, jobID, itemID)
 	if err != nil {
 		return fmt.Errorf("failed to get item: %w", err)
 	}
@@ -113,7 +113,7 @@ func (q *RedisQueue) Ack(ctx context.Context, jobID string, itemID string, resul
 	item.Result = result
 
 	// Save updated item
-	if err := q.saveItem(ctx, item); err != nil {
+	if err := q.saveItem(ctx, jobID, item); err != nil {
 		return fmt.Errorf("failed to update item: %w", err)
 	}
 
@@ -147,7 +147,7 @@ func (q *RedisQueue) Nack(ctx context.Context, jobID string, itemID string, errM
 	q.client.LRem(ctx, q.processingKey(jobID), 1, itemID)
 
 	// Get the item
-	item, err := q.getItem(ctx, itemID)
+	item, err := q.getItem(ctx, jobID, itemID)
 	if err != nil {
 		return fmt.Errorf("failed to get item: %w", err)
 	}
@@ -162,7 +162,7 @@ func (q *RedisQueue) Nack(ctx context.Context, jobID string, itemID string, errM
 		}
 
 		// Save updated item
-		if err := q.saveItem(ctx, item); err != nil {
+		if err := q.saveItem(ctx, jobID, item); err != nil {
 			return fmt.Errorf("failed to update item: %w", err)
 		}
 
@@ -178,7 +178,7 @@ func (q *RedisQueue) Nack(ctx context.Context, jobID string, itemID string, errM
 		}
 
 		// Save updated item
-		if err := q.saveItem(ctx, item); err != nil {
+		if err := q.saveItem(ctx, jobID, item); err != nil {
 			return fmt.Errorf("failed to update item: %w", err)
 		}
 
@@ -213,7 +213,7 @@ func (q *RedisQueue) CompleteItem(ctx context.Context, jobID string, itemID stri
 	q.client.LRem(ctx, q.processingKey(jobID), 1, itemID)
 
 	// Get the item for scenarioID/providerID
-	item, err := q.getItem(ctx, itemID)
+	item, err := q.getItem(ctx, jobID, itemID)
 	if err != nil {
 		return fmt.Errorf("failed to get item: %w", err)
 	}
@@ -240,9 +240,12 @@ func (q *RedisQueue) CompleteItem(ctx context.Context, jobID string, itemID stri
 
 	// Build and execute the accumulator pipeline
 	pipe := q.client.Pipeline()
-	q.saveItemPipe(ctx, pipe, item)
+	q.saveItemPipe(ctx, pipe, jobID, item)
 	q.addToCompletedSetPipe(ctx, pipe, jobID, itemID)
 	q.incrementStatsPipe(ctx, pipe, jobID, item, result, alreadyCounted)
+	if !alreadyCounted {
+		q.publishEventPipe(ctx, pipe, jobID, newItemEvent(item, result))
+	}
 	_, err = pipe.Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to execute complete pipeline: %w", err)
@@ -273,7 +276,7 @@ func (q *RedisQueue) FailItem(ctx context.Context, jobID string, itemID string,
 	q.client.LRem(ctx, q.processingKey(jobID), 1, itemID)
 
 	// Get the item for scenarioID/providerID
-	item, err := q.getItem(ctx, itemID)
+	item, err := q.getItem(ctx, jobID, itemID)
 	if err != nil {
 		return fmt.Errorf("failed to get item: %w", err)
 	}
@@ -292,10 +295,11 @@ func (q *RedisQueue) FailItem(ctx context.Context, jobID string, itemID string,
 
 	// Build and execute the failure pipeline
 	pipe := q.client.Pipeline()
-	q.saveItemPipe(ctx, pipe, item)
+	q.saveItemPipe(ctx, pipe, jobID, item)
 	q.addToFailedSetPipe(ctx, pipe, jobID, itemID)
 	if !alreadyCounted {
 		q.incrementFailureStatsPipe(ctx, pipe, jobID, item)
+		q.publishEventPipe(ctx, pipe, jobID, newItemEvent(item, nil))
 	}
 	_, err = pipe.Exec(ctx)
 	if err != nil {
@@ -331,6 +335,11 @@ func (q *RedisQueue) incrementStatsPipe(
 		provKey := q.statsProviderKey(jobID, item.ProviderID)
 		q.incrStatsFields(ctx, pipe, provKey, result.Status, result.DurationMs, tokens, cost)
 	}
+
+	if item.Parameters != nil {
+		paramKey := q.statsParamSetKey(jobID, item.Parameters.Name)
+		q.incrStatsFields(ctx, pipe, paramKey, result.Status, result.DurationMs, tokens, cost)
+	}
 }
 
 // markStatsCounted atomically adds the item ID to the stats-counted set.
@@ -378,6 +387,10 @@ func (q *RedisQueue) incrementFailureStatsPipe(
 		provKey := q.statsProviderKey(jobID, item.ProviderID)
 		q.incrFailureFields(ctx, pipe, provKey)
 	}
+
+	if item.Parameters != nil {
+		q.incrFailureFields(ctx, pipe, q.statsParamSetKey(jobID, item.Parameters.Name))
+	}
 }
 
 // incrFailureFields adds HINCRBY commands for failure counters only.
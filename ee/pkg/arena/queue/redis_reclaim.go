@@ -23,16 +23,16 @@ func (q *RedisQueue) findLatestCompletionTime(ctx context.Context, jobID string)
 	var latestCompletion time.Time
 
 	// Check completed items using cursor-based iteration
-	q.scanSetForLatestCompletion(ctx, q.completedKey(jobID), &latestCompletion)
+	q.scanSetForLatestCompletion(ctx, jobID, q.completedKey(jobID), &latestCompletion)
 
 	// Check failed items using cursor-based iteration
-	q.scanSetForLatestCompletion(ctx, q.failedKey(jobID), &latestCompletion)
+	q.scanSetForLatestCompletion(ctx, jobID, q.failedKey(jobID), &latestCompletion)
 
 	return latestCompletion
 }
 
 // scanSetForLatestCompletion iterates a set with SScan and finds the latest completion time.
-func (q *RedisQueue) scanSetForLatestCompletion(ctx context.Context, setKey string, latest *time.Time) {
+func (q *RedisQueue) scanSetForLatestCompletion(ctx context.Context, jobID, setKey string, latest *time.Time) {
 	var cursor uint64
 	for {
 		ids, nextCursor, err := q.client.SScan(ctx, setKey, cursor, "", sscanCount).Result()
@@ -41,7 +41,7 @@ func (q *RedisQueue) scanSetForLatestCompletion(ctx context.Context, setKey stri
 		}
 
 		// Batch GET for this chunk of IDs
-		q.updateLatestFromIDs(ctx, ids, latest)
+		q.updateLatestFromIDs(ctx, jobID, ids, latest)
 
 		cursor = nextCursor
 		if cursor == 0 {
@@ -51,7 +51,7 @@ func (q *RedisQueue) scanSetForLatestCompletion(ctx context.Context, setKey stri
 }
 
 // updateLatestFromIDs uses a pipeline to batch-GET items and update the latest completion time.
-func (q *RedisQueue) updateLatestFromIDs(ctx context.Context, ids []string, latest *time.Time) {
+func (q *RedisQueue) updateLatestFromIDs(ctx context.Context, jobID string, ids []string, latest *time.Time) {
 	if len(ids) == 0 {
 		return
 	}
@@ -59,7 +59,7 @@ func (q *RedisQueue) updateLatestFromIDs(ctx context.Context, ids []string, late
 	pipe := q.client.Pipeline()
 	cmds := make([]*redis.StringCmd, len(ids))
 	for i, itemID := range ids {
-		cmds[i] = pipe.Get(ctx, q.itemKey(itemID))
+		cmds[i] = pipe.Get(ctx, q.itemKey(jobID, itemID))
 	}
 	_, _ = pipe.Exec(ctx)
 
@@ -118,7 +118,7 @@ func (q *RedisQueue) RequeueTimedOutItems(ctx context.Context, jobID string) (in
 		q.client.LRem(ctx, q.processingKey(jobID), 1, itemID)
 
 		// Get the item
-		item, err := q.getItem(ctx, itemID)
+		item, err := q.getItem(ctx, jobID, itemID)
 		if err != nil {
 			continue
 		}
@@ -127,7 +127,7 @@ func (q *RedisQueue) RequeueTimedOutItems(ctx context.Context, jobID string) (in
 		item.Status = ItemStatusPending
 		item.StartedAt = nil
 
-		if err := q.saveItem(ctx, item); err != nil {
+		if err := q.saveItem(ctx, jobID, item); err != nil {
 			continue
 		}
 
@@ -178,7 +178,7 @@ func (q *RedisQueue) getItemsFromSet(ctx context.Context, jobID, setKey, itemTyp
 		firstIteration = false
 
 		// Batch GET for this chunk of IDs
-		items := q.batchGetItems(ctx, ids)
+		items := q.batchGetItems(ctx, jobID, ids)
 		allItems = append(allItems, items...)
 
 		cursor = nextCursor
@@ -212,7 +212,7 @@ func (q *RedisQueue) checkJobExists(ctx context.Context, jobID string) error {
 }
 
 // batchGetItems uses a pipeline to batch-GET items by their IDs.
-func (q *RedisQueue) batchGetItems(ctx context.Context, ids []string) []*WorkItem {
+func (q *RedisQueue) batchGetItems(ctx context.Context, jobID string, ids []string) []*WorkItem {
 	if len(ids) == 0 {
 		return nil
 	}
@@ -230,7 +230,7 @@ func (q *RedisQueue) batchGetItems(ctx context.Context, ids []string) []*WorkIte
 		pipe := q.client.Pipeline()
 		cmds := make([]*redis.StringCmd, len(batch))
 		for j, itemID := range batch {
-			cmds[j] = pipe.Get(ctx, q.itemKey(itemID))
+			cmds[j] = pipe.Get(ctx, q.itemKey(jobID, itemID))
 		}
 		_, _ = pipe.Exec(ctx)
 
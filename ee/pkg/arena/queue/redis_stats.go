@@ -95,8 +95,9 @@ func (q *RedisQueue) GetStats(ctx context.Context, jobID string) (*JobStats, err
 	q.mu.RUnlock()
 
 	stats := &JobStats{
-		ByScenario: make(map[string]*GroupStats),
-		ByProvider: make(map[string]*GroupStats),
+		ByScenario:     make(map[string]*GroupStats),
+		ByProvider:     make(map[string]*GroupStats),
+		ByParameterSet: make(map[string]*GroupStats),
 	}
 
 	// Read main stats hash
@@ -113,6 +114,9 @@ func (q *RedisQueue) GetStats(ctx context.Context, jobID string) (*JobStats, err
 	// Scan for provider sub-keys
 	q.scanGroupStats(ctx, jobID, statsProviderKeyInfix, stats.ByProvider)
 
+	// Scan for parameter-set sub-keys
+	q.scanGroupStats(ctx, jobID, statsParamSetKeyInfix, stats.ByParameterSet)
+
 	return stats, nil
 }
 
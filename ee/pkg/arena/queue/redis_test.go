@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -89,7 +90,7 @@ func TestRedisQueue_Push(t *testing.T) {
 	assert.Equal(t, int64(2), pendingLen)
 
 	// Verify item data was stored
-	item1, err := q.getItem(ctx, "item-1")
+	item1, err := q.getItem(ctx, jobID, "item-1")
 	require.NoError(t, err)
 	assert.Equal(t, "item-1", item1.ID)
 	assert.Equal(t, jobID, item1.JobID)
@@ -155,7 +156,7 @@ func TestRedisQueue_Pop_ItemNotFound(t *testing.T) {
 	jobID := "test-job-pop-item-not-found"
 	items := []WorkItem{{ID: "item-1", ScenarioID: "scenario-1", ProviderID: "provider-1"}}
 	require.NoError(t, q.Push(ctx, jobID, items))
-	require.NoError(t, client.Del(ctx, q.itemKey("item-1")).Err())
+	require.NoError(t, client.Del(ctx, q.itemKey(jobID, "item-1")).Err())
 
 	_, err := q.Pop(ctx, jobID)
 	assert.Equal(t, ErrItemNotFound, err)
@@ -198,7 +199,7 @@ func TestRedisQueue_Ack(t *testing.T) {
 	assert.True(t, isMember)
 
 	// Verify item data was updated
-	updatedItem, err := q.getItem(ctx, item.ID)
+	updatedItem, err := q.getItem(ctx, jobID, item.ID)
 	require.NoError(t, err)
 	assert.Equal(t, ItemStatusCompleted, updatedItem.Status)
 	assert.NotNil(t, updatedItem.CompletedAt)
@@ -789,7 +790,7 @@ func TestRedisQueue_ItemTTL(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify item key has a TTL set
-	itemTTL, err := client.TTL(ctx, q.itemKey("item-ttl-1")).Result()
+	itemTTL, err := client.TTL(ctx, q.itemKey(jobID, "item-ttl-1")).Result()
 	require.NoError(t, err)
 	assert.Greater(t, itemTTL, time.Duration(0), "item key should have a TTL")
 	assert.LessOrEqual(t, itemTTL, ttl, "item TTL should not exceed configured TTL")
@@ -1172,6 +1173,39 @@ func TestRedisQueue_FailItem(t *testing.T) {
 	assert.Equal(t, int64(0), processing)
 }
 
+// TestRedisQueue_StatsByParameterSet verifies that pass and failure
+// accumulators are grouped by the work item's parameter set.
+func TestRedisQueue_StatsByParameterSet(t *testing.T) {
+	client := getTestRedisClient(t)
+	defer cleanupRedisKeys(t, client)
+	defer func() { _ = client.Close() }()
+
+	q := NewRedisQueueFromClient(client, Options{VisibilityTimeout: 5 * time.Minute, MaxRetries: 1})
+	defer func() { _ = q.Close() }()
+
+	ctx := context.Background()
+	jobID := "test-param-stats"
+
+	items := []WorkItem{
+		{ID: "item-1", ScenarioID: failTestScenario, ProviderID: failTestProvider, Parameters: &ParameterSet{Name: "precise"}},
+		{ID: "item-2", ScenarioID: failTestScenario, ProviderID: failTestProvider, Parameters: &ParameterSet{Name: "creative"}},
+	}
+	require.NoError(t, q.Push(ctx, jobID, items))
+	for range items {
+		_, err := q.Pop(ctx, jobID)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, q.CompleteItem(ctx, jobID, "item-1", &ItemResult{Status: "pass", DurationMs: 50}))
+	require.NoError(t, q.FailItem(ctx, jobID, "item-2", errors.New("execution timeout")))
+
+	stats, err := q.GetStats(ctx, jobID)
+	require.NoError(t, err)
+	require.Len(t, stats.ByParameterSet, 2)
+	assert.Equal(t, int64(1), stats.ByParameterSet["precise"].Passed)
+	assert.Equal(t, int64(1), stats.ByParameterSet["creative"].Failed)
+}
+
 // TestRedisQueue_FailItem_NotInProcessing verifies that failing an item that is
 // not currently in the processing set returns ErrItemNotFound.
 func TestRedisQueue_FailItem_NotInProcessing(t *testing.T) {
@@ -1225,3 +1259,52 @@ func TestRedisQueue_FailItem_Idempotent(t *testing.T) {
 	assert.Equal(t, int64(1), stats.Passed)
 	assert.Equal(t, int64(0), stats.Failed, "already-counted item must not increment failed")
 }
+
+func TestRedisQueue_ScopedJobIDsIsolateNamespaces(t *testing.T) {
+	client := getTestRedisClient(t)
+	defer cleanupRedisKeys(t, client)
+	defer func() { _ = client.Close() }()
+
+	q := NewRedisQueueFromClient(client, DefaultOptions())
+	ctx := context.Background()
+
+	// Same job name and item IDs in two namespaces must not share state.
+	jobA := ScopedJobID("tenant-a", "eval")
+	jobB := ScopedJobID("tenant-b", "eval")
+	require.NoError(t, q.Push(ctx, jobA, []WorkItem{{ID: "eval-default-0", ScenarioID: "a"}}))
+	require.NoError(t, q.Push(ctx, jobB, []WorkItem{{ID: "eval-default-0", ScenarioID: "b"}}))
+
+	itemA, err := q.Pop(ctx, jobA)
+	require.NoError(t, err)
+	assert.Equal(t, "a", itemA.ScenarioID)
+	require.NoError(t, q.Ack(ctx, jobA, itemA.ID, []byte(`{"status":"pass"}`)))
+
+	progressB, err := q.Progress(ctx, jobB)
+	require.NoError(t, err)
+	assert.Equal(t, 1, progressB.Pending)
+	assert.Equal(t, 0, progressB.Completed)
+
+	itemB, err := q.Pop(ctx, jobB)
+	require.NoError(t, err)
+	assert.Equal(t, "b", itemB.ScenarioID)
+	assert.Equal(t, ItemStatusProcessing, itemB.Status)
+}
+
+func TestScopedJobID(t *testing.T) {
+	assert.Equal(t, "ns/job", ScopedJobID("ns", "job"))
+	assert.Equal(t, "job", ScopedJobID("", "job"))
+}
+
+func TestRedisQueue_Ping(t *testing.T) {
+	mr := miniredis.RunT(t)
+	q := NewRedisQueueFromClient(redis.NewClient(&redis.Options{Addr: mr.Addr()}), DefaultOptions())
+	ctx := context.Background()
+
+	require.NoError(t, q.Ping(ctx))
+
+	mr.Close()
+	assert.Error(t, q.Ping(ctx))
+
+	require.NoError(t, q.Close())
+	assert.Equal(t, ErrQueueClosed, q.Ping(ctx))
+}
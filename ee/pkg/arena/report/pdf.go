@@ -0,0 +1,277 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/go-pdf/fpdf"
+
+	"github.com/altairalabs/omnia/ee/pkg/arena/aggregator"
+	"github.com/altairalabs/omnia/ee/pkg/arena/storage"
+)
+
+// PDF layout, in millimetres on A4 portrait.
+const (
+	pdfPageWidth = 190.0
+	pdfLineH     = 5.0
+	pdfRowH      = 6.0
+)
+
+// pdfFont is the family the embedded DejaVu Sans Condensed faces are
+// registered under. They cover the scripts transcripts and assertion messages
+// arrive in, which the built-in PDF fonts' cp1252 cannot encode.
+const pdfFont = "DejaVu"
+
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	pdfFontRegular []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	pdfFontBold []byte
+)
+
+// PDFFileName returns the PDF report file name for a job.
+func PDFFileName(jobName string) string {
+	return jobName + "-report.pdf"
+}
+
+// RenderPDF writes the PDF report for the given job results. It carries the
+// same sections as the HTML report, laid out for print, in an embedded UTF-8
+// font so the worker needs no font files.
+func RenderPDF(w io.Writer, results *storage.JobResults) error {
+	if results == nil || results.Summary == nil {
+		return fmt.Errorf("job results are required")
+	}
+	v := buildView(results)
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Arena report: "+v.JobID, true)
+	if !v.CompletedAt.IsZero() {
+		pdf.SetCreationDate(v.CompletedAt)
+	}
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddUTF8FontFromBytes(pdfFont, "", pdfFontRegular)
+	pdf.AddUTF8FontFromBytes(pdfFont, "B", pdfFontBold)
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("failed to load PDF report font: %w", err)
+	}
+	pdf.AddPage()
+	p := &pdfWriter{Fpdf: pdf}
+
+	p.SetFont(pdfFont, "B", 18)
+	p.text(10, "Arena report: "+v.JobID)
+	p.SetFont(pdfFont, "", 9)
+	p.SetTextColor(89, 99, 110)
+	p.text(pdfLineH, pdfMeta(v))
+	p.SetTextColor(31, 35, 40)
+	p.Ln(2)
+
+	summary := [][]string{{"Pass rate", pct(v.Summary.PassRate)}, {"Items", strconv.Itoa(v.Summary.TotalItems)},
+		{"Passed", strconv.Itoa(v.Summary.PassedItems)}, {"Failed", strconv.Itoa(v.Summary.FailedItems)},
+		{"Avg duration", ms(v.Summary.AvgDuration)}}
+	if v.HasCost {
+		summary = append(summary, []string{"Tokens", strconv.FormatInt(v.Summary.TotalTokens, 10)},
+			[]string{"Cost", usd(v.Summary.TotalCost)})
+	}
+	p.table([]string{"Summary", ""}, []float64{50, 50}, summary)
+
+	scoreHeader := []string{"", "Total", "Passed", "Failed", "Pass rate", "Avg duration"}
+	scoreWidths := []float64{70, 20, 20, 20, 25, 35}
+	p.scores("Scores by provider", "Provider", scoreHeader, scoreWidths, v.Providers)
+	p.scores("Scores by parameter set", "Parameter set", scoreHeader, scoreWidths, v.ParamSets)
+	p.scores("Scores by scenario", "Scenario", scoreHeader, scoreWidths, scenarioRows(v.Scenarios))
+
+	if len(v.Datasets) > 0 {
+		p.heading("Golden datasets")
+		rows := make([][]string, 0, len(v.Datasets))
+		for _, d := range v.Datasets {
+			rows = append(rows, []string{d.Key, strconv.Itoa(d.Total), strconv.Itoa(d.Passed),
+				strconv.Itoa(d.Failed), pct(d.PassRate)})
+		}
+		p.table([]string{"Dataset", "Total", "Passed", "Failed", "Pass rate"}, []float64{90, 25, 25, 25, 25}, rows)
+	}
+
+	if v.HasCost {
+		p.heading("Cost breakdown")
+		costWidths := []float64{100, 30, 30, 30}
+		p.table([]string{"Provider", "Tokens", "Cost", "Share"}, costWidths, costRows(v.Providers))
+		p.Ln(3)
+		p.table([]string{"Scenario", "Tokens", "Cost", "Share"}, costWidths, costRows(scenarioRows(v.Scenarios)))
+	}
+
+	if len(v.Summary.Errors) > 0 {
+		p.heading("Errors")
+		rows := make([][]string, 0, len(v.Summary.Errors))
+		for _, e := range v.Summary.Errors {
+			rows = append(rows, []string{e.Message, strconv.Itoa(e.Count)})
+		}
+		p.table([]string{"Message", "Count"}, []float64{165, 25}, rows)
+	}
+
+	if len(v.Scenarios) > 0 {
+		p.heading("Scenario runs")
+		for _, s := range v.Scenarios {
+			p.scenario(s, len(v.ParamSets) > 0)
+		}
+	}
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to render PDF report: %w", err)
+	}
+	return nil
+}
+
+// pdfWriter adds the report's building blocks to an fpdf document.
+type pdfWriter struct {
+	*fpdf.Fpdf
+}
+
+func (p *pdfWriter) text(h float64, s string) {
+	p.MultiCell(pdfPageWidth, h, s, "", "L", false)
+}
+
+func (p *pdfWriter) heading(s string) {
+	p.Ln(4)
+	p.SetFont(pdfFont, "B", 13)
+	p.text(8, s)
+	p.SetFont(pdfFont, "", 9)
+}
+
+// table draws a bordered table with a shaded header row. Cells are clipped to
+// their column; long text belongs in text blocks, not tables.
+func (p *pdfWriter) table(header []string, widths []float64, rows [][]string) {
+	p.SetFont(pdfFont, "B", 9)
+	p.SetFillColor(246, 248, 250)
+	for i, h := range header {
+		p.CellFormat(widths[i], pdfRowH, h, "1", 0, "L", true, 0, "")
+	}
+	p.Ln(-1)
+	p.SetFont(pdfFont, "", 9)
+	for _, row := range rows {
+		for i, cell := range row {
+			align := "L"
+			if i > 0 {
+				align = "R"
+			}
+			p.CellFormat(widths[i], pdfRowH, p.fit(cell, widths[i]-2), "1", 0, align, false, 0, "")
+		}
+		p.Ln(-1)
+	}
+}
+
+// fit shortens s with an ellipsis to fit width, cutting whole characters.
+func (p *pdfWriter) fit(s string, width float64) string {
+	if p.GetStringWidth(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && p.GetStringWidth(string(r)+"…") > width {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "…"
+}
+
+func (p *pdfWriter) scores(title, first string, header []string, widths []float64, rows []groupRow) {
+	if len(rows) == 0 {
+		return
+	}
+	p.heading(title)
+	header = append([]string{first}, header[1:]...)
+	cells := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		cells = append(cells, []string{r.Name, strconv.Itoa(r.Total), strconv.Itoa(r.Passed),
+			strconv.Itoa(r.Failed), pct(r.PassRate), ms(r.AvgDuration)})
+	}
+	p.table(header, widths, cells)
+}
+
+// scenario draws one scenario's runs: a line per run with its status,
+// assertions and error, followed by its transcript.
+func (p *pdfWriter) scenario(s scenarioSection, paramSets bool) {
+	p.Ln(2)
+	p.SetFont(pdfFont, "B", 11)
+	p.text(7, s.Name)
+	for _, r := range s.Runs {
+		label := r.ProviderID
+		if paramSets && r.ParameterSetID != "" {
+			label += " / " + r.ParameterSetID
+		}
+		p.SetFont(pdfFont, "B", 9)
+		if r.Status == aggregator.StatusFail {
+			p.SetTextColor(207, 34, 46)
+		} else {
+			p.SetTextColor(26, 127, 55)
+		}
+		p.text(pdfLineH, fmt.Sprintf("%s: %s, %s", label, r.Status, ms(r.Duration)))
+		p.SetTextColor(31, 35, 40)
+		p.SetFont(pdfFont, "", 9)
+		if r.Error != "" {
+			p.text(pdfLineH, "Error: "+r.Error)
+		}
+		for _, a := range r.Assertions {
+			mark := "pass"
+			if !a.Passed {
+				mark = "fail"
+			}
+			line := fmt.Sprintf("[%s] %s", mark, a.Name)
+			if a.Message != "" {
+				line += ": " + a.Message
+			}
+			p.text(pdfLineH, line)
+		}
+		if r.SessionID != "" {
+			p.text(pdfLineH, "Session: "+r.SessionID)
+		}
+		for _, m := range r.Transcript {
+			p.SetFont(pdfFont, "B", 8)
+			p.SetTextColor(89, 99, 110)
+			p.text(4, m.Role)
+			p.SetFont(pdfFont, "", 9)
+			p.SetTextColor(31, 35, 40)
+			p.text(pdfLineH, m.Content)
+		}
+		p.Ln(2)
+	}
+}
+
+func costRows(rows []groupRow) [][]string {
+	cells := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		cells = append(cells, []string{r.Name, strconv.FormatInt(r.TotalTokens, 10), usd(r.TotalCost), pct(r.CostShare)})
+	}
+	return cells
+}
+
+func scenarioRows(sections []scenarioSection) []groupRow {
+	rows := make([]groupRow, len(sections))
+	for i, s := range sections {
+		rows[i] = s.groupRow
+	}
+	return rows
+}
+
+func pdfMeta(v view) string {
+	meta := ""
+	if v.Namespace != "" {
+		meta = "Namespace " + v.Namespace
+	}
+	if !v.StartedAt.IsZero() {
+		if meta != "" {
+			meta += " - "
+		}
+		meta += "Started " + datetime(v.StartedAt)
+	}
+	if !v.CompletedAt.IsZero() {
+		meta += " - Completed " + datetime(v.CompletedAt)
+	}
+	return meta
+}
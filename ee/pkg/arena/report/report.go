@@ -0,0 +1,203 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+// Package report renders completed Arena job results as a standalone HTML
+// report and a PDF with score tables, per-scenario run details and
+// transcripts, and cost breakdowns. The HTML report is self-contained (inline
+// CSS, no scripts).
+package report
+
+import (
+	_ "embed" // Required for //go:embed of the report template
+	"fmt"
+	"html/template"
+	"io"
+	"path"
+	"sort"
+	"time"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/arena/aggregator"
+	"github.com/altairalabs/omnia/ee/pkg/arena/storage"
+)
+
+//go:embed report.html.tmpl
+var reportTemplate string
+
+var tmpl = template.Must(template.New("report").Funcs(template.FuncMap{
+	"pct":      pct,
+	"ms":       ms,
+	"cost":     usd,
+	"datetime": datetime,
+}).Parse(reportTemplate))
+
+func pct(v float64) string        { return fmt.Sprintf("%.1f%%", v) }
+func ms(d time.Duration) string   { return fmt.Sprintf("%d ms", d.Milliseconds()) }
+func usd(v float64) string        { return fmt.Sprintf("$%.4f", v) }
+func datetime(t time.Time) string { return t.UTC().Format(time.RFC3339) }
+
+// FileName returns the report file name for a job. The job name is part of
+// the file name because PVC output directories are shared between jobs.
+func FileName(jobName string) string {
+	return jobName + "-report.html"
+}
+
+// Location returns the URL where the report for a job is persisted by the
+// given output config, or "" when the job has no durable output.
+func Location(jobName string, output *omniav1alpha1.OutputConfig) string {
+	if output == nil {
+		return ""
+	}
+	switch output.Type {
+	case omniav1alpha1.OutputTypeS3:
+		if output.S3 == nil {
+			return ""
+		}
+		return "s3://" + path.Join(output.S3.Bucket, output.S3.Prefix, jobName, FileName(jobName))
+	case omniav1alpha1.OutputTypePVC:
+		if output.PVC == nil {
+			return ""
+		}
+		return "pvc://" + path.Join(output.PVC.ClaimName, output.PVC.SubPath, FileName(jobName))
+	default:
+		return ""
+	}
+}
+
+// Render writes the HTML report for the given job results.
+func Render(w io.Writer, results *storage.JobResults) error {
+	if results == nil || results.Summary == nil {
+		return fmt.Errorf("job results are required")
+	}
+	if err := tmpl.Execute(w, buildView(results)); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	return nil
+}
+
+// view is the template model. Maps are flattened into sorted slices so the
+// report is deterministic.
+type view struct {
+	JobID       string
+	Namespace   string
+	StartedAt   time.Time
+	CompletedAt time.Time
+	Summary     *aggregator.AggregatedResult
+	Providers   []groupRow
+	ParamSets   []groupRow
+	Scenarios   []scenarioSection
+	Datasets    []datasetRow
+	HasCost     bool
+}
+
+type groupRow struct {
+	Name        string
+	Total       int
+	Passed      int
+	Failed      int
+	PassRate    float64
+	AvgDuration time.Duration
+	TotalTokens int64
+	TotalCost   float64
+	CostShare   float64
+}
+
+type scenarioSection struct {
+	groupRow
+	Runs []runRow
+}
+
+type runRow struct {
+	aggregator.ExecutionResult
+	Tokens int64
+	Cost   float64
+}
+
+type datasetRow struct {
+	Key string
+	*aggregator.DatasetStats
+}
+
+func buildView(results *storage.JobResults) view {
+	summary := results.Summary
+	v := view{
+		JobID:       results.JobID,
+		Namespace:   results.Namespace,
+		StartedAt:   results.StartedAt,
+		CompletedAt: results.CompletedAt,
+		Summary:     summary,
+		HasCost:     summary.TotalCost > 0 || summary.TotalTokens > 0,
+	}
+
+	for _, name := range sortedKeys(summary.ByProvider) {
+		s := summary.ByProvider[name]
+		v.Providers = append(v.Providers, newGroupRow(name, s.Total, s.Passed, s.Failed, s.PassRate,
+			s.AvgDuration, s.TotalTokens, s.TotalCost, summary.TotalCost))
+	}
+
+	for _, name := range sortedKeys(summary.ByParameterSet) {
+		s := summary.ByParameterSet[name]
+		v.ParamSets = append(v.ParamSets, newGroupRow(name, s.Total, s.Passed, s.Failed, s.PassRate,
+			s.AvgDuration, s.TotalTokens, s.TotalCost, summary.TotalCost))
+	}
+
+	runs := make(map[string][]runRow)
+	for _, r := range results.Results {
+		runs[r.ScenarioID] = append(runs[r.ScenarioID], runRow{
+			ExecutionResult: r,
+			Tokens:          int64(r.Metrics["tokens"]),
+			Cost:            r.Metrics["cost"],
+		})
+	}
+	for _, name := range sortedKeys(summary.ByScenario) {
+		s := summary.ByScenario[name]
+		scenarioRuns := runs[name]
+		sort.SliceStable(scenarioRuns, func(i, j int) bool {
+			return scenarioRuns[i].ProviderID < scenarioRuns[j].ProviderID
+		})
+		v.Scenarios = append(v.Scenarios, scenarioSection{
+			groupRow: newGroupRow(name, s.Total, s.Passed, s.Failed, s.PassRate,
+				s.AvgDuration, s.TotalTokens, s.TotalCost, summary.TotalCost),
+			Runs: scenarioRuns,
+		})
+	}
+
+	for _, key := range sortedKeys(summary.ByDataset) {
+		v.Datasets = append(v.Datasets, datasetRow{Key: key, DatasetStats: summary.ByDataset[key]})
+	}
+	return v
+}
+
+func newGroupRow(
+	name string, total, passed, failed int, passRate float64,
+	avg time.Duration, tokens int64, cost, totalCost float64,
+) groupRow {
+	row := groupRow{
+		Name:        name,
+		Total:       total,
+		Passed:      passed,
+		Failed:      failed,
+		PassRate:    passRate,
+		AvgDuration: avg,
+		TotalTokens: tokens,
+		TotalCost:   cost,
+	}
+	if totalCost > 0 {
+		row.CostShare = cost / totalCost * 100
+	}
+	return row
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/arena/aggregator"
+	"github.com/altairalabs/omnia/ee/pkg/arena/storage"
+)
+
+func TestRender(t *testing.T) {
+	results := &storage.JobResults{
+		JobID:       "nightly",
+		Namespace:   "team-a",
+		StartedAt:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		CompletedAt: time.Date(2026, 1, 2, 3, 9, 5, 0, time.UTC),
+		Summary: &aggregator.AggregatedResult{
+			TotalItems:  2,
+			PassedItems: 1,
+			FailedItems: 1,
+			PassRate:    50,
+			TotalTokens: 300,
+			TotalCost:   0.02,
+			ByScenario: map[string]*aggregator.ScenarioStats{
+				"refund": {Total: 2, Passed: 1, Failed: 1, PassRate: 50, TotalTokens: 300, TotalCost: 0.02},
+			},
+			ByProvider: map[string]*aggregator.ProviderStats{
+				"openai":    {Total: 1, Passed: 1, PassRate: 100, TotalTokens: 100, TotalCost: 0.005},
+				"anthropic": {Total: 1, Failed: 1, TotalTokens: 200, TotalCost: 0.015},
+			},
+			Errors: []aggregator.ErrorSummary{{Message: "<timeout>", Count: 1}},
+		},
+		Results: []aggregator.ExecutionResult{
+			{
+				ScenarioID: "refund", ProviderID: "openai", Status: aggregator.StatusPass,
+				Metrics:    map[string]float64{"tokens": 100, "cost": 0.005},
+				Assertions: []aggregator.AssertionResult{{Name: "mentions refund", Passed: true}},
+				SessionID:  "sess-1",
+				Transcript: []aggregator.TranscriptMessage{
+					{Role: "user", Content: "Where is my <refund>?"},
+					{Role: "assistant", Content: "It was issued today."},
+				},
+			},
+			{
+				ScenarioID: "refund", ProviderID: "anthropic", Status: aggregator.StatusFail,
+				Error:   "<timeout>",
+				Metrics: map[string]float64{"tokens": 200, "cost": 0.015},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, results); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	html := buf.String()
+
+	for _, want := range []string{
+		"Arena report: nightly",
+		"team-a",
+		"2026-01-02T03:04:05Z",
+		"Scores by provider",
+		"Cost breakdown",
+		"$0.0200",
+		"75.0%", // anthropic cost share
+		`id="scenario-refund"`,
+		"mentions refund",
+		"sess-1",
+		"&lt;timeout&gt;",
+		"Transcript: openai",
+		"Where is my &lt;refund&gt;?",
+		"It was issued today.",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+	if strings.Contains(html, "<timeout>") {
+		t.Error("error messages must be HTML-escaped")
+	}
+	if strings.Index(html, "anthropic") > strings.Index(html, "openai") {
+		t.Error("providers should be sorted by name")
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	results := &storage.JobResults{
+		JobID:       "nightly",
+		CompletedAt: time.Date(2026, 1, 2, 3, 9, 5, 0, time.UTC),
+		Summary: &aggregator.AggregatedResult{
+			TotalItems: 1, PassedItems: 1, PassRate: 100, TotalTokens: 10, TotalCost: 0.01,
+			ByScenario: map[string]*aggregator.ScenarioStats{"refund": {Total: 1, Passed: 1, PassRate: 100}},
+			ByProvider: map[string]*aggregator.ProviderStats{"openai": {Total: 1, Passed: 1, PassRate: 100}},
+		},
+		Results: []aggregator.ExecutionResult{{
+			ScenarioID: "refund", ProviderID: "openai", Status: aggregator.StatusPass,
+			Transcript: []aggregator.TranscriptMessage{
+				{Role: "user", Content: "Où est mon remboursement ? " + strings.Repeat("long ", 200)},
+				{Role: "assistant", Content: "Ваш возврат в пути — Η επιστροφή σας είναι καθ' οδόν ✓"},
+			},
+		}},
+	}
+	results.Summary.ByScenario["возврат-"+strings.Repeat("очень-длинный-", 10)] = &aggregator.ScenarioStats{Total: 1}
+
+	var buf bytes.Buffer
+	if err := RenderPDF(&buf, results); err != nil {
+		t.Fatalf("RenderPDF() error = %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Error("output is not a PDF")
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("/FontFile2")) || bytes.Contains(buf.Bytes(), []byte("Helvetica")) {
+		t.Error("the report must use its embedded UTF-8 font")
+	}
+	if err := RenderPDF(&bytes.Buffer{}, &storage.JobResults{JobID: "j"}); err == nil {
+		t.Error("expected error for missing summary")
+	}
+	if got := PDFFileName("job"); got != "job-report.pdf" {
+		t.Errorf("PDFFileName() = %q", got)
+	}
+}
+
+func TestRender_NoCostSection(t *testing.T) {
+	var buf bytes.Buffer
+	err := Render(&buf, &storage.JobResults{JobID: "j", Summary: &aggregator.AggregatedResult{}})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "Cost breakdown") {
+		t.Error("cost breakdown should be omitted when no tokens or cost were recorded")
+	}
+}
+
+func TestRender_ParameterSets(t *testing.T) {
+	results := &storage.JobResults{
+		JobID: "sweep",
+		Summary: &aggregator.AggregatedResult{
+			ByParameterSet: map[string]*aggregator.ParameterSetStats{
+				"precise": {Total: 1, Passed: 1, PassRate: 100},
+			},
+			ByScenario: map[string]*aggregator.ScenarioStats{"refund": {Total: 1, Passed: 1}},
+		},
+		Results: []aggregator.ExecutionResult{
+			{ScenarioID: "refund", ProviderID: "openai", ParameterSetID: "precise", Status: aggregator.StatusPass},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, results); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	html := buf.String()
+	if !strings.Contains(html, "Scores by parameter set") {
+		t.Error("report missing parameter set table")
+	}
+	if !strings.Contains(html, "<td>precise</td>") {
+		t.Error("scenario runs should show the parameter set")
+	}
+}
+
+func TestRender_RequiresSummary(t *testing.T) {
+	if err := Render(&bytes.Buffer{}, &storage.JobResults{JobID: "j"}); err == nil {
+		t.Error("expected error for missing summary")
+	}
+}
+
+func TestLocation(t *testing.T) {
+	tests := []struct {
+		name   string
+		output *omniav1alpha1.OutputConfig
+		want   string
+	}{
+		{"none", nil, ""},
+		{
+			"s3",
+			&omniav1alpha1.OutputConfig{
+				Type: omniav1alpha1.OutputTypeS3,
+				S3:   &omniav1alpha1.S3OutputConfig{Bucket: "results", Prefix: "arena"},
+			},
+			"s3://results/arena/job/job-report.html",
+		},
+		{
+			"pvc",
+			&omniav1alpha1.OutputConfig{
+				Type: omniav1alpha1.OutputTypePVC,
+				PVC:  &omniav1alpha1.PVCOutputConfig{ClaimName: "out", SubPath: "runs"},
+			},
+			"pvc://out/runs/job-report.html",
+		},
+		{"s3 without config", &omniav1alpha1.OutputConfig{Type: omniav1alpha1.OutputTypeS3}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Location("job", tt.output); got != tt.want {
+				t.Errorf("Location() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
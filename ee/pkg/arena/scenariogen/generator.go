@@ -0,0 +1,154 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+// Package scenariogen turns production sessions into Arena regression
+// scenarios. It samples sessions from a generate.SessionSourceAdapter (usually
+// the session-api backed "omnia" adapter), filters them by label and outcome,
+// strips PII from the replayed user turns, and writes the resulting
+// .scenario.yaml files into an ArenaSource-compatible directory layout.
+package scenariogen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/AltairaLabs/promptarena/arena/arenaconfig"
+	"github.com/AltairaLabs/promptarena/arena/generate"
+
+	"github.com/altairalabs/omnia/ee/pkg/redaction"
+)
+
+// overFetchFactor widens the List request when label filtering happens
+// client-side, so enough sessions survive the filter to reach the limit.
+const overFetchFactor = 5
+
+// Options controls which sessions are sampled and how they are converted.
+type Options struct {
+	// Labels restricts generation to sessions carrying every listed tag.
+	Labels []string
+
+	// Passed filters by outcome: nil=all, *true=passed only, *false=failed only.
+	Passed *bool
+
+	// EvalType restricts sampling to sessions with results for this eval.
+	EvalType string
+
+	// Limit caps the number of scenarios produced. 0 means unlimited.
+	Limit int
+
+	// TaskType overrides the generated scenario task_type.
+	TaskType string
+}
+
+// Generator samples sessions and converts them into scenarios.
+type Generator struct {
+	source   generate.SessionSourceAdapter
+	redactor redaction.TextRedactor
+}
+
+// NewGenerator creates a Generator reading from source. A nil redactor
+// disables PII redaction.
+func NewGenerator(source generate.SessionSourceAdapter, redactor redaction.TextRedactor) *Generator {
+	if redactor == nil {
+		redactor = redaction.NoOpRedactor{}
+	}
+	return &Generator{source: source, redactor: redactor}
+}
+
+// Generate samples sessions matching opts and returns one deduplicated,
+// PII-redacted scenario per distinct session.
+func (g *Generator) Generate(ctx context.Context, opts Options) ([]*arenaconfig.ScenarioConfig, error) {
+	if g.source == nil {
+		return nil, errors.New("scenariogen: session source is required")
+	}
+
+	summaries, err := g.source.List(ctx, listOptions(opts))
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+
+	details := make([]*generate.SessionDetail, 0, len(summaries))
+	for _, summary := range summaries {
+		if !hasAllLabels(summary.Tags, opts.Labels) {
+			continue
+		}
+		detail, err := g.source.Get(ctx, summary.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get session %s: %w", summary.ID, err)
+		}
+		details = append(details, detail)
+	}
+
+	details = generate.DeduplicateSessions(details)
+	if opts.Limit > 0 && len(details) > opts.Limit {
+		details = details[:opts.Limit]
+	}
+
+	scenarios := make([]*arenaconfig.ScenarioConfig, 0, len(details))
+	for _, detail := range details {
+		scenario, err := generate.ConvertSessionToScenario(detail, generate.ConvertOptions{TaskType: opts.TaskType})
+		if err != nil {
+			return nil, fmt.Errorf("convert session %s: %w", detail.ID, err)
+		}
+		if err := g.redactScenario(ctx, scenario); err != nil {
+			return nil, fmt.Errorf("redact session %s: %w", detail.ID, err)
+		}
+		scenarios = append(scenarios, scenario)
+	}
+	return scenarios, nil
+}
+
+// redactScenario strips PII from every replayed turn of the scenario.
+func (g *Generator) redactScenario(ctx context.Context, scenario *arenaconfig.ScenarioConfig) error {
+	for i := range scenario.Spec.Turns {
+		turn := &scenario.Spec.Turns[i]
+		redacted, err := g.redactor.RedactText(ctx, turn.Content)
+		if err != nil {
+			return err
+		}
+		turn.Content = redacted
+		for j := range turn.Parts {
+			if turn.Parts[j].Text == "" {
+				continue
+			}
+			redacted, err := g.redactor.RedactText(ctx, turn.Parts[j].Text)
+			if err != nil {
+				return err
+			}
+			turn.Parts[j].Text = redacted
+		}
+	}
+	return nil
+}
+
+// listOptions maps generator options onto the adapter's list options. When
+// labels are filtered client-side the limit is widened so the filter has
+// enough candidates to work with.
+func listOptions(opts Options) generate.ListOptions {
+	limit := opts.Limit
+	if limit > 0 && len(opts.Labels) > 0 {
+		limit *= overFetchFactor
+	}
+	return generate.ListOptions{
+		FilterPassed:   opts.Passed,
+		FilterEvalType: opts.EvalType,
+		Limit:          limit,
+	}
+}
+
+// hasAllLabels reports whether tags contains every entry in labels.
+func hasAllLabels(tags, labels []string) bool {
+	for _, label := range labels {
+		if !slices.Contains(tags, label) {
+			return false
+		}
+	}
+	return true
+}
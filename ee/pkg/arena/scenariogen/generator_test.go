@@ -0,0 +1,186 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package scenariogen
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AltairaLabs/PromptKit/runtime/types"
+	"github.com/AltairaLabs/promptarena/arena/generate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/redaction"
+)
+
+// fakeSource implements generate.SessionSourceAdapter for testing.
+type fakeSource struct {
+	summaries []generate.SessionSummary
+	details   map[string]*generate.SessionDetail
+	lastOpts  generate.ListOptions
+	listErr   error
+	getErr    error
+}
+
+func (f *fakeSource) Name() string { return "fake" }
+
+func (f *fakeSource) List(_ context.Context, opts generate.ListOptions) ([]generate.SessionSummary, error) {
+	f.lastOpts = opts
+	return f.summaries, f.listErr
+}
+
+func (f *fakeSource) Get(_ context.Context, id string) (*generate.SessionDetail, error) {
+	if f.getErr != nil {
+		return nil, f.getErr
+	}
+	return f.details[id], nil
+}
+
+func newFakeSource() *fakeSource {
+	mk := func(id, text string, tags ...string) *generate.SessionDetail {
+		return &generate.SessionDetail{
+			SessionSummary: generate.SessionSummary{ID: id, Tags: tags, HasFailures: true},
+			Messages:       []types.Message{types.NewUserMessage(text)},
+		}
+	}
+	details := map[string]*generate.SessionDetail{
+		"sess-a": mk("sess-a", "email me at jane@example.com", "billing", "escalated"),
+		"sess-b": mk("sess-b", "what is my balance", "billing"),
+		"sess-c": mk("sess-c", "reset my password", "auth"),
+	}
+	src := &fakeSource{details: details}
+	for _, id := range []string{"sess-a", "sess-b", "sess-c"} {
+		src.summaries = append(src.summaries, details[id].SessionSummary)
+	}
+	return src
+}
+
+func emailRedactor(t *testing.T) redaction.TextRedactor {
+	t.Helper()
+	r, err := redaction.NewPatternRedactor(&omniav1alpha1.PIIConfig{
+		Redact:   true,
+		Patterns: []string{"email"},
+	})
+	require.NoError(t, err)
+	return r
+}
+
+func TestGenerate_FiltersByLabelsAndRedacts(t *testing.T) {
+	src := newFakeSource()
+	failed := false
+	g := NewGenerator(src, emailRedactor(t))
+
+	scenarios, err := g.Generate(context.Background(), Options{
+		Labels: []string{"billing", "escalated"},
+		Passed: &failed,
+		Limit:  2,
+	})
+	require.NoError(t, err)
+	require.Len(t, scenarios, 1)
+
+	assert.Equal(t, "sess-a", scenarios[0].Spec.ID)
+	require.Len(t, scenarios[0].Spec.Turns, 1)
+	assert.NotContains(t, scenarios[0].Spec.Turns[0].Content, "jane@example.com")
+	assert.Equal(t, &failed, src.lastOpts.FilterPassed)
+	assert.Equal(t, 2*overFetchFactor, src.lastOpts.Limit)
+}
+
+func TestGenerate_LimitWithoutLabels(t *testing.T) {
+	src := newFakeSource()
+	g := NewGenerator(src, nil)
+
+	scenarios, err := g.Generate(context.Background(), Options{Limit: 2, TaskType: "support"})
+	require.NoError(t, err)
+	require.Len(t, scenarios, 2)
+	assert.Equal(t, 2, src.lastOpts.Limit)
+	assert.Equal(t, "support", scenarios[0].Spec.TaskType)
+	assert.Contains(t, scenarios[0].Spec.Turns[0].Content, "jane@example.com")
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	_, err := NewGenerator(nil, nil).Generate(context.Background(), Options{})
+	assert.Error(t, err)
+
+	src := newFakeSource()
+	src.listErr = errors.New("boom")
+	_, err = NewGenerator(src, nil).Generate(context.Background(), Options{})
+	assert.ErrorContains(t, err, "list sessions")
+
+	src = newFakeSource()
+	src.getErr = errors.New("boom")
+	_, err = NewGenerator(src, nil).Generate(context.Background(), Options{})
+	assert.ErrorContains(t, err, "get session")
+}
+
+func TestWriteAndRegisterScenarios(t *testing.T) {
+	root := t.TempDir()
+	scenarios, err := NewGenerator(newFakeSource(), nil).Generate(context.Background(), Options{})
+	require.NoError(t, err)
+
+	files, err := WriteScenarios(root, "", scenarios)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	assert.Equal(t, "scenarios/sess-a.scenario.yaml", files[0])
+
+	data, err := os.ReadFile(filepath.Join(root, files[0]))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "kind: Scenario")
+
+	configPath := filepath.Join(root, "config.arena.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`# arena config
+apiVersion: promptkit.altairalabs.ai/v1alpha1
+kind: Arena
+spec:
+  scenarios:
+    - file: scenarios/sess-a.scenario.yaml
+`), 0o644))
+
+	require.NoError(t, RegisterScenarios(configPath, files))
+	require.NoError(t, RegisterScenarios(configPath, files))
+
+	data, err = os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# arena config")
+
+	var cfg struct {
+		Spec struct {
+			Scenarios []struct {
+				File string `yaml:"file"`
+			} `yaml:"scenarios"`
+		} `yaml:"spec"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &cfg))
+	require.Len(t, cfg.Spec.Scenarios, 3)
+	assert.Equal(t, "scenarios/sess-c.scenario.yaml", cfg.Spec.Scenarios[2].File)
+}
+
+func TestRegisterScenarios_CreatesList(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.arena.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("kind: Arena\nspec:\n  defaults: {}\n"), 0o644))
+
+	require.NoError(t, RegisterScenarios(configPath, []string{"scenarios/x.scenario.yaml"}))
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "file: scenarios/x.scenario.yaml")
+}
+
+func TestRegisterScenarios_Errors(t *testing.T) {
+	dir := t.TempDir()
+	assert.Error(t, RegisterScenarios(filepath.Join(dir, "missing.yaml"), nil))
+
+	noSpec := filepath.Join(dir, "nospec.yaml")
+	require.NoError(t, os.WriteFile(noSpec, []byte("kind: Arena\n"), 0o644))
+	assert.ErrorContains(t, RegisterScenarios(noSpec, nil), "no spec")
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package scenariogen
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/AltairaLabs/promptarena/arena/arenaconfig"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultScenariosDir is the directory, relative to the source root, that
+	// generated scenarios are written to. It matches the layout used by the
+	// arena configs shipped with Omnia.
+	DefaultScenariosDir = "scenarios"
+
+	// ScenarioFileSuffix is the file suffix for generated scenarios.
+	ScenarioFileSuffix = ".scenario.yaml"
+
+	scenarioFileMode = 0o644
+	scenarioDirMode  = 0o755
+)
+
+// WriteScenarios writes each scenario to <root>/<scenariosDir>/<id>.scenario.yaml
+// and returns the written paths relative to root, in input order. Existing
+// files with the same name are overwritten so regeneration is idempotent.
+func WriteScenarios(root, scenariosDir string, scenarios []*arenaconfig.ScenarioConfig) ([]string, error) {
+	if scenariosDir == "" {
+		scenariosDir = DefaultScenariosDir
+	}
+	dir := filepath.Join(root, scenariosDir)
+	if err := os.MkdirAll(dir, scenarioDirMode); err != nil {
+		return nil, fmt.Errorf("create scenarios dir: %w", err)
+	}
+
+	files := make([]string, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		name := scenario.Spec.ID + ScenarioFileSuffix
+		data, err := yaml.Marshal(scenario)
+		if err != nil {
+			return nil, fmt.Errorf("marshal scenario %s: %w", scenario.Spec.ID, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, scenarioFileMode); err != nil {
+			return nil, fmt.Errorf("write scenario %s: %w", scenario.Spec.ID, err)
+		}
+		files = append(files, path.Join(filepath.ToSlash(scenariosDir), name))
+	}
+	return files, nil
+}
+
+// RegisterScenarios appends file entries to spec.scenarios of the arena
+// config at configPath, skipping files that are already referenced. The rest
+// of the document, including comments, is preserved.
+func RegisterScenarios(configPath string, files []string) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("read arena config: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parse arena config: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return fmt.Errorf("arena config %s is empty", configPath)
+	}
+
+	spec := mappingValue(doc.Content[0], "spec")
+	if spec == nil {
+		return fmt.Errorf("arena config %s has no spec", configPath)
+	}
+	list := mappingValue(spec, "scenarios")
+	if list == nil {
+		list = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		spec.Content = append(spec.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "scenarios"}, list)
+	}
+
+	existing := make([]string, 0, len(list.Content))
+	for _, entry := range list.Content {
+		if file := mappingValue(entry, "file"); file != nil {
+			existing = append(existing, file.Value)
+		}
+	}
+	for _, file := range files {
+		if slices.Contains(existing, file) {
+			continue
+		}
+		list.Content = append(list.Content, &yaml.Node{
+			Kind: yaml.MappingNode,
+			Tag:  "!!map",
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "file"},
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: file},
+			},
+		})
+		existing = append(existing, file)
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return fmt.Errorf("marshal arena config: %w", err)
+	}
+	return os.WriteFile(configPath, out, scenarioFileMode)
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,432 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package evals
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	runtimeevals "github.com/AltairaLabs/PromptKit/runtime/evals"
+	"github.com/AltairaLabs/PromptKit/runtime/evals/handlers"
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	goredis "github.com/redis/go-redis/v9"
+
+	v1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/session/api"
+)
+
+// judgeSpendKeyPrefix prefixes the per-judge, per-UTC-day Redis counters of
+// judge spend under EvalJudgeConfig.MaxCostPerDayUSD. Each counter outlives
+// its day so a replica whose clock lags still finds it.
+const (
+	judgeSpendKeyPrefix = "omnia:eval-judge-spend:"
+	judgeSpendTTL       = 48 * time.Hour
+)
+
+// Reasons a judge is passed over for the next one, as recorded in metrics.
+const (
+	judgeFallbackRateLimited = "rate_limited"
+	judgeFallbackJobBudget   = "job_budget"
+	judgeFallbackDailyBudget = "daily_budget"
+)
+
+// judgeDetailsKey is the key of the judge attribution in a result's details.
+const judgeDetailsKey = "judge"
+
+// skipReasonJudgesExhausted marks the results of evals no judge could score.
+const skipReasonJudgesExhausted = "judgesExhausted"
+
+// errJudgesExhausted is returned by a judgeChain whose judges are all
+// rate-limited or over budget.
+var errJudgesExhausted = errors.New("every judge is rate-limited or over budget")
+
+// JudgePolicy is an agent's resolved spec.evals.judge: the judges an
+// evaluation tries, in order, and the spend ceilings each is held to.
+type JudgePolicy struct {
+	// Providers are the judges' provider names, primary first.
+	Providers []string
+	// MaxCostPerJob caps each judge's spend within one evaluation, in USD.
+	// Zero means no cap.
+	MaxCostPerJob float64
+	// MaxCostPerDay caps each judge's spend per UTC day, in USD. Zero means
+	// no cap.
+	MaxCostPerDay float64
+}
+
+// judgePolicyFor converts an agent's judge config to a JudgePolicy. Returns
+// nil when no judge is configured. Cost ceilings that don't parse are
+// ignored; the CRD's validation pattern rules them out.
+func judgePolicyFor(cfg *v1alpha1.EvalJudgeConfig) *JudgePolicy {
+	if cfg == nil || len(cfg.Providers) == 0 {
+		return nil
+	}
+	return &JudgePolicy{
+		Providers:     cfg.Providers,
+		MaxCostPerJob: parseCostUSD(cfg.MaxCostPerJobUSD),
+		MaxCostPerDay: parseCostUSD(cfg.MaxCostPerDayUSD),
+	}
+}
+
+func parseCostUSD(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// judgeAttribution identifies the judge that scored an eval. It is recorded
+// in the result's details so analysis can tell which judge scored what.
+type judgeAttribution struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model,omitempty"`
+	// Fallback is true when the primary judge was passed over.
+	Fallback bool `json:"fallback"`
+}
+
+// judgeOutcome is what the chain did for one eval: the judge that answered,
+// or that no judge was available.
+type judgeOutcome struct {
+	judge     *judgeAttribution
+	exhausted bool
+}
+
+// chainJudge is one judge of a judgeChain and its standing in the job.
+type chainJudge struct {
+	name        string
+	model       string
+	judge       handlers.JudgeProvider
+	jobSpend    float64
+	rateLimited bool
+}
+
+// judgeChain is the handlers.JudgeProvider of one eval job (one
+// sdk.Evaluate call) for an agent with spec.evals.judge. Each judge request
+// goes to the first judge that is neither rate-limited nor over its per-job
+// or daily ceiling; a judge that answers 429 is passed over for the rest of
+// the job. Spend is the cost the provider reports for each call, so ceilings
+// are checked before a call and may be overshot by the call that reaches
+// them. Daily spend is shared by all worker replicas through Redis; without
+// Redis, or when Redis fails, the daily ceiling is not enforced.
+type judgeChain struct {
+	policy    *JudgePolicy
+	namespace string
+	agent     string
+	sessionID string
+	redis     goredis.UniversalClient
+	metrics   WorkerMetricsRecorder
+	logger    *slog.Logger
+	// bus receives the judges' provider call events; nil drops them.
+	bus events.Bus
+
+	mu       sync.Mutex
+	judges   []*chainJudge
+	current  *judgeOutcome
+	outcomes map[string]judgeOutcome
+}
+
+// newJudgeChain builds the chain for policy from the agent's resolved
+// providers. Returns nil when none of the policy's providers resolved.
+func newJudgeChain(
+	policy *JudgePolicy,
+	specs map[string]providers.ProviderSpec,
+	labels EvalLabels,
+	sessionID string,
+	redisClient goredis.UniversalClient,
+	metrics WorkerMetricsRecorder,
+	logger *slog.Logger,
+) *judgeChain {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	if metrics == nil {
+		metrics = &NoOpWorkerMetrics{}
+	}
+	c := &judgeChain{
+		policy:    policy,
+		namespace: labels.Namespace,
+		agent:     labels.Agent,
+		sessionID: sessionID,
+		redis:     redisClient,
+		metrics:   metrics,
+		logger:    logger,
+		outcomes:  make(map[string]judgeOutcome),
+	}
+	for _, name := range policy.Providers {
+		spec, ok := specs[name]
+		if !ok {
+			logger.Warn("eval judge not found in agent providers",
+				"judge", name,
+				"agentName", labels.Agent,
+				"namespace", labels.Namespace,
+			)
+			continue
+		}
+		c.judges = append(c.judges, &chainJudge{
+			name:  name,
+			model: spec.Model,
+			judge: handlers.NewSpecJudgeProvider(&spec),
+		})
+	}
+	if len(c.judges) == 0 {
+		return nil
+	}
+	return c
+}
+
+// Judge sends the request to the first available judge, falling back to the
+// next one while judges answer with a rate-limit error.
+//
+//nolint:gocritic // JudgeOpts is passed by value by the JudgeProvider interface
+func (c *judgeChain) Judge(ctx context.Context, opts handlers.JudgeOpts) (*handlers.JudgeResult, error) {
+	var lastErr error
+	for i, j := range c.judges {
+		if reason := c.unavailable(ctx, j); reason != "" {
+			c.metrics.RecordJudgeFallback(j.name, reason)
+			continue
+		}
+		result, err := c.call(ctx, j, opts)
+		if err != nil && isRateLimited(err) {
+			c.mu.Lock()
+			j.rateLimited = true
+			c.mu.Unlock()
+			c.metrics.RecordJudgeFallback(j.name, judgeFallbackRateLimited)
+			c.logger.Debug("eval judge rate-limited", "judge", j.name, "sessionID", c.sessionID)
+			lastErr = err
+			continue
+		}
+		c.setCurrent(&judgeOutcome{judge: &judgeAttribution{Provider: j.name, Model: j.model, Fallback: i > 0}})
+		return result, err
+	}
+
+	c.setCurrent(&judgeOutcome{exhausted: true})
+	if lastErr != nil {
+		return nil, fmt.Errorf("%w: %w", errJudgesExhausted, lastErr)
+	}
+	return nil, errJudgesExhausted
+}
+
+// unavailable returns the reason judge j must be passed over, or "".
+func (c *judgeChain) unavailable(ctx context.Context, j *chainJudge) string {
+	c.mu.Lock()
+	rateLimited, jobSpend := j.rateLimited, j.jobSpend
+	c.mu.Unlock()
+
+	switch {
+	case rateLimited:
+		return judgeFallbackRateLimited
+	case c.policy.MaxCostPerJob > 0 && jobSpend >= c.policy.MaxCostPerJob:
+		return judgeFallbackJobBudget
+	case c.policy.MaxCostPerDay > 0 && c.dailySpend(ctx, j.name) >= c.policy.MaxCostPerDay:
+		return judgeFallbackDailyBudget
+	}
+	return ""
+}
+
+// call sends the request to judge j and counts the cost of the provider
+// call against its ceilings.
+//
+//nolint:gocritic // JudgeOpts is passed by value by the JudgeProvider interface
+func (c *judgeChain) call(
+	ctx context.Context, j *chainJudge, opts handlers.JudgeOpts,
+) (*handlers.JudgeResult, error) {
+	meter := &judgeCallMeter{next: c.bus}
+	opts.Emitter = events.NewEmitter(meter, "", c.sessionID, "")
+	result, err := j.judge.Judge(ctx, opts)
+
+	cost := meter.total()
+	if cost > 0 {
+		c.mu.Lock()
+		j.jobSpend += cost
+		c.mu.Unlock()
+		c.addDailySpend(ctx, j.name, cost)
+	}
+	return result, err
+}
+
+// dailySpend returns what the judge has spent today. Spend that cannot be
+// read is logged and treated as zero.
+func (c *judgeChain) dailySpend(ctx context.Context, judge string) float64 {
+	if c.redis == nil {
+		return 0
+	}
+	key := judgeSpendKey(c.namespace, c.agent, judge, time.Now())
+	spent, err := c.redis.Get(ctx, key).Float64()
+	if err != nil {
+		if !errors.Is(err, goredis.Nil) {
+			c.logger.Warn("failed to read eval judge spend", "key", key, "error", err)
+		}
+		return 0
+	}
+	return spent
+}
+
+// addDailySpend adds cost to the judge's spend today.
+func (c *judgeChain) addDailySpend(ctx context.Context, judge string, cost float64) {
+	if c.redis == nil || c.policy.MaxCostPerDay <= 0 {
+		return
+	}
+	key := judgeSpendKey(c.namespace, c.agent, judge, time.Now())
+	pipe := c.redis.TxPipeline()
+	pipe.IncrByFloat(ctx, key, cost)
+	pipe.Expire(ctx, key, judgeSpendTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.logger.Warn("failed to record eval judge spend", "key", key, "error", err)
+	}
+}
+
+// judgeSpendKey returns the Redis counter of a judge's spend for an agent in
+// the UTC day containing now.
+func judgeSpendKey(namespace, agentName, judge string, now time.Time) string {
+	return judgeSpendKeyPrefix + namespace + ":" + agentName + ":" + judge + ":" + now.UTC().Format("20060102")
+}
+
+// isRateLimited reports whether err is a provider's 429 response.
+func isRateLimited(err error) bool {
+	var httpErr *providers.ProviderHTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests
+}
+
+func (c *judgeChain) setCurrent(o *judgeOutcome) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current = o
+}
+
+// settle assigns the judge calls made since the previous eval finished to
+// the eval that just finished.
+func (c *judgeChain) settle(evalID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.current != nil {
+		c.outcomes[evalID] = *c.current
+		c.current = nil
+	}
+}
+
+// observe wraps the evaluation's event bus so the chain learns which eval
+// each judge call belongs to. The eval runner runs evals one at a time and
+// publishes each one's completed or failed event before starting the next.
+func (c *judgeChain) observe(bus events.Bus) events.Bus {
+	return &judgeAttributionBus{Bus: bus, chain: c}
+}
+
+// skipExhausted marks the results of evals that no judge could score as
+// skipped. Like answerless sessions, they say nothing about answer quality,
+// and a score of 0 would read as a regression.
+func (c *judgeChain) skipExhausted(results []runtimeevals.EvalResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range results {
+		if o, ok := c.outcomes[results[i].EvalID]; ok && o.exhausted {
+			results[i].Skipped = true
+			results[i].SkipReason = skipReasonJudgesExhausted
+		}
+	}
+}
+
+// attribute records the judge that scored each item in its details.
+func (c *judgeChain) attribute(items []api.EvaluateResultItem) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range items {
+		o, ok := c.outcomes[items[i].EvalID]
+		if !ok || o.judge == nil {
+			continue
+		}
+		items[i].Details = withJudgeDetails(items[i].Details, o.judge)
+	}
+}
+
+// withJudgeDetails adds the judge attribution to a details JSON object.
+func withJudgeDetails(details json.RawMessage, judge *judgeAttribution) json.RawMessage {
+	fields := make(map[string]any)
+	if len(details) > 0 {
+		if err := json.Unmarshal(details, &fields); err != nil {
+			return details
+		}
+	}
+	fields[judgeDetailsKey] = judge
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return details
+	}
+	return data
+}
+
+// judgeAttributionBus passes every event to the evaluation's bus, settling
+// the chain's judge calls on each eval's completed or failed event.
+type judgeAttributionBus struct {
+	events.Bus
+	chain *judgeChain
+}
+
+// Publish settles eval results on the chain before forwarding the event.
+func (b *judgeAttributionBus) Publish(e *events.Event) bool {
+	if data, ok := e.Data.(*events.EvalEventData); ok {
+		b.chain.settle(data.EvalID)
+	}
+	return b.Bus.Publish(e)
+}
+
+// judgeCallMeter is the bus a chained judge emits its provider call events
+// on. It totals the cost of the calls and forwards the events to the
+// evaluation's bus, if any. Only the emitter publishes to it, so it takes
+// no listeners of its own.
+type judgeCallMeter struct {
+	next events.Bus
+
+	mu   sync.Mutex
+	cost float64
+}
+
+// Publish records the cost of completed provider calls.
+func (m *judgeCallMeter) Publish(e *events.Event) bool {
+	if data, ok := e.Data.(*events.ProviderCallCompletedData); ok {
+		m.mu.Lock()
+		m.cost += data.Cost
+		m.mu.Unlock()
+	}
+	if m.next == nil {
+		return true
+	}
+	return m.next.Publish(e)
+}
+
+// Subscribe is a no-op; see judgeCallMeter.
+func (m *judgeCallMeter) Subscribe(events.EventType, events.Listener) func() { return func() {} }
+
+// SubscribeAll is a no-op; see judgeCallMeter.
+func (m *judgeCallMeter) SubscribeAll(events.Listener) func() { return func() {} }
+
+// Close is a no-op: the meter owns nothing to drain.
+func (m *judgeCallMeter) Close() {}
+
+func (m *judgeCallMeter) total() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cost
+}
+
+var (
+	_ handlers.JudgeProvider = (*judgeChain)(nil)
+	_ events.Bus             = (*judgeAttributionBus)(nil)
+	_ events.Bus             = (*judgeCallMeter)(nil)
+)
@@ -0,0 +1,256 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package evals
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	runtimeevals "github.com/AltairaLabs/PromptKit/runtime/evals"
+	"github.com/AltairaLabs/PromptKit/runtime/evals/handlers"
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/api"
+)
+
+// stubJudge answers judge requests with a fixed result or error, reporting
+// cost for each call the way SpecJudgeProvider does.
+type stubJudge struct {
+	cost  float64
+	err   error
+	calls int
+}
+
+//nolint:gocritic // matches the JudgeProvider interface
+func (s *stubJudge) Judge(ctx context.Context, opts handlers.JudgeOpts) (*handlers.JudgeResult, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	opts.Emitter.ProviderCallCompletedCtx(ctx, &events.ProviderCallCompletedData{
+		Provider: "stub",
+		Cost:     s.cost,
+		Source:   events.SourceJudge,
+	})
+	return &handlers.JudgeResult{Passed: true, Score: 1}, nil
+}
+
+var errRateLimited = fmt.Errorf("judge predict failed: %w",
+	&providers.ProviderHTTPError{StatusCode: http.StatusTooManyRequests, Body: "slow down"})
+
+// newStubChain builds a chain over the "primary" and "fallback" judges.
+func newStubChain(t *testing.T, policy *JudgePolicy, primary, fallback *stubJudge,
+	metrics WorkerMetricsRecorder) *judgeChain {
+	t.Helper()
+	specs := map[string]providers.ProviderSpec{
+		"primary":  {ID: "primary", Type: "openai", Model: "gpt-4o"},
+		"fallback": {ID: "fallback", Type: "openai", Model: "gpt-4o-mini"},
+	}
+	policy.Providers = []string{"primary", "fallback"}
+	c := newJudgeChain(policy, specs, EvalLabels{Agent: "agent", Namespace: "ns"}, "s1", nil, metrics, nil)
+	require.NotNil(t, c)
+	c.judges[0].judge = primary
+	c.judges[1].judge = fallback
+	return c
+}
+
+func TestJudgePolicyFor(t *testing.T) {
+	assert.Nil(t, judgePolicyFor(nil))
+	assert.Nil(t, judgePolicyFor(&v1alpha1.EvalJudgeConfig{}))
+
+	policy := judgePolicyFor(&v1alpha1.EvalJudgeConfig{
+		Providers:        []string{"primary", "fallback"},
+		MaxCostPerJobUSD: "0.05",
+		MaxCostPerDayUSD: "20",
+	})
+	require.NotNil(t, policy)
+	assert.Equal(t, []string{"primary", "fallback"}, policy.Providers)
+	assert.InDelta(t, 0.05, policy.MaxCostPerJob, 1e-9)
+	assert.InDelta(t, 20, policy.MaxCostPerDay, 1e-9)
+}
+
+func TestNewJudgeChain_NoResolvedJudges(t *testing.T) {
+	specs := map[string]providers.ProviderSpec{"other": {ID: "other"}}
+	c := newJudgeChain(&JudgePolicy{Providers: []string{"missing"}}, specs, EvalLabels{}, "s1", nil, nil, nil)
+	assert.Nil(t, c)
+}
+
+func TestJudgeChain_FallsBackWhenRateLimited(t *testing.T) {
+	primary := &stubJudge{err: errRateLimited}
+	fallback := &stubJudge{}
+	spy := &spyMetrics{}
+	c := newStubChain(t, &JudgePolicy{}, primary, fallback, spy)
+
+	for range 2 {
+		result, err := c.Judge(context.Background(), handlers.JudgeOpts{})
+		require.NoError(t, err)
+		assert.True(t, result.Passed)
+	}
+	assert.Equal(t, 1, primary.calls, "a rate-limited judge is passed over for the rest of the job")
+	assert.Equal(t, 2, fallback.calls)
+	assert.Equal(t, []judgeFallbackCall{
+		{"primary", judgeFallbackRateLimited},
+		{"primary", judgeFallbackRateLimited},
+	}, spy.judgeFallbacks)
+	assert.Equal(t, &judgeAttribution{Provider: "fallback", Model: "gpt-4o-mini", Fallback: true}, c.current.judge)
+}
+
+func TestJudgeChain_OtherErrorsDoNotFallBack(t *testing.T) {
+	primary := &stubJudge{err: errors.New("bad request")}
+	fallback := &stubJudge{}
+	c := newStubChain(t, &JudgePolicy{}, primary, fallback, nil)
+
+	_, err := c.Judge(context.Background(), handlers.JudgeOpts{})
+	require.Error(t, err)
+	assert.Zero(t, fallback.calls)
+	assert.Equal(t, "primary", c.current.judge.Provider)
+}
+
+func TestJudgeChain_JobBudget(t *testing.T) {
+	primary := &stubJudge{cost: 0.02}
+	fallback := &stubJudge{cost: 0.001}
+	spy := &spyMetrics{}
+	c := newStubChain(t, &JudgePolicy{MaxCostPerJob: 0.03}, primary, fallback, spy)
+
+	for range 3 {
+		_, err := c.Judge(context.Background(), handlers.JudgeOpts{})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, primary.calls, "the call that reaches the ceiling still runs")
+	assert.Equal(t, 1, fallback.calls)
+	assert.Equal(t, []judgeFallbackCall{{"primary", judgeFallbackJobBudget}}, spy.judgeFallbacks)
+}
+
+func TestJudgeChain_DailyBudget(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	primary := &stubJudge{cost: 0.5}
+	fallback := &stubJudge{cost: 0.25}
+	c := newStubChain(t, &JudgePolicy{MaxCostPerDay: 1}, primary, fallback, nil)
+	c.redis = client
+	ctx := context.Background()
+
+	// Another replica already spent the primary's day.
+	primaryKey := judgeSpendKey("ns", "agent", "primary", time.Now())
+	require.NoError(t, client.Set(ctx, primaryKey, "1.0", 0).Err())
+
+	_, err := c.Judge(ctx, handlers.JudgeOpts{})
+	require.NoError(t, err)
+	assert.Zero(t, primary.calls)
+	assert.Equal(t, 1, fallback.calls)
+
+	fallbackKey := judgeSpendKey("ns", "agent", "fallback", time.Now())
+	spent, err := client.Get(ctx, fallbackKey).Float64()
+	require.NoError(t, err)
+	assert.InDelta(t, 0.25, spent, 1e-9)
+	assert.Positive(t, mr.TTL(fallbackKey))
+}
+
+func TestJudgeChain_Exhausted(t *testing.T) {
+	c := newStubChain(t, &JudgePolicy{}, &stubJudge{err: errRateLimited}, &stubJudge{err: errRateLimited}, nil)
+
+	_, err := c.Judge(context.Background(), handlers.JudgeOpts{})
+	require.ErrorIs(t, err, errJudgesExhausted)
+	assert.True(t, isRateLimited(err))
+
+	c.settle("judged")
+	results := []runtimeevals.EvalResult{{EvalID: "judged"}, {EvalID: "contains"}}
+	c.skipExhausted(results)
+	assert.True(t, results[0].Skipped)
+	assert.Equal(t, skipReasonJudgesExhausted, results[0].SkipReason)
+	assert.False(t, results[1].Skipped)
+}
+
+func TestJudgeChain_AttributesResults(t *testing.T) {
+	primary := &stubJudge{cost: 0.01}
+	c := newStubChain(t, &JudgePolicy{}, primary, &stubJudge{}, nil)
+	bus := events.NewEventBus()
+	completed := make(chan string, 1)
+	bus.Subscribe(events.EventProviderCallCompleted, func(e *events.Event) {
+		completed <- e.Data.(*events.ProviderCallCompletedData).Provider
+	})
+	c.bus = bus
+	observed := c.observe(bus)
+
+	_, err := c.Judge(context.Background(), handlers.JudgeOpts{})
+	require.NoError(t, err)
+	observed.Publish(&events.Event{Type: events.EventEvalCompleted, Data: &events.EvalEventData{EvalID: "judged"}})
+	observed.Publish(&events.Event{Type: events.EventEvalCompleted, Data: &events.EvalEventData{EvalID: "contains"}})
+	bus.Close()
+	assert.Equal(t, "stub", <-completed, "judge calls reach the evaluation's bus")
+
+	items := []api.EvaluateResultItem{
+		{EvalID: "judged", Details: json.RawMessage(`{"explanation":"good"}`)},
+		{EvalID: "contains"},
+	}
+	c.attribute(items)
+
+	var details map[string]any
+	require.NoError(t, json.Unmarshal(items[0].Details, &details))
+	assert.Equal(t, "good", details["explanation"])
+	assert.Equal(t, map[string]any{"provider": "primary", "model": "gpt-4o", "fallback": false}, details[judgeDetailsKey])
+	assert.Nil(t, items[1].Details)
+}
+
+func TestSDKRunner_RunTurnEvals_JudgeFallback(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"error":{"message":"rate limited"}}`, http.StatusTooManyRequests)
+	}))
+	t.Cleanup(primary.Close)
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"c1","object":"chat.completion","model":"gpt-4o-mini",` +
+			`"choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant",` +
+			`"content":"{\"passed\":true,\"score\":0.9,\"reasoning\":\"helpful\"}"}}],` +
+			`"usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}}`))
+	}))
+	t.Cleanup(fallback.Close)
+
+	specs := map[string]providers.ProviderSpec{
+		"primary":  {ID: "primary", Type: "openai", Model: "gpt-4o", BaseURL: primary.URL},
+		"fallback": {ID: "fallback", Type: "openai", Model: "gpt-4o-mini", BaseURL: fallback.URL},
+	}
+	packData := testPackData([]runtimeevals.EvalDef{{
+		ID:      "helpful",
+		Type:    "llm_judge",
+		Trigger: runtimeevals.TriggerEveryTurn,
+		Params:  map[string]any{"criteria": "Is the response helpful?"},
+	}})
+	messages := []session.Message{
+		{ID: "m1", Role: session.RoleUser, Content: "hi"},
+		{ID: "m2", Role: session.RoleAssistant, Content: "hello, how can I help?"},
+	}
+	spy := &spyMetrics{}
+	runner := NewSDKRunner(WithMetrics(spy))
+
+	labels := EvalLabels{Agent: "agent", Namespace: "ns", Judge: &JudgePolicy{Providers: []string{"primary", "fallback"}}}
+	items := runner.RunTurnEvals(context.Background(), packData, messages, "s1", 1, specs, labels)
+
+	require.Len(t, items, 1)
+	var details map[string]any
+	require.NoError(t, json.Unmarshal(items[0].Details, &details))
+	assert.Equal(t, map[string]any{"provider": "fallback", "model": "gpt-4o-mini", "fallback": true},
+		details[judgeDetailsKey])
+	assert.Contains(t, spy.judgeFallbacks, judgeFallbackCall{"primary", judgeFallbackRateLimited})
+}
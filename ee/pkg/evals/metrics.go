@@ -33,6 +33,19 @@ var DefaultEvalScoreBuckets = []float64{
 	0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1.0,
 }
 
+// DefaultEventAgeBuckets are histogram buckets for the time an event waits in
+// its stream before processing. Ranges from a keeping-up worker (100ms) to a
+// backlog of an hour.
+var DefaultEventAgeBuckets = []float64{
+	0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600,
+}
+
+// DefaultJudgeCallBuckets are histogram buckets for provider calls made by
+// evals, which are dominated by LLM judge round trips.
+var DefaultJudgeCallBuckets = []float64{
+	0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60,
+}
+
 // WorkerMetrics holds Prometheus metrics for the eval worker.
 type WorkerMetrics struct {
 	// EventsReceived counts events consumed from Redis Streams by event type.
@@ -47,9 +60,37 @@ type WorkerMetrics struct {
 	// EvalsSampled counts sampling decisions (sampled vs skipped).
 	EvalsSampled *prometheus.CounterVec
 
+	// SessionsSampled counts the worker's per-session sampling decisions by
+	// decision and reason (rate, hourly cap, or a forcing signal).
+	SessionsSampled *prometheus.CounterVec
+
 	// StreamLag tracks the approximate consumer lag (pending messages) per stream.
 	StreamLag *prometheus.GaugeVec
 
+	// StreamBacklog tracks stream entries not yet delivered to the consumer
+	// group. Unlike StreamLag it counts work no worker has picked up yet.
+	StreamBacklog *prometheus.GaugeVec
+
+	// OldestPendingAge tracks how long the oldest delivered-but-unacknowledged
+	// entry per stream has been idle, i.e. the age of the stalest claim.
+	OldestPendingAge *prometheus.GaugeVec
+
+	// PendingReclaimed counts pending entries taken over from other
+	// consumers, by stream and reason (stale entry or dead consumer).
+	PendingReclaimed *prometheus.CounterVec
+
+	// EventAge tracks the time from an event being appended to its stream to
+	// the worker finishing it.
+	EventAge *prometheus.HistogramVec
+
+	// JudgeCallDuration tracks provider calls made by evals (LLM judges, RAG
+	// embeddings) by provider, source, and status.
+	JudgeCallDuration *prometheus.HistogramVec
+
+	// JudgeFallbacks counts judges passed over for the next one in an
+	// agent's spec.evals.judge.providers list, by judge and reason.
+	JudgeFallbacks *prometheus.CounterVec
+
 	// EventProcessingDuration tracks time to process a single stream message.
 	EventProcessingDuration *prometheus.HistogramVec
 
@@ -109,11 +150,48 @@ func NewWorkerMetricsWithRegisterer(reg prometheus.Registerer, cfg *WorkerMetric
 			Help: "Sampling decisions: sampled (executed) vs skipped",
 		}, []string{"eval_type", "decision"}),
 
+		SessionsSampled: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "omnia_eval_worker_sessions_sampled_total",
+			Help: "Per-session sampling decisions by decision and reason",
+		}, []string{"decision", "reason"}),
+
 		StreamLag: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Name: "omnia_eval_worker_stream_lag",
 			Help: "Approximate pending messages per Redis stream",
 		}, []string{"stream"}),
 
+		StreamBacklog: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "omnia_eval_worker_stream_backlog",
+			Help: "Stream entries not yet delivered to the eval worker consumer group",
+		}, []string{"stream"}),
+
+		OldestPendingAge: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "omnia_eval_worker_oldest_pending_age_seconds",
+			Help: "Idle time of the oldest delivered but unacknowledged entry per stream",
+		}, []string{"stream"}),
+
+		PendingReclaimed: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "omnia_eval_worker_pending_reclaimed_total",
+			Help: "Pending stream entries reclaimed from other consumers, by reason",
+		}, []string{"stream", "reason"}),
+
+		EventAge: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "omnia_eval_worker_event_age_seconds",
+			Help:    "Time from a session event entering its stream to the eval worker finishing it",
+			Buckets: DefaultEventAgeBuckets,
+		}, []string{"event_type"}),
+
+		JudgeCallDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "omnia_eval_worker_judge_call_duration_seconds",
+			Help:    "Latency of provider calls made by evals (LLM judges, RAG embeddings)",
+			Buckets: DefaultJudgeCallBuckets,
+		}, []string{"provider", "source", "status"}),
+
+		JudgeFallbacks: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "omnia_eval_worker_judge_fallbacks_total",
+			Help: "Judges passed over for the next configured judge, by judge and reason",
+		}, []string{"provider", "reason"}),
+
 		EventProcessingDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Name:    "omnia_eval_worker_event_processing_duration_seconds",
 			Help:    "Time to process a single stream event end-to-end",
@@ -138,6 +216,7 @@ func (m *WorkerMetrics) Initialize() {
 	for _, et := range []string{"message.assistant", "session.completed", "unknown"} {
 		m.EventsReceived.WithLabelValues(et).Add(0)
 		m.EventProcessingDuration.WithLabelValues(et)
+		m.EventAge.WithLabelValues(et)
 	}
 
 	for _, status := range []string{MetricStatusSuccess, MetricStatusError} {
@@ -150,9 +229,16 @@ type WorkerMetricsRecorder interface {
 	RecordEventReceived(eventType string)
 	RecordEvalExecuted(evalType, trigger, status string, durationSec float64)
 	RecordSamplingDecision(evalType, decision string)
+	RecordSessionSampling(decision, reason string)
 	RecordEventProcessing(eventType string, durationSec float64)
 	RecordResultsWritten(count int, success bool)
 	SetStreamLag(stream string, lag float64)
+	SetStreamBacklog(stream string, backlog float64)
+	SetOldestPendingAge(stream string, seconds float64)
+	RecordPendingReclaimed(stream, reason string, count int)
+	RecordEventAge(eventType string, seconds float64)
+	RecordJudgeCall(provider, source, status string, durationSec float64)
+	RecordJudgeFallback(provider, reason string)
 	RecordEvalScore(evalID string, labels EvalLabels, score float64)
 }
 
@@ -178,6 +264,12 @@ func (m *WorkerMetrics) RecordSamplingDecision(evalType, decision string) {
 	m.EvalsSampled.WithLabelValues(evalType, decision).Inc()
 }
 
+// RecordSessionSampling records whether the worker evaluated or skipped a
+// session event, and why.
+func (m *WorkerMetrics) RecordSessionSampling(decision, reason string) {
+	m.SessionsSampled.WithLabelValues(decision, reason).Inc()
+}
+
 // RecordEventProcessing records the total time to process a stream event.
 func (m *WorkerMetrics) RecordEventProcessing(eventType string, durationSec float64) {
 	m.EventProcessingDuration.WithLabelValues(eventType).Observe(durationSec)
@@ -197,6 +289,39 @@ func (m *WorkerMetrics) SetStreamLag(stream string, lag float64) {
 	m.StreamLag.WithLabelValues(stream).Set(lag)
 }
 
+// SetStreamBacklog sets the number of entries not yet delivered to the
+// consumer group for a stream.
+func (m *WorkerMetrics) SetStreamBacklog(stream string, backlog float64) {
+	m.StreamBacklog.WithLabelValues(stream).Set(backlog)
+}
+
+// SetOldestPendingAge sets the idle time of a stream's oldest unacknowledged entry.
+func (m *WorkerMetrics) SetOldestPendingAge(stream string, seconds float64) {
+	m.OldestPendingAge.WithLabelValues(stream).Set(seconds)
+}
+
+// RecordPendingReclaimed counts pending entries reclaimed from other consumers.
+func (m *WorkerMetrics) RecordPendingReclaimed(stream, reason string, count int) {
+	m.PendingReclaimed.WithLabelValues(stream, reason).Add(float64(count))
+}
+
+// RecordEventAge records how long an event waited in its stream before it
+// was processed.
+func (m *WorkerMetrics) RecordEventAge(eventType string, seconds float64) {
+	m.EventAge.WithLabelValues(eventType).Observe(seconds)
+}
+
+// RecordJudgeCall records the latency of a provider call made by an eval.
+func (m *WorkerMetrics) RecordJudgeCall(provider, source, status string, durationSec float64) {
+	m.JudgeCallDuration.WithLabelValues(provider, source, status).Observe(durationSec)
+}
+
+// RecordJudgeFallback records that a judge was passed over for the next
+// configured judge (rate-limited or over budget).
+func (m *WorkerMetrics) RecordJudgeFallback(provider, reason string) {
+	m.JudgeFallbacks.WithLabelValues(provider, reason).Inc()
+}
+
 // RecordEvalScore observes a numeric eval quality score. The histogram's
 // _sum/_count series let rollout gates window on fresh observations only (#1467).
 func (m *WorkerMetrics) RecordEvalScore(evalID string, labels EvalLabels, score float64) {
@@ -226,6 +351,11 @@ func (n *NoOpWorkerMetrics) RecordSamplingDecision(_, _ string) {
 	// Intentionally empty — see RecordEventReceived for rationale.
 }
 
+// RecordSessionSampling is a no-op for the metrics-disabled build.
+func (n *NoOpWorkerMetrics) RecordSessionSampling(_, _ string) {
+	// Intentionally empty — see RecordEventReceived for rationale.
+}
+
 // RecordEventProcessing is a no-op for the metrics-disabled build.
 func (n *NoOpWorkerMetrics) RecordEventProcessing(_ string, _ float64) {
 	// Intentionally empty — see RecordEventReceived for rationale.
@@ -241,6 +371,36 @@ func (n *NoOpWorkerMetrics) SetStreamLag(_ string, _ float64) {
 	// Intentionally empty — see RecordEventReceived for rationale.
 }
 
+// SetStreamBacklog is a no-op for the metrics-disabled build.
+func (n *NoOpWorkerMetrics) SetStreamBacklog(_ string, _ float64) {
+	// Intentionally empty — see RecordEventReceived for rationale.
+}
+
+// SetOldestPendingAge is a no-op for the metrics-disabled build.
+func (n *NoOpWorkerMetrics) SetOldestPendingAge(_ string, _ float64) {
+	// Intentionally empty — see RecordEventReceived for rationale.
+}
+
+// RecordPendingReclaimed is a no-op for the metrics-disabled build.
+func (n *NoOpWorkerMetrics) RecordPendingReclaimed(_, _ string, _ int) {
+	// Intentionally empty — see RecordEventReceived for rationale.
+}
+
+// RecordEventAge is a no-op for the metrics-disabled build.
+func (n *NoOpWorkerMetrics) RecordEventAge(_ string, _ float64) {
+	// Intentionally empty — see RecordEventReceived for rationale.
+}
+
+// RecordJudgeCall is a no-op for the metrics-disabled build.
+func (n *NoOpWorkerMetrics) RecordJudgeCall(_, _, _ string, _ float64) {
+	// Intentionally empty — see RecordEventReceived for rationale.
+}
+
+// RecordJudgeFallback is a no-op for the metrics-disabled build.
+func (n *NoOpWorkerMetrics) RecordJudgeFallback(_, _ string) {
+	// Intentionally empty — see RecordEventReceived for rationale.
+}
+
 // RecordEvalScore is a no-op for the metrics-disabled build.
 func (n *NoOpWorkerMetrics) RecordEvalScore(_ string, _ EvalLabels, _ float64) {
 	// Intentionally empty — see RecordEventReceived for rationale.
@@ -13,6 +13,7 @@ import (
 
 	runtimeevals "github.com/AltairaLabs/PromptKit/runtime/evals"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -249,6 +250,27 @@ func TestWorkerMetrics_SetStreamLag(t *testing.T) {
 	}
 }
 
+func TestWorkerMetrics_QueueLagAndJudgeCalls(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := newWorkerMetricsWithRegistry(reg, nil)
+
+	m.SetStreamBacklog("omnia:eval-events:ns1", 7)
+	m.SetOldestPendingAge("omnia:eval-events:ns1", 90)
+	m.RecordEventAge("message.assistant", 12)
+	m.RecordJudgeCall("openai", "judge", MetricStatusSuccess, 2.5)
+	m.RecordPendingReclaimed("omnia:eval-events:ns1", reclaimReasonDeadConsumer, 3)
+	m.RecordJudgeFallback("primary", judgeFallbackRateLimited)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(
+		m.JudgeFallbacks.WithLabelValues("primary", judgeFallbackRateLimited)), 0)
+	assert.InDelta(t, 3, testutil.ToFloat64(
+		m.PendingReclaimed.WithLabelValues("omnia:eval-events:ns1", reclaimReasonDeadConsumer)), 0)
+	assert.InDelta(t, 7, testutil.ToFloat64(m.StreamBacklog.WithLabelValues("omnia:eval-events:ns1")), 0)
+	assert.InDelta(t, 90, testutil.ToFloat64(m.OldestPendingAge.WithLabelValues("omnia:eval-events:ns1")), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(m.EventAge, "omnia_eval_worker_event_age_seconds"))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.JudgeCallDuration, "omnia_eval_worker_judge_call_duration_seconds"))
+}
+
 func TestWorkerMetrics_RecordEventProcessing(t *testing.T) {
 	reg := prometheus.NewRegistry()
 	m := newWorkerMetricsWithRegistry(reg, nil)
@@ -277,6 +299,12 @@ func TestNoOpWorkerMetrics(t *testing.T) {
 	m.RecordEventProcessing("test", 0.5)
 	m.RecordResultsWritten(1, true)
 	m.SetStreamLag("stream", 10)
+	m.SetStreamBacklog("stream", 10)
+	m.SetOldestPendingAge("stream", 1)
+	m.RecordPendingReclaimed("stream", reclaimReasonStale, 1)
+	m.RecordEventAge("test", 1)
+	m.RecordJudgeCall("openai", "judge", MetricStatusSuccess, 1)
+	m.RecordJudgeFallback("openai", judgeFallbackDailyBudget)
 }
 
 // newWorkerMetricsWithRegistry delegates to the exported constructor.
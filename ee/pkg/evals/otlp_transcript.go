@@ -0,0 +1,176 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package evals
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// Metadata keys used by the session-api OTLP transformer.
+const (
+	metaKeySource     = "source"
+	metaKeySpanID     = "otel.span_id"
+	metaKeyGenAIModel = "gen_ai.model"
+	metaKeyToolName   = "tool_name"
+	metaKeyToolArgs   = "tool_args"
+	metaKeyToolStatus = "status"
+	metaKeyDurationMs = "duration_ms"
+
+	metaValueSourceOTLP       = "otlp"
+	metaTypeToolCallCompleted = "tool.call.completed"
+	toolStatusError           = "error"
+)
+
+// normalizeOTLPTranscript rewrites a transcript recorded by the OTLP
+// transformer into the shape PromptKit runtimes record, so third-party agents
+// get the same evals. Other transcripts are returned unchanged.
+//
+// Every GenAI span carries the conversation so far as its input messages,
+// so the stored transcript repeats earlier turns once per LLM call. Messages
+// are put in span order, each span keeps only what it adds to the
+// conversation, and tool spans become a tool call and its result.
+func normalizeOTLPTranscript(messages []session.Message) []session.Message {
+	if !isOTLPTranscript(messages) {
+		return messages
+	}
+
+	sorted := make([]session.Message, len(messages))
+	copy(sorted, messages)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	result := make([]session.Message, 0, len(sorted))
+	var history []session.Message
+	for start := 0; start < len(sorted); {
+		end := start + 1
+		for end < len(sorted) && spanKey(&sorted[end]) == spanKey(&sorted[start]) {
+			end++
+		}
+		span := sorted[start:end]
+		start = end
+
+		if span[0].Metadata[metaKeyType] == metaTypeToolCallCompleted {
+			for i := range span {
+				result = append(result, toolSpanMessages(&span[i])...)
+			}
+			continue
+		}
+		if !isConversational(&span[0]) {
+			result = append(result, span...)
+			continue
+		}
+		added := span[replayedPrefix(history, span):]
+		history = append(history, added...)
+		result = append(result, added...)
+	}
+	return result
+}
+
+// isOTLPTranscript reports whether messages were written by the OTLP
+// transformer. Messages recorded before it stamped its source are recognised
+// by the model and tool metadata it has always set.
+func isOTLPTranscript(messages []session.Message) bool {
+	for i := range messages {
+		md := messages[i].Metadata
+		if md[metaKeySource] == metaValueSourceOTLP ||
+			md[metaKeyGenAIModel] != "" ||
+			md[metaKeyType] == metaTypeToolCallCompleted {
+			return true
+		}
+	}
+	return false
+}
+
+// spanKey identifies the span a message came from. Without a recorded span
+// id it falls back to the timestamp, which all messages of a span share.
+func spanKey(msg *session.Message) string {
+	if id := msg.Metadata[metaKeySpanID]; id != "" {
+		return id
+	}
+	return strconv.FormatInt(msg.Timestamp.UnixNano(), 10)
+}
+
+// isConversational reports whether msg is a user, assistant or system turn
+// rather than a tool or workflow record.
+func isConversational(msg *session.Message) bool {
+	return msg.Role != session.RoleSystem || msg.Metadata[metaKeyType] == ""
+}
+
+// replayedPrefix returns how many leading messages of span repeat the tail
+// of history: the whole conversation for frameworks that replay it on every
+// call, or its last turns for ones that send a sliding window.
+func replayedPrefix(history, span []session.Message) int {
+	for n := min(len(history), len(span)); n > 0; n-- {
+		if sameTurns(history[len(history)-n:], span[:n]) {
+			return n
+		}
+	}
+	return 0
+}
+
+// sameTurns reports whether a and b hold the same roles and contents.
+func sameTurns(a, b []session.Message) bool {
+	for i := range a {
+		if a[i].Role != b[i].Role || a[i].Content != b[i].Content {
+			return false
+		}
+	}
+	return true
+}
+
+// toolSpanMessages converts a tool.call.completed record into the tool_call
+// and tool_result messages a PromptKit runtime records. Spans carry no tool
+// output, so the result only says whether the call failed.
+func toolSpanMessages(msg *session.Message) []session.Message {
+	name := msg.Metadata[metaKeyToolName]
+	call := struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments,omitempty"`
+	}{Name: name}
+	if args := msg.Metadata[metaKeyToolArgs]; json.Valid([]byte(args)) {
+		call.Arguments = json.RawMessage(args)
+	}
+	content, err := json.Marshal(call)
+	if err != nil {
+		return nil
+	}
+
+	result := session.Message{
+		ID:         msg.ID + "-result",
+		Role:       session.RoleSystem,
+		Timestamp:  msg.Timestamp,
+		ToolCallID: msg.ToolCallID,
+		Metadata: map[string]string{
+			metaKeyType:      metaTypeToolResult,
+			metaKeyToolName:  name,
+			metaKeyLatencyMs: msg.Metadata[metaKeyDurationMs],
+		},
+	}
+	if msg.Metadata[metaKeyToolStatus] == toolStatusError {
+		result.Content = "tool " + name + " failed"
+		result.Metadata[metaKeyIsError] = "true"
+	}
+
+	return []session.Message{
+		{
+			ID:         msg.ID,
+			Role:       session.RoleAssistant,
+			Content:    string(content),
+			Timestamp:  msg.Timestamp,
+			ToolCallID: msg.ToolCallID,
+			Metadata:   map[string]string{metaKeyType: metaTypeToolCall},
+		},
+		result,
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package evals
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// otlpMessage builds a message as the OTLP transformer writes it for a span.
+func otlpMessage(spanID string, at time.Time, role session.MessageRole, content string) session.Message {
+	return session.Message{
+		ID:        spanID + "-" + content,
+		Role:      role,
+		Content:   content,
+		Timestamp: at,
+		Metadata: map[string]string{
+			metaKeySource:     metaValueSourceOTLP,
+			metaKeySpanID:     spanID,
+			metaKeyGenAIModel: "gpt-4o",
+		},
+	}
+}
+
+// otlpToolMessage builds the record the OTLP transformer writes for a tool span.
+func otlpToolMessage(spanID string, at time.Time, name, args, status string) session.Message {
+	return session.Message{
+		ID:         spanID,
+		Role:       session.RoleSystem,
+		Timestamp:  at,
+		ToolCallID: "call-" + spanID,
+		Metadata: map[string]string{
+			metaKeySource:     metaValueSourceOTLP,
+			metaKeySpanID:     spanID,
+			metaKeyType:       metaTypeToolCallCompleted,
+			metaKeyToolName:   name,
+			metaKeyToolArgs:   args,
+			metaKeyToolStatus: status,
+			metaKeyDurationMs: "42",
+		},
+	}
+}
+
+// turns flattens messages to "role: content" for compact assertions.
+func turns(messages []session.Message) []string {
+	out := make([]string, 0, len(messages))
+	for _, m := range messages {
+		out = append(out, string(m.Role)+": "+m.Content)
+	}
+	return out
+}
+
+func TestNormalizeOTLPTranscript_RuntimeTranscriptUnchanged(t *testing.T) {
+	now := time.Now()
+	messages := []session.Message{
+		{ID: "m1", Role: session.RoleUser, Content: "hi", Timestamp: now},
+		{ID: "m2", Role: session.RoleAssistant, Content: "hello", Timestamp: now,
+			Metadata: map[string]string{metaKeySource: "runtime"}},
+		{ID: "m3", Role: session.RoleUser, Content: "hi", Timestamp: now},
+	}
+	assert.Equal(t, messages, normalizeOTLPTranscript(messages))
+}
+
+func TestNormalizeOTLPTranscript_DropsReplayedHistory(t *testing.T) {
+	t0 := time.Now()
+	t1, t2 := t0.Add(time.Second), t0.Add(2*time.Second)
+
+	// Spans are stored out of order: the second LLM call's export landed first.
+	messages := []session.Message{
+		otlpMessage("b", t2, session.RoleSystem, "be brief"),
+		otlpMessage("b", t2, session.RoleUser, "weather?"),
+		otlpMessage("b", t2, session.RoleAssistant, "checking"),
+		otlpMessage("b", t2, session.RoleUser, "in Paris"),
+		otlpMessage("b", t2, session.RoleAssistant, "sunny"),
+		otlpToolMessage("t", t1, "get_weather", `{"city":"Paris"}`, "success"),
+		otlpMessage("a", t0, session.RoleSystem, "be brief"),
+		otlpMessage("a", t0, session.RoleUser, "weather?"),
+		otlpMessage("a", t0, session.RoleAssistant, "checking"),
+	}
+
+	got := normalizeOTLPTranscript(messages)
+	assert.Equal(t, []string{
+		"system: be brief",
+		"user: weather?",
+		"assistant: checking",
+		`assistant: {"name":"get_weather","arguments":{"city":"Paris"}}`,
+		"system: ",
+		"user: in Paris",
+		"assistant: sunny",
+	}, turns(got))
+	assert.Equal(t, 3, countAssistantMessages(got))
+}
+
+func TestNormalizeOTLPTranscript_SlidingWindow(t *testing.T) {
+	t0 := time.Now()
+	t1 := t0.Add(time.Second)
+	messages := []session.Message{
+		otlpMessage("a", t0, session.RoleUser, "yes"),
+		otlpMessage("a", t0, session.RoleAssistant, "booked"),
+		// Only the last turn is replayed, and the user repeats themselves.
+		otlpMessage("b", t1, session.RoleAssistant, "booked"),
+		otlpMessage("b", t1, session.RoleUser, "yes"),
+		otlpMessage("b", t1, session.RoleAssistant, "already booked"),
+	}
+	assert.Equal(t, []string{
+		"user: yes",
+		"assistant: booked",
+		"user: yes",
+		"assistant: already booked",
+	}, turns(normalizeOTLPTranscript(messages)))
+}
+
+func TestNormalizeOTLPTranscript_GroupsLegacyMessagesByTimestamp(t *testing.T) {
+	t0 := time.Now()
+	t1 := t0.Add(time.Second)
+	legacy := func(at time.Time, role session.MessageRole, content string) session.Message {
+		return session.Message{Role: role, Content: content, Timestamp: at,
+			Metadata: map[string]string{metaKeyGenAIModel: "claude"}}
+	}
+	messages := []session.Message{
+		legacy(t0, session.RoleUser, "hi"),
+		legacy(t0, session.RoleAssistant, "hello"),
+		legacy(t1, session.RoleUser, "hi"),
+		legacy(t1, session.RoleAssistant, "hello"),
+		legacy(t1, session.RoleUser, "bye"),
+		legacy(t1, session.RoleAssistant, "goodbye"),
+	}
+	assert.Equal(t, []string{
+		"user: hi",
+		"assistant: hello",
+		"user: bye",
+		"assistant: goodbye",
+	}, turns(normalizeOTLPTranscript(messages)))
+}
+
+func TestNormalizeOTLPTranscript_ToolCalls(t *testing.T) {
+	now := time.Now()
+	got := ConvertToTypesMessages(normalizeOTLPTranscript([]session.Message{
+		otlpToolMessage("ok", now, "search", `{"q":"omnia"}`, "success"),
+		otlpToolMessage("bad", now.Add(time.Second), "charge", "not json", toolStatusError),
+	}))
+	require.Len(t, got, 4)
+
+	assert.Equal(t, "assistant", got[0].Role)
+	require.Len(t, got[0].ToolCalls, 1)
+	assert.Equal(t, "call-ok", got[0].ToolCalls[0].ID)
+	assert.Equal(t, "search", got[0].ToolCalls[0].Name)
+	assert.JSONEq(t, `{"q":"omnia"}`, string(got[0].ToolCalls[0].Args))
+
+	assert.Equal(t, "tool", got[1].Role)
+	require.NotNil(t, got[1].ToolResult)
+	assert.Equal(t, "call-ok", got[1].ToolResult.ID)
+	assert.Empty(t, got[1].ToolResult.Error)
+	assert.Equal(t, int64(42), got[1].LatencyMs)
+
+	require.Len(t, got[2].ToolCalls, 1)
+	assert.Equal(t, "charge", got[2].ToolCalls[0].Name)
+	assert.True(t, json.Valid(got[2].ToolCalls[0].Args), "invalid tool args are dropped")
+	assert.Equal(t, "tool charge failed", got[3].ToolResult.Error)
+}
+
+func TestGetMessages_NormalizesOTLPTranscript(t *testing.T) {
+	now := time.Now()
+	msgs := []session.Message{
+		otlpMessage("a", now, session.RoleUser, "hi"),
+		otlpMessage("a", now, session.RoleAssistant, "hello"),
+		otlpMessage("b", now.Add(time.Second), session.RoleUser, "hi"),
+		otlpMessage("b", now.Add(time.Second), session.RoleAssistant, "hello"),
+		otlpMessage("b", now.Add(time.Second), session.RoleUser, "thanks"),
+		otlpMessage("b", now.Add(time.Second), session.RoleAssistant, "any time"),
+	}
+	worker := NewEvalWorker(WorkerConfig{Logger: testLogger()})
+	worker.messageStore = &mockMessageStore{messages: toMessagePtrs(msgs)}
+
+	got, err := worker.getMessages(context.Background(), "s1")
+	require.NoError(t, err)
+	assert.Len(t, got, 4)
+	assert.Equal(t, 2, countAssistantMessages(got))
+}
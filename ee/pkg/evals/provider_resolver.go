@@ -105,6 +105,21 @@ func (r *ProviderResolver) ResolveSamplingConfig(
 	return ar.Spec.Evals.Sampling
 }
 
+// ResolveJudgeConfig returns the eval judge config from the AgentRuntime CRD.
+// Returns nil if the agent has no judge config or on resolution error.
+func (r *ProviderResolver) ResolveJudgeConfig(
+	ctx context.Context, agentName, namespace string,
+) *v1alpha1.EvalJudgeConfig {
+	ar, err := k8s.GetAgentRuntime(ctx, r.client, agentName, namespace)
+	if err != nil {
+		return nil
+	}
+	if ar.Spec.Evals == nil {
+		return nil
+	}
+	return ar.Spec.Evals.Judge
+}
+
 // ResolveWorkerGroups returns the eval group filter configured for the
 // worker path on this agent's AgentRuntime CRD.
 //
@@ -180,6 +195,12 @@ func (r *ProviderResolver) resolveOne(
 		Headers: provider.Spec.Headers,
 	}
 
+	if provider.Spec.Type == v1alpha1.ProviderTypeOpenAICompatible {
+		oc := pkgprovider.OpenAICompatible(&provider.Spec)
+		spec.UnsupportedParams = oc.UnsupportedParams
+		spec.Capabilities = oc.Capabilities
+	}
+
 	if provider.Spec.Defaults != nil {
 		spec.Defaults = convertDefaults(provider.Spec.Defaults)
 	}
@@ -234,7 +255,9 @@ func (r *ProviderResolver) resolveAPIKeyCredential(
 	}
 
 	apiKey := string(apiKeyBytes)
-	if !pkgprovider.Type(provider.Spec.Type).RequiresCredentials() {
+	// openai-compatible keys are optional but, when configured, are sent.
+	optionalKey := provider.Spec.Type == v1alpha1.ProviderTypeOpenAICompatible
+	if !optionalKey && !pkgprovider.Type(provider.Spec.Type).RequiresCredentials() {
 		return nil, nil //nolint:nilnil // provider doesn't use credentials
 	}
 
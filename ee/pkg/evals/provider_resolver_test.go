@@ -195,6 +195,63 @@ func TestResolveProviderSpecs_MockProviderNoCredential(t *testing.T) {
 	assert.Nil(t, specs["default"].Credential)
 }
 
+func TestResolveProviderSpecs_OpenAICompatible(t *testing.T) {
+	ns := testNamespace
+
+	ar := &v1alpha1.AgentRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: ns},
+		Spec: v1alpha1.AgentRuntimeSpec{
+			PromptPackRef: v1alpha1.PromptPackRef{Name: "pack"},
+			Providers: []v1alpha1.NamedProviderRef{
+				{Name: "default", ProviderRef: v1alpha1.ProviderRef{Name: "local"}},
+				{Name: "keyed", ProviderRef: v1alpha1.ProviderRef{Name: "keyed"}},
+			},
+		},
+	}
+
+	local := &v1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: ns},
+		Spec: v1alpha1.ProviderSpec{
+			Type:         v1alpha1.ProviderTypeOpenAICompatible,
+			Model:        "llama3.1",
+			BaseURL:      "http://ollama:11434/v1",
+			Capabilities: []v1alpha1.ProviderCapability{v1alpha1.ProviderCapabilityTools},
+			OpenAICompatible: &v1alpha1.OpenAICompatibleConfig{
+				UnsupportedParams: []string{"top_p"},
+			},
+		},
+	}
+	keyed := &v1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "keyed", Namespace: ns},
+		Spec: v1alpha1.ProviderSpec{
+			Type:       v1alpha1.ProviderTypeOpenAICompatible,
+			Model:      "qwen2.5",
+			BaseURL:    "http://vllm:8000/v1",
+			Credential: &v1alpha1.CredentialConfig{SecretRef: &v1alpha1.SecretKeyRef{Name: "vllm-key"}},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vllm-key", Namespace: ns},
+		Data:       map[string][]byte{"OPENAI_API_KEY": []byte("token")},
+	}
+
+	c := buildFakeClient(ar, local, keyed, secret).Build()
+	resolver := NewProviderResolver(c)
+
+	specs, err := resolver.ResolveProviderSpecs(context.Background(), "agent", ns)
+	require.NoError(t, err)
+	require.Len(t, specs, 2)
+
+	spec := specs["default"]
+	assert.Equal(t, "openai-compatible", spec.Type)
+	assert.Equal(t, []string{"max_completion_tokens", "top_p"}, spec.UnsupportedParams)
+	assert.Equal(t, []string{"tools"}, spec.Capabilities)
+	assert.Nil(t, spec.Credential, "keyless endpoint")
+
+	require.NotNil(t, specs["keyed"].Credential)
+	assert.Equal(t, "api_key", specs["keyed"].Credential.Type())
+}
+
 func TestResolveProviderSpecs_Cache(t *testing.T) {
 	ns := testNamespace
 
@@ -472,6 +529,31 @@ func TestResolveSamplingConfig_AgentNotFound(t *testing.T) {
 	assert.Nil(t, sampling)
 }
 
+func TestResolveJudgeConfig(t *testing.T) {
+	ns := testNamespace
+	ar := &v1alpha1.AgentRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: ns},
+		Spec: v1alpha1.AgentRuntimeSpec{
+			PromptPackRef: v1alpha1.PromptPackRef{Name: "pack"},
+			Evals: &v1alpha1.EvalConfig{
+				Enabled: true,
+				Judge: &v1alpha1.EvalJudgeConfig{
+					Providers:        []string{"primary", "fallback"},
+					MaxCostPerDayUSD: "20",
+				},
+			},
+		},
+	}
+
+	resolver := NewProviderResolver(buildFakeClient(ar).Build())
+
+	judge := resolver.ResolveJudgeConfig(context.Background(), "agent", ns)
+	require.NotNil(t, judge)
+	assert.Equal(t, []string{"primary", "fallback"}, judge.Providers)
+	assert.Equal(t, "20", judge.MaxCostPerDayUSD)
+	assert.Nil(t, resolver.ResolveJudgeConfig(context.Background(), "nonexistent", ns))
+}
+
 func TestResolveWorkerGroups_Configured(t *testing.T) {
 	ns := testNamespace
 	ar := &v1alpha1.AgentRuntime{
@@ -19,6 +19,7 @@ import (
 	sdkmetrics "github.com/AltairaLabs/PromptKit/runtime/metrics"
 	"github.com/AltairaLabs/PromptKit/runtime/providers"
 	"github.com/AltairaLabs/PromptKit/sdk"
+	goredis "github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/altairalabs/omnia/internal/session"
@@ -32,6 +33,7 @@ type SDKRunner struct {
 	evalCollector      *sdkmetrics.Collector
 	metrics            WorkerMetricsRecorder
 	providerCallWriter ProviderCallWriter
+	judgeSpendRedis    goredis.UniversalClient
 }
 
 // NewSDKRunner creates an SDKRunner. Options can configure tracing and logging.
@@ -71,12 +73,20 @@ func WithMetrics(m WorkerMetricsRecorder) SDKRunnerOption {
 // WithProviderCallWriter wires a writer that persists the provider calls the
 // eval pipeline emits (judge LLM calls, RAG-eval embeddings, …). When set, the
 // runner attaches an event bus to sdk.Evaluate and forwards each
-// ProviderCallCompleted/Failed to session-api. When nil (default), no bus is
-// attached and the events are dropped — preserving the prior behavior.
+// ProviderCallCompleted/Failed to session-api. When nil (default), the calls
+// are not persisted; with metrics set they still feed the judge-call latency
+// histogram.
 func WithProviderCallWriter(w ProviderCallWriter) SDKRunnerOption {
 	return func(r *SDKRunner) { r.providerCallWriter = w }
 }
 
+// WithJudgeSpendRedis shares each judge's daily spend across worker replicas
+// through Redis, enforcing spec.evals.judge.maxCostPerDayUSD. When nil
+// (default), the daily ceiling is not enforced.
+func WithJudgeSpendRedis(c goredis.UniversalClient) SDKRunnerOption {
+	return func(r *SDKRunner) { r.judgeSpendRedis = c }
+}
+
 // EvalCollector returns the unified metrics Collector, if any.
 func (s *SDKRunner) EvalCollector() *sdkmetrics.Collector {
 	return s.evalCollector
@@ -88,13 +98,15 @@ func (s *SDKRunner) EvalCollector() *sdkmetrics.Collector {
 // through to sdk.EvaluateOpts.EvalGroups to filter which evals this
 // invocation executes. An empty or nil Groups leaves the SDK's default
 // behavior (run all defs) in place; callers that want worker-scoped
-// filtering should populate it.
+// filtering should populate it. Judge, likewise not a label, orders and
+// budgets the judges; nil judges with one of the agent's providers.
 type EvalLabels struct {
 	Agent          string
 	Namespace      string
 	PromptPackName string
 	Variant        string
 	Groups         []string
+	Judge          *JudgePolicy
 }
 
 // Prometheus instance-label keys for omnia_eval_<name> series.
@@ -191,21 +203,35 @@ func (s *SDKRunner) evaluate(
 		opts.MetricsInstanceLabels = evalInstanceLabels(labels)
 	}
 
-	if len(providerSpecs) > 0 {
+	// A configured judge chain takes precedence over JudgeTargets, from
+	// which the SDK would pick an arbitrary judge.
+	var chain *judgeChain
+	if labels.Judge != nil {
+		chain = newJudgeChain(labels.Judge, providerSpecs, labels, sessionID,
+			s.judgeSpendRedis, s.metrics, s.logger)
+	}
+	if chain != nil {
+		opts.JudgeProvider = chain
+	} else if len(providerSpecs) > 0 {
 		opts.JudgeTargets = toAnyMap(providerSpecs)
 	}
 
 	// Capture the provider calls the eval pipeline makes (judge LLM calls,
-	// RAG-eval embeddings, …) so their token usage is recorded. sdk.Evaluate
-	// only emits these when an EventBus is attached.
+	// RAG-eval embeddings, …) so their token usage and latency are recorded.
+	// sdk.Evaluate only emits these when an EventBus is attached. A judge
+	// chain needs the bus's eval events to attribute results to judges.
 	var collector *providerCallCollector
 	var bus *events.EventBus
-	if s.providerCallWriter != nil {
+	if s.providerCallWriter != nil || s.metrics != nil || chain != nil {
 		bus = events.NewEventBus()
 		collector = newProviderCallCollector(sessionID, labels.Namespace, labels.Agent)
 		bus.Subscribe(events.EventProviderCallCompleted, collector.onCompleted)
 		bus.Subscribe(events.EventProviderCallFailed, collector.onFailed)
 		opts.EventBus = bus
+		if chain != nil {
+			chain.bus = bus
+			opts.EventBus = chain.observe(bus)
+		}
 	}
 
 	results, err := sdk.Evaluate(ctx, opts)
@@ -213,7 +239,11 @@ func (s *SDKRunner) evaluate(
 	// Drain the bus (dispatch is async) before reading + forwarding the calls.
 	if bus != nil {
 		bus.Close()
-		flushProviderCalls(ctx, s.providerCallWriter, s.logger, collector.collected())
+		calls := collector.collected()
+		s.recordJudgeCallMetrics(calls)
+		if s.providerCallWriter != nil {
+			flushProviderCalls(ctx, s.providerCallWriter, s.logger, calls)
+		}
 	}
 
 	if err != nil {
@@ -227,9 +257,17 @@ func (s *SDKRunner) evaluate(
 		return nil
 	}
 
+	if chain != nil {
+		chain.skipExhausted(results)
+	}
+
 	s.recordEvalMetrics(results, trigger, labels)
 
-	return convertSDKResults(results, trigger)
+	items := convertSDKResults(results, trigger)
+	if chain != nil {
+		chain.attribute(items)
+	}
+	return items
 }
 
 // convertSDKResults converts PromptKit EvalResult to Omnia EvaluateResultItem.
@@ -334,6 +372,21 @@ func (s *SDKRunner) recordEvalMetrics(
 	}
 }
 
+// recordJudgeCallMetrics records the latency of each provider call the eval
+// pipeline made.
+func (s *SDKRunner) recordJudgeCallMetrics(calls []*session.ProviderCall) {
+	if s.metrics == nil {
+		return
+	}
+	for _, pc := range calls {
+		status := MetricStatusSuccess
+		if pc.Status == session.ProviderCallStatusFailed {
+			status = MetricStatusError
+		}
+		s.metrics.RecordJudgeCall(pc.Provider, pc.Source, status, float64(pc.DurationMs)/1000.0)
+	}
+}
+
 // hasEvaluableAnswer reports whether the session contains at least one assistant
 // message with non-empty text content. Sessions where every assistant turn is
 // empty — e.g. the provider stream failed and returned no content — have nothing
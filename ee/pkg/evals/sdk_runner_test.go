@@ -33,6 +33,18 @@ type spyMetrics struct {
 	eventsReceived   []string
 	resultsWritten   []resultsWrittenCall
 	eventProcessing  []eventProcessingCall
+	streamBacklog    []streamLagCall
+	oldestPending    []streamLagCall
+	eventAge         []eventProcessingCall
+	judgeCalls       []judgeCall
+	pendingReclaimed map[string]int
+	sessionSampling  []sessionSamplingCall
+	judgeFallbacks   []judgeFallbackCall
+}
+
+type judgeCall struct {
+	provider, source, status string
+	durationSec              float64
 }
 
 type evalExecutedCall struct {
@@ -44,6 +56,14 @@ type samplingDecisionCall struct {
 	evalType, decision string
 }
 
+type sessionSamplingCall struct {
+	decision, reason string
+}
+
+type judgeFallbackCall struct {
+	provider, reason string
+}
+
 type streamLagCall struct {
 	stream string
 	lag    float64
@@ -83,6 +103,37 @@ func (s *spyMetrics) SetStreamLag(stream string, lag float64) {
 	s.streamLag = append(s.streamLag, streamLagCall{stream, lag})
 }
 
+func (s *spyMetrics) SetStreamBacklog(stream string, backlog float64) {
+	s.streamBacklog = append(s.streamBacklog, streamLagCall{stream, backlog})
+}
+
+func (s *spyMetrics) SetOldestPendingAge(stream string, seconds float64) {
+	s.oldestPending = append(s.oldestPending, streamLagCall{stream, seconds})
+}
+
+func (s *spyMetrics) RecordPendingReclaimed(_, reason string, count int) {
+	if s.pendingReclaimed == nil {
+		s.pendingReclaimed = make(map[string]int)
+	}
+	s.pendingReclaimed[reason] += count
+}
+
+func (s *spyMetrics) RecordSessionSampling(decision, reason string) {
+	s.sessionSampling = append(s.sessionSampling, sessionSamplingCall{decision, reason})
+}
+
+func (s *spyMetrics) RecordJudgeFallback(provider, reason string) {
+	s.judgeFallbacks = append(s.judgeFallbacks, judgeFallbackCall{provider, reason})
+}
+
+func (s *spyMetrics) RecordEventAge(eventType string, seconds float64) {
+	s.eventAge = append(s.eventAge, eventProcessingCall{eventType, seconds})
+}
+
+func (s *spyMetrics) RecordJudgeCall(provider, source, status string, durationSec float64) {
+	s.judgeCalls = append(s.judgeCalls, judgeCall{provider, source, status, durationSec})
+}
+
 // testPackData builds a minimal pack.json with the given eval definitions.
 func testPackData(defs []runtimeevals.EvalDef) []byte {
 	pack := map[string]any{
@@ -397,6 +448,22 @@ func TestRecordEvalMetrics_EmptyResults(t *testing.T) {
 	assert.Empty(t, spy.samplingDecision)
 }
 
+func TestRecordJudgeCallMetrics(t *testing.T) {
+	spy := &spyMetrics{}
+	runner := &SDKRunner{metrics: spy}
+	runner.recordJudgeCallMetrics([]*session.ProviderCall{
+		{Provider: "openai", Source: "judge", Status: session.ProviderCallStatusCompleted, DurationMs: 1500},
+		{Provider: "openai", Source: "judge", Status: session.ProviderCallStatusFailed, DurationMs: 250},
+	})
+
+	require.Len(t, spy.judgeCalls, 2)
+	assert.Equal(t, judgeCall{"openai", "judge", MetricStatusSuccess, 1.5}, spy.judgeCalls[0])
+	assert.Equal(t, judgeCall{"openai", "judge", MetricStatusError, 0.25}, spy.judgeCalls[1])
+
+	// Should not panic without metrics.
+	(&SDKRunner{}).recordJudgeCallMetrics([]*session.ProviderCall{{Provider: "openai"}})
+}
+
 func TestWithMetrics_Option(t *testing.T) {
 	spy := &spyMetrics{}
 	runner := NewSDKRunner(WithMetrics(spy))
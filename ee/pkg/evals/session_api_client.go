@@ -15,6 +15,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/AltairaLabs/PromptKit/runtime/events"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	"github.com/altairalabs/omnia/internal/serviceauth"
@@ -61,6 +62,24 @@ type ProviderCallWriter interface {
 	RecordProviderCall(ctx context.Context, sessionID string, pc *session.ProviderCall) error
 }
 
+// SessionSignalReader reads the session signals that make sampling evaluate
+// a session it would otherwise skip (see EvalSampling.AlwaysEvalOn).
+type SessionSignalReader interface {
+	// HasNegativeFeedback reports whether any message of the session has a
+	// thumbs-down annotation.
+	HasNegativeFeedback(ctx context.Context, sessionID string) (bool, error)
+	// HasPolicyViolation reports whether a validator failed or a ToolPolicy
+	// denied a tool call during the session.
+	HasPolicyViolation(ctx context.Context, sessionID string) (bool, error)
+}
+
+// Runtime events that record a policy violation. policy.decision is the
+// event the runtime records for every ToolPolicy decision; only denials count.
+const (
+	eventTypePolicyDecision = "policy.decision"
+	policyDecisionDeny      = "deny"
+)
+
 // HTTPSessionAPIClient implements SessionAPIClient using the generated session-api client.
 type HTTPSessionAPIClient struct {
 	client *sessionapi.ClientWithResponses
@@ -206,6 +225,78 @@ func (c *HTTPSessionAPIClient) GetSessionEvalResults(ctx context.Context, sessio
 	return sessionapi.EvalResultsFromAPI(resp.JSON200.Results), nil
 }
 
+// HasNegativeFeedback reports whether any annotation on the session scores a
+// message thumbs-down.
+func (c *HTTPSessionAPIClient) HasNegativeFeedback(ctx context.Context, sessionID string) (bool, error) {
+	id, err := parseSessionID(sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.client.GetSessionAnnotationsWithResponse(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("get annotations for %s: %w", sessionID, err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return false, fmt.Errorf("get annotations for %s: status %d", sessionID, resp.StatusCode())
+	}
+	if resp.JSON200 == nil || resp.JSON200.Annotations == nil {
+		return false, nil
+	}
+
+	for _, a := range *resp.JSON200.Annotations {
+		if a.Score != nil && *a.Score == api.AnnotationScoreNegative {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// HasPolicyViolation reports whether the session's runtime events include a
+// failed validation or a ToolPolicy denial.
+func (c *HTTPSessionAPIClient) HasPolicyViolation(ctx context.Context, sessionID string) (bool, error) {
+	id, err := parseSessionID(sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.client.GetRuntimeEventsWithResponse(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("get runtime events for %s: %w", sessionID, err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return false, fmt.Errorf("get runtime events for %s: status %d", sessionID, resp.StatusCode())
+	}
+	if resp.JSON200 == nil {
+		return false, nil
+	}
+
+	for _, ev := range *resp.JSON200 {
+		if ev.EventType == nil {
+			continue
+		}
+		switch *ev.EventType {
+		case string(events.EventValidationFailed):
+			return true, nil
+		case eventTypePolicyDecision:
+			if isPolicyDenial(ev.Data) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// isPolicyDenial reports whether a recorded policy.decision event denied the
+// call. The runtime records the event's custom data under "Data".
+func isPolicyDenial(data *map[string]any) bool {
+	if data == nil {
+		return false
+	}
+	fields, ok := (*data)["Data"].(map[string]any)
+	return ok && fields["decision"] == policyDecisionDeny
+}
+
 // parseSessionID parses a string session ID into the UUID type expected by the generated client.
 func parseSessionID(sessionID string) (sessionapi.SessionID, error) {
 	var id sessionapi.SessionID
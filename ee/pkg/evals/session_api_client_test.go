@@ -387,6 +387,82 @@ func TestHTTPSessionAPIClient_GetSessionEvalResults_ConnectionError(t *testing.T
 	require.Error(t, err)
 }
 
+func TestHTTPSessionAPIClient_HasNegativeFeedback(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores []int
+		want   bool
+	}{
+		{name: "thumbs down", scores: []int{1, api.AnnotationScoreNegative}, want: true},
+		{name: "only thumbs up", scores: []int{1}, want: false},
+		{name: "no annotations", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/sessions/550e8400-e29b-41d4-a716-446655440000/annotations", r.URL.Path)
+				annotations := []sessionapi.MessageAnnotation{{Labels: &[]string{"reviewed"}}}
+				for _, score := range tt.scores {
+					annotations = append(annotations, sessionapi.MessageAnnotation{Score: &score})
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(sessionapi.AnnotationListResponse{Annotations: &annotations})
+			}))
+			defer server.Close()
+
+			got, err := newTestClient(t, server.URL).HasNegativeFeedback(context.Background(), "550e8400-e29b-41d4-a716-446655440000")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHTTPSessionAPIClient_HasPolicyViolation(t *testing.T) {
+	policyDecision := func(decision string) sessionapi.RuntimeEvent {
+		return sessionapi.RuntimeEvent{
+			EventType: ptr(eventTypePolicyDecision),
+			Data:      &map[string]any{"Data": map[string]any{"tool": "refund", "decision": decision}},
+		}
+	}
+	tests := []struct {
+		name   string
+		events []sessionapi.RuntimeEvent
+		want   bool
+	}{
+		{name: "validation failed", events: []sessionapi.RuntimeEvent{{EventType: ptr("validation.failed")}}, want: true},
+		{name: "tool call denied", events: []sessionapi.RuntimeEvent{policyDecision("allow"), policyDecision("deny")}, want: true},
+		{name: "would deny only", events: []sessionapi.RuntimeEvent{policyDecision("would_deny")}, want: false},
+		{name: "other events", events: []sessionapi.RuntimeEvent{{EventType: ptr("validation.passed")}, {}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/sessions/550e8400-e29b-41d4-a716-446655440000/events", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(tt.events)
+			}))
+			defer server.Close()
+
+			got, err := newTestClient(t, server.URL).HasPolicyViolation(context.Background(), "550e8400-e29b-41d4-a716-446655440000")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHTTPSessionAPIClient_SessionSignals_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	_, err := client.HasNegativeFeedback(context.Background(), "550e8400-e29b-41d4-a716-446655440000")
+	assert.ErrorContains(t, err, "500")
+	_, err = client.HasPolicyViolation(context.Background(), "550e8400-e29b-41d4-a716-446655440000")
+	assert.ErrorContains(t, err, "500")
+}
+
 func TestNewHTTPSessionAPIClient(t *testing.T) {
 	client, err := NewHTTPSessionAPIClient("http://example.com")
 
@@ -40,6 +40,15 @@ const (
 	pendingReclaimInterval  = 30 * time.Second
 	pendingMinIdle          = 2 * time.Minute
 	pendingReclaimBatchSize = 25
+	// pendingReclaimMaxBatches caps the XAUTOCLAIM pages one reclaim pass walks.
+	pendingReclaimMaxBatches = 10
+	heartbeatKeyPrefix       = "omnia:eval-workers:heartbeat:"
+	heartbeatInterval        = 10 * time.Second
+	heartbeatTTL             = 3 * heartbeatInterval
+	// deadConsumerMinIdle is how long a consumer without a heartbeat must
+	// have gone without reading before its pending entries are taken over.
+	deadConsumerMinIdle = time.Minute
+	groupExitTimeout    = 5 * time.Second
 )
 
 // MessageStore provides read access to session data from the Redis hot tier.
@@ -90,6 +99,10 @@ type WorkerConfig struct {
 	// dashboard discovers these via {__name__=~"omnia_eval_.*"}. If nil, one is
 	// created automatically with the default Prometheus registerer.
 	EvalCollector *sdkmetrics.Collector
+	// SignalReader reads the session signals that force evaluation of
+	// sessions sampling would skip (spec.evals.sampling.alwaysEvalOn).
+	// If nil, those signals are never seen.
+	SignalReader SessionSignalReader
 	// TracerProvider enables OTel tracing for eval execution.
 	// When set, the SDK emits per-eval spans with GenAI attributes.
 	TracerProvider trace.TracerProvider
@@ -102,7 +115,7 @@ type EvalWorker struct {
 	messageStore      MessageStore
 	namespaces        []string
 	streamKeys        []string
-	consumerGroup     string
+	consumerGroup     string // empty for multi-namespace workers; see partitions
 	consumerName      string
 	logger            *slog.Logger
 	sdkRunner         *SDKRunner
@@ -110,6 +123,7 @@ type EvalWorker struct {
 	rateLimiter       *RateLimiter
 	packLoader        *PromptPackLoader
 	providerResolver  *ProviderResolver
+	signalReader      SessionSignalReader
 
 	// workerGroupsOverride pins resolveWorkerGroups to a fixed list,
 	// bypassing both the resolver and the default. Test-only.
@@ -146,6 +160,9 @@ func NewEvalWorker(config WorkerConfig) *EvalWorker {
 		if config.ProviderCallWriter != nil {
 			runnerOpts = append(runnerOpts, WithProviderCallWriter(config.ProviderCallWriter))
 		}
+		if config.RedisClient != nil {
+			runnerOpts = append(runnerOpts, WithJudgeSpendRedis(config.RedisClient))
+		}
 		sdkRunner = NewSDKRunner(runnerOpts...)
 	}
 
@@ -171,7 +188,13 @@ func NewEvalWorker(config WorkerConfig) *EvalWorker {
 
 	namespaces := resolveNamespaces(config)
 	streamKeys := buildStreamKeys(namespaces)
-	consumerGroup := buildConsumerGroup(namespaces)
+	var consumerGroup string
+	switch len(namespaces) {
+	case 0:
+		consumerGroup = buildConsumerGroup("")
+	case 1:
+		consumerGroup = buildConsumerGroup(namespaces[0])
+	}
 
 	w := &EvalWorker{
 		redisClient:      config.RedisClient,
@@ -186,6 +209,7 @@ func NewEvalWorker(config WorkerConfig) *EvalWorker {
 		rateLimiter:      rateLimiter,
 		packLoader:       config.PackLoader,
 		providerResolver: resolver,
+		signalReader:     config.SignalReader,
 		metrics:          metricsRecorder,
 	}
 
@@ -215,16 +239,14 @@ func buildStreamKeys(namespaces []string) []string {
 	return keys
 }
 
-// buildConsumerGroup returns the consumer group name.
-// For multi-namespace mode, uses a "cluster" suffix; for single-namespace, uses the namespace name.
-func buildConsumerGroup(namespaces []string) string {
-	if len(namespaces) > 1 {
-		return consumerGroupPrefix + "cluster"
-	}
-	if len(namespaces) == 1 {
-		return consumerGroupPrefix + namespaces[0]
+// buildConsumerGroup returns the consumer group a namespace's stream is read
+// with. Groups are per namespace, including for multi-namespace workers, so
+// workers serving different tenants never share a group on a shared Redis.
+func buildConsumerGroup(namespace string) string {
+	if namespace == "" {
+		return consumerGroupPrefix + "default"
 	}
-	return consumerGroupPrefix + "default"
+	return consumerGroupPrefix + namespace
 }
 
 type packEvalDefs struct {
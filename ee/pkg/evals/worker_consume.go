@@ -14,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/altairalabs/omnia/internal/session"
 	"github.com/altairalabs/omnia/internal/session/api"
@@ -40,9 +42,36 @@ func (w *EvalWorker) StreamKeys() []string {
 	return w.streamKeys
 }
 
-// ConsumerGroup returns the consumer group name. Exported for testing.
-func (w *EvalWorker) ConsumerGroup() string {
-	return w.consumerGroup
+// ConsumerGroups returns the consumer group of each watched namespace's
+// stream, in StreamKeys order. Exported for testing.
+func (w *EvalWorker) ConsumerGroups() []string {
+	parts := w.partitions()
+	groups := make([]string, 0, len(parts))
+	for _, p := range parts {
+		groups = append(groups, p.consumerGroup)
+	}
+	return groups
+}
+
+// partitions returns one worker per watched namespace, each reading only that
+// namespace's stream with that namespace's consumer group. A single XREADGROUP
+// can only name one group, so a multi-namespace worker runs one consume loop
+// per partition; they share everything but the stream state. A worker with at
+// most one namespace is its own partition.
+func (w *EvalWorker) partitions() []*EvalWorker {
+	if len(w.streamKeys) <= 1 {
+		return []*EvalWorker{w}
+	}
+	parts := make([]*EvalWorker, 0, len(w.streamKeys))
+	for i, key := range w.streamKeys {
+		p := *w
+		p.namespaces = []string{w.namespaces[i]}
+		p.streamKeys = []string{key}
+		p.consumerGroup = buildConsumerGroup(w.namespaces[i])
+		p.lastPendingReclaim = time.Time{}
+		parts = append(parts, &p)
+	}
+	return parts
 }
 
 // Namespaces returns the namespaces this worker watches. Exported for testing.
@@ -51,24 +80,44 @@ func (w *EvalWorker) Namespaces() []string {
 }
 
 // Start begins consuming events from Redis Streams. It blocks until
-// the context is cancelled or an unrecoverable error occurs.
+// the context is cancelled or an unrecoverable error occurs. While running it
+// keeps a heartbeat per consumer group, and on cancellation it leaves the
+// groups it joined.
 func (w *EvalWorker) Start(ctx context.Context) error {
-	for _, key := range w.streamKeys {
-		if err := w.ensureConsumerGroup(ctx, key); err != nil {
-			return fmt.Errorf("ensure consumer group on %s: %w", key, err)
+	parts := w.partitions()
+	for _, p := range parts {
+		for _, key := range p.streamKeys {
+			if err := p.ensureConsumerGroup(ctx, key); err != nil {
+				return fmt.Errorf("ensure consumer group on %s: %w", key, err)
+			}
 		}
 	}
 
 	w.logger.Info("worker started",
 		"streams", strings.Join(w.streamKeys, ","),
 		"namespaces", strings.Join(w.namespaces, ","),
-		"consumerGroup", w.consumerGroup,
+		"consumerGroups", strings.Join(w.ConsumerGroups(), ","),
 		"consumer", w.consumerName,
 	)
 
-	go w.completionTracker.StartPeriodicCheck(ctx, periodicCheckInterval)
-
-	return w.consumeLoop(ctx)
+	// The first consume loop to fail cancels the others and the heartbeats.
+	g, gctx := errgroup.WithContext(ctx)
+	go w.completionTracker.StartPeriodicCheck(gctx, periodicCheckInterval)
+
+	heartbeatsDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatsDone)
+		w.runHeartbeats(gctx, parts)
+	}()
+	defer func() {
+		<-heartbeatsDone
+		w.leaveGroups(parts)
+	}()
+
+	for _, p := range parts {
+		g.Go(func() error { return p.consumeLoop(gctx) })
+	}
+	return g.Wait()
 }
 
 // ensureConsumerGroup creates the consumer group if it does not already exist.
@@ -105,8 +154,10 @@ func (w *EvalWorker) consumeLoop(ctx context.Context) error {
 	}
 }
 
-// reclaimPending periodically reclaims stale pending entries from other consumers
-// and re-processes them via the normal message handling path.
+// reclaimPending periodically reclaims pending entries that other consumers
+// left behind, either stale past pendingMinIdle or held by a consumer whose
+// heartbeat has lapsed, and re-processes them via the normal message handling
+// path.
 func (w *EvalWorker) reclaimPending(ctx context.Context) {
 	if !w.lastPendingReclaim.IsZero() && time.Since(w.lastPendingReclaim) < pendingReclaimInterval {
 		return
@@ -114,24 +165,41 @@ func (w *EvalWorker) reclaimPending(ctx context.Context) {
 	w.lastPendingReclaim = time.Now()
 
 	for _, key := range w.streamKeys {
-		msgs, _, err := w.redisClient.XAutoClaim(ctx, &goredis.XAutoClaimArgs{
-			Stream:   key,
+		w.claimStalePending(ctx, key)
+		w.removeDeadConsumers(ctx, key)
+	}
+}
+
+// claimStalePending walks the stream's pending entries list with XAUTOCLAIM,
+// taking over entries idle for longer than pendingMinIdle.
+func (w *EvalWorker) claimStalePending(ctx context.Context, streamKey string) {
+	start := "0-0"
+	for range pendingReclaimMaxBatches {
+		msgs, next, err := w.redisClient.XAutoClaim(ctx, &goredis.XAutoClaimArgs{
+			Stream:   streamKey,
 			Group:    w.consumerGroup,
 			Consumer: w.consumerName,
 			MinIdle:  pendingMinIdle,
-			Start:    "0-0",
+			Start:    start,
 			Count:    pendingReclaimBatchSize,
 		}).Result()
 		if err != nil {
 			if !errors.Is(err, goredis.Nil) {
-				w.logger.Debug("XAUTOCLAIM failed", "stream", key, "error", err)
+				w.logger.Debug("XAUTOCLAIM failed", "stream", streamKey, "error", err)
 			}
-			continue
+			return
 		}
 
+		if len(msgs) > 0 {
+			w.getMetrics().RecordPendingReclaimed(streamKey, reclaimReasonStale, len(msgs))
+		}
 		for _, msg := range msgs {
-			w.handleMessage(ctx, key, msg)
+			w.handleMessage(ctx, streamKey, msg)
+		}
+		if next == "0-0" || next == "" {
+			return
 		}
+		start = next
 	}
 }
 
@@ -147,7 +215,9 @@ func (w *EvalWorker) readFromStreams(ctx context.Context) ([]goredis.XStream, er
 	}).Result()
 }
 
-// reportStreamLag queries XPENDING for each stream to report consumer lag.
+// reportStreamLag reports, for each stream, the entries delivered but not yet
+// acknowledged (XPENDING), the age of the oldest of them, and the entries not
+// yet delivered to the group at all (XINFO GROUPS).
 func (w *EvalWorker) reportStreamLag(ctx context.Context) {
 	for _, key := range w.streamKeys {
 		pending, err := w.redisClient.XPending(ctx, key, w.consumerGroup).Result()
@@ -156,6 +226,45 @@ func (w *EvalWorker) reportStreamLag(ctx context.Context) {
 			continue
 		}
 		w.getMetrics().SetStreamLag(key, float64(pending.Count))
+		w.getMetrics().SetOldestPendingAge(key, w.oldestPendingAge(ctx, key, pending.Count).Seconds())
+		w.reportStreamBacklog(ctx, key)
+	}
+}
+
+// oldestPendingAge returns how long the oldest unacknowledged entry of a
+// stream has been idle since it was last delivered, or zero when none is
+// pending.
+func (w *EvalWorker) oldestPendingAge(ctx context.Context, streamKey string, pendingCount int64) time.Duration {
+	if pendingCount == 0 {
+		return 0
+	}
+	entries, err := w.redisClient.XPendingExt(ctx, &goredis.XPendingExtArgs{
+		Stream: streamKey,
+		Group:  w.consumerGroup,
+		Start:  "-",
+		End:    "+",
+		Count:  1,
+	}).Result()
+	if err != nil || len(entries) == 0 {
+		return 0
+	}
+	return entries[0].Idle
+}
+
+// reportStreamBacklog reports the entries not yet delivered to the consumer
+// group. Redis reports the lag as unknown (-1) after some stream deletions;
+// the gauge then keeps its previous value.
+func (w *EvalWorker) reportStreamBacklog(ctx context.Context, streamKey string) {
+	groups, err := w.redisClient.XInfoGroups(ctx, streamKey).Result()
+	if err != nil {
+		w.logger.Debug("failed to get stream group info", "stream", streamKey, "error", err)
+		return
+	}
+	for _, g := range groups {
+		if g.Name == w.consumerGroup && g.Lag >= 0 {
+			w.getMetrics().SetStreamBacklog(streamKey, float64(g.Lag))
+			return
+		}
 	}
 }
 
@@ -181,6 +290,17 @@ func (w *EvalWorker) handleMessage(ctx context.Context, streamKey string, msg go
 		return
 	}
 
+	// A stream only carries its own namespace's events; anything else was
+	// written by another tenant and must not be evaluated with this
+	// namespace's packs and providers.
+	if len(w.namespaces) == 1 && event.Namespace != "" && event.Namespace != w.namespaces[0] {
+		w.logger.Warn("event namespace does not match stream, skipping",
+			"messageID", msg.ID, "stream", streamKey, "eventNamespace", event.Namespace)
+		w.getMetrics().RecordEventReceived("namespace_mismatch")
+		w.ackMessage(ctx, streamKey, msg.ID)
+		return
+	}
+
 	// Restore trace context from the event's traceparent so spans are nested
 	// under the originating session trace.
 	ctx = restoreTraceContext(ctx, event)
@@ -199,9 +319,26 @@ func (w *EvalWorker) handleMessage(ctx context.Context, streamKey string, msg go
 	}
 
 	w.getMetrics().RecordEventProcessing(event.EventType, time.Since(start).Seconds())
+	if appended, ok := streamEntryTime(msg.ID); ok {
+		w.getMetrics().RecordEventAge(event.EventType, time.Since(appended).Seconds())
+	}
 	w.ackMessage(ctx, streamKey, msg.ID)
 }
 
+// streamEntryTime returns the time Redis appended a stream entry, taken from
+// the millisecond timestamp of its auto-generated "<ms>-<seq>" ID.
+func streamEntryTime(id string) (time.Time, bool) {
+	ms, _, found := strings.Cut(id, "-")
+	if !found {
+		return time.Time{}, false
+	}
+	millis, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(millis), true
+}
+
 // getTracker returns the completion tracker, initializing a no-op one if needed.
 // This ensures backward compatibility with tests that construct EvalWorker directly.
 func (w *EvalWorker) getTracker() *CompletionTracker {
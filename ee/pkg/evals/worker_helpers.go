@@ -27,7 +27,8 @@ func (w *EvalWorker) EvalCollector() *sdkmetrics.Collector {
 	return w.getSDKRunner().EvalCollector()
 }
 
-// getMessages reads session messages from the Redis hot tier.
+// getMessages reads session messages from the Redis hot tier. Transcripts
+// streamed in over OTLP are normalized to the PromptKit runtime's shape.
 func (w *EvalWorker) getMessages(ctx context.Context, sessionID string) ([]session.Message, error) {
 	ptrMsgs, err := w.getMessageStore().GetRecentMessages(ctx, sessionID, 0)
 	if err != nil {
@@ -39,7 +40,7 @@ func (w *EvalWorker) getMessages(ctx context.Context, sessionID string) ([]sessi
 			messages = append(messages, *m)
 		}
 	}
-	return messages, nil
+	return normalizeOTLPTranscript(messages), nil
 }
 
 // convertToEvalResults converts SDK result items into persistable EvalResults.
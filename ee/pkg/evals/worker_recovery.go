@@ -0,0 +1,180 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package evals
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Reasons a pending entry was reclaimed from another consumer.
+const (
+	reclaimReasonStale        = "stale"
+	reclaimReasonDeadConsumer = "dead_consumer"
+)
+
+// heartbeatKey is the Redis key through which a consumer of a group
+// advertises that it is alive.
+func heartbeatKey(consumerGroup, consumerName string) string {
+	return heartbeatKeyPrefix + consumerGroup + ":" + consumerName
+}
+
+// runHeartbeats refreshes the heartbeat of every partition's consumer until
+// ctx is done. Heartbeats expire after heartbeatTTL, so a consumer that
+// crashed stops advertising itself within that window.
+func (w *EvalWorker) runHeartbeats(ctx context.Context, parts []*EvalWorker) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		for _, p := range parts {
+			p.beat(ctx)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// beat refreshes this worker's heartbeat in its consumer group.
+func (w *EvalWorker) beat(ctx context.Context) {
+	key := heartbeatKey(w.consumerGroup, w.consumerName)
+	if err := w.redisClient.Set(ctx, key, time.Now().UTC().Format(time.RFC3339), heartbeatTTL).Err(); err != nil {
+		if ctx.Err() == nil {
+			w.logger.Debug("heartbeat failed", "consumerGroup", w.consumerGroup, "error", err)
+		}
+	}
+}
+
+// removeDeadConsumers takes over the pending entries of consumers in the
+// stream's group that have neither a heartbeat nor read for
+// deadConsumerMinIdle, then removes them from the group. Such consumers are
+// left behind by crashed or rescheduled pods, which come back under a new
+// name and would otherwise never see their entries again.
+func (w *EvalWorker) removeDeadConsumers(ctx context.Context, streamKey string) {
+	consumers, err := w.redisClient.XInfoConsumers(ctx, streamKey, w.consumerGroup).Result()
+	if err != nil {
+		w.logger.Debug("failed to list stream consumers", "stream", streamKey, "error", err)
+		return
+	}
+	for _, c := range consumers {
+		if c.Name == w.consumerName || c.Idle < deadConsumerMinIdle {
+			continue
+		}
+		alive, err := w.redisClient.Exists(ctx, heartbeatKey(w.consumerGroup, c.Name)).Result()
+		if err != nil {
+			w.logger.Debug("failed to check consumer heartbeat", "consumer", c.Name, "error", err)
+			continue
+		}
+		if alive > 0 {
+			continue
+		}
+		if w.claimDeadConsumer(ctx, streamKey, c.Name) {
+			w.deleteConsumer(ctx, streamKey, c.Name)
+		}
+	}
+}
+
+// claimDeadConsumer moves a batch of a dead consumer's pending entries to
+// this worker and processes them. It reports whether the dead consumer has
+// no pending entries left. The claim keeps deadConsumerMinIdle as its
+// minimum idle time so two workers sweeping the same consumer never both
+// take an entry.
+func (w *EvalWorker) claimDeadConsumer(ctx context.Context, streamKey, consumer string) bool {
+	pending, err := w.pendingOf(ctx, streamKey, consumer, pendingReclaimBatchSize)
+	if err != nil {
+		w.logger.Debug("failed to list dead consumer's pending entries", "consumer", consumer, "error", err)
+		return false
+	}
+	if len(pending) == 0 {
+		return true
+	}
+
+	ids := make([]string, len(pending))
+	for i, p := range pending {
+		ids[i] = p.ID
+	}
+	msgs, err := w.redisClient.XClaim(ctx, &goredis.XClaimArgs{
+		Stream:   streamKey,
+		Group:    w.consumerGroup,
+		Consumer: w.consumerName,
+		MinIdle:  deadConsumerMinIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		w.logger.Debug("XCLAIM failed", "stream", streamKey, "consumer", consumer, "error", err)
+		return false
+	}
+	if len(msgs) > 0 {
+		w.logger.Info("reclaimed pending entries from dead consumer",
+			"stream", streamKey, "consumer", consumer, "count", len(msgs))
+		w.getMetrics().RecordPendingReclaimed(streamKey, reclaimReasonDeadConsumer, len(msgs))
+	}
+	for _, msg := range msgs {
+		w.handleMessage(ctx, streamKey, msg)
+	}
+
+	remaining, err := w.pendingOf(ctx, streamKey, consumer, 1)
+	return err == nil && len(remaining) == 0
+}
+
+// pendingOf lists up to count pending entries held by consumer.
+func (w *EvalWorker) pendingOf(ctx context.Context, streamKey, consumer string, count int64) ([]goredis.XPendingExt, error) {
+	return w.redisClient.XPendingExt(ctx, &goredis.XPendingExtArgs{
+		Stream:   streamKey,
+		Group:    w.consumerGroup,
+		Start:    "-",
+		End:      "+",
+		Count:    count,
+		Consumer: consumer,
+	}).Result()
+}
+
+// deleteConsumer removes a consumer that holds no pending entries from the
+// stream's group.
+func (w *EvalWorker) deleteConsumer(ctx context.Context, streamKey, consumer string) {
+	if err := w.redisClient.XGroupDelConsumer(ctx, streamKey, w.consumerGroup, consumer).Err(); err != nil {
+		w.logger.Debug("failed to remove consumer", "stream", streamKey, "consumer", consumer, "error", err)
+		return
+	}
+	w.logger.Info("removed consumer from group",
+		"stream", streamKey, "consumerGroup", w.consumerGroup, "consumer", consumer)
+}
+
+// leaveGroups withdraws every partition's consumer from its group on
+// shutdown. The heartbeat is deleted so other workers can take over at
+// once. A consumer that still holds pending entries is kept in the group,
+// since deleting it would drop those entries; they are reclaimed like any
+// dead consumer's.
+func (w *EvalWorker) leaveGroups(parts []*EvalWorker) {
+	ctx, cancel := context.WithTimeout(context.Background(), groupExitTimeout)
+	defer cancel()
+
+	for _, p := range parts {
+		if err := p.redisClient.Del(ctx, heartbeatKey(p.consumerGroup, p.consumerName)).Err(); err != nil {
+			p.logger.Debug("failed to delete heartbeat", "consumerGroup", p.consumerGroup, "error", err)
+		}
+		for _, key := range p.streamKeys {
+			pending, err := p.pendingOf(ctx, key, p.consumerName, 1)
+			if err != nil {
+				p.logger.Debug("failed to list pending entries", "stream", key, "error", err)
+				continue
+			}
+			if len(pending) > 0 {
+				p.logger.Info("leaving pending entries for other consumers",
+					"stream", key, "consumerGroup", p.consumerGroup)
+				continue
+			}
+			p.deleteConsumer(ctx, key, p.consumerName)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package evals
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session/api"
+)
+
+var (
+	recoveryStreamKey = api.StreamKey("ns")
+	recoveryGroup     = buildConsumerGroup("ns")
+)
+
+// newRecoveryRedis starts a miniredis with the ns stream and its group. The
+// client honours context cancellation, so blocking reads end on shutdown.
+func newRecoveryRedis(t *testing.T) (*miniredis.Miniredis, *goredis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr(), ContextTimeoutEnabled: true})
+	t.Cleanup(func() { _ = client.Close() })
+	require.NoError(t, client.XGroupCreateMkStream(context.Background(), recoveryStreamKey, recoveryGroup, "0").Err())
+	return mr, client
+}
+
+// deliverEvents appends n assistant-message events and delivers them to consumer.
+func deliverEvents(t *testing.T, client *goredis.Client, consumer string, n int) {
+	t.Helper()
+	ctx := context.Background()
+	payload, err := json.Marshal(api.SessionEvent{
+		EventType: eventTypeMessage, SessionID: "s1", Namespace: "ns", MessageRole: "assistant",
+	})
+	require.NoError(t, err)
+	for range n {
+		require.NoError(t, client.XAdd(ctx, &goredis.XAddArgs{
+			Stream: recoveryStreamKey,
+			Values: map[string]any{streamPayloadField: string(payload)},
+		}).Err())
+	}
+	streams, err := client.XReadGroup(ctx, &goredis.XReadGroupArgs{
+		Group: recoveryGroup, Consumer: consumer, Streams: []string{recoveryStreamKey, ">"}, Count: int64(n),
+	}).Result()
+	require.NoError(t, err)
+	require.Len(t, streams[0].Messages, n)
+}
+
+// touchConsumer records activity by consumer: XCLAIM of its own entries is
+// the only command miniredis tracks consumer idle time for.
+func touchConsumer(t *testing.T, client *goredis.Client, consumer string) {
+	t.Helper()
+	ctx := context.Background()
+	pending, err := client.XPendingExt(ctx, &goredis.XPendingExtArgs{
+		Stream: recoveryStreamKey, Group: recoveryGroup, Start: "-", End: "+", Count: 100, Consumer: consumer,
+	}).Result()
+	require.NoError(t, err)
+	ids := make([]string, len(pending))
+	for i, p := range pending {
+		ids[i] = p.ID
+	}
+	require.NoError(t, client.XClaim(ctx, &goredis.XClaimArgs{
+		Stream: recoveryStreamKey, Group: recoveryGroup, Consumer: consumer, Messages: ids,
+	}).Err())
+}
+
+// consumerNames lists the consumers of the ns group.
+func consumerNames(t *testing.T, client *goredis.Client) []string {
+	t.Helper()
+	consumers, err := client.XInfoConsumers(context.Background(), recoveryStreamKey, recoveryGroup).Result()
+	require.NoError(t, err)
+	names := make([]string, 0, len(consumers))
+	for _, c := range consumers {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func newRecoveryWorker(client *goredis.Client, metrics WorkerMetricsRecorder) *EvalWorker {
+	return &EvalWorker{
+		redisClient:   client,
+		resultWriter:  &mockResultWriter{},
+		messageStore:  &mockMessageStore{},
+		namespaces:    []string{"ns"},
+		streamKeys:    []string{recoveryStreamKey},
+		consumerGroup: recoveryGroup,
+		consumerName:  "self",
+		logger:        testLogger(),
+		metrics:       metrics,
+	}
+}
+
+func TestRemoveDeadConsumers_TakesOverAndRemovesDeadConsumer(t *testing.T) {
+	mr, client := newRecoveryRedis(t)
+	ctx := context.Background()
+
+	deliverEvents(t, client, "dead-pod", 2)
+	deliverEvents(t, client, "busy-pod", 1)
+	touchConsumer(t, client, "dead-pod")
+	touchConsumer(t, client, "busy-pod")
+	mr.SetTime(time.Now().Add(2 * deadConsumerMinIdle))
+	// busy-pod is stuck in a long judge call but still heartbeating.
+	require.NoError(t, client.Set(ctx, heartbeatKey(recoveryGroup, "busy-pod"), "now", heartbeatTTL).Err())
+
+	spy := &spyMetrics{}
+	newRecoveryWorker(client, spy).removeDeadConsumers(ctx, recoveryStreamKey)
+
+	assert.Equal(t, 2, spy.pendingReclaimed[reclaimReasonDeadConsumer])
+	assert.Equal(t, []string{"busy-pod", "self"}, consumerNames(t, client))
+	pending, err := client.XPending(ctx, recoveryStreamKey, recoveryGroup).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pending.Count, "only busy-pod's entry is still pending")
+}
+
+func TestRemoveDeadConsumers_SkipsRecentlyActiveConsumer(t *testing.T) {
+	_, client := newRecoveryRedis(t)
+	ctx := context.Background()
+
+	deliverEvents(t, client, "new-pod", 1)
+	touchConsumer(t, client, "new-pod")
+
+	spy := &spyMetrics{}
+	newRecoveryWorker(client, spy).removeDeadConsumers(ctx, recoveryStreamKey)
+
+	assert.Empty(t, spy.pendingReclaimed)
+	assert.Equal(t, []string{"new-pod"}, consumerNames(t, client))
+}
+
+func TestClaimStalePending_WalksAllPages(t *testing.T) {
+	mr, client := newRecoveryRedis(t)
+	ctx := context.Background()
+
+	deliverEvents(t, client, "slow-pod", pendingReclaimBatchSize+5)
+	mr.SetTime(time.Now().Add(2 * pendingMinIdle))
+
+	spy := &spyMetrics{}
+	newRecoveryWorker(client, spy).claimStalePending(ctx, recoveryStreamKey)
+
+	assert.Equal(t, pendingReclaimBatchSize+5, spy.pendingReclaimed[reclaimReasonStale])
+	pending, err := client.XPending(ctx, recoveryStreamKey, recoveryGroup).Result()
+	require.NoError(t, err)
+	assert.Zero(t, pending.Count)
+}
+
+func TestLeaveGroups(t *testing.T) {
+	_, client := newRecoveryRedis(t)
+	ctx := context.Background()
+
+	w := newRecoveryWorker(client, nil)
+	w.beat(ctx)
+	ttl, err := client.TTL(ctx, heartbeatKey(recoveryGroup, "self")).Result()
+	require.NoError(t, err)
+	assert.Equal(t, heartbeatTTL, ttl)
+
+	// A consumer still holding entries stays in the group so they survive.
+	deliverEvents(t, client, "self", 1)
+	w.leaveGroups([]*EvalWorker{w})
+	assert.Equal(t, []string{"self"}, consumerNames(t, client))
+	assert.Zero(t, client.Exists(ctx, heartbeatKey(recoveryGroup, "self")).Val())
+
+	// Once its entries are acknowledged it leaves.
+	entries, err := w.pendingOf(ctx, recoveryStreamKey, "self", 1)
+	require.NoError(t, err)
+	w.ackMessage(ctx, recoveryStreamKey, entries[0].ID)
+	w.leaveGroups([]*EvalWorker{w})
+	assert.Empty(t, consumerNames(t, client))
+}
+
+func TestStart_HeartbeatsAndLeavesGroupOnShutdown(t *testing.T) {
+	mr, client := newRecoveryRedis(t)
+
+	w := NewEvalWorker(WorkerConfig{
+		RedisClient:  client,
+		ResultWriter: &mockResultWriter{},
+		MessageStore: &mockMessageStore{},
+		Namespaces:   []string{"ns"},
+		Logger:       testLogger(),
+	})
+	key := heartbeatKey(recoveryGroup, w.consumerName)
+	// An event for the worker to read and acknowledge, joining the group.
+	payload, err := json.Marshal(api.SessionEvent{
+		EventType: eventTypeMessage, SessionID: "s1", Namespace: "ns", MessageRole: "assistant",
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.XAdd(context.Background(), &goredis.XAddArgs{
+		Stream: recoveryStreamKey,
+		Values: map[string]any{streamPayloadField: string(payload)},
+	}).Err())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Start(ctx) }()
+
+	require.Eventually(t, func() bool { return mr.Exists(key) }, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		pending, err := client.XPending(context.Background(), recoveryStreamKey, recoveryGroup).Result()
+		return err == nil && len(consumerNames(t, client)) == 1 && pending.Count == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("worker did not shut down in time")
+	}
+	assert.False(t, mr.Exists(key))
+	assert.Empty(t, consumerNames(t, client))
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package evals
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	runtimeevals "github.com/AltairaLabs/PromptKit/runtime/evals"
+
+	v1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/session/api"
+)
+
+// Reasons for a session sampling decision, as recorded in metrics.
+const (
+	samplingReasonRate             = "rate"
+	samplingReasonExtended         = TierExtended
+	samplingReasonLightweight      = TierLightweight
+	samplingReasonCap              = "hourly_cap"
+	samplingReasonNegativeFeedback = "negative_feedback"
+	samplingReasonPolicyViolation  = "policy_violation"
+)
+
+// sessionCapKeyPrefix prefixes the per-agent, per-hour Redis sets of the
+// sessions admitted under EvalSampling.MaxSessionsPerHour. Each set outlives
+// its hour so late turns of an admitted session still find it.
+const (
+	sessionCapKeyPrefix = "omnia:eval-sampling:"
+	sessionCapTTL       = 2 * time.Hour
+)
+
+// sampleSession decides whether the worker evaluates an automatically
+// triggered event, and with which eval groups. Agents without
+// spec.evals.sampling evaluate every session with the worker's groups.
+//
+// Otherwise the session is sampled per tier: sessions sampled for the
+// extended tier run the worker's groups, those sampled only for the
+// lightweight tier run its fast-running evals, and the rest are skipped.
+// Sampled sessions then count against the agent's hourly cap. A session that
+// is skipped either way is still evaluated in full if one of the configured
+// alwaysEvalOn signals applies to it.
+func (w *EvalWorker) sampleSession(
+	ctx context.Context, event api.SessionEvent, groups []string,
+) ([]string, bool) {
+	cfg := w.resolveSampling(ctx, event)
+	if cfg == nil {
+		return groups, true
+	}
+
+	sampled, reason := sampledGroups(NewSampler(cfg), event.SessionID, groups)
+	if reason != samplingReasonRate {
+		if cfg.MaxSessionsPerHour == nil || w.admitSession(ctx, event, *cfg.MaxSessionsPerHour) {
+			w.getMetrics().RecordSessionSampling(MetricStatusSampled, reason)
+			return sampled, true
+		}
+		reason = samplingReasonCap
+	}
+
+	if signal := w.forcingSignal(ctx, event.SessionID, cfg.AlwaysEvalOn); signal != "" {
+		w.getMetrics().RecordSessionSampling(MetricStatusSampled, signal)
+		return groups, true
+	}
+
+	w.getMetrics().RecordSessionSampling(MetricStatusSkipped, reason)
+	w.logger.Debug("session not sampled for evals",
+		"sessionID", event.SessionID,
+		"agentName", event.AgentName,
+		"reason", reason,
+	)
+	return nil, false
+}
+
+// resolveSampling returns the agent's sampling config, or nil when sampling
+// is not configured or the agent cannot be resolved.
+func (w *EvalWorker) resolveSampling(ctx context.Context, event api.SessionEvent) *v1alpha1.EvalSampling {
+	if w.providerResolver == nil || event.AgentName == "" || event.Namespace == "" {
+		return nil
+	}
+	return w.providerResolver.ResolveSamplingConfig(ctx, event.AgentName, event.Namespace)
+}
+
+// sampledGroups returns the eval groups to run for a session given its
+// sampled tiers, and the reason for the decision. A session sampled for no
+// tier, or only for the lightweight tier on a worker path that doesn't run
+// fast-running evals, yields samplingReasonRate and no groups.
+func sampledGroups(sampler *Sampler, sessionID string, groups []string) ([]string, string) {
+	tiers := sampler.EvalTiersForSession(sessionID)
+	if slices.Contains(tiers, TierExtended) {
+		return groups, samplingReasonExtended
+	}
+	if !slices.Contains(tiers, TierLightweight) {
+		return nil, samplingReasonRate
+	}
+	// A nil filter runs every group, so the lightweight tier narrows it to
+	// fast-running. "default" is not lightweight: every eval, LLM judges
+	// included, carries it.
+	if groups == nil || slices.Contains(groups, runtimeevals.GroupFastRunning) {
+		return []string{runtimeevals.GroupFastRunning}, samplingReasonLightweight
+	}
+	return nil, samplingReasonRate
+}
+
+// admitSession counts a session against the agent's hourly cap, shared by
+// all worker replicas through Redis, and reports whether it is within the
+// cap. A session already admitted this hour is admitted again without being
+// counted twice. Without Redis, or when Redis fails, sessions are admitted.
+func (w *EvalWorker) admitSession(ctx context.Context, event api.SessionEvent, limit int32) bool {
+	if w.redisClient == nil {
+		return true
+	}
+	key := sessionCapKey(event.Namespace, event.AgentName, time.Now())
+
+	pipe := w.redisClient.TxPipeline()
+	added := pipe.SAdd(ctx, key, event.SessionID)
+	size := pipe.SCard(ctx, key)
+	pipe.Expire(ctx, key, sessionCapTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		w.logger.Warn("failed to count session against eval cap", "key", key, "error", err)
+		return true
+	}
+	if added.Val() == 0 || size.Val() <= int64(limit) {
+		return true
+	}
+
+	// Over the cap: give the slot back so the set only holds admitted sessions.
+	if err := w.redisClient.SRem(ctx, key, event.SessionID).Err(); err != nil {
+		w.logger.Debug("failed to release eval cap slot", "key", key, "error", err)
+	}
+	return false
+}
+
+// sessionCapKey returns the Redis set of an agent's sessions admitted in the
+// clock hour containing now.
+func sessionCapKey(namespace, agentName string, now time.Time) string {
+	return sessionCapKeyPrefix + namespace + ":" + agentName + ":" + now.UTC().Format("2006010215")
+}
+
+// forcingSignal returns the reason of the first configured signal that
+// applies to the session, or "" if none does. Signals that cannot be read
+// are logged and treated as absent.
+func (w *EvalWorker) forcingSignal(
+	ctx context.Context, sessionID string, signals []v1alpha1.EvalSamplingSignal,
+) string {
+	if w.signalReader == nil {
+		return ""
+	}
+	for _, signal := range signals {
+		var (
+			found  bool
+			err    error
+			reason string
+		)
+		switch signal {
+		case v1alpha1.EvalSamplingSignalNegativeFeedback:
+			found, err = w.signalReader.HasNegativeFeedback(ctx, sessionID)
+			reason = samplingReasonNegativeFeedback
+		case v1alpha1.EvalSamplingSignalPolicyViolation:
+			found, err = w.signalReader.HasPolicyViolation(ctx, sessionID)
+			reason = samplingReasonPolicyViolation
+		default:
+			continue
+		}
+		if err != nil {
+			w.logger.Warn("failed to read session signal",
+				"sessionID", sessionID,
+				"signal", string(signal),
+				"error", err,
+			)
+			continue
+		}
+		if found {
+			return reason
+		}
+	}
+	return ""
+}
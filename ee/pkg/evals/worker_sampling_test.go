@@ -0,0 +1,222 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package evals
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	runtimeevals "github.com/AltairaLabs/PromptKit/runtime/evals"
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/api"
+)
+
+// stubSignalReader reports fixed session signals.
+type stubSignalReader struct {
+	negativeFeedback bool
+	policyViolation  bool
+	err              error
+}
+
+func (s *stubSignalReader) HasNegativeFeedback(context.Context, string) (bool, error) {
+	return s.negativeFeedback, s.err
+}
+
+func (s *stubSignalReader) HasPolicyViolation(context.Context, string) (bool, error) {
+	return s.policyViolation, s.err
+}
+
+// sampledAgent returns an AgentRuntime named "agent" with the given sampling config.
+func sampledAgent(sampling *v1alpha1.EvalSampling) *v1alpha1.AgentRuntime {
+	return &v1alpha1.AgentRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "ns"},
+		Spec: v1alpha1.AgentRuntimeSpec{
+			PromptPackRef: v1alpha1.PromptPackRef{Name: "test-pack"},
+			Evals:         &v1alpha1.EvalConfig{Enabled: true, Sampling: sampling},
+		},
+	}
+}
+
+func samplingRates(defaultRate, extendedRate int32) *v1alpha1.EvalSampling {
+	return &v1alpha1.EvalSampling{DefaultRate: &defaultRate, ExtendedRate: &extendedRate}
+}
+
+func newSamplingWorker(t *testing.T, sampling *v1alpha1.EvalSampling, metrics WorkerMetricsRecorder) *EvalWorker {
+	t.Helper()
+	return &EvalWorker{
+		providerResolver: NewProviderResolver(buildFakeClient(sampledAgent(sampling)).Build()),
+		logger:           testLogger(),
+		metrics:          metrics,
+	}
+}
+
+func samplingEvent(sessionID string) api.SessionEvent {
+	return api.SessionEvent{SessionID: sessionID, AgentName: "agent", Namespace: "ns"}
+}
+
+func TestSampledGroups(t *testing.T) {
+	tests := []struct {
+		name       string
+		rates      *v1alpha1.EvalSampling
+		groups     []string
+		wantGroups []string
+		wantReason string
+	}{
+		{
+			name:       "extended tier keeps the worker groups",
+			rates:      samplingRates(100, 100),
+			groups:     DefaultWorkerEvalGroups,
+			wantGroups: DefaultWorkerEvalGroups,
+			wantReason: samplingReasonExtended,
+		},
+		{
+			name:       "lightweight tier drops groups without fast-running evals",
+			rates:      samplingRates(100, 0),
+			groups:     DefaultWorkerEvalGroups,
+			wantReason: samplingReasonRate,
+		},
+		{
+			name:       "lightweight tier narrows to fast-running",
+			rates:      samplingRates(100, 0),
+			groups:     []string{runtimeevals.GroupFastRunning, runtimeevals.GroupLongRunning},
+			wantGroups: []string{runtimeevals.GroupFastRunning},
+			wantReason: samplingReasonLightweight,
+		},
+		{
+			name:       "lightweight tier narrows an unfiltered path",
+			rates:      samplingRates(100, 0),
+			wantGroups: []string{runtimeevals.GroupFastRunning},
+			wantReason: samplingReasonLightweight,
+		},
+		{
+			name:       "no tier",
+			rates:      samplingRates(0, 0),
+			groups:     DefaultWorkerEvalGroups,
+			wantReason: samplingReasonRate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, reason := sampledGroups(NewSampler(tt.rates), "s1", tt.groups)
+			assert.Equal(t, tt.wantGroups, groups)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
+func TestSampleSession_NoSamplingConfig(t *testing.T) {
+	spy := &spyMetrics{}
+	w := newSamplingWorker(t, nil, spy)
+
+	groups, ok := w.sampleSession(context.Background(), samplingEvent("s1"), DefaultWorkerEvalGroups)
+	assert.True(t, ok)
+	assert.Equal(t, DefaultWorkerEvalGroups, groups)
+	assert.Empty(t, spy.sessionSampling, "agents without sampling are not sampled")
+}
+
+func TestSampleSession_HourlyCap(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	sampling := samplingRates(100, 100)
+	sampling.MaxSessionsPerHour = ptr(int32(2))
+	spy := &spyMetrics{}
+	w := newSamplingWorker(t, sampling, spy)
+	w.redisClient = client
+	ctx := context.Background()
+
+	for _, id := range []string{"s1", "s2", "s1", "s3", "s2"} {
+		_, ok := w.sampleSession(ctx, samplingEvent(id), nil)
+		assert.Equal(t, id != "s3", ok, id)
+	}
+	assert.Equal(t, sessionSamplingCall{MetricStatusSkipped, samplingReasonCap}, spy.sessionSampling[3])
+
+	key := sessionCapKey("ns", "agent", time.Now())
+	members, err := client.SMembers(ctx, key).Result()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"s1", "s2"}, members)
+	assert.Positive(t, mr.TTL(key))
+}
+
+func TestSampleSession_AlwaysEvalOn(t *testing.T) {
+	sampling := samplingRates(0, 0)
+	sampling.AlwaysEvalOn = []v1alpha1.EvalSamplingSignal{
+		v1alpha1.EvalSamplingSignalNegativeFeedback,
+		v1alpha1.EvalSamplingSignalPolicyViolation,
+	}
+	tests := []struct {
+		name       string
+		reader     SessionSignalReader
+		wantOK     bool
+		wantReason string
+	}{
+		{name: "negative feedback", reader: &stubSignalReader{negativeFeedback: true},
+			wantOK: true, wantReason: samplingReasonNegativeFeedback},
+		{name: "policy violation", reader: &stubSignalReader{policyViolation: true},
+			wantOK: true, wantReason: samplingReasonPolicyViolation},
+		{name: "no signal", reader: &stubSignalReader{}, wantReason: samplingReasonRate},
+		{name: "unreadable signals", reader: &stubSignalReader{err: errors.New("session-api down")},
+			wantReason: samplingReasonRate},
+		{name: "no reader", wantReason: samplingReasonRate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spy := &spyMetrics{}
+			w := newSamplingWorker(t, sampling, spy)
+			w.signalReader = tt.reader
+
+			groups, ok := w.sampleSession(context.Background(), samplingEvent("s1"), DefaultWorkerEvalGroups)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, DefaultWorkerEvalGroups, groups, "forced sessions run every worker group")
+			}
+			require.Len(t, spy.sessionSampling, 1)
+			assert.Equal(t, tt.wantReason, spy.sessionSampling[0].reason)
+		})
+	}
+}
+
+func TestProcessAssistantMessage_SkipsUnsampledSession(t *testing.T) {
+	writer := &mockResultWriter{}
+	w := newSamplingWorker(t, samplingRates(0, 0), nil)
+	w.resultWriter = writer
+	w.messageStore = &mockMessageStore{
+		sess: &session.Session{ID: "s1", AgentName: "agent", Namespace: "ns"},
+		messages: toMessagePtrs([]session.Message{
+			{ID: "m1", Role: session.RoleUser, Content: "hi"},
+			{ID: "m2", Role: session.RoleAssistant, Content: "hello"},
+		}),
+	}
+	w.packLoader = newTestPackLoader([]runtimeevals.EvalDef{
+		containsEvalDef("e1", runtimeevals.TriggerEveryTurn, "hello"),
+		containsEvalDef("e2", runtimeevals.TriggerOnSessionComplete, "hello"),
+	})
+
+	event := samplingEvent("s1")
+	event.EventType = eventTypeMessage
+	event.PromptPackName = "test-pack"
+	event.PromptPackVersion = "v1"
+	require.NoError(t, w.processAssistantMessage(context.Background(), event))
+	assert.Empty(t, writer.written)
+
+	// Manual evaluation ignores sampling.
+	event.EventType = eventTypeEvaluate
+	require.NoError(t, w.processEvaluateRequest(context.Background(), event))
+	assert.NotEmpty(t, writer.written)
+}
@@ -1424,7 +1424,7 @@ func TestNewEvalWorker_MultiNamespace(t *testing.T) {
 
 	assert.Equal(t, []string{"ns1", "ns2"}, w.Namespaces())
 	assert.Len(t, w.StreamKeys(), 2)
-	assert.Equal(t, consumerGroupPrefix+"cluster", w.ConsumerGroup())
+	assert.Equal(t, []string{consumerGroupPrefix + "ns1", consumerGroupPrefix + "ns2"}, w.ConsumerGroups())
 }
 
 func TestNewEvalWorker_SingleNamespace(t *testing.T) {
@@ -1444,7 +1444,7 @@ func TestNewEvalWorker_SingleNamespace(t *testing.T) {
 
 	assert.Equal(t, []string{"ns"}, w.Namespaces())
 	assert.Len(t, w.StreamKeys(), 1)
-	assert.Equal(t, consumerGroupPrefix+"ns", w.ConsumerGroup())
+	assert.Equal(t, []string{consumerGroupPrefix + "ns"}, w.ConsumerGroups())
 }
 
 func TestNewEvalWorker_BackwardCompat_DeprecatedNamespace(t *testing.T) {
@@ -1513,23 +1513,119 @@ func TestStart_CreatesConsumerGroupsOnAllStreams(t *testing.T) {
 		t.Fatal("worker did not shut down")
 	}
 
-	// Verify both streams have the consumer group.
-	for _, key := range w.StreamKeys() {
+	// Verify each stream has its own namespace's consumer group.
+	for i, key := range w.StreamKeys() {
 		groups, err := client.XInfoGroups(context.Background(), key).Result()
 		require.NoError(t, err)
 		require.Len(t, groups, 1, "expected consumer group on %s", key)
+		assert.Equal(t, consumerGroupPrefix+w.Namespaces()[i], groups[0].Name)
 	}
 }
 
+func TestEvalWorker_NamespacesDoNotCrossConsume(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	defer func() { _ = client.Close() }()
+	ctx := context.Background()
+
+	newWorker := func(namespaces ...string) *EvalWorker {
+		return NewEvalWorker(WorkerConfig{
+			RedisClient:  client,
+			ResultWriter: &mockResultWriter{},
+			Namespaces:   namespaces,
+			Logger:       testLogger(),
+		})
+	}
+	tenantA := newWorker("tenant-a")
+	cluster := newWorker("tenant-a", "tenant-b")
+	for _, w := range []*EvalWorker{tenantA, cluster} {
+		for _, p := range w.partitions() {
+			require.NoError(t, p.ensureConsumerGroup(ctx, p.streamKeys[0]))
+		}
+	}
+
+	for _, ns := range []string{"tenant-a", "tenant-b"} {
+		require.NoError(t, client.XAdd(ctx, &goredis.XAddArgs{
+			Stream: api.StreamKey(ns),
+			Values: map[string]any{streamPayloadField: `{"namespace":"` + ns + `"}`},
+		}).Err())
+	}
+
+	// tenant-a's worker reads only tenant-a's stream.
+	streams, err := tenantA.readFromStreams(ctx)
+	require.NoError(t, err)
+	require.Len(t, streams, 1)
+	assert.Equal(t, api.StreamKey("tenant-a"), streams[0].Stream)
+
+	// The multi-namespace worker reads each stream with that namespace's
+	// group: tenant-a's entry was already taken by tenant-a's group, which it
+	// shares, and its tenant-b partition sees only tenant-b's entry.
+	parts := cluster.partitions()
+	require.Len(t, parts, 2)
+	shortCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	streams, _ = parts[0].readFromStreams(shortCtx)
+	assert.Empty(t, streams)
+	streams, err = parts[1].readFromStreams(ctx)
+	require.NoError(t, err)
+	require.Len(t, streams, 1)
+	assert.Equal(t, api.StreamKey("tenant-b"), streams[0].Stream)
+	require.Len(t, streams[0].Messages, 1)
+
+	groups, err := client.XInfoGroups(ctx, api.StreamKey("tenant-a")).Result()
+	require.NoError(t, err)
+	require.Len(t, groups, 1, "tenant-a's stream is read by tenant-a's group only")
+}
+
+func TestHandleMessage_ForeignNamespaceSkipped(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	defer func() { _ = client.Close() }()
+	ctx := context.Background()
+
+	streamKey := api.StreamKey("tenant-a")
+	group := buildConsumerGroup("tenant-a")
+	require.NoError(t, client.XGroupCreateMkStream(ctx, streamKey, group, "0").Err())
+	require.NoError(t, client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: streamKey,
+		Values: map[string]any{streamPayloadField: `{"namespace":"tenant-b","sessionId":"s1","eventType":"session.evaluate"}`},
+	}).Err())
+
+	writer := &mockResultWriter{}
+	w := &EvalWorker{
+		redisClient:   client,
+		resultWriter:  writer,
+		messageStore:  &mockMessageStore{},
+		namespaces:    []string{"tenant-a"},
+		streamKeys:    []string{streamKey},
+		consumerGroup: group,
+		consumerName:  "test-consumer",
+		logger:        testLogger(),
+	}
+	streams, err := w.readFromStreams(ctx)
+	require.NoError(t, err)
+	w.processStreams(ctx, streams)
+
+	pending, err := client.XPending(ctx, streamKey, group).Result()
+	require.NoError(t, err)
+	assert.Zero(t, pending.Count, "foreign event is acknowledged, not retried")
+	assert.Empty(t, writer.written)
+}
+
 func TestRepeatedGt(t *testing.T) {
 	assert.Equal(t, []string{">", ">", ">"}, repeatedGt(3))
 	assert.Equal(t, []string{">"}, repeatedGt(1))
 }
 
 func TestBuildConsumerGroup(t *testing.T) {
-	assert.Equal(t, consumerGroupPrefix+"cluster", buildConsumerGroup([]string{"a", "b"}))
-	assert.Equal(t, consumerGroupPrefix+"a", buildConsumerGroup([]string{"a"}))
-	assert.Equal(t, consumerGroupPrefix+"default", buildConsumerGroup(nil))
+	assert.Equal(t, consumerGroupPrefix+"a", buildConsumerGroup("a"))
+	assert.Equal(t, consumerGroupPrefix+"default", buildConsumerGroup(""))
 }
 
 func TestBuildStreamKeys(t *testing.T) {
@@ -1749,6 +1845,8 @@ func TestReportStreamLag(t *testing.T) {
 	group := "omnia-eval-workers-ns"
 
 	// Create consumer group, add messages, then read (without ACK) to create pending entries.
+	now := time.Now()
+	mr.SetTime(now)
 	client.XGroupCreateMkStream(context.Background(), streamKey, group, "0")
 	for i := 0; i < 3; i++ {
 		client.XAdd(context.Background(), &goredis.XAddArgs{
@@ -1775,11 +1873,28 @@ func TestReportStreamLag(t *testing.T) {
 		metrics:       spy,
 	}
 
+	// Two more entries nobody has read yet.
+	for i := 0; i < 2; i++ {
+		client.XAdd(context.Background(), &goredis.XAddArgs{
+			Stream: streamKey,
+			Values: map[string]any{streamPayloadField: `{"eventType":"message.assistant"}`},
+		})
+	}
+	mr.SetTime(now.Add(2 * time.Second))
+
 	w.reportStreamLag(context.Background())
 
 	require.Len(t, spy.streamLag, 1)
 	assert.Equal(t, streamKey, spy.streamLag[0].stream)
 	assert.Equal(t, float64(3), spy.streamLag[0].lag)
+
+	// miniredis reports the whole stream length as the group lag, so only
+	// check that the backlog is reported; real Redis would report 2.
+	require.Len(t, spy.streamBacklog, 1)
+	assert.Equal(t, streamKey, spy.streamBacklog[0].stream)
+
+	require.Len(t, spy.oldestPending, 1)
+	assert.Equal(t, streamLagCall{streamKey, 2}, spy.oldestPending[0])
 }
 
 func TestReportStreamLag_NoMessages(t *testing.T) {
@@ -1807,6 +1922,19 @@ func TestReportStreamLag_NoMessages(t *testing.T) {
 
 	require.Len(t, spy.streamLag, 1)
 	assert.Equal(t, float64(0), spy.streamLag[0].lag)
+	assert.Equal(t, []streamLagCall{{streamKey, 0}}, spy.oldestPending)
+	assert.Equal(t, []streamLagCall{{streamKey, 0}}, spy.streamBacklog)
+}
+
+func TestStreamEntryTime(t *testing.T) {
+	ts, ok := streamEntryTime("1700000000123-4")
+	require.True(t, ok)
+	assert.Equal(t, time.UnixMilli(1700000000123), ts)
+
+	_, ok = streamEntryTime("not-an-id")
+	assert.False(t, ok)
+	_, ok = streamEntryTime("12345")
+	assert.False(t, ok)
 }
 
 func TestProcessAssistantMessage_RecordsEvalMetrics(t *testing.T) {
@@ -40,6 +40,11 @@ func (w *EvalWorker) processAssistantMessage(ctx context.Context, event api.Sess
 		return nil
 	}
 
+	groups, ok := w.sampleSession(ctx, event, w.resolveWorkerGroups(ctx, event))
+	if !ok {
+		return nil
+	}
+
 	messages, err := w.getMessages(ctx, event.SessionID)
 	if err != nil {
 		return err
@@ -54,8 +59,8 @@ func (w *EvalWorker) processAssistantMessage(ctx context.Context, event api.Sess
 	providerSpecs := w.resolveProviders(ctx, event)
 	enrichedEvent := enrichEvent(event, packEvals)
 
-	labels := evalLabelsFor(sess.AgentName, event.Namespace, packEvals.PackName,
-		sess.Variant, w.resolveWorkerGroups(ctx, event))
+	labels := evalLabelsFor(sess.AgentName, event.Namespace, packEvals.PackName, sess.Variant, groups)
+	labels.Judge = w.resolveJudgePolicy(ctx, event)
 	items := w.getSDKRunner().RunTurnEvals(ctx, packEvals.PackData, messages,
 		event.SessionID, turnIndex, providerSpecs, labels)
 	w.logWorkerGroupFilteredSkip(event.SessionID, runtimeevals.TriggerEveryTurn, packEvals, labels.Groups, items)
@@ -86,6 +91,11 @@ func (w *EvalWorker) onSessionComplete(ctx context.Context, sessionID string) er
 		return nil
 	}
 
+	groups, ok := w.sampleSession(ctx, event, w.resolveWorkerGroups(ctx, event))
+	if !ok {
+		return nil
+	}
+
 	messages, err := w.getMessages(ctx, sessionID)
 	if err != nil {
 		return err
@@ -95,8 +105,8 @@ func (w *EvalWorker) onSessionComplete(ctx context.Context, sessionID string) er
 	providerSpecs := w.resolveProviders(ctx, event)
 	enrichedEvent := enrichEvent(event, packEvals)
 
-	labels := evalLabelsFor(sess.AgentName, event.Namespace, packEvals.PackName,
-		sess.Variant, w.resolveWorkerGroups(ctx, event))
+	labels := evalLabelsFor(sess.AgentName, event.Namespace, packEvals.PackName, sess.Variant, groups)
+	labels.Judge = w.resolveJudgePolicy(ctx, event)
 	items := w.getSDKRunner().RunSessionEvals(ctx, packEvals.PackData, messages,
 		sessionID, turnIndex, providerSpecs, labels)
 	w.logWorkerGroupFilteredSkip(sessionID, runtimeevals.TriggerOnSessionComplete, packEvals, labels.Groups, items)
@@ -129,6 +139,7 @@ func (w *EvalWorker) processEvaluateRequest(ctx context.Context, event api.Sessi
 	enrichedEvent := enrichEvent(event, packEvals)
 
 	labels := evalLabelsFor(sess.AgentName, event.Namespace, packEvals.PackName, sess.Variant, nil)
+	labels.Judge = w.resolveJudgePolicy(ctx, event)
 	// Run all evals without tier filtering — manual trigger runs everything.
 	items := w.getSDKRunner().RunSessionEvals(ctx, packEvals.PackData, messages,
 		event.SessionID, turnIndex, providerSpecs, labels)
@@ -287,6 +298,15 @@ func (w *EvalWorker) resolveProviders(ctx context.Context, event api.SessionEven
 	return specs
 }
 
+// resolveJudgePolicy returns the agent's judge policy, or nil when no judge
+// is configured or no resolver is configured.
+func (w *EvalWorker) resolveJudgePolicy(ctx context.Context, event api.SessionEvent) *JudgePolicy {
+	if w.providerResolver == nil || event.AgentName == "" || event.Namespace == "" {
+		return nil
+	}
+	return judgePolicyFor(w.providerResolver.ResolveJudgeConfig(ctx, event.AgentName, event.Namespace))
+}
+
 // resolveWorkerGroups returns the eval group filter for worker-path
 // execution on this agent, falling back to DefaultWorkerEvalGroups when
 // the CRD does not specify one (or the resolver is not configured).
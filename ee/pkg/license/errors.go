@@ -11,6 +11,7 @@ package license
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Common license validation errors.
@@ -23,6 +24,8 @@ var (
 	ErrLicenseInvalid = errors.New("license is invalid")
 	// ErrInvalidSignature indicates the JWT signature is invalid.
 	ErrInvalidSignature = errors.New("invalid license signature")
+	// ErrClusterMismatch indicates the license is bound to other clusters.
+	ErrClusterMismatch = errors.New("license is not issued for this cluster")
 )
 
 // ValidationError represents a license validation failure with upgrade messaging.
@@ -118,3 +121,14 @@ func NewLicenseExpiredError() *ValidationError {
 		UpgradeURL: DefaultUpgradeURL,
 	}
 }
+
+// NewGracePeriodError creates a validation error for a resource admitted while
+// an expired license is in its grace period.
+func NewGracePeriodError(graceEndsAt time.Time) *ValidationError {
+	return &ValidationError{
+		Feature: "license_grace_period",
+		Message: fmt.Sprintf("Your Enterprise license has expired. Existing resources keep running until %s, "+
+			"but new or changed resources require a renewed license", graceEndsAt.UTC().Format(time.RFC3339)),
+		UpgradeURL: DefaultUpgradeURL,
+	}
+}
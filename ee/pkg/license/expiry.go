@@ -0,0 +1,116 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package license
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ExpiryWarningWindow is how long before expiry licensed resources start
+// reporting that the license is about to expire.
+const ExpiryWarningWindow = 30 * 24 * time.Hour
+
+// Reasons of the Warning Events and conditions reported as a license expires.
+const (
+	// ReasonLicenseExpiringSoon is reported within ExpiryWarningWindow of expiry.
+	ReasonLicenseExpiringSoon = "LicenseExpiringSoon"
+	// ReasonLicenseGracePeriod is reported while an expired license is in its
+	// grace period.
+	ReasonLicenseGracePeriod = "LicenseGracePeriod"
+)
+
+// Metric names of the license expiry gauges.
+const (
+	metricDaysUntilExpiry = "omnia_license_days_until_expiry"
+	metricGracePeriod     = "omnia_license_grace_period"
+)
+
+// daysUntil returns the days from now until t, negative once t has passed.
+func daysUntil(t, now time.Time) float64 {
+	return t.Sub(now).Hours() / 24
+}
+
+// ExpiryWarning returns the reason and message to report on licensed resources
+// when the license is within ExpiryWarningWindow of expiry or in its grace
+// period, and empty strings otherwise. Only enterprise licenses expire.
+func (l *License) ExpiryWarning(now time.Time) (reason, message string) {
+	if !l.IsEnterprise() {
+		return "", ""
+	}
+	switch {
+	case now.After(l.ExpiresAt) && now.Before(l.GraceEndsAt):
+		return ReasonLicenseGracePeriod, fmt.Sprintf(
+			"Enterprise license %s expired on %s; enterprise resources are read-only until %s, renew at %s",
+			l.ID, l.ExpiresAt.UTC().Format(time.DateOnly), l.GraceEndsAt.UTC().Format(time.RFC3339), DefaultUpgradeURL)
+	case !now.After(l.ExpiresAt) && l.ExpiresAt.Sub(now) <= ExpiryWarningWindow:
+		return ReasonLicenseExpiringSoon, fmt.Sprintf(
+			"Enterprise license %s expires in %d days on %s, renew at %s",
+			l.ID, int(math.Ceil(daysUntil(l.ExpiresAt, now))), l.ExpiresAt.UTC().Format(time.DateOnly), DefaultUpgradeURL)
+	}
+	return "", ""
+}
+
+// ExpiryWarning returns the current license's ExpiryWarning. A dev-mode
+// license never warns.
+func (v *Validator) ExpiryWarning(ctx context.Context) (reason, message string) {
+	lic := v.GetLicenseOrDefault(ctx)
+	if lic.ID == devLicenseID {
+		return "", ""
+	}
+	return lic.ExpiryWarning(time.Now())
+}
+
+// expiryCollector reports the current license's expiry at scrape time, so the
+// gauges stay accurate between reconciles without a polling loop.
+type expiryCollector struct {
+	validator *Validator
+	days      *prometheus.Desc
+	grace     *prometheus.Desc
+}
+
+// RegisterMetrics registers the license expiry gauges with reg:
+// omnia_license_days_until_expiry, negative once the license has expired, and
+// omnia_license_grace_period, 1 while an expired license is in its grace
+// period. Open-core and dev-mode licenses do not expire and report neither.
+func (v *Validator) RegisterMetrics(reg prometheus.Registerer) error {
+	return reg.Register(&expiryCollector{
+		validator: v,
+		days: prometheus.NewDesc(metricDaysUntilExpiry,
+			"Days until the Enterprise license expires; negative once it has expired.",
+			[]string{"license_id"}, nil),
+		grace: prometheus.NewDesc(metricGracePeriod,
+			"1 while an expired Enterprise license is in its read-only grace period, otherwise 0.",
+			[]string{"license_id"}, nil),
+	})
+}
+
+// Describe implements prometheus.Collector.
+func (c *expiryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.days
+	ch <- c.grace
+}
+
+// Collect implements prometheus.Collector.
+func (c *expiryCollector) Collect(ch chan<- prometheus.Metric) {
+	lic := c.validator.GetLicenseOrDefault(context.Background())
+	if !lic.IsEnterprise() || lic.ID == devLicenseID {
+		return
+	}
+	grace := 0.0
+	if lic.InGracePeriod() {
+		grace = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.days, prometheus.GaugeValue, daysUntil(lic.ExpiresAt, time.Now()), lic.ID)
+	ch <- prometheus.MustNewConstMetric(c.grace, prometheus.GaugeValue, grace, lic.ID)
+}
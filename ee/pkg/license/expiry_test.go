@@ -0,0 +1,119 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package license
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// enterpriseValidator returns a validator whose license Secret holds an
+// enterprise license that expires at expiresAt.
+func enterpriseValidator(t *testing.T, expiresAt time.Time) *Validator {
+	t.Helper()
+	privateKey, publicKey := generateTestKeyPair(t)
+	token := createTestToken(t, privateKey, &licenseClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(expiresAt.AddDate(-1, 0, 0)),
+		},
+		LicenseID: "lic-1",
+		Tier:      "enterprise",
+		Features:  Features{GitSource: true, OCISource: true},
+	})
+	return newValidatorWithSecret(t, publicKey, token)
+}
+
+func TestValidator_GracePeriod(t *testing.T) {
+	v := enterpriseValidator(t, time.Now().Add(-48*time.Hour))
+	ctx := context.Background()
+
+	lic, err := v.GetLicense(ctx)
+	require.NoError(t, err)
+	assert.True(t, lic.IsEnterprise(), "an expired license in its grace period is still returned")
+	assert.True(t, lic.InGracePeriod())
+	assert.WithinDuration(t, lic.ExpiresAt.Add(DefaultGracePeriod), lic.GraceEndsAt, time.Second)
+
+	assert.NoError(t, v.ValidateArenaSource(ctx, "oci"), "reconciles of existing resources pass")
+	err = v.ValidateArenaSource(ForAdmission(ctx), "oci")
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr, "admission of new or changed resources fails")
+	assert.Equal(t, "license_grace_period", verr.Feature)
+	assert.Error(t, v.ValidateArenaJob(ForAdmission(ctx), "evaluation", 1, false))
+}
+
+func TestValidator_WithGracePeriod(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+	token := createTestToken(t, privateKey, &licenseClaims{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(-48 * time.Hour))},
+		LicenseID:        "lic-1",
+		Tier:             "enterprise",
+	})
+
+	lic, err := newValidatorWithSecret(t, publicKey, token, WithGracePeriod(0)).GetLicense(context.Background())
+	assert.ErrorIs(t, err, ErrLicenseExpired, "no grace period without one")
+	assert.Equal(t, TierOpenCore, lic.Tier)
+
+	lic, err = newValidatorWithSecret(t, publicKey, token, WithGracePeriod(time.Hour)).GetLicense(context.Background())
+	assert.ErrorIs(t, err, ErrLicenseExpired, "a grace period that has ended")
+	assert.Equal(t, TierOpenCore, lic.Tier)
+}
+
+func TestLicense_ExpiryWarning(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	lic := &License{ID: "lic-1", Tier: TierEnterprise, ExpiresAt: now.AddDate(0, 0, 45)}
+	lic.GraceEndsAt = lic.ExpiresAt.Add(DefaultGracePeriod)
+
+	reason, _ := lic.ExpiryWarning(now)
+	assert.Empty(t, reason, "no warning outside the warning window")
+
+	reason, message := lic.ExpiryWarning(now.AddDate(0, 0, 38))
+	assert.Equal(t, ReasonLicenseExpiringSoon, reason)
+	assert.Contains(t, message, "expires in 7 days on 2026-12-01")
+
+	reason, message = lic.ExpiryWarning(now.AddDate(0, 0, 46))
+	assert.Equal(t, ReasonLicenseGracePeriod, reason)
+	assert.Contains(t, message, "read-only until 2026-12-15T12:00:00Z")
+
+	reason, _ = OpenCoreLicense().ExpiryWarning(now.AddDate(200, 0, 0))
+	assert.Empty(t, reason, "open-core never expires")
+}
+
+func TestValidator_RegisterMetrics(t *testing.T) {
+	v := enterpriseValidator(t, time.Now().Add(10*24*time.Hour))
+	reg := prometheus.NewRegistry()
+	require.NoError(t, v.RegisterMetrics(reg))
+
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+	require.Len(t, mfs, 2)
+	assert.Equal(t, metricDaysUntilExpiry, mfs[0].GetName())
+	assert.InDelta(t, 10, mfs[0].GetMetric()[0].GetGauge().GetValue(), 0.01)
+	expected := `
+# HELP omnia_license_grace_period 1 while an expired Enterprise license is in its read-only grace period, otherwise 0.
+# TYPE omnia_license_grace_period gauge
+omnia_license_grace_period{license_id="lic-1"} 0
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), metricGracePeriod))
+
+	openCore := newValidatorWithSecret(t, nil, "")
+	reg = prometheus.NewRegistry()
+	require.NoError(t, openCore.RegisterMetrics(reg))
+	mfs, err = reg.Gather()
+	require.NoError(t, err)
+	assert.Empty(t, mfs, "open-core licenses do not expire")
+}
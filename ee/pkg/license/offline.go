@@ -0,0 +1,136 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package license
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Offline activation keys.
+const (
+	// ActivationSecretKey is the key within the license Secret holding the
+	// signed offline activation response.
+	ActivationSecretKey = "activation"
+	// ActivationRequestKey is the key within the activation ConfigMap holding
+	// the pending offline activation request.
+	ActivationRequestKey = "request"
+)
+
+// ErrActivationMismatch indicates an offline activation response was not
+// issued for the pending activation request.
+var ErrActivationMismatch = errors.New("offline activation response does not match the activation request")
+
+// OfflineActivationRequest is the challenge a cluster without egress to the
+// license server hands to the licensing portal, out of band, to activate.
+type OfflineActivationRequest struct {
+	// LicenseID is the license being activated.
+	LicenseID string `json:"license_id"`
+	// ClusterFingerprint identifies the cluster being activated.
+	ClusterFingerprint string `json:"cluster_fingerprint"`
+	// ClusterName is an optional user-friendly name for the cluster.
+	ClusterName string `json:"cluster_name,omitempty"`
+	// Version is the Omnia version running on this cluster.
+	Version string `json:"version,omitempty"`
+	// Challenge is a random value the signed response must echo.
+	Challenge string `json:"challenge"`
+	// CreatedAt is when the request was generated.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewOfflineActivationRequest creates an activation request with a fresh
+// random challenge.
+func NewOfflineActivationRequest(req ActivationRequest) (*OfflineActivationRequest, error) {
+	challenge := make([]byte, 16)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, fmt.Errorf("failed to generate activation challenge: %w", err)
+	}
+	return &OfflineActivationRequest{
+		LicenseID:          req.LicenseID,
+		ClusterFingerprint: req.ClusterFingerprint,
+		ClusterName:        req.ClusterName,
+		Version:            req.Version,
+		Challenge:          hex.EncodeToString(challenge),
+		CreatedAt:          time.Now().UTC(),
+	}, nil
+}
+
+// Encode returns the request as the text artifact an operator copies to the
+// licensing portal.
+func (r *OfflineActivationRequest) Encode() (string, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal activation request: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeOfflineActivationRequest parses an artifact produced by Encode.
+func DecodeOfflineActivationRequest(artifact string) (*OfflineActivationRequest, error) {
+	data, err := base64.RawURLEncoding.DecodeString(artifact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode activation request: %w", err)
+	}
+	req := &OfflineActivationRequest{}
+	if err := json.Unmarshal(data, req); err != nil {
+		return nil, fmt.Errorf("failed to parse activation request: %w", err)
+	}
+	return req, nil
+}
+
+// offlineActivationClaims are the JWT claims of an offline activation
+// response, signed with the same key as licenses.
+type offlineActivationClaims struct {
+	jwt.RegisteredClaims
+	LicenseID          string `json:"lid"`
+	ClusterFingerprint string `json:"cfp"`
+	Challenge          string `json:"chl"`
+}
+
+// VerifyOfflineActivation verifies a signed offline activation response
+// against the pending request and returns the activation state to record.
+// Offline activations need no heartbeat.
+func (v *Validator) VerifyOfflineActivation(response string, req *OfflineActivationRequest) (*ActivationState, error) {
+	token, err := jwt.ParseWithClaims(response, &offlineActivationClaims{}, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+		return v.publicKey, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	claims, ok := token.Claims.(*offlineActivationClaims)
+	if !ok || !token.Valid {
+		return nil, ErrLicenseInvalid
+	}
+	if claims.LicenseID != req.LicenseID || claims.ClusterFingerprint != req.ClusterFingerprint ||
+		claims.Challenge != req.Challenge {
+		return nil, ErrActivationMismatch
+	}
+
+	now := time.Now()
+	return &ActivationState{
+		ActivationID:       claims.ID,
+		ClusterFingerprint: claims.ClusterFingerprint,
+		LicenseID:          claims.LicenseID,
+		ActivatedAt:        now,
+		LastHeartbeat:      now,
+		Offline:            true,
+	}, nil
+}
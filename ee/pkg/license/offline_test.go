@@ -0,0 +1,137 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package license
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestOfflineActivationRequest_EncodeDecode(t *testing.T) {
+	req, err := NewOfflineActivationRequest(ActivationRequest{
+		LicenseID: "lic-1", ClusterFingerprint: "fp-1", ClusterName: "prod",
+	})
+	require.NoError(t, err)
+	assert.Len(t, req.Challenge, 32)
+
+	artifact, err := req.Encode()
+	require.NoError(t, err)
+	decoded, err := DecodeOfflineActivationRequest(artifact)
+	require.NoError(t, err)
+	assert.Equal(t, req, decoded)
+
+	_, err = DecodeOfflineActivationRequest("not base64!")
+	assert.Error(t, err)
+}
+
+func TestValidator_VerifyOfflineActivation(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+	v := newValidatorWithSecret(t, publicKey, "")
+	req := &OfflineActivationRequest{LicenseID: "lic-1", ClusterFingerprint: "fp-1", Challenge: "abc"}
+	sign := func(claims *offlineActivationClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(privateKey)
+		require.NoError(t, err)
+		return token
+	}
+	valid := &offlineActivationClaims{
+		RegisteredClaims:   jwt.RegisteredClaims{ID: "act-1"},
+		LicenseID:          "lic-1",
+		ClusterFingerprint: "fp-1",
+		Challenge:          "abc",
+	}
+
+	state, err := v.VerifyOfflineActivation(sign(valid), req)
+	require.NoError(t, err)
+	assert.Equal(t, "act-1", state.ActivationID)
+	assert.True(t, state.Offline)
+	assert.False(t, state.NeedsHeartbeat(0), "offline activations never heartbeat")
+
+	other := *valid
+	other.Challenge = "def"
+	_, err = v.VerifyOfflineActivation(sign(&other), req)
+	assert.ErrorIs(t, err, ErrActivationMismatch, "a response to another request")
+
+	otherKey, _ := generateTestKeyPair(t)
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodRS256, valid).SignedString(otherKey)
+	require.NoError(t, err)
+	_, err = v.VerifyOfflineActivation(forged, req)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestValidator_WithLicenseFile(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+	token := createTestToken(t, privateKey, &licenseClaims{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour))},
+		LicenseID:        "from-file",
+		Tier:             "enterprise",
+	})
+	path := filepath.Join(t.TempDir(), "license")
+	require.NoError(t, os.WriteFile(path, []byte(token+"\n"), 0o600))
+
+	lic, err := newValidatorWithSecret(t, publicKey, "", WithLicenseFile(path)).GetLicense(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", lic.ID)
+
+	missing := filepath.Join(t.TempDir(), "absent")
+	lic, err = newValidatorWithSecret(t, publicKey, "", WithLicenseFile(missing)).GetLicense(context.Background())
+	assert.ErrorIs(t, err, ErrLicenseNotFound, "a missing file falls back to the Secret")
+	assert.Equal(t, TierOpenCore, lic.Tier)
+}
+
+func TestValidator_ClusterBinding(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "kube-system", UID: "kube-system-uid"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: LicenseSecretNamespace, UID: "omnia-system-uid"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespaces[0], namespaces[1]).Build()
+	fingerprint, err := ClusterFingerprint(context.Background(), c)
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		clusters []string
+		wantErr  error
+	}{
+		"bound to this cluster":    {clusters: []string{"other", fingerprint}},
+		"bound to another cluster": {clusters: []string{"other"}, wantErr: ErrClusterMismatch},
+		"unbound":                  {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			token := createTestToken(t, privateKey, &licenseClaims{
+				RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour))},
+				LicenseID:        "lic-1",
+				Tier:             "enterprise",
+				Clusters:         tc.clusters,
+			})
+			v, err := NewValidator(c, WithPublicKey(publicKey), WithNamespace(LicenseSecretNamespace))
+			require.NoError(t, err)
+
+			lic, err := v.validateBound(context.Background(), token)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.clusters, lic.ClusterFingerprints)
+		})
+	}
+}
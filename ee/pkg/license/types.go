@@ -95,6 +95,13 @@ type License struct {
 	IssuedAt time.Time `json:"issuedAt"`
 	// ExpiresAt is when the license expires.
 	ExpiresAt time.Time `json:"expiresAt"`
+	// GraceEndsAt is when the grace period after expiry ends. Between
+	// ExpiresAt and GraceEndsAt the license still covers existing resources
+	// but no new or changed ones; unset for licenses without a grace period.
+	GraceEndsAt time.Time `json:"graceEndsAt,omitzero"`
+	// ClusterFingerprints are the clusters the license is bound to (see
+	// ClusterFingerprint); empty means any cluster.
+	ClusterFingerprints []string `json:"clusterFingerprints,omitempty"`
 }
 
 // OpenCoreLicense returns a default open-core license.
@@ -128,11 +135,14 @@ func OpenCoreLicense() *License {
 	}
 }
 
+// devLicenseID is the ID of the DevLicense.
+const devLicenseID = "dev-mode"
+
 // DevLicense returns a full-featured development license.
 // This should ONLY be used for testing and development, never in production.
 func DevLicense() *License {
 	return &License{
-		ID:       "dev-mode",
+		ID:       devLicenseID,
 		Tier:     TierEnterprise,
 		Customer: "Development Mode",
 		Features: Features{
@@ -163,6 +173,12 @@ func (l *License) IsExpired() bool {
 	return time.Now().After(l.ExpiresAt)
 }
 
+// InGracePeriod returns true if the license has expired but its grace period
+// has not yet ended.
+func (l *License) InGracePeriod() bool {
+	return l.IsExpired() && time.Now().Before(l.GraceEndsAt)
+}
+
 // IsEnterprise returns true if this is an enterprise license.
 func (l *License) IsEnterprise() bool {
 	return l.Tier == TierEnterprise
@@ -261,6 +277,49 @@ func (l *License) CanUseScenarioCount(count int) bool {
 	return count <= l.Limits.MaxScenarios
 }
 
+// The Check methods return the ValidationError for a configuration the license
+// does not allow, or nil. They ignore expiry, which the Validator checks
+// against the admission context; offline tools use them directly.
+
+// CheckSourceType checks that a *Source CRD of the given type is allowed.
+func (l *License) CheckSourceType(sourceType string) error {
+	if !l.CanUseSourceType(sourceType) {
+		return NewSourceTypeError(sourceType)
+	}
+	return nil
+}
+
+// CheckArenaJob checks that an ArenaJob of the given type, worker replicas
+// and scheduling is allowed.
+func (l *License) CheckArenaJob(jobType string, replicas int, hasSchedule bool) error {
+	if !l.CanUseJobType(jobType) {
+		return NewJobTypeError(jobType)
+	}
+	if !l.CanUseWorkerReplicas(replicas) {
+		return NewWorkerReplicasError(replicas, l.Limits.MaxWorkerReplicas)
+	}
+	if hasSchedule && !l.CanUseScheduling() {
+		return NewSchedulingError()
+	}
+	return nil
+}
+
+// CheckCustomFacade checks that "custom" facades are allowed.
+func (l *License) CheckCustomFacade() error {
+	if !l.CanUseCustomFacade() {
+		return NewCustomFacadeError()
+	}
+	return nil
+}
+
+// CheckScenarioCount checks that the given number of scenarios is allowed.
+func (l *License) CheckScenarioCount(count int) error {
+	if !l.CanUseScenarioCount(count) {
+		return NewScenarioCountError(count, l.Limits.MaxScenarios)
+	}
+	return nil
+}
+
 // ActivationState represents the activation status stored in a ConfigMap.
 // This persists the activation state to survive pod restarts.
 type ActivationState struct {
@@ -276,11 +335,15 @@ type ActivationState struct {
 	LastHeartbeat time.Time `json:"last_heartbeat"`
 	// HeartbeatFailures tracks consecutive heartbeat failures for grace period.
 	HeartbeatFailures int `json:"heartbeat_failures,omitempty"`
+	// Offline is true for an activation completed with a signed offline
+	// activation response rather than the license server.
+	Offline bool `json:"offline,omitempty"`
 }
 
-// NeedsHeartbeat returns true if a heartbeat should be sent.
+// NeedsHeartbeat returns true if a heartbeat should be sent. Offline
+// activations never send one.
 func (s *ActivationState) NeedsHeartbeat(interval time.Duration) bool {
-	return time.Since(s.LastHeartbeat) >= interval
+	return !s.Offline && time.Since(s.LastHeartbeat) >= interval
 }
 
 // IsInGracePeriod returns true if the activation is within the heartbeat grace period.
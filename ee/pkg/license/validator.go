@@ -16,6 +16,10 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -51,6 +55,11 @@ const (
 // Default cache TTL.
 const DefaultCacheTTL = 5 * time.Minute
 
+// DefaultGracePeriod is how long an expired license keeps covering existing
+// resources, so that a lapsed renewal degrades to read-only enforcement rather
+// than failing every reconcile the moment the license expires.
+const DefaultGracePeriod = 14 * 24 * time.Hour
+
 // Validator validates licenses using RS256 JWT tokens.
 type Validator struct {
 	client    client.Client
@@ -60,7 +69,13 @@ type Validator struct {
 	cacheTTL  time.Duration
 	devMode   bool   // When true, returns a full-featured dev license
 	namespace string // Namespace of the license Secret and public-key ConfigMap.
-	mu        sync.RWMutex
+	// gracePeriod is how long after expiry a license is still accepted.
+	gracePeriod time.Duration
+	// licenseFile is a mounted license file read in place of the Secret.
+	licenseFile string
+	// fingerprint caches this cluster's fingerprint for cluster-bound licenses.
+	fingerprint string
+	mu          sync.RWMutex
 }
 
 // ValidatorOption configures the Validator.
@@ -97,13 +112,32 @@ func WithNamespace(namespace string) ValidatorOption {
 	}
 }
 
+// WithGracePeriod sets how long an expired license keeps covering existing
+// resources (see DefaultGracePeriod). Zero disables the grace period.
+func WithGracePeriod(d time.Duration) ValidatorOption {
+	return func(v *Validator) {
+		v.gracePeriod = d
+	}
+}
+
+// WithLicenseFile reads the license JWT from a mounted file, such as one
+// provisioned by a CSI secrets driver on a cluster without a license Secret.
+// The file is re-read on every cache refresh; when it does not exist the
+// license Secret is used instead.
+func WithLicenseFile(path string) ValidatorOption {
+	return func(v *Validator) {
+		v.licenseFile = path
+	}
+}
+
 // NewValidator creates a new license validator.
 // It first checks for a public key in the ConfigMap (for easy rotation),
 // then falls back to the embedded public key.
 func NewValidator(c client.Client, opts ...ValidatorOption) (*Validator, error) {
 	v := &Validator{
-		client:   c,
-		cacheTTL: DefaultCacheTTL,
+		client:      c,
+		cacheTTL:    DefaultCacheTTL,
+		gracePeriod: DefaultGracePeriod,
 	}
 
 	for _, opt := range opts {
@@ -253,8 +287,18 @@ func (v *Validator) InvalidateCache() {
 	v.mu.Unlock()
 }
 
-// fetchAndValidate fetches the license Secret and validates the JWT.
+// fetchAndValidate fetches the license file or Secret and validates the JWT.
 func (v *Validator) fetchAndValidate(ctx context.Context) (*License, error) {
+	if v.licenseFile != "" {
+		data, err := os.ReadFile(v.licenseFile)
+		switch {
+		case err == nil:
+			return v.validateBound(ctx, strings.TrimSpace(string(data)))
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, fmt.Errorf("failed to read license file: %w", err)
+		}
+	}
+
 	// No client means there is no Secret to read (e.g. a dev-mode validator
 	// constructed without one). Treat it as "not found" so callers fall back to
 	// the dev license or open-core rather than dereferencing a nil client.
@@ -279,7 +323,45 @@ func (v *Validator) fetchAndValidate(ctx context.Context) (*License, error) {
 		return nil, fmt.Errorf("license secret missing '%s' key", LicenseSecretKey)
 	}
 
-	return v.validateToken(string(tokenData))
+	return v.validateBound(ctx, string(tokenData))
+}
+
+// validateBound validates a JWT token and, for a license bound to clusters,
+// checks that this cluster is one of them.
+func (v *Validator) validateBound(ctx context.Context, tokenString string) (*License, error) {
+	license, err := v.validateToken(tokenString)
+	if err != nil || len(license.ClusterFingerprints) == 0 {
+		return license, err
+	}
+	fingerprint, err := v.clusterFingerprint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrClusterMismatch, err)
+	}
+	if !slices.Contains(license.ClusterFingerprints, fingerprint) {
+		return nil, fmt.Errorf("%w: cluster fingerprint %s", ErrClusterMismatch, fingerprint)
+	}
+	return license, nil
+}
+
+// clusterFingerprint returns this cluster's fingerprint, computed once.
+func (v *Validator) clusterFingerprint(ctx context.Context) (string, error) {
+	v.mu.RLock()
+	fingerprint := v.fingerprint
+	v.mu.RUnlock()
+	if fingerprint != "" {
+		return fingerprint, nil
+	}
+	if v.client == nil {
+		return "", errors.New("no client to compute the cluster fingerprint")
+	}
+	fingerprint, err := ClusterFingerprint(ctx, v.client)
+	if err != nil {
+		return "", err
+	}
+	v.mu.Lock()
+	v.fingerprint = fingerprint
+	v.mu.Unlock()
+	return fingerprint, nil
 }
 
 // licenseClaims represents the JWT claims for a license.
@@ -290,9 +372,14 @@ type licenseClaims struct {
 	Customer  string   `json:"customer"`
 	Features  Features `json:"features"`
 	Limits    Limits   `json:"limits"`
+	// Clusters binds the license to the fingerprints of the clusters it was
+	// issued for; empty means any cluster.
+	Clusters []string `json:"clusters,omitempty"`
 }
 
-// validateToken validates a JWT token and returns the license.
+// validateToken validates a JWT token and returns the license. A license that
+// expired less than the grace period ago is still returned, with GraceEndsAt
+// set, so callers can enforce it read-only.
 func (v *Validator) validateToken(tokenString string) (*License, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &licenseClaims{}, func(token *jwt.Token) (any, error) {
 		// Ensure the signing method is RS256
@@ -300,7 +387,7 @@ func (v *Validator) validateToken(tokenString string) (*License, error) {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return v.publicKey, nil
-	})
+	}, jwt.WithLeeway(v.gracePeriod))
 	if err != nil {
 		// Check if the error is due to token expiration
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -333,10 +420,15 @@ func (v *Validator) validateToken(tokenString string) (*License, error) {
 		Limits:    claims.Limits,
 		IssuedAt:  issuedAt,
 		ExpiresAt: expiresAt,
+
+		ClusterFingerprints: claims.Clusters,
+	}
+	if v.gracePeriod > 0 {
+		license.GraceEndsAt = expiresAt.Add(v.gracePeriod)
 	}
 
 	// Double-check expiration (in case JWT library didn't catch it)
-	if license.IsExpired() {
+	if license.IsExpired() && !license.InGracePeriod() {
 		return nil, ErrLicenseExpired
 	}
 
@@ -363,18 +455,42 @@ func parsePublicKey(pemData []byte) (*rsa.PublicKey, error) {
 	return rsaKey, nil
 }
 
-// validateSourceType is the shared license gate for any *Source CRD's source type.
-func (v *Validator) validateSourceType(ctx context.Context, sourceType string) error {
-	lic := v.GetLicenseOrDefault(ctx)
-	if lic.IsExpired() {
+// admissionKey is the context key marking a license check made while admitting
+// a resource rather than reconciling one.
+type admissionKey struct{}
+
+// ForAdmission returns ctx marked as admitting a created or changed resource.
+// During the grace period after expiry such checks fail, while checks made by
+// reconcilers of existing resources still pass.
+func ForAdmission(ctx context.Context) context.Context {
+	return context.WithValue(ctx, admissionKey{}, true)
+}
+
+// checkExpiry is the shared expiry gate of the Validate methods: a license in
+// its grace period is read-only, covering existing resources but refusing
+// admission of new or changed ones.
+func checkExpiry(ctx context.Context, lic *License) error {
+	if !lic.IsExpired() {
+		return nil
+	}
+	if !lic.InGracePeriod() {
 		return NewLicenseExpiredError()
 	}
-	if !lic.CanUseSourceType(sourceType) {
-		return NewSourceTypeError(sourceType)
+	if admitting, _ := ctx.Value(admissionKey{}).(bool); admitting {
+		return NewGracePeriodError(lic.GraceEndsAt)
 	}
 	return nil
 }
 
+// validateSourceType is the shared license gate for any *Source CRD's source type.
+func (v *Validator) validateSourceType(ctx context.Context, sourceType string) error {
+	lic := v.GetLicenseOrDefault(ctx)
+	if err := checkExpiry(ctx, lic); err != nil {
+		return err
+	}
+	return lic.CheckSourceType(sourceType)
+}
+
 // ValidateArenaSource validates that the source type is allowed by the license.
 func (v *Validator) ValidateArenaSource(ctx context.Context, sourceType string) error {
 	return v.validateSourceType(ctx, sourceType)
@@ -389,23 +505,11 @@ func (v *Validator) ValidatePromptPackSource(ctx context.Context, sourceType str
 func (v *Validator) ValidateArenaJob(ctx context.Context, jobType string, replicas int, hasSchedule bool) error {
 	license := v.GetLicenseOrDefault(ctx)
 
-	if license.IsExpired() {
-		return NewLicenseExpiredError()
-	}
-
-	if !license.CanUseJobType(jobType) {
-		return NewJobTypeError(jobType)
-	}
-
-	if !license.CanUseWorkerReplicas(replicas) {
-		return NewWorkerReplicasError(replicas, license.Limits.MaxWorkerReplicas)
-	}
-
-	if hasSchedule && !license.CanUseScheduling() {
-		return NewSchedulingError()
+	if err := checkExpiry(ctx, license); err != nil {
+		return err
 	}
 
-	return nil
+	return license.CheckArenaJob(jobType, replicas, hasSchedule)
 }
 
 // ValidateCustomFacade validates that "custom" (bring-your-own-container)
@@ -414,28 +518,20 @@ func (v *Validator) ValidateArenaJob(ctx context.Context, jobType string, replic
 func (v *Validator) ValidateCustomFacade(ctx context.Context) error {
 	license := v.GetLicenseOrDefault(ctx)
 
-	if license.IsExpired() {
-		return NewLicenseExpiredError()
-	}
-
-	if !license.CanUseCustomFacade() {
-		return NewCustomFacadeError()
+	if err := checkExpiry(ctx, license); err != nil {
+		return err
 	}
 
-	return nil
+	return license.CheckCustomFacade()
 }
 
 // ValidateScenarioCount validates that the scenario count is allowed by the license.
 func (v *Validator) ValidateScenarioCount(ctx context.Context, count int) error {
 	license := v.GetLicenseOrDefault(ctx)
 
-	if license.IsExpired() {
-		return NewLicenseExpiredError()
-	}
-
-	if !license.CanUseScenarioCount(count) {
-		return NewScenarioCountError(count, license.Limits.MaxScenarios)
+	if err := checkExpiry(ctx, license); err != nil {
+		return err
 	}
 
-	return nil
+	return license.CheckScenarioCount(count)
 }
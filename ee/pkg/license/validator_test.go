@@ -483,6 +483,36 @@ func TestLicense_CanUseScenarioCount(t *testing.T) {
 	}
 }
 
+func TestLicense_CheckMethods(t *testing.T) {
+	openCore, dev := OpenCoreLicense(), DevLicense()
+
+	tests := []struct {
+		name    string
+		err     error
+		feature string
+	}{
+		{"oci source", openCore.CheckSourceType("oci"), "source_type_oci"},
+		{"git source", openCore.CheckSourceType("git"), ""},
+		{"loadtest job", openCore.CheckArenaJob("loadtest", 1, false), "job_type_loadtest"},
+		{"worker replicas", openCore.CheckArenaJob("evaluation", 3, false), "worker_replicas"},
+		{"scheduled job", openCore.CheckArenaJob("evaluation", 1, true), "scheduling"},
+		{"custom facade", openCore.CheckCustomFacade(), "custom_facade"},
+		{"scenario count", openCore.CheckScenarioCount(11), "scenario_count"},
+		{"dev license job", dev.CheckArenaJob("loadtest", 8, true), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.feature == "" {
+				assert.NoError(t, tt.err)
+				return
+			}
+			var licErr *ValidationError
+			require.ErrorAs(t, tt.err, &licErr)
+			assert.Equal(t, tt.feature, licErr.Feature)
+		})
+	}
+}
+
 func TestValidator_GetLicense_CachedWithinTTL(t *testing.T) {
 	privateKey, publicKey := generateTestKeyPair(t)
 
@@ -646,8 +676,8 @@ func TestValidator_validateToken_ExpiredToken(t *testing.T) {
 
 	claims := &licenseClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-24 * time.Hour)), // Expired
-			IssuedAt:  jwt.NewNumericDate(time.Now().Add(-48 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-DefaultGracePeriod - 24*time.Hour)), // Expired past grace
+			IssuedAt:  jwt.NewNumericDate(time.Now().Add(-DefaultGracePeriod - 48*time.Hour)),
 		},
 		LicenseID: "test-123",
 		Tier:      "enterprise",
@@ -884,12 +914,12 @@ func TestValidator_ValidateArenaSource(t *testing.T) {
 	})
 
 	t.Run("expired license falls back to open-core", func(t *testing.T) {
-		// When a JWT license expires, the validator falls back to open-core
+		// When a JWT license's grace period ends, the validator falls back to open-core
 		// Open-core allows configmap and git, but not OCI or S3
 		claims := &licenseClaims{
 			RegisteredClaims: jwt.RegisteredClaims{
-				ExpiresAt: jwt.NewNumericDate(time.Now().Add(-24 * time.Hour)),
-				IssuedAt:  jwt.NewNumericDate(time.Now().Add(-48 * time.Hour)),
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(-DefaultGracePeriod - 24*time.Hour)),
+				IssuedAt:  jwt.NewNumericDate(time.Now().Add(-DefaultGracePeriod - 48*time.Hour)),
 			},
 			LicenseID: "test-123",
 			Tier:      "enterprise",
@@ -977,7 +1007,7 @@ func TestValidatePromptPackSource(t *testing.T) {
 	})
 
 	t.Run("expired license returns expired error", func(t *testing.T) {
-		token := enterpriseToken(Features{GitSource: true, OCISource: true}, time.Now().Add(-24*time.Hour))
+		token := enterpriseToken(Features{GitSource: true, OCISource: true}, time.Now().Add(-DefaultGracePeriod-24*time.Hour))
 		validator := newValidatorWithSecret(t, publicKey, token)
 
 		// Expired license falls back to open-core, which allows git — so use
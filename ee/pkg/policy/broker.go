@@ -231,6 +231,7 @@ func writeDecisionResponse(w http.ResponseWriter, decision Decision, injected ma
 		Message:         decision.Message,
 		Mode:            string(decision.Mode),
 		WouldDeny:       decision.WouldDeny,
+		Policy:          decision.Policy,
 		InjectedHeaders: injected,
 	}
 	w.Header().Set(headerContentType, contentTypeJSON)
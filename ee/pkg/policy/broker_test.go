@@ -236,6 +236,9 @@ func TestBrokerHandler_AuditMode(t *testing.T) {
 	if resp.Mode != string(omniav1alpha1.PolicyModeAudit) {
 		t.Errorf("Mode = %q, want %q", resp.Mode, omniav1alpha1.PolicyModeAudit)
 	}
+	if resp.Policy != "audit-policy" {
+		t.Errorf("Policy = %q, want %q", resp.Policy, "audit-policy")
+	}
 }
 
 func TestBrokerHandler_HeaderInjection(t *testing.T) {
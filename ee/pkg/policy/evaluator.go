@@ -11,6 +11,7 @@ package policy
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sync"
 
 	"github.com/google/cel-go/cel"
@@ -45,7 +46,7 @@ type Decision struct {
 	// Mode is the policy mode that produced this decision.
 	Mode omniav1alpha1.PolicyMode
 	// WouldDeny indicates whether the request would have been denied in enforce mode.
-	// This is true when the policy is in audit mode and a rule matched.
+	// This is true when the policy is in shadow (audit) mode and a rule matched.
 	WouldDeny bool
 	// Policy is the name of the policy that produced this decision.
 	Policy string
@@ -71,17 +72,21 @@ type CompiledPolicy struct {
 	Namespace       string
 	Selector        omniav1alpha1.ToolPolicySelector
 	Rules           []CompiledRule
+	OPA             *opaQuery // set when the policy's engine is opa; Rules is then empty
 	HeaderInjection []CompiledHeaderInjection
 	RequiredClaims  []omniav1alpha1.RequiredClaim
 	Mode            omniav1alpha1.PolicyMode
 	OnFailure       omniav1alpha1.OnFailureAction
 }
 
-// Evaluator compiles and evaluates CEL-based ToolPolicy rules.
+// Evaluator compiles and evaluates ToolPolicy rules: CEL rules in process,
+// and OPA policies by querying the OPA server they name.
 type Evaluator struct {
 	mu       sync.RWMutex
 	env      *cel.Env
 	policies map[string]*CompiledPolicy // key: namespace/name
+	opa      *http.Client
+	opaLog   *OPADecisionLogger
 }
 
 // NewEvaluator creates a new Evaluator with a shared CEL environment.
@@ -93,6 +98,7 @@ func NewEvaluator() (*Evaluator, error) {
 	return &Evaluator{
 		env:      env,
 		policies: make(map[string]*CompiledPolicy),
+		opa:      &http.Client{},
 	}, nil
 }
 
@@ -139,16 +145,15 @@ func (e *Evaluator) compileRules(policy *omniav1alpha1.ToolPolicy) (*CompiledPol
 		Rules:          make([]CompiledRule, 0, len(policy.Spec.Rules)),
 	}
 
-	for _, rule := range policy.Spec.Rules {
-		program, err := compileCEL(e.env, rule.Deny.CEL)
+	if policy.Spec.Engine == omniav1alpha1.PolicyEngineOPA {
+		// Rules are ignored with the opa engine; OPA makes the decision.
+		query, err := newOPAQuery(policy.Spec.OPA, e.opa)
 		if err != nil {
-			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+			return nil, err
 		}
-		compiled.Rules = append(compiled.Rules, CompiledRule{
-			Name:    rule.Name,
-			Program: program,
-			Message: rule.Deny.Message,
-		})
+		compiled.OPA = query
+	} else if err := e.compileDenyRules(policy.Spec.Rules, compiled); err != nil {
+		return nil, err
 	}
 
 	injections, err := e.compileHeaderInjection(policy.Spec.HeaderInjection)
@@ -160,6 +165,22 @@ func (e *Evaluator) compileRules(policy *omniav1alpha1.ToolPolicy) (*CompiledPol
 	return compiled, nil
 }
 
+// compileDenyRules compiles the CEL deny rules of a policy into compiled.
+func (e *Evaluator) compileDenyRules(rules []omniav1alpha1.PolicyRule, compiled *CompiledPolicy) error {
+	for _, rule := range rules {
+		program, err := compileCEL(e.env, rule.Deny.CEL)
+		if err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		compiled.Rules = append(compiled.Rules, CompiledRule{
+			Name:    rule.Name,
+			Program: program,
+			Message: rule.Deny.Message,
+		})
+	}
+	return nil
+}
+
 // compileHeaderInjection compiles header injection rules.
 func (e *Evaluator) compileHeaderInjection(
 	rules []omniav1alpha1.HeaderInjectionRule,
@@ -237,7 +258,7 @@ func (e *Evaluator) RemovePolicy(namespace, name string) {
 
 // Evaluate evaluates all matching policies against the given context.
 // It returns a Decision indicating whether the request should be allowed.
-// In audit mode, the decision will be Allowed=true but DeniedBy will be set
+// In shadow mode, the decision will be Allowed=true but DeniedBy will be set
 // to indicate which rule would have denied the request.
 //
 // This form does not consult request context, so the `identity` CEL root is
@@ -265,11 +286,11 @@ func (e *Evaluator) EvaluateWithContext(
 
 	var auditDecision *Decision
 	for _, p := range matching {
-		decision := e.evaluatePolicy(p, headers, body, identity)
+		decision := e.evaluatePolicy(ctx, p, headers, body, identity)
 		if !decision.Allowed {
 			return decision
 		}
-		// Track the first audit-mode denial for reporting
+		// Track the first shadow-mode denial for reporting
 		if auditDecision == nil && decision.DeniedBy != "" {
 			d := decision
 			auditDecision = &d
@@ -382,6 +403,7 @@ func matchesSelector(selector omniav1alpha1.ToolPolicySelector, registry, tool s
 
 // evaluatePolicy evaluates a single compiled policy against the given context.
 func (e *Evaluator) evaluatePolicy(
+	ctx context.Context,
 	policy *CompiledPolicy,
 	headers map[string]string,
 	body map[string]interface{},
@@ -392,8 +414,16 @@ func (e *Evaluator) evaluatePolicy(
 		return applyMode(policy, decision)
 	}
 
-	// Evaluate CEL rules
 	activation := buildActivation(headers, body, identity)
+	if policy.OPA != nil {
+		decision := e.evaluateOPA(ctx, policy, activation)
+		if !decision.Allowed {
+			return applyMode(policy, decision)
+		}
+		return decision
+	}
+
+	// Evaluate CEL rules
 	for _, rule := range policy.Rules {
 		decision := evaluateRule(rule, activation, policy.OnFailure)
 		if !decision.Allowed {
@@ -541,11 +571,12 @@ func handleEvalError(ruleName string, err error, onFailure omniav1alpha1.OnFailu
 }
 
 // applyMode adjusts the decision based on the policy mode.
-// In audit mode, denials are converted to allow but the decision info is preserved.
+// In shadow (audit) mode, denials are converted to allow but the decision info
+// is preserved.
 func applyMode(policy *CompiledPolicy, decision Decision) Decision {
 	decision.Mode = policy.Mode
 	decision.Policy = policy.Name
-	if policy.Mode == omniav1alpha1.PolicyModeAudit {
+	if !policy.Mode.Enforces() {
 		decision.WouldDeny = true
 		decision.Allowed = true
 	}
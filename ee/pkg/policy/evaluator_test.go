@@ -370,6 +370,47 @@ func TestEvaluate_AuditModeAllowsDenied(t *testing.T) {
 	}
 }
 
+func TestEvaluate_ShadowModeDoesNotHideEnforcedDenial(t *testing.T) {
+	eval, err := NewEvaluator()
+	if err != nil {
+		t.Fatalf("NewEvaluator() error = %v", err)
+	}
+
+	shadow := newTestPolicy("new-limits", []omniav1alpha1.PolicyRule{
+		{Name: "strict-amount", Deny: omniav1alpha1.PolicyRuleDeny{CEL: "double(body.amount) > 100.0", Message: "strict"}},
+	})
+	shadow.Spec.Mode = omniav1alpha1.PolicyModeShadow
+	enforced := newTestPolicy("limits", []omniav1alpha1.PolicyRule{
+		{Name: "max-amount", Deny: omniav1alpha1.PolicyRuleDeny{CEL: "double(body.amount) > 500.0", Message: "too much"}},
+	})
+	for _, p := range []*omniav1alpha1.ToolPolicy{shadow, enforced} {
+		if err := eval.CompilePolicy(p); err != nil {
+			t.Fatalf("CompilePolicy(%s) error = %v", p.Name, err)
+		}
+	}
+
+	headers := map[string]string{
+		HeaderToolName:     "process_refund",
+		HeaderToolRegistry: "customer-tools",
+	}
+
+	decision := eval.Evaluate(headers, map[string]interface{}{"amount": 200.0})
+	if !decision.Allowed || !decision.WouldDeny {
+		t.Errorf("Allowed = %v, WouldDeny = %v, want both true for a shadow-only match", decision.Allowed, decision.WouldDeny)
+	}
+	if decision.Policy != "new-limits" || decision.Mode != omniav1alpha1.PolicyModeShadow {
+		t.Errorf("Policy = %q, Mode = %q, want new-limits, shadow", decision.Policy, decision.Mode)
+	}
+
+	decision = eval.Evaluate(headers, map[string]interface{}{"amount": 900.0})
+	if decision.Allowed {
+		t.Error("Allowed = true, want the enforced policy to deny despite the shadow match")
+	}
+	if decision.Policy != "limits" {
+		t.Errorf("Policy = %q, want %q", decision.Policy, "limits")
+	}
+}
+
 func TestEvaluate_AuditModeRequiredClaimsMissing(t *testing.T) {
 	eval, err := NewEvaluator()
 	if err != nil {
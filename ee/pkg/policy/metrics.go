@@ -102,7 +102,7 @@ func (m *Metrics) SetActivePolicies(count int) {
 
 // decisionOutcome classifies a Decision into the outcome label values the
 // DecisionsTotal counter uses: "denied" when a rule actually blocked the
-// call, "would_deny" when an audit-mode policy would have blocked it, and
+// call, "would_deny" when a shadow-mode policy would have blocked it, and
 // "allowed" otherwise.
 func decisionOutcome(decision Decision) string {
 	if decision.Error != nil {
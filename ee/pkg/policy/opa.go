@@ -0,0 +1,270 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+)
+
+const (
+	// defaultOPATimeout bounds an OPA query when the policy sets no timeout.
+	defaultOPATimeout = 2 * time.Second
+	// maxOPAResponseBytes bounds the OPA response body the broker reads.
+	maxOPAResponseBytes = 1 << 20 // 1 MiB
+	// opaRuleName is the DeniedBy prefix of decisions an OPA policy makes;
+	// the decision path follows it.
+	opaRuleName = "opa:"
+)
+
+// opaQuery queries an OPA server's Data API for one policy's decision.
+type opaQuery struct {
+	endpoint string // <url>/v1/data/<path>
+	path     string
+	timeout  time.Duration
+	client   *http.Client
+}
+
+// newOPAQuery validates cfg and returns the query it describes.
+func newOPAQuery(cfg *omniav1alpha1.OPAPolicyConfig, client *http.Client) (*opaQuery, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("opa: configuration is required when engine is opa")
+	}
+	base, err := url.Parse(cfg.URL)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("opa: url %q must be an absolute http(s) URL", cfg.URL)
+	}
+	path := strings.Trim(cfg.Path, "/")
+	if path == "" {
+		return nil, fmt.Errorf("opa: path is required")
+	}
+	timeout := defaultOPATimeout
+	if cfg.Timeout != "" {
+		timeout, err = time.ParseDuration(cfg.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("opa: timeout %q must be a positive duration", cfg.Timeout)
+		}
+	}
+	return &opaQuery{
+		endpoint: strings.TrimRight(base.String(), "/") + "/v1/data/" + path,
+		path:     path,
+		timeout:  timeout,
+		client:   client,
+	}, nil
+}
+
+// opaResult is the outcome of one OPA query.
+type opaResult struct {
+	// Allowed and Message are read from the decision document.
+	Allowed bool
+	Message string
+	// Result is the decision document as OPA returned it.
+	Result interface{}
+	// DecisionID is OPA's ID for the decision when its decision logging is
+	// on, or one generated here, so both sides' logs can be joined.
+	DecisionID string
+}
+
+// opaDataResponse is the body of an OPA Data API response.
+type opaDataResponse struct {
+	Result     *json.RawMessage `json:"result"`
+	DecisionID string           `json:"decision_id"`
+}
+
+// opaDecisionDocument is the object form of a decision document.
+type opaDecisionDocument struct {
+	Allow   *bool  `json:"allow"`
+	Message string `json:"message"`
+}
+
+// decide queries OPA with input. It fails when OPA cannot be reached, does
+// not answer 200, or returns a document that is undefined or not a decision.
+func (q *opaQuery) decide(ctx context.Context, input map[string]interface{}) (opaResult, error) {
+	res := opaResult{}
+	payload, err := json.Marshal(map[string]interface{}{"input": input})
+	if err != nil {
+		return res, fmt.Errorf("opa: encode input: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, q.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, q.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return res, fmt.Errorf("opa: build request: %w", err)
+	}
+	req.Header.Set(headerContentType, contentTypeJSON)
+	resp, err := q.client.Do(req)
+	if err != nil {
+		return res, fmt.Errorf("opa: query %s: %w", q.path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOPAResponseBytes))
+	if err != nil {
+		return res, fmt.Errorf("opa: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return res, fmt.Errorf("opa: query %s: status %d", q.path, resp.StatusCode)
+	}
+	var data opaDataResponse
+	if err := json.Unmarshal(body, &data); err != nil {
+		return res, fmt.Errorf("opa: decode response: %w", err)
+	}
+	res.DecisionID = data.DecisionID
+	if res.DecisionID == "" {
+		res.DecisionID = uuid.NewString()
+	}
+	if data.Result == nil {
+		return res, fmt.Errorf("opa: decision %s is undefined", q.path)
+	}
+	_ = json.Unmarshal(*data.Result, &res.Result)
+	return res, readOPADecision(*data.Result, &res)
+}
+
+// readOPADecision reads a decision document — true/false, or an object with
+// a boolean allow field — into res.
+func readOPADecision(doc json.RawMessage, res *opaResult) error {
+	var allowed bool
+	if err := json.Unmarshal(doc, &allowed); err == nil {
+		res.Allowed = allowed
+		return nil
+	}
+	var obj opaDecisionDocument
+	if err := json.Unmarshal(doc, &obj); err != nil || obj.Allow == nil {
+		return fmt.Errorf("opa: decision must be a boolean or an object with a boolean allow field")
+	}
+	res.Allowed = *obj.Allow
+	res.Message = obj.Message
+	return nil
+}
+
+// evaluateOPA decides a policy with the OPA engine. A failed query is handled
+// as a failed CEL rule is (see handleEvalError). Every query is written to
+// the evaluator's OPA decision log.
+func (e *Evaluator) evaluateOPA(
+	ctx context.Context,
+	policy *CompiledPolicy,
+	activation map[string]interface{},
+) Decision {
+	start := time.Now()
+	res, err := policy.OPA.decide(ctx, activation)
+	e.logOPADecision(policy, activation, res, err, time.Since(start))
+
+	ruleName := opaRuleName + policy.OPA.path
+	if err != nil {
+		return handleEvalError(ruleName, err, policy.OnFailure)
+	}
+	if res.Allowed {
+		return Decision{Allowed: true}
+	}
+	message := res.Message
+	if message == "" {
+		message = "denied by OPA policy " + policy.OPA.path
+	}
+	return Decision{Allowed: false, DeniedBy: ruleName, Message: message}
+}
+
+// OPADecisionEvent is a decision log event in OPA's decision log format, so
+// tools that consume OPA's own decision logs read the broker's unchanged.
+type OPADecisionEvent struct {
+	Labels     map[string]string      `json:"labels"`
+	DecisionID string                 `json:"decision_id"`
+	Path       string                 `json:"path"`
+	Input      map[string]interface{} `json:"input"`
+	Result     interface{}            `json:"result,omitempty"`
+	Error      *OPADecisionError      `json:"error,omitempty"`
+	Timestamp  time.Time              `json:"timestamp"`
+	Metrics    map[string]int64       `json:"metrics"`
+}
+
+// OPADecisionError describes a query that produced no decision.
+type OPADecisionError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// OPADecisionLogger writes OPA decision log events as JSON lines.
+type OPADecisionLogger struct {
+	mu     sync.Mutex
+	w      io.Writer
+	labels map[string]string
+}
+
+// NewOPADecisionLogger returns a logger writing events to w. labels are
+// stamped on every event, as OPA stamps its instance labels (id, version).
+func NewOPADecisionLogger(w io.Writer, labels map[string]string) *OPADecisionLogger {
+	return &OPADecisionLogger{w: w, labels: labels}
+}
+
+// Log writes one event.
+func (l *OPADecisionLogger) Log(event OPADecisionEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(append(line, '\n'))
+	return err
+}
+
+// SetOPADecisionLogger attaches the logger OPA decisions are written to.
+// Nil-safe: with none attached, OPA decisions are not logged.
+func (e *Evaluator) SetOPADecisionLogger(l *OPADecisionLogger) {
+	e.mu.Lock()
+	e.opaLog = l
+	e.mu.Unlock()
+}
+
+// logOPADecision writes the decision log event for one OPA query.
+func (e *Evaluator) logOPADecision(
+	policy *CompiledPolicy,
+	input map[string]interface{},
+	res opaResult,
+	queryErr error,
+	elapsed time.Duration,
+) {
+	e.mu.RLock()
+	l := e.opaLog
+	e.mu.RUnlock()
+	if l == nil {
+		return
+	}
+	labels := make(map[string]string, len(l.labels)+1)
+	for k, v := range l.labels {
+		labels[k] = v
+	}
+	labels["policy"] = policyKey(policy.Namespace, policy.Name)
+	event := OPADecisionEvent{
+		Labels:     labels,
+		DecisionID: res.DecisionID,
+		Path:       policy.OPA.path,
+		Input:      input,
+		Result:     res.Result,
+		Timestamp:  time.Now().UTC(),
+		Metrics:    map[string]int64{"timer_server_handler_ns": elapsed.Nanoseconds()},
+	}
+	if event.DecisionID == "" {
+		event.DecisionID = uuid.NewString()
+	}
+	if queryErr != nil {
+		event.Error = &OPADecisionError{Code: "evaluation_error", Message: queryErr.Error()}
+	}
+	_ = l.Log(event)
+}
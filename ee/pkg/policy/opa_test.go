@@ -0,0 +1,237 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	omniapolicy "github.com/altairalabs/omnia/pkg/policy"
+)
+
+// fakeOPA serves the OPA Data API for one document, answering each query
+// with decide(input). It records the last input it was sent.
+type fakeOPA struct {
+	decide    func(input map[string]interface{}) string
+	lastInput map[string]interface{}
+}
+
+func (f *fakeOPA) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/v1/data/omnia/tools/decision" {
+		http.NotFound(w, r)
+		return
+	}
+	var req struct {
+		Input map[string]interface{} `json:"input"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	f.lastInput = req.Input
+	_, _ = w.Write([]byte(f.decide(req.Input)))
+}
+
+func newOPAPolicy(url string) *omniav1alpha1.ToolPolicy {
+	tp := newTestPolicy("opa-policy", nil)
+	tp.Spec.Engine = omniav1alpha1.PolicyEngineOPA
+	tp.Spec.OPA = &omniav1alpha1.OPAPolicyConfig{URL: url, Path: "/omnia/tools/decision"}
+	return tp
+}
+
+func newOPAEvaluator(t *testing.T, opa *fakeOPA, mutate func(*omniav1alpha1.ToolPolicy)) (*Evaluator, *bytes.Buffer) {
+	t.Helper()
+	srv := httptest.NewServer(opa)
+	t.Cleanup(srv.Close)
+	eval, err := NewEvaluator()
+	if err != nil {
+		t.Fatalf("NewEvaluator() error = %v", err)
+	}
+	var logs bytes.Buffer
+	eval.SetOPADecisionLogger(NewOPADecisionLogger(&logs, map[string]string{"id": "broker-0"}))
+	tp := newOPAPolicy(srv.URL)
+	if mutate != nil {
+		mutate(tp)
+	}
+	if err := eval.CompilePolicy(tp); err != nil {
+		t.Fatalf("CompilePolicy() error = %v", err)
+	}
+	return eval, &logs
+}
+
+func refundHeaders(amount string) map[string]string {
+	return map[string]string{
+		HeaderToolRegistry:            "customer-tools",
+		HeaderToolName:                "process_refund",
+		"X-Omnia-Param-Amount":        amount,
+		"X-Omnia-Claim-Customer-Tier": "gold",
+	}
+}
+
+func TestOPA_Decisions(t *testing.T) {
+	opa := &fakeOPA{decide: func(input map[string]interface{}) string {
+		headers := input["headers"].(map[string]interface{})
+		switch headers["X-Omnia-Param-Amount"] {
+		case "10":
+			return `{"result": true}`
+		case "20":
+			return `{"result": {"allow": true}, "decision_id": "opa-1"}`
+		case "5000":
+			return `{"result": {"allow": false, "message": "refunds over 1000 need approval"}}`
+		default:
+			return `{"result": false}`
+		}
+	}}
+	eval, _ := newOPAEvaluator(t, opa, nil)
+
+	tests := []struct {
+		amount      string
+		wantAllowed bool
+		wantMessage string
+	}{
+		{"10", true, ""},
+		{"20", true, ""},
+		{"5000", false, "refunds over 1000 need approval"},
+		{"30", false, "denied by OPA policy omnia/tools/decision"},
+	}
+	for _, tt := range tests {
+		d := eval.Evaluate(refundHeaders(tt.amount), nil)
+		if d.Allowed != tt.wantAllowed || d.Message != tt.wantMessage {
+			t.Errorf("amount %s: decision = %+v, want allowed=%v message=%q", tt.amount, d, tt.wantAllowed, tt.wantMessage)
+		}
+		if !d.Allowed && d.DeniedBy != "opa:omnia/tools/decision" {
+			t.Errorf("amount %s: DeniedBy = %q", tt.amount, d.DeniedBy)
+		}
+	}
+}
+
+func TestOPA_InputCarriesIdentity(t *testing.T) {
+	opa := &fakeOPA{decide: func(map[string]interface{}) string { return `{"result": true}` }}
+	eval, _ := newOPAEvaluator(t, opa, nil)
+
+	ctx := omniapolicy.WithIdentity(context.Background(), &omniapolicy.AuthenticatedIdentity{Subject: "alice"})
+	eval.EvaluateWithContext(ctx, refundHeaders("10"), map[string]interface{}{"reason": "damaged"})
+
+	identity, _ := opa.lastInput["identity"].(map[string]interface{})
+	if identity["subject"] != "alice" {
+		t.Errorf("input identity = %v, want subject alice", opa.lastInput["identity"])
+	}
+	body, _ := opa.lastInput["body"].(map[string]interface{})
+	if body["reason"] != "damaged" {
+		t.Errorf("input body = %v", opa.lastInput["body"])
+	}
+}
+
+func TestOPA_FailuresFollowOnFailure(t *testing.T) {
+	for _, answer := range []string{`{}`, `{"result": "yes"}`, `{"result": {"message": "no allow"}}`, `not json`} {
+		opa := &fakeOPA{decide: func(map[string]interface{}) string { return answer }}
+
+		eval, _ := newOPAEvaluator(t, opa, nil)
+		if d := eval.Evaluate(refundHeaders("10"), nil); d.Allowed || d.Error == nil {
+			t.Errorf("answer %s, onFailure=deny: decision = %+v, want a denial with an error", answer, d)
+		}
+
+		eval, _ = newOPAEvaluator(t, opa, func(tp *omniav1alpha1.ToolPolicy) {
+			tp.Spec.OnFailure = omniav1alpha1.OnFailureAllow
+		})
+		if d := eval.Evaluate(refundHeaders("10"), nil); !d.Allowed {
+			t.Errorf("answer %s, onFailure=allow: decision = %+v, want an allow", answer, d)
+		}
+	}
+}
+
+func TestOPA_AuditModeAndRequiredClaims(t *testing.T) {
+	opa := &fakeOPA{decide: func(map[string]interface{}) string { return `{"result": false}` }}
+	eval, _ := newOPAEvaluator(t, opa, func(tp *omniav1alpha1.ToolPolicy) {
+		tp.Spec.Mode = omniav1alpha1.PolicyModeAudit
+	})
+	if d := eval.Evaluate(refundHeaders("10"), nil); !d.Allowed || !d.WouldDeny {
+		t.Errorf("audit decision = %+v, want allowed with WouldDeny", d)
+	}
+
+	eval, _ = newOPAEvaluator(t, &fakeOPA{decide: func(map[string]interface{}) string { return `{"result": true}` }},
+		func(tp *omniav1alpha1.ToolPolicy) {
+			tp.Spec.RequiredClaims = []omniav1alpha1.RequiredClaim{{Claim: "department", Message: "department required"}}
+		})
+	if d := eval.Evaluate(refundHeaders("10"), nil); d.Allowed || d.DeniedBy != "required-claim:department" {
+		t.Errorf("decision = %+v, want the required claim to deny before OPA is queried", d)
+	}
+}
+
+func TestOPA_DecisionLogFormat(t *testing.T) {
+	opa := &fakeOPA{decide: func(map[string]interface{}) string {
+		return `{"result": {"allow": false, "message": "no"}, "decision_id": "opa-42"}`
+	}}
+	eval, logs := newOPAEvaluator(t, opa, nil)
+	eval.Evaluate(refundHeaders("10"), nil)
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d decision log lines, want 1: %q", len(lines), logs.String())
+	}
+	var event map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &event); err != nil {
+		t.Fatalf("decision log line is not JSON: %v", err)
+	}
+	for _, field := range []string{"labels", "decision_id", "path", "input", "result", "timestamp", "metrics"} {
+		if _, ok := event[field]; !ok {
+			t.Errorf("decision log event has no %q field: %s", field, lines[0])
+		}
+	}
+	if event["decision_id"] != "opa-42" || event["path"] != "omnia/tools/decision" {
+		t.Errorf("decision_id/path = %v/%v", event["decision_id"], event["path"])
+	}
+	labels := event["labels"].(map[string]interface{})
+	if labels["id"] != "broker-0" || labels["policy"] != "default/opa-policy" {
+		t.Errorf("labels = %v", labels)
+	}
+
+	// A failed query is logged with an error and a generated decision ID.
+	logs.Reset()
+	opa.decide = func(map[string]interface{}) string { return `{}` }
+	eval.Evaluate(refundHeaders("10"), nil)
+	var failed OPADecisionEvent
+	if err := json.Unmarshal(logs.Bytes(), &failed); err != nil {
+		t.Fatalf("decision log line is not JSON: %v", err)
+	}
+	if failed.Error == nil || failed.DecisionID == "" {
+		t.Errorf("failed query event = %+v, want an error and a decision ID", failed)
+	}
+}
+
+func TestOPA_CompileValidatesConfig(t *testing.T) {
+	eval, err := NewEvaluator()
+	if err != nil {
+		t.Fatalf("NewEvaluator() error = %v", err)
+	}
+	tests := map[string]*omniav1alpha1.OPAPolicyConfig{
+		"missing config":   nil,
+		"relative url":     {URL: "opa:8181", Path: "omnia/allow"},
+		"empty path":       {URL: "http://opa:8181", Path: "/"},
+		"bad timeout":      {URL: "http://opa:8181", Path: "omnia/allow", Timeout: "soon"},
+		"negative timeout": {URL: "http://opa:8181", Path: "omnia/allow", Timeout: "-1s"},
+	}
+	for name, cfg := range tests {
+		tp := newOPAPolicy("")
+		tp.Spec.OPA = cfg
+		if err := eval.CompilePolicy(tp); err == nil {
+			t.Errorf("%s: CompilePolicy() succeeded, want an error", name)
+		}
+	}
+
+	// Rules are ignored with the opa engine, even ones that do not compile.
+	tp := newOPAPolicy("http://opa:8181")
+	tp.Spec.Rules = []omniav1alpha1.PolicyRule{{Name: "bad", Deny: omniav1alpha1.PolicyRuleDeny{CEL: "((", Message: "x"}}}
+	if err := eval.CompilePolicy(tp); err != nil {
+		t.Errorf("CompilePolicy() error = %v, want rules ignored", err)
+	}
+}
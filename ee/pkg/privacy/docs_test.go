@@ -101,6 +101,7 @@ func TestOpenAPISpecCoversAllRoutes(t *testing.T) {
 		"GET /api/v1/privacy/preferences/{userID}/consent",
 		"GET /api/v1/privacy/consent/stats",
 		"GET /api/v1/privacy/enforcement-stats",
+		"POST /api/v1/privacy/policies/simulate",
 	}
 	for _, route := range want {
 		assert.True(t, documented[route], "route %q is registered but missing from openapi.yaml", route)
@@ -0,0 +1,265 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package privacy
+
+import (
+	"context"
+	"fmt"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/redaction"
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// dropReasonFacadeData is reported for facade-emitted content when
+// recording.facadeData is false. The facade drops it at the edge, so it never
+// reaches the middleware's writesDropped metric.
+const (
+	dropReasonFacadeData  = "facade-data-disabled"
+	remediationFacadeData = "recording.facadeData is false; set facadeData:true to record user message content"
+)
+
+// Retention tiers a simulated record is classified into.
+const (
+	RetentionTierFacade   = "facade"
+	RetentionTierRichData = "richData"
+)
+
+// SimulationTranscript is a sample conversation to run a policy against.
+type SimulationTranscript struct {
+	Messages  []SimulatedMessage  `json:"messages"`
+	ToolCalls []SimulatedToolCall `json:"toolCalls,omitempty"`
+}
+
+// SimulatedMessage is one message of a sample transcript. Source is "facade"
+// or "runtime"; when empty it is derived from the role, as the facade and
+// runtime set it (user turns come from the facade, everything else from the
+// runtime).
+type SimulatedMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+	Source  string `json:"source,omitempty"`
+}
+
+// SimulatedToolCall is one tool call of a sample transcript.
+type SimulatedToolCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments,omitempty"`
+	Result    string `json:"result,omitempty"`
+}
+
+// SimulationResult is the transcript as it would be recorded under the policy.
+type SimulationResult struct {
+	Messages  []SimulatedMessageOutcome  `json:"messages"`
+	ToolCalls []SimulatedToolCallOutcome `json:"toolCalls"`
+	Summary   SimulationSummary          `json:"summary"`
+}
+
+// RecordOutcome is what the policy does to one record.
+type RecordOutcome struct {
+	// Recorded is false when the record would be dropped.
+	Recorded bool `json:"recorded"`
+	// DropReason uses the same values as the omnia_session_api_writes_dropped_total
+	// reason label, plus facade-data-disabled.
+	DropReason  string `json:"dropReason,omitempty"`
+	Remediation string `json:"remediation,omitempty"`
+	// Retention classifies a recorded record; nil when it is dropped.
+	Retention  *RetentionClass `json:"retention,omitempty"`
+	Redactions []RedactionHit  `json:"redactions,omitempty"`
+}
+
+// RetentionClass is the retention tier a record falls into and the policy's
+// retention for that tier. Unset days mean the workspace default applies.
+type RetentionClass struct {
+	Tier     string `json:"tier"`
+	WarmDays *int32 `json:"warmDays,omitempty"`
+	ColdDays *int32 `json:"coldDays,omitempty"`
+}
+
+// RedactionHit is one PII match. Offsets are byte offsets into the original
+// field value.
+type RedactionHit struct {
+	Field   string `json:"field"`
+	Pattern string `json:"pattern"`
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+}
+
+// SimulatedMessageOutcome is a message with the policy applied. Content is
+// the value that would be stored, and is empty when the message is dropped.
+type SimulatedMessageOutcome struct {
+	Index   int    `json:"index"`
+	Role    string `json:"role"`
+	Source  string `json:"source"`
+	Content string `json:"content,omitempty"`
+	RecordOutcome
+}
+
+// SimulatedToolCallOutcome is a tool call with the policy applied.
+type SimulatedToolCallOutcome struct {
+	Index     int    `json:"index"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments,omitempty"`
+	Result    string `json:"result,omitempty"`
+	RecordOutcome
+}
+
+// SimulationSummary totals the simulation.
+type SimulationSummary struct {
+	Recorded   int            `json:"recorded"`
+	Dropped    int            `json:"dropped"`
+	Redactions int            `json:"redactions"`
+	ByPattern  map[string]int `json:"byPattern,omitempty"`
+	// EncryptedAtRest is true when recorded content would be encrypted.
+	EncryptedAtRest bool `json:"encryptedAtRest"`
+}
+
+// SimulatePolicy applies a SessionPrivacyPolicy to a sample transcript the way
+// the facade and the session-api privacy middleware would, without recording
+// anything. It fails only when the policy's PII config cannot be applied (for
+// example an invalid custom pattern).
+func SimulatePolicy(
+	ctx context.Context,
+	redactor redaction.Redactor,
+	spec *omniav1alpha1.SessionPrivacyPolicySpec,
+	transcript SimulationTranscript,
+) (*SimulationResult, error) {
+	s := &simulation{ctx: ctx, redactor: redactor, spec: spec}
+	res := &SimulationResult{
+		Messages:  make([]SimulatedMessageOutcome, 0, len(transcript.Messages)),
+		ToolCalls: make([]SimulatedToolCallOutcome, 0, len(transcript.ToolCalls)),
+		Summary: SimulationSummary{
+			EncryptedAtRest: spec.Encryption != nil && spec.Encryption.Enabled,
+		},
+	}
+
+	for i, msg := range transcript.Messages {
+		out := SimulatedMessageOutcome{Index: i, Role: msg.Role, Source: messageSource(msg)}
+		out.RecordOutcome = s.messageOutcome(out.Source)
+		if out.Recorded {
+			var err error
+			if out.Content, err = s.redact(&out.RecordOutcome, "content", msg.Content); err != nil {
+				return nil, err
+			}
+		}
+		res.Summary.add(&out.RecordOutcome)
+		res.Messages = append(res.Messages, out)
+	}
+
+	for i, call := range transcript.ToolCalls {
+		out := SimulatedToolCallOutcome{Index: i, Name: call.Name}
+		out.RecordOutcome = s.recordingOutcome(RetentionTierRichData)
+		if out.Recorded {
+			var err error
+			if out.Arguments, err = s.redact(&out.RecordOutcome, "arguments", call.Arguments); err != nil {
+				return nil, err
+			}
+			if out.Result, err = s.redact(&out.RecordOutcome, "result", call.Result); err != nil {
+				return nil, err
+			}
+		}
+		res.Summary.add(&out.RecordOutcome)
+		res.ToolCalls = append(res.ToolCalls, out)
+	}
+	return res, nil
+}
+
+// messageSource returns the message's source, deriving it from the role when
+// unset.
+func messageSource(msg SimulatedMessage) string {
+	if msg.Source != "" {
+		return msg.Source
+	}
+	if msg.Role == string(session.RoleUser) {
+		return session.SourceFacade
+	}
+	return session.SourceRuntime
+}
+
+type simulation struct {
+	ctx      context.Context
+	redactor redaction.Redactor
+	spec     *omniav1alpha1.SessionPrivacyPolicySpec
+}
+
+// messageOutcome applies the recording flags to a message: facade content is
+// gated by facadeData at the edge, runtime content by runtimeData in the
+// middleware.
+func (s *simulation) messageOutcome(source string) RecordOutcome {
+	rec := s.spec.Recording
+	switch {
+	case !rec.Enabled:
+		return s.recordingOutcome(RetentionTierFacade)
+	case source == session.SourceFacade && !rec.FacadeData:
+		return RecordOutcome{DropReason: dropReasonFacadeData, Remediation: remediationFacadeData}
+	case source == session.SourceFacade:
+		return s.recordingOutcome(RetentionTierFacade)
+	case !rec.RuntimeData:
+		return RecordOutcome{DropReason: dropReasonRuntimeData, Remediation: remediationRuntimeData}
+	default:
+		return s.recordingOutcome(RetentionTierRichData)
+	}
+}
+
+// recordingOutcome records into tier unless recording is disabled outright.
+func (s *simulation) recordingOutcome(tier string) RecordOutcome {
+	if !s.spec.Recording.Enabled {
+		return RecordOutcome{DropReason: dropReasonRecordingDisabled, Remediation: remediationRecording}
+	}
+	return RecordOutcome{Recorded: true, Retention: s.retention(tier)}
+}
+
+func (s *simulation) retention(tier string) *RetentionClass {
+	class := &RetentionClass{Tier: tier}
+	if s.spec.Retention == nil {
+		return class
+	}
+	cfg := s.spec.Retention.RichData
+	if tier == RetentionTierFacade {
+		cfg = s.spec.Retention.Facade
+	}
+	if cfg != nil {
+		class.WarmDays, class.ColdDays = cfg.WarmDays, cfg.ColdDays
+	}
+	return class
+}
+
+// redact redacts one field value when the policy asks for it, recording each
+// match on out.
+func (s *simulation) redact(out *RecordOutcome, field, value string) (string, error) {
+	pii := s.spec.Recording.PII
+	if value == "" || pii == nil || !pii.Redact {
+		return value, nil
+	}
+	redacted, events, err := s.redactor.Redact(s.ctx, value, pii)
+	if err != nil {
+		return "", fmt.Errorf("redacting %s: %w", field, err)
+	}
+	for _, e := range events {
+		out.Redactions = append(out.Redactions, RedactionHit{
+			Field: field, Pattern: e.Pattern, Start: e.StartIndex, End: e.EndIndex,
+		})
+	}
+	return redacted, nil
+}
+
+func (sum *SimulationSummary) add(out *RecordOutcome) {
+	if !out.Recorded {
+		sum.Dropped++
+		return
+	}
+	sum.Recorded++
+	for _, hit := range out.Redactions {
+		if sum.ByPattern == nil {
+			sum.ByPattern = make(map[string]int)
+		}
+		sum.ByPattern[hit.Pattern]++
+		sum.Redactions++
+	}
+}
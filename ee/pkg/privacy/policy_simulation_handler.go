@@ -0,0 +1,73 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package privacy
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-logr/logr"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/redaction"
+)
+
+// maxSimulationBodyBytes caps a simulation request; sample transcripts are
+// meant to be small.
+const maxSimulationBodyBytes = 1 << 20
+
+// PolicySimulationRequest is the JSON body of a policy simulation.
+type PolicySimulationRequest struct {
+	// Policy is the SessionPrivacyPolicy to simulate; only its spec is used,
+	// so a policy that has not been applied can be tried out.
+	Policy     omniav1alpha1.SessionPrivacyPolicy `json:"policy"`
+	Transcript SimulationTranscript               `json:"transcript"`
+}
+
+// PolicySimulationHandler exposes POST /api/v1/privacy/policies/simulate, which
+// runs a sample transcript through a SessionPrivacyPolicy so privacy teams can
+// iterate on a policy without waiting for real traffic. Nothing is recorded.
+type PolicySimulationHandler struct {
+	redactor redaction.Redactor
+	log      logr.Logger
+}
+
+// NewPolicySimulationHandler creates a PolicySimulationHandler.
+func NewPolicySimulationHandler(redactor redaction.Redactor, log logr.Logger) *PolicySimulationHandler {
+	return &PolicySimulationHandler{redactor: redactor, log: log.WithName("policy-simulation")}
+}
+
+// RegisterRoutes registers the simulation route on the given mux.
+func (h *PolicySimulationHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/v1/privacy/policies/simulate", h.handleSimulate)
+}
+
+func (h *PolicySimulationHandler) handleSimulate(w http.ResponseWriter, r *http.Request) {
+	var req PolicySimulationRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSimulationBodyBytes)).Decode(&req); err != nil {
+		writeErr(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if len(req.Transcript.Messages) == 0 && len(req.Transcript.ToolCalls) == 0 {
+		writeErr(w, http.StatusBadRequest, "transcript must contain at least one message or tool call")
+		return
+	}
+
+	result, err := SimulatePolicy(r.Context(), h.redactor, &req.Policy.Spec, req.Transcript)
+	if err != nil {
+		// The only failure is a PII config the redactor rejects.
+		writeErr(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.log.Error(err, "policy simulation encode failed")
+	}
+}
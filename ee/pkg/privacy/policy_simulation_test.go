@@ -0,0 +1,159 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: FSL-1.1-Apache-2.0
+This file is part of Omnia Enterprise and is subject to the
+Functional Source License. See ee/LICENSE for details.
+*/
+
+package privacy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/ee/api/v1alpha1"
+	"github.com/altairalabs/omnia/ee/pkg/redaction"
+)
+
+var sampleTranscript = SimulationTranscript{
+	Messages: []SimulatedMessage{
+		{Role: "user", Content: "My SSN is 123-45-6789, email me at a@b.io"},
+		{Role: "assistant", Content: "Thanks, I have noted 123-45-6789."},
+	},
+	ToolCalls: []SimulatedToolCall{
+		{Name: "lookup", Arguments: `{"ssn":"123-45-6789"}`, Result: `{"ok":true}`},
+	},
+}
+
+func redactingSpec() *omniav1alpha1.SessionPrivacyPolicySpec {
+	return &omniav1alpha1.SessionPrivacyPolicySpec{
+		Recording: omniav1alpha1.RecordingConfig{
+			Enabled:     true,
+			FacadeData:  true,
+			RuntimeData: true,
+			PII: &omniav1alpha1.PIIConfig{
+				Redact:   true,
+				Patterns: []string{"ssn", "email"},
+			},
+		},
+		Retention: &omniav1alpha1.PrivacyRetentionConfig{
+			Facade:   &omniav1alpha1.PrivacyRetentionTierConfig{WarmDays: ptr.To[int32](30)},
+			RichData: &omniav1alpha1.PrivacyRetentionTierConfig{WarmDays: ptr.To[int32](7), ColdDays: ptr.To[int32](90)},
+		},
+	}
+}
+
+func TestSimulatePolicy_Redacts(t *testing.T) {
+	res, err := SimulatePolicy(context.Background(), redaction.NewRedactor(), redactingSpec(), sampleTranscript)
+	require.NoError(t, err)
+
+	user := res.Messages[0]
+	assert.True(t, user.Recorded)
+	assert.Equal(t, "facade", user.Source)
+	assert.Equal(t, "My SSN is [REDACTED_SSN], email me at [REDACTED_EMAIL]", user.Content)
+	require.Len(t, user.Redactions, 2)
+	assert.Equal(t, RedactionHit{Field: "content", Pattern: "ssn", Start: 10, End: 21}, user.Redactions[0])
+	assert.Equal(t, &RetentionClass{Tier: RetentionTierFacade, WarmDays: ptr.To[int32](30)}, user.Retention)
+
+	assistant := res.Messages[1]
+	assert.Equal(t, "runtime", assistant.Source)
+	assert.Equal(t, RetentionTierRichData, assistant.Retention.Tier)
+	assert.Equal(t, int32(90), *assistant.Retention.ColdDays)
+
+	call := res.ToolCalls[0]
+	assert.True(t, call.Recorded)
+	assert.Equal(t, `{"ssn":"[REDACTED_SSN]"}`, call.Arguments)
+	assert.Equal(t, `{"ok":true}`, call.Result)
+
+	assert.Equal(t, SimulationSummary{
+		Recorded:   3,
+		Redactions: 4,
+		ByPattern:  map[string]int{"ssn": 3, "email": 1},
+	}, res.Summary)
+}
+
+func TestSimulatePolicy_Drops(t *testing.T) {
+	spec := redactingSpec()
+	spec.Recording.RuntimeData = false
+	spec.Encryption = &omniav1alpha1.EncryptionConfig{Enabled: true}
+
+	res, err := SimulatePolicy(context.Background(), redaction.NewRedactor(), spec, sampleTranscript)
+	require.NoError(t, err)
+	assert.True(t, res.Messages[0].Recorded)
+	assert.False(t, res.Messages[1].Recorded)
+	assert.Equal(t, dropReasonRuntimeData, res.Messages[1].DropReason)
+	assert.Empty(t, res.Messages[1].Content)
+	assert.Nil(t, res.Messages[1].Retention)
+	assert.True(t, res.ToolCalls[0].Recorded, "tool calls are not gated by runtimeData")
+	assert.True(t, res.Summary.EncryptedAtRest)
+
+	spec.Recording.FacadeData = false
+	res, err = SimulatePolicy(context.Background(), redaction.NewRedactor(), spec, sampleTranscript)
+	require.NoError(t, err)
+	assert.Equal(t, dropReasonFacadeData, res.Messages[0].DropReason)
+
+	spec.Recording.Enabled = false
+	res, err = SimulatePolicy(context.Background(), redaction.NewRedactor(), spec, sampleTranscript)
+	require.NoError(t, err)
+	for _, m := range res.Messages {
+		assert.Equal(t, dropReasonRecordingDisabled, m.DropReason)
+	}
+	assert.Equal(t, dropReasonRecordingDisabled, res.ToolCalls[0].DropReason)
+	assert.Equal(t, 3, res.Summary.Dropped)
+}
+
+func TestSimulatePolicy_ExplicitSource(t *testing.T) {
+	spec := redactingSpec()
+	spec.Recording.RuntimeData = false
+	transcript := SimulationTranscript{Messages: []SimulatedMessage{
+		{Role: "system", Content: "greeting", Source: "facade"},
+	}}
+	res, err := SimulatePolicy(context.Background(), redaction.NewRedactor(), spec, transcript)
+	require.NoError(t, err)
+	assert.True(t, res.Messages[0].Recorded)
+}
+
+func postSimulation(t *testing.T, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	mux := http.NewServeMux()
+	NewPolicySimulationHandler(redaction.NewRedactor(), logr.Discard()).RegisterRoutes(mux)
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/privacy/policies/simulate", bytes.NewReader(data))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	return w
+}
+
+func TestPolicySimulationHandler(t *testing.T) {
+	w := postSimulation(t, PolicySimulationRequest{
+		Policy:     omniav1alpha1.SessionPrivacyPolicy{Spec: *redactingSpec()},
+		Transcript: sampleTranscript,
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var res SimulationResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, 4, res.Summary.Redactions)
+
+	w = postSimulation(t, PolicySimulationRequest{Policy: omniav1alpha1.SessionPrivacyPolicy{Spec: *redactingSpec()}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	bad := redactingSpec()
+	bad.Recording.PII.Patterns = []string{"custom:[invalid"}
+	w = postSimulation(t, PolicySimulationRequest{
+		Policy:     omniav1alpha1.SessionPrivacyPolicy{Spec: *bad},
+		Transcript: sampleTranscript,
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid custom pattern")
+}
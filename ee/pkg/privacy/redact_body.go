@@ -70,6 +70,8 @@ func redactByEndpoint(
 		return redactEventBody(ctx, data, redactor, pii)
 	case strings.Contains(path, "/eval-results") || strings.HasSuffix(path, "/evaluate"):
 		return redactEvalResultBody(ctx, data, redactor, pii)
+	case strings.HasSuffix(path, "/annotations"):
+		return redactAnnotationBody(ctx, data, redactor, pii)
 	default:
 		return data, nil
 	}
@@ -96,6 +98,14 @@ func redactProviderCallBody(
 	return redactFields(ctx, data, r, pii, "request", "response")
 }
 
+// redactAnnotationBody redacts the suggested "correction" of a message
+// annotation.
+func redactAnnotationBody(
+	ctx context.Context, data []byte, r redaction.Redactor, pii *omniav1alpha1.PIIConfig,
+) ([]byte, error) {
+	return redactFields(ctx, data, r, pii, "correction")
+}
+
 // redactFields deserializes JSON, redacts the named fields, and re-serializes.
 func redactFields(
 	ctx context.Context, data []byte, r redaction.Redactor,
@@ -126,6 +126,16 @@ func TestRedactByEndpoint_UnknownPath(t *testing.T) {
 	assert.Contains(t, string(result), "123-45-6789")
 }
 
+func TestRedactByEndpoint_Annotations(t *testing.T) {
+	input := []byte(`{"score":-1,"labels":["wrong-answer"],"correction":"Email user@example.com instead"}`)
+	r := redaction.NewRedactor()
+
+	result, err := redactByEndpoint(input, "/api/v1/sessions/abc/messages/def/annotations", r, testPIIConfig())
+	require.NoError(t, err)
+	assert.NotContains(t, string(result), "user@example.com")
+	assert.Contains(t, string(result), "wrong-answer")
+}
+
 func TestRedactStringField_MissingField(t *testing.T) {
 	m := map[string]any{"other": "value"}
 	r := redaction.NewRedactor()
@@ -20,6 +20,13 @@ type EnterpriseOptions struct {
 	// ClusterName is the human-readable name for this cluster in license records.
 	ClusterName string
 
+	// LicenseFile is a mounted license file read in place of the license Secret.
+	LicenseFile string
+
+	// OfflineActivation activates the license with a signed offline activation
+	// response instead of contacting the license server.
+	OfflineActivation bool
+
 	// EnableWebhooks enables admission webhook registration for EE resources.
 	EnableWebhooks bool
 
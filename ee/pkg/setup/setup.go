@@ -96,7 +96,11 @@ func registerToolPolicy(mgr ctrl.Manager) error {
 
 // registerLicenseActivation sets up the LicenseActivation controller.
 func registerLicenseActivation(mgr ctrl.Manager, opts EnterpriseOptions) error {
-	validator, err := license.NewValidator(mgr.GetClient())
+	var validatorOpts []license.ValidatorOption
+	if opts.LicenseFile != "" {
+		validatorOpts = append(validatorOpts, license.WithLicenseFile(opts.LicenseFile))
+	}
+	validator, err := license.NewValidator(mgr.GetClient(), validatorOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to create license validator: %w", err)
 	}
@@ -116,6 +120,7 @@ func registerLicenseActivation(mgr ctrl.Manager, opts EnterpriseOptions) error {
 		LicenseValidator: validator,
 		ActivationClient: license.NewActivationClient(clientOpts...),
 		ClusterName:      opts.ClusterName,
+		Offline:          opts.OfflineActivation,
 	}).SetupWithManager(mgr)
 }
 
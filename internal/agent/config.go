@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/altairalabs/omnia/api/v1alpha1"
 	"github.com/altairalabs/omnia/internal/media"
 )
 
@@ -230,6 +231,30 @@ type Config struct {
 	// facade.DefaultServerConfig default (30s)".
 	DrainTimeout time.Duration
 
+	// SendQueueSize / SendQueueMaxBytes / SlowConsumerPolicy bound each
+	// WebSocket connection's outbound queue. Sourced from the primary
+	// facade's sendQueue. Zero values leave the facade defaults in place.
+	SendQueueSize      int
+	SendQueueMaxBytes  int
+	SlowConsumerPolicy string
+
+	// DisableCompression / CompressionLevel / CompressionThreshold /
+	// MaxFragmentSize / MaxFragmentedSize carry the primary facade's
+	// payload settings. Zero values leave the facade defaults in place.
+	DisableCompression   bool
+	CompressionLevel     int
+	CompressionThreshold int
+	MaxFragmentSize      int
+	MaxFragmentedSize    int
+
+	// DuplexAudioFormat / DuplexAudioSampleRate / DuplexAudioChannels carry
+	// spec.duplex.audio (format / recommendedSampleRate / channels). The
+	// WebSocket facade advertises them to voice clients in the connected
+	// message. Zero values leave the facade defaults in place.
+	DuplexAudioFormat     string
+	DuplexAudioSampleRate int
+	DuplexAudioChannels   int
+
 	// Media storage configuration.
 	MediaStorageType    MediaStorageType
 	MediaStoragePath    string
@@ -269,6 +294,10 @@ type Config struct {
 	A2APort            int    // port for A2A in dual-protocol mode (default 9999)
 	A2AClientsJSON     string // JSON-encoded resolved client list from controller
 
+	// A2AAgentCard is the Agent Card configured on the a2a facade. Nil
+	// serves a default card naming the agent.
+	A2AAgentCard *v1alpha1.AgentCardSpec
+
 	// MCP configuration. Function-mode only; the operator's CEL
 	// validation rejects MCPEnabled=true on agent-mode runtimes.
 	MCPEnabled bool
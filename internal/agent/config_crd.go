@@ -95,6 +95,7 @@ func LoadFromCRD(ctx context.Context, c client.Client, name, namespace string) (
 
 	loadToolRegistryConfigFromCRD(cfg, ar, namespace)
 	loadMediaConfigFromCRD(cfg, ar)
+	loadDuplexAudioFromCRD(cfg, ar)
 
 	if err := loadTracingConfigFromEnv(cfg); err != nil {
 		return nil, err
@@ -209,8 +210,8 @@ func primaryFacade(wsF, restF, a2aF *v1alpha1.FacadeConfig) *v1alpha1.FacadeConf
 	}
 }
 
-// applyPrimaryFacade copies the primary facade's type, port, and timeouts into
-// the flat Config.
+// applyPrimaryFacade copies the primary facade's type, port, timeouts, send
+// queue bounds, and payload settings into the flat Config.
 func applyPrimaryFacade(cfg *Config, f *v1alpha1.FacadeConfig) error {
 	cfg.FacadeType = FacadeType(f.Type)
 	cfg.FacadePort = int32PtrOr(f.Port, DefaultFacadePort)
@@ -224,11 +225,23 @@ func applyPrimaryFacade(cfg *Config, f *v1alpha1.FacadeConfig) error {
 		}
 		cfg.DrainTimeout = d
 	}
+	if q := f.SendQueue; q != nil {
+		cfg.SendQueueSize = int32PtrOr(q.MaxMessages, 0)
+		cfg.SendQueueMaxBytes = int32PtrOr(q.MaxBytes, 0)
+		cfg.SlowConsumerPolicy = string(q.Policy)
+	}
+	if p := f.Payload; p != nil {
+		cfg.DisableCompression = p.Compression != nil && !*p.Compression
+		cfg.CompressionLevel = int32PtrOr(p.CompressionLevel, 0)
+		cfg.CompressionThreshold = int32PtrOr(p.CompressionThreshold, 0)
+		cfg.MaxFragmentSize = int32PtrOr(p.MaxFragmentSize, 0)
+		cfg.MaxFragmentedSize = int32PtrOr(p.MaxFragmentedSize, 0)
+	}
 	return nil
 }
 
-// applyA2AFacade loads the A2A TTLs, task store, clients, and dual-protocol port
-// from the a2a facade entry. wsPrimary reports whether a websocket facade is the
+// applyA2AFacade loads the A2A TTLs, task store, clients, agent card, and
+// dual-protocol port from the a2a facade entry. wsPrimary reports whether a websocket facade is the
 // primary; when true the a2a facade is a secondary listener (A2AEnabled) on
 // A2APort, otherwise a2a is itself the primary (on FacadePort) and A2AEnabled
 // stays false.
@@ -253,6 +266,7 @@ func applyA2AFacade(cfg *Config, f *v1alpha1.FacadeConfig, wsPrimary bool) error
 		return err
 	}
 	cfg.A2APort = int32PtrOr(a2a.Port, DefaultA2APort)
+	cfg.A2AAgentCard = a2a.AgentCard
 	loadA2ATaskStoreFromCRD(cfg, a2a)
 	return nil
 }
@@ -363,6 +377,23 @@ func loadMediaConfigFromCRD(cfg *Config, ar *v1alpha1.AgentRuntime) {
 	cfg.MediaDownloadURLTTL = getEnvDuration(EnvMediaDownloadURLTTL, DefaultMediaDownloadURLTTL)
 }
 
+// loadDuplexAudioFromCRD copies the voice format the runtime requires
+// (spec.duplex.audio) so the facade can advertise it before the first audio
+// frame, instead of clients learning it from the runtime's counter-offer.
+func loadDuplexAudioFromCRD(cfg *Config, ar *v1alpha1.AgentRuntime) {
+	if ar.Spec.Duplex == nil || ar.Spec.Duplex.Audio == nil {
+		return
+	}
+	audio := ar.Spec.Duplex.Audio
+	cfg.DuplexAudioFormat = audio.Format
+	if audio.RecommendedSampleRate != nil {
+		cfg.DuplexAudioSampleRate = int(*audio.RecommendedSampleRate)
+	}
+	if audio.Channels != nil {
+		cfg.DuplexAudioChannels = int(*audio.Channels)
+	}
+}
+
 // loadTracingConfigFromEnv populates tracing-related config fields from environment variables.
 func loadTracingConfigFromEnv(cfg *Config) error {
 	cfg.TracingEnabled = os.Getenv(EnvTracingEnabled) == envValueTrue
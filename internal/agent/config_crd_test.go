@@ -290,6 +290,53 @@ func TestLoadConfigFromCRD_DrainTimeout_Invalid(t *testing.T) {
 	}
 }
 
+func TestLoadConfigFromCRD_SendQueue(t *testing.T) {
+	maxMessages, maxBytes := int32(64), int32(1<<20)
+	ar := &v1alpha1.AgentRuntime{Spec: v1alpha1.AgentRuntimeSpec{
+		Facades: []v1alpha1.FacadeConfig{{
+			Type: v1alpha1.FacadeTypeWebSocket,
+			SendQueue: &v1alpha1.FacadeSendQueueConfig{
+				MaxMessages: &maxMessages,
+				MaxBytes:    &maxBytes,
+				Policy:      v1alpha1.SlowConsumerPolicyDrop,
+			},
+		}},
+	}}
+	cfg := &Config{}
+	if err := loadFacadesFromCRD(cfg, ar); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.SendQueueSize != 64 || cfg.SendQueueMaxBytes != 1<<20 || cfg.SlowConsumerPolicy != "drop" {
+		t.Fatalf("send queue = %d/%d/%q, want 64/%d/\"drop\"",
+			cfg.SendQueueSize, cfg.SendQueueMaxBytes, cfg.SlowConsumerPolicy, 1<<20)
+	}
+}
+
+func TestLoadConfigFromCRD_Payload(t *testing.T) {
+	off, level, fragment := false, int32(6), int32(4096)
+	ar := &v1alpha1.AgentRuntime{Spec: v1alpha1.AgentRuntimeSpec{
+		Facades: []v1alpha1.FacadeConfig{{
+			Type: v1alpha1.FacadeTypeWebSocket,
+			Payload: &v1alpha1.FacadePayloadConfig{
+				Compression:      &off,
+				CompressionLevel: &level,
+				MaxFragmentSize:  &fragment,
+			},
+		}},
+	}}
+	cfg := &Config{}
+	if err := loadFacadesFromCRD(cfg, ar); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !cfg.DisableCompression || cfg.CompressionLevel != 6 || cfg.MaxFragmentSize != 4096 {
+		t.Fatalf("payload = %v/%d/%d, want true/6/4096",
+			cfg.DisableCompression, cfg.CompressionLevel, cfg.MaxFragmentSize)
+	}
+	if cfg.CompressionThreshold != 0 || cfg.MaxFragmentedSize != 0 {
+		t.Fatalf("unset payload fields = %d/%d, want 0/0", cfg.CompressionThreshold, cfg.MaxFragmentedSize)
+	}
+}
+
 func TestLoadFromCRD_ToolRegistryNoNamespace(t *testing.T) {
 	ar := newFakeAgentRuntime("agent", "mynamespace", v1alpha1.AgentRuntimeSpec{
 		PromptPackRef: v1alpha1.PromptPackRef{Name: "pack"},
@@ -701,6 +748,33 @@ func TestLoadA2AConfigFromCRD_CustomTTLs(t *testing.T) {
 	}
 }
 
+func TestLoadA2AConfigFromCRD_AgentCard(t *testing.T) {
+	card := &v1alpha1.AgentCardSpec{
+		Name:         "Research Agent",
+		Skills:       []v1alpha1.AgentSkillSpec{{ID: "search", Name: "Search"}},
+		Capabilities: &v1alpha1.AgentCapabilitiesSpec{Streaming: true},
+	}
+	ar := newFakeAgentRuntime("agent", "ns", v1alpha1.AgentRuntimeSpec{
+		PromptPackRef: v1alpha1.PromptPackRef{Name: "pack"},
+		Facades: []v1alpha1.FacadeConfig{{
+			Type: v1alpha1.FacadeTypeA2A,
+			A2A:  &v1alpha1.A2AConfig{AgentCard: card},
+		}},
+	})
+	c := fake.NewClientBuilder().WithScheme(k8s.Scheme()).WithRuntimeObjects(ar, testNamespace(ar.Namespace)).Build()
+
+	cfg, err := LoadFromCRD(context.Background(), c, "agent", "ns")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.A2AAgentCard == nil {
+		t.Fatal("A2AAgentCard = nil, want the facade's agentCard")
+	}
+	if cfg.A2AAgentCard.Name != "Research Agent" || len(cfg.A2AAgentCard.Skills) != 1 {
+		t.Errorf("A2AAgentCard = %+v, want %+v", cfg.A2AAgentCard, card)
+	}
+}
+
 func TestLoadA2AConfigFromCRD_InvalidTaskTTL(t *testing.T) {
 	ar := newFakeAgentRuntime("agent", "ns", v1alpha1.AgentRuntimeSpec{
 		PromptPackRef: v1alpha1.PromptPackRef{Name: "pack"},
@@ -1146,3 +1220,29 @@ func TestLoadInternalPortsFromEnv_Invalid(t *testing.T) {
 		t.Error("expected error for malformed internal port, got nil")
 	}
 }
+
+func TestLoadFromCRD_DuplexAudio(t *testing.T) {
+	ar := newFakeAgentRuntime("agent", "ns", v1alpha1.AgentRuntimeSpec{
+		PromptPackRef: v1alpha1.PromptPackRef{Name: "pack"},
+		Facades:       []v1alpha1.FacadeConfig{{Type: v1alpha1.FacadeTypeWebSocket}},
+		Duplex: &v1alpha1.DuplexConfig{
+			Enabled: true,
+			Audio: &v1alpha1.AudioRequirements{
+				Format:                "pcm16",
+				RecommendedSampleRate: ptr.To(int32(24000)),
+				Channels:              ptr.To(int32(1)),
+			},
+		},
+	})
+
+	c := fake.NewClientBuilder().WithScheme(k8s.Scheme()).WithRuntimeObjects(ar, testNamespace(ar.Namespace)).Build()
+
+	cfg, err := LoadFromCRD(context.Background(), c, "agent", "ns")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.DuplexAudioFormat != "pcm16" || cfg.DuplexAudioSampleRate != 24000 || cfg.DuplexAudioChannels != 1 {
+		t.Errorf("duplex audio = %q/%d/%d, want pcm16/24000/1",
+			cfg.DuplexAudioFormat, cfg.DuplexAudioSampleRate, cfg.DuplexAudioChannels)
+	}
+}
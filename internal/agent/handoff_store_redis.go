@@ -0,0 +1,65 @@
+/*
+Copyright 2026 Altaira Labs.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/altairalabs/omnia/internal/facade"
+)
+
+const handoffKeyPrefix = "rt:handoff:"
+
+type redisHandoffStore struct{ client redis.UniversalClient }
+
+// NewRedisHandoffStore returns a facade.HandoffStore backed by Redis, storing
+// each handoff as JSON under rt:handoff:<session_id>.
+func NewRedisHandoffStore(client redis.UniversalClient) facade.HandoffStore {
+	return &redisHandoffStore{client: client}
+}
+
+func (r *redisHandoffStore) PutHandoff(ctx context.Context, h *facade.SessionHandoff, ttl time.Duration) error {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, handoffKeyPrefix+h.SessionID, data, ttl).Err()
+}
+
+func (r *redisHandoffStore) GetHandoff(ctx context.Context, sessionID string) (*facade.SessionHandoff, error) {
+	data, err := r.client.Get(ctx, handoffKeyPrefix+sessionID).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var h facade.SessionHandoff
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (r *redisHandoffStore) DeleteHandoff(ctx context.Context, sessionID string) error {
+	return r.client.Del(ctx, handoffKeyPrefix+sessionID).Err()
+}
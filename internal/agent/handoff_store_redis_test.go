@@ -0,0 +1,70 @@
+/*
+Copyright 2026 Altaira Labs.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/altairalabs/omnia/internal/facade"
+)
+
+func TestRedisHandoffStore_PutGetDelete(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	hs := NewRedisHandoffStore(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	ctx := context.Background()
+
+	if h, err := hs.GetHandoff(ctx, "sid"); err != nil || h != nil {
+		t.Fatalf("missing handoff = %v, %v; want nil, nil", h, err)
+	}
+
+	in := &facade.SessionHandoff{
+		SessionID: "sid",
+		OwnerID:   "user-1",
+		Persisted: true,
+		Seq:       7,
+		Messages:  []*facade.ServerMessage{facade.NewDoneMessage("sid", "hello")},
+	}
+	if err := hs.PutHandoff(ctx, in, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := mr.TTL("rt:handoff:sid"); ttl <= 0 {
+		t.Fatalf("PutHandoff must set a TTL; got %v", ttl)
+	}
+	out, err := hs.GetHandoff(ctx, "sid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.OwnerID != "user-1" || out.Seq != 7 || !out.Persisted || len(out.Messages) != 1 || out.Messages[0].Content != "hello" {
+		t.Fatalf("round trip = %+v", out)
+	}
+
+	if err := hs.DeleteHandoff(ctx, "sid"); err != nil {
+		t.Fatal(err)
+	}
+	if mr.Exists("rt:handoff:sid") {
+		t.Fatal("key not deleted")
+	}
+}
@@ -21,7 +21,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	"go.opentelemetry.io/otel/trace"
+
+	"github.com/altairalabs/omnia/pkg/metrics"
 )
 
 // Metrics holds all Prometheus metrics for the agent.
@@ -110,6 +111,42 @@ type Metrics struct {
 	// by the per-connection message-count rate limiter (control-plane flood).
 	ControlMessagesRateLimitedTotal prometheus.Counter
 
+	// Outbound backpressure metrics
+
+	// SendQueueBytes is the number of encoded bytes queued for clients
+	// across all connections. Growth that does not drain points at clients
+	// that have stopped reading.
+	SendQueueBytes prometheus.Gauge
+	// OutboundMessagesDroppedTotal counts messages shed from full send
+	// queues under the drop slow-consumer policy.
+	OutboundMessagesDroppedTotal prometheus.Counter
+	// SlowConsumerDisconnectsTotal counts connections closed because their
+	// send queue stayed full.
+	SlowConsumerDisconnectsTotal prometheus.Counter
+	// MessagesFragmentedTotal counts messages too large for one frame that
+	// were sent to clients as fragments.
+	MessagesFragmentedTotal prometheus.Counter
+	// FragmentsSentTotal counts the fragment messages those were split into.
+	FragmentsSentTotal prometheus.Counter
+
+	// Delivery metrics
+
+	// MessagesRedeliveredTotal counts server messages replayed to clients
+	// that resumed their session.
+	MessagesRedeliveredTotal prometheus.Counter
+	// DuplicateClientMessagesTotal counts resent client messages dropped
+	// because their seq was already accepted.
+	DuplicateClientMessagesTotal prometheus.Counter
+
+	// Admin API metrics
+
+	// AdminSessionsTerminatedTotal counts sessions operators terminated
+	// through the admin API.
+	AdminSessionsTerminatedTotal prometheus.Counter
+	// AdminNoticesSentTotal counts operator notices delivered to
+	// connections.
+	AdminNoticesSentTotal prometheus.Counter
+
 	// Realtime blip-resume counters
 
 	// RealtimeSessionsParkedTotal is the total number of realtime sessions parked
@@ -293,6 +330,60 @@ func NewMetrics(agentName, namespace string) *Metrics {
 			ConstLabels: labels,
 		}),
 
+		SendQueueBytes: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:        "omnia_facade_send_queue_bytes",
+			Help:        "Encoded bytes queued for WebSocket clients across all connections",
+			ConstLabels: labels,
+		}),
+
+		OutboundMessagesDroppedTotal: promauto.NewCounter(prometheus.CounterOpts{
+			Name:        "omnia_facade_outbound_messages_dropped_total",
+			Help:        "Outbound messages shed from full per-connection send queues (drop policy)",
+			ConstLabels: labels,
+		}),
+
+		SlowConsumerDisconnectsTotal: promauto.NewCounter(prometheus.CounterOpts{
+			Name:        "omnia_facade_slow_consumer_disconnects_total",
+			Help:        "WebSocket connections closed because their send queue stayed full",
+			ConstLabels: labels,
+		}),
+
+		MessagesFragmentedTotal: promauto.NewCounter(prometheus.CounterOpts{
+			Name:        "omnia_facade_messages_fragmented_total",
+			Help:        "Messages too large for one frame sent to WebSocket clients as fragments",
+			ConstLabels: labels,
+		}),
+
+		FragmentsSentTotal: promauto.NewCounter(prometheus.CounterOpts{
+			Name:        "omnia_facade_fragments_sent_total",
+			Help:        "Fragment messages sent to WebSocket clients",
+			ConstLabels: labels,
+		}),
+
+		MessagesRedeliveredTotal: promauto.NewCounter(prometheus.CounterOpts{
+			Name:        "omnia_facade_messages_redelivered_total",
+			Help:        "Server messages replayed to WebSocket clients that resumed their session",
+			ConstLabels: labels,
+		}),
+
+		DuplicateClientMessagesTotal: promauto.NewCounter(prometheus.CounterOpts{
+			Name:        "omnia_facade_duplicate_client_messages_total",
+			Help:        "Resent client messages dropped because their seq was already accepted",
+			ConstLabels: labels,
+		}),
+
+		AdminSessionsTerminatedTotal: promauto.NewCounter(prometheus.CounterOpts{
+			Name:        "omnia_facade_admin_sessions_terminated_total",
+			Help:        "Sessions terminated by an operator through the facade admin API",
+			ConstLabels: labels,
+		}),
+
+		AdminNoticesSentTotal: promauto.NewCounter(prometheus.CounterOpts{
+			Name:        "omnia_facade_admin_notices_sent_total",
+			Help:        "Operator notices delivered to WebSocket connections through the facade admin API",
+			ConstLabels: labels,
+		}),
+
 		// Realtime blip-resume counters
 		RealtimeSessionsParkedTotal: promauto.NewCounter(prometheus.CounterOpts{
 			Name:        "omnia_facade_realtime_sessions_parked_total",
@@ -397,41 +488,9 @@ func (m *Metrics) RequestStarted() {
 // enabling metric → trace drill-down in Grafana.
 func (m *Metrics) RequestCompleted(ctx context.Context, status string, durationSeconds float64, handler string) {
 	m.RequestsInflight.Dec()
-	exemplar := traceExemplar(ctx)
-	incWithExemplar(m.RequestsTotal.WithLabelValues(status), exemplar)
-	observeWithExemplar(m.RequestDuration.WithLabelValues(handler), durationSeconds, exemplar)
-}
-
-// traceExemplar extracts the trace_id from the span context for use as a Prometheus exemplar.
-func traceExemplar(ctx context.Context) prometheus.Labels {
-	sc := trace.SpanFromContext(ctx).SpanContext()
-	tid := sc.TraceID()
-	if !tid.IsValid() {
-		return nil
-	}
-	return prometheus.Labels{"trace_id": tid.String()}
-}
-
-// observeWithExemplar records a histogram observation with an optional trace exemplar.
-func observeWithExemplar(observer prometheus.Observer, value float64, exemplar prometheus.Labels) {
-	if exemplar != nil {
-		if eo, ok := observer.(prometheus.ExemplarObserver); ok {
-			eo.ObserveWithExemplar(value, exemplar)
-			return
-		}
-	}
-	observer.Observe(value)
-}
-
-// incWithExemplar increments a counter with an optional trace exemplar.
-func incWithExemplar(counter prometheus.Counter, exemplar prometheus.Labels) {
-	if exemplar != nil {
-		if ea, ok := counter.(prometheus.ExemplarAdder); ok {
-			ea.AddWithExemplar(1, exemplar)
-			return
-		}
-	}
-	counter.Inc()
+	exemplar := metrics.TraceExemplar(ctx)
+	metrics.IncWithExemplar(m.RequestsTotal.WithLabelValues(status), exemplar)
+	metrics.ObserveWithExemplar(m.RequestDuration.WithLabelValues(handler), durationSeconds, exemplar)
 }
 
 // MessageReceived records a received message.
@@ -527,6 +586,49 @@ func (m *Metrics) ControlMessageRateLimited() {
 	m.ControlMessagesRateLimitedTotal.Inc()
 }
 
+// SendQueueBytesChanged records a change in the bytes queued for clients.
+func (m *Metrics) SendQueueBytesChanged(delta int) {
+	m.SendQueueBytes.Add(float64(delta))
+}
+
+// OutboundMessageDropped records a message shed from a full send queue.
+func (m *Metrics) OutboundMessageDropped() {
+	m.OutboundMessagesDroppedTotal.Inc()
+}
+
+// SlowConsumerDisconnected records a connection closed as a slow consumer.
+func (m *Metrics) SlowConsumerDisconnected() {
+	m.SlowConsumerDisconnectsTotal.Inc()
+}
+
+// MessageFragmented records a message sent to a client as fragments.
+func (m *Metrics) MessageFragmented(fragments int) {
+	m.MessagesFragmentedTotal.Inc()
+	m.FragmentsSentTotal.Add(float64(fragments))
+}
+
+// MessagesRedelivered records server messages replayed on resume.
+func (m *Metrics) MessagesRedelivered(n int) {
+	m.MessagesRedeliveredTotal.Add(float64(n))
+}
+
+// DuplicateClientMessage records a resent client message dropped as a
+// duplicate.
+func (m *Metrics) DuplicateClientMessage() {
+	m.DuplicateClientMessagesTotal.Inc()
+}
+
+// AdminSessionTerminated records a session terminated through the admin API.
+func (m *Metrics) AdminSessionTerminated() {
+	m.AdminSessionsTerminatedTotal.Inc()
+}
+
+// AdminNoticeSent records an operator notice delivered to recipients
+// connections.
+func (m *Metrics) AdminNoticeSent(recipients int) {
+	m.AdminNoticesSentTotal.Add(float64(recipients))
+}
+
 // RealtimeSessionParked records that a realtime session was parked after
 // a client disconnect, awaiting reconnect within the grace window.
 func (m *Metrics) RealtimeSessionParked() {
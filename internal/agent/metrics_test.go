@@ -183,6 +183,42 @@ func newMetricsWithRegistry(agentName, namespace string, reg prometheus.Register
 		Name: "omnia_facade_control_messages_ratelimited_total", Help: "test", ConstLabels: labels,
 	})
 	reg.MustRegister(controlMessagesRateLimitedTotal)
+	sendQueueBytes := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "omnia_facade_send_queue_bytes", Help: "test", ConstLabels: labels,
+	})
+	reg.MustRegister(sendQueueBytes)
+	outboundMessagesDroppedTotal := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "omnia_facade_outbound_messages_dropped_total", Help: "test", ConstLabels: labels,
+	})
+	reg.MustRegister(outboundMessagesDroppedTotal)
+	slowConsumerDisconnectsTotal := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "omnia_facade_slow_consumer_disconnects_total", Help: "test", ConstLabels: labels,
+	})
+	reg.MustRegister(slowConsumerDisconnectsTotal)
+	messagesFragmentedTotal := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "omnia_facade_messages_fragmented_total", Help: "test", ConstLabels: labels,
+	})
+	reg.MustRegister(messagesFragmentedTotal)
+	fragmentsSentTotal := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "omnia_facade_fragments_sent_total", Help: "test", ConstLabels: labels,
+	})
+	reg.MustRegister(fragmentsSentTotal)
+	messagesRedeliveredTotal := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "omnia_facade_messages_redelivered_total", Help: "test", ConstLabels: labels,
+	})
+	reg.MustRegister(messagesRedeliveredTotal)
+	duplicateClientMessagesTotal := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "omnia_facade_duplicate_client_messages_total", Help: "test", ConstLabels: labels,
+	})
+	reg.MustRegister(duplicateClientMessagesTotal)
+	adminSessionsTerminatedTotal := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "omnia_facade_admin_sessions_terminated_total", Help: "test", ConstLabels: labels,
+	})
+	reg.MustRegister(adminSessionsTerminatedTotal)
+	adminNoticesSentTotal := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "omnia_facade_admin_notices_sent_total", Help: "test", ConstLabels: labels,
+	})
+	reg.MustRegister(adminNoticesSentTotal)
 
 	return &Metrics{
 		ConnectionsActive:     connectionsActive,
@@ -208,6 +244,15 @@ func newMetricsWithRegistry(agentName, namespace string, reg prometheus.Register
 		AudioBytesReceivedTotal:         audioBytesReceivedTotal,
 		MediaFramesRateLimitedTotal:     mediaFramesRateLimitedTotal,
 		ControlMessagesRateLimitedTotal: controlMessagesRateLimitedTotal,
+		SendQueueBytes:                  sendQueueBytes,
+		OutboundMessagesDroppedTotal:    outboundMessagesDroppedTotal,
+		SlowConsumerDisconnectsTotal:    slowConsumerDisconnectsTotal,
+		MessagesFragmentedTotal:         messagesFragmentedTotal,
+		FragmentsSentTotal:              fragmentsSentTotal,
+		MessagesRedeliveredTotal:        messagesRedeliveredTotal,
+		DuplicateClientMessagesTotal:    duplicateClientMessagesTotal,
+		AdminSessionsTerminatedTotal:    adminSessionsTerminatedTotal,
+		AdminNoticesSentTotal:           adminNoticesSentTotal,
 	}
 }
 
@@ -336,27 +381,6 @@ func TestMetricsRequestExemplar(t *testing.T) {
 	assert.Equal(t, float64(0), getGaugeValue(t, m.RequestsInflight))
 }
 
-func TestTraceExemplar(t *testing.T) {
-	t.Run("no span context returns nil", func(t *testing.T) {
-		labels := traceExemplar(context.Background())
-		assert.Nil(t, labels)
-	})
-
-	t.Run("valid span context returns trace_id", func(t *testing.T) {
-		traceID, _ := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
-		sc := trace.NewSpanContext(trace.SpanContextConfig{
-			TraceID:    traceID,
-			SpanID:     trace.SpanID{0x01},
-			TraceFlags: trace.FlagsSampled,
-		})
-		ctx := trace.ContextWithSpanContext(context.Background(), sc)
-
-		labels := traceExemplar(ctx)
-		require.NotNil(t, labels)
-		assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", labels["trace_id"])
-	})
-}
-
 func TestMetricsMessageTracking(t *testing.T) {
 	reg := prometheus.NewRegistry()
 	m := newMetricsWithRegistry("test-agent", "test-namespace", reg)
@@ -380,6 +404,47 @@ func TestMetricsRecordingDropped(t *testing.T) {
 	assert.Equal(t, float64(2), getCounterValue(t, m.RecordingDroppedTotal))
 }
 
+func TestMetricsSendQueue(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := newMetricsWithRegistry("test-agent", "test-namespace", reg)
+
+	m.SendQueueBytesChanged(4096)
+	m.SendQueueBytesChanged(-1024)
+	m.OutboundMessageDropped()
+	m.SlowConsumerDisconnected()
+	m.MessageFragmented(3)
+
+	assert.Equal(t, float64(3072), getGaugeValue(t, m.SendQueueBytes))
+	assert.Equal(t, float64(1), getCounterValue(t, m.OutboundMessagesDroppedTotal))
+	assert.Equal(t, float64(1), getCounterValue(t, m.SlowConsumerDisconnectsTotal))
+	assert.Equal(t, float64(1), getCounterValue(t, m.MessagesFragmentedTotal))
+	assert.Equal(t, float64(3), getCounterValue(t, m.FragmentsSentTotal))
+}
+
+func TestMetricsDelivery(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := newMetricsWithRegistry("test-agent", "test-namespace", reg)
+
+	m.MessagesRedelivered(3)
+	m.MessagesRedelivered(2)
+	m.DuplicateClientMessage()
+
+	assert.Equal(t, float64(5), getCounterValue(t, m.MessagesRedeliveredTotal))
+	assert.Equal(t, float64(1), getCounterValue(t, m.DuplicateClientMessagesTotal))
+}
+
+func TestMetricsAdmin(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := newMetricsWithRegistry("test-agent", "test-namespace", reg)
+
+	m.AdminSessionTerminated()
+	m.AdminNoticeSent(4)
+	m.AdminNoticeSent(0)
+
+	assert.Equal(t, float64(1), getCounterValue(t, m.AdminSessionsTerminatedTotal))
+	assert.Equal(t, float64(4), getCounterValue(t, m.AdminNoticesSentTotal))
+}
+
 // Helper functions to extract metric values for testing
 
 func getGaugeValue(t *testing.T, g prometheus.Gauge) float64 {
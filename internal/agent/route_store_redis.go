@@ -18,6 +18,7 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -29,7 +30,9 @@ const routeKeyPrefix = "rt:route:"
 
 type redisRouteStore struct{ client redis.UniversalClient }
 
-// NewRedisRouteStore returns a facade.RouteStore backed by Redis.
+// NewRedisRouteStore returns a facade.RouteStore backed by Redis. It is also
+// a facade.RouteResolver, so a replica can relay a resume to the pod holding
+// the session.
 func NewRedisRouteStore(client redis.UniversalClient) facade.RouteStore {
 	return &redisRouteStore{client: client}
 }
@@ -41,3 +44,11 @@ func (r *redisRouteStore) PutRoute(ctx context.Context, sessionID, addr string,
 func (r *redisRouteStore) DeleteRoute(ctx context.Context, sessionID string) error {
 	return r.client.Del(ctx, routeKeyPrefix+sessionID).Err()
 }
+
+func (r *redisRouteStore) GetRoute(ctx context.Context, sessionID string) (string, error) {
+	addr, err := r.client.Get(ctx, routeKeyPrefix+sessionID).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return addr, err
+}
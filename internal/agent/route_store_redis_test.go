@@ -23,6 +23,8 @@ import (
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
+
+	"github.com/altairalabs/omnia/internal/facade"
 )
 
 func TestRedisRouteStore_PutGetDelete(t *testing.T) {
@@ -43,10 +45,20 @@ func TestRedisRouteStore_PutGetDelete(t *testing.T) {
 	if ttl := mr.TTL("rt:route:sid"); ttl <= 0 {
 		t.Fatalf("PutRoute must set a TTL (EX); got %v", ttl)
 	}
+	resolver, ok := rs.(facade.RouteResolver)
+	if !ok {
+		t.Fatal("redis route store must implement facade.RouteResolver")
+	}
+	if addr, err := resolver.GetRoute(context.Background(), "sid"); err != nil || addr != "10.0.0.5:8080" {
+		t.Fatalf("GetRoute = %q, %v", addr, err)
+	}
 	if err := rs.DeleteRoute(context.Background(), "sid"); err != nil {
 		t.Fatal(err)
 	}
 	if mr.Exists("rt:route:sid") {
 		t.Fatalf("key not deleted")
 	}
+	if addr, err := resolver.GetRoute(context.Background(), "sid"); err != nil || addr != "" {
+		t.Fatalf("GetRoute after delete = %q, %v; want no route", addr, err)
+	}
 }
@@ -152,6 +152,7 @@ func (h *RuntimeHandler) HandleMessage(
 		Content:   msg.Content,
 		Metadata:  metadata,
 		Parts:     toGRPCContentParts(msg.Parts),
+		Progress:  wantsProgress(writer),
 	}
 
 	if err := stream.Send(grpcMsg); err != nil {
@@ -235,7 +236,11 @@ func (h *RuntimeHandler) handleRecvResult(
 	if result.err != nil {
 		return fmt.Errorf("error receiving from runtime: %w", result.err)
 	}
-	resetTimer(inactivityTimer, defaultStreamInactivityTimeout)
+	// Progress does not count as activity, so a turn times out the same
+	// whether or not its client asked for progress.
+	if _, ok := result.resp.Message.(*runtimev1.ServerMessage_Progress); !ok {
+		resetTimer(inactivityTimer, defaultStreamInactivityTimeout)
+	}
 
 	if isClientToolCall(result.resp) {
 		return h.handleClientToolCall(ctx, stream, writer, result.resp)
@@ -422,6 +427,9 @@ func (h *RuntimeHandler) forwardResponse(resp *runtimev1.ServerMessage, writer f
 	case *runtimev1.ServerMessage_Error:
 		return writer.WriteError(msg.Error.Code, msg.Error.Message)
 
+	case *runtimev1.ServerMessage_Progress:
+		return forwardProgress(msg.Progress, writer)
+
 	case *runtimev1.ServerMessage_RuntimeHello:
 		// The runtime's per-session hello. On the text path it carries only
 		// capabilities (no media counter-offer); consume it without forwarding.
@@ -433,6 +441,29 @@ func (h *RuntimeHandler) forwardResponse(resp *runtimev1.ServerMessage, writer f
 	}
 }
 
+// wantsProgress reports whether writer's client takes progress messages.
+func wantsProgress(writer facade.ResponseWriter) bool {
+	pw, ok := writer.(facade.ProgressWriter)
+	return ok && pw.WantsProgress()
+}
+
+// forwardProgress translates a gRPC Progress to a progress message. Progress
+// is best effort: a failed write does not end the turn.
+func forwardProgress(p *runtimev1.Progress, writer facade.ResponseWriter) error {
+	pw, ok := writer.(facade.ProgressWriter)
+	if !ok {
+		return nil
+	}
+	_ = pw.WriteProgress(&facade.ProgressInfo{
+		Stage:     p.GetStage(),
+		Status:    p.GetStatus(),
+		ToolName:  p.GetToolName(),
+		CallID:    p.GetCallId(),
+		ElapsedMs: p.GetElapsedMs(),
+	})
+	return nil
+}
+
 // forwardToolCall translates a gRPC ToolCall to a facade ToolCallInfo.
 // Only client-side tool calls should reach this point; server-side tool calls
 // are handled internally by the runtime and filtered in forwardResponse.
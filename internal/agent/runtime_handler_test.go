@@ -1219,3 +1219,61 @@ func TestFromGRPCContentParts_ForwardsStorageRef(t *testing.T) {
 		t.Errorf("StorageRef = %q, want %q", got[0].Media.StorageRef, ref)
 	}
 }
+
+// progressResponseWriter is a mockResponseWriter whose client takes progress.
+type progressResponseWriter struct {
+	mockResponseWriter
+	progress []*facade.ProgressInfo
+}
+
+func (w *progressResponseWriter) WantsProgress() bool { return true }
+
+func (w *progressResponseWriter) WriteProgress(p *facade.ProgressInfo) error {
+	w.progress = append(w.progress, p)
+	return nil
+}
+
+func TestRuntimeHandler_HandleMessage_Progress(t *testing.T) {
+	requested := make(chan bool, 2)
+	mock := &capturingRuntimeServer{
+		mockRuntimeServer: &mockRuntimeServer{
+			responses: []*runtimev1.ServerMessage{
+				{Message: &runtimev1.ServerMessage_Progress{Progress: &runtimev1.Progress{
+					Stage: "tool", Status: "running", ToolName: "search", CallId: "call-1", ElapsedMs: 2000,
+				}}},
+				{Message: &runtimev1.ServerMessage_Done{Done: &runtimev1.Done{FinalContent: "found"}}},
+			},
+			healthy: true,
+		},
+		onReceive: func(msg *runtimev1.ClientMessage) { requested <- msg.GetProgress() },
+	}
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	runtimev1.RegisterRuntimeServiceServer(server, mock)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	client, err := facade.NewRuntimeClient(facade.RuntimeClientConfig{
+		Address:     lis.Addr().String(),
+		DialTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+	handler := NewRuntimeHandler(client)
+
+	writer := &progressResponseWriter{}
+	require.NoError(t, handler.HandleMessage(context.Background(), "session-1", &facade.ClientMessage{Content: "find"}, writer))
+	require.Len(t, writer.progress, 1)
+	assert.Equal(t, &facade.ProgressInfo{
+		Stage: "tool", Status: "running", ToolName: "search", CallID: "call-1", ElapsedMs: 2000,
+	}, writer.progress[0])
+	assert.Equal(t, "found", writer.doneMsg)
+	assert.True(t, <-requested, "progress not requested for a writer that wants it")
+
+	// A writer that cannot deliver progress does not ask for it.
+	plain := &mockResponseWriter{}
+	require.NoError(t, handler.HandleMessage(context.Background(), "session-1", &facade.ClientMessage{Content: "find"}, plain))
+	assert.Equal(t, "found", plain.doneMsg)
+	assert.False(t, <-requested, "progress requested for a writer that cannot deliver it")
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 Altaira Labs.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/altairalabs/omnia/internal/facade"
+)
+
+type redisSessionRelay struct{ client redis.UniversalClient }
+
+// NewRedisSessionRelay returns a facade.SessionRelay over Redis pub/sub.
+func NewRedisSessionRelay(client redis.UniversalClient) facade.SessionRelay {
+	return &redisSessionRelay{client: client}
+}
+
+func (r *redisSessionRelay) Publish(ctx context.Context, channel string, payload []byte) error {
+	n, err := r.client.Publish(ctx, channel, payload).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return facade.ErrNoRelaySubscriber
+	}
+	return nil
+}
+
+func (r *redisSessionRelay) Subscribe(ctx context.Context, channel string) (facade.RelaySubscription, error) {
+	ps := r.client.Subscribe(ctx, channel)
+	// Wait for the subscription to be confirmed, so a reply published
+	// right after Subscribe returns is delivered.
+	if _, err := ps.Receive(ctx); err != nil {
+		_ = ps.Close()
+		return nil, err
+	}
+	sub := &redisRelaySubscription{
+		ps:       ps,
+		messages: make(chan []byte),
+		done:     make(chan struct{}),
+	}
+	go sub.pump()
+	return sub, nil
+}
+
+// redisRelaySubscription adapts a Redis PubSub to facade.RelaySubscription.
+type redisRelaySubscription struct {
+	ps       *redis.PubSub
+	messages chan []byte
+	done     chan struct{}
+	once     sync.Once
+}
+
+func (s *redisRelaySubscription) pump() {
+	defer close(s.messages)
+	for msg := range s.ps.Channel() {
+		select {
+		case s.messages <- []byte(msg.Payload):
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *redisRelaySubscription) Messages() <-chan []byte { return s.messages }
+
+func (s *redisRelaySubscription) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.done)
+		err = s.ps.Close()
+	})
+	return err
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 Altaira Labs.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/altairalabs/omnia/internal/facade"
+)
+
+func TestRedisSessionRelay_PublishSubscribe(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	relay := NewRedisSessionRelay(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	ctx := context.Background()
+
+	if err := relay.Publish(ctx, "omnia:relay:pod:a", []byte("x")); !errors.Is(err, facade.ErrNoRelaySubscriber) {
+		t.Fatalf("Publish without subscribers = %v, want ErrNoRelaySubscriber", err)
+	}
+
+	sub, err := relay.Subscribe(ctx, "omnia:relay:pod:a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := relay.Publish(ctx, "omnia:relay:pod:a", []byte(`{"op":"attach"}`)); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-sub.Messages():
+		if string(got) != `{"op":"attach"}` {
+			t.Fatalf("payload = %q", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("published payload not delivered")
+	}
+
+	if err := sub.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case _, ok := <-sub.Messages():
+		if ok {
+			t.Fatal("Messages delivered after Close")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Messages not closed after Close")
+	}
+}
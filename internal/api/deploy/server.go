@@ -15,9 +15,13 @@ import (
 // authz middleware.
 const routePrefix = "/api/v1/workspaces/{workspace}/deployments"
 
-// Server hosts the operator's deploy-intent API. The single POST route is
-// wrapped by the authz middleware (editor required), so an unauthenticated or
-// under-privileged request never reaches the handler.
+// packRoutePrefix is the PromptPack snapshot/diff path prefix.
+const packRoutePrefix = "/api/v1/workspaces/{workspace}/promptpacks/{packName}"
+
+// Server hosts the operator's deploy-intent API and the PromptPack
+// snapshot/diff preview routes. Every route is wrapped by the authz middleware
+// (viewer for GET, editor for POST), so an unauthenticated or under-privileged
+// request never reaches a handler.
 type Server struct {
 	addr   string
 	log    logr.Logger
@@ -25,10 +29,12 @@ type Server struct {
 }
 
 // NewServer builds a deploy API server.
-func NewServer(addr string, handler *Handler, authorizer *authz.Authorizer, log logr.Logger) *Server {
+func NewServer(addr string, handler *Handler, snapshots *SnapshotHandler, authorizer *authz.Authorizer, log logr.Logger) *Server {
 	mux := http.NewServeMux()
 	guard := authorizer.Middleware
 	mux.Handle("POST "+routePrefix, guard(http.HandlerFunc(handler.Deploy)))
+	mux.Handle("GET "+packRoutePrefix+"/snapshot", guard(http.HandlerFunc(snapshots.Snapshot)))
+	mux.Handle("POST "+packRoutePrefix+"/diff", guard(http.HandlerFunc(snapshots.Diff)))
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
 	return &Server{
 		addr: addr,
@@ -64,7 +64,8 @@ func newWiredServer(t *testing.T) (*Server, *rsa.PrivateKey) {
 	verifier := authz.NewIdentityVerifier(resolver)
 	authorizer := authz.NewAuthorizer(verifier, fakeWS{ns: "ns"})
 	c := fake.NewClientBuilder().WithScheme(testScheme(t)).Build()
-	srv := NewServer("127.0.0.1:0", NewHandler(NewApplier(c, logr.Discard()), logr.Discard()), authorizer, logr.Discard())
+	srv := NewServer("127.0.0.1:0", NewHandler(NewApplier(c, logr.Discard()), logr.Discard()),
+		NewSnapshotHandler(c, logr.Discard()), authorizer, logr.Discard())
 	return srv, key
 }
 
@@ -127,6 +128,23 @@ func TestServer_RejectsUnknownAPIVersion(t *testing.T) {
 	}
 }
 
+func TestServer_RoutesPackSnapshot(t *testing.T) {
+	srv, key := newWiredServer(t)
+	ts := httptest.NewServer(srv.server.Handler)
+	defer ts.Close()
+
+	r, _ := http.NewRequest(http.MethodGet, ts.URL+"/api/v1/workspaces/ws/promptpacks/support/snapshot", nil)
+	r.Header.Set("Authorization", "Bearer "+mintToken(t, key, "k1", "ws", []string{"editors"}))
+	resp, err := http.DefaultClient.Do(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("code = %d, want 404 for a pack with no versions", resp.StatusCode)
+	}
+}
+
 func TestServer_Healthz(t *testing.T) {
 	srv, _ := newWiredServer(t)
 	ts := httptest.NewServer(srv.server.Handler)
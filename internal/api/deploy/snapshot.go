@@ -0,0 +1,141 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/AltairaLabs/PromptKit/runtime/prompt"
+)
+
+// maxRenderPasses bounds nested substitution (a variable or fragment whose
+// value contains another placeholder), matching PromptKit's renderer.
+const maxRenderPasses = 10
+
+// placeholderPattern matches a {{name}} template placeholder.
+var placeholderPattern = regexp.MustCompile(`\{\{([^{}]+)\}\}`)
+
+// PackSnapshot is the materialized form of a PromptPack: each prompt's system
+// template rendered the way the runtime renders it, plus the tools and model
+// parameters the prompt carries.
+type PackSnapshot struct {
+	PackName string           `json:"packName"`
+	Version  string           `json:"version"`
+	Prompts  []RenderedPrompt `json:"prompts"`
+}
+
+// RenderedPrompt is one prompt of a PackSnapshot.
+type RenderedPrompt struct {
+	Name         string `json:"name"`
+	SystemPrompt string `json:"systemPrompt"`
+	// Unresolved lists placeholders with no default and no supplied value;
+	// they are left in SystemPrompt as written.
+	Unresolved []string          `json:"unresolved,omitempty"`
+	Tools      []string          `json:"tools,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// renderSnapshot renders every prompt in the pack.json content. vars override
+// the variable defaults declared by each prompt; pack fragments are available
+// to every prompt as {{fragment_name}}.
+func renderSnapshot(packName, version string, content []byte, vars map[string]string) (*PackSnapshot, error) {
+	var pack prompt.Pack
+	if err := json.Unmarshal(content, &pack); err != nil {
+		return nil, fmt.Errorf("parse pack.json: %w", err)
+	}
+	snap := &PackSnapshot{PackName: packName, Version: version, Prompts: []RenderedPrompt{}}
+	for name, p := range pack.Prompts {
+		if p == nil {
+			continue
+		}
+		snap.Prompts = append(snap.Prompts, renderPrompt(name, p, pack.Fragments, vars))
+	}
+	sort.Slice(snap.Prompts, func(i, j int) bool { return snap.Prompts[i].Name < snap.Prompts[j].Name })
+	return snap, nil
+}
+
+// renderPrompt renders one prompt's system template.
+func renderPrompt(name string, p *prompt.PackPrompt, fragments, vars map[string]string) RenderedPrompt {
+	values := make(map[string]string, len(fragments)+len(p.Variables)+len(vars))
+	for k, v := range fragments {
+		values[k] = v
+	}
+	for _, v := range p.Variables {
+		if v.Default != nil {
+			values[v.Name] = fmt.Sprint(v.Default)
+		}
+	}
+	for k, v := range vars {
+		values[k] = v
+	}
+
+	text := substitute(p.SystemTemplate, values)
+	tools := append([]string(nil), p.Tools...)
+	sort.Strings(tools)
+	return RenderedPrompt{
+		Name:         name,
+		SystemPrompt: text,
+		Unresolved:   unresolvedPlaceholders(text),
+		Tools:        tools,
+		Parameters:   promptParameters(p.Parameters),
+	}
+}
+
+// substitute replaces {{name}} placeholders with values, repeating so that
+// values which themselves hold placeholders are resolved too.
+func substitute(text string, values map[string]string) string {
+	for range maxRenderPasses {
+		next := placeholderPattern.ReplaceAllStringFunc(text, func(m string) string {
+			if v, ok := values[m[2:len(m)-2]]; ok {
+				return v
+			}
+			return m
+		})
+		if next == text {
+			break
+		}
+		text = next
+	}
+	return text
+}
+
+// unresolvedPlaceholders returns the distinct placeholder names left in text, sorted.
+func unresolvedPlaceholders(text string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, m := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		name := m[1]
+		if !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// promptParameters flattens the model parameters a prompt sets.
+func promptParameters(p *prompt.ParametersPack) map[string]string {
+	if p == nil {
+		return nil
+	}
+	out := map[string]string{}
+	if p.Temperature != nil {
+		out["temperature"] = strconv.FormatFloat(*p.Temperature, 'g', -1, 64)
+	}
+	if p.TopP != nil {
+		out["top_p"] = strconv.FormatFloat(*p.TopP, 'g', -1, 64)
+	}
+	if p.MaxTokens != nil {
+		out["max_tokens"] = strconv.Itoa(*p.MaxTokens)
+	}
+	if p.TopK != nil {
+		out["top_k"] = strconv.Itoa(*p.TopK)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,211 @@
+package deploy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// diffContextLines is how many unchanged lines surround each changed hunk.
+const diffContextLines = 3
+
+// Prompt diff statuses.
+const (
+	PromptAdded     = "added"
+	PromptRemoved   = "removed"
+	PromptChanged   = "changed"
+	PromptUnchanged = "unchanged"
+)
+
+// Diff line operations.
+const (
+	LineEqual  = "equal"
+	LineInsert = "insert"
+	LineDelete = "delete"
+)
+
+// SnapshotDiff is the structured difference between two PackSnapshots.
+type SnapshotDiff struct {
+	// Changed is true when any rendered prompt, tool list, parameter or the
+	// version differs.
+	Changed bool         `json:"changed"`
+	Version *ValueChange `json:"version,omitempty"`
+	Prompts []PromptDiff `json:"prompts"`
+}
+
+// ValueChange is a scalar that differs between the current and proposed pack.
+type ValueChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// PromptDiff is the difference for one prompt, matched by name.
+type PromptDiff struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// SystemPrompt holds line hunks of the rendered system prompt; empty when
+	// it is unchanged.
+	SystemPrompt []DiffHunk             `json:"systemPrompt,omitempty"`
+	ToolsAdded   []string               `json:"toolsAdded,omitempty"`
+	ToolsRemoved []string               `json:"toolsRemoved,omitempty"`
+	Parameters   map[string]ValueChange `json:"parameters,omitempty"`
+	// Unresolved lists placeholders the proposed prompt leaves unresolved.
+	Unresolved []string `json:"unresolved,omitempty"`
+}
+
+// DiffHunk is a run of changed lines with surrounding context. Line numbers
+// are 1-based.
+type DiffHunk struct {
+	FromLine int        `json:"fromLine"`
+	ToLine   int        `json:"toLine"`
+	Lines    []DiffLine `json:"lines"`
+}
+
+// DiffLine is one line of a hunk.
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// diffSnapshots compares current against proposed. Either may have no
+// prompts, in which case every prompt of the other is added or removed.
+func diffSnapshots(current, proposed *PackSnapshot) SnapshotDiff {
+	out := SnapshotDiff{Prompts: []PromptDiff{}}
+	if current.Version != proposed.Version {
+		out.Version = &ValueChange{From: current.Version, To: proposed.Version}
+		out.Changed = true
+	}
+
+	before := promptsByName(current)
+	after := promptsByName(proposed)
+	names := make([]string, 0, len(before)+len(after))
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		d := diffPrompt(name, before[name], after[name])
+		if d.Status != PromptUnchanged {
+			out.Changed = true
+		}
+		out.Prompts = append(out.Prompts, d)
+	}
+	return out
+}
+
+func promptsByName(s *PackSnapshot) map[string]*RenderedPrompt {
+	out := make(map[string]*RenderedPrompt, len(s.Prompts))
+	for i := range s.Prompts {
+		out[s.Prompts[i].Name] = &s.Prompts[i]
+	}
+	return out
+}
+
+// diffPrompt compares one prompt; a nil side means it is absent there.
+func diffPrompt(name string, from, to *RenderedPrompt) PromptDiff {
+	empty := &RenderedPrompt{}
+	d := PromptDiff{Name: name, Status: PromptChanged}
+	switch {
+	case from == nil:
+		d.Status = PromptAdded
+		from = empty
+	case to == nil:
+		d.Status = PromptRemoved
+		to = empty
+	}
+
+	d.SystemPrompt = diffLines(from.SystemPrompt, to.SystemPrompt)
+	d.ToolsAdded = missingFrom(to.Tools, from.Tools)
+	d.ToolsRemoved = missingFrom(from.Tools, to.Tools)
+	d.Parameters = diffParameters(from.Parameters, to.Parameters)
+	d.Unresolved = to.Unresolved
+
+	if d.Status == PromptChanged && len(d.SystemPrompt) == 0 && len(d.ToolsAdded) == 0 &&
+		len(d.ToolsRemoved) == 0 && len(d.Parameters) == 0 {
+		d.Status = PromptUnchanged
+	}
+	return d
+}
+
+// diffLines returns the changed hunks between two texts, nil when equal.
+func diffLines(from, to string) []DiffHunk {
+	if from == to {
+		return nil
+	}
+	a, b := splitLines(from), splitLines(to)
+	m := difflib.NewMatcher(a, b)
+	var hunks []DiffHunk
+	for _, group := range m.GetGroupedOpCodes(diffContextLines) {
+		h := DiffHunk{FromLine: group[0].I1 + 1, ToLine: group[0].J1 + 1}
+		for _, op := range group {
+			if op.Tag == 'e' {
+				h.Lines = appendLines(h.Lines, LineEqual, a[op.I1:op.I2])
+				continue
+			}
+			if op.Tag == 'r' || op.Tag == 'd' {
+				h.Lines = appendLines(h.Lines, LineDelete, a[op.I1:op.I2])
+			}
+			if op.Tag == 'r' || op.Tag == 'i' {
+				h.Lines = appendLines(h.Lines, LineInsert, b[op.J1:op.J2])
+			}
+		}
+		hunks = append(hunks, h)
+	}
+	return hunks
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+func appendLines(out []DiffLine, op string, lines []string) []DiffLine {
+	for _, l := range lines {
+		out = append(out, DiffLine{Op: op, Text: l})
+	}
+	return out
+}
+
+// missingFrom returns the entries of a that are not in b, in a's order.
+func missingFrom(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, s := range b {
+		in[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if !in[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// diffParameters returns the parameters whose value differs; an unset
+// parameter is reported as an empty string.
+func diffParameters(from, to map[string]string) map[string]ValueChange {
+	out := map[string]ValueChange{}
+	for k, v := range from {
+		if to[k] != v {
+			out[k] = ValueChange{From: v, To: to[k]}
+		}
+	}
+	for k, v := range to {
+		if _, ok := from[k]; !ok {
+			out[k] = ValueChange{To: v}
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
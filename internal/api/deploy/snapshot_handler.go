@@ -0,0 +1,189 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/api/authz"
+	"github.com/altairalabs/omnia/internal/promptpack"
+)
+
+// errUnprocessablePack marks pack content that could not be rendered.
+var errUnprocessablePack = errors.New("pack content cannot be rendered")
+
+// SnapshotDiffRequest asks for the rendered difference between the current
+// version of a pack and a proposed one.
+type SnapshotDiffRequest struct {
+	// CurrentVersion pins the current side; empty selects the stable channel
+	// max, as an AgentRuntime without a version pin would.
+	CurrentVersion string `json:"currentVersion,omitempty"`
+	// Proposed is the pack as it would be applied.
+	Proposed ProposedPack `json:"proposed"`
+	// Variables override prompt variable defaults on both sides.
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// ProposedPack is either raw pack.json content (as a DeployIntent carries it)
+// or a PromptPack spec whose source is resolved in the workspace.
+type ProposedPack struct {
+	Version string                        `json:"version,omitempty"`
+	Content string                        `json:"content,omitempty"`
+	Spec    *omniav1alpha1.PromptPackSpec `json:"spec,omitempty"`
+}
+
+// SnapshotDiffResponse carries both snapshots and their diff.
+type SnapshotDiffResponse struct {
+	Current  *PackSnapshot `json:"current"`
+	Proposed *PackSnapshot `json:"proposed"`
+	Diff     SnapshotDiff  `json:"diff"`
+}
+
+// SnapshotHandler serves rendered PromptPack snapshots and diffs, so the
+// dashboard and CLI can preview exactly what an edit changes before applying it.
+type SnapshotHandler struct {
+	resolver *promptpack.Resolver
+	log      logr.Logger
+}
+
+// NewSnapshotHandler constructs a SnapshotHandler backed by a Kubernetes client.
+func NewSnapshotHandler(c client.Client, log logr.Logger) *SnapshotHandler {
+	return &SnapshotHandler{resolver: promptpack.NewResolver(c), log: log}
+}
+
+// Snapshot handles GET .../promptpacks/{packName}/snapshot?version=&var=name=value.
+func (h *SnapshotHandler) Snapshot(w http.ResponseWriter, r *http.Request) {
+	id, ok := authz.IdentityFromContext(r.Context())
+	if !ok || id.Namespace == "" {
+		http.Error(w, "missing request identity", http.StatusInternalServerError)
+		return
+	}
+	vars, err := queryVariables(r.URL.Query()["var"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	snap, err := h.deployed(r.Context(), id.Namespace, r.PathValue("packName"), r.URL.Query().Get("version"), vars)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, snap)
+}
+
+// Diff handles POST .../promptpacks/{packName}/diff. A pack with no version
+// yet diffs against an empty snapshot, so every prompt shows as added.
+func (h *SnapshotHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	id, ok := authz.IdentityFromContext(r.Context())
+	if !ok || id.Namespace == "" {
+		http.Error(w, "missing request identity", http.StatusInternalServerError)
+		return
+	}
+	var req SnapshotDiffRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxIntentBytes)).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if (req.Proposed.Content == "") == (req.Proposed.Spec == nil) {
+		http.Error(w, "exactly one of proposed.content or proposed.spec is required", http.StatusBadRequest)
+		return
+	}
+
+	packName := r.PathValue("packName")
+	current, err := h.current(r.Context(), id.Namespace, packName, req.CurrentVersion, req.Variables)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	proposed, err := h.proposed(r.Context(), id.Namespace, packName, req.Proposed, req.Variables)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, SnapshotDiffResponse{
+		Current:  current,
+		Proposed: proposed,
+		Diff:     diffSnapshots(current, proposed),
+	})
+}
+
+// current renders the diff's current side. When the pack has no version at
+// all (and none was pinned) it returns an empty snapshot.
+func (h *SnapshotHandler) current(ctx context.Context, namespace, packName, version string, vars map[string]string) (*PackSnapshot, error) {
+	snap, err := h.deployed(ctx, namespace, packName, version, vars)
+	if errors.Is(err, promptpack.ErrNotFound) && version == "" {
+		return &PackSnapshot{PackName: packName, Prompts: []RenderedPrompt{}}, nil
+	}
+	return snap, err
+}
+
+// deployed renders a version of packName that exists in the cluster: the
+// pinned one, or the stable channel max when version is empty.
+func (h *SnapshotHandler) deployed(ctx context.Context, namespace, packName, version string, vars map[string]string) (*PackSnapshot, error) {
+	pp, content, err := h.resolver.Resolve(ctx, namespace, packName, version)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := renderSnapshot(packName, pp.Spec.Version, content, vars)
+	if err != nil {
+		return nil, errors.Join(errUnprocessablePack, err)
+	}
+	return snap, nil
+}
+
+// proposed renders the proposed pack from its inline content or its spec's source.
+func (h *SnapshotHandler) proposed(ctx context.Context, namespace, packName string, p ProposedPack, vars map[string]string) (*PackSnapshot, error) {
+	content, version := []byte(p.Content), p.Version
+	if p.Spec != nil {
+		pp := &omniav1alpha1.PromptPack{Spec: *p.Spec}
+		pp.Name, pp.Namespace = packName, namespace
+		var err error
+		if content, err = h.resolver.LoadContent(ctx, pp); err != nil {
+			return nil, errors.Join(errUnprocessablePack, err)
+		}
+		version = p.Spec.Version
+	}
+	snap, err := renderSnapshot(packName, version, content, vars)
+	if err != nil {
+		return nil, errors.Join(errUnprocessablePack, err)
+	}
+	return snap, nil
+}
+
+// queryVariables parses repeated var=name=value query parameters.
+func queryVariables(params []string) (map[string]string, error) {
+	vars := make(map[string]string, len(params))
+	for _, p := range params {
+		name, value, ok := strings.Cut(p, "=")
+		if !ok || name == "" {
+			return nil, errors.New("var must be name=value")
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+func (h *SnapshotHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, promptpack.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, errUnprocessablePack):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		h.log.Error(err, "render PromptPack snapshot")
+		http.Error(w, "failed to render PromptPack", http.StatusInternalServerError)
+	}
+}
+
+func (h *SnapshotHandler) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.log.Error(err, "encode snapshot response")
+	}
+}
@@ -0,0 +1,256 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/promptpack/packselect"
+)
+
+const currentPackJSON = `{
+  "id": "support", "name": "support", "version": "1.0.0",
+  "fragments": {"tone": "Be {{style}}."},
+  "prompts": {
+    "triage": {
+      "id": "triage", "name": "triage", "version": "1.0.0",
+      "system_template": "You triage tickets for {{company}}.\n{{tone}}\nEscalate outages.",
+      "variables": [{"name": "company", "type": "string", "default": "Acme"}, {"name": "style", "type": "string"}],
+      "tools": ["lookup_order"],
+      "parameters": {"temperature": 0.2}
+    },
+    "billing": {"id": "billing", "name": "billing", "version": "1.0.0", "system_template": "Answer billing questions."}
+  }
+}`
+
+const proposedPackJSON = `{
+  "id": "support", "name": "support", "version": "1.1.0",
+  "fragments": {"tone": "Be {{style}}."},
+  "prompts": {
+    "triage": {
+      "id": "triage", "name": "triage", "version": "1.1.0",
+      "system_template": "You triage tickets for {{company}}.\n{{tone}}\nEscalate outages and data loss.",
+      "variables": [{"name": "company", "type": "string", "default": "Acme"}, {"name": "style", "type": "string"}],
+      "tools": ["lookup_order", "create_incident"],
+      "parameters": {"temperature": 0.4}
+    },
+    "refunds": {"id": "refunds", "name": "refunds", "version": "1.1.0", "system_template": "Handle refunds for {{company}}."}
+  }
+}`
+
+func packObjects(packName, version, content string) []client.Object {
+	name := omniav1alpha1.PromptPackObjectName(packName, version)
+	return []client.Object{
+		&omniav1alpha1.PromptPack{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: map[string]string{packselect.Label: packName}},
+			Spec: omniav1alpha1.PromptPackSpec{
+				PackName: packName,
+				Version:  version,
+				Source: omniav1alpha1.PromptPackContentSource{
+					Type:         omniav1alpha1.PromptPackSourceTypeConfigMap,
+					ConfigMapRef: &corev1.LocalObjectReference{Name: contentConfigMapName(name)},
+				},
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: contentConfigMapName(name), Namespace: "ns"},
+			Data:       map[string]string{packJSONKey: content},
+		},
+	}
+}
+
+func TestRenderSnapshot(t *testing.T) {
+	snap, err := renderSnapshot("support", "1.0.0", []byte(currentPackJSON), map[string]string{"style": "brief"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snap.Prompts) != 2 || snap.Prompts[0].Name != "billing" {
+		t.Fatalf("prompts = %+v, want billing then triage", snap.Prompts)
+	}
+	triage := snap.Prompts[1]
+	want := "You triage tickets for Acme.\nBe brief.\nEscalate outages."
+	if triage.SystemPrompt != want {
+		t.Errorf("systemPrompt = %q, want %q", triage.SystemPrompt, want)
+	}
+	if triage.Parameters["temperature"] != "0.2" {
+		t.Errorf("parameters = %v", triage.Parameters)
+	}
+
+	snap, err = renderSnapshot("support", "1.0.0", []byte(currentPackJSON), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := snap.Prompts[1].Unresolved; !reflect.DeepEqual(got, []string{"style"}) {
+		t.Errorf("unresolved = %v, want [style]", got)
+	}
+
+	if _, err := renderSnapshot("support", "1.0.0", []byte("{not json"), nil); err == nil {
+		t.Error("expected a parse error")
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	vars := map[string]string{"style": "brief"}
+	current, _ := renderSnapshot("support", "1.0.0", []byte(currentPackJSON), vars)
+	proposed, _ := renderSnapshot("support", "1.1.0", []byte(proposedPackJSON), vars)
+
+	d := diffSnapshots(current, proposed)
+	if !d.Changed || d.Version == nil || d.Version.To != "1.1.0" {
+		t.Fatalf("diff = %+v", d)
+	}
+	status := map[string]PromptDiff{}
+	for _, p := range d.Prompts {
+		status[p.Name] = p
+	}
+	if status["billing"].Status != PromptRemoved || status["refunds"].Status != PromptAdded {
+		t.Errorf("statuses = %+v", status)
+	}
+
+	triage := status["triage"]
+	if triage.Status != PromptChanged {
+		t.Fatalf("triage status = %s", triage.Status)
+	}
+	if !reflect.DeepEqual(triage.ToolsAdded, []string{"create_incident"}) || len(triage.ToolsRemoved) != 0 {
+		t.Errorf("tools added = %v removed = %v", triage.ToolsAdded, triage.ToolsRemoved)
+	}
+	if triage.Parameters["temperature"] != (ValueChange{From: "0.2", To: "0.4"}) {
+		t.Errorf("parameters = %v", triage.Parameters)
+	}
+	if len(triage.SystemPrompt) != 1 {
+		t.Fatalf("hunks = %+v", triage.SystemPrompt)
+	}
+	wantLines := []DiffLine{
+		{Op: LineEqual, Text: "You triage tickets for Acme."},
+		{Op: LineEqual, Text: "Be brief."},
+		{Op: LineDelete, Text: "Escalate outages."},
+		{Op: LineInsert, Text: "Escalate outages and data loss."},
+	}
+	if got := triage.SystemPrompt[0].Lines; !reflect.DeepEqual(got, wantLines) {
+		t.Errorf("lines = %+v", got)
+	}
+
+	if same := diffSnapshots(current, current); same.Changed {
+		t.Errorf("identical snapshots reported as changed: %+v", same)
+	}
+}
+
+func postDiff(t *testing.T, h *SnapshotHandler, req SnapshotDiffRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	body, _ := json.Marshal(req)
+	r := httptest.NewRequest("POST", "/diff", bytes.NewReader(body)).WithContext(testIdentityContext(context.Background(), "ns"))
+	r.SetPathValue("packName", "support")
+	w := httptest.NewRecorder()
+	h.Diff(w, r)
+	return w
+}
+
+func TestSnapshotHandler_Diff(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(packObjects("support", "1.0.0", currentPackJSON)...).Build()
+	h := NewSnapshotHandler(c, logr.Discard())
+
+	w := postDiff(t, h, SnapshotDiffRequest{
+		Proposed:  ProposedPack{Version: "1.1.0", Content: proposedPackJSON},
+		Variables: map[string]string{"style": "brief"},
+	})
+	if w.Code != 200 {
+		t.Fatalf("code = %d: %s", w.Code, w.Body.String())
+	}
+	var resp SnapshotDiffResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Current.Version != "1.0.0" || resp.Proposed.Version != "1.1.0" || !resp.Diff.Changed {
+		t.Errorf("response = %+v", resp)
+	}
+
+	if w := postDiff(t, h, SnapshotDiffRequest{}); w.Code != 400 {
+		t.Errorf("missing proposed: code = %d, want 400", w.Code)
+	}
+	if w := postDiff(t, h, SnapshotDiffRequest{Proposed: ProposedPack{Content: "{not json"}}); w.Code != 422 {
+		t.Errorf("bad content: code = %d, want 422", w.Code)
+	}
+	if w := postDiff(t, h, SnapshotDiffRequest{CurrentVersion: "9.9.9", Proposed: ProposedPack{Content: proposedPackJSON}}); w.Code != 404 {
+		t.Errorf("unknown pinned version: code = %d, want 404", w.Code)
+	}
+}
+
+func TestSnapshotHandler_DiffProposedSpec(t *testing.T) {
+	// The proposed version's ConfigMap exists but its PromptPack is not applied yet.
+	objs := append(packObjects("support", "1.0.0", currentPackJSON), packObjects("support", "1.1.0", proposedPackJSON)[1])
+	c := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(objs...).Build()
+	h := NewSnapshotHandler(c, logr.Discard())
+
+	name := omniav1alpha1.PromptPackObjectName("support", "1.1.0")
+	w := postDiff(t, h, SnapshotDiffRequest{Proposed: ProposedPack{Spec: &omniav1alpha1.PromptPackSpec{
+		PackName: "support",
+		Version:  "1.1.0",
+		Source: omniav1alpha1.PromptPackContentSource{
+			Type:         omniav1alpha1.PromptPackSourceTypeConfigMap,
+			ConfigMapRef: &corev1.LocalObjectReference{Name: contentConfigMapName(name)},
+		},
+	}}})
+	if w.Code != 200 {
+		t.Fatalf("code = %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestSnapshotHandler_DiffNewPack(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(testScheme(t)).Build()
+	h := NewSnapshotHandler(c, logr.Discard())
+
+	w := postDiff(t, h, SnapshotDiffRequest{Proposed: ProposedPack{Version: "1.0.0", Content: currentPackJSON}})
+	if w.Code != 200 {
+		t.Fatalf("code = %d: %s", w.Code, w.Body.String())
+	}
+	var resp SnapshotDiffResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range resp.Diff.Prompts {
+		if p.Status != PromptAdded {
+			t.Errorf("prompt %s status = %s, want added", p.Name, p.Status)
+		}
+	}
+}
+
+func TestSnapshotHandler_Snapshot(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(packObjects("support", "1.0.0", currentPackJSON)...).Build()
+	h := NewSnapshotHandler(c, logr.Discard())
+
+	get := func(packName, query string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/snapshot?"+query, nil).WithContext(testIdentityContext(context.Background(), "ns"))
+		r.SetPathValue("packName", packName)
+		w := httptest.NewRecorder()
+		h.Snapshot(w, r)
+		return w
+	}
+
+	w := get("support", "var=style%3Dterse")
+	if w.Code != 200 {
+		t.Fatalf("code = %d: %s", w.Code, w.Body.String())
+	}
+	var snap PackSnapshot
+	if err := json.Unmarshal(w.Body.Bytes(), &snap); err != nil {
+		t.Fatal(err)
+	}
+	if snap.Version != "1.0.0" || snap.Prompts[1].SystemPrompt != "You triage tickets for Acme.\nBe terse.\nEscalate outages." {
+		t.Errorf("snapshot = %+v", snap)
+	}
+
+	if w := get("missing", ""); w.Code != 404 {
+		t.Errorf("unknown pack: code = %d, want 404", w.Code)
+	}
+	if w := get("support", "var=novalue"); w.Code != 400 {
+		t.Errorf("malformed var: code = %d, want 400", w.Code)
+	}
+}
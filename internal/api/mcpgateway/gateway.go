@@ -0,0 +1,330 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcpgateway serves a workspace's ToolRegistry tools over MCP, so
+// agents running outside the cluster (IDE assistants, third-party agent
+// frameworks) can call the same tools Omnia agents use, under the same
+// ToolPolicy enforcement and with every call audited.
+package mcpgateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/api/authz"
+	facademcp "github.com/altairalabs/omnia/internal/facade/mcp"
+	"github.com/altairalabs/omnia/internal/runtime/tools"
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+// serverName is the MCP serverInfo name the gateway reports on initialize.
+const serverName = "omnia-mcp-gateway"
+
+// EntryBuilder converts a ToolRegistry into runtime handler entries with their
+// credentials resolved. tooltest.Tester implements it, so the gateway and the
+// dashboard's tool test prepare handlers identically.
+type EntryBuilder interface {
+	HandlerEntries(ctx context.Context, registry *omniav1alpha1.ToolRegistry) ([]tools.HandlerEntry, map[string]string)
+}
+
+// registryTools is an initialized executor for one ToolRegistry, valid for
+// the resourceVersion it was built from.
+type registryTools struct {
+	resourceVersion string
+	executor        *tools.OmniaExecutor
+	tools           []facademcp.Tool
+}
+
+// Gateway is the http.Handler behind the MCP route. It must be wrapped by the
+// authz middleware: the workspace namespace and the caller come from the
+// RequestIdentity it attaches.
+type Gateway struct {
+	client  client.Client
+	entries EntryBuilder
+	log     logr.Logger
+
+	mu         sync.Mutex
+	registries map[types.NamespacedName]*registryTools
+}
+
+// NewGateway creates a gateway that reads ToolRegistries through c.
+func NewGateway(c client.Client, entries EntryBuilder, log logr.Logger) *Gateway {
+	return &Gateway{
+		client:     c,
+		entries:    entries,
+		log:        log.WithName("mcp-gateway"),
+		registries: make(map[types.NamespacedName]*registryTools),
+	}
+}
+
+// ServeHTTP serves one MCP JSON-RPC request against the tools of the
+// caller's workspace.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := authz.IdentityFromContext(r.Context())
+	if !ok || id.Namespace == "" {
+		http.Error(w, "missing request identity", http.StatusInternalServerError)
+		return
+	}
+	adapter, err := g.workspaceTools(r.Context(), id)
+	if err != nil {
+		g.log.Error(err, "failed to load workspace tools", "namespace", id.Namespace)
+		http.Error(w, "failed to load tools", http.StatusInternalServerError)
+		return
+	}
+	facademcp.NewTransport(facademcp.TransportConfig{
+		Adapter:    adapter,
+		ServerInfo: facademcp.ServerInfo{Name: serverName, Version: "1"},
+		Log:        g.log,
+	}).ServeHTTP(w, r)
+}
+
+// Close shuts down every cached executor.
+func (g *Gateway) Close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for key, rt := range g.registries {
+		g.closeExecutor(key, rt)
+		delete(g.registries, key)
+	}
+}
+
+// workspaceTools returns an adapter over every ToolRegistry in the caller's
+// namespace, initializing executors for registries that are new or changed
+// since the last request.
+func (g *Gateway) workspaceTools(ctx context.Context, id *authz.RequestIdentity) (*toolAdapter, error) {
+	var list omniav1alpha1.ToolRegistryList
+	if err := g.client.List(ctx, &list, client.InNamespace(id.Namespace)); err != nil {
+		return nil, fmt.Errorf("list ToolRegistries: %w", err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+
+	adapter := &toolAdapter{gateway: g, identity: id, routes: make(map[string]toolRoute)}
+	live := make(map[types.NamespacedName]bool, len(list.Items))
+	for i := range list.Items {
+		registry := &list.Items[i]
+		key := client.ObjectKeyFromObject(registry)
+		live[key] = true
+		rt := g.registryTools(ctx, registry)
+		if rt == nil {
+			continue
+		}
+		for _, tool := range rt.tools {
+			// Registries are visited in name order, so on a clash the tool
+			// from the alphabetically first registry wins.
+			if prev, clash := adapter.routes[tool.Name]; clash {
+				g.log.V(1).Info("tool name served by an earlier registry",
+					"tool", tool.Name, "registry", registry.Name, "servedBy", prev.registry)
+				continue
+			}
+			adapter.routes[tool.Name] = toolRoute{registry: registry.Name, executor: rt.executor}
+			adapter.tools = append(adapter.tools, tool)
+		}
+	}
+	g.pruneDeleted(id.Namespace, live)
+	return adapter, nil
+}
+
+// registryTools returns the cached executor for registry, building a new one
+// when the registry changed. It returns nil when the registry has no usable
+// tools or its backends could not be initialized; the next request retries.
+func (g *Gateway) registryTools(ctx context.Context, registry *omniav1alpha1.ToolRegistry) *registryTools {
+	key := client.ObjectKeyFromObject(registry)
+	g.mu.Lock()
+	cached := g.registries[key]
+	g.mu.Unlock()
+	if cached != nil && cached.resourceVersion == registry.ResourceVersion {
+		return cached
+	}
+
+	built, err := g.buildRegistryTools(ctx, registry)
+	if err != nil {
+		g.log.Error(err, "failed to initialize ToolRegistry", "registry", registry.Name, "namespace", registry.Namespace)
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if current := g.registries[key]; current != nil {
+		if current.resourceVersion == registry.ResourceVersion {
+			// A concurrent request built the same version first.
+			g.closeExecutor(key, built)
+			return current
+		}
+		// Calls still running on the old executor may fail once it closes;
+		// that only happens while the registry is being edited.
+		g.closeExecutor(key, current)
+	}
+	g.registries[key] = built
+	return built
+}
+
+// buildRegistryTools initializes an executor for the registry's handlers that
+// can run inside the gateway.
+func (g *Gateway) buildRegistryTools(ctx context.Context, registry *omniav1alpha1.ToolRegistry) (*registryTools, error) {
+	entries, skipped := g.entries.HandlerEntries(ctx, registry)
+	entries = servableEntries(entries, skipped)
+	for handler, reason := range skipped {
+		g.log.Info("handler not served by the MCP gateway",
+			"registry", registry.Name, "namespace", registry.Namespace, "handler", handler, "reason", reason)
+	}
+
+	executor := tools.NewOmniaExecutor(g.log, nil)
+	if err := executor.LoadConfigFromEntries(entries); err != nil {
+		return nil, err
+	}
+	// MCP sessions opened here outlive the request that triggered the build,
+	// so they must not be bound to its context.
+	if err := executor.Initialize(context.WithoutCancel(ctx)); err != nil {
+		_ = executor.Close()
+		return nil, err
+	}
+	// Registry identity makes registry-scoped ToolPolicies match, exactly as
+	// they do for an agent bound to this registry.
+	executor.SetRegistryInfo(registry.Name, registry.Namespace, entries)
+	executor.SetPolicyDecisionFunc(g.auditDecision)
+
+	descs := executor.ToolDescriptors()
+	rt := &registryTools{
+		resourceVersion: registry.ResourceVersion,
+		executor:        executor,
+		tools:           make([]facademcp.Tool, 0, len(descs)),
+	}
+	for _, d := range descs {
+		rt.tools = append(rt.tools, facademcp.Tool{Name: d.Name, Description: d.Description, InputSchema: d.InputSchema})
+	}
+	sort.Slice(rt.tools, func(i, j int) bool { return rt.tools[i].Name < rt.tools[j].Name })
+	return rt, nil
+}
+
+// servableEntries drops handlers the gateway must not run: client tools are
+// executed by an end user's browser, and a stdio MCP server would be spawned
+// inside the operator pod. Dropped handlers are added to skipped.
+func servableEntries(entries []tools.HandlerEntry, skipped map[string]string) []tools.HandlerEntry {
+	kept := entries[:0]
+	for _, e := range entries {
+		switch {
+		case e.Type == string(omniav1alpha1.HandlerTypeClient):
+			skipped[e.Name] = "client tools run in the end user's browser"
+		case e.MCPConfig != nil && e.MCPConfig.Transport == string(omniav1alpha1.MCPTransportStdio):
+			skipped[e.Name] = "stdio MCP servers are not started by the gateway"
+		default:
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// pruneDeleted closes executors for registries in namespace that no longer
+// exist.
+func (g *Gateway) pruneDeleted(namespace string, live map[types.NamespacedName]bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for key, rt := range g.registries {
+		if key.Namespace == namespace && !live[key] {
+			g.closeExecutor(key, rt)
+			delete(g.registries, key)
+		}
+	}
+}
+
+func (g *Gateway) closeExecutor(key types.NamespacedName, rt *registryTools) {
+	if err := rt.executor.Close(); err != nil {
+		g.log.Error(err, "failed to close executor", "registry", key.Name, "namespace", key.Namespace)
+	}
+}
+
+// auditDecision records the policy broker's decision on a gateway call.
+func (g *Gateway) auditDecision(ctx context.Context, toolName string, _ json.RawMessage, decision *policy.DecisionResponse) {
+	fields := policy.ExtractPropagationFields(ctx)
+	g.log.Info("mcp gateway policy decision",
+		"workspace", fields.Workspace,
+		"namespace", fields.Namespace,
+		"user", fields.UserID,
+		"tool", toolName,
+		"allow", decision.Allow,
+		"wouldDeny", decision.WouldDeny,
+		"deniedBy", decision.DeniedBy)
+}
+
+// toolRoute names the registry serving a tool and its executor.
+type toolRoute struct {
+	registry string
+	executor *tools.OmniaExecutor
+}
+
+// toolAdapter is the facade MCP ToolAdapter for one request: the workspace's
+// tools and the caller they are served to.
+type toolAdapter struct {
+	gateway  *Gateway
+	identity *authz.RequestIdentity
+	tools    []facademcp.Tool
+	routes   map[string]toolRoute
+}
+
+// ListTools implements facademcp.ToolAdapter.
+func (a *toolAdapter) ListTools() []facademcp.Tool {
+	return a.tools
+}
+
+// CallTool implements facademcp.ToolAdapter. The caller's identity is put on
+// the context the executor hands to the policy broker, so identity- and
+// workspace-scoped ToolPolicy rules apply to gateway calls.
+func (a *toolAdapter) CallTool(ctx context.Context, name string, arguments json.RawMessage) facademcp.CallToolResult {
+	route, ok := a.routes[name]
+	if !ok {
+		return textResult(true, "tool_not_found: "+name)
+	}
+	ctx = policy.WithPropagationFields(ctx, &policy.PropagationFields{
+		Namespace: a.identity.Namespace,
+		Workspace: a.identity.Workspace,
+		UserID:    a.identity.Subject,
+		UserEmail: a.identity.Identity,
+		Origin:    policy.OriginMCPGateway,
+	})
+
+	start := time.Now()
+	result, err := route.executor.ExecuteTool(ctx, name, arguments)
+	a.gateway.log.Info("mcp gateway tool call",
+		"workspace", a.identity.Workspace,
+		"namespace", a.identity.Namespace,
+		"user", a.identity.Subject,
+		"role", a.identity.Role,
+		"tool", name,
+		"registry", route.registry,
+		"success", err == nil,
+		"durationMs", time.Since(start).Milliseconds())
+	if err != nil {
+		return textResult(true, "runtime_error: "+err.Error())
+	}
+	return textResult(false, string(result))
+}
+
+func textResult(isError bool, text string) facademcp.CallToolResult {
+	return facademcp.CallToolResult{
+		IsError: isError,
+		Content: []facademcp.ContentPart{{Type: facademcp.ContentTypeText, Text: text}},
+	}
+}
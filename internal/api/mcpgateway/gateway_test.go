@@ -0,0 +1,183 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mcpgateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/api/authz"
+	facademcp "github.com/altairalabs/omnia/internal/facade/mcp"
+	"github.com/altairalabs/omnia/internal/tooltest"
+	"github.com/altairalabs/omnia/pkg/workspaceauth"
+)
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+	require.NoError(t, omniav1alpha1.AddToScheme(s))
+	return s
+}
+
+// echoBackend is an HTTP tool backend that reports which handler was called.
+func echoBackend(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"path": r.URL.Path, "args": string(body)})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func httpHandler(name, tool, endpoint string) omniav1alpha1.HandlerDefinition {
+	return omniav1alpha1.HandlerDefinition{
+		Name:       name,
+		Type:       omniav1alpha1.HandlerTypeHTTP,
+		HTTPConfig: &omniav1alpha1.HTTPConfig{Endpoint: endpoint, Method: http.MethodPost},
+		Tool: &omniav1alpha1.ToolDefinition{
+			Name:        tool,
+			Description: "tool " + tool,
+			InputSchema: apiextensionsv1.JSON{Raw: []byte(`{"type":"object"}`)},
+		},
+	}
+}
+
+func toolRegistry(ns, name string, handlers ...omniav1alpha1.HandlerDefinition) *omniav1alpha1.ToolRegistry {
+	return &omniav1alpha1.ToolRegistry{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Spec:       omniav1alpha1.ToolRegistrySpec{Handlers: handlers},
+	}
+}
+
+func newGateway(t *testing.T, objs ...client.Object) (*Gateway, client.Client) {
+	t.Helper()
+	c := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(objs...).Build()
+	g := NewGateway(c, tooltest.NewTester(c, logr.Discard()), logr.Discard())
+	t.Cleanup(g.Close)
+	return g, c
+}
+
+func testIdentity(ns string) *authz.RequestIdentity {
+	return &authz.RequestIdentity{
+		VerifiedIdentity: &authz.VerifiedIdentity{Subject: "u@x.io", Identity: "u@x.io", Workspace: "ws"},
+		Role:             workspaceauth.RoleEditor,
+		Namespace:        ns,
+	}
+}
+
+// rpc sends one JSON-RPC request to the gateway as the test identity.
+func rpc(t *testing.T, g *Gateway, method string, params any) json.RawMessage {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/mcp", bytes.NewReader(body))
+	req = req.WithContext(authz.ContextWithIdentity(req.Context(), testIdentity("team-a")))
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp facademcp.JSONRPCResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Nil(t, resp.Error)
+	return resp.Result
+}
+
+func listTools(t *testing.T, g *Gateway) []facademcp.Tool {
+	t.Helper()
+	var res facademcp.ListToolsResult
+	require.NoError(t, json.Unmarshal(rpc(t, g, facademcp.MethodToolsList, nil), &res))
+	return res.Tools
+}
+
+func callTool(t *testing.T, g *Gateway, name string) facademcp.CallToolResult {
+	t.Helper()
+	var res facademcp.CallToolResult
+	params := facademcp.CallToolParams{Name: name, Arguments: json.RawMessage(`{"q":"hi"}`)}
+	require.NoError(t, json.Unmarshal(rpc(t, g, facademcp.MethodToolsCall, params), &res))
+	return res
+}
+
+func TestGateway_ServesWorkspaceRegistries(t *testing.T) {
+	backend := echoBackend(t)
+	g, _ := newGateway(t,
+		toolRegistry("team-a", "alpha",
+			httpHandler("search", "search", backend.URL+"/alpha"),
+			omniav1alpha1.HandlerDefinition{Name: "ui", Type: omniav1alpha1.HandlerTypeClient}),
+		toolRegistry("team-a", "beta",
+			httpHandler("search", "search", backend.URL+"/beta"),
+			httpHandler("lookup", "lookup", backend.URL+"/lookup")),
+		toolRegistry("team-b", "other", httpHandler("secret", "secret", backend.URL+"/other")),
+	)
+
+	var names []string
+	for _, tool := range listTools(t, g) {
+		names = append(names, tool.Name)
+	}
+	assert.ElementsMatch(t, []string{"search", "lookup"}, names)
+
+	res := callTool(t, g, "search")
+	require.False(t, res.IsError, res.Content)
+	assert.Contains(t, res.Content[0].Text, `"path":"/alpha"`, "first registry by name wins a tool-name clash")
+	assert.Contains(t, res.Content[0].Text, `\"q\":\"hi\"`)
+
+	res = callTool(t, g, "secret")
+	assert.True(t, res.IsError, "tools of another workspace must not be callable")
+}
+
+func TestGateway_RebuildsChangedRegistries(t *testing.T) {
+	backend := echoBackend(t)
+	g, c := newGateway(t, toolRegistry("team-a", "alpha", httpHandler("search", "search", backend.URL+"/v1")))
+	assert.Contains(t, callTool(t, g, "search").Content[0].Text, "/v1")
+
+	var reg omniav1alpha1.ToolRegistry
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "team-a", Name: "alpha"}, &reg))
+	reg.Spec.Handlers = []omniav1alpha1.HandlerDefinition{httpHandler("search", "search", backend.URL+"/v2")}
+	require.NoError(t, c.Update(context.Background(), &reg))
+	assert.Contains(t, callTool(t, g, "search").Content[0].Text, "/v2")
+
+	require.NoError(t, c.Delete(context.Background(), &reg))
+	assert.Empty(t, listTools(t, g))
+	g.mu.Lock()
+	assert.Empty(t, g.registries)
+	g.mu.Unlock()
+}
+
+func TestGateway_RequiresIdentity(t *testing.T) {
+	g, _ := newGateway(t)
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", bytes.NewReader([]byte("{}"))))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
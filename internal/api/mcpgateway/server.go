@@ -0,0 +1,86 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mcpgateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/internal/api/authz"
+)
+
+// routePath is the gateway's Streamable HTTP MCP endpoint; {workspace} is
+// consumed by the authz middleware.
+const routePath = "/api/v1/workspaces/{workspace}/mcp"
+
+// Server hosts the MCP gateway. The MCP route is wrapped by the authz
+// middleware, and MCP only uses POST, so every caller needs at least the
+// editor role on the workspace.
+type Server struct {
+	addr    string
+	log     logr.Logger
+	gateway *Gateway
+	server  *http.Server
+}
+
+// NewServer builds an MCP gateway server.
+func NewServer(addr string, gateway *Gateway, authorizer *authz.Authorizer, log logr.Logger) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("POST "+routePath, authorizer.Middleware(gateway))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	return &Server{
+		addr:    addr,
+		log:     log,
+		gateway: gateway,
+		server: &http.Server{
+			Addr:         addr,
+			Handler:      mux,
+			ReadTimeout:  30 * time.Second,
+			WriteTimeout: 90 * time.Second,
+			IdleTimeout:  120 * time.Second,
+		},
+	}
+}
+
+// Start runs the server until ctx is cancelled or ListenAndServe fails.
+func (s *Server) Start(ctx context.Context) error {
+	s.log.Info("starting MCP gateway server", "addr", s.addr)
+	errCh := make(chan error, 1)
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+// Shutdown gracefully stops the server and closes the tool connections it
+// holds open.
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.server.Shutdown(ctx)
+	s.gateway.Close()
+	return err
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mcpgateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/api/authz"
+	"github.com/altairalabs/omnia/pkg/facade/auth"
+	"github.com/altairalabs/omnia/pkg/workspaceauth"
+)
+
+type fakeWS struct{ ns string }
+
+func (f fakeWS) Resolve(_ context.Context, _ string) (authz.ResolvedWorkspace, error) {
+	return authz.ResolvedWorkspace{
+		Namespace: f.ns,
+		Inputs: workspaceauth.Inputs{
+			RoleBindings: []workspaceauth.RoleBinding{
+				{Groups: []string{"editors"}, Role: workspaceauth.RoleEditor},
+				{Groups: []string{"viewers"}, Role: workspaceauth.RoleViewer},
+			},
+		},
+	}, nil
+}
+
+func mintToken(t *testing.T, key *rsa.PrivateKey, workspace string, groups []string) string {
+	t.Helper()
+	now := time.Now()
+	claims := authz.IdentityClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    authz.IssuerDashboard,
+			Audience:  jwt.ClaimStrings{authz.AudienceContentAPI},
+			Subject:   "u@x.io",
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(5 * time.Minute)),
+		},
+		Identity: "u@x.io", Groups: groups, Workspace: workspace,
+	}
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tok.Header["kid"] = "k1"
+	signed, err := tok.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestServer_Auth(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	resolver := &auth.StaticKeyResolver{Keys: map[string]*rsa.PublicKey{"k1": &key.PublicKey}}
+	authorizer := authz.NewAuthorizer(authz.NewIdentityVerifier(resolver), fakeWS{ns: "team-a"})
+	g, _ := newGateway(t)
+	srv := NewServer("127.0.0.1:0", g, authorizer, logr.Discard())
+	ts := httptest.NewServer(srv.server.Handler)
+	defer ts.Close()
+
+	initialize := []byte(`{"jsonrpc":"2.0","id":1,"method":"initialize"}`)
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{name: "no token", want: http.StatusUnauthorized},
+		{name: "other workspace", token: mintToken(t, key, "other", []string{"editors"}), want: http.StatusForbidden},
+		{name: "viewer", token: mintToken(t, key, "ws", []string{"viewers"}), want: http.StatusForbidden},
+		{name: "editor", token: mintToken(t, key, "ws", []string{"editors"}), want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, ts.URL+"/api/v1/workspaces/ws/mcp", bytes.NewReader(initialize))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+}
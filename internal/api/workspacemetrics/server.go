@@ -0,0 +1,131 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacemetrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/model"
+
+	"github.com/altairalabs/omnia/internal/api/authz"
+)
+
+// routePath is the summary endpoint; {workspace} is consumed by the authz
+// middleware.
+const routePath = "/api/v1/workspaces/{workspace}/metrics/summary"
+
+// Server hosts the workspace metrics API. The summary route is GET-only and
+// wrapped by the authz middleware, so any workspace viewer can read it.
+type Server struct {
+	addr   string
+	log    logr.Logger
+	server *http.Server
+}
+
+// NewServer builds a workspace metrics API server.
+func NewServer(addr string, aggregator *Aggregator, authorizer *authz.Authorizer, log logr.Logger) *Server {
+	mux := http.NewServeMux()
+	h := &handler{aggregator: aggregator, log: log}
+	mux.Handle("GET "+routePath, authorizer.Middleware(http.HandlerFunc(h.summary)))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	return &Server{
+		addr: addr,
+		log:  log,
+		server: &http.Server{
+			Addr:         addr,
+			Handler:      mux,
+			ReadTimeout:  30 * time.Second,
+			WriteTimeout: 90 * time.Second,
+			IdleTimeout:  120 * time.Second,
+		},
+	}
+}
+
+// Start runs the server until ctx is cancelled or ListenAndServe fails.
+func (s *Server) Start(ctx context.Context) error {
+	s.log.Info("starting workspace metrics API server", "addr", s.addr)
+	errCh := make(chan error, 1)
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+// Shutdown gracefully stops the server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+type handler struct {
+	aggregator *Aggregator
+	log        logr.Logger
+}
+
+// summary handles GET .../metrics/summary?window=1h.
+func (h *handler) summary(w http.ResponseWriter, r *http.Request) {
+	id, ok := authz.IdentityFromContext(r.Context())
+	if !ok || id.Namespace == "" {
+		http.Error(w, "missing request identity", http.StatusInternalServerError)
+		return
+	}
+	window, err := parseWindow(r.URL.Query().Get("window"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	summary, err := h.aggregator.Summarize(r.Context(), id.Namespace, window)
+	if err != nil {
+		h.log.Error(err, "workspace metrics query failed", "namespace", id.Namespace)
+		http.Error(w, "metrics backend unavailable", http.StatusBadGateway)
+		return
+	}
+	summary.Workspace = r.PathValue("workspace")
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		h.log.Error(err, "encode workspace metrics summary")
+	}
+}
+
+// parseWindow parses a Prometheus duration (e.g. "15m", "1h", "1d"),
+// defaulting to DefaultWindow and bounded to [MinWindow, MaxWindow].
+func parseWindow(raw string) (time.Duration, error) {
+	if raw == "" {
+		return DefaultWindow, nil
+	}
+	d, err := model.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid window %q: %w", raw, err)
+	}
+	window := time.Duration(d)
+	if window < MinWindow || window > MaxWindow {
+		return 0, fmt.Errorf("window must be between %s and %s", model.Duration(MinWindow), model.Duration(MaxWindow))
+	}
+	return window, nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacemetrics
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/api/authz"
+	"github.com/altairalabs/omnia/pkg/facade/auth"
+	"github.com/altairalabs/omnia/pkg/workspaceauth"
+)
+
+type fakeWS struct{ ns string }
+
+func (f fakeWS) Resolve(_ context.Context, _ string) (authz.ResolvedWorkspace, error) {
+	return authz.ResolvedWorkspace{
+		Namespace: f.ns,
+		Inputs: workspaceauth.Inputs{
+			RoleBindings: []workspaceauth.RoleBinding{
+				{Groups: []string{"viewers"}, Role: workspaceauth.RoleViewer},
+			},
+		},
+	}, nil
+}
+
+func mintToken(t *testing.T, key *rsa.PrivateKey, workspace string, groups []string) string {
+	t.Helper()
+	now := time.Now()
+	claims := authz.IdentityClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    authz.IssuerDashboard,
+			Audience:  jwt.ClaimStrings{authz.AudienceContentAPI},
+			Subject:   "u@x.io",
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(5 * time.Minute)),
+		},
+		Identity: "u@x.io", Groups: groups, Workspace: workspace,
+	}
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tok.Header["kid"] = "k1"
+	signed, err := tok.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func newTestServer(t *testing.T, q Querier) (*httptest.Server, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	resolver := &auth.StaticKeyResolver{Keys: map[string]*rsa.PublicKey{"k1": &key.PublicKey}}
+	authorizer := authz.NewAuthorizer(authz.NewIdentityVerifier(resolver), fakeWS{ns: "team-a"})
+	srv := NewServer("127.0.0.1:0", NewAggregator(q), authorizer, logr.Discard())
+	ts := httptest.NewServer(srv.server.Handler)
+	t.Cleanup(ts.Close)
+	return ts, key
+}
+
+func getSummary(t *testing.T, url, token string) *http.Response {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestServer_Summary(t *testing.T) {
+	q := &fakeQuerier{vectors: workspaceVectors()}
+	ts, key := newTestServer(t, q)
+	url := ts.URL + "/api/v1/workspaces/ws/metrics/summary"
+
+	assert.Equal(t, http.StatusUnauthorized, getSummary(t, url, "").StatusCode)
+	assert.Equal(t, http.StatusForbidden,
+		getSummary(t, url, mintToken(t, key, "other", []string{"viewers"})).StatusCode)
+
+	token := mintToken(t, key, "ws", []string{"viewers"})
+	resp := getSummary(t, url+"?window=15m", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var summary Summary
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&summary))
+	assert.Equal(t, "ws", summary.Workspace)
+	assert.Equal(t, "team-a", summary.Namespace)
+	assert.Equal(t, "15m", summary.Window)
+	assert.Len(t, summary.Agents, 2)
+
+	assert.Equal(t, http.StatusBadRequest, getSummary(t, url+"?window=30d", token).StatusCode)
+}
+
+func TestServer_SummaryBackendError(t *testing.T) {
+	ts, key := newTestServer(t, &fakeQuerier{err: errors.New("connection refused")})
+	resp := getSummary(t, ts.URL+"/api/v1/workspaces/ws/metrics/summary",
+		mintToken(t, key, "ws", []string{"viewers"}))
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package workspacemetrics aggregates a workspace's key Prometheus metrics
+// (session volume, error rate, cost, eval pass rate) server-side, so the
+// dashboard can chart them per workspace without raw Prometheus access.
+package workspacemetrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// Metric names the summary is built from. The agent series come from the
+// facade; the session, cost and eval series from session-api.
+const (
+	metricAgentRequests   = "omnia_agent_requests_total"
+	metricSessionsActive  = "omnia_agent_sessions_active"
+	metricSessionsCreated = "omnia_session_api_sessions_created_total"
+	metricProviderCost    = "omnia_session_api_provider_cost_usd_total"
+	metricEvalResults     = "omnia_session_api_eval_results_total"
+)
+
+// Window bounds. The default matches the dashboard's overview charts.
+const (
+	DefaultWindow = time.Hour
+	MinWindow     = 5 * time.Minute
+	MaxWindow     = 7 * 24 * time.Hour
+)
+
+// Querier runs an instant PromQL query. promv1.API satisfies it.
+type Querier interface {
+	Query(ctx context.Context, query string, ts time.Time, opts ...promv1.Option) (model.Value, promv1.Warnings, error)
+}
+
+// NewPrometheusQuerier returns a Querier for the Prometheus server at address.
+func NewPrometheusQuerier(address string) (Querier, error) {
+	client, err := promapi.NewClient(promapi.Config{Address: address})
+	if err != nil {
+		return nil, fmt.Errorf("create prometheus client: %w", err)
+	}
+	return promv1.NewAPI(client), nil
+}
+
+// Totals are the aggregated metrics of a workspace or one of its agents over
+// the window. Ratios are nil when their denominator is zero, so "no traffic"
+// is distinguishable from "0% errors".
+type Totals struct {
+	SessionsCreated   float64  `json:"sessionsCreated"`
+	SessionsPerMinute float64  `json:"sessionsPerMinute"`
+	ActiveSessions    float64  `json:"activeSessions"`
+	Requests          float64  `json:"requests"`
+	Errors            float64  `json:"errors"`
+	ErrorRate         *float64 `json:"errorRate,omitempty"`
+	CostUSD           float64  `json:"costUSD"`
+	EvalsPassed       float64  `json:"evalsPassed"`
+	EvalsFailed       float64  `json:"evalsFailed"`
+	EvalPassRate      *float64 `json:"evalPassRate,omitempty"`
+}
+
+// AgentSummary is one agent's share of the workspace totals.
+type AgentSummary struct {
+	Agent string `json:"agent"`
+	Totals
+}
+
+// Summary is the response of the workspace metrics summary endpoint.
+type Summary struct {
+	Workspace string         `json:"workspace"`
+	Namespace string         `json:"namespace"`
+	Window    string         `json:"window"`
+	Time      time.Time      `json:"time"`
+	Totals    Totals         `json:"totals"`
+	Agents    []AgentSummary `json:"agents"`
+}
+
+// Aggregator builds workspace summaries from Prometheus.
+type Aggregator struct {
+	querier Querier
+	now     func() time.Time
+}
+
+// NewAggregator creates an Aggregator over querier.
+func NewAggregator(querier Querier) *Aggregator {
+	return &Aggregator{querier: querier, now: time.Now}
+}
+
+// Summarize aggregates the metrics of every agent in namespace over window.
+// The namespace is the only label callers influence, and it comes from the
+// authorized workspace, so a caller can never read another workspace's series.
+func (a *Aggregator) Summarize(ctx context.Context, namespace string, window time.Duration) (*Summary, error) {
+	now := a.now()
+	sel := fmt.Sprintf("namespace=%q", namespace)
+	rng := model.Duration(window).String()
+	agents := map[string]*Totals{}
+
+	queries := []struct {
+		query string
+		apply func(t *Totals, s *model.Sample)
+	}{
+		{
+			query: fmt.Sprintf("sum by (agent) (increase(%s{%s}[%s]))", metricSessionsCreated, sel, rng),
+			apply: func(t *Totals, s *model.Sample) { t.SessionsCreated += float64(s.Value) },
+		},
+		{
+			query: fmt.Sprintf("sum by (agent) (%s{%s})", metricSessionsActive, sel),
+			apply: func(t *Totals, s *model.Sample) { t.ActiveSessions += float64(s.Value) },
+		},
+		{
+			query: fmt.Sprintf("sum by (agent, status) (increase(%s{%s}[%s]))", metricAgentRequests, sel, rng),
+			apply: func(t *Totals, s *model.Sample) {
+				t.Requests += float64(s.Value)
+				if s.Metric["status"] == "error" {
+					t.Errors += float64(s.Value)
+				}
+			},
+		},
+		{
+			query: fmt.Sprintf("sum by (agent) (increase(%s{%s}[%s]))", metricProviderCost, sel, rng),
+			apply: func(t *Totals, s *model.Sample) { t.CostUSD += float64(s.Value) },
+		},
+		{
+			query: fmt.Sprintf("sum by (agent, result) (increase(%s{%s}[%s]))", metricEvalResults, sel, rng),
+			apply: func(t *Totals, s *model.Sample) {
+				switch s.Metric["result"] {
+				case "passed":
+					t.EvalsPassed += float64(s.Value)
+				case "failed":
+					t.EvalsFailed += float64(s.Value)
+				}
+			},
+		},
+	}
+
+	for _, q := range queries {
+		vec, err := a.queryVector(ctx, q.query, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range vec {
+			agent := string(s.Metric["agent"])
+			t, ok := agents[agent]
+			if !ok {
+				t = &Totals{}
+				agents[agent] = t
+			}
+			q.apply(t, s)
+		}
+	}
+
+	summary := &Summary{
+		Namespace: namespace,
+		Window:    rng,
+		Time:      now.UTC(),
+		Agents:    make([]AgentSummary, 0, len(agents)),
+	}
+	for agent, t := range agents {
+		t.finish(window)
+		summary.Totals.add(t)
+		summary.Agents = append(summary.Agents, AgentSummary{Agent: agent, Totals: *t})
+	}
+	summary.Totals.finish(window)
+	sort.Slice(summary.Agents, func(i, j int) bool { return summary.Agents[i].Agent < summary.Agents[j].Agent })
+	return summary, nil
+}
+
+func (a *Aggregator) queryVector(ctx context.Context, query string, ts time.Time) (model.Vector, error) {
+	result, _, err := a.querier.Query(ctx, query, ts)
+	if err != nil {
+		return nil, fmt.Errorf("execute query %q: %w", query, err)
+	}
+	vec, ok := result.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("query %q: unsupported result type %T", query, result)
+	}
+	return vec, nil
+}
+
+// add sums the counts of o into t; ratios are derived afterwards by finish.
+func (t *Totals) add(o *Totals) {
+	t.SessionsCreated += o.SessionsCreated
+	t.ActiveSessions += o.ActiveSessions
+	t.Requests += o.Requests
+	t.Errors += o.Errors
+	t.CostUSD += o.CostUSD
+	t.EvalsPassed += o.EvalsPassed
+	t.EvalsFailed += o.EvalsFailed
+}
+
+// finish derives the per-minute rate and ratios from the counts.
+func (t *Totals) finish(window time.Duration) {
+	t.SessionsPerMinute = t.SessionsCreated / window.Minutes()
+	t.ErrorRate = ratio(t.Errors, t.Requests)
+	t.EvalPassRate = ratio(t.EvalsPassed, t.EvalsPassed+t.EvalsFailed)
+}
+
+func ratio(num, den float64) *float64 {
+	if den <= 0 {
+		return nil
+	}
+	r := num / den
+	return &r
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacemetrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQuerier answers each query with the vector of the first metric name it
+// contains, and records the queries it ran.
+type fakeQuerier struct {
+	vectors map[string]model.Vector
+	err     error
+	queries []string
+}
+
+func (f *fakeQuerier) Query(_ context.Context, query string, _ time.Time, _ ...promv1.Option) (model.Value, promv1.Warnings, error) {
+	f.queries = append(f.queries, query)
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	for name, vec := range f.vectors {
+		if strings.Contains(query, name+"{") {
+			return vec, nil, nil
+		}
+	}
+	return model.Vector{}, nil, nil
+}
+
+func sample(value float64, labels ...string) *model.Sample {
+	metric := model.Metric{}
+	for i := 0; i+1 < len(labels); i += 2 {
+		metric[model.LabelName(labels[i])] = model.LabelValue(labels[i+1])
+	}
+	return &model.Sample{Metric: metric, Value: model.SampleValue(value)}
+}
+
+func workspaceVectors() map[string]model.Vector {
+	return map[string]model.Vector{
+		metricSessionsCreated: {sample(60, "agent", "support"), sample(30, "agent", "sales")},
+		metricSessionsActive:  {sample(4, "agent", "support")},
+		metricAgentRequests: {
+			sample(90, "agent", "support", "status", "success"),
+			sample(10, "agent", "support", "status", "error"),
+			sample(50, "agent", "sales", "status", "success"),
+		},
+		metricProviderCost: {sample(1.5, "agent", "support"), sample(0.5, "agent", "sales")},
+		metricEvalResults: {
+			sample(8, "agent", "support", "result", "passed"),
+			sample(2, "agent", "support", "result", "failed"),
+		},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	q := &fakeQuerier{vectors: workspaceVectors()}
+	summary, err := NewAggregator(q).Summarize(context.Background(), "team-a", 30*time.Minute)
+	require.NoError(t, err)
+
+	assert.Equal(t, "team-a", summary.Namespace)
+	assert.Equal(t, "30m", summary.Window)
+	for _, query := range q.queries {
+		assert.Contains(t, query, `namespace="team-a"`)
+	}
+
+	total := summary.Totals
+	assert.InDelta(t, 3.0, total.SessionsPerMinute, 1e-9)
+	assert.Equal(t, 4.0, total.ActiveSessions)
+	assert.Equal(t, 150.0, total.Requests)
+	require.NotNil(t, total.ErrorRate)
+	assert.InDelta(t, 10.0/150, *total.ErrorRate, 1e-9)
+	assert.InDelta(t, 2.0, total.CostUSD, 1e-9)
+	require.NotNil(t, total.EvalPassRate)
+	assert.InDelta(t, 0.8, *total.EvalPassRate, 1e-9)
+
+	require.Len(t, summary.Agents, 2)
+	sales, support := summary.Agents[0], summary.Agents[1]
+	assert.Equal(t, "sales", sales.Agent)
+	assert.Equal(t, 0.0, *sales.ErrorRate)
+	assert.Nil(t, sales.EvalPassRate, "no evals ran for sales")
+	assert.Equal(t, "support", support.Agent)
+	assert.InDelta(t, 0.1, *support.ErrorRate, 1e-9)
+	assert.InDelta(t, 2.0, support.SessionsPerMinute, 1e-9)
+}
+
+func TestSummarize_NoTraffic(t *testing.T) {
+	summary, err := NewAggregator(&fakeQuerier{}).Summarize(context.Background(), "team-a", time.Hour)
+	require.NoError(t, err)
+	assert.Empty(t, summary.Agents)
+	assert.Nil(t, summary.Totals.ErrorRate)
+	assert.Nil(t, summary.Totals.EvalPassRate)
+}
+
+func TestSummarize_QueryError(t *testing.T) {
+	_, err := NewAggregator(&fakeQuerier{err: errors.New("connection refused")}).
+		Summarize(context.Background(), "team-a", time.Hour)
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestParseWindow(t *testing.T) {
+	d, err := parseWindow("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultWindow, d)
+
+	d, err = parseWindow("1d")
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, d)
+
+	for _, bad := range []string{"1m", "8d", "soon"} {
+		_, err := parseWindow(bad)
+		assert.Error(t, err, bad)
+	}
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package canary implements synthetic canary probes for agents. The operator
+// periodically opens a WebSocket to every Ready AgentRuntime, runs a scripted
+// conversation against whatever provider the agent is configured with (mock or
+// real), labels the resulting session as canary traffic, and exports success
+// and latency metrics for alerting.
+package canary
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/pkg/servicediscovery"
+	"github.com/altairalabs/omnia/pkg/session/httpclient"
+)
+
+// AnnotationCanary opts an AgentRuntime out of canary probes when set to
+// "disabled".
+const AnnotationCanary = "omnia.altairalabs.ai/canary"
+
+// annotationValueDisabled is the AnnotationCanary value that skips an agent.
+const annotationValueDisabled = "disabled"
+
+// Session labels applied to canary sessions. The facade tags every WebSocket
+// session "source:interactive"; the canary replaces it so synthetic traffic
+// is not counted as user sessions.
+const (
+	sourceInteractiveTag = "source:interactive"
+	sourceCanaryTag      = "source:canary"
+	canaryResultTagKey   = "canary:"
+)
+
+// Defaults applied by NewRunner to zero Config fields.
+const (
+	DefaultInterval    = 5 * time.Minute
+	DefaultTimeout     = 2 * time.Minute
+	DefaultConcurrency = 4
+
+	defaultFacadePort   = 8080
+	defaultServiceGroup = "default"
+	readyConditionType  = "Ready"
+)
+
+// Config configures a Runner.
+type Config struct {
+	// Interval is the time between probe rounds.
+	Interval time.Duration
+	// Timeout bounds each agent's probe conversation.
+	Timeout time.Duration
+	// Concurrency is the number of agents probed at once.
+	Concurrency int
+}
+
+// sessionDecorator is the part of session.Store the runner uses to label
+// canary sessions.
+type sessionDecorator interface {
+	DecorateSession(ctx context.Context, sessionID string, opts session.DecorateSessionOptions) error
+}
+
+// Runner probes every Ready AgentRuntime on an interval. It implements
+// manager.Runnable and, as the default for runnables, only runs on the leader,
+// so replicas of the operator do not probe the same agents.
+type Runner struct {
+	client  client.Client
+	prober  *Prober
+	metrics *Metrics
+	cfg     Config
+	log     logr.Logger
+
+	// facadeURL and decoratorFor are replaced in tests.
+	facadeURL    func(ar *omniav1alpha1.AgentRuntime) string
+	decoratorFor func(ctx context.Context, ar *omniav1alpha1.AgentRuntime) (sessionDecorator, error)
+
+	// probed holds the agents of the previous round, so agents that drop out
+	// have their metrics forgotten.
+	probed map[types.NamespacedName]struct{}
+
+	// stores caches session-api clients by URL so each round reuses their
+	// connections.
+	storesMu sync.Mutex
+	stores   map[string]*httpclient.Store
+}
+
+// NewRunner returns a Runner that lists AgentRuntimes with c and probes them
+// with prober. metrics may be nil.
+func NewRunner(c client.Client, prober *Prober, metrics *Metrics, cfg Config, log logr.Logger) *Runner {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = DefaultConcurrency
+	}
+	r := &Runner{
+		client:  c,
+		prober:  prober,
+		metrics: metrics,
+		cfg:     cfg,
+		log:     log.WithName("canary"),
+		probed:  map[types.NamespacedName]struct{}{},
+		stores:  map[string]*httpclient.Store{},
+	}
+	r.facadeURL = facadeURL
+	r.decoratorFor = r.sessionStoreFor
+	return r
+}
+
+// Start runs a probe round immediately and then every Interval until ctx is
+// cancelled.
+func (r *Runner) Start(ctx context.Context) error {
+	r.log.Info("starting canary probes", "interval", r.cfg.Interval, "timeout", r.cfg.Timeout)
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		r.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce probes every eligible agent once.
+func (r *Runner) RunOnce(ctx context.Context) {
+	agents, err := r.targets(ctx)
+	if err != nil {
+		r.log.Error(err, "failed to list agents to probe")
+		return
+	}
+
+	current := make(map[types.NamespacedName]struct{}, len(agents))
+	sem := make(chan struct{}, r.cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i := range agents {
+		ar := &agents[i]
+		current[types.NamespacedName{Namespace: ar.Namespace, Name: ar.Name}] = struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+			r.probeAgent(ctx, ar)
+		}()
+	}
+	wg.Wait()
+
+	for key := range r.probed {
+		if _, ok := current[key]; !ok {
+			r.metrics.Forget(key.Namespace, key.Name)
+		}
+	}
+	r.probed = current
+}
+
+// targets lists the AgentRuntimes to probe: Ready, with a WebSocket facade,
+// and not opted out.
+func (r *Runner) targets(ctx context.Context) ([]omniav1alpha1.AgentRuntime, error) {
+	var list omniav1alpha1.AgentRuntimeList
+	if err := r.client.List(ctx, &list); err != nil {
+		return nil, err
+	}
+	var out []omniav1alpha1.AgentRuntime
+	for _, ar := range list.Items {
+		if eligible(&ar) {
+			out = append(out, ar)
+		}
+	}
+	return out, nil
+}
+
+// eligible reports whether ar should be probed.
+func eligible(ar *omniav1alpha1.AgentRuntime) bool {
+	if ar.Annotations[AnnotationCanary] == annotationValueDisabled || ar.DeletionTimestamp != nil {
+		return false
+	}
+	if websocketFacade(ar) == nil {
+		return false
+	}
+	return ar.Status.Phase == omniav1alpha1.AgentRuntimePhaseRunning &&
+		meta.IsStatusConditionTrue(ar.Status.Conditions, readyConditionType)
+}
+
+// probeAgent probes one agent, records the outcome, and labels its session.
+func (r *Runner) probeAgent(ctx context.Context, ar *omniav1alpha1.AgentRuntime) {
+	target := Target{Name: ar.Name, Namespace: ar.Namespace, URL: r.facadeURL(ar)}
+	log := r.log.WithValues("agent", ar.Name, "namespace", ar.Namespace)
+
+	probeCtx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	res := r.prober.Probe(probeCtx, target)
+	cancel()
+	if ctx.Err() != nil {
+		// Shutting down: the probe was cut short, not failed.
+		return
+	}
+
+	r.metrics.Observe(target, res, time.Now())
+	if res.Success() {
+		log.V(1).Info("canary probe succeeded", "sessionID", res.SessionID, "duration", res.Duration)
+	} else {
+		log.Info("canary probe failed", "result", res.Result, "error", res.Error,
+			"sessionID", res.SessionID, "duration", res.Duration)
+	}
+	if res.SessionID != "" {
+		r.labelSession(ctx, ar, res, log)
+	}
+}
+
+// labelSession tags the probe's session as canary traffic with its outcome,
+// so canary sessions can be filtered in or out of session listings.
+func (r *Runner) labelSession(ctx context.Context, ar *omniav1alpha1.AgentRuntime, res Result, log logr.Logger) {
+	store, err := r.decoratorFor(ctx, ar)
+	if err != nil {
+		log.V(1).Info("cannot label canary session", "sessionID", res.SessionID, "reason", err.Error())
+		return
+	}
+	opts := session.DecorateSessionOptions{
+		RemoveTags: []string{sourceInteractiveTag},
+		AddTags:    []string{sourceCanaryTag, canaryResultTagKey + res.Result},
+		MergeState: map[string]string{
+			"canary.result":      res.Result,
+			"canary.turns":       strconv.Itoa(res.Turns),
+			"canary.duration_ms": strconv.FormatInt(res.Duration.Milliseconds(), 10),
+		},
+	}
+	if res.Error != "" {
+		opts.MergeState["canary.error"] = res.Error
+	}
+	if err := store.DecorateSession(ctx, res.SessionID, opts); err != nil {
+		// A failed probe may end before the facade persisted the session.
+		log.Error(err, "failed to label canary session", "sessionID", res.SessionID)
+	}
+}
+
+// sessionStoreFor returns a session-api client for ar's service group,
+// resolved from the Workspace that owns ar's namespace.
+func (r *Runner) sessionStoreFor(ctx context.Context, ar *omniav1alpha1.AgentRuntime) (sessionDecorator, error) {
+	var workspaces omniav1alpha1.WorkspaceList
+	if err := r.client.List(ctx, &workspaces); err != nil {
+		return nil, err
+	}
+	for _, ws := range workspaces.Items {
+		if ws.Spec.Namespace.Name != ar.Namespace {
+			continue
+		}
+		group := ar.Spec.ServiceGroup
+		if group == "" {
+			group = defaultServiceGroup
+		}
+		url, err := servicediscovery.NewResolver(r.client).SessionURL(ctx, ws.Name, group)
+		if err != nil {
+			return nil, err
+		}
+		return r.storeFor(url), nil
+	}
+	return nil, fmt.Errorf("no workspace owns namespace %q", ar.Namespace)
+}
+
+// storeFor returns the cached session-api client for url, creating it on
+// first use. Writes are unbuffered: a label that fails is logged, not retried.
+func (r *Runner) storeFor(url string) *httpclient.Store {
+	r.storesMu.Lock()
+	defer r.storesMu.Unlock()
+	store, ok := r.stores[url]
+	if !ok {
+		store = httpclient.NewStore(url, r.log, httpclient.WithBufferCapacity(0))
+		r.stores[url] = store
+	}
+	return store
+}
+
+// websocketFacade returns ar's WebSocket facade, or nil when it has none.
+func websocketFacade(ar *omniav1alpha1.AgentRuntime) *omniav1alpha1.FacadeConfig {
+	for i := range ar.Spec.Facades {
+		if ar.Spec.Facades[i].Type == omniav1alpha1.FacadeTypeWebSocket {
+			return &ar.Spec.Facades[i]
+		}
+	}
+	return nil
+}
+
+// facadeURL returns the in-cluster base URL of ar's WebSocket facade. Like
+// Doctor, it prefers the internal management-plane port the agent advertises,
+// falling back to the facade's external port.
+func facadeURL(ar *omniav1alpha1.AgentRuntime) string {
+	port := int32(defaultFacadePort)
+	if f := websocketFacade(ar); f != nil && f.Port != nil {
+		port = *f.Port
+	}
+	if me := ar.Status.ManagementEndpoints; me != nil && me.WS != nil && *me.WS > 0 {
+		port = *me.WS
+	}
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", ar.Name, ar.Namespace, port)
+}
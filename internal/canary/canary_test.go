@@ -0,0 +1,184 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// readyAgent returns a Ready AgentRuntime with a WebSocket facade.
+func readyAgent(name string) *omniav1alpha1.AgentRuntime {
+	return &omniav1alpha1.AgentRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agents"},
+		Spec: omniav1alpha1.AgentRuntimeSpec{
+			Facades: []omniav1alpha1.FacadeConfig{{Type: omniav1alpha1.FacadeTypeWebSocket}},
+		},
+		Status: omniav1alpha1.AgentRuntimeStatus{
+			Phase: omniav1alpha1.AgentRuntimePhaseRunning,
+			Conditions: []metav1.Condition{{
+				Type: readyConditionType, Status: metav1.ConditionTrue, Reason: "Ready",
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, omniav1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+// recordingDecorator records DecorateSession calls.
+type recordingDecorator struct {
+	mu    sync.Mutex
+	calls map[string]session.DecorateSessionOptions
+}
+
+func (d *recordingDecorator) DecorateSession(_ context.Context, id string, opts session.DecorateSessionOptions) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.calls[id] = opts
+	return nil
+}
+
+func TestEligible(t *testing.T) {
+	optedOut := readyAgent("opted-out")
+	optedOut.Annotations = map[string]string{AnnotationCanary: "disabled"}
+
+	pending := readyAgent("pending")
+	pending.Status.Phase = omniav1alpha1.AgentRuntimePhasePending
+
+	notReady := readyAgent("not-ready")
+	notReady.Status.Conditions[0].Status = metav1.ConditionFalse
+
+	function := readyAgent("function")
+	function.Spec.Facades = []omniav1alpha1.FacadeConfig{{Type: omniav1alpha1.FacadeTypeREST}}
+
+	assert.True(t, eligible(readyAgent("ok")))
+	for _, ar := range []*omniav1alpha1.AgentRuntime{optedOut, pending, notReady, function} {
+		assert.False(t, eligible(ar), ar.Name)
+	}
+}
+
+func TestFacadeURL(t *testing.T) {
+	ar := readyAgent("support-bot")
+	assert.Equal(t, "http://support-bot.agents.svc.cluster.local:8080", facadeURL(ar))
+
+	ar.Spec.Facades[0].Port = ptr.To[int32](9000)
+	assert.Equal(t, "http://support-bot.agents.svc.cluster.local:9000", facadeURL(ar))
+
+	ar.Status.ManagementEndpoints = &omniav1alpha1.ManagementEndpoints{WS: ptr.To[int32](8443)}
+	assert.Equal(t, "http://support-bot.agents.svc.cluster.local:8443", facadeURL(ar),
+		"the management-plane port wins when advertised")
+}
+
+func TestRunner_RunOnce(t *testing.T) {
+	_, healthy := newFakeFacade(t, echoReply)
+	_, broken := newFakeFacade(t, func(string) []wsServerMessage {
+		return []wsServerMessage{{Type: wsMessageTypeError, Error: &wsErrorInfo{Code: "X", Message: "boom"}}}
+	})
+	urls := map[string]string{"healthy": healthy.URL, "broken": broken.URL}
+
+	c := newFakeClient(t, readyAgent("healthy"), readyAgent("broken"))
+	reg := prometheus.NewRegistry()
+	metrics := NewMetrics(reg)
+	decorator := &recordingDecorator{calls: map[string]session.DecorateSessionOptions{}}
+
+	r := NewRunner(c, NewProber(DefaultScript(), nil), metrics, Config{Timeout: 2 * time.Second}, logr.Discard())
+	r.facadeURL = func(ar *omniav1alpha1.AgentRuntime) string { return urls[ar.Name] }
+	r.decoratorFor = func(context.Context, *omniav1alpha1.AgentRuntime) (sessionDecorator, error) {
+		return decorator, nil
+	}
+
+	r.RunOnce(context.Background())
+
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.Probes.WithLabelValues("agents", "healthy", ResultSuccess)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.Probes.WithLabelValues("agents", "broken", ResultAgentError)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.Up.WithLabelValues("agents", "healthy")), 0)
+	assert.InDelta(t, 0, testutil.ToFloat64(metrics.Up.WithLabelValues("agents", "broken")), 0)
+	assert.Positive(t, testutil.ToFloat64(metrics.LastSuccess.WithLabelValues("agents", "healthy")))
+
+	// Both fake facades assign the same session ID; the last label wins, so
+	// check the shape rather than which agent wrote it.
+	require.Contains(t, decorator.calls, "sess-1")
+	opts := decorator.calls["sess-1"]
+	assert.Equal(t, []string{sourceInteractiveTag}, opts.RemoveTags)
+	assert.Contains(t, opts.AddTags, sourceCanaryTag)
+	assert.Contains(t, opts.MergeState, "canary.result")
+}
+
+func TestRunner_ForgetsAgentsThatDropOut(t *testing.T) {
+	_, facade := newFakeFacade(t, echoReply)
+	agent := readyAgent("healthy")
+	c := newFakeClient(t, agent)
+	metrics := NewMetrics(prometheus.NewRegistry())
+
+	r := NewRunner(c, NewProber(DefaultScript(), nil), metrics, Config{Timeout: 2 * time.Second}, logr.Discard())
+	r.facadeURL = func(*omniav1alpha1.AgentRuntime) string { return facade.URL }
+	r.decoratorFor = func(context.Context, *omniav1alpha1.AgentRuntime) (sessionDecorator, error) {
+		return &recordingDecorator{calls: map[string]session.DecorateSessionOptions{}}, nil
+	}
+
+	r.RunOnce(context.Background())
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.Up))
+
+	agent.Annotations = map[string]string{AnnotationCanary: "disabled"}
+	require.NoError(t, c.Update(context.Background(), agent))
+	r.RunOnce(context.Background())
+	assert.Equal(t, 0, testutil.CollectAndCount(metrics.Up))
+}
+
+func TestRunner_SessionStoreFor(t *testing.T) {
+	ws := &omniav1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo"},
+		Spec:       omniav1alpha1.WorkspaceSpec{Namespace: omniav1alpha1.NamespaceConfig{Name: "agents"}},
+		Status: omniav1alpha1.WorkspaceStatus{Services: []omniav1alpha1.ServiceGroupStatus{{
+			Name: "default", SessionURL: "http://session-api.agents:8080",
+		}}},
+	}
+	r := NewRunner(newFakeClient(t, ws), NewProber(DefaultScript(), nil), nil, Config{}, logr.Discard())
+
+	store, err := r.sessionStoreFor(context.Background(), readyAgent("a"))
+	require.NoError(t, err)
+	again, err := r.sessionStoreFor(context.Background(), readyAgent("b"))
+	require.NoError(t, err)
+	assert.Same(t, store, again, "clients are cached per session-api URL")
+
+	other := readyAgent("c")
+	other.Namespace = "elsewhere"
+	_, err = r.sessionStoreFor(context.Background(), other)
+	assert.ErrorContains(t, err, `no workspace owns namespace "elsewhere"`)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Canary metric name constants.
+const (
+	metricProbes             = "omnia_canary_probes_total"
+	metricProbeDuration      = "omnia_canary_probe_duration_seconds"
+	metricFirstReplyDuration = "omnia_canary_first_reply_seconds"
+	metricUp                 = "omnia_canary_up"
+	metricLastSuccess        = "omnia_canary_last_success_timestamp_seconds"
+)
+
+// DefaultProbeDurationBuckets are histogram buckets for probe conversations,
+// which span a WebSocket handshake and one or more LLM turns.
+var DefaultProbeDurationBuckets = []float64{0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120}
+
+// Metrics holds Prometheus metrics for canary probes, labelled by the probed
+// agent's namespace and name.
+type Metrics struct {
+	// Probes counts probes by namespace, agent, and result.
+	Probes *prometheus.CounterVec
+
+	// ProbeDuration tracks the duration of the whole probe conversation.
+	ProbeDuration *prometheus.HistogramVec
+
+	// FirstReplyDuration tracks the time from the first message to the first
+	// reply frame, approximating time to first token.
+	FirstReplyDuration *prometheus.HistogramVec
+
+	// Up is 1 when the agent's latest probe succeeded and 0 otherwise.
+	Up *prometheus.GaugeVec
+
+	// LastSuccess is the Unix time of the agent's latest successful probe.
+	LastSuccess *prometheus.GaugeVec
+}
+
+// NewMetrics creates the canary metrics and registers them with reg.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	factory := promauto.With(reg)
+	labels := []string{"namespace", "agent"}
+	return &Metrics{
+		Probes: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: metricProbes,
+			Help: "Total canary probes by namespace, agent, and result",
+		}, []string{"namespace", "agent", "result"}),
+
+		ProbeDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    metricProbeDuration,
+			Help:    "Canary probe conversation duration in seconds",
+			Buckets: DefaultProbeDurationBuckets,
+		}, labels),
+
+		FirstReplyDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    metricFirstReplyDuration,
+			Help:    "Time from the canary's first message to the first reply frame in seconds",
+			Buckets: DefaultProbeDurationBuckets,
+		}, labels),
+
+		Up: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: metricUp,
+			Help: "Whether the agent's latest canary probe succeeded (1) or failed (0)",
+		}, labels),
+
+		LastSuccess: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: metricLastSuccess,
+			Help: "Unix time of the agent's latest successful canary probe",
+		}, labels),
+	}
+}
+
+// Observe records the outcome of a probe of target that finished at now.
+func (m *Metrics) Observe(target Target, res Result, now time.Time) {
+	if m == nil {
+		return
+	}
+	m.Probes.WithLabelValues(target.Namespace, target.Name, res.Result).Inc()
+	m.ProbeDuration.WithLabelValues(target.Namespace, target.Name).Observe(res.Duration.Seconds())
+	if res.FirstReplyDuration > 0 {
+		m.FirstReplyDuration.WithLabelValues(target.Namespace, target.Name).Observe(res.FirstReplyDuration.Seconds())
+	}
+	if res.Success() {
+		m.Up.WithLabelValues(target.Namespace, target.Name).Set(1)
+		m.LastSuccess.WithLabelValues(target.Namespace, target.Name).Set(float64(now.Unix()))
+		return
+	}
+	m.Up.WithLabelValues(target.Namespace, target.Name).Set(0)
+}
+
+// Forget drops the gauges of an agent that is no longer probed, so a deleted
+// or opted-out agent does not alert on a stale omnia_canary_up of 0.
+func (m *Metrics) Forget(namespace, agent string) {
+	if m == nil {
+		return
+	}
+	labels := prometheus.Labels{"namespace": namespace, "agent": agent}
+	m.Up.DeletePartialMatch(labels)
+	m.LastSuccess.DeletePartialMatch(labels)
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/altairalabs/omnia/internal/mgmtplane"
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+// Probe outcomes, used as the result label of omnia_canary_probes_total.
+const (
+	// ResultSuccess means every turn got a reply that met its expectation.
+	ResultSuccess = "success"
+	// ResultConnectFailed means the WebSocket handshake or the facade's
+	// connected message failed.
+	ResultConnectFailed = "connect_failed"
+	// ResultAgentError means the facade answered a turn with an error frame.
+	ResultAgentError = "agent_error"
+	// ResultNoReply means a turn did not complete before the probe timed out
+	// or the connection dropped.
+	ResultNoReply = "no_reply"
+	// ResultUnexpectedReply means a reply was empty or missed its expected text.
+	ResultUnexpectedReply = "unexpected_reply"
+)
+
+// canaryUserID is the user identity the probe presents, so canary sessions
+// are attributed to a recognisable synthetic user rather than anonymous ones.
+const canaryUserID = "omnia-canary"
+
+// wsHandshakeTimeout bounds the WebSocket upgrade and the connected message.
+const wsHandshakeTimeout = 10 * time.Second
+
+// Facade WebSocket message types the probe sends or handles.
+const (
+	wsMessageTypeMessage   = "message"
+	wsMessageTypeConnected = "connected"
+	wsMessageTypeChunk     = "chunk"
+	wsMessageTypeDone      = "done"
+	wsMessageTypeError     = "error"
+)
+
+// wsClientMessage is the minimal outbound message shape.
+type wsClientMessage struct {
+	Type      string `json:"type"`
+	SessionID string `json:"session_id,omitempty"`
+	Content   string `json:"content"`
+}
+
+// wsServerMessage is the minimal inbound message shape.
+type wsServerMessage struct {
+	Type      string       `json:"type"`
+	SessionID string       `json:"session_id,omitempty"`
+	Content   string       `json:"content,omitempty"`
+	Error     *wsErrorInfo `json:"error,omitempty"`
+}
+
+// wsErrorInfo holds the code and message of a facade error frame.
+type wsErrorInfo struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Target is an agent to probe.
+type Target struct {
+	Name      string
+	Namespace string
+	// URL is the facade base URL, e.g.
+	// http://my-agent.agents.svc.cluster.local:8080.
+	URL string
+}
+
+// Result is the outcome of one probe.
+type Result struct {
+	// Result is one of the Result* constants.
+	Result string
+	// SessionID is the session the facade opened, empty when the connection
+	// failed before the facade assigned one.
+	SessionID string
+	// Error describes the failure; empty on success.
+	Error string
+	// Turns is the number of turns that completed successfully.
+	Turns int
+	// ConnectDuration is the time to the facade's connected message.
+	ConnectDuration time.Duration
+	// FirstReplyDuration is the time from sending the first turn to the first
+	// reply frame, approximating time to first token.
+	FirstReplyDuration time.Duration
+	// Duration is the time for the whole probe conversation.
+	Duration time.Duration
+}
+
+// Success reports whether the probe passed.
+func (r Result) Success() bool {
+	return r.Result == ResultSuccess
+}
+
+// Prober runs a scripted conversation against an agent facade over WebSocket.
+type Prober struct {
+	script Script
+	// tokenSource mints the management-plane JWT the facade requires; nil
+	// dials without an Authorization header.
+	tokenSource mgmtplane.TokenSource
+	now         func() time.Time
+}
+
+// NewProber returns a Prober for script. tokenSource may be nil.
+func NewProber(script Script, tokenSource mgmtplane.TokenSource) *Prober {
+	return &Prober{script: script, tokenSource: tokenSource, now: time.Now}
+}
+
+// Probe runs the script against target. ctx bounds the whole conversation.
+func (p *Prober) Probe(ctx context.Context, target Target) Result {
+	start := p.now()
+	res := p.probe(ctx, target, start)
+	res.Duration = p.now().Sub(start)
+	return res
+}
+
+func (p *Prober) probe(ctx context.Context, target Target, start time.Time) Result {
+	conn, sessionID, err := p.dial(ctx, target)
+	if err != nil {
+		return Result{Result: ResultConnectFailed, Error: err.Error()}
+	}
+	defer closeConn(conn)
+	res := Result{SessionID: sessionID, ConnectDuration: p.now().Sub(start)}
+
+	// Unblock reads when ctx ends before the read deadline does.
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetReadDeadline(time.Now()) })
+	defer stop()
+
+	for i, turn := range p.script.Turns {
+		sent := p.now()
+		if err := sendMessage(conn, sessionID, turn.Message); err != nil {
+			res.Result, res.Error = ResultNoReply, fmt.Sprintf("turn %d: send: %v", i+1, err)
+			return res
+		}
+		reply, first, outcome, err := p.collectReply(ctx, conn)
+		if i == 0 && !first.IsZero() {
+			res.FirstReplyDuration = first.Sub(sent)
+		}
+		if err != nil {
+			res.Result, res.Error = outcome, fmt.Sprintf("turn %d: %v", i+1, err)
+			return res
+		}
+		if err := checkReply(turn, reply); err != nil {
+			res.Result, res.Error = ResultUnexpectedReply, fmt.Sprintf("turn %d: %v", i+1, err)
+			return res
+		}
+		res.Turns++
+	}
+	res.Result = ResultSuccess
+	return res
+}
+
+// dial opens the WebSocket and waits for the facade's connected message,
+// returning the session ID it assigns.
+func (p *Prober) dial(ctx context.Context, target Target) (*websocket.Conn, string, error) {
+	headers := http.Header{}
+	headers.Set(policy.IstioHeaderUserID, canaryUserID)
+	if p.tokenSource != nil {
+		token, err := p.tokenSource.Token(target.Name, target.Namespace)
+		if err != nil {
+			return nil, "", fmt.Errorf("mint mgmt-plane token: %w", err)
+		}
+		headers.Set("Authorization", "Bearer "+token)
+	}
+
+	dialer := websocket.Dialer{HandshakeTimeout: wsHandshakeTimeout}
+	conn, resp, err := dialer.DialContext(ctx, wsURL(target), headers)
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("dial: %w", err)
+	}
+
+	if err := conn.SetReadDeadline(readDeadline(ctx, wsHandshakeTimeout)); err != nil {
+		_ = conn.Close()
+		return nil, "", err
+	}
+	for {
+		var msg wsServerMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			_ = conn.Close()
+			return nil, "", fmt.Errorf("reading connected message: %w", err)
+		}
+		if msg.Type == wsMessageTypeConnected {
+			return conn, msg.SessionID, nil
+		}
+	}
+}
+
+// collectReply reads frames until the turn's done or error frame. It returns
+// the reply text, the arrival time of the first reply frame, and on failure
+// the probe result to report.
+func (p *Prober) collectReply(ctx context.Context, conn *websocket.Conn) (string, time.Time, string, error) {
+	if err := conn.SetReadDeadline(readDeadline(ctx, 0)); err != nil {
+		return "", time.Time{}, ResultNoReply, err
+	}
+	var reply strings.Builder
+	var first time.Time
+	for {
+		var msg wsServerMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return reply.String(), first, ResultNoReply, fmt.Errorf("waiting for reply: %w", err)
+		}
+		switch msg.Type {
+		case wsMessageTypeChunk, wsMessageTypeDone:
+			if first.IsZero() {
+				first = p.now()
+			}
+			reply.WriteString(msg.Content)
+			if msg.Type == wsMessageTypeDone {
+				return reply.String(), first, "", nil
+			}
+		case wsMessageTypeError:
+			return reply.String(), first, ResultAgentError, facadeError(msg)
+		}
+	}
+}
+
+// checkReply reports whether reply satisfies turn.
+func checkReply(turn Turn, reply string) error {
+	if strings.TrimSpace(reply) == "" {
+		return fmt.Errorf("empty reply")
+	}
+	if turn.Expect != "" && !strings.Contains(strings.ToLower(reply), strings.ToLower(turn.Expect)) {
+		return fmt.Errorf("reply does not contain %q", turn.Expect)
+	}
+	return nil
+}
+
+// facadeError converts an error frame into an error.
+func facadeError(msg wsServerMessage) error {
+	code, text := "", msg.Content
+	if msg.Error != nil {
+		code = msg.Error.Code
+		if msg.Error.Message != "" {
+			text = msg.Error.Message
+		}
+	}
+	return fmt.Errorf("agent returned error (%s): %s", code, text)
+}
+
+// readDeadline returns ctx's deadline, or now+fallback when ctx has none. A
+// zero fallback means no deadline.
+func readDeadline(ctx context.Context, fallback time.Duration) time.Time {
+	if d, ok := ctx.Deadline(); ok {
+		return d
+	}
+	if fallback > 0 {
+		return time.Now().Add(fallback)
+	}
+	return time.Time{}
+}
+
+// sendMessage writes a chat message to the WebSocket.
+func sendMessage(conn *websocket.Conn, sessionID, content string) error {
+	return conn.WriteJSON(wsClientMessage{Type: wsMessageTypeMessage, SessionID: sessionID, Content: content})
+}
+
+// closeConn sends a clean close frame and closes the connection.
+func closeConn(conn *websocket.Conn) {
+	_ = conn.WriteMessage(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	_ = conn.Close()
+}
+
+// wsURL converts the target's HTTP base URL into the facade WebSocket URL.
+func wsURL(target Target) string {
+	base := strings.Replace(target.URL, "http://", "ws://", 1)
+	base = strings.Replace(base, "https://", "wss://", 1)
+	q := url.Values{"agent": {target.Name}, "namespace": {target.Namespace}}
+	return base + "/ws?" + q.Encode()
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFacade is a minimal WebSocket facade: it sends connected, then answers
+// each message with reply(content) as one chunk and a done frame.
+type fakeFacade struct {
+	reply func(content string) []wsServerMessage
+	// headers records the upgrade request headers.
+	headers http.Header
+	// query records the upgrade request query.
+	query string
+}
+
+func (f *fakeFacade) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.headers = r.Header.Clone()
+	f.query = r.URL.RawQuery
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.WriteJSON(wsServerMessage{Type: wsMessageTypeConnected, SessionID: "sess-1"})
+	for {
+		var msg wsClientMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		for _, out := range f.reply(msg.Content) {
+			_ = conn.WriteJSON(out)
+		}
+	}
+}
+
+func echoReply(content string) []wsServerMessage {
+	return []wsServerMessage{
+		{Type: wsMessageTypeChunk, Content: "echo: "},
+		{Type: wsMessageTypeDone, Content: content},
+	}
+}
+
+func newFakeFacade(t *testing.T, reply func(string) []wsServerMessage) (*fakeFacade, Target) {
+	t.Helper()
+	f := &fakeFacade{reply: reply}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, Target{Name: "support-bot", Namespace: "agents", URL: srv.URL}
+}
+
+type staticToken string
+
+func (s staticToken) Token(_, _ string) (string, error) {
+	if s == "" {
+		return "", errors.New("no token")
+	}
+	return string(s), nil
+}
+
+func TestProber_Success(t *testing.T) {
+	facade, target := newFakeFacade(t, echoReply)
+	script := Script{Turns: []Turn{{Message: "hello"}, {Message: "ping", Expect: "PING"}}}
+
+	res := NewProber(script, staticToken("jwt")).Probe(context.Background(), target)
+
+	assert.Equal(t, ResultSuccess, res.Result, res.Error)
+	assert.True(t, res.Success())
+	assert.Equal(t, "sess-1", res.SessionID)
+	assert.Equal(t, 2, res.Turns)
+	assert.Positive(t, res.Duration)
+	assert.Equal(t, "Bearer jwt", facade.headers.Get("Authorization"))
+	assert.Equal(t, canaryUserID, facade.headers.Get("X-User-Id"))
+	assert.Equal(t, "agent=support-bot&namespace=agents", facade.query)
+}
+
+func TestProber_Failures(t *testing.T) {
+	tests := []struct {
+		name   string
+		reply  func(string) []wsServerMessage
+		script Script
+		want   string
+	}{
+		{
+			name:   "unexpected reply",
+			reply:  echoReply,
+			script: Script{Turns: []Turn{{Message: "hello", Expect: "goodbye"}}},
+			want:   ResultUnexpectedReply,
+		},
+		{
+			name: "empty reply",
+			reply: func(string) []wsServerMessage {
+				return []wsServerMessage{{Type: wsMessageTypeDone}}
+			},
+			script: DefaultScript(),
+			want:   ResultUnexpectedReply,
+		},
+		{
+			name: "agent error",
+			reply: func(string) []wsServerMessage {
+				return []wsServerMessage{{Type: wsMessageTypeError,
+					Error: &wsErrorInfo{Code: "PROVIDER_ERROR", Message: "rate limited"}}}
+			},
+			script: DefaultScript(),
+			want:   ResultAgentError,
+		},
+		{
+			name:   "no reply",
+			reply:  func(string) []wsServerMessage { return nil },
+			script: DefaultScript(),
+			want:   ResultNoReply,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, target := newFakeFacade(t, tt.reply)
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			res := NewProber(tt.script, nil).Probe(ctx, target)
+			assert.Equal(t, tt.want, res.Result)
+			assert.Equal(t, "sess-1", res.SessionID, "the session is known even when a turn fails")
+			assert.NotEmpty(t, res.Error)
+		})
+	}
+}
+
+func TestProber_AgentErrorDetail(t *testing.T) {
+	_, target := newFakeFacade(t, func(string) []wsServerMessage {
+		return []wsServerMessage{{Type: wsMessageTypeError,
+			Error: &wsErrorInfo{Code: "PROVIDER_ERROR", Message: "rate limited"}}}
+	})
+	res := NewProber(DefaultScript(), nil).Probe(context.Background(), target)
+	assert.Equal(t, "turn 1: agent returned error (PROVIDER_ERROR): rate limited", res.Error)
+}
+
+func TestProber_ConnectFailed(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	res := NewProber(DefaultScript(), nil).Probe(context.Background(),
+		Target{Name: "a", Namespace: "b", URL: srv.URL})
+	assert.Equal(t, ResultConnectFailed, res.Result)
+	assert.Empty(t, res.SessionID)
+
+	_, target := newFakeFacade(t, echoReply)
+	res = NewProber(DefaultScript(), staticToken("")).Probe(context.Background(), target)
+	assert.Equal(t, ResultConnectFailed, res.Result)
+	assert.Contains(t, res.Error, "mint mgmt-plane token")
+}
+
+func TestWSURL(t *testing.T) {
+	assert.Equal(t, "ws://a.b.svc:8080/ws?agent=a&namespace=b",
+		wsURL(Target{Name: "a", Namespace: "b", URL: "http://a.b.svc:8080"}))
+	assert.Equal(t, "wss://example.com/ws?agent=a&namespace=b",
+		wsURL(Target{Name: "a", Namespace: "b", URL: "https://example.com"}))
+}
+
+func TestCheckReply(t *testing.T) {
+	require.NoError(t, checkReply(Turn{}, "anything"))
+	require.NoError(t, checkReply(Turn{Expect: "ok"}, "Sure — OK!"))
+	assert.EqualError(t, checkReply(Turn{Expect: "ok"}, "no"), `reply does not contain "ok"`)
+	assert.EqualError(t, checkReply(Turn{}, "  "), "empty reply")
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// defaultProbeMessage is the single turn of DefaultScript.
+const defaultProbeMessage = "This is an automated health check. Please reply with OK."
+
+// Turn is one user message of a probe conversation.
+type Turn struct {
+	// Message is sent to the agent as a user message.
+	Message string `json:"message"`
+	// Expect, when set, must appear in the agent's reply (case-insensitive).
+	// Leave it empty for real providers whose wording varies: any non-empty
+	// reply then passes. Set it for agents running the mock provider, whose
+	// replies are scripted.
+	Expect string `json:"expect,omitempty"`
+}
+
+// Script is the conversation a probe runs against each agent. All turns run in
+// one session, so later turns exercise conversation state.
+type Script struct {
+	Turns []Turn `json:"turns"`
+}
+
+// DefaultScript returns the single-turn script used when no script file is
+// configured.
+func DefaultScript() Script {
+	return Script{Turns: []Turn{{Message: defaultProbeMessage}}}
+}
+
+// LoadScript reads and validates a probe script YAML file, typically mounted
+// from a ConfigMap.
+func LoadScript(path string) (Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Script{}, fmt.Errorf("reading canary script: %w", err)
+	}
+	var s Script
+	if err := yaml.UnmarshalStrict(data, &s); err != nil {
+		return Script{}, fmt.Errorf("parsing canary script: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return Script{}, err
+	}
+	return s, nil
+}
+
+// Validate reports whether the script has at least one turn and every turn
+// has a message.
+func (s Script) Validate() error {
+	if len(s.Turns) == 0 {
+		return errors.New("canary script has no turns")
+	}
+	for i, t := range s.Turns {
+		if t.Message == "" {
+			return fmt.Errorf("canary script turn %d has no message", i+1)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeScript(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "script.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadScript(t *testing.T) {
+	path := writeScript(t, `
+turns:
+  - message: "Hi, what can you help with?"
+  - message: "Reply with PONG."
+    expect: pong
+`)
+	s, err := LoadScript(path)
+	require.NoError(t, err)
+	assert.Equal(t, []Turn{
+		{Message: "Hi, what can you help with?"},
+		{Message: "Reply with PONG.", Expect: "pong"},
+	}, s.Turns)
+}
+
+func TestLoadScript_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "unknown field", content: "turns:\n  - mesage: hi\n", wantErr: "parsing canary script"},
+		{name: "no turns", content: "turns: []\n", wantErr: "canary script has no turns"},
+		{name: "empty message", content: "turns:\n  - expect: ok\n", wantErr: "turn 1 has no message"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadScript(writeScript(t, tt.content))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := LoadScript(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "reading canary script")
+}
+
+func TestDefaultScript(t *testing.T) {
+	require.NoError(t, DefaultScript().Validate())
+}
@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -55,8 +56,9 @@ const (
 	istioSecurityAPIVersion = "security.istio.io/v1"
 	istioAuthPolicyKind     = "AuthorizationPolicy"
 	headerToolName          = "X-Omnia-Tool-Name"
+	headerToolRegistry      = "X-Omnia-Tool-Registry"
 	headerAgentName         = "X-Omnia-Agent-Name"
-	istioActionAllow        = "ALLOW"
+	headerWorkspace         = "X-Omnia-Workspace"
 	istioActionDeny         = "DENY"
 	istioActionAudit        = "AUDIT"
 	managedByLabelValue     = "agentpolicy-controller"
@@ -153,7 +155,32 @@ func (r *AgentPolicyReconciler) setActiveStatus(policy *omniav1alpha1.AgentPolic
 // validatePolicy validates the AgentPolicy spec.
 func (r *AgentPolicyReconciler) validatePolicy(policy *omniav1alpha1.AgentPolicy) error {
 	if policy.Spec.ToolAccess != nil {
-		return validateToolAccess(policy.Spec.ToolAccess)
+		if err := validateToolAccess(policy.Spec.ToolAccess); err != nil {
+			return err
+		}
+	}
+	if policy.Spec.Transform != nil {
+		return validateTransform(policy.Spec.Transform)
+	}
+	return nil
+}
+
+// validateTransform validates the transform configuration.
+func validateTransform(cfg *omniav1alpha1.TransformConfig) error {
+	for name := range cfg.Headers {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("transform header name must not be empty")
+		}
+	}
+	for _, param := range cfg.StripParams {
+		if param == "" {
+			return fmt.Errorf("transform stripParams entry must not be empty")
+		}
+	}
+	for from, to := range cfg.ModelRewrites {
+		if from == "" || to == "" {
+			return fmt.Errorf("transform modelRewrites must map a model to a model, got %q to %q", from, to)
+		}
 	}
 	return nil
 }
@@ -183,10 +210,26 @@ func validateToolAccessRule(rule omniav1alpha1.ToolAccessRule) error {
 		if tool == "" {
 			return fmt.Errorf("tool name must not be empty in registry %q", rule.Registry)
 		}
+		if !validToolPattern(tool) {
+			return fmt.Errorf("tool %q in registry %q: a wildcard may only be the first or last character", tool, rule.Registry)
+		}
 	}
 	return nil
 }
 
+// validToolPattern reports whether tool is an exact name or a pattern Istio
+// can match: "*", "prefix*" or "*suffix".
+func validToolPattern(tool string) bool {
+	if tool == "*" {
+		return true
+	}
+	inner := strings.TrimSuffix(strings.TrimPrefix(tool, "*"), "*")
+	if len(inner) != len(tool)-1 && len(inner) != len(tool) {
+		return false // wildcards at both ends
+	}
+	return inner != "" && !strings.Contains(inner, "*")
+}
+
 // buildAppliedMessage creates the status message based on mode and matched agent count.
 func buildAppliedMessage(mode omniav1alpha1.AgentPolicyMode, matchedCount int32) string {
 	if mode == omniav1alpha1.AgentPolicyModePermissive {
@@ -233,36 +276,77 @@ func (r *AgentPolicyReconciler) buildDesiredAuthPolicies(policy *omniav1alpha1.A
 	return policies
 }
 
-// resolveIstioAction maps policy mode to Istio action string.
+// resolveIstioAction maps policy mode to Istio action string. Both access
+// modes are expressed as DENY policies: Istio evaluates DENY before ALLOW, so
+// an ALLOW policy could not narrow what another policy already permits.
 func (r *AgentPolicyReconciler) resolveIstioAction(policy *omniav1alpha1.AgentPolicy) string {
 	if policy.Spec.Mode == omniav1alpha1.AgentPolicyModePermissive {
 		return istioActionAudit
 	}
-	if policy.Spec.ToolAccess.Mode == omniav1alpha1.ToolAccessModeAllowlist {
-		return istioActionAllow
-	}
 	return istioActionDeny
 }
 
-// buildAllowlistPolicies creates ALLOW + DENY catch-all AuthorizationPolicies for allowlist mode.
+// buildAllowlistPolicies creates a DENY AuthorizationPolicy for allowlist mode
+// that matches every tool call not listed: unlisted tools of a listed
+// registry, and any tool of a registry the policy does not list. Traffic that
+// is not a tool call carries no tool header and is never matched.
 func (r *AgentPolicyReconciler) buildAllowlistPolicies(policy *omniav1alpha1.AgentPolicy, action string) []*unstructured.Unstructured {
-	var result []*unstructured.Unstructured
-
-	// Create an ALLOW (or AUDIT) policy for the listed tools
-	allowPolicy := r.newAuthPolicyBase(policy, policy.Name+"-allow")
-	rules := buildRulesFromToolAccess(policy.Spec.ToolAccess.Rules, policy.Spec.Selector)
-	setAuthPolicySpec(allowPolicy, action, rules)
-	result = append(result, allowPolicy)
-
-	// For enforce mode, add a DENY catch-all to block everything else
-	if action == istioActionAllow {
-		denyPolicy := r.newAuthPolicyBase(policy, policy.Name+"-deny-all")
-		denyRules := buildCatchAllRules(policy.Spec.Selector)
-		setAuthPolicySpec(denyPolicy, istioActionDeny, denyRules)
-		result = append(result, denyPolicy)
+	denyPolicy := r.newAuthPolicyBase(policy, policy.Name+"-deny-unlisted")
+	toolRules := mergeRulesByRegistry(policy.Spec.ToolAccess.Rules)
+	rules := make([]interface{}, 0, len(toolRules)+1)
+	registries := make([]interface{}, 0, len(toolRules))
+	for _, rule := range toolRules {
+		when := []interface{}{
+			buildHeaderCondition(headerToolRegistry, []interface{}{rule.Registry}),
+			map[string]interface{}{
+				"key":       "request.headers[" + headerToolName + "]",
+				"values":    []interface{}{"*"},
+				"notValues": buildToolHeaderValues(rule),
+			},
+		}
+		rules = append(rules, map[string]interface{}{
+			"when": append(when, buildScopeConditions(policy.Spec.Selector)...),
+		})
+		registries = append(registries, rule.Registry)
+	}
+	when := []interface{}{
+		buildHeaderCondition(headerToolName, []interface{}{"*"}),
+		map[string]interface{}{
+			"key":       "request.headers[" + headerToolRegistry + "]",
+			"notValues": registries,
+		},
 	}
+	rules = append(rules, map[string]interface{}{
+		"when": append(when, buildScopeConditions(policy.Spec.Selector)...),
+	})
+	setAuthPolicySpec(denyPolicy, action, rules)
+	return []*unstructured.Unstructured{denyPolicy}
+}
 
-	return result
+// mergeRulesByRegistry folds rules naming the same registry into one, in
+// first-seen order. In allowlist mode each rule denies its registry's
+// unlisted tools, so two separate rules for one registry would each deny the
+// other's tools.
+func mergeRulesByRegistry(rules []omniav1alpha1.ToolAccessRule) []omniav1alpha1.ToolAccessRule {
+	merged := make([]omniav1alpha1.ToolAccessRule, 0, len(rules))
+	index := make(map[string]int, len(rules))
+	for _, rule := range rules {
+		i, ok := index[rule.Registry]
+		if !ok {
+			index[rule.Registry] = len(merged)
+			merged = append(merged, omniav1alpha1.ToolAccessRule{
+				Registry: rule.Registry,
+				Tools:    slices.Clone(rule.Tools),
+			})
+			continue
+		}
+		for _, tool := range rule.Tools {
+			if !slices.Contains(merged[i].Tools, tool) {
+				merged[i].Tools = append(merged[i].Tools, tool)
+			}
+		}
+	}
+	return merged
 }
 
 // buildDenylistPolicies creates DENY AuthorizationPolicies for denylist mode.
@@ -313,51 +397,67 @@ func buildRulesFromToolAccess(rules []omniav1alpha1.ToolAccessRule, selector *om
 	return istioRules
 }
 
-// buildSingleToolRule converts a single ToolAccessRule into an Istio rule map.
+// buildSingleToolRule converts a single ToolAccessRule into an Istio rule map
+// matching calls to the rule's tools in the rule's registry.
 func buildSingleToolRule(rule omniav1alpha1.ToolAccessRule, selector *omniav1alpha1.AgentPolicySelector) map[string]interface{} {
-	toolValues := buildToolHeaderValues(rule)
 	whenConditions := []interface{}{
-		map[string]interface{}{
-			"key":    "request.headers[" + headerToolName + "]",
-			"values": toolValues,
-		},
-	}
-	if selector != nil && len(selector.Agents) > 0 {
-		whenConditions = append(whenConditions, buildAgentCondition(selector.Agents))
+		buildHeaderCondition(headerToolRegistry, []interface{}{rule.Registry}),
+		buildHeaderCondition(headerToolName, buildToolHeaderValues(rule)),
 	}
 	return map[string]interface{}{
-		"when": whenConditions,
+		"when": append(whenConditions, buildScopeConditions(selector)...),
 	}
 }
 
-// buildToolHeaderValues creates the tool header values in "registry/tool" format.
+// buildToolHeaderValues creates the tool header values for a rule. Istio
+// matches "prefix*", "*suffix" and "*" values as patterns, which are passed
+// through unchanged.
 func buildToolHeaderValues(rule omniav1alpha1.ToolAccessRule) []interface{} {
 	values := make([]interface{}, 0, len(rule.Tools))
 	for _, tool := range rule.Tools {
-		values = append(values, rule.Registry+"/"+tool)
+		values = append(values, tool)
 	}
 	return values
 }
 
-// buildAgentCondition creates a "when" condition for agent name matching.
-func buildAgentCondition(agents []string) map[string]interface{} {
-	agentValues := make([]interface{}, 0, len(agents))
-	for _, a := range agents {
-		agentValues = append(agentValues, a)
-	}
+// buildHeaderCondition creates a "when" condition matching a request header
+// against values.
+func buildHeaderCondition(header string, values []interface{}) map[string]interface{} {
 	return map[string]interface{}{
-		"key":    "request.headers[" + headerAgentName + "]",
-		"values": agentValues,
+		"key":    "request.headers[" + header + "]",
+		"values": values,
 	}
 }
 
-// buildCatchAllRules creates a catch-all rule that matches all requests (optionally scoped to agents).
-func buildCatchAllRules(selector *omniav1alpha1.AgentPolicySelector) []interface{} {
-	rule := map[string]interface{}{}
-	if selector != nil && len(selector.Agents) > 0 {
-		rule["when"] = []interface{}{buildAgentCondition(selector.Agents)}
+// buildScopeConditions creates the "when" conditions that scope a rule to the
+// selector's agents and workspaces.
+func buildScopeConditions(selector *omniav1alpha1.AgentPolicySelector) []interface{} {
+	if selector == nil {
+		return nil
+	}
+	var conditions []interface{}
+	if len(selector.Agents) > 0 {
+		conditions = append(conditions, buildAgentCondition(selector.Agents))
 	}
-	return []interface{}{rule}
+	if len(selector.Workspaces) > 0 {
+		conditions = append(conditions, buildHeaderCondition(headerWorkspace, stringValues(selector.Workspaces)))
+	}
+	return conditions
+}
+
+// buildAgentCondition creates a "when" condition for agent name matching.
+func buildAgentCondition(agents []string) map[string]interface{} {
+	return buildHeaderCondition(headerAgentName, stringValues(agents))
+}
+
+// stringValues converts a string slice to the []interface{} form unstructured
+// objects require.
+func stringValues(in []string) []interface{} {
+	values := make([]interface{}, 0, len(in))
+	for _, v := range in {
+		values = append(values, v)
+	}
+	return values
 }
 
 // applyAuthPolicies applies the desired AuthorizationPolicies and deletes stale ones.
@@ -117,6 +117,15 @@ type AgentRuntimeReconciler struct {
 	WorkspaceReaderRBACEnabled bool
 	// DefaultExposure configures external exposure (#1553). See DefaultExposureConfig.
 	DefaultExposure DefaultExposureConfig
+	// PodMonitors configures operator-managed PodMonitors for agent and
+	// eval-worker pods. See PodMonitorConfig.
+	PodMonitors PodMonitorConfig
+	// PrometheusRules configures the PrometheusRules generated from
+	// spec.slos. See PrometheusRuleConfig.
+	PrometheusRules PrometheusRuleConfig
+	// SLOQuerier evaluates spec.slos for the SLOBreached condition. Nil leaves
+	// the condition Unknown.
+	SLOQuerier MetricsQuerier
 	// LicenseAPIURL is the operator/arena-controller license endpoint,
 	// stamped onto the policy-broker sidecar as OPERATOR_API_URL so it logs a
 	// startup nag when unlicensed (#1682). Empty disables the nag. Never gates.
@@ -197,6 +206,8 @@ type AgentRuntimeReconciler struct {
 // +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete
 
 // reconcileReferences fetches and validates all referenced resources.
 // Returns promptPack (required), toolRegistry (optional), providers map, and any error.
@@ -471,6 +482,11 @@ func (r *AgentRuntimeReconciler) reconcileResources(
 		log.Error(err, "Failed to reconcile PDB")
 	}
 
+	// Reconcile PodMonitor (only when the Prometheus Operator is installed); best-effort.
+	if err := r.reconcileAgentPodMonitor(ctx, agentRuntime); err != nil {
+		log.Error(err, "Failed to reconcile PodMonitor")
+	}
+
 	return deployment, nil
 }
 
@@ -603,6 +619,10 @@ func (r *AgentRuntimeReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		log.Error(err, "OIDC JWKS reconciliation failed")
 	}
 
+	// Generate Prometheus alerts from spec.slos and mirror breaches into the
+	// SLOBreached condition. Non-blocking; re-evaluated periodically.
+	sloNext := r.reconcileSLOs(ctx, agentRuntime)
+
 	// Set overall Ready condition
 	if agentRuntime.Status.Replicas != nil && agentRuntime.Status.Replicas.Ready > 0 {
 		agentRuntime.Status.Phase = omniav1alpha1.AgentRuntimePhaseRunning
@@ -625,7 +645,7 @@ func (r *AgentRuntimeReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
-	return scheduleOIDCJWKSRefresh(earliestRequeue(jwksNext, capRequeue)), nil
+	return scheduleOIDCJWKSRefresh(earliestRequeue(earliestRequeue(jwksNext, capRequeue), sloNext)), nil
 }
 
 func (r *AgentRuntimeReconciler) reconcileDelete(ctx context.Context, agentRuntime *omniav1alpha1.AgentRuntime) (ctrl.Result, error) {
@@ -0,0 +1,326 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+)
+
+const (
+	prometheusRuleKind = "PrometheusRule"
+	// prometheusRuleSuffix names an agent's PrometheusRule <agent>-slos.
+	prometheusRuleSuffix = "-slos"
+	// sloRefreshInterval is how often SLOBreached is re-evaluated.
+	sloRefreshInterval = time.Minute
+
+	defaultSLOWindow = "5m"
+	defaultSLOFor    = "5m"
+)
+
+// MetricsQuerier runs an instant PromQL query. promv1.API satisfies it.
+type MetricsQuerier interface {
+	Query(ctx context.Context, query string, ts time.Time, opts ...promv1.Option) (model.Value, promv1.Warnings, error)
+}
+
+// PrometheusRuleConfig configures the PrometheusRules generated from
+// AgentRuntime spec.slos. The zero value disables them; the SLOBreached
+// condition is still reported when a MetricsQuerier is configured.
+type PrometheusRuleConfig struct {
+	// Enabled is set only when the PrometheusRule CRD was detected at startup.
+	Enabled bool
+	// Labels are added to every PrometheusRule so the Prometheus Operator's
+	// ruleSelector adopts them (e.g. release: kube-prometheus-stack).
+	Labels map[string]string
+}
+
+// PrometheusRuleCRDInstalled reports whether the cluster serves the Prometheus
+// Operator PrometheusRule API. Called once at operator startup.
+func PrometheusRuleCRDInstalled(dc discovery.DiscoveryInterface) (bool, error) {
+	return monitoringKindInstalled(dc, prometheusRuleKind)
+}
+
+// sloObjective is one objective of spec.slos as PromQL: query yields the
+// observed value, which breaches when it exceeds threshold.
+type sloObjective struct {
+	name      string
+	alert     string
+	query     string
+	threshold float64
+	// format renders an observed value or the threshold for messages.
+	format func(float64) string
+}
+
+func (o sloObjective) expr() string {
+	return fmt.Sprintf("%s > %s", o.query, strconv.FormatFloat(o.threshold, 'f', -1, 64))
+}
+
+// sloObjectives translates spec.slos into PromQL. The facade and runtime
+// metrics both carry the agent and namespace labels, so the selectors work
+// with any scrape config.
+func sloObjectives(ar *omniav1alpha1.AgentRuntime) ([]sloObjective, error) {
+	slos := ar.Spec.SLOs
+	window := defaultSLOWindow
+	if slos.Window != "" {
+		window = slos.Window
+	}
+	if _, err := model.ParseDuration(window); err != nil {
+		return nil, fmt.Errorf("invalid slos.window %q: %w", window, err)
+	}
+	sel := fmt.Sprintf("namespace=%q,agent=%q", ar.Namespace, ar.Name)
+
+	var objectives []sloObjective
+	if slos.MaxP95Latency != "" {
+		d, err := time.ParseDuration(slos.MaxP95Latency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slos.maxP95Latency %q: %w", slos.MaxP95Latency, err)
+		}
+		objectives = append(objectives, sloObjective{
+			name:  "p95 latency",
+			alert: "OmniaAgentP95LatencyHigh",
+			query: fmt.Sprintf(
+				"histogram_quantile(0.95, sum by (le) (rate(omnia_agent_request_duration_seconds_bucket{%s}[%s])))",
+				sel, window),
+			threshold: d.Seconds(),
+			format: func(v float64) string {
+				if math.IsInf(v, 1) {
+					// Above the histogram's largest finite bucket.
+					return "+Inf"
+				}
+				return time.Duration(v * float64(time.Second)).Round(time.Millisecond).String()
+			},
+		})
+	}
+	if slos.MaxErrorRate != "" {
+		rate, err := strconv.ParseFloat(slos.MaxErrorRate, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slos.maxErrorRate %q: %w", slos.MaxErrorRate, err)
+		}
+		objectives = append(objectives, sloObjective{
+			name:  "error rate",
+			alert: "OmniaAgentErrorRateHigh",
+			query: fmt.Sprintf(
+				"sum(rate(omnia_agent_requests_total{%s,status=\"error\"}[%s])) / sum(rate(omnia_agent_requests_total{%s}[%s]))",
+				sel, window, sel, window),
+			threshold: rate,
+			format:    func(v float64) string { return strconv.FormatFloat(v*100, 'f', 2, 64) + "%" },
+		})
+	}
+	if slos.MaxCostPerHourUSD != "" {
+		cost, err := strconv.ParseFloat(slos.MaxCostPerHourUSD, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slos.maxCostPerHourUSD %q: %w", slos.MaxCostPerHourUSD, err)
+		}
+		objectives = append(objectives, sloObjective{
+			name:  "cost per hour",
+			alert: "OmniaAgentCostPerHourHigh",
+			query: fmt.Sprintf(
+				"sum(rate(omnia_provider_cost_total{%s,source=\"agent\"}[%s])) * 3600", sel, window),
+			threshold: cost,
+			format:    func(v float64) string { return "$" + strconv.FormatFloat(v, 'f', 2, 64) + "/h" },
+		})
+	}
+	return objectives, nil
+}
+
+// prometheusRuleSpec builds one rule group with an alert per objective.
+func prometheusRuleSpec(ar *omniav1alpha1.AgentRuntime, objectives []sloObjective) map[string]interface{} {
+	forDuration := defaultSLOFor
+	if ar.Spec.SLOs.For != "" {
+		forDuration = ar.Spec.SLOs.For
+	}
+	rules := make([]interface{}, 0, len(objectives))
+	for _, o := range objectives {
+		rules = append(rules, map[string]interface{}{
+			"alert": o.alert,
+			"expr":  o.expr(),
+			"for":   forDuration,
+			"labels": map[string]interface{}{
+				"severity":  "warning",
+				"namespace": ar.Namespace,
+				"agent":     ar.Name,
+			},
+			"annotations": map[string]interface{}{
+				"summary": fmt.Sprintf("Agent %s/%s %s is above its SLO", ar.Namespace, ar.Name, o.name),
+				"description": fmt.Sprintf("%s has exceeded %s for %s (spec.slos on AgentRuntime %s/%s).",
+					o.name, o.format(o.threshold), forDuration, ar.Namespace, ar.Name),
+			},
+		})
+	}
+	return map[string]interface{}{
+		"groups": []interface{}{
+			map[string]interface{}{
+				"name":  fmt.Sprintf("omnia-agent-slos.%s.%s", ar.Namespace, ar.Name),
+				"rules": rules,
+			},
+		},
+	}
+}
+
+// newPrometheusRuleObject returns an empty PrometheusRule with its GVK, name
+// and namespace set.
+func newPrometheusRuleObject(name, namespace string) *unstructured.Unstructured {
+	pr := &unstructured.Unstructured{}
+	pr.SetGroupVersionKind(podMonitorGVK.GroupVersion().WithKind(prometheusRuleKind))
+	pr.SetName(name)
+	pr.SetNamespace(namespace)
+	return pr
+}
+
+// reconcileSLOs keeps the agent's PrometheusRule in line with spec.slos and
+// sets the SLOBreached condition. Non-blocking: failures are logged and
+// surfaced on the condition. Returns the delay until the next evaluation, or
+// 0 when the agent declares no SLOs.
+func (r *AgentRuntimeReconciler) reconcileSLOs(ctx context.Context, ar *omniav1alpha1.AgentRuntime) time.Duration {
+	log := logf.FromContext(ctx)
+
+	if ar.Spec.SLOs == nil {
+		if r.PrometheusRules.Enabled {
+			pr := newPrometheusRuleObject(ar.Name+prometheusRuleSuffix, ar.Namespace)
+			if err := r.Delete(ctx, pr); err != nil && !apierrors.IsNotFound(err) {
+				log.Error(err, "Failed to delete SLO PrometheusRule")
+			}
+		}
+		apimeta.RemoveStatusCondition(&ar.Status.Conditions, ConditionTypeSLOBreached)
+		return 0
+	}
+
+	objectives, err := sloObjectives(ar)
+	if err != nil {
+		SetCondition(&ar.Status.Conditions, ar.Generation, ConditionTypeSLOBreached,
+			metav1.ConditionUnknown, reasonSLOQueryFailed, err.Error())
+		return 0
+	}
+
+	if r.PrometheusRules.Enabled {
+		if err := r.reconcilePrometheusRule(ctx, ar, objectives); err != nil {
+			log.Error(err, "Failed to reconcile SLO PrometheusRule")
+		}
+	}
+
+	r.evaluateSLOs(ctx, ar, objectives)
+	return sloRefreshInterval
+}
+
+// reconcilePrometheusRule creates or updates the agent's <agent>-slos
+// PrometheusRule, owned by the AgentRuntime.
+func (r *AgentRuntimeReconciler) reconcilePrometheusRule(
+	ctx context.Context,
+	ar *omniav1alpha1.AgentRuntime,
+	objectives []sloObjective,
+) error {
+	pr := newPrometheusRuleObject(ar.Name+prometheusRuleSuffix, ar.Namespace)
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, pr, func() error {
+		if err := controllerutil.SetControllerReference(ar, pr, r.Scheme); err != nil {
+			return err
+		}
+		labels := make(map[string]string, len(r.PrometheusRules.Labels)+4)
+		for k, v := range r.PrometheusRules.Labels {
+			labels[k] = v
+		}
+		labels[labelAppName] = labelValueOmniaAgent
+		labels[labelAppInstance] = ar.Name
+		labels[labelAppManagedBy] = labelValueOmniaOperator
+		labels[labelOmniaComp] = "agent"
+		pr.SetLabels(labels)
+		return unstructured.SetNestedField(pr.Object, prometheusRuleSpec(ar, objectives), "spec")
+	})
+	if err != nil {
+		return fmt.Errorf("reconcile PrometheusRule %s/%s: %w", pr.GetNamespace(), pr.GetName(), err)
+	}
+	logf.FromContext(ctx).V(1).Info("SLO PrometheusRule reconciled", "name", pr.GetName(), "result", result)
+	return nil
+}
+
+// evaluateSLOs queries each objective's current value and sets SLOBreached.
+// An objective with no data (e.g. no traffic in the window) counts as met.
+func (r *AgentRuntimeReconciler) evaluateSLOs(
+	ctx context.Context,
+	ar *omniav1alpha1.AgentRuntime,
+	objectives []sloObjective,
+) {
+	if r.SLOQuerier == nil {
+		SetCondition(&ar.Status.Conditions, ar.Generation, ConditionTypeSLOBreached, metav1.ConditionUnknown,
+			reasonSLOPrometheusUnavailable, "no Prometheus configured for the operator (--prometheus-url); SLOs are not evaluated")
+		return
+	}
+
+	now := time.Now()
+	var breached []string
+	for _, o := range objectives {
+		value, ok, err := r.querySLOValue(ctx, o.query, now)
+		if err != nil {
+			SetCondition(&ar.Status.Conditions, ar.Generation, ConditionTypeSLOBreached, metav1.ConditionUnknown,
+				reasonSLOQueryFailed, fmt.Sprintf("%s: %v", o.name, err))
+			return
+		}
+		if ok && value > o.threshold {
+			breached = append(breached, fmt.Sprintf("%s %s exceeds %s", o.name, o.format(value), o.format(o.threshold)))
+		}
+	}
+
+	if len(breached) == 0 {
+		SetCondition(&ar.Status.Conditions, ar.Generation, ConditionTypeSLOBreached, metav1.ConditionFalse,
+			reasonSLOsMet, "all objectives are met")
+		return
+	}
+	msg := strings.Join(breached, "; ")
+	wasBreached := apimeta.IsStatusConditionTrue(ar.Status.Conditions, ConditionTypeSLOBreached)
+	SetCondition(&ar.Status.Conditions, ar.Generation, ConditionTypeSLOBreached, metav1.ConditionTrue,
+		reasonSLOsBreached, msg)
+	if !wasBreached && r.Recorder != nil {
+		r.Recorder.Event(ar, corev1.EventTypeWarning, reasonSLOsBreached, msg)
+	}
+}
+
+// querySLOValue runs query and returns its single value. ok is false when the
+// result is empty or NaN (e.g. a ratio or quantile over zero requests).
+func (r *AgentRuntimeReconciler) querySLOValue(ctx context.Context, query string, ts time.Time) (float64, bool, error) {
+	result, _, err := r.SLOQuerier.Query(ctx, query, ts)
+	if err != nil {
+		return 0, false, err
+	}
+	vec, isVector := result.(model.Vector)
+	if !isVector {
+		return 0, false, fmt.Errorf("unsupported result type %T", result)
+	}
+	if len(vec) == 0 {
+		return 0, false, nil
+	}
+	v := float64(vec[0].Value)
+	if math.IsNaN(v) {
+		return 0, false, nil
+	}
+	return v, true, nil
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2026 Altaira Labs.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+)
+
+// fakeSLOQuerier answers each query with the value of the first entry whose
+// key is a substring of the query; no match yields an empty vector.
+type fakeSLOQuerier struct {
+	values map[string]float64
+	err    error
+}
+
+func (f *fakeSLOQuerier) Query(_ context.Context, query string, _ time.Time, _ ...promv1.Option) (model.Value, promv1.Warnings, error) {
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	for k, v := range f.values {
+		if strings.Contains(query, k) {
+			return model.Vector{{Value: model.SampleValue(v)}}, nil, nil
+		}
+	}
+	return model.Vector{}, nil, nil
+}
+
+func sloTestAgent(slos *omniav1alpha1.AgentSLOs) *omniav1alpha1.AgentRuntime {
+	return &omniav1alpha1.AgentRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "support", Namespace: "agents", UID: "uid-1", Generation: 3},
+		Spec:       omniav1alpha1.AgentRuntimeSpec{SLOs: slos},
+	}
+}
+
+func allSLOs() *omniav1alpha1.AgentSLOs {
+	return &omniav1alpha1.AgentSLOs{
+		MaxP95Latency:     "2s",
+		MaxErrorRate:      "0.05",
+		MaxCostPerHourUSD: "10",
+		Window:            "10m",
+		For:               "15m",
+	}
+}
+
+func sloCondition(t *testing.T, ar *omniav1alpha1.AgentRuntime) *metav1.Condition {
+	t.Helper()
+	cond := apimeta.FindStatusCondition(ar.Status.Conditions, ConditionTypeSLOBreached)
+	require.NotNil(t, cond)
+	return cond
+}
+
+func TestSLOObjectives_Queries(t *testing.T) {
+	objectives, err := sloObjectives(sloTestAgent(allSLOs()))
+	require.NoError(t, err)
+	require.Len(t, objectives, 3)
+
+	sel := `namespace="agents",agent="support"`
+	require.Equal(t,
+		`histogram_quantile(0.95, sum by (le) (rate(omnia_agent_request_duration_seconds_bucket{`+sel+`}[10m]))) > 2`,
+		objectives[0].expr())
+	require.Equal(t,
+		`sum(rate(omnia_agent_requests_total{`+sel+`,status="error"}[10m])) / sum(rate(omnia_agent_requests_total{`+sel+`}[10m])) > 0.05`,
+		objectives[1].expr())
+	require.Equal(t,
+		`sum(rate(omnia_provider_cost_total{`+sel+`,source="agent"}[10m])) * 3600 > 10`,
+		objectives[2].expr())
+}
+
+func TestSLOObjectives_OnlyDeclared(t *testing.T) {
+	objectives, err := sloObjectives(sloTestAgent(&omniav1alpha1.AgentSLOs{MaxErrorRate: "0.1"}))
+	require.NoError(t, err)
+	require.Len(t, objectives, 1)
+	require.Equal(t, "OmniaAgentErrorRateHigh", objectives[0].alert)
+	require.Contains(t, objectives[0].query, "[5m]", "window defaults to 5m")
+}
+
+func TestEvaluateSLOs(t *testing.T) {
+	tests := []struct {
+		name       string
+		querier    MetricsQuerier
+		wantStatus metav1.ConditionStatus
+		wantReason string
+		wantMsg    []string
+	}{
+		{
+			name:       "no prometheus",
+			wantStatus: metav1.ConditionUnknown,
+			wantReason: reasonSLOPrometheusUnavailable,
+		},
+		{
+			name:       "query error",
+			querier:    &fakeSLOQuerier{err: errors.New("connection refused")},
+			wantStatus: metav1.ConditionUnknown,
+			wantReason: reasonSLOQueryFailed,
+			wantMsg:    []string{"connection refused"},
+		},
+		{
+			name:       "no traffic counts as met",
+			querier:    &fakeSLOQuerier{values: map[string]float64{"omnia_agent_requests_total": math.NaN()}},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: reasonSLOsMet,
+		},
+		{
+			name: "within objectives",
+			querier: &fakeSLOQuerier{values: map[string]float64{
+				"omnia_agent_request_duration_seconds": 1.5,
+				"omnia_agent_requests_total":           0.01,
+				"omnia_provider_cost_total":            9,
+			}},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: reasonSLOsMet,
+		},
+		{
+			name: "breached",
+			querier: &fakeSLOQuerier{values: map[string]float64{
+				"omnia_agent_request_duration_seconds": math.Inf(1),
+				"omnia_agent_requests_total":           0.2,
+				"omnia_provider_cost_total":            3,
+			}},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: reasonSLOsBreached,
+			wantMsg:    []string{"p95 latency +Inf exceeds 2s", "error rate 20.00% exceeds 5.00%"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ar := sloTestAgent(allSLOs())
+			objectives, err := sloObjectives(ar)
+			require.NoError(t, err)
+			r := &AgentRuntimeReconciler{SLOQuerier: tt.querier}
+
+			r.evaluateSLOs(context.Background(), ar, objectives)
+
+			cond := sloCondition(t, ar)
+			require.Equal(t, tt.wantStatus, cond.Status)
+			require.Equal(t, tt.wantReason, cond.Reason)
+			for _, m := range tt.wantMsg {
+				require.Contains(t, cond.Message, m)
+			}
+			if tt.wantStatus == metav1.ConditionTrue {
+				require.NotContains(t, cond.Message, "cost per hour")
+			}
+		})
+	}
+}
+
+func TestEvaluateSLOs_EventOnlyOnTransition(t *testing.T) {
+	ar := sloTestAgent(&omniav1alpha1.AgentSLOs{MaxErrorRate: "0.05"})
+	objectives, err := sloObjectives(ar)
+	require.NoError(t, err)
+	recorder := record.NewFakeRecorder(4)
+	r := &AgentRuntimeReconciler{
+		SLOQuerier: &fakeSLOQuerier{values: map[string]float64{"omnia_agent_requests_total": 0.5}},
+		Recorder:   recorder,
+	}
+
+	r.evaluateSLOs(context.Background(), ar, objectives)
+	r.evaluateSLOs(context.Background(), ar, objectives)
+
+	require.Len(t, recorder.Events, 1)
+	require.Contains(t, <-recorder.Events, reasonSLOsBreached)
+}
+
+func TestReconcileSLOs_PrometheusRuleLifecycle(t *testing.T) {
+	scheme := evalWorkerTestScheme()
+	ruleGVK := podMonitorGVK.GroupVersion().WithKind(prometheusRuleKind)
+	scheme.AddKnownTypeWithName(ruleGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(ruleGVK.GroupVersion().WithKind(prometheusRuleKind+"List"), &unstructured.UnstructuredList{})
+
+	ar := sloTestAgent(allSLOs())
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ar).Build()
+	r := &AgentRuntimeReconciler{
+		Client:          c,
+		Scheme:          scheme,
+		PrometheusRules: PrometheusRuleConfig{Enabled: true, Labels: map[string]string{"release": "kps"}},
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "support-slos", Namespace: "agents"}
+
+	require.Equal(t, sloRefreshInterval, r.reconcileSLOs(ctx, ar))
+
+	rule := newPrometheusRuleObject(key.Name, key.Namespace)
+	require.NoError(t, c.Get(ctx, key, rule))
+	require.Equal(t, "kps", rule.GetLabels()["release"])
+	require.Equal(t, "AgentRuntime", rule.GetOwnerReferences()[0].Kind)
+	groups, _, _ := unstructured.NestedSlice(rule.Object, "spec", "groups")
+	require.Len(t, groups, 1)
+	rules := groups[0].(map[string]interface{})["rules"].([]interface{})
+	require.Len(t, rules, 3)
+	first := rules[0].(map[string]interface{})
+	require.Equal(t, "OmniaAgentP95LatencyHigh", first["alert"])
+	require.Equal(t, "15m", first["for"])
+	require.Equal(t, "support", first["labels"].(map[string]interface{})["agent"])
+
+	// Without a querier the condition is Unknown rather than absent.
+	require.Equal(t, metav1.ConditionUnknown, sloCondition(t, ar).Status)
+
+	// Removing spec.slos deletes the rule and the condition.
+	ar.Spec.SLOs = nil
+	require.Zero(t, r.reconcileSLOs(ctx, ar))
+	require.Error(t, c.Get(ctx, key, newPrometheusRuleObject(key.Name, key.Namespace)))
+	require.Nil(t, apimeta.FindStatusCondition(ar.Status.Conditions, ConditionTypeSLOBreached))
+}
+
+func TestReconcileSLOs_RulesDisabledStillEvaluates(t *testing.T) {
+	// The scheme does not know PrometheusRule, so any rule write would fail.
+	scheme := evalWorkerTestScheme()
+	ar := sloTestAgent(&omniav1alpha1.AgentSLOs{MaxCostPerHourUSD: "1"})
+	r := &AgentRuntimeReconciler{
+		Client:     fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:     scheme,
+		SLOQuerier: &fakeSLOQuerier{values: map[string]float64{"omnia_provider_cost_total": 4.5}},
+	}
+
+	require.Equal(t, sloRefreshInterval, r.reconcileSLOs(context.Background(), ar))
+
+	cond := sloCondition(t, ar)
+	require.Equal(t, metav1.ConditionTrue, cond.Status)
+	require.Equal(t, "cost per hour $4.50/h exceeds $1.00/h", cond.Message)
+}
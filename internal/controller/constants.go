@@ -245,6 +245,12 @@ const (
 	// not advertise a capability the AgentRuntime's spec requires (§4.4). While
 	// False for the current generation, the Deployment is scaled to 0.
 	ConditionTypeCapabilitiesSatisfied = "CapabilitiesSatisfied"
+
+	// ConditionTypeSLOBreached is True while any objective in spec.slos is
+	// breached over its window, False while all are met, and Unknown when the
+	// operator has no Prometheus to evaluate them against. Absent when the
+	// agent declares no SLOs.
+	ConditionTypeSLOBreached = "SLOBreached"
 )
 
 // Autoscaling condition reasons.
@@ -255,6 +261,14 @@ const (
 	reasonAutoscalingKEDAMissing = "KEDANotInstalled"
 )
 
+// SLO condition reasons.
+const (
+	reasonSLOsMet                  = "ObjectivesMet"
+	reasonSLOsBreached             = "ObjectivesBreached"
+	reasonSLOPrometheusUnavailable = "PrometheusNotConfigured"
+	reasonSLOQueryFailed           = "QueryFailed"
+)
+
 // Capability-gating condition reasons.
 const (
 	reasonCapabilitiesMissing   = "CapabilitiesMissing"
@@ -91,6 +91,11 @@ func (r *AgentRuntimeReconciler) getConfigHash(
 		if ref := effectiveSecretRef(provider); ref != nil {
 			r.hashSecretData(ctx, hasher, ref.Name, provider.Namespace)
 		}
+		// The runtime reads a spec.tls client certificate at startup, so a
+		// renewed certificate must roll the pod.
+		if t := provider.Spec.TLS; t != nil && t.SecretRef != nil {
+			r.hashSecretData(ctx, hasher, t.SecretRef.Name, provider.Namespace)
+		}
 	}
 
 	return finishHash(hasher)
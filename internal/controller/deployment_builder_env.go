@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
 	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
 )
@@ -29,6 +30,17 @@ import (
 // Redis connection URL (consumed by the facade's blip-resume route store).
 const secretKeyRedisURL = "url"
 
+// secretKeyRelayKey is the optional key within the same secret that holds the
+// key facade replicas seal session relay envelopes with. Without it the
+// facade does not relay sessions across replicas.
+const secretKeyRelayKey = "relayKey"
+
+// Keys within the spec.knowledge.vectorStore secret.
+const (
+	secretKeyKnowledgeURL    = "url"
+	secretKeyKnowledgeAPIKey = "apiKey"
+)
+
 // buildFacadeEnvVars creates environment variables for the facade container.
 func (r *AgentRuntimeReconciler) buildFacadeEnvVars(
 	agentRuntime *omniav1alpha1.AgentRuntime,
@@ -147,7 +159,8 @@ func (r *AgentRuntimeReconciler) buildFacadeEnvVars(
 	// OMNIA_ROUTE_REDIS_URL — the Redis URL used by the facade's blip-resume
 	// route store. Sourced from the same secret as the context store when a
 	// Redis-backed context store is configured; omitted otherwise so the facade
-	// falls back to the noop route store silently.
+	// falls back to the noop route store silently. OMNIA_SESSION_RELAY_KEY,
+	// from the secret's optional relayKey, enables the cross-replica relay.
 	if agentRuntime.Spec.Context != nil &&
 		agentRuntime.Spec.Context.Type == omniav1alpha1.ContextStoreTypeRedis &&
 		agentRuntime.Spec.Context.StoreRef != nil {
@@ -159,6 +172,15 @@ func (r *AgentRuntimeReconciler) buildFacadeEnvVars(
 					Key:                  secretKeyRedisURL,
 				},
 			},
+		}, corev1.EnvVar{
+			Name: "OMNIA_SESSION_RELAY_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: *agentRuntime.Spec.Context.StoreRef,
+					Key:                  secretKeyRelayKey,
+					Optional:             ptr.To(true),
+				},
+			},
 		})
 	}
 
@@ -237,10 +259,14 @@ func (r *AgentRuntimeReconciler) buildRuntimeEnvVars(
 			Name:  "OMNIA_TOOLS_CONFIG_PATH",
 			Value: ToolsMountPath + "/" + ToolsConfigFileName,
 		})
-		// The runtime unions the registry's tools into the pack's allowed-tools
-		// list so they reach the model, writing the rewritten pack here. The
-		// container root filesystem is read-only, so this MUST point at the
-		// writable emptyDir the operator mounts (see buildVolumes).
+	}
+
+	// The runtime writes rewritten packs here — registry tools unioned into
+	// the allowed-tools list, a structured-output schema taken over, the
+	// knowledge placeholder added. The container root filesystem is
+	// read-only, so this MUST point at the writable emptyDir the operator
+	// mounts (see buildVolumes).
+	if needsPackCache(agentRuntime, toolRegistry) {
 		envVars = append(envVars, corev1.EnvVar{
 			Name:  "OMNIA_PACK_CACHE_DIR",
 			Value: RuntimePackCacheMountPath,
@@ -319,6 +345,8 @@ func (r *AgentRuntimeReconciler) buildRuntimeEnvVars(
 		})
 	}
 
+	envVars = append(envVars, runtimeKnowledgeEnvVars(agentRuntime)...)
+
 	// Activate the runtime's PolicyBrokerClient (internal/runtime/tools) by
 	// pointing it at the co-located policy-broker sidecar. The client is a
 	// no-op unless POLICY_BROKER_URL is set, so this env var is the sole
@@ -349,6 +377,39 @@ func (r *AgentRuntimeReconciler) buildRuntimeEnvVars(
 	return envVars
 }
 
+// runtimeKnowledgeEnvVars returns the vector store credentials the runtime's
+// knowledge retrieval connects with, sourced from spec.knowledge.vectorStore's
+// secret: OMNIA_KNOWLEDGE_URL from its "url" key and, when present,
+// OMNIA_KNOWLEDGE_API_KEY from its "apiKey" key. The store type, collection
+// and retrieval limits are read from the CRD by the runtime itself.
+func runtimeKnowledgeEnvVars(agentRuntime *omniav1alpha1.AgentRuntime) []corev1.EnvVar {
+	k := agentRuntime.Spec.Knowledge
+	if k == nil || !k.Enabled {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{
+			Name: "OMNIA_KNOWLEDGE_URL",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: k.VectorStore.SecretRef,
+					Key:                  secretKeyKnowledgeURL,
+				},
+			},
+		},
+		{
+			Name: "OMNIA_KNOWLEDGE_API_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: k.VectorStore.SecretRef,
+					Key:                  secretKeyKnowledgeAPIKey,
+					Optional:             ptr.To(true),
+				},
+			},
+		},
+	}
+}
+
 // runtimeMediaEnvVars returns the runtime container's media-related env vars:
 // OMNIA_FACADE_PORT (the runtime's local media backend needs this to build
 // download URLs pointing at the co-located facade container — uses the same
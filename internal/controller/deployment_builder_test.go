@@ -1034,6 +1034,51 @@ func TestRuntimeEnv_NoContextURLForMemory(t *testing.T) {
 	}
 }
 
+// TestRuntimeEnv_Knowledge verifies that enabling spec.knowledge injects the
+// vector store URL and optional API key from its secret, and the writable
+// pack-cache dir the runtime stages the knowledge-enabled pack in, even
+// without a ToolRegistry.
+func TestRuntimeEnv_Knowledge(t *testing.T) {
+	r := &AgentRuntimeReconciler{}
+	ar := &omniav1alpha1.AgentRuntime{
+		Spec: omniav1alpha1.AgentRuntimeSpec{
+			Knowledge: &omniav1alpha1.KnowledgeConfig{
+				Enabled: true,
+				VectorStore: omniav1alpha1.VectorStoreConfig{
+					Type:       omniav1alpha1.VectorStoreTypeQdrant,
+					Collection: "docs",
+					SecretRef:  corev1.LocalObjectReference{Name: "qdrant-credentials"},
+				},
+			},
+		},
+	}
+	env := r.buildRuntimeEnvVars(ar, nil, nil)
+
+	url := findEnvVar(env, "OMNIA_KNOWLEDGE_URL")
+	if url == nil || url.ValueFrom == nil || url.ValueFrom.SecretKeyRef == nil {
+		t.Fatalf("OMNIA_KNOWLEDGE_URL not sourced from secret: %+v", url)
+	}
+	if url.ValueFrom.SecretKeyRef.Name != "qdrant-credentials" || url.ValueFrom.SecretKeyRef.Key != "url" {
+		t.Fatalf("wrong secret key ref: %+v", url.ValueFrom.SecretKeyRef)
+	}
+	apiKey := findEnvVar(env, "OMNIA_KNOWLEDGE_API_KEY")
+	if apiKey == nil || apiKey.ValueFrom == nil || apiKey.ValueFrom.SecretKeyRef == nil {
+		t.Fatalf("OMNIA_KNOWLEDGE_API_KEY not sourced from secret: %+v", apiKey)
+	}
+	if apiKey.ValueFrom.SecretKeyRef.Key != "apiKey" || !ptr.Deref(apiKey.ValueFrom.SecretKeyRef.Optional, false) {
+		t.Fatalf("API key must be the optional apiKey key: %+v", apiKey.ValueFrom.SecretKeyRef)
+	}
+	if e := findEnvVar(env, "OMNIA_PACK_CACHE_DIR"); e == nil || e.Value != RuntimePackCacheMountPath {
+		t.Fatalf("OMNIA_PACK_CACHE_DIR not set for knowledge: %+v", e)
+	}
+
+	ar.Spec.Knowledge.Enabled = false
+	env = r.buildRuntimeEnvVars(ar, nil, nil)
+	if findEnvVar(env, "OMNIA_KNOWLEDGE_URL") != nil || findEnvVar(env, "OMNIA_PACK_CACHE_DIR") != nil {
+		t.Fatal("knowledge env must not be set when knowledge is disabled")
+	}
+}
+
 // TestBuildRuntimeEnvVars_SkillManifestPathKeyedOnResolvedPack is the #1837
 // Task 5 regression: OMNIA_PROMPTPACK_MANIFEST_PATH must be keyed on the
 // RESOLVED PromptPack's object name, not agentRuntime.Spec.PromptPackRef.Name
@@ -1415,6 +1460,38 @@ func TestGetConfigHash_ProviderModelChange(t *testing.T) {
 	assert.NotEqual(t, hash1, hash2, "model change should produce different hash")
 }
 
+func TestGetConfigHash_ProviderTLSSecretChange(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = omniav1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	provider := &omniav1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "vllm", Namespace: "default"},
+		Spec: omniav1alpha1.ProviderSpec{
+			Type:  "vllm",
+			Model: "llama3.1",
+			TLS: &omniav1alpha1.ProviderTLSConfig{
+				SecretRef: &corev1.LocalObjectReference{Name: "vllm-client-cert"},
+			},
+		},
+	}
+	providers := map[string]*omniav1alpha1.Provider{"default": provider}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vllm-client-cert", Namespace: "default"},
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert-1"), corev1.TLSPrivateKeyKey: []byte("key-1")},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	r := &AgentRuntimeReconciler{Client: fakeClient, Scheme: scheme}
+	ctx := context.Background()
+
+	hash1 := r.getConfigHash(ctx, providers, nil, nil)
+
+	secret.Data[corev1.TLSCertKey] = []byte("cert-2")
+	require.NoError(t, fakeClient.Update(ctx, secret))
+	hash2 := r.getConfigHash(ctx, providers, nil, nil)
+	assert.NotEqual(t, hash1, hash2, "a renewed client certificate should roll the pod")
+}
+
 func TestGetConfigHash_FieldSensitivity(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = omniav1alpha1.AddToScheme(scheme)
@@ -139,19 +139,32 @@ func (r *AgentRuntimeReconciler) ensureEvalWorkerDeployment(
 	key := types.NamespacedName{Name: evalWorkerName(serviceGroup), Namespace: namespace}
 	err := r.Get(ctx, key, existing)
 
-	if apierrors.IsNotFound(err) {
+	switch {
+	case apierrors.IsNotFound(err):
 		log.Info("creating eval worker Deployment", "namespace", namespace, "serviceGroup", serviceGroup)
-		return r.Create(ctx, desired)
-	}
-	if err != nil {
+		if err := r.Create(ctx, desired); err != nil {
+			return err
+		}
+	case err != nil:
 		return fmt.Errorf("failed to get eval worker Deployment: %w", err)
+	default:
+		// Update the existing deployment spec
+		existing.Labels = desired.Labels
+		existing.Spec = desired.Spec
+		log.V(1).Info("updating eval worker Deployment", "namespace", namespace, "serviceGroup", serviceGroup)
+		if err := r.Update(ctx, existing); err != nil {
+			return err
+		}
 	}
 
-	// Update the existing deployment spec
-	existing.Labels = desired.Labels
-	existing.Spec = desired.Spec
-	log.V(1).Info("updating eval worker Deployment", "namespace", namespace, "serviceGroup", serviceGroup)
-	return r.Update(ctx, existing)
+	// The eval worker is shared across agents, so its PodMonitor has no owner;
+	// cleanupEvalWorkers removes it with the Deployment.
+	return reconcilePodMonitor(ctx, r.Client, r.Scheme, r.PodMonitors, nil, podMonitorTarget{
+		name:      desired.Name,
+		namespace: namespace,
+		labels:    desired.Labels,
+		selector:  desired.Spec.Selector.MatchLabels,
+	})
 }
 
 // cleanupEvalWorkers deletes operator-managed eval worker Deployments and their
@@ -175,6 +188,9 @@ func (r *AgentRuntimeReconciler) cleanupEvalWorkers(
 		&rbacv1.RoleList{},
 		&rbacv1.RoleBindingList{},
 	}
+	if r.PodMonitors.Enabled {
+		namespaced = append(namespaced, newPodMonitorList())
+	}
 	for _, list := range namespaced {
 		if err := r.deleteStaleEvalWorkerObjects(ctx, list, needed,
 			client.InNamespace(namespace), nsLabels); err != nil {
@@ -245,6 +245,13 @@ func (r *AgentRuntimeReconciler) reconcileRole(
 				Resources: []string{"toolpolicies"},
 				Verbs:     []string{"get", "list", "watch"},
 			},
+			{
+				// The runtime reads the transforms of the AgentPolicies
+				// selecting its agent at startup.
+				APIGroups: []string{"omnia.altairalabs.ai"},
+				Resources: []string{"agentpolicies"},
+				Verbs:     []string{"get", "list"},
+			},
 		}
 		return nil
 	})
@@ -117,6 +117,44 @@ func TestReconcileRole_IncludesToolPoliciesReadAccess(t *testing.T) {
 		"facade Role must grant get/list/watch on toolpolicies for the policy-broker sidecar")
 }
 
+// TestReconcileRole_IncludesAgentPoliciesReadAccess asserts the runtime can
+// list the AgentPolicies whose transforms it applies at startup; without it
+// the runtime fails to load its config.
+func TestReconcileRole_IncludesAgentPoliciesReadAccess(t *testing.T) {
+	ar := &omniav1alpha1.AgentRuntime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-agent",
+			Namespace: "test-ns",
+			UID:       "fake-uid",
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, omniav1alpha1.AddToScheme(scheme))
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &AgentRuntimeReconciler{Client: fakeClient, Scheme: scheme}
+	require.NoError(t, r.reconcileRole(context.Background(), ar))
+
+	role := &rbacv1.Role{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{
+		Name:      "test-agent-facade",
+		Namespace: "test-ns",
+	}, role))
+
+	var verbs []string
+	for _, rule := range role.Rules {
+		for _, res := range rule.Resources {
+			if res == "agentpolicies" {
+				verbs = rule.Verbs
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"get", "list"}, verbs,
+		"facade Role must grant get/list on agentpolicies for the runtime's transforms")
+}
+
 // TestReconcileRole_ExcludesToolRegistriesReadAccess asserts the runtime's
 // agent Role no longer grants toolregistries. The runtime's only GET was
 // vestigial and 403'd on cross-namespace refs; registry provenance now comes
@@ -0,0 +1,228 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+)
+
+// Prometheus Operator PodMonitor API. Built as unstructured objects to avoid a
+// dependency on the prometheus-operator API module (same as KEDA ScaledObjects).
+const (
+	monitoringAPIGroup        = "monitoring.coreos.com"
+	monitoringAPIVersion      = "v1"
+	podMonitorKind            = "PodMonitor"
+	defaultPodMonitorInterval = "30s"
+)
+
+var podMonitorGVK = schema.GroupVersionKind{
+	Group:   monitoringAPIGroup,
+	Version: monitoringAPIVersion,
+	Kind:    podMonitorKind,
+}
+
+// PodMonitorConfig configures operator-managed Prometheus Operator PodMonitors
+// for the workloads the operator deploys (agent facade + runtime, session-api,
+// memory-api, eval worker). Each PodMonitor scrapes the pods' "metrics"-named
+// container ports, so scrape config follows the workloads as they are created
+// and removed. The zero value disables the feature.
+type PodMonitorConfig struct {
+	// Enabled is set only when the PodMonitor CRD was detected at startup.
+	Enabled bool
+	// Interval is the scrape interval. Empty defaults to 30s.
+	Interval string
+	// Labels are added to every PodMonitor so the Prometheus Operator's
+	// podMonitorSelector adopts them (e.g. release: kube-prometheus-stack).
+	Labels map[string]string
+}
+
+// PodMonitorCRDInstalled reports whether the cluster serves the Prometheus
+// Operator PodMonitor API. Called once at operator startup.
+func PodMonitorCRDInstalled(dc discovery.DiscoveryInterface) (bool, error) {
+	return monitoringKindInstalled(dc, podMonitorKind)
+}
+
+// monitoringKindInstalled reports whether the cluster serves kind in the
+// monitoring.coreos.com/v1 API.
+func monitoringKindInstalled(dc discovery.DiscoveryInterface, kind string) (bool, error) {
+	resources, err := dc.ServerResourcesForGroupVersion(podMonitorGVK.GroupVersion().String())
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, r := range resources.APIResources {
+		if r.Kind == kind {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// newPodMonitorObject returns an empty PodMonitor with its GVK, name and
+// namespace set, for Get/Delete/CreateOrUpdate.
+func newPodMonitorObject(name, namespace string) *unstructured.Unstructured {
+	pm := &unstructured.Unstructured{}
+	pm.SetGroupVersionKind(podMonitorGVK)
+	pm.SetName(name)
+	pm.SetNamespace(namespace)
+	return pm
+}
+
+// newPodMonitorList returns an empty PodMonitor list for List calls.
+func newPodMonitorList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(podMonitorGVK.GroupVersion().WithKind(podMonitorKind + "List"))
+	return list
+}
+
+// podMonitorTarget describes one PodMonitor: its name, namespace and labels,
+// the pods it selects, and pod labels copied onto every scraped series
+// (pod label name -> series label name).
+type podMonitorTarget struct {
+	name, namespace  string
+	labels, selector map[string]string
+	podLabelTargets  map[string]string
+}
+
+// podMonitorSpec builds the PodMonitor spec: pods matching selector in the
+// PodMonitor's own namespace, scraped on every container port named "metrics".
+func (c PodMonitorConfig) podMonitorSpec(target podMonitorTarget) map[string]interface{} {
+	interval := c.Interval
+	if interval == "" {
+		interval = defaultPodMonitorInterval
+	}
+	matchLabels := make(map[string]interface{}, len(target.selector))
+	for k, v := range target.selector {
+		matchLabels[k] = v
+	}
+	endpoint := map[string]interface{}{
+		"port":     metricsPortName,
+		"path":     "/metrics",
+		"interval": interval,
+	}
+	if len(target.podLabelTargets) > 0 {
+		podLabels := make([]string, 0, len(target.podLabelTargets))
+		for podLabel := range target.podLabelTargets {
+			podLabels = append(podLabels, podLabel)
+		}
+		sort.Strings(podLabels)
+		relabelings := make([]interface{}, 0, len(podLabels))
+		for _, podLabel := range podLabels {
+			relabelings = append(relabelings, map[string]interface{}{
+				"sourceLabels": []interface{}{"__meta_kubernetes_pod_label_" + sanitizeLabelName(podLabel)},
+				"targetLabel":  target.podLabelTargets[podLabel],
+			})
+		}
+		endpoint["relabelings"] = relabelings
+	}
+	return map[string]interface{}{
+		"selector":            map[string]interface{}{"matchLabels": matchLabels},
+		"podMetricsEndpoints": []interface{}{endpoint},
+	}
+}
+
+// sanitizeLabelName maps a Kubernetes label name to the form Prometheus
+// service discovery uses in __meta_kubernetes_pod_label_<name>.
+func sanitizeLabelName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// podMonitorLabels merges the configured selector labels under the
+// component's own labels, which win on collision so cleanup selectors hold.
+func (c PodMonitorConfig) podMonitorLabels(labels map[string]string) map[string]string {
+	out := make(map[string]string, len(c.Labels)+len(labels))
+	for k, v := range c.Labels {
+		out[k] = v
+	}
+	for k, v := range labels {
+		out[k] = v
+	}
+	return out
+}
+
+// reconcilePodMonitor creates or updates the target's PodMonitor. When owner is
+// non-nil it becomes the controller owner, so the PodMonitor is
+// garbage-collected with it. A no-op when PodMonitors are disabled.
+func reconcilePodMonitor(
+	ctx context.Context,
+	c client.Client,
+	scheme *runtime.Scheme,
+	cfg PodMonitorConfig,
+	owner client.Object,
+	target podMonitorTarget,
+) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	name, namespace := target.name, target.namespace
+	pm := newPodMonitorObject(name, namespace)
+	result, err := controllerutil.CreateOrUpdate(ctx, c, pm, func() error {
+		if owner != nil {
+			if err := controllerutil.SetControllerReference(owner, pm, scheme); err != nil {
+				return err
+			}
+		}
+		pm.SetLabels(cfg.podMonitorLabels(target.labels))
+		return unstructured.SetNestedField(pm.Object, cfg.podMonitorSpec(target), "spec")
+	})
+	if err != nil {
+		return fmt.Errorf("reconcile PodMonitor %s/%s: %w", namespace, name, err)
+	}
+	logf.FromContext(ctx).V(1).Info("PodMonitor reconciled", "name", name, "namespace", namespace, "result", result)
+	return nil
+}
+
+// reconcileAgentPodMonitor scrapes every pod of the agent — stable and canary
+// tracks alike, facade and runtime containers both — via one PodMonitor owned
+// by the AgentRuntime. Series get the same "agent" label the chart's bundled
+// omnia-agents scrape job adds, so dashboards and rules work with either.
+func (r *AgentRuntimeReconciler) reconcileAgentPodMonitor(ctx context.Context, agentRuntime *omniav1alpha1.AgentRuntime) error {
+	labels := map[string]string{
+		labelAppName:      labelValueOmniaAgent,
+		labelAppInstance:  agentRuntime.Name,
+		labelAppManagedBy: labelValueOmniaOperator,
+		labelOmniaComp:    "agent",
+	}
+	return reconcilePodMonitor(ctx, r.Client, r.Scheme, r.PodMonitors, agentRuntime, podMonitorTarget{
+		name:            agentRuntime.Name,
+		namespace:       agentRuntime.Namespace,
+		labels:          labels,
+		selector:        labels,
+		podLabelTargets: map[string]string{labelAppInstance: "agent"},
+	})
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 Altaira Labs.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+)
+
+const testPodMonitorNS = "agents"
+
+func podMonitorTestScheme() *runtime.Scheme {
+	scheme := evalWorkerTestScheme()
+	scheme.AddKnownTypeWithName(podMonitorGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(
+		podMonitorGVK.GroupVersion().WithKind(podMonitorKind+"List"), &unstructured.UnstructuredList{})
+	return scheme
+}
+
+func enabledPodMonitors() PodMonitorConfig {
+	return PodMonitorConfig{Enabled: true, Interval: "15s", Labels: map[string]string{"release": "kps"}}
+}
+
+func getPodMonitor(t *testing.T, c client.Client, name string) *unstructured.Unstructured {
+	t.Helper()
+	pm := newPodMonitorObject(name, testPodMonitorNS)
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: testPodMonitorNS}, pm))
+	return pm
+}
+
+func TestPodMonitorCRDInstalled(t *testing.T) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	installed, err := PodMonitorCRDInstalled(dc)
+	require.NoError(t, err)
+	require.False(t, installed, "missing group version means not installed")
+
+	dc.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "monitoring.coreos.com/v1",
+		APIResources: []metav1.APIResource{{Name: "servicemonitors", Kind: "ServiceMonitor"}},
+	}}
+	installed, err = PodMonitorCRDInstalled(dc)
+	require.NoError(t, err)
+	require.False(t, installed, "ServiceMonitor alone is not enough")
+
+	dc.Resources[0].APIResources = append(dc.Resources[0].APIResources,
+		metav1.APIResource{Name: "podmonitors", Kind: "PodMonitor"})
+	installed, err = PodMonitorCRDInstalled(dc)
+	require.NoError(t, err)
+	require.True(t, installed)
+}
+
+func TestReconcilePodMonitor_DisabledIsNoop(t *testing.T) {
+	// The scheme does not know PodMonitor, so any client call would fail.
+	scheme := evalWorkerTestScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	require.NoError(t, reconcilePodMonitor(context.Background(), c, scheme, PodMonitorConfig{}, nil,
+		podMonitorTarget{name: "x", namespace: testPodMonitorNS, selector: map[string]string{"a": "b"}}))
+}
+
+func TestReconcileAgentPodMonitor(t *testing.T) {
+	scheme := podMonitorTestScheme()
+	ar := &omniav1alpha1.AgentRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "support", Namespace: testPodMonitorNS, UID: "uid-1"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ar).Build()
+	r := &AgentRuntimeReconciler{Client: c, Scheme: scheme, PodMonitors: enabledPodMonitors()}
+
+	require.NoError(t, r.reconcileAgentPodMonitor(context.Background(), ar))
+
+	pm := getPodMonitor(t, c, "support")
+	require.Equal(t, "kps", pm.GetLabels()["release"])
+	require.Equal(t, "agent", pm.GetLabels()[labelOmniaComp])
+	require.Len(t, pm.GetOwnerReferences(), 1)
+	require.Equal(t, "AgentRuntime", pm.GetOwnerReferences()[0].Kind)
+
+	sel, _, _ := unstructured.NestedStringMap(pm.Object, "spec", "selector", "matchLabels")
+	require.Equal(t, "support", sel[labelAppInstance])
+	require.NotContains(t, sel, labelOmniaTrack, "canary pods must be scraped too")
+
+	eps, _, _ := unstructured.NestedSlice(pm.Object, "spec", "podMetricsEndpoints")
+	require.Len(t, eps, 1)
+	ep := eps[0].(map[string]interface{})
+	require.Equal(t, metricsPortName, ep["port"])
+	require.Equal(t, "15s", ep["interval"])
+	require.Equal(t, []interface{}{map[string]interface{}{
+		"sourceLabels": []interface{}{"__meta_kubernetes_pod_label_app_kubernetes_io_instance"},
+		"targetLabel":  "agent",
+	}}, ep["relabelings"])
+}
+
+func TestPodMonitorLabels_ComponentLabelsWin(t *testing.T) {
+	cfg := PodMonitorConfig{Labels: map[string]string{"release": "kps", labelAppManagedBy: "helm"}}
+	got := cfg.podMonitorLabels(map[string]string{labelAppManagedBy: labelValueOmniaOperator})
+	require.Equal(t, labelValueOmniaOperator, got[labelAppManagedBy])
+	require.Equal(t, "kps", got["release"])
+}
+
+func TestEvalWorkerPodMonitor_CreatedAndCleanedUp(t *testing.T) {
+	scheme := podMonitorTestScheme()
+	r := &AgentRuntimeReconciler{
+		Client:      fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:      scheme,
+		PodMonitors: enabledPodMonitors(),
+	}
+	ctx := context.Background()
+
+	require.NoError(t, r.ensureEvalWorkerDeployment(ctx, testPodMonitorNS, "default", nil))
+	// Second pass takes the update path and must keep the PodMonitor.
+	require.NoError(t, r.ensureEvalWorkerDeployment(ctx, testPodMonitorNS, "default", nil))
+
+	name := evalWorkerName("default")
+	pm := getPodMonitor(t, r.Client, name)
+	require.Empty(t, pm.GetOwnerReferences())
+	require.Equal(t, "default", pm.GetLabels()[labelServiceGroup])
+
+	require.NoError(t, r.cleanupEvalWorkers(ctx, testPodMonitorNS, map[string]*omniav1alpha1.PodOverrides{}))
+	list := newPodMonitorList()
+	require.NoError(t, r.List(ctx, list, client.InNamespace(testPodMonitorNS)))
+	require.Empty(t, list.Items)
+	require.Error(t, r.Get(ctx, types.NamespacedName{Name: name, Namespace: testPodMonitorNS}, &appsv1.Deployment{}))
+}
+
+func TestWorkspaceCleanupOrphanedPodMonitors(t *testing.T) {
+	scheme := podMonitorTestScheme()
+	stale := newPodMonitorObject("session-ws-old", testPodMonitorNS)
+	stale.SetLabels(serviceLabels("session-api", "ws", "old"))
+	kept := newPodMonitorObject("session-ws-default", testPodMonitorNS)
+	kept.SetLabels(serviceLabels("session-api", "ws", "default"))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stale, kept).Build()
+	r := &WorkspaceReconciler{Client: c, Scheme: scheme, PodMonitors: enabledPodMonitors()}
+
+	require.NoError(t, r.cleanupOrphanedPodMonitors(context.Background(), "ws", testPodMonitorNS,
+		map[string]bool{"default": true}))
+
+	list := newPodMonitorList()
+	require.NoError(t, c.List(context.Background(), list, client.InNamespace(testPodMonitorNS)))
+	require.Len(t, list.Items, 1)
+	require.Equal(t, "session-ws-default", list.Items[0].GetName())
+}
@@ -578,7 +578,7 @@ func expectedPlatformSecretKeys(platform omniav1alpha1.PlatformType, auth omniav
 // the platform auth instead of an API key, so credentials are not required.
 //
 // Some role × type combinations don't need credentials regardless of role
-// (mock, self-hosted ollama, self-hosted vllm). Role currently doesn't
+// (mock, self-hosted ollama, vllm or openai-compatible). Role currently doesn't
 // flip credential requirements on its own — every cloud-hosted vendor needs
 // an API key for any role they implement.
 func providerRequiresCredentials(provider *omniav1alpha1.Provider) bool {
@@ -586,7 +586,8 @@ func providerRequiresCredentials(provider *omniav1alpha1.Provider) bool {
 		return false
 	}
 	switch provider.Spec.Type {
-	case omniav1alpha1.ProviderTypeMock, omniav1alpha1.ProviderTypeOllama, omniav1alpha1.ProviderTypeVLLM:
+	case omniav1alpha1.ProviderTypeMock, omniav1alpha1.ProviderTypeOllama, omniav1alpha1.ProviderTypeVLLM,
+		omniav1alpha1.ProviderTypeOpenAICompatible:
 		return false
 	default:
 		return true
@@ -607,16 +608,17 @@ func providerRequiresModel(provider *omniav1alpha1.Provider) bool {
 // deliberately short (these go stale as vendors ship models; they are hints,
 // not law).
 var suggestedModelHints = map[omniav1alpha1.ProviderType]string{
-	omniav1alpha1.ProviderTypeClaude:      "claude-sonnet-4-20250514, claude-opus-4-20250514",
-	omniav1alpha1.ProviderTypeOpenAI:      "gpt-4o, gpt-4o-mini, text-embedding-3-small",
-	omniav1alpha1.ProviderTypeGemini:      "gemini-2.5-flash, gemini-2.5-pro",
-	omniav1alpha1.ProviderTypeOllama:      "llama3.1, qwen2.5",
-	omniav1alpha1.ProviderTypeVLLM:        "the model id your vLLM server serves",
-	omniav1alpha1.ProviderTypeVoyageAI:    "voyage-3, voyage-3-lite",
-	omniav1alpha1.ProviderTypeCartesia:    "sonic-2",
-	omniav1alpha1.ProviderTypeElevenLabs:  "eleven_turbo_v2_5",
-	omniav1alpha1.ProviderTypeImagen:      "imagen-3.0-generate-002",
-	omniav1alpha1.ProviderTypeHuggingFace: "a model repo id, e.g. meta-llama/Llama-3.1-8B-Instruct",
+	omniav1alpha1.ProviderTypeClaude:           "claude-sonnet-4-20250514, claude-opus-4-20250514",
+	omniav1alpha1.ProviderTypeOpenAI:           "gpt-4o, gpt-4o-mini, text-embedding-3-small",
+	omniav1alpha1.ProviderTypeGemini:           "gemini-2.5-flash, gemini-2.5-pro",
+	omniav1alpha1.ProviderTypeOllama:           "llama3.1, qwen2.5",
+	omniav1alpha1.ProviderTypeVLLM:             "the model id your vLLM server serves",
+	omniav1alpha1.ProviderTypeOpenAICompatible: "the model id your server lists at /v1/models",
+	omniav1alpha1.ProviderTypeVoyageAI:         "voyage-3, voyage-3-lite",
+	omniav1alpha1.ProviderTypeCartesia:         "sonic-2",
+	omniav1alpha1.ProviderTypeElevenLabs:       "eleven_turbo_v2_5",
+	omniav1alpha1.ProviderTypeImagen:           "imagen-3.0-generate-002",
+	omniav1alpha1.ProviderTypeHuggingFace:      "a model repo id, e.g. meta-llama/Llama-3.1-8B-Instruct",
 }
 
 // modelSuggestion returns a human-readable hint of valid model IDs for the
@@ -75,6 +75,17 @@ func TestBuildFacadeEnvVars_SetsRouteRedisURLFromContextStoreRef(t *testing.T) {
 	if got.ValueFrom.SecretKeyRef.Key != testRedisSecretKey {
 		t.Errorf("SecretKeyRef.Key = %q, want %q", got.ValueFrom.SecretKeyRef.Key, testRedisSecretKey)
 	}
+
+	relayKey := findEnvVar(envs, "OMNIA_SESSION_RELAY_KEY")
+	if relayKey == nil || relayKey.ValueFrom == nil || relayKey.ValueFrom.SecretKeyRef == nil {
+		t.Fatalf("expected OMNIA_SESSION_RELAY_KEY from the storeRef secret, got %+v", relayKey)
+	}
+	if ref := relayKey.ValueFrom.SecretKeyRef; ref.Name != testRedisSecretName || ref.Key != secretKeyRelayKey {
+		t.Errorf("relay key SecretKeyRef = %s/%s, want %s/%s", ref.Name, ref.Key, testRedisSecretName, secretKeyRelayKey)
+	}
+	if ref := relayKey.ValueFrom.SecretKeyRef; ref.Optional == nil || !*ref.Optional {
+		t.Error("relay key must be optional so agents without one still start")
+	}
 }
 
 // TestBuildFacadeEnvVars_OmitsRouteRedisURLWhenNoContext verifies that when
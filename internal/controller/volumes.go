@@ -40,6 +40,16 @@ func workspaceContentPVCName(namespace string) string {
 	return fmt.Sprintf("workspace-%s-content", namespace)
 }
 
+// needsPackCache reports whether the runtime rewrites the pack at startup and
+// so needs the writable pack-cache scratch: to surface registry tools, to take
+// over a structured-output schema, or to add the knowledge placeholder.
+func needsPackCache(agentRuntime *omniav1alpha1.AgentRuntime, toolRegistry *omniav1alpha1.ToolRegistry) bool {
+	spec := agentRuntime.Spec
+	return toolRegistry != nil ||
+		(spec.StructuredOutput != nil && spec.StructuredOutput.Enabled) ||
+		(spec.Knowledge != nil && spec.Knowledge.Enabled)
+}
+
 func (r *AgentRuntimeReconciler) buildVolumes(
 	agentRuntime *omniav1alpha1.AgentRuntime,
 	promptPack *omniav1alpha1.PromptPack,
@@ -72,8 +82,11 @@ func (r *AgentRuntimeReconciler) buildVolumes(
 				},
 			},
 		})
-		// Writable scratch for the runtime to stage the tool-surfaced pack
-		// (root filesystem is read-only, so /tmp is not writable).
+	}
+
+	// Writable scratch for the runtime to stage a rewritten pack (root
+	// filesystem is read-only, so /tmp is not writable).
+	if needsPackCache(agentRuntime, toolRegistry) {
 		volumes = append(volumes, corev1.Volume{
 			Name:         runtimePackCacheVolumeName,
 			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
@@ -229,7 +242,10 @@ func (r *AgentRuntimeReconciler) buildRuntimeVolumeMounts(
 			MountPath: ToolsMountPath,
 			ReadOnly:  true,
 		})
-		// Writable pack-cache scratch (see buildVolumes).
+	}
+
+	// Writable pack-cache scratch (see buildVolumes).
+	if needsPackCache(agentRuntime, toolRegistry) {
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      runtimePackCacheVolumeName,
 			MountPath: RuntimePackCacheMountPath,
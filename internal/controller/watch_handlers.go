@@ -263,6 +263,9 @@ func (r *AgentRuntimeReconciler) findAgentRuntimesForSecret(ctx context.Context,
 			p.Spec.Credential.SecretRef.Name == secret.Name {
 			providersUsingSecret[p.Name] = true
 		}
+		if p.Spec.TLS != nil && p.Spec.TLS.SecretRef != nil && p.Spec.TLS.SecretRef.Name == secret.Name {
+			providersUsingSecret[p.Name] = true
+		}
 	}
 
 	// Find AgentRuntimes in the same namespace that reference these providers or use the secret directly.
@@ -96,6 +96,48 @@ func TestFindAgentRuntimesForSecret(t *testing.T) {
 			expectedCount:  1,
 			expectedAgents: []string{"my-agent"},
 		},
+		{
+			name: "client certificate secret triggers agent reconcile via provider tls",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "vllm-client-cert",
+					Namespace: "default",
+					Labels: map[string]string{
+						"omnia.altairalabs.ai/type": "credentials",
+					},
+				},
+			},
+			providers: []omniav1alpha1.Provider{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "vllm",
+						Namespace: "default",
+					},
+					Spec: omniav1alpha1.ProviderSpec{
+						Type: "vllm",
+						TLS: &omniav1alpha1.ProviderTLSConfig{
+							SecretRef: &corev1.LocalObjectReference{Name: "vllm-client-cert"},
+						},
+					},
+				},
+			},
+			agentRuntimes: []omniav1alpha1.AgentRuntime{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-agent",
+						Namespace: "default",
+					},
+					Spec: omniav1alpha1.AgentRuntimeSpec{
+						Providers: []omniav1alpha1.NamedProviderRef{
+							{Name: "default", ProviderRef: omniav1alpha1.ProviderRef{Name: "vllm"}},
+						},
+						PromptPackRef: omniav1alpha1.PromptPackRef{Name: "test-pack"},
+					},
+				},
+			},
+			expectedCount:  1,
+			expectedAgents: []string{"my-agent"},
+		},
 		{
 			name: "credential secret triggers agent reconcile via named provider",
 			secret: &corev1.Secret{
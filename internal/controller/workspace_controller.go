@@ -128,6 +128,10 @@ type WorkspaceReconciler struct {
 	// can enumerate MemoryPolicy CRDs across workspaces (#1899). Empty disables
 	// the binding (OSS installs).
 	MemoryConsolidationReaderClusterRole string
+
+	// PodMonitors configures operator-managed PodMonitors for the session-api
+	// and memory-api pods of managed service groups. See PodMonitorConfig.
+	PodMonitors PodMonitorConfig
 }
 
 // +kubebuilder:rbac:groups=omnia.altairalabs.ai,resources=workspaces,verbs=get;list;watch;create;update;patch;delete
@@ -138,6 +142,7 @@ type WorkspaceReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts/token,verbs=create
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;list;watch;create;update;patch;delete
 // clusterroles: the per-workspace reader granting agents get on their own
 // Workspace (#1875). Markers on individual reconcile helpers are not picked up
 // by controller-gen, so it lives in this canonical block.
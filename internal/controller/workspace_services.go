@@ -323,6 +323,18 @@ func (r *WorkspaceReconciler) reconcileManagedServiceGroup(
 		return omniav1alpha1.ServiceGroupStatus{}, fmt.Errorf("memory service: %w", err)
 	}
 
+	// Reconcile PodMonitors (only when the Prometheus Operator is installed)
+	for _, dep := range []*appsv1.Deployment{sessionDep, memoryDep} {
+		if err := reconcilePodMonitor(ctx, r.Client, r.Scheme, r.PodMonitors, workspace, podMonitorTarget{
+			name:      dep.Name,
+			namespace: namespace,
+			labels:    dep.Labels,
+			selector:  dep.Spec.Selector.MatchLabels,
+		}); err != nil {
+			return omniav1alpha1.ServiceGroupStatus{}, err
+		}
+	}
+
 	// Check readiness
 	sessionReady := r.isDeploymentReady(ctx, sessionDepName, namespace)
 	memoryReady := r.isDeploymentReady(ctx, memoryDepName, namespace)
@@ -776,7 +788,15 @@ func (r *WorkspaceReconciler) cleanupRemovedServiceGroups(
 	}
 
 	// Clean up services
-	return r.cleanupOrphanedServices(ctx, workspace.Name, namespace, expected)
+	if err := r.cleanupOrphanedServices(ctx, workspace.Name, namespace, expected); err != nil {
+		return err
+	}
+
+	// Clean up PodMonitors
+	if !r.PodMonitors.Enabled {
+		return nil
+	}
+	return r.cleanupOrphanedPodMonitors(ctx, workspace.Name, namespace, expected)
 }
 
 // cleanupOrphanedDeployments deletes Deployments whose service group is not in the expected set.
@@ -828,3 +848,28 @@ func (r *WorkspaceReconciler) cleanupOrphanedServices(
 	}
 	return nil
 }
+
+// cleanupOrphanedPodMonitors deletes PodMonitors whose service group is not in the expected set.
+func (r *WorkspaceReconciler) cleanupOrphanedPodMonitors(
+	ctx context.Context,
+	workspaceName, namespace string,
+	expected map[string]bool,
+) error {
+	pmList := newPodMonitorList()
+	if err := r.List(ctx, pmList,
+		client.InNamespace(namespace),
+		client.MatchingLabels{labelAppManagedBy: labelValueOmniaOperator, labelWorkspace: workspaceName},
+	); err != nil {
+		return fmt.Errorf("list podmonitors: %w", err)
+	}
+
+	for i := range pmList.Items {
+		groupName := pmList.Items[i].GetLabels()[labelServiceGroup]
+		if groupName != "" && !expected[groupName] {
+			if err := r.Delete(ctx, &pmList.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("delete podmonitor %s: %w", pmList.Items[i].GetName(), err)
+			}
+		}
+	}
+	return nil
+}
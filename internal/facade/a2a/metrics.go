@@ -13,6 +13,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/altairalabs/omnia/pkg/metrics"
 )
 
 // Metrics holds Prometheus metrics for the A2A facade.
@@ -116,8 +118,9 @@ func (m *MetricsMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	method := classifyMethod(r)
 	status := strconv.Itoa(sw.status)
 
-	m.metrics.RPCRequests.WithLabelValues(method, status).Inc()
-	m.metrics.RPCDuration.WithLabelValues(method).Observe(duration)
+	exemplar := metrics.TraceExemplar(r.Context())
+	metrics.IncWithExemplar(m.metrics.RPCRequests.WithLabelValues(method, status), exemplar)
+	metrics.ObserveWithExemplar(m.metrics.RPCDuration.WithLabelValues(method), duration, exemplar)
 }
 
 // classifyMethod determines the A2A method from the request path.
@@ -0,0 +1,81 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+// startsTurn reports whether a client message of type t is handled as a turn
+// rather than routed to a turn already running.
+func startsTurn(t MessageType) bool {
+	switch t {
+	case MessageTypeToolCallAck, MessageTypeToolCallNack, MessageTypeToolDebug,
+		MessageTypeToolResult, MessageTypeHangup:
+		return false
+	}
+	return true
+}
+
+// handleAck applies a client ack: the server messages up to ack.seq are
+// dropped from the session's replay log and never redelivered.
+func (s *Server) handleAck(c *Connection, msg *ClientMessage) {
+	if msg.Ack == nil {
+		s.sendError(c, "", ErrorCodeInvalidMessage, "ack requires ack.seq")
+		return
+	}
+	c.mu.Lock()
+	rl := c.replay
+	c.mu.Unlock()
+	if rl != nil {
+		rl.acknowledge(msg.Ack.Seq)
+	}
+}
+
+// acceptClientMessage deduplicates a client message by its seq. A new one is
+// acknowledged and proceeds; a resend is acknowledged as a duplicate and
+// dropped, so a message is processed once however often the client resends
+// it across reconnects. The ack is not logged for replay: a client that
+// misses it resends, or learns from connected.client_seq on resume.
+func (s *Server) acceptClientMessage(c *Connection, msg *ClientMessage) bool {
+	if msg.Seq == 0 {
+		return true
+	}
+	accepted := c.acceptClientSeq(msg.Seq)
+	if !accepted {
+		s.metrics.DuplicateClientMessage()
+		s.log.V(1).Info("duplicate client message dropped", "sessionID", c.SessionID(), "seq", msg.Seq)
+	}
+	if err := s.writeMessage(c, NewAckMessage(c.SessionID(), msg.Seq, !accepted)); err != nil {
+		s.log.V(1).Info("failed to send ack", "error", err.Error())
+	}
+	return accepted
+}
+
+// acceptClientSeq records seq as the client's latest message and reports
+// whether it is new. The count lives in the session's replay log so it
+// survives reconnects; until the log exists it is kept on the connection.
+func (c *Connection) acceptClientSeq(seq uint64) bool {
+	c.mu.Lock()
+	rl := c.replay
+	if rl == nil {
+		defer c.mu.Unlock()
+		if seq <= c.clientSeq {
+			return false
+		}
+		c.clientSeq = seq
+		return true
+	}
+	c.mu.Unlock()
+	return rl.acceptClientSeq(seq)
+}
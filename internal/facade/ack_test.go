@@ -0,0 +1,216 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/internal/session/sessiontest"
+)
+
+// deliveryMetrics counts the delivery metrics.
+type deliveryMetrics struct {
+	NoOpMetrics
+	redelivered atomic.Int32
+	duplicates  atomic.Int32
+}
+
+func (m *deliveryMetrics) MessagesRedelivered(n int) { m.redelivered.Add(int32(n)) }
+func (m *deliveryMetrics) DuplicateClientMessage()   { m.duplicates.Add(1) }
+
+func newAckServer(t *testing.T, handler MessageHandler) (*Server, *httptest.Server, *deliveryMetrics) {
+	t.Helper()
+	metrics := &deliveryMetrics{}
+	store := sessiontest.NewStore()
+	server := NewServer(DefaultServerConfig(), store, handler, logr.Discard(),
+		WithGraceWindow(5*time.Second), WithMetrics(metrics))
+	ts := httptest.NewServer(server)
+	t.Cleanup(func() {
+		ts.Close()
+		_ = store.Close()
+	})
+	return server, ts, metrics
+}
+
+const ackFeatures = "&protocol=1&features=streaming,resume,ack"
+
+func TestAck_ResentMessageIsNotProcessedAgain(t *testing.T) {
+	var turns atomic.Int32
+	handler := &mockHandler{handleFunc: func(_ context.Context, _ string, msg *ClientMessage, w ResponseWriter) error {
+		turns.Add(1)
+		return w.WriteDone("echo: " + msg.Content)
+	}}
+	_, ts, metrics := newAckServer(t, handler)
+
+	ws := dialReplay(t, ts, ackFeatures)
+	sessionID := readConnected(t, ws)
+	send := ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "charge card", Seq: 1}
+	if err := ws.WriteJSON(send); err != nil {
+		t.Fatal(err)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeAck || msg.Ack.Seq != 1 || msg.Ack.Duplicate {
+		t.Fatalf("message = %+v, want ack for seq 1", msg)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeDone {
+		t.Fatalf("message = %+v, want done", msg)
+	}
+
+	if err := ws.WriteJSON(send); err != nil {
+		t.Fatal(err)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeAck || msg.Ack.Seq != 1 || !msg.Ack.Duplicate {
+		t.Fatalf("message = %+v, want duplicate ack for seq 1", msg)
+	}
+	if n := turns.Load(); n != 1 {
+		t.Errorf("handler ran %d turns, want 1", n)
+	}
+	if n := metrics.duplicates.Load(); n != 1 {
+		t.Errorf("duplicates = %d, want 1", n)
+	}
+}
+
+func TestAck_NotSentWithoutFeature(t *testing.T) {
+	var turns atomic.Int32
+	handler := &mockHandler{handleFunc: func(_ context.Context, _ string, _ *ClientMessage, w ResponseWriter) error {
+		turns.Add(1)
+		return w.WriteDone("ok")
+	}}
+	_, ts, _ := newAckServer(t, handler)
+
+	ws := dialReplay(t, ts, "")
+	sessionID := readConnected(t, ws)
+	send := ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "hi", Seq: 7}
+	if err := ws.WriteJSON(send); err != nil {
+		t.Fatal(err)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeDone {
+		t.Fatalf("message = %+v, want done and no ack", msg)
+	}
+	if err := ws.WriteJSON(send); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return turns.Load() == 1 })
+	// The resend is dropped silently: a later, new message is the next reply.
+	if err := ws.WriteJSON(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "hi", Seq: 8}); err != nil {
+		t.Fatal(err)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeDone || msg.Seq != 2 {
+		t.Fatalf("message = %+v, want the second turn's done", msg)
+	}
+}
+
+func TestAck_ClientSeqSurvivesResume(t *testing.T) {
+	handler := newGatedHandler()
+	t.Cleanup(func() { close(handler.release) })
+	server, ts, metrics := newAckServer(t, handler)
+
+	ws := dialReplay(t, ts, ackFeatures)
+	sessionID := readConnected(t, ws)
+	if err := ws.WriteJSON(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "hi", Seq: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeAck {
+		t.Fatalf("message = %+v, want ack", msg)
+	}
+	if msg := readServerMessage(t, ws); msg.Content != "one" {
+		t.Fatalf("message = %+v, want chunk \"one\"", msg)
+	}
+	_ = ws.Close()
+	waitFor(t, func() bool { return server.ConnectionCount() == 0 })
+
+	ws = dialReplay(t, ts, ackFeatures+resumeQuery(sessionID, 0))
+	connected := readServerMessage(t, ws)
+	if !connected.Connected.Resumed || connected.Connected.ClientSeq != 1 {
+		t.Fatalf("connected = %+v, want resumed with client_seq 1", connected.Connected)
+	}
+	if msg := readServerMessage(t, ws); msg.Content != "one" || msg.Seq != 1 {
+		t.Fatalf("replayed = %+v, want chunk \"one\"", msg)
+	}
+	if n := metrics.redelivered.Load(); n != 1 {
+		t.Errorf("redelivered = %d, want 1", n)
+	}
+
+	// A client unsure whether its message arrived resends it.
+	if err := ws.WriteJSON(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "hi", Seq: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeAck || !msg.Ack.Duplicate {
+		t.Fatalf("message = %+v, want duplicate ack", msg)
+	}
+}
+
+func TestAck_AcknowledgedMessagesAreNotRedelivered(t *testing.T) {
+	handler := newGatedHandler()
+	server, ts, metrics := newAckServer(t, handler)
+
+	ws, sessionID := startTurn(t, ts)
+	close(handler.release)
+	if msg := readServerMessage(t, ws); msg.Content != "two" || msg.Seq != 2 {
+		t.Fatalf("message = %+v, want chunk \"two\" with seq 2", msg)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeDone || msg.Seq != 3 {
+		t.Fatalf("message = %+v, want done with seq 3", msg)
+	}
+	if err := ws.WriteJSON(ClientMessage{Type: MessageTypeAck, Ack: &AckInfo{Seq: 2}}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		server.replays.mu.Lock()
+		rl := server.replays.logs[sessionID]
+		server.replays.mu.Unlock()
+		rl.mu.Lock()
+		defer rl.mu.Unlock()
+		return rl.acked == 2
+	})
+	_ = ws.Close()
+	waitFor(t, func() bool { return server.ConnectionCount() == 0 })
+
+	// A stale last_seq does not redeliver what the client acknowledged.
+	ws = dialReplay(t, ts, resumeQuery(sessionID, 0))
+	if connected := readServerMessage(t, ws); !connected.Connected.Resumed {
+		t.Fatalf("connected = %+v, want resumed", connected)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeDone || msg.Seq != 3 {
+		t.Fatalf("replayed = %+v, want only done with seq 3", msg)
+	}
+	if n := metrics.redelivered.Load(); n != 1 {
+		t.Errorf("redelivered = %d, want 1", n)
+	}
+}
+
+func TestReplayLog_AcknowledgeTrims(t *testing.T) {
+	rl := &replayLog{size: 8}
+	server := &Server{}
+	for range 4 {
+		_ = rl.send(server, &ServerMessage{Type: MessageTypeChunk})
+	}
+	rl.acknowledge(2)
+	rl.acknowledge(1)
+	if len(rl.messages) != 2 || rl.messages[0].Seq != 3 {
+		t.Fatalf("messages = %d starting at %d, want seq 3 and 4", len(rl.messages), rl.messages[0].Seq)
+	}
+	rl.acknowledge(99)
+	if len(rl.messages) != 0 || rl.acked != 4 {
+		t.Errorf("ack past the last message: %d left, acked %d", len(rl.messages), rl.acked)
+	}
+}
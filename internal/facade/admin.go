@@ -0,0 +1,421 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+// AdminPath is the route prefix of the admin API, which lets operators see
+// and act on the live connections of a facade replica.
+const AdminPath = "/admin/v1/"
+
+// maxNoticeLength bounds the text of an operator notice.
+const maxNoticeLength = 4096
+
+// AdminConnection describes a live connection in the admin API.
+type AdminConnection struct {
+	// ID is the connection's unique ID.
+	ID string `json:"id"`
+	// SessionID is the session the connection is attached to.
+	SessionID string `json:"session_id,omitempty"`
+	Agent     string `json:"agent"`
+	Namespace string `json:"namespace"`
+	Workspace string `json:"workspace,omitempty"`
+	// UserID is the end user the connection authenticated as.
+	UserID string `json:"user_id,omitempty"`
+	// RemoteAddr is the address the connection came from, as seen by the
+	// facade: usually the ingress, not the client.
+	RemoteAddr  string    `json:"remote_addr,omitempty"`
+	ConnectedAt time.Time `json:"connected_at"`
+	// Protocol is the negotiated schema version and features; nil for
+	// clients that did not negotiate.
+	Protocol *ProtocolInfo `json:"protocol,omitempty"`
+	// Role is the participant role of a shared-session connection.
+	Role    ParticipantRole `json:"role,omitempty"`
+	Cohort  string          `json:"cohort,omitempty"`
+	Variant string          `json:"variant,omitempty"`
+	// Relay is "in" for a client connected to another replica whose
+	// session runs here, and "out" for a client connected here whose
+	// session runs on another replica.
+	Relay string `json:"relay,omitempty"`
+	// Realtime is true while the connection has a duplex audio session open.
+	Realtime bool `json:"realtime,omitempty"`
+	// MessagesReceived and MessagesSent count the connection's frames.
+	MessagesReceived uint64 `json:"messages_received"`
+	MessagesSent     uint64 `json:"messages_sent"`
+}
+
+// AdminSession describes a session a facade replica holds.
+type AdminSession struct {
+	SessionID string `json:"session_id"`
+	// Connections are the connections attached to the session.
+	Connections []AdminConnection `json:"connections"`
+	// Held is true while the session waits out the resume grace window with
+	// no connection attached.
+	Held bool `json:"held,omitempty"`
+	// Parked is true while the session's realtime audio stream waits for a
+	// resume.
+	Parked bool `json:"parked,omitempty"`
+	// TurnOpen is true while a turn has not sent its done or error.
+	TurnOpen bool `json:"turn_open,omitempty"`
+	// LastSeq is the seq of the last message sent in the session.
+	LastSeq uint64 `json:"last_seq,omitempty"`
+	// ClientSeq is the seq of the last client message accepted.
+	ClientSeq uint64 `json:"client_seq,omitempty"`
+}
+
+// AdminNoticeRequest is the body of a POST /admin/v1/notices request.
+type AdminNoticeRequest struct {
+	// Message is the text sent to clients.
+	Message string `json:"message"`
+	// SessionID limits the notice to one session's clients. Empty sends it
+	// to every client connected to the replica.
+	SessionID string `json:"session_id,omitempty"`
+}
+
+// AdminNoticeResponse reports how many connections a notice was sent to.
+// Clients that did not negotiate the notice feature are not counted.
+type AdminNoticeResponse struct {
+	Recipients int `json:"recipients"`
+}
+
+// AdminTerminateResponse reports a terminated session.
+type AdminTerminateResponse struct {
+	SessionID string `json:"session_id"`
+	// Connections is the number of connections closed.
+	Connections int `json:"connections"`
+}
+
+// AdminHandler returns the handler of the admin API under AdminPath:
+//
+//	GET    /admin/v1/connections          live connections
+//	GET    /admin/v1/sessions             sessions, including held ones
+//	GET    /admin/v1/sessions/{id}        one session
+//	DELETE /admin/v1/sessions/{id}        terminate a session (?reason=)
+//	POST   /admin/v1/notices              send clients a notice
+//
+// Requests are authenticated with this server's auth chain and admitted only
+// for management-plane callers. They act on the connections of targets, or of
+// this server when none are given, so the API can be mounted on the
+// management-plane listener and still reach the data-plane one. Each replica
+// answers for itself.
+func (s *Server) AdminHandler(targets ...*Server) http.Handler {
+	if len(targets) == 0 {
+		targets = []*Server{s}
+	}
+	a := &adminAPI{server: s, targets: targets}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+AdminPath+"connections", a.listConnections)
+	mux.HandleFunc("GET "+AdminPath+"sessions", a.listSessions)
+	mux.HandleFunc("GET "+AdminPath+"sessions/{id}", a.getSession)
+	mux.HandleFunc("DELETE "+AdminPath+"sessions/{id}", a.terminateSession)
+	mux.HandleFunc("POST "+AdminPath+"notices", a.sendNotice)
+	return a.authorize(mux)
+}
+
+// adminAPI serves the admin API for a set of facade servers.
+type adminAPI struct {
+	server  *Server
+	targets []*Server
+}
+
+// authorize admits management-plane callers. Data-plane credentials, even
+// valid ones, are refused: end users must not see each other's sessions.
+func (a *adminAPI) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := a.server.authenticateRequest(r)
+		if err != nil {
+			status := authRejectStatus(err)
+			a.server.log.V(1).Info("auth rejected admin request", "reason", err.Error(), "status", status)
+			http.Error(w, strings.ToLower(http.StatusText(status)), status)
+			return
+		}
+		if id == nil || id.Origin != policy.OriginManagementPlane {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodGet {
+			a.server.log.Info("admin request", "method", r.Method, "path", r.URL.Path, "subject", id.Subject)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (a *adminAPI) listConnections(w http.ResponseWriter, _ *http.Request) {
+	connections := []AdminConnection{}
+	for _, s := range a.targets {
+		for _, c := range s.adminConnections() {
+			connections = append(connections, c.adminInfo())
+		}
+	}
+	sort.Slice(connections, func(i, j int) bool {
+		return connections[i].ConnectedAt.Before(connections[j].ConnectedAt)
+	})
+	writeAdminJSON(w, http.StatusOK, map[string]any{"connections": connections})
+}
+
+func (a *adminAPI) listSessions(w http.ResponseWriter, _ *http.Request) {
+	sessions := a.sessions("")
+	list := make([]*AdminSession, 0, len(sessions))
+	for _, as := range sessions {
+		list = append(list, as)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].SessionID < list[j].SessionID })
+	writeAdminJSON(w, http.StatusOK, map[string]any{"sessions": list})
+}
+
+func (a *adminAPI) getSession(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	as, ok := a.sessions(sessionID)[sessionID]
+	if !ok {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, as)
+}
+
+func (a *adminAPI) terminateSession(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	reason := r.URL.Query().Get("reason")
+	if reason == "" {
+		reason = "session terminated by an operator"
+	}
+	closed, found := 0, false
+	for _, s := range a.targets {
+		n, ok := s.terminateSession(sessionID, reason)
+		closed += n
+		found = found || ok
+	}
+	if !found {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+	a.server.metrics.AdminSessionTerminated()
+	writeAdminJSON(w, http.StatusOK, &AdminTerminateResponse{SessionID: sessionID, Connections: closed})
+}
+
+func (a *adminAPI) sendNotice(w http.ResponseWriter, r *http.Request) {
+	var req AdminNoticeRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 2*maxNoticeLength)).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.Message = strings.TrimSpace(req.Message)
+	if req.Message == "" || len(req.Message) > maxNoticeLength {
+		http.Error(w, "message is required and must not exceed 4096 bytes", http.StatusBadRequest)
+		return
+	}
+	recipients := 0
+	for _, s := range a.targets {
+		recipients += s.sendNotice(req.SessionID, req.Message)
+	}
+	a.server.metrics.AdminNoticeSent(recipients)
+	writeAdminJSON(w, http.StatusOK, &AdminNoticeResponse{Recipients: recipients})
+}
+
+// sessions collects the sessions of every target, or only sessionID when it
+// is set.
+func (a *adminAPI) sessions(sessionID string) map[string]*AdminSession {
+	sessions := make(map[string]*AdminSession)
+	get := func(id string) *AdminSession {
+		as, ok := sessions[id]
+		if !ok {
+			as = &AdminSession{SessionID: id, Connections: []AdminConnection{}}
+			sessions[id] = as
+		}
+		return as
+	}
+	wanted := func(id string) bool { return id != "" && (sessionID == "" || id == sessionID) }
+
+	for _, s := range a.targets {
+		for _, c := range s.adminConnections() {
+			info := c.adminInfo()
+			if wanted(info.SessionID) {
+				as := get(info.SessionID)
+				as.Connections = append(as.Connections, info)
+			}
+		}
+		for _, rl := range s.replays.snapshot() {
+			if !wanted(rl.sessionID) {
+				continue
+			}
+			as := get(rl.sessionID)
+			rl.mu.Lock()
+			as.Held = as.Held || rl.conn == nil
+			as.TurnOpen = rl.turnOpen
+			as.LastSeq = rl.seq
+			as.ClientSeq = rl.clientSeq
+			rl.mu.Unlock()
+		}
+		for _, id := range s.parked.sessionIDs() {
+			if wanted(id) {
+				get(id).Parked = true
+			}
+		}
+	}
+	return sessions
+}
+
+// adminConnections returns the server's connections: its clients, and the
+// stand-ins for clients relayed from other replicas.
+func (s *Server) adminConnections() []*Connection {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	connections := make([]*Connection, 0, len(s.connections)+len(s.relayed))
+	for _, c := range s.connections {
+		connections = append(connections, c)
+	}
+	for _, rc := range s.relayed {
+		connections = append(connections, rc)
+	}
+	return connections
+}
+
+// adminInfo describes the connection for the admin API.
+func (c *Connection) adminInfo() AdminConnection {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	info := AdminConnection{
+		ID:               c.id,
+		SessionID:        c.sessionID,
+		Agent:            c.agentName,
+		Namespace:        c.namespace,
+		Workspace:        c.workspaceName,
+		UserID:           c.userID,
+		RemoteAddr:       c.remoteAddr,
+		ConnectedAt:      c.connectedAt,
+		Protocol:         c.protocol,
+		Role:             c.role,
+		Cohort:           c.cohortID,
+		Variant:          c.variant,
+		Realtime:         c.audioSession != nil,
+		MessagesReceived: c.received.Load(),
+		MessagesSent:     c.sent.Load(),
+	}
+	switch {
+	case c.peer != nil:
+		info.Relay = "in"
+	case c.upstream != nil:
+		info.Relay = "out"
+	}
+	return info
+}
+
+// sendNotice sends message to the clients connected to this server, or to
+// sessionID's when it is set, and returns how many it reached. Notices are
+// not logged for replay. Clients relayed from another replica get the
+// notice there.
+func (s *Server) sendNotice(sessionID, message string) int {
+	s.mu.RLock()
+	connections := make([]*Connection, 0, len(s.connections))
+	for _, c := range s.connections {
+		connections = append(connections, c)
+	}
+	s.mu.RUnlock()
+
+	recipients := 0
+	for _, c := range connections {
+		id := c.SessionID()
+		if (sessionID != "" && id != sessionID) || !c.supports(FeatureNotice) {
+			continue
+		}
+		if err := s.writeMessage(c, NewNoticeMessage(id, message)); err != nil {
+			s.log.V(1).Info("failed to send notice", "sessionID", id, "error", err.Error())
+			continue
+		}
+		recipients++
+	}
+	return recipients
+}
+
+// terminateSession ends sessionID on this server for good: its clients are
+// sent SESSION_TERMINATED and closed, a held or parked session is discarded,
+// and nothing is kept for a resume. Returns the number of connections closed
+// and whether the server had anything of the session.
+func (s *Server) terminateSession(sessionID, reason string) (int, bool) {
+	closed := 0
+	for _, c := range s.adminConnections() {
+		if c.SessionID() == sessionID {
+			s.terminateConnection(c, reason)
+			closed++
+		}
+	}
+	held := s.replays.evict(sessionID)
+	parked := s.parked.evict(sessionID)
+	if closed > 0 || held || parked {
+		s.log.Info("session terminated", "sessionID", sessionID, "connections", closed, "reason", reason)
+		return closed, true
+	}
+	return 0, false
+}
+
+// terminateConnection closes c as a hangup would, so its session is not held
+// for a resume, after sending it a SESSION_TERMINATED error.
+func (s *Server) terminateConnection(c *Connection, reason string) {
+	c.mu.Lock()
+	c.intentionalClose = true
+	c.mu.Unlock()
+	msg := NewErrorMessage(c.SessionID(), ErrorCodeSessionTerminated, reason)
+
+	if c.peer != nil {
+		// The client is on another replica: relay the error, then tell that
+		// replica to drop it.
+		if err := s.writeMessage(c, msg); err != nil {
+			s.log.V(1).Info("failed to send termination", "sessionID", c.SessionID(), "error", err.Error())
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), s.config.WriteTimeout)
+		_ = s.publishRelay(ctx, relayConnChannel(c.peer.relayID), &relayEnvelope{Op: relayOpDetach, RelayID: c.peer.relayID})
+		cancel()
+		s.detachRelay(c.peer.relayID)
+		return
+	}
+	if up := c.relayUpstream(); up != nil {
+		// The session runs on another replica; hang it up there before the
+		// upstream is detached, so it is not held for a resume either.
+		hangup, _ := json.Marshal(&ClientMessage{Type: MessageTypeHangup, SessionID: c.SessionID()})
+		s.forwardClientMessage(context.Background(), c, up, hangup)
+	}
+	if err := s.writeMessage(c, msg); err != nil {
+		s.log.V(1).Info("failed to send termination", "sessionID", c.SessionID(), "error", err.Error())
+	}
+	s.flushOutbound(c)
+	if err := c.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "session terminated"),
+		time.Now().Add(s.config.WriteTimeout)); err != nil && !errors.Is(err, websocket.ErrCloseSent) {
+		s.log.V(1).Info("failed to send close frame", "sessionID", c.SessionID(), "error", err.Error())
+	}
+	// The read loop then ends and cleanupConnection runs.
+	c.abort()
+}
+
+// writeAdminJSON writes v as the JSON body of an admin API response.
+func writeAdminJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
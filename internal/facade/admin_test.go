@@ -0,0 +1,272 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/websocket"
+
+	"github.com/altairalabs/omnia/internal/session/sessiontest"
+	"github.com/altairalabs/omnia/pkg/facade/auth"
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+// adminTestValidator admits "Bearer operator" as a management-plane caller
+// and "Bearer end-user" as a data-plane one.
+type adminTestValidator struct{}
+
+func (adminTestValidator) Validate(_ context.Context, r *http.Request) (*policy.AuthenticatedIdentity, error) {
+	switch r.Header.Get("Authorization") {
+	case "":
+		return nil, auth.ErrNoCredential
+	case "Bearer operator":
+		return &policy.AuthenticatedIdentity{Origin: policy.OriginManagementPlane, Subject: "ops@example.com"}, nil
+	case "Bearer end-user":
+		return &policy.AuthenticatedIdentity{Origin: policy.OriginClientKey, Subject: "key-1"}, nil
+	}
+	return nil, auth.ErrInvalidCredential
+}
+
+// adminMetrics counts the admin metrics.
+type adminMetrics struct {
+	NoOpMetrics
+	terminated atomic.Int32
+	notices    atomic.Int32
+}
+
+func (m *adminMetrics) AdminSessionTerminated()        { m.terminated.Add(1) }
+func (m *adminMetrics) AdminNoticeSent(recipients int) { m.notices.Add(int32(recipients)) }
+
+// newAdminServers starts a client-facing server and, like cmd/agent, serves
+// its admin API from a second server with a management-plane auth chain.
+func newAdminServers(t *testing.T, handler MessageHandler) (*Server, *httptest.Server, *httptest.Server, *adminMetrics) {
+	t.Helper()
+	metrics := &adminMetrics{}
+	store := sessiontest.NewStore()
+	client := NewServer(DefaultServerConfig(), store, handler, logr.Discard(),
+		WithGraceWindow(5*time.Second), WithMetrics(metrics))
+	admin := NewServer(DefaultServerConfig(), store, handler, logr.Discard(),
+		WithAuthChain(auth.Chain{adminTestValidator{}}), WithAllowUnauthenticated(false), WithMetrics(metrics))
+	tsClient := httptest.NewServer(client)
+	tsAdmin := httptest.NewServer(admin.AdminHandler(client))
+	t.Cleanup(func() {
+		tsAdmin.Close()
+		tsClient.Close()
+		_ = store.Close()
+	})
+	return client, tsClient, tsAdmin, metrics
+}
+
+func adminRequest(t *testing.T, ts *httptest.Server, method, path, token, body string) (int, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, data
+}
+
+func TestAdmin_RequiresManagementPlane(t *testing.T) {
+	_, _, tsAdmin, _ := newAdminServers(t, &mockHandler{})
+
+	for _, tc := range []struct {
+		token string
+		want  int
+	}{
+		{"", http.StatusUnauthorized},
+		{"forged", http.StatusUnauthorized},
+		{"end-user", http.StatusForbidden},
+		{"operator", http.StatusOK},
+	} {
+		if status, _ := adminRequest(t, tsAdmin, http.MethodGet, AdminPath+"connections", tc.token, ""); status != tc.want {
+			t.Errorf("token %q: status = %d, want %d", tc.token, status, tc.want)
+		}
+	}
+}
+
+func TestAdmin_ListsAndInspectsSessions(t *testing.T) {
+	_, tsClient, tsAdmin, _ := newAdminServers(t, &mockHandler{})
+	ws := dialReplay(t, tsClient, "&protocol=1&features=streaming")
+	sessionID := readConnected(t, ws)
+	if err := ws.WriteJSON(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeDone {
+		t.Fatalf("message = %+v, want done", msg)
+	}
+
+	status, body := adminRequest(t, tsAdmin, http.MethodGet, AdminPath+"connections", "operator", "")
+	var list struct {
+		Connections []AdminConnection `json:"connections"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil || status != http.StatusOK {
+		t.Fatalf("list: status %d, %v", status, err)
+	}
+	if len(list.Connections) != 1 {
+		t.Fatalf("connections = %+v, want one", list.Connections)
+	}
+	c := list.Connections[0]
+	if c.SessionID != sessionID || c.Agent != "test-agent" || c.ConnectedAt.IsZero() || c.Protocol == nil {
+		t.Errorf("connection = %+v", c)
+	}
+	// Sent: connected and done.
+	if c.MessagesReceived != 1 || c.MessagesSent != 2 {
+		t.Errorf("messages received/sent = %d/%d, want 1/2", c.MessagesReceived, c.MessagesSent)
+	}
+
+	status, body = adminRequest(t, tsAdmin, http.MethodGet, AdminPath+"sessions/"+sessionID, "operator", "")
+	var session AdminSession
+	if err := json.Unmarshal(body, &session); err != nil || status != http.StatusOK {
+		t.Fatalf("inspect: status %d, %v", status, err)
+	}
+	if len(session.Connections) != 1 || session.Held || session.TurnOpen || session.LastSeq != 1 {
+		t.Errorf("session = %+v, want one connection, no turn open, last seq 1", session)
+	}
+	if status, _ := adminRequest(t, tsAdmin, http.MethodGet, AdminPath+"sessions/unknown", "operator", ""); status != http.StatusNotFound {
+		t.Errorf("unknown session: status = %d, want 404", status)
+	}
+}
+
+func TestAdmin_NoticeReachesOptedInClients(t *testing.T) {
+	_, tsClient, tsAdmin, metrics := newAdminServers(t, &mockHandler{})
+	optedIn := dialReplay(t, tsClient, "&protocol=1&features=streaming,notice")
+	readConnected(t, optedIn)
+	legacy := dialReplay(t, tsClient, "")
+	readConnected(t, legacy)
+
+	status, body := adminRequest(t, tsAdmin, http.MethodPost, AdminPath+"notices", "operator",
+		`{"message": "Maintenance at 22:00 UTC"}`)
+	var resp AdminNoticeResponse
+	if err := json.Unmarshal(body, &resp); err != nil || status != http.StatusOK {
+		t.Fatalf("notice: status %d, %v", status, err)
+	}
+	if resp.Recipients != 1 || metrics.notices.Load() != 1 {
+		t.Errorf("recipients = %d (metric %d), want 1", resp.Recipients, metrics.notices.Load())
+	}
+	if msg := readServerMessage(t, optedIn); msg.Type != MessageTypeNotice || msg.Notice.Message != "Maintenance at 22:00 UTC" {
+		t.Fatalf("message = %+v, want the notice", msg)
+	}
+
+	if status, _ := adminRequest(t, tsAdmin, http.MethodPost, AdminPath+"notices", "operator", `{"message": " "}`); status != http.StatusBadRequest {
+		t.Errorf("empty notice: status = %d, want 400", status)
+	}
+}
+
+func TestAdmin_TerminateClosesClientWithoutHolding(t *testing.T) {
+	handler := newGatedHandler()
+	t.Cleanup(func() { close(handler.release) })
+	client, tsClient, tsAdmin, metrics := newAdminServers(t, handler)
+	ws, sessionID := startTurn(t, tsClient)
+
+	status, body := adminRequest(t, tsAdmin, http.MethodDelete, AdminPath+"sessions/"+sessionID+"?reason=abuse", "operator", "")
+	var resp AdminTerminateResponse
+	if err := json.Unmarshal(body, &resp); err != nil || status != http.StatusOK {
+		t.Fatalf("terminate: status %d, %v", status, err)
+	}
+	if resp.Connections != 1 || metrics.terminated.Load() != 1 {
+		t.Errorf("response = %+v (metric %d), want one connection closed", resp, metrics.terminated.Load())
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeError || msg.Error.Code != ErrorCodeSessionTerminated || msg.Error.Message != "abuse" {
+		t.Fatalf("message = %+v, want SESSION_TERMINATED", msg)
+	}
+	_, _, err := ws.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.ClosePolicyViolation {
+		t.Errorf("read after termination = %v, want close 1008", err)
+	}
+	if ctxErr := <-handler.ctxErr; ctxErr == nil {
+		t.Error("the turn kept running after its session was terminated")
+	}
+	waitFor(t, func() bool { return client.ConnectionCount() == 0 && client.replays.len() == 0 })
+
+	// The session cannot be resumed.
+	ws = dialReplay(t, tsClient, resumeQuery(sessionID, 1))
+	if connected := readServerMessage(t, ws); connected.Connected.Resumed {
+		t.Error("terminated session was resumed")
+	}
+	if status, _ := adminRequest(t, tsAdmin, http.MethodDelete, AdminPath+"sessions/"+sessionID, "operator", ""); status != http.StatusNotFound {
+		t.Errorf("second terminate: status = %d, want 404", status)
+	}
+}
+
+func TestAdmin_TerminateDiscardsHeldSession(t *testing.T) {
+	handler := newGatedHandler()
+	t.Cleanup(func() { close(handler.release) })
+	client, tsClient, tsAdmin, _ := newAdminServers(t, handler)
+	ws, sessionID := startTurn(t, tsClient)
+	_ = ws.Close()
+	waitFor(t, func() bool { return client.ConnectionCount() == 0 })
+
+	status, body := adminRequest(t, tsAdmin, http.MethodGet, AdminPath+"sessions/"+sessionID, "operator", "")
+	var session AdminSession
+	if err := json.Unmarshal(body, &session); err != nil || status != http.StatusOK {
+		t.Fatalf("inspect: status %d, %v", status, err)
+	}
+	if !session.Held || !session.TurnOpen || len(session.Connections) != 0 {
+		t.Errorf("session = %+v, want held with its turn open", session)
+	}
+
+	if status, _ := adminRequest(t, tsAdmin, http.MethodDelete, AdminPath+"sessions/"+sessionID, "operator", ""); status != http.StatusOK {
+		t.Fatalf("terminate: status = %d", status)
+	}
+	if ctxErr := <-handler.ctxErr; ctxErr == nil {
+		t.Error("the held turn kept running after its session was terminated")
+	}
+	if client.replays.len() != 0 {
+		t.Error("held session kept after termination")
+	}
+}
+
+func TestAdmin_TerminateRelayedSession(t *testing.T) {
+	routes, relay := newMemRouteStore(), newMemRelay()
+	handler := newGatedHandler()
+	t.Cleanup(func() { close(handler.release) })
+	owner, tsA := newRelayServer(t, handler, "10.0.0.1:8080", routes, relay)
+	relaying, tsB := newRelayServer(t, &mockHandler{}, "10.0.0.2:8080", routes, relay)
+	sessionID, read, _ := relayedSession(t, owner, tsA, tsB)
+
+	if n, ok := owner.terminateSession(sessionID, "abuse"); n != 1 || !ok {
+		t.Fatalf("terminateSession = %d, %v, want the relayed connection", n, ok)
+	}
+	if msg := read(); msg.Type != MessageTypeError || msg.Error.Code != ErrorCodeSessionTerminated {
+		t.Fatalf("message = %+v, want SESSION_TERMINATED relayed to the client", msg)
+	}
+	waitFor(t, func() bool { return relaying.ConnectionCount() == 0 })
+	if owner.replays.len() != 0 {
+		t.Error("owner held the terminated session for a resume")
+	}
+}
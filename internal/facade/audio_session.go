@@ -24,6 +24,32 @@ import (
 // defaultAudioCodec is the fallback audio codec for duplex sessions.
 const defaultAudioCodec = "pcm"
 
+// defaultAudioFormat is the capture format assumed when neither the agent nor
+// the client's first audio frame names one.
+func defaultAudioFormat() *SessionConfigInfo {
+	return &SessionConfigInfo{Codec: defaultAudioCodec, SampleRate: 16000, Channels: 1}
+}
+
+// audioFormat returns the format clients should capture voice in: the
+// agent's declared format (WithAudioFormat), with unset fields filled from
+// defaultAudioFormat. It is advertised in the connected message and used for
+// any field the first audio frame leaves out.
+func (s *Server) audioFormat() *SessionConfigInfo {
+	f := defaultAudioFormat()
+	if o := s.audioFormatOverride; o != nil {
+		if o.Codec != "" {
+			f.Codec = o.Codec
+		}
+		if o.SampleRate > 0 {
+			f.SampleRate = o.SampleRate
+		}
+		if o.Channels > 0 {
+			f.Channels = o.Channels
+		}
+	}
+	return f
+}
+
 // AudioSessionStart holds negotiated audio parameters for a duplex session.
 //
 // TODO: DuplexStart.system_instruction is honored by the runtime (injected as a
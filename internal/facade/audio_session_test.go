@@ -19,6 +19,7 @@ package facade
 import (
 	"context"
 	"errors"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -232,6 +233,27 @@ func TestHandleBinaryMessage_NilFactory_DoesNotPanic(t *testing.T) {
 	})
 }
 
+// TestConnected_AdvertisesAudioFormat verifies that the connected message
+// carries the voice format only when a duplex sink is configured, with the
+// agent's declared fields over the defaults.
+func TestConnected_AdvertisesAudioFormat(t *testing.T) {
+	store, handler := newTestStoreAndHandler(t)
+	voice := NewServer(DefaultServerConfig(), store, handler, testLogger(t),
+		WithDuplexSinkFactory(func(_ string, _ ResponseWriter) DuplexSink { return &fakeDuplexSink{} }),
+		WithAudioFormat(&SessionConfigInfo{Codec: "pcm16", SampleRate: 24000}),
+	)
+	text := NewServer(DefaultServerConfig(), store, handler, testLogger(t))
+	voiceTS, textTS := httptest.NewServer(voice), httptest.NewServer(text)
+	t.Cleanup(func() { voiceTS.Close(); textTS.Close() })
+
+	caps := connectAndReadCapabilities(t, wsURL(voiceTS.URL)+"?agent=test-agent")
+	require.NotNil(t, caps.Audio)
+	assert.Equal(t, SessionConfigInfo{Codec: "pcm16", SampleRate: 24000, Channels: 1}, *caps.Audio)
+
+	caps = connectAndReadCapabilities(t, wsURL(textTS.URL)+"?agent=test-agent")
+	assert.Nil(t, caps.Audio, "no duplex sink: voice must not be advertised")
+}
+
 // TestAudioSession_CleanedUpOnDisconnect verifies that close() is called on
 // the audioSession when cleanupConnection runs (via the audioSession field).
 func TestAudioSession_CleanedUpOnDisconnect(t *testing.T) {
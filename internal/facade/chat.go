@@ -0,0 +1,490 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+
+	"github.com/altairalabs/omnia/internal/tracing"
+	"github.com/altairalabs/omnia/pkg/apierror"
+	"github.com/altairalabs/omnia/pkg/logctx"
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+// ChatPath is the route of the HTTP chat endpoint, served alongside the
+// WebSocket endpoint for clients that cannot hold a WebSocket open.
+const ChatPath = "/v1/chat"
+
+// mimeEventStream is the content type of a streaming chat response.
+const mimeEventStream = "text/event-stream"
+
+// ChatRequest is the body of a POST /v1/chat request.
+type ChatRequest struct {
+	// SessionID continues an existing session. Empty starts a new one.
+	SessionID string `json:"session_id,omitempty"`
+	// Content is the message content (text-only).
+	// If Parts is provided, it takes precedence over Content.
+	Content string `json:"content,omitempty"`
+	// Parts contains multi-modal content parts (text, images, audio, etc.).
+	Parts []ContentPart `json:"parts,omitempty"`
+	// Metadata contains optional additional data.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// ConsentGrants carries consent category grants for this request.
+	ConsentGrants []string `json:"consent_grants,omitempty"`
+	// Stream requests the response as Server-Sent Events, as does an
+	// "Accept: text/event-stream" header.
+	Stream bool `json:"stream,omitempty"`
+}
+
+// ChatResponse is the body of a non-streaming POST /v1/chat response.
+type ChatResponse struct {
+	// SessionID is the session the turn ran in; send it back to continue.
+	SessionID string `json:"session_id,omitempty"`
+	// Content is the agent's reply.
+	Content string `json:"content,omitempty"`
+	// Parts contains the reply's multi-modal content parts, if any.
+	Parts []ContentPart `json:"parts,omitempty"`
+	// Error describes why the turn failed.
+	Error *ErrorInfo `json:"error,omitempty"`
+}
+
+// ChatHandler returns the handler of POST /v1/chat. Each request is one turn,
+// run through the same auth chain, session resolution, message handler, and
+// metrics as a WebSocket message. The reply is returned as a ChatResponse or,
+// when streaming, as Server-Sent Events: one event per ServerMessage, named by
+// its type, ending with a done or error event.
+//
+// A request without session_id starts a session whose ID is returned for
+// follow-up requests. The session is left open when the request ends.
+// Client-side tools need a connection to return their results on, so a turn
+// that calls one fails with TOOL_FAILED.
+func (s *Server) ChatHandler() http.Handler {
+	return http.HandlerFunc(s.serveChat)
+}
+
+func (s *Server) serveChat(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	requestID := apierror.CorrelationID(r)
+	w.Header().Set(policy.HeaderRequestID, requestID)
+	s.mu.RLock()
+	shutdown := s.shutdown
+	s.mu.RUnlock()
+	if shutdown || s.IsDraining() {
+		writeChatError(w, requestID, ErrorCodeServerShutdown, "server draining")
+		return
+	}
+
+	agentCtx, err := s.resolveAgentContext(r)
+	if err != nil {
+		writeChatError(w, requestID, ErrorCodeInvalidMessage, err.Error())
+		return
+	}
+	locale, err := resolveLocale(r)
+	if err != nil {
+		writeChatError(w, requestID, ErrorCodeInvalidMessage, err.Error())
+		return
+	}
+	authIdentity, authErr := s.authenticateRequest(r)
+	if authErr != nil {
+		status := authRejectStatus(authErr)
+		s.log.V(1).Info("auth rejected chat request", "reason", authErr.Error(), "status", status)
+		code := ErrorCodeUnauthenticated
+		if status == http.StatusForbidden {
+			code = ErrorCodePermissionDenied
+		}
+		writeChatError(w, requestID, code, strings.ToLower(http.StatusText(status)))
+		return
+	}
+	userCtx := s.resolveUserContext(r, authIdentity)
+
+	req, code, err := decodeChatRequest(w, r, s.config.MaxMessageSize)
+	if err != nil {
+		writeChatError(w, requestID, code, err.Error())
+		return
+	}
+	s.metrics.MessageReceived()
+
+	c := &Connection{
+		id:            uuid.New().String(),
+		agentName:     agentCtx.agentName,
+		namespace:     agentCtx.namespace,
+		workspaceName: agentCtx.workspaceName,
+		userID:        userCtx.userID,
+		userEmail:     userCtx.userEmail,
+		authorization: userCtx.authorization,
+		cohortID:      userCtx.cohortID,
+		variant:       userCtx.variant,
+		locale:        locale,
+	}
+	// buildConnectionContext starts from a background context because a
+	// WebSocket turn outlives its upgrade request. A chat turn is the
+	// request, so a client that goes away cancels it.
+	ctx, cancel := context.WithCancel(s.buildConnectionContext(r, requestID, agentCtx, userCtx, authIdentity))
+	defer cancel()
+	stop := context.AfterFunc(r.Context(), cancel)
+	defer stop()
+	ctx = WithConnectionID(ctx, c.id)
+	log := logctx.LoggerWithContext(s.log, ctx)
+
+	c.requestID = requestID
+	writer := &chatWriter{server: s, w: w, requestID: requestID}
+	if req.Stream || strings.Contains(r.Header.Get("Accept"), mimeEventStream) {
+		if flusher, ok := w.(http.Flusher); ok {
+			writer.flusher = flusher
+		}
+	}
+
+	s.metrics.RequestStarted()
+	startTime := time.Now()
+	err = s.processChatTurn(ctx, c, &ClientMessage{
+		Type:          MessageTypeMessage,
+		SessionID:     req.SessionID,
+		Content:       req.Content,
+		Parts:         req.Parts,
+		Metadata:      req.Metadata,
+		ConsentGrants: req.ConsentGrants,
+	}, writer, log)
+	writer.finish()
+
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+		log.Error(err, "error processing chat request")
+	}
+	handlerName := "none"
+	if s.handler != nil {
+		handlerName = s.handler.Name()
+	}
+	s.metrics.RequestCompleted(ctx, outcome, time.Since(startTime).Seconds(), handlerName)
+}
+
+// decodeChatRequest reads and validates a chat request body, returning the
+// error code to answer with when it is rejected.
+func decodeChatRequest(w http.ResponseWriter, r *http.Request, maxBytes int64) (*ChatRequest, string, error) {
+	body := r.Body
+	if maxBytes > 0 {
+		body = http.MaxBytesReader(w, r.Body, maxBytes)
+	}
+	var req ChatRequest
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, ErrorCodeMessageTooLarge, fmt.Errorf("request body exceeds %d bytes", tooLarge.Limit)
+		}
+		return nil, ErrorCodeInvalidMessage, errors.New("invalid request body")
+	}
+	if strings.TrimSpace(req.Content) == "" && len(req.Parts) == 0 {
+		return nil, ErrorCodeInvalidMessage, errors.New("content or parts is required")
+	}
+	return &req, "", nil
+}
+
+// processChatTurn runs one chat request as a turn of its session, the way
+// processMessage runs a WebSocket message.
+func (s *Server) processChatTurn(ctx context.Context, c *Connection, msg *ClientMessage, writer *chatWriter, log logr.Logger) error {
+	// A request without session_id names a new session. Binding it to the
+	// connection marks it as this request's own, so it is not probed for
+	// resumption.
+	sessionID := msg.SessionID
+	if sessionID == "" {
+		sessionID = uuid.New().String()
+		c.sessionID = sessionID
+	}
+	sessionID, err := s.ensureSession(ctx, c, sessionID, log)
+	if err != nil {
+		if errors.Is(err, errSessionExpired) {
+			writer.fail(ErrorCodeSessionExpired, "session context has expired; start a new session")
+			return err
+		}
+		writer.fail(ErrorCodeInternalError, "failed to create session")
+		return err
+	}
+	writer.setSessionID(sessionID)
+
+	ctx, msgSpan := s.startMessageSpan(ctx, c, sessionID)
+	defer msgSpan.End()
+	ctx = turnContext(ctx, c, sessionID, msg, msgSpan)
+	log = logctx.LoggerWithContext(s.log, ctx)
+
+	if s.chain == nil {
+		return writer.WriteDone("Handler not configured")
+	}
+	if err := safeHandleMessage(s.chain, ctx, sessionID, msg, writer, log); err != nil {
+		e := apierror.FromError(err)
+		writer.fail(e.Code, e.Message)
+		tracing.RecordError(msgSpan, err)
+		return err
+	}
+	tracing.SetSuccess(msgSpan)
+	return nil
+}
+
+// chatWriter implements ResponseWriter for a chat request. Without a flusher
+// it collects the reply for a ChatResponse; with one it relays every message
+// as a Server-Sent Event.
+type chatWriter struct {
+	server  *Server
+	w       http.ResponseWriter
+	flusher http.Flusher // nil unless streaming
+
+	mu        sync.Mutex
+	sessionID string
+	started   bool // the event stream's headers are written
+	text      strings.Builder
+	parts     []ContentPart
+	err       *ErrorInfo
+	// requestID is the request's correlation ID, quoted by its errors.
+	requestID string
+}
+
+// errorInfo returns the details of an error with code, quoting the
+// request's correlation ID.
+func (w *chatWriter) errorInfo(code, message string) *ErrorInfo {
+	info := newErrorInfo(code, message)
+	info.CorrelationID = w.requestID
+	return info
+}
+
+func (w *chatWriter) setSessionID(sessionID string) {
+	w.mu.Lock()
+	w.sessionID = sessionID
+	w.mu.Unlock()
+}
+
+// emit sends msg as an event when streaming. Must be called with w.mu held.
+func (w *chatWriter) emit(msg *ServerMessage) error {
+	if w.flusher == nil {
+		return nil
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if !w.started {
+		h := w.w.Header()
+		h.Set("Content-Type", mimeEventStream)
+		h.Set("Cache-Control", "no-cache")
+		h.Set("X-Accel-Buffering", "no")
+		w.w.WriteHeader(http.StatusOK)
+		w.started = true
+	}
+	if _, err := fmt.Fprintf(w.w, "event: %s\ndata: %s\n\n", msg.Type, data); err != nil {
+		return err
+	}
+	w.flusher.Flush()
+	w.server.metrics.MessageSent()
+	return nil
+}
+
+// errorMessage returns the error message for the recorded error. Must be
+// called with w.mu held.
+func (w *chatWriter) errorMessage() *ServerMessage {
+	msg := NewErrorMessage(w.sessionID, w.err.Code, w.err.Message)
+	msg.Error = w.err
+	return msg
+}
+
+// fail records an error the facade itself raised. The first error wins.
+func (w *chatWriter) fail(code, message string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return
+	}
+	w.err = w.errorInfo(code, message)
+	if w.started {
+		_ = w.emit(w.errorMessage())
+	}
+}
+
+// finish completes the response. An event stream that never started — the
+// turn failed before its first message — is answered like a non-streaming
+// request, so the failure keeps its HTTP status.
+func (w *chatWriter) finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.started {
+		return
+	}
+	if w.err != nil {
+		writeChatJSON(w.w, apierror.HTTPStatus(w.err.Code), &ChatResponse{SessionID: w.sessionID, Error: w.err})
+		return
+	}
+	writeChatJSON(w.w, http.StatusOK, &ChatResponse{SessionID: w.sessionID, Content: w.text.String(), Parts: w.parts})
+}
+
+// WriteChunk sends a chunk of the response.
+func (w *chatWriter) WriteChunk(content string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.text.WriteString(content)
+	return w.emit(NewChunkMessage(w.sessionID, content))
+}
+
+// WriteUserTranscript is a duplex-only message; chat turns are text.
+func (w *chatWriter) WriteUserTranscript(string) error { return nil }
+
+// WriteChunkWithParts sends a chunk with multi-modal content parts.
+func (w *chatWriter) WriteChunkWithParts(parts []ContentPart) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.parts = append(w.parts, parts...)
+	return w.emit(NewChunkMessageWithParts(w.sessionID, parts))
+}
+
+// WriteDone signals the response is complete. A non-empty content is the full
+// reply and replaces the chunks collected so far.
+func (w *chatWriter) WriteDone(content string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if content != "" {
+		w.text.Reset()
+		w.text.WriteString(content)
+	}
+	return w.emit(NewDoneMessage(w.sessionID, content))
+}
+
+// WriteDoneWithParts signals completion with multi-modal content parts.
+func (w *chatWriter) WriteDoneWithParts(parts []ContentPart) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.parts = parts
+	w.text.Reset()
+	for _, p := range parts {
+		if p.Type == ContentPartTypeText {
+			w.text.WriteString(p.Text)
+		}
+	}
+	return w.emit(NewDoneMessageWithParts(w.sessionID, parts))
+}
+
+// WriteToolCall fails the turn: a client-side tool's result can only come
+// back over a WebSocket connection.
+func (w *chatWriter) WriteToolCall(toolCall *ToolCallInfo) error {
+	message := fmt.Sprintf("client-side tool %q requires a WebSocket connection", toolCall.Name)
+	w.fail(ErrorCodeToolFailed, message)
+	return errors.New(message)
+}
+
+// WriteToolResult sends a tool result.
+func (w *chatWriter) WriteToolResult(result *ToolResultInfo) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.emit(NewToolResultMessage(w.sessionID, result))
+}
+
+// WriteError sends an error message and marks the session errored, as the
+// WebSocket writer does.
+func (w *chatWriter) WriteError(code, message string) error {
+	w.mu.Lock()
+	sessionID := w.sessionID
+	var err error
+	if w.err == nil {
+		w.err = w.errorInfo(code, message)
+		err = w.emit(w.errorMessage())
+	}
+	w.mu.Unlock()
+	w.server.recordError(sessionID, code, message)
+	return err
+}
+
+// WriteInterrupt is a duplex-only message; chat turns are text.
+func (w *chatWriter) WriteInterrupt() error { return nil }
+
+// WriteSessionConfig is a duplex-only message; chat turns are text.
+func (w *chatWriter) WriteSessionConfig(*SessionConfigInfo) error { return nil }
+
+// WriteUploadReady is never called: uploads are negotiated over WebSocket.
+func (w *chatWriter) WriteUploadReady(*UploadReadyInfo) error { return nil }
+
+// WriteUploadComplete is never called: uploads are negotiated over WebSocket.
+func (w *chatWriter) WriteUploadComplete(*UploadCompleteInfo) error { return nil }
+
+// WriteMediaChunk sends a streaming media chunk. Media is only relayed on
+// the event stream.
+func (w *chatWriter) WriteMediaChunk(mediaChunk *MediaChunkInfo) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.flusher == nil {
+		return nil
+	}
+	err := w.emit(NewMediaChunkMessage(w.sessionID, mediaChunk))
+	if err == nil {
+		w.server.metrics.MediaChunkSent(false, len(mediaChunk.Data))
+	}
+	return err
+}
+
+// WantsProgress reports whether the turn streams events; a JSON reply has
+// nowhere to put progress.
+func (w *chatWriter) WantsProgress() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flusher != nil
+}
+
+// WriteProgress sends a step of the turn on the event stream.
+func (w *chatWriter) WriteProgress(progress *ProgressInfo) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.flusher == nil {
+		return nil
+	}
+	return w.emit(NewProgressMessage(w.sessionID, progress))
+}
+
+// WriteBinaryMediaChunk sends a media chunk base64-encoded; events are text.
+func (w *chatWriter) WriteBinaryMediaChunk(mediaID [MediaIDSize]byte, sequence uint32, isLast bool, mimeType string, payload []byte) error {
+	return w.WriteMediaChunk(&MediaChunkInfo{
+		MediaID:  MediaIDToString(mediaID),
+		Sequence: int(sequence),
+		IsLast:   isLast,
+		Data:     base64.StdEncoding.EncodeToString(payload),
+		MimeType: mimeType,
+	})
+}
+
+// SupportsBinary reports false: chat responses carry no binary frames.
+func (w *chatWriter) SupportsBinary() bool { return false }
+
+// writeChatError answers a chat request refused before its turn started.
+func writeChatError(w http.ResponseWriter, requestID, code, message string) {
+	info := newErrorInfo(code, message)
+	info.CorrelationID = requestID
+	writeChatJSON(w, apierror.HTTPStatus(code), &ChatResponse{Error: info})
+}
+
+func writeChatJSON(w http.ResponseWriter, status int, resp *ChatResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/internal/session/sessiontest"
+)
+
+// newChatServer serves the chat endpoint of a server backed by a session
+// store double.
+func newChatServer(t *testing.T, handler MessageHandler) (*Server, *httptest.Server, *sessiontest.Store) {
+	t.Helper()
+	store := sessiontest.NewStore()
+	server := NewServer(DefaultServerConfig(), store, handler, logr.Discard())
+	ts := httptest.NewServer(server.ChatHandler())
+	t.Cleanup(func() {
+		ts.Close()
+		_ = store.Close()
+	})
+	return server, ts, store
+}
+
+func postChat(t *testing.T, url, body string, header http.Header) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url+"?agent=test-agent", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func decodeChatResponse(t *testing.T, resp *http.Response) ChatResponse {
+	t.Helper()
+	var out ChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return out
+}
+
+func TestChat_Sync(t *testing.T) {
+	var seen []string
+	handler := &mockHandler{handleFunc: func(_ context.Context, sessionID string, msg *ClientMessage, w ResponseWriter) error {
+		seen = append(seen, sessionID)
+		if err := w.WriteChunk("Hello, "); err != nil {
+			return err
+		}
+		if err := w.WriteChunk(msg.Content); err != nil {
+			return err
+		}
+		return w.WriteDone("")
+	}}
+	_, ts, store := newChatServer(t, handler)
+
+	resp := postChat(t, ts.URL, `{"content":"world"}`, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	out := decodeChatResponse(t, resp)
+	if out.Content != "Hello, world" {
+		t.Errorf("content = %q, want the collected chunks", out.Content)
+	}
+	if out.SessionID == "" || out.SessionID != seen[0] {
+		t.Errorf("session_id = %q, want the handler's session %q", out.SessionID, seen[0])
+	}
+	sess, err := store.GetSession(context.Background(), out.SessionID)
+	if err != nil {
+		t.Fatalf("session not recorded: %v", err)
+	}
+	if sess.AgentName != "test-agent" {
+		t.Errorf("agent = %q, want test-agent", sess.AgentName)
+	}
+
+	// A follow-up naming the session continues it.
+	resp = postChat(t, ts.URL, `{"session_id":"`+out.SessionID+`","content":"again"}`, nil)
+	if next := decodeChatResponse(t, resp); next.SessionID != out.SessionID {
+		t.Errorf("follow-up session_id = %q, want %q", next.SessionID, out.SessionID)
+	}
+}
+
+func TestChat_Stream(t *testing.T) {
+	handler := &mockHandler{handleFunc: func(_ context.Context, _ string, _ *ClientMessage, w ResponseWriter) error {
+		if err := w.WriteChunk("Hel"); err != nil {
+			return err
+		}
+		if err := w.WriteChunk("lo"); err != nil {
+			return err
+		}
+		return w.WriteDone("Hello")
+	}}
+	_, ts, _ := newChatServer(t, handler)
+
+	resp := postChat(t, ts.URL, `{"content":"hi"}`, http.Header{"Accept": {mimeEventStream}})
+	if ct := resp.Header.Get("Content-Type"); ct != mimeEventStream {
+		t.Fatalf("content type = %q, want %q", ct, mimeEventStream)
+	}
+	var events []string
+	var last ServerMessage
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if name, ok := strings.CutPrefix(line, "event: "); ok {
+			events = append(events, name)
+		}
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			if err := json.Unmarshal([]byte(data), &last); err != nil {
+				t.Fatalf("event data: %v", err)
+			}
+		}
+	}
+	if got := strings.Join(events, ","); got != "chunk,chunk,done" {
+		t.Errorf("events = %s, want chunk,chunk,done", got)
+	}
+	if last.Type != MessageTypeDone || last.Content != "Hello" || last.SessionID == "" {
+		t.Errorf("last event = %+v, want done carrying the session", last)
+	}
+}
+
+func TestChat_Errors(t *testing.T) {
+	handler := &mockHandler{handleFunc: func(_ context.Context, _ string, msg *ClientMessage, w ResponseWriter) error {
+		switch msg.Content {
+		case "tool":
+			if err := w.WriteToolCall(&ToolCallInfo{ID: "tc-1", Name: "get_location"}); err != nil {
+				return err
+			}
+			return w.WriteDone("unreachable")
+		default:
+			return w.WriteError(ErrorCodeBudgetExceeded, "session budget exceeded")
+		}
+	}}
+	_, ts, _ := newChatServer(t, handler)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		code   string
+	}{
+		{"missing content", `{}`, http.StatusBadRequest, ErrorCodeInvalidMessage},
+		{"malformed body", `{"content":`, http.StatusBadRequest, ErrorCodeInvalidMessage},
+		{"runtime error", `{"content":"hi"}`, http.StatusTooManyRequests, ErrorCodeBudgetExceeded},
+		{"client tool", `{"content":"tool"}`, http.StatusUnprocessableEntity, ErrorCodeToolFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := postChat(t, ts.URL, tt.body, nil)
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if out := decodeChatResponse(t, resp); out.Error == nil || out.Error.Code != tt.code {
+				t.Errorf("error = %+v, want code %s", out.Error, tt.code)
+			}
+		})
+	}
+}
+
+func TestChat_ExpiredSession(t *testing.T) {
+	handler := &probeHandler{state: ResumeStateNotFound}
+	_, ts, _ := newChatServer(t, handler)
+
+	resp := postChat(t, ts.URL, `{"session_id":"gone","content":"hi"}`, nil)
+	if resp.StatusCode != http.StatusGone {
+		t.Errorf("status = %d, want 410", resp.StatusCode)
+	}
+	if out := decodeChatResponse(t, resp); out.Error == nil || out.Error.Code != ErrorCodeSessionExpired {
+		t.Errorf("error = %+v, want SESSION_EXPIRED", out.Error)
+	}
+	if len(handler.calls) != 1 || handler.calls[0] != "gone" {
+		t.Errorf("probe calls = %v, want the named session probed", handler.calls)
+	}
+}
+
+func TestChat_RejectsWhileDraining(t *testing.T) {
+	server, ts, _ := newChatServer(t, &mockHandler{})
+	server.markDraining()
+
+	resp := postChat(t, ts.URL, `{"content":"hi"}`, nil)
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", resp.StatusCode)
+	}
+}
@@ -18,7 +18,11 @@ package facade
 
 import (
 	"context"
+	"errors"
+	"net"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -30,30 +34,106 @@ import (
 	"github.com/altairalabs/omnia/pkg/logctx"
 )
 
+// connectionIDKey is the context key carrying the connection ID.
+type connectionIDKey struct{}
+
+// WithConnectionID returns a context carrying the WebSocket connection ID.
+func WithConnectionID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, connectionIDKey{}, id)
+}
+
+// ConnectionIDFromContext returns the ID of the WebSocket connection a
+// message arrived on, or "" outside a connection. Handlers that implement
+// ConnectionObserver use it to tell participants of a shared session apart.
+func ConnectionIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(connectionIDKey{}).(string)
+	return id
+}
+
 // Connection represents an active WebSocket connection.
 type Connection struct {
-	conn             *websocket.Conn
-	sessionID        string
-	agentName        string
-	namespace        string
-	workspaceName    string
-	binaryCapable    bool // Client supports binary WebSocket frames
+	id            string // unique per connection; see ConnectionIDFromContext
+	conn          *websocket.Conn
+	sessionID     string
+	agentName     string
+	namespace     string
+	workspaceName string
+	binaryCapable bool // Client supports binary WebSocket frames
+	// features are the protocol features the client asked for on connect;
+	// nil when the connection did not go through the handshake (see supports).
+	features map[Feature]bool
+	// protocol is the handshake result echoed in connected; nil alongside
+	// a nil features.
+	protocol *ProtocolInfo
+	// withheld collects the text of chunks held back from a client that did
+	// not negotiate streaming, until the turn's done. Protected by c.mu.
+	withheld         strings.Builder
 	mu               sync.Mutex
 	closed           bool
 	sessionPersisted bool // true once the session has been written to the store
 
+	// connectedAt and remoteAddr describe the connection in the admin API.
+	connectedAt time.Time
+	remoteAddr  string
+	// received and sent count the connection's frames in each direction.
+	received atomic.Uint64
+	sent     atomic.Uint64
+
+	// requestID identifies the connection's requests in logs and to the
+	// runtime; error messages carry it as their correlation ID.
+	requestID string
+
 	// User identity fields extracted from Istio-injected headers on WebSocket upgrade.
 	userID        string
 	userEmail     string
 	authorization string // Original JWT token for passthrough
+	// authWorkspace is the workspace the auth chain admitted the caller to;
+	// empty without one. See authWorkspace.
+	authWorkspace string
 
 	// Rollout cohort tracking fields extracted from HTTP headers on WebSocket upgrade.
 	cohortID string
 	variant  string
 
+	// locale is the user's BCP 47 locale from ?locale= or Accept-Language on
+	// connect. It is passed to the runtime on every message.
+	locale string
+
 	// resumeID is the session_id the client asked to resume via ?resume=.
 	// Empty when this is a fresh (non-resume) connection.
 	resumeID string
+	// lastSeq is the sequence number of the last message the client received
+	// before reconnecting, from ?last_seq=. Messages after it are replayed.
+	lastSeq uint64
+	// clientSeq is the seq of the last client message accepted while the
+	// connection had no replay log, and on resume the session's. Protected by
+	// c.mu.
+	clientSeq uint64
+	// replay is the log this connection's session messages are sent through.
+	// Nil until a message binds the session, and always nil when replay is
+	// disabled or the connection joined a shared session. Protected by c.mu.
+	replay *replayLog
+	// turnCtx is the context the connection's turns run on. Unlike the read
+	// loop's context it survives the connection while the session is held
+	// for a resume; cancelTurns stops it.
+	turnCtx     context.Context
+	cancelTurns context.CancelFunc
+	// peer is set on the owner's stand-in for a client connected to another
+	// replica (see SessionRelay); messages to it are published to the relay.
+	peer *relayPeer
+	// upstream is set when another replica runs this connection's session;
+	// client messages are forwarded to it. Protected by c.mu.
+	upstream *relayUpstream
+	// joinID is the session_id the client asked to join via ?join=, for
+	// handlers that support collaborative sessions (ConnectionObserver).
+	joinID string
+	// joined is set once the connection attached to joinID.
+	joined bool
+	// joinedAsOwner is set when the connection joined a session its user
+	// owns.
+	joinedAsOwner bool
+	// role is the participant role requested via ?role=.
+	role ParticipantRole
 	// intentionalClose is set to true when the client explicitly hangs up
 	// (e.g. sends a close frame with a normal-closure code) so that the
 	// blip-resume path knows NOT to park the audio session.
@@ -79,6 +159,12 @@ type Connection struct {
 	// inFlightMessages limits concurrently processed non-tool messages per connection.
 	// Nil when disabled.
 	inFlightMessages chan struct{}
+	// outbound queues messages for the connection's write loop. Nil when
+	// ServerConfig.SendQueueSize is 0, in which case senders write to the
+	// socket themselves under c.mu.
+	outbound *sendQueue
+	// fragments rebuilds a message the client is sending as fragments.
+	fragments fragmentAssembler
 
 	// audioSession is the persistent duplex audio stream for this connection.
 	// Created lazily on the first inbound BinaryMessageTypeMediaChunk frame
@@ -143,12 +229,30 @@ func (s *Server) handleConnection(ctx context.Context, c *Connection) {
 		return
 	}
 
-	// Attempt to reattach to a parked realtime session named by ?resume=<sid>.
-	// On success, bind the connection to the parked session and send connected
-	// with resumed=true. On miss (no park, owner mismatch, or no resumeID),
-	// fall through to the existing fresh-session path.
-	if _, resumed := s.tryReattach(ctx, c); resumed {
-		if err := s.sendConnected(c, c.SessionID(), true); err != nil {
+	// In-flight turns outlive a dropped connection while its session is held
+	// for a resume (see replayRegistry.hold), so they do not run on connCtx.
+	c.turnCtx, c.cancelTurns = context.WithCancel(context.WithoutCancel(ctx))
+
+	// Attempt to reattach to a parked realtime session named by ?resume=<sid>,
+	// and to replay the messages the client missed. On success, bind the
+	// connection to the session and send connected with resumed=true. On miss
+	// (nothing held, owner mismatch, or no resumeID), fall through to the
+	// existing fresh-session path.
+	_, reattached := s.tryReattach(ctx, c)
+	if replayed, err := s.tryResume(ctx, c); replayed || reattached {
+		if err == nil && !replayed {
+			err = s.sendConnected(c, c.SessionID(), true)
+		}
+		if err != nil {
+			log.Error(err, "failed to send connected message")
+			return
+		}
+	} else if joined, code, reason := s.tryJoin(ctx, c); code != "" {
+		log.V(1).Info("join refused", "sessionID", c.joinID, "reason", reason)
+		s.sendError(c, c.joinID, code, reason)
+		return
+	} else if joined {
+		if err := s.sendConnected(c, c.SessionID(), false); err != nil {
 			log.Error(err, "failed to send connected message")
 			return
 		}
@@ -163,6 +267,10 @@ func (s *Server) handleConnection(ctx context.Context, c *Connection) {
 		}
 	}
 
+	if observer, ok := s.handler.(ConnectionObserver); ok {
+		observer.ConnectionOpened(ctx, c.info(), &connResponseWriter{conn: c, sessionID: c.SessionID(), server: s})
+	}
+
 	// Start ping ticker
 	pingTicker := time.NewTicker(s.config.PingInterval)
 	defer pingTicker.Stop()
@@ -177,6 +285,91 @@ func (s *Server) handleConnection(ctx context.Context, c *Connection) {
 	s.readMessageLoop(connCtx, c, log)
 }
 
+// tryJoin binds the connection to the session named by ?join= when the
+// handler supports collaborative sessions. Returns false to fall through to
+// a fresh session, or an error code and reason when the join is refused: the
+// session must be held by this replica or known to the session store, and
+// the caller must be its owner or admitted to its workspace.
+func (s *Server) tryJoin(ctx context.Context, c *Connection) (joined bool, code, reason string) {
+	if c.joinID == "" {
+		return false, "", ""
+	}
+	if _, ok := s.handler.(ConnectionObserver); !ok {
+		s.log.V(1).Info("join ignored", "sessionID", c.joinID, "reason", "handler does not support shared sessions")
+		return false, "", ""
+	}
+	target, ok := s.joinTarget(ctx, c)
+	if !ok {
+		return false, ErrorCodeSessionNotFound, "no session " + c.joinID + " to join"
+	}
+	owner := c.userID != "" && c.userID == target.owner
+	member := c.authWorkspace != "" && c.authWorkspace == target.workspace
+	if !owner && !member {
+		return false, ErrorCodePermissionDenied, "only the session's owner or members of its workspace may join it"
+	}
+	c.mu.Lock()
+	c.sessionID = c.joinID
+	c.joined = true
+	c.joinedAsOwner = owner
+	c.mu.Unlock()
+	return true, "", ""
+}
+
+// joinSession is the owner and workspace of a session a client asks to join.
+type joinSession struct {
+	owner     string
+	workspace string
+}
+
+// sessionGetter is implemented by session stores that can read a session
+// back, as the session-api client does.
+type sessionGetter interface {
+	GetSession(ctx context.Context, sessionID string) (*session.Session, error)
+}
+
+// joinTarget finds the session c asks to join: bound to a live connection of
+// this replica that started it, held here for a resume, or archived in the
+// session store for this agent.
+func (s *Server) joinTarget(ctx context.Context, c *Connection) (joinSession, bool) {
+	s.mu.RLock()
+	for _, other := range s.connections {
+		other.mu.Lock()
+		match := other != c && other.sessionID == c.joinID && !other.joined
+		other.mu.Unlock()
+		if match {
+			s.mu.RUnlock()
+			return joinSession{owner: other.userID, workspace: other.workspaceName}, true
+		}
+	}
+	s.mu.RUnlock()
+
+	if owner, ok := s.replays.owner(c.joinID); ok {
+		return joinSession{owner: owner, workspace: s.config.WorkspaceName}, true
+	}
+	if getter, ok := s.sessionStore.(sessionGetter); ok {
+		sess, err := getter.GetSession(ctx, c.joinID)
+		if err == nil && sess.AgentName == c.agentName && sess.Namespace == c.namespace {
+			return joinSession{owner: sess.VirtualUserID, workspace: sess.WorkspaceName}, true
+		}
+	}
+	return joinSession{}, false
+}
+
+// info describes the connection for a ConnectionObserver.
+func (c *Connection) info() ConnectionInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ConnectionInfo{
+		ID:            c.id,
+		SessionID:     c.sessionID,
+		UserID:        c.userID,
+		UserEmail:     c.userEmail,
+		RequestedRole: c.role,
+		Joined:        c.joined,
+		Owner:         !c.joined || c.joinedAsOwner,
+	}
+}
+
 // SessionID returns the connection's current session ID safely.
 func (c *Connection) SessionID() string {
 	c.mu.Lock()
@@ -241,6 +434,99 @@ func (s *Server) tryReattach(ctx context.Context, c *Connection) (*audioSession,
 	return as, true
 }
 
+// tryResume binds the connection to the replay log of the session named by
+// c.resumeID, sending connected with resumed=true followed by every message
+// after c.lastSeq. A session held by another replica is relayed to it (see
+// tryRelay). Returns false to fall through to a fresh session.
+func (s *Server) tryResume(ctx context.Context, c *Connection) (bool, error) {
+	if c.resumeID == "" {
+		return false, nil
+	}
+	var err error
+	resumed := s.replays.resume(ctx, c, c.resumeID, c.lastSeq, func(persisted bool, clientSeq uint64, missed []*ServerMessage) {
+		c.mu.Lock()
+		c.sessionID = c.resumeID
+		c.sessionPersisted = c.sessionPersisted || persisted
+		c.clientSeq = clientSeq
+		c.mu.Unlock()
+		if err = s.sendConnected(c, c.resumeID, true); err != nil {
+			return
+		}
+		s.metrics.MessagesRedelivered(len(missed))
+		for _, msg := range missed {
+			if err = s.writeMessage(c, msg); err != nil {
+				return
+			}
+		}
+	})
+	if !resumed {
+		// Another replica may hold the session; relay to it.
+		return s.tryRelay(ctx, c)
+	}
+	return resumed, err
+}
+
+// holdForResume keeps the session's replay log and in-flight turns for the
+// grace window when the client dropped without hanging up, and stops them
+// otherwise. Returns true when a turn was still open, so completion waits
+// for the resume or the end of the window.
+func (s *Server) holdForResume(c *Connection, parked bool) bool {
+	c.mu.Lock()
+	rl, intentional := c.replay, c.intentionalClose
+	c.mu.Unlock()
+
+	s.mu.RLock()
+	shutdown := s.shutdown
+	s.mu.RUnlock()
+
+	if !intentional && !shutdown {
+		if held, pending := s.replays.hold(context.Background(), c, parked, c.stopTurns); held {
+			// A client leaving a draining pod resumes on another replica.
+			if s.IsDraining() {
+				s.replays.handoff(context.Background(), rl)
+			}
+			return pending
+		}
+	}
+	if rl != nil {
+		s.replays.drop(rl, c)
+	}
+	c.stopTurns()
+	return false
+}
+
+// inFlightContext returns the context the connection's turns run on, or ctx
+// for a connection not served by handleConnection.
+func (c *Connection) inFlightContext(ctx context.Context) context.Context {
+	if c.turnCtx == nil {
+		return ctx
+	}
+	return c.turnCtx
+}
+
+// stopTurns cancels the connection's in-flight turns.
+func (c *Connection) stopTurns() {
+	if c.cancelTurns != nil {
+		c.cancelTurns()
+	}
+}
+
+// replayLogFor returns the replay log msg is sent through, or nil when msg is
+// written to the connection directly: replay is off, msg belongs to no logged
+// session, or it is a connected message, which describes the connection
+// rather than the session.
+func (c *Connection) replayLogFor(msg *ServerMessage) *replayLog {
+	if msg.Type == MessageTypeConnected {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.replay == nil || c.replay.sessionID != msg.SessionID {
+		return nil
+	}
+	return c.replay
+}
+
 // cleanupConnection handles connection cleanup when it closes.
 func (s *Server) cleanupConnection(c *Connection, log logr.Logger) {
 	s.mu.Lock()
@@ -251,22 +537,36 @@ func (s *Server) cleanupConnection(c *Connection, log logr.Logger) {
 	c.closed = true
 	c.mu.Unlock()
 
+	// A relayed session is run, held, and completed by its owner.
+	relayed := s.closeUpstream(c)
 	parked := s.parkOnClose(context.Background(), c)
+	held := s.holdForResume(c, parked)
+
+	// Other participants of a shared session keep it alive; completion is
+	// left to whichever connection leaves last.
+	shared := false
+	if observer, ok := s.handler.(ConnectionObserver); ok {
+		shared = observer.ConnectionClosed(c.info()) > 0
+	}
 
 	s.metrics.ConnectionClosed()
 
 	// Snapshot session ID once under the mutex; the closure runs in a goroutine
 	// and must not race against concurrent writers of c.sessionID.
 	sessionID := c.SessionID()
-	// A parked session is still live — its provider socket is held open for a
-	// reattach — so completion is deferred to whichever end actually finishes
-	// it: a later close after reattach, or the registry's expiry callback.
-	if !parked && sessionID != "" && c.SessionPersisted() {
+	// A parked session, or a held one with a turn still open, is still live —
+	// its provider socket or its in-flight turn is kept for a resume — so
+	// completion is deferred to
+	// whichever end actually finishes it: a later close after the resume, or
+	// the registry's expiry callback.
+	if !relayed && !parked && !held && !shared && sessionID != "" && c.SessionPersisted() {
 		s.metrics.SessionClosed()
 		s.completeSession(sessionID, log)
 	}
 
-	if err := c.conn.Close(); err != nil {
+	s.flushOutbound(c)
+	// A slow consumer's socket was already closed by abort.
+	if err := c.conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
 		log.Error(err, "error closing connection")
 	}
 }
@@ -329,11 +629,6 @@ func (s *Server) sendPing(c *Connection) bool {
 	if c.closed {
 		return false
 	}
-	if c.conn.SetWriteDeadline(time.Now().Add(s.config.WriteTimeout)) != nil {
-		return false
-	}
-	if c.conn.WriteMessage(websocket.PingMessage, nil) != nil {
-		return false
-	}
-	return true
+	// WriteControl may run alongside the write loop's data frames.
+	return c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(s.config.WriteTimeout)) == nil
 }
@@ -44,10 +44,18 @@ func (s *Server) liveRealtimeSessions() int {
 	return int(s.activeAudioSessions.Load()) + s.parked.len()
 }
 
-// Drain marks the server draining and blocks until there are no active or
-// parked realtime sessions, or DrainTimeout elapses (whichever first). Returns
-// the number of sessions still live at return (0 = fully drained). Safe to call
-// once; subsequent calls return immediately.
+// liveWork returns the realtime sessions plus the turns in flight on this pod:
+// everything drain waits for.
+func (s *Server) liveWork() int {
+	return s.liveRealtimeSessions() + int(s.turnsInFlight.Load())
+}
+
+// Drain marks the server draining, sends connected clients a reconnect hint,
+// and blocks until there are no active or parked realtime sessions and no
+// turns in flight, or DrainTimeout elapses (whichever first). It then hands
+// the sessions' replay state off for resume on another replica. Returns the
+// number of sessions and turns still live at return (0 = fully drained). Safe
+// to call once; subsequent calls return immediately.
 func (s *Server) Drain(ctx context.Context) int {
 	s.markDraining()
 	s.metrics.RealtimeDrainStarted()
@@ -56,11 +64,17 @@ func (s *Server) Drain(ctx context.Context) int {
 
 	finishDrain := func(reason string, remaining int) int {
 		elapsed := time.Since(drainStart).Seconds()
-		drained := initialSessions - remaining
+		realtime := s.liveRealtimeSessions()
+		drained := initialSessions - realtime
 		if drained < 0 {
 			drained = 0
 		}
-		s.metrics.RealtimeDrainCompleted(reason, elapsed, drained, remaining)
+		s.metrics.RealtimeDrainCompleted(reason, elapsed, drained, realtime)
+		// The drain context may be what ended the wait; the handoff still
+		// gets a write timeout's worth of time.
+		handoffCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.config.WriteTimeout)
+		defer cancel()
+		s.handOffSessions(handoffCtx)
 		return remaining
 	}
 
@@ -70,19 +84,21 @@ func (s *Server) Drain(ctx context.Context) int {
 	defer ticker.Stop()
 	s.log.Info("facade draining started",
 		"drainTimeout", s.config.DrainTimeout,
-		"liveSessions", initialSessions)
+		"liveSessions", initialSessions,
+		"turnsInFlight", s.turnsInFlight.Load())
+	s.sendReconnectHints()
 	for {
-		if n := s.liveRealtimeSessions(); n == 0 {
+		if n := s.liveWork(); n == 0 {
 			s.log.Info("facade drain complete", "reason", drainReasonAllDrained)
 			return finishDrain(drainReasonAllDrained, 0)
 		}
 		select {
 		case <-deadline.C:
-			n := s.liveRealtimeSessions()
+			n := s.liveWork()
 			s.log.Info("facade drain complete", "reason", drainReasonDeadline, "remaining", n)
 			return finishDrain(drainReasonDeadline, n)
 		case <-ctx.Done():
-			return finishDrain(drainReasonCtxCanceled, s.liveRealtimeSessions())
+			return finishDrain(drainReasonCtxCanceled, s.liveWork())
 		case <-ticker.C:
 		}
 	}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+func TestErrors_WebSocketFrameCarriesErrorModel(t *testing.T) {
+	_, ts := newTestServer(t, &mockHandler{handleFunc: func(context.Context, string, *ClientMessage, ResponseWriter) error {
+		return status.Error(codes.Unavailable, "runtime restarting")
+	}})
+	header := http.Header{}
+	header.Set(policy.HeaderRequestID, "client-req-1")
+	ws, _, err := websocket.DefaultDialer.Dial(wsURL(ts.URL)+"?agent=test-agent", header)
+	require.NoError(t, err)
+	defer func() { _ = ws.Close() }()
+	sessionID := readConnected(t, ws)
+
+	require.NoError(t, ws.WriteJSON(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "hi"}))
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var msg ServerMessage
+		require.NoError(t, ws.ReadJSON(&msg))
+		if msg.Type != MessageTypeError {
+			continue
+		}
+		assert.Equal(t, &ErrorInfo{
+			Code:          ErrorCodeUnavailable,
+			Message:       "runtime restarting",
+			Retryable:     true,
+			CorrelationID: "client-req-1",
+		}, msg.Error)
+		return
+	}
+}
+
+func TestErrors_ChatResponseCarriesErrorModel(t *testing.T) {
+	_, ts, _ := newChatServer(t, &mockHandler{handleFunc: func(_ context.Context, _ string, _ *ClientMessage, w ResponseWriter) error {
+		return w.WriteError(ErrorCodeRateLimited, "provider rate limited")
+	}})
+
+	resp := postChat(t, ts.URL, `{"content":"hi"}`, nil)
+	requestID := resp.Header.Get(policy.HeaderRequestID)
+	require.NotEmpty(t, requestID)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	out := decodeChatResponse(t, resp)
+	assert.Equal(t, &ErrorInfo{
+		Code:          ErrorCodeRateLimited,
+		Message:       "provider rate limited",
+		Retryable:     true,
+		CorrelationID: requestID,
+	}, out.Error)
+
+	// A request refused before its turn carries the model too.
+	resp = postChat(t, ts.URL, `{}`, http.Header{"X-Omnia-Request-Id": {"client-req-2"}})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	out = decodeChatResponse(t, resp)
+	assert.Equal(t, ErrorCodeInvalidMessage, out.Error.Code)
+	assert.False(t, out.Error.Retryable)
+	assert.Equal(t, "client-req-2", out.Error.CorrelationID)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"unicode/utf8"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+var (
+	// errFragmentTooLarge is returned when a fragmented client message grows
+	// past ServerConfig.MaxFragmentedSize.
+	errFragmentTooLarge = errors.New("fragmented message too large")
+	// errFragmentInvalid is returned for a fragment that does not continue
+	// the message being reassembled.
+	errFragmentInvalid = errors.New("fragment out of sequence")
+)
+
+// encodeFrames encodes msg as the text frames that carry it: one, or, when
+// split is set and the encoding is larger than MaxFragmentSize, one fragment
+// message per MaxFragmentSize bytes of it.
+func (s *Server) encodeFrames(msg *ServerMessage, split bool) ([][]byte, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	limit := s.config.MaxFragmentSize
+	if !split || limit <= 0 || len(data) <= limit {
+		return [][]byte{data}, nil
+	}
+	pieces := splitUTF8(string(data), limit)
+	id := uuid.NewString()
+	frames := make([][]byte, len(pieces))
+	for i, piece := range pieces {
+		frames[i], err = json.Marshal(NewFragmentMessage(msg.SessionID, &FragmentInfo{
+			ID:    id,
+			Index: i,
+			Count: len(pieces),
+			Data:  piece,
+		}))
+		if err != nil {
+			return nil, err
+		}
+	}
+	s.metrics.MessageFragmented(len(frames))
+	return frames, nil
+}
+
+// splitUTF8 splits s into pieces of at most n bytes without cutting a UTF-8
+// sequence, so each piece survives being encoded as a JSON string.
+func splitUTF8(s string, n int) []string {
+	pieces := make([]string, 0, len(s)/n+1)
+	for len(s) > n {
+		cut := n
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		if cut == 0 {
+			cut = n
+		}
+		pieces = append(pieces, s[:cut])
+		s = s[cut:]
+	}
+	return append(pieces, s)
+}
+
+// compressNext enables compression for the connection's next write when the
+// frame is text of at least CompressionThreshold bytes. It has no effect on a
+// client that did not negotiate permessage-deflate.
+func (s *Server) compressNext(c *Connection, messageType, size int) {
+	if !s.config.EnableCompression {
+		return
+	}
+	c.conn.EnableWriteCompression(messageType == websocket.TextMessage && size >= s.config.CompressionThreshold)
+}
+
+// fragmentAssembler rebuilds a message a client sent as fragments. A client
+// sends the fragments of one message in order before the next fragmented
+// message; fragment 0 starts over. Used only by the goroutine reading the
+// connection's messages.
+type fragmentAssembler struct {
+	id    string
+	count int
+	next  int
+	buf   []byte
+}
+
+// add appends f to the message being rebuilt and returns the message once
+// its last fragment arrives. On error the partial message is discarded.
+func (a *fragmentAssembler) add(f *FragmentInfo, maxSize int) ([]byte, error) {
+	if f.ID == "" || f.Count < 1 || f.Index < 0 || f.Index >= f.Count {
+		a.reset()
+		return nil, errFragmentInvalid
+	}
+	if f.Index == 0 {
+		a.reset()
+		a.id, a.count = f.ID, f.Count
+	} else if f.ID != a.id || f.Index != a.next || f.Count != a.count {
+		a.reset()
+		return nil, errFragmentInvalid
+	}
+	if len(a.buf)+len(f.Data) > maxSize {
+		a.reset()
+		return nil, errFragmentTooLarge
+	}
+	a.buf = append(a.buf, f.Data...)
+	a.next++
+	if a.next < a.count {
+		return nil, nil
+	}
+	data := a.buf
+	a.buf = nil
+	a.reset()
+	return data, nil
+}
+
+func (a *fragmentAssembler) reset() {
+	a.id, a.count, a.next, a.buf = "", 0, 0, nil
+}
+
+// handleFragment adds a client fragment to the message being rebuilt and
+// handles the message once complete.
+func (s *Server) handleFragment(ctx context.Context, c *Connection, msg *ClientMessage, log logr.Logger) {
+	if s.config.MaxFragmentedSize <= 0 {
+		s.sendError(c, c.SessionID(), ErrorCodeInvalidMessage, "fragments are not accepted")
+		return
+	}
+	if msg.Fragment == nil {
+		s.sendError(c, c.SessionID(), ErrorCodeInvalidMessage, "fragment message without fragment")
+		return
+	}
+	data, err := c.fragments.add(msg.Fragment, s.config.MaxFragmentedSize)
+	switch {
+	case errors.Is(err, errFragmentTooLarge):
+		s.sendError(c, c.SessionID(), ErrorCodeMessageTooLarge, err.Error())
+		return
+	case err != nil:
+		s.sendError(c, c.SessionID(), ErrorCodeInvalidMessage, err.Error())
+		return
+	case data == nil:
+		return
+	}
+	var clientMsg ClientMessage
+	if err := json.Unmarshal(data, &clientMsg); err != nil || clientMsg.Type == MessageTypeFragment {
+		s.sendError(c, c.SessionID(), ErrorCodeInvalidMessage, "invalid message format")
+		return
+	}
+	s.dispatchClientMessage(ctx, c, &clientMsg, log)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session/sessiontest"
+)
+
+func newFragmentServer(t *testing.T, handler MessageHandler) *httptest.Server {
+	t.Helper()
+	cfg := DefaultServerConfig()
+	cfg.MaxFragmentSize = 1024
+	cfg.MaxFragmentedSize = 8 * 1024
+	ts := httptest.NewServer(NewServer(cfg, sessiontest.NewStore(), handler, logr.Discard()))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestSplitUTF8(t *testing.T) {
+	s := strings.Repeat("é", 5) // 2 bytes each
+	pieces := splitUTF8(s, 3)
+	assert.Equal(t, []string{"é", "é", "é", "é", "é"}, pieces)
+	assert.Equal(t, []string{"abc", "de"}, splitUTF8("abcde", 3))
+	assert.Equal(t, []string{"ab"}, splitUTF8("ab", 3))
+}
+
+func TestFragmentAssembler(t *testing.T) {
+	var a fragmentAssembler
+	data, err := a.add(&FragmentInfo{ID: "m1", Index: 0, Count: 2, Data: "hel"}, 16)
+	require.NoError(t, err)
+	assert.Nil(t, data)
+	data, err = a.add(&FragmentInfo{ID: "m1", Index: 1, Count: 2, Data: "lo"}, 16)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	_, err = a.add(&FragmentInfo{ID: "m2", Index: 1, Count: 2, Data: "x"}, 16)
+	assert.ErrorIs(t, err, errFragmentInvalid, "a fragment must continue the message")
+
+	_, err = a.add(&FragmentInfo{ID: "m3", Index: 0, Count: 2, Data: strings.Repeat("x", 10)}, 16)
+	require.NoError(t, err)
+	_, err = a.add(&FragmentInfo{ID: "m3", Index: 1, Count: 2, Data: strings.Repeat("x", 10)}, 16)
+	assert.ErrorIs(t, err, errFragmentTooLarge)
+	assert.Empty(t, a.buf, "a rejected message is discarded")
+}
+
+func TestFragments_LargeMessageSplitForNegotiatingClient(t *testing.T) {
+	reply := strings.Repeat("a long tool output ", 300)
+	ts := newFragmentServer(t, &mockHandler{handleFunc: func(_ context.Context, _ string, _ *ClientMessage, w ResponseWriter) error {
+		return w.WriteDone(reply)
+	}})
+	base := wsURL(ts.URL) + "?agent=test-agent"
+
+	t.Run("sent whole without the feature", func(t *testing.T) {
+		msgs := turnMessages(t, base+"&protocol=1&features=streaming")
+		require.Equal(t, []MessageType{MessageTypeDone}, messageTypes(msgs))
+		assert.Equal(t, reply, msgs[0].Content)
+	})
+
+	t.Run("fragmented with the feature", func(t *testing.T) {
+		ws, _, err := websocket.DefaultDialer.Dial(base+"&protocol=1&features=streaming,fragments", nil)
+		require.NoError(t, err)
+		defer func() { _ = ws.Close() }()
+		sessionID := readConnected(t, ws)
+		require.NoError(t, ws.WriteJSON(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "hi"}))
+
+		var data strings.Builder
+		_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+		for n := 0; ; n++ {
+			var msg ServerMessage
+			require.NoError(t, ws.ReadJSON(&msg))
+			require.Equal(t, MessageTypeFragment, msg.Type)
+			require.Equal(t, n, msg.Fragment.Index)
+			data.WriteString(msg.Fragment.Data)
+			if msg.Fragment.Index == msg.Fragment.Count-1 {
+				require.Greater(t, msg.Fragment.Count, 1)
+				break
+			}
+		}
+		var done ServerMessage
+		require.NoError(t, json.Unmarshal([]byte(data.String()), &done))
+		assert.Equal(t, MessageTypeDone, done.Type)
+		assert.Equal(t, reply, done.Content)
+	})
+}
+
+func TestFragments_ClientMessageReassembled(t *testing.T) {
+	received := make(chan string, 1)
+	ts := newFragmentServer(t, &mockHandler{handleFunc: func(_ context.Context, _ string, msg *ClientMessage, w ResponseWriter) error {
+		received <- msg.Content
+		return w.WriteDone("ok")
+	}})
+	ws, _, err := websocket.DefaultDialer.Dial(wsURL(ts.URL)+"?agent=test-agent", nil)
+	require.NoError(t, err)
+	defer func() { _ = ws.Close() }()
+	sessionID := readConnected(t, ws)
+
+	content := strings.Repeat("x", 3000)
+	data, err := json.Marshal(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: content})
+	require.NoError(t, err)
+	pieces := splitUTF8(string(data), 1000)
+	for i, piece := range pieces {
+		require.NoError(t, ws.WriteJSON(ClientMessage{Type: MessageTypeFragment, Fragment: &FragmentInfo{
+			ID: "m1", Index: i, Count: len(pieces), Data: piece,
+		}}))
+	}
+	select {
+	case got := <-received:
+		assert.Equal(t, content, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reassembled message not handled")
+	}
+
+	// A fragmented message over the limit is refused.
+	big := strings.Repeat("y", 5000)
+	for i := range 2 {
+		require.NoError(t, ws.WriteJSON(ClientMessage{Type: MessageTypeFragment, Fragment: &FragmentInfo{
+			ID: "m2", Index: i, Count: 2, Data: big,
+		}}))
+	}
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var msg ServerMessage
+		require.NoError(t, ws.ReadJSON(&msg))
+		if msg.Type == MessageTypeError {
+			assert.Equal(t, ErrorCodeMessageTooLarge, msg.Error.Code)
+			return
+		}
+	}
+}
+
+func TestCompression_NegotiatedWithOfferingClient(t *testing.T) {
+	reply := strings.Repeat("compressible ", 500)
+	ts := newFragmentServer(t, &mockHandler{handleFunc: func(_ context.Context, _ string, _ *ClientMessage, w ResponseWriter) error {
+		return w.WriteDone(reply)
+	}})
+	dialer := websocket.Dialer{EnableCompression: true}
+	ws, resp, err := dialer.Dial(wsURL(ts.URL)+"?agent=test-agent", nil)
+	require.NoError(t, err)
+	defer func() { _ = ws.Close() }()
+	assert.Contains(t, resp.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+
+	sessionID := readConnected(t, ws)
+	require.NoError(t, ws.WriteJSON(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "hi"}))
+	var done ServerMessage
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	require.NoError(t, ws.ReadJSON(&done))
+	assert.Equal(t, reply, done.Content)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// reconnectReasonDraining is the reconnect hint reason sent when the server
+// starts draining for shutdown.
+const reconnectReasonDraining = "draining"
+
+// SessionHandoff is the replay state of a session a draining pod gives up, so
+// a client that resumes on another replica is replayed what it missed and its
+// sequence numbers carry on.
+type SessionHandoff struct {
+	SessionID string `json:"session_id"`
+	OwnerID   string `json:"owner_id"`
+	// Persisted records whether the session has an archive row.
+	Persisted bool `json:"persisted"`
+	// Seq is the sequence number of the last message sent.
+	Seq uint64 `json:"seq"`
+	// Messages are the session's most recent messages the client has not
+	// acknowledged, oldest first.
+	Messages []*ServerMessage `json:"messages"`
+	// ClientSeq is the seq of the last client message accepted, so resends
+	// are still recognized on the adopting replica.
+	ClientSeq uint64 `json:"client_seq,omitempty"`
+}
+
+// HandoffStore keeps the replay state of sessions a draining pod handed off
+// until a replica adopts them. Like RouteStore it is shared by the agent's
+// replicas and best-effort: a lost handoff costs only the replay, since the
+// conversation itself lives in the context store.
+type HandoffStore interface {
+	PutHandoff(ctx context.Context, h *SessionHandoff, ttl time.Duration) error
+	// GetHandoff returns nil and no error when nothing was handed off for
+	// sessionID.
+	GetHandoff(ctx context.Context, sessionID string) (*SessionHandoff, error)
+	DeleteHandoff(ctx context.Context, sessionID string) error
+}
+
+// noopHandoffStore is the default when no handoff store is wired: sessions
+// are not handed off and a resume on another replica starts without replay.
+type noopHandoffStore struct{}
+
+func (noopHandoffStore) PutHandoff(context.Context, *SessionHandoff, time.Duration) error {
+	return nil
+}
+
+func (noopHandoffStore) GetHandoff(context.Context, string) (*SessionHandoff, error) {
+	return nil, nil
+}
+
+func (noopHandoffStore) DeleteHandoff(context.Context, string) error { return nil }
+
+// handoffEnabled reports whether a shared handoff store is wired.
+func (r *replayRegistry) handoffEnabled() bool {
+	if !r.enabled() || r.handoffs == nil {
+		return false
+	}
+	_, noop := r.handoffs.(noopHandoffStore)
+	return !noop
+}
+
+// handoff writes rl's replay state to the handoff store for the grace window
+// and removes this pod's route hint, so a resume lands on a peer that can
+// replay it. The session continues elsewhere, so this pod no longer completes
+// it when the log expires.
+func (r *replayRegistry) handoff(ctx context.Context, rl *replayLog) {
+	if !r.handoffEnabled() {
+		return
+	}
+	rl.mu.Lock()
+	h := &SessionHandoff{
+		SessionID: rl.sessionID,
+		OwnerID:   rl.ownerID,
+		Persisted: rl.persisted,
+		Seq:       rl.seq,
+		Messages:  slices.Clone(rl.messages),
+		ClientSeq: rl.clientSeq,
+	}
+	rl.complete = false
+	rl.mu.Unlock()
+
+	if err := r.handoffs.PutHandoff(ctx, h, r.grace); err != nil {
+		r.log.Error(err, "session handoff write failed", "sessionID", rl.sessionID)
+		return
+	}
+	if err := r.routes.DeleteRoute(ctx, rl.sessionID); err != nil {
+		r.log.Error(err, "replay route hint delete failed", "sessionID", rl.sessionID)
+	}
+	r.log.V(1).Info("session handed off", "sessionID", rl.sessionID, "seq", h.Seq)
+}
+
+// adopt registers the replay state a draining peer handed off for sessionID
+// as a log of this pod, ready for resume to attach. It does nothing when the
+// session already has a log here, nothing was handed off, or the handoff
+// belongs to another user.
+func (r *replayRegistry) adopt(ctx context.Context, sessionID, userID string) {
+	if !r.handoffEnabled() {
+		return
+	}
+	r.mu.Lock()
+	_, local := r.logs[sessionID]
+	r.mu.Unlock()
+	if local {
+		return
+	}
+
+	h, err := r.handoffs.GetHandoff(ctx, sessionID)
+	if err != nil {
+		r.log.Error(err, "session handoff read failed", "sessionID", sessionID)
+		return
+	}
+	if h == nil || h.OwnerID != userID {
+		return
+	}
+	if err := r.handoffs.DeleteHandoff(ctx, sessionID); err != nil {
+		r.log.Error(err, "session handoff delete failed", "sessionID", sessionID)
+	}
+
+	messages := h.Messages
+	if over := len(messages) - r.size; over > 0 {
+		messages = messages[over:]
+	}
+	r.mu.Lock()
+	if _, ok := r.logs[sessionID]; !ok {
+		r.logs[sessionID] = &replayLog{
+			sessionID: sessionID,
+			ownerID:   h.OwnerID,
+			size:      r.size,
+			seq:       h.Seq,
+			messages:  messages,
+			persisted: h.Persisted,
+			clientSeq: h.ClientSeq,
+		}
+	}
+	r.mu.Unlock()
+	r.log.V(1).Info("session handoff adopted", "sessionID", sessionID, "seq", h.Seq)
+}
+
+// snapshot returns the replay logs currently registered.
+func (r *replayRegistry) snapshot() []*replayLog {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	logs := make([]*replayLog, 0, len(r.logs))
+	for _, rl := range r.logs {
+		logs = append(logs, rl)
+	}
+	return logs
+}
+
+// sendReconnectHints tells every connected client that the server is
+// draining, when to reconnect, and where. The hint is not logged for replay:
+// it describes this pod, not the session.
+func (s *Server) sendReconnectHints() {
+	info := &ReconnectInfo{
+		Reason:       reconnectReasonDraining,
+		RetryAfterMs: s.config.ReconnectRetryAfter.Milliseconds(),
+		Endpoint:     s.config.ReconnectEndpoint,
+	}
+	s.mu.RLock()
+	connections := make([]*Connection, 0, len(s.connections))
+	for _, c := range s.connections {
+		connections = append(connections, c)
+	}
+	s.mu.RUnlock()
+
+	for _, c := range connections {
+		if err := s.writeMessage(c, NewReconnectMessage(c.SessionID(), info)); err != nil {
+			s.log.V(1).Info("failed to send reconnect hint", "sessionID", c.SessionID(), "error", err.Error())
+		}
+	}
+}
+
+// handOffSessions runs when draining ends. Turns still open are ended with
+// SERVER_SHUTDOWN so their clients stop waiting for a done, and every
+// session's replay state is handed to the store for the replica the client
+// resumes on.
+func (s *Server) handOffSessions(ctx context.Context) {
+	for _, rl := range s.replays.snapshot() {
+		rl.mu.Lock()
+		open := rl.turnOpen
+		rl.mu.Unlock()
+		if open {
+			msg := NewErrorMessage(rl.sessionID, ErrorCodeServerShutdown, "server shut down before the turn completed")
+			if err := rl.send(s, msg); err != nil {
+				s.log.V(1).Info("failed to send shutdown error", "sessionID", rl.sessionID, "error", err.Error())
+			}
+		}
+		s.replays.handoff(ctx, rl)
+	}
+}
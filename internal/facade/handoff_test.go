@@ -0,0 +1,206 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/internal/session/sessiontest"
+)
+
+// memHandoffStore is a HandoffStore shared by test servers standing in for
+// an agent's replicas.
+type memHandoffStore struct {
+	mu       sync.Mutex
+	handoffs map[string]*SessionHandoff
+}
+
+func newMemHandoffStore() *memHandoffStore {
+	return &memHandoffStore{handoffs: make(map[string]*SessionHandoff)}
+}
+
+func (m *memHandoffStore) PutHandoff(_ context.Context, h *SessionHandoff, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handoffs[h.SessionID] = h
+	return nil
+}
+
+func (m *memHandoffStore) GetHandoff(_ context.Context, sessionID string) (*SessionHandoff, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.handoffs[sessionID], nil
+}
+
+func (m *memHandoffStore) DeleteHandoff(_ context.Context, sessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.handoffs, sessionID)
+	return nil
+}
+
+func newHandoffServer(t *testing.T, handler MessageHandler, handoffs HandoffStore, cfg ServerConfig) (*Server, *httptest.Server) {
+	t.Helper()
+	store := sessiontest.NewStore()
+	server := NewServer(cfg, store, handler, logr.Discard(),
+		WithGraceWindow(5*time.Second), WithHandoffStore(handoffs))
+	ts := httptest.NewServer(server)
+	t.Cleanup(func() {
+		ts.Close()
+		_ = store.Close()
+	})
+	return server, ts
+}
+
+func drainAsync(server *Server) <-chan int {
+	left := make(chan int, 1)
+	go func() { left <- server.Drain(context.Background()) }()
+	return left
+}
+
+func TestDrain_SendsReconnectHint(t *testing.T) {
+	cfg := DefaultServerConfig()
+	cfg.ReconnectEndpoint = "wss://agent.example.com/ws"
+	server, ts := newHandoffServer(t, &mockHandler{}, newMemHandoffStore(), cfg)
+
+	ws := dialReplay(t, ts, "&protocol=1&features=reconnect")
+	sessionID := readConnected(t, ws)
+	left := drainAsync(server)
+
+	msg := readServerMessage(t, ws)
+	if msg.Type != MessageTypeReconnect || msg.SessionID != sessionID || msg.Reconnect == nil {
+		t.Fatalf("message = %+v, want a reconnect hint for %s", msg, sessionID)
+	}
+	want := ReconnectInfo{Reason: reconnectReasonDraining, RetryAfterMs: 1000, Endpoint: cfg.ReconnectEndpoint}
+	if *msg.Reconnect != want {
+		t.Errorf("reconnect = %+v, want %+v", *msg.Reconnect, want)
+	}
+	if n := <-left; n != 0 {
+		t.Errorf("Drain left %d, want 0", n)
+	}
+}
+
+func TestDrain_WaitsForTurnInFlight(t *testing.T) {
+	handler := newGatedHandler()
+	cfg := DefaultServerConfig()
+	cfg.DrainTimeout = 5 * time.Second
+	server, ts := newHandoffServer(t, handler, newMemHandoffStore(), cfg)
+
+	ws, _ := startTurn(t, ts)
+	left := drainAsync(server)
+	select {
+	case <-left:
+		t.Fatal("Drain returned with a turn in flight")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	close(handler.release)
+	if msg := readServerMessage(t, ws); msg.Content != "two" {
+		t.Errorf("message = %+v, want chunk \"two\"", msg)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeDone {
+		t.Errorf("message = %+v, want done", msg)
+	}
+	if n := <-left; n != 0 {
+		t.Errorf("Drain left %d, want 0", n)
+	}
+}
+
+func TestDrain_HandsOffSessionForResumeOnAnotherReplica(t *testing.T) {
+	handoffs := newMemHandoffStore()
+	handler := newGatedHandler()
+	close(handler.release)
+	cfg := DefaultServerConfig()
+	draining, tsA := newHandoffServer(t, handler, handoffs, cfg)
+	_, tsB := newHandoffServer(t, &mockHandler{}, handoffs, cfg)
+
+	ws, sessionID := startTurn(t, tsA)
+	if msg := readServerMessage(t, ws); msg.Content != "two" || msg.Seq != 2 {
+		t.Fatalf("message = %+v, want chunk \"two\" with seq 2", msg)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeDone || msg.Seq != 3 {
+		t.Fatalf("message = %+v, want done with seq 3", msg)
+	}
+	waitFor(t, func() bool { return draining.turnsInFlight.Load() == 0 })
+	if n := draining.Drain(context.Background()); n != 0 {
+		t.Fatalf("Drain left %d, want 0", n)
+	}
+
+	// The client missed everything after the first chunk and resumes on
+	// the other replica.
+	ws = dialReplay(t, tsB, resumeQuery(sessionID, 1))
+	if connected := readServerMessage(t, ws); !connected.Connected.Resumed || connected.SessionID != sessionID {
+		t.Fatalf("connected = %+v, want the resumed session %s", connected, sessionID)
+	}
+	if msg := readServerMessage(t, ws); msg.Content != "two" || msg.Seq != 2 {
+		t.Errorf("replayed = %+v, want chunk \"two\" with seq 2", msg)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeDone || msg.Seq != 3 {
+		t.Errorf("replayed = %+v, want done with seq 3", msg)
+	}
+	if h, _ := handoffs.GetHandoff(context.Background(), sessionID); h != nil {
+		t.Error("handoff not removed once adopted")
+	}
+}
+
+func TestDrain_DeadlineEndsOpenTurns(t *testing.T) {
+	handoffs := newMemHandoffStore()
+	handler := newGatedHandler()
+	t.Cleanup(func() { close(handler.release) })
+	cfg := DefaultServerConfig()
+	cfg.DrainTimeout = 100 * time.Millisecond
+	server, ts := newHandoffServer(t, handler, handoffs, cfg)
+
+	ws, sessionID := startTurn(t, ts)
+	if n := server.Drain(context.Background()); n != 1 {
+		t.Fatalf("Drain left %d, want the open turn", n)
+	}
+
+	msg := readServerMessage(t, ws)
+	if msg.Type != MessageTypeError || msg.Error == nil || msg.Error.Code != ErrorCodeServerShutdown || msg.Seq != 2 {
+		t.Fatalf("message = %+v, want SERVER_SHUTDOWN with seq 2", msg)
+	}
+	h, _ := handoffs.GetHandoff(context.Background(), sessionID)
+	if h == nil || h.Seq != 2 || len(h.Messages) != 2 {
+		t.Fatalf("handoff = %+v, want both messages up to seq 2", h)
+	}
+}
+
+func TestResume_IgnoresAnotherUsersHandoff(t *testing.T) {
+	handoffs := newMemHandoffStore()
+	_ = handoffs.PutHandoff(context.Background(), &SessionHandoff{
+		SessionID: "sess-1",
+		OwnerID:   "someone-else",
+		Seq:       1,
+		Messages:  []*ServerMessage{{Type: MessageTypeDone, SessionID: "sess-1", Seq: 1}},
+	}, time.Minute)
+	_, ts := newHandoffServer(t, &mockHandler{}, handoffs, DefaultServerConfig())
+
+	ws := dialReplay(t, ts, resumeQuery("sess-1", 0))
+	if connected := readServerMessage(t, ws); connected.Connected.Resumed {
+		t.Fatalf("connected = %+v, want a fresh session", connected)
+	}
+	if h, _ := handoffs.GetHandoff(context.Background(), "sess-1"); h == nil {
+		t.Error("another user's handoff was consumed")
+	}
+}
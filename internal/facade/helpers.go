@@ -18,33 +18,87 @@ package facade
 
 import (
 	"time"
+
+	"github.com/gorilla/websocket"
 )
 
-// sendMessage sends a server message to a connection.
+// sendMessage sends a server message to a connection. A message of the
+// connection's logged session goes through its replay log, which stamps its
+// sequence number and delivers it to whichever connection now holds the
+// session.
 func (s *Server) sendMessage(c *Connection, msg *ServerMessage) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	if rl := c.replayLogFor(msg); rl != nil {
+		return rl.send(s, msg)
+	}
+	return s.writeMessage(c, msg)
+}
 
+// writeMessage writes a server message to the connection, adapted to the
+// protocol features the client negotiated. With a send queue the message is
+// queued for the write loop; otherwise it is written here. A message for a
+// client on another replica is published to the relay, which adapts it there.
+// A message larger than MaxFragmentSize goes to a client that negotiated
+// fragments as several fragment messages.
+func (s *Server) writeMessage(c *Connection, msg *ServerMessage) error {
+	c.mu.Lock()
+	if !c.closed && c.peer != nil {
+		c.mu.Unlock()
+		return s.writeRelayed(c, msg)
+	}
 	if c.closed || c.conn == nil {
+		c.mu.Unlock()
 		return nil
 	}
-
-	if err := c.conn.SetWriteDeadline(time.Now().Add(s.config.WriteTimeout)); err != nil {
-		return err
+	msg, ok := c.degrade(msg)
+	if !ok {
+		c.mu.Unlock()
+		return nil
 	}
+	msg = c.withCorrelationID(msg)
+	split := c.features[FeatureFragments]
+	if q := c.outbound; q != nil {
+		// Queue outside c.mu: a full queue may wait for the write loop.
+		c.mu.Unlock()
+		frames, err := s.encodeFrames(msg, split)
+		if err != nil {
+			return err
+		}
+		// Shedding one fragment would lose the whole message, so only a
+		// message sent in one frame may be dropped.
+		droppable := len(frames) == 1 && droppableMessage(msg)
+		for _, data := range frames {
+			if err := s.enqueue(c, q, outboundFrame{
+				messageType: websocket.TextMessage,
+				data:        data,
+				droppable:   droppable,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	defer c.mu.Unlock()
 
-	if err := c.conn.WriteJSON(msg); err != nil {
+	frames, err := s.encodeFrames(msg, split)
+	if err != nil {
 		return err
 	}
+	for _, data := range frames {
+		if err := c.conn.SetWriteDeadline(time.Now().Add(s.config.WriteTimeout)); err != nil {
+			return err
+		}
+		s.compressNext(c, websocket.TextMessage, len(data))
+		if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			return err
+		}
 
-	// Clear the deadline so idle connections aren't killed
-	if err := c.conn.SetWriteDeadline(time.Time{}); err != nil {
-		return err
+		// Record message sent
+		s.metrics.MessageSent()
+		c.sent.Add(1)
 	}
 
-	// Record message sent
-	s.metrics.MessageSent()
-	return nil
+	// Clear the deadline so idle connections aren't killed
+	return c.conn.SetWriteDeadline(time.Time{})
 }
 
 // sendError sends an error message to a connection.
@@ -54,13 +108,50 @@ func (s *Server) sendError(c *Connection, sessionID, code, message string) {
 	}
 }
 
+// withCorrelationID returns msg with its error's correlation ID set to the
+// connection's request ID. The message is copied: it may be shared with the
+// session's replay log and other participants.
+func (c *Connection) withCorrelationID(msg *ServerMessage) *ServerMessage {
+	if msg.Error == nil || msg.Error.CorrelationID != "" || c.requestID == "" {
+		return msg
+	}
+	info := *msg.Error
+	info.CorrelationID = c.requestID
+	stamped := *msg
+	stamped.Error = &info
+	return &stamped
+}
+
 // sendConnected sends a connected message to a connection.
 func (s *Server) sendConnected(c *Connection, sessionID string, resumed bool) error {
 	// Always send capabilities so clients know the max payload size
 	// for deciding when to use the upload mechanism
-	return s.sendMessage(c, NewConnectedMessageResumed(sessionID, &ConnectionCapabilities{
-		BinaryFrames:    c.binaryCapable,
-		MaxPayloadSize:  int(s.config.MaxMessageSize),
-		ProtocolVersion: BinaryVersion,
-	}, resumed))
+	caps := &ConnectionCapabilities{
+		BinaryFrames:      c.binaryCapable,
+		MaxPayloadSize:    int(s.config.MaxMessageSize),
+		ProtocolVersion:   BinaryVersion,
+		MaxFragmentedSize: s.config.MaxFragmentedSize,
+	}
+	// Advertise the voice format only when this facade can open a duplex
+	// stream; otherwise inbound audio is rejected with MEDIA_NOT_ENABLED.
+	if s.duplexSinkFactory != nil {
+		caps.Audio = s.audioFormat()
+	}
+	msg := NewConnectedMessageResumed(sessionID, caps, resumed)
+	msg.Connected.Protocol = c.protocol
+	if resumed {
+		c.mu.Lock()
+		msg.Connected.ClientSeq = c.clientSeq
+		c.mu.Unlock()
+	}
+	return s.sendMessage(c, msg)
+}
+
+// sendTyping tells the client the agent has started on a turn. It is
+// ephemeral: not logged for replay, since a resumed client learns the turn's
+// state from the messages that follow.
+func (s *Server) sendTyping(c *Connection, sessionID string) {
+	if err := s.writeMessage(c, NewTypingMessage(sessionID)); err != nil {
+		s.log.V(1).Info("failed to send typing message", "error", err.Error())
+	}
 }
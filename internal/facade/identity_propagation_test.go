@@ -38,7 +38,7 @@ func buildOutboundMetadata(
 	t.Helper()
 	server, _ := newTestServer(t, nil)
 	r := httptest.NewRequest(http.MethodGet, "/agent?agent=agent-1", nil)
-	ctx := server.buildConnectionContext(r, agentCtx, requestUserContext{userID: "user-1"}, authIdentity)
+	ctx := server.buildConnectionContext(r, "req-1", agentCtx, requestUserContext{userID: "user-1"}, authIdentity)
 	return policy.ToGRPCMetadata(ctx)
 }
 
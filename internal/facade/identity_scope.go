@@ -34,3 +34,13 @@ func identityScope(id *policy.AuthenticatedIdentity, agentWorkspace string) (ori
 	}
 	return origin, workspace
 }
+
+// authWorkspace returns the workspace the auth chain admitted the caller to,
+// or "" when no validator admitted it or the validator carries no workspace
+// scope. Unlike the ?workspace= parameter it is not chosen by the client.
+func authWorkspace(id *policy.AuthenticatedIdentity) string {
+	if id == nil {
+		return ""
+	}
+	return id.Workspace
+}
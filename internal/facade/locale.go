@@ -0,0 +1,66 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"errors"
+	"net/http"
+
+	"golang.org/x/text/language"
+)
+
+// MetadataKeyLocale is the client message metadata key carrying the user's
+// locale to the runtime, which exposes it to the prompt as {{locale}}.
+const MetadataKeyLocale = "locale"
+
+// errInvalidLocale is returned for a ?locale= that is not a BCP 47 tag.
+var errInvalidLocale = errors.New("locale parameter must be a BCP 47 language tag")
+
+// resolveLocale returns the locale a connection's user writes in, as a
+// canonical BCP 47 tag: ?locale= when given, otherwise the preferred language
+// of the Accept-Language header. It returns "" when neither is present. An
+// unparsable Accept-Language is ignored, since browsers send it unasked.
+func resolveLocale(r *http.Request) (string, error) {
+	if v := r.URL.Query().Get("locale"); v != "" {
+		tag, err := language.Parse(v)
+		if err != nil {
+			return "", errInvalidLocale
+		}
+		return tag.String(), nil
+	}
+	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil || len(tags) == 0 {
+		return "", nil
+	}
+	// "*" parses as "mul" (multiple languages); like "und" it names none.
+	if locale := tags[0].String(); locale != "und" && locale != "mul" {
+		return locale, nil
+	}
+	return "", nil
+}
+
+// applyLocale stamps the connection's locale on msg, unless the client set
+// one on the message itself.
+func applyLocale(c *Connection, msg *ClientMessage) {
+	if c.locale == "" || msg.Metadata[MetadataKeyLocale] != "" {
+		return
+	}
+	if msg.Metadata == nil {
+		msg.Metadata = make(map[string]string, 1)
+	}
+	msg.Metadata[MetadataKeyLocale] = c.locale
+}
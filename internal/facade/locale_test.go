@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveLocale(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		acceptLanguage string
+		want           string
+		wantErr        bool
+	}{
+		{name: "query parameter", query: "?locale=pt-BR", want: "pt-BR"},
+		{name: "query is canonicalized", query: "?locale=en_us", want: "en-US"},
+		{name: "query wins over header", query: "?locale=de", acceptLanguage: "fr-FR", want: "de"},
+		{name: "preferred Accept-Language", acceptLanguage: "fr-CA;q=0.8, es-MX, en;q=0.5", want: "es-MX"},
+		{name: "wildcard Accept-Language", acceptLanguage: "*", want: ""},
+		{name: "malformed Accept-Language is ignored", acceptLanguage: ";;;", want: ""},
+		{name: "neither", want: ""},
+		{name: "invalid query", query: "?locale=not%20a%20locale", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/ws"+tt.query, nil)
+			if tt.acceptLanguage != "" {
+				r.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			got, err := resolveLocale(r)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errInvalidLocale)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyLocale(t *testing.T) {
+	c := &Connection{locale: "es-MX"}
+
+	msg := &ClientMessage{}
+	applyLocale(c, msg)
+	assert.Equal(t, "es-MX", msg.Metadata[MetadataKeyLocale])
+
+	msg = &ClientMessage{Metadata: map[string]string{MetadataKeyLocale: "en-GB"}}
+	applyLocale(c, msg)
+	assert.Equal(t, "en-GB", msg.Metadata[MetadataKeyLocale], "a per-message locale wins")
+
+	msg = &ClientMessage{}
+	applyLocale(&Connection{}, msg)
+	assert.Nil(t, msg.Metadata)
+}
+
+func TestServerLocalePropagation(t *testing.T) {
+	locales := make(chan string, 1)
+	handler := &mockHandler{
+		handleFunc: func(_ context.Context, _ string, msg *ClientMessage, writer ResponseWriter) error {
+			locales <- msg.Metadata[MetadataKeyLocale]
+			return writer.WriteDone("ok")
+		},
+	}
+	_, ts := newTestServer(t, handler)
+
+	header := http.Header{"Accept-Language": []string{"ja-JP,ja;q=0.9"}}
+	ws, _, err := websocket.DefaultDialer.Dial(wsURL(ts.URL)+"?agent=test-agent", header)
+	require.NoError(t, err)
+	defer func() { _ = ws.Close() }()
+
+	sessionID := readConnected(t, ws)
+	require.NoError(t, ws.WriteJSON(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "こんにちは"}))
+	assert.Equal(t, "ja-JP", <-locales)
+}
+
+func TestServerRejectsInvalidLocale(t *testing.T) {
+	_, ts := newTestServer(t, &mockHandler{})
+
+	_, resp, err := websocket.DefaultDialer.Dial(wsURL(ts.URL)+"?agent=test-agent&locale=%25%25", nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -40,6 +40,7 @@ func (s *Server) readMessageLoop(ctx context.Context, c *Connection, log logr.Lo
 		}
 
 		s.metrics.MessageReceived()
+		c.received.Add(1)
 
 		// Rate limiting is split by plane (see admitMessage): binary media frames
 		// are bounded by bandwidth, text/control messages by message count.
@@ -79,14 +80,40 @@ func (s *Server) logCloseError(err error, log logr.Logger) {
 
 // handleClientMessage parses and processes a single client message.
 func (s *Server) handleClientMessage(ctx context.Context, c *Connection, message []byte, log logr.Logger) {
+	if up := c.relayUpstream(); up != nil {
+		s.forwardClientMessage(ctx, c, up, message)
+		return
+	}
+
 	var clientMsg ClientMessage
 	if err := json.Unmarshal(message, &clientMsg); err != nil {
 		log.Error(err, "failed to unmarshal message", "contentLength", logging.ContentLength(string(message)))
 		s.sendError(c, "", ErrorCodeInvalidMessage, "invalid message format")
 		return
 	}
+	if clientMsg.Type == MessageTypeFragment {
+		s.handleFragment(ctx, c, &clientMsg, log)
+		return
+	}
+	s.dispatchClientMessage(ctx, c, &clientMsg, log)
+}
+
+// dispatchClientMessage processes a decoded client message, whether it
+// arrived in one frame or as fragments.
+func (s *Server) dispatchClientMessage(ctx context.Context, c *Connection, clientMsg *ClientMessage, log logr.Logger) {
+	if clientMsg.Type == MessageTypeAck {
+		s.handleAck(c, clientMsg)
+		return
+	}
+	// A resent message is dropped before it reaches a handler. Turns are
+	// accepted once an in-flight slot is taken, so one shed by the limit
+	// can be resent.
+	turn := startsTurn(clientMsg.Type)
+	if !turn && !s.acceptClientMessage(c, clientMsg) {
+		return
+	}
 
-	if s.handleToolMessage(ctx, c, &clientMsg, log) {
+	if s.handleToolMessage(ctx, c, clientMsg, log) {
 		return
 	}
 
@@ -104,13 +131,18 @@ func (s *Server) handleClientMessage(ctx context.Context, c *Connection, message
 		s.sendError(c, c.sessionID, ErrorCodeRateLimited, "too many in-flight requests")
 		return
 	}
+	if turn && !s.acceptClientMessage(c, clientMsg) {
+		c.releaseInFlightMessage()
+		return
+	}
 
 	s.metrics.RequestStarted()
 
 	// Process the message asynchronously so the read loop can continue
 	// reading tool_result messages while HandleMessage blocks waiting
-	// for client tool responses.
-	go s.processAndRecordMessage(ctx, c, &clientMsg, log)
+	// for client tool responses. The turn runs on the connection's in-flight
+	// context so it can finish into the replay log if the client drops.
+	go s.processAndRecordMessage(c.inFlightContext(ctx), c, clientMsg, log)
 }
 
 // handleToolMessage routes tool-related messages (ACK, NACK, result) to the handler.
@@ -138,6 +170,17 @@ func (s *Server) handleToolMessage(ctx context.Context, c *Connection, clientMsg
 		return true
 	}
 
+	// Route step-through debugging decisions to a handler paused on the call
+	if clientMsg.Type == MessageTypeToolDebug && clientMsg.ToolDebug != nil {
+		if router, ok := s.handler.(ToolDebugRouter); ok {
+			if router.SendToolDebug(ctx, c.SessionID(), clientMsg.ToolDebug) {
+				return true
+			}
+		}
+		s.sendError(c, c.SessionID(), ErrorCodeInvalidMessage, "no paused tool call")
+		return true
+	}
+
 	// Route client-side tool results to the active handler
 	if clientMsg.Type == MessageTypeToolResult && clientMsg.ToolResult != nil {
 		sessionID := c.SessionID()
@@ -219,6 +262,11 @@ func (s *Server) processAndRecordMessage(ctx context.Context, c *Connection, msg
 
 // handleBinaryMessage decodes and processes a binary WebSocket frame.
 func (s *Server) handleBinaryMessage(ctx context.Context, c *Connection, data []byte, log logr.Logger) {
+	if c.relayUpstream() != nil {
+		s.sendError(c, c.SessionID(), ErrorCodeInvalidMessage, "binary frames are not relayed to the replica holding this session")
+		return
+	}
+
 	frame, err := DecodeBinaryFrame(data)
 	if err != nil {
 		log.Error(err, "failed to decode binary frame")
@@ -246,9 +294,14 @@ func (s *Server) handleBinaryMessage(ctx context.Context, c *Connection, data []
 }
 
 // audioParamsFromFrame parses negotiated audio params from a media-chunk frame,
-// falling back to defaults (pcm/16000/mono) when fields are absent or invalid.
-func audioParamsFromFrame(frame *BinaryFrame) *AudioSessionStart {
-	p := &AudioSessionStart{Codec: defaultAudioCodec, SampleRate: 16000, Channels: 1}
+// falling back to the advertised format (see Server.audioFormat) when fields
+// are absent or invalid.
+func audioParamsFromFrame(frame *BinaryFrame, format *SessionConfigInfo) *AudioSessionStart {
+	p := &AudioSessionStart{
+		Codec:      format.Codec,
+		SampleRate: int32(format.SampleRate), //nolint:gosec // sample rate and channels are small positive values
+		Channels:   int32(format.Channels),   //nolint:gosec // see above
+	}
 	if frame == nil || len(frame.Metadata) == 0 {
 		return p
 	}
@@ -363,7 +416,7 @@ func (s *Server) tryStartAudioSession(ctx context.Context, c *Connection, frame
 	sink := s.duplexSinkFactory(c.sessionID, w)
 	as := newAudioSession(c.sessionID, sink, w)
 
-	startParams := audioParamsFromFrame(frame)
+	startParams := audioParamsFromFrame(frame, s.audioFormat())
 	if err := as.start(ctx, startParams); err != nil {
 		log.Error(err, "audio session start failed", "sessionID", c.sessionID)
 		s.sendError(c, c.sessionID, ErrorCodeInvalidMessage, "audio session start failed: "+err.Error())
@@ -394,15 +447,28 @@ func (s *Server) tryStartAudioSession(ctx context.Context, c *Connection, frame
 	return as
 }
 
-// sendBinaryFrame sends a binary WebSocket frame to the connection.
-// Uses a pooled buffer for encoding to reduce GC pressure on the streaming path.
+// sendBinaryFrame sends a binary WebSocket frame to the connection. A direct
+// write encodes into a pooled buffer to reduce GC pressure on the streaming
+// path; a queued frame needs its own copy until the write loop sends it.
 func (s *Server) sendBinaryFrame(c *Connection, frame *BinaryFrame) error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
 	if c.closed || c.conn == nil {
+		c.mu.Unlock()
 		return nil
 	}
+	if q := c.outbound; q != nil {
+		c.mu.Unlock()
+		data, err := frame.Encode()
+		if err != nil {
+			return err
+		}
+		return s.enqueue(c, q, outboundFrame{
+			messageType: websocket.BinaryMessage,
+			data:        data,
+			droppable:   frame.Header.MessageType == BinaryMessageTypeMediaChunk,
+		})
+	}
+	defer c.mu.Unlock()
 
 	bp, err := frame.EncodePooled()
 	if err != nil {
@@ -414,6 +480,7 @@ func (s *Server) sendBinaryFrame(c *Connection, frame *BinaryFrame) error {
 		return err
 	}
 
+	s.compressNext(c, websocket.BinaryMessage, len(*bp))
 	if err := c.conn.WriteMessage(websocket.BinaryMessage, *bp); err != nil {
 		return err
 	}
@@ -424,5 +491,6 @@ func (s *Server) sendBinaryFrame(c *Connection, frame *BinaryFrame) error {
 	}
 
 	s.metrics.MessageSent()
+	c.sent.Add(1)
 	return nil
 }
@@ -27,10 +27,12 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-// mockToolRouter implements both MessageHandler and ClientToolRouter for testing.
+// mockToolRouter implements MessageHandler, ClientToolRouter and
+// ToolDebugRouter for testing.
 type mockToolRouter struct {
 	ackCalls    []ackCall
 	resultCalls []resultCall
+	debugCalls  []*ToolDebugInfo
 }
 
 type ackCall struct {
@@ -58,6 +60,11 @@ func (m *mockToolRouter) SendToolResult(sessionID string, result *ClientToolResu
 	return true
 }
 
+func (m *mockToolRouter) SendToolDebug(_ context.Context, _ string, cmd *ToolDebugInfo) bool {
+	m.debugCalls = append(m.debugCalls, cmd)
+	return true
+}
+
 func TestLogCloseError(t *testing.T) {
 	s := &Server{log: logr.Discard()}
 	log := logr.Discard()
@@ -126,21 +133,56 @@ func TestHandleClientMessage_ToolCallNackConvertedToResult(t *testing.T) {
 	assert.Equal(t, "tool not supported", router.resultCalls[0].result.Error)
 }
 
+func TestHandleClientMessage_ToolDebugRouted(t *testing.T) {
+	router := &mockToolRouter{}
+	s := &Server{
+		config:  DefaultServerConfig(),
+		handler: router,
+		metrics: &NoOpMetrics{},
+		log:     logr.Discard(),
+	}
+	c := &Connection{sessionID: "sess-3"}
+
+	msg := ClientMessage{
+		Type: MessageTypeToolDebug,
+		ToolDebug: &ToolDebugInfo{
+			CallID:    "call-7",
+			Action:    ToolDebugActionEdit,
+			Arguments: map[string]interface{}{"city": "Paris"},
+		},
+	}
+	data, _ := json.Marshal(msg)
+	s.handleClientMessage(context.Background(), c, data, logr.Discard())
+
+	assert.Len(t, router.debugCalls, 1)
+	assert.Equal(t, "call-7", router.debugCalls[0].CallID)
+	assert.Equal(t, ToolDebugActionEdit, router.debugCalls[0].Action)
+	assert.Equal(t, "Paris", router.debugCalls[0].Arguments["city"])
+}
+
 func TestAudioParamsFromFrame_HonorsNegotiation(t *testing.T) {
 	meta, _ := json.Marshal(BinaryMediaChunkMetadata{SampleRate: 24000, Channels: 1, Codec: "pcm"})
-	got := audioParamsFromFrame(&BinaryFrame{Metadata: meta})
+	got := audioParamsFromFrame(&BinaryFrame{Metadata: meta}, defaultAudioFormat())
 	if got.SampleRate != 24000 || got.Channels != 1 || got.Codec != "pcm" {
 		t.Fatalf("got %+v", got)
 	}
 }
 
 func TestAudioParamsFromFrame_Defaults(t *testing.T) {
-	got := audioParamsFromFrame(&BinaryFrame{})
+	got := audioParamsFromFrame(&BinaryFrame{}, defaultAudioFormat())
 	if got.SampleRate != 16000 || got.Channels != 1 || got.Codec != defaultAudioCodec {
 		t.Fatalf("got %+v", got)
 	}
 }
 
+func TestAudioParamsFromFrame_FallsBackToAdvertisedFormat(t *testing.T) {
+	s := &Server{audioFormatOverride: &SessionConfigInfo{Codec: "pcm16", SampleRate: 24000}}
+	got := audioParamsFromFrame(&BinaryFrame{}, s.audioFormat())
+	if got.Codec != "pcm16" || got.SampleRate != 24000 || got.Channels != 1 {
+		t.Fatalf("got %+v, want the agent's format with the default channel count", got)
+	}
+}
+
 func TestSendBinaryFrame_ClosedConnection(t *testing.T) {
 	s := &Server{
 		config:  DefaultServerConfig(),
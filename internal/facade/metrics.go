@@ -76,6 +76,38 @@ type ServerMetrics interface {
 	// per-connection message-count rate limiter.
 	ControlMessageRateLimited()
 
+	// Outbound backpressure metrics
+
+	// SendQueueBytesChanged records a change in the bytes queued for clients.
+	SendQueueBytesChanged(delta int)
+	// OutboundMessageDropped records a message shed from a full send queue
+	// under SlowConsumerPolicyDrop.
+	OutboundMessageDropped()
+	// SlowConsumerDisconnected records a connection closed because its
+	// client stopped reading and its send queue stayed full.
+	SlowConsumerDisconnected()
+	// MessageFragmented records a message too large for one frame, sent to
+	// a client split into the given number of fragment messages.
+	MessageFragmented(fragments int)
+
+	// Delivery metrics
+
+	// MessagesRedelivered records server messages replayed to a client that
+	// resumed its session.
+	MessagesRedelivered(n int)
+	// DuplicateClientMessage records a resent client message dropped
+	// because its seq was already accepted.
+	DuplicateClientMessage()
+
+	// Admin API metrics
+
+	// AdminSessionTerminated records a session an operator terminated
+	// through the admin API.
+	AdminSessionTerminated()
+	// AdminNoticeSent records an operator notice delivered to recipients
+	// connections.
+	AdminNoticeSent(recipients int)
+
 	// Realtime blip-resume metrics
 
 	// RealtimeSessionParked records that a realtime session was parked after
@@ -172,6 +204,30 @@ func (n *NoOpMetrics) MediaFrameRateLimited() { /* no-op: null object pattern */
 // ControlMessageRateLimited is a no-op - metrics are disabled.
 func (n *NoOpMetrics) ControlMessageRateLimited() { /* no-op: null object pattern */ }
 
+// SendQueueBytesChanged is a no-op - metrics are disabled.
+func (n *NoOpMetrics) SendQueueBytesChanged(int) { /* no-op: null object pattern */ }
+
+// OutboundMessageDropped is a no-op - metrics are disabled.
+func (n *NoOpMetrics) OutboundMessageDropped() { /* no-op: null object pattern */ }
+
+// SlowConsumerDisconnected is a no-op - metrics are disabled.
+func (n *NoOpMetrics) SlowConsumerDisconnected() { /* no-op: null object pattern */ }
+
+// MessageFragmented is a no-op - metrics are disabled.
+func (n *NoOpMetrics) MessageFragmented(int) { /* no-op: null object pattern */ }
+
+// MessagesRedelivered is a no-op - metrics are disabled.
+func (n *NoOpMetrics) MessagesRedelivered(int) { /* no-op: null object pattern */ }
+
+// DuplicateClientMessage is a no-op - metrics are disabled.
+func (n *NoOpMetrics) DuplicateClientMessage() { /* no-op: null object pattern */ }
+
+// AdminSessionTerminated is a no-op - metrics are disabled.
+func (n *NoOpMetrics) AdminSessionTerminated() { /* no-op: null object pattern */ }
+
+// AdminNoticeSent is a no-op - metrics are disabled.
+func (n *NoOpMetrics) AdminNoticeSent(int) { /* no-op: null object pattern */ }
+
 // RealtimeSessionParked is a no-op - metrics are disabled.
 func (n *NoOpMetrics) RealtimeSessionParked() { /* no-op: null object pattern */ }
 
@@ -0,0 +1,75 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import "context"
+
+// HandleFunc is the signature of MessageHandler.HandleMessage.
+type HandleFunc func(ctx context.Context, sessionID string, msg *ClientMessage, writer ResponseWriter) error
+
+// Middleware wraps a MessageHandler to run cross-cutting logic — logging,
+// context injection, content scrubbing, metrics, policy checks — around
+// every turn, whatever the handler. A middleware may change the context,
+// message or writer it passes on, or answer the turn itself without calling
+// next.
+//
+// Only HandleMessage goes through middleware. The facade keeps detecting
+// optional interfaces (ClientToolRouter, ConnectionObserver, ...) and
+// reading Name on the handler passed to NewServer, so a middleware need not
+// forward them.
+type Middleware func(next MessageHandler) MessageHandler
+
+// MiddlewareFunc returns a Middleware from a function that wraps the next
+// handler's HandleMessage. The wrapped handler keeps next's name.
+func MiddlewareFunc(fn func(next HandleFunc) HandleFunc) Middleware {
+	return func(next MessageHandler) MessageHandler {
+		return &middlewareHandler{next: next, handle: fn(next.HandleMessage)}
+	}
+}
+
+// middlewareHandler is the MessageHandler MiddlewareFunc returns.
+type middlewareHandler struct {
+	next   MessageHandler
+	handle HandleFunc
+}
+
+func (h *middlewareHandler) Name() string { return h.next.Name() }
+
+func (h *middlewareHandler) HandleMessage(ctx context.Context, sessionID string, msg *ClientMessage, writer ResponseWriter) error {
+	return h.handle(ctx, sessionID, msg, writer)
+}
+
+// WithMiddleware adds middleware around the server's handler. Middleware
+// runs in the order added, across calls: the first added sees each turn
+// first.
+func WithMiddleware(mw ...Middleware) ServerOption {
+	return func(s *Server) {
+		s.middleware = append(s.middleware, mw...)
+	}
+}
+
+// chainMiddleware wraps handler in mw so that mw[0] is outermost. It returns
+// nil for a nil handler: there is no turn to wrap.
+func chainMiddleware(handler MessageHandler, mw []Middleware) MessageHandler {
+	if handler == nil {
+		return nil
+	}
+	for i := len(mw) - 1; i >= 0; i-- {
+		handler = mw[i](handler)
+	}
+	return handler
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session/sessiontest"
+)
+
+type middlewareKey struct{}
+
+// tagMiddleware records its tag in trace and appends it to the context value
+// the handler reads.
+func tagMiddleware(tag string, trace *[]string) Middleware {
+	return MiddlewareFunc(func(next HandleFunc) HandleFunc {
+		return func(ctx context.Context, sessionID string, msg *ClientMessage, w ResponseWriter) error {
+			*trace = append(*trace, tag)
+			prev, _ := ctx.Value(middlewareKey{}).(string)
+			return next(context.WithValue(ctx, middlewareKey{}, prev+tag), sessionID, msg, w)
+		}
+	})
+}
+
+// scrubMiddleware masks "secret" in the message the handler sees.
+var scrubMiddleware = MiddlewareFunc(func(next HandleFunc) HandleFunc {
+	return func(ctx context.Context, sessionID string, msg *ClientMessage, w ResponseWriter) error {
+		scrubbed := *msg
+		scrubbed.Content = strings.ReplaceAll(msg.Content, "secret", "***")
+		return next(ctx, sessionID, &scrubbed, w)
+	}
+})
+
+func newMiddlewareServer(t *testing.T, handler MessageHandler, mw ...Middleware) (*Server, *httptest.Server) {
+	t.Helper()
+	store := sessiontest.NewStore()
+	server := NewServer(DefaultServerConfig(), store, handler, logr.Discard(),
+		WithMiddleware(mw[:1]...), WithMiddleware(mw[1:]...))
+	mux := http.NewServeMux()
+	mux.Handle("/ws", server)
+	mux.Handle("/chat", server.ChatHandler())
+	ts := httptest.NewServer(mux)
+	t.Cleanup(func() {
+		ts.Close()
+		_ = store.Close()
+	})
+	return server, ts
+}
+
+func TestMiddleware_WrapsTurnsInOrder(t *testing.T) {
+	var trace []string
+	handler := &mockHandler{handleFunc: func(ctx context.Context, _ string, msg *ClientMessage, w ResponseWriter) error {
+		tags, _ := ctx.Value(middlewareKey{}).(string)
+		return w.WriteDone(tags + ":" + msg.Content)
+	}}
+	_, ts := newMiddlewareServer(t, handler,
+		tagMiddleware("a", &trace), tagMiddleware("b", &trace), scrubMiddleware)
+
+	out := decodeChatResponse(t, postChat(t, ts.URL+"/chat", `{"content":"my secret"}`, nil))
+	assert.Equal(t, "ab:my ***", out.Content)
+	assert.Equal(t, []string{"a", "b"}, trace)
+
+	// WebSocket turns go through the same chain.
+	ws, _, err := websocket.DefaultDialer.Dial(wsURL(ts.URL)+"/ws?agent=test-agent", nil)
+	require.NoError(t, err)
+	defer func() { _ = ws.Close() }()
+	sessionID := readConnected(t, ws)
+	require.NoError(t, ws.WriteJSON(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "secret"}))
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var msg ServerMessage
+		require.NoError(t, ws.ReadJSON(&msg))
+		if msg.Type == MessageTypeDone {
+			assert.Equal(t, "ab:***", msg.Content)
+			return
+		}
+	}
+}
+
+func TestMiddleware_CanAnswerTheTurn(t *testing.T) {
+	called := false
+	handler := &mockHandler{handleFunc: func(_ context.Context, _ string, _ *ClientMessage, w ResponseWriter) error {
+		called = true
+		return w.WriteDone("")
+	}}
+	deny := MiddlewareFunc(func(next HandleFunc) HandleFunc {
+		return func(ctx context.Context, sessionID string, msg *ClientMessage, w ResponseWriter) error {
+			if strings.Contains(msg.Content, "forbidden") {
+				return w.WriteError(ErrorCodePermissionDenied, "not allowed")
+			}
+			return next(ctx, sessionID, msg, w)
+		}
+	})
+	var trace []string
+	_, ts := newMiddlewareServer(t, handler, deny, tagMiddleware("x", &trace))
+
+	resp := postChat(t, ts.URL+"/chat", `{"content":"forbidden topic"}`, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	out := decodeChatResponse(t, resp)
+	require.NotNil(t, out.Error)
+	assert.Equal(t, ErrorCodePermissionDenied, out.Error.Code)
+	assert.False(t, called, "the handler must not run")
+	assert.Empty(t, trace, "middleware after the one answering must not run")
+}
+
+func TestMiddleware_KeepsHandlerInterfacesAndName(t *testing.T) {
+	handler := &observingHandler{}
+	var trace []string
+	server, _ := newMiddlewareServer(t, handler, tagMiddleware("a", &trace), scrubMiddleware)
+
+	_, ok := server.handler.(ConnectionObserver)
+	assert.True(t, ok, "interface checks see the handler passed to NewServer")
+	assert.Equal(t, "observing", server.chain.Name())
+	assert.Nil(t, chainMiddleware(nil, []Middleware{scrubMiddleware}))
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ProtocolVersion is the version of the JSON message schema this facade
+	// speaks. It is bumped only when an existing message changes shape;
+	// additive message types are gated by features instead.
+	ProtocolVersion = 1
+	// MinProtocolVersion is the oldest schema version this facade still
+	// serves. Clients asking for an older one are refused with 426.
+	MinProtocolVersion = 1
+)
+
+// Feature names an optional part of the WebSocket protocol that client and
+// server negotiate on connect.
+type Feature string
+
+const (
+	// FeatureStreaming delivers the reply as chunk messages. Without it the
+	// chunks are withheld and done carries the full reply.
+	FeatureStreaming Feature = "streaming"
+	// FeatureBinary allows binary OMNI frames, like ?binary=true.
+	FeatureBinary Feature = "binary"
+	// FeatureMedia delivers media_chunk messages and binary media frames.
+	FeatureMedia Feature = "media"
+	// FeatureVoice delivers the duplex voice events: session_config,
+	// interrupt, and the caller's transcribed speech as user-role chunks.
+	FeatureVoice Feature = "voice"
+	// FeatureResume means ?resume= reattaches a dropped session and messages
+	// carry the seq a client passes back as ?last_seq=.
+	FeatureResume Feature = "resume"
+	// FeaturePresence delivers presence messages for shared sessions.
+	FeaturePresence Feature = "presence"
+	// FeatureTyping delivers a typing message when the agent starts on a turn.
+	FeatureTyping Feature = "typing"
+	// FeatureReconnect delivers a reconnect hint when the server starts
+	// draining for shutdown.
+	FeatureReconnect Feature = "reconnect"
+	// FeatureAck delivers an ack for every client message that carries a
+	// seq. Client acks and the deduplication of resent messages work without
+	// it.
+	FeatureAck Feature = "ack"
+	// FeatureNotice delivers the notices operators broadcast through the
+	// admin API.
+	FeatureNotice Feature = "notice"
+	// FeatureProgress delivers progress messages while a turn runs.
+	FeatureProgress Feature = "progress"
+	// FeatureFragments splits messages larger than the server's fragment
+	// size into fragment messages the client reassembles. Without it such
+	// messages are sent whole.
+	FeatureFragments Feature = "fragments"
+)
+
+// legacyFeatures are the features a client that does not negotiate gets: the
+// protocol as it was before negotiation existed. Features added since are
+// opt-in, so older clients never see message types they do not know.
+var legacyFeatures = []Feature{
+	FeatureStreaming, FeatureMedia, FeatureVoice, FeatureResume, FeaturePresence,
+}
+
+// errProtocolTooOld is returned for a client that asks for a schema version
+// older than MinProtocolVersion.
+var errProtocolTooOld = errors.New("protocol version is no longer supported")
+
+// negotiation is the outcome of the connect-time protocol handshake.
+type negotiation struct {
+	version int
+	// requested are the features the client understands. Messages are
+	// withheld by these: a client that asked for a feature the server does
+	// not offer simply never receives its messages.
+	requested map[Feature]bool
+	// supported are the features this server offers.
+	supported map[Feature]bool
+}
+
+// supportedFeatures returns the features this server can serve. Features that
+// depend on configuration are offered only when that configuration is present.
+func (s *Server) supportedFeatures() map[Feature]bool {
+	features := map[Feature]bool{
+		FeatureStreaming: true,
+		FeatureBinary:    true,
+		FeatureMedia:     true,
+		FeatureTyping:    true,
+		FeatureReconnect: true,
+		FeatureNotice:    true,
+		FeatureProgress:  true,
+	}
+	if s.config.MaxFragmentSize > 0 {
+		features[FeatureFragments] = true
+	}
+	if s.duplexSinkFactory != nil {
+		features[FeatureVoice] = true
+		features[FeatureResume] = true
+	}
+	if s.replays.enabled() {
+		features[FeatureResume] = true
+		features[FeatureAck] = true
+	}
+	if _, ok := s.handler.(ConnectionObserver); ok {
+		features[FeaturePresence] = true
+	}
+	return features
+}
+
+// negotiateProtocol reads the client's half of the handshake from the upgrade
+// request: ?protocol=<version> and ?features=<comma-separated list>. The
+// version in use is the lower of the client's and the server's. A client that
+// sends no protocol gets the legacy feature set. ?binary=true requests binary
+// frames either way.
+func (s *Server) negotiateProtocol(r *http.Request) (negotiation, error) {
+	query := r.URL.Query()
+	requested := legacyFeatures
+	version := ProtocolVersion
+	if v := query.Get("protocol"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return negotiation{}, errors.New("protocol parameter must be a positive integer")
+		}
+		if n < MinProtocolVersion {
+			return negotiation{}, errProtocolTooOld
+		}
+		version = min(n, ProtocolVersion)
+		requested = parseFeatures(query.Get("features"))
+	}
+	if query.Get("binary") == "true" {
+		requested = append(requested, FeatureBinary)
+	}
+
+	n := negotiation{
+		version:   version,
+		requested: make(map[Feature]bool, len(requested)),
+		supported: s.supportedFeatures(),
+	}
+	for _, f := range requested {
+		n.requested[f] = true
+	}
+	return n, nil
+}
+
+// parseFeatures splits a comma-separated feature list.
+func parseFeatures(list string) []Feature {
+	var features []Feature
+	for _, f := range strings.Split(list, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			features = append(features, Feature(f))
+		}
+	}
+	return features
+}
+
+// protocolInfo is the server's half of the handshake, sent in connected. It
+// lists the features both sides support; features the server does not know
+// are left out.
+func (n negotiation) protocolInfo() *ProtocolInfo {
+	features := make([]string, 0, len(n.requested))
+	for f := range n.requested {
+		if n.supported[f] {
+			features = append(features, string(f))
+		}
+	}
+	sort.Strings(features)
+	return &ProtocolInfo{
+		Version:    n.version,
+		MinVersion: MinProtocolVersion,
+		Features:   features,
+	}
+}
+
+// supports reports whether the client asked for f. A connection that did not
+// go through the handshake, such as an HTTP chat turn, supports everything.
+func (c *Connection) supports(f Feature) bool {
+	return c.features == nil || c.features[f]
+}
+
+// messageFeature returns the feature a server message requires, or "" for
+// messages every client receives.
+func messageFeature(msg *ServerMessage) Feature {
+	switch msg.Type {
+	case MessageTypeChunk:
+		if msg.Role == RoleUser {
+			return FeatureVoice
+		}
+		return FeatureStreaming
+	case MessageTypeMediaChunk:
+		return FeatureMedia
+	case MessageTypeSessionConfig, MessageTypeInterrupt:
+		return FeatureVoice
+	case MessageTypePresence:
+		return FeaturePresence
+	case MessageTypeTyping:
+		return FeatureTyping
+	case MessageTypeReconnect:
+		return FeatureReconnect
+	case MessageTypeAck:
+		return FeatureAck
+	case MessageTypeNotice:
+		return FeatureNotice
+	case MessageTypeProgress:
+		return FeatureProgress
+	}
+	return ""
+}
+
+// degrade adapts msg to the features the client asked for. It reports false
+// for a message the client did not opt into. A withheld chunk's text is kept
+// and sent as the content of the turn's done. Called with c.mu held.
+func (c *Connection) degrade(msg *ServerMessage) (*ServerMessage, bool) {
+	if c.features == nil {
+		return msg, true
+	}
+	if f := messageFeature(msg); f != "" && !c.features[f] {
+		if f == FeatureStreaming {
+			c.withheld.WriteString(msg.Content)
+			for _, p := range msg.Parts {
+				if p.Type == ContentPartTypeText {
+					c.withheld.WriteString(p.Text)
+				}
+			}
+		}
+		return nil, false
+	}
+	switch msg.Type {
+	case MessageTypeDone:
+		if msg.Content == "" && len(msg.Parts) == 0 && c.withheld.Len() > 0 {
+			// Copy: msg may be shared with the session's replay log.
+			full := *msg
+			full.Content = c.withheld.String()
+			msg = &full
+		}
+		c.withheld.Reset()
+	case MessageTypeError:
+		c.withheld.Reset()
+	}
+	return msg, true
+}
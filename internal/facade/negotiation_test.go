@@ -0,0 +1,249 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateProtocol(t *testing.T) {
+	srv := NewServer(DefaultServerConfig(), nil, &mockHandler{}, testLoggerDiscard())
+
+	tests := []struct {
+		name         string
+		query        string
+		wantVersion  int
+		wantFeatures []string
+	}{
+		{
+			name:         "legacy client gets the pre-negotiation features",
+			query:        "",
+			wantVersion:  ProtocolVersion,
+			wantFeatures: []string{"media", "resume", "streaming"},
+		},
+		{
+			name:         "legacy binary client",
+			query:        "binary=true",
+			wantVersion:  ProtocolVersion,
+			wantFeatures: []string{"binary", "media", "resume", "streaming"},
+		},
+		{
+			name:         "intersection with server features, unknown ignored",
+			query:        "protocol=1&features=streaming,typing,voice,hologram",
+			wantVersion:  1,
+			wantFeatures: []string{"streaming", "typing"},
+		},
+		{
+			name:         "newer client is acked with the server version",
+			query:        "protocol=7&features=typing",
+			wantVersion:  ProtocolVersion,
+			wantFeatures: []string{"typing"},
+		},
+		{
+			name:         "negotiating client with no features",
+			query:        "protocol=1",
+			wantVersion:  1,
+			wantFeatures: []string{},
+		},
+		{
+			name:         "binary param adds to negotiated features",
+			query:        "protocol=1&features=%20media%20&binary=true",
+			wantVersion:  1,
+			wantFeatures: []string{"binary", "media"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/ws?"+tt.query, nil)
+			n, err := srv.negotiateProtocol(r)
+			require.NoError(t, err)
+			info := n.protocolInfo()
+			assert.Equal(t, tt.wantVersion, info.Version)
+			assert.Equal(t, MinProtocolVersion, info.MinVersion)
+			assert.Equal(t, tt.wantFeatures, info.Features)
+		})
+	}
+}
+
+func TestNegotiateProtocol_RejectsInvalidVersion(t *testing.T) {
+	srv := NewServer(DefaultServerConfig(), nil, &mockHandler{}, testLoggerDiscard())
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	for _, v := range []string{"abc", "0", "-1"} {
+		_, resp, err := websocket.DefaultDialer.Dial(wsURL(ts.URL)+"?agent=test-agent&protocol="+v, nil)
+		require.Error(t, err, "protocol=%s", v)
+		require.NotNil(t, resp)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "protocol=%s", v)
+		_ = resp.Body.Close()
+	}
+}
+
+func TestNegotiateProtocol_ServerFeaturesFollowConfig(t *testing.T) {
+	srv := NewServer(DefaultServerConfig(), nil, &observingHandler{}, testLoggerDiscard(),
+		WithDuplexSinkFactory(func(_ string, _ ResponseWriter) DuplexSink { return &fakeDuplexSink{} }),
+	)
+	r := httptest.NewRequest(http.MethodGet, "/ws?protocol=1&features=voice,presence,resume", nil)
+	n, err := srv.negotiateProtocol(r)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"presence", "resume", "voice"}, n.protocolInfo().Features)
+}
+
+func TestConnected_AcknowledgesProtocol(t *testing.T) {
+	_, ts := newTestServer(t, &mockHandler{})
+
+	ws, _, err := websocket.DefaultDialer.Dial(wsURL(ts.URL)+"?agent=test-agent&protocol=1&features=streaming,typing", nil)
+	require.NoError(t, err)
+	defer func() { _ = ws.Close() }()
+
+	var msg ServerMessage
+	require.NoError(t, ws.ReadJSON(&msg))
+	require.NotNil(t, msg.Connected)
+	require.NotNil(t, msg.Connected.Protocol)
+	assert.Equal(t, &ProtocolInfo{
+		Version:    ProtocolVersion,
+		MinVersion: MinProtocolVersion,
+		Features:   []string{"streaming", "typing"},
+	}, msg.Connected.Protocol)
+	assert.Equal(t, BinaryVersion, msg.Connected.Capabilities.ProtocolVersion)
+}
+
+// streamingHandler streams its reply as chunks and sends an empty done.
+func streamingHandler() *mockHandler {
+	return &mockHandler{handleFunc: func(_ context.Context, _ string, _ *ClientMessage, w ResponseWriter) error {
+		_ = w.WriteChunk("Hello, ")
+		_ = w.WriteChunk("world")
+		return w.WriteDone("")
+	}}
+}
+
+// turnMessages sends one message and collects server messages until done.
+func turnMessages(t *testing.T, url string) []ServerMessage {
+	t.Helper()
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer func() { _ = ws.Close() }()
+	sessionID := readConnected(t, ws)
+
+	require.NoError(t, ws.WriteJSON(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "hi"}))
+	var msgs []ServerMessage
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var msg ServerMessage
+		require.NoError(t, ws.ReadJSON(&msg))
+		msgs = append(msgs, msg)
+		if msg.Type == MessageTypeDone || msg.Type == MessageTypeError {
+			return msgs
+		}
+	}
+}
+
+func messageTypes(msgs []ServerMessage) []MessageType {
+	types := make([]MessageType, len(msgs))
+	for i, m := range msgs {
+		types[i] = m.Type
+	}
+	return types
+}
+
+func TestNegotiation_StreamingAndTyping(t *testing.T) {
+	_, ts := newTestServer(t, streamingHandler())
+	base := wsURL(ts.URL) + "?agent=test-agent"
+
+	t.Run("legacy client streams and never sees typing", func(t *testing.T) {
+		msgs := turnMessages(t, base)
+		assert.Equal(t, []MessageType{MessageTypeChunk, MessageTypeChunk, MessageTypeDone}, messageTypes(msgs))
+	})
+
+	t.Run("typing precedes the reply when negotiated", func(t *testing.T) {
+		msgs := turnMessages(t, base+"&protocol=1&features=streaming,typing")
+		assert.Equal(t, []MessageType{MessageTypeTyping, MessageTypeChunk, MessageTypeChunk, MessageTypeDone}, messageTypes(msgs))
+	})
+
+	t.Run("without streaming done carries the full reply", func(t *testing.T) {
+		msgs := turnMessages(t, base+"&protocol=1")
+		require.Equal(t, []MessageType{MessageTypeDone}, messageTypes(msgs))
+		assert.Equal(t, "Hello, world", msgs[0].Content)
+	})
+}
+
+func TestNegotiation_Progress(t *testing.T) {
+	handler := &mockHandler{handleFunc: func(_ context.Context, _ string, _ *ClientMessage, w ResponseWriter) error {
+		if pw, ok := w.(ProgressWriter); ok && pw.WantsProgress() {
+			_ = pw.WriteProgress(&ProgressInfo{Stage: ProgressStageTool, Status: ProgressStatusStarted, ToolName: "search", CallID: "call-1"})
+		}
+		return w.WriteDone("found it")
+	}}
+	_, ts, _ := newReplayServer(t, handler, 5*time.Second)
+	base := wsURL(ts.URL) + "?agent=test-agent"
+
+	t.Run("legacy client gets no progress", func(t *testing.T) {
+		msgs := turnMessages(t, base)
+		assert.Equal(t, []MessageType{MessageTypeDone}, messageTypes(msgs))
+	})
+
+	t.Run("progress precedes the reply and is not logged for replay", func(t *testing.T) {
+		msgs := turnMessages(t, base+"&protocol=1&features=streaming,resume,progress")
+		require.Equal(t, []MessageType{MessageTypeProgress, MessageTypeDone}, messageTypes(msgs))
+		assert.Equal(t, &ProgressInfo{Stage: ProgressStageTool, Status: ProgressStatusStarted, ToolName: "search", CallID: "call-1"}, msgs[0].Progress)
+		assert.Zero(t, msgs[0].Seq)
+		assert.Equal(t, uint64(1), msgs[1].Seq)
+	})
+}
+
+func TestConnectionDegrade(t *testing.T) {
+	c := &Connection{features: map[Feature]bool{FeatureStreaming: true}}
+
+	_, ok := c.degrade(NewUserTranscriptMessage("s", "hello"))
+	assert.False(t, ok, "user transcripts need voice")
+	_, ok = c.degrade(NewPresenceMessage("s", &PresenceInfo{}))
+	assert.False(t, ok, "presence needs presence")
+	_, ok = c.degrade(NewMediaChunkMessage("s", &MediaChunkInfo{}))
+	assert.False(t, ok, "media_chunk needs media")
+	msg, ok := c.degrade(NewChunkMessage("s", "hi"))
+	assert.True(t, ok)
+	assert.Equal(t, "hi", msg.Content)
+
+	// A connection without a handshake gets everything.
+	legacy := &Connection{}
+	_, ok = legacy.degrade(NewTypingMessage("s"))
+	assert.True(t, ok)
+}
+
+func TestConnectionDegrade_DoneIsNotMutated(t *testing.T) {
+	c := &Connection{features: map[Feature]bool{}}
+	_, ok := c.degrade(NewChunkMessage("s", "partial"))
+	require.False(t, ok)
+
+	done := NewDoneMessage("s", "")
+	got, ok := c.degrade(done)
+	require.True(t, ok)
+	assert.Equal(t, "partial", got.Content)
+	assert.Empty(t, done.Content, "the replay log's copy must stay untouched")
+
+	// The buffer resets with the turn.
+	got, _ = c.degrade(NewDoneMessage("s", ""))
+	assert.Empty(t, got.Content)
+}
@@ -17,7 +17,11 @@ limitations under the License.
 // Package facade provides the WebSocket facade for agent communication.
 package facade
 
-import "time"
+import (
+	"time"
+
+	"github.com/altairalabs/omnia/pkg/apierror"
+)
 
 // ContentPartType represents the type of content in a message part.
 type ContentPartType string
@@ -102,11 +106,27 @@ const (
 	// end. The facade marks the connection as intentionalClose so that
 	// cleanupConnection does not park the realtime audio session.
 	MessageTypeHangup MessageType = "hangup"
+	// MessageTypeToolDebug carries a step-through debugging decision for a
+	// tool call the handler has paused on (arena dev console debug mode).
+	MessageTypeToolDebug MessageType = "tool_debug"
 
 	// Bidirectional message types
 	// Server → Client: tool execution result (informational)
 	// Client → Server: client-side tool result (response to a client ToolCall)
 	MessageTypeToolResult MessageType = "tool_result"
+	// MessageTypeAck acknowledges messages by seq.
+	// Client → Server: every server message up to ack.seq was received, so
+	// it is never redelivered on resume.
+	// Server → Client: the client message numbered ack.seq was accepted (or
+	// was a duplicate and dropped). Sent only to clients that negotiated the
+	// ack feature.
+	MessageTypeAck MessageType = "ack"
+	// MessageTypeFragment carries one piece of a message too large to send
+	// in one frame. The receiver concatenates the data of fragments 0 to
+	// count-1 with the same id and decodes the result as the original
+	// message. The server fragments only for clients that negotiated the
+	// fragments feature; clients may fragment what they send at any time.
+	MessageTypeFragment MessageType = "fragment"
 
 	// Server to Client message types
 	MessageTypeChunk          MessageType = "chunk"
@@ -123,6 +143,24 @@ const (
 	// format (the RuntimeHello counter-offer) to the client, which (re)captures
 	// at that codec / sample_rate / channels.
 	MessageTypeSessionConfig MessageType = "session_config"
+	// MessageTypePresence lists the participants attached to a collaborative
+	// session. Sent whenever someone joins, leaves, or changes role.
+	MessageTypePresence MessageType = "presence"
+	// MessageTypeTyping tells the client the agent has started on a turn.
+	// Sent only to clients that negotiated the typing feature.
+	MessageTypeTyping MessageType = "typing"
+	// MessageTypeReconnect tells the client the server is shutting down and
+	// where and when to resume its session. Sent only to clients that
+	// negotiated the reconnect feature.
+	MessageTypeReconnect MessageType = "reconnect"
+	// MessageTypeNotice carries an operator's announcement to the client,
+	// sent through the admin API. Sent only to clients that negotiated the
+	// notice feature.
+	MessageTypeNotice MessageType = "notice"
+	// MessageTypeProgress reports a step of a turn in progress: the agent
+	// thinking, a tool running, knowledge being retrieved. Sent only to
+	// clients that negotiated the progress feature.
+	MessageTypeProgress MessageType = "progress"
 )
 
 // ToolCallAckInfo contains acknowledgement of a client-side tool call.
@@ -141,6 +179,33 @@ type ToolCallNackInfo struct {
 	Reason string `json:"reason"`
 }
 
+// ToolDebugAction is the developer's decision for a paused tool call.
+type ToolDebugAction string
+
+const (
+	// ToolDebugActionApprove executes the tool with the model's arguments.
+	ToolDebugActionApprove ToolDebugAction = "approve"
+	// ToolDebugActionEdit executes the tool with replacement arguments, or
+	// skips execution and returns the supplied result to the model.
+	ToolDebugActionEdit ToolDebugAction = "edit"
+	// ToolDebugActionSkip does not execute the tool; the model is told the
+	// call was skipped.
+	ToolDebugActionSkip ToolDebugAction = "skip"
+)
+
+// ToolDebugInfo is a step-through debugging command for a paused tool call.
+type ToolDebugInfo struct {
+	// CallID matches the ToolCallInfo.ID the handler is paused on.
+	CallID string `json:"call_id"`
+	// Action is approve, edit, or skip.
+	Action ToolDebugAction `json:"action"`
+	// Arguments replaces the tool arguments (edit only).
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	// Result, when set, is returned to the model instead of executing the
+	// tool (edit only).
+	Result interface{} `json:"result,omitempty"`
+}
+
 // ClientToolResultInfo contains the client's response to a client-side tool call.
 type ClientToolResultInfo struct {
 	// CallID matches the ToolCallInfo.ID that this result is for.
@@ -173,6 +238,8 @@ type ClientMessage struct {
 	ToolCallAck *ToolCallAckInfo `json:"tool_call_ack,omitempty"`
 	// ToolCallNack rejects a client-side tool call.
 	ToolCallNack *ToolCallNackInfo `json:"tool_call_nack,omitempty"`
+	// ToolDebug resolves a tool call paused in debug mode (for type "tool_debug").
+	ToolDebug *ToolDebugInfo `json:"tool_debug,omitempty"`
 	// ConsentGrants carries per-message consent category grants from the client.
 	// When present, these override stored consent for this request.
 	ConsentGrants []string `json:"consent_grants,omitempty"`
@@ -186,6 +253,15 @@ type ClientMessage struct {
 	// categories for a session use the binary opt-out via the privacy
 	// preferences API; per-session grants are additive only.
 	SessionConsentGrants []string `json:"session_consent_grants,omitempty"`
+	// Seq is the client's sequence number for the message within its
+	// session. A message whose seq is not greater than the last one the
+	// server accepted for the session is a resend: it is acknowledged and
+	// not processed again. Zero opts the message out of deduplication.
+	Seq uint64 `json:"seq,omitempty"`
+	// Ack acknowledges server messages (for ack type).
+	Ack *AckInfo `json:"ack,omitempty"`
+	// Fragment is one piece of a larger message (for fragment type).
+	Fragment *FragmentInfo `json:"fragment,omitempty"`
 }
 
 // ServerMessage represents a message sent from server to client.
@@ -221,6 +297,25 @@ type ServerMessage struct {
 	SessionConfig *SessionConfigInfo `json:"session_config,omitempty"`
 	// Connected contains connection info (for connected type).
 	Connected *ConnectedInfo `json:"connected,omitempty"`
+	// Presence lists the participants of a collaborative session (for
+	// presence type).
+	Presence *PresenceInfo `json:"presence,omitempty"`
+	// Reconnect tells the client how to resume on another replica (for
+	// reconnect type).
+	Reconnect *ReconnectInfo `json:"reconnect,omitempty"`
+	// Ack acknowledges a client message (for ack type).
+	Ack *AckInfo `json:"ack,omitempty"`
+	// Notice is an operator's announcement (for notice type).
+	Notice *NoticeInfo `json:"notice,omitempty"`
+	// Progress reports a step of the turn (for progress type).
+	Progress *ProgressInfo `json:"progress,omitempty"`
+	// Fragment is one piece of a larger message (for fragment type).
+	Fragment *FragmentInfo `json:"fragment,omitempty"`
+	// Seq is the message's sequence number within its session, starting at 1.
+	// A client that reconnects with ?resume=<session_id>&last_seq=<seq> is
+	// replayed every message after seq. Unset on connected messages and on
+	// errors that belong to no session.
+	Seq uint64 `json:"seq,omitempty"`
 	// Timestamp is when the message was created.
 	Timestamp time.Time `json:"timestamp"`
 }
@@ -240,6 +335,9 @@ type ToolCallInfo struct {
 	ConsentMessage string `json:"consent_message,omitempty"`
 	// Categories are semantic consent categories (e.g., "location", "filesystem").
 	Categories []string `json:"categories,omitempty"`
+	// AwaitingDebug is set when the handler has paused on this call and waits
+	// for a tool_debug command before executing it.
+	AwaitingDebug bool `json:"awaiting_debug,omitempty"`
 }
 
 // ToolResultInfo contains information about a tool result.
@@ -258,6 +356,12 @@ type ErrorInfo struct {
 	Code string `json:"code"`
 	// Message is the error message.
 	Message string `json:"message"`
+	// Retryable reports whether sending the request again, after a backoff,
+	// may succeed.
+	Retryable bool `json:"retryable"`
+	// CorrelationID identifies the connection or request in server logs and
+	// traces; quote it when reporting the error.
+	CorrelationID string `json:"correlation_id,omitempty"`
 	// Details contains additional error details.
 	Details map[string]interface{} `json:"details,omitempty"`
 }
@@ -323,6 +427,109 @@ type SessionConfigInfo struct {
 	Channels int `json:"channels"`
 }
 
+// ParticipantRole is a participant's permission level in a collaborative session.
+type ParticipantRole string
+
+const (
+	// ParticipantRoleDriver may send messages and commands.
+	ParticipantRoleDriver ParticipantRole = "driver"
+	// ParticipantRoleObserver receives everything but may not send.
+	ParticipantRoleObserver ParticipantRole = "observer"
+)
+
+// ParticipantInfo describes one connection attached to a collaborative session.
+type ParticipantInfo struct {
+	// ID is the connection identifier, unique per WebSocket connection.
+	ID string `json:"id"`
+	// UserID is the participant's pseudonymous user ID, when known.
+	UserID string `json:"user_id,omitempty"`
+	// Email is the participant's email, when the auth identity carries one.
+	Email string `json:"email,omitempty"`
+	// Role is the participant's current role.
+	Role ParticipantRole `json:"role"`
+	// JoinedAt is when the participant attached to the session.
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// PresenceInfo lists the participants attached to a collaborative session.
+type PresenceInfo struct {
+	// SelfID is the recipient's own participant ID.
+	SelfID string `json:"self_id,omitempty"`
+	// Participants are the attached connections in join order.
+	Participants []ParticipantInfo `json:"participants"`
+}
+
+// ReconnectInfo is the reconnect hint sent when the server starts draining.
+// The client finishes the turn in progress, closes, and resumes its session
+// with ?resume=<session_id>&last_seq=<n> at Endpoint, or at the URL it used,
+// after RetryAfterMs.
+type ReconnectInfo struct {
+	// Reason is why the client should reconnect; "draining" for a server
+	// shutting down.
+	Reason string `json:"reason"`
+	// RetryAfterMs is how long to wait before reconnecting, in milliseconds.
+	RetryAfterMs int64 `json:"retry_after_ms"`
+	// Endpoint is the URL to reconnect to, when the server knows one that
+	// does not route back to itself.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// NoticeInfo is an announcement an operator broadcast to connected clients,
+// such as planned maintenance.
+type NoticeInfo struct {
+	// Message is the text to show the user.
+	Message string `json:"message"`
+}
+
+// Progress stages.
+const (
+	// ProgressStageThinking is the agent waiting on the model.
+	ProgressStageThinking = "thinking"
+	// ProgressStageTool is a server-side tool running.
+	ProgressStageTool = "tool"
+	// ProgressStageRetrieval is knowledge being retrieved for the turn.
+	ProgressStageRetrieval = "retrieval"
+)
+
+// Progress statuses.
+const (
+	ProgressStatusStarted = "started"
+	// ProgressStatusRunning is repeated while a tool runs, with the time so
+	// far.
+	ProgressStatusRunning   = "running"
+	ProgressStatusCompleted = "completed"
+	ProgressStatusFailed    = "failed"
+)
+
+// FragmentInfo is one piece of a message split across several frames.
+type FragmentInfo struct {
+	// ID identifies the message being fragmented. Fragments of different
+	// messages may interleave.
+	ID string `json:"id"`
+	// Index is the position of this fragment, starting at 0.
+	Index int `json:"index"`
+	// Count is how many fragments make up the message.
+	Count int `json:"count"`
+	// Data is this fragment's slice of the message's JSON encoding.
+	Data string `json:"data"`
+}
+
+// ProgressInfo reports a step of a turn, so a client can show what the agent
+// is doing rather than a spinner. The outcome of the turn is still carried by
+// its chunks, done and error.
+type ProgressInfo struct {
+	// Stage is the kind of step, one of the ProgressStage constants.
+	Stage string `json:"stage"`
+	// Status is one of the ProgressStatus constants.
+	Status string `json:"status"`
+	// ToolName names the tool, for the tool stage.
+	ToolName string `json:"tool_name,omitempty"`
+	// CallID identifies the tool call, for the tool stage.
+	CallID string `json:"call_id,omitempty"`
+	// ElapsedMs is how long the step has run, in milliseconds.
+	ElapsedMs int64 `json:"elapsed_ms,omitempty"`
+}
+
 // ConnectionCapabilities represents negotiated connection features.
 // Sent in the connected message to inform the client of available capabilities.
 type ConnectionCapabilities struct {
@@ -330,21 +537,61 @@ type ConnectionCapabilities struct {
 	BinaryFrames bool `json:"binary_frames"`
 	// MaxPayloadSize is the maximum binary payload size in bytes.
 	MaxPayloadSize int `json:"max_payload_size,omitempty"`
-	// ProtocolVersion is the binary protocol version supported.
+	// ProtocolVersion is the binary frame version supported. The JSON
+	// message schema is versioned separately; see ConnectedInfo.Protocol.
 	ProtocolVersion int `json:"protocol_version,omitempty"`
+	// MaxFragmentedSize is the largest message the client may send as
+	// fragments. Zero means the server does not accept fragments.
+	MaxFragmentedSize int `json:"max_fragmented_size,omitempty"`
+	// Audio is the format to capture voice in, present when the agent accepts
+	// duplex audio. The runtime may still counter-offer a different format
+	// with session_config.
+	Audio *SessionConfigInfo `json:"audio,omitempty"`
 }
 
 // ConnectedInfo contains additional information sent with the connected message.
 type ConnectedInfo struct {
 	// Capabilities describes the server's supported features.
 	Capabilities *ConnectionCapabilities `json:"capabilities,omitempty"`
-	// Resumed is true when this connection re-attached to a parked realtime
-	// session (T1 blip-resume) rather than starting fresh. The client keeps its
-	// sequence counter on resume and resets it on a fresh session.
+	// Resumed is true when this connection re-attached to the session named
+	// by ?resume= — a parked realtime session (T1 blip-resume) or a session
+	// whose missed messages are replayed — rather than starting fresh. The
+	// client keeps its sequence counters on resume and resets them on a fresh
+	// session.
 	Resumed bool `json:"resumed,omitempty"`
-}
-
-// Error codes.
+	// Protocol is the outcome of the connect-time handshake: the message
+	// schema version and the features this connection uses.
+	Protocol *ProtocolInfo `json:"protocol,omitempty"`
+	// ClientSeq is, on a resumed session, the seq of the last client message
+	// the server accepted. The client resends the messages after it.
+	ClientSeq uint64 `json:"client_seq,omitempty"`
+}
+
+// AckInfo acknowledges messages up to a sequence number.
+type AckInfo struct {
+	// Seq is the sequence number acknowledged: a server message seq in a
+	// client ack, a client message seq in a server ack.
+	Seq uint64 `json:"seq"`
+	// Duplicate is set on a server ack for a client message that was already
+	// accepted and was dropped rather than processed again.
+	Duplicate bool `json:"duplicate,omitempty"`
+}
+
+// ProtocolInfo acknowledges the protocol version and features requested with
+// ?protocol= and ?features= on connect.
+type ProtocolInfo struct {
+	// Version is the message schema version in use: the lower of the
+	// client's and the server's.
+	Version int `json:"version"`
+	// MinVersion is the oldest schema version the server still serves.
+	MinVersion int `json:"min_version"`
+	// Features are the features both sides support, sorted. Messages that
+	// need a feature not listed are not sent.
+	Features []string `json:"features"`
+}
+
+// Error codes. Each is a code of the shared error model in pkg/apierror,
+// which decides whether an error with it is retryable.
 const (
 	ErrorCodeInvalidMessage   = "INVALID_MESSAGE"
 	ErrorCodeSessionNotFound  = "SESSION_NOT_FOUND"
@@ -359,6 +606,38 @@ const (
 	// counter-offer cannot be satisfied by this facade (e.g. it requires video,
 	// which this audio-only path does not implement).
 	ErrorCodeUnsatisfiableFormat = "UNSATISFIABLE_FORMAT"
+	// ErrorCodeStructuredOutputInvalid is relayed from the runtime when an
+	// agent with spec.structuredOutput could not produce a response that
+	// satisfies its response schema, even after repair attempts.
+	ErrorCodeStructuredOutputInvalid = "STRUCTURED_OUTPUT_INVALID"
+	// ErrorCodeGuardrailRejected is relayed from the runtime when an input or
+	// output guardrail hook rejected the user's message or the agent's
+	// response.
+	ErrorCodeGuardrailRejected = "GUARDRAIL_REJECTED"
+	// ErrorCodeBudgetExceeded is relayed from the runtime when a turn was
+	// rejected because its session or the agent is over its token or cost
+	// budget.
+	ErrorCodeBudgetExceeded = "BUDGET_EXCEEDED"
+	// ErrorCodeServerShutdown is sent when a turn is cut off because the
+	// server finished draining before it completed. The session can be
+	// resumed on another replica.
+	ErrorCodeServerShutdown = "SERVER_SHUTDOWN"
+	// ErrorCodeSessionTerminated is sent when an operator ended the session
+	// through the admin API. The session cannot be resumed.
+	ErrorCodeSessionTerminated = "SESSION_TERMINATED"
+	// ErrorCodeMessageTooLarge is sent when a message the client sent as
+	// fragments is larger than ConnectionCapabilities.MaxFragmentedSize. The
+	// message is discarded.
+	ErrorCodeMessageTooLarge = "MESSAGE_TOO_LARGE"
+	// ErrorCodeUnauthenticated is returned by HTTP chat when the request's
+	// credentials are missing or invalid.
+	ErrorCodeUnauthenticated = "UNAUTHENTICATED"
+	// ErrorCodePermissionDenied is returned by HTTP chat when the caller is
+	// not allowed to talk to the agent, and on a refused ?join=.
+	ErrorCodePermissionDenied = "PERMISSION_DENIED"
+	// ErrorCodeUnavailable is sent when a service the turn needs, such as
+	// the agent's runtime, is temporarily unavailable.
+	ErrorCodeUnavailable = "UNAVAILABLE"
 )
 
 // NewChunkMessage creates a new chunk message.
@@ -421,14 +700,17 @@ func NewErrorMessage(sessionID, code, message string) *ServerMessage {
 	return &ServerMessage{
 		Type:      MessageTypeError,
 		SessionID: sessionID,
-		Error: &ErrorInfo{
-			Code:    code,
-			Message: message,
-		},
+		Error:     newErrorInfo(code, message),
 		Timestamp: time.Now(),
 	}
 }
 
+// newErrorInfo returns the details of an error with code, retryable as the
+// code is.
+func newErrorInfo(code, message string) *ErrorInfo {
+	return &ErrorInfo{Code: code, Message: message, Retryable: apierror.Retryable(code)}
+}
+
 // NewConnectedMessageWithCapabilities creates a new connected message with capabilities.
 func NewConnectedMessageWithCapabilities(sessionID string, capabilities *ConnectionCapabilities) *ServerMessage {
 	return &ServerMessage{
@@ -508,6 +790,76 @@ func NewSessionConfigMessage(sessionID string, cfg *SessionConfigInfo) *ServerMe
 	}
 }
 
+// NewTypingMessage creates a typing message for the start of a turn.
+func NewTypingMessage(sessionID string) *ServerMessage {
+	return &ServerMessage{
+		Type:      MessageTypeTyping,
+		SessionID: sessionID,
+		Timestamp: time.Now(),
+	}
+}
+
+// NewAckMessage creates a server ack for the client message numbered seq.
+func NewAckMessage(sessionID string, seq uint64, duplicate bool) *ServerMessage {
+	return &ServerMessage{
+		Type:      MessageTypeAck,
+		SessionID: sessionID,
+		Ack:       &AckInfo{Seq: seq, Duplicate: duplicate},
+		Timestamp: time.Now(),
+	}
+}
+
+// NewReconnectMessage creates a reconnect hint for a draining server.
+func NewReconnectMessage(sessionID string, info *ReconnectInfo) *ServerMessage {
+	return &ServerMessage{
+		Type:      MessageTypeReconnect,
+		SessionID: sessionID,
+		Reconnect: info,
+		Timestamp: time.Now(),
+	}
+}
+
+// NewProgressMessage creates a progress message for a step of a turn.
+func NewProgressMessage(sessionID string, progress *ProgressInfo) *ServerMessage {
+	return &ServerMessage{
+		Type:      MessageTypeProgress,
+		SessionID: sessionID,
+		Progress:  progress,
+		Timestamp: time.Now(),
+	}
+}
+
+// NewFragmentMessage creates a fragment message carrying one piece of a
+// larger message.
+func NewFragmentMessage(sessionID string, fragment *FragmentInfo) *ServerMessage {
+	return &ServerMessage{
+		Type:      MessageTypeFragment,
+		SessionID: sessionID,
+		Fragment:  fragment,
+		Timestamp: time.Now(),
+	}
+}
+
+// NewNoticeMessage creates an operator notice.
+func NewNoticeMessage(sessionID, message string) *ServerMessage {
+	return &ServerMessage{
+		Type:      MessageTypeNotice,
+		SessionID: sessionID,
+		Notice:    &NoticeInfo{Message: message},
+		Timestamp: time.Now(),
+	}
+}
+
+// NewPresenceMessage creates a presence message for a collaborative session.
+func NewPresenceMessage(sessionID string, presence *PresenceInfo) *ServerMessage {
+	return &ServerMessage{
+		Type:      MessageTypePresence,
+		SessionID: sessionID,
+		Presence:  presence,
+		Timestamp: time.Now(),
+	}
+}
+
 // NewMediaChunkMessage creates a new media chunk message for streaming media responses.
 func NewMediaChunkMessage(sessionID string, mediaChunk *MediaChunkInfo) *ServerMessage {
 	return &ServerMessage{
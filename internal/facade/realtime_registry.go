@@ -114,6 +114,32 @@ func (r *realtimeRegistry) expire(sessionID string) {
 	r.log.V(1).Info("realtime session park expired", "sessionID", sessionID)
 }
 
+// evict expires sessionID now if it is parked, as if its grace timer had
+// fired. Reports whether it was parked.
+func (r *realtimeRegistry) evict(sessionID string) bool {
+	r.mu.Lock()
+	ps, ok := r.parked[sessionID]
+	if ok {
+		ps.timer.Stop()
+	}
+	r.mu.Unlock()
+	if ok {
+		r.expire(sessionID)
+	}
+	return ok
+}
+
+// sessionIDs returns the IDs of the parked sessions.
+func (r *realtimeRegistry) sessionIDs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := make([]string, 0, len(r.parked))
+	for id := range r.parked {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 // len reports the number of currently parked sessions (test/metrics).
 func (r *realtimeRegistry) len() int {
 	r.mu.Lock()
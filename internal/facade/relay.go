@@ -0,0 +1,580 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/altairalabs/omnia/pkg/logctx"
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+// relayAttachTimeout bounds how long a replica waits for the owner of a
+// session to accept a relay before falling back to a fresh session.
+const relayAttachTimeout = 2 * time.Second
+
+// relayAttachMaxAge is how old an attach may be when the owner receives it.
+// An older one is dropped, so a captured attach cannot be replayed later.
+const relayAttachMaxAge = 30 * time.Second
+
+// ErrNoRelaySubscriber is returned by SessionRelay.Publish when nobody is
+// listening on the channel: the replica at the other end of a relay is gone.
+var ErrNoRelaySubscriber = errors.New("session relay: no subscriber")
+
+// SessionRelay carries a relayed session's traffic between the agent's facade
+// replicas. When a client resumes on a replica that does not hold its
+// session, that replica looks the owner up in the route store and relays the
+// connection to it: the owner keeps running the session and its turns, and
+// the client-facing replica only moves messages.
+type SessionRelay interface {
+	// Publish sends payload to the channel's subscribers. It returns
+	// ErrNoRelaySubscriber when there are none.
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe listens on channel. The subscription is active when
+	// Subscribe returns, so a reply published afterwards is not missed.
+	Subscribe(ctx context.Context, channel string) (RelaySubscription, error)
+}
+
+// RelaySubscription delivers the payloads published on a channel.
+type RelaySubscription interface {
+	// Messages is closed after Close.
+	Messages() <-chan []byte
+	Close() error
+}
+
+// RouteResolver is implemented by a RouteStore that can also read route
+// hints. A relay is only attempted when the route store is one.
+type RouteResolver interface {
+	// GetRoute returns "" and no error when sessionID has no route hint.
+	GetRoute(ctx context.Context, sessionID string) (string, error)
+}
+
+// relayOp is the kind of a relay envelope.
+type relayOp string
+
+const (
+	// Sent by the client-facing replica.
+	relayOpAttach relayOp = "attach"
+	relayOpClient relayOp = "client"
+	relayOpDetach relayOp = "detach"
+	// Sent by the owner.
+	relayOpAttached relayOp = "attached"
+	relayOpRefused  relayOp = "refused"
+	relayOpMessage  relayOp = "message"
+	relayOpPing     relayOp = "ping"
+)
+
+// relayEnvelope is one message on a relay channel.
+type relayEnvelope struct {
+	Op      relayOp `json:"op"`
+	RelayID string  `json:"relay_id"`
+	// SentAt is when the envelope was published, in Unix milliseconds.
+	SentAt int64 `json:"sent_at"`
+	// SessionID and LastSeq name the session an attach resumes and the last
+	// message the client received.
+	SessionID string `json:"session_id,omitempty"`
+	LastSeq   uint64 `json:"last_seq,omitempty"`
+	// Identity describes the client of an attach.
+	Identity *relayIdentity `json:"identity,omitempty"`
+	// Persisted reports on attached whether the session has an archive row,
+	// and ClientSeq the seq of the last client message accepted.
+	Persisted bool   `json:"persisted,omitempty"`
+	ClientSeq uint64 `json:"client_seq,omitempty"`
+	// Message is a server message for the client.
+	Message *ServerMessage `json:"message,omitempty"`
+	// Client is a client message, as the client sent it.
+	Client json.RawMessage `json:"client,omitempty"`
+}
+
+// relayIdentity is what the owner needs to run turns for a client connected
+// elsewhere: the connection's identity and the fields propagated to the
+// runtime. The authenticated Identity itself is in-process only and does not
+// cross replicas. Envelopes are sealed with the relay key, so the owner can
+// trust an identity it receives: only a replica of the agent can have sent it.
+type relayIdentity struct {
+	AgentName     string                    `json:"agent_name"`
+	Namespace     string                    `json:"namespace"`
+	WorkspaceName string                    `json:"workspace_name,omitempty"`
+	UserID        string                    `json:"user_id,omitempty"`
+	UserEmail     string                    `json:"user_email,omitempty"`
+	Authorization string                    `json:"authorization,omitempty"`
+	CohortID      string                    `json:"cohort_id,omitempty"`
+	Variant       string                    `json:"variant,omitempty"`
+	Propagation   *policy.PropagationFields `json:"propagation,omitempty"`
+}
+
+// relayPodChannel is the channel a replica receives attaches and client
+// messages on.
+func relayPodChannel(podAddr string) string { return "omnia:relay:pod:" + podAddr }
+
+// relayConnChannel is the channel the owner sends one relay's server
+// messages on.
+func relayConnChannel(relayID string) string { return "omnia:relay:conn:" + relayID }
+
+// relayPeer marks a connection on the owner that stands in for a client
+// connected to another replica. Messages written to it are published to the
+// relay instead of a socket.
+type relayPeer struct {
+	relayID string
+	// ctx carries the client's identity, as the connection context does for
+	// a local client.
+	ctx  context.Context
+	done chan struct{}
+}
+
+// relayUpstream marks a connection whose session is run by another replica.
+// Its client messages are forwarded to the owner.
+type relayUpstream struct {
+	relayID string
+	channel string
+	sub     RelaySubscription
+}
+
+// relayEnabled reports whether sessions can be relayed across replicas.
+func (s *Server) relayEnabled() bool {
+	return s.relay != nil && s.relaySealer != nil && s.podAddr != "" && s.replays.enabled()
+}
+
+// publishRelay encodes and seals env and publishes it on channel.
+func (s *Server) publishRelay(ctx context.Context, channel string, env *relayEnvelope) error {
+	env.SentAt = time.Now().UnixMilli()
+	data, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	sealed, err := s.relaySealer.seal(channel, data)
+	if err != nil {
+		return err
+	}
+	return s.relay.Publish(ctx, channel, sealed)
+}
+
+// openRelay verifies and decodes an envelope received on channel.
+func (s *Server) openRelay(channel string, data []byte) (*relayEnvelope, error) {
+	plaintext, err := s.relaySealer.open(channel, data)
+	if err != nil {
+		return nil, err
+	}
+	var env relayEnvelope
+	if err := json.Unmarshal(plaintext, &env); err != nil {
+		return nil, err
+	}
+	return &env, nil
+}
+
+// StartRelay subscribes this replica to its relay channel so peers can relay
+// the clients of sessions it holds. It does nothing when no SessionRelay or
+// relay key is wired, the pod address is unknown, or replay is disabled.
+// Shutdown stops it.
+func (s *Server) StartRelay(ctx context.Context) error {
+	if !s.relayEnabled() {
+		return nil
+	}
+	sub, err := s.relay.Subscribe(ctx, relayPodChannel(s.podAddr))
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.relaySub = sub
+	s.mu.Unlock()
+	go s.serveRelay(sub)
+	return nil
+}
+
+// serveRelay handles the envelopes peers send this replica until the
+// subscription closes. Envelopes not sealed with the relay key are dropped.
+func (s *Server) serveRelay(sub RelaySubscription) {
+	channel := relayPodChannel(s.podAddr)
+	for data := range sub.Messages() {
+		env, err := s.openRelay(channel, data)
+		if err != nil {
+			s.log.Error(err, "invalid relay envelope", "channel", channel)
+			continue
+		}
+		switch env.Op {
+		case relayOpAttach:
+			if age := time.Since(time.UnixMilli(env.SentAt)); age > relayAttachMaxAge || age < -relayAttachMaxAge {
+				s.log.Info("stale session relay attach dropped", "relayID", env.RelayID, "age", age.String())
+				continue
+			}
+			s.attachRelay(env)
+		case relayOpClient:
+			s.relayClientMessage(env)
+		case relayOpDetach:
+			s.detachRelay(env.RelayID)
+		}
+	}
+}
+
+// attachRelay resumes the session an attach names on a relayed connection,
+// as tryResume does for a local client, and replies attached followed by the
+// messages the client missed. An attach for a session this replica does not
+// hold, or that belongs to another user, is refused.
+func (s *Server) attachRelay(env *relayEnvelope) {
+	channel := relayConnChannel(env.RelayID)
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.WriteTimeout)
+	defer cancel()
+	if env.Identity == nil || env.RelayID == "" {
+		return
+	}
+
+	rc := s.newRelayedConnection(env.RelayID, env.Identity)
+	s.mu.Lock()
+	if s.shutdown {
+		s.mu.Unlock()
+		_ = s.publishRelay(ctx, channel, &relayEnvelope{Op: relayOpRefused, RelayID: env.RelayID})
+		return
+	}
+	s.relayed[env.RelayID] = rc
+	s.mu.Unlock()
+
+	var err error
+	resumed := s.replays.resume(ctx, rc, env.SessionID, env.LastSeq, func(persisted bool, clientSeq uint64, missed []*ServerMessage) {
+		rc.mu.Lock()
+		rc.sessionID = env.SessionID
+		rc.sessionPersisted = persisted
+		rc.clientSeq = clientSeq
+		rc.mu.Unlock()
+		if err = s.publishRelay(ctx, channel, &relayEnvelope{
+			Op: relayOpAttached, RelayID: env.RelayID, Persisted: persisted, ClientSeq: clientSeq,
+		}); err != nil {
+			return
+		}
+		s.metrics.MessagesRedelivered(len(missed))
+		for _, msg := range missed {
+			if err = s.writeMessage(rc, msg); err != nil {
+				return
+			}
+		}
+	})
+	if !resumed {
+		s.mu.Lock()
+		delete(s.relayed, env.RelayID)
+		s.mu.Unlock()
+		s.log.V(1).Info("session relay refused", "sessionID", env.SessionID, "reason", "miss_or_owner_mismatch")
+		_ = s.publishRelay(ctx, channel, &relayEnvelope{Op: relayOpRefused, RelayID: env.RelayID})
+		return
+	}
+	if err != nil {
+		s.log.V(1).Info("session relay attach failed", "sessionID", env.SessionID, "error", err.Error())
+		s.detachRelay(env.RelayID)
+		return
+	}
+	go s.watchRelay(rc)
+	s.log.V(1).Info("session relayed", "sessionID", env.SessionID, "relayID", env.RelayID)
+}
+
+// newRelayedConnection builds the owner's stand-in for a client connected to
+// another replica. The client-facing replica applies rate limits and the
+// negotiated features, so the stand-in has neither.
+func (s *Server) newRelayedConnection(relayID string, id *relayIdentity) *Connection {
+	ctx := logctx.WithAgent(context.Background(), id.AgentName)
+	ctx = logctx.WithNamespace(ctx, id.Namespace)
+	ctx = logctx.WithRequestID(ctx, uuid.New().String())
+	ctx = policy.WithPropagationFields(ctx, id.Propagation)
+	rc := &Connection{
+		id:            uuid.New().String(),
+		agentName:     id.AgentName,
+		namespace:     id.Namespace,
+		workspaceName: id.WorkspaceName,
+		userID:        id.UserID,
+		userEmail:     id.UserEmail,
+		authorization: id.Authorization,
+		cohortID:      id.CohortID,
+		variant:       id.Variant,
+		connectedAt:   time.Now(),
+	}
+	ctx = WithConnectionID(ctx, rc.id)
+	rc.peer = &relayPeer{relayID: relayID, ctx: ctx, done: make(chan struct{})}
+	rc.turnCtx, rc.cancelTurns = context.WithCancel(ctx)
+	if s.config.MaxInFlightMessagesPerConnection > 0 {
+		rc.inFlightMessages = make(chan struct{}, s.config.MaxInFlightMessagesPerConnection)
+	}
+	return rc
+}
+
+// relayClientMessage handles a client message relayed from the client-facing
+// replica as the read loop handles one from a socket.
+func (s *Server) relayClientMessage(env *relayEnvelope) {
+	s.mu.RLock()
+	rc := s.relayed[env.RelayID]
+	s.mu.RUnlock()
+	if rc == nil {
+		return
+	}
+	s.metrics.MessageReceived()
+	rc.received.Add(1)
+	ctx := rc.peer.ctx
+	s.handleClientMessage(ctx, rc, env.Client, logctx.LoggerWithContext(s.log, ctx))
+}
+
+// writeRelayed publishes msg to the replica the connection's client is on. A
+// relay with nobody listening means that replica is gone, and the connection
+// is closed as a dropped client would be.
+func (s *Server) writeRelayed(c *Connection, msg *ServerMessage) error {
+	ctx, cancel := context.WithTimeout(c.peer.ctx, s.config.WriteTimeout)
+	defer cancel()
+	err := s.publishRelay(ctx, relayConnChannel(c.peer.relayID), &relayEnvelope{
+		Op: relayOpMessage, RelayID: c.peer.relayID, Message: msg,
+	})
+	if errors.Is(err, ErrNoRelaySubscriber) {
+		go s.detachRelay(c.peer.relayID)
+	}
+	if err == nil {
+		s.metrics.MessageSent()
+		c.sent.Add(1)
+	}
+	return err
+}
+
+// watchRelay pings the client-facing replica every PingInterval, so a relay
+// whose replica died is detached even when the session is idle.
+func (s *Server) watchRelay(rc *Connection) {
+	ticker := time.NewTicker(s.config.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-rc.peer.done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(rc.peer.ctx, s.config.WriteTimeout)
+			err := s.publishRelay(ctx, relayConnChannel(rc.peer.relayID), &relayEnvelope{
+				Op: relayOpPing, RelayID: rc.peer.relayID,
+			})
+			cancel()
+			if errors.Is(err, ErrNoRelaySubscriber) {
+				s.detachRelay(rc.peer.relayID)
+				return
+			}
+		}
+	}
+}
+
+// detachRelay ends a relayed connection as cleanupConnection ends a local
+// one: the session is held for a resume unless the client hung up.
+func (s *Server) detachRelay(relayID string) {
+	s.mu.Lock()
+	rc, ok := s.relayed[relayID]
+	delete(s.relayed, relayID)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+	rc.mu.Lock()
+	rc.closed = true
+	rc.mu.Unlock()
+	close(rc.peer.done)
+
+	held := s.holdForResume(rc, false)
+	s.metrics.ConnectionClosed()
+	sessionID := rc.SessionID()
+	if !held && sessionID != "" && rc.SessionPersisted() {
+		s.metrics.SessionClosed()
+		s.completeSession(sessionID, logctx.LoggerWithContext(s.log, rc.peer.ctx))
+	}
+	s.log.V(1).Info("session relay detached", "sessionID", sessionID, "relayID", relayID)
+}
+
+// stopRelay unsubscribes from the relay channel and detaches every relayed
+// connection, telling the client-facing replicas to drop their clients so
+// they reconnect. Called by Shutdown.
+func (s *Server) stopRelay() {
+	s.mu.Lock()
+	sub := s.relaySub
+	s.relaySub = nil
+	relayIDs := make([]string, 0, len(s.relayed))
+	for id := range s.relayed {
+		relayIDs = append(relayIDs, id)
+	}
+	s.mu.Unlock()
+	if sub == nil {
+		return
+	}
+	_ = sub.Close()
+
+	for _, id := range relayIDs {
+		ctx, cancel := context.WithTimeout(context.Background(), s.config.WriteTimeout)
+		_ = s.publishRelay(ctx, relayConnChannel(id), &relayEnvelope{Op: relayOpDetach, RelayID: id})
+		cancel()
+		s.detachRelay(id)
+	}
+}
+
+// tryRelay is the client-facing half of a relay. When the session c resumes
+// has a route hint naming another replica, it asks that replica to run the
+// session for c and, once accepted, sends connected with resumed=true and
+// forwards the owner's messages to c. Returns false to fall through to a
+// fresh session.
+func (s *Server) tryRelay(ctx context.Context, c *Connection) (bool, error) {
+	resolver, ok := s.routeStore.(RouteResolver)
+	if c.resumeID == "" || !ok || !s.relayEnabled() {
+		return false, nil
+	}
+	attachCtx, cancel := context.WithTimeout(ctx, relayAttachTimeout)
+	defer cancel()
+	addr, err := resolver.GetRoute(attachCtx, c.resumeID)
+	if err != nil {
+		s.log.Error(err, "route hint read failed", "sessionID", c.resumeID)
+		return false, nil
+	}
+	if addr == "" || addr == s.podAddr {
+		return false, nil
+	}
+
+	relayID := uuid.New().String()
+	sub, err := s.relay.Subscribe(attachCtx, relayConnChannel(relayID))
+	if err != nil {
+		s.log.Error(err, "session relay subscribe failed", "sessionID", c.resumeID)
+		return false, nil
+	}
+	reply, err := s.requestRelay(attachCtx, c, addr, relayID, sub)
+	if err != nil || reply.Op != relayOpAttached {
+		_ = sub.Close()
+		s.log.V(1).Info("session relay miss", "sessionID", c.resumeID, "owner", addr)
+		return false, nil
+	}
+
+	up := &relayUpstream{relayID: relayID, channel: relayPodChannel(addr), sub: sub}
+	c.mu.Lock()
+	c.sessionID = c.resumeID
+	c.sessionPersisted = reply.Persisted
+	c.clientSeq = reply.ClientSeq
+	c.upstream = up
+	c.mu.Unlock()
+	if err := s.sendConnected(c, c.resumeID, true); err != nil {
+		return true, err
+	}
+	go s.forwardRelayed(c, relayID, sub)
+	s.log.V(1).Info("session relayed to owner", "sessionID", c.resumeID, "owner", addr)
+	return true, nil
+}
+
+// requestRelay publishes an attach for c to the owner at addr and waits for
+// its reply on sub.
+func (s *Server) requestRelay(ctx context.Context, c *Connection, addr, relayID string, sub RelaySubscription) (*relayEnvelope, error) {
+	fields := policy.ExtractPropagationFields(ctx)
+	fields.Identity = nil
+	if err := s.publishRelay(ctx, relayPodChannel(addr), &relayEnvelope{
+		Op:        relayOpAttach,
+		RelayID:   relayID,
+		SessionID: c.resumeID,
+		LastSeq:   c.lastSeq,
+		Identity: &relayIdentity{
+			AgentName:     c.agentName,
+			Namespace:     c.namespace,
+			WorkspaceName: c.workspaceName,
+			UserID:        c.userID,
+			UserEmail:     c.userEmail,
+			Authorization: c.authorization,
+			CohortID:      c.cohortID,
+			Variant:       c.variant,
+			Propagation:   &fields,
+		},
+	}); err != nil {
+		return nil, err
+	}
+	select {
+	case data, ok := <-sub.Messages():
+		if !ok {
+			return nil, errors.New("session relay closed")
+		}
+		return s.openRelay(relayConnChannel(relayID), data)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// forwardRelayed writes the owner's messages to c until the relay closes. A
+// detach from the owner closes c so its client reconnects.
+func (s *Server) forwardRelayed(c *Connection, relayID string, sub RelaySubscription) {
+	channel := relayConnChannel(relayID)
+	for data := range sub.Messages() {
+		env, err := s.openRelay(channel, data)
+		if err != nil {
+			s.log.Error(err, "invalid relay envelope", "channel", channel)
+			continue
+		}
+		switch env.Op {
+		case relayOpMessage:
+			if env.Message == nil {
+				continue
+			}
+			if err := s.writeMessage(c, env.Message); err != nil {
+				s.log.V(1).Info("failed to write relayed message", "sessionID", c.SessionID(), "error", err.Error())
+			}
+		case relayOpDetach:
+			// Let what the owner sent last, such as a termination error,
+			// reach the client before it is dropped.
+			s.flushOutbound(c)
+			c.abort()
+			return
+		}
+	}
+}
+
+// relayUpstream returns the relay c's session runs through, or nil when the
+// session is run here.
+func (c *Connection) relayUpstream() *relayUpstream {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.upstream
+}
+
+// forwardClientMessage sends a client message to the owner of c's session.
+// An owner that is gone closes c, so its client reconnects.
+func (s *Server) forwardClientMessage(ctx context.Context, c *Connection, up *relayUpstream, message []byte) {
+	if !json.Valid(message) {
+		s.sendError(c, "", ErrorCodeInvalidMessage, "invalid message format")
+		return
+	}
+	pubCtx, cancel := context.WithTimeout(ctx, s.config.WriteTimeout)
+	defer cancel()
+	err := s.publishRelay(pubCtx, up.channel, &relayEnvelope{Op: relayOpClient, RelayID: up.relayID, Client: message})
+	if err == nil {
+		return
+	}
+	s.log.V(1).Info("session relay forward failed", "sessionID", c.SessionID(), "error", err.Error())
+	if errors.Is(err, ErrNoRelaySubscriber) {
+		c.abort()
+		return
+	}
+	s.sendError(c, c.SessionID(), ErrorCodeInternalError, "session relay unavailable")
+}
+
+// closeUpstream ends c's relay, if any, telling the owner to hold the session
+// for a resume. Returns false when c's session is run here.
+func (s *Server) closeUpstream(c *Connection) bool {
+	c.mu.Lock()
+	up := c.upstream
+	c.upstream = nil
+	c.mu.Unlock()
+	if up == nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.WriteTimeout)
+	defer cancel()
+	if err := s.publishRelay(ctx, up.channel, &relayEnvelope{Op: relayOpDetach, RelayID: up.relayID}); err != nil {
+		s.log.V(1).Info("session relay detach failed", "sessionID", c.SessionID(), "error", err.Error())
+	}
+	_ = up.sub.Close()
+	return true
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+)
+
+// MinSessionRelayKeyLen is the shortest key WithSessionRelay accepts.
+const MinSessionRelayKeyLen = 32
+
+// errRelaySeal is returned for an envelope that was not sealed with this
+// replica's key for the channel it arrived on.
+var errRelaySeal = errors.New("session relay: envelope not sealed with the relay key")
+
+// relaySealer encrypts and authenticates relay envelopes with a key shared by
+// an agent's replicas. An attach carries the client's identity and
+// Authorization header, so envelopes are sealed rather than trusted: anyone
+// who can publish to the relay Redis but lacks the key can neither read them
+// nor forge one. The channel is bound as associated data, so an envelope
+// cannot be replayed onto another channel.
+type relaySealer struct {
+	aead cipher.AEAD
+}
+
+// newRelaySealer returns a sealer for key, or nil when key is shorter than
+// MinSessionRelayKeyLen.
+func newRelaySealer(key []byte) *relaySealer {
+	if len(key) < MinSessionRelayKeyLen {
+		return nil
+	}
+	sum := sha256.Sum256(key)
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil
+	}
+	return &relaySealer{aead: aead}
+}
+
+// seal returns nonce || ciphertext for plaintext published on channel.
+func (r *relaySealer) seal(channel string, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, r.aead.NonceSize(), r.aead.NonceSize()+len(plaintext)+r.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return r.aead.Seal(nonce, nonce, plaintext, []byte(channel)), nil
+}
+
+// open verifies and decrypts data received on channel.
+func (r *relaySealer) open(channel string, data []byte) ([]byte, error) {
+	n := r.aead.NonceSize()
+	if len(data) < n+r.aead.Overhead() {
+		return nil, errRelaySeal
+	}
+	plaintext, err := r.aead.Open(nil, data[:n], data[n:], []byte(channel))
+	if err != nil {
+		return nil, errRelaySeal
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,361 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/internal/session/sessiontest"
+)
+
+// testRelayKey is the relay key shared by test replicas.
+var testRelayKey = []byte("0123456789abcdef0123456789abcdef")
+
+// memRouteStore is a RouteStore and RouteResolver shared by test servers
+// standing in for an agent's replicas.
+type memRouteStore struct {
+	mu     sync.Mutex
+	routes map[string]string
+}
+
+func newMemRouteStore() *memRouteStore {
+	return &memRouteStore{routes: make(map[string]string)}
+}
+
+func (m *memRouteStore) PutRoute(_ context.Context, sessionID, addr string, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.routes[sessionID] = addr
+	return nil
+}
+
+func (m *memRouteStore) DeleteRoute(_ context.Context, sessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.routes, sessionID)
+	return nil
+}
+
+func (m *memRouteStore) GetRoute(_ context.Context, sessionID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.routes[sessionID], nil
+}
+
+// memRelay is an in-process SessionRelay.
+type memRelay struct {
+	mu   sync.Mutex
+	subs map[string][]*memRelaySub
+}
+
+type memRelaySub struct {
+	relay    *memRelay
+	channel  string
+	messages chan []byte
+	once     sync.Once
+}
+
+func newMemRelay() *memRelay {
+	return &memRelay{subs: make(map[string][]*memRelaySub)}
+}
+
+func (m *memRelay) Publish(_ context.Context, channel string, payload []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subs := m.subs[channel]
+	if len(subs) == 0 {
+		return ErrNoRelaySubscriber
+	}
+	for _, sub := range subs {
+		sub.messages <- payload
+	}
+	return nil
+}
+
+func (m *memRelay) Subscribe(_ context.Context, channel string) (RelaySubscription, error) {
+	sub := &memRelaySub{relay: m, channel: channel, messages: make(chan []byte, 256)}
+	m.mu.Lock()
+	m.subs[channel] = append(m.subs[channel], sub)
+	m.mu.Unlock()
+	return sub, nil
+}
+
+func (s *memRelaySub) Messages() <-chan []byte { return s.messages }
+
+func (s *memRelaySub) Close() error {
+	s.once.Do(func() {
+		s.relay.mu.Lock()
+		defer s.relay.mu.Unlock()
+		subs := s.relay.subs[s.channel]
+		for i, sub := range subs {
+			if sub == s {
+				s.relay.subs[s.channel] = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+		close(s.messages)
+	})
+	return nil
+}
+
+func newRelayServer(t *testing.T, handler MessageHandler, podAddr string, routes *memRouteStore, relay *memRelay) (*Server, *httptest.Server) {
+	t.Helper()
+	store := sessiontest.NewStore()
+	server := NewServer(DefaultServerConfig(), store, handler, logr.Discard(),
+		WithGraceWindow(5*time.Second), WithPodAddr(podAddr),
+		WithRouteStore(routes), WithSessionRelay(relay, testRelayKey))
+	if err := server.StartRelay(context.Background()); err != nil {
+		t.Fatalf("StartRelay: %v", err)
+	}
+	ts := httptest.NewServer(server)
+	t.Cleanup(func() {
+		ts.Close()
+		_ = server.Shutdown(context.Background())
+		_ = store.Close()
+	})
+	return server, ts
+}
+
+// relayedSession starts a turn on owner, drops its client, and resumes the
+// session on the other replica after the first chunk.
+func relayedSession(t *testing.T, owner *Server, tsOwner, tsOther *httptest.Server) (string, func() ServerMessage, func(ClientMessage)) {
+	t.Helper()
+	ws, sessionID := startTurn(t, tsOwner)
+	_ = ws.Close()
+	waitFor(t, func() bool { return owner.ConnectionCount() == 0 })
+
+	ws = dialReplay(t, tsOther, resumeQuery(sessionID, 1))
+	if connected := readServerMessage(t, ws); connected.Connected == nil || !connected.Connected.Resumed || connected.SessionID != sessionID {
+		t.Fatalf("connected = %+v, want the resumed session %s", connected, sessionID)
+	}
+	read := func() ServerMessage { return readServerMessage(t, ws) }
+	write := func(msg ClientMessage) {
+		if err := ws.WriteJSON(msg); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	t.Cleanup(func() { _ = ws.Close() })
+	return sessionID, read, write
+}
+
+func TestRelay_ResumeOnAnotherReplicaReachesLiveSession(t *testing.T) {
+	routes, relay := newMemRouteStore(), newMemRelay()
+	handler := newGatedHandler()
+	owner, tsA := newRelayServer(t, handler, "10.0.0.1:8080", routes, relay)
+	_, tsB := newRelayServer(t, &mockHandler{}, "10.0.0.2:8080", routes, relay)
+
+	sessionID, read, write := relayedSession(t, owner, tsA, tsB)
+
+	// The turn still running on the owner streams to the client on the
+	// other replica, numbered on from the first chunk.
+	close(handler.release)
+	if msg := read(); msg.Content != "two" || msg.Seq != 2 {
+		t.Fatalf("message = %+v, want chunk \"two\" with seq 2", msg)
+	}
+	if msg := read(); msg.Type != MessageTypeDone || msg.Seq != 3 {
+		t.Fatalf("message = %+v, want done with seq 3", msg)
+	}
+	<-handler.ctxErr
+
+	// A new message is handled by the owner's handler, not the relaying
+	// replica's.
+	write(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "again"})
+	for _, want := range []string{"one", "two"} {
+		if msg := read(); msg.Content != want {
+			t.Fatalf("message = %+v, want chunk %q from the owner", msg, want)
+		}
+	}
+	if msg := read(); msg.Type != MessageTypeDone || msg.Seq != 6 {
+		t.Fatalf("message = %+v, want done with seq 6", msg)
+	}
+	if addr, _ := routes.GetRoute(context.Background(), sessionID); addr != "" {
+		t.Error("route hint kept while the session is relayed")
+	}
+}
+
+func TestRelay_ClientDropHoldsSessionOnOwner(t *testing.T) {
+	routes, relay := newMemRouteStore(), newMemRelay()
+	handler := newGatedHandler()
+	t.Cleanup(func() { close(handler.release) })
+	owner, tsA := newRelayServer(t, handler, "10.0.0.1:8080", routes, relay)
+	relaying, tsB := newRelayServer(t, &mockHandler{}, "10.0.0.2:8080", routes, relay)
+
+	sessionID, _, _ := relayedSession(t, owner, tsA, tsB)
+	relaying.mu.RLock()
+	var clients []*Connection
+	for _, c := range relaying.connections {
+		clients = append(clients, c)
+	}
+	relaying.mu.RUnlock()
+	for _, c := range clients {
+		c.abort()
+	}
+
+	waitFor(t, func() bool {
+		addr, _ := routes.GetRoute(context.Background(), sessionID)
+		return addr == "10.0.0.1:8080"
+	})
+	owner.mu.RLock()
+	relayed := len(owner.relayed)
+	owner.mu.RUnlock()
+	if relayed != 0 {
+		t.Errorf("owner kept %d relayed connections after the client dropped", relayed)
+	}
+	if owner.replays.len() != 1 {
+		t.Error("owner dropped the session instead of holding it for a resume")
+	}
+}
+
+func TestRelay_OwnerShutdownDropsRelayedClient(t *testing.T) {
+	routes, relay := newMemRouteStore(), newMemRelay()
+	handler := newGatedHandler()
+	t.Cleanup(func() { close(handler.release) })
+	owner, tsA := newRelayServer(t, handler, "10.0.0.1:8080", routes, relay)
+	relaying, tsB := newRelayServer(t, &mockHandler{}, "10.0.0.2:8080", routes, relay)
+
+	_, _, _ = relayedSession(t, owner, tsA, tsB)
+	if err := owner.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return relaying.ConnectionCount() == 0 })
+}
+
+func TestRelay_RefusedResumeStartsFreshSession(t *testing.T) {
+	routes, relay := newMemRouteStore(), newMemRelay()
+	newRelayServer(t, &mockHandler{}, "10.0.0.1:8080", routes, relay)
+	_, tsB := newRelayServer(t, &mockHandler{}, "10.0.0.2:8080", routes, relay)
+	// A stale hint: the owner holds nothing for the session.
+	_ = routes.PutRoute(context.Background(), "sess-1", "10.0.0.1:8080", time.Minute)
+
+	ws := dialReplay(t, tsB, resumeQuery("sess-1", 0))
+	if connected := readServerMessage(t, ws); connected.Connected.Resumed || connected.SessionID == "sess-1" {
+		t.Fatalf("connected = %+v, want a fresh session", connected)
+	}
+}
+
+func TestRelay_ForgedAttachRefused(t *testing.T) {
+	routes, relay := newMemRouteStore(), newMemRelay()
+	handler := newGatedHandler()
+	t.Cleanup(func() { close(handler.release) })
+	owner, tsA := newRelayServer(t, handler, "10.0.0.1:8080", routes, relay)
+
+	ws, sessionID := startTurn(t, tsA)
+	_ = ws.Close()
+	waitFor(t, func() bool { return owner.ConnectionCount() == 0 })
+
+	attach := func(relayID string) []byte {
+		env := relayEnvelope{
+			Op: relayOpAttach, RelayID: relayID, SessionID: sessionID, SentAt: time.Now().UnixMilli(),
+			Identity: &relayIdentity{AgentName: "agent", Namespace: "ns", UserID: "someone-else"},
+		}
+		data, err := json.Marshal(env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	podChannel := relayPodChannel("10.0.0.1:8080")
+
+	for name, payload := range map[string]func(relayID string) []byte{
+		"unsealed": attach,
+		"wrong key": func(relayID string) []byte {
+			sealed, err := newRelaySealer([]byte("not-the-relay-key-not-the-relay-key")).seal(podChannel, attach(relayID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return sealed
+		},
+		"other channel": func(relayID string) []byte {
+			sealed, err := newRelaySealer(testRelayKey).seal(relayConnChannel(relayID), attach(relayID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return sealed
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			relayID := name
+			sub, _ := relay.Subscribe(context.Background(), relayConnChannel(relayID))
+			defer func() { _ = sub.Close() }()
+			if err := relay.Publish(context.Background(), podChannel, payload(relayID)); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case <-sub.Messages():
+				t.Fatal("owner replied to a forged attach")
+			case <-time.After(100 * time.Millisecond):
+			}
+			owner.mu.RLock()
+			_, attached := owner.relayed[relayID]
+			owner.mu.RUnlock()
+			if attached {
+				t.Fatal("owner attached a forged relay")
+			}
+		})
+	}
+}
+
+func TestRelay_StaleAttachDropped(t *testing.T) {
+	routes, relay := newMemRouteStore(), newMemRelay()
+	handler := newGatedHandler()
+	t.Cleanup(func() { close(handler.release) })
+	owner, tsA := newRelayServer(t, handler, "10.0.0.1:8080", routes, relay)
+
+	ws, sessionID := startTurn(t, tsA)
+	_ = ws.Close()
+	waitFor(t, func() bool { return owner.ConnectionCount() == 0 })
+
+	data, err := json.Marshal(relayEnvelope{
+		Op: relayOpAttach, RelayID: "stale", SessionID: sessionID,
+		SentAt:   time.Now().Add(-2 * relayAttachMaxAge).UnixMilli(),
+		Identity: &relayIdentity{AgentName: "agent", Namespace: "ns"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	podChannel := relayPodChannel("10.0.0.1:8080")
+	sealed, err := newRelaySealer(testRelayKey).seal(podChannel, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub, _ := relay.Subscribe(context.Background(), relayConnChannel("stale"))
+	defer func() { _ = sub.Close() }()
+	if err := relay.Publish(context.Background(), podChannel, sealed); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-sub.Messages():
+		t.Fatal("owner replied to a stale attach")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRelay_DisabledWithoutKey(t *testing.T) {
+	server := NewServer(DefaultServerConfig(), sessiontest.NewStore(), &mockHandler{}, logr.Discard(),
+		WithPodAddr("10.0.0.1:8080"), WithRouteStore(newMemRouteStore()),
+		WithSessionRelay(newMemRelay(), []byte("short")))
+	if server.HasSessionRelay() || server.relayEnabled() {
+		t.Error("relay enabled with a key shorter than MinSessionRelayKeyLen")
+	}
+}
@@ -0,0 +1,408 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// replayLog keeps the most recent messages sent for one session, each stamped
+// with a per-session sequence number, so a client that drops and reconnects
+// with ?resume=<session_id>&last_seq=<n> receives what it missed.
+//
+// Messages are delivered through the log rather than to a fixed connection:
+// a turn that started on a dropped connection keeps streaming into the log
+// and reaches whichever connection resumed the session.
+type replayLog struct {
+	sessionID string
+	ownerID   string
+	size      int
+
+	mu       sync.Mutex
+	seq      uint64
+	messages []*ServerMessage // the last size messages, oldest first
+	// conn is the connection messages are delivered to; nil while the log
+	// waits for a resume.
+	conn      *Connection
+	persisted bool
+	// turnOpen is set while a turn's done or error has not been sent yet. A
+	// session dropped with a turn open is completed when the resume window
+	// closes rather than on disconnect.
+	turnOpen bool
+	// complete records whether expiry should complete the archived session:
+	// a turn was open when the connection dropped, and the session's audio
+	// stream was not parked as well (the realtime registry completes those).
+	complete bool
+	// cancels stop the in-flight turns of connections that dropped while the
+	// log was held; called when the log expires without a resume.
+	cancels []context.CancelFunc
+	timer   *time.Timer
+	// clientSeq is the seq of the last client message accepted for the
+	// session; client messages numbered at or below it are resends.
+	clientSeq uint64
+	// acked is the seq of the last message the client acknowledged. Messages
+	// up to it are dropped from the log and never redelivered.
+	acked uint64
+}
+
+// send stamps msg with the next sequence number, keeps it for replay, and
+// writes it to the attached connection, if any. A message produced while the
+// log waits for a resume is kept only.
+func (l *replayLog) send(s *Server, msg *ServerMessage) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.seq++
+	msg.Seq = l.seq
+	if msg.Type == MessageTypeDone || msg.Type == MessageTypeError {
+		l.turnOpen = false
+	}
+	l.messages = append(l.messages, msg)
+	if over := len(l.messages) - l.size; over > 0 {
+		l.messages = l.messages[over:]
+	}
+	if l.conn == nil {
+		return nil
+	}
+	return s.writeMessage(l.conn, msg)
+}
+
+// sendEphemeral writes msg to the connection attached to the session, if any,
+// without logging it for replay.
+func (l *replayLog) sendEphemeral(s *Server, msg *ServerMessage) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn == nil {
+		return nil
+	}
+	return s.writeMessage(l.conn, msg)
+}
+
+// acknowledge drops the messages up to seq, which the client confirmed it
+// received, so a resume never redelivers them.
+func (l *replayLog) acknowledge(seq uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	seq = min(seq, l.seq)
+	if seq <= l.acked {
+		return
+	}
+	l.acked = seq
+	i := 0
+	for i < len(l.messages) && l.messages[i].Seq <= seq {
+		i++
+	}
+	l.messages = l.messages[i:]
+}
+
+// acceptClientSeq records seq as the session's latest client message and
+// reports whether it is new rather than a resend.
+func (l *replayLog) acceptClientSeq(seq uint64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if seq <= l.clientSeq {
+		return false
+	}
+	l.clientSeq = seq
+	return true
+}
+
+// beginTurn marks a turn open on the connection's replay log until its done
+// or error is sent.
+func (c *Connection) beginTurn() {
+	c.mu.Lock()
+	rl := c.replay
+	c.mu.Unlock()
+	if rl == nil {
+		return
+	}
+	rl.mu.Lock()
+	rl.turnOpen = true
+	rl.mu.Unlock()
+}
+
+// replayRegistry holds the replay logs of this pod's sessions, including those
+// of dropped connections waiting out the grace window for a resume.
+type replayRegistry struct {
+	mu       sync.Mutex
+	logs     map[string]*replayLog
+	size     int
+	routes   RouteStore
+	handoffs HandoffStore
+	podAddr  string
+	grace    time.Duration
+	log      logr.Logger
+	onExpire func(sessionID string, complete bool)
+}
+
+func newReplayRegistry(size int, routes RouteStore, podAddr string, grace time.Duration, log logr.Logger) *replayRegistry {
+	return &replayRegistry{
+		logs:    make(map[string]*replayLog),
+		size:    size,
+		routes:  routes,
+		podAddr: podAddr,
+		grace:   grace,
+		log:     log,
+	}
+}
+
+// enabled reports whether messages are logged for replay at all. A nil
+// registry, as on a Server not built by NewServer, logs nothing.
+func (r *replayRegistry) enabled() bool {
+	return r != nil && r.size > 0
+}
+
+// bind attaches c to the replay log of sessionID, creating it if absent. A log
+// held for a resume by the same owner is taken over, so a client that names
+// its session in a message instead of resuming still receives the rest of the
+// in-flight turn. Connections that joined a shared session are not logged:
+// the session's owner already is.
+func (r *replayRegistry) bind(c *Connection, sessionID string) {
+	if !r.enabled() || sessionID == "" {
+		return
+	}
+	c.mu.Lock()
+	previous, joined, clientSeq := c.replay, c.joined, c.clientSeq
+	c.mu.Unlock()
+	if joined || (previous != nil && previous.sessionID == sessionID) {
+		return
+	}
+
+	r.mu.Lock()
+	rl, ok := r.logs[sessionID]
+	switch {
+	case !ok:
+		rl = &replayLog{sessionID: sessionID, ownerID: c.userID, size: r.size, conn: c, persisted: true, clientSeq: clientSeq}
+		r.logs[sessionID] = rl
+	case rl.ownerID == c.userID:
+		rl.mu.Lock()
+		r.attachLocked(rl, c)
+		rl.clientSeq = max(rl.clientSeq, clientSeq)
+		rl.mu.Unlock()
+	default:
+		r.mu.Unlock()
+		return
+	}
+	r.mu.Unlock()
+
+	c.mu.Lock()
+	c.replay = rl
+	c.mu.Unlock()
+
+	// The connection moved on to another session; the previous log has no
+	// one left to replay it to.
+	if previous != nil {
+		r.drop(previous, c)
+	}
+}
+
+// resume attaches c to the log of sessionID when one exists and is owned by
+// c.userID, adopting one a draining peer handed off if this pod has none, then
+// calls deliver with the seq of the last client message accepted and the
+// messages after lastSeq that the client has not acknowledged. deliver runs
+// before any message produced from here on is written, so the connection sees
+// the replayed messages and the live stream in sequence order. Returns false
+// on miss or owner mismatch.
+func (r *replayRegistry) resume(ctx context.Context, c *Connection, sessionID string, lastSeq uint64, deliver func(persisted bool, clientSeq uint64, missed []*ServerMessage)) bool {
+	if !r.enabled() || sessionID == "" {
+		return false
+	}
+	r.adopt(ctx, sessionID, c.userID)
+	r.mu.Lock()
+	rl, ok := r.logs[sessionID]
+	if !ok || rl.ownerID != c.userID {
+		r.mu.Unlock()
+		return false
+	}
+	rl.mu.Lock()
+	r.attachLocked(rl, c)
+	r.mu.Unlock()
+	defer rl.mu.Unlock()
+
+	if err := r.routes.DeleteRoute(ctx, sessionID); err != nil {
+		r.log.Error(err, "replay route hint delete failed", "sessionID", sessionID)
+	}
+
+	var missed []*ServerMessage
+	for _, msg := range rl.messages {
+		if msg.Seq > lastSeq {
+			missed = append(missed, msg)
+		}
+	}
+	c.mu.Lock()
+	c.replay = rl
+	c.mu.Unlock()
+	deliver(rl.persisted, rl.clientSeq, missed)
+	r.log.V(1).Info("session resumed with replay", "sessionID", sessionID,
+		"lastSeq", lastSeq, "replayed", len(missed))
+	return true
+}
+
+// owner returns the user that owns the log of sessionID, when this pod holds
+// one.
+func (r *replayRegistry) owner(sessionID string) (string, bool) {
+	if !r.enabled() {
+		return "", false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rl, ok := r.logs[sessionID]
+	if !ok {
+		return "", false
+	}
+	return rl.ownerID, true
+}
+
+// attachLocked points rl at c and disarms its expiry. Callers hold r.mu and
+// rl.mu.
+func (r *replayRegistry) attachLocked(rl *replayLog, c *Connection) {
+	if rl.timer != nil {
+		rl.timer.Stop()
+		rl.timer = nil
+	}
+	rl.conn = c
+}
+
+// hold is called when c drops without hanging up. If c is attached to its
+// log, the log is kept for the grace window, the route hint written, and
+// c's in-flight turns left running so their output can be replayed. If
+// another connection has already resumed the session, c's turns keep
+// streaming to it. Either way cancelTurns is called once the log expires.
+//
+// held is false when c has no log, meaning nothing was kept. pending is true
+// when the session must not be completed yet: a turn was still open, or the
+// session lives on in another connection.
+func (r *replayRegistry) hold(ctx context.Context, c *Connection, parked bool, cancelTurns context.CancelFunc) (held, pending bool) {
+	if !r.enabled() {
+		return false, false
+	}
+	c.mu.Lock()
+	rl, persisted := c.replay, c.sessionPersisted
+	c.mu.Unlock()
+	if rl == nil {
+		return false, false
+	}
+
+	r.mu.Lock()
+	if r.logs[rl.sessionID] != rl {
+		r.mu.Unlock()
+		return false, false
+	}
+	rl.mu.Lock()
+	rl.cancels = append(rl.cancels, cancelTurns)
+	detached := rl.conn == c
+	pending = !detached || rl.turnOpen
+	if detached {
+		rl.conn = nil
+		rl.persisted = persisted
+		rl.complete = rl.turnOpen && !parked
+		rl.timer = time.AfterFunc(r.grace, func() { r.expire(rl) })
+	}
+	rl.mu.Unlock()
+	r.mu.Unlock()
+
+	if detached {
+		if err := r.routes.PutRoute(ctx, rl.sessionID, r.podAddr, r.grace); err != nil {
+			r.log.Error(err, "replay route hint write failed", "sessionID", rl.sessionID)
+		}
+		r.log.V(1).Info("session held for resume", "sessionID", rl.sessionID, "turnOpen", pending)
+	}
+	return true, pending
+}
+
+// drop discards rl if c is still the connection it delivers to, stopping the
+// turns it was holding. Used when a connection ends its session for good:
+// hangup, shutdown, or moving on to another session.
+func (r *replayRegistry) drop(rl *replayLog, c *Connection) {
+	r.mu.Lock()
+	rl.mu.Lock()
+	owned := r.logs[rl.sessionID] == rl && rl.conn == c
+	if owned {
+		delete(r.logs, rl.sessionID)
+	}
+	cancels := rl.cancels
+	rl.mu.Unlock()
+	r.mu.Unlock()
+	if !owned {
+		return
+	}
+	for _, cancel := range cancels {
+		cancel()
+	}
+}
+
+// expire fires when the grace window elapses without a resume: the log is
+// discarded, its held turns cancelled, the route hint deleted, and onExpire
+// invoked.
+func (r *replayRegistry) expire(rl *replayLog) {
+	r.mu.Lock()
+	rl.mu.Lock()
+	if r.logs[rl.sessionID] != rl || rl.conn != nil {
+		rl.mu.Unlock()
+		r.mu.Unlock()
+		return
+	}
+	delete(r.logs, rl.sessionID)
+	cancels, complete := rl.cancels, rl.complete && rl.persisted
+	rl.mu.Unlock()
+	r.mu.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+	if err := r.routes.DeleteRoute(context.Background(), rl.sessionID); err != nil {
+		r.log.Error(err, "replay route hint delete failed", "sessionID", rl.sessionID)
+	}
+	if r.onExpire != nil {
+		r.onExpire(rl.sessionID, complete)
+	}
+	r.log.V(1).Info("held session expired", "sessionID", rl.sessionID)
+}
+
+// evict expires sessionID's log now, as if its grace window had elapsed, when
+// it is held for a resume. Reports whether a held log was found.
+func (r *replayRegistry) evict(sessionID string) bool {
+	if !r.enabled() {
+		return false
+	}
+	r.mu.Lock()
+	rl := r.logs[sessionID]
+	r.mu.Unlock()
+	if rl == nil {
+		return false
+	}
+	rl.mu.Lock()
+	held := rl.conn == nil
+	if held && rl.timer != nil {
+		rl.timer.Stop()
+	}
+	rl.mu.Unlock()
+	if held {
+		r.expire(rl)
+	}
+	return held
+}
+
+// len reports the number of replay logs, held or attached (test/metrics).
+func (r *replayRegistry) len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.logs)
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/websocket"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/sessiontest"
+)
+
+// gatedHandler streams "one", waits for release, then streams "two" and
+// finishes, so a test can drop the connection mid-turn.
+type gatedHandler struct {
+	release chan struct{}
+	ctxErr  chan error
+}
+
+func newGatedHandler() *gatedHandler {
+	return &gatedHandler{release: make(chan struct{}), ctxErr: make(chan error, 1)}
+}
+
+func (h *gatedHandler) Name() string { return "gated" }
+
+func (h *gatedHandler) HandleMessage(ctx context.Context, _ string, _ *ClientMessage, w ResponseWriter) error {
+	if err := w.WriteChunk("one"); err != nil {
+		return err
+	}
+	select {
+	case <-h.release:
+	case <-ctx.Done():
+	}
+	h.ctxErr <- ctx.Err()
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err := w.WriteChunk("two"); err != nil {
+		return err
+	}
+	return w.WriteDone("one two")
+}
+
+func newReplayServer(t *testing.T, handler MessageHandler, grace time.Duration) (*Server, *httptest.Server, *sessiontest.Store) {
+	t.Helper()
+	store := sessiontest.NewStore()
+	server := NewServer(DefaultServerConfig(), store, handler, logr.Discard(), WithGraceWindow(grace))
+	ts := httptest.NewServer(server)
+	t.Cleanup(func() {
+		ts.Close()
+		_ = store.Close()
+	})
+	return server, ts, store
+}
+
+func dialReplay(t *testing.T, ts *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+	ws, _, err := websocket.DefaultDialer.Dial(wsURL(ts.URL)+"?agent=test-agent&device_id=dev-1"+query, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = ws.Close() })
+	return ws
+}
+
+func readServerMessage(t *testing.T, ws *websocket.Conn) ServerMessage {
+	t.Helper()
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg ServerMessage
+	if err := ws.ReadJSON(&msg); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return msg
+}
+
+// startTurn opens a session, sends one message, and reads its first chunk.
+func startTurn(t *testing.T, ts *httptest.Server) (*websocket.Conn, string) {
+	t.Helper()
+	ws := dialReplay(t, ts, "")
+	sessionID := readConnected(t, ws)
+	if err := ws.WriteJSON(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "hi"}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if chunk := readServerMessage(t, ws); chunk.Content != "one" || chunk.Seq != 1 {
+		t.Fatalf("first chunk = %+v, want \"one\" with seq 1", chunk)
+	}
+	return ws, sessionID
+}
+
+func resumeQuery(sessionID string, lastSeq uint64) string {
+	return "&resume=" + sessionID + "&last_seq=" + strconv.FormatUint(lastSeq, 10)
+}
+
+func TestResume_ReplaysMissedMessages(t *testing.T) {
+	handler := newGatedHandler()
+	server, ts, _ := newReplayServer(t, handler, 5*time.Second)
+
+	ws, sessionID := startTurn(t, ts)
+	_ = ws.Close()
+	// The turn outlives the dropped connection and finishes into the log.
+	waitFor(t, func() bool { return server.ConnectionCount() == 0 })
+	close(handler.release)
+	if err := <-handler.ctxErr; err != nil {
+		t.Fatalf("turn context cancelled on disconnect: %v", err)
+	}
+
+	ws = dialReplay(t, ts, resumeQuery(sessionID, 1))
+	connected := readServerMessage(t, ws)
+	if connected.Type != MessageTypeConnected || connected.SessionID != sessionID || !connected.Connected.Resumed {
+		t.Fatalf("connected = %+v, want the resumed session %s", connected, sessionID)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeChunk || msg.Content != "two" || msg.Seq != 2 {
+		t.Errorf("replayed = %+v, want chunk \"two\" with seq 2", msg)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeDone || msg.Seq != 3 {
+		t.Errorf("replayed = %+v, want done with seq 3", msg)
+	}
+}
+
+func TestResume_ContinuesLiveStream(t *testing.T) {
+	handler := newGatedHandler()
+	server, ts, store := newReplayServer(t, handler, 5*time.Second)
+
+	ws, sessionID := startTurn(t, ts)
+	_ = ws.Close()
+	waitFor(t, func() bool { return server.ConnectionCount() == 0 })
+
+	ws = dialReplay(t, ts, resumeQuery(sessionID, 1))
+	if connected := readServerMessage(t, ws); !connected.Connected.Resumed {
+		t.Fatalf("connected = %+v, want resumed", connected)
+	}
+	close(handler.release)
+	if msg := readServerMessage(t, ws); msg.Content != "two" || msg.Seq != 2 {
+		t.Errorf("live = %+v, want chunk \"two\" with seq 2", msg)
+	}
+	if msg := readServerMessage(t, ws); msg.Type != MessageTypeDone || msg.Seq != 3 {
+		t.Errorf("live = %+v, want done with seq 3", msg)
+	}
+
+	// The held session was not completed on the first disconnect.
+	sess, err := store.GetSession(context.Background(), sessionID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if sess.Status == session.SessionStatusCompleted {
+		t.Error("session completed while held for a resume")
+	}
+}
+
+func TestResume_ExpiryStopsTurnAndCompletes(t *testing.T) {
+	handler := newGatedHandler()
+	server, ts, store := newReplayServer(t, handler, 50*time.Millisecond)
+
+	ws, sessionID := startTurn(t, ts)
+	_ = ws.Close()
+
+	select {
+	case err := <-handler.ctxErr:
+		if err == nil {
+			t.Fatal("turn context not cancelled")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("turn not stopped when the resume window closed")
+	}
+	waitFor(t, func() bool {
+		sess, err := store.GetSession(context.Background(), sessionID)
+		return err == nil && sess.Status == session.SessionStatusCompleted
+	})
+	if n := server.replays.len(); n != 0 {
+		t.Errorf("replay logs = %d, want the expired log discarded", n)
+	}
+}
+
+func TestResume_HangupStopsTurn(t *testing.T) {
+	handler := newGatedHandler()
+	server, ts, _ := newReplayServer(t, handler, 5*time.Second)
+
+	ws, _ := startTurn(t, ts)
+	if err := ws.WriteJSON(ClientMessage{Type: MessageTypeHangup}); err != nil {
+		t.Fatalf("write hangup: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	_ = ws.Close()
+
+	select {
+	case err := <-handler.ctxErr:
+		if err == nil {
+			t.Fatal("turn context not cancelled")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("turn kept running after hangup")
+	}
+	if n := server.replays.len(); n != 0 {
+		t.Errorf("replay logs = %d, want none after hangup", n)
+	}
+}
+
+func TestResume_Miss(t *testing.T) {
+	handler := newGatedHandler()
+	_, ts, _ := newReplayServer(t, handler, 5*time.Second)
+
+	ws, sessionID := startTurn(t, ts)
+	defer close(handler.release)
+	_ = ws.Close()
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"unknown session", resumeQuery("no-such-session", 0)},
+		{"other owner", "&device_id=dev-2" + resumeQuery(sessionID, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws, _, err := websocket.DefaultDialer.Dial(wsURL(ts.URL)+"?agent=test-agent"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("dial: %v", err)
+			}
+			defer func() { _ = ws.Close() }()
+			connected := readServerMessage(t, ws)
+			if connected.Connected.Resumed || connected.SessionID == sessionID {
+				t.Errorf("connected = %+v, want a fresh session", connected)
+			}
+		})
+	}
+}
+
+func TestResume_InvalidLastSeq(t *testing.T) {
+	_, ts, _ := newReplayServer(t, &mockHandler{}, time.Second)
+
+	resp, err := http.Get(ts.URL + "?agent=test-agent&resume=s1&last_seq=-1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestReplayLog_KeepsLastMessages(t *testing.T) {
+	rl := &replayLog{sessionID: "s1", size: 2}
+	server := &Server{}
+	for _, content := range []string{"a", "b", "c"} {
+		if err := rl.send(server, NewChunkMessage("s1", content)); err != nil {
+			t.Fatalf("send: %v", err)
+		}
+	}
+	if len(rl.messages) != 2 || rl.messages[0].Content != "b" || rl.messages[0].Seq != 2 {
+		t.Errorf("messages = %+v, want the last two with their sequence numbers", rl.messages)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
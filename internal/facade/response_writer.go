@@ -117,6 +117,27 @@ func (w *connResponseWriter) WriteSessionConfig(cfg *SessionConfigInfo) error {
 	return w.server.sendMessage(w.conn, NewSessionConfigMessage(w.sessionID, cfg))
 }
 
+// WritePresence sends the participant list of a collaborative session.
+func (w *connResponseWriter) WritePresence(presence *PresenceInfo) error {
+	return w.server.sendMessage(w.conn, NewPresenceMessage(w.sessionID, presence))
+}
+
+// WantsProgress reports whether the client negotiated progress messages.
+func (w *connResponseWriter) WantsProgress() bool {
+	return w.conn.supports(FeatureProgress)
+}
+
+// WriteProgress reports a step of the turn. Progress is ephemeral: it goes to
+// whichever connection is attached to the session now and is not logged for
+// replay, since it is stale by the time a client resumes.
+func (w *connResponseWriter) WriteProgress(progress *ProgressInfo) error {
+	msg := NewProgressMessage(w.sessionID, progress)
+	if rl := w.conn.replayLogFor(msg); rl != nil {
+		return rl.sendEphemeral(w.server, msg)
+	}
+	return w.server.writeMessage(w.conn, msg)
+}
+
 // WriteUploadReady sends upload URL information to the client.
 func (w *connResponseWriter) WriteUploadReady(uploadReady *UploadReadyInfo) error {
 	return w.server.sendMessage(w.conn, NewUploadReadyMessage(w.sessionID, uploadReady))
@@ -146,6 +167,9 @@ func (w *connResponseWriter) SupportsBinary() bool {
 // Payloads exceeding ChunkThreshold (1 MB) are split into MaxChunkSize (64 KB)
 // chunks using the OMNI binary frame chunked transfer protocol.
 func (w *connResponseWriter) WriteBinaryMediaChunk(mediaID [MediaIDSize]byte, sequence uint32, isLast bool, mimeType string, payload []byte) error {
+	if !w.conn.supports(FeatureMedia) {
+		return nil
+	}
 	if !w.SupportsBinary() {
 		return w.WriteMediaChunk(&MediaChunkInfo{
 			MediaID:  MediaIDToString(mediaID),
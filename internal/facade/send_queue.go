@@ -0,0 +1,318 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// SlowConsumerPolicy is what the facade does when a client's send queue is
+// full.
+type SlowConsumerPolicy string
+
+const (
+	// SlowConsumerPolicyClose waits up to WriteTimeout for the queue to drain
+	// and then closes the connection. The session is held for a resume like
+	// any dropped connection, so the client can reconnect and be replayed.
+	SlowConsumerPolicyClose SlowConsumerPolicy = "close"
+	// SlowConsumerPolicyDrop sheds messages that are superseded or useless
+	// late (media, typing, presence, progress) while the queue is full, and
+	// closes as SlowConsumerPolicyClose for everything else.
+	SlowConsumerPolicyDrop SlowConsumerPolicy = "drop"
+)
+
+var (
+	// errSlowConsumer is returned to senders once a connection is closed
+	// because its client stopped reading.
+	errSlowConsumer = errors.New("slow consumer: client stopped reading")
+	// errQueueFull is returned by push when the queue stayed full for the
+	// whole wait.
+	errQueueFull = errors.New("send queue full")
+	// errFrameDropped is returned by push for a frame shed by
+	// SlowConsumerPolicyDrop.
+	errFrameDropped = errors.New("send queue full: frame dropped")
+)
+
+// outboundFrame is one encoded WebSocket message waiting to be written.
+type outboundFrame struct {
+	messageType int // websocket.TextMessage or websocket.BinaryMessage
+	data        []byte
+	// droppable marks frames SlowConsumerPolicyDrop may shed.
+	droppable bool
+}
+
+// sendQueue is a connection's bounded outbound queue. Handlers enqueue and a
+// single writer goroutine (runWriteLoop) drains it, so a client that stops
+// reading costs at most maxFrames / maxBytes of memory instead of growing
+// with the response it is not consuming.
+type sendQueue struct {
+	maxFrames int
+	maxBytes  int
+	policy    SlowConsumerPolicy
+	wait      time.Duration
+	// onBytes reports changes to the queued byte count (for metrics).
+	onBytes func(delta int)
+
+	mu     sync.Mutex
+	frames []outboundFrame
+	bytes  int
+	closed bool
+	// err is returned to senders after the queue failed; nil after a
+	// normal close.
+	err error
+	// ready is signalled when a frame is queued or the queue closes; space
+	// when a frame is taken.
+	ready chan struct{}
+	space chan struct{}
+	done  chan struct{}
+}
+
+func newSendQueue(maxFrames, maxBytes int, policy SlowConsumerPolicy, wait time.Duration, onBytes func(int)) *sendQueue {
+	return &sendQueue{
+		maxFrames: maxFrames,
+		maxBytes:  maxBytes,
+		policy:    policy,
+		wait:      wait,
+		onBytes:   onBytes,
+		ready:     make(chan struct{}, 1),
+		space:     make(chan struct{}, 1),
+		done:      make(chan struct{}),
+	}
+}
+
+// fitsLocked reports whether f can be queued without passing a high-water
+// mark. An empty queue takes any frame, so one larger than maxBytes is still
+// sendable.
+func (q *sendQueue) fitsLocked(f outboundFrame) bool {
+	if len(q.frames) == 0 {
+		return true
+	}
+	if q.maxFrames > 0 && len(q.frames) >= q.maxFrames {
+		return false
+	}
+	return q.maxBytes <= 0 || q.bytes+len(f.data) <= q.maxBytes
+}
+
+// push queues f. When the queue is full, a droppable frame under
+// SlowConsumerPolicyDrop is shed with errFrameDropped; otherwise push waits
+// for the writer to make room and gives up with errQueueFull. A closed queue
+// discards f, like a write to a closed connection, and returns the error it
+// failed with.
+func (q *sendQueue) push(f outboundFrame) error {
+	var timeout <-chan time.Time
+	for {
+		q.mu.Lock()
+		if q.closed {
+			err := q.err
+			q.mu.Unlock()
+			return err
+		}
+		if q.fitsLocked(f) {
+			q.frames = append(q.frames, f)
+			q.bytes += len(f.data)
+			q.mu.Unlock()
+			q.onBytes(len(f.data))
+			signal(q.ready)
+			return nil
+		}
+		q.mu.Unlock()
+
+		if f.droppable && q.policy == SlowConsumerPolicyDrop {
+			return errFrameDropped
+		}
+		if timeout == nil {
+			timer := time.NewTimer(q.wait)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case <-q.space:
+		case <-q.done:
+		case <-timeout:
+			return errQueueFull
+		}
+	}
+}
+
+// next returns the oldest queued frame, waiting for one. It reports false
+// once the queue is closed and empty.
+func (q *sendQueue) next() (outboundFrame, bool) {
+	for {
+		q.mu.Lock()
+		if len(q.frames) > 0 {
+			f := q.frames[0]
+			q.frames[0] = outboundFrame{}
+			q.frames = q.frames[1:]
+			q.bytes -= len(f.data)
+			q.mu.Unlock()
+			q.onBytes(-len(f.data))
+			signal(q.space)
+			return f, true
+		}
+		closed := q.closed
+		q.mu.Unlock()
+		if closed {
+			return outboundFrame{}, false
+		}
+		<-q.ready
+	}
+}
+
+// close stops the queue taking frames. Frames already queued are still
+// written.
+func (q *sendQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	signal(q.ready)
+}
+
+// fail closes the queue and discards its frames. Later pushes return err.
+func (q *sendQueue) fail(err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.err == nil {
+		q.err = err
+	}
+	q.closed = true
+	if q.bytes > 0 {
+		q.onBytes(-q.bytes)
+	}
+	q.frames = nil
+	q.bytes = 0
+	signal(q.ready)
+}
+
+// signal wakes one waiter on ch without blocking.
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// droppableMessage reports whether SlowConsumerPolicyDrop may shed msg: media
+// is useless once late, and typing, presence and progress are superseded by
+// the next message of their kind.
+func droppableMessage(msg *ServerMessage) bool {
+	switch msg.Type {
+	case MessageTypeMediaChunk, MessageTypeTyping, MessageTypePresence, MessageTypeProgress:
+		return true
+	}
+	return false
+}
+
+// enqueue queues f on the connection's send queue and applies the slow
+// consumer policy when the queue is full.
+func (s *Server) enqueue(c *Connection, q *sendQueue, f outboundFrame) error {
+	err := q.push(f)
+	switch {
+	case errors.Is(err, errFrameDropped):
+		s.metrics.OutboundMessageDropped()
+		return nil
+	case errors.Is(err, errQueueFull):
+		s.closeSlowConsumer(c, q)
+		return errSlowConsumer
+	}
+	return err
+}
+
+// closeSlowConsumer drops the queued frames and closes the connection of a
+// client that stopped reading.
+func (s *Server) closeSlowConsumer(c *Connection, q *sendQueue) {
+	s.metrics.SlowConsumerDisconnected()
+	s.log.Info("closing slow consumer",
+		"sessionID", c.SessionID(),
+		"maxFrames", q.maxFrames,
+		"maxBytes", q.maxBytes,
+		"policy", string(q.policy),
+	)
+	q.fail(errSlowConsumer)
+	c.abort()
+}
+
+// runWriteLoop writes the connection's queued frames until the queue closes.
+// A write that times out means the client stopped reading for WriteTimeout
+// and closes it as a slow consumer; any other failure closes the connection
+// too, since the client is gone.
+func (s *Server) runWriteLoop(c *Connection, q *sendQueue) {
+	defer close(q.done)
+	for {
+		f, ok := q.next()
+		if !ok {
+			return
+		}
+		if err := s.writeFrame(c, f); err != nil {
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Timeout() {
+				s.closeSlowConsumer(c, q)
+				return
+			}
+			s.log.V(1).Info("outbound write failed", "sessionID", c.SessionID(), "error", err.Error())
+			q.fail(err)
+			c.abort()
+			return
+		}
+	}
+}
+
+// writeFrame writes one frame to the socket. Only the write loop calls it, so
+// it needs no lock: control frames (pings, close) use WriteControl, which is
+// safe alongside it.
+func (s *Server) writeFrame(c *Connection, f outboundFrame) error {
+	if err := c.conn.SetWriteDeadline(time.Now().Add(s.config.WriteTimeout)); err != nil {
+		return err
+	}
+	s.compressNext(c, f.messageType, len(f.data))
+	if err := c.conn.WriteMessage(f.messageType, f.data); err != nil {
+		return err
+	}
+	if err := c.conn.SetWriteDeadline(time.Time{}); err != nil {
+		return err
+	}
+	s.metrics.MessageSent()
+	c.sent.Add(1)
+	return nil
+}
+
+// flushOutbound closes the connection's send queue and waits, up to
+// WriteTimeout, for the frames already queued to be written, so a message
+// sent just before the connection closes (a final error) still reaches the
+// client.
+func (s *Server) flushOutbound(c *Connection) {
+	q := c.outbound
+	if q == nil {
+		return
+	}
+	q.close()
+	select {
+	case <-q.done:
+	case <-time.After(s.config.WriteTimeout):
+		q.fail(nil)
+	}
+}
+
+// abort closes the connection's socket underneath its read loop, which then
+// runs the normal cleanup. Used when the client has stopped reading.
+func (c *Connection) abort() {
+	if nc := c.conn.NetConn(); nc != nil {
+		_ = nc.Close()
+	}
+}
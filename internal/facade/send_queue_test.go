@@ -0,0 +1,154 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session/sessiontest"
+)
+
+func textFrame(s string) outboundFrame {
+	return outboundFrame{messageType: websocket.TextMessage, data: []byte(s)}
+}
+
+func TestSendQueue_HighWaterMarks(t *testing.T) {
+	var queued atomic.Int64
+	q := newSendQueue(2, 10, SlowConsumerPolicyClose, 20*time.Millisecond, func(d int) { queued.Add(int64(d)) })
+
+	// An empty queue takes a frame larger than maxBytes.
+	require.NoError(t, q.push(textFrame("0123456789abc")))
+	assert.ErrorIs(t, q.push(textFrame("x")), errQueueFull, "over maxBytes")
+	assert.Equal(t, int64(13), queued.Load())
+
+	f, ok := q.next()
+	require.True(t, ok)
+	assert.Equal(t, "0123456789abc", string(f.data))
+	require.NoError(t, q.push(textFrame("a")))
+	require.NoError(t, q.push(textFrame("b")))
+	assert.ErrorIs(t, q.push(textFrame("c")), errQueueFull, "over maxFrames")
+	assert.Equal(t, int64(2), queued.Load())
+}
+
+func TestSendQueue_DropPolicyShedsDroppableFrames(t *testing.T) {
+	q := newSendQueue(1, 0, SlowConsumerPolicyDrop, 20*time.Millisecond, func(int) {})
+	require.NoError(t, q.push(textFrame("done")))
+
+	media := textFrame("media")
+	media.droppable = true
+	assert.ErrorIs(t, q.push(media), errFrameDropped)
+	assert.ErrorIs(t, q.push(textFrame("chunk")), errQueueFull, "only droppable frames are shed")
+}
+
+func TestSendQueue_PushWaitsForRoom(t *testing.T) {
+	q := newSendQueue(1, 0, SlowConsumerPolicyClose, 5*time.Second, func(int) {})
+	require.NoError(t, q.push(textFrame("first")))
+
+	pushed := make(chan error, 1)
+	go func() { pushed <- q.push(textFrame("second")) }()
+	select {
+	case <-pushed:
+		t.Fatal("push should wait while the queue is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	_, _ = q.next()
+	require.NoError(t, <-pushed)
+	f, _ := q.next()
+	assert.Equal(t, "second", string(f.data))
+}
+
+func TestSendQueue_CloseFlushesAndFailDiscards(t *testing.T) {
+	var queued atomic.Int64
+	q := newSendQueue(0, 0, SlowConsumerPolicyClose, time.Second, func(d int) { queued.Add(int64(d)) })
+	require.NoError(t, q.push(textFrame("a")))
+	require.NoError(t, q.push(textFrame("b")))
+	q.close()
+	require.NoError(t, q.push(textFrame("late")), "a closed queue discards silently")
+
+	f, ok := q.next()
+	require.True(t, ok)
+	assert.Equal(t, "a", string(f.data))
+	q.fail(errSlowConsumer)
+	_, ok = q.next()
+	assert.False(t, ok)
+	assert.Zero(t, queued.Load())
+	assert.ErrorIs(t, q.push(textFrame("later")), errSlowConsumer)
+}
+
+// slowConsumerMetrics counts the outbound backpressure metrics.
+type slowConsumerMetrics struct {
+	NoOpMetrics
+	disconnects atomic.Int32
+	dropped     atomic.Int32
+}
+
+func (m *slowConsumerMetrics) SlowConsumerDisconnected() { m.disconnects.Add(1) }
+func (m *slowConsumerMetrics) OutboundMessageDropped()   { m.dropped.Add(1) }
+
+func TestServer_ClosesSlowConsumer(t *testing.T) {
+	chunk := strings.Repeat("x", 256*1024)
+	turnErr := make(chan error, 1)
+	handler := &mockHandler{handleFunc: func(_ context.Context, _ string, _ *ClientMessage, w ResponseWriter) error {
+		// Far more than the socket buffers hold; the client never reads.
+		for range 400 {
+			if err := w.WriteChunk(chunk); err != nil {
+				turnErr <- err
+				return err
+			}
+		}
+		turnErr <- nil
+		return w.WriteDone("")
+	}}
+
+	cfg := DefaultServerConfig()
+	cfg.SendQueueSize = 4
+	cfg.WriteTimeout = 200 * time.Millisecond
+	cfg.ReplayBufferSize = 0
+	metrics := &slowConsumerMetrics{}
+	store := sessiontest.NewStore()
+	t.Cleanup(func() { _ = store.Close() })
+	srv := NewServer(cfg, store, handler, logr.Discard(), WithMetrics(metrics))
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	ws, _, err := websocket.DefaultDialer.Dial(wsURL(ts.URL)+"?agent=test-agent", nil)
+	require.NoError(t, err)
+	defer func() { _ = ws.Close() }()
+	sessionID := readConnected(t, ws)
+	require.NoError(t, ws.WriteJSON(ClientMessage{Type: MessageTypeMessage, SessionID: sessionID, Content: "hi"}))
+
+	select {
+	case err := <-turnErr:
+		require.True(t, errors.Is(err, errSlowConsumer), "got %v", err)
+	case <-time.After(20 * time.Second):
+		t.Fatal("the turn kept writing into a stalled client")
+	}
+	assert.Equal(t, int32(1), metrics.disconnects.Load())
+	require.Eventually(t, func() bool { return srv.ConnectionCount() == 0 }, 5*time.Second, 10*time.Millisecond)
+}
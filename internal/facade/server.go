@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -37,6 +38,7 @@ import (
 	"github.com/altairalabs/omnia/internal/media"
 	"github.com/altairalabs/omnia/internal/session"
 	"github.com/altairalabs/omnia/internal/tracing"
+	"github.com/altairalabs/omnia/pkg/apierror"
 	"github.com/altairalabs/omnia/pkg/facade/auth"
 	"github.com/altairalabs/omnia/pkg/logctx"
 	"github.com/altairalabs/omnia/pkg/logging"
@@ -72,6 +74,69 @@ type ClientToolRouter interface {
 	AckToolCall(sessionID string, callID string)
 }
 
+// ToolDebugRouter is implemented by handlers that can pause tool calls for
+// step-through debugging. The facade routes tool_debug messages to it.
+type ToolDebugRouter interface {
+	// SendToolDebug delivers a debug command to the handler paused on the call.
+	// ctx is the sending connection's context (see ConnectionIDFromContext).
+	// Returns true if a paused call consumed the command, false otherwise.
+	SendToolDebug(ctx context.Context, sessionID string, cmd *ToolDebugInfo) bool
+}
+
+// ConnectionInfo identifies a connection bound to a session.
+type ConnectionInfo struct {
+	// ID is unique per WebSocket connection.
+	ID string
+	// SessionID is the session the connection is bound to.
+	SessionID string
+	// UserID is the pseudonymous user ID resolved at upgrade.
+	UserID string
+	// UserEmail is the user's email, when known.
+	UserEmail string
+	// RequestedRole is the role asked for via ?role= (may be empty).
+	RequestedRole ParticipantRole
+	// Joined is true when the connection attached to an existing session
+	// via ?join= rather than starting its own.
+	Joined bool
+	// Owner is true when the connection's user owns the session: it started
+	// the session, or joined one the same user started.
+	Owner bool
+}
+
+// ConnectionObserver is implemented by handlers that let several clients
+// share a session (collaborative sessions). Only when the handler implements
+// it does the facade honour ?join=<session_id> on upgrade; other handlers
+// keep one session per connection. The facade admits a join only to a session
+// this replica holds or the session store has, and only for the session's
+// owner or a caller the auth chain admitted to the session's workspace.
+type ConnectionObserver interface {
+	// ConnectionOpened is called once the connection is bound to its session.
+	// writer stays valid until ConnectionClosed and also implements
+	// PresenceWriter.
+	ConnectionOpened(ctx context.Context, conn ConnectionInfo, writer ResponseWriter)
+	// ConnectionClosed is called when the connection goes away. It returns
+	// the number of connections still attached to the session; the facade
+	// only completes the session once none remain.
+	ConnectionClosed(conn ConnectionInfo) int
+}
+
+// PresenceWriter is implemented by response writers that can deliver
+// presence updates for collaborative sessions.
+type PresenceWriter interface {
+	// WritePresence sends the session's participant list.
+	WritePresence(presence *PresenceInfo) error
+}
+
+// ProgressWriter is implemented by response writers that can deliver
+// progress messages. Handlers check WantsProgress before producing progress,
+// since most clients do not ask for it.
+type ProgressWriter interface {
+	// WantsProgress reports whether the client takes progress messages.
+	WantsProgress() bool
+	// WriteProgress sends a step of the turn.
+	WriteProgress(progress *ProgressInfo) error
+}
+
 // ResponseWriter allows sending responses back to the client.
 type ResponseWriter interface {
 	// WriteChunk sends a chunk of the response.
@@ -122,6 +187,10 @@ type Server struct {
 	tracingProvider *tracing.Provider
 	recordingPool   *RecordingPool
 	allowedOrigins  []string
+	// middleware wraps handler's turns (see WithMiddleware); chain is the
+	// result, which turns run through. Interface checks use handler.
+	middleware []Middleware
+	chain      MessageHandler
 	// authChain, when non-empty, runs every configured Validator against
 	// the upgrade request in order and admits on the first match. On
 	// admit the identity flows into PropagationFields.Identity and the
@@ -149,6 +218,9 @@ type Server struct {
 	// implementation lives in internal/agent and is injected via
 	// WithDuplexSinkFactory — the facade never imports internal/agent directly.
 	duplexSinkFactory func(sessionID string, w ResponseWriter) DuplexSink
+	// audioFormatOverride is the agent's declared voice format
+	// (WithAudioFormat); nil uses defaultAudioFormat. See audioFormat.
+	audioFormatOverride *SessionConfigInfo
 
 	// activeAudioSessions counts the number of live audio sessions on this pod.
 	// Manipulated via sync/atomic so ensureAudioSession can read/CAS without
@@ -173,6 +245,23 @@ type Server struct {
 	// parked holds in-flight realtime sessions that have disconnected but
 	// whose underlying audio stream is still alive (blip-resume).
 	parked *realtimeRegistry
+	// replays holds the replay logs of sessions, including those of dropped
+	// connections waiting for a resume.
+	replays *replayRegistry
+	// handoffStore receives the replay state of sessions this pod gives up
+	// on drain. Defaults to noopHandoffStore{}.
+	handoffStore HandoffStore
+	// turnsInFlight counts the turns being handled, which drain waits for.
+	turnsInFlight atomic.Int64
+	// relay carries sessions relayed across replicas; nil disables relaying.
+	relay SessionRelay
+	// relaySealer seals relay envelopes; nil disables relaying.
+	relaySealer *relaySealer
+	// relaySub is this replica's relay subscription, set by StartRelay.
+	relaySub RelaySubscription
+	// relayed holds the stand-ins for clients of this replica's sessions
+	// connected to other replicas, by relay ID. Protected by mu.
+	relayed map[string]*Connection
 
 	mu           sync.RWMutex
 	connections  map[*websocket.Conn]*Connection
@@ -190,6 +279,7 @@ func NewServer(cfg ServerConfig, store session.Recorder, handler MessageHandler,
 		metrics:      &NoOpMetrics{}, // Default to no-op
 		log:          log.WithName("websocket-server"),
 		connections:  make(map[*websocket.Conn]*Connection),
+		relayed:      make(map[string]*Connection),
 		// Default true so dev/test binaries keep working without an
 		// auth chain configured. Production deployments always have
 		// at least mgmt-plane in the chain so this flag is a no-op
@@ -203,15 +293,18 @@ func NewServer(cfg ServerConfig, store session.Recorder, handler MessageHandler,
 		opt(s)
 	}
 
+	s.chain = chainMiddleware(handler, s.middleware)
+
 	// Load allowed origins from environment if not set via options
 	if len(s.allowedOrigins) == 0 {
 		s.allowedOrigins = ParseAllowedOrigins(os.Getenv(envAllowedOrigins))
 	}
 
 	s.upgrader = websocket.Upgrader{
-		ReadBufferSize:  cfg.ReadBufferSize,
-		WriteBufferSize: cfg.WriteBufferSize,
-		CheckOrigin:     s.checkOrigin,
+		ReadBufferSize:    cfg.ReadBufferSize,
+		WriteBufferSize:   cfg.WriteBufferSize,
+		CheckOrigin:       s.checkOrigin,
+		EnableCompression: cfg.EnableCompression,
 	}
 
 	// Initialise the parked session registry.
@@ -239,6 +332,19 @@ func NewServer(cfg ServerConfig, store session.Recorder, handler MessageHandler,
 			s.completeSession(sessionID, s.log)
 		}
 	}
+	s.replays = newReplayRegistry(cfg.ReplayBufferSize, s.routeStore, s.podAddr, s.graceWindow, s.log)
+	if s.handoffStore == nil {
+		s.handoffStore = noopHandoffStore{}
+	}
+	s.replays.handoffs = s.handoffStore
+	// Like parking, holding a session for a resume defers its completion to
+	// the moment the resume definitively did not happen.
+	s.replays.onExpire = func(sessionID string, complete bool) {
+		if complete {
+			s.metrics.SessionClosed()
+			s.completeSession(sessionID, s.log)
+		}
+	}
 
 	return s
 }
@@ -337,7 +443,8 @@ func ParseAllowedOrigins(raw string) []string {
 //
 // Returning (nil, nil) means "proceed without identity" — callers
 // should treat this as the unauthenticated-but-allowed path. Returning
-// a non-nil error means "reject" — callers translate to 401.
+// a non-nil error means "reject" — callers translate it with
+// authRejectStatus.
 func (s *Server) authenticateRequest(r *http.Request) (*policy.AuthenticatedIdentity, error) {
 	if len(s.authChain) == 0 {
 		if s.allowUnauthenticated {
@@ -360,6 +467,15 @@ func (s *Server) authenticateRequest(r *http.Request) (*policy.AuthenticatedIden
 	}
 }
 
+// authRejectStatus maps an authenticateRequest error to its HTTP status:
+// 403 when an authorizer refused a verified caller, 401 otherwise.
+func authRejectStatus(err error) int {
+	if errors.Is(err, auth.ErrForbidden) {
+		return http.StatusForbidden
+	}
+	return http.StatusUnauthorized
+}
+
 // ServeHTTP handles WebSocket upgrade requests.
 // mgmtPlaneUserID resolves the memory-scoping identity for a management-
 // plane WebSocket connection, in order of precedence:
@@ -392,8 +508,10 @@ type requestAgentContext struct {
 	agentName     string
 	namespace     string
 	workspaceName string
-	binaryCapable bool
 	resumeID      string // session_id the client asked to resume (from ?resume=)
+	lastSeq       uint64 // last sequence number the client received (from ?last_seq=)
+	joinID        string // session_id the client asked to join (from ?join=)
+	role          ParticipantRole
 }
 
 type requestUserContext struct {
@@ -423,13 +541,23 @@ func (s *Server) resolveAgentContext(r *http.Request) (requestAgentContext, erro
 	if agentName == "" {
 		return requestAgentContext{}, errors.New("agent parameter is required")
 	}
+	var lastSeq uint64
+	if v := r.URL.Query().Get("last_seq"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return requestAgentContext{}, errors.New("last_seq parameter must be a non-negative integer")
+		}
+		lastSeq = n
+	}
 
 	return requestAgentContext{
 		agentName:     agentName,
 		namespace:     namespace,
 		workspaceName: workspaceName,
-		binaryCapable: r.URL.Query().Get("binary") == "true",
 		resumeID:      r.URL.Query().Get("resume"),
+		lastSeq:       lastSeq,
+		joinID:        r.URL.Query().Get("join"),
+		role:          ParticipantRole(r.URL.Query().Get("role")),
 	}, nil
 }
 
@@ -481,13 +609,14 @@ func resolveVariant(r *http.Request) string {
 
 func (s *Server) buildConnectionContext(
 	r *http.Request,
+	requestID string,
 	agentCtx requestAgentContext,
 	userCtx requestUserContext,
 	authIdentity *policy.AuthenticatedIdentity,
 ) context.Context {
 	connCtx := logctx.WithAgent(context.Background(), agentCtx.agentName)
 	connCtx = logctx.WithNamespace(connCtx, agentCtx.namespace)
-	connCtx = logctx.WithRequestID(connCtx, uuid.New().String())
+	connCtx = logctx.WithRequestID(connCtx, requestID)
 
 	// Extract W3C trace context (traceparent/tracestate) from upgrade headers.
 	// If no traceparent header is present, the context is unchanged (no-op).
@@ -541,6 +670,21 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	proto, err := s.negotiateProtocol(r)
+	if errors.Is(err, errProtocolTooOld) {
+		http.Error(w, err.Error(), http.StatusUpgradeRequired)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	locale, err := resolveLocale(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// While draining, reject NEW upgrades but allow realtime resume
 	// reattach requests through to tryReattach, so T1-parked sessions can
 	// be reclaimed and completed before teardown.
@@ -552,14 +696,16 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Run the auth chain (PR 1: mgmt-plane validator only). On admit the
 	// returned identity takes precedence over Istio-injected headers for
 	// user fields; on unambiguous reject (invalid/expired) 401 here and
-	// skip the upgrade entirely.
+	// skip the upgrade entirely. A caller the agent's authorizer refuses
+	// gets 403 instead.
 	authIdentity, authErr := s.authenticateRequest(r)
 	if authErr != nil {
+		status := authRejectStatus(authErr)
 		s.log.V(1).Info("auth rejected upgrade",
 			"reason", authErr.Error(),
-			"status", http.StatusUnauthorized,
+			"status", status,
 		)
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		http.Error(w, strings.ToLower(http.StatusText(status)), status)
 		return
 	}
 
@@ -574,20 +720,39 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		s.log.Error(err, "failed to upgrade connection")
 		return
 	}
+	if s.config.EnableCompression && s.config.CompressionLevel != 0 {
+		if err := conn.SetCompressionLevel(s.config.CompressionLevel); err != nil {
+			s.log.V(1).Info("invalid compression level, using the default", "level", s.config.CompressionLevel)
+		}
+	}
+
+	// Create enriched context with connection info
+	connCtx := s.buildConnectionContext(r, apierror.CorrelationID(r), agentCtx, userCtx, authIdentity)
 
 	// Create connection wrapper
 	c := &Connection{
+		id:            uuid.New().String(),
 		conn:          conn,
+		requestID:     logctx.RequestID(connCtx),
 		agentName:     agentCtx.agentName,
 		namespace:     agentCtx.namespace,
 		workspaceName: agentCtx.workspaceName,
-		binaryCapable: agentCtx.binaryCapable,
+		binaryCapable: proto.requested[FeatureBinary],
+		features:      proto.requested,
+		protocol:      proto.protocolInfo(),
 		resumeID:      agentCtx.resumeID,
+		lastSeq:       agentCtx.lastSeq,
+		joinID:        agentCtx.joinID,
+		role:          agentCtx.role,
 		userID:        userCtx.userID,
+		authWorkspace: authWorkspace(authIdentity),
 		userEmail:     userCtx.userEmail,
 		authorization: userCtx.authorization,
 		cohortID:      userCtx.cohortID,
 		variant:       userCtx.variant,
+		locale:        locale,
+		connectedAt:   time.Now(),
+		remoteAddr:    r.RemoteAddr,
 	}
 	if s.config.MaxInFlightMessagesPerConnection > 0 {
 		c.inFlightMessages = make(chan struct{}, s.config.MaxInFlightMessagesPerConnection)
@@ -598,6 +763,11 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if s.config.MediaByteRateLimit > 0 {
 		c.mediaRateLimiter = rate.NewLimiter(rate.Limit(s.config.MediaByteRateLimit), s.config.MediaByteRateBurst)
 	}
+	if s.config.SendQueueSize > 0 {
+		c.outbound = newSendQueue(s.config.SendQueueSize, s.config.SendQueueMaxBytes,
+			s.config.SlowConsumerPolicy, s.config.WriteTimeout, s.metrics.SendQueueBytesChanged)
+		go s.runWriteLoop(c, c.outbound)
+	}
 
 	s.mu.Lock()
 	if s.shutdown {
@@ -616,8 +786,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Record connection metrics
 	s.metrics.ConnectionOpened()
 
-	// Create enriched context with connection info
-	connCtx := s.buildConnectionContext(r, agentCtx, userCtx, authIdentity)
+	connCtx = WithConnectionID(connCtx, c.id)
 
 	log := logctx.LoggerWithContext(s.log, connCtx)
 	log.Info("new connection")
@@ -636,6 +805,10 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	}
 	s.mu.Unlock()
 
+	// Hand relayed clients back to their replicas, which drop them so they
+	// reconnect.
+	s.stopRelay()
+
 	// Close all connections
 	for _, conn := range connections {
 		if err := conn.WriteControl(
@@ -683,3 +856,18 @@ func (s *Server) HasRouteStore() bool {
 	_, ok := s.routeStore.(noopRouteStore)
 	return !ok
 }
+
+// HasHandoffStore reports whether a real HandoffStore (not the no-op) is
+// configured. Used by wiring tests to assert that cmd/agent wires the Redis
+// HandoffStore alongside the RouteStore.
+func (s *Server) HasHandoffStore() bool {
+	_, ok := s.handoffStore.(noopHandoffStore)
+	return !ok
+}
+
+// HasSessionRelay reports whether a SessionRelay and its key are configured.
+// Used by wiring tests to assert that cmd/agent wires the Redis relay
+// alongside the RouteStore.
+func (s *Server) HasSessionRelay() bool {
+	return s.relay != nil && s.relaySealer != nil
+}
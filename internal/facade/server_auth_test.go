@@ -339,6 +339,33 @@ func TestServerAuth_BearerChain_RejectsWrongToken(t *testing.T) {
 		"the data-plane validator's ErrInvalidCredential must reject; mgmt-plane must NOT be reached")
 }
 
+// TestServerAuth_AuthorizerRefusal_Rejects403 proves a verified caller the
+// agent's authorizer refuses gets 403 on both the WebSocket upgrade and the
+// chat endpoint, not the 401 that would tell it to re-authenticate.
+func TestServerAuth_AuthorizerRefusal_Rejects403(t *testing.T) {
+	id := &policy.AuthenticatedIdentity{
+		Origin: policy.OriginOIDC,
+		Claims: map[string]string{"agents": "other-ns/*"},
+	}
+	authz, err := auth.NewAgentScopeAuthorizer("agents", "default", "test-agent")
+	require.NoError(t, err)
+	chain := auth.Chain{auth.Authorized(&stubClaimValidator{id: id}, authz)}
+
+	store := sessiontest.NewStore()
+	server := NewServer(DefaultServerConfig(), store, &mockHandler{}, logr.Discard(), WithAuthChain(chain))
+	ts := httptest.NewServer(server)
+	chat := httptest.NewServer(server.ChatHandler())
+	t.Cleanup(func() { ts.Close(); chat.Close(); _ = store.Close() })
+
+	_, resp, err := dialWS(t, ts, nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = postChat(t, chat.URL, `{"content":"hi"}`, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
 // stubClaimValidator is an auth.Validator that admits every request
 // and returns a canned AuthenticatedIdentity with a populated Claims
 // map. Good enough to prove the facade copies the map into
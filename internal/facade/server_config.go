@@ -69,9 +69,57 @@ type ServerConfig struct {
 	// ErrorCodeRateLimited. 0 applies the conservative default (8).
 	MaxAudioSessions int
 	// DrainTimeout is how long the facade keeps serving active realtime calls
-	// after receiving SIGTERM before tearing down remaining connections.
-	// New calls are shed immediately on drain. Defaults to 30s.
+	// and in-flight turns after receiving SIGTERM before tearing down
+	// remaining connections. New sessions are shed immediately on drain.
+	// Defaults to 30s.
 	DrainTimeout time.Duration
+	// ReconnectRetryAfter is the delay the reconnect hint sent on drain asks
+	// clients to wait before resuming elsewhere, long enough for the load
+	// balancer to stop routing to this pod. Defaults to 1s.
+	ReconnectRetryAfter time.Duration
+	// ReconnectEndpoint, when set, is the URL the reconnect hint points
+	// clients at instead of the one they connected to.
+	ReconnectEndpoint string
+	// ReplayBufferSize is how many of a session's most recent messages are
+	// kept for a client that reconnects with ?resume=<session_id>&last_seq=<n>.
+	// The buffer, and the session's in-flight turns, are held for the grace
+	// window after the connection drops. 0 disables replay: messages carry no
+	// sequence number and turns stop when their connection closes.
+	ReplayBufferSize int
+	// SendQueueSize is the most messages queued for one client before it is
+	// treated as a slow consumer. Messages are written by a per-connection
+	// write loop, so a client that stops reading holds at most this many
+	// (and SendQueueMaxBytes) in memory. 0 disables the queue: senders write
+	// to the socket directly and block for up to WriteTimeout.
+	SendQueueSize int
+	// SendQueueMaxBytes is the most encoded bytes queued for one client. A
+	// single message larger than this is still sent when the queue is empty.
+	// 0 leaves only SendQueueSize.
+	SendQueueMaxBytes int
+	// SlowConsumerPolicy is what happens when a client's queue is full.
+	// Senders wait up to WriteTimeout for room; under SlowConsumerPolicyDrop
+	// media, typing and presence messages are shed instead of waiting.
+	// Empty means SlowConsumerPolicyClose.
+	SlowConsumerPolicy SlowConsumerPolicy
+	// EnableCompression negotiates permessage-deflate with clients that offer
+	// it. Binary frames are never compressed: they carry media that is
+	// usually compressed already.
+	EnableCompression bool
+	// CompressionLevel is the flate level for compressed messages, from 1
+	// (fastest) to 9 (smallest).
+	CompressionLevel int
+	// CompressionThreshold is the smallest encoded message, in bytes, worth
+	// compressing. Smaller messages are sent as they are.
+	CompressionThreshold int
+	// MaxFragmentSize is the most bytes of a message's encoding sent in one
+	// frame to a client that negotiated the fragments feature; a larger
+	// message is split into fragment messages. 0 disables fragmenting.
+	MaxFragmentSize int
+	// MaxFragmentedSize is the largest message a client may send as
+	// fragments, which lets clients send more than MaxMessageSize (a large
+	// tool result) without raising the limit on single frames. 0 refuses
+	// fragments.
+	MaxFragmentedSize int
 }
 
 // DefaultServerConfig returns a ServerConfig with default values.
@@ -96,7 +144,25 @@ func DefaultServerConfig() ServerConfig {
 		// stream fan-out and chunk interleaving the client cannot correlate.
 		MaxInFlightMessagesPerConnection: 1,
 		// Conservative audio session cap. Overridden via ServerConfig.MaxAudioSessions.
-		MaxAudioSessions: 8,
-		DrainTimeout:     30 * time.Second,
+		MaxAudioSessions:    8,
+		DrainTimeout:        30 * time.Second,
+		ReconnectRetryAfter: time.Second,
+		// Enough for a long streamed answer with tool calls; a client that
+		// missed more sees the gap in the sequence numbers.
+		ReplayBufferSize: 512,
+		// Room for the replay buffer to be resent in one go, and for a few
+		// seconds of 24 kHz audio output, before a stalled client is cut off.
+		SendQueueSize:      1024,
+		SendQueueMaxBytes:  8 * 1024 * 1024,
+		SlowConsumerPolicy: SlowConsumerPolicyClose,
+		// Level 1 gets most of the saving on JSON at a fraction of the CPU
+		// of higher levels; below 1 KiB the deflate framing eats the gain.
+		EnableCompression:    true,
+		CompressionLevel:     1,
+		CompressionThreshold: 1024,
+		// Small enough for a mobile client to show progress on a large tool
+		// output, large enough that most messages are never split.
+		MaxFragmentSize:   1024 * 1024,
+		MaxFragmentedSize: 64 * 1024 * 1024,
 	}
 }
@@ -118,6 +118,16 @@ func WithDuplexSinkFactory(f func(sessionID string, w ResponseWriter) DuplexSink
 	}
 }
 
+// WithAudioFormat sets the voice format advertised to clients in the
+// connected message (capabilities.audio) and assumed for any field the first
+// audio frame omits. Unset fields keep the defaults (pcm, 16000 Hz, mono).
+// Only meaningful alongside WithDuplexSinkFactory.
+func WithAudioFormat(f *SessionConfigInfo) ServerOption {
+	return func(s *Server) {
+		s.audioFormatOverride = f
+	}
+}
+
 // WithAllowUnauthenticated controls the fallback behaviour when the
 // auth chain is empty (no validators configured). Defaults to true so
 // standalone dev/test binaries without a k8s client or mgmt-plane key
@@ -141,6 +151,26 @@ func WithRouteStore(rs RouteStore) ServerOption {
 	return func(s *Server) { s.routeStore = rs }
 }
 
+// WithHandoffStore sets the store a draining pod hands its sessions' replay
+// state to, so a client resuming on another replica is replayed what it
+// missed. Defaults to noopHandoffStore{}.
+func WithHandoffStore(hs HandoffStore) ServerOption {
+	return func(s *Server) { s.handoffStore = hs }
+}
+
+// WithSessionRelay sets the relay a replica uses to reach the replica holding
+// a resumed session, so clients need no sticky load balancing. It takes effect
+// with a RouteStore that implements RouteResolver and a pod address; the
+// holding replica must also call StartRelay. key seals every envelope and
+// must be shared by the agent's replicas; one shorter than
+// MinSessionRelayKeyLen leaves relaying disabled.
+func WithSessionRelay(relay SessionRelay, key []byte) ServerOption {
+	return func(s *Server) {
+		s.relay = relay
+		s.relaySealer = newRelaySealer(key)
+	}
+}
+
 // WithPodAddr sets the "<podIP>:<port>" address for this pod. Written into
 // route hints when a realtime session is parked so a peer can redirect
 // reconnecting clients to the correct pod.
@@ -32,6 +32,7 @@ import (
 	"github.com/altairalabs/omnia/internal/session"
 	"github.com/altairalabs/omnia/internal/session/otlp"
 	"github.com/altairalabs/omnia/internal/tracing"
+	"github.com/altairalabs/omnia/pkg/apierror"
 	"github.com/altairalabs/omnia/pkg/identity"
 	"github.com/altairalabs/omnia/pkg/logctx"
 	"github.com/altairalabs/omnia/pkg/policy"
@@ -94,21 +95,7 @@ func (s *Server) processMessage(ctx context.Context, c *Connection, msg *ClientM
 	ctx, msgSpan = s.startMessageSpan(ctx, c, sessionID)
 	defer msgSpan.End()
 
-	// Enrich context with session ID, namespace, trace ID, and user ID for
-	// log↔trace correlation and privacy header propagation.
-	ctx = logctx.WithSessionID(ctx, sessionID)
-	ctx = logctx.WithNamespace(ctx, c.namespace)
-	ctx = logctx.WithTraceID(ctx, msgSpan.SpanContext().TraceID().String())
-	if c.userID != "" {
-		ctx = httpclient.WithUserID(ctx, c.userID)
-		ctx = policy.WithUserID(ctx, c.userID)
-	}
-	captureSessionConsentGrants(c, msg)
-	effective, layer := effectiveConsentGrants(c, msg)
-	if effective != nil {
-		ctx = policy.WithConsentGrants(ctx, effective)
-	}
-	ctx = policy.WithConsentLayer(ctx, layer)
+	ctx = turnContext(ctx, c, sessionID, msg, msgSpan)
 	log = logctx.LoggerWithContext(s.log, ctx)
 
 	// Update connection's session ID and mark as persisted
@@ -116,6 +103,7 @@ func (s *Server) processMessage(ctx context.Context, c *Connection, msg *ClientM
 	c.sessionID = sessionID
 	c.sessionPersisted = true
 	c.mu.Unlock()
+	s.replays.bind(c, sessionID)
 
 	// Send connected message if this is a new session
 	if msg.SessionID == "" {
@@ -147,6 +135,26 @@ func (s *Server) processMessage(ctx context.Context, c *Connection, msg *ClientM
 	return processErr
 }
 
+// turnContext enriches ctx with session ID, namespace, trace ID, user ID, and
+// consent grants for one turn, for log↔trace correlation and privacy header
+// propagation. It also stamps the connection's locale on msg.
+func turnContext(ctx context.Context, c *Connection, sessionID string, msg *ClientMessage, span trace.Span) context.Context {
+	ctx = logctx.WithSessionID(ctx, sessionID)
+	ctx = logctx.WithNamespace(ctx, c.namespace)
+	ctx = logctx.WithTraceID(ctx, span.SpanContext().TraceID().String())
+	if c.userID != "" {
+		ctx = httpclient.WithUserID(ctx, c.userID)
+		ctx = policy.WithUserID(ctx, c.userID)
+	}
+	captureSessionConsentGrants(c, msg)
+	applyLocale(c, msg)
+	effective, layer := effectiveConsentGrants(c, msg)
+	if effective != nil {
+		ctx = policy.WithConsentGrants(ctx, effective)
+	}
+	return policy.WithConsentLayer(ctx, layer)
+}
+
 // startMessageSpan starts a tracing span for the message if tracing is enabled.
 // It always derives the trace ID from the session ID (UUID → 128-bit trace ID)
 // so that all messages in a session share the same trace, enabling direct Tempo
@@ -223,9 +231,16 @@ func sessionIDToTraceID(sessionID string) trace.TraceID {
 // gRPC bus, so it is recorded once, protocol- and runtime-agnostically — no
 // per-protocol recording here.
 func (s *Server) processRegularMessage(ctx context.Context, c *Connection, sessionID string, msg *ClientMessage, writer *connResponseWriter, log logr.Logger) error {
-	if s.handler != nil {
-		if err := safeHandleMessage(s.handler, ctx, sessionID, msg, writer, log); err != nil {
-			s.sendError(c, sessionID, ErrorCodeInternalError, "internal server error")
+	s.turnsInFlight.Add(1)
+	defer s.turnsInFlight.Add(-1)
+	c.beginTurn()
+	s.sendTyping(c, sessionID)
+	if s.chain != nil {
+		if err := safeHandleMessage(s.chain, ctx, sessionID, msg, writer, log); err != nil {
+			// An error the handler describes, such as a runtime status,
+			// keeps its code; anything else is an internal error.
+			e := apierror.FromError(err)
+			s.sendError(c, sessionID, e.Code, e.Message)
 			return err
 		}
 	} else {
@@ -269,6 +284,14 @@ func (s *Server) ensureSession(ctx context.Context, c *Connection, sessionID str
 		return sessionID, nil
 	}
 
+	// A connection that joined a shared session speaks for that session even
+	// when the client omits session_id.
+	c.mu.Lock()
+	if sessionID == "" && c.joined {
+		sessionID = c.sessionID
+	}
+	c.mu.Unlock()
+
 	if sessionID != "" && sessionID != c.SessionID() {
 		if err := s.requireResumableContext(ctx, sessionID, log); err != nil {
 			return "", err
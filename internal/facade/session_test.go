@@ -526,6 +526,14 @@ func (m *ensureSessionMetricsSpy) AudioIngestLatency(float64)
 func (m *ensureSessionMetricsSpy) MediaFrameReceived(int)                           {}
 func (m *ensureSessionMetricsSpy) MediaFrameRateLimited()                           {}
 func (m *ensureSessionMetricsSpy) ControlMessageRateLimited()                       {}
+func (m *ensureSessionMetricsSpy) SendQueueBytesChanged(int)                        {}
+func (m *ensureSessionMetricsSpy) OutboundMessageDropped()                          {}
+func (m *ensureSessionMetricsSpy) SlowConsumerDisconnected()                        {}
+func (m *ensureSessionMetricsSpy) MessageFragmented(int)                            {}
+func (m *ensureSessionMetricsSpy) MessagesRedelivered(int)                          {}
+func (m *ensureSessionMetricsSpy) DuplicateClientMessage()                          {}
+func (m *ensureSessionMetricsSpy) AdminSessionTerminated()                          {}
+func (m *ensureSessionMetricsSpy) AdminNoticeSent(int)                              {}
 func (m *ensureSessionMetricsSpy) RealtimeSessionParked()                           {}
 func (m *ensureSessionMetricsSpy) RealtimeSessionReattached()                       {}
 func (m *ensureSessionMetricsSpy) RealtimeSessionParkExpired()                      {}
@@ -0,0 +1,246 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package facade
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session/sessiontest"
+	"github.com/altairalabs/omnia/pkg/facade/auth"
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+// observingHandler records connection lifecycle calls and the connection ID
+// each message arrived on.
+type observingHandler struct {
+	mu       sync.Mutex
+	opened   []ConnectionInfo
+	closed   []ConnectionInfo
+	msgConns []string
+	writers  []ResponseWriter
+}
+
+func (h *observingHandler) Name() string { return "observing" }
+
+func (h *observingHandler) HandleMessage(ctx context.Context, _ string, msg *ClientMessage, w ResponseWriter) error {
+	h.mu.Lock()
+	h.msgConns = append(h.msgConns, ConnectionIDFromContext(ctx))
+	h.mu.Unlock()
+	return w.WriteDone("ok: " + msg.Content)
+}
+
+func (h *observingHandler) ConnectionOpened(_ context.Context, conn ConnectionInfo, w ResponseWriter) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.opened = append(h.opened, conn)
+	h.writers = append(h.writers, w)
+}
+
+func (h *observingHandler) ConnectionClosed(conn ConnectionInfo) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = append(h.closed, conn)
+	return len(h.opened) - len(h.closed)
+}
+
+func (h *observingHandler) openedCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.opened)
+}
+
+func (h *observingHandler) closedCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.closed)
+}
+
+func dialShared(t *testing.T, url, query string) (*websocket.Conn, string) {
+	t.Helper()
+	ws := dialSharedWith(t, url, query, nil)
+	return ws, readConnected(t, ws)
+}
+
+func dialSharedWith(t *testing.T, url, query string, header http.Header) *websocket.Conn {
+	t.Helper()
+	ws, _, err := websocket.DefaultDialer.Dial(wsURL(url)+"?agent=test-agent"+query, header)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+	return ws
+}
+
+// readJoinRefusal reads the error a refused join is answered with.
+func readJoinRefusal(t *testing.T, ws *websocket.Conn) string {
+	t.Helper()
+	var msg ServerMessage
+	require.NoError(t, ws.ReadJSON(&msg))
+	require.Equal(t, MessageTypeError, msg.Type)
+	require.NotNil(t, msg.Error)
+	return msg.Error.Code
+}
+
+// workspaceValidator admits "Bearer <user>" as <user>, a member of team-a.
+type workspaceValidator struct{}
+
+func (workspaceValidator) Validate(_ context.Context, r *http.Request) (*policy.AuthenticatedIdentity, error) {
+	user, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return nil, auth.ErrNoCredential
+	}
+	return &policy.AuthenticatedIdentity{
+		Origin: policy.OriginClientKey, Subject: user, EndUser: user, Workspace: "team-a",
+	}, nil
+}
+
+func TestServerJoinSharedSession(t *testing.T) {
+	h := &observingHandler{}
+	_, ts := newTestServer(t, h)
+
+	_, driverSession := dialShared(t, ts.URL, "&device_id=dev-1")
+	_, observerSession := dialShared(t, ts.URL, "&device_id=dev-1&join="+driverSession+"&role=observer")
+	assert.Equal(t, driverSession, observerSession)
+
+	require.Eventually(t, func() bool { return h.openedCount() == 2 }, time.Second, 10*time.Millisecond)
+	h.mu.Lock()
+	first, second := h.opened[0], h.opened[1]
+	h.mu.Unlock()
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.False(t, first.Joined)
+	assert.True(t, first.Owner)
+	assert.True(t, second.Joined)
+	assert.True(t, second.Owner, "the session's own user joined it")
+	assert.Equal(t, ParticipantRoleObserver, second.RequestedRole)
+	assert.Equal(t, driverSession, second.SessionID)
+}
+
+func TestServerJoinRefused(t *testing.T) {
+	h := &observingHandler{}
+	_, ts := newTestServer(t, h)
+	_, sessionID := dialShared(t, ts.URL, "&device_id=dev-1")
+
+	unknown := dialSharedWith(t, ts.URL, "&device_id=dev-1&join=not-a-session", nil)
+	assert.Equal(t, ErrorCodeSessionNotFound, readJoinRefusal(t, unknown))
+
+	stranger := dialSharedWith(t, ts.URL, "&device_id=dev-2&join="+sessionID, nil)
+	assert.Equal(t, ErrorCodePermissionDenied, readJoinRefusal(t, stranger))
+
+	anonymous := dialSharedWith(t, ts.URL, "&join="+sessionID, nil)
+	assert.Equal(t, ErrorCodePermissionDenied, readJoinRefusal(t, anonymous))
+	assert.Equal(t, 1, h.openedCount(), "refused joins never reach the handler")
+}
+
+func TestServerJoinAsWorkspaceMember(t *testing.T) {
+	h := &observingHandler{}
+	store := sessiontest.NewStore()
+	t.Cleanup(func() { _ = store.Close() })
+	server := NewServer(DefaultServerConfig(), store, h, logr.Discard(), WithAuthChain(auth.Chain{workspaceValidator{}}))
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	bearer := func(user string) http.Header { return http.Header{"Authorization": {"Bearer " + user}} }
+
+	owner := dialSharedWith(t, ts.URL, "&workspace=team-a", bearer("alice"))
+	sessionID := readConnected(t, owner)
+	member := dialSharedWith(t, ts.URL, "&join="+sessionID, bearer("bob"))
+	assert.Equal(t, sessionID, readConnected(t, member))
+
+	other := dialSharedWith(t, ts.URL, "&workspace=team-b", bearer("carol"))
+	otherSession := readConnected(t, other)
+	outsider := dialSharedWith(t, ts.URL, "&join="+otherSession, bearer("bob"))
+	assert.Equal(t, ErrorCodePermissionDenied, readJoinRefusal(t, outsider))
+
+	require.Eventually(t, func() bool { return h.openedCount() == 3 }, time.Second, 10*time.Millisecond)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	assert.True(t, h.opened[1].Joined)
+	assert.False(t, h.opened[1].Owner, "a workspace member joins as a guest")
+}
+
+func TestServerJoinIgnoredWithoutObserver(t *testing.T) {
+	_, ts := newTestServer(t, &mockHandler{})
+
+	_, first := dialShared(t, ts.URL, "")
+	_, second := dialShared(t, ts.URL, "&join="+first)
+	assert.NotEqual(t, first, second)
+}
+
+func TestServerJoinedConnectionUsesSharedSessionID(t *testing.T) {
+	h := &observingHandler{}
+	_, ts := newTestServer(t, h)
+
+	_, sessionID := dialShared(t, ts.URL, "&device_id=dev-1")
+	joiner, _ := dialShared(t, ts.URL, "&device_id=dev-1&join="+sessionID)
+
+	// The joiner omits session_id; the facade still routes to the shared session.
+	require.NoError(t, joiner.WriteJSON(ClientMessage{Type: MessageTypeMessage, Content: "hi"}))
+	var msg ServerMessage
+	for msg.Type != MessageTypeDone {
+		require.NoError(t, joiner.ReadJSON(&msg))
+		assert.Equal(t, sessionID, msg.SessionID)
+	}
+	assert.Equal(t, "ok: hi", msg.Content)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	require.Len(t, h.msgConns, 1)
+	assert.Equal(t, h.opened[1].ID, h.msgConns[0])
+}
+
+func TestServerPresenceWriterAndClose(t *testing.T) {
+	h := &observingHandler{}
+	_, ts := newTestServer(t, h)
+
+	ws, sessionID := dialShared(t, ts.URL, "")
+	require.Eventually(t, func() bool { return h.openedCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	h.mu.Lock()
+	pw, ok := h.writers[0].(PresenceWriter)
+	connID := h.opened[0].ID
+	h.mu.Unlock()
+	require.True(t, ok)
+	require.NoError(t, pw.WritePresence(&PresenceInfo{
+		SelfID:       connID,
+		Participants: []ParticipantInfo{{ID: connID, Role: ParticipantRoleDriver}},
+	}))
+
+	var msg ServerMessage
+	require.NoError(t, ws.ReadJSON(&msg))
+	assert.Equal(t, MessageTypePresence, msg.Type)
+	assert.Equal(t, sessionID, msg.SessionID)
+	require.NotNil(t, msg.Presence)
+	assert.Equal(t, connID, msg.Presence.SelfID)
+	require.Len(t, msg.Presence.Participants, 1)
+	assert.Equal(t, ParticipantRoleDriver, msg.Presence.Participants[0].Role)
+
+	_ = ws.Close()
+	require.Eventually(t, func() bool { return h.closedCount() == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func TestConnectionIDFromContext(t *testing.T) {
+	assert.Empty(t, ConnectionIDFromContext(context.Background()))
+	assert.Equal(t, "conn-1", ConnectionIDFromContext(WithConnectionID(context.Background(), "conn-1")))
+}
@@ -28,6 +28,7 @@ package promptpack
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
@@ -43,6 +44,10 @@ import (
 // callers — Load returns raw bytes.
 const packJSONKey = "pack.json"
 
+// ErrNotFound is returned when no PromptPack exists for a packName (and
+// version, when one is pinned).
+var ErrNotFound = errors.New("no PromptPack found")
+
 // Resolver loads PromptPack content via the PromptPack CR.
 type Resolver struct {
 	client client.Client
@@ -62,22 +67,39 @@ func NewResolver(c client.Client) *Resolver {
 // equals packName — and then resolves spec.source; callers must not read the
 // backing store directly.
 func (r *Resolver) Load(ctx context.Context, namespace, packName, version string) ([]byte, error) {
+	_, content, err := r.Resolve(ctx, namespace, packName, version)
+	return content, err
+}
+
+// Resolve is Load that also returns the selected PromptPack CR, so callers
+// can report which version the content belongs to.
+func (r *Resolver) Resolve(ctx context.Context, namespace, packName, version string) (*omniav1alpha1.PromptPack, []byte, error) {
 	var list omniav1alpha1.PromptPackList
 	if err := r.client.List(ctx, &list, client.InNamespace(namespace), client.MatchingLabels{packselect.Label: packName}); err != nil {
-		return nil, fmt.Errorf("list PromptPacks %s/%s: %w", namespace, packName, err)
+		return nil, nil, fmt.Errorf("list PromptPacks %s/%s: %w", namespace, packName, err)
 	}
 
 	pp, err := selectPromptPack(list.Items, packName, version)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	content, err := r.LoadContent(ctx, pp)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pp, content, nil
+}
 
+// LoadContent returns the raw pack.json bytes that pp's spec.source points at.
+// pp need not exist in the cluster yet — callers previewing a PromptPack
+// before creating it resolve its source this way.
+func (r *Resolver) LoadContent(ctx context.Context, pp *omniav1alpha1.PromptPack) ([]byte, error) {
 	switch pp.Spec.Source.Type {
 	case omniav1alpha1.PromptPackSourceTypeConfigMap:
 		return r.loadConfigMap(ctx, pp)
 	default:
 		return nil, fmt.Errorf("PromptPack %s/%s: unsupported source type %q",
-			namespace, pp.Name, pp.Spec.Source.Type)
+			pp.Namespace, pp.Name, pp.Spec.Source.Type)
 	}
 }
 
@@ -90,7 +112,7 @@ func (r *Resolver) Load(ctx context.Context, namespace, packName, version string
 // packselect with resolver-specific error wording that callers/tests rely on.
 func selectPromptPack(candidates []omniav1alpha1.PromptPack, packName, version string) (*omniav1alpha1.PromptPack, error) {
 	if len(candidates) == 0 {
-		return nil, fmt.Errorf("no PromptPack found for packName %q", packName)
+		return nil, fmt.Errorf("%w for packName %q", ErrNotFound, packName)
 	}
 	if version != "" {
 		for i := range candidates {
@@ -98,7 +120,7 @@ func selectPromptPack(candidates []omniav1alpha1.PromptPack, packName, version s
 				return &candidates[i], nil
 			}
 		}
-		return nil, fmt.Errorf("no PromptPack matches packName %q version %q", packName, version)
+		return nil, fmt.Errorf("%w for packName %q version %q", ErrNotFound, packName, version)
 	}
 	pp, err := packselect.ChannelMax(candidates, packselect.TrackStable)
 	if err != nil {
@@ -0,0 +1,312 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/runtime/hooks"
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/PromptKit/runtime/statestore"
+	"github.com/AltairaLabs/PromptKit/runtime/tokenizer"
+	"github.com/AltairaLabs/PromptKit/runtime/types"
+	"github.com/AltairaLabs/PromptKit/sdk"
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/internal/runtime/budget"
+	"github.com/altairalabs/omnia/pkg/apierror"
+)
+
+// errorCodeBudgetExceeded is the Error code sent when a turn is rejected
+// because its session, the agent or its workspace is over budget.
+const errorCodeBudgetExceeded = apierror.CodeBudgetExceeded
+
+// eventBudgetExceeded is recorded in the session whenever a turn finds a
+// budget exceeded, with the action taken.
+const eventBudgetExceeded events.EventType = "budget.exceeded"
+
+// Actions taken on an exceeded budget.
+const (
+	budgetActionReject    = "reject"
+	budgetActionSummarize = "summarize"
+)
+
+// Conversation state metadata holding the session's usage since its history
+// was last summarized, so the session keeps its budget when it reconnects or
+// moves to another replica.
+const (
+	stateMetadataBudgetTokens  = "omnia.budget.tokens"
+	stateMetadataBudgetCostUSD = "omnia.budget.costUSD"
+)
+
+// hookMetadataBudget carries the *budget.ExceededError on a provider call
+// denied by budgetProviderHook.
+const hookMetadataBudget = "omnia.budget"
+
+// enforceBudget checks the session, agent and workspace budgets before a turn.
+// A session over its budget is summarized and continues when budgetSummarize
+// is set; otherwise, and whenever the agent or workspace is over budget, the
+// turn is rejected with a *budget.ExceededError. Either way the exceeded
+// budget is recorded in the session.
+func (s *Server) enforceBudget(ctx context.Context, conv *sdk.Conversation, sessionID string, log logr.Logger) error {
+	if s.budget == nil {
+		return nil
+	}
+	s.seedSessionBudget(ctx, sessionID)
+	exceeded := s.budget.Check(ctx, sessionID)
+	if exceeded == nil {
+		return nil
+	}
+	if exceeded.Scope == budget.ScopeSession && s.budgetSummarize {
+		err := s.summarizeSession(ctx, conv, sessionID)
+		if err == nil {
+			s.budget.Metrics().RecordExceeded(exceeded.Scope, budgetActionSummarize)
+			s.publishBudgetExceeded(conv, sessionID, exceeded, budgetActionSummarize)
+			log.Info("session over budget summarized", "usedTokens", exceeded.Usage.Tokens,
+				"usedCostUSD", exceeded.Usage.CostUSD)
+			return nil
+		}
+		log.Error(err, "summarizing session over budget failed; rejecting the turn")
+	}
+	s.budget.Metrics().RecordExceeded(exceeded.Scope, budgetActionReject)
+	s.publishBudgetExceeded(conv, sessionID, exceeded, budgetActionReject)
+	return exceeded
+}
+
+// seedSessionBudget starts tracking a session this replica has not seen from
+// the usage saved in its conversation state.
+func (s *Server) seedSessionBudget(ctx context.Context, sessionID string) {
+	if s.budget.Tracking(sessionID) {
+		return
+	}
+	s.budget.Seed(sessionID, loadSessionUsage(ctx, s.stateStore, sessionID))
+}
+
+// loadSessionUsage reads a session's usage from its conversation state.
+func loadSessionUsage(ctx context.Context, store statestore.Store, sessionID string) budget.Usage {
+	if store == nil {
+		return budget.Usage{}
+	}
+	var metadata map[string]any
+	if accessor, ok := store.(statestore.MetadataAccessor); ok {
+		metadata, _ = accessor.LoadMetadata(ctx, sessionID)
+	} else if state, err := store.Load(ctx, sessionID); err == nil && state != nil {
+		metadata = state.Metadata
+	}
+	tokens, _ := metadata[stateMetadataBudgetTokens].(float64)
+	cost, _ := metadata[stateMetadataBudgetCostUSD].(float64)
+	return budget.Usage{Tokens: int64(tokens), CostUSD: cost}
+}
+
+// saveSessionUsage writes a session's usage to its conversation state. Stores
+// that cannot merge metadata keep the usage in process only.
+func saveSessionUsage(ctx context.Context, store statestore.Store, sessionID string, u budget.Usage) error {
+	accessor, ok := store.(statestore.MetadataAccessor)
+	if !ok {
+		return nil
+	}
+	return accessor.MergeMetadata(ctx, sessionID, usageMetadata(u))
+}
+
+// usageMetadata is the conversation state metadata recording u. Tokens are
+// stored as a float64, the type they decode to from JSON.
+func usageMetadata(u budget.Usage) map[string]any {
+	return map[string]any{
+		stateMetadataBudgetTokens:  float64(u.Tokens),
+		stateMetadataBudgetCostUSD: u.CostUSD,
+	}
+}
+
+// summarizeSession replaces the conversation's history with a summary written
+// by the agent's provider and resets the session's usage. The summary takes
+// the form PromptKit's own summarizing truncation uses.
+func (s *Server) summarizeSession(ctx context.Context, conv *sdk.Conversation, sessionID string) error {
+	writer, ok := s.stateStore.(statestore.BulkWriter)
+	if !ok {
+		return errors.New("summarizing needs a state store that can replace a conversation's history")
+	}
+	llm, err := s.summarizerProvider()
+	if err != nil {
+		return err
+	}
+	state, err := s.stateStore.Load(ctx, conv.ID())
+	if err != nil {
+		return fmt.Errorf("load conversation: %w", err)
+	}
+	// The summarizer reads Content; user turns carry their text in Parts.
+	history := make([]types.Message, len(state.Messages))
+	for i := range state.Messages {
+		history[i] = types.Message{Role: state.Messages[i].Role, Content: state.Messages[i].GetContent()}
+	}
+	summary, err := statestore.NewLLMSummarizer(llm).Summarize(ctx, history)
+	if err != nil {
+		return err
+	}
+
+	state.Metadata = maps.Clone(state.Metadata)
+	if state.Metadata == nil {
+		state.Metadata = make(map[string]any)
+	}
+	maps.Copy(state.Metadata, usageMetadata(budget.Usage{}))
+	state.Messages = []types.Message{{
+		Role:    "system",
+		Content: fmt.Sprintf("[Summary of %d earlier messages]: %s", len(state.Messages), summary),
+		Source:  "summary",
+	}}
+	state.Summaries = nil
+	if err := writer.Save(ctx, state); err != nil {
+		return fmt.Errorf("save summarized conversation: %w", err)
+	}
+	s.budget.Reset(sessionID)
+	return nil
+}
+
+// summarizerProvider returns a provider to summarize with: the mock provider,
+// or the explicit provider from config.
+func (s *Server) summarizerProvider() (providers.Provider, error) {
+	if s.mockProvider {
+		return s.createMockProvider()
+	}
+	llm, err := s.createProviderFromConfig()
+	if err != nil {
+		return nil, err
+	}
+	if llm == nil {
+		return nil, errors.New("summarizing needs an explicit provider")
+	}
+	return llm, nil
+}
+
+// budgetDenied returns the *budget.ExceededError behind err when the budget
+// hook stopped the turn part way through, recording it in the session. Other
+// errors are returned unchanged.
+func (s *Server) budgetDenied(conv *sdk.Conversation, sessionID string, err error) error {
+	exceeded := deniedBudget(err)
+	if exceeded == nil {
+		return err
+	}
+	s.publishBudgetExceeded(conv, sessionID, exceeded, budgetActionReject)
+	return exceeded
+}
+
+// deniedBudget returns the *budget.ExceededError of a provider call denied by
+// budgetProviderHook, or nil.
+func deniedBudget(err error) *budget.ExceededError {
+	var denied *hooks.HookDeniedError
+	if !errors.As(err, &denied) {
+		return nil
+	}
+	exceeded, _ := denied.Metadata[hookMetadataBudget].(*budget.ExceededError)
+	return exceeded
+}
+
+// publishBudgetExceeded records an exceeded budget in the session.
+func (s *Server) publishBudgetExceeded(conv *sdk.Conversation, sessionID string, exceeded *budget.ExceededError, action string) {
+	bus := conv.EventBus()
+	if bus == nil {
+		return
+	}
+	bus.Publish(&events.Event{
+		Type:           eventBudgetExceeded,
+		Timestamp:      time.Now(),
+		SessionID:      sessionID,
+		ConversationID: conv.ID(),
+		Data: &events.CustomEventData{
+			EventName: string(eventBudgetExceeded),
+			Data: map[string]any{
+				"scope":      string(exceeded.Scope),
+				"action":     action,
+				"tokens":     exceeded.Usage.Tokens,
+				"costUSD":    exceeded.Usage.CostUSD,
+				"maxTokens":  exceeded.Limit.MaxTokens,
+				"maxCostUSD": exceeded.Limit.MaxCostUSD,
+			},
+			Message: exceeded.Error(),
+		},
+	})
+}
+
+// budgetProviderHook counts the usage of every provider call of a session,
+// including each round of a tool loop, and denies the next call once the
+// session or the agent is over budget, stopping a runaway loop mid-turn.
+type budgetProviderHook struct {
+	tracker   *budget.Tracker
+	store     statestore.Store
+	sessionID string
+	log       logr.Logger
+}
+
+// Compile-time interface check.
+var _ hooks.ProviderHook = budgetProviderHook{}
+
+// Name implements hooks.ProviderHook.
+func (budgetProviderHook) Name() string { return "omnia-budget" }
+
+// BeforeCall implements hooks.ProviderHook.
+func (h budgetProviderHook) BeforeCall(ctx context.Context, _ *hooks.ProviderRequest) hooks.Decision {
+	exceeded := h.tracker.Check(ctx, h.sessionID)
+	if exceeded == nil {
+		return hooks.Allow
+	}
+	h.tracker.Metrics().RecordExceeded(exceeded.Scope, budgetActionReject)
+	return hooks.DenyWithMetadata(exceeded.Error(), map[string]any{hookMetadataBudget: exceeded})
+}
+
+// AfterCall implements hooks.ProviderHook. A provider that reports no usage
+// is counted by an estimate of the call's tokens, with no cost. When the call
+// takes a budget over its cap and asks for tool calls, the turn stops here
+// rather than running another round; a final response is kept, and the next
+// turn is rejected.
+func (h budgetProviderHook) AfterCall(ctx context.Context, req *hooks.ProviderRequest, resp *hooks.ProviderResponse) hooks.Decision {
+	var u budget.Usage
+	if cost := resp.Message.CostInfo; cost != nil {
+		u = budget.Usage{
+			Tokens:  int64(cost.InputTokens + cost.OutputTokens),
+			CostUSD: cost.TotalCost,
+		}
+	} else {
+		u = estimateUsage(req, resp)
+	}
+	exceeded := h.tracker.Record(ctx, h.sessionID, u)
+	if err := saveSessionUsage(ctx, h.store, h.sessionID, h.tracker.Session(h.sessionID)); err != nil {
+		h.log.Error(err, "saving session budget usage failed", "sessionID", h.sessionID)
+	}
+	if exceeded == nil || len(resp.Message.ToolCalls) == 0 {
+		return hooks.Allow
+	}
+	h.tracker.Metrics().RecordExceeded(exceeded.Scope, budgetActionReject)
+	return hooks.DenyWithMetadata(exceeded.Error(), map[string]any{hookMetadataBudget: exceeded})
+}
+
+// estimateUsage estimates the tokens of a provider call from the text of its
+// request and response.
+func estimateUsage(req *hooks.ProviderRequest, resp *hooks.ProviderResponse) budget.Usage {
+	counter := tokenizer.NewHeuristicTokenCounter(tokenizer.GetModelFamily(resp.Model))
+	tokens := counter.CountTokens(resp.Message.GetContent())
+	if req != nil {
+		tokens += counter.CountTokens(req.SystemPrompt)
+		for i := range req.Messages {
+			tokens += counter.CountTokens(req.Messages[i].GetContent())
+		}
+	}
+	return budget.Usage{Tokens: int64(tokens)}
+}
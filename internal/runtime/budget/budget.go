@@ -0,0 +1,312 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package budget tracks the tokens and estimated cost an agent spends, per
+// session, per agent over a time window and per workspace per day and month,
+// and reports when a configured limit is reached. Agent and workspace usage
+// lives in a Ledger, which a Redis backend shares across replicas and agents;
+// per-session usage is kept in process, seeded from the session's recorded
+// totals when a replica first sees the session.
+//
+// Tracking is fail-open: ledger errors are logged and treated as no usage, so
+// a ledger outage loosens the agent budget, never fails turns.
+package budget
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// DefaultWindow is the agent budget window when Config.Window is unset.
+const DefaultWindow = 24 * time.Hour
+
+// Scope is what a budget limits.
+type Scope string
+
+// Budget scopes.
+const (
+	ScopeSession          Scope = "session"
+	ScopeAgent            Scope = "agent"
+	ScopeWorkspaceDaily   Scope = "workspace-daily"
+	ScopeWorkspaceMonthly Scope = "workspace-monthly"
+)
+
+// Usage is the tokens and estimated cost spent.
+type Usage struct {
+	Tokens  int64
+	CostUSD float64
+}
+
+// Add returns the sum of u and o.
+func (u Usage) Add(o Usage) Usage {
+	return Usage{Tokens: u.Tokens + o.Tokens, CostUSD: u.CostUSD + o.CostUSD}
+}
+
+// Sub returns u less o, floored at zero.
+func (u Usage) Sub(o Usage) Usage {
+	return Usage{Tokens: max(u.Tokens-o.Tokens, 0), CostUSD: max(u.CostUSD-o.CostUSD, 0)}
+}
+
+// Limit caps tokens, cost or both. Zero caps are unlimited.
+type Limit struct {
+	MaxTokens  int64
+	MaxCostUSD float64
+}
+
+// Empty reports whether the limit caps nothing.
+func (l Limit) Empty() bool {
+	return l.MaxTokens <= 0 && l.MaxCostUSD <= 0
+}
+
+// Exceeded reports whether u has reached either cap.
+func (l Limit) Exceeded(u Usage) bool {
+	return (l.MaxTokens > 0 && u.Tokens >= l.MaxTokens) ||
+		(l.MaxCostUSD > 0 && u.CostUSD >= l.MaxCostUSD)
+}
+
+// ExceededError reports a budget that has been reached.
+type ExceededError struct {
+	Scope Scope
+	Usage Usage
+	Limit Limit
+}
+
+// Error describes the cap that was reached, e.g. "session budget exceeded:
+// 12000 of 10000 tokens used".
+func (e *ExceededError) Error() string {
+	if e.Limit.MaxTokens > 0 && e.Usage.Tokens >= e.Limit.MaxTokens {
+		return fmt.Sprintf("%s budget exceeded: %d of %d tokens used", e.Scope, e.Usage.Tokens, e.Limit.MaxTokens)
+	}
+	return fmt.Sprintf("%s budget exceeded: $%.2f of $%.2f used", e.Scope, e.Usage.CostUSD, e.Limit.MaxCostUSD)
+}
+
+// Ledger counts agent and workspace usage per window.
+type Ledger interface {
+	// Add atomically adds u to the usage counted under key, which expires
+	// after ttl, and returns the usage counted including u. Replicas sharing a
+	// ledger each see the total their own increment produced, so exactly the
+	// first write to reach a cap observes it.
+	Add(ctx context.Context, key string, u Usage, ttl time.Duration) (Usage, error)
+	// Get returns the usage counted under key.
+	Get(ctx context.Context, key string) (Usage, error)
+}
+
+// Config configures a Tracker.
+type Config struct {
+	// Session caps each session's lifetime usage.
+	Session Limit
+	// Agent caps the usage of all sessions together per Window.
+	Agent Limit
+	// Window is the period agent usage is counted over (DefaultWindow when
+	// zero). Windows are aligned to the Unix epoch.
+	Window time.Duration
+	// Workspace names the workspace whose WorkspaceDaily and WorkspaceMonthly
+	// limits the agent counts towards.
+	Workspace string
+	// WorkspaceDaily caps the usage of every agent in Workspace together per
+	// UTC day.
+	WorkspaceDaily Limit
+	// WorkspaceMonthly caps the usage of every agent in Workspace together per
+	// UTC calendar month.
+	WorkspaceMonthly Limit
+	// Metrics records exceeded budgets. Optional.
+	Metrics *Metrics
+	// Log receives fail-open ledger errors.
+	Log logr.Logger
+}
+
+// Empty reports whether the config caps nothing.
+func (c Config) Empty() bool {
+	return c.Session.Empty() && c.Agent.Empty() && !c.workspace()
+}
+
+// workspace reports whether the config caps workspace usage.
+func (c Config) workspace() bool {
+	return c.Workspace != "" && (!c.WorkspaceDaily.Empty() || !c.WorkspaceMonthly.Empty())
+}
+
+// Tracker tracks usage against a Config.
+type Tracker struct {
+	cfg    Config
+	agent  string
+	ledger Ledger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]Usage
+}
+
+// New creates a Tracker for the agent identified by agentKey (typically
+// namespace/name), counting agent usage in ledger.
+func New(cfg Config, agentKey string, ledger Ledger) *Tracker {
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultWindow
+	}
+	return &Tracker{
+		cfg:      cfg,
+		agent:    agentKey,
+		ledger:   ledger,
+		now:      time.Now,
+		sessions: make(map[string]Usage),
+	}
+}
+
+// Metrics returns the Tracker's metrics; nil when none are configured.
+func (t *Tracker) Metrics() *Metrics {
+	return t.cfg.Metrics
+}
+
+// Tracking reports whether sessionID's usage is already known.
+func (t *Tracker) Tracking(sessionID string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.sessions[sessionID]
+	return ok
+}
+
+// Seed sets sessionID's usage unless it is already known.
+func (t *Tracker) Seed(sessionID string, u Usage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.sessions[sessionID]; !ok {
+		t.sessions[sessionID] = u
+	}
+}
+
+// Reset clears sessionID's usage, giving the session a fresh budget. Agent
+// usage is unaffected.
+func (t *Tracker) Reset(sessionID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sessions[sessionID] = Usage{}
+}
+
+// Forget drops sessionID's usage when the session ends on this replica.
+func (t *Tracker) Forget(sessionID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.sessions, sessionID)
+}
+
+// Session returns sessionID's usage.
+func (t *Tracker) Session(sessionID string) Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.sessions[sessionID]
+}
+
+// Record adds u to sessionID's usage, to the agent's current window and to
+// the workspace's current day and month. It returns an *ExceededError for the
+// first of those budgets the new totals have reached, or nil; the totals are
+// the ones each increment produced, not a separate read, so a call that takes
+// a shared budget over its cap is caught even while other replicas spend from
+// it.
+func (t *Tracker) Record(ctx context.Context, sessionID string, u Usage) *ExceededError {
+	if u == (Usage{}) {
+		return nil
+	}
+	t.mu.Lock()
+	used := t.sessions[sessionID].Add(u)
+	t.sessions[sessionID] = used
+	t.mu.Unlock()
+	var exceeded *ExceededError
+	if t.cfg.Session.Exceeded(used) {
+		exceeded = &ExceededError{Scope: ScopeSession, Usage: used, Limit: t.cfg.Session}
+	}
+	for _, w := range t.windows() {
+		used, err := t.ledger.Add(ctx, w.key, u, w.ttl)
+		if err != nil {
+			t.cfg.Log.Error(err, "budget ledger write failed; usage not counted", "scope", w.scope)
+			continue
+		}
+		if exceeded == nil && w.limit.Exceeded(used) {
+			exceeded = &ExceededError{Scope: w.scope, Usage: used, Limit: w.limit}
+		}
+	}
+	return exceeded
+}
+
+// Check returns an *ExceededError for the first of sessionID's session budget,
+// the agent budget and the workspace budgets that has been reached, or nil.
+func (t *Tracker) Check(ctx context.Context, sessionID string) *ExceededError {
+	if used := t.Session(sessionID); t.cfg.Session.Exceeded(used) {
+		return &ExceededError{Scope: ScopeSession, Usage: used, Limit: t.cfg.Session}
+	}
+	for _, w := range t.windows() {
+		used, err := t.ledger.Get(ctx, w.key)
+		if err != nil {
+			t.cfg.Log.Error(err, "budget ledger read failed; budget not checked", "scope", w.scope)
+			continue
+		}
+		if w.limit.Exceeded(used) {
+			return &ExceededError{Scope: w.scope, Usage: used, Limit: w.limit}
+		}
+	}
+	return nil
+}
+
+// window is a budget counted in the ledger.
+type window struct {
+	scope Scope
+	limit Limit
+	key   string
+	ttl   time.Duration
+}
+
+// windows returns the current windows of the ledger-counted budgets that cap
+// something. Each window's usage is kept for two windows so a replica whose
+// clock lags still finds it.
+func (t *Tracker) windows() []window {
+	now := t.now()
+	var ws []window
+	if !t.cfg.Agent.Empty() {
+		index := now.UnixMilli() / t.cfg.Window.Milliseconds()
+		ws = append(ws, window{
+			scope: ScopeAgent,
+			limit: t.cfg.Agent,
+			key:   t.agent + ":" + strconv.FormatInt(index, 10),
+			ttl:   2 * t.cfg.Window,
+		})
+	}
+	if t.cfg.Workspace == "" {
+		return ws
+	}
+	// Workspace names cannot contain a colon, so these keys never collide
+	// with an agent's namespace/name keys.
+	now = now.UTC()
+	if !t.cfg.WorkspaceDaily.Empty() {
+		ws = append(ws, window{
+			scope: ScopeWorkspaceDaily,
+			limit: t.cfg.WorkspaceDaily,
+			key:   "workspace:" + t.cfg.Workspace + ":day:" + now.Format(time.DateOnly),
+			ttl:   2 * 24 * time.Hour,
+		})
+	}
+	if !t.cfg.WorkspaceMonthly.Empty() {
+		ws = append(ws, window{
+			scope: ScopeWorkspaceMonthly,
+			limit: t.cfg.WorkspaceMonthly,
+			key:   "workspace:" + t.cfg.Workspace + ":month:" + now.Format("2006-01"),
+			ttl:   2 * 31 * 24 * time.Hour,
+		})
+	}
+	return ws
+}
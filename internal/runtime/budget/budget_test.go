@@ -0,0 +1,232 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budget
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimit(t *testing.T) {
+	assert.True(t, Limit{}.Empty())
+	assert.False(t, Limit{}.Exceeded(Usage{Tokens: 1 << 40, CostUSD: 1e9}), "an empty limit caps nothing")
+
+	l := Limit{MaxTokens: 100, MaxCostUSD: 1}
+	assert.False(t, l.Exceeded(Usage{Tokens: 99, CostUSD: 0.99}))
+	assert.True(t, l.Exceeded(Usage{Tokens: 100}), "reaching the cap exceeds it")
+	assert.True(t, l.Exceeded(Usage{CostUSD: 1.5}))
+}
+
+func TestExceededError(t *testing.T) {
+	err := &ExceededError{Scope: ScopeSession, Usage: Usage{Tokens: 12000}, Limit: Limit{MaxTokens: 10000}}
+	assert.Equal(t, "session budget exceeded: 12000 of 10000 tokens used", err.Error())
+	err = &ExceededError{Scope: ScopeAgent, Usage: Usage{Tokens: 5, CostUSD: 100.5}, Limit: Limit{MaxTokens: 10, MaxCostUSD: 100}}
+	assert.Equal(t, "agent budget exceeded: $100.50 of $100.00 used", err.Error())
+}
+
+func TestTracker_Session(t *testing.T) {
+	tr := New(Config{Session: Limit{MaxTokens: 100}, Log: logr.Discard()}, "ns/agent", NewMemoryLedger())
+	ctx := context.Background()
+
+	assert.False(t, tr.Tracking("s1"))
+	tr.Seed("s1", Usage{Tokens: 60})
+	tr.Seed("s1", Usage{Tokens: 1000})
+	assert.Equal(t, Usage{Tokens: 60}, tr.Session("s1"), "seeding a tracked session changes nothing")
+	assert.Nil(t, tr.Check(ctx, "s1"))
+
+	tr.Record(ctx, "s1", Usage{Tokens: 40, CostUSD: 0.01})
+	exceeded := tr.Check(ctx, "s1")
+	require.NotNil(t, exceeded)
+	assert.Equal(t, ScopeSession, exceeded.Scope)
+	assert.Equal(t, Usage{Tokens: 100, CostUSD: 0.01}, exceeded.Usage)
+	assert.Nil(t, tr.Check(ctx, "s2"), "sessions have their own budgets")
+
+	tr.Reset("s1")
+	assert.Nil(t, tr.Check(ctx, "s1"))
+	assert.True(t, tr.Tracking("s1"))
+	tr.Forget("s1")
+	assert.False(t, tr.Tracking("s1"))
+}
+
+func TestTracker_Agent(t *testing.T) {
+	ledger := NewMemoryLedger()
+	now := time.Date(2026, 10, 17, 23, 0, 0, 0, time.UTC)
+	ledger.now = func() time.Time { return now }
+	tr := New(Config{Agent: Limit{MaxCostUSD: 1}, Log: logr.Discard()}, "ns/agent", ledger)
+	tr.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	tr.Record(ctx, "s1", Usage{Tokens: 10, CostUSD: 0.6})
+	assert.Nil(t, tr.Check(ctx, "s2"))
+	tr.Record(ctx, "s2", Usage{Tokens: 10, CostUSD: 0.6})
+	exceeded := tr.Check(ctx, "s3")
+	require.NotNil(t, exceeded, "the agent budget counts every session")
+	assert.Equal(t, ScopeAgent, exceeded.Scope)
+	assert.InDelta(t, 1.2, exceeded.Usage.CostUSD, 1e-9)
+
+	now = now.Add(2 * time.Hour)
+	assert.Nil(t, tr.Check(ctx, "s3"), "a new window starts at midnight UTC")
+}
+
+func TestTracker_Workspace(t *testing.T) {
+	ledger := NewMemoryLedger()
+	now := time.Date(2026, 10, 30, 23, 0, 0, 0, time.UTC)
+	ledger.now = func() time.Time { return now }
+	cfg := Config{
+		Workspace:        "acme",
+		WorkspaceDaily:   Limit{MaxCostUSD: 1},
+		WorkspaceMonthly: Limit{MaxCostUSD: 1.5},
+		Log:              logr.Discard(),
+	}
+	require.False(t, cfg.Empty())
+	first := New(cfg, "ns/first", ledger)
+	second := New(cfg, "ns/second", ledger)
+	for _, tr := range []*Tracker{first, second} {
+		tr.now = func() time.Time { return now }
+	}
+	ctx := context.Background()
+
+	first.Record(ctx, "s1", Usage{Tokens: 10, CostUSD: 0.6})
+	assert.Nil(t, second.Check(ctx, "s2"))
+	second.Record(ctx, "s2", Usage{Tokens: 10, CostUSD: 0.6})
+	exceeded := first.Check(ctx, "s3")
+	require.NotNil(t, exceeded, "every agent in the workspace counts towards its budget")
+	assert.Equal(t, ScopeWorkspaceDaily, exceeded.Scope)
+	assert.Equal(t, "workspace-daily budget exceeded: $1.20 of $1.00 used", exceeded.Error())
+
+	now = now.Add(2 * time.Hour)
+	assert.Nil(t, first.Check(ctx, "s1"), "a new day starts at midnight UTC")
+	first.Record(ctx, "s1", Usage{Tokens: 10, CostUSD: 0.4})
+	exceeded = first.Check(ctx, "s1")
+	require.NotNil(t, exceeded)
+	assert.Equal(t, ScopeWorkspaceMonthly, exceeded.Scope)
+
+	now = now.Add(24 * time.Hour)
+	assert.Nil(t, first.Check(ctx, "s1"), "a new month starts on the first, UTC")
+
+	assert.True(t, Config{WorkspaceDaily: Limit{MaxCostUSD: 1}}.Empty(), "workspace limits need a workspace")
+}
+
+func TestMemoryLedger_Expiry(t *testing.T) {
+	ledger := NewMemoryLedger()
+	now := time.Now()
+	ledger.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := ledger.Add(ctx, "a", Usage{Tokens: 5}, time.Minute)
+	require.NoError(t, err)
+	total, err := ledger.Add(ctx, "a", Usage{Tokens: 5}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, Usage{Tokens: 10}, total, "Add returns the new total")
+	u, err := ledger.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, Usage{Tokens: 10}, u)
+
+	now = now.Add(time.Minute)
+	u, err = ledger.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, Usage{}, u)
+	_, err = ledger.Add(ctx, "b", Usage{Tokens: 1}, time.Minute)
+	require.NoError(t, err)
+	assert.NotContains(t, ledger.counts, "a", "expired counts are dropped on write")
+}
+
+func TestRedisLedger(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ledger := NewRedisLedger(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	ctx := context.Background()
+
+	u, err := ledger.Get(ctx, "ns/agent:1")
+	require.NoError(t, err)
+	assert.Equal(t, Usage{}, u)
+
+	_, err = ledger.Add(ctx, "ns/agent:1", Usage{Tokens: 100, CostUSD: 0.25}, time.Hour)
+	require.NoError(t, err)
+	total, err := ledger.Add(ctx, "ns/agent:1", Usage{Tokens: 50, CostUSD: 0.5}, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), total.Tokens, "Add returns the new total")
+	assert.InDelta(t, 0.75, total.CostUSD, 1e-9)
+	u, err = ledger.Get(ctx, "ns/agent:1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), u.Tokens)
+	assert.InDelta(t, 0.75, u.CostUSD, 1e-9)
+
+	mr.FastForward(time.Hour)
+	u, err = ledger.Get(ctx, "ns/agent:1")
+	require.NoError(t, err)
+	assert.Equal(t, Usage{}, u, "a window's usage expires")
+
+	mr.Close()
+	_, err = ledger.Add(ctx, "ns/agent:1", Usage{Tokens: 1}, time.Hour)
+	assert.Error(t, err)
+}
+
+// Replicas sharing a Redis ledger see the totals their own increments
+// produced, so exactly one concurrent write is the one that reaches the cap.
+func TestTracker_RecordReportsCrossingAcrossReplicas(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cfg := Config{Workspace: "acme", WorkspaceDaily: Limit{MaxTokens: 100}, Log: logr.Discard()}
+	ctx := context.Background()
+
+	const replicas = 10
+	var wg sync.WaitGroup
+	var crossed atomic.Int32
+	for i := range replicas {
+		tr := New(cfg, fmt.Sprintf("ns/agent-%d", i), NewRedisLedger(redis.NewClient(&redis.Options{Addr: mr.Addr()})))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if exceeded := tr.Record(ctx, "s", Usage{Tokens: 10}); exceeded != nil {
+				assert.Equal(t, ScopeWorkspaceDaily, exceeded.Scope)
+				if exceeded.Usage.Tokens == 100 {
+					crossed.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), crossed.Load(), "exactly one write takes the workspace to its cap")
+
+	tr := New(cfg, "ns/late", NewRedisLedger(redis.NewClient(&redis.Options{Addr: mr.Addr()})))
+	exceeded := tr.Record(ctx, "s", Usage{Tokens: 1})
+	require.NotNil(t, exceeded)
+	assert.Equal(t, int64(101), exceeded.Usage.Tokens)
+}
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics(prometheus.NewRegistry(), prometheus.Labels{"agent": "a", "namespace": "ns"})
+	m.RecordExceeded(ScopeSession, "reject")
+	m.RecordExceeded(ScopeSession, "reject")
+	m.RecordExceeded(ScopeAgent, "reject")
+	assert.InDelta(t, 2, testutil.ToFloat64(m.exceeded.WithLabelValues("session", "reject")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.exceeded.WithLabelValues("agent", "reject")), 0)
+
+	var nilMetrics *Metrics
+	nilMetrics.RecordExceeded(ScopeSession, "reject")
+}
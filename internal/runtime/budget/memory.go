@@ -0,0 +1,75 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budget
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Compile-time interface check.
+var _ Ledger = (*MemoryLedger)(nil)
+
+type memoryCount struct {
+	usage     Usage
+	expiresAt time.Time
+}
+
+// MemoryLedger is an in-process Ledger. Usage is local to one runtime pod;
+// use RedisLedger to share it across replicas.
+type MemoryLedger struct {
+	mu     sync.Mutex
+	counts map[string]memoryCount
+	now    func() time.Time
+}
+
+// NewMemoryLedger creates an empty MemoryLedger.
+func NewMemoryLedger() *MemoryLedger {
+	return &MemoryLedger{counts: make(map[string]memoryCount), now: time.Now}
+}
+
+// Add adds u to the usage counted under key and returns the new total.
+// Expired counts are dropped on every write.
+func (l *MemoryLedger) Add(_ context.Context, key string, u Usage, ttl time.Duration) (Usage, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	for k, c := range l.counts {
+		if !now.Before(c.expiresAt) {
+			delete(l.counts, k)
+		}
+	}
+	c, ok := l.counts[key]
+	if !ok {
+		c.expiresAt = now.Add(ttl)
+	}
+	c.usage = c.usage.Add(u)
+	l.counts[key] = c
+	return c.usage, nil
+}
+
+// Get returns the unexpired usage counted under key.
+func (l *MemoryLedger) Get(_ context.Context, key string) (Usage, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c, ok := l.counts[key]
+	if !ok || !l.now().Before(c.expiresAt) {
+		return Usage{}, nil
+	}
+	return c.usage, nil
+}
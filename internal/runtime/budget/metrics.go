@@ -0,0 +1,49 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budget
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics counts exceeded budgets by scope and action. A nil
+// *Metrics records nothing.
+type Metrics struct {
+	exceeded *prometheus.CounterVec
+}
+
+// NewMetrics registers the budget metrics on reg with the given constant
+// labels (typically agent and namespace).
+func NewMetrics(reg prometheus.Registerer, constLabels prometheus.Labels) *Metrics {
+	m := &Metrics{
+		exceeded: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "omnia_runtime_budget_exceeded_total",
+			Help:        "Turns and provider calls that found a budget exceeded, by scope (session|agent|workspace-daily|workspace-monthly) and action (reject|summarize).",
+			ConstLabels: constLabels,
+		}, []string{"scope", "action"}),
+	}
+	reg.MustRegister(m.exceeded)
+	return m
+}
+
+// RecordExceeded counts an exceeded budget and the action taken.
+func (m *Metrics) RecordExceeded(scope Scope, action string) {
+	if m == nil {
+		return
+	}
+	m.exceeded.WithLabelValues(string(scope), action).Inc()
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budget
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis key layout: one hash per agent window holding the tokens and cost
+// counted in it.
+const (
+	redisKeyPrefix  = "omnia:budget:"
+	redisFieldToken = "tokens"
+	redisFieldCost  = "costUSD"
+)
+
+// Compile-time interface check.
+var _ Ledger = (*RedisLedger)(nil)
+
+// RedisLedger is a Ledger shared by every runtime replica of an agent.
+type RedisLedger struct {
+	redis *redis.Client
+}
+
+// NewRedisLedger creates a RedisLedger on rdb.
+func NewRedisLedger(rdb *redis.Client) *RedisLedger {
+	return &RedisLedger{redis: rdb}
+}
+
+// Add atomically adds u to the usage counted under key, extends its expiry to
+// ttl and returns the totals the increments produced. The increments run in
+// one MULTI/EXEC, so the tokens and cost returned are never torn by another
+// replica's write.
+func (l *RedisLedger) Add(ctx context.Context, key string, u Usage, ttl time.Duration) (Usage, error) {
+	k := redisKeyPrefix + key
+	pipe := l.redis.TxPipeline()
+	tokens := pipe.HIncrBy(ctx, k, redisFieldToken, u.Tokens)
+	cost := pipe.HIncrByFloat(ctx, k, redisFieldCost, u.CostUSD)
+	pipe.Expire(ctx, k, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return Usage{}, fmt.Errorf("add budget usage: %w", err)
+	}
+	return Usage{Tokens: tokens.Val(), CostUSD: cost.Val()}, nil
+}
+
+// Get returns the usage counted under key.
+func (l *RedisLedger) Get(ctx context.Context, key string) (Usage, error) {
+	values, err := l.redis.HMGet(ctx, redisKeyPrefix+key, redisFieldToken, redisFieldCost).Result()
+	if err != nil {
+		return Usage{}, fmt.Errorf("get budget usage: %w", err)
+	}
+	var u Usage
+	if s, ok := values[0].(string); ok {
+		u.Tokens, _ = strconv.ParseInt(s, 10, 64)
+	}
+	if s, ok := values[1].(string); ok {
+		u.CostUSD, _ = strconv.ParseFloat(s, 64)
+	}
+	return u, nil
+}
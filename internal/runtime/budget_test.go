@@ -0,0 +1,225 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/runtime/hooks"
+	"github.com/AltairaLabs/PromptKit/runtime/statestore"
+	"github.com/AltairaLabs/PromptKit/runtime/types"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/altairalabs/omnia/internal/runtime/budget"
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+)
+
+func newBudgetServer(t *testing.T, tracker *budget.Tracker, summarize bool, store statestore.Store) *Server {
+	t.Helper()
+	dir := t.TempDir()
+	packPath := filepath.Join(dir, "pack.promptpack")
+	require.NoError(t, writeTestFile(t, packPath, invokeTestPack))
+	server := NewServer(
+		WithLogger(logr.Discard()),
+		WithPackPath(packPath),
+		WithPromptName("default"),
+		WithMockProvider(true),
+		WithStateStore(store),
+		WithBudget(tracker, summarize),
+	)
+	t.Cleanup(func() { _ = server.Close() })
+	return server
+}
+
+func TestConverse_BudgetReject(t *testing.T) {
+	tracker := budget.New(budget.Config{Session: budget.Limit{MaxTokens: 1}, Log: logr.Discard()},
+		"ns/agent", budget.NewMemoryLedger())
+	server := newBudgetServer(t, tracker, false, statestore.NewMemoryStore())
+
+	require.Len(t, converse(t, server, &runtimev1.ClientMessage{SessionId: "sess-1", Content: "hello"}), 1)
+	used := loadSessionUsage(context.Background(), server.stateStore, "sess-1")
+	assert.Positive(t, used.Tokens, "the turn's provider calls are counted in the session's state")
+	assert.False(t, tracker.Tracking("sess-1"), "the usage is dropped from memory when the stream ends")
+
+	recorded := eventRecorder(t, server, "sess-1", eventBudgetExceeded)
+
+	chunks, errs := converseErrors(t, server, &runtimev1.ClientMessage{SessionId: "sess-1", Content: "hello again"})
+	assert.Empty(t, chunks)
+	require.Len(t, errs, 1)
+	assert.Equal(t, errorCodeBudgetExceeded, errs[0].GetCode())
+	assert.Equal(t, fmt.Sprintf("session budget exceeded: %d of 1 tokens used", used.Tokens), errs[0].GetMessage())
+
+	require.Eventually(t, func() bool { return len(recorded()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	data := recorded()[0].Data.(*events.CustomEventData)
+	assert.Equal(t, "session", data.Data["scope"])
+	assert.Equal(t, budgetActionReject, data.Data["action"])
+
+	require.Len(t, converse(t, server, &runtimev1.ClientMessage{SessionId: "sess-2", Content: "hello"}), 1,
+		"other sessions keep their budgets")
+}
+
+func TestConverse_BudgetSummarize(t *testing.T) {
+	tracker := budget.New(budget.Config{Session: budget.Limit{MaxTokens: 1}, Log: logr.Discard()},
+		"ns/agent", budget.NewMemoryLedger())
+	store := statestore.NewMemoryStore()
+	server := newBudgetServer(t, tracker, true, store)
+	ctx := context.Background()
+
+	require.Len(t, converse(t, server, &runtimev1.ClientMessage{SessionId: "sess-1", Content: "hello"}), 1)
+	recorded := eventRecorder(t, server, "sess-1", eventBudgetExceeded)
+	require.Len(t, converse(t, server, &runtimev1.ClientMessage{SessionId: "sess-1", Content: "hello again"}), 1,
+		"a session over budget is summarized and continues")
+
+	state, err := store.Load(ctx, "sess-1")
+	require.NoError(t, err)
+	require.NotEmpty(t, state.Messages)
+	assert.Equal(t, "system", state.Messages[0].Role)
+	assert.Equal(t, "summary", state.Messages[0].Source)
+	assert.Contains(t, state.Messages[0].Content, "[Summary of 2 earlier messages]")
+	assert.Equal(t, "hello again", state.Messages[1].GetContent())
+	assert.Equal(t, budget.Usage{Tokens: int64(state.Messages[2].CostInfo.InputTokens + state.Messages[2].CostInfo.OutputTokens),
+		CostUSD: state.Messages[2].CostInfo.TotalCost}, loadSessionUsage(ctx, store, "sess-1"),
+		"only the turn after the summary counts against the fresh budget")
+
+	require.Eventually(t, func() bool { return len(recorded()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, budgetActionSummarize, recorded()[0].Data.(*events.CustomEventData).Data["action"])
+}
+
+// sequenceLedger returns its usages in turn from Get, then the last one.
+type sequenceLedger struct {
+	mu     sync.Mutex
+	usages []budget.Usage
+}
+
+func (l *sequenceLedger) Add(context.Context, string, budget.Usage, time.Duration) (budget.Usage, error) {
+	return budget.Usage{}, nil
+}
+
+func (l *sequenceLedger) Get(context.Context, string) (budget.Usage, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	u := l.usages[0]
+	if len(l.usages) > 1 {
+		l.usages = l.usages[1:]
+	}
+	return u, nil
+}
+
+// A budget reached after the turn started stops the turn at its next
+// provider call.
+func TestConverse_BudgetExceededMidTurn(t *testing.T) {
+	ledger := &sequenceLedger{usages: []budget.Usage{{}, {Tokens: 500}}}
+	tracker := budget.New(budget.Config{Agent: budget.Limit{MaxTokens: 100}, Log: logr.Discard()}, "ns/agent", ledger)
+	server := newBudgetServer(t, tracker, true, statestore.NewMemoryStore())
+
+	_, errs := converseErrors(t, server, &runtimev1.ClientMessage{SessionId: "sess-1", Content: "hello"})
+	require.Len(t, errs, 1)
+	assert.Equal(t, errorCodeBudgetExceeded, errs[0].GetCode())
+	assert.Equal(t, "agent budget exceeded: 500 of 100 tokens used", errs[0].GetMessage())
+}
+
+func TestBudgetProviderHook(t *testing.T) {
+	tracker := budget.New(budget.Config{Session: budget.Limit{MaxCostUSD: 1}, Log: logr.Discard()},
+		"ns/agent", budget.NewMemoryLedger())
+	hook := budgetProviderHook{tracker: tracker, sessionID: "sess-1"}
+	ctx := context.Background()
+
+	assert.True(t, hook.BeforeCall(ctx, &hooks.ProviderRequest{}).Allow)
+	hook.AfterCall(ctx, &hooks.ProviderRequest{}, &hooks.ProviderResponse{Message: types.Message{
+		CostInfo: &types.CostInfo{InputTokens: 30, OutputTokens: 20, TotalCost: 1.25},
+	}})
+	assert.Equal(t, budget.Usage{Tokens: 50, CostUSD: 1.25}, tracker.Session("sess-1"))
+
+	d := hook.BeforeCall(ctx, &hooks.ProviderRequest{})
+	require.False(t, d.Allow)
+	assert.Equal(t, "session budget exceeded: $1.25 of $1.00 used", d.Reason)
+
+	err := fmt.Errorf("provider stream failed: %w", &hooks.HookDeniedError{Reason: d.Reason, Metadata: d.Metadata})
+	exceeded := deniedBudget(err)
+	require.NotNil(t, exceeded)
+	assert.Equal(t, budget.ScopeSession, exceeded.Scope)
+	assert.Nil(t, deniedBudget(&hooks.HookDeniedError{Reason: "other hook"}))
+}
+
+// A call that takes a shared budget over its cap and asks for tools stops the
+// turn there, using the total its own increment produced.
+func TestBudgetProviderHook_CrossingCallStopsToolLoop(t *testing.T) {
+	ledger := budget.NewMemoryLedger()
+	cfg := budget.Config{Agent: budget.Limit{MaxCostUSD: 1}, Log: logr.Discard()}
+	other := budget.New(cfg, "ns/agent", ledger)
+	tracker := budget.New(cfg, "ns/agent", ledger)
+	hook := budgetProviderHook{tracker: tracker, sessionID: "sess-1"}
+	ctx := context.Background()
+
+	assert.True(t, hook.BeforeCall(ctx, &hooks.ProviderRequest{}).Allow)
+	assert.Nil(t, other.Record(ctx, "sess-2", budget.Usage{CostUSD: 0.7}), "another replica spends mid-call")
+
+	d := hook.AfterCall(ctx, &hooks.ProviderRequest{}, &hooks.ProviderResponse{Message: types.Message{
+		CostInfo:  &types.CostInfo{InputTokens: 10, OutputTokens: 10, TotalCost: 0.5},
+		ToolCalls: []types.MessageToolCall{{ID: "t1", Name: "lookup"}},
+	}})
+	require.False(t, d.Allow)
+	exceeded := deniedBudget(&hooks.HookDeniedError{Reason: d.Reason, Metadata: d.Metadata})
+	require.NotNil(t, exceeded)
+	assert.Equal(t, budget.ScopeAgent, exceeded.Scope)
+	assert.InDelta(t, 1.2, exceeded.Usage.CostUSD, 1e-9)
+
+	d = hook.AfterCall(ctx, &hooks.ProviderRequest{}, &hooks.ProviderResponse{Message: types.Message{
+		CostInfo: &types.CostInfo{TotalCost: 0.1},
+	}})
+	assert.True(t, d.Allow, "a final response over budget is kept")
+}
+
+func TestBudgetProviderHook_EstimatesUsage(t *testing.T) {
+	tracker := budget.New(budget.Config{Log: logr.Discard()}, "ns/agent", budget.NewMemoryLedger())
+	hook := budgetProviderHook{tracker: tracker, sessionID: "sess-1"}
+
+	hook.AfterCall(context.Background(), &hooks.ProviderRequest{
+		SystemPrompt: "You are a helpful assistant.",
+		Messages:     []types.Message{{Role: "user", Content: "What is the capital of France?"}},
+	}, &hooks.ProviderResponse{Message: types.Message{Role: "assistant", Content: "Paris."}})
+	used := tracker.Session("sess-1")
+	assert.Positive(t, used.Tokens, "a provider that reports no usage is counted by an estimate")
+	assert.Zero(t, used.CostUSD)
+}
+
+func TestServer_Invoke_Budget(t *testing.T) {
+	ledger := budget.NewMemoryLedger()
+	tracker := budget.New(budget.Config{Agent: budget.Limit{MaxTokens: 1}, Log: logr.Discard()}, "ns/agent", ledger)
+	s := newInvokeTestServer(t)
+	s.budget = tracker
+
+	_, err := s.Invoke(context.Background(), &runtimev1.InvocationRequest{InvocationId: "test-1", InputJson: `{"q":"hi"}`})
+	require.NoError(t, err)
+	assert.False(t, tracker.Tracking("test-1"), "an invocation's session usage is dropped when it ends")
+
+	_, err = s.Invoke(context.Background(), &runtimev1.InvocationRequest{InvocationId: "test-2", InputJson: `{"q":"hi"}`})
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Contains(t, st.Message(), "agent budget exceeded")
+}
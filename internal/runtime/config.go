@@ -24,6 +24,11 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/altairalabs/omnia/internal/runtime/budget"
+	"github.com/altairalabs/omnia/internal/runtime/delegation"
+	"github.com/altairalabs/omnia/internal/runtime/guardrails"
+	"github.com/altairalabs/omnia/internal/runtime/mtls"
+	"github.com/altairalabs/omnia/internal/runtime/resilience"
 	"github.com/altairalabs/omnia/pkg/k8s"
 )
 
@@ -41,6 +46,9 @@ type Config struct {
 	PromptPackNamespace string // Namespace of the PromptPack CRD (for metrics)
 	PromptPackVersion   string // Version of the PromptPack (for tracing)
 	PromptName          string // Name of the prompt to use from the pack
+	// PromptPackReloadInterval is how often the mounted pack is polled for
+	// changes to reload (0 = reload disabled).
+	PromptPackReloadInterval time.Duration
 
 	// Function configuration (spec.mode == "function"). Consumed by
 	// resolveResponseFormat to constrain the provider's output (#1483).
@@ -54,7 +62,7 @@ type Config struct {
 	ContextTTL  time.Duration // Context TTL
 
 	// Provider configuration
-	ProviderType         string // "claude", "openai", "gemini", "ollama", "mock", "vllm", "voyageai"
+	ProviderType         string // "claude", "openai", "gemini", "ollama", "mock", "vllm", "openai-compatible", "voyageai"
 	Model                string // Model override (e.g., "claude-3-opus")
 	BaseURL              string // Custom base URL for API calls
 	ProviderRefName      string // Name of the Provider CRD (for metrics, if using providerRef)
@@ -71,6 +79,13 @@ type Config struct {
 	// Empty map/nil means no custom headers. Used for gateway providers like OpenRouter.
 	Headers map[string]string
 
+	// openai-compatible request shaping: request params the server rejects
+	// (PromptKit unsupported_params) and the model's declared capabilities
+	// (spec.capabilities). Empty for every other provider type, except for
+	// params an AgentPolicy transform strips.
+	ProviderUnsupportedParams []string
+	ProviderCapabilities      []string
+
 	// ExtraProviders carries every non-default spec.providers[] entry resolved
 	// by role (e.g. inference, embedding). The default llm provider is flattened
 	// into the scalar fields above and does NOT appear here. A later task maps
@@ -83,6 +98,9 @@ type Config struct {
 	PlatformRegion   string
 	PlatformProject  string
 	PlatformEndpoint string
+	// Azure OpenAI routing: deployment name (defaults to Model) and api-version.
+	PlatformDeployment string
+	PlatformAPIVersion string
 
 	// Auth configuration for platform-hosted providers.
 	// AuthType is one of "workloadIdentity", "accessKey", "serviceAccount", "servicePrincipal".
@@ -99,12 +117,61 @@ type Config struct {
 
 	// Context management
 	ContextWindow      int    // Token budget for conversation context (0 = no limit)
-	TruncationStrategy string // How to handle context overflow: "sliding", "summarize", "custom"
+	TruncationStrategy string // How to handle context overflow: "sliding", "summarize", "relevance", "custom"
+
+	// Response cache (spec.responseCache)
+	ResponseCacheEnabled    bool          // Serve repeated prompts from cached responses
+	ResponseCacheTTL        time.Duration // How long a cached response is served (0 = cache default)
+	ResponseCacheSimilarity float64       // Min cosine similarity for near-identical prompts (0 = exact only)
+
+	// Structured output (spec.structuredOutput)
+	StructuredOutputEnabled    bool // Validate responses against the pack's response schema
+	StructuredOutputMaxRepairs int  // Repair requests before a turn fails as STRUCTURED_OUTPUT_INVALID
+
+	// Knowledge retrieval (spec.knowledge)
+	KnowledgeEnabled    bool    // Retrieve vector store chunks into each turn's prompt
+	KnowledgeStoreType  string  // Vector store backend: "pgvector" or "qdrant"
+	KnowledgeCollection string  // pgvector table or Qdrant collection
+	KnowledgeURL        string  // Vector store connection URL (from secret via env)
+	KnowledgeAPIKey     string  // Vector store API key (from secret via env, optional)
+	KnowledgeTopK       int     // Chunks retrieved per turn
+	KnowledgeMinScore   float64 // Min cosine similarity for a chunk to be used (0 = no floor)
+
+	// Guardrail hooks (spec.guardrails); the pack's metadata.guardrails run first
+	Guardrails guardrails.Config
+
+	// Token and cost budgets (spec.budget)
+	BudgetSession   budget.Limit  // Per-session lifetime cap (zero = none)
+	BudgetAgent     budget.Limit  // Per-agent cap per window (zero = none)
+	BudgetWindow    time.Duration // Agent budget window (0 = budget default)
+	BudgetSummarize bool          // Summarize a session over budget instead of rejecting its turns
+
+	// Workspace cost budgets (Workspace spec.costControls with budgetExceededAction block)
+	BudgetWorkspaceDaily   budget.Limit // Cap on every agent in the workspace per UTC day (zero = none)
+	BudgetWorkspaceMonthly budget.Limit // Cap on every agent in the workspace per UTC month (zero = none)
+
+	// Redis shared by every agent of the workspace for agent and workspace
+	// budget counters (env-only; the context store's Redis when empty)
+	BudgetRedisURL string
+
+	// Other agents offered as tools (spec.delegates), resolved to their A2A endpoints
+	Delegates []delegation.Delegate
+
+	// Prepended to the system prompt by the transforms of the AgentPolicies
+	// selecting this agent, which also shape Headers, Model and
+	// ProviderUnsupportedParams
+	SystemPromptPrefix string
 
 	// Provider timeouts
 	ProviderRequestTimeout    time.Duration // Non-streaming HTTP call timeout (0 = provider default)
 	ProviderStreamIdleTimeout time.Duration // SSE stream idle timeout (0 = 30s default)
 
+	// Provider resilience (spec.resilience on the default provider; nil = PromptKit retries)
+	ProviderResilience *resilience.Config
+
+	// Client certificate for the default provider (spec.tls; nil = none)
+	ProviderTLS *mtls.Config
+
 	// Mock provider configuration (for testing)
 	MockProvider   bool   // Enable mock provider instead of real LLM
 	MockConfigPath string // Path to mock responses YAML file (optional)
@@ -188,6 +255,8 @@ const (
 	envPromptName        = "OMNIA_PROMPT_NAME"
 	envContextURL        = "OMNIA_CONTEXT_URL"
 	envContextTTL        = "OMNIA_CONTEXT_TTL"
+	envKnowledgeURL      = "OMNIA_KNOWLEDGE_URL"
+	envKnowledgeAPIKey   = "OMNIA_KNOWLEDGE_API_KEY"
 	envTracingEnabled    = "OMNIA_TRACING_ENABLED"
 	envTracingEndpoint   = "OMNIA_TRACING_ENDPOINT"
 	envTracingSampleRate = "OMNIA_TRACING_SAMPLE_RATE"
@@ -206,6 +275,12 @@ const (
 	// when memory is enabled. Preferred over a cluster-wide WorkspaceList so
 	// every memory-enabled agent pod does not List all workspaces at startup.
 	envWorkspaceUID = "OMNIA_WORKSPACE_UID"
+	// envPromptPackReloadInterval overrides how often the mounted pack is
+	// polled for changes; "0" turns hot reload off.
+	envPromptPackReloadInterval = "OMNIA_PROMPTPACK_RELOAD_INTERVAL"
+	// envBudgetRedisURL names the Redis agent and workspace budgets are
+	// counted in, shared by every agent of the workspace.
+	envBudgetRedisURL = "OMNIA_BUDGET_REDIS_URL"
 )
 
 // Default values.
@@ -214,6 +289,7 @@ const (
 	defaultPromptName         = "default"
 	defaultContextType        = "memory"
 	defaultContextTTL         = 24 * time.Hour
+	defaultPackReloadInterval = 10 * time.Second
 	defaultMediaBasePath      = "/etc/omnia/media"
 	defaultToolsMountPath     = "/etc/omnia/tools"
 	defaultToolsConfigFile    = "tools.yaml"
@@ -243,6 +319,9 @@ func (cfg *Config) parseEnvironmentOverrides() error {
 	if err := cfg.parsePorts(); err != nil {
 		return err
 	}
+	if err := cfg.parsePackReloadInterval(); err != nil {
+		return err
+	}
 	return cfg.parseContextTTL()
 }
 
@@ -298,6 +377,24 @@ func (cfg *Config) parseContextTTL() error {
 	return nil
 }
 
+// parsePackReloadInterval parses the pack reload interval from the
+// OMNIA_PROMPTPACK_RELOAD_INTERVAL environment variable.
+func (cfg *Config) parsePackReloadInterval() error {
+	interval := os.Getenv(envPromptPackReloadInterval)
+	if interval == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return fmt.Errorf(errFmtInvalidEnvVar, envPromptPackReloadInterval, err)
+	}
+	if d < 0 {
+		return fmt.Errorf("invalid %s: must not be negative", envPromptPackReloadInterval)
+	}
+	cfg.PromptPackReloadInterval = d
+	return nil
+}
+
 // LoadConfigWithContext loads configuration from the AgentRuntime CRD.
 // OMNIA_AGENT_NAME and OMNIA_NAMESPACE must be set via the Downward API.
 func LoadConfigWithContext(ctx context.Context) (*Config, error) {
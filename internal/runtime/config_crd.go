@@ -19,15 +19,26 @@ package runtime
 import (
 	"context"
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	v1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/runtime/budget"
+	"github.com/altairalabs/omnia/internal/runtime/delegation"
+	"github.com/altairalabs/omnia/internal/runtime/guardrails"
+	"github.com/altairalabs/omnia/internal/runtime/mtls"
+	"github.com/altairalabs/omnia/internal/runtime/resilience"
 	"github.com/altairalabs/omnia/pkg/k8s"
 	pkgprovider "github.com/altairalabs/omnia/pkg/provider"
 	"github.com/altairalabs/omnia/pkg/servicediscovery"
@@ -89,6 +100,8 @@ func LoadFromCRD(ctx context.Context, c client.Client, name, namespace string) (
 		HealthPort:     defaultHealthPort,
 		ContextTTL:     defaultContextTTL,
 		MediaBasePath:  defaultMediaBasePath,
+
+		PromptPackReloadInterval: defaultPackReloadInterval,
 	}
 
 	// PromptPack info from CRD
@@ -118,6 +131,26 @@ func LoadFromCRD(ctx context.Context, c client.Client, name, namespace string) (
 		return nil, err
 	}
 
+	if err := loadResponseCacheFromCRD(cfg, ar.Spec.ResponseCache); err != nil {
+		return nil, err
+	}
+	loadStructuredOutputFromCRD(cfg, ar.Spec.StructuredOutput)
+	if err := loadKnowledgeFromCRD(cfg, ar.Spec.Knowledge); err != nil {
+		return nil, err
+	}
+	if err := loadGuardrailsFromCRD(cfg, ar.Spec.Guardrails); err != nil {
+		return nil, err
+	}
+	if err := loadModerationKeys(ctx, c, cfg, ar.Spec.Guardrails, namespace); err != nil {
+		return nil, err
+	}
+	if err := loadBudgetFromCRD(cfg, ar.Spec.Budget); err != nil {
+		return nil, err
+	}
+	if err := loadDelegatesFromCRD(ctx, c, cfg, ar.Spec.Delegates, namespace); err != nil {
+		return nil, err
+	}
+
 	// Media config from CRD
 	if ar.Spec.Media != nil && ar.Spec.Media.BasePath != "" {
 		cfg.MediaBasePath = ar.Spec.Media.BasePath
@@ -139,6 +172,11 @@ func LoadFromCRD(ctx context.Context, c client.Client, name, namespace string) (
 	if err := loadProviderFromCRD(ctx, c, cfg, ar, namespace); err != nil {
 		return nil, err
 	}
+	// After provider resolution: transforms rewrite the provider's model,
+	// headers and request parameters.
+	if err := loadTransformsFromPolicies(ctx, c, cfg, namespace); err != nil {
+		return nil, err
+	}
 
 	// Mock provider annotation (dev/test mode)
 	if mock, ok := ar.Annotations["omnia.altairalabs.ai/mock-provider"]; ok && mock == "true" {
@@ -178,6 +216,19 @@ func LoadFromCRD(ctx context.Context, c client.Client, name, namespace string) (
 	}
 	log := logf.FromContext(ctx)
 
+	// The workspace's cost budgets are enforced by every agent in it. An
+	// unreadable Workspace leaves them unenforced rather than failing startup,
+	// as a budget ledger outage does.
+	if workspaceName != "" {
+		if ws, err := resolver.GetWorkspace(ctx, workspaceName); err != nil {
+			if !apierrors.IsNotFound(err) {
+				log.Error(err, "workspace cost budgets not enforced", "workspace", workspaceName)
+			}
+		} else if err := loadWorkspaceBudget(cfg, ws.Spec.CostControls); err != nil {
+			return nil, err
+		}
+	}
+
 	// Session is required, but a failure here is non-fatal: the runtime still
 	// serves, it just has no archive to write to (#1223 keeps that loud).
 	if sessionURL, err := resolver.SessionURL(ctx, workspaceName, serviceGroup); err != nil {
@@ -242,6 +293,9 @@ func LoadFromCRD(ctx context.Context, c client.Client, name, namespace string) (
 	cfg.TracingInsecure = os.Getenv(envTracingInsecure) == "true"
 	cfg.TracingSampleRate = 1.0
 
+	// Shared budget counters live in a Redis named by env (secret-backed)
+	cfg.BudgetRedisURL = os.Getenv(envBudgetRedisURL)
+
 	// Parse env-only overrides (ports, tracing sample rate, etc.)
 	if err := cfg.parseEnvironmentOverrides(); err != nil {
 		return nil, err
@@ -292,6 +346,371 @@ func loadRuntimeContextFromCRD(cfg *Config, ar *v1alpha1.AgentRuntime) error {
 	return nil
 }
 
+// loadResponseCacheFromCRD copies spec.responseCache into the runtime Config.
+func loadResponseCacheFromCRD(cfg *Config, rc *v1alpha1.ResponseCacheConfig) error {
+	if rc == nil || !rc.Enabled {
+		return nil
+	}
+	cfg.ResponseCacheEnabled = true
+	if rc.TTL != nil {
+		ttl, err := time.ParseDuration(*rc.TTL)
+		if err != nil {
+			return fmt.Errorf("parse response cache TTL %q: %w", *rc.TTL, err)
+		}
+		cfg.ResponseCacheTTL = ttl
+	}
+	if rc.SimilarityThreshold != "" {
+		threshold, err := strconv.ParseFloat(rc.SimilarityThreshold, 64)
+		if err != nil || threshold < 0 || threshold > 1 {
+			return fmt.Errorf("response cache similarityThreshold %q must be between 0 and 1", rc.SimilarityThreshold)
+		}
+		cfg.ResponseCacheSimilarity = threshold
+	}
+	return nil
+}
+
+// defaultStructuredOutputMaxRepairs matches the CRD default for
+// spec.structuredOutput.maxRepairAttempts.
+const defaultStructuredOutputMaxRepairs = 1
+
+// loadStructuredOutputFromCRD copies spec.structuredOutput into the runtime Config.
+func loadStructuredOutputFromCRD(cfg *Config, so *v1alpha1.StructuredOutputConfig) {
+	if so == nil || !so.Enabled {
+		return
+	}
+	cfg.StructuredOutputEnabled = true
+	cfg.StructuredOutputMaxRepairs = defaultStructuredOutputMaxRepairs
+	if so.MaxRepairAttempts != nil {
+		cfg.StructuredOutputMaxRepairs = int(*so.MaxRepairAttempts)
+	}
+}
+
+// defaultKnowledgeTopK matches the CRD default for spec.knowledge.topK.
+const defaultKnowledgeTopK = 4
+
+// loadKnowledgeFromCRD copies spec.knowledge into the runtime Config. The
+// vector store URL and API key come from env (secret-backed).
+func loadKnowledgeFromCRD(cfg *Config, k *v1alpha1.KnowledgeConfig) error {
+	if k == nil || !k.Enabled {
+		return nil
+	}
+	cfg.KnowledgeEnabled = true
+	cfg.KnowledgeStoreType = string(k.VectorStore.Type)
+	cfg.KnowledgeCollection = k.VectorStore.Collection
+	cfg.KnowledgeURL = os.Getenv(envKnowledgeURL)
+	cfg.KnowledgeAPIKey = os.Getenv(envKnowledgeAPIKey)
+	cfg.KnowledgeTopK = defaultKnowledgeTopK
+	if k.TopK != nil {
+		cfg.KnowledgeTopK = int(*k.TopK)
+	}
+	if k.MinScore != "" {
+		minScore, err := strconv.ParseFloat(k.MinScore, 64)
+		if err != nil || minScore < 0 || minScore > 1 {
+			return fmt.Errorf("knowledge minScore %q must be between 0 and 1", k.MinScore)
+		}
+		cfg.KnowledgeMinScore = minScore
+	}
+	return nil
+}
+
+// defaultGuardrailOutputWindow matches the CRD default for
+// spec.guardrails.outputStreaming.windowChars.
+const defaultGuardrailOutputWindow = 200
+
+// loadGuardrailsFromCRD copies spec.guardrails into the runtime Config.
+func loadGuardrailsFromCRD(cfg *Config, g *v1alpha1.GuardrailsConfig) error {
+	if g == nil {
+		return nil
+	}
+	var err error
+	if cfg.Guardrails.Input, err = guardrailSpecs(g.Input); err != nil {
+		return fmt.Errorf("guardrails input: %w", err)
+	}
+	if cfg.Guardrails.Output, err = guardrailSpecs(g.Output); err != nil {
+		return fmt.Errorf("guardrails output: %w", err)
+	}
+	if cfg.Guardrails.ToolCalls, err = guardrailSpecs(g.ToolCalls); err != nil {
+		return fmt.Errorf("guardrails toolCalls: %w", err)
+	}
+	if g.OutputStreaming != nil {
+		cfg.Guardrails.OutputWindow = defaultGuardrailOutputWindow
+		if g.OutputStreaming.WindowChars != nil {
+			cfg.Guardrails.OutputWindow = int(*g.OutputStreaming.WindowChars)
+		}
+	}
+	return nil
+}
+
+// loadModerationKeys reads the API keys of the moderation hooks in
+// spec.guardrails from their secretRefs into the hook specs loaded by
+// loadGuardrailsFromCRD.
+func loadModerationKeys(ctx context.Context, c client.Client, cfg *Config, g *v1alpha1.GuardrailsConfig, namespace string) error {
+	if g == nil {
+		return nil
+	}
+	for _, stage := range []struct {
+		hooks []v1alpha1.GuardrailHook
+		specs []guardrails.Spec
+	}{
+		{g.Input, cfg.Guardrails.Input},
+		{g.Output, cfg.Guardrails.Output},
+		{g.ToolCalls, cfg.Guardrails.ToolCalls},
+	} {
+		for i, h := range stage.hooks {
+			if h.Moderation == nil || h.Moderation.SecretRef == nil {
+				continue
+			}
+			name := h.Moderation.SecretRef.Name
+			secret, err := k8s.GetSecret(ctx, c, name, namespace)
+			if err != nil {
+				return fmt.Errorf("read moderation secret: %w", err)
+			}
+			key := secret.Data[secretKeyAPIKey]
+			if len(key) == 0 {
+				return fmt.Errorf("secret %s/%s does not contain key %q", namespace, name, secretKeyAPIKey)
+			}
+			stage.specs[i].Moderation.APIKey = string(key)
+		}
+	}
+	return nil
+}
+
+// secretKeyAPIKey is the key of an API key in a credentials Secret.
+const secretKeyAPIKey = "api-key"
+
+// guardrailSpecs converts GuardrailHooks to guardrail hook specs.
+func guardrailSpecs(hooks []v1alpha1.GuardrailHook) ([]guardrails.Spec, error) {
+	specs := make([]guardrails.Spec, 0, len(hooks))
+	for i, h := range hooks {
+		spec := guardrails.Spec{
+			Name:            string(h.Name),
+			Patterns:        h.Patterns,
+			CaseInsensitive: h.CaseInsensitive,
+			AllowedScripts:  h.AllowedScripts,
+			ClassifierURL:   h.ClassifierURL,
+			Action:          string(h.Action),
+			Message:         h.Message,
+		}
+		if h.MaxChars != nil {
+			spec.MaxChars = int(*h.MaxChars)
+		}
+		if h.MinRatio != "" {
+			minRatio, err := strconv.ParseFloat(h.MinRatio, 64)
+			if err != nil || minRatio < 0 || minRatio > 1 {
+				return nil, fmt.Errorf("hook %d minRatio %q must be between 0 and 1", i, h.MinRatio)
+			}
+			spec.MinRatio = minRatio
+		}
+		if h.Threshold != "" {
+			threshold, err := strconv.ParseFloat(h.Threshold, 64)
+			if err != nil || threshold < 0 || threshold > 1 {
+				return nil, fmt.Errorf("hook %d threshold %q must be between 0 and 1", i, h.Threshold)
+			}
+			spec.Threshold = threshold
+		}
+		if m := h.Moderation; m != nil {
+			spec.Moderation = &guardrails.ModerationSpec{
+				Provider:   string(m.Provider),
+				URL:        m.URL,
+				Model:      m.Model,
+				Region:     m.Region,
+				FailClosed: m.FailClosed,
+			}
+			for _, c := range m.Categories {
+				if spec.Moderation.Categories == nil {
+					spec.Moderation.Categories = make(map[string]float64, len(m.Categories))
+				}
+				var threshold float64
+				if c.Threshold != "" {
+					var err error
+					threshold, err = strconv.ParseFloat(c.Threshold, 64)
+					if err != nil || threshold < 0 || threshold > 1 {
+						return nil, fmt.Errorf("hook %d category %s threshold %q must be between 0 and 1", i, c.Name, c.Threshold)
+					}
+				}
+				spec.Moderation.Categories[c.Name] = threshold
+			}
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// loadBudgetFromCRD copies spec.budget into the runtime Config.
+func loadBudgetFromCRD(cfg *Config, b *v1alpha1.BudgetConfig) error {
+	if b == nil {
+		return nil
+	}
+	var err error
+	if b.Session != nil {
+		if cfg.BudgetSession, err = budgetLimit(b.Session.MaxTokens, b.Session.MaxCostUSD); err != nil {
+			return fmt.Errorf("budget session: %w", err)
+		}
+	}
+	if b.Agent != nil {
+		if cfg.BudgetAgent, err = budgetLimit(b.Agent.MaxTokens, b.Agent.MaxCostUSD); err != nil {
+			return fmt.Errorf("budget agent: %w", err)
+		}
+		if b.Agent.Window != nil {
+			window, err := time.ParseDuration(*b.Agent.Window)
+			if err != nil || window <= 0 {
+				return fmt.Errorf("budget agent window %q must be a positive duration", *b.Agent.Window)
+			}
+			cfg.BudgetWindow = window
+		}
+	}
+	cfg.BudgetSummarize = b.OnExceeded == v1alpha1.BudgetActionSummarize
+	return nil
+}
+
+// loadWorkspaceBudget copies a Workspace's spec.costControls into the runtime
+// Config when its budgetExceededAction is block; the other actions do not
+// stop agents.
+func loadWorkspaceBudget(cfg *Config, cc *v1alpha1.CostControls) error {
+	if cc == nil || cc.BudgetExceededAction != v1alpha1.BudgetExceededActionBlock {
+		return nil
+	}
+	for _, b := range []struct {
+		field string
+		value string
+		limit *budget.Limit
+	}{
+		{"dailyBudget", cc.DailyBudget, &cfg.BudgetWorkspaceDaily},
+		{"monthlyBudget", cc.MonthlyBudget, &cfg.BudgetWorkspaceMonthly},
+	} {
+		if b.value == "" {
+			continue
+		}
+		cost, err := strconv.ParseFloat(b.value, 64)
+		if err != nil || cost <= 0 {
+			return fmt.Errorf("workspace costControls %s %q must be a positive amount", b.field, b.value)
+		}
+		*b.limit = budget.Limit{MaxCostUSD: cost}
+	}
+	return nil
+}
+
+// budgetLimit converts a budget limit's caps.
+func budgetLimit(maxTokens *int64, maxCostUSD string) (budget.Limit, error) {
+	var l budget.Limit
+	if maxTokens != nil {
+		l.MaxTokens = *maxTokens
+	}
+	if maxCostUSD != "" {
+		cost, err := strconv.ParseFloat(maxCostUSD, 64)
+		if err != nil || cost <= 0 {
+			return budget.Limit{}, fmt.Errorf("maxCostUSD %q must be a positive amount", maxCostUSD)
+		}
+		l.MaxCostUSD = cost
+	}
+	return l, nil
+}
+
+// loadDelegatesFromCRD resolves spec.delegates to the A2A endpoints of their
+// AgentRuntimes. A delegate that cannot be resolved fails startup, so the pod
+// retries until the agent it delegates to is serving.
+func loadDelegatesFromCRD(ctx context.Context, c client.Client, cfg *Config, delegates []v1alpha1.AgentDelegate, namespace string) error {
+	for _, d := range delegates {
+		target, err := k8s.GetAgentRuntime(ctx, c, d.AgentRuntimeRef.Name, namespace)
+		if err != nil {
+			return fmt.Errorf("delegate %s: %w", d.Name, err)
+		}
+		if target.Status.A2A == nil || target.Status.A2A.Endpoint == "" {
+			return fmt.Errorf("delegate %s: AgentRuntime %s has no a2a endpoint", d.Name, d.AgentRuntimeRef.Name)
+		}
+		resolved := delegation.Delegate{
+			Name:        d.Name,
+			Agent:       d.AgentRuntimeRef.Name,
+			URL:         target.Status.A2A.Endpoint,
+			Description: d.Description,
+		}
+		if d.Timeout != nil {
+			timeout, err := time.ParseDuration(*d.Timeout)
+			if err != nil || timeout <= 0 {
+				return fmt.Errorf("delegate %s: timeout %q must be a positive duration", d.Name, *d.Timeout)
+			}
+			resolved.Timeout = timeout
+		}
+		if d.Authentication != nil && d.Authentication.SecretRef != nil {
+			secret, err := k8s.GetSecret(ctx, c, d.Authentication.SecretRef.Name, namespace)
+			if err != nil {
+				return fmt.Errorf("delegate %s: read token secret: %w", d.Name, err)
+			}
+			token, ok := secret.Data["token"]
+			if !ok {
+				return fmt.Errorf("delegate %s: secret %s/%s does not contain key \"token\"",
+					d.Name, namespace, d.Authentication.SecretRef.Name)
+			}
+			resolved.Token = string(token)
+		}
+		cfg.Delegates = append(cfg.Delegates, resolved)
+	}
+	return nil
+}
+
+// loadTransformsFromPolicies applies the transforms of the AgentPolicies
+// selecting this agent, in name order: system prompt prefixes are joined, and
+// a later policy's headers and model rewrites override an earlier one's. A
+// policy in the Error phase is ignored.
+func loadTransformsFromPolicies(ctx context.Context, c client.Client, cfg *Config, namespace string) error {
+	var list v1alpha1.AgentPolicyList
+	if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("list AgentPolicies: %w", err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+
+	var applied, prefixes []string
+	rewrites := map[string]string{}
+	for i := range list.Items {
+		policy := &list.Items[i]
+		t := policy.Spec.Transform
+		if t == nil || policy.Status.Phase == v1alpha1.AgentPolicyPhaseError ||
+			!policySelects(policy.Spec.Selector, cfg.AgentName, cfg.WorkspaceName) {
+			continue
+		}
+		if t.SystemPromptPrefix != "" {
+			prefixes = append(prefixes, t.SystemPromptPrefix)
+		}
+		if len(t.Headers) > 0 {
+			headers := maps.Clone(cfg.Headers)
+			if headers == nil {
+				headers = make(map[string]string, len(t.Headers))
+			}
+			maps.Copy(headers, t.Headers)
+			cfg.Headers = headers
+		}
+		for _, param := range t.StripParams {
+			if !slices.Contains(cfg.ProviderUnsupportedParams, param) {
+				cfg.ProviderUnsupportedParams = append(cfg.ProviderUnsupportedParams, param)
+			}
+		}
+		maps.Copy(rewrites, t.ModelRewrites)
+		applied = append(applied, policy.Name)
+	}
+	if len(applied) == 0 {
+		return nil
+	}
+
+	cfg.SystemPromptPrefix = strings.Join(prefixes, "\n\n")
+	if to, ok := rewrites[cfg.Model]; ok && cfg.Model != "" {
+		cfg.Model = to
+	}
+	logf.FromContext(ctx).Info("AgentPolicy transforms applied", "policies", applied, "model", cfg.Model)
+	return nil
+}
+
+// policySelects reports whether an AgentPolicy selector matches the agent and
+// workspace. An empty list matches every agent or workspace.
+func policySelects(selector *v1alpha1.AgentPolicySelector, agent, workspace string) bool {
+	if selector == nil {
+		return true
+	}
+	if len(selector.Agents) > 0 && !slices.Contains(selector.Agents, agent) {
+		return false
+	}
+	return len(selector.Workspaces) == 0 || slices.Contains(selector.Workspaces, workspace)
+}
+
 // ResolvedProvider is a non-default provider referenced by the AgentRuntime,
 // carried through to conversation wiring where it maps to a WithXProvider option.
 type ResolvedProvider struct {
@@ -390,6 +809,13 @@ func loadFromProviderRef(ctx context.Context, c client.Client, cfg *Config, ref
 
 	loadPlatformConfig(cfg, provider.Spec.Platform)
 	loadAuthConfig(cfg, provider.Spec.Auth)
+	loadOpenAICompatibleConfig(cfg, provider)
+	if err := loadProviderResilience(cfg, provider.Spec.Resilience); err != nil {
+		return err
+	}
+	if err := loadProviderTLS(ctx, c, cfg, provider); err != nil {
+		return err
+	}
 
 	if provider.Spec.Defaults != nil {
 		if err := loadProviderDefaults(cfg, provider.Spec.Defaults); err != nil {
@@ -424,6 +850,19 @@ func loadPlatformConfig(cfg *Config, platform *v1alpha1.PlatformConfig) {
 	cfg.PlatformRegion = platform.Region
 	cfg.PlatformProject = platform.Project
 	cfg.PlatformEndpoint = platform.Endpoint
+	cfg.PlatformDeployment = platform.Deployment
+	cfg.PlatformAPIVersion = platform.APIVersion
+}
+
+// loadOpenAICompatibleConfig copies the request shaping of an
+// openai-compatible provider into the runtime Config.
+func loadOpenAICompatibleConfig(cfg *Config, provider *v1alpha1.Provider) {
+	if provider.Spec.Type != v1alpha1.ProviderTypeOpenAICompatible {
+		return
+	}
+	oc := pkgprovider.OpenAICompatible(&provider.Spec)
+	cfg.ProviderUnsupportedParams = oc.UnsupportedParams
+	cfg.ProviderCapabilities = oc.Capabilities
 }
 
 // loadAuthConfig copies spec.auth into the runtime Config.
@@ -473,6 +912,111 @@ func loadProviderDefaults(cfg *Config, defaults *v1alpha1.ProviderDefaults) erro
 	return nil
 }
 
+// loadProviderResilience copies spec.resilience into the runtime Config.
+// Unset fields stay zero so the resilience package defaults apply.
+func loadProviderResilience(cfg *Config, r *v1alpha1.ProviderResilienceConfig) error {
+	if r == nil {
+		return nil
+	}
+	rc := &resilience.Config{}
+	if err := parseResilienceDuration("hedgeAfter", &r.HedgeAfter, &rc.HedgeAfter); err != nil {
+		return err
+	}
+	if r.Retry != nil {
+		if r.Retry.MaxAttempts != nil {
+			rc.MaxAttempts = int(*r.Retry.MaxAttempts)
+		}
+		if err := parseResilienceDuration("retry.initialDelay", r.Retry.InitialDelay, &rc.InitialDelay); err != nil {
+			return err
+		}
+		if err := parseResilienceDuration("retry.maxDelay", r.Retry.MaxDelay, &rc.MaxDelay); err != nil {
+			return err
+		}
+	}
+	if cb := r.CircuitBreaker; cb != nil {
+		rc.FailureThreshold = cbDefaultFailureThreshold
+		if cb.FailureThreshold != nil {
+			rc.FailureThreshold = int(*cb.FailureThreshold)
+		}
+		if err := parseResilienceDuration("circuitBreaker.openDuration", cb.OpenDuration, &rc.OpenDuration); err != nil {
+			return err
+		}
+	}
+	if r.FailoverURL != "" {
+		u, err := url.Parse(r.FailoverURL)
+		if err != nil {
+			return fmt.Errorf("parse resilience.failoverURL %q: %w", r.FailoverURL, err)
+		}
+		if u.Host == "" {
+			return fmt.Errorf("resilience.failoverURL %q has no host", r.FailoverURL)
+		}
+		rc.Failover = u
+	}
+	cfg.ProviderResilience = rc
+	return nil
+}
+
+// loadProviderTLS resolves spec.tls into the client certificate the runtime
+// presents to the provider, reading a static certificate from its Secret.
+func loadProviderTLS(ctx context.Context, c client.Client, cfg *Config, provider *v1alpha1.Provider) error {
+	t := provider.Spec.TLS
+	if t == nil {
+		return nil
+	}
+	if t.SPIFFE != nil {
+		cfg.ProviderTLS = &mtls.Config{SPIFFESocket: t.SPIFFE.SocketPath, SPIFFEServerID: t.SPIFFE.ServerID}
+		if cfg.ProviderTLS.SPIFFESocket == "" {
+			cfg.ProviderTLS.SPIFFESocket = spiffeDefaultSocketPath
+		}
+		return nil
+	}
+	if t.SecretRef == nil {
+		return nil
+	}
+	secret, err := k8s.GetSecret(ctx, c, t.SecretRef.Name, provider.Namespace)
+	if err != nil {
+		return fmt.Errorf("read provider TLS secret: %w", err)
+	}
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		if len(secret.Data[key]) == 0 {
+			return fmt.Errorf("secret %s/%s does not contain key %q", provider.Namespace, t.SecretRef.Name, key)
+		}
+	}
+	cfg.ProviderTLS = &mtls.Config{
+		CertPEM: secret.Data[corev1.TLSCertKey],
+		KeyPEM:  secret.Data[corev1.TLSPrivateKeyKey],
+		CAPEM:   secret.Data[tlsCAKey],
+	}
+	return nil
+}
+
+// tlsCAKey is the optional CA bundle of a kubernetes.io/tls Secret, as
+// written by cert-manager.
+const tlsCAKey = "ca.crt"
+
+// spiffeDefaultSocketPath mirrors the CRD default of spec.tls.spiffe.socketPath.
+const spiffeDefaultSocketPath = "/spiffe-workload-api/spire-agent.sock"
+
+// cbDefaultFailureThreshold mirrors the CRD default for an empty
+// spec.resilience.circuitBreaker, which turns the breaker on.
+const cbDefaultFailureThreshold = 5
+
+// parseResilienceDuration parses an optional spec.resilience duration into dst.
+func parseResilienceDuration(field string, value *string, dst *time.Duration) error {
+	if value == nil || *value == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(*value)
+	if err != nil {
+		return fmt.Errorf("parse resilience.%s %q: %w", field, *value, err)
+	}
+	if d < 0 {
+		return fmt.Errorf("resilience.%s %q must be non-negative", field, *value)
+	}
+	*dst = d
+	return nil
+}
+
 // loadProviderPricing extracts pricing from the Provider CRD and converts to float64.
 func loadProviderPricing(cfg *Config, pricing *v1alpha1.ProviderPricing) error {
 	if pricing == nil {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,6 +34,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	v1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/runtime/budget"
+	"github.com/altairalabs/omnia/internal/runtime/delegation"
+	"github.com/altairalabs/omnia/internal/runtime/guardrails"
+	"github.com/altairalabs/omnia/internal/runtime/mtls"
+	"github.com/altairalabs/omnia/internal/runtime/resilience"
 	"github.com/altairalabs/omnia/pkg/k8s"
 )
 
@@ -935,6 +941,460 @@ func TestLoadProviderPricing(t *testing.T) {
 	})
 }
 
+func TestLoadResponseCacheFromCRD(t *testing.T) {
+	t.Run("nil or disabled", func(t *testing.T) {
+		cfg := &Config{}
+		require.NoError(t, loadResponseCacheFromCRD(cfg, nil))
+		require.NoError(t, loadResponseCacheFromCRD(cfg, &v1alpha1.ResponseCacheConfig{TTL: strPtr("5m")}))
+		assert.False(t, cfg.ResponseCacheEnabled)
+		assert.Zero(t, cfg.ResponseCacheTTL)
+	})
+
+	t.Run("enabled with ttl and similarity", func(t *testing.T) {
+		cfg := &Config{}
+		require.NoError(t, loadResponseCacheFromCRD(cfg, &v1alpha1.ResponseCacheConfig{
+			Enabled:             true,
+			TTL:                 strPtr("30m"),
+			SimilarityThreshold: "0.95",
+		}))
+		assert.True(t, cfg.ResponseCacheEnabled)
+		assert.Equal(t, 30*time.Minute, cfg.ResponseCacheTTL)
+		assert.InDelta(t, 0.95, cfg.ResponseCacheSimilarity, 1e-9)
+	})
+
+	t.Run("invalid ttl", func(t *testing.T) {
+		err := loadResponseCacheFromCRD(&Config{}, &v1alpha1.ResponseCacheConfig{Enabled: true, TTL: strPtr("soon")})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "response cache TTL")
+	})
+
+	t.Run("invalid similarity", func(t *testing.T) {
+		err := loadResponseCacheFromCRD(&Config{}, &v1alpha1.ResponseCacheConfig{Enabled: true, SimilarityThreshold: "1.5"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "similarityThreshold")
+	})
+}
+
+func TestLoadStructuredOutputFromCRD(t *testing.T) {
+	cfg := &Config{}
+	loadStructuredOutputFromCRD(cfg, &v1alpha1.StructuredOutputConfig{MaxRepairAttempts: int32Ptr(3)})
+	assert.False(t, cfg.StructuredOutputEnabled, "disabled")
+
+	loadStructuredOutputFromCRD(cfg, &v1alpha1.StructuredOutputConfig{Enabled: true})
+	assert.True(t, cfg.StructuredOutputEnabled)
+	assert.Equal(t, 1, cfg.StructuredOutputMaxRepairs, "CRD default")
+
+	loadStructuredOutputFromCRD(cfg, &v1alpha1.StructuredOutputConfig{Enabled: true, MaxRepairAttempts: int32Ptr(0)})
+	assert.Equal(t, 0, cfg.StructuredOutputMaxRepairs)
+}
+
+func TestLoadKnowledgeFromCRD(t *testing.T) {
+	t.Setenv(envKnowledgeURL, "http://qdrant:6333")
+	t.Setenv(envKnowledgeAPIKey, "secret")
+	store := v1alpha1.VectorStoreConfig{Type: v1alpha1.VectorStoreTypeQdrant, Collection: "docs"}
+
+	cfg := &Config{}
+	require.NoError(t, loadKnowledgeFromCRD(cfg, &v1alpha1.KnowledgeConfig{VectorStore: store}))
+	assert.False(t, cfg.KnowledgeEnabled, "disabled")
+
+	require.NoError(t, loadKnowledgeFromCRD(cfg, &v1alpha1.KnowledgeConfig{Enabled: true, VectorStore: store}))
+	assert.True(t, cfg.KnowledgeEnabled)
+	assert.Equal(t, "qdrant", cfg.KnowledgeStoreType)
+	assert.Equal(t, "docs", cfg.KnowledgeCollection)
+	assert.Equal(t, "http://qdrant:6333", cfg.KnowledgeURL)
+	assert.Equal(t, "secret", cfg.KnowledgeAPIKey)
+	assert.Equal(t, 4, cfg.KnowledgeTopK, "CRD default")
+	assert.Zero(t, cfg.KnowledgeMinScore)
+
+	require.NoError(t, loadKnowledgeFromCRD(cfg, &v1alpha1.KnowledgeConfig{
+		Enabled: true, VectorStore: store, TopK: int32Ptr(8), MinScore: "0.75",
+	}))
+	assert.Equal(t, 8, cfg.KnowledgeTopK)
+	assert.InDelta(t, 0.75, cfg.KnowledgeMinScore, 1e-9)
+
+	err := loadKnowledgeFromCRD(&Config{}, &v1alpha1.KnowledgeConfig{Enabled: true, VectorStore: store, MinScore: "high"})
+	assert.ErrorContains(t, err, "knowledge minScore")
+}
+
+func TestLoadGuardrailsFromCRD(t *testing.T) {
+	cfg := &Config{}
+	require.NoError(t, loadGuardrailsFromCRD(cfg, nil))
+	assert.True(t, cfg.Guardrails.Empty())
+
+	require.NoError(t, loadGuardrailsFromCRD(cfg, &v1alpha1.GuardrailsConfig{
+		Input: []v1alpha1.GuardrailHook{
+			{Name: v1alpha1.GuardrailHookMaxLength, MaxChars: int32Ptr(4000)},
+			{Name: v1alpha1.GuardrailHookLanguage, AllowedScripts: []string{"Latin"}, MinRatio: "0.9"},
+			{Name: v1alpha1.GuardrailHookPromptInjection, Threshold: "0.8", ClassifierURL: "http://classifier:8080/score",
+				Action: v1alpha1.GuardrailActionAnnotate},
+		},
+		ToolCalls: []v1alpha1.GuardrailHook{
+			{Name: v1alpha1.GuardrailHookRegexBlocklist, Patterns: []string{"DROP TABLE"}, CaseInsensitive: true,
+				Message: "That query is not allowed."},
+		},
+	}))
+	assert.Equal(t, []guardrails.Spec{
+		{Name: guardrails.HookMaxLength, MaxChars: 4000},
+		{Name: guardrails.HookLanguage, AllowedScripts: []string{"Latin"}, MinRatio: 0.9},
+		{Name: guardrails.HookInjection, Threshold: 0.8, ClassifierURL: "http://classifier:8080/score", Action: "annotate"},
+	}, cfg.Guardrails.Input)
+	assert.Empty(t, cfg.Guardrails.Output)
+	assert.Equal(t, []guardrails.Spec{
+		{Name: guardrails.HookRegexBlocklist, Patterns: []string{"DROP TABLE"}, CaseInsensitive: true,
+			Message: "That query is not allowed."},
+	}, cfg.Guardrails.ToolCalls)
+
+	assert.Zero(t, cfg.Guardrails.OutputWindow, "output is held back without outputStreaming")
+
+	cfg = &Config{}
+	require.NoError(t, loadGuardrailsFromCRD(cfg, &v1alpha1.GuardrailsConfig{
+		OutputStreaming: &v1alpha1.GuardrailStreaming{},
+	}))
+	assert.Equal(t, defaultGuardrailOutputWindow, cfg.Guardrails.OutputWindow)
+	require.NoError(t, loadGuardrailsFromCRD(cfg, &v1alpha1.GuardrailsConfig{
+		OutputStreaming: &v1alpha1.GuardrailStreaming{WindowChars: int32Ptr(64)},
+	}))
+	assert.Equal(t, 64, cfg.Guardrails.OutputWindow)
+
+	err := loadGuardrailsFromCRD(&Config{}, &v1alpha1.GuardrailsConfig{
+		Output: []v1alpha1.GuardrailHook{{Name: v1alpha1.GuardrailHookLanguage, MinRatio: "most"}},
+	})
+	assert.ErrorContains(t, err, `guardrails output: hook 0 minRatio "most"`)
+
+	err = loadGuardrailsFromCRD(&Config{}, &v1alpha1.GuardrailsConfig{
+		Input: []v1alpha1.GuardrailHook{{Name: v1alpha1.GuardrailHookPromptInjection, Threshold: "2"}},
+	})
+	assert.ErrorContains(t, err, `guardrails input: hook 0 threshold "2" must be between 0 and 1`)
+}
+
+func TestLoadGuardrailsFromCRD_Moderation(t *testing.T) {
+	g := &v1alpha1.GuardrailsConfig{
+		Input: []v1alpha1.GuardrailHook{{Name: v1alpha1.GuardrailHookRegexBlocklist, Patterns: []string{"x"}}},
+		Output: []v1alpha1.GuardrailHook{{
+			Name:      v1alpha1.GuardrailHookModeration,
+			Threshold: "0.6",
+			Moderation: &v1alpha1.GuardrailModeration{
+				Provider:  v1alpha1.GuardrailModerationOpenAI,
+				SecretRef: &corev1.LocalObjectReference{Name: "moderation-key"},
+				Categories: []v1alpha1.GuardrailModerationCategory{
+					{Name: "violence", Threshold: "0.9"},
+					{Name: "harassment"},
+				},
+				FailClosed: true,
+			},
+			Action: v1alpha1.GuardrailActionFlag,
+		}},
+	}
+	cfg := &Config{}
+	require.NoError(t, loadGuardrailsFromCRD(cfg, g))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "moderation-key", Namespace: "test-ns"},
+		Data:       map[string][]byte{"api-key": []byte("sk-test")},
+	}
+	require.NoError(t, loadModerationKeys(context.Background(), buildTestClient(secret), cfg, g, "test-ns"))
+	assert.Equal(t, []guardrails.Spec{{
+		Name:      guardrails.HookModeration,
+		Threshold: 0.6,
+		Moderation: &guardrails.ModerationSpec{
+			Provider:   guardrails.ModerationOpenAI,
+			Categories: map[string]float64{"violence": 0.9, "harassment": 0},
+			FailClosed: true,
+			APIKey:     "sk-test",
+		},
+		Action: "flag",
+	}}, cfg.Guardrails.Output)
+
+	err := loadModerationKeys(context.Background(), buildTestClient(), cfg, g, "test-ns")
+	assert.ErrorContains(t, err, "read moderation secret")
+	empty := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "moderation-key", Namespace: "test-ns"}}
+	err = loadModerationKeys(context.Background(), buildTestClient(empty), cfg, g, "test-ns")
+	assert.ErrorContains(t, err, `secret test-ns/moderation-key does not contain key "api-key"`)
+
+	g.Output[0].Moderation.Categories[0].Threshold = "high"
+	err = loadGuardrailsFromCRD(&Config{}, g)
+	assert.ErrorContains(t, err, `guardrails output: hook 0 category violence threshold "high" must be between 0 and 1`)
+}
+
+func TestLoadBudgetFromCRD(t *testing.T) {
+	cfg := &Config{}
+	require.NoError(t, loadBudgetFromCRD(cfg, nil))
+	assert.True(t, cfg.BudgetSession.Empty())
+	assert.True(t, cfg.BudgetAgent.Empty())
+
+	maxTokens := int64(200000)
+	window := "1h"
+	require.NoError(t, loadBudgetFromCRD(cfg, &v1alpha1.BudgetConfig{
+		Session:    &v1alpha1.BudgetLimit{MaxTokens: &maxTokens},
+		Agent:      &v1alpha1.AgentBudgetLimit{MaxCostUSD: "100.00", Window: &window},
+		OnExceeded: v1alpha1.BudgetActionSummarize,
+	}))
+	assert.Equal(t, budget.Limit{MaxTokens: 200000}, cfg.BudgetSession)
+	assert.Equal(t, budget.Limit{MaxCostUSD: 100}, cfg.BudgetAgent)
+	assert.Equal(t, time.Hour, cfg.BudgetWindow)
+	assert.True(t, cfg.BudgetSummarize)
+
+	err := loadBudgetFromCRD(&Config{}, &v1alpha1.BudgetConfig{Session: &v1alpha1.BudgetLimit{MaxCostUSD: "0"}})
+	assert.ErrorContains(t, err, `budget session: maxCostUSD "0" must be a positive amount`)
+	bad := "daily"
+	err = loadBudgetFromCRD(&Config{}, &v1alpha1.BudgetConfig{Agent: &v1alpha1.AgentBudgetLimit{MaxCostUSD: "1", Window: &bad}})
+	assert.ErrorContains(t, err, `budget agent window "daily" must be a positive duration`)
+}
+
+func TestLoadWorkspaceBudget(t *testing.T) {
+	cfg := &Config{}
+	require.NoError(t, loadWorkspaceBudget(cfg, nil))
+	require.NoError(t, loadWorkspaceBudget(cfg, &v1alpha1.CostControls{
+		DailyBudget:          "100.00",
+		BudgetExceededAction: v1alpha1.BudgetExceededActionWarn,
+	}))
+	assert.True(t, cfg.BudgetWorkspaceDaily.Empty(), "only the block action stops agents")
+
+	require.NoError(t, loadWorkspaceBudget(cfg, &v1alpha1.CostControls{
+		DailyBudget:          "100.00",
+		MonthlyBudget:        "2000.00",
+		BudgetExceededAction: v1alpha1.BudgetExceededActionBlock,
+	}))
+	assert.Equal(t, budget.Limit{MaxCostUSD: 100}, cfg.BudgetWorkspaceDaily)
+	assert.Equal(t, budget.Limit{MaxCostUSD: 2000}, cfg.BudgetWorkspaceMonthly)
+
+	err := loadWorkspaceBudget(&Config{}, &v1alpha1.CostControls{
+		MonthlyBudget:        "lots",
+		BudgetExceededAction: v1alpha1.BudgetExceededActionBlock,
+	})
+	assert.ErrorContains(t, err, `workspace costControls monthlyBudget "lots" must be a positive amount`)
+}
+
+func TestLoadDelegatesFromCRD(t *testing.T) {
+	researcher := &v1alpha1.AgentRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "research-agent", Namespace: "test-ns"},
+		Status: v1alpha1.AgentRuntimeStatus{A2A: &v1alpha1.A2AStatus{
+			Endpoint: "http://research-agent.test-ns.svc.cluster.local:8080",
+		}},
+	}
+	noA2A := &v1alpha1.AgentRuntime{ObjectMeta: metav1.ObjectMeta{Name: "chat-agent", Namespace: "test-ns"}}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "research-token", Namespace: "test-ns"},
+		Data:       map[string][]byte{"token": []byte("s3cret")},
+	}
+	c := buildTestClient(researcher, noA2A, secret)
+	ctx := context.Background()
+
+	timeout := "30s"
+	cfg := &Config{}
+	require.NoError(t, loadDelegatesFromCRD(ctx, c, cfg, []v1alpha1.AgentDelegate{{
+		Name:            "researcher",
+		AgentRuntimeRef: corev1.LocalObjectReference{Name: "research-agent"},
+		Description:     "Finds sources.",
+		Timeout:         &timeout,
+		Authentication: &v1alpha1.A2AClientAuthConfig{
+			SecretRef: &corev1.LocalObjectReference{Name: "research-token"},
+		},
+	}}, "test-ns"))
+	assert.Equal(t, []delegation.Delegate{{
+		Name:        "researcher",
+		Agent:       "research-agent",
+		URL:         "http://research-agent.test-ns.svc.cluster.local:8080",
+		Description: "Finds sources.",
+		Timeout:     30 * time.Second,
+		Token:       "s3cret",
+	}}, cfg.Delegates)
+
+	err := loadDelegatesFromCRD(ctx, c, &Config{}, []v1alpha1.AgentDelegate{{
+		Name: "chat", AgentRuntimeRef: corev1.LocalObjectReference{Name: "chat-agent"},
+	}}, "test-ns")
+	assert.ErrorContains(t, err, "delegate chat: AgentRuntime chat-agent has no a2a endpoint")
+	err = loadDelegatesFromCRD(ctx, c, &Config{}, []v1alpha1.AgentDelegate{{
+		Name: "missing", AgentRuntimeRef: corev1.LocalObjectReference{Name: "missing-agent"},
+	}}, "test-ns")
+	assert.ErrorContains(t, err, "delegate missing:")
+	bad := "soon"
+	err = loadDelegatesFromCRD(ctx, c, &Config{}, []v1alpha1.AgentDelegate{{
+		Name: "researcher", AgentRuntimeRef: corev1.LocalObjectReference{Name: "research-agent"}, Timeout: &bad,
+	}}, "test-ns")
+	assert.ErrorContains(t, err, `delegate researcher: timeout "soon" must be a positive duration`)
+}
+
+func TestLoadTransformsFromPolicies(t *testing.T) {
+	policy := func(name string, selector *v1alpha1.AgentPolicySelector, transform *v1alpha1.TransformConfig) *v1alpha1.AgentPolicy {
+		return &v1alpha1.AgentPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Spec:       v1alpha1.AgentPolicySpec{Selector: selector, Transform: transform},
+		}
+	}
+	broken := policy("c-broken", nil, &v1alpha1.TransformConfig{SystemPromptPrefix: "Broken."})
+	broken.Status.Phase = v1alpha1.AgentPolicyPhaseError
+	c := buildTestClient(
+		policy("b-team", &v1alpha1.AgentPolicySelector{Agents: []string{"my-agent"}}, &v1alpha1.TransformConfig{
+			SystemPromptPrefix: "Answer in English.",
+			Headers:            map[string]string{"X-Org": "team"},
+			StripParams:        []string{"seed"},
+		}),
+		policy("a-org", nil, &v1alpha1.TransformConfig{
+			SystemPromptPrefix: "Follow the Acme policy.",
+			Headers:            map[string]string{"X-Org": "acme", "X-Cost-Center": "42"},
+			StripParams:        []string{"temperature"},
+			ModelRewrites:      map[string]string{"gpt-4o": "gpt-4o-mini"},
+		}),
+		broken,
+		policy("d-other-agent", &v1alpha1.AgentPolicySelector{Agents: []string{"other-agent"}},
+			&v1alpha1.TransformConfig{SystemPromptPrefix: "Other."}),
+		policy("e-other-workspace", &v1alpha1.AgentPolicySelector{Workspaces: []string{"other-ws"}},
+			&v1alpha1.TransformConfig{SystemPromptPrefix: "Other."}),
+		policy("f-tools-only", nil, nil),
+	)
+
+	providerHeaders := map[string]string{"X-Title": "omnia"}
+	cfg := &Config{
+		AgentName:                 "my-agent",
+		WorkspaceName:             "test-ws",
+		Model:                     "gpt-4o",
+		Headers:                   providerHeaders,
+		ProviderUnsupportedParams: []string{"temperature"},
+	}
+	require.NoError(t, loadTransformsFromPolicies(context.Background(), c, cfg, "test-ns"))
+	assert.Equal(t, "Follow the Acme policy.\n\nAnswer in English.", cfg.SystemPromptPrefix)
+	assert.Equal(t, map[string]string{"X-Title": "omnia", "X-Org": "team", "X-Cost-Center": "42"}, cfg.Headers)
+	assert.Equal(t, map[string]string{"X-Title": "omnia"}, providerHeaders, "the provider's headers are not modified")
+	assert.Equal(t, []string{"temperature", "seed"}, cfg.ProviderUnsupportedParams)
+	assert.Equal(t, "gpt-4o-mini", cfg.Model)
+
+	cfg = &Config{AgentName: "unselected", WorkspaceName: "test-ws", Model: "claude-sonnet"}
+	require.NoError(t, loadTransformsFromPolicies(context.Background(), buildTestClient(), cfg, "test-ns"))
+	assert.Empty(t, cfg.SystemPromptPrefix)
+	assert.Nil(t, cfg.Headers)
+	assert.Equal(t, "claude-sonnet", cfg.Model)
+}
+
+func TestLoadProviderResilience(t *testing.T) {
+	t.Run("unset keeps PromptKit retries", func(t *testing.T) {
+		cfg := &Config{}
+		require.NoError(t, loadProviderResilience(cfg, nil))
+		assert.Nil(t, cfg.ProviderResilience)
+	})
+
+	t.Run("all settings", func(t *testing.T) {
+		cfg := &Config{}
+		require.NoError(t, loadProviderResilience(cfg, &v1alpha1.ProviderResilienceConfig{
+			Retry: &v1alpha1.ProviderRetryConfig{
+				MaxAttempts:  int32Ptr(4),
+				InitialDelay: strPtr("250ms"),
+				MaxDelay:     strPtr("10s"),
+			},
+			HedgeAfter: "3s",
+			CircuitBreaker: &v1alpha1.ProviderCircuitBreakerConfig{
+				FailureThreshold: int32Ptr(8),
+				OpenDuration:     strPtr("1m"),
+			},
+		}))
+		assert.Equal(t, &resilience.Config{
+			MaxAttempts:      4,
+			InitialDelay:     250 * time.Millisecond,
+			MaxDelay:         10 * time.Second,
+			HedgeAfter:       3 * time.Second,
+			FailureThreshold: 8,
+			OpenDuration:     time.Minute,
+		}, cfg.ProviderResilience)
+	})
+
+	t.Run("empty circuitBreaker turns the breaker on", func(t *testing.T) {
+		cfg := &Config{}
+		require.NoError(t, loadProviderResilience(cfg, &v1alpha1.ProviderResilienceConfig{
+			CircuitBreaker: &v1alpha1.ProviderCircuitBreakerConfig{},
+		}))
+		assert.Equal(t, cbDefaultFailureThreshold, cfg.ProviderResilience.FailureThreshold)
+		assert.Zero(t, cfg.ProviderResilience.HedgeAfter)
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		err := loadProviderResilience(&Config{}, &v1alpha1.ProviderResilienceConfig{
+			Retry: &v1alpha1.ProviderRetryConfig{MaxDelay: strPtr("later")},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resilience.retry.maxDelay")
+	})
+
+	t.Run("failover URL", func(t *testing.T) {
+		cfg := &Config{}
+		require.NoError(t, loadProviderResilience(cfg, &v1alpha1.ProviderResilienceConfig{
+			CircuitBreaker: &v1alpha1.ProviderCircuitBreakerConfig{},
+			FailoverURL:    "https://eu.api.example.com",
+		}))
+		require.NotNil(t, cfg.ProviderResilience.Failover)
+		assert.Equal(t, "https", cfg.ProviderResilience.Failover.Scheme)
+		assert.Equal(t, "eu.api.example.com", cfg.ProviderResilience.Failover.Host)
+	})
+
+	t.Run("failover URL without host", func(t *testing.T) {
+		err := loadProviderResilience(&Config{}, &v1alpha1.ProviderResilienceConfig{
+			CircuitBreaker: &v1alpha1.ProviderCircuitBreakerConfig{},
+			FailoverURL:    "https://",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resilience.failoverURL")
+	})
+}
+
+func TestLoadProviderTLS(t *testing.T) {
+	provider := func(tlsCfg *v1alpha1.ProviderTLSConfig) *v1alpha1.Provider {
+		return &v1alpha1.Provider{
+			ObjectMeta: metav1.ObjectMeta{Name: "vllm", Namespace: "test-ns"},
+			Spec:       v1alpha1.ProviderSpec{Type: "vllm", TLS: tlsCfg},
+		}
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "test-ns"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("cert"),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+			"ca.crt":                []byte("ca"),
+		},
+	}
+	ctx := context.Background()
+
+	t.Run("unset", func(t *testing.T) {
+		cfg := &Config{}
+		require.NoError(t, loadProviderTLS(ctx, buildTestClient(), cfg, provider(nil)))
+		assert.Nil(t, cfg.ProviderTLS)
+	})
+
+	t.Run("secret", func(t *testing.T) {
+		cfg := &Config{}
+		require.NoError(t, loadProviderTLS(ctx, buildTestClient(secret), cfg, provider(&v1alpha1.ProviderTLSConfig{
+			SecretRef: &corev1.LocalObjectReference{Name: "client-cert"},
+		})))
+		assert.Equal(t, &mtls.Config{CertPEM: []byte("cert"), KeyPEM: []byte("key"), CAPEM: []byte("ca")}, cfg.ProviderTLS)
+	})
+
+	t.Run("secret without key", func(t *testing.T) {
+		partial := secret.DeepCopy()
+		delete(partial.Data, corev1.TLSPrivateKeyKey)
+		err := loadProviderTLS(ctx, buildTestClient(partial), &Config{}, provider(&v1alpha1.ProviderTLSConfig{
+			SecretRef: &corev1.LocalObjectReference{Name: "client-cert"},
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"tls.key"`)
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		err := loadProviderTLS(ctx, buildTestClient(), &Config{}, provider(&v1alpha1.ProviderTLSConfig{
+			SecretRef: &corev1.LocalObjectReference{Name: "client-cert"},
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "read provider TLS secret")
+	})
+
+	t.Run("spiffe", func(t *testing.T) {
+		cfg := &Config{}
+		require.NoError(t, loadProviderTLS(ctx, buildTestClient(), cfg, provider(&v1alpha1.ProviderTLSConfig{
+			SPIFFE: &v1alpha1.ProviderSPIFFEConfig{ServerID: "spiffe://example.org/llm"},
+		})))
+		assert.Equal(t, &mtls.Config{
+			SPIFFESocket:   spiffeDefaultSocketPath,
+			SPIFFEServerID: "spiffe://example.org/llm",
+		}, cfg.ProviderTLS)
+	})
+}
+
 func TestLoadFromCRD_ProviderPricing(t *testing.T) {
 	provider := &v1alpha1.Provider{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1398,6 +1858,41 @@ func TestLoadPlatformAndAuthConfig(t *testing.T) {
 		assert.Equal(t, "https://example", cfg.PlatformEndpoint)
 	})
 
+	t.Run("azure routing fields populate Config", func(t *testing.T) {
+		cfg := &Config{}
+		loadPlatformConfig(cfg, &v1alpha1.PlatformConfig{
+			Type:       v1alpha1.PlatformTypeAzure,
+			Endpoint:   "https://example.openai.azure.com",
+			Deployment: "prod-gpt4o",
+			APIVersion: "2024-10-21",
+		})
+		assert.Equal(t, "prod-gpt4o", cfg.PlatformDeployment)
+		assert.Equal(t, "2024-10-21", cfg.PlatformAPIVersion)
+	})
+
+	t.Run("openai-compatible request shaping populates Config", func(t *testing.T) {
+		cfg := &Config{}
+		loadOpenAICompatibleConfig(cfg, &v1alpha1.Provider{Spec: v1alpha1.ProviderSpec{
+			Type:         v1alpha1.ProviderTypeOpenAICompatible,
+			Capabilities: []v1alpha1.ProviderCapability{v1alpha1.ProviderCapabilityVision},
+			OpenAICompatible: &v1alpha1.OpenAICompatibleConfig{
+				UnsupportedParams: []string{"temperature"},
+			},
+		}})
+		assert.Equal(t, []string{"max_completion_tokens", "temperature"}, cfg.ProviderUnsupportedParams)
+		assert.Equal(t, []string{"vision"}, cfg.ProviderCapabilities)
+	})
+
+	t.Run("openai-compatible shaping ignored for other types", func(t *testing.T) {
+		cfg := &Config{}
+		loadOpenAICompatibleConfig(cfg, &v1alpha1.Provider{Spec: v1alpha1.ProviderSpec{
+			Type:         v1alpha1.ProviderTypeOpenAI,
+			Capabilities: []v1alpha1.ProviderCapability{v1alpha1.ProviderCapabilityVision},
+		}})
+		assert.Nil(t, cfg.ProviderUnsupportedParams)
+		assert.Nil(t, cfg.ProviderCapabilities)
+	})
+
 	t.Run("nil platform is no-op", func(t *testing.T) {
 		cfg := &Config{PlatformType: "unchanged"}
 		loadPlatformConfig(cfg, nil)
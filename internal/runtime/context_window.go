@@ -0,0 +1,136 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/PromptKit/sdk"
+	"github.com/go-logr/logr"
+
+	v1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+)
+
+// Summarize and relevance run in PromptKit's hot-window mode, the only mode in
+// which it accepts a summarizer or an embedding provider. Each turn loads the
+// most recent hotWindowMessages verbatim; older history reaches the model as
+// rolling summary messages (summarize) or as retrieved relevant messages
+// (relevance). A batch of rollingSummaryBatchSize messages is summarized once
+// history passes the window. Each turn adds about two messages, so summaries
+// keep up and no message falls between the last summary and the window.
+// Relevance retrieves up to relevanceRetrievalTopK older messages per turn.
+const (
+	hotWindowMessages       = 20
+	rollingSummaryThreshold = hotWindowMessages
+	rollingSummaryBatchSize = hotWindowMessages / 2
+	relevanceRetrievalTopK  = 5
+)
+
+// contextManagementOptions returns the per-conversation SDK options for the
+// configured truncation strategy, applied after s.sdkOptions so they take
+// precedence:
+//   - summarize: the conversation's LLM writes rolling summary messages for
+//     history that leaves the hot window, and for history dropped when the
+//     token budget is exceeded.
+//   - relevance: the older messages most similar to the latest user turn are
+//     retrieved into context and, over the token budget, the least similar
+//     are pruned first, scored by the agent's embedding-role provider.
+//
+// Both need a state store. When it or the provider a strategy relies on is
+// missing, the runtime logs and falls back to sliding rather than failing
+// every turn.
+//
+// When no contextWindow is configured and the provider reports its own
+// context window, that becomes the token budget.
+func (s *Server) contextManagementOptions(ctx context.Context, log logr.Logger, llm providers.Provider) []sdk.Option {
+	var opts []sdk.Option
+
+	if s.tokenBudget == 0 && s.truncationStrategy != "" &&
+		s.truncationStrategy != string(v1alpha1.TruncationStrategyCustom) {
+		if cwp, ok := llm.(providers.ContextWindowProvider); ok && cwp.MaxContextTokens() > 0 {
+			opts = append(opts, sdk.WithTokenBudget(cwp.MaxContextTokens()))
+			log.V(1).Info("token budget from provider context window", "tokens", cwp.MaxContextTokens())
+		}
+	}
+
+	switch v1alpha1.TruncationStrategy(s.truncationStrategy) {
+	case v1alpha1.TruncationStrategySummarize:
+		if llm == nil || s.stateStore == nil {
+			log.V(0).Info("summarize truncation needs an explicit provider and a state store; falling back to sliding",
+				"hasProvider", llm != nil, "hasStateStore", s.stateStore != nil)
+			return append(opts, sdk.WithTruncation(string(v1alpha1.TruncationStrategySliding)))
+		}
+		opts = append(opts,
+			sdk.WithContextWindow(hotWindowMessages),
+			sdk.WithAutoSummarize(llm, rollingSummaryThreshold, rollingSummaryBatchSize))
+	case v1alpha1.TruncationStrategyRelevance:
+		ep, err := s.embeddingRoleProvider(ctx)
+		if err != nil || ep == nil || s.stateStore == nil {
+			log.V(0).Info("relevance truncation needs an embedding provider and a state store; falling back to sliding",
+				"error", err, "hasEmbeddingProvider", ep != nil, "hasStateStore", s.stateStore != nil,
+				"fix", "add an embedding-role provider to spec.providers")
+			return append(opts, sdk.WithTruncation(string(v1alpha1.TruncationStrategySliding)))
+		}
+		opts = append(opts,
+			sdk.WithContextWindow(hotWindowMessages),
+			sdk.WithContextRetrieval(ep, relevanceRetrievalTopK),
+			sdk.WithRelevanceTruncation(&sdk.RelevanceConfig{
+				EmbeddingProvider:    ep,
+				AlwaysKeepSystemRole: true,
+			}))
+	}
+	return opts
+}
+
+// embeddingRoleProvider returns the embedding provider built from the first
+// embedding-role provider in spec.providers. It scores messages for relevance
+// truncation, matches near-identical prompts in the response cache and serves
+// Embed; the provider is built once and shared. Returns nil, nil when there is
+// none.
+func (s *Server) embeddingRoleProvider(ctx context.Context) (providers.EmbeddingProvider, error) {
+	s.embeddingProviderMu.Lock()
+	defer s.embeddingProviderMu.Unlock()
+	if s.embeddingProvider != nil {
+		return s.embeddingProvider, nil
+	}
+	for _, rp := range s.extraProviders {
+		if rp.Role != v1alpha1.ProviderRoleEmbedding {
+			continue
+		}
+		spec := providerToSDKSpec(rp.Provider, rp.APIKey)
+		cred, err := providers.ResolveEmbeddingCredential(ctx, spec.Type, "", spec.Credential, nil)
+		if err != nil {
+			return nil, fmt.Errorf("resolve credential for embedding provider %q: %w", spec.ID, err)
+		}
+		ep, err := providers.CreateEmbeddingProviderFromSpec(providers.EmbeddingProviderSpec{
+			ID:               spec.ID,
+			Type:             spec.Type,
+			Model:            spec.Model,
+			BaseURL:          spec.BaseURL,
+			Credential:       cred,
+			AdditionalConfig: spec.AdditionalConfig,
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.embeddingProvider = ep
+		return ep, nil
+	}
+	return nil, nil //nolint:nilnil // no embedding provider configured
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/PromptKit/runtime/providers/mock"
+	"github.com/AltairaLabs/PromptKit/runtime/statestore"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+)
+
+// windowedProvider is a provider that reports its context window.
+type windowedProvider struct {
+	*mock.Provider
+	window int
+}
+
+func (p *windowedProvider) MaxContextTokens() int { return p.window }
+
+func embeddingProvider(name string) ResolvedProvider {
+	return ResolvedProvider{
+		Role: v1alpha1.ProviderRoleEmbedding,
+		Provider: &v1alpha1.Provider{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha1.ProviderSpec{
+				Type:  v1alpha1.ProviderTypeOpenAI,
+				Model: "text-embedding-3-small",
+			},
+		},
+		APIKey: "sk-embed",
+	}
+}
+
+func TestContextManagementOptions(t *testing.T) {
+	llm := mock.NewProvider("mock", "mock-model", false)
+	withEmbedding := []ResolvedProvider{embeddingProvider("embed")}
+
+	tests := []struct {
+		name     string
+		strategy string
+		budget   int
+		store    bool
+		llm      providers.Provider
+		extra    []ResolvedProvider
+		want     int
+	}{
+		{"unset", "", 0, true, llm, nil, 0},
+		{"sliding", "sliding", 1000, true, llm, nil, 0},
+		{"custom", "custom", 1000, true, llm, nil, 0},
+		{"summarize: hot window and rolling summaries", "summarize", 1000, true, llm, nil, 2},
+		{"summarize without explicit provider falls back", "summarize", 1000, true, nil, nil, 1},
+		{"summarize without state store falls back", "summarize", 1000, false, llm, nil, 1},
+		{"relevance: hot window, retrieval and pruning", "relevance", 1000, true, llm, withEmbedding, 3},
+		{"relevance without embedding provider falls back", "relevance", 1000, true, llm, nil, 1},
+		{"relevance without state store falls back", "relevance", 1000, false, llm, withEmbedding, 1},
+		{"budget from provider window", "sliding", 0, true, &windowedProvider{Provider: llm, window: 8000}, nil, 1},
+		{"configured budget wins", "sliding", 1000, true, &windowedProvider{Provider: llm, window: 8000}, nil, 0},
+		{"custom ignores provider window", "custom", 0, true, &windowedProvider{Provider: llm, window: 8000}, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []ServerOption{
+				WithLogger(logr.Discard()),
+				WithContextWindow(tt.budget),
+				WithTruncationStrategy(tt.strategy),
+			}
+			if tt.store {
+				opts = append(opts, WithStateStore(statestore.NewMemoryStore()))
+			}
+			s := NewServer(opts...)
+			s.extraProviders = tt.extra
+
+			got := s.contextManagementOptions(context.Background(), logr.Discard(), tt.llm)
+			assert.Len(t, got, tt.want)
+		})
+	}
+}
+
+func TestEmbeddingRoleProvider(t *testing.T) {
+	s := NewServer(WithLogger(logr.Discard()))
+
+	ep, err := s.embeddingRoleProvider(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, ep, "no embedding-role provider configured")
+
+	s.extraProviders = []ResolvedProvider{
+		{Role: v1alpha1.ProviderRoleTTS, Provider: &v1alpha1.Provider{
+			ObjectMeta: metav1.ObjectMeta{Name: "voice"},
+			Spec:       v1alpha1.ProviderSpec{Type: v1alpha1.ProviderTypeOpenAI, Model: "tts-1"},
+		}},
+		embeddingProvider("embed"),
+	}
+	ep, err = s.embeddingRoleProvider(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, ep, "the embedding-role provider scores relevance")
+}
+
+// A summarize-strategy conversation opens and answers every turn once history
+// exceeds both the token budget and the hot window.
+func TestConverse_SummarizeStrategyOverBudget(t *testing.T) {
+	packPath := filepath.Join(t.TempDir(), "pack.promptpack")
+	require.NoError(t, writeTestFile(t, packPath, `{
+		"id": "test-pack",
+		"name": "test-pack",
+		"version": "1.0.0",
+		"template_engine": { "version": "v1", "syntax": "{{variable}}" },
+		"prompts": {
+			"default": {
+				"id": "default",
+				"name": "default",
+				"version": "1.0.0",
+				"system_template": "You are a test assistant."
+			}
+		}
+	}`))
+
+	server := NewServer(
+		WithLogger(logr.Discard()),
+		WithPackPath(packPath),
+		WithPromptName("default"),
+		WithMockProvider(true),
+		WithStateStore(statestore.NewMemoryStore()),
+		WithContextWindow(16),
+		WithTruncationStrategy("summarize"),
+	)
+	t.Cleanup(func() { _ = server.Close() })
+
+	const turns = hotWindowMessages/2 + 3
+	var msgs []*runtimev1.ClientMessage
+	for i := range turns {
+		msgs = append(msgs, &runtimev1.ClientMessage{
+			SessionId: "sess-summarize",
+			Content:   fmt.Sprintf("question %d about a long-running topic", i),
+		})
+	}
+	stream := newMockStream(context.Background(), msgs)
+	_ = server.Converse(stream) // ends via the mock stream's context.Canceled sentinel
+
+	var done int
+	for _, msg := range stream.sentMessages {
+		require.Nil(t, msg.GetError(), "turn failed: %v", msg.GetError())
+		if msg.GetDone() != nil {
+			done++
+		}
+	}
+	assert.Equal(t, turns, done)
+}
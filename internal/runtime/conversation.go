@@ -22,6 +22,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
 	"github.com/AltairaLabs/PromptKit/sdk"
 	"github.com/go-logr/logr"
 	"go.opentelemetry.io/otel/trace"
@@ -56,23 +57,33 @@ func (s *Server) getOrCreateConversation(ctx context.Context, sessionID string)
 	}
 
 	// Create and initialize the conversation
-	conv, err := s.createConversation(ctx, sessionID)
+	conv, packVersion, err := s.createConversation(ctx, sessionID)
 	if err != nil {
 		return nil, err
 	}
 
 	s.conversations[sessionID] = conv
+	s.conversationPacks[sessionID] = packVersion
 	return conv, nil
 }
 
-// createConversation creates and initializes a new conversation with the given session ID.
-func (s *Server) createConversation(ctx context.Context, sessionID string) (*sdk.Conversation, error) {
+// conversationPackVersion returns the version of the pack the session's open
+// conversation was opened with, which a pack reload does not change.
+func (s *Server) conversationPackVersion(sessionID string) string {
+	s.conversationMu.RLock()
+	defer s.conversationMu.RUnlock()
+	return s.conversationPacks[sessionID]
+}
+
+// createConversation creates and initializes a new conversation with the
+// given session ID, returning it with the version of the pack it opened.
+func (s *Server) createConversation(ctx context.Context, sessionID string) (*sdk.Conversation, string, error) {
 	log := logctx.LoggerWithContext(s.log, ctx)
 
 	// Build SDK options with provider
 	opts, err := s.buildConversationOptions(ctx, sessionID)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	log.V(1).Info("conversation creating",
@@ -81,10 +92,12 @@ func (s *Server) createConversation(ctx context.Context, sessionID string) (*sdk
 		"evalDefCount", len(s.evalDefs))
 
 	// Try to resume existing conversation first, or create new
-	conv, err := s.resumeOrOpenConversation(sessionID, opts, log)
+	packPath, packVersion := s.activePack()
+	conv, err := s.resumeOrOpenConversation(sessionID, packPath, opts, log)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	s.recordPackVersion(ctx, conv, sessionID, packVersion, log)
 
 	// Register tools with the conversation if available
 	if s.toolsInitialized && s.toolExecutor != nil {
@@ -93,11 +106,14 @@ func (s *Server) createConversation(ctx context.Context, sessionID string) (*sdk
 			// Continue without tools - don't fail the conversation
 		}
 	}
+	if s.delegator != nil {
+		s.registerDelegates(conv, sessionID, log)
+	}
 
 	// Subscribe to event bus logging for observability
 	s.subscribeToEventBusLogging(sessionID, conv)
 
-	return conv, nil
+	return conv, packVersion, nil
 }
 
 // buildConversationOptions builds the SDK options for a conversation, including provider setup.
@@ -119,12 +135,14 @@ func (s *Server) buildConversationOptions(ctx context.Context, sessionID string)
 	}
 
 	// Add provider based on configuration
+	var llm providers.Provider
 	if s.mockProvider {
 		log.Info("using mock provider for conversation")
 		provider, err := s.createMockProvider()
 		if err != nil {
 			return nil, err
 		}
+		llm = provider
 		opts = append(opts, sdk.WithProvider(provider))
 	} else {
 		// Try to create an explicit provider from config
@@ -134,6 +152,7 @@ func (s *Server) buildConversationOptions(ctx context.Context, sessionID string)
 		}
 		if provider != nil {
 			log.Info("using explicit provider from config", "type", s.providerType)
+			llm = provider
 			opts = append(opts, sdk.WithProvider(provider))
 		}
 		// If provider is nil, PromptKit will auto-detect from environment
@@ -142,6 +161,9 @@ func (s *Server) buildConversationOptions(ctx context.Context, sessionID string)
 	// Wire each resolved non-default provider to its role's SDK option.
 	opts = append(opts, s.extraProviderOptions(log)...)
 
+	// Truncation strategy wiring that needs the conversation's providers.
+	opts = append(opts, s.contextManagementOptions(ctx, log, llm)...)
+
 	// Function-mode response-format constraint (#1483). resolveResponseFormat
 	// returns nil for agent mode and for outputFormat "text", so this is a
 	// no-op outside constrained function invocations.
@@ -153,6 +175,35 @@ func (s *Server) buildConversationOptions(ctx context.Context, sessionID string)
 			"formatType", string(rf.Type))
 	}
 
+	// Agent-mode structured output: ask the provider for JSON bound to the
+	// pack's response schema; enforceStructuredOutput validates every turn.
+	if so := s.activeStructuredOutput(); so != nil {
+		opts = append(opts, sdk.WithResponseFormat(so.responseFormat(s.agentName)))
+	}
+
+	// Knowledge retrieval: render the chunks retrieveKnowledge put on the
+	// send context into {{knowledge_context}}.
+	if s.knowledgeStore != nil {
+		opts = append(opts, sdk.WithVariableProvider(knowledgeVariables{}))
+	}
+
+	// Locale: render the user's locale withLocale put on the send context
+	// into {{locale}}.
+	opts = append(opts, sdk.WithVariableProvider(localeVariables{}))
+
+	// Tool-call guardrails: a rejected call becomes the tool's error result.
+	if g := s.activeGuardrails(); g != nil && !g.ToolCalls.Empty() {
+		opts = append(opts, sdk.WithToolHook(guardrailToolHook{chain: g.ToolCalls}))
+	}
+
+	// Budgets: count every provider call and stop a turn once the session
+	// or the agent is over budget.
+	if s.budget != nil {
+		opts = append(opts, sdk.WithProviderHook(budgetProviderHook{
+			tracker: s.budget, store: s.stateStore, sessionID: sessionID, log: s.log,
+		}))
+	}
+
 	// Wire eval middleware when collector is configured
 	evalOpts := s.buildEvalOptions()
 	log.V(1).Info("eval options wired",
@@ -245,11 +296,11 @@ func (s *Server) buildConversationOptions(ctx context.Context, sessionID string)
 		opts = append(opts, sdk.WithTracerProvider(s.tracingProvider.TracerProvider()))
 	}
 
-	// Increase pipeline execution timeout when tools are configured.
+	// Increase pipeline execution timeout when tools or delegates are configured.
 	// The default 30s is too short for multi-round tool-calling with
 	// slower providers (e.g. Ollama). Each round involves LLM inference
 	// + tool execution, so we allow 120s.
-	if s.toolsInitialized && s.toolExecutor != nil {
+	if (s.toolsInitialized && s.toolExecutor != nil) || s.delegator != nil {
 		opts = append(opts, sdk.WithExecutionTimeout(toolCallExecutionTimeout))
 	}
 
@@ -257,11 +308,11 @@ func (s *Server) buildConversationOptions(ctx context.Context, sessionID string)
 }
 
 // resumeOrOpenConversation tries to resume an existing conversation, or opens a new one.
-func (s *Server) resumeOrOpenConversation(sessionID string, opts []sdk.Option, log logr.Logger) (*sdk.Conversation, error) {
-	conv, err := sdk.Resume(sessionID, s.packPath, s.promptName, opts...)
+func (s *Server) resumeOrOpenConversation(sessionID, packPath string, opts []sdk.Option, log logr.Logger) (*sdk.Conversation, error) {
+	conv, err := sdk.Resume(sessionID, packPath, s.promptName, opts...)
 	if err != nil {
 		log.V(1).Info("creating new conversation")
-		conv, err = sdk.Open(s.packPath, s.promptName, opts...)
+		conv, err = sdk.Open(packPath, s.promptName, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open pack: %w", err)
 		}
@@ -273,8 +324,8 @@ func (s *Server) resumeOrOpenConversation(sessionID string, opts []sdk.Option, l
 	// trap — the model can't call it. Logged at info so it is visible without
 	// debug (the class of bug that is otherwise invisible).
 	log.Info("conversation tool offering",
-		"packPath", s.packPath,
+		"packPath", packPath,
 		"prompt", s.promptName,
-		"allowedTools", promptAllowedTools(s.packPath, s.promptName))
+		"allowedTools", promptAllowedTools(packPath, s.promptName))
 	return conv, nil
 }
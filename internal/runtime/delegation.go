@@ -0,0 +1,74 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/sdk"
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/internal/runtime/delegation"
+)
+
+// eventDelegationCompleted is recorded in the calling session for every call
+// to a delegate, linking it to the nested session of the exchange.
+const eventDelegationCompleted events.EventType = "delegation.completed"
+
+// registerDelegates offers the delegates to the conversation of sessionID.
+func (s *Server) registerDelegates(conv *sdk.Conversation, sessionID string, log logr.Logger) {
+	registry := conv.ToolRegistry()
+	registry.RegisterExecutor(s.delegator.Executor(sessionID, s.sessionStore, func(x delegation.Exchange) {
+		s.publishDelegation(conv, sessionID, x)
+	}))
+	for _, desc := range s.delegator.Descriptors() {
+		if err := registry.Register(desc); err != nil {
+			log.Error(err, "failed to register delegate", "tool", desc.Name)
+		}
+	}
+}
+
+// publishDelegation records a call to a delegate in the calling session.
+func (s *Server) publishDelegation(conv *sdk.Conversation, sessionID string, x delegation.Exchange) {
+	bus := conv.EventBus()
+	if bus == nil {
+		return
+	}
+	data := map[string]any{
+		"delegate":   x.Delegate,
+		"agent":      x.Agent,
+		"sessionId":  x.SessionID,
+		"durationMs": x.Duration.Milliseconds(),
+	}
+	message := "delegated to agent " + x.Agent
+	if x.Err != nil {
+		data["error"] = x.Err.Error()
+		message = "delegation to agent " + x.Agent + " failed"
+	}
+	bus.Publish(&events.Event{
+		Type:           eventDelegationCompleted,
+		Timestamp:      time.Now(),
+		SessionID:      sessionID,
+		ConversationID: conv.ID(),
+		Data: &events.CustomEventData{
+			EventName: string(eventDelegationCompleted),
+			Data:      data,
+			Message:   message,
+		},
+	})
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package delegation lets an agent call other agents as tools. Each delegate
+// is offered to the model as an a2a__<name> tool taking a query; a call sends
+// the query to the delegate's A2A facade and returns its reply.
+//
+// Every exchange is recorded in session-api as a nested session of the
+// delegate's agent, linked to the calling session through its state. The
+// nested session is derived from the calling session and the delegate, so
+// repeated calls in one session continue the same conversation with the
+// delegate. Recording is best-effort: a session-api failure is logged and
+// never fails the call.
+package delegation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/a2a"
+	"github.com/AltairaLabs/PromptKit/runtime/tools"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/pkg/identity"
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+// ExecutorName is the tool mode delegate descriptors are dispatched by.
+const ExecutorName = "omnia-delegate"
+
+// toolNamespace is the PromptKit tool namespace delegates are offered in.
+// PromptKit offers tools in this namespace without the prompt listing them.
+const toolNamespace = "a2a"
+
+// DefaultTimeout bounds a call when Delegate.Timeout is unset.
+const DefaultTimeout = 90 * time.Second
+
+// Keys of the nested session's state and of the A2A message metadata that
+// link an exchange to the calling session.
+const (
+	StateParentSessionID = "delegation.parentSessionId"
+	StateParentAgent     = "delegation.parentAgent"
+	StateDelegate        = "delegation.delegate"
+)
+
+// sessionTag marks nested sessions in session-api.
+const sessionTag = "source:delegation"
+
+// recordTimeout bounds each session-api write of an exchange.
+const recordTimeout = 10 * time.Second
+
+// sessionNamespace seeds the nested session IDs.
+var sessionNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://omnia.altairalabs.ai/delegation"))
+
+var inputSchema = json.RawMessage(`{"type":"object","properties":{"query":{"type":"string","description":"The message to send to the agent"}},"required":["query"]}`)
+
+var outputSchema = json.RawMessage(`{"type":"object","properties":{"response":{"type":"string"}}}`)
+
+// Delegate is an agent offered as a tool.
+type Delegate struct {
+	// Name is the tool's name, offered as a2a__<Name>.
+	Name string
+	// Agent is the delegate's AgentRuntime name, recorded on its sessions.
+	Agent string
+	// URL is the base URL of the agent's A2A facade.
+	URL string
+	// Description is the tool's description.
+	Description string
+	// Timeout bounds each call; zero means DefaultTimeout.
+	Timeout time.Duration
+	// Token is the bearer token sent to the facade, if any.
+	Token string
+}
+
+// ToolName returns the name the model calls the delegate by.
+func (d Delegate) ToolName() string {
+	return tools.QualifyToolName(toolNamespace, d.Name)
+}
+
+// Agent identifies the delegating agent.
+type Agent struct {
+	Name          string
+	Namespace     string
+	WorkspaceName string
+}
+
+// Exchange describes a completed call to a delegate.
+type Exchange struct {
+	Delegate  string
+	Agent     string
+	SessionID string // the nested session
+	Duration  time.Duration
+	Err       error
+}
+
+// Delegator holds the A2A clients of an agent's delegates.
+type Delegator struct {
+	agent     Agent
+	delegates map[string]*delegate // by tool name
+	log       logr.Logger
+}
+
+type delegate struct {
+	Delegate
+	client *a2a.Client
+}
+
+// New returns a Delegator for agent's delegates.
+func New(agent Agent, delegates []Delegate, log logr.Logger) *Delegator {
+	d := &Delegator{agent: agent, delegates: make(map[string]*delegate, len(delegates)), log: log}
+	for _, del := range delegates {
+		if del.Timeout <= 0 {
+			del.Timeout = DefaultTimeout
+		}
+		var opts []a2a.ClientOption
+		if del.Token != "" {
+			opts = append(opts, a2a.WithAuth("Bearer", del.Token))
+		}
+		d.delegates[del.ToolName()] = &delegate{Delegate: del, client: a2a.NewClient(del.URL, opts...)}
+	}
+	return d
+}
+
+// Descriptors returns the tool descriptors of the delegates, sorted by name.
+func (d *Delegator) Descriptors() []*tools.ToolDescriptor {
+	descs := make([]*tools.ToolDescriptor, 0, len(d.delegates))
+	for name, del := range d.delegates {
+		descs = append(descs, &tools.ToolDescriptor{
+			Name:         name,
+			Namespace:    toolNamespace,
+			Description:  del.Description,
+			InputSchema:  inputSchema,
+			OutputSchema: outputSchema,
+			Mode:         ExecutorName,
+		})
+	}
+	sort.Slice(descs, func(i, j int) bool { return descs[i].Name < descs[j].Name })
+	return descs
+}
+
+// Executor returns the tool executor for the conversation of sessionID.
+// Exchanges are recorded in store, unless it is nil, and reported to
+// onExchange, unless it is nil.
+func (d *Delegator) Executor(sessionID string, store session.Store, onExchange func(Exchange)) tools.Executor {
+	return &executor{delegator: d, sessionID: sessionID, store: store, onExchange: onExchange}
+}
+
+// SessionID returns the nested session of a delegate's exchanges with the
+// calling session.
+func SessionID(parentSessionID, delegateName string) string {
+	return uuid.NewSHA1(sessionNamespace, []byte(parentSessionID+"/"+delegateName)).String()
+}
+
+type executor struct {
+	delegator  *Delegator
+	sessionID  string
+	store      session.Store
+	onExchange func(Exchange)
+}
+
+// Name implements tools.Executor.
+func (e *executor) Name() string { return ExecutorName }
+
+// Execute implements tools.Executor.
+func (e *executor) Execute(ctx context.Context, desc *tools.ToolDescriptor, args json.RawMessage) (json.RawMessage, error) {
+	del, ok := e.delegator.delegates[desc.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown delegate %q", desc.Name)
+	}
+	var in struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(args, &in); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if strings.TrimSpace(in.Query) == "" {
+		return nil, errors.New("query is required")
+	}
+
+	start := time.Now()
+	childID := SessionID(e.sessionID, del.Name)
+	reply, err := e.call(ctx, del, childID, in.Query)
+	if e.onExchange != nil {
+		e.onExchange(Exchange{
+			Delegate: del.Name, Agent: del.Agent, SessionID: childID,
+			Duration: time.Since(start), Err: err,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]string{"response": reply})
+}
+
+// call sends query to del in the nested session childID, recording both
+// sides of the exchange.
+func (e *executor) call(ctx context.Context, del *delegate, childID, query string) (string, error) {
+	e.record(ctx, del, childID, session.RoleUser, query)
+
+	callCtx, cancel := context.WithTimeout(ctx, del.Timeout)
+	defer cancel()
+	task, err := del.client.SendMessage(callCtx, &a2a.SendMessageRequest{
+		Message: a2a.Message{
+			MessageID: uuid.NewString(),
+			ContextID: childID,
+			Role:      a2a.RoleUser,
+			Parts:     []a2a.Part{{Text: &query}},
+			Metadata: map[string]any{
+				StateParentSessionID: e.sessionID,
+				StateParentAgent:     e.delegator.agent.Name,
+			},
+		},
+		Configuration: &a2a.SendMessageConfiguration{Blocking: true},
+	})
+	if err == nil && task.Status.State != a2a.TaskStateCompleted {
+		err = fmt.Errorf("task %s", task.Status.State)
+		if reason := taskMessage(task); reason != "" {
+			err = fmt.Errorf("task %s: %s", task.Status.State, reason)
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("agent %s: %w", del.Agent, err)
+		e.recordFailure(ctx, childID, err)
+		return "", err
+	}
+
+	reply := a2a.ExtractResponseText(task)
+	e.record(ctx, del, childID, session.RoleAssistant, reply)
+	return reply, nil
+}
+
+// taskMessage returns the text of a task's status message.
+func taskMessage(task *a2a.Task) string {
+	if task.Status.Message == nil {
+		return ""
+	}
+	for _, p := range task.Status.Message.Parts {
+		if p.Text != nil {
+			return *p.Text
+		}
+	}
+	return ""
+}
+
+// record appends a message to the nested session, registering the session
+// on first use.
+func (e *executor) record(ctx context.Context, del *delegate, childID string, role session.MessageRole, content string) {
+	store := e.store
+	if store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancel()
+	log := e.delegator.log.WithValues("delegate", del.Name, "sessionID", childID)
+
+	if role == session.RoleUser {
+		userID := policy.UserID(ctx)
+		if userID == "" {
+			userID = identity.PseudonymizeID(childID)
+		}
+		if _, err := store.EnsureSessionRecord(ctx, session.SessionRecordOptions{
+			ID:            childID,
+			AgentName:     del.Agent,
+			Namespace:     e.delegator.agent.Namespace,
+			WorkspaceName: e.delegator.agent.WorkspaceName,
+			InitialState: map[string]string{
+				StateParentSessionID: e.sessionID,
+				StateParentAgent:     e.delegator.agent.Name,
+				StateDelegate:        del.Name,
+			},
+			Tags:          []string{sessionTag},
+			VirtualUserID: userID,
+		}); err != nil {
+			log.Error(err, "failed to record delegation session")
+			return
+		}
+	}
+	if err := store.AppendMessage(ctx, childID, session.Message{
+		ID:        uuid.NewString(),
+		Role:      role,
+		Content:   content,
+		Timestamp: time.Now(),
+	}); err != nil {
+		log.Error(err, "failed to record delegation message", "role", role)
+	}
+}
+
+// recordFailure marks the nested session failed.
+func (e *executor) recordFailure(ctx context.Context, childID string, cause error) {
+	store := e.store
+	if store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancel()
+	if err := store.UpdateSessionStatus(ctx, childID, session.SessionStatusUpdate{
+		SetStatus: session.SessionStatusError,
+	}); err != nil {
+		e.delegator.log.Error(err, "failed to record delegation failure", "sessionID", childID, "cause", cause.Error())
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delegation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/a2a"
+	"github.com/AltairaLabs/PromptKit/runtime/tools"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/sessiontest"
+)
+
+// fakeAgent is an A2A facade answering every message with reply, or ending
+// the task in state when it is set.
+type fakeAgent struct {
+	reply string
+	state a2a.TaskState
+
+	mu       sync.Mutex
+	messages []a2a.Message
+	auth     []string
+}
+
+func (f *fakeAgent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req a2a.JSONRPCRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var params a2a.SendMessageRequest
+	_ = json.Unmarshal(req.Params, &params)
+	f.mu.Lock()
+	f.messages = append(f.messages, params.Message)
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	f.mu.Unlock()
+
+	state := f.state
+	if state == "" {
+		state = a2a.TaskStateCompleted
+	}
+	reply := f.reply
+	task := a2a.Task{
+		ID:        "task-1",
+		ContextID: params.Message.ContextID,
+		Status: a2a.TaskStatus{State: state, Message: &a2a.Message{
+			Role: a2a.RoleAgent, Parts: []a2a.Part{{Text: &reply}},
+		}},
+	}
+	result, _ := json.Marshal(task)
+	_ = json.NewEncoder(w).Encode(a2a.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: result})
+}
+
+func newDelegator(t *testing.T, agent *fakeAgent, del Delegate) *Delegator {
+	t.Helper()
+	srv := httptest.NewServer(agent)
+	t.Cleanup(srv.Close)
+	del.URL = srv.URL
+	return New(Agent{Name: "concierge", Namespace: "ns", WorkspaceName: "ws"}, []Delegate{del}, logr.Discard())
+}
+
+func TestDescriptors(t *testing.T) {
+	d := New(Agent{Name: "concierge"}, []Delegate{
+		{Name: "researcher", Description: "Finds sources."},
+		{Name: "billing", Description: "Answers billing questions."},
+	}, logr.Discard())
+	descs := d.Descriptors()
+	require.Len(t, descs, 2)
+	assert.Equal(t, "a2a__billing", descs[0].Name)
+	assert.Equal(t, "a2a__researcher", descs[1].Name)
+	assert.Equal(t, "Finds sources.", descs[1].Description)
+	assert.Equal(t, ExecutorName, descs[1].Mode)
+	assert.True(t, tools.IsSystemTool(descs[0].Name), "delegates are offered without the prompt listing them")
+}
+
+func TestExecute(t *testing.T) {
+	agent := &fakeAgent{reply: "Three sources found."}
+	d := newDelegator(t, agent, Delegate{Name: "researcher", Agent: "research-agent", Token: "s3cret"})
+	store := sessiontest.NewStore()
+	var exchanges []Exchange
+	exec := d.Executor("parent-1", store, func(x Exchange) { exchanges = append(exchanges, x) })
+	desc := d.Descriptors()[0]
+	ctx := context.Background()
+
+	out, err := exec.Execute(ctx, desc, json.RawMessage(`{"query":"Find sources on tides"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"response":"Three sources found."}`, string(out))
+
+	childID := SessionID("parent-1", "researcher")
+	require.Len(t, agent.messages, 1)
+	msg := agent.messages[0]
+	assert.Equal(t, childID, msg.ContextID, "the nested session is the agent's conversation")
+	assert.Equal(t, "Find sources on tides", *msg.Parts[0].Text)
+	assert.Equal(t, "parent-1", msg.Metadata[StateParentSessionID])
+	assert.Equal(t, "concierge", msg.Metadata[StateParentAgent])
+	assert.Equal(t, "Bearer s3cret", agent.auth[0])
+
+	require.Len(t, exchanges, 1)
+	assert.Equal(t, "research-agent", exchanges[0].Agent)
+	assert.Equal(t, childID, exchanges[0].SessionID)
+	assert.NoError(t, exchanges[0].Err)
+
+	sess, err := store.GetSession(ctx, childID)
+	require.NoError(t, err)
+	assert.Equal(t, "research-agent", sess.AgentName)
+	assert.Equal(t, "ns", sess.Namespace)
+	assert.Equal(t, "parent-1", sess.State[StateParentSessionID])
+	assert.Equal(t, "researcher", sess.State[StateDelegate])
+	assert.Contains(t, sess.Tags, sessionTag)
+	msgs, err := store.GetMessages(ctx, childID)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, session.RoleUser, msgs[0].Role)
+	assert.Equal(t, "Find sources on tides", msgs[0].Content)
+	assert.Equal(t, session.RoleAssistant, msgs[1].Role)
+	assert.Equal(t, "Three sources found.", msgs[1].Content)
+
+	_, err = exec.Execute(ctx, desc, json.RawMessage(`{"query":"And on currents?"}`))
+	require.NoError(t, err)
+	assert.Equal(t, childID, agent.messages[1].ContextID, "repeated calls continue the nested session")
+
+	assert.NotEqual(t, childID, SessionID("parent-2", "researcher"))
+}
+
+func TestExecute_Failures(t *testing.T) {
+	agent := &fakeAgent{reply: "I cannot help with that.", state: a2a.TaskStateFailed}
+	d := newDelegator(t, agent, Delegate{Name: "researcher", Agent: "research-agent"})
+	store := sessiontest.NewStore()
+	var exchanges []Exchange
+	exec := d.Executor("parent-1", store, func(x Exchange) { exchanges = append(exchanges, x) })
+	desc := d.Descriptors()[0]
+	ctx := context.Background()
+
+	_, err := exec.Execute(ctx, desc, json.RawMessage(`{"query":"Find sources"}`))
+	assert.EqualError(t, err, "agent research-agent: task failed: I cannot help with that.")
+	require.Len(t, exchanges, 1)
+	assert.Equal(t, err, exchanges[0].Err)
+	sess, err := store.GetSession(ctx, SessionID("parent-1", "researcher"))
+	require.NoError(t, err)
+	assert.Equal(t, session.SessionStatusError, sess.Status)
+
+	_, err = exec.Execute(ctx, desc, json.RawMessage(`{"query":" "}`))
+	assert.EqualError(t, err, "query is required")
+	_, err = exec.Execute(ctx, &tools.ToolDescriptor{Name: "a2a__other"}, json.RawMessage(`{"query":"hi"}`))
+	assert.EqualError(t, err, `unknown delegate "a2a__other"`)
+	assert.Len(t, agent.messages, 1)
+}
+
+func TestExecute_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { <-release }))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+	d := New(Agent{Name: "concierge"}, []Delegate{
+		{Name: "slow", Agent: "slow-agent", URL: srv.URL, Timeout: 50 * time.Millisecond},
+	}, logr.Discard())
+
+	_, err := d.Executor("parent-1", nil, nil).Execute(context.Background(), d.Descriptors()[0],
+		json.RawMessage(`{"query":"hi"}`))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
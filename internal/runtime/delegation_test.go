@@ -0,0 +1,110 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/a2a"
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/runtime/statestore"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/runtime/delegation"
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+)
+
+func TestConverse_Delegation(t *testing.T) {
+	var mu sync.Mutex
+	var received []a2a.Message
+	agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req a2a.JSONRPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var params a2a.SendMessageRequest
+		require.NoError(t, json.Unmarshal(req.Params, &params))
+		mu.Lock()
+		received = append(received, params.Message)
+		mu.Unlock()
+		reply := "Tides are driven by the moon."
+		result, _ := json.Marshal(a2a.Task{ID: "task-1", ContextID: params.Message.ContextID, Status: a2a.TaskStatus{
+			State: a2a.TaskStateCompleted, Message: &a2a.Message{Role: a2a.RoleAgent, Parts: []a2a.Part{{Text: &reply}}},
+		}})
+		_ = json.NewEncoder(w).Encode(a2a.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: result})
+	}))
+	t.Cleanup(agent.Close)
+
+	dir := t.TempDir()
+	packPath := filepath.Join(dir, "pack.promptpack")
+	require.NoError(t, writeTestFile(t, packPath, invokeTestPack))
+	mockPath := filepath.Join(dir, "mock.yaml")
+	require.NoError(t, writeTestFile(t, mockPath, `defaultResponse: "fallback text"
+scenarios:
+  default:
+    turns:
+      1:
+        type: tool_calls
+        content: ""
+        tool_calls:
+          - name: a2a__researcher
+            arguments:
+              query: "What drives the tides?"
+      2:
+        content: "The moon drives the tides."
+`))
+	server := NewServer(
+		WithLogger(logr.Discard()),
+		WithPackPath(packPath),
+		WithPromptName("default"),
+		WithMockProvider(true),
+		WithMockConfigPath(mockPath),
+		WithStateStore(statestore.NewMemoryStore()),
+		WithAgentIdentity("concierge", "ns"),
+		WithDelegator(delegation.New(delegation.Agent{Name: "concierge", Namespace: "ns"}, []delegation.Delegate{{
+			Name: "researcher", Agent: "research-agent", URL: agent.URL, Description: "Answers research questions.",
+		}}, logr.Discard())),
+	)
+	t.Cleanup(func() { _ = server.Close() })
+	recorded := eventRecorder(t, server, "sess-1", eventDelegationCompleted)
+
+	chunks, errs := converseErrors(t, server, &runtimev1.ClientMessage{SessionId: "sess-1", Content: "Why are there tides?"})
+	assert.Empty(t, errs)
+	assert.Equal(t, "The moon drives the tides.", strings.Join(chunks, ""))
+
+	childID := delegation.SessionID("sess-1", "researcher")
+	mu.Lock()
+	require.Len(t, received, 1, "the scripted tool call is delegated to the agent")
+	assert.Equal(t, "What drives the tides?", *received[0].Parts[0].Text)
+	assert.Equal(t, childID, received[0].ContextID)
+	assert.Equal(t, "sess-1", received[0].Metadata[delegation.StateParentSessionID])
+	mu.Unlock()
+
+	require.Eventually(t, func() bool { return len(recorded()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	data := recorded()[0].Data.(*events.CustomEventData).Data
+	assert.Equal(t, "researcher", data["delegate"])
+	assert.Equal(t, "research-agent", data["agent"])
+	assert.Equal(t, childID, data["sessionId"])
+	assert.NotContains(t, data, "error")
+}
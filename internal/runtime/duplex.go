@@ -257,7 +257,8 @@ func (s *Server) handleDuplexSession(ctx context.Context, stream runtimev1.Runti
 		opts = append(opts, sdk.WithVariables(map[string]string{"system_instruction": si}))
 	}
 
-	conv, err := sdk.OpenDuplex(s.packPath, s.promptName, opts...)
+	packPath, _ := s.activePack()
+	conv, err := sdk.OpenDuplex(packPath, s.promptName, opts...)
 	if err != nil {
 		return err
 	}
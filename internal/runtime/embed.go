@@ -0,0 +1,68 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+)
+
+// Embed returns embedding vectors for req.Texts from the agent's
+// embedding-role provider, the same one relevance truncation and the response
+// cache use, so callers need no embedding credentials of their own. The
+// provider batches to its own limits.
+func (s *Server) Embed(ctx context.Context, req *runtimev1.EmbedRequest) (*runtimev1.EmbedResponse, error) {
+	if len(req.GetTexts()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "texts is required")
+	}
+	ep, err := s.embeddingRoleProvider(ctx)
+	if err != nil {
+		s.log.Error(err, "embedding provider unavailable")
+		return nil, status.Error(codes.Unavailable, "embedding provider unavailable")
+	}
+	if ep == nil {
+		return nil, status.Error(codes.FailedPrecondition,
+			"no embedding provider configured: add an embedding-role provider to spec.providers")
+	}
+
+	resp, err := ep.Embed(ctx, providers.EmbeddingRequest{Texts: req.GetTexts(), Model: req.GetModel()})
+	if err != nil {
+		s.log.Error(err, "embed failed", "texts", len(req.GetTexts()))
+		return nil, status.Error(codes.Internal, "embed failed")
+	}
+
+	out := &runtimev1.EmbedResponse{
+		Embeddings: make([]*runtimev1.Embedding, len(resp.Embeddings)),
+		Model:      resp.Model,
+		Dimensions: int32(ep.EmbeddingDimensions()), //nolint:gosec // vector lengths are small
+	}
+	for i, v := range resp.Embeddings {
+		out.Embeddings[i] = &runtimev1.Embedding{Values: v}
+	}
+	if len(resp.Embeddings) > 0 {
+		out.Dimensions = int32(len(resp.Embeddings[0])) //nolint:gosec // vector lengths are small
+	}
+	if resp.Usage != nil {
+		out.TotalTokens = int32(resp.Usage.TotalTokens) //nolint:gosec // token counts fit in int32
+	}
+	return out, nil
+}
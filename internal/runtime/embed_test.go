@@ -0,0 +1,87 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+)
+
+func TestEmbed(t *testing.T) {
+	var calls int
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/embeddings", r.URL.Path)
+		assert.Equal(t, "Bearer sk-embed", r.Header.Get("Authorization"), "the provider's credential is reused")
+		var req struct {
+			Input []string `json:"input"`
+			Model string   `json:"model"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		data := make([]map[string]any, len(req.Input))
+		for i := range req.Input {
+			data[i] = map[string]any{"index": i, "embedding": []float32{float32(i), 1}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data":  data,
+			"model": req.Model,
+			"usage": map[string]any{"prompt_tokens": 4, "total_tokens": 4},
+		})
+	}))
+	t.Cleanup(api.Close)
+
+	rp := embeddingProvider("embed")
+	rp.Provider.Spec.BaseURL = api.URL
+	s := NewServer(WithLogger(logr.Discard()))
+	s.extraProviders = []ResolvedProvider{rp}
+
+	resp, err := s.Embed(context.Background(), &runtimev1.EmbedRequest{Texts: []string{"a", "b"}})
+	require.NoError(t, err)
+	require.Len(t, resp.GetEmbeddings(), 2)
+	assert.Equal(t, []float32{1, 1}, resp.GetEmbeddings()[1].GetValues())
+	assert.Equal(t, int32(2), resp.GetDimensions())
+	assert.Equal(t, "text-embedding-3-small", resp.GetModel())
+	assert.Equal(t, int32(4), resp.GetTotalTokens())
+
+	first, err := s.embeddingRoleProvider(context.Background())
+	require.NoError(t, err)
+	second, err := s.embeddingRoleProvider(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, first, second, "one provider is shared by every embedding consumer")
+	assert.Equal(t, 1, calls)
+}
+
+func TestEmbed_Errors(t *testing.T) {
+	s := NewServer(WithLogger(logr.Discard()))
+
+	_, err := s.Embed(context.Background(), &runtimev1.EmbedRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.Embed(context.Background(), &runtimev1.EmbedRequest{Texts: []string{"a"}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "no embedding-role provider")
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/runtime/hooks"
+	"github.com/AltairaLabs/PromptKit/sdk"
+
+	"github.com/altairalabs/omnia/internal/runtime/guardrails"
+	"github.com/altairalabs/omnia/pkg/apierror"
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+)
+
+// errorCodeGuardrailRejected is the Error code sent when an input or output
+// guardrail rejects a turn.
+const errorCodeGuardrailRejected = apierror.CodeGuardrailRejected
+
+// eventGuardrailRejected is recorded in the session for every message or
+// response a guardrail rejects.
+const eventGuardrailRejected events.EventType = "guardrail.rejected"
+
+// eventGuardrailFlagged is recorded in the session for every finding of a
+// flagging, annotating or redacting guardrail.
+const eventGuardrailFlagged events.EventType = "guardrail.flagged"
+
+// packMetadataGuardrails is the PromptPack metadata entry declaring the
+// pack's guardrail hooks.
+const packMetadataGuardrails = "guardrails"
+
+// InitializeGuardrails builds the guardrail chains from the pack's
+// metadata.guardrails followed by spec.guardrails. An unknown hook or invalid
+// parameter fails startup rather than leaving the agent unguarded. A pack that
+// cannot be read or parsed is left to the readiness check, which fails for it.
+func (s *Server) InitializeGuardrails() error {
+	var packCfg guardrails.Config
+	if data, err := os.ReadFile(s.packPath); err == nil {
+		if packCfg, err = packGuardrails(data); err != nil {
+			return err
+		}
+	}
+	cfg := packCfg.Merge(s.guardrailConfig)
+	if cfg.Empty() {
+		return nil
+	}
+	g, err := guardrails.New(cfg, s.guardrailMetrics)
+	if err != nil {
+		return err
+	}
+	s.guardrails = g
+	s.log.Info("guardrails enabled",
+		"input", len(cfg.Input), "output", len(cfg.Output), "toolCalls", len(cfg.ToolCalls),
+		"fromPack", !packCfg.Empty())
+	return nil
+}
+
+// packGuardrails reads the hooks declared in the pack's metadata.guardrails.
+// Data that is not a pack declares none.
+func packGuardrails(data []byte) (guardrails.Config, error) {
+	var pack struct {
+		Metadata map[string]json.RawMessage `json:"metadata"`
+	}
+	if json.Unmarshal(data, &pack) != nil {
+		return guardrails.Config{}, nil
+	}
+	raw, ok := pack.Metadata[packMetadataGuardrails]
+	if !ok {
+		return guardrails.Config{}, nil
+	}
+	var cfg guardrails.Config
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return guardrails.Config{}, fmt.Errorf("pack metadata.guardrails: %w", err)
+	}
+	return cfg, nil
+}
+
+// holdsResponse reports whether response text is held back until the turn's
+// response is final, for structured output or output guardrails to check it.
+// Output guardrails with an output window check the response as it streams
+// instead (see outputGuard).
+func (s *Server) holdsResponse() bool {
+	g := s.activeGuardrails()
+	return s.activeStructuredOutput() != nil || (g != nil && !g.Output.Empty() && g.OutputWindow == 0)
+}
+
+// inputGuardrails and outputGuardrails return the stage's chain; nil when
+// no guardrails are configured.
+func (s *Server) inputGuardrails() *guardrails.Chain {
+	g := s.activeGuardrails()
+	if g == nil {
+		return nil
+	}
+	return g.Input
+}
+
+func (s *Server) outputGuardrails() *guardrails.Chain {
+	g := s.activeGuardrails()
+	if g == nil {
+		return nil
+	}
+	return g.Output
+}
+
+// checkGuardrails runs chain against text. Findings and a rejection are
+// recorded in the session when conv is non-nil; a rejection is returned as a
+// *guardrails.Rejection. The result is never nil.
+func (s *Server) checkGuardrails(ctx context.Context, chain *guardrails.Chain, conv *sdk.Conversation, sessionID, text string) (*guardrails.Result, error) {
+	res := chain.Evaluate(ctx, text)
+	return res, s.recordGuardrails(conv, sessionID, res)
+}
+
+// recordGuardrails logs the findings of a check and records them, with its
+// rejection, in the session when conv is non-nil. It returns the rejection.
+func (s *Server) recordGuardrails(conv *sdk.Conversation, sessionID string, res *guardrails.Result) error {
+	for _, f := range res.Findings {
+		s.log.Info("guardrail flagged text", "hook", f.Hook, "stage", f.Stage, "action", f.Action,
+			"reason", f.Reason, "sessionID", sessionID)
+		if conv != nil {
+			s.publishGuardrailFlagged(conv, sessionID, f)
+		}
+	}
+	if res.Rejection == nil {
+		return nil
+	}
+	if conv != nil {
+		s.publishGuardrailRejected(conv, sessionID, res.Rejection)
+	}
+	return res.Rejection
+}
+
+// outputGuard checks a turn's response with the output guardrails while it
+// streams, when they have an output window and the response is not held back
+// for structured output. Text chunks sent through its stream (see wrap) are
+// released as the window passes them; a rejection fails the send, which ends
+// the provider stream and the turn.
+type outputGuard struct {
+	s         *Server
+	ctx       context.Context
+	conv      *sdk.Conversation
+	sessionID string
+	stream    *guardrails.Stream
+	redacted  bool
+	// out is the stream the checked text is sent on (see wrap).
+	out runtimev1.RuntimeService_ConverseServer
+}
+
+// newOutputGuard returns the turn's output guard, or nil when the response is
+// not checked as it streams.
+func (s *Server) newOutputGuard(ctx context.Context, conv *sdk.Conversation, sessionID string) *outputGuard {
+	g := s.activeGuardrails()
+	if g == nil || g.Output.Empty() || g.OutputWindow == 0 || s.activeStructuredOutput() != nil {
+		return nil
+	}
+	return &outputGuard{
+		s:         s,
+		ctx:       ctx,
+		conv:      conv,
+		sessionID: sessionID,
+		stream:    g.Output.Stream(g.OutputWindow),
+	}
+}
+
+// wrap returns stream with its text chunks checked by the guard. A nil guard
+// returns stream.
+func (o *outputGuard) wrap(stream runtimev1.RuntimeService_ConverseServer) runtimev1.RuntimeService_ConverseServer {
+	if o == nil {
+		return stream
+	}
+	o.out = stream
+	return &guardedStream{RuntimeService_ConverseServer: stream, o: o}
+}
+
+// record records a check's findings and rejection.
+func (o *outputGuard) record(res *guardrails.Result) error {
+	o.redacted = o.redacted || res.Redacted()
+	return o.s.recordGuardrails(o.conv, o.sessionID, res)
+}
+
+// finish checks the rest of the response and sends the text still held. The
+// result's Text is the response as sent.
+func (o *outputGuard) finish() (*guardrails.Result, error) {
+	text, res := o.stream.Close(o.ctx)
+	if err := o.record(res); err != nil {
+		return res, err
+	}
+	return res, sendText(o.out, text)
+}
+
+// guardedStream is a Converse stream whose text chunks pass through an
+// outputGuard.
+type guardedStream struct {
+	runtimev1.RuntimeService_ConverseServer
+	o *outputGuard
+}
+
+// Send checks text chunks. Any other message first flushes the held text, so
+// the response up to a tool call or media is sent before it.
+func (st *guardedStream) Send(msg *runtimev1.ServerMessage) error {
+	var out string
+	var res *guardrails.Result
+	if chunk := msg.GetChunk(); chunk != nil {
+		out, res = st.o.stream.Write(st.o.ctx, chunk.GetContent())
+	} else {
+		out, res = st.o.stream.Flush(st.o.ctx)
+	}
+	if err := st.o.record(res); err != nil {
+		return err
+	}
+	if err := sendText(st.RuntimeService_ConverseServer, out); err != nil {
+		return err
+	}
+	if msg.GetChunk() != nil {
+		return nil
+	}
+	return st.RuntimeService_ConverseServer.Send(msg)
+}
+
+// sendText sends text as a chunk unless it is empty.
+func sendText(stream runtimev1.RuntimeService_ConverseServer, text string) error {
+	if text == "" {
+		return nil
+	}
+	return stream.Send(&runtimev1.ServerMessage{
+		Message: &runtimev1.ServerMessage_Chunk{
+			Chunk: &runtimev1.Chunk{Content: text},
+		},
+	})
+}
+
+// publishGuardrailFlagged records a finding in the session.
+func (s *Server) publishGuardrailFlagged(conv *sdk.Conversation, sessionID string, f guardrails.Finding) {
+	bus := conv.EventBus()
+	if bus == nil {
+		return
+	}
+	bus.Publish(&events.Event{
+		Type:           eventGuardrailFlagged,
+		Timestamp:      time.Now(),
+		SessionID:      sessionID,
+		ConversationID: conv.ID(),
+		Data: &events.CustomEventData{
+			EventName: string(eventGuardrailFlagged),
+			Data: map[string]any{
+				"hook":          f.Hook,
+				"stage":         string(f.Stage),
+				"reason":        f.Reason,
+				"action":        string(f.Action),
+				"contentSHA256": f.ContentSHA256,
+			},
+			Message: fmt.Sprintf("%s flagged by guardrail %s: %s", f.Stage, f.Hook, f.Reason),
+		},
+	})
+}
+
+// publishGuardrailRejected records a rejection in the session.
+func (s *Server) publishGuardrailRejected(conv *sdk.Conversation, sessionID string, rej *guardrails.Rejection) {
+	bus := conv.EventBus()
+	if bus == nil {
+		return
+	}
+	bus.Publish(&events.Event{
+		Type:           eventGuardrailRejected,
+		Timestamp:      time.Now(),
+		SessionID:      sessionID,
+		ConversationID: conv.ID(),
+		Data: &events.CustomEventData{
+			EventName: string(eventGuardrailRejected),
+			Data: map[string]any{
+				"hook":          rej.Hook,
+				"stage":         string(rej.Stage),
+				"reason":        rej.Reason,
+				"contentSHA256": rej.ContentSHA256,
+			},
+			Message: rej.Error(),
+		},
+	})
+}
+
+// guardrailToolHook runs the tool-call guardrails on each tool call's
+// arguments. PromptKit turns a denial into the tool's error result, so the
+// model learns the call was refused and the turn continues. Arguments cannot
+// be changed: annotating and redacting hooks only flag tool calls.
+type guardrailToolHook struct {
+	chain *guardrails.Chain
+}
+
+// Compile-time interface check.
+var _ hooks.ToolHook = guardrailToolHook{}
+
+// Name implements hooks.ToolHook.
+func (guardrailToolHook) Name() string { return "omnia-guardrails" }
+
+// BeforeExecution implements hooks.ToolHook.
+func (h guardrailToolHook) BeforeExecution(ctx context.Context, req hooks.ToolRequest) hooks.Decision {
+	if rej := h.chain.Check(ctx, string(req.Args)); rej != nil {
+		return hooks.Deny(rej.Error())
+	}
+	return hooks.Allow
+}
+
+// AfterExecution implements hooks.ToolHook.
+func (guardrailToolHook) AfterExecution(context.Context, hooks.ToolRequest, hooks.ToolResponse) hooks.Decision {
+	return hooks.Allow
+}
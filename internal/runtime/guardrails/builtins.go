@@ -0,0 +1,201 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guardrails
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Built-in hook names.
+const (
+	HookRegexBlocklist = "regexBlocklist"
+	HookMaxLength      = "maxLength"
+	HookLanguage       = "language"
+	HookInjection      = "promptInjection"
+	HookModeration     = "moderation"
+)
+
+// defaultMinRatio is the share of letters the language hook requires in the
+// allowed scripts when the spec sets none.
+const defaultMinRatio = 0.8
+
+// Spec enables a built-in hook. It mirrors the AgentRuntime's GuardrailHook
+// and the entries of a PromptPack's metadata.guardrails.
+type Spec struct {
+	// Name is the built-in hook.
+	Name string `json:"name"`
+	// Patterns are the regexBlocklist regular expressions.
+	Patterns []string `json:"patterns,omitempty"`
+	// CaseInsensitive makes the regexBlocklist patterns ignore case.
+	CaseInsensitive bool `json:"caseInsensitive,omitempty"`
+	// MaxChars is the longest text maxLength allows.
+	MaxChars int `json:"maxChars,omitempty"`
+	// AllowedScripts are the Unicode script names language accepts.
+	AllowedScripts []string `json:"allowedScripts,omitempty"`
+	// MinRatio is the share of letters language requires in AllowedScripts
+	// (0 = defaultMinRatio).
+	MinRatio float64 `json:"minRatio,omitempty"`
+	// Threshold is the score at or above which promptInjection or moderation
+	// rejects the text (0 = defaultInjectionThreshold or
+	// defaultModerationThreshold).
+	Threshold float64 `json:"threshold,omitempty"`
+	// ClassifierURL is the optional endpoint promptInjection asks to score
+	// the text alongside its heuristics.
+	ClassifierURL string `json:"classifierURL,omitempty"`
+	// Moderation configures the provider moderation asks to score the text.
+	Moderation *ModerationSpec `json:"moderation,omitempty"`
+	// Action is what the chain does with text the hook rejects: block
+	// (default), flag, annotate or redact.
+	Action string `json:"action,omitempty"`
+	// Message replaces the rejection message sent to the client.
+	Message string `json:"message,omitempty"`
+}
+
+// newHook builds the built-in hook spec enables.
+func newHook(spec Spec) (Hook, error) {
+	switch spec.Name {
+	case HookRegexBlocklist:
+		return newRegexBlocklist(spec.Patterns, spec.CaseInsensitive)
+	case HookMaxLength:
+		if spec.MaxChars <= 0 {
+			return nil, errors.New("maxChars must be positive")
+		}
+		return maxLength{maxChars: spec.MaxChars}, nil
+	case HookLanguage:
+		return newLanguage(spec.AllowedScripts, spec.MinRatio)
+	case HookInjection:
+		return newInjection(spec.Threshold, spec.ClassifierURL)
+	case HookModeration:
+		return newModeration(spec.Threshold, spec.Moderation)
+	default:
+		return nil, fmt.Errorf("unknown guardrail hook %q", spec.Name)
+	}
+}
+
+// regexBlocklist rejects text matching any of its patterns.
+type regexBlocklist struct {
+	patterns []*regexp.Regexp
+}
+
+func newRegexBlocklist(patterns []string, caseInsensitive bool) (Hook, error) {
+	if len(patterns) == 0 {
+		return nil, errors.New("patterns is empty")
+	}
+	h := regexBlocklist{}
+	for i, p := range patterns {
+		if caseInsensitive {
+			p = "(?i)" + p
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %d: %w", i, err)
+		}
+		h.patterns = append(h.patterns, re)
+	}
+	return h, nil
+}
+
+func (regexBlocklist) Name() string { return HookRegexBlocklist }
+
+// Check names the matching pattern by its position so the rejection does not
+// reveal the blocklist.
+func (h regexBlocklist) Check(_ context.Context, text string) string {
+	for i, re := range h.patterns {
+		if re.MatchString(text) {
+			return fmt.Sprintf("matches blocked pattern %d", i+1)
+		}
+	}
+	return ""
+}
+
+// Redact implements Redactor.
+func (h regexBlocklist) Redact(text string) string {
+	for _, re := range h.patterns {
+		text = re.ReplaceAllLiteralString(text, redactedText)
+	}
+	return text
+}
+
+// maxLength rejects text longer than maxChars characters.
+type maxLength struct {
+	maxChars int
+}
+
+func (maxLength) Name() string { return HookMaxLength }
+
+func (h maxLength) Check(_ context.Context, text string) string {
+	if n := utf8.RuneCountInString(text); n > h.maxChars {
+		return fmt.Sprintf("%d characters exceeds the limit of %d", n, h.maxChars)
+	}
+	return ""
+}
+
+// language rejects text in which less than minRatio of the letters belong to
+// the allowed scripts. Text without letters is accepted.
+type language struct {
+	scripts  []*unicode.RangeTable
+	minRatio float64
+}
+
+func newLanguage(names []string, minRatio float64) (Hook, error) {
+	if len(names) == 0 {
+		return nil, errors.New("allowedScripts is empty")
+	}
+	if minRatio < 0 || minRatio > 1 {
+		return nil, fmt.Errorf("minRatio %v must be between 0 and 1", minRatio)
+	}
+	if minRatio == 0 {
+		minRatio = defaultMinRatio
+	}
+	h := language{minRatio: minRatio}
+	for _, name := range names {
+		table, ok := unicode.Scripts[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown Unicode script %q", name)
+		}
+		h.scripts = append(h.scripts, table)
+	}
+	return h, nil
+}
+
+func (language) Name() string { return HookLanguage }
+
+func (h language) Check(_ context.Context, text string) string {
+	var letters, allowed int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.IsOneOf(h.scripts, r) {
+			allowed++
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+	if ratio := float64(allowed) / float64(letters); ratio < h.minRatio {
+		return fmt.Sprintf("%.0f%% of the letters are in an allowed script, below the required %.0f%%",
+			ratio*100, h.minRatio*100)
+	}
+	return ""
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guardrails
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegexBlocklist(t *testing.T) {
+	h, err := newHook(Spec{Name: HookRegexBlocklist, Patterns: []string{`\bpassword\b`, `\d{16}`}, CaseInsensitive: true})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	assert.Empty(t, h.Check(ctx, "what is the weather?"))
+	assert.Equal(t, "matches blocked pattern 1", h.Check(ctx, "my PASSWORD is hunter2"))
+	assert.Equal(t, "matches blocked pattern 2", h.Check(ctx, "card 4111111111111111"))
+
+	_, err = newHook(Spec{Name: HookRegexBlocklist, Patterns: []string{"("}})
+	assert.ErrorContains(t, err, "pattern 0")
+	_, err = newHook(Spec{Name: HookRegexBlocklist})
+	assert.ErrorContains(t, err, "patterns is empty")
+}
+
+func TestMaxLength(t *testing.T) {
+	h, err := newHook(Spec{Name: HookMaxLength, MaxChars: 5})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	assert.Empty(t, h.Check(ctx, "héllo"), "characters, not bytes, are counted")
+	assert.Equal(t, "6 characters exceeds the limit of 5", h.Check(ctx, "hello!"))
+
+	_, err = newHook(Spec{Name: HookMaxLength})
+	assert.ErrorContains(t, err, "maxChars must be positive")
+}
+
+func TestLanguage(t *testing.T) {
+	h, err := newHook(Spec{Name: HookLanguage, AllowedScripts: []string{"Latin"}})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	assert.Empty(t, h.Check(ctx, "Where is my order #1234?"))
+	assert.Empty(t, h.Check(ctx, "12345 !!!"), "text without letters is accepted")
+	assert.Empty(t, h.Check(ctx, "Order Заказ status update for today"), "mostly Latin")
+	assert.Equal(t, "0% of the letters are in an allowed script, below the required 80%",
+		h.Check(ctx, "Где мой заказ?"))
+
+	h, err = newHook(Spec{Name: HookLanguage, AllowedScripts: []string{"Latin", "Cyrillic"}, MinRatio: 1})
+	require.NoError(t, err)
+	assert.Empty(t, h.Check(ctx, "Где мой order?"))
+	assert.NotEmpty(t, h.Check(ctx, "Где мой 订单?"))
+
+	_, err = newHook(Spec{Name: HookLanguage, AllowedScripts: []string{"Klingon"}})
+	assert.ErrorContains(t, err, `unknown Unicode script "Klingon"`)
+	_, err = newHook(Spec{Name: HookLanguage, AllowedScripts: []string{"Latin"}, MinRatio: 2})
+	assert.ErrorContains(t, err, "minRatio 2 must be between 0 and 1")
+}
+
+func TestChain(t *testing.T) {
+	metrics := NewMetrics(prometheus.NewRegistry(), nil)
+	g, err := New(Config{
+		Input: []Spec{
+			{Name: HookMaxLength, MaxChars: 20},
+			{Name: HookRegexBlocklist, Patterns: []string{"secret"}, Message: "Please don't share secrets."},
+		},
+	}, metrics)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	assert.Nil(t, g.Input.Check(ctx, "hello"))
+	assert.Nil(t, g.Output.Check(ctx, strings.Repeat("x", 100)), "an empty chain accepts everything")
+	assert.True(t, g.Output.Empty())
+
+	rej := g.Input.Check(ctx, strings.Repeat("secret", 5))
+	require.NotNil(t, rej)
+	assert.Equal(t, &Rejection{
+		Hook: HookMaxLength, Stage: StageInput, Reason: "30 characters exceeds the limit of 20",
+		ContentSHA256: contentSHA256(strings.Repeat("secret", 5)),
+	}, rej, "the first rejecting hook stops the chain")
+	assert.Len(t, rej.ContentSHA256, 64)
+	assert.Equal(t, "message rejected by guardrail maxLength: 30 characters exceeds the limit of 20", rej.Error())
+
+	rej = g.Input.Check(ctx, "my secret")
+	require.NotNil(t, rej)
+	assert.Equal(t, HookRegexBlocklist, rej.Hook)
+	assert.Equal(t, "Please don't share secrets.", rej.Error(), "a configured message replaces the default")
+
+	assert.InDelta(t, 2, testutil.ToFloat64(metrics.checks.WithLabelValues(HookMaxLength, "input", resultPass)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.checks.WithLabelValues(HookMaxLength, "input", resultRejected)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.checks.WithLabelValues(HookRegexBlocklist, "input", resultPass)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.checks.WithLabelValues(HookRegexBlocklist, "input", resultRejected)), 0)
+
+	_, err = New(Config{ToolCalls: []Spec{{Name: "profanity"}}}, nil)
+	assert.ErrorContains(t, err, `tool_call guardrail 0 (profanity): unknown guardrail hook "profanity"`)
+}
+
+func TestConfigMerge(t *testing.T) {
+	pack := Config{Input: []Spec{{Name: HookMaxLength, MaxChars: 10}}}
+	agent := Config{Input: []Spec{{Name: HookLanguage}}, Output: []Spec{{Name: HookMaxLength, MaxChars: 5}}}
+	merged := pack.Merge(agent)
+	assert.Equal(t, []Spec{{Name: HookMaxLength, MaxChars: 10}, {Name: HookLanguage}}, merged.Input)
+	assert.Equal(t, agent.Output, merged.Output)
+	assert.Len(t, pack.Input, 1, "merging leaves the receiver unchanged")
+	assert.Zero(t, merged.OutputWindow)
+	assert.Equal(t, 64, Config{OutputWindow: 32}.Merge(Config{OutputWindow: 64}).OutputWindow)
+	assert.Equal(t, 32, Config{OutputWindow: 32}.Merge(Config{}).OutputWindow)
+	assert.True(t, Config{}.Empty())
+	assert.False(t, merged.Empty())
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guardrails
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// defaultInjectionThreshold is the score promptInjection rejects at when
+	// the spec sets none.
+	defaultInjectionThreshold = 0.7
+	// classifierTimeout bounds a classifier request.
+	classifierTimeout = 2 * time.Second
+	// maxClassifierResponseBytes bounds the classifier response read.
+	maxClassifierResponseBytes = 64 << 10
+)
+
+// injectionSignal is a family of prompt injection or jailbreak phrasings.
+// Weight is the likelihood, between 0 and 1, that text matching it is an
+// attack.
+type injectionSignal struct {
+	name    string
+	weight  float64
+	pattern *regexp.Regexp
+}
+
+// injectionSignals are the heuristics promptInjection scores text with.
+var injectionSignals = []injectionSignal{
+	{"instruction override", 0.9, regexp.MustCompile(
+		`(?i)\b(ignore|disregard|forget|override|bypass)\b[^.\n]{0,40}\b(previous|prior|above|earlier|preceding|all|any|your|system)\b[^.\n]{0,20}\b(instructions?|rules|prompts?|directions|guidelines|constraints)\b`)},
+	{"prompt extraction", 0.8, regexp.MustCompile(
+		`(?i)\b(reveal|print|show|repeat|output|display|leak|tell me)\b[^.\n]{0,40}\b(system|initial|hidden|original|developer)\s+(prompt|instructions?|message)`)},
+	{"jailbreak persona", 0.7, regexp.MustCompile(
+		`\bDAN\b|(?i)\b(do anything now|developer mode|jailbreak(ed|ing)?|jailbroken|god mode|unfiltered mode)\b`)},
+	{"delimiter injection", 0.7, regexp.MustCompile(
+		`(?im)</?\s*(system|instructions?|assistant)\s*>|\[/?(INST|SYS)\]|<\|im_(start|end)\|>|^\s*#{2,}\s*(system|instructions?)\b`)},
+	{"restriction removal", 0.5, regexp.MustCompile(
+		`(?i)\b(without|no|free of|free from|disable)\b[^.\n]{0,20}\b(restrictions|filters|limitations|censorship|guardrails|safety|ethical guidelines|content polic(y|ies))\b`)},
+	{"role override", 0.4, regexp.MustCompile(
+		`(?i)\b(you are now|from now on,? you|act as|pretend (to be|you are|that you)|role-?play as)\b`)},
+	{"encoded payload", 0.3, regexp.MustCompile(
+		`[A-Za-z0-9+/]{120,}={0,2}`)},
+}
+
+// injection rejects text that scores at or above threshold as a prompt
+// injection or jailbreak attempt. The score is the heuristic score or, when
+// a classifier is configured and answers, the higher of the two.
+type injection struct {
+	threshold  float64
+	classifier string
+	client     *http.Client
+}
+
+func newInjection(threshold float64, classifierURL string) (Hook, error) {
+	if threshold < 0 || threshold > 1 {
+		return nil, fmt.Errorf("threshold %v must be between 0 and 1", threshold)
+	}
+	if threshold == 0 {
+		threshold = defaultInjectionThreshold
+	}
+	h := injection{threshold: threshold}
+	if classifierURL != "" {
+		u, err := url.Parse(classifierURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("classifierURL %q must be an absolute http(s) URL", classifierURL)
+		}
+		h.classifier = classifierURL
+		h.client = &http.Client{Timeout: classifierTimeout}
+	}
+	return h, nil
+}
+
+func (injection) Name() string { return HookInjection }
+
+// Check names the matched signals, not the patterns behind them.
+func (h injection) Check(ctx context.Context, text string) string {
+	score, signals := heuristicScore(text)
+	if h.classifier != "" {
+		// A classifier that cannot answer leaves the heuristics to decide.
+		if cs, err := h.classify(ctx, text); err == nil && cs > score {
+			score = cs
+			signals = append(signals, "classifier")
+		}
+	}
+	if score < h.threshold {
+		return ""
+	}
+	return fmt.Sprintf("prompt injection score %.2f (%s)", score, strings.Join(signals, ", "))
+}
+
+// heuristicScore combines the weights of the signals text matches as
+// independent evidence: 1 - Π(1 - weight). Invisible format characters are
+// removed first so they cannot split a phrase.
+func heuristicScore(text string) (float64, []string) {
+	text = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		return r
+	}, text)
+	miss := 1.0
+	var signals []string
+	for _, s := range injectionSignals {
+		if s.pattern.MatchString(text) {
+			miss *= 1 - s.weight
+			signals = append(signals, s.name)
+		}
+	}
+	return 1 - miss, signals
+}
+
+// classify asks the classifier to score text. The classifier is sent
+// {"text": "..."} and answers {"score": <0..1>}.
+func (h injection) classify(ctx context.Context, text string) (float64, error) {
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.classifier, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("classifier status %d", resp.StatusCode)
+	}
+	var out struct {
+		Score *float64 `json:"score"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxClassifierResponseBytes)).Decode(&out); err != nil {
+		return 0, fmt.Errorf("decode classifier response: %w", err)
+	}
+	if out.Score == nil || *out.Score < 0 || *out.Score > 1 {
+		return 0, fmt.Errorf("classifier score must be between 0 and 1")
+	}
+	return *out.Score, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guardrails
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Check results.
+const (
+	resultPass      = "pass"
+	resultRejected  = "rejected"
+	resultFlagged   = "flagged"
+	resultAnnotated = "annotated"
+	resultRedacted  = "redacted"
+)
+
+// Metrics counts and times guardrail checks per hook and stage. A nil
+// *Metrics records nothing.
+type Metrics struct {
+	checks   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewMetrics registers the guardrail metrics on reg with the given constant
+// labels (typically agent and namespace).
+func NewMetrics(reg prometheus.Registerer, constLabels prometheus.Labels) *Metrics {
+	m := &Metrics{
+		checks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "omnia_runtime_guardrail_checks_total",
+			Help:        "Guardrail hook checks by hook, stage (input|output|tool_call) and result (pass|rejected|flagged|annotated|redacted).",
+			ConstLabels: constLabels,
+		}, []string{"hook", "stage", "result"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "omnia_runtime_guardrail_check_duration_seconds",
+			Help:        "Time spent in a guardrail hook check, by hook and stage.",
+			ConstLabels: constLabels,
+			Buckets:     []float64{.0001, .0005, .001, .005, .01, .05, .1},
+		}, []string{"hook", "stage"}),
+	}
+	reg.MustRegister(m.checks, m.duration)
+	return m
+}
+
+func (m *Metrics) record(hook string, stage Stage, passed bool, action Action, elapsed time.Duration) {
+	if m == nil {
+		return
+	}
+	result := resultPass
+	switch {
+	case passed:
+	case action == ActionFlag:
+		result = resultFlagged
+	case action == ActionAnnotate:
+		result = resultAnnotated
+	case action == ActionRedact:
+		result = resultRedacted
+	default:
+		result = resultRejected
+	}
+	m.checks.WithLabelValues(hook, string(stage), result).Inc()
+	m.duration.WithLabelValues(hook, string(stage)).Observe(elapsed.Seconds())
+}
@@ -0,0 +1,487 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guardrails
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/comprehend"
+	comprehendtypes "github.com/aws/aws-sdk-go-v2/service/comprehend/types"
+	"golang.org/x/sync/singleflight"
+)
+
+// Moderation providers.
+const (
+	ModerationOpenAI     = "openai"
+	ModerationComprehend = "comprehend"
+	ModerationHTTP       = "http"
+)
+
+const (
+	// defaultModerationThreshold is the category score moderation rejects at
+	// when the spec sets none.
+	defaultModerationThreshold = 0.5
+	// defaultOpenAIModerationURL is the OpenAI moderation endpoint.
+	defaultOpenAIModerationURL = "https://api.openai.com/v1/moderations"
+	// defaultOpenAIModerationModel is the OpenAI moderation model.
+	defaultOpenAIModerationModel = "omni-moderation-latest"
+	// moderationTimeout bounds a moderation request.
+	moderationTimeout = 5 * time.Second
+	// moderationCacheTTL is how long a text's scores are reused.
+	moderationCacheTTL = 5 * time.Minute
+	// moderationCacheSize bounds the texts whose scores a moderator keeps.
+	moderationCacheSize = 1024
+	// comprehendSegmentBytes and comprehendSegmentsPerCall are the limits of
+	// a Comprehend DetectToxicContent request.
+	comprehendSegmentBytes    = 1024
+	comprehendSegmentsPerCall = 10
+	// comprehendToxicity is the category of Comprehend's overall score.
+	comprehendToxicity = "TOXICITY"
+)
+
+// ModerationSpec configures the provider a moderation hook asks to score text.
+type ModerationSpec struct {
+	// Provider is ModerationOpenAI, ModerationComprehend or ModerationHTTP.
+	Provider string `json:"provider"`
+	// URL overrides the OpenAI endpoint or names the HTTP classifier.
+	URL string `json:"url,omitempty"`
+	// Model is the OpenAI moderation model.
+	Model string `json:"model,omitempty"`
+	// Region is the Comprehend AWS region.
+	Region string `json:"region,omitempty"`
+	// Categories maps the categories the hook rejects to their thresholds (0
+	// = the hook's). Empty rejects any category at the hook's threshold.
+	Categories map[string]float64 `json:"categories,omitempty"`
+	// FailClosed rejects text the provider cannot score.
+	FailClosed bool `json:"failClosed,omitempty"`
+	// APIKey is sent as a bearer token to OpenAI or the HTTP classifier. It
+	// comes from the AgentRuntime's secretRef, never from a PromptPack.
+	APIKey string `json:"-"`
+}
+
+// scorer scores texts by category, one map per text.
+type scorer interface {
+	score(ctx context.Context, texts []string) ([]map[string]float64, error)
+}
+
+// moderation rejects text a moderator scores at or above a category's
+// threshold.
+type moderation struct {
+	moderator  *moderator
+	threshold  float64
+	categories map[string]float64
+	failClosed bool
+}
+
+func newModeration(threshold float64, spec *ModerationSpec) (Hook, error) {
+	if spec == nil {
+		return nil, errors.New("moderation settings are missing")
+	}
+	if threshold < 0 || threshold > 1 {
+		return nil, fmt.Errorf("threshold %v must be between 0 and 1", threshold)
+	}
+	if threshold == 0 {
+		threshold = defaultModerationThreshold
+	}
+	for name, t := range spec.Categories {
+		if t < 0 || t > 1 {
+			return nil, fmt.Errorf("category %s threshold %v must be between 0 and 1", name, t)
+		}
+	}
+	m, err := sharedModerator(spec)
+	if err != nil {
+		return nil, err
+	}
+	return moderation{moderator: m, threshold: threshold, categories: spec.Categories, failClosed: spec.FailClosed}, nil
+}
+
+func (moderation) Name() string { return HookModeration }
+
+// Check names the categories at or above their thresholds, highest first.
+func (h moderation) Check(ctx context.Context, text string) string {
+	if strings.TrimSpace(text) == "" {
+		return ""
+	}
+	scores, err := h.moderator.scores(ctx, text)
+	if err != nil {
+		if h.failClosed {
+			return "moderation provider unavailable"
+		}
+		return ""
+	}
+	var hits []string
+	for _, name := range sortedByScore(scores) {
+		threshold := h.threshold
+		if len(h.categories) > 0 {
+			t, ok := h.categories[name]
+			if !ok {
+				continue
+			}
+			if t > 0 {
+				threshold = t
+			}
+		}
+		if scores[name] >= threshold {
+			hits = append(hits, fmt.Sprintf("%s %.2f", name, scores[name]))
+		}
+	}
+	if len(hits) == 0 {
+		return ""
+	}
+	return "moderation flagged " + strings.Join(hits, ", ")
+}
+
+// sortedByScore returns the categories of scores, highest score first.
+func sortedByScore(scores map[string]float64) []string {
+	names := make([]string, 0, len(scores))
+	for name := range scores {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		if scores[a] != scores[b] {
+			if scores[a] > scores[b] {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	return names
+}
+
+// moderators are shared by the hooks of every chain with the same provider
+// settings, so several hooks checking the same text call the provider once,
+// and the cache outlives a PromptPack reload.
+var (
+	moderatorsMu sync.Mutex
+	moderators   = map[string]*moderator{}
+)
+
+// sharedModerator returns the moderator for spec's provider settings,
+// creating it on first use.
+func sharedModerator(spec *ModerationSpec) (*moderator, error) {
+	key := strings.Join([]string{spec.Provider, spec.URL, spec.Model, spec.Region, contentSHA256(spec.APIKey)}, "\x00")
+	moderatorsMu.Lock()
+	defer moderatorsMu.Unlock()
+	if m, ok := moderators[key]; ok {
+		return m, nil
+	}
+	s, err := newScorer(spec)
+	if err != nil {
+		return nil, err
+	}
+	m := newModerator(s)
+	moderators[key] = m
+	return m, nil
+}
+
+// newScorer builds the scorer of spec's provider.
+func newScorer(spec *ModerationSpec) (scorer, error) {
+	client := &http.Client{Timeout: moderationTimeout}
+	switch spec.Provider {
+	case ModerationOpenAI:
+		if spec.APIKey == "" {
+			return nil, errors.New("the openai provider needs an API key")
+		}
+		endpoint := defaultOpenAIModerationURL
+		if spec.URL != "" {
+			endpoint = spec.URL
+		}
+		if err := checkHTTPURL(endpoint); err != nil {
+			return nil, err
+		}
+		model := spec.Model
+		if model == "" {
+			model = defaultOpenAIModerationModel
+		}
+		return openAIScorer{url: endpoint, model: model, apiKey: spec.APIKey, client: client}, nil
+	case ModerationComprehend:
+		opts := []func(*config.LoadOptions) error{}
+		if spec.Region != "" {
+			opts = append(opts, config.WithRegion(spec.Region))
+		}
+		awsCfg, err := config.LoadDefaultConfig(context.Background(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("load AWS config: %w", err)
+		}
+		return comprehendScorer{client: comprehend.NewFromConfig(awsCfg)}, nil
+	case ModerationHTTP:
+		if err := checkHTTPURL(spec.URL); err != nil {
+			return nil, err
+		}
+		return httpScorer{url: spec.URL, apiKey: spec.APIKey, client: client}, nil
+	default:
+		return nil, fmt.Errorf("unknown moderation provider %q", spec.Provider)
+	}
+}
+
+// checkHTTPURL reports whether raw is an absolute http(s) URL.
+func checkHTTPURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url %q must be an absolute http(s) URL", raw)
+	}
+	return nil
+}
+
+// moderator caches a scorer's answers by text and merges concurrent requests
+// for the same text.
+type moderator struct {
+	scorer scorer
+	group  singleflight.Group
+	now    func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedScores
+}
+
+// cachedScores are a text's scores until expires.
+type cachedScores struct {
+	scores  map[string]float64
+	expires time.Time
+}
+
+func newModerator(s scorer) *moderator {
+	return &moderator{scorer: s, now: time.Now, cache: make(map[string]cachedScores)}
+}
+
+// scores returns text's category scores, from the cache when they are recent.
+func (m *moderator) scores(ctx context.Context, text string) (map[string]float64, error) {
+	key := contentSHA256(text)
+	m.mu.Lock()
+	cached, ok := m.cache[key]
+	m.mu.Unlock()
+	if ok && m.now().Before(cached.expires) {
+		return cached.scores, nil
+	}
+	// The call is shared with concurrent checks of the same text, so one
+	// caller going away must not cancel it for the others.
+	v, err, _ := m.group.Do(key, func() (any, error) {
+		out, err := m.scorer.score(context.WithoutCancel(ctx), []string{text})
+		if err != nil {
+			return nil, err
+		}
+		if len(out) != 1 {
+			return nil, fmt.Errorf("moderation returned %d results for 1 text", len(out))
+		}
+		m.store(key, out[0])
+		return out[0], nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(map[string]float64), nil
+}
+
+// store caches scores under key, dropping expired entries, or an arbitrary
+// one, when the cache is full.
+func (m *moderator) store(key string, scores map[string]float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if len(m.cache) >= moderationCacheSize {
+		for k, c := range m.cache {
+			if !now.Before(c.expires) {
+				delete(m.cache, k)
+			}
+		}
+		for k := range m.cache {
+			if len(m.cache) < moderationCacheSize {
+				break
+			}
+			delete(m.cache, k)
+		}
+	}
+	m.cache[key] = cachedScores{scores: scores, expires: now.Add(moderationCacheTTL)}
+}
+
+// openAIScorer scores texts with the OpenAI moderation API, all in one call.
+type openAIScorer struct {
+	url    string
+	model  string
+	apiKey string
+	client *http.Client
+}
+
+func (s openAIScorer) score(ctx context.Context, texts []string) ([]map[string]float64, error) {
+	var out struct {
+		Results []struct {
+			CategoryScores map[string]float64 `json:"category_scores"`
+		} `json:"results"`
+	}
+	if err := postJSON(ctx, s.client, s.url, s.apiKey, map[string]any{"model": s.model, "input": texts}, &out); err != nil {
+		return nil, err
+	}
+	scores := make([]map[string]float64, len(out.Results))
+	for i, r := range out.Results {
+		scores[i] = r.CategoryScores
+	}
+	return scores, nil
+}
+
+// httpScorer scores texts with a custom classifier, one call per text. The
+// classifier is sent {"text": "..."} and answers {"categories": {"<name>":
+// <0..1>}}.
+type httpScorer struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+func (s httpScorer) score(ctx context.Context, texts []string) ([]map[string]float64, error) {
+	scores := make([]map[string]float64, len(texts))
+	for i, text := range texts {
+		var out struct {
+			Categories map[string]float64 `json:"categories"`
+		}
+		if err := postJSON(ctx, s.client, s.url, s.apiKey, map[string]string{"text": text}, &out); err != nil {
+			return nil, err
+		}
+		for name, score := range out.Categories {
+			if score < 0 || score > 1 {
+				return nil, fmt.Errorf("category %s score %v must be between 0 and 1", name, score)
+			}
+		}
+		scores[i] = out.Categories
+	}
+	return scores, nil
+}
+
+// postJSON posts body to url and decodes the answer into out.
+func postJSON(ctx context.Context, client *http.Client, url, apiKey string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("moderation status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxClassifierResponseBytes)).Decode(out); err != nil {
+		return fmt.Errorf("decode moderation response: %w", err)
+	}
+	return nil
+}
+
+// comprehendAPI is the part of the Comprehend client comprehendScorer uses.
+type comprehendAPI interface {
+	DetectToxicContent(ctx context.Context, in *comprehend.DetectToxicContentInput,
+		optFns ...func(*comprehend.Options)) (*comprehend.DetectToxicContentOutput, error)
+}
+
+// comprehendScorer scores texts with AWS Comprehend toxicity detection. A
+// text longer than a segment is split, its segments batched into as few
+// calls as Comprehend allows, and each category scored by its highest
+// segment.
+type comprehendScorer struct {
+	client comprehendAPI
+}
+
+func (s comprehendScorer) score(ctx context.Context, texts []string) ([]map[string]float64, error) {
+	var segments []string
+	var owners []int
+	for i, text := range texts {
+		for _, seg := range splitSegments(text, comprehendSegmentBytes) {
+			segments = append(segments, seg)
+			owners = append(owners, i)
+		}
+	}
+	scores := make([]map[string]float64, len(texts))
+	for i := range scores {
+		scores[i] = map[string]float64{}
+	}
+	for start := 0; start < len(segments); start += comprehendSegmentsPerCall {
+		batch := segments[start:min(start+comprehendSegmentsPerCall, len(segments))]
+		in := &comprehend.DetectToxicContentInput{LanguageCode: comprehendtypes.LanguageCodeEn}
+		for _, seg := range batch {
+			in.TextSegments = append(in.TextSegments, comprehendtypes.TextSegment{Text: aws.String(seg)})
+		}
+		out, err := s.client.DetectToxicContent(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for j, labels := range out.ResultList {
+			if j >= len(batch) {
+				break
+			}
+			owner := scores[owners[start+j]]
+			raise(owner, comprehendToxicity, labels.Toxicity)
+			for _, l := range labels.Labels {
+				raise(owner, string(l.Name), l.Score)
+			}
+		}
+	}
+	return scores, nil
+}
+
+// raise sets scores[name] to score when it is higher.
+func raise(scores map[string]float64, name string, score *float32) {
+	if score == nil {
+		return
+	}
+	if v := float64(*score); v > scores[name] {
+		scores[name] = v
+	}
+}
+
+// splitSegments splits text into pieces of at most limit bytes, on rune
+// boundaries and, where there is one in the second half of a piece, after
+// whitespace.
+func splitSegments(text string, limit int) []string {
+	var segments []string
+	for len(text) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		if ws := strings.LastIndexAny(text[:cut], " \n\t"); ws >= limit/2 {
+			cut = ws + 1
+		}
+		segments = append(segments, text[:cut])
+		text = text[cut:]
+	}
+	if strings.TrimSpace(text) != "" {
+		segments = append(segments, text)
+	}
+	return segments
+}
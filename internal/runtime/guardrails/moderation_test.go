@@ -0,0 +1,205 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guardrails
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/comprehend"
+	comprehendtypes "github.com/aws/aws-sdk-go-v2/service/comprehend/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModeration_OpenAI(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, "Bearer sk-test", r.Header.Get("Authorization"))
+		var body struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, defaultOpenAIModerationModel, body.Model)
+		scores := map[string]float64{"violence": 0.02, "harassment": 0.01}
+		if strings.Contains(body.Input[0], "hurt") {
+			scores = map[string]float64{"violence": 0.91, "harassment": 0.66, "hate": 0.1}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"results": []any{map[string]any{"category_scores": scores}}})
+	}))
+	defer srv.Close()
+	spec := &ModerationSpec{Provider: ModerationOpenAI, URL: srv.URL, APIKey: "sk-test"}
+	ctx := context.Background()
+
+	anyCategory, err := newHook(Spec{Name: HookModeration, Moderation: spec})
+	require.NoError(t, err)
+	assert.Empty(t, anyCategory.Check(ctx, "What is the weather?"))
+	assert.Equal(t, "moderation flagged violence 0.91, harassment 0.66", anyCategory.Check(ctx, "I will hurt you"))
+
+	violence, err := newHook(Spec{Name: HookModeration, Moderation: &ModerationSpec{
+		Provider: ModerationOpenAI, URL: srv.URL, APIKey: "sk-test",
+		Categories: map[string]float64{"violence": 0.95, "harassment": 0},
+	}, Threshold: 0.6})
+	require.NoError(t, err)
+	assert.Equal(t, "moderation flagged harassment 0.66", violence.Check(ctx, "I will hurt you"),
+		"a category uses its own threshold, or the hook's")
+	assert.Equal(t, int32(2), calls.Load(), "hooks with the same provider share its cached answers")
+	assert.Empty(t, violence.Check(ctx, "   "), "blank text is not sent")
+}
+
+func TestModeration_HTTP(t *testing.T) {
+	failing := atomic.Bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var body struct {
+			Text string `json:"text"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		score := 0.0
+		if strings.Contains(body.Text, "spam") {
+			score = 0.8
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"categories": map[string]float64{"spam": score}})
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	h, err := newHook(Spec{Name: HookModeration, Moderation: &ModerationSpec{Provider: ModerationHTTP, URL: srv.URL}})
+	require.NoError(t, err)
+	assert.Equal(t, "moderation flagged spam 0.80", h.Check(ctx, "buy spam now"))
+
+	failing.Store(true)
+	assert.Empty(t, h.Check(ctx, "new text"), "an unreachable provider lets text through")
+	closed, err := newHook(Spec{Name: HookModeration, Moderation: &ModerationSpec{
+		Provider: ModerationHTTP, URL: srv.URL, FailClosed: true,
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "moderation provider unavailable", closed.Check(ctx, "other text"))
+}
+
+type fakeComprehend struct {
+	calls    int
+	segments []int
+}
+
+func (f *fakeComprehend) DetectToxicContent(_ context.Context, in *comprehend.DetectToxicContentInput,
+	_ ...func(*comprehend.Options)) (*comprehend.DetectToxicContentOutput, error) {
+	f.calls++
+	f.segments = append(f.segments, len(in.TextSegments))
+	out := &comprehend.DetectToxicContentOutput{}
+	for _, seg := range in.TextSegments {
+		toxicity := float32(0.1)
+		labels := []comprehendtypes.ToxicContent{{Name: comprehendtypes.ToxicContentTypeInsult, Score: aws.Float32(0.05)}}
+		if strings.Contains(*seg.Text, "idiot") {
+			toxicity = 0.85
+			labels[0].Score = aws.Float32(0.9)
+		}
+		out.ResultList = append(out.ResultList, comprehendtypes.ToxicLabels{Toxicity: aws.Float32(toxicity), Labels: labels})
+	}
+	return out, nil
+}
+
+func TestComprehendScorer(t *testing.T) {
+	fake := &fakeComprehend{}
+	s := comprehendScorer{client: fake}
+	long := strings.Repeat("a perfectly polite sentence. ", 400) + "you idiot"
+
+	scores, err := s.score(context.Background(), []string{"hello", long})
+	require.NoError(t, err)
+	assert.Equal(t, 2, fake.calls, "segments are batched up to the per-call limit")
+	assert.Equal(t, comprehendSegmentsPerCall, fake.segments[0])
+	assert.InDelta(t, 0.1, scores[0][comprehendToxicity], 1e-6)
+	assert.InDelta(t, 0.85, scores[1][comprehendToxicity], 1e-6, "a text scores its most toxic segment")
+	assert.InDelta(t, 0.9, scores[1]["INSULT"], 1e-6)
+}
+
+func TestSplitSegments(t *testing.T) {
+	assert.Equal(t, []string{"short"}, splitSegments("short", 10))
+	assert.Empty(t, splitSegments("  ", 10))
+	assert.Equal(t, []string{"hello ", "world"}, splitSegments("hello world", 8), "splits after whitespace")
+	for _, seg := range splitSegments(strings.Repeat("é", 10), 5) {
+		assert.LessOrEqual(t, len(seg), 5)
+		assert.True(t, strings.HasPrefix(seg, "é"), "splits on rune boundaries")
+	}
+}
+
+type countingScorer struct{ calls atomic.Int32 }
+
+func (s *countingScorer) score(_ context.Context, texts []string) ([]map[string]float64, error) {
+	s.calls.Add(1)
+	if texts[0] == "fail" {
+		return nil, errors.New("boom")
+	}
+	return []map[string]float64{{"x": 0.1}}, nil
+}
+
+func TestModerator_Cache(t *testing.T) {
+	s := &countingScorer{}
+	m := newModerator(s)
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for range 3 {
+		_, err := m.scores(ctx, "same")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), s.calls.Load())
+	_, err := m.scores(ctx, "fail")
+	require.Error(t, err)
+	_, err = m.scores(ctx, "fail")
+	require.Error(t, err)
+	assert.Equal(t, int32(3), s.calls.Load(), "errors are not cached")
+
+	now = now.Add(moderationCacheTTL)
+	_, err = m.scores(ctx, "same")
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), s.calls.Load(), "scores expire")
+
+	for i := range moderationCacheSize + 10 {
+		m.store(strings.Repeat("k", i+1), nil)
+	}
+	assert.LessOrEqual(t, len(m.cache), moderationCacheSize)
+}
+
+func TestNewModeration_Errors(t *testing.T) {
+	for name, spec := range map[string]Spec{
+		"missing settings": {Name: HookModeration},
+		"openai key":       {Name: HookModeration, Moderation: &ModerationSpec{Provider: ModerationOpenAI}},
+		"http url":         {Name: HookModeration, Moderation: &ModerationSpec{Provider: ModerationHTTP, URL: "classifier:80"}},
+		"provider":         {Name: HookModeration, Moderation: &ModerationSpec{Provider: "perspective"}},
+		"threshold": {Name: HookModeration, Moderation: &ModerationSpec{
+			Provider: ModerationHTTP, URL: "http://classifier", Categories: map[string]float64{"x": 2},
+		}},
+	} {
+		_, err := newHook(spec)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guardrails
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// Stream checks a response with a chain while it is generated, so the
+// response can be sent without waiting for it to be complete.
+//
+// Text is held back until it is window characters behind the newest text.
+// Each time held text is released, the chain checks it together with the
+// last window characters already released, so a match up to window
+// characters long is found, and redacted, before any of it is sent. A
+// rejection ends the stream. Close checks the complete response once more
+// for hooks that judge the whole text, such as maxLength; by then it can
+// still reject the response but no longer redact it.
+//
+// Each finding is reported once per stream, although overlapping scans may
+// match it again; its ContentSHA256 is that of the scan that first matched.
+// A Stream is not safe for concurrent use.
+type Stream struct {
+	chain  *Chain
+	window int
+	// sent is the text released so far; held the text not yet released.
+	sent  strings.Builder
+	held  string
+	ended bool
+	// seen holds the findings reported, without their ContentSHA256, which
+	// differs between scans.
+	seen map[Finding]bool
+}
+
+// Stream returns a stream checking a response with c, holding back window
+// characters.
+func (c *Chain) Stream(window int) *Stream {
+	return &Stream{chain: c, window: window, seen: map[Finding]bool{}}
+}
+
+// Write adds generated text. It returns the text that may be sent now and
+// the result of the check: Rejection set when the stream was rejected, new
+// Findings, and Text holding all the text released so far.
+func (st *Stream) Write(ctx context.Context, text string) (string, *Result) {
+	if st.ended {
+		return "", &Result{Text: st.sent.String()}
+	}
+	st.held += text
+	if utf8.RuneCountInString(st.held) <= st.window {
+		return "", &Result{Text: st.sent.String()}
+	}
+	return st.release(ctx, st.window)
+}
+
+// Flush checks and releases all held text, for when the response must be
+// sent up to this point, as before a tool call.
+func (st *Stream) Flush(ctx context.Context) (string, *Result) {
+	if st.ended || st.held == "" {
+		return "", &Result{Text: st.sent.String()}
+	}
+	return st.release(ctx, 0)
+}
+
+// Close flushes the stream and checks the complete response. Text of the
+// result is the complete response as released.
+func (st *Stream) Close(ctx context.Context) (string, *Result) {
+	out, res := st.Flush(ctx)
+	if st.ended {
+		return out, res
+	}
+	st.ended = true
+	whole := st.chain.Evaluate(ctx, st.sent.String())
+	res.Findings = append(res.Findings, st.unseen(whole.Findings)...)
+	if whole.Rejection != nil {
+		res.Rejection = whole.Rejection
+		return "", res
+	}
+	return out, res
+}
+
+// release checks the held text with the tail already released and releases
+// all but its last keep characters.
+func (st *Stream) release(ctx context.Context, keep int) (string, *Result) {
+	tail := lastRunes(st.sent.String(), st.window)
+	res := st.chain.Evaluate(ctx, tail+st.held)
+	out := &Result{Rejection: res.Rejection, Findings: st.unseen(res.Findings)}
+	if res.Rejection != nil {
+		st.ended = true
+		out.Text = st.sent.String()
+		return "", out
+	}
+	// A redaction reaching back into the released tail starts the held
+	// text where the redacted scan first differs from what was sent.
+	held := res.Text[commonPrefix(res.Text, tail):]
+	cut := len(held) - len(lastRunes(held, keep))
+	released := held[:cut]
+	st.held = held[cut:]
+	st.sent.WriteString(released)
+	out.Text = st.sent.String()
+	return released, out
+}
+
+// unseen returns the findings not reported yet and marks them reported.
+func (st *Stream) unseen(findings []Finding) []Finding {
+	var fresh []Finding
+	for _, f := range findings {
+		key := f
+		key.ContentSHA256 = ""
+		if !st.seen[key] {
+			st.seen[key] = true
+			fresh = append(fresh, f)
+		}
+	}
+	return fresh
+}
+
+// lastRunes returns the last n characters of s.
+func lastRunes(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	i := len(s)
+	for ; n > 0 && i > 0; n-- {
+		_, size := utf8.DecodeLastRuneInString(s[:i])
+		i -= size
+	}
+	return s[i:]
+}
+
+// commonPrefix returns the length in bytes of the longest common prefix of a
+// and b that ends on a character boundary.
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) {
+		r, size := utf8.DecodeRuneInString(a[i:])
+		if r2, size2 := utf8.DecodeRuneInString(b[i:]); r != r2 || size != size2 {
+			break
+		}
+		i += size
+	}
+	return i
+}
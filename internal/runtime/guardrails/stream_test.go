@@ -0,0 +1,166 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guardrails
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeAll streams chunks through st and returns what was released and the
+// last result.
+func writeAll(t *testing.T, st *Stream, chunks ...string) (string, *Result) {
+	t.Helper()
+	var sent strings.Builder
+	var res *Result
+	for _, c := range chunks {
+		var out string
+		out, res = st.Write(context.Background(), c)
+		sent.WriteString(out)
+		if res.Rejection != nil {
+			break
+		}
+	}
+	return sent.String(), res
+}
+
+func TestStreamReleasesBehindTheWindow(t *testing.T) {
+	c, err := NewChain(StageOutput, []Spec{{Name: HookRegexBlocklist, Patterns: []string{"secret"}}}, nil)
+	require.NoError(t, err)
+	st := c.Stream(10)
+	ctx := context.Background()
+
+	out, res := st.Write(ctx, "Hello")
+	assert.Empty(t, out, "text within the window is held back")
+	assert.Nil(t, res.Rejection)
+
+	out, _ = st.Write(ctx, ", wonderful world")
+	assert.Equal(t, "Hello, wonde", out, "all but the last 10 characters are released")
+
+	out, res = st.Close(ctx)
+	assert.Equal(t, "rful world", out)
+	assert.Equal(t, "Hello, wonderful world", res.Text)
+}
+
+func TestStreamStopsOnRejection(t *testing.T) {
+	c, err := NewChain(StageOutput, []Spec{{Name: HookRegexBlocklist, Patterns: []string{`\d{3}-\d{2}-\d{4}`}}}, nil)
+	require.NoError(t, err)
+	st := c.Stream(16)
+
+	sent, res := writeAll(t, st, "Sure, here it is. ", "The SSN is 123-", "45-", "6789 and ", "more text follows")
+	require.NotNil(t, res.Rejection)
+	assert.Equal(t, HookRegexBlocklist, res.Rejection.Hook)
+	assert.NotContains(t, sent, "123", "no part of a match within the window is sent")
+
+	out, res := st.Write(context.Background(), "after")
+	assert.Empty(t, out, "a rejected stream releases nothing more")
+	assert.Nil(t, res.Rejection)
+	out, _ = st.Close(context.Background())
+	assert.Empty(t, out)
+}
+
+func TestStreamRedactsHeldText(t *testing.T) {
+	c, err := NewChain(StageOutput, []Spec{
+		{Name: HookRegexBlocklist, Patterns: []string{`\b\d{16}\b`}, Action: string(ActionRedact)},
+		{Name: HookRegexBlocklist, Patterns: []string{`confidential`}, Action: string(ActionFlag)},
+	}, nil)
+	require.NoError(t, err)
+	st := c.Stream(20)
+	ctx := context.Background()
+
+	sent, res := writeAll(t, st, "Card 4111", "111111111111 is ", "on file. It is confidential", " and should stay so.")
+	require.Nil(t, res.Rejection)
+	out, final := st.Close(ctx)
+	sent += out
+
+	assert.Equal(t, "Card [REDACTED] is on file. It is confidential and should stay so.", sent)
+	assert.Equal(t, sent, final.Text)
+	assert.NotContains(t, sent, "4111")
+
+	// Each finding is reported once, however many scans match it.
+	st = c.Stream(20)
+	var findings []Finding
+	for _, chunk := range []string{"Card 4111111111111111 ", "is on file and ", "is confidential. ", "More words here."} {
+		_, r := st.Write(ctx, chunk)
+		findings = append(findings, r.Findings...)
+	}
+	_, r := st.Close(ctx)
+	findings = append(findings, r.Findings...)
+	assert.Len(t, findings, 2)
+}
+
+func TestStreamCloseChecksTheWholeResponse(t *testing.T) {
+	c, err := NewChain(StageOutput, []Spec{{Name: HookMaxLength, MaxChars: 30}}, nil)
+	require.NoError(t, err)
+	st := c.Stream(5)
+	ctx := context.Background()
+
+	sent, res := writeAll(t, st, "Ten chars ", "and ten.. ", "and more.. ", "and more.")
+	require.Nil(t, res.Rejection, "no window is over the limit")
+	assert.NotEmpty(t, sent)
+
+	out, res := st.Close(ctx)
+	require.NotNil(t, res.Rejection)
+	assert.Equal(t, HookMaxLength, res.Rejection.Hook)
+	assert.Empty(t, out, "the held text of a rejected response is not released")
+}
+
+func TestStreamFlush(t *testing.T) {
+	c, err := NewChain(StageOutput, []Spec{{Name: HookRegexBlocklist, Patterns: []string{"secret"}}}, nil)
+	require.NoError(t, err)
+	st := c.Stream(50)
+	ctx := context.Background()
+
+	out, _ := st.Write(ctx, "Let me look that up.")
+	assert.Empty(t, out)
+	out, _ = st.Flush(ctx)
+	assert.Equal(t, "Let me look that up.", out, "flush releases all held text")
+
+	_, _ = st.Write(ctx, " The secret is out.")
+	out, res := st.Flush(ctx)
+	assert.Empty(t, out)
+	require.NotNil(t, res.Rejection)
+}
+
+func TestRedactAction(t *testing.T) {
+	c, err := NewChain(StageInput, []Spec{
+		{Name: HookRegexBlocklist, Patterns: []string{`(?i)password:\s*\S+`}, Action: string(ActionRedact)},
+		{Name: HookRegexBlocklist, Patterns: []string{`hunter2`}},
+	}, nil)
+	require.NoError(t, err)
+
+	res := c.Evaluate(context.Background(), "Password: hunter2 please")
+	assert.Nil(t, res.Rejection, "later hooks check the redacted text")
+	assert.Equal(t, "[REDACTED] please", res.Text)
+	assert.True(t, res.Redacted())
+	assert.False(t, c.Evaluate(context.Background(), "hello").Redacted())
+
+	_, err = NewChain(StageOutput, []Spec{{Name: HookMaxLength, MaxChars: 5, Action: string(ActionRedact)}}, nil)
+	assert.ErrorContains(t, err, "action redact needs a hook that locates its matches")
+}
+
+func TestLastRunesAndCommonPrefix(t *testing.T) {
+	assert.Equal(t, "llo", lastRunes("hello", 3))
+	assert.Equal(t, "héé", lastRunes("héé", 5))
+	assert.Equal(t, "", lastRunes("héé", 0))
+	assert.Equal(t, 4, commonPrefix("héllo", "hélp"))
+	assert.Equal(t, 1, commonPrefix("hé", "hè"), "a differing character is not split")
+}
@@ -43,6 +43,13 @@ import (
 // Client-side tools are not supported in function mode — there's no
 // WebSocket peer to fulfil them. If the model emits a client tool call,
 // Invoke returns codes.FailedPrecondition.
+//
+// Input guardrails check input_json (codes.InvalidArgument on rejection) and
+// output guardrails the response (codes.FailedPrecondition); annotating
+// guardrails only flag, so input_json stays valid JSON, while redacting ones
+// redact both. An invocation
+// over the agent budget, or whose own calls exceed the session budget, fails
+// with codes.ResourceExhausted.
 func (s *Server) Invoke(ctx context.Context, req *runtimev1.InvocationRequest) (*runtimev1.InvocationResponse, error) {
 	invocationID := req.GetInvocationId()
 	if invocationID == "" {
@@ -62,6 +69,20 @@ func (s *Server) Invoke(ctx context.Context, req *runtimev1.InvocationRequest) (
 		"invocationID", invocationID,
 		"inputBytes", len(req.GetInputJson()))
 
+	screened, err := s.checkGuardrails(ctx, s.inputGuardrails(), nil, "", req.GetInputJson())
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if s.budget != nil {
+		if exceeded := s.budget.Check(ctx, invocationID); exceeded != nil {
+			s.budget.Metrics().RecordExceeded(exceeded.Scope, budgetActionReject)
+			tracing.RecordError(span, exceeded)
+			return nil, status.Error(codes.ResourceExhausted, exceeded.Error())
+		}
+	}
+
 	conv, err := s.openInvocationConversation(ctx, invocationID)
 	if err != nil {
 		tracing.RecordError(span, err)
@@ -70,7 +91,7 @@ func (s *Server) Invoke(ctx context.Context, req *runtimev1.InvocationRequest) (
 	defer s.closeInvocationConversation(invocationID, conv, log)
 
 	start := time.Now()
-	response, content, err := s.runInvocation(ctx, conv, req.GetInputJson(), log)
+	response, content, err := s.runInvocation(ctx, conv, screened.Text, log)
 	// durationMs is captured for both success and failure paths but only
 	// surfaced on the success-side gRPC response; logged on error.
 	durationMs := int32(time.Since(start).Milliseconds())
@@ -80,6 +101,13 @@ func (s *Server) Invoke(ctx context.Context, req *runtimev1.InvocationRequest) (
 		log.Error(err, "invoke failed", "invocationID", invocationID, "durationMs", durationMs)
 		return nil, err
 	}
+	checked, err := s.checkGuardrails(ctx, s.outputGuardrails(), nil, "", content)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.V(1).Info("invoke output rejected by guardrail", "invocationID", invocationID, "error", err.Error())
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	content = checked.Text
 
 	usage := buildInvocationUsage(response)
 	tracing.SetSuccess(span)
@@ -107,7 +135,8 @@ func (s *Server) Invoke(ctx context.Context, req *runtimev1.InvocationRequest) (
 func (s *Server) openInvocationConversation(ctx context.Context, invocationID string) (*sdk.Conversation, error) {
 	s.conversationMu.Lock()
 	defer s.conversationMu.Unlock()
-	return s.createConversation(ctx, invocationID)
+	conv, _, err := s.createConversation(ctx, invocationID)
+	return conv, err
 }
 
 // closeInvocationConversation runs the cleanup that removeConversation
@@ -122,6 +151,9 @@ func (s *Server) closeInvocationConversation(invocationID string, conv *sdk.Conv
 	}
 	delete(s.unsubscribeFns, invocationID)
 	s.conversationMu.Unlock()
+	if s.budget != nil {
+		s.budget.Forget(invocationID)
+	}
 
 	if err := conv.Close(); err != nil {
 		log.Error(err, "failed to close ephemeral conversation",
@@ -187,6 +219,9 @@ func consumeInvocationStream(streamCh <-chan sdk.StreamChunk, llmSpan trace.Span
 			if llmSpan != nil {
 				tracing.RecordError(llmSpan, chunk.Error)
 			}
+			if exceeded := deniedBudget(chunk.Error); exceeded != nil {
+				return nil, "", status.Error(codes.ResourceExhausted, exceeded.Error())
+			}
 			return nil, "", status.Errorf(codes.Internal,
 				"provider stream failed: %v", chunk.Error)
 		}
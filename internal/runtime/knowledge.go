@@ -0,0 +1,215 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/PromptKit/runtime/variables"
+	"github.com/AltairaLabs/PromptKit/sdk"
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/internal/runtime/vectorstore"
+)
+
+// knowledgeVariable is the template variable the retrieved chunks render
+// into. InitializeKnowledge appends it to the system template of a prompt
+// that does not place it itself.
+const knowledgeVariable = "knowledge_context"
+
+// eventKnowledgeRetrieved is recorded in the session for every turn that
+// retrieved knowledge, listing the chunks the prompt cited.
+const eventKnowledgeRetrieved events.EventType = "knowledge.retrieved"
+
+// knowledgeHeader introduces the retrieved chunks in the system prompt.
+const knowledgeHeader = "Relevant knowledge retrieved for this turn. Use it when it answers the question, " +
+	"and cite the sources you use by their [number]:"
+
+// metadataKeySource is the document metadata entry cited in place of the
+// document ID.
+const metadataKeySource = "source"
+
+// knowledgeContextKey carries a turn's formatted knowledge on the send context.
+type knowledgeContextKey struct{}
+
+// Citation identifies a retrieved chunk injected into the prompt.
+type Citation struct {
+	// Number is the chunk's [n] label in the prompt.
+	Number int `json:"number"`
+	// ID is the document ID in the vector store.
+	ID string `json:"id"`
+	// Source is the document's "source" metadata, or its ID.
+	Source string `json:"source"`
+	// Score is the cosine similarity between the chunk and the user message.
+	Score float64 `json:"score"`
+}
+
+// knowledgeVariables renders the knowledge retrieved for the current turn
+// into the prompt. It always provides the variable, empty when nothing was
+// retrieved, so the placeholder never renders literally.
+type knowledgeVariables struct{}
+
+// Name implements variables.Provider.
+func (knowledgeVariables) Name() string { return "knowledge" }
+
+// Provide implements variables.Provider.
+func (knowledgeVariables) Provide(ctx context.Context) (map[string]string, error) {
+	text, _ := ctx.Value(knowledgeContextKey{}).(string)
+	return map[string]string{knowledgeVariable: text}, nil
+}
+
+// Compile-time interface check.
+var _ variables.Provider = knowledgeVariables{}
+
+// InitializeKnowledge prepares retrieval when a vector store is configured:
+// it checks that an embedding-role provider can embed user messages, and
+// stages a copy of the pack whose active prompt renders {{knowledge_context}}
+// at the end of its system template unless the prompt already places it.
+// Call it after InitializeStructuredOutput, which may also stage the pack.
+func (s *Server) InitializeKnowledge() error {
+	if s.knowledgeStore == nil {
+		return nil
+	}
+	ep, err := s.embeddingRoleProvider(context.Background())
+	if err != nil {
+		return fmt.Errorf("knowledge embedding provider: %w", err)
+	}
+	if ep == nil {
+		return fmt.Errorf("knowledge retrieval needs an embedding provider: " +
+			"add an embedding-role provider to spec.providers")
+	}
+
+	data, err := os.ReadFile(s.packPath)
+	if err != nil {
+		return fmt.Errorf("read pack: %w", err)
+	}
+	staged, changed, err := addKnowledgePlaceholder(data, s.promptName)
+	if err != nil {
+		return err
+	}
+	if changed {
+		// Staged on the same writable mount as the tool-surfaced pack; see
+		// surfaceRegistryToolsInPack.
+		outPath := filepath.Join(packCacheDir(), "omnia-pack-knowledge.promptpack")
+		if err := os.WriteFile(outPath, staged, 0o600); err != nil {
+			return fmt.Errorf("stage pack: %w", err)
+		}
+		s.packPath = outPath
+	}
+	s.log.Info("knowledge retrieval enabled",
+		"prompt", s.promptName, "topK", s.knowledgeTopK, "minScore", s.knowledgeMinScore, "packPath", s.packPath)
+	return nil
+}
+
+// addKnowledgePlaceholder appends the knowledge placeholder to promptName's
+// system template, reporting whether the template needed it.
+func addKnowledgePlaceholder(data []byte, promptName string) (staged []byte, changed bool, err error) {
+	return rewriteSystemTemplate(data, promptName, func(template string) (string, bool) {
+		if strings.Contains(template, "{{"+knowledgeVariable+"}}") {
+			return template, false
+		}
+		return template + "\n\n{{" + knowledgeVariable + "}}", true
+	})
+}
+
+// retrieveKnowledge embeds the user message, queries the vector store, and
+// returns a context carrying the chunks that scored at least the minimum
+// score, for knowledgeVariables to render into the prompt. The citations are
+// recorded in the session as a knowledge.retrieved event. Retrieval is
+// fail-open: an embedding or vector store error is logged and the turn is
+// answered without knowledge.
+func (s *Server) retrieveKnowledge(ctx context.Context, conv *sdk.Conversation, sessionID, content string, progress *progressReporter, log logr.Logger) context.Context {
+	if s.knowledgeStore == nil || strings.TrimSpace(content) == "" {
+		return ctx
+	}
+	endStep := progress.step(progressStageRetrieval)
+	retrieved := false
+	defer func() { endStep(retrieved) }()
+	ep, err := s.embeddingRoleProvider(ctx)
+	if err != nil || ep == nil {
+		log.Error(err, "knowledge retrieval skipped: no embedding provider")
+		return ctx
+	}
+	resp, err := ep.Embed(ctx, providers.EmbeddingRequest{Texts: []string{content}})
+	if err != nil || len(resp.Embeddings) != 1 {
+		log.Error(err, "knowledge retrieval skipped: embedding the message failed")
+		return ctx
+	}
+	matches, err := s.knowledgeStore.Query(ctx, resp.Embeddings[0], s.knowledgeTopK)
+	if err != nil {
+		log.Error(err, "knowledge retrieval skipped: vector store query failed")
+		return ctx
+	}
+	retrieved = true
+
+	text, citations := formatKnowledge(matches, s.knowledgeMinScore)
+	log.V(1).Info("knowledge retrieved", "matches", len(matches), "used", len(citations))
+	if len(citations) == 0 {
+		return ctx
+	}
+	s.publishKnowledgeRetrieved(conv, sessionID, citations)
+	return context.WithValue(ctx, knowledgeContextKey{}, text)
+}
+
+// formatKnowledge numbers the matches scoring at least minScore for the
+// prompt and returns their citations.
+func formatKnowledge(matches []vectorstore.Match, minScore float64) (string, []Citation) {
+	var b strings.Builder
+	var citations []Citation
+	for _, m := range matches {
+		if m.Score < minScore {
+			continue
+		}
+		source := m.Metadata[metadataKeySource]
+		if source == "" {
+			source = m.ID
+		}
+		c := Citation{Number: len(citations) + 1, ID: m.ID, Source: source, Score: m.Score}
+		citations = append(citations, c)
+		fmt.Fprintf(&b, "\n\n[%d] (source: %s)\n%s", c.Number, c.Source, strings.TrimSpace(m.Content))
+	}
+	if len(citations) == 0 {
+		return "", nil
+	}
+	return knowledgeHeader + b.String(), citations
+}
+
+// publishKnowledgeRetrieved records a turn's citations in the session.
+func (s *Server) publishKnowledgeRetrieved(conv *sdk.Conversation, sessionID string, citations []Citation) {
+	bus := conv.EventBus()
+	if bus == nil {
+		return
+	}
+	bus.Publish(&events.Event{
+		Type:           eventKnowledgeRetrieved,
+		Timestamp:      time.Now(),
+		SessionID:      sessionID,
+		ConversationID: conv.ID(),
+		Data: &events.CustomEventData{
+			EventName: string(eventKnowledgeRetrieved),
+			Data:      map[string]any{"citations": citations},
+			Message:   fmt.Sprintf("%d knowledge chunk(s) retrieved", len(citations)),
+		},
+	})
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/PromptKit/runtime/statestore"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/runtime/vectorstore"
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+)
+
+const knowledgePack = `{
+	"id": "test-pack",
+	"name": "test-pack",
+	"version": "1.0.0",
+	"template_engine": { "version": "v1", "syntax": "{{variable}}" },
+	"prompts": {
+		"default": {
+			"id": "default",
+			"name": "default",
+			"version": "1.0.0",
+			"system_template": "You are a support assistant."
+		}
+	}
+}`
+
+// fixedEmbedder embeds every text to the same vector.
+type fixedEmbedder struct{ err error }
+
+func (f fixedEmbedder) Embed(_ context.Context, req providers.EmbeddingRequest) (providers.EmbeddingResponse, error) {
+	if f.err != nil {
+		return providers.EmbeddingResponse{}, f.err
+	}
+	out := make([][]float32, len(req.Texts))
+	for i := range req.Texts {
+		out[i] = []float32{1, 0, 0}
+	}
+	return providers.EmbeddingResponse{Embeddings: out}, nil
+}
+
+func (fixedEmbedder) EmbeddingDimensions() int { return 3 }
+func (fixedEmbedder) MaxBatchSize() int        { return 16 }
+func (fixedEmbedder) ID() string               { return "fixed" }
+
+// fakeVectorStore returns canned matches for every query.
+type fakeVectorStore struct {
+	matches []vectorstore.Match
+	err     error
+	topK    int
+}
+
+func (f *fakeVectorStore) Upsert(context.Context, []vectorstore.Document) error { return nil }
+func (f *fakeVectorStore) Delete(context.Context, []string) error               { return nil }
+func (f *fakeVectorStore) Close() error                                         { return nil }
+
+func (f *fakeVectorStore) Query(_ context.Context, _ []float32, topK int) ([]vectorstore.Match, error) {
+	f.topK = topK
+	return f.matches, f.err
+}
+
+func TestAddKnowledgePlaceholder(t *testing.T) {
+	staged, changed, err := addKnowledgePlaceholder([]byte(knowledgePack), "default")
+	require.NoError(t, err)
+	require.True(t, changed)
+	var pack struct {
+		Prompts map[string]struct {
+			SystemTemplate string `json:"system_template"`
+		} `json:"prompts"`
+	}
+	require.NoError(t, json.Unmarshal(staged, &pack))
+	assert.Equal(t, "You are a support assistant.\n\n{{knowledge_context}}", pack.Prompts["default"].SystemTemplate)
+
+	_, changed, err = addKnowledgePlaceholder(staged, "default")
+	require.NoError(t, err)
+	assert.False(t, changed, "a prompt that places the variable itself is left alone")
+
+	_, _, err = addKnowledgePlaceholder([]byte(knowledgePack), "missing")
+	assert.ErrorContains(t, err, `prompt "missing" not found`)
+}
+
+func TestFormatKnowledge(t *testing.T) {
+	text, citations := formatKnowledge([]vectorstore.Match{
+		{Document: vectorstore.Document{ID: "refunds#1", Content: "Refunds take 3 days.\n",
+			Metadata: map[string]string{"source": "refunds.md"}}, Score: 0.91},
+		{Document: vectorstore.Document{ID: "shipping#1", Content: "Shipping is free."}, Score: 0.42},
+		{Document: vectorstore.Document{ID: "returns#1", Content: "Returns within 30 days."}, Score: 0.8},
+	}, 0.5)
+	assert.Equal(t, []Citation{
+		{Number: 1, ID: "refunds#1", Source: "refunds.md", Score: 0.91},
+		{Number: 2, ID: "returns#1", Source: "returns#1", Score: 0.8},
+	}, citations)
+	assert.Equal(t, knowledgeHeader+
+		"\n\n[1] (source: refunds.md)\nRefunds take 3 days."+
+		"\n\n[2] (source: returns#1)\nReturns within 30 days.", text)
+
+	text, citations = formatKnowledge(nil, 0)
+	assert.Empty(t, text)
+	assert.Empty(t, citations)
+}
+
+func newKnowledgeServer(t *testing.T, store vectorstore.VectorStore, embedder providers.EmbeddingProvider) *Server {
+	t.Helper()
+	t.Setenv("OMNIA_PACK_CACHE_DIR", t.TempDir())
+	packPath := filepath.Join(t.TempDir(), "pack.promptpack")
+	require.NoError(t, writeTestFile(t, packPath, knowledgePack))
+
+	server := NewServer(
+		WithLogger(logr.Discard()),
+		WithPackPath(packPath),
+		WithPromptName("default"),
+		WithMockProvider(true),
+		WithStateStore(statestore.NewMemoryStore()),
+		WithKnowledge(store, 3, 0.5),
+	)
+	server.embeddingProvider = embedder
+	require.NoError(t, server.InitializeKnowledge())
+	t.Cleanup(func() { _ = server.Close() })
+	return server
+}
+
+// eventRecorder collects the events of the given types published on conv.
+func eventRecorder(t *testing.T, server *Server, sessionID string, types ...events.EventType) func() []*events.Event {
+	t.Helper()
+	conv, err := server.getOrCreateConversation(context.Background(), sessionID)
+	require.NoError(t, err)
+	var mu sync.Mutex
+	var got []*events.Event
+	for _, et := range types {
+		unsub := conv.EventBus().Subscribe(et, func(e *events.Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, e)
+		})
+		t.Cleanup(unsub)
+	}
+	return func() []*events.Event {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]*events.Event(nil), got...)
+	}
+}
+
+func TestConverse_Knowledge(t *testing.T) {
+	store := &fakeVectorStore{matches: []vectorstore.Match{
+		{Document: vectorstore.Document{ID: "refunds#1", Content: "Refunds take 3 days.",
+			Metadata: map[string]string{"source": "refunds.md"}}, Score: 0.9},
+		{Document: vectorstore.Document{ID: "shipping#1", Content: "Shipping is free."}, Score: 0.1},
+	}}
+	server := newKnowledgeServer(t, store, fixedEmbedder{})
+	recorded := eventRecorder(t, server, "sess-1", events.EventTemplateRendered, eventKnowledgeRetrieved)
+
+	require.Len(t, converse(t, server, &runtimev1.ClientMessage{SessionId: "sess-1", Content: "How long do refunds take?"}), 1)
+	assert.Equal(t, 3, store.topK)
+
+	var systemPrompt string
+	var citations []Citation
+	require.Eventually(t, func() bool {
+		for _, e := range recorded() {
+			switch data := e.Data.(type) {
+			case *events.TemplateRenderedData:
+				systemPrompt = data.SystemPrompt
+			case *events.CustomEventData:
+				citations, _ = data.Data["citations"].([]Citation)
+			}
+		}
+		return systemPrompt != "" && citations != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Contains(t, systemPrompt, "You are a support assistant.")
+	assert.Contains(t, systemPrompt, "[1] (source: refunds.md)\nRefunds take 3 days.")
+	assert.NotContains(t, systemPrompt, "Shipping is free.", "below the minimum score")
+	assert.Equal(t, []Citation{{Number: 1, ID: "refunds#1", Source: "refunds.md", Score: 0.9}}, citations)
+}
+
+func TestConverse_KnowledgeFailOpen(t *testing.T) {
+	server := newKnowledgeServer(t, &fakeVectorStore{err: errors.New("store down")}, fixedEmbedder{})
+	recorded := eventRecorder(t, server, "sess-1", events.EventTemplateRendered)
+
+	require.Len(t, converse(t, server, &runtimev1.ClientMessage{SessionId: "sess-1", Content: "hello"}), 1,
+		"a vector store outage does not fail the turn")
+	require.Eventually(t, func() bool { return len(recorded()) > 0 }, 5*time.Second, 10*time.Millisecond)
+	data := recorded()[0].Data.(*events.TemplateRenderedData)
+	assert.NotContains(t, data.SystemPrompt, "{{knowledge_context}}", "the placeholder renders empty")
+	assert.NotContains(t, data.SystemPrompt, knowledgeHeader)
+}
+
+func TestInitializeKnowledge_NeedsEmbeddingProvider(t *testing.T) {
+	packPath := filepath.Join(t.TempDir(), "pack.promptpack")
+	require.NoError(t, writeTestFile(t, packPath, knowledgePack))
+	server := NewServer(
+		WithLogger(logr.Discard()),
+		WithPackPath(packPath),
+		WithPromptName("default"),
+		WithKnowledge(&fakeVectorStore{}, 3, 0),
+	)
+	assert.ErrorContains(t, server.InitializeKnowledge(), "add an embedding-role provider")
+
+	server = NewServer(WithLogger(logr.Discard()), WithPackPath(packPath), WithPromptName("default"))
+	require.NoError(t, server.InitializeKnowledge(), "a no-op without a vector store")
+	assert.Equal(t, packPath, server.packPath)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/AltairaLabs/PromptKit/runtime/variables"
+)
+
+// MetadataKeyLocale is the message metadata key carrying the user's BCP 47
+// locale, which the facade takes from the connect handshake. It is also the
+// name of the prompt variable the locale renders into.
+const MetadataKeyLocale = "locale"
+
+// localeContextKey carries a turn's locale on the send context.
+type localeContextKey struct{}
+
+// withLocale returns a context carrying the locale from a message's
+// metadata, for localeVariables to render into the prompt.
+func withLocale(ctx context.Context, metadata map[string]string) context.Context {
+	if locale := metadata[MetadataKeyLocale]; locale != "" {
+		return context.WithValue(ctx, localeContextKey{}, locale)
+	}
+	return ctx
+}
+
+// localeVariables renders the user's locale into the prompt as {{locale}}.
+// A turn without a locale provides nothing, so the prompt's own default for
+// the variable applies.
+type localeVariables struct{}
+
+// Name implements variables.Provider.
+func (localeVariables) Name() string { return "locale" }
+
+// Provide implements variables.Provider.
+func (localeVariables) Provide(ctx context.Context) (map[string]string, error) {
+	locale, _ := ctx.Value(localeContextKey{}).(string)
+	if locale == "" {
+		return nil, nil
+	}
+	return map[string]string{MetadataKeyLocale: locale}, nil
+}
+
+// Compile-time interface check.
+var _ variables.Provider = localeVariables{}
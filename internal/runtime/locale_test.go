@@ -0,0 +1,114 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/runtime/statestore"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/runtime/responsecache"
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+)
+
+const localePack = `{
+	"id": "test-pack",
+	"name": "test-pack",
+	"version": "1.0.0",
+	"template_engine": { "version": "v1", "syntax": "{{variable}}" },
+	"prompts": {
+		"default": {
+			"id": "default",
+			"name": "default",
+			"version": "1.0.0",
+			"system_template": "You are a support assistant. Reply in the language of locale {{locale}}.",
+			"variables": [
+				{"name": "locale", "type": "string", "required": false, "default": "en"}
+			]
+		}
+	}
+}`
+
+func TestConverse_Locale(t *testing.T) {
+	packPath := filepath.Join(t.TempDir(), "pack.promptpack")
+	require.NoError(t, writeTestFile(t, packPath, localePack))
+	server := NewServer(
+		WithLogger(logr.Discard()),
+		WithPackPath(packPath),
+		WithPromptName("default"),
+		WithMockProvider(true),
+		WithStateStore(statestore.NewMemoryStore()),
+	)
+	t.Cleanup(func() { _ = server.Close() })
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		want     string
+	}{
+		{"locale from the handshake", map[string]string{MetadataKeyLocale: "es-MX"}, "locale es-MX."},
+		{"prompt default without one", nil, "locale en."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionID := "sess-" + tt.name
+			recorded := eventRecorder(t, server, sessionID, events.EventTemplateRendered)
+			require.Len(t, converse(t, server,
+				&runtimev1.ClientMessage{SessionId: sessionID, Content: "hola", Metadata: tt.metadata}), 1)
+
+			require.Eventually(t, func() bool { return len(recorded()) > 0 }, 5*time.Second, 10*time.Millisecond)
+			data := recorded()[0].Data.(*events.TemplateRenderedData)
+			assert.Contains(t, data.SystemPrompt, tt.want)
+		})
+	}
+}
+
+// A response cached for one locale is not served to a user in another.
+func TestConverse_LocaleResponseCache(t *testing.T) {
+	packPath := filepath.Join(t.TempDir(), "pack.promptpack")
+	require.NoError(t, writeTestFile(t, packPath, localePack))
+	reg := prometheus.NewRegistry()
+	server := NewServer(
+		WithLogger(logr.Discard()),
+		WithPackPath(packPath),
+		WithPromptName("default"),
+		WithMockProvider(true),
+		WithStateStore(statestore.NewMemoryStore()),
+		WithResponseCache(responsecache.NewMemoryBackend(0), responsecache.Config{
+			Metrics: responsecache.NewMetrics(reg, nil),
+		}),
+	)
+	t.Cleanup(func() { _ = server.Close() })
+	ask := func(sessionID, locale string) {
+		require.Len(t, converse(t, server, &runtimev1.ClientMessage{
+			SessionId: sessionID, Content: "hola", Metadata: map[string]string{MetadataKeyLocale: locale},
+		}), 1)
+	}
+
+	ask("sess-1", "es-MX")
+	ask("sess-2", "pt-BR")
+	assert.InDelta(t, 2, cacheLookups(t, reg, "miss"), 0, "another locale renders another prompt")
+	ask("sess-3", "es-MX")
+	assert.InDelta(t, 1, cacheLookups(t, reg, "exact_hit"), 0)
+}
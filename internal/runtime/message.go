@@ -24,12 +24,15 @@ import (
 	"sync/atomic"
 
 	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/PromptKit/runtime/types"
 	"github.com/AltairaLabs/PromptKit/sdk"
 	"github.com/go-logr/logr"
 	"go.opentelemetry.io/otel/trace"
 
 	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
 
+	"github.com/altairalabs/omnia/internal/runtime/guardrails"
+	"github.com/altairalabs/omnia/internal/runtime/responsecache"
 	"github.com/altairalabs/omnia/internal/tracing"
 	"github.com/altairalabs/omnia/pkg/logctx"
 )
@@ -76,9 +79,53 @@ func (s *Server) processMessage(ctx context.Context, stream runtimev1.RuntimeSer
 		tracing.RecordError(span, err)
 		return err
 	}
+	ctx = withSessionConversation(ctx, conv, sessionID)
+	ctx = withLocale(ctx, metadata)
 
-	// Prepare message content with scenario if needed
-	messageContent := s.prepareMessageContent(content, scenario, log)
+	// Check the user's message with the input guardrails
+	screened, err := s.checkGuardrails(ctx, s.inputGuardrails(), conv, sessionID, content)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	content = screened.Text
+
+	// Reject the turn, or summarize the session, when over budget
+	if err := s.enforceBudget(ctx, conv, sessionID, log); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	// Prepare message content with scenario if needed, marked as untrusted
+	// when an annotating guardrail flagged it
+	messageContent := screened.Annotate(s.prepareMessageContent(content, scenario, log))
+
+	// Report the turn's progress when the facade asked for it
+	progress := s.startProgress(stream, conv.EventBus(), msg.GetProgress())
+	defer progress.stop()
+	stream = progress.wrap(stream)
+
+	// Ground the turn in knowledge retrieved for the user's message
+	ctx = s.retrieveKnowledge(ctx, conv, sessionID, content, progress, log)
+
+	// Answer text-only turns from the response cache when it holds a response
+	var cacheQuery *responsecache.Query
+	if len(msg.GetParts()) == 0 {
+		var served bool
+		served, cacheQuery, err = s.serveFromResponseCache(ctx, stream, conv, sessionID, messageContent, log)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return err
+		}
+		if served {
+			tracing.SetSuccess(span)
+			return nil
+		}
+	}
+
+	// Check the response as it streams when the output guardrails allow it
+	guard := s.newOutputGuard(ctx, conv, sessionID)
+	stream = guard.wrap(stream)
 
 	// Build send options for multimodal content (images, audio, etc.)
 	sendOpts := buildSendOptions(msg.GetParts(), log)
@@ -86,6 +133,7 @@ func (s *Server) processMessage(ctx context.Context, stream runtimev1.RuntimeSer
 	// Stream response and collect results
 	finalResponse, accumulatedContent, pendingTools, err := s.streamResponse(ctx, stream, conv, messageContent, sendOpts)
 	if err != nil {
+		err = s.budgetDenied(conv, sessionID, err)
 		tracing.RecordError(span, err)
 		return err
 	}
@@ -94,17 +142,61 @@ func (s *Server) processMessage(ctx context.Context, stream runtimev1.RuntimeSer
 	if len(pendingTools) > 0 {
 		finalResponse, accumulatedContent, err = s.processClientTools(ctx, stream, conv, pendingTools, log)
 		if err != nil {
+			err = s.budgetDenied(conv, sessionID, err)
+			tracing.RecordError(span, err)
+			return err
+		}
+	}
+
+	if so := s.activeStructuredOutput(); so != nil {
+		finalResponse, accumulatedContent, err = s.enforceStructuredOutput(ctx, so, conv, finalResponse, accumulatedContent, log)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return err
+		}
+	}
+
+	// Check the response with the output guardrails, finishing the check of
+	// a streamed response
+	var checked *guardrails.Result
+	var redacted bool
+	if guard != nil {
+		checked, err = guard.finish()
+		redacted = guard.redacted
+	} else {
+		checked, err = s.checkGuardrails(ctx, s.outputGuardrails(), conv, sessionID, accumulatedContent)
+		redacted = checked.Redacted()
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	// Send the response text held back for checking as a single chunk
+	if s.holdsResponse() {
+		if err := sendText(stream, checked.Text); err != nil {
 			tracing.RecordError(span, err)
 			return err
 		}
 	}
 
-	// Build and send the done message
-	if err := s.sendDoneMessage(ctx, stream, log, finalResponse, accumulatedContent, content); err != nil {
+	// Build and send the done message, with the redacted text when the output
+	// guardrails redacted the response
+	checkedText := ""
+	if redacted {
+		checkedText = checked.Text
+	}
+	progress.stop()
+	if err := s.sendDoneMessage(ctx, stream, log, finalResponse, accumulatedContent, content, checkedText); err != nil {
 		tracing.RecordError(span, err)
 		return err
 	}
 
+	// A redacted response is not cached: the cache would serve it unredacted
+	if !redacted {
+		s.saveToResponseCache(ctx, conv, cacheQuery, finalResponse, accumulatedContent)
+	}
+
 	tracing.SetSuccess(span)
 	return nil
 }
@@ -166,6 +258,10 @@ func (s *Server) streamResponse(ctx context.Context, stream runtimev1.RuntimeSer
 		"hasTraceContext", trace.SpanFromContext(ctx).SpanContext().IsValid(),
 		"traceID", trace.SpanFromContext(ctx).SpanContext().TraceID().String(),
 		"spanID", trace.SpanFromContext(ctx).SpanContext().SpanID().String())
+	// Cancelling when the turn stops reading, as when a streamed response is
+	// rejected, ends the provider stream early
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	streamCh := conv.Stream(ctx, content, opts...)
 
 	finalResponse, accContent, pendingTools, err := s.consumeStream(ctx, stream, streamCh, log, llmSpan)
@@ -234,12 +330,17 @@ func (s *Server) consumeStream(
 	return finalResponse, accumulatedContent.String(), pendingTools, nil
 }
 
-// handleChunkText sends a text chunk on the gRPC stream.
+// handleChunkText sends a text chunk on the gRPC stream. With structured
+// output or output guardrails, text is only accumulated: a response is not
+// sent until it has been checked.
 func (s *Server) handleChunkText(stream runtimev1.RuntimeService_ConverseServer, text string, acc *strings.Builder) error {
 	if text == "" {
 		return nil
 	}
 	acc.WriteString(text)
+	if s.holdsResponse() {
+		return nil
+	}
 	return stream.Send(&runtimev1.ServerMessage{
 		Message: &runtimev1.ServerMessage_Chunk{
 			Chunk: &runtimev1.Chunk{Content: text},
@@ -298,8 +399,10 @@ func (s *Server) processClientTools(
 		}
 
 		// Resume the conversation — may yield more client tools
-		resumeCh := conv.ResumeStream(ctx)
-		finalResp, accContent, newPending, err := s.consumeStream(ctx, stream, resumeCh, log, nil)
+		resumeCtx, cancel := context.WithCancel(ctx)
+		resumeCh := conv.ResumeStream(resumeCtx)
+		finalResp, accContent, newPending, err := s.consumeStream(resumeCtx, stream, resumeCh, log, nil)
+		cancel()
 		if err != nil {
 			return nil, "", err
 		}
@@ -370,8 +473,13 @@ func buildMediaChunk(media *providers.StreamMediaData) *runtimev1.MediaChunk {
 }
 
 // sendDoneMessage builds usage info and sends the done message to the client.
-func (s *Server) sendDoneMessage(ctx context.Context, stream runtimev1.RuntimeService_ConverseServer, log logr.Logger, finalResponse *sdk.Response, accumulatedContent string, originalContent string) error {
+// checkedText, when set, is the response as the output guardrails redacted
+// it: it replaces the final content and the response's text parts.
+func (s *Server) sendDoneMessage(ctx context.Context, stream runtimev1.RuntimeService_ConverseServer, log logr.Logger, finalResponse *sdk.Response, accumulatedContent string, originalContent string, checkedText string) error {
 	responseText, usage := s.buildUsageInfo(ctx, finalResponse, accumulatedContent, originalContent)
+	if checkedText != "" {
+		responseText = checkedText
+	}
 
 	// Build multimodal parts if response contains media
 	var parts []*runtimev1.ContentPart
@@ -381,6 +489,9 @@ func (s *Server) sendDoneMessage(ctx context.Context, stream runtimev1.RuntimeSe
 		if err != nil {
 			log.Error(err, "failed to resolve media parts, falling back to text-only")
 		}
+		if checkedText != "" {
+			parts = withoutTextParts(parts)
+		}
 	}
 
 	// Send done message
@@ -399,6 +510,17 @@ func (s *Server) sendDoneMessage(ctx context.Context, stream runtimev1.RuntimeSe
 	return nil
 }
 
+// withoutTextParts returns parts without their text parts.
+func withoutTextParts(parts []*runtimev1.ContentPart) []*runtimev1.ContentPart {
+	kept := parts[:0]
+	for _, p := range parts {
+		if p.GetType() != types.ContentTypeText {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
 // buildUsageInfo extracts usage info from the final response and records tracing metrics.
 func (s *Server) buildUsageInfo(ctx context.Context, finalResponse *sdk.Response, accumulatedContent string, originalContent string) (string, *runtimev1.Usage) {
 	if finalResponse == nil {
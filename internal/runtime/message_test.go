@@ -19,6 +19,7 @@ package runtime
 import (
 	"context"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -543,3 +544,22 @@ func TestConverse_NoTracingProvider_NoSpans(t *testing.T) {
 	// Verify responses were sent
 	assert.NotEmpty(t, stream.sentMessages)
 }
+
+// Token deltas reach the facade as they arrive: each non-empty text chunk is
+// its own Chunk on the Converse stream, not buffered until Done.
+func TestHandleChunkText_ForwardsEachDelta(t *testing.T) {
+	s := NewServer(WithLogger(logr.Discard()))
+	stream := newMockStream(context.Background(), nil)
+	var acc strings.Builder
+
+	for _, delta := range []string{"Hel", "", "lo ", "world"} {
+		require.NoError(t, s.handleChunkText(stream, delta, &acc))
+	}
+
+	var got []string
+	for _, msg := range stream.sentMessages {
+		got = append(got, msg.GetChunk().GetContent())
+	}
+	assert.Equal(t, []string{"Hel", "lo ", "world"}, got, "empty deltas are dropped")
+	assert.Equal(t, "Hello world", acc.String())
+}
@@ -18,7 +18,8 @@ import (
 // policy-broker E2E gap: a mock provider scripts a tool_calls turn for a
 // server-side ("http") tool defined only in the ToolRegistry (NOT declared in
 // the pack prompt's allowed_tools). The runtime must dispatch the tool to the
-// backend, not return the mock's defaultResponse text.
+// backend, not return the mock's defaultResponse text, and then continue the
+// loop with the tool result.
 func TestServer_MockScriptedToolCall_DispatchesExecutor(t *testing.T) {
 	// --- Echo upstream backend: records that it was hit. ---
 	var echoHits atomic.Int32
@@ -109,4 +110,15 @@ scenarios:
 	if got := echoHits.Load(); got == 0 {
 		t.Fatalf("echo backend was NEVER hit: the scripted mock tool_call did not dispatch the server tool (got defaultResponse instead)")
 	}
+
+	// The tool result is fed back to the model, which answers with turn 2.
+	var final string
+	for _, msg := range stream.sentMessages {
+		if done := msg.GetDone(); done != nil {
+			final = done.GetFinalContent()
+		}
+	}
+	if final != "Request processed." {
+		t.Fatalf("final content = %q, want the post-tool model turn %q", final, "Request processed.")
+	}
 }
@@ -0,0 +1,106 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mtls builds the TLS configuration the runtime uses to authenticate
+// to its provider with a client certificate, taken either from a Secret or
+// from the SPIFFE Workload API.
+package mtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+)
+
+// DefaultSPIFFETimeout bounds how long ClientConfig waits for the Workload
+// API to issue the first X.509 SVID.
+const DefaultSPIFFETimeout = 30 * time.Second
+
+// Config selects the client certificate. Either CertPEM and KeyPEM or
+// SPIFFESocket is set.
+type Config struct {
+	CertPEM []byte // PEM client certificate chain
+	KeyPEM  []byte // PEM private key of CertPEM
+	CAPEM   []byte // PEM roots that verify the provider (nil = system roots)
+
+	SPIFFESocket   string // Path of the Workload API socket
+	SPIFFEServerID string // SPIFFE ID the provider must present ("" = any in the bundle)
+}
+
+// ClientConfig returns the TLS configuration for connections to the provider.
+// A SPIFFE configuration keeps a Workload API stream open, so rotated SVIDs
+// and bundles take effect on new connections, and returns the Closer that
+// ends it; the Closer is nil otherwise.
+func ClientConfig(ctx context.Context, cfg Config) (*tls.Config, io.Closer, error) {
+	if cfg.SPIFFESocket != "" {
+		return spiffeClientConfig(ctx, cfg)
+	}
+	tlsCfg, err := staticClientConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tlsCfg, nil, nil
+}
+
+// staticClientConfig presents the certificate in cfg.
+func staticClientConfig(cfg Config) (*tls.Config, error) {
+	cert, err := tls.X509KeyPair(cfg.CertPEM, cfg.KeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("load client certificate: %w", err)
+	}
+	tlsCfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if len(cfg.CAPEM) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(cfg.CAPEM) {
+			return nil, errors.New("load CA bundle: no certificates found")
+		}
+		tlsCfg.RootCAs = pool
+	}
+	return tlsCfg, nil
+}
+
+// spiffeClientConfig presents the workload's X.509 SVID and verifies the
+// provider against the workload's trust bundle.
+func spiffeClientConfig(ctx context.Context, cfg Config) (*tls.Config, io.Closer, error) {
+	authorizer := tlsconfig.AuthorizeAny()
+	if cfg.SPIFFEServerID != "" {
+		id, err := spiffeid.FromString(cfg.SPIFFEServerID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse SPIFFE server ID %q: %w", cfg.SPIFFEServerID, err)
+		}
+		authorizer = tlsconfig.AuthorizeID(id)
+	}
+	ctx, cancel := context.WithTimeout(ctx, DefaultSPIFFETimeout)
+	defer cancel()
+	source, err := workloadapi.NewX509Source(ctx, workloadapi.WithClientOptions(
+		workloadapi.WithAddr("unix://"+cfg.SPIFFESocket),
+	))
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch X.509 SVID from %s: %w", cfg.SPIFFESocket, err)
+	}
+	return tlsconfig.MTLSClientConfig(source, source, authorizer), source, nil
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/runtime/statestore"
+	"github.com/AltairaLabs/PromptKit/sdk"
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/internal/runtime/guardrails"
+	"github.com/altairalabs/omnia/internal/schemautil"
+)
+
+// eventPromptPackVersion is recorded in a session when it is first served
+// from a pack version, so each turn can be traced to the prompts it used.
+const eventPromptPackVersion events.EventType = "promptpack.version"
+
+// stateMetadataPackVersion is the conversation-state metadata entry holding
+// the pack version the session was last served from.
+const stateMetadataPackVersion = "omnia.promptpack.revision"
+
+// packVersionLength is the number of hex digits of the pack's SHA-256 kept
+// as its version stamp.
+const packVersionLength = 12
+
+// maxStagedPacks is how many reload-staged packs are kept. The previous one
+// stays on disk for conversations that read the pack path just before a swap.
+const maxStagedPacks = 2
+
+// packVersion stamps pack content. The PromptPack controller can update a
+// pack's ConfigMap without changing its version, so the content is hashed.
+func packVersion(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:packVersionLength]
+}
+
+// InitializePackVersion stamps the mounted pack's version. Call it before the
+// Initialize methods that stage the pack: a pack changed in between is then
+// seen as a new version by the first ReloadPack. A pack that cannot be read
+// is left to the readiness check and leaves the version unset.
+func (s *Server) InitializePackVersion() {
+	data, err := os.ReadFile(s.sourcePackPath)
+	if err != nil {
+		return
+	}
+	s.packMu.Lock()
+	defer s.packMu.Unlock()
+	s.packVersion = packVersion(data)
+}
+
+// activePack returns the staged pack conversations open and its version.
+func (s *Server) activePack() (path, version string) {
+	s.packMu.RLock()
+	defer s.packMu.RUnlock()
+	return s.packPath, s.packVersion
+}
+
+// activeStructuredOutput returns the response schema of the active pack; nil
+// when structured output is off.
+func (s *Server) activeStructuredOutput() *structuredOutput {
+	s.packMu.RLock()
+	defer s.packMu.RUnlock()
+	return s.structuredOutput
+}
+
+// activeGuardrails returns the guardrails built with the active pack's
+// metadata.guardrails; nil when none are configured.
+func (s *Server) activeGuardrails() *guardrails.Guardrails {
+	s.packMu.RLock()
+	defer s.packMu.RUnlock()
+	return s.guardrails
+}
+
+// WatchPack polls the mounted pack every interval and reloads it when its
+// content changes. Kubernetes updates a ConfigMap volume by swapping a
+// symlink, which file watches miss, so the content is polled. A pack that
+// fails to reload is logged and the previous one keeps serving. Blocks until
+// ctx is cancelled.
+func (s *Server) WatchPack(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ReloadPack(); err != nil {
+				s.log.Error(err, "promptpack reload failed, serving the previous version",
+					"packPath", s.sourcePackPath)
+			}
+		}
+	}
+}
+
+// ReloadPack restages the mounted pack when its content has changed since it
+// was last loaded, reporting whether it did. The new pack goes through the
+// same rewrites as at startup and is swapped in atomically: conversations
+// opened afterwards use it, while open conversations finish on the pack they
+// were opened with. Eval definitions and the prompt name are read at startup
+// and still need a restart to change.
+func (s *Server) ReloadPack() (bool, error) {
+	data, err := os.ReadFile(s.sourcePackPath)
+	if err != nil {
+		return false, fmt.Errorf("read pack: %w", err)
+	}
+	version := packVersion(data)
+	_, current := s.activePack()
+	if version == current {
+		return false, nil
+	}
+
+	staged, so, g, err := s.stagePack(data)
+	if err != nil {
+		return false, fmt.Errorf("pack version %s: %w", version, err)
+	}
+	// Staged on the same writable mount as the tool-surfaced pack; see
+	// surfaceRegistryToolsInPack. Each version gets its own file so a
+	// conversation opening the previous path never reads a partial write.
+	outPath := filepath.Join(packCacheDir(), "omnia-pack-"+version+".promptpack")
+	if err := os.WriteFile(outPath, staged, 0o600); err != nil {
+		return false, fmt.Errorf("stage pack: %w", err)
+	}
+
+	s.packMu.Lock()
+	s.packPath, s.packVersion = outPath, version
+	s.structuredOutput, s.guardrails = so, g
+	s.packStaged = append(s.packStaged, outPath)
+	var stale []string
+	if n := len(s.packStaged) - maxStagedPacks; n > 0 {
+		stale, s.packStaged = s.packStaged[:n], s.packStaged[n:]
+	}
+	s.packMu.Unlock()
+
+	for _, p := range stale {
+		_ = os.Remove(p)
+	}
+	s.log.Info("promptpack reloaded", "version", version, "previous", current, "packPath", outPath)
+	return true, nil
+}
+
+// stagePack applies the startup rewrites to a pack — InitializeTools'
+// registry tools, InitializeStructuredOutput's response schema,
+// InitializeKnowledge's placeholder, InitializeSystemPromptPrefix's prefix —
+// and builds the guardrails its
+// metadata declares. Unlike at startup, every failure rejects the pack.
+func (s *Server) stagePack(data []byte) ([]byte, *structuredOutput, *guardrails.Guardrails, error) {
+	var pack struct {
+		Prompts map[string]json.RawMessage `json:"prompts"`
+	}
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return nil, nil, nil, fmt.Errorf("unmarshal pack: %w", err)
+	}
+	if _, ok := pack.Prompts[s.promptName]; !ok {
+		return nil, nil, nil, fmt.Errorf("prompt %q not found in pack", s.promptName)
+	}
+	packCfg, err := packGuardrails(data)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if s.toolExecutor != nil {
+		names := s.toolExecutor.ToolNames()
+		sort.Strings(names)
+		if data, _, err = injectToolsIntoPackJSON(data, names); err != nil {
+			return nil, nil, nil, fmt.Errorf("surface registry tools: %w", err)
+		}
+	}
+
+	var so *structuredOutput
+	if s.structuredOutputEnabled {
+		var schemaJSON []byte
+		if schemaJSON, data, err = takeResponseSchema(data, s.promptName); err != nil {
+			return nil, nil, nil, err
+		}
+		compiled, err := schemautil.CompileSchema(schemaJSON)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("prompt %q response schema: %w", s.promptName, err)
+		}
+		so = &structuredOutput{schemaJSON: schemaJSON, schema: compiled, maxRepairs: s.structuredOutputMaxRepairs}
+	}
+
+	if s.knowledgeStore != nil {
+		if data, _, err = addKnowledgePlaceholder(data, s.promptName); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if s.systemPromptPrefix != "" {
+		if data, _, err = addSystemPromptPrefix(data, s.promptName, s.systemPromptPrefix); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	var g *guardrails.Guardrails
+	if cfg := packCfg.Merge(s.guardrailConfig); !cfg.Empty() {
+		if g, err = guardrails.New(cfg, s.guardrailMetrics); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return data, so, g, nil
+}
+
+// recordPackVersion stamps the session with the pack version its
+// conversation was opened with, recording an event when the version differs
+// from the one the session was last served from.
+func (s *Server) recordPackVersion(ctx context.Context, conv *sdk.Conversation, sessionID, version string, log logr.Logger) {
+	accessor, ok := s.stateStore.(statestore.MetadataAccessor)
+	if version == "" || !ok {
+		return
+	}
+	var previous string
+	if md, err := accessor.LoadMetadata(ctx, sessionID); err == nil {
+		previous, _ = md[stateMetadataPackVersion].(string)
+	}
+	if previous == version {
+		return
+	}
+	if err := accessor.MergeMetadata(ctx, sessionID, map[string]any{stateMetadataPackVersion: version}); err != nil {
+		log.Error(err, "failed to record promptpack version", "version", version)
+		return
+	}
+	bus := conv.EventBus()
+	if bus == nil {
+		return
+	}
+	bus.Publish(&events.Event{
+		Type:           eventPromptPackVersion,
+		Timestamp:      time.Now(),
+		SessionID:      sessionID,
+		ConversationID: conv.ID(),
+		Data: &events.CustomEventData{
+			EventName: string(eventPromptPackVersion),
+			Data:      map[string]any{"version": version, "previous": previous},
+			Message:   "session served from promptpack version " + version,
+		},
+	})
+}
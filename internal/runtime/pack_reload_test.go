@@ -0,0 +1,187 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/runtime/statestore"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/runtime/guardrails"
+	"github.com/altairalabs/omnia/internal/runtime/responsecache"
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+)
+
+// newReloadServer serves invokeTestPack from a pack file tests can rewrite.
+func newReloadServer(t *testing.T, opts ...ServerOption) (*Server, string) {
+	t.Helper()
+	t.Setenv("OMNIA_PACK_CACHE_DIR", t.TempDir())
+	packPath := filepath.Join(t.TempDir(), "pack.json")
+	require.NoError(t, writeTestFile(t, packPath, invokeTestPack))
+	server := NewServer(append([]ServerOption{
+		WithLogger(logr.Discard()),
+		WithPackPath(packPath),
+		WithPromptName("default"),
+		WithMockProvider(true),
+		WithStateStore(statestore.NewMemoryStore()),
+	}, opts...)...)
+	server.InitializePackVersion()
+	t.Cleanup(func() { _ = server.Close() })
+	return server, packPath
+}
+
+func TestReloadPack(t *testing.T) {
+	server, packPath := newReloadServer(t)
+	path, version := server.activePack()
+	assert.Equal(t, packPath, path)
+	assert.Equal(t, packVersion([]byte(invokeTestPack)), version)
+	assert.Len(t, version, packVersionLength)
+
+	reloaded, err := server.ReloadPack()
+	require.NoError(t, err)
+	assert.False(t, reloaded, "an unchanged pack is not restaged")
+
+	updated := strings.Replace(invokeTestPack, "You are a test assistant.", "You are a terse assistant.", 1)
+	require.NoError(t, writeTestFile(t, packPath, updated))
+	reloaded, err = server.ReloadPack()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	path, version = server.activePack()
+	assert.Equal(t, packVersion([]byte(updated)), version)
+	assert.Equal(t, filepath.Join(packCacheDir(), "omnia-pack-"+version+".promptpack"), path)
+	staged, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(staged), "You are a terse assistant.")
+
+	require.NoError(t, writeTestFile(t, packPath, strings.Replace(invokeTestPack, `"default": {`, `"other": {`, 1)))
+	_, err = server.ReloadPack()
+	assert.ErrorContains(t, err, `prompt "default" not found in pack`)
+	current, _ := server.activePack()
+	assert.Equal(t, path, current, "a rejected pack leaves the previous one serving")
+
+	require.NoError(t, writeTestFile(t, packPath, strings.Replace(invokeTestPack, "test assistant", "second", 1)))
+	_, err = server.ReloadPack()
+	require.NoError(t, err)
+	require.NoError(t, writeTestFile(t, packPath, strings.Replace(invokeTestPack, "test assistant", "third", 1)))
+	_, err = server.ReloadPack()
+	require.NoError(t, err)
+	assert.NoFileExists(t, path, "only the last staged packs are kept")
+	assert.Len(t, server.packStaged, maxStagedPacks)
+	for _, p := range server.packStaged {
+		assert.FileExists(t, p)
+	}
+}
+
+// A conversation opened on a reloaded pack is not answered from responses
+// the previous version produced.
+func TestReloadPack_ResponseCache(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	server, packPath := newReloadServer(t, WithResponseCache(responsecache.NewMemoryBackend(0), responsecache.Config{
+		Metrics: responsecache.NewMetrics(reg, nil),
+	}))
+	ask := func(sessionID string) {
+		require.Len(t, converse(t, server, &runtimev1.ClientMessage{SessionId: sessionID, Content: "hello"}), 1)
+	}
+
+	ask("sess-1")
+	ask("sess-2")
+	assert.InDelta(t, 1, cacheLookups(t, reg, "exact_hit"), 0)
+
+	updated := strings.Replace(invokeTestPack, "You are a test assistant.", "You are a terse assistant.", 1)
+	require.NoError(t, writeTestFile(t, packPath, updated))
+	reloaded, err := server.ReloadPack()
+	require.NoError(t, err)
+	require.True(t, reloaded)
+	ask("sess-3")
+	assert.InDelta(t, 1, cacheLookups(t, reg, "exact_hit"), 0)
+	assert.InDelta(t, 2, cacheLookups(t, reg, "miss"), 0, "the new pack version is a fresh scope")
+}
+
+func TestReloadPack_Rewrites(t *testing.T) {
+	server, packPath := newReloadServer(t,
+		WithGuardrails(guardrails.Config{Output: []guardrails.Spec{{Name: guardrails.HookMaxLength, MaxChars: 100}}}, nil))
+	require.NoError(t, server.InitializeGuardrails())
+	assert.True(t, server.inputGuardrails().Empty())
+
+	require.NoError(t, writeTestFile(t, packPath, guardrailsPack))
+	_, err := server.ReloadPack()
+	require.NoError(t, err)
+	assert.False(t, server.inputGuardrails().Empty(), "the new pack's metadata.guardrails apply")
+	assert.False(t, server.outputGuardrails().Empty(), "spec.guardrails still apply")
+
+	server.structuredOutputEnabled = true
+	require.NoError(t, writeTestFile(t, packPath, strings.Replace(guardrailsPack, "test-pack", "test-pack-2", 1)))
+	_, err = server.ReloadPack()
+	assert.ErrorContains(t, err, `prompt "default" declares no response schema`)
+}
+
+func TestWatchPack(t *testing.T) {
+	server, packPath := newReloadServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.WatchPack(ctx, 10*time.Millisecond)
+
+	updated := strings.Replace(invokeTestPack, "You are a test assistant.", "You are a terse assistant.", 1)
+	require.NoError(t, writeTestFile(t, packPath, updated))
+	require.Eventually(t, func() bool {
+		_, version := server.activePack()
+		return version == packVersion([]byte(updated))
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRecordPackVersion(t *testing.T) {
+	server, _ := newReloadServer(t)
+	ctx := context.Background()
+	_, version := server.activePack()
+	recorded := eventRecorder(t, server, "sess-1", eventPromptPackVersion)
+	accessor := server.stateStore.(statestore.MetadataAccessor)
+	md, err := accessor.LoadMetadata(ctx, "sess-1")
+	require.NoError(t, err)
+	assert.Equal(t, version, md[stateMetadataPackVersion], "the session is stamped when its conversation opens")
+
+	conv, err := server.getOrCreateConversation(ctx, "sess-1")
+	require.NoError(t, err)
+	server.recordPackVersion(ctx, conv, "sess-1", version, logr.Discard())
+	server.recordPackVersion(ctx, conv, "sess-1", "abc123", logr.Discard())
+	md, err = accessor.LoadMetadata(ctx, "sess-1")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", md[stateMetadataPackVersion])
+
+	want := map[string]any{"version": "abc123", "previous": version}
+	require.Eventually(t, func() bool {
+		got := recorded()
+		return len(got) > 0 && assert.ObjectsAreEqual(want, got[len(got)-1].Data.(*events.CustomEventData).Data)
+	}, 5*time.Second, 10*time.Millisecond)
+	// The bus may deliver the event published when the conversation opened,
+	// but an unchanged version is never recorded again.
+	assert.LessOrEqual(t, len(recorded()), 2)
+
+	require.Len(t, converse(t, server, &runtimev1.ClientMessage{SessionId: "sess-2", Content: "hello"}), 1)
+	md, err = accessor.LoadMetadata(ctx, "sess-2")
+	require.NoError(t, err)
+	assert.Equal(t, version, md[stateMetadataPackVersion], "the stamp survives the turn saving its state")
+}
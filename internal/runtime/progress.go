@@ -0,0 +1,274 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"sync"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+)
+
+// Progress stages and statuses, as sent in runtimev1.Progress.
+const (
+	progressStageThinking  = "thinking"
+	progressStageTool      = "tool"
+	progressStageRetrieval = "retrieval"
+
+	progressStarted   = "started"
+	progressRunning   = "running"
+	progressCompleted = "completed"
+	progressFailed    = "failed"
+)
+
+// progressInterval is how often a tool call still running is reported.
+const progressInterval = 2 * time.Second
+
+// progressReporter sends the Progress frames of one turn. PromptKit delivers
+// events on its own goroutines, possibly out of order, so the reporter
+// serializes its sends with the turn's (see wrap) and drops what arrives
+// after the turn ends. A nil reporter reports nothing.
+type progressReporter struct {
+	stream   runtimev1.RuntimeService_ConverseServer
+	interval time.Duration
+
+	// mu serializes Send on stream and guards the fields below.
+	mu      sync.Mutex
+	stopped bool
+	// tools are the tool calls in flight, by call ID.
+	tools map[string]*toolProgress
+	// ended holds the sequence of the event that ended each step, so an
+	// older start delivered late does not reopen it.
+	ended  map[string]int64
+	unsubs []func()
+	quit   chan struct{}
+}
+
+// toolProgress is a tool call in flight.
+type toolProgress struct {
+	name    string
+	started time.Time
+}
+
+// startProgress returns a reporter for a turn that asked for progress, fed by
+// the conversation's event bus, or nil when the turn did not ask.
+func (s *Server) startProgress(stream runtimev1.RuntimeService_ConverseServer, bus events.Bus, want bool) *progressReporter {
+	if !want {
+		return nil
+	}
+	p := newProgressReporter(stream, progressInterval)
+	if bus != nil {
+		p.subscribe(bus)
+	}
+	go p.tick()
+	return p
+}
+
+func newProgressReporter(stream runtimev1.RuntimeService_ConverseServer, interval time.Duration) *progressReporter {
+	return &progressReporter{
+		stream:   stream,
+		interval: interval,
+		tools:    map[string]*toolProgress{},
+		ended:    map[string]int64{},
+		quit:     make(chan struct{}),
+	}
+}
+
+// wrap returns stream with its sends serialized with the reporter's. The turn
+// must send through it while the reporter runs.
+func (p *progressReporter) wrap(stream runtimev1.RuntimeService_ConverseServer) runtimev1.RuntimeService_ConverseServer {
+	if p == nil {
+		return stream
+	}
+	return &progressStream{RuntimeService_ConverseServer: stream, p: p}
+}
+
+// progressStream is a Converse stream whose sends take the reporter's lock.
+type progressStream struct {
+	runtimev1.RuntimeService_ConverseServer
+	p *progressReporter
+}
+
+func (st *progressStream) Send(msg *runtimev1.ServerMessage) error {
+	st.p.mu.Lock()
+	defer st.p.mu.Unlock()
+	return st.RuntimeService_ConverseServer.Send(msg)
+}
+
+// stop ends reporting. It must be called before the turn's done or error is
+// sent, so no progress follows them. Safe to call more than once.
+func (p *progressReporter) stop() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return
+	}
+	p.stopped = true
+	unsubs := p.unsubs
+	p.mu.Unlock()
+	close(p.quit)
+	for _, unsub := range unsubs {
+		unsub()
+	}
+}
+
+// step reports the start of a step the turn runs itself and returns the
+// function that reports its end.
+func (p *progressReporter) step(stage string) func(ok bool) {
+	if p == nil {
+		return func(bool) {}
+	}
+	start := time.Now()
+	p.send(&runtimev1.Progress{Stage: stage, Status: progressStarted})
+	return func(ok bool) {
+		p.send(&runtimev1.Progress{Stage: stage, Status: endStatus(ok), ElapsedMs: time.Since(start).Milliseconds()})
+	}
+}
+
+func (p *progressReporter) send(progress *runtimev1.Progress) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sendLocked(progress)
+}
+
+// sendLocked sends a Progress frame unless the turn is over. A failed send is
+// ignored: the turn's own next send reports the broken stream.
+func (p *progressReporter) sendLocked(progress *runtimev1.Progress) {
+	if p.stopped {
+		return
+	}
+	_ = p.stream.Send(&runtimev1.ServerMessage{
+		Message: &runtimev1.ServerMessage_Progress{Progress: progress},
+	})
+}
+
+// subscribe reports the agent's model calls and tool calls from the
+// conversation's events. Model calls made by evals are not the agent's.
+func (p *progressReporter) subscribe(bus events.Bus) {
+	unsubs := []func(){
+		bus.Subscribe(events.EventProviderCallStarted, func(e *events.Event) {
+			if data, ok := asPtr[events.ProviderCallStartedData](e.Data); ok && agentSource(data.Source) {
+				p.begin(e, progressStageThinking, progressStageThinking, "", "")
+			}
+		}),
+		bus.Subscribe(events.EventProviderCallCompleted, func(e *events.Event) {
+			if data, ok := asPtr[events.ProviderCallCompletedData](e.Data); ok && agentSource(data.Source) {
+				p.end(e, progressStageThinking, progressStageThinking, "", "", data.Duration, true)
+			}
+		}),
+		bus.Subscribe(events.EventProviderCallFailed, func(e *events.Event) {
+			if data, ok := asPtr[events.ProviderCallFailedData](e.Data); ok && agentSource(data.Source) {
+				p.end(e, progressStageThinking, progressStageThinking, "", "", data.Duration, false)
+			}
+		}),
+		bus.Subscribe(events.EventToolCallStarted, func(e *events.Event) {
+			if data, ok := asPtr[events.ToolCallStartedData](e.Data); ok {
+				p.begin(e, "tool:"+data.CallID, progressStageTool, data.ToolName, data.CallID)
+			}
+		}),
+		bus.Subscribe(events.EventToolCallCompleted, func(e *events.Event) {
+			if data, ok := asPtr[events.ToolCallCompletedData](e.Data); ok {
+				p.end(e, "tool:"+data.CallID, progressStageTool, data.ToolName, data.CallID, data.Duration, true)
+			}
+		}),
+		bus.Subscribe(events.EventToolCallFailed, func(e *events.Event) {
+			if data, ok := asPtr[events.ToolCallFailedData](e.Data); ok {
+				p.end(e, "tool:"+data.CallID, progressStageTool, data.ToolName, data.CallID, data.Duration, false)
+			}
+		}),
+	}
+	p.mu.Lock()
+	p.unsubs = unsubs
+	stopped := p.stopped
+	p.mu.Unlock()
+	if stopped {
+		for _, unsub := range unsubs {
+			unsub()
+		}
+	}
+}
+
+// begin reports the start of the step key.
+func (p *progressReporter) begin(e *events.Event, key, stage, toolName, callID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e.Sequence < p.ended[key] {
+		return
+	}
+	if stage == progressStageTool {
+		p.tools[callID] = &toolProgress{name: toolName, started: e.Timestamp}
+	}
+	p.sendLocked(&runtimev1.Progress{Stage: stage, Status: progressStarted, ToolName: toolName, CallId: callID})
+}
+
+// end reports the end of the step key.
+func (p *progressReporter) end(e *events.Event, key, stage, toolName, callID string, elapsed time.Duration, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ended[key] = e.Sequence
+	if stage == progressStageTool {
+		delete(p.tools, callID)
+	}
+	p.sendLocked(&runtimev1.Progress{
+		Stage:     stage,
+		Status:    endStatus(ok),
+		ToolName:  toolName,
+		CallId:    callID,
+		ElapsedMs: elapsed.Milliseconds(),
+	})
+}
+
+// tick reports the tool calls still running every interval until stop.
+func (p *progressReporter) tick() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.quit:
+			return
+		case now := <-ticker.C:
+			p.mu.Lock()
+			for callID, t := range p.tools {
+				p.sendLocked(&runtimev1.Progress{
+					Stage:     progressStageTool,
+					Status:    progressRunning,
+					ToolName:  t.name,
+					CallId:    callID,
+					ElapsedMs: now.Sub(t.started).Milliseconds(),
+				})
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+func endStatus(ok bool) string {
+	if ok {
+		return progressCompleted
+	}
+	return progressFailed
+}
+
+// agentSource reports whether a provider call was made by the agent itself.
+func agentSource(source string) bool {
+	return source == "" || source == events.SourceAgent
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/runtime/vectorstore"
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+)
+
+// progressStreamRecorder records the Progress frames sent on a stream.
+type progressStreamRecorder struct {
+	runtimev1.RuntimeService_ConverseServer
+	mu       sync.Mutex
+	progress []*runtimev1.Progress
+}
+
+func (r *progressStreamRecorder) Send(msg *runtimev1.ServerMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if p := msg.GetProgress(); p != nil {
+		r.progress = append(r.progress, p)
+	}
+	return nil
+}
+
+func (r *progressStreamRecorder) sent() []*runtimev1.Progress {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*runtimev1.Progress(nil), r.progress...)
+}
+
+func TestProgressReporter_ToolCall(t *testing.T) {
+	stream := &progressStreamRecorder{}
+	bus := events.NewEventBus()
+	t.Cleanup(func() { bus.Close() })
+	p := newProgressReporter(stream, 10*time.Millisecond)
+	p.subscribe(bus)
+	go p.tick()
+
+	bus.Publish(&events.Event{Type: events.EventToolCallStarted, Timestamp: time.Now(),
+		Data: &events.ToolCallStartedData{ToolName: "search", CallID: "call-1"}})
+	require.Eventually(t, func() bool {
+		sent := stream.sent()
+		return len(sent) > 1 && sent[len(sent)-1].Status == progressRunning
+	}, 5*time.Second, 5*time.Millisecond)
+	bus.Publish(&events.Event{Type: events.EventToolCallCompleted, Timestamp: time.Now(),
+		Data: &events.ToolCallCompletedData{ToolName: "search", CallID: "call-1", Duration: 1500 * time.Millisecond}})
+	require.Eventually(t, func() bool {
+		sent := stream.sent()
+		return sent[len(sent)-1].Status == progressCompleted
+	}, 5*time.Second, 5*time.Millisecond)
+	p.stop()
+
+	sent := stream.sent()
+	assert.Equal(t, progressStarted, sent[0].Status)
+	for _, progress := range sent {
+		assert.Equal(t, progressStageTool, progress.Stage)
+		assert.Equal(t, "search", progress.ToolName)
+		assert.Equal(t, "call-1", progress.CallId)
+	}
+	assert.Equal(t, int64(1500), sent[len(sent)-1].ElapsedMs)
+
+	// Nothing is reported once the turn is over.
+	p.send(&runtimev1.Progress{Stage: progressStageThinking, Status: progressStarted})
+	assert.Len(t, stream.sent(), len(sent))
+}
+
+func TestProgressReporter_LateStartIgnored(t *testing.T) {
+	stream := &progressStreamRecorder{}
+	p := newProgressReporter(stream, time.Hour)
+	p.end(&events.Event{Sequence: 5}, "tool:call-1", progressStageTool, "search", "call-1", time.Second, false)
+	p.begin(&events.Event{Sequence: 4}, "tool:call-1", progressStageTool, "search", "call-1")
+
+	sent := stream.sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, progressFailed, sent[0].Status)
+	assert.Empty(t, p.tools, "a call that already ended is not reported as running")
+}
+
+func TestProgressReporter_Nil(t *testing.T) {
+	var p *progressReporter
+	stream := &progressStreamRecorder{}
+	assert.Same(t, runtimev1.RuntimeService_ConverseServer(stream), p.wrap(stream))
+	p.step(progressStageRetrieval)(true)
+	p.stop()
+}
+
+func TestConverse_ProgressOnlyWhenRequested(t *testing.T) {
+	store := &fakeVectorStore{matches: []vectorstore.Match{
+		{Document: vectorstore.Document{ID: "refunds#1", Content: "Refunds take 3 days."}, Score: 0.9},
+	}}
+	server := newKnowledgeServer(t, store, fixedEmbedder{})
+
+	stream := newMockStream(context.Background(), []*runtimev1.ClientMessage{
+		{SessionId: "sess-1", Content: "How long do refunds take?", Progress: true},
+		{SessionId: "sess-1", Content: "And exchanges?"},
+	})
+	_ = server.Converse(stream)
+
+	var turns [][]*runtimev1.Progress
+	var progress []*runtimev1.Progress
+	for _, msg := range stream.sentMessages {
+		require.Nil(t, msg.GetError())
+		if p := msg.GetProgress(); p != nil {
+			progress = append(progress, p)
+		}
+		if msg.GetDone() != nil {
+			turns = append(turns, progress)
+			progress = nil
+		}
+	}
+	require.Len(t, turns, 2)
+	assert.Empty(t, progress, "no progress after the last done")
+	assert.Empty(t, turns[1], "the second turn did not ask for progress")
+
+	var retrieval []string
+	for _, p := range turns[0] {
+		if p.Stage == progressStageRetrieval {
+			retrieval = append(retrieval, p.Status)
+		}
+	}
+	assert.Equal(t, []string{progressStarted, progressCompleted}, retrieval)
+}
@@ -19,13 +19,23 @@ package runtime
 import (
 	"context"
 	"fmt"
+	"math"
+	"net/http"
 	"time"
 
 	"github.com/AltairaLabs/PromptKit/runtime/credentials"
+	"github.com/AltairaLabs/PromptKit/runtime/pipeline"
 	"github.com/AltairaLabs/PromptKit/runtime/providers"
 	"github.com/AltairaLabs/PromptKit/runtime/providers/mock"
+
+	"github.com/altairalabs/omnia/internal/runtime/resilience"
+	"github.com/altairalabs/omnia/pkg/apierror"
 )
 
+// errorCodeProviderUnavailable is the Error code sent when a turn fails
+// because the provider's circuit breaker, and its failover's, is open.
+const errorCodeProviderUnavailable = apierror.CodeUnavailable
+
 // httpTimeoutSetter is satisfied by providers whose BaseProvider exposes
 // SetHTTPTimeout (available in PromptKit since the request_timeout feature).
 // Applied via type assertion so Omnia builds against both current and older
@@ -43,6 +53,18 @@ type streamIdleTimeoutSetter interface {
 	SetStreamIdleTimeout(time.Duration)
 }
 
+// httpTransportSetter is satisfied by providers whose BaseProvider lets the
+// outbound transport be replaced; retryPolicySetter by those whose built-in
+// retries can be tuned.
+type httpTransportSetter interface {
+	GetHTTPClient() *http.Client
+	SetHTTPTransport(http.RoundTripper)
+}
+
+type retryPolicySetter interface {
+	SetRetryPolicy(pipeline.RetryPolicy)
+}
+
 // createMockProvider creates a mock provider based on configuration.
 // Returns a ToolProvider (not the basic Provider) so that PredictWithTools
 // is available — without this, the ProviderStage falls back to plain Predict
@@ -142,6 +164,9 @@ func (s *Server) createProviderFromConfig() (providers.Provider, error) {
 	}
 
 	s.applyProviderTimeouts(provider)
+	s.applyProviderTLS(provider)
+	s.applyProviderResilience(provider)
+	s.applyProviderTracing(provider, spec.Model)
 	return provider, nil
 }
 
@@ -159,6 +184,11 @@ func (s *Server) buildProviderSpec() providers.ProviderSpec {
 		Headers: s.headers,
 	}
 
+	// Request shaping for openai-compatible providers and AgentPolicy
+	// transforms; empty otherwise.
+	spec.UnsupportedParams = s.unsupportedParams
+	spec.Capabilities = s.providerCapabilities
+
 	if s.inputCostPer1K > 0 && s.outputCostPer1K > 0 {
 		spec.Defaults.Pricing = providers.Pricing{
 			InputCostPer1K:  s.inputCostPer1K,
@@ -178,6 +208,10 @@ func (s *Server) buildProviderSpec() providers.ProviderSpec {
 		Endpoint: s.platformEndpoint,
 	}
 
+	if s.platformType == "azure" {
+		s.applyAzureRouting(&spec)
+	}
+
 	// Auto-map claude release names to Bedrock model IDs.
 	if s.platformType == "bedrock" && s.model != "" {
 		if bedrockID, ok := credentials.BedrockModelMapping[s.model]; ok {
@@ -188,6 +222,24 @@ func (s *Server) buildProviderSpec() providers.ProviderSpec {
 	return spec
 }
 
+// azureAPIVersionKey is the PlatformConfig.AdditionalConfig key PromptKit's
+// openai provider reads the Azure api-version from.
+const azureAPIVersionKey = "api_version"
+
+// applyAzureRouting routes Azure OpenAI requests to an explicitly named
+// deployment and pins the api-version. PromptKit derives the deployment URL
+// from the model name, so a deployment named differently from its model is
+// addressed by setting the deployment URL as the base URL. An explicit
+// spec.baseURL still wins.
+func (s *Server) applyAzureRouting(spec *providers.ProviderSpec) {
+	if s.platformDeployment != "" && spec.BaseURL == "" && s.providerType == "openai" {
+		spec.BaseURL = credentials.AzureOpenAIEndpoint(s.platformEndpoint, s.platformDeployment)
+	}
+	if s.platformAPIVersion != "" {
+		spec.PlatformConfig.AdditionalConfig = map[string]any{azureAPIVersionKey: s.platformAPIVersion}
+	}
+}
+
 // applyProviderTimeouts sets HTTP and stream-idle timeouts via the setter
 // interfaces exposed by BaseProvider, without requiring those fields on the
 // published ProviderSpec.
@@ -203,3 +255,70 @@ func (s *Server) applyProviderTimeouts(provider providers.Provider) {
 		}
 	}
 }
+
+// applyProviderTLS replaces the provider's transport with a pooled,
+// instrumented one presenting the client certificate. Runs before
+// applyProviderResilience, which wraps whatever transport it finds.
+func (s *Server) applyProviderTLS(provider providers.Provider) {
+	if s.providerTLS == nil {
+		return
+	}
+	p, ok := provider.(httpTransportSetter)
+	if !ok {
+		s.log.V(0).Info("provider does not support a custom transport; client certificate not applied",
+			"type", s.providerType)
+		return
+	}
+	transport := providers.NewPooledTransport()
+	transport.TLSClientConfig = s.providerTLS
+	p.SetHTTPTransport(providers.NewInstrumentedTransport(transport))
+}
+
+// applyProviderResilience routes the provider's HTTP calls through the
+// resilience policy and turns off PromptKit's own retries, so a call is not
+// retried twice over or counted against the circuit breaker per inner retry.
+// The provider's transport (connection pooling, tracing) is kept underneath.
+func (s *Server) applyProviderResilience(provider providers.Provider) {
+	if s.providerResilience == nil {
+		return
+	}
+	p, ok := provider.(httpTransportSetter)
+	if !ok {
+		s.log.V(0).Info("provider does not support a custom transport; resilience policy not applied",
+			"type", s.providerType)
+		return
+	}
+	var next http.RoundTripper
+	if client := p.GetHTTPClient(); client != nil {
+		next = client.Transport
+	}
+	p.SetHTTPTransport(s.providerResilience.Transport(next))
+	if r, ok := provider.(retryPolicySetter); ok {
+		r.SetRetryPolicy(pipeline.RetryPolicy{MaxRetries: 0})
+	}
+}
+
+// applyProviderTracing records each provider HTTP request as a model span, so
+// model latency is separable from tool and store latency within a turn. It
+// wraps the resilience policy, so retries of one call fall under one span.
+func (s *Server) applyProviderTracing(provider providers.Provider, model string) {
+	if s.tracingProvider == nil {
+		return
+	}
+	p, ok := provider.(httpTransportSetter)
+	if !ok {
+		return
+	}
+	var next http.RoundTripper
+	if client := p.GetHTTPClient(); client != nil {
+		next = client.Transport
+	}
+	p.SetHTTPTransport(s.tracingProvider.ModelTransport(next, model, s.providerType))
+}
+
+// providerUnavailableMessage tells the client which provider is unavailable
+// and when to retry, in whole seconds.
+func providerUnavailableMessage(open *resilience.OpenError) string {
+	return fmt.Sprintf("provider %s is unavailable, retry after %ds",
+		open.Provider, int(math.Ceil(open.RetryAfter.Seconds())))
+}
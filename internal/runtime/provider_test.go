@@ -18,14 +18,28 @@ package runtime
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/AltairaLabs/PromptKit/runtime/credentials"
+	"github.com/AltairaLabs/PromptKit/runtime/pipeline"
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
 	"github.com/AltairaLabs/PromptKit/runtime/providers/mock"
+	"github.com/AltairaLabs/PromptKit/runtime/types"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/altairalabs/omnia/internal/runtime/resilience"
+	"github.com/altairalabs/omnia/internal/tracing"
 )
 
 func TestDefaultScenarioRepo_SubstitutesEmptyID(t *testing.T) {
@@ -222,3 +236,307 @@ scenarios:
 	require.NoError(t, err)
 	assert.Equal(t, "default scenario text", resp)
 }
+
+// The Anthropic provider is the PromptKit claude provider: constructing it
+// from a carried key must yield a provider with streaming and tool use.
+func TestCreateProviderFromConfig_ClaudeSupportsStreamingAndTools(t *testing.T) {
+	s := NewServer(
+		WithLogger(logr.Discard()),
+		WithProviderInfo("claude", "claude-sonnet-4-20250514"),
+		WithProviderAPIKey("sk-ant-unit-test"),
+	)
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	p, err := s.createProviderFromConfig()
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.True(t, p.SupportsStreaming(), "claude provider must stream")
+	_, ok := p.(providers.ToolSupport)
+	assert.True(t, ok, "claude provider must support tool use")
+}
+
+// Bedrock-hosted claude signs requests with SigV4 from the AWS credential
+// chain (here: env access keys) and streams via InvokeModelWithResponseStream.
+func TestCreateProviderFromConfig_BedrockClaudeStreams(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIAUNITTEST")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "unit-test-secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_CONFIG_FILE", os.DevNull)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", os.DevNull)
+
+	var claudeName string
+	for name := range credentials.BedrockModelMapping {
+		claudeName = name
+		break
+	}
+	s := &Server{
+		log:            logr.Discard(),
+		providerType:   "claude",
+		model:          claudeName,
+		platformType:   "bedrock",
+		platformRegion: "us-east-1",
+		authType:       "accessKey",
+	}
+
+	p, err := s.createProviderFromConfig()
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.True(t, p.SupportsStreaming(), "bedrock claude provider must stream")
+	_, ok := p.(providers.ToolSupport)
+	assert.True(t, ok, "bedrock claude provider must support tool use")
+}
+
+// Gemini (AI Studio key flow) is the PromptKit gemini provider: it must
+// stream, call functions and accept multimodal inputs. Vertex hosting with
+// workload identity is covered by TestBuildProviderSpec_VertexPlatform.
+func TestCreateProviderFromConfig_GeminiSupportsToolsAndMultimodal(t *testing.T) {
+	s := NewServer(
+		WithLogger(logr.Discard()),
+		WithProviderInfo("gemini", "gemini-2.5-flash"),
+		WithProviderAPIKey("gemini-unit-test"),
+	)
+	t.Setenv("GEMINI_API_KEY", "")
+	t.Setenv("GOOGLE_API_KEY", "")
+
+	p, err := s.createProviderFromConfig()
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.True(t, p.SupportsStreaming(), "gemini provider must stream")
+	_, ok := p.(providers.ToolSupport)
+	assert.True(t, ok, "gemini provider must support function calling")
+	mm := providers.GetMultimodalProvider(p)
+	require.NotNil(t, mm, "gemini provider must accept multimodal inputs")
+	assert.True(t, mm.GetMultimodalCapabilities().SupportsImages)
+}
+
+func TestBuildProviderSpec_AzureDeploymentAndAPIVersion(t *testing.T) {
+	s := &Server{
+		log:                logr.Discard(),
+		providerType:       "openai",
+		model:              "gpt-4o",
+		platformType:       "azure",
+		platformEndpoint:   "https://example.openai.azure.com/",
+		platformDeployment: "prod-gpt4o-eastus",
+		platformAPIVersion: "2024-10-21",
+		authType:           "servicePrincipal",
+	}
+
+	spec := s.buildProviderSpec()
+	assert.Equal(t, "gpt-4o", spec.Model, "model name is kept for pricing and metrics")
+	assert.Equal(t, "https://example.openai.azure.com/openai/deployments/prod-gpt4o-eastus", spec.BaseURL)
+	require.NotNil(t, spec.PlatformConfig)
+	assert.Equal(t, "2024-10-21", spec.PlatformConfig.AdditionalConfig[azureAPIVersionKey])
+}
+
+func TestBuildProviderSpec_AzureDeploymentKeepsExplicitBaseURL(t *testing.T) {
+	s := &Server{
+		log:                logr.Discard(),
+		providerType:       "openai",
+		model:              "gpt-4o",
+		baseURL:            "https://gateway.internal/openai",
+		platformType:       "azure",
+		platformEndpoint:   "https://example.openai.azure.com",
+		platformDeployment: "prod-gpt4o",
+	}
+
+	spec := s.buildProviderSpec()
+	assert.Equal(t, "https://gateway.internal/openai", spec.BaseURL)
+	assert.Nil(t, spec.PlatformConfig.AdditionalConfig, "no api-version set means PromptKit's default")
+}
+
+func TestBuildProviderSpec_OpenAICompatible(t *testing.T) {
+	s := &Server{
+		log:                  logr.Discard(),
+		providerType:         "openai-compatible",
+		model:                "llama3.1",
+		baseURL:              "http://ollama:11434/v1",
+		unsupportedParams:    []string{"max_completion_tokens", "top_p"},
+		providerCapabilities: []string{"tools"},
+	}
+
+	spec := s.buildProviderSpec()
+	assert.Equal(t, "openai-compatible", spec.Type)
+	assert.Equal(t, "openai-compatible", spec.ID)
+	assert.Equal(t, "http://ollama:11434/v1", spec.BaseURL)
+	assert.Equal(t, []string{"max_completion_tokens", "top_p"}, spec.UnsupportedParams)
+	assert.Equal(t, []string{"tools"}, spec.Capabilities)
+}
+
+func TestCreateProviderFromConfig_OpenAICompatibleAuth(t *testing.T) {
+	// A key in the pod env must never reach a keyless self-hosted server.
+	t.Setenv("OPENAI_API_KEY", "sk-real-openai-key")
+
+	tests := []struct {
+		name     string
+		apiKey   string
+		wantAuth string
+	}{
+		{"keyless", "", ""},
+		{"with key", "local-token", "Bearer local-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotAuth, gotPath string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAuth = r.Header.Get("Authorization")
+				gotPath = r.URL.Path
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"hi"},` +
+					`"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1}}`))
+			}))
+			defer srv.Close()
+
+			s := &Server{
+				log:               logr.Discard(),
+				providerType:      "openai-compatible",
+				model:             "llama3.1",
+				baseURL:           srv.URL + "/v1",
+				providerAPIKey:    tt.apiKey,
+				unsupportedParams: []string{"max_completion_tokens"},
+			}
+			provider, err := s.createProviderFromConfig()
+			require.NoError(t, err)
+
+			_, err = provider.Predict(context.Background(), providers.PredictionRequest{
+				Messages: []types.Message{{Role: "user", Content: "hello"}},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "/v1/chat/completions", gotPath)
+			assert.Equal(t, tt.wantAuth, gotAuth)
+		})
+	}
+}
+
+func TestCreateProviderFromConfig_ProviderResilience(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"hi"},` +
+			`"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1}}`))
+	}))
+	defer srv.Close()
+
+	s := &Server{
+		log:                logr.Discard(),
+		providerType:       "openai-compatible",
+		model:              "llama3.1",
+		baseURL:            srv.URL + "/v1",
+		providerResilience: resilience.New("local", resilience.Config{MaxAttempts: 2}),
+	}
+	provider, err := s.createProviderFromConfig()
+	require.NoError(t, err)
+	rp, ok := provider.(interface{ GetRetryPolicy() pipeline.RetryPolicy })
+	require.True(t, ok)
+	assert.Zero(t, rp.GetRetryPolicy().MaxRetries, "the policy replaces PromptKit's retries")
+
+	_, err = provider.Predict(context.Background(), providers.PredictionRequest{
+		Messages: []types.Message{{Role: "user", Content: "hello"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls, "the 503 was retried once by the policy")
+}
+
+func TestCreateProviderFromConfig_OpenCircuit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	s := &Server{
+		log:          logr.Discard(),
+		providerType: "openai-compatible",
+		model:        "llama3.1",
+		baseURL:      srv.URL + "/v1",
+		providerResilience: resilience.New("local", resilience.Config{
+			MaxAttempts: 1, FailureThreshold: 1, OpenDuration: 90 * time.Second,
+		}),
+	}
+	provider, err := s.createProviderFromConfig()
+	require.NoError(t, err)
+	req := providers.PredictionRequest{Messages: []types.Message{{Role: "user", Content: "hello"}}}
+	_, err = provider.Predict(context.Background(), req)
+	require.Error(t, err)
+
+	_, err = provider.Predict(context.Background(), req)
+	var open *resilience.OpenError
+	require.ErrorAs(t, err, &open, "the open circuit reaches the caller")
+	assert.Equal(t, "provider local is unavailable, retry after 90s", providerUnavailableMessage(open))
+}
+
+func TestCreateProviderFromConfig_ProviderTLS(t *testing.T) {
+	var gotClientCert bool
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotClientCert = len(r.TLS.PeerCertificates) > 0
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"hi"},` +
+			`"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1}}`))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	s := &Server{
+		log:          logr.Discard(),
+		providerType: "openai-compatible",
+		model:        "llama3.1",
+		baseURL:      srv.URL + "/v1",
+		providerTLS: &tls.Config{
+			RootCAs:      roots,
+			Certificates: []tls.Certificate{srv.TLS.Certificates[0]},
+		},
+	}
+	provider, err := s.createProviderFromConfig()
+	require.NoError(t, err)
+	_, err = provider.Predict(context.Background(), providers.PredictionRequest{
+		Messages: []types.Message{{Role: "user", Content: "hello"}},
+	})
+	require.NoError(t, err)
+	assert.True(t, gotClientCert, "the provider received the client certificate")
+}
+
+func TestCreateProviderFromConfig_ModelSpan(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"hi"},` +
+			`"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1}}`))
+	}))
+	defer srv.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+
+	s := &Server{
+		log:             logr.Discard(),
+		providerType:    "openai-compatible",
+		model:           "llama3.1",
+		baseURL:         srv.URL + "/v1",
+		tracingProvider: tracing.NewTestProvider(tp),
+	}
+	provider, err := s.createProviderFromConfig()
+	require.NoError(t, err)
+	_, err = provider.Predict(context.Background(), providers.PredictionRequest{
+		Messages: []types.Message{{Role: "user", Content: "hello"}},
+	})
+	require.NoError(t, err)
+
+	var model *tracetest.SpanStub
+	spans := exporter.GetSpans()
+	for i := range spans {
+		if spans[i].Name == "genai.model.request" {
+			model = &spans[i]
+		}
+	}
+	require.NotNil(t, model, "the provider call was recorded as a model span")
+	assert.Contains(t, model.Attributes, attribute.String(tracing.AttrGenAIRequestModel, "llama3.1"))
+	assert.Contains(t, model.Attributes, attribute.String(tracing.AttrLatencyKind, tracing.LatencyKindModel))
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resilience
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Hedge winners.
+const (
+	winnerPrimary = "primary"
+	winnerHedge   = "hedge"
+	winnerNone    = "none"
+)
+
+// Metrics records retries, hedged requests and circuit breaker activity per
+// provider. A nil *Metrics records nothing.
+type Metrics struct {
+	retries      *prometheus.CounterVec
+	hedges       *prometheus.CounterVec
+	breakerState *prometheus.GaugeVec
+	rejections   *prometheus.CounterVec
+	failovers    *prometheus.CounterVec
+}
+
+// NewMetrics registers the provider resilience metrics on reg with the given
+// constant labels (typically agent and namespace).
+func NewMetrics(reg prometheus.Registerer, constLabels prometheus.Labels) *Metrics {
+	m := &Metrics{
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "omnia_runtime_provider_retries_total",
+			Help:        "Provider calls retried after a transient failure, by reason (HTTP status code or network).",
+			ConstLabels: constLabels,
+		}, []string{"provider", "reason"}),
+		hedges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "omnia_runtime_provider_hedged_requests_total",
+			Help:        "Provider calls that sent a hedged request, by which request answered (primary|hedge|none).",
+			ConstLabels: constLabels,
+		}, []string{"provider", "winner"}),
+		breakerState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "omnia_runtime_provider_circuit_breaker_state",
+			Help:        "Provider circuit breaker state (0=closed, 1=half-open, 2=open).",
+			ConstLabels: constLabels,
+		}, []string{"provider"}),
+		rejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "omnia_runtime_provider_circuit_breaker_rejections_total",
+			Help:        "Provider calls failed fast because the circuit breaker was open.",
+			ConstLabels: constLabels,
+		}, []string{"provider"}),
+		failovers: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "omnia_runtime_provider_failovers_total",
+			Help:        "Provider calls sent to the failover endpoint because the circuit breaker was open.",
+			ConstLabels: constLabels,
+		}, []string{"provider"}),
+	}
+	reg.MustRegister(m.retries, m.hedges, m.breakerState, m.rejections, m.failovers)
+	return m
+}
+
+func (m *Metrics) recordRetry(provider, reason string) {
+	if m == nil {
+		return
+	}
+	m.retries.WithLabelValues(provider, reason).Inc()
+}
+
+func (m *Metrics) recordHedge(provider, winner string) {
+	if m == nil {
+		return
+	}
+	m.hedges.WithLabelValues(provider, winner).Inc()
+}
+
+func (m *Metrics) setBreakerState(provider string, state float64) {
+	if m == nil {
+		return
+	}
+	m.breakerState.WithLabelValues(provider).Set(state)
+}
+
+func (m *Metrics) recordRejection(provider string) {
+	if m == nil {
+		return
+	}
+	m.rejections.WithLabelValues(provider).Inc()
+}
+
+func (m *Metrics) recordFailover(provider string) {
+	if m == nil {
+		return
+	}
+	m.failovers.WithLabelValues(provider).Inc()
+}
@@ -0,0 +1,459 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resilience wraps a provider's HTTP transport with retries, hedged
+// requests and a circuit breaker, so transient provider failures are absorbed
+// by the runtime instead of reaching end users.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/sony/gobreaker/v2"
+)
+
+// Defaults applied to zero Config fields.
+const (
+	DefaultMaxAttempts  = 3
+	DefaultInitialDelay = 500 * time.Millisecond
+	DefaultMaxDelay     = 30 * time.Second
+	DefaultOpenDuration = 30 * time.Second
+
+	// drainLimit bounds how much of a failed response body is read so the
+	// connection can be reused before retrying.
+	drainLimit = 4 << 10
+)
+
+// errFailedStatus marks a provider response whose status counts against the
+// circuit breaker. The response itself is still returned to the caller.
+var errFailedStatus = errors.New("provider returned a failure status")
+
+// Config configures a Policy.
+type Config struct {
+	MaxAttempts      int           // Attempts per call, including the first (0 = DefaultMaxAttempts, 1 = no retries)
+	InitialDelay     time.Duration // Backoff before the first retry, doubled per retry (0 = DefaultInitialDelay)
+	MaxDelay         time.Duration // Backoff cap; a longer Retry-After ends the retries (0 = DefaultMaxDelay)
+	HedgeAfter       time.Duration // Send a second request when the first is this slow (0 = no hedging)
+	FailureThreshold int           // Consecutive failed calls that open the circuit (0 = no circuit breaker)
+	OpenDuration     time.Duration // How long an open circuit rejects calls (0 = DefaultOpenDuration)
+	Failover         *url.URL      // Endpoint calls go to while the circuit is open (nil = fail fast)
+	Metrics          *Metrics
+}
+
+// OpenError is returned for a call rejected by an open circuit breaker, and
+// by the failover endpoint's breaker too when one is configured.
+type OpenError struct {
+	Provider   string
+	RetryAfter time.Duration // Until the circuit lets a trial call through
+	err        error
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("provider %s circuit breaker: %v", e.Provider, e.err)
+}
+
+func (e *OpenError) Unwrap() error { return e.err }
+
+// Policy holds the resilience settings and circuit breaker for one provider.
+// Provider clients are created per conversation; they share one Policy so the
+// circuit breaker sees every call the runtime makes to the provider.
+type Policy struct {
+	name     string
+	cfg      Config
+	breaker  *gobreaker.CircuitBreaker[*http.Response]
+	openedAt atomic.Int64 // Unix nanoseconds the circuit last opened
+	failover *Policy
+}
+
+// New creates the Policy for the provider called name, which labels its
+// metrics. A failover endpoint gets its own Policy, labelled
+// "<name>-failover".
+func New(name string, cfg Config) *Policy {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultMaxAttempts
+	}
+	if cfg.InitialDelay <= 0 {
+		cfg.InitialDelay = DefaultInitialDelay
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = DefaultMaxDelay
+	}
+	if cfg.OpenDuration <= 0 {
+		cfg.OpenDuration = DefaultOpenDuration
+	}
+	p := &Policy{name: name, cfg: cfg}
+	if cfg.FailureThreshold > 0 {
+		threshold := uint32(cfg.FailureThreshold)
+		p.breaker = gobreaker.NewCircuitBreaker[*http.Response](gobreaker.Settings{
+			Name:        name,
+			MaxRequests: 1,
+			Timeout:     cfg.OpenDuration,
+			ReadyToTrip: func(counts gobreaker.Counts) bool {
+				return counts.ConsecutiveFailures >= threshold
+			},
+			OnStateChange: func(_ string, _, to gobreaker.State) {
+				if to == gobreaker.StateOpen {
+					p.openedAt.Store(time.Now().UnixNano())
+				}
+				cfg.Metrics.setBreakerState(name, breakerStateValue(to))
+			},
+			// A caller that hung up says nothing about the provider's health.
+			IsExcluded: func(err error) bool {
+				return errors.Is(err, context.Canceled)
+			},
+		})
+		cfg.Metrics.setBreakerState(name, breakerStateValue(gobreaker.StateClosed))
+		if cfg.Failover != nil {
+			failover := cfg
+			failover.Failover = nil
+			p.failover = New(name+"-failover", failover)
+		}
+	}
+	return p
+}
+
+// Transport returns a RoundTripper applying the policy to requests sent
+// through next.
+func (p *Policy) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	t := &transport{policy: p, next: next}
+	if p.failover != nil {
+		t.failover = &transport{policy: p.failover, next: next}
+	}
+	return t
+}
+
+// untilHalfOpen returns how long until the open circuit lets a trial call
+// through, at least a second.
+func (p *Policy) untilHalfOpen() time.Duration {
+	until := time.Unix(0, p.openedAt.Load()).Add(p.cfg.OpenDuration)
+	return max(time.Until(until), time.Second)
+}
+
+// failoverRequest returns a copy of req addressed to the failover endpoint.
+func (p *Policy) failoverRequest(req *http.Request) *http.Request {
+	out := req.Clone(req.Context())
+	out.URL.Scheme = p.cfg.Failover.Scheme
+	out.URL.Host = p.cfg.Failover.Host
+	out.Host = ""
+	return out
+}
+
+// breakerStateValue maps a breaker state to its gauge value.
+func breakerStateValue(s gobreaker.State) float64 {
+	switch s {
+	case gobreaker.StateHalfOpen:
+		return 1
+	case gobreaker.StateOpen:
+		return 2
+	default:
+		return 0
+	}
+}
+
+type transport struct {
+	policy   *Policy
+	next     http.RoundTripper
+	failover *transport
+}
+
+// RoundTrip sends req through the circuit breaker, retrying transient
+// failures. Requests whose body cannot be replayed are sent once. While the
+// circuit is open, requests go to the failover endpoint when there is one.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.breaker(req)
+	var open *OpenError
+	if !errors.As(err, &open) {
+		return resp, err
+	}
+	if t.failover == nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+	t.policy.cfg.Metrics.recordFailover(t.policy.name)
+	resp, err = t.failover.RoundTrip(t.policy.failoverRequest(req))
+	var failoverOpen *OpenError
+	if errors.As(err, &failoverOpen) {
+		open.RetryAfter = min(open.RetryAfter, failoverOpen.RetryAfter)
+		return nil, open
+	}
+	return resp, err
+}
+
+// breaker sends req through the circuit breaker, returning an *OpenError
+// without touching req when the circuit is open.
+func (t *transport) breaker(req *http.Request) (*http.Response, error) {
+	p := t.policy
+	if p.breaker == nil {
+		return t.retry(req)
+	}
+	resp, err := p.breaker.Execute(func() (*http.Response, error) {
+		resp, err := t.retry(req)
+		if err == nil && resp.StatusCode >= http.StatusInternalServerError {
+			return resp, errFailedStatus
+		}
+		if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+			return resp, errFailedStatus
+		}
+		return resp, err
+	})
+	switch {
+	case errors.Is(err, errFailedStatus):
+		return resp, nil
+	case errors.Is(err, gobreaker.ErrOpenState), errors.Is(err, gobreaker.ErrTooManyRequests):
+		p.cfg.Metrics.recordRejection(p.name)
+		return nil, &OpenError{Provider: p.name, RetryAfter: p.untilHalfOpen(), err: err}
+	}
+	return resp, err
+}
+
+// retry sends req until it succeeds, fails permanently, or runs out of
+// attempts, waiting out the backoff or Retry-After between attempts.
+func (t *transport) retry(req *http.Request) (*http.Response, error) {
+	p := t.policy
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return t.next.RoundTrip(req)
+	}
+	closeRequestBody(req)
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := t.hedged(req)
+		reason, retryable := retryReason(resp, err)
+		if !retryable || attempt >= p.cfg.MaxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+		delay, ok := p.backoff(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+		discard(resp)
+		p.cfg.Metrics.recordRetry(p.name, reason)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attemptResult is the outcome of one request sent by hedged.
+type attemptResult struct {
+	resp  *http.Response
+	err   error
+	index int // 0 for the primary request, 1 for the hedge
+}
+
+func (r attemptResult) ok() bool {
+	_, retryable := retryReason(r.resp, r.err)
+	return r.err == nil && !retryable
+}
+
+// hedged sends one attempt of req. When hedging is on and the request has
+// not answered within HedgeAfter, a second copy is sent and the first
+// successful answer wins; the other request is cancelled.
+func (t *transport) hedged(req *http.Request) (*http.Response, error) {
+	p := t.policy
+	results := make(chan attemptResult, 2)
+	var cancels []context.CancelFunc
+	send := func() {
+		ctx, cancel := context.WithCancel(req.Context())
+		index := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			resp, err := t.send(ctx, req)
+			results <- attemptResult{resp: resp, err: err, index: index}
+		}()
+	}
+	send()
+
+	var hedgeTimer <-chan time.Time
+	if p.cfg.HedgeAfter > 0 {
+		timer := time.NewTimer(p.cfg.HedgeAfter)
+		defer timer.Stop()
+		hedgeTimer = timer.C
+	}
+
+	pending := 1
+	var failed *attemptResult
+	for {
+		select {
+		case <-hedgeTimer:
+			hedgeTimer = nil
+			pending++
+			send()
+		case r := <-results:
+			pending--
+			if !r.ok() && pending > 0 {
+				failed = &r
+				continue
+			}
+			if failed != nil {
+				discard(failed.resp)
+			}
+			for i, cancel := range cancels {
+				if i != r.index {
+					cancel()
+				}
+			}
+			if pending > 0 {
+				go drainResults(results, pending)
+			}
+			if len(cancels) > 1 {
+				p.cfg.Metrics.recordHedge(p.name, hedgeWinner(r))
+			}
+			return withCancel(r.resp, cancels[r.index]), r.err
+		}
+	}
+}
+
+// send issues one copy of req on ctx with a fresh body.
+func (t *transport) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	out := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("replay request body: %w", err)
+		}
+		out.Body = body
+	}
+	return t.next.RoundTrip(out)
+}
+
+// hedgeWinner labels which request of a hedged attempt answered.
+func hedgeWinner(r attemptResult) string {
+	switch {
+	case !r.ok():
+		return winnerNone
+	case r.index > 0:
+		return winnerHedge
+	default:
+		return winnerPrimary
+	}
+}
+
+// drainResults releases the responses of requests that lost a hedged
+// attempt; their contexts are already cancelled.
+func drainResults(results <-chan attemptResult, n int) {
+	for range n {
+		discard((<-results).resp)
+	}
+}
+
+// withCancel releases a response's request context when its body is closed,
+// or now when there is no body to read.
+func withCancel(resp *http.Response, cancel context.CancelFunc) *http.Response {
+	if resp == nil || resp.Body == nil {
+		cancel()
+		return resp
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// retryReason reports whether a response or error is a transient failure
+// worth retrying, and the reason label for the retry metric.
+func retryReason(resp *http.Response, err error) (string, bool) {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return "", false
+		}
+		return "network", true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout, statusOverloaded:
+		return strconv.Itoa(resp.StatusCode), true
+	}
+	return "", false
+}
+
+// statusOverloaded is Anthropic's "overloaded" status.
+const statusOverloaded = 529
+
+// backoff returns the wait before retry number attempt: the response's
+// Retry-After when present, exponential backoff with jitter otherwise.
+// It returns false when Retry-After asks for longer than MaxDelay.
+func (p *Policy) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if after, ok := retryAfter(resp); ok {
+		return after, after <= p.cfg.MaxDelay
+	}
+	delay := p.cfg.InitialDelay << (attempt - 1)
+	if delay <= 0 || delay > p.cfg.MaxDelay {
+		delay = p.cfg.MaxDelay
+	}
+	// Full jitter over the upper half keeps retries from synchronizing.
+	delay = delay/2 + rand.N(delay/2+1)
+	return delay, true
+}
+
+// retryAfter parses a Retry-After header in delta-seconds or HTTP-date form.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// discard drains and closes a response body that will not be returned.
+func discard(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.CopyN(io.Discard, resp.Body, drainLimit)
+	_ = resp.Body.Close()
+}
+
+// closeRequestBody closes the original request body; attempts read fresh
+// copies from GetBody.
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resilience
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sony/gobreaker/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClient returns a client whose transport applies p, and the metrics p
+// records to.
+func newClient(t *testing.T, cfg Config) (*http.Client, *Metrics) {
+	t.Helper()
+	cfg.Metrics = NewMetrics(prometheus.NewRegistry(), nil)
+	return &http.Client{Transport: New("claude", cfg).Transport(nil)}, cfg.Metrics
+}
+
+func post(t *testing.T, client *http.Client, url string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"prompt":"hi"}`))
+	require.NoError(t, err)
+	return client.Do(req)
+}
+
+func TestPolicy_RetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"prompt":"hi"}`, string(body), "every attempt carries the full body")
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client, metrics := newClient(t, Config{InitialDelay: time.Millisecond})
+	resp, err := post(t, client, srv.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.EqualValues(t, 3, calls.Load())
+	assert.InDelta(t, 2, testutil.ToFloat64(metrics.retries.WithLabelValues("claude", "503")), 0)
+}
+
+func TestPolicy_GivesUp(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch r.URL.Path {
+		case "/rate-limited":
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/bad-request":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+	client, _ := newClient(t, Config{MaxAttempts: 2, InitialDelay: time.Millisecond, MaxDelay: time.Second})
+
+	for path, want := range map[string]struct {
+		status int
+		calls  int32
+	}{
+		"/rate-limited": {http.StatusTooManyRequests, 1}, // Retry-After beyond MaxDelay
+		"/bad-request":  {http.StatusBadRequest, 1},      // not transient
+		"/unavailable":  {http.StatusBadGateway, 2},      // out of attempts
+	} {
+		calls.Store(0)
+		resp, err := post(t, client, srv.URL+path)
+		require.NoError(t, err, path)
+		_ = resp.Body.Close()
+		assert.Equal(t, want.status, resp.StatusCode, path)
+		assert.Equal(t, want.calls, calls.Load(), path)
+	}
+}
+
+func TestPolicy_HedgesSlowRequests(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body) // lets the server notice the client hanging up
+		if calls.Add(1) == 1 {
+			<-r.Context().Done() // the primary hangs until it is cancelled
+			return
+		}
+		_, _ = w.Write([]byte("hedge"))
+	}))
+	defer srv.Close()
+
+	client, metrics := newClient(t, Config{HedgeAfter: 20 * time.Millisecond})
+	resp, err := post(t, client, srv.URL)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "hedge", string(body))
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.hedges.WithLabelValues("claude", winnerHedge)), 0)
+}
+
+func TestPolicy_CircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	client, metrics := newClient(t, Config{MaxAttempts: 1, FailureThreshold: 2, OpenDuration: time.Hour})
+	for range 2 {
+		resp, err := post(t, client, srv.URL)
+		require.NoError(t, err, "failure statuses reach the caller")
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	}
+	assert.InDelta(t, 2, testutil.ToFloat64(metrics.breakerState.WithLabelValues("claude")), 0, "open")
+
+	_, err := post(t, client, srv.URL)
+	require.ErrorIs(t, err, gobreaker.ErrOpenState)
+	assert.EqualValues(t, 2, calls.Load(), "an open circuit does not call the provider")
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.rejections.WithLabelValues("claude")), 0)
+}
+
+func TestPolicy_OpenErrorRetryAfter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	client, _ := newClient(t, Config{MaxAttempts: 1, FailureThreshold: 1, OpenDuration: time.Minute})
+	resp, err := post(t, client, srv.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	_, err = post(t, client, srv.URL)
+	var open *OpenError
+	require.ErrorAs(t, err, &open)
+	assert.Equal(t, "claude", open.Provider)
+	assert.Greater(t, open.RetryAfter, 59*time.Second)
+	assert.LessOrEqual(t, open.RetryAfter, time.Minute)
+}
+
+func TestPolicy_Failover(t *testing.T) {
+	var primaryCalls atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		primaryCalls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer primary.Close()
+	var failoverOK atomic.Bool
+	failover := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"prompt":"hi"}`, string(body))
+		assert.Equal(t, "/v1/messages", r.URL.Path, "the request path is kept")
+		assert.Equal(t, "key", r.Header.Get("X-Api-Key"), "headers are kept")
+		if !failoverOK.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer failover.Close()
+	failoverURL, err := url.Parse(failover.URL)
+	require.NoError(t, err)
+
+	client, metrics := newClient(t, Config{
+		MaxAttempts: 1, FailureThreshold: 1, OpenDuration: time.Hour, Failover: failoverURL,
+	})
+	send := func() (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodPost, primary.URL+"/v1/messages", strings.NewReader(`{"prompt":"hi"}`))
+		require.NoError(t, err)
+		req.Header.Set("X-Api-Key", "key")
+		return client.Do(req)
+	}
+
+	resp, err := send()
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode, "the first failure opens the circuit")
+
+	failoverOK.Store(true)
+	resp, err = send()
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "an open circuit fails over")
+	assert.EqualValues(t, 1, primaryCalls.Load())
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.failovers.WithLabelValues("claude")), 0)
+
+	failoverOK.Store(false)
+	resp, err = send()
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.InDelta(t, 2, testutil.ToFloat64(metrics.breakerState.WithLabelValues("claude-failover")), 0,
+		"the failover endpoint has its own breaker")
+
+	_, err = send()
+	var open *OpenError
+	require.ErrorAs(t, err, &open, "both circuits open")
+	assert.Equal(t, "claude", open.Provider)
+	assert.Greater(t, open.RetryAfter, 59*time.Minute)
+}
+
+func TestPolicy_UnreplayableBodySentOnce(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client, _ := newClient(t, Config{InitialDelay: time.Millisecond})
+	req, err := http.NewRequest(http.MethodPost, srv.URL, io.NopCloser(strings.NewReader("x")))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.EqualValues(t, 1, calls.Load())
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/runtime/statestore"
+	"github.com/AltairaLabs/PromptKit/runtime/types"
+	"github.com/AltairaLabs/PromptKit/runtime/variables"
+	"github.com/AltairaLabs/PromptKit/sdk"
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/pkg/policy"
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+
+	"github.com/altairalabs/omnia/internal/runtime/responsecache"
+)
+
+// getResponseCache returns the response cache, building it on first use, or
+// nil when the cache is off. A hit bypasses the SDK pipeline, so the runtime
+// writes the turn into the conversation's history itself; a state store that
+// cannot append messages keeps the cache off rather than letting history
+// diverge from what the client saw.
+func (s *Server) getResponseCache(ctx context.Context, log logr.Logger) *responsecache.Cache {
+	if s.responseCacheBackend == nil {
+		return nil
+	}
+	s.responseCacheOnce.Do(func() {
+		if _, ok := s.stateStore.(statestore.MessageAppender); !ok {
+			log.V(0).Info("response cache needs a state store that can append messages; cache disabled",
+				"hasStateStore", s.stateStore != nil)
+			return
+		}
+		cfg := s.responseCacheConfig
+		cfg.Log = s.log.WithName("response-cache")
+		if cfg.SimilarityThreshold > 0 {
+			ep, err := s.embeddingRoleProvider(ctx)
+			if err != nil || ep == nil {
+				log.V(0).Info("response cache similarity needs an embedding provider; matching exact prompts only",
+					"error", err, "fix", "add an embedding-role provider to spec.providers")
+			}
+			cfg.Embedder = ep
+		}
+		s.responseCache = responsecache.New(s.responseCacheBackend, cfg)
+	})
+	return s.responseCache
+}
+
+// turnVariables are the prompt variables rendered per turn from the send
+// context rather than fixed for the conversation.
+var turnVariables = []variables.Provider{knowledgeVariables{}, localeVariables{}}
+
+// responseCacheScope identifies what a cached response depends on besides the
+// prompt: the agent, the version of the pack the session's conversation runs
+// (so a reloaded pack starts afresh), its prompt and model, the user, the
+// variables rendered into this turn's prompt, and the conversation so far.
+// Two turns share cache entries only when all of these match, so a follow-up
+// question is never answered from a different conversation's context and one
+// user's answer is never served to another.
+func (s *Server) responseCacheScope(ctx context.Context, sessionID string, history []types.Message) string {
+	var b strings.Builder
+	for _, part := range []string{
+		s.namespace, s.agentName, s.promptPackName, s.conversationPackVersion(sessionID),
+		s.promptName, s.providerType, s.model, policy.UserID(ctx),
+	} {
+		b.WriteString(part)
+		b.WriteByte(0)
+	}
+	for _, p := range turnVariables {
+		vars, _ := p.Provide(ctx)
+		names := make([]string, 0, len(vars))
+		for name := range vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(&b, "%s\x00%d\x00%s\x00", name, len(vars[name]), vars[name])
+		}
+	}
+	for _, m := range history {
+		fmt.Fprintf(&b, "%s\x00%d\x00%s\x00", m.Role, len(m.Content), m.Content)
+	}
+	return b.String()
+}
+
+// serveFromResponseCache answers the turn from the response cache when it
+// holds a response for content. It returns whether the turn was served and,
+// on a miss, the query to pass to saveToResponseCache once the provider has
+// answered. Cache trouble never fails the turn; it falls through to the
+// provider.
+func (s *Server) serveFromResponseCache(
+	ctx context.Context,
+	stream runtimev1.RuntimeService_ConverseServer,
+	conv *sdk.Conversation,
+	sessionID, content string,
+	log logr.Logger,
+) (bool, *responsecache.Query, error) {
+	cache := s.getResponseCache(ctx, log)
+	if cache == nil {
+		return false, nil, nil
+	}
+
+	history := conv.Messages(ctx)
+	response, query, hit := cache.Lookup(ctx, s.responseCacheScope(ctx, sessionID, history), content)
+	if !hit {
+		return false, query, nil
+	}
+
+	appender, ok := s.stateStore.(statestore.MessageAppender)
+	if !ok {
+		return false, query, nil
+	}
+	now := time.Now()
+	turn := []types.Message{
+		{Role: "user", Content: content, Timestamp: now},
+		{Role: "assistant", Content: response, Timestamp: now},
+	}
+	if err := appender.AppendMessages(ctx, sessionID, turn); err != nil {
+		log.Error(err, "failed to record cached turn; calling the provider")
+		return false, nil, nil
+	}
+	s.publishCachedTurn(conv, sessionID, len(history), turn)
+	log.V(1).Info("served from response cache", "responseLength", len(response))
+
+	if err := stream.Send(&runtimev1.ServerMessage{
+		Message: &runtimev1.ServerMessage_Chunk{
+			Chunk: &runtimev1.Chunk{Content: response},
+		},
+	}); err != nil {
+		return true, nil, err
+	}
+	if err := stream.Send(&runtimev1.ServerMessage{
+		Message: &runtimev1.ServerMessage_Done{
+			Done: &runtimev1.Done{FinalContent: response},
+		},
+	}); err != nil {
+		return true, nil, fmt.Errorf("failed to send done: %w", err)
+	}
+	return true, nil, nil
+}
+
+// publishCachedTurn emits message.created events for a cached turn so the
+// event store records it in the session like any provider-answered turn.
+func (s *Server) publishCachedTurn(conv *sdk.Conversation, sessionID string, index int, turn []types.Message) {
+	bus := conv.EventBus()
+	if bus == nil {
+		return
+	}
+	for i, m := range turn {
+		bus.Publish(&events.Event{
+			Type:           events.EventMessageCreated,
+			Timestamp:      m.Timestamp,
+			SessionID:      sessionID,
+			ConversationID: conv.ID(),
+			Data: &events.MessageCreatedData{
+				Role:    m.Role,
+				Content: m.Content,
+				Index:   index + i,
+			},
+		})
+	}
+}
+
+// saveToResponseCache caches a provider-answered turn. Turns that called
+// tools or produced media are not cached: the first depends on what the tools
+// returned at the time, the second cannot be replayed as text.
+func (s *Server) saveToResponseCache(ctx context.Context, conv *sdk.Conversation, query *responsecache.Query, finalResponse *sdk.Response, accumulatedContent string) {
+	if query == nil || s.responseCache == nil || turnCalledTools(conv.Messages(ctx)) {
+		return
+	}
+	text := accumulatedContent
+	if finalResponse != nil {
+		if finalResponse.HasMedia() || finalResponse.HasToolCalls() {
+			return
+		}
+		text = finalResponse.Text()
+	}
+	s.responseCache.Save(ctx, query, text)
+}
+
+// turnCalledTools reports whether the last turn of history, the messages
+// after its last user message, called a tool: server tools, client tools and
+// delegates alike.
+func turnCalledTools(history []types.Message) bool {
+	for i := len(history) - 1; i >= 0 && history[i].Role != "user"; i-- {
+		if len(history[i].ToolCalls) > 0 || history[i].Role == "tool" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/AltairaLabs/PromptKit/runtime/statestore"
+	"github.com/AltairaLabs/PromptKit/runtime/types"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/runtime/responsecache"
+	"github.com/altairalabs/omnia/pkg/policy"
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+)
+
+// cacheLookups returns the response cache lookup count for result.
+func cacheLookups(t *testing.T, reg *prometheus.Registry, result string) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, mf := range families {
+		if mf.GetName() != "omnia_runtime_response_cache_lookups_total" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "result" && l.GetValue() == result {
+					return m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func newResponseCacheServer(t *testing.T, store statestore.Store, reg *prometheus.Registry) *Server {
+	t.Helper()
+	packPath := filepath.Join(t.TempDir(), "pack.promptpack")
+	require.NoError(t, writeTestFile(t, packPath, `{
+		"id": "test-pack",
+		"name": "test-pack",
+		"version": "1.0.0",
+		"template_engine": { "version": "v1", "syntax": "{{variable}}" },
+		"prompts": {
+			"default": {
+				"id": "default",
+				"name": "default",
+				"version": "1.0.0",
+				"system_template": "You are a test assistant."
+			}
+		}
+	}`))
+	opts := []ServerOption{
+		WithLogger(logr.Discard()),
+		WithPackPath(packPath),
+		WithPromptName("default"),
+		WithMockProvider(true),
+		WithResponseCache(responsecache.NewMemoryBackend(0), responsecache.Config{
+			Metrics: responsecache.NewMetrics(reg, nil),
+		}),
+	}
+	if store != nil {
+		opts = append(opts, WithStateStore(store))
+	}
+	server := NewServer(opts...)
+	t.Cleanup(func() { _ = server.Close() })
+	return server
+}
+
+func converse(t *testing.T, server *Server, msgs ...*runtimev1.ClientMessage) []*runtimev1.Done {
+	t.Helper()
+	return converseWith(t, context.Background(), server, msgs...)
+}
+
+// converseWith is converse on a stream whose context is ctx, as the policy
+// interceptor leaves it.
+func converseWith(t *testing.T, ctx context.Context, server *Server, msgs ...*runtimev1.ClientMessage) []*runtimev1.Done {
+	t.Helper()
+	stream := newMockStream(ctx, msgs)
+	_ = server.Converse(stream) // ends via the mock stream's context.Canceled sentinel
+	var done []*runtimev1.Done
+	for _, msg := range stream.sentMessages {
+		require.Nil(t, msg.GetError(), "turn failed: %v", msg.GetError())
+		if msg.GetDone() != nil {
+			done = append(done, msg.GetDone())
+		}
+	}
+	return done
+}
+
+// A repeated opening question in a new session is answered from the cache
+// and lands in that session's history, so the conversation continues
+// normally; the same question later in a conversation is a miss because the
+// history differs.
+func TestConverse_ResponseCache(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	store := statestore.NewMemoryStore()
+	server := newResponseCacheServer(t, store, reg)
+
+	first := converse(t, server, &runtimev1.ClientMessage{SessionId: "sess-1", Content: "What are your opening hours?"})
+	require.Len(t, first, 1)
+	assert.InDelta(t, 1, cacheLookups(t, reg, "miss"), 0)
+
+	second := converse(t, server,
+		&runtimev1.ClientMessage{SessionId: "sess-2", Content: "what are your  opening hours?"},
+		&runtimev1.ClientMessage{SessionId: "sess-2", Content: "What are your opening hours?"},
+	)
+	require.Len(t, second, 2)
+	assert.Equal(t, first[0].GetFinalContent(), second[0].GetFinalContent())
+	assert.InDelta(t, 1, cacheLookups(t, reg, "exact_hit"), 0)
+	assert.InDelta(t, 2, cacheLookups(t, reg, "miss"), 0, "a later turn has different history")
+
+	conv, err := server.getOrCreateConversation(context.Background(), "sess-2")
+	require.NoError(t, err)
+	history := conv.Messages(context.Background())
+	require.Len(t, history, 4, "the cached turn is part of the conversation history")
+	assert.Equal(t, "user", history[0].Role)
+	assert.Equal(t, "assistant", history[1].Role)
+	assert.Equal(t, first[0].GetFinalContent(), history[1].Content)
+}
+
+func TestConverse_ResponseCacheNeedsAppendableStore(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	server := newResponseCacheServer(t, nil, reg)
+
+	msg := &runtimev1.ClientMessage{SessionId: "sess-1", Content: "hello"}
+	require.Len(t, converse(t, server, msg), 1)
+	require.Len(t, converse(t, server, &runtimev1.ClientMessage{SessionId: "sess-2", Content: "hello"}), 1)
+	assert.Nil(t, server.responseCache, "no state store: the cache stays off")
+	assert.Zero(t, cacheLookups(t, reg, "miss")+cacheLookups(t, reg, "exact_hit"))
+}
+
+// The same opening question from two users, or grounded in different
+// knowledge, is answered by the provider each time.
+func TestConverse_ResponseCacheScope(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	server := newResponseCacheServer(t, statestore.NewMemoryStore(), reg)
+	ask := func(sessionID, userID string) {
+		ctx := policy.WithUserID(context.Background(), userID)
+		require.Len(t, converseWith(t, ctx, server,
+			&runtimev1.ClientMessage{SessionId: sessionID, Content: "What is my balance?"}), 1)
+	}
+
+	ask("sess-1", "alice")
+	ask("sess-2", "bob")
+	assert.InDelta(t, 2, cacheLookups(t, reg, "miss"), 0, "another user's answer is not served")
+	ask("sess-3", "alice")
+	assert.InDelta(t, 1, cacheLookups(t, reg, "exact_hit"), 0)
+
+	ctx := policy.WithUserID(context.Background(), "alice")
+	grounded := context.WithValue(ctx, knowledgeContextKey{}, "[1] (source: faq.md)\nBalances update nightly.")
+	assert.NotEqual(t, server.responseCacheScope(ctx, "sess-3", nil), server.responseCacheScope(grounded, "sess-3", nil),
+		"the knowledge rendered into the prompt is part of the scope")
+}
+
+func TestTurnCalledTools(t *testing.T) {
+	tests := []struct {
+		name    string
+		history []types.Message
+		want    bool
+	}{
+		{"text answer", []types.Message{
+			{Role: "user", Content: "hi"},
+			{Role: "assistant", Content: "hello"},
+		}, false},
+		{"server tool call", []types.Message{
+			{Role: "user", Content: "weather?"},
+			{Role: "assistant", ToolCalls: []types.MessageToolCall{{ID: "1", Name: "weather"}}},
+			{Role: "tool", Content: "sunny"},
+			{Role: "assistant", Content: "It is sunny."},
+		}, true},
+		{"tool call in an earlier turn", []types.Message{
+			{Role: "user", Content: "weather?"},
+			{Role: "assistant", ToolCalls: []types.MessageToolCall{{ID: "1", Name: "weather"}}},
+			{Role: "tool", Content: "sunny"},
+			{Role: "assistant", Content: "It is sunny."},
+			{Role: "user", Content: "thanks"},
+			{Role: "assistant", Content: "You're welcome."},
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, turnCalledTools(tt.history))
+		})
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package responsecache answers repeated prompts from earlier responses
+// instead of calling the provider. Entries are keyed by a caller-supplied
+// scope (agent, prompt, model and conversation history) plus the normalized
+// prompt text. With an embedding provider and a similarity threshold, a prompt
+// that misses exactly is also matched against the scope's recent entries by
+// cosine similarity.
+//
+// The cache is fail-open: backend and embedding errors are logged, counted,
+// and treated as misses, so a cache outage costs provider calls, never turns.
+package responsecache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/pipeline/stage"
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/go-logr/logr"
+)
+
+// DefaultTTL is how long a cached response is served when Config.TTL is unset.
+const DefaultTTL = time.Hour
+
+// maxSimilarCandidates caps how many recent entries of a scope a similarity
+// lookup compares against.
+const maxSimilarCandidates = 200
+
+// Entry is a cached response.
+type Entry struct {
+	Prompt    string    `json:"prompt"`
+	Response  string    `json:"response"`
+	Embedding []float32 `json:"embedding,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Backend stores cache entries. Keys are unique across scopes.
+type Backend interface {
+	// Get returns the unexpired entry stored under key, or nil when there is none.
+	Get(ctx context.Context, key string) (*Entry, error)
+	// Put stores entry under key for ttl and indexes it under scope.
+	Put(ctx context.Context, scope, key string, entry *Entry, ttl time.Duration) error
+	// Recent returns up to limit unexpired entries indexed under scope, newest first.
+	Recent(ctx context.Context, scope string, limit int) ([]*Entry, error)
+}
+
+// Config configures a Cache.
+type Config struct {
+	// TTL is how long a cached response is served (DefaultTTL when zero).
+	TTL time.Duration
+	// SimilarityThreshold is the minimum cosine similarity for a
+	// near-identical match, in (0, 1]. Zero means exact matches only.
+	SimilarityThreshold float64
+	// Embedder embeds prompts for similarity matching. Nil means exact
+	// matches only.
+	Embedder providers.EmbeddingProvider
+	// Metrics records lookup outcomes. Optional.
+	Metrics *Metrics
+	// Log receives fail-open errors.
+	Log logr.Logger
+}
+
+// Cache is a response cache over a Backend.
+type Cache struct {
+	backend Backend
+	cfg     Config
+}
+
+// Query is a prompt looked up in the cache. Pass it to Save after a miss so
+// the response is stored under the same key without re-embedding the prompt.
+type Query struct {
+	scope     string
+	key       string
+	prompt    string
+	embedding []float32
+}
+
+// New creates a Cache over backend.
+func New(backend Backend, cfg Config) *Cache {
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultTTL
+	}
+	return &Cache{backend: backend, cfg: cfg}
+}
+
+// similarityEnabled reports whether near-identical prompts are matched.
+func (c *Cache) similarityEnabled() bool {
+	return c.cfg.Embedder != nil && c.cfg.SimilarityThreshold > 0
+}
+
+// Lookup returns the cached response for prompt in scope, and whether there
+// was one. The returned Query is always non-nil.
+func (c *Cache) Lookup(ctx context.Context, scope, prompt string) (string, *Query, bool) {
+	scopeID := hashString(scope)
+	q := &Query{
+		scope:  scopeID,
+		key:    scopeID + ":" + hashString(normalizePrompt(prompt)),
+		prompt: prompt,
+	}
+
+	entry, err := c.backend.Get(ctx, q.key)
+	if err != nil {
+		c.cfg.Log.Error(err, "response cache lookup failed")
+		c.cfg.Metrics.recordLookup(resultError)
+		return "", q, false
+	}
+	if entry != nil {
+		c.cfg.Metrics.recordLookup(resultExactHit)
+		return entry.Response, q, true
+	}
+
+	if !c.similarityEnabled() {
+		c.cfg.Metrics.recordLookup(resultMiss)
+		return "", q, false
+	}
+	response, ok, err := c.lookupSimilar(ctx, q)
+	switch {
+	case err != nil:
+		c.cfg.Log.Error(err, "response cache similarity lookup failed")
+		c.cfg.Metrics.recordLookup(resultError)
+	case ok:
+		c.cfg.Metrics.recordLookup(resultSimilarHit)
+	default:
+		c.cfg.Metrics.recordLookup(resultMiss)
+	}
+	return response, q, ok
+}
+
+// lookupSimilar embeds the query prompt and returns the response of the most
+// similar recent entry in its scope at or above the threshold.
+func (c *Cache) lookupSimilar(ctx context.Context, q *Query) (string, bool, error) {
+	resp, err := c.cfg.Embedder.Embed(ctx, providers.EmbeddingRequest{Texts: []string{q.prompt}})
+	if err != nil {
+		return "", false, err
+	}
+	if len(resp.Embeddings) == 0 {
+		return "", false, nil
+	}
+	q.embedding = resp.Embeddings[0]
+
+	candidates, err := c.backend.Recent(ctx, q.scope, maxSimilarCandidates)
+	if err != nil {
+		return "", false, err
+	}
+	var best *Entry
+	bestScore := c.cfg.SimilarityThreshold
+	for _, e := range candidates {
+		if len(e.Embedding) != len(q.embedding) {
+			continue
+		}
+		if score := stage.CosineSimilarity(q.embedding, e.Embedding); score >= bestScore {
+			best, bestScore = e, score
+		}
+	}
+	if best == nil {
+		return "", false, nil
+	}
+	return best.Response, true, nil
+}
+
+// Save caches response for the query's prompt.
+func (c *Cache) Save(ctx context.Context, q *Query, response string) {
+	if q == nil || response == "" {
+		return
+	}
+	if c.similarityEnabled() && q.embedding == nil {
+		resp, err := c.cfg.Embedder.Embed(ctx, providers.EmbeddingRequest{Texts: []string{q.prompt}})
+		if err != nil {
+			c.cfg.Log.Error(err, "response cache embedding failed; caching for exact matches only")
+		} else if len(resp.Embeddings) > 0 {
+			q.embedding = resp.Embeddings[0]
+		}
+	}
+	entry := &Entry{
+		Prompt:    q.prompt,
+		Response:  response,
+		Embedding: q.embedding,
+		CreatedAt: time.Now(),
+	}
+	if err := c.backend.Put(ctx, q.scope, q.key, entry, c.cfg.TTL); err != nil {
+		c.cfg.Log.Error(err, "response cache store failed")
+		c.cfg.Metrics.recordStore(resultError)
+		return
+	}
+	c.cfg.Metrics.recordStore(resultStored)
+}
+
+// normalizePrompt folds case and whitespace so trivially different spellings
+// of the same prompt share an exact-match key.
+func normalizePrompt(prompt string) string {
+	return strings.Join(strings.Fields(strings.ToLower(prompt)), " ")
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
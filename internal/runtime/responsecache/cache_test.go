@@ -0,0 +1,209 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsecache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEmbedder embeds known texts to fixed vectors and everything else to a
+// vector orthogonal to all of them.
+type fakeEmbedder struct {
+	vectors map[string][]float32
+	err     error
+	calls   int
+}
+
+func (f *fakeEmbedder) Embed(_ context.Context, req providers.EmbeddingRequest) (providers.EmbeddingResponse, error) {
+	f.calls++
+	if f.err != nil {
+		return providers.EmbeddingResponse{}, f.err
+	}
+	out := make([][]float32, len(req.Texts))
+	for i, text := range req.Texts {
+		v, ok := f.vectors[text]
+		if !ok {
+			v = []float32{0, 0, 1}
+		}
+		out[i] = v
+	}
+	return providers.EmbeddingResponse{Embeddings: out}, nil
+}
+
+func (f *fakeEmbedder) EmbeddingDimensions() int { return 3 }
+func (f *fakeEmbedder) MaxBatchSize() int        { return 16 }
+func (f *fakeEmbedder) ID() string               { return "fake" }
+
+// failingBackend fails every operation.
+type failingBackend struct{}
+
+func (failingBackend) Get(context.Context, string) (*Entry, error) {
+	return nil, errors.New("backend down")
+}
+
+func (failingBackend) Put(context.Context, string, string, *Entry, time.Duration) error {
+	return errors.New("backend down")
+}
+
+func (failingBackend) Recent(context.Context, string, int) ([]*Entry, error) {
+	return nil, errors.New("backend down")
+}
+
+func newTestMetrics(t *testing.T) *Metrics {
+	t.Helper()
+	return NewMetrics(prometheus.NewRegistry(), prometheus.Labels{"agent": "faq", "namespace": "test"})
+}
+
+func TestCache_ExactMatch(t *testing.T) {
+	metrics := newTestMetrics(t)
+	c := New(NewMemoryBackend(0), Config{Metrics: metrics, Log: logr.Discard()})
+	ctx := context.Background()
+
+	_, q, hit := c.Lookup(ctx, "scope", "What are your opening hours?")
+	require.False(t, hit)
+	c.Save(ctx, q, "9 to 5, Monday to Friday.")
+
+	got, _, hit := c.Lookup(ctx, "scope", "  what are YOUR opening\nhours? ")
+	require.True(t, hit, "case and whitespace do not matter")
+	assert.Equal(t, "9 to 5, Monday to Friday.", got)
+
+	_, _, hit = c.Lookup(ctx, "other-scope", "What are your opening hours?")
+	assert.False(t, hit, "entries are not shared across scopes")
+
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.lookups.WithLabelValues(resultExactHit)), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(metrics.lookups.WithLabelValues(resultMiss)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.stores.WithLabelValues(resultStored)), 0)
+}
+
+func TestCache_SimilarityMatch(t *testing.T) {
+	embedder := &fakeEmbedder{vectors: map[string][]float32{
+		"What are your opening hours?": {1, 0, 0},
+		"When are you open?":           {0.98, 0.2, 0},
+		"Where are you located?":       {0.5, 0.86, 0},
+	}}
+	metrics := newTestMetrics(t)
+	c := New(NewMemoryBackend(0), Config{
+		SimilarityThreshold: 0.95,
+		Embedder:            embedder,
+		Metrics:             metrics,
+		Log:                 logr.Discard(),
+	})
+	ctx := context.Background()
+
+	_, q, hit := c.Lookup(ctx, "scope", "What are your opening hours?")
+	require.False(t, hit)
+	c.Save(ctx, q, "9 to 5.")
+	assert.Equal(t, 1, embedder.calls, "the lookup's embedding is reused on save")
+
+	got, _, hit := c.Lookup(ctx, "scope", "When are you open?")
+	require.True(t, hit)
+	assert.Equal(t, "9 to 5.", got)
+
+	_, _, hit = c.Lookup(ctx, "scope", "Where are you located?")
+	assert.False(t, hit, "below the similarity threshold")
+
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.lookups.WithLabelValues(resultSimilarHit)), 0)
+}
+
+func TestCache_FailOpen(t *testing.T) {
+	metrics := newTestMetrics(t)
+	c := New(failingBackend{}, Config{Metrics: metrics, Log: logr.Discard()})
+	ctx := context.Background()
+
+	_, q, hit := c.Lookup(ctx, "scope", "hello")
+	assert.False(t, hit)
+	c.Save(ctx, q, "hi")
+
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.lookups.WithLabelValues(resultError)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.stores.WithLabelValues(resultError)), 0)
+
+	embedder := &fakeEmbedder{err: errors.New("embedding down")}
+	c = New(NewMemoryBackend(0), Config{SimilarityThreshold: 0.9, Embedder: embedder, Log: logr.Discard()})
+	_, q, hit = c.Lookup(ctx, "scope", "hello")
+	assert.False(t, hit)
+	c.Save(ctx, q, "hi")
+	got, _, hit := c.Lookup(ctx, "scope", "hello")
+	assert.True(t, hit, "an embedding outage still caches for exact matches")
+	assert.Equal(t, "hi", got)
+}
+
+func TestMemoryBackend_ExpiryAndBound(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	b := NewMemoryBackend(2)
+	b.now = func() time.Time { return now }
+
+	require.NoError(t, b.Put(ctx, "s", "a", &Entry{Response: "a", CreatedAt: now}, time.Minute))
+	require.NoError(t, b.Put(ctx, "s", "b", &Entry{Response: "b", CreatedAt: now.Add(time.Second)}, time.Hour))
+
+	now = now.Add(2 * time.Minute)
+	got, err := b.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Nil(t, got, "expired")
+
+	require.NoError(t, b.Put(ctx, "s", "c", &Entry{Response: "c", CreatedAt: now}, time.Hour))
+	require.NoError(t, b.Put(ctx, "s", "d", &Entry{Response: "d", CreatedAt: now.Add(time.Second)}, time.Hour))
+	recent, err := b.Recent(ctx, "s", 10)
+	require.NoError(t, err)
+	require.Len(t, recent, 2, "bounded to maxEntries")
+	assert.Equal(t, "d", recent[0].Response, "newest first")
+	assert.Equal(t, "c", recent[1].Response, "the oldest entry was evicted")
+}
+
+func TestRedisBackend(t *testing.T) {
+	mr := miniredis.RunT(t)
+	b := NewRedisBackend(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	ctx := context.Background()
+
+	got, err := b.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	now := time.Now()
+	require.NoError(t, b.Put(ctx, "s", "s:a", &Entry{Response: "a", Embedding: []float32{1, 0}, CreatedAt: now}, time.Hour))
+	require.NoError(t, b.Put(ctx, "s", "s:b", &Entry{Response: "b", CreatedAt: now}, 2*time.Hour))
+	require.NoError(t, b.Put(ctx, "other", "other:c", &Entry{Response: "c", CreatedAt: now}, time.Hour))
+
+	got, err = b.Get(ctx, "s:a")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, []float32{1, 0}, got.Embedding)
+	assert.Equal(t, time.Hour, mr.TTL(redisKeyPrefix+"s:a"))
+
+	recent, err := b.Recent(ctx, "s", 10)
+	require.NoError(t, err)
+	require.Len(t, recent, 2)
+	assert.Equal(t, "b", recent[0].Response, "longest-lived first")
+
+	mr.Del(redisKeyPrefix + "s:b")
+	recent, err = b.Recent(ctx, "s", 10)
+	require.NoError(t, err)
+	require.Len(t, recent, 1, "entries gone from Redis are skipped")
+	assert.Equal(t, "a", recent[0].Response)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsecache
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultMemoryMaxEntries bounds a MemoryBackend so a busy agent cannot grow
+// the runtime's heap without limit.
+const defaultMemoryMaxEntries = 10000
+
+// Compile-time interface check.
+var _ Backend = (*MemoryBackend)(nil)
+
+type memoryItem struct {
+	scope     string
+	entry     *Entry
+	expiresAt time.Time
+}
+
+// MemoryBackend is an in-process Backend. Entries are local to one runtime
+// pod; use RedisBackend to share them across replicas.
+type MemoryBackend struct {
+	mu         sync.Mutex
+	items      map[string]memoryItem
+	maxEntries int
+	now        func() time.Time
+}
+
+// NewMemoryBackend creates a MemoryBackend holding at most maxEntries entries
+// (a default bound when maxEntries <= 0). When full, expired entries are
+// dropped first, then the oldest.
+func NewMemoryBackend(maxEntries int) *MemoryBackend {
+	if maxEntries <= 0 {
+		maxEntries = defaultMemoryMaxEntries
+	}
+	return &MemoryBackend{
+		items:      make(map[string]memoryItem),
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+}
+
+// Get returns the unexpired entry stored under key.
+func (b *MemoryBackend) Get(_ context.Context, key string) (*Entry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	item, ok := b.items[key]
+	if !ok {
+		return nil, nil
+	}
+	if !b.now().Before(item.expiresAt) {
+		delete(b.items, key)
+		return nil, nil
+	}
+	return item.entry, nil
+}
+
+// Put stores entry under key for ttl.
+func (b *MemoryBackend) Put(_ context.Context, scope, key string, entry *Entry, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, exists := b.items[key]; !exists && len(b.items) >= b.maxEntries {
+		b.evictLocked()
+	}
+	b.items[key] = memoryItem{scope: scope, entry: entry, expiresAt: b.now().Add(ttl)}
+	return nil
+}
+
+// Recent returns up to limit unexpired entries in scope, newest first.
+func (b *MemoryBackend) Recent(_ context.Context, scope string, limit int) ([]*Entry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	var entries []*Entry
+	for _, item := range b.items {
+		if item.scope == scope && now.Before(item.expiresAt) {
+			entries = append(entries, item.entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].CreatedAt.After(entries[j].CreatedAt) })
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// evictLocked drops expired entries, or the oldest entry when none has
+// expired. Callers hold b.mu.
+func (b *MemoryBackend) evictLocked() {
+	now := b.now()
+	oldestKey := ""
+	var oldest time.Time
+	for key, item := range b.items {
+		if !now.Before(item.expiresAt) {
+			delete(b.items, key)
+			continue
+		}
+		if oldestKey == "" || item.entry.CreatedAt.Before(oldest) {
+			oldestKey, oldest = key, item.entry.CreatedAt
+		}
+	}
+	if len(b.items) >= b.maxEntries && oldestKey != "" {
+		delete(b.items, oldestKey)
+	}
+}
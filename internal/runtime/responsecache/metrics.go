@@ -0,0 +1,72 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsecache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Lookup and store outcomes.
+const (
+	resultExactHit   = "exact_hit"
+	resultSimilarHit = "similar_hit"
+	resultMiss       = "miss"
+	resultError      = "error"
+	resultStored     = "stored"
+)
+
+// Metrics counts response cache lookups and stores. The hit rate is
+//
+//	sum(rate(omnia_runtime_response_cache_lookups_total{result=~".*_hit"}[5m]))
+//	  / sum(rate(omnia_runtime_response_cache_lookups_total[5m]))
+//
+// A nil *Metrics records nothing.
+type Metrics struct {
+	lookups *prometheus.CounterVec
+	stores  *prometheus.CounterVec
+}
+
+// NewMetrics registers the response cache counters on reg with the given
+// constant labels (typically agent and namespace).
+func NewMetrics(reg prometheus.Registerer, constLabels prometheus.Labels) *Metrics {
+	m := &Metrics{
+		lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "omnia_runtime_response_cache_lookups_total",
+			Help:        "Response cache lookups by result (exact_hit|similar_hit|miss|error).",
+			ConstLabels: constLabels,
+		}, []string{"result"}),
+		stores: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "omnia_runtime_response_cache_stores_total",
+			Help:        "Responses written to the response cache by result (stored|error).",
+			ConstLabels: constLabels,
+		}, []string{"result"}),
+	}
+	reg.MustRegister(m.lookups, m.stores)
+	return m
+}
+
+func (m *Metrics) recordLookup(result string) {
+	if m == nil {
+		return
+	}
+	m.lookups.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) recordStore(result string) {
+	if m == nil {
+		return
+	}
+	m.stores.WithLabelValues(result).Inc()
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsecache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis key layout: one string per entry, plus one sorted set per scope whose
+// members are entry keys scored by expiry (unix millis), so similarity lookups
+// can list a scope's live entries newest first.
+const (
+	redisKeyPrefix   = "omnia:respcache:"
+	redisIndexSuffix = ":index"
+)
+
+// Compile-time interface check.
+var _ Backend = (*RedisBackend)(nil)
+
+// RedisBackend is a Backend shared by every runtime replica of an agent.
+type RedisBackend struct {
+	redis *redis.Client
+}
+
+// NewRedisBackend creates a RedisBackend on rdb.
+func NewRedisBackend(rdb *redis.Client) *RedisBackend {
+	return &RedisBackend{redis: rdb}
+}
+
+// Get returns the unexpired entry stored under key.
+func (b *RedisBackend) Get(ctx context.Context, key string) (*Entry, error) {
+	data, err := b.redis.Get(ctx, redisKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get response cache entry: %w", err)
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("decode response cache entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// Put stores entry under key for ttl and indexes it under scope. Expired
+// index members are trimmed on every write.
+func (b *RedisBackend) Put(ctx context.Context, scope, key string, entry *Entry, ttl time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode response cache entry: %w", err)
+	}
+	index := redisKeyPrefix + scope + redisIndexSuffix
+	now := time.Now()
+	pipe := b.redis.TxPipeline()
+	pipe.Set(ctx, redisKeyPrefix+key, data, ttl)
+	pipe.ZAdd(ctx, index, redis.Z{Score: float64(now.Add(ttl).UnixMilli()), Member: key})
+	pipe.ZRemRangeByScore(ctx, index, "-inf", strconv.FormatInt(now.UnixMilli(), 10))
+	pipe.Expire(ctx, index, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("put response cache entry: %w", err)
+	}
+	return nil
+}
+
+// Recent returns up to limit unexpired entries in scope, newest first.
+func (b *RedisBackend) Recent(ctx context.Context, scope string, limit int) ([]*Entry, error) {
+	keys, err := b.redis.ZRevRangeByScore(ctx, redisKeyPrefix+scope+redisIndexSuffix, &redis.ZRangeBy{
+		Min:   strconv.FormatInt(time.Now().UnixMilli(), 10),
+		Max:   "+inf",
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list response cache scope: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	for i, key := range keys {
+		keys[i] = redisKeyPrefix + key
+	}
+	values, err := b.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("load response cache entries: %w", err)
+	}
+	entries := make([]*Entry, 0, len(values))
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue // expired between the index read and the load
+		}
+		var entry Entry
+		if err := json.Unmarshal([]byte(s), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
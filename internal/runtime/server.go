@@ -18,6 +18,7 @@ package runtime
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -31,6 +32,7 @@ import (
 
 	"github.com/AltairaLabs/PromptKit/runtime/evals"
 	pkmetrics "github.com/AltairaLabs/PromptKit/runtime/metrics"
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
 	"github.com/AltairaLabs/PromptKit/runtime/statestore"
 
 	// Register all providers via blank imports
@@ -40,14 +42,22 @@ import (
 	_ "github.com/AltairaLabs/PromptKit/runtime/providers/ollama"
 	_ "github.com/AltairaLabs/PromptKit/runtime/providers/openai"
 	"github.com/AltairaLabs/PromptKit/sdk"
+	"github.com/altairalabs/omnia/pkg/apierror"
+	_ "github.com/altairalabs/omnia/pkg/provider/openaicompatible"
 	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
 
 	pkmemory "github.com/AltairaLabs/PromptKit/runtime/memory"
 	pkskills "github.com/AltairaLabs/PromptKit/runtime/skills"
 
 	"github.com/altairalabs/omnia/internal/media"
+	"github.com/altairalabs/omnia/internal/runtime/budget"
+	"github.com/altairalabs/omnia/internal/runtime/delegation"
+	"github.com/altairalabs/omnia/internal/runtime/guardrails"
+	"github.com/altairalabs/omnia/internal/runtime/resilience"
+	"github.com/altairalabs/omnia/internal/runtime/responsecache"
 	"github.com/altairalabs/omnia/internal/runtime/skills"
 	"github.com/altairalabs/omnia/internal/runtime/tools"
+	"github.com/altairalabs/omnia/internal/runtime/vectorstore"
 	"github.com/altairalabs/omnia/internal/session"
 	"github.com/altairalabs/omnia/internal/tracing"
 	"github.com/altairalabs/omnia/pkg/runtime/contract"
@@ -75,6 +85,7 @@ type Server struct {
 	outputSchemaJSON  []byte // spec.outputSchema bytes for json_schema mode
 	sdkOptions        []sdk.Option
 	conversations     map[string]*sdk.Conversation
+	conversationPacks map[string]string   // Pack version each open conversation was opened with
 	turnIndices       map[string]int      // Track turn count per session
 	unsubscribeFns    map[string][]func() // Event bus unsubscribe functions per session
 	conversationMu    sync.RWMutex
@@ -100,18 +111,29 @@ type Server struct {
 	providerRefName           string             // Provider CRD name (for per-provider attribution)
 	extraProviders            []ResolvedProvider // Non-default providers (embedding/tts/stt/image/inference)
 	model                     string
-	baseURL                   string            // Custom base URL for provider (e.g., Ollama endpoint)
-	headers                   map[string]string // Custom HTTP headers for every provider request
-	inputCostPer1K            float64           // CRD pricing: cost per 1K input tokens
-	outputCostPer1K           float64           // CRD pricing: cost per 1K output tokens
-	providerRequestTimeout    time.Duration     // Non-streaming HTTP timeout (0 = provider default)
-	providerStreamIdleTimeout time.Duration     // SSE stream idle timeout (0 = 30s default)
+	baseURL                   string             // Custom base URL for provider (e.g., Ollama endpoint)
+	headers                   map[string]string  // Custom HTTP headers for every provider request
+	unsupportedParams         []string           // Request params the provider rejects (openai-compatible)
+	providerCapabilities      []string           // Declared model capabilities (openai-compatible)
+	inputCostPer1K            float64            // CRD pricing: cost per 1K input tokens
+	outputCostPer1K           float64            // CRD pricing: cost per 1K output tokens
+	providerRequestTimeout    time.Duration      // Non-streaming HTTP timeout (0 = provider default)
+	providerStreamIdleTimeout time.Duration      // SSE stream idle timeout (0 = 30s default)
+	providerResilience        *resilience.Policy // Retries, hedging and circuit breaking (nil = PromptKit retries)
+	providerTLS               *tls.Config        // Client certificate for provider calls (nil = none)
+
+	// Context management (the SDK options are also set; these drive per-conversation wiring)
+	tokenBudget        int    // Configured contextWindow (0 = unset)
+	truncationStrategy string // "sliding", "summarize", "relevance", "custom" or ""
 
 	// Platform hosting configuration (empty platformType = direct provider access)
 	platformType     string // "bedrock", "vertex", or "azure"
 	platformRegion   string
 	platformProject  string
 	platformEndpoint string
+	// Azure OpenAI routing (empty = deployment named after the model, default api-version)
+	platformDeployment string
+	platformAPIVersion string
 
 	// Auth configuration for platform-hosted providers.
 	authType                  string // "workloadIdentity", "accessKey", "serviceAccount", "servicePrincipal"
@@ -151,6 +173,56 @@ type Server struct {
 	// counter-offer in RuntimeHello and preferred over the client's DuplexStart
 	// proposal. Nil means accept the client's proposed format.
 	duplexAudio *DuplexAudioParams
+
+	// Response cache (spec.responseCache). The cache itself is built on first
+	// use, once the embedding-role provider for similarity matching is known.
+	responseCacheBackend responsecache.Backend
+	responseCacheConfig  responsecache.Config
+	responseCacheOnce    sync.Once
+	responseCache        *responsecache.Cache
+
+	// embeddingProvider is built from the embedding-role provider on first use
+	// and shared by relevance truncation, the response cache and Embed.
+	embeddingProviderMu sync.Mutex
+	embeddingProvider   providers.EmbeddingProvider
+
+	// Structured output (spec.structuredOutput). The response schema is
+	// loaded from the pack by InitializeStructuredOutput.
+	structuredOutputEnabled    bool
+	structuredOutputMaxRepairs int
+	structuredOutput           *structuredOutput
+
+	// Knowledge retrieval (spec.knowledge). The pack is prepared by
+	// InitializeKnowledge; the store is closed with the server.
+	knowledgeStore    vectorstore.VectorStore
+	knowledgeTopK     int
+	knowledgeMinScore float64
+
+	// System prompt prefix from AgentPolicy transforms. The pack is prepared
+	// by InitializeSystemPromptPrefix.
+	systemPromptPrefix string
+
+	// Guardrail hooks (spec.guardrails). The chains, including the pack's
+	// metadata.guardrails, are built by InitializeGuardrails.
+	guardrailConfig  guardrails.Config
+	guardrailMetrics *guardrails.Metrics
+	guardrails       *guardrails.Guardrails
+
+	// Token and cost budgets (spec.budget). A session over its budget is
+	// rejected, or summarized and continued when budgetSummarize is set.
+	budget          *budget.Tracker
+	budgetSummarize bool
+
+	// Other agents offered as tools (spec.delegates).
+	delegator *delegation.Delegator
+
+	// PromptPack hot reload. ReloadPack restages the mounted pack at
+	// sourcePackPath and swaps packPath, packVersion, structuredOutput and
+	// guardrails together under packMu.
+	sourcePackPath string
+	packVersion    string
+	packStaged     []string // packs staged by ReloadPack, oldest first
+	packMu         sync.RWMutex
 }
 
 // ServerOption configures the server.
@@ -177,6 +249,7 @@ func WithSlogLogger(l *slog.Logger) ServerOption {
 func WithPackPath(path string) ServerOption {
 	return func(s *Server) {
 		s.packPath = path
+		s.sourcePackPath = path
 	}
 }
 
@@ -423,12 +496,33 @@ func WithHeaders(headers map[string]string) ServerOption {
 	}
 }
 
+// WithUnsupportedParams sets the request parameters the provider rejects.
+// Used for openai-compatible providers whose servers lag the OpenAI API.
+func WithUnsupportedParams(params []string) ServerOption {
+	return func(s *Server) {
+		s.unsupportedParams = params
+	}
+}
+
+// WithProviderCapabilities sets the model capabilities declared on the
+// Provider (e.g. "vision", "tools"). Used for openai-compatible providers,
+// where PromptKit cannot infer them from the model name.
+func WithProviderCapabilities(capabilities []string) ServerOption {
+	return func(s *Server) {
+		s.providerCapabilities = capabilities
+	}
+}
+
 // PlatformConfig holds the hyperscaler platform hosting configuration.
 type PlatformConfig struct {
 	Type     string // "bedrock", "vertex", or "azure"
 	Region   string
 	Project  string
 	Endpoint string
+	// Deployment is the Azure OpenAI deployment name; empty means the model name.
+	Deployment string
+	// APIVersion is the Azure OpenAI api-version; empty means PromptKit's default.
+	APIVersion string
 }
 
 // AuthConfig holds authentication configuration for platform-hosted providers.
@@ -449,6 +543,8 @@ func WithPlatform(p PlatformConfig) ServerOption {
 		s.platformRegion = p.Region
 		s.platformProject = p.Project
 		s.platformEndpoint = p.Endpoint
+		s.platformDeployment = p.Deployment
+		s.platformAPIVersion = p.APIVersion
 	}
 }
 
@@ -482,6 +578,23 @@ func WithProviderStreamIdleTimeout(d time.Duration) ServerOption {
 	}
 }
 
+// WithProviderResilience routes provider calls through policy's retries,
+// hedged requests and circuit breaker in place of PromptKit's built-in
+// retries. Nil keeps the built-in retries.
+func WithProviderResilience(policy *resilience.Policy) ServerOption {
+	return func(s *Server) {
+		s.providerResilience = policy
+	}
+}
+
+// WithProviderTLS sends provider calls over connections using cfg, which
+// carries the client certificate for providers that require mutual TLS.
+func WithProviderTLS(cfg *tls.Config) ServerOption {
+	return func(s *Server) {
+		s.providerTLS = cfg
+	}
+}
+
 // WithPricing sets the provider pricing from the CRD for cost calculation.
 // When set, PromptKit uses these rates instead of its built-in pricing tables.
 func WithPricing(inputCostPer1K, outputCostPer1K float64) ServerOption {
@@ -526,10 +639,11 @@ func WithSessionStore(store session.Store) ServerOption {
 // NewServer creates a new runtime server.
 func NewServer(opts ...ServerOption) *Server {
 	s := &Server{
-		conversations:  make(map[string]*sdk.Conversation),
-		turnIndices:    make(map[string]int),
-		unsubscribeFns: make(map[string][]func()),
-		healthy:        true,
+		conversations:     make(map[string]*sdk.Conversation),
+		conversationPacks: make(map[string]string),
+		turnIndices:       make(map[string]int),
+		unsubscribeFns:    make(map[string][]func()),
+		healthy:           true,
 		// Default both memory axes on; WithMemoryModes overrides from the CRD.
 		memoryRetrievalEnabled: true,
 		memoryToolsEnabled:     true,
@@ -553,6 +667,7 @@ func (s *Server) InitializeTools(ctx context.Context) error {
 	s.log.Info("initializing tools", "configPath", s.toolsConfigPath)
 
 	executor := tools.NewOmniaExecutor(s.log, s.tracingProvider)
+	executor.SetPolicyDecisionFunc(s.publishPolicyDecision)
 
 	if err := executor.LoadConfig(s.toolsConfigPath); err != nil {
 		return fmt.Errorf("failed to load tools config: %w", err)
@@ -763,12 +878,31 @@ func (s *Server) Converse(stream runtimev1.RuntimeService_ConverseServer) error
 
 			// Send a generic error to the client. The detailed error is
 			// logged above but must not be forwarded because it may contain
-			// sensitive information such as provider API keys.
+			// sensitive information such as provider API keys. A structured
+			// output failure only describes the model's response, a
+			// guardrail rejection the checked text and an exceeded budget
+			// the usage, so they are sent as is under their own codes. An
+			// open provider circuit is sent as retryable with its retry hint.
+			code, message := apierror.CodeInternalError, "an internal error occurred while processing the message"
+			var soErr *StructuredOutputError
+			var rej *guardrails.Rejection
+			var exceeded *budget.ExceededError
+			var open *resilience.OpenError
+			switch {
+			case errors.As(err, &soErr):
+				code, message = errorCodeStructuredOutputInvalid, soErr.Error()
+			case errors.As(err, &rej):
+				code, message = errorCodeGuardrailRejected, rej.Error()
+			case errors.As(err, &exceeded):
+				code, message = errorCodeBudgetExceeded, exceeded.Error()
+			case errors.As(err, &open):
+				code, message = errorCodeProviderUnavailable, providerUnavailableMessage(open)
+			}
 			_ = stream.Send(&runtimev1.ServerMessage{
 				Message: &runtimev1.ServerMessage_Error{
 					Error: &runtimev1.Error{
-						Code:    "INTERNAL_ERROR",
-						Message: "an internal error occurred while processing the message",
+						Code:    code,
+						Message: message,
 					},
 				},
 			})
@@ -797,7 +931,11 @@ func (s *Server) removeConversation(sessionID string) {
 		s.log.Error(err, "failed to close conversation", "sessionID", sessionID)
 	}
 	delete(s.conversations, sessionID)
+	delete(s.conversationPacks, sessionID)
 	delete(s.turnIndices, sessionID)
+	if s.budget != nil {
+		s.budget.Forget(sessionID)
+	}
 
 	s.log.V(1).Info("conversation removed", "sessionID", sessionID)
 }
@@ -816,6 +954,7 @@ func (s *Server) Close() error {
 		}
 	}
 	s.conversations = make(map[string]*sdk.Conversation)
+	s.conversationPacks = make(map[string]string)
 	s.turnIndices = make(map[string]int)
 	s.unsubscribeFns = make(map[string][]func())
 
@@ -828,6 +967,12 @@ func (s *Server) Close() error {
 		s.toolsInitialized = false
 	}
 
+	if s.knowledgeStore != nil {
+		if err := s.knowledgeStore.Close(); err != nil {
+			s.log.Error(err, "failed to close knowledge vector store")
+		}
+	}
+
 	// Shutdown tracing provider
 	if s.tracingProvider != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
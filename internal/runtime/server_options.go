@@ -20,6 +20,11 @@ import (
 	pkmemory "github.com/AltairaLabs/PromptKit/runtime/memory"
 	"github.com/AltairaLabs/PromptKit/sdk"
 	v1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+	"github.com/altairalabs/omnia/internal/runtime/budget"
+	"github.com/altairalabs/omnia/internal/runtime/delegation"
+	"github.com/altairalabs/omnia/internal/runtime/guardrails"
+	"github.com/altairalabs/omnia/internal/runtime/responsecache"
+	"github.com/altairalabs/omnia/internal/runtime/vectorstore"
 )
 
 // WithMemoryStore sets the memory store for cross-session memory.
@@ -83,6 +88,7 @@ func (s *Server) HasMediaResolver() bool {
 func WithContextWindow(tokens int) ServerOption {
 	return func(s *Server) {
 		if tokens > 0 {
+			s.tokenBudget = tokens
 			s.sdkOptions = append(s.sdkOptions, sdk.WithTokenBudget(tokens))
 		}
 	}
@@ -90,15 +96,83 @@ func WithContextWindow(tokens int) ServerOption {
 
 // WithTruncationStrategy sets the strategy for handling context overflow.
 // Valid values: "sliding" (remove oldest), "summarize" (summarize before
-// removing), "custom" (the runtime implements truncation itself — no SDK
+// removing), "relevance" (drop the messages least similar to the latest
+// user turn), "custom" (the runtime implements truncation itself — no SDK
 // truncation is configured). "custom" is intended for custom runtimes
 // (spec.framework.type: custom); on this PromptKit runtime it means no
 // truncation is applied at all, which cmd/runtime warns about at startup.
 func WithTruncationStrategy(strategy string) ServerOption {
 	return func(s *Server) {
+		s.truncationStrategy = strategy
 		// "custom" means the custom runtime handles it - don't set SDK truncation
 		if strategy != "" && strategy != string(v1alpha1.TruncationStrategyCustom) {
 			s.sdkOptions = append(s.sdkOptions, sdk.WithTruncation(strategy))
 		}
 	}
 }
+
+// WithResponseCache enables the response cache on backend. A similarity
+// threshold in cfg is honored only when spec.providers has an embedding-role
+// provider; cfg.Embedder is filled in from it on first use.
+func WithResponseCache(backend responsecache.Backend, cfg responsecache.Config) ServerOption {
+	return func(s *Server) {
+		s.responseCacheBackend = backend
+		s.responseCacheConfig = cfg
+	}
+}
+
+// WithKnowledge enables knowledge retrieval from store: each turn injects up
+// to topK chunks scoring at least minScore into the prompt. The pack is
+// prepared by InitializeKnowledge. The server closes store when it closes.
+func WithKnowledge(store vectorstore.VectorStore, topK int, minScore float64) ServerOption {
+	return func(s *Server) {
+		s.knowledgeStore = store
+		s.knowledgeTopK = topK
+		s.knowledgeMinScore = minScore
+	}
+}
+
+// WithSystemPromptPrefix prepends prefix to the active prompt's system
+// template. The pack is prepared by InitializeSystemPromptPrefix.
+func WithSystemPromptPrefix(prefix string) ServerOption {
+	return func(s *Server) {
+		s.systemPromptPrefix = prefix
+	}
+}
+
+// WithGuardrails adds the guardrail hooks of spec.guardrails, which run after
+// the pack's own, recording checks to metrics (which may be nil). The chains
+// are built by InitializeGuardrails.
+func WithGuardrails(cfg guardrails.Config, metrics *guardrails.Metrics) ServerOption {
+	return func(s *Server) {
+		s.guardrailConfig = cfg
+		s.guardrailMetrics = metrics
+	}
+}
+
+// WithBudget enforces the token and cost budgets tracked by tracker. With
+// summarize, a session over its budget has its history summarized and
+// continues instead of being rejected.
+func WithBudget(tracker *budget.Tracker, summarize bool) ServerOption {
+	return func(s *Server) {
+		s.budget = tracker
+		s.budgetSummarize = summarize
+	}
+}
+
+// WithDelegator offers the delegator's agents to every conversation as tools.
+func WithDelegator(d *delegation.Delegator) ServerOption {
+	return func(s *Server) {
+		s.delegator = d
+	}
+}
+
+// WithStructuredOutput enables structured output, asking the model to repair
+// an invalid response up to maxRepairs times. The response schema is loaded
+// by InitializeStructuredOutput.
+func WithStructuredOutput(enabled bool, maxRepairs int) ServerOption {
+	return func(s *Server) {
+		s.structuredOutputEnabled = enabled
+		s.structuredOutputMaxRepairs = maxRepairs
+	}
+}
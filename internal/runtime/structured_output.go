@@ -0,0 +1,238 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/PromptKit/sdk"
+	"github.com/go-logr/logr"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	"github.com/altairalabs/omnia/internal/schemautil"
+	"github.com/altairalabs/omnia/pkg/apierror"
+)
+
+// errorCodeStructuredOutputInvalid is the Error code sent when a turn's
+// response still does not satisfy the response schema after every repair
+// attempt.
+const errorCodeStructuredOutputInvalid = apierror.CodeStructuredOutputInvalid
+
+// validatorTypeJSONSchema is the PromptPack validator type that declares a
+// prompt's response schema.
+const validatorTypeJSONSchema = "json_schema"
+
+// structuredOutput is the response contract enforced when
+// spec.structuredOutput is enabled.
+type structuredOutput struct {
+	schemaJSON []byte
+	schema     *jsonschema.Schema
+	maxRepairs int
+}
+
+// StructuredOutputError reports a turn whose response did not satisfy the
+// response schema, including after the model was asked to repair it.
+type StructuredOutputError struct {
+	// Attempts is the number of responses the model produced for the turn.
+	Attempts int
+	// Err is the validation failure of the last response.
+	Err error
+}
+
+// Error implements error.
+func (e *StructuredOutputError) Error() string {
+	return fmt.Sprintf("response does not satisfy the response schema after %d attempt(s): %v", e.Attempts, e.Err)
+}
+
+// Unwrap returns the validation failure of the last response.
+func (e *StructuredOutputError) Unwrap() error { return e.Err }
+
+// InitializeStructuredOutput loads the response schema from the active
+// prompt's json_schema validator when structured output is enabled. The
+// runtime enforces the schema itself so it can ask the model to repair an
+// invalid response; PromptKit would replace it with a blocked message
+// instead, so the validator is switched off in a staged copy of the pack.
+// Call it after InitializeTools, which may also stage the pack. A prompt
+// that declares no schema is an error: serving free text to clients that
+// expect JSON would fail them silently.
+func (s *Server) InitializeStructuredOutput() error {
+	if !s.structuredOutputEnabled {
+		return nil
+	}
+	data, err := os.ReadFile(s.packPath)
+	if err != nil {
+		return fmt.Errorf("read pack: %w", err)
+	}
+	schemaJSON, staged, err := takeResponseSchema(data, s.promptName)
+	if err != nil {
+		return err
+	}
+	compiled, err := schemautil.CompileSchema(schemaJSON)
+	if err != nil {
+		return fmt.Errorf("prompt %q response schema: %w", s.promptName, err)
+	}
+
+	// Staged on the same writable mount as the tool-surfaced pack; see
+	// surfaceRegistryToolsInPack.
+	outPath := filepath.Join(packCacheDir(), "omnia-pack-structured-output.promptpack")
+	if err := os.WriteFile(outPath, staged, 0o600); err != nil {
+		return fmt.Errorf("stage pack: %w", err)
+	}
+	s.packPath = outPath
+	s.structuredOutput = &structuredOutput{
+		schemaJSON: schemaJSON,
+		schema:     compiled,
+		maxRepairs: s.structuredOutputMaxRepairs,
+	}
+	s.log.Info("structured output enabled",
+		"prompt", s.promptName, "maxRepairAttempts", s.structuredOutputMaxRepairs, "packPath", outPath)
+	return nil
+}
+
+// takeResponseSchema returns the schema of the first enabled json_schema
+// validator on promptName, and the pack with that prompt's json_schema
+// validators disabled.
+func takeResponseSchema(data []byte, promptName string) (schema, staged []byte, err error) {
+	var pack map[string]json.RawMessage
+	if err = json.Unmarshal(data, &pack); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal pack: %w", err)
+	}
+	var prompts map[string]json.RawMessage
+	if err = json.Unmarshal(pack["prompts"], &prompts); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal prompts: %w", err)
+	}
+	rawPrompt, ok := prompts[promptName]
+	if !ok {
+		return nil, nil, fmt.Errorf("prompt %q not found in pack", promptName)
+	}
+	var prompt map[string]json.RawMessage
+	if err = json.Unmarshal(rawPrompt, &prompt); err != nil {
+		return nil, nil, fmt.Errorf("prompt %q: %w", promptName, err)
+	}
+	var validators []map[string]json.RawMessage
+	if raw, ok := prompt["validators"]; ok {
+		if err = json.Unmarshal(raw, &validators); err != nil {
+			return nil, nil, fmt.Errorf("prompt %q validators: %w", promptName, err)
+		}
+	}
+
+	for _, v := range validators {
+		var fields struct {
+			Type    string `json:"type"`
+			Enabled bool   `json:"enabled"`
+			Params  struct {
+				Schema json.RawMessage `json:"schema"`
+			} `json:"params"`
+		}
+		raw, _ := json.Marshal(v)
+		if json.Unmarshal(raw, &fields) != nil || fields.Type != validatorTypeJSONSchema || !fields.Enabled {
+			continue
+		}
+		if schema == nil && bytes.HasPrefix(bytes.TrimSpace(fields.Params.Schema), []byte("{")) {
+			schema = fields.Params.Schema
+		}
+		v["enabled"] = json.RawMessage("false")
+	}
+	if schema == nil {
+		return nil, nil, fmt.Errorf("prompt %q declares no response schema: "+
+			"add an enabled %s validator with params.schema to the prompt", promptName, validatorTypeJSONSchema)
+	}
+
+	if prompt["validators"], err = json.Marshal(validators); err != nil {
+		return nil, nil, fmt.Errorf("marshal validators: %w", err)
+	}
+	if prompts[promptName], err = json.Marshal(prompt); err != nil {
+		return nil, nil, fmt.Errorf("marshal prompt: %w", err)
+	}
+	if pack["prompts"], err = json.Marshal(prompts); err != nil {
+		return nil, nil, fmt.Errorf("marshal prompts: %w", err)
+	}
+	if staged, err = json.Marshal(pack); err != nil {
+		return nil, nil, fmt.Errorf("marshal pack: %w", err)
+	}
+	return schema, staged, nil
+}
+
+// responseFormat asks the provider for output constrained to the schema.
+// Strict mode is off: strict providers reject schemas outside their subset
+// (OpenAI requires every property to be required), and the runtime validates
+// the response against the full schema regardless.
+func (so *structuredOutput) responseFormat(schemaName string) *providers.ResponseFormat {
+	return &providers.ResponseFormat{
+		Type:       providers.ResponseFormatJSONSchema,
+		JSONSchema: so.schemaJSON,
+		SchemaName: schemaName,
+	}
+}
+
+// validate reports why text is not a JSON document satisfying the schema.
+func (so *structuredOutput) validate(text string) error {
+	var doc any
+	if err := json.Unmarshal([]byte(text), &doc); err != nil {
+		return fmt.Errorf("response is not valid JSON: %w", err)
+	}
+	return so.schema.Validate(doc)
+}
+
+// repairPrompt is the follow-up message asking the model to fix an invalid
+// response.
+func repairPrompt(verr error) string {
+	return fmt.Sprintf("Your previous response did not satisfy the required JSON schema: %v\n"+
+		"Reply again with only a JSON document that satisfies the schema.", verr)
+}
+
+// enforceStructuredOutput validates the turn's response against the response
+// schema, asking the model to repair an invalid one up to maxRepairs times.
+// Text chunks are held back in structured output mode (see handleChunkText);
+// processMessage sends the valid response it returns as a single chunk. Repair
+// requests and the responses to them become part of the conversation history.
+func (s *Server) enforceStructuredOutput(
+	ctx context.Context,
+	so *structuredOutput,
+	conv *sdk.Conversation,
+	finalResponse *sdk.Response,
+	accumulatedContent string,
+	log logr.Logger,
+) (*sdk.Response, string, error) {
+	for attempt := 1; ; attempt++ {
+		text := accumulatedContent
+		if finalResponse != nil {
+			text = finalResponse.Text()
+		}
+		verr := so.validate(text)
+		if verr == nil {
+			return finalResponse, text, nil
+		}
+		if attempt > so.maxRepairs {
+			return nil, "", &StructuredOutputError{Attempts: attempt, Err: verr}
+		}
+
+		log.V(1).Info("response does not satisfy the response schema; asking the model to repair it",
+			"attempt", attempt, "error", verr.Error())
+		resp, err := conv.Send(ctx, repairPrompt(verr))
+		if err != nil {
+			return nil, "", fmt.Errorf("structured output repair: %w", err)
+		}
+		finalResponse, accumulatedContent = resp, resp.Text()
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/AltairaLabs/PromptKit/runtime/statestore"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
+)
+
+const structuredOutputPack = `{
+	"id": "test-pack",
+	"name": "test-pack",
+	"version": "1.0.0",
+	"template_engine": { "version": "v1", "syntax": "{{variable}}" },
+	"prompts": {
+		"default": {
+			"id": "default",
+			"name": "default",
+			"version": "1.0.0",
+			"system_template": "You are a test assistant.",
+			"validators": [
+				{ "type": "banned_words", "enabled": true, "params": { "words": ["x"] } },
+				{ "type": "json_schema", "enabled": false, "params": { "schema": { "type": "array" } } },
+				{ "type": "json_schema", "enabled": true, "params": { "schema": {
+					"type": "object",
+					"properties": { "answer": { "type": "string" } },
+					"required": ["answer"]
+				} } }
+			]
+		}
+	}
+}`
+
+func TestTakeResponseSchema(t *testing.T) {
+	schema, staged, err := takeResponseSchema([]byte(structuredOutputPack), "default")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object","properties":{"answer":{"type":"string"}},"required":["answer"]}`, string(schema),
+		"the first enabled json_schema validator declares the schema")
+
+	var pack struct {
+		Prompts map[string]struct {
+			Validators []struct {
+				Type    string `json:"type"`
+				Enabled bool   `json:"enabled"`
+			} `json:"validators"`
+		} `json:"prompts"`
+	}
+	require.NoError(t, json.Unmarshal(staged, &pack))
+	validators := pack.Prompts["default"].Validators
+	require.Len(t, validators, 3)
+	assert.True(t, validators[0].Enabled, "other validators still run")
+	assert.False(t, validators[2].Enabled, "the runtime enforces the schema instead of the guardrail")
+
+	_, _, err = takeResponseSchema([]byte(structuredOutputPack), "missing")
+	assert.ErrorContains(t, err, `prompt "missing" not found`)
+
+	noSchema := `{"prompts": {"default": {"validators": [{"type": "json_schema", "enabled": false, "params": {"schema": {}}}]}}}`
+	_, _, err = takeResponseSchema([]byte(noSchema), "default")
+	assert.ErrorContains(t, err, "declares no response schema")
+}
+
+func newStructuredOutputServer(t *testing.T, mockResponse string, maxRepairs int) *Server {
+	t.Helper()
+	t.Setenv("OMNIA_PACK_CACHE_DIR", t.TempDir())
+	dir := t.TempDir()
+	packPath := filepath.Join(dir, "pack.promptpack")
+	require.NoError(t, writeTestFile(t, packPath, structuredOutputPack))
+	mockPath := filepath.Join(dir, "mock.yaml")
+	require.NoError(t, writeTestFile(t, mockPath, "defaultResponse: '"+mockResponse+"'\n"))
+
+	server := NewServer(
+		WithLogger(logr.Discard()),
+		WithPackPath(packPath),
+		WithPromptName("default"),
+		WithMockProvider(true),
+		WithMockConfigPath(mockPath),
+		WithStructuredOutput(true, maxRepairs),
+		WithStateStore(statestore.NewMemoryStore()),
+	)
+	require.NoError(t, server.InitializeStructuredOutput())
+	t.Cleanup(func() { _ = server.Close() })
+	return server
+}
+
+func TestConverse_StructuredOutput(t *testing.T) {
+	server := newStructuredOutputServer(t, `{"answer": "42"}`, 1)
+
+	stream := newMockStream(context.Background(), []*runtimev1.ClientMessage{
+		{SessionId: "sess-1", Content: "What is the answer?"},
+	})
+	_ = server.Converse(stream)
+
+	var chunks []string
+	var done *runtimev1.Done
+	for _, msg := range stream.sentMessages {
+		require.Nil(t, msg.GetError(), "turn failed: %v", msg.GetError())
+		if c := msg.GetChunk(); c != nil {
+			chunks = append(chunks, c.GetContent())
+		}
+		if msg.GetDone() != nil {
+			done = msg.GetDone()
+		}
+	}
+	require.NotNil(t, done)
+	assert.JSONEq(t, `{"answer": "42"}`, done.GetFinalContent())
+	assert.Equal(t, []string{done.GetFinalContent()}, chunks, "the validated response is sent as one chunk")
+}
+
+func TestConverse_StructuredOutputInvalid(t *testing.T) {
+	server := newStructuredOutputServer(t, `{"result": 42}`, 1)
+
+	stream := newMockStream(context.Background(), []*runtimev1.ClientMessage{
+		{SessionId: "sess-1", Content: "What is the answer?"},
+	})
+	_ = server.Converse(stream)
+
+	var errMsg *runtimev1.Error
+	for _, msg := range stream.sentMessages {
+		assert.Nil(t, msg.GetChunk(), "an invalid response is never sent")
+		assert.Nil(t, msg.GetDone())
+		if msg.GetError() != nil {
+			errMsg = msg.GetError()
+		}
+	}
+	require.NotNil(t, errMsg)
+	assert.Equal(t, errorCodeStructuredOutputInvalid, errMsg.GetCode())
+	assert.Contains(t, errMsg.GetMessage(), "after 2 attempt(s)")
+	assert.Contains(t, errMsg.GetMessage(), "answer")
+
+	conv, err := server.getOrCreateConversation(context.Background(), "sess-1")
+	require.NoError(t, err)
+	history := conv.Messages(context.Background())
+	require.Len(t, history, 4, "the repair request is part of the history")
+	assert.Contains(t, history[2].GetContent(), "did not satisfy the required JSON schema")
+}
+
+func TestInitializeStructuredOutput_NoSchema(t *testing.T) {
+	packPath := filepath.Join(t.TempDir(), "pack.promptpack")
+	require.NoError(t, writeTestFile(t, packPath, `{"prompts": {"default": {"id": "default"}}}`))
+	server := NewServer(
+		WithLogger(logr.Discard()),
+		WithPackPath(packPath),
+		WithPromptName("default"),
+		WithStructuredOutput(true, 1),
+	)
+	assert.ErrorContains(t, server.InitializeStructuredOutput(), "declares no response schema")
+
+	server = NewServer(WithLogger(logr.Discard()), WithPackPath(packPath), WithPromptName("default"))
+	require.NoError(t, server.InitializeStructuredOutput(), "a no-op when structured output is off")
+	assert.Equal(t, packPath, server.packPath)
+}
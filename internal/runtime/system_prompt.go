@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// InitializeSystemPromptPrefix prepends the AgentPolicy transforms' system
+// prompt prefix to the active prompt's system template in a staged copy of the
+// pack. Call it after InitializeTools, which may also stage the pack.
+func (s *Server) InitializeSystemPromptPrefix() error {
+	if s.systemPromptPrefix == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.packPath)
+	if err != nil {
+		return fmt.Errorf("read pack: %w", err)
+	}
+	staged, _, err := addSystemPromptPrefix(data, s.promptName, s.systemPromptPrefix)
+	if err != nil {
+		return err
+	}
+	// Staged on the same writable mount as the tool-surfaced pack; see
+	// surfaceRegistryToolsInPack.
+	outPath := filepath.Join(packCacheDir(), "omnia-pack-system-prompt.promptpack")
+	if err := os.WriteFile(outPath, staged, 0o600); err != nil {
+		return fmt.Errorf("stage pack: %w", err)
+	}
+	s.packPath = outPath
+	s.log.Info("system prompt prefix applied", "prompt", s.promptName, "packPath", s.packPath)
+	return nil
+}
+
+// addSystemPromptPrefix prepends prefix to promptName's system template.
+func addSystemPromptPrefix(data []byte, promptName, prefix string) (staged []byte, changed bool, err error) {
+	return rewriteSystemTemplate(data, promptName, func(template string) (string, bool) {
+		if template == "" {
+			return prefix, true
+		}
+		return prefix + "\n\n" + template, true
+	})
+}
+
+// rewriteSystemTemplate applies rewrite to promptName's system template,
+// leaving every other pack field as it was. It reports whether rewrite
+// changed the template; an unchanged pack is returned as data.
+func rewriteSystemTemplate(data []byte, promptName string, rewrite func(string) (string, bool)) (staged []byte, changed bool, err error) {
+	var pack map[string]json.RawMessage
+	if err = json.Unmarshal(data, &pack); err != nil {
+		return nil, false, fmt.Errorf("unmarshal pack: %w", err)
+	}
+	var prompts map[string]json.RawMessage
+	if err = json.Unmarshal(pack["prompts"], &prompts); err != nil {
+		return nil, false, fmt.Errorf("unmarshal prompts: %w", err)
+	}
+	rawPrompt, ok := prompts[promptName]
+	if !ok {
+		return nil, false, fmt.Errorf("prompt %q not found in pack", promptName)
+	}
+	var prompt map[string]json.RawMessage
+	if err = json.Unmarshal(rawPrompt, &prompt); err != nil {
+		return nil, false, fmt.Errorf("prompt %q: %w", promptName, err)
+	}
+	var template string
+	if raw, ok := prompt["system_template"]; ok {
+		if err = json.Unmarshal(raw, &template); err != nil {
+			return nil, false, fmt.Errorf("prompt %q system_template: %w", promptName, err)
+		}
+	}
+	if template, changed = rewrite(template); !changed {
+		return data, false, nil
+	}
+
+	if prompt["system_template"], err = json.Marshal(template); err != nil {
+		return nil, false, fmt.Errorf("marshal system_template: %w", err)
+	}
+	if prompts[promptName], err = json.Marshal(prompt); err != nil {
+		return nil, false, fmt.Errorf("marshal prompt: %w", err)
+	}
+	if pack["prompts"], err = json.Marshal(prompts); err != nil {
+		return nil, false, fmt.Errorf("marshal prompts: %w", err)
+	}
+	if staged, err = json.Marshal(pack); err != nil {
+		return nil, false, fmt.Errorf("marshal pack: %w", err)
+	}
+	return staged, true, nil
+}
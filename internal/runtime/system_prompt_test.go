@@ -0,0 +1,76 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func systemTemplate(t *testing.T, data []byte, promptName string) string {
+	t.Helper()
+	var pack struct {
+		Prompts map[string]struct {
+			SystemTemplate string `json:"system_template"`
+		} `json:"prompts"`
+	}
+	require.NoError(t, json.Unmarshal(data, &pack))
+	return pack.Prompts[promptName].SystemTemplate
+}
+
+func TestAddSystemPromptPrefix(t *testing.T) {
+	staged, changed, err := addSystemPromptPrefix([]byte(knowledgePack), "default", "Follow the Acme policy.")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "Follow the Acme policy.\n\nYou are a support assistant.", systemTemplate(t, staged, "default"))
+
+	_, _, err = addSystemPromptPrefix([]byte(knowledgePack), "missing", "Follow the Acme policy.")
+	assert.ErrorContains(t, err, `prompt "missing" not found`)
+}
+
+func TestInitializeSystemPromptPrefix(t *testing.T) {
+	t.Setenv("OMNIA_PACK_CACHE_DIR", t.TempDir())
+	packPath := filepath.Join(t.TempDir(), "pack.promptpack")
+	require.NoError(t, writeTestFile(t, packPath, knowledgePack))
+
+	server := NewServer(WithLogger(logr.Discard()), WithPackPath(packPath), WithPromptName("default"))
+	require.NoError(t, server.InitializeSystemPromptPrefix(), "a no-op without a prefix")
+	assert.Equal(t, packPath, server.packPath)
+
+	server = NewServer(
+		WithLogger(logr.Discard()),
+		WithPackPath(packPath),
+		WithPromptName("default"),
+		WithSystemPromptPrefix("Follow the Acme policy."),
+	)
+	require.NoError(t, server.InitializeSystemPromptPrefix())
+	require.NotEqual(t, packPath, server.packPath)
+	staged, err := os.ReadFile(server.packPath)
+	require.NoError(t, err)
+	assert.Equal(t, "Follow the Acme policy.\n\nYou are a support assistant.", systemTemplate(t, staged, "default"))
+
+	// A reloaded pack gets the prefix too, once.
+	restaged, _, _, err := server.stagePack([]byte(knowledgePack))
+	require.NoError(t, err)
+	assert.Equal(t, "Follow the Acme policy.\n\nYou are a support assistant.", systemTemplate(t, restaged, "default"))
+}
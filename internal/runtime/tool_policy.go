@@ -0,0 +1,106 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/AltairaLabs/PromptKit/sdk"
+
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+// eventPolicyDecision is recorded in the session for every ToolPolicy
+// decision on a tool call.
+const eventPolicyDecision events.EventType = "policy.decision"
+
+// Values of a policy.decision event's decision field.
+const (
+	policyDecisionAllow     = "allow"
+	policyDecisionDeny      = "deny"
+	policyDecisionWouldDeny = "would_deny"
+)
+
+// sessionConversationKey is the context key of the conversation a turn runs
+// in, for callbacks that only receive the turn's context.
+type sessionConversationKey struct{}
+
+// sessionConversation is the conversation and session a turn runs in.
+type sessionConversation struct {
+	conv      *sdk.Conversation
+	sessionID string
+}
+
+// withSessionConversation returns ctx carrying the turn's conversation.
+func withSessionConversation(ctx context.Context, conv *sdk.Conversation, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionConversationKey{}, sessionConversation{conv: conv, sessionID: sessionID})
+}
+
+// publishPolicyDecision records a ToolPolicy decision on a tool call in the
+// session: the policy and rule that decided it, and a hash of the call's
+// arguments rather than the arguments themselves. Calls outside a session, as
+// in function mode, are left to the broker's metrics and decision logs.
+func (s *Server) publishPolicyDecision(ctx context.Context, toolName string, args json.RawMessage, decision *policy.DecisionResponse) {
+	sc, ok := ctx.Value(sessionConversationKey{}).(sessionConversation)
+	if !ok {
+		return
+	}
+	bus := sc.conv.EventBus()
+	if bus == nil {
+		return
+	}
+	// A broker that cannot be reached denies without a policy.
+	by := decision.DeniedBy
+	if decision.Policy != "" {
+		by = fmt.Sprintf("policy %s (rule %s)", decision.Policy, decision.DeniedBy)
+	}
+	outcome, message := policyDecisionAllow, fmt.Sprintf("tool %s allowed", toolName)
+	switch {
+	case !decision.Allow && !decision.WouldDeny:
+		outcome = policyDecisionDeny
+		message = fmt.Sprintf("tool %s denied by %s", toolName, by)
+	case decision.WouldDeny:
+		outcome = policyDecisionWouldDeny
+		message = fmt.Sprintf("tool %s would have been denied by %s", toolName, by)
+	}
+	sum := sha256.Sum256(args)
+	bus.Publish(&events.Event{
+		Type:           eventPolicyDecision,
+		Timestamp:      time.Now(),
+		SessionID:      sc.sessionID,
+		ConversationID: sc.conv.ID(),
+		Data: &events.CustomEventData{
+			EventName: string(eventPolicyDecision),
+			Data: map[string]any{
+				"tool":          toolName,
+				"decision":      outcome,
+				"policy":        decision.Policy,
+				"rule":          decision.DeniedBy,
+				"mode":          decision.Mode,
+				"message":       decision.Message,
+				"contentSHA256": hex.EncodeToString(sum[:]),
+			},
+			Message: message,
+		},
+	})
+}
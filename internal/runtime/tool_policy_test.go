@@ -0,0 +1,69 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/AltairaLabs/PromptKit/runtime/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/runtime/guardrails"
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+func TestPublishPolicyDecision(t *testing.T) {
+	server := newGuardrailsServer(t, "ok", guardrails.Config{})
+	recorded := eventRecorder(t, server, "sess-1", eventPolicyDecision)
+	conv, err := server.getOrCreateConversation(context.Background(), "sess-1")
+	require.NoError(t, err)
+	ctx := withSessionConversation(context.Background(), conv, "sess-1")
+	args := json.RawMessage(`{"amount":250}`)
+
+	wouldDeny := &policy.DecisionResponse{
+		Allow: true, WouldDeny: true, Mode: "shadow",
+		Policy: "refunds", DeniedBy: "no-large-refunds", Message: "refunds over 100 need approval",
+	}
+	server.publishPolicyDecision(context.Background(), "issue_refund", args, wouldDeny)
+	server.publishPolicyDecision(ctx, "issue_refund", args, wouldDeny)
+	server.publishPolicyDecision(ctx, "issue_refund", args, &policy.DecisionResponse{Allow: true, Mode: "enforce"})
+	server.publishPolicyDecision(ctx, "issue_refund", args, &policy.DecisionResponse{DeniedBy: "policy-broker-unreachable"})
+
+	require.Eventually(t, func() bool { return len(recorded()) == 3 }, 5*time.Second, 10*time.Millisecond)
+	byDecision := map[string]*events.CustomEventData{}
+	for _, e := range recorded() {
+		assert.Equal(t, "sess-1", e.SessionID)
+		d := e.Data.(*events.CustomEventData)
+		byDecision[d.Data["decision"].(string)] = d
+	}
+
+	sum := sha256.Sum256(args)
+	assert.Equal(t, map[string]any{
+		"tool": "issue_refund", "decision": "would_deny", "policy": "refunds", "rule": "no-large-refunds",
+		"mode": "shadow", "message": "refunds over 100 need approval", "contentSHA256": hex.EncodeToString(sum[:]),
+	}, byDecision["would_deny"].Data)
+	assert.Equal(t, "tool issue_refund would have been denied by policy refunds (rule no-large-refunds)",
+		byDecision["would_deny"].Message)
+	assert.Equal(t, "tool issue_refund allowed", byDecision["allow"].Message)
+	assert.Equal(t, "tool issue_refund denied by policy-broker-unreachable", byDecision["deny"].Message)
+}
@@ -32,6 +32,7 @@ import (
 	pktools "github.com/AltairaLabs/PromptKit/runtime/tools"
 
 	"github.com/altairalabs/omnia/internal/tracing"
+	"github.com/altairalabs/omnia/pkg/policy"
 	toolsv1 "github.com/altairalabs/omnia/pkg/tools/v1"
 )
 
@@ -91,6 +92,9 @@ type OmniaExecutor struct {
 	// policyBroker enforces ToolPolicy decisions per tool call. Disabled
 	// (zero behavior change) unless POLICY_BROKER_URL is set.
 	policyBroker *PolicyBrokerClient
+	// policyDecided, when set, is told of each decision the policy broker
+	// makes.
+	policyDecided PolicyDecisionFunc
 
 	// tokenAcquirer resolves workloadIdentity auth for HTTP/OpenAPI handlers.
 	// nil (safe default) unless the ambient environment has an Azure identity;
@@ -343,6 +347,17 @@ func (e *OmniaExecutor) GetToolMeta(toolName string) (ToolMeta, bool) {
 	return meta, ok
 }
 
+// PolicyDecisionFunc receives the policy broker's decision on a tool call,
+// with the call's arguments, before the call is dispatched or aborted.
+type PolicyDecisionFunc func(ctx context.Context, toolName string, args json.RawMessage, decision *policy.DecisionResponse)
+
+// SetPolicyDecisionFunc sets the function told of each decision the policy
+// broker makes. It must be set before tool calls are served, and is not
+// called while the broker client is disabled.
+func (e *OmniaExecutor) SetPolicyDecisionFunc(fn PolicyDecisionFunc) {
+	e.policyDecided = fn
+}
+
 // SetRegistryInfo populates registry metadata for tracing/events.
 func (e *OmniaExecutor) SetRegistryInfo(registryName, registryNamespace string, cfgHandlers []HandlerEntry) {
 	e.mu.Lock()
@@ -428,11 +443,12 @@ func (e *OmniaExecutor) dispatch(
 }
 
 // enforcePolicy calls the policy broker for a decision on this tool call.
-// A real denial (enforce mode, not matched-but-audit) aborts dispatch with
-// errPolicyDenied. An allow — including audit-mode "would deny" — proceeds,
+// A real denial (enforce mode, not matched-but-shadow) aborts dispatch with
+// errPolicyDenied. An allow — including shadow-mode "would deny" — proceeds,
 // stashing any broker-injected headers on ctx for the executor's
-// header/metadata builder to merge in. Decide never fails transport-side
-// (fail-mode always resolves to a decision), so there is no error path here.
+// header/metadata builder to merge in. Every decision is reported to the
+// PolicyDecisionFunc first. Decide never fails transport-side (fail-mode
+// always resolves to a decision), so there is no error path here.
 func (e *OmniaExecutor) enforcePolicy(
 	ctx context.Context,
 	toolName, handlerName string,
@@ -461,6 +477,10 @@ func (e *OmniaExecutor) enforcePolicy(
 	decision := e.policyBroker.Decide(ctx, toolName, registryName, args)
 	e.log.V(1).Info("enforcePolicy decision", "tool", toolName, "allow", decision.Allow, "wouldDeny", decision.WouldDeny, "deniedBy", decision.DeniedBy)
 
+	if e.policyDecided != nil && e.policyBroker.Enabled() {
+		e.policyDecided(ctx, toolName, args, decision)
+	}
+
 	if !decision.Allow && !decision.WouldDeny {
 		return ctx, fmt.Errorf("%w: %s (rule %q)", errPolicyDenied, decision.Message, decision.DeniedBy)
 	}
@@ -452,6 +452,57 @@ func TestDispatch_PolicyBrokerAuditWouldDeny_ProceedsWithCall(t *testing.T) {
 	assert.True(t, toolCalled, "audit-mode wouldDeny must not block the call")
 }
 
+func TestDispatch_PolicyBrokerDecisionsReported(t *testing.T) {
+	var captured http.Header
+	toolSrv := newHTTPToolServer(t, &captured)
+	defer toolSrv.Close()
+
+	answer := `{"allow":true,"wouldDeny":true,"deniedBy":"no-refunds","message":"refunds need approval","mode":"shadow","policy":"refunds"}`
+	brokerSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(answer))
+	}))
+	defer brokerSrv.Close()
+	t.Setenv(envPolicyBrokerURL, brokerSrv.URL)
+
+	e := newHTTPToolExecutor(toolSrv)
+	var reported []*policy.DecisionResponse
+	e.SetPolicyDecisionFunc(func(_ context.Context, toolName string, args json.RawMessage, d *policy.DecisionResponse) {
+		assert.Equal(t, "test-http-tool", toolName)
+		assert.JSONEq(t, `{"amount":50}`, string(args))
+		reported = append(reported, d)
+	})
+
+	_, err := e.ExecuteTool(context.Background(), "test-http-tool", json.RawMessage(`{"amount":50}`))
+	require.NoError(t, err)
+	assert.NotNil(t, captured, "a shadow-mode would-deny must not block the call")
+
+	answer = `{"allow":false,"deniedBy":"max-amount","message":"too much","mode":"enforce","policy":"limits"}`
+	_, err = e.ExecuteTool(context.Background(), "test-http-tool", json.RawMessage(`{"amount":50}`))
+	require.ErrorIs(t, err, errPolicyDenied)
+
+	require.Len(t, reported, 2, "allows, would-denies and denials are all reported")
+	assert.Equal(t, "refunds", reported[0].Policy)
+	assert.True(t, reported[0].WouldDeny)
+	assert.Equal(t, "max-amount", reported[1].DeniedBy)
+}
+
+func TestDispatch_PolicyBrokerDisabled_DecisionsNotReported(t *testing.T) {
+	t.Setenv(envPolicyBrokerURL, "")
+
+	var captured http.Header
+	toolSrv := newHTTPToolServer(t, &captured)
+	defer toolSrv.Close()
+
+	e := newHTTPToolExecutor(toolSrv)
+	e.SetPolicyDecisionFunc(func(context.Context, string, json.RawMessage, *policy.DecisionResponse) {
+		t.Error("no decision is reported while the broker client is disabled")
+	})
+
+	_, err := e.ExecuteTool(context.Background(), "test-http-tool", json.RawMessage(`{}`))
+	require.NoError(t, err)
+}
+
 func TestDispatch_PolicyBrokerDisabled_NoBehaviorChange(t *testing.T) {
 	t.Setenv(envPolicyBrokerURL, "")
 
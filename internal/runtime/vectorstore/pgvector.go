@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vectorstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pgvector/pgvector-go"
+)
+
+// Compile-time interface check.
+var _ VectorStore = (*Pgvector)(nil)
+
+// Pgvector is a VectorStore on a PostgreSQL table with the pgvector
+// extension. The table is owned by whoever loads the knowledge and must have
+// the columns id text primary key, content text, metadata jsonb and
+// embedding vector(n).
+type Pgvector struct {
+	pool  *pgxpool.Pool
+	table string
+}
+
+// NewPgvector connects to the database at url and uses table.
+func NewPgvector(ctx context.Context, url, table string) (*Pgvector, error) {
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("connect to pgvector: %w", err)
+	}
+	return NewPgvectorFromPool(pool, table), nil
+}
+
+// NewPgvectorFromPool uses table through an existing pool. Close closes the
+// pool.
+func NewPgvectorFromPool(pool *pgxpool.Pool, table string) *Pgvector {
+	return &Pgvector{pool: pool, table: pgx.Identifier{table}.Sanitize()}
+}
+
+// Upsert inserts docs in one batch, replacing rows with the same IDs.
+func (p *Pgvector) Upsert(ctx context.Context, docs []Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	query := `INSERT INTO ` + p.table + ` (id, content, metadata, embedding)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE
+		SET content = EXCLUDED.content, metadata = EXCLUDED.metadata, embedding = EXCLUDED.embedding`
+	batch := &pgx.Batch{}
+	for _, doc := range docs {
+		metadata, err := json.Marshal(doc.Metadata)
+		if err != nil {
+			return fmt.Errorf("marshal metadata of %q: %w", doc.ID, err)
+		}
+		batch.Queue(query, doc.ID, doc.Content, metadata, pgvector.NewVector(doc.Embedding))
+	}
+	if err := p.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("upsert documents: %w", err)
+	}
+	return nil
+}
+
+// Query returns the topK rows nearest to embedding by cosine distance.
+func (p *Pgvector) Query(ctx context.Context, embedding []float32, topK int) ([]Match, error) {
+	rows, err := p.pool.Query(ctx, `SELECT id, content, metadata, 1 - (embedding <=> $1)
+		FROM `+p.table+`
+		ORDER BY embedding <=> $1
+		LIMIT $2`, pgvector.NewVector(embedding), topK)
+	if err != nil {
+		return nil, fmt.Errorf("query documents: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []Match
+	for rows.Next() {
+		var m Match
+		var metadata []byte
+		if err := rows.Scan(&m.ID, &m.Content, &metadata, &m.Score); err != nil {
+			return nil, fmt.Errorf("scan document: %w", err)
+		}
+		if len(metadata) > 0 {
+			if err := json.Unmarshal(metadata, &m.Metadata); err != nil {
+				return nil, fmt.Errorf("unmarshal metadata of %q: %w", m.ID, err)
+			}
+		}
+		matches = append(matches, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query documents: %w", err)
+	}
+	return matches, nil
+}
+
+// Delete removes the rows with the given IDs.
+func (p *Pgvector) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if _, err := p.pool.Exec(ctx, `DELETE FROM `+p.table+` WHERE id = ANY($1)`, ids); err != nil {
+		return fmt.Errorf("delete documents: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection pool.
+func (p *Pgvector) Close() error {
+	p.pool.Close()
+	return nil
+}
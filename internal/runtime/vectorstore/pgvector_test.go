@@ -0,0 +1,84 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vectorstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestPgvector(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping pgvector test in short mode")
+	}
+	ctx := context.Background()
+	container, err := tcpostgres.Run(ctx, "pgvector/pgvector:pg16",
+		tcpostgres.WithDatabase("omnia_test"),
+		tcpostgres.WithUsername("test"),
+		tcpostgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = container.Terminate(ctx) })
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	store, err := NewPgvector(ctx, connStr, "Knowledge")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	_, err = store.pool.Exec(ctx, `CREATE EXTENSION IF NOT EXISTS vector;
+		CREATE TABLE "Knowledge" (id text PRIMARY KEY, content text NOT NULL, metadata jsonb, embedding vector(3))`)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Upsert(ctx, []Document{
+		{ID: "refunds#1", Content: "Refunds take 5 days.", Embedding: []float32{1, 0, 0},
+			Metadata: map[string]string{"source": "refunds.md"}},
+		{ID: "shipping#1", Content: "Shipping is free.", Embedding: []float32{0, 1, 0}},
+		{ID: "returns#1", Content: "Returns within 30 days.", Embedding: []float32{0.8, 0.6, 0}},
+	}))
+	require.NoError(t, store.Upsert(ctx, []Document{
+		{ID: "refunds#1", Content: "Refunds take 3 days.", Embedding: []float32{1, 0, 0},
+			Metadata: map[string]string{"source": "refunds.md"}},
+	}))
+
+	matches, err := store.Query(ctx, []float32{1, 0, 0}, 2)
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "refunds#1", matches[0].ID)
+	assert.Equal(t, "Refunds take 3 days.", matches[0].Content, "upserting an existing ID replaces the row")
+	assert.Equal(t, "refunds.md", matches[0].Metadata["source"])
+	assert.InDelta(t, 1.0, matches[0].Score, 1e-6)
+	assert.Equal(t, "returns#1", matches[1].ID)
+	assert.InDelta(t, 0.8, matches[1].Score, 1e-6)
+
+	require.NoError(t, store.Delete(ctx, []string{"refunds#1", "unknown"}))
+	matches, err = store.Query(ctx, []float32{1, 0, 0}, 1)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "returns#1", matches[0].ID)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vectorstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// qdrantTimeout bounds each Qdrant request when no client is supplied.
+const qdrantTimeout = 10 * time.Second
+
+// maxQdrantErrorBody caps how much of an error response is quoted.
+const maxQdrantErrorBody = 512
+
+// Payload fields of a Qdrant point. Qdrant point IDs must be unsigned
+// integers or UUIDs, so the document ID is kept in the payload and the point
+// ID is derived from it.
+const (
+	qdrantPayloadID       = "id"
+	qdrantPayloadContent  = "content"
+	qdrantPayloadMetadata = "metadata"
+)
+
+// qdrantPointNamespace namespaces the UUIDv5 point IDs derived from document
+// IDs.
+var qdrantPointNamespace = uuid.MustParse("6b1e3f3c-2a4d-5e8f-9a0b-1c2d3e4f5a6b")
+
+// Compile-time interface check.
+var _ VectorStore = (*Qdrant)(nil)
+
+// Qdrant is a VectorStore on a Qdrant collection, spoken to over its REST
+// API. The collection must exist and use cosine distance.
+type Qdrant struct {
+	baseURL    string
+	apiKey     string
+	collection string
+	client     *http.Client
+}
+
+// NewQdrant uses collection on the Qdrant server at baseURL. A nil client
+// gets a default one with a request timeout.
+func NewQdrant(baseURL, apiKey, collection string, client *http.Client) *Qdrant {
+	if client == nil {
+		client = &http.Client{Timeout: qdrantTimeout}
+	}
+	return &Qdrant{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		apiKey:     apiKey,
+		collection: collection,
+		client:     client,
+	}
+}
+
+type qdrantPoint struct {
+	ID      string         `json:"id"`
+	Vector  []float32      `json:"vector,omitempty"`
+	Payload map[string]any `json:"payload,omitempty"`
+}
+
+type qdrantScoredPoint struct {
+	Score   float64 `json:"score"`
+	Payload struct {
+		ID       string            `json:"id"`
+		Content  string            `json:"content"`
+		Metadata map[string]string `json:"metadata"`
+	} `json:"payload"`
+}
+
+// pointID maps a document ID to its Qdrant point ID.
+func pointID(docID string) string {
+	return uuid.NewSHA1(qdrantPointNamespace, []byte(docID)).String()
+}
+
+// Upsert stores docs as points, waiting until they are searchable.
+func (q *Qdrant) Upsert(ctx context.Context, docs []Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	points := make([]qdrantPoint, len(docs))
+	for i, doc := range docs {
+		points[i] = qdrantPoint{
+			ID:     pointID(doc.ID),
+			Vector: doc.Embedding,
+			Payload: map[string]any{
+				qdrantPayloadID:       doc.ID,
+				qdrantPayloadContent:  doc.Content,
+				qdrantPayloadMetadata: doc.Metadata,
+			},
+		}
+	}
+	body := map[string]any{"points": points}
+	if err := q.do(ctx, http.MethodPut, "/points?wait=true", body, nil); err != nil {
+		return fmt.Errorf("upsert documents: %w", err)
+	}
+	return nil
+}
+
+// Query searches the collection for the topK points nearest to embedding.
+func (q *Qdrant) Query(ctx context.Context, embedding []float32, topK int) ([]Match, error) {
+	body := map[string]any{
+		"vector":       embedding,
+		"limit":        topK,
+		"with_payload": true,
+	}
+	var resp struct {
+		Result []qdrantScoredPoint `json:"result"`
+	}
+	if err := q.do(ctx, http.MethodPost, "/points/search", body, &resp); err != nil {
+		return nil, fmt.Errorf("query documents: %w", err)
+	}
+	matches := make([]Match, len(resp.Result))
+	for i, p := range resp.Result {
+		matches[i] = Match{
+			Document: Document{
+				ID:       p.Payload.ID,
+				Content:  p.Payload.Content,
+				Metadata: p.Payload.Metadata,
+			},
+			Score: p.Score,
+		}
+	}
+	return matches, nil
+}
+
+// Delete removes the points of the given document IDs.
+func (q *Qdrant) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	points := make([]string, len(ids))
+	for i, id := range ids {
+		points[i] = pointID(id)
+	}
+	body := map[string]any{"points": points}
+	if err := q.do(ctx, http.MethodPost, "/points/delete?wait=true", body, nil); err != nil {
+		return fmt.Errorf("delete documents: %w", err)
+	}
+	return nil
+}
+
+// Close releases idle connections.
+func (q *Qdrant) Close() error {
+	q.client.CloseIdleConnections()
+	return nil
+}
+
+// do sends body as JSON to path under the collection and decodes the
+// response into out when it is non-nil.
+func (q *Qdrant) do(ctx context.Context, method, path string, body, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+	endpoint := q.baseURL + "/collections/" + url.PathEscape(q.collection) + path
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if q.apiKey != "" {
+		req.Header.Set("api-key", q.apiKey)
+	}
+
+	resp, err := q.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxQdrantErrorBody))
+		return fmt.Errorf("qdrant returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
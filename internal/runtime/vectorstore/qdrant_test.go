@@ -0,0 +1,155 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vectorstore
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQdrant is an in-memory Qdrant collection serving the REST endpoints
+// the store uses.
+type fakeQdrant struct {
+	mu     sync.Mutex
+	points map[string]qdrantPoint
+	apiKey string
+}
+
+func cosine(a, b []float32) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+func (f *fakeQdrant) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Header.Get("api-key") != f.apiKey {
+		http.Error(w, `{"status":{"error":"unauthorized"}}`, http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case r.Method == http.MethodPut && r.URL.Path == "/collections/docs/points":
+		var req struct {
+			Points []qdrantPoint `json:"points"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for _, p := range req.Points {
+			f.points[p.ID] = p
+		}
+		_, _ = w.Write([]byte(`{"result":{"status":"completed"}}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/collections/docs/points/search":
+		var req struct {
+			Vector []float32 `json:"vector"`
+			Limit  int       `json:"limit"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		var result []map[string]any
+		for _, p := range f.points {
+			result = append(result, map[string]any{"id": p.ID, "score": cosine(req.Vector, p.Vector), "payload": p.Payload})
+		}
+		sort.Slice(result, func(i, j int) bool { return result[i]["score"].(float64) > result[j]["score"].(float64) })
+		if len(result) > req.Limit {
+			result = result[:req.Limit]
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"result": result})
+	case r.Method == http.MethodPost && r.URL.Path == "/collections/docs/points/delete":
+		var req struct {
+			Points []string `json:"points"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for _, id := range req.Points {
+			delete(f.points, id)
+		}
+		_, _ = w.Write([]byte(`{"result":{"status":"completed"}}`))
+	default:
+		http.Error(w, `{"status":{"error":"not found"}}`, http.StatusNotFound)
+	}
+}
+
+func TestQdrant(t *testing.T) {
+	fake := &fakeQdrant{points: map[string]qdrantPoint{}, apiKey: "secret"}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	store := NewQdrant(srv.URL+"/", "secret", "docs", srv.Client())
+	ctx := context.Background()
+
+	require.NoError(t, store.Upsert(ctx, []Document{
+		{ID: "refunds#1", Content: "Refunds take 5 days.", Embedding: []float32{1, 0, 0},
+			Metadata: map[string]string{"source": "refunds.md"}},
+		{ID: "shipping#1", Content: "Shipping is free.", Embedding: []float32{0, 1, 0}},
+		{ID: "returns#1", Content: "Returns within 30 days.", Embedding: []float32{0.8, 0.6, 0}},
+	}))
+	require.NoError(t, store.Upsert(ctx, []Document{
+		{ID: "refunds#1", Content: "Refunds take 3 days.", Embedding: []float32{1, 0, 0},
+			Metadata: map[string]string{"source": "refunds.md"}},
+	}))
+	assert.Len(t, fake.points, 3, "upserting an existing ID replaces the point")
+
+	matches, err := store.Query(ctx, []float32{1, 0, 0}, 2)
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "refunds#1", matches[0].ID)
+	assert.Equal(t, "Refunds take 3 days.", matches[0].Content)
+	assert.Equal(t, "refunds.md", matches[0].Metadata["source"])
+	assert.InDelta(t, 1.0, matches[0].Score, 1e-6)
+	assert.Equal(t, "returns#1", matches[1].ID)
+
+	require.NoError(t, store.Delete(ctx, []string{"refunds#1", "unknown"}))
+	matches, err = store.Query(ctx, []float32{1, 0, 0}, 1)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "returns#1", matches[0].ID)
+}
+
+func TestQdrant_Errors(t *testing.T) {
+	fake := &fakeQdrant{points: map[string]qdrantPoint{}, apiKey: "secret"}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	_, err := NewQdrant(srv.URL, "wrong", "docs", srv.Client()).Query(context.Background(), []float32{1}, 1)
+	assert.ErrorContains(t, err, "401 Unauthorized")
+
+	_, err = NewQdrant(srv.URL, "secret", "missing", srv.Client()).Query(context.Background(), []float32{1}, 1)
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(context.Background(), Config{Type: TypeQdrant, Collection: "docs"})
+	assert.ErrorContains(t, err, "no URL configured")
+
+	_, err = New(context.Background(), Config{Type: "chroma", URL: "http://chroma"})
+	assert.ErrorContains(t, err, `unsupported vector store type "chroma"`)
+
+	store, err := New(context.Background(), Config{Type: TypeQdrant, Collection: "docs", URL: "http://qdrant:6333"})
+	require.NoError(t, err)
+	assert.IsType(t, &Qdrant{}, store)
+	require.NoError(t, store.Close())
+}
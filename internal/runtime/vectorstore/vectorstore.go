@@ -0,0 +1,95 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vectorstore stores embedded knowledge chunks and finds the ones
+// nearest to a query embedding, for agents that ground their answers in
+// retrieved documents. Backends are interchangeable behind VectorStore;
+// scores are cosine similarities in [-1, 1], higher meaning more similar.
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+)
+
+// Supported backend types, matching spec.knowledge.vectorStore.type.
+const (
+	TypePgvector = "pgvector"
+	TypeQdrant   = "qdrant"
+)
+
+// Document is a chunk of knowledge and its embedding.
+type Document struct {
+	// ID identifies the document within its collection. Upserting an
+	// existing ID replaces the document.
+	ID string
+	// Content is the text injected into the prompt when the document is
+	// retrieved.
+	Content string
+	// Embedding is the vector the document is matched on. It must come from
+	// the same embedding model as the query embeddings.
+	Embedding []float32
+	// Metadata is free-form; a "source" entry is cited in place of the ID.
+	Metadata map[string]string
+}
+
+// Match is a document returned by a query.
+type Match struct {
+	Document
+	// Score is the cosine similarity between the document and the query.
+	Score float64
+}
+
+// VectorStore stores documents and queries them by embedding similarity.
+type VectorStore interface {
+	// Upsert inserts docs, replacing any stored documents with the same IDs.
+	Upsert(ctx context.Context, docs []Document) error
+	// Query returns up to topK documents nearest to embedding, best first.
+	// Returned matches carry no embedding.
+	Query(ctx context.Context, embedding []float32, topK int) ([]Match, error)
+	// Delete removes the documents with the given IDs. Unknown IDs are
+	// ignored.
+	Delete(ctx context.Context, ids []string) error
+	// Close releases the store's connections.
+	Close() error
+}
+
+// Config selects and configures a backend.
+type Config struct {
+	// Type is TypePgvector or TypeQdrant.
+	Type string
+	// Collection is the pgvector table or Qdrant collection.
+	Collection string
+	// URL is the PostgreSQL connection string or the Qdrant REST endpoint.
+	URL string
+	// APIKey authenticates to Qdrant. Unused by pgvector.
+	APIKey string
+}
+
+// New connects to the backend described by cfg.
+func New(ctx context.Context, cfg Config) (VectorStore, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("vector store %q: no URL configured", cfg.Type)
+	}
+	switch cfg.Type {
+	case TypePgvector:
+		return NewPgvector(ctx, cfg.URL, cfg.Collection)
+	case TypeQdrant:
+		return NewQdrant(cfg.URL, cfg.APIKey, cfg.Collection, nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported vector store type %q", cfg.Type)
+	}
+}
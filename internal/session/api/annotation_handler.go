@@ -0,0 +1,335 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/altairalabs/omnia/internal/httputil"
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// Errors for the annotation list + aggregate endpoints.
+var (
+	errAnnotationsBadGroupBy = errors.New(
+		"groupBy must be a comma-separated list of: agent, source, label, time:hour, time:day")
+	errAnnotationsBadMetric = errors.New(
+		"metric must be one of: count, thumbs_up, thumbs_down, avg_score, corrections")
+)
+
+// AnnotationResponse is the JSON body returned for a recorded annotation.
+type AnnotationResponse struct {
+	Annotation *MessageAnnotation `json:"annotation"`
+}
+
+// AnnotationListResponse is the JSON body for annotation listings. Total and
+// HasMore are set for the paginated namespace listing only.
+type AnnotationListResponse struct {
+	Annotations []*MessageAnnotation `json:"annotations"`
+	Total       int64                `json:"total,omitempty"`
+	HasMore     bool                 `json:"hasMore,omitempty"`
+}
+
+// AnnotationsAggregateResponse is the JSON body for
+// /api/v1/annotations/aggregate.
+type AnnotationsAggregateResponse struct {
+	Rows []*AnnotationAggregateRow `json:"rows"`
+}
+
+// handleRecordAnnotation records feedback on one message.
+// POST /api/v1/sessions/{sessionID}/messages/{messageID}/annotations
+// Returns 201 with the stored annotation.
+func (h *Handler) handleRecordAnnotation(w http.ResponseWriter, r *http.Request) {
+	if h.annotationService == nil {
+		writeAnnotationError(w, ErrMissingAnnotationStore)
+		return
+	}
+	sessionID, err := sessionIDFromRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	messageID := r.PathValue("messageID")
+	if _, err := uuid.Parse(messageID); err != nil {
+		writeError(w, ErrInvalidMessageID)
+		return
+	}
+
+	h.limitBody(w, r)
+	var a MessageAnnotation
+	if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+		if isMaxBytesError(err) {
+			writeError(w, ErrBodyTooLarge)
+			return
+		}
+		writeError(w, ErrMissingBody)
+		return
+	}
+	a.SessionID = sessionID
+	a.MessageID = messageID
+
+	if err := h.annotationService.RecordAnnotation(r.Context(), &a); err != nil {
+		if !errors.Is(err, session.ErrSessionNotFound) && !errors.Is(err, session.ErrMessageNotFound) &&
+			!errors.Is(err, ErrInvalidAnnotation) {
+			h.requestLog(r.Context()).Error(err, "RecordAnnotation failed", "sessionID", sessionID, "messageID", messageID)
+		}
+		writeAnnotationError(w, err)
+		return
+	}
+
+	w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(AnnotationResponse{Annotation: &a})
+}
+
+// handleGetSessionAnnotations returns every annotation on a session's
+// messages. GET /api/v1/sessions/{sessionID}/annotations
+func (h *Handler) handleGetSessionAnnotations(w http.ResponseWriter, r *http.Request) {
+	if h.annotationService == nil {
+		writeAnnotationError(w, ErrMissingAnnotationStore)
+		return
+	}
+	sessionID, err := sessionIDFromRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	annotations, err := h.annotationService.ListSessionAnnotations(r.Context(), sessionID)
+	if err != nil {
+		writeAnnotationError(w, err)
+		return
+	}
+	if annotations == nil {
+		annotations = []*MessageAnnotation{}
+	}
+
+	w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
+	_ = json.NewEncoder(w).Encode(AnnotationListResponse{Annotations: annotations})
+}
+
+// handleListAnnotations pages through a namespace's annotations, newest
+// first. GET /api/v1/annotations?namespace=X[&label=Y&hasCorrection=true]
+func (h *Handler) handleListAnnotations(w http.ResponseWriter, r *http.Request) {
+	if h.annotationService == nil {
+		writeAnnotationError(w, ErrMissingAnnotationStore)
+		return
+	}
+
+	opts, err := parseAnnotationListOpts(r)
+	if err != nil {
+		writeAnnotationAggregateError(w, err)
+		return
+	}
+
+	annotations, total, err := h.annotationService.ListAnnotations(r.Context(), opts)
+	if err != nil {
+		writeAnnotationError(w, err)
+		return
+	}
+	if annotations == nil {
+		annotations = []*MessageAnnotation{}
+	}
+
+	w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
+	_ = json.NewEncoder(w).Encode(AnnotationListResponse{
+		Annotations: annotations,
+		Total:       total,
+		HasMore:     int64(opts.Offset+opts.Limit) < total,
+	})
+}
+
+// handleAggregateAnnotations runs a namespace-scoped GROUP BY over message
+// annotations. GET /api/v1/annotations/aggregate?namespace=X&groupBy=Y&metric=Z
+func (h *Handler) handleAggregateAnnotations(w http.ResponseWriter, r *http.Request) {
+	if h.annotationService == nil {
+		writeAnnotationError(w, ErrMissingAnnotationStore)
+		return
+	}
+
+	opts, err := parseAnnotationAggregateOpts(r)
+	if err != nil {
+		writeAnnotationAggregateError(w, err)
+		return
+	}
+
+	rows, err := h.annotationService.AggregateAnnotations(r.Context(), opts)
+	if err != nil {
+		writeAnnotationError(w, err)
+		return
+	}
+	if rows == nil {
+		rows = []*AnnotationAggregateRow{}
+	}
+
+	w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
+	_ = json.NewEncoder(w).Encode(AnnotationsAggregateResponse{Rows: rows})
+}
+
+// parseAnnotationListOpts extracts AnnotationListOpts from the request query.
+func parseAnnotationListOpts(r *http.Request) (AnnotationListOpts, error) {
+	q := r.URL.Query()
+	opts := AnnotationListOpts{
+		Namespace: q.Get("namespace"),
+		AgentName: q.Get("agentName"),
+		Source:    q.Get("source"),
+		Label:     q.Get("label"),
+		Limit:     defaultListLimit,
+	}
+	if opts.Namespace == "" {
+		return AnnotationListOpts{}, errAggregateMissingNamespace
+	}
+	if n := parseIntQueryParam(q.Get("limit"), defaultListLimit); n > 0 {
+		opts.Limit = min(n, maxListLimit)
+	}
+	if n := parseIntQueryParam(q.Get("offset"), 0); n > 0 {
+		opts.Offset = n
+	}
+	if b, err := strconv.ParseBool(q.Get("hasCorrection")); err == nil {
+		opts.HasCorrection = b
+	}
+
+	from, to, err := parseAnnotationTimeRange(q.Get("from"), q.Get("to"))
+	if err != nil {
+		return AnnotationListOpts{}, err
+	}
+	opts.From, opts.To = from, to
+	return opts, nil
+}
+
+// parseAnnotationAggregateOpts extracts AnnotationAggregateOpts from the
+// request query. Returns one of the err* sentinels for 400s.
+func parseAnnotationAggregateOpts(r *http.Request) (AnnotationAggregateOpts, error) {
+	q := r.URL.Query()
+
+	namespace := q.Get("namespace")
+	if namespace == "" {
+		return AnnotationAggregateOpts{}, errAggregateMissingNamespace
+	}
+	groupBy, err := parseAnnotationGroupByList(q.Get("groupBy"))
+	if err != nil {
+		return AnnotationAggregateOpts{}, err
+	}
+	metric, err := parseAnnotationMetric(q.Get("metric"))
+	if err != nil {
+		return AnnotationAggregateOpts{}, err
+	}
+	from, to, err := parseAnnotationTimeRange(q.Get("from"), q.Get("to"))
+	if err != nil {
+		return AnnotationAggregateOpts{}, err
+	}
+
+	return AnnotationAggregateOpts{
+		Namespace: namespace,
+		AgentName: q.Get("agentName"),
+		Source:    q.Get("source"),
+		From:      from,
+		To:        to,
+		GroupBy:   groupBy,
+		Metric:    metric,
+		Limit:     clampAnnotationAggregateLimit(parseIntQueryParam(q.Get("limit"), DefaultAnnotationAggregateLimit)),
+	}, nil
+}
+
+// parseAnnotationTimeRange parses the optional RFC3339 from/to parameters.
+func parseAnnotationTimeRange(fromParam, toParam string) (from, to time.Time, err error) {
+	if fromParam != "" {
+		if from, err = time.Parse(time.RFC3339, fromParam); err != nil {
+			return time.Time{}, time.Time{}, errAggregateBadFrom
+		}
+	}
+	if toParam != "" {
+		if to, err = time.Parse(time.RFC3339, toParam); err != nil {
+			return time.Time{}, time.Time{}, errAggregateBadTo
+		}
+	}
+	return from, to, nil
+}
+
+// parseAnnotationGroupByList parses a comma-separated groupBy into an ordered
+// list of validated dimensions. An empty or all-blank value is rejected.
+func parseAnnotationGroupByList(v string) ([]AnnotationAggregateGroupBy, error) {
+	parts := strings.Split(v, ",")
+	out := make([]AnnotationAggregateGroupBy, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		switch dim := AnnotationAggregateGroupBy(p); dim {
+		case AnnotationAggregateGroupByAgent,
+			AnnotationAggregateGroupBySource,
+			AnnotationAggregateGroupByLabel,
+			AnnotationAggregateGroupByTimeHour,
+			AnnotationAggregateGroupByTimeDay:
+			out = append(out, dim)
+		default:
+			return nil, errAnnotationsBadGroupBy
+		}
+	}
+	if len(out) == 0 {
+		return nil, errAnnotationsBadGroupBy
+	}
+	return out, nil
+}
+
+func parseAnnotationMetric(v string) (AnnotationAggregateMetric, error) {
+	switch AnnotationAggregateMetric(v) {
+	case AnnotationAggregateMetricCount,
+		AnnotationAggregateMetricThumbsUp,
+		AnnotationAggregateMetricThumbsDown,
+		AnnotationAggregateMetricAvgScore,
+		AnnotationAggregateMetricCorrections:
+		return AnnotationAggregateMetric(v), nil
+	default:
+		return "", errAnnotationsBadMetric
+	}
+}
+
+func clampAnnotationAggregateLimit(n int) int {
+	if n < 1 {
+		return DefaultAnnotationAggregateLimit
+	}
+	if n > MaxAnnotationAggregateLimit {
+		return MaxAnnotationAggregateLimit
+	}
+	return n
+}
+
+// writeAnnotationAggregateError emits 400 for the err* sentinels above;
+// other errors fall through to writeError.
+func writeAnnotationAggregateError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errAnnotationsBadGroupBy),
+		errors.Is(err, errAnnotationsBadMetric),
+		errors.Is(err, errAggregateBadFrom),
+		errors.Is(err, errAggregateBadTo),
+		errors.Is(err, errAggregateMissingNamespace):
+		writeBadRequest(w, err.Error())
+	default:
+		writeError(w, err)
+	}
+}
+
+// writeAnnotationError maps annotation service errors to HTTP statuses.
+func writeAnnotationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrMissingAnnotationStore):
+		writeNotConfigured(w, "annotation store not configured")
+	case errors.Is(err, ErrInvalidAnnotation):
+		writeBadRequest(w, err.Error())
+	default:
+		writeError(w, err)
+	}
+}
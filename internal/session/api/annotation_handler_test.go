@@ -0,0 +1,193 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+const testAnnotationMessageID = "6f1c2d3e-4b5a-4c6d-8e7f-901234567890"
+
+// mockAnnotationStore is a test double for AnnotationStore.
+type mockAnnotationStore struct {
+	recorded      []*MessageAnnotation
+	recordErr     error
+	listOpts      AnnotationListOpts
+	list          []*MessageAnnotation
+	total         int64
+	aggregateOpts AnnotationAggregateOpts
+	aggregateRows []*AnnotationAggregateRow
+}
+
+func (m *mockAnnotationStore) RecordAnnotation(_ context.Context, a *MessageAnnotation) error {
+	if m.recordErr != nil {
+		return m.recordErr
+	}
+	a.ID = "ann-1"
+	a.Namespace = "default"
+	a.AgentName = "support"
+	m.recorded = append(m.recorded, a)
+	return nil
+}
+
+func (m *mockAnnotationStore) ListSessionAnnotations(_ context.Context, sessionID string) ([]*MessageAnnotation, error) {
+	var out []*MessageAnnotation
+	for _, a := range m.recorded {
+		if a.SessionID == sessionID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockAnnotationStore) ListAnnotations(_ context.Context, opts AnnotationListOpts) ([]*MessageAnnotation, int64, error) {
+	m.listOpts = opts
+	return m.list, m.total, nil
+}
+
+func (m *mockAnnotationStore) AggregateAnnotations(_ context.Context, opts AnnotationAggregateOpts) ([]*AnnotationAggregateRow, error) {
+	m.aggregateOpts = opts
+	return m.aggregateRows, nil
+}
+
+func newTestAnnotationHandler(store AnnotationStore) *http.ServeMux {
+	h := NewHandler(nil, logr.Discard())
+	if store != nil {
+		h.SetAnnotationService(NewAnnotationService(store, logr.Discard()))
+	}
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+	return mux
+}
+
+func annotationPath(sessionID, messageID string) string {
+	return "/api/v1/sessions/" + sessionID + "/messages/" + messageID + "/annotations"
+}
+
+func TestHandleRecordAnnotation(t *testing.T) {
+	store := &mockAnnotationStore{}
+	mux := newTestAnnotationHandler(store)
+
+	body := `{"score":-1,"source":"reviewer","author":"qa@example.com","labels":["wrong-answer"," wrong-answer ",""],"correction":"The refund window is 30 days."}`
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost,
+		annotationPath(testSessionID, testAnnotationMessageID), strings.NewReader(body)))
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var resp AnnotationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "ann-1", resp.Annotation.ID)
+	assert.Equal(t, testSessionID, resp.Annotation.SessionID)
+	assert.Equal(t, testAnnotationMessageID, resp.Annotation.MessageID)
+	assert.Equal(t, []string{"wrong-answer"}, resp.Annotation.Labels)
+	require.Len(t, store.recorded, 1)
+
+	// The session listing returns it.
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/sessions/"+testSessionID+"/annotations", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var list AnnotationListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Annotations, 1)
+}
+
+func TestHandleRecordAnnotation_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		store     AnnotationStore
+		messageID string
+		body      string
+		want      int
+	}{
+		{"not configured", nil, testAnnotationMessageID, `{"score":1}`, http.StatusServiceUnavailable},
+		{"bad message id", &mockAnnotationStore{}, "m1", `{"score":1}`, http.StatusBadRequest},
+		{"malformed body", &mockAnnotationStore{}, testAnnotationMessageID, `{`, http.StatusBadRequest},
+		{"no feedback", &mockAnnotationStore{}, testAnnotationMessageID, `{"author":"a"}`, http.StatusBadRequest},
+		{"bad score", &mockAnnotationStore{}, testAnnotationMessageID, `{"score":5}`, http.StatusBadRequest},
+		{"bad source", &mockAnnotationStore{}, testAnnotationMessageID, `{"score":1,"source":"bot"}`, http.StatusBadRequest},
+		{"unknown message", &mockAnnotationStore{recordErr: session.ErrMessageNotFound},
+			testAnnotationMessageID, `{"score":1}`, http.StatusNotFound},
+		{"unknown session", &mockAnnotationStore{recordErr: session.ErrSessionNotFound},
+			testAnnotationMessageID, `{"score":1}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newTestAnnotationHandler(tt.store).ServeHTTP(w, httptest.NewRequest(http.MethodPost,
+				annotationPath(testSessionID, tt.messageID), strings.NewReader(tt.body)))
+			assert.Equal(t, tt.want, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestHandleListAnnotations(t *testing.T) {
+	store := &mockAnnotationStore{
+		list:  []*MessageAnnotation{{ID: "ann-1", Correction: "better answer"}},
+		total: 3,
+	}
+	mux := newTestAnnotationHandler(store)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/api/v1/annotations?namespace=default&agentName=support&label=wrong-answer&hasCorrection=true&limit=1", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp AnnotationListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Annotations, 1)
+	assert.Equal(t, int64(3), resp.Total)
+	assert.True(t, resp.HasMore)
+	assert.Equal(t, AnnotationListOpts{
+		Namespace: "default", AgentName: "support", Label: "wrong-answer", HasCorrection: true, Limit: 1,
+	}, store.listOpts)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/annotations", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code, "namespace is required")
+}
+
+func TestHandleAggregateAnnotations(t *testing.T) {
+	store := &mockAnnotationStore{
+		aggregateRows: []*AnnotationAggregateRow{{Key: "support|2026-01-02", Value: 4, Count: 6}},
+	}
+	mux := newTestAnnotationHandler(store)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/api/v1/annotations/aggregate?namespace=default&groupBy=agent,time:day&metric=thumbs_down", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp AnnotationsAggregateResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Rows, 1)
+	assert.Equal(t, []AnnotationAggregateGroupBy{AnnotationAggregateGroupByAgent, AnnotationAggregateGroupByTimeDay},
+		store.aggregateOpts.GroupBy)
+	assert.Equal(t, AnnotationAggregateMetricThumbsDown, store.aggregateOpts.Metric)
+	assert.Equal(t, DefaultAnnotationAggregateLimit, store.aggregateOpts.Limit)
+
+	for _, q := range []string{
+		"groupBy=agent&metric=count",
+		"namespace=default&groupBy=model&metric=count",
+		"namespace=default&groupBy=label&metric=p95",
+		"namespace=default&groupBy=label&metric=count&from=yesterday",
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/annotations/aggregate?"+q, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, q)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// ErrMissingAnnotationStore is returned when no annotation store has been
+// wired into the Handler — typically because session-api is running without
+// database access.
+var ErrMissingAnnotationStore = errors.New("annotation store is not configured")
+
+// ErrInvalidAnnotation is returned when a submitted annotation has an unknown
+// source, a score other than 1 or -1, or carries no feedback at all.
+var ErrInvalidAnnotation = errors.New("invalid annotation")
+
+// AnnotationService is the business-logic wrapper around AnnotationStore.
+type AnnotationService struct {
+	store AnnotationStore
+	log   logr.Logger
+}
+
+// NewAnnotationService creates a new AnnotationService.
+func NewAnnotationService(store AnnotationStore, log logr.Logger) *AnnotationService {
+	return &AnnotationService{
+		store: store,
+		log:   log.WithName("annotation-service"),
+	}
+}
+
+// RecordAnnotation validates and persists an annotation on a message. Source
+// defaults to "user"; labels are trimmed and de-duplicated.
+func (s *AnnotationService) RecordAnnotation(ctx context.Context, a *MessageAnnotation) error {
+	if s.store == nil {
+		return ErrMissingAnnotationStore
+	}
+	if err := normalizeAnnotation(a); err != nil {
+		return err
+	}
+	return s.store.RecordAnnotation(ctx, a)
+}
+
+// ListSessionAnnotations returns a session's annotations, oldest first.
+func (s *AnnotationService) ListSessionAnnotations(ctx context.Context, sessionID string) ([]*MessageAnnotation, error) {
+	if s.store == nil {
+		return nil, ErrMissingAnnotationStore
+	}
+	if sessionID == "" {
+		return nil, ErrMissingSessionID
+	}
+	return s.store.ListSessionAnnotations(ctx, sessionID)
+}
+
+// ListAnnotations returns a namespace's annotations, newest first. Powers
+// GET /api/v1/annotations, which eval dataset generation pages through.
+func (s *AnnotationService) ListAnnotations(ctx context.Context, opts AnnotationListOpts) ([]*MessageAnnotation, int64, error) {
+	if s.store == nil {
+		return nil, 0, ErrMissingAnnotationStore
+	}
+	return s.store.ListAnnotations(ctx, opts)
+}
+
+// AggregateAnnotations runs a namespace-scoped GROUP BY over annotations.
+// Powers GET /api/v1/annotations/aggregate.
+func (s *AnnotationService) AggregateAnnotations(ctx context.Context, opts AnnotationAggregateOpts) ([]*AnnotationAggregateRow, error) {
+	if s.store == nil {
+		return nil, ErrMissingAnnotationStore
+	}
+	return s.store.AggregateAnnotations(ctx, opts)
+}
+
+// normalizeAnnotation applies defaults and rejects annotations the store's
+// constraints would refuse.
+func normalizeAnnotation(a *MessageAnnotation) error {
+	if a == nil {
+		return fmt.Errorf("%w: empty body", ErrInvalidAnnotation)
+	}
+	if a.Source == "" {
+		a.Source = AnnotationSourceUser
+	}
+	if a.Source != AnnotationSourceUser && a.Source != AnnotationSourceReviewer {
+		return fmt.Errorf("%w: source must be %q or %q", ErrInvalidAnnotation, AnnotationSourceUser, AnnotationSourceReviewer)
+	}
+	if a.Score != nil && *a.Score != AnnotationScorePositive && *a.Score != AnnotationScoreNegative {
+		return fmt.Errorf("%w: score must be 1 or -1", ErrInvalidAnnotation)
+	}
+
+	labels := make([]string, 0, len(a.Labels))
+	for _, l := range a.Labels {
+		if l = strings.TrimSpace(l); l != "" && !slices.Contains(labels, l) {
+			labels = append(labels, l)
+		}
+	}
+	a.Labels = labels
+
+	if a.Score == nil && len(a.Labels) == 0 && strings.TrimSpace(a.Correction) == "" {
+		return fmt.Errorf("%w: one of score, labels or correction is required", ErrInvalidAnnotation)
+	}
+	return nil
+}
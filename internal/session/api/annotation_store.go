@@ -0,0 +1,133 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"context"
+	"time"
+)
+
+// Annotation sources.
+const (
+	// AnnotationSourceUser is feedback from the end user of the agent.
+	AnnotationSourceUser = "user"
+	// AnnotationSourceReviewer is a label or correction from a human reviewer.
+	AnnotationSourceReviewer = "reviewer"
+)
+
+// Annotation scores: thumbs up and thumbs down.
+const (
+	AnnotationScorePositive = 1
+	AnnotationScoreNegative = -1
+)
+
+// MessageAnnotation is feedback on one message: an end-user score, reviewer
+// labels, a suggested correction, or any mix of the three. Namespace and
+// AgentName are copied from the session when the annotation is recorded.
+type MessageAnnotation struct {
+	ID         string    `json:"id"`
+	SessionID  string    `json:"sessionId"`
+	MessageID  string    `json:"messageId"`
+	Namespace  string    `json:"namespace"`
+	AgentName  string    `json:"agentName"`
+	Source     string    `json:"source"`
+	Author     string    `json:"author,omitempty"`
+	Score      *int      `json:"score,omitempty"`
+	Labels     []string  `json:"labels,omitempty"`
+	Correction string    `json:"correction,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// AnnotationListOpts filters ListAnnotations. Namespace is required; the
+// rest are optional. Label matches annotations carrying that label, and
+// HasCorrection keeps only annotations with a suggested correction (the
+// rows eval dataset generation reads).
+type AnnotationListOpts struct {
+	Namespace     string
+	AgentName     string
+	Source        string
+	Label         string
+	HasCorrection bool
+	From          time.Time
+	To            time.Time
+	Limit         int
+	Offset        int
+}
+
+// AnnotationAggregateGroupBy enumerates valid groupBy values for
+// AggregateAnnotations. Time buckets sort ASC (chronological); categorical
+// groups sort DESC by value.
+type AnnotationAggregateGroupBy string
+
+const (
+	// AnnotationAggregateGroupByAgent groups by the agent_name column.
+	AnnotationAggregateGroupByAgent AnnotationAggregateGroupBy = "agent"
+	// AnnotationAggregateGroupBySource groups by user vs reviewer.
+	AnnotationAggregateGroupBySource AnnotationAggregateGroupBy = "source"
+	// AnnotationAggregateGroupByLabel groups by label; an annotation with
+	// several labels counts once per label and one without is left out.
+	AnnotationAggregateGroupByLabel AnnotationAggregateGroupBy = "label"
+	// AnnotationAggregateGroupByTimeHour buckets created_at by hour (UTC).
+	AnnotationAggregateGroupByTimeHour AnnotationAggregateGroupBy = "time:hour"
+	// AnnotationAggregateGroupByTimeDay buckets created_at by day (UTC).
+	AnnotationAggregateGroupByTimeDay AnnotationAggregateGroupBy = "time:day"
+)
+
+// AnnotationAggregateMetric enumerates the metrics over message_annotations.
+type AnnotationAggregateMetric string
+
+const (
+	AnnotationAggregateMetricCount       AnnotationAggregateMetric = "count"
+	AnnotationAggregateMetricThumbsUp    AnnotationAggregateMetric = "thumbs_up"
+	AnnotationAggregateMetricThumbsDown  AnnotationAggregateMetric = "thumbs_down"
+	AnnotationAggregateMetricAvgScore    AnnotationAggregateMetric = "avg_score"
+	AnnotationAggregateMetricCorrections AnnotationAggregateMetric = "corrections"
+)
+
+// Limits for AggregateAnnotations.
+const (
+	DefaultAnnotationAggregateLimit = 500
+	MaxAnnotationAggregateLimit     = 5000
+)
+
+// AnnotationAggregateRow is one returned row from AggregateAnnotations.
+type AnnotationAggregateRow struct {
+	Key   string  `json:"key"`
+	Value float64 `json:"value"`
+	Count int64   `json:"count"`
+}
+
+// AnnotationAggregateOpts configures the AggregateAnnotations query.
+// Namespace, GroupBy and Metric are required; the rest are optional filters.
+type AnnotationAggregateOpts struct {
+	Namespace string
+	AgentName string
+	Source    string
+	From      time.Time
+	To        time.Time
+	GroupBy   []AnnotationAggregateGroupBy
+	Metric    AnnotationAggregateMetric
+	Limit     int
+}
+
+// AnnotationStore defines the persistence interface for message annotations.
+type AnnotationStore interface {
+	// RecordAnnotation stores a, filling in its ID, Namespace, AgentName and
+	// CreatedAt. Returns session.ErrMessageNotFound when a.MessageID is not
+	// a message of a.SessionID.
+	RecordAnnotation(ctx context.Context, a *MessageAnnotation) error
+
+	// ListSessionAnnotations returns a session's annotations, oldest first.
+	ListSessionAnnotations(ctx context.Context, sessionID string) ([]*MessageAnnotation, error)
+
+	// ListAnnotations returns a page of a namespace's annotations, newest
+	// first, and the total number matching opts.
+	ListAnnotations(ctx context.Context, opts AnnotationListOpts) ([]*MessageAnnotation, int64, error)
+
+	// AggregateAnnotations runs a namespace-scoped GROUP BY over annotations.
+	AggregateAnnotations(ctx context.Context, opts AnnotationAggregateOpts) ([]*AnnotationAggregateRow, error)
+}
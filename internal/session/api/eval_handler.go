@@ -132,9 +132,7 @@ func (h *Handler) handleEvaluateSession(w http.ResponseWriter, r *http.Request)
 	}
 
 	if h.service == nil {
-		w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
-		w.WriteHeader(http.StatusServiceUnavailable)
-		_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "session service not configured"})
+		writeNotConfigured(w, "session service not configured")
 		return
 	}
 
@@ -374,9 +372,7 @@ func writeAggregateError(w http.ResponseWriter, err error) {
 		errors.Is(err, errAggregateBadFrom),
 		errors.Is(err, errAggregateBadTo),
 		errors.Is(err, errAggregateMissingNamespace):
-		w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()})
+		writeBadRequest(w, err.Error())
 	default:
 		writeError(w, err)
 	}
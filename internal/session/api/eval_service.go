@@ -24,7 +24,6 @@ import (
 	"errors"
 	"net/http"
 
-	"github.com/altairalabs/omnia/internal/httputil"
 	"github.com/go-logr/logr"
 )
 
@@ -91,8 +90,9 @@ type EvalResultSummaryResponse struct {
 
 // EvalService provides business logic for eval result CRUD operations.
 type EvalService struct {
-	store EvalStore
-	log   logr.Logger
+	store   EvalStore
+	metrics *UsageMetrics
+	log     logr.Logger
 }
 
 // NewEvalService creates a new EvalService with the given store.
@@ -103,6 +103,12 @@ func NewEvalService(store EvalStore, log logr.Logger) *EvalService {
 	}
 }
 
+// SetUsageMetrics sets the metrics that count persisted eval results by
+// outcome. Nil disables counting.
+func (s *EvalService) SetUsageMetrics(m *UsageMetrics) {
+	s.metrics = m
+}
+
 // CreateEvalResults persists one or more eval results.
 func (s *EvalService) CreateEvalResults(ctx context.Context, results []*EvalResult) error {
 	if len(results) == 0 {
@@ -111,7 +117,11 @@ func (s *EvalService) CreateEvalResults(ctx context.Context, results []*EvalResu
 	if s.store == nil {
 		return ErrMissingEvalStore
 	}
-	return s.store.InsertEvalResults(ctx, results)
+	if err := s.store.InsertEvalResults(ctx, results); err != nil {
+		return err
+	}
+	s.metrics.ObserveEvalResults(results)
+	return nil
 }
 
 // GetSessionEvalResults retrieves all eval results for a session.
@@ -164,13 +174,9 @@ func (s *EvalService) GetEvalResultSummary(ctx context.Context, opts EvalResultS
 func writeEvalError(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, ErrMissingEvalResults):
-		w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()})
+		writeBadRequest(w, err.Error())
 	case errors.Is(err, ErrMissingEvalStore):
-		w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
-		w.WriteHeader(http.StatusServiceUnavailable)
-		_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "eval store not configured"})
+		writeNotConfigured(w, "eval store not configured")
 	default:
 		writeError(w, err)
 	}
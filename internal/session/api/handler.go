@@ -33,6 +33,7 @@ import (
 	"github.com/altairalabs/omnia/internal/httputil"
 	"github.com/altairalabs/omnia/internal/session"
 	"github.com/altairalabs/omnia/internal/session/providers"
+	"github.com/altairalabs/omnia/pkg/apierror"
 	"github.com/altairalabs/omnia/pkg/logctx"
 )
 
@@ -72,11 +73,28 @@ type MessagesResponse struct {
 	HasMore  bool               `json:"hasMore"`
 }
 
-// ErrorResponse is the JSON response for errors.
-type ErrorResponse struct {
-	Error string `json:"error"`
+// AttachmentsResponse is the JSON response for a session's attachments.
+type AttachmentsResponse struct {
+	Attachments []*session.Artifact `json:"attachments"`
 }
 
+// AttachmentURLResponse is the JSON response for a resolved attachment: the
+// attachment reference and a URL its content can be downloaded from.
+type AttachmentURLResponse struct {
+	Attachment *session.Artifact `json:"attachment"`
+	URL        string            `json:"url"`
+}
+
+// MessageLanguagesResponse is the JSON response for a per-language message
+// breakdown.
+type MessageLanguagesResponse struct {
+	Languages []providers.MessageLanguageCount `json:"languages"`
+}
+
+// ErrorResponse is the JSON response for errors: the message under error,
+// with the error model's code, retryability and correlation ID.
+type ErrorResponse = apierror.Body
+
 // PolicyResolver returns the effective privacy policy JSON for a namespace/agent pair.
 // Returns (policyJSON, true) when a policy applies, or (nil, false) when none applies.
 // Using json.RawMessage keeps this package unaware of ee/ types.
@@ -98,6 +116,7 @@ type Handler struct {
 	evalService          *EvalService
 	providerCallsService *ProviderCallsService
 	providerUsageService *ProviderUsageService
+	annotationService    *AnnotationService
 	policyResolver       PolicyResolver
 	encryptorResolver    EncryptorResolver
 	log                  logr.Logger
@@ -136,6 +155,12 @@ func (h *Handler) SetProviderUsageService(svc *ProviderUsageService) {
 	h.providerUsageService = svc
 }
 
+// SetAnnotationService configures the annotation service for the message
+// annotation endpoints. When unset the endpoints return 503.
+func (h *Handler) SetAnnotationService(svc *AnnotationService) {
+	h.annotationService = svc
+}
+
 // SetPolicyResolver configures the resolver for GET /api/v1/privacy-policy.
 // When unset, the endpoint returns 204 No Content (non-enterprise mode).
 func (h *Handler) SetPolicyResolver(r PolicyResolver) {
@@ -209,6 +234,18 @@ type UpdateStatsRequest struct {
 	session.SessionStatusUpdate
 }
 
+// TransitionSessionRequest is the JSON body for POST /api/v1/sessions/{sessionID}/transition.
+type TransitionSessionRequest struct {
+	Status session.SessionStatus `json:"status"`
+}
+
+// TransitionSessionResponse is returned by POST /api/v1/sessions/{sessionID}/transition.
+type TransitionSessionResponse struct {
+	SessionID      string                `json:"sessionId"`
+	Status         session.SessionStatus `json:"status"`
+	PreviousStatus session.SessionStatus `json:"previousStatus"`
+}
+
 // RefreshTTLRequest is the JSON body for POST /api/v1/sessions/{sessionID}/ttl.
 type RefreshTTLRequest struct {
 	TTLSeconds int `json:"ttlSeconds"`
@@ -236,12 +273,17 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v1/sessions/search", h.handleSearchSessions)
 	mux.HandleFunc("GET /api/v1/sessions/{sessionID}", h.handleGetSession)
 	mux.HandleFunc("GET /api/v1/sessions/{sessionID}/messages", h.handleGetMessages)
+	mux.HandleFunc("GET /api/v1/sessions/{sessionID}/attachments", h.handleGetAttachments)
+	mux.HandleFunc("GET /api/v1/sessions/{sessionID}/attachments/{attachmentID}/url", h.handleGetAttachmentURL)
+	mux.HandleFunc("GET /api/v1/messages/languages", h.handleGetMessageLanguages)
 
 	// Write endpoints
 	mux.HandleFunc("POST /api/v1/sessions", h.handleCreateSession)
 	mux.HandleFunc("POST /api/v1/sessions/{sessionID}/messages", h.handleAppendMessage)
 	mux.HandleFunc("PATCH /api/v1/sessions/{sessionID}/status", h.handleUpdateStats)
 	mux.HandleFunc("PATCH /api/v1/sessions/{sessionID}/stats", h.handleUpdateStats) // backward-compat alias
+	mux.HandleFunc("POST /api/v1/sessions/{sessionID}/transition", h.handleTransitionSession)
+	mux.HandleFunc("POST /api/v1/sessions/{sessionID}/fork", h.handleForkSession)
 	mux.HandleFunc("PATCH /api/v1/sessions/{sessionID}/decorate", h.handleDecorateSession)
 	mux.HandleFunc("POST /api/v1/sessions/{sessionID}/ttl", h.handleRefreshTTL)
 	mux.HandleFunc("DELETE /api/v1/sessions", h.handleBulkDeleteSessions)
@@ -255,6 +297,13 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /api/v1/sessions/{sessionID}/provider-calls", h.handleRecordProviderCall)
 	mux.HandleFunc("GET /api/v1/sessions/{sessionID}/provider-calls", h.handleGetProviderCalls)
 
+	// Message annotation endpoints: end-user feedback, reviewer labels and
+	// suggested corrections.
+	mux.HandleFunc("POST /api/v1/sessions/{sessionID}/messages/{messageID}/annotations", h.handleRecordAnnotation)
+	mux.HandleFunc("GET /api/v1/sessions/{sessionID}/annotations", h.handleGetSessionAnnotations)
+	mux.HandleFunc("GET /api/v1/annotations", h.handleListAnnotations)
+	mux.HandleFunc("GET /api/v1/annotations/aggregate", h.handleAggregateAnnotations)
+
 	// Runtime event endpoints
 	mux.HandleFunc("POST /api/v1/sessions/{sessionID}/events", h.handleRecordRuntimeEvent)
 	mux.HandleFunc("GET /api/v1/sessions/{sessionID}/events", h.handleGetRuntimeEvents)
@@ -323,14 +372,7 @@ func truncateParam(s string, maxLen int) string {
 
 // validSessionStatus returns true if s is one of the known session status values.
 func validSessionStatus(s session.SessionStatus) bool {
-	switch s {
-	case session.SessionStatusActive,
-		session.SessionStatusCompleted,
-		session.SessionStatusError,
-		session.SessionStatusExpired:
-		return true
-	}
-	return false
+	return session.IsValidStatus(s)
 }
 
 // limitBody wraps the request body with a max bytes reader to prevent
@@ -407,6 +449,13 @@ func parseListParams(r *http.Request) (providers.SessionListOpts, error) {
 		opts.Status = s
 	}
 
+	if parent := q.Get("parentSessionId"); parent != "" {
+		if _, err := uuid.Parse(parent); err != nil {
+			return opts, ErrInvalidSessionID
+		}
+		opts.ParentSessionID = parent
+	}
+
 	if from := q.Get("from"); from != "" {
 		t, err := parseTimeParam(from)
 		if err != nil {
@@ -464,65 +513,82 @@ func writeJSON(w http.ResponseWriter, data any) {
 	}
 }
 
-// writeError maps known errors to HTTP status codes and writes a JSON error response.
+// writeError maps known errors to the error model and writes a JSON error
+// response.
 func writeError(w http.ResponseWriter, err error) {
-	status := http.StatusInternalServerError
+	code := apierror.CodeInternalError
 	msg := "internal server error"
 
 	switch {
 	case errors.Is(err, session.ErrSessionNotFound):
-		status = http.StatusNotFound
+		code = apierror.CodeSessionNotFound
 		msg = "session not found"
+	case errors.Is(err, session.ErrMessageNotFound):
+		code = apierror.CodeNotFound
+		msg = "message not found"
+	case errors.Is(err, session.ErrArtifactNotFound):
+		code = apierror.CodeNotFound
+		msg = "attachment not found"
+	case errors.Is(err, ErrAttachmentUnavailable):
+		code = apierror.CodeNotFound
+		msg = ErrAttachmentUnavailable.Error()
+	case errors.Is(err, ErrAttachmentResolverMissing):
+		writeNotConfigured(w, err.Error())
+		return
 	case errors.Is(err, ErrWarmStoreRequired):
-		status = http.StatusServiceUnavailable
-		msg = "warm store not configured"
-	case errors.Is(err, ErrMissingWorkspace):
-		status = http.StatusBadRequest
-		msg = ErrMissingWorkspace.Error()
-	case errors.Is(err, ErrMissingQuery):
-		status = http.StatusBadRequest
-		msg = ErrMissingQuery.Error()
-	case errors.Is(err, ErrMissingSessionID):
-		status = http.StatusBadRequest
-		msg = ErrMissingSessionID.Error()
-	case errors.Is(err, ErrInvalidSessionID):
-		status = http.StatusBadRequest
-		msg = ErrInvalidSessionID.Error()
-	case errors.Is(err, ErrMissingBody):
-		status = http.StatusBadRequest
-		msg = ErrMissingBody.Error()
-	case errors.Is(err, ErrMissingAgentName):
-		status = http.StatusBadRequest
-		msg = ErrMissingAgentName.Error()
-	case errors.Is(err, ErrMissingNamespace):
-		status = http.StatusBadRequest
-		msg = ErrMissingNamespace.Error()
-	case errors.Is(err, ErrMissingVirtualUserID):
-		status = http.StatusBadRequest
-		msg = ErrMissingVirtualUserID.Error()
-	case errors.Is(err, ErrInvalidStatus):
-		status = http.StatusBadRequest
-		msg = ErrInvalidStatus.Error()
-	case errors.Is(err, ErrSearchQueryTooLong):
-		status = http.StatusBadRequest
-		msg = ErrSearchQueryTooLong.Error()
+		writeNotConfigured(w, "warm store not configured")
+		return
+	case errors.Is(err, ErrForkUnsupported),
+		errors.Is(err, ErrLanguageBreakdownUnsupported):
+		writeNotConfigured(w, err.Error())
+		return
+	case errors.Is(err, ErrMissingWorkspace),
+		errors.Is(err, ErrMissingQuery),
+		errors.Is(err, ErrMissingSessionID),
+		errors.Is(err, ErrInvalidSessionID),
+		errors.Is(err, ErrInvalidMessageID),
+		errors.Is(err, ErrInvalidAttachment),
+		errors.Is(err, ErrInvalidAttachmentID),
+		errors.Is(err, ErrMissingBody),
+		errors.Is(err, ErrMissingAgentName),
+		errors.Is(err, ErrMissingNamespace),
+		errors.Is(err, ErrMissingVirtualUserID),
+		errors.Is(err, ErrInvalidStatus),
+		errors.Is(err, ErrSearchQueryTooLong):
+		code = apierror.CodeInvalidRequest
+		msg = err.Error()
+	case errors.Is(err, session.ErrInvalidTransition):
+		code = apierror.CodeConflict
+		msg = err.Error()
 	case errors.Is(err, ErrRateLimitExceeded):
-		status = http.StatusTooManyRequests
+		code = apierror.CodeRateLimited
 		msg = ErrRateLimitExceeded.Error()
 	case errors.Is(err, ErrBodyTooLarge) || isMaxBytesError(err):
-		status = http.StatusRequestEntityTooLarge
+		code = apierror.CodeMessageTooLarge
 		msg = ErrBodyTooLarge.Error()
 	default:
 		var timeErr *time.ParseError
 		if errors.As(err, &timeErr) {
-			status = http.StatusBadRequest
+			code = apierror.CodeInvalidRequest
 			msg = "invalid time format, expected RFC3339"
 		}
 	}
 
-	w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
-	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(ErrorResponse{Error: msg})
+	apierror.WriteHTTP(w, apierror.New(code, msg))
+}
+
+// writeBadRequest writes a 400 error response with msg.
+func writeBadRequest(w http.ResponseWriter, msg string) {
+	apierror.WriteHTTP(w, apierror.New(apierror.CodeInvalidRequest, msg))
+}
+
+// writeNotConfigured writes a 503 error response for a backend this
+// session-api was deployed without. Retrying will not help, so it is not
+// retryable.
+func writeNotConfigured(w http.ResponseWriter, msg string) {
+	e := apierror.New(apierror.CodeUnavailable, msg)
+	e.Retryable = false
+	apierror.WriteHTTP(w, e)
 }
 
 // isMaxBytesError checks if the error is an http.MaxBytesError from MaxBytesReader.
@@ -20,6 +20,9 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/altairalabs/omnia/internal/session"
 	"github.com/altairalabs/omnia/internal/session/providers"
@@ -96,6 +99,9 @@ func (h *Handler) handleAppendMessage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log := h.requestLog(r.Context())
+	// Detect the language before the content is encrypted; the service
+	// cannot see it afterwards.
+	tagMessageLanguage(&msg)
 	toAppend := msg
 	if enc := h.encryptorFor(sessionID); enc != nil {
 		if err := encryptMessage(enc, &toAppend); err != nil {
@@ -116,6 +122,53 @@ func (h *Handler) handleAppendMessage(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// handleGetAttachments returns the attachment references of a session's
+// messages.
+func (h *Handler) handleGetAttachments(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := sessionIDFromRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	attachments, err := h.service.ListAttachments(r.Context(), sessionID)
+	if err != nil {
+		if !errors.Is(err, session.ErrSessionNotFound) {
+			h.requestLog(r.Context()).Error(err, "ListAttachments failed", "sessionID", sessionID)
+		}
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, AttachmentsResponse{Attachments: attachments})
+}
+
+// handleGetAttachmentURL resolves an attachment to a URL its content can be
+// downloaded from.
+func (h *Handler) handleGetAttachmentURL(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := sessionIDFromRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	attachmentID := r.PathValue("attachmentID")
+	if _, err := uuid.Parse(attachmentID); err != nil {
+		writeError(w, ErrInvalidAttachmentID)
+		return
+	}
+
+	attachment, url, err := h.service.ResolveAttachmentURL(r.Context(), sessionID, attachmentID)
+	if err != nil {
+		if !errors.Is(err, session.ErrSessionNotFound) && !errors.Is(err, session.ErrArtifactNotFound) &&
+			!errors.Is(err, ErrAttachmentUnavailable) && !errors.Is(err, ErrAttachmentResolverMissing) {
+			h.requestLog(r.Context()).Error(err, "ResolveAttachmentURL failed",
+				"sessionID", sessionID, "attachmentID", attachmentID)
+		}
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, AttachmentURLResponse{Attachment: attachment, URL: url})
+}
+
 // handleUpdateStats applies incremental counter updates to a session.
 func (h *Handler) handleUpdateStats(w http.ResponseWriter, r *http.Request) {
 	sessionID, err := sessionIDFromRequest(r)
@@ -147,3 +200,75 @@ func (h *Handler) handleUpdateStats(w http.ResponseWriter, r *http.Request) {
 	log.V(2).Info("session status updated", "sessionID", sessionID)
 	w.WriteHeader(http.StatusOK)
 }
+
+// handleTransitionSession moves a session to a new lifecycle status,
+// rejecting moves session.CanTransition does not allow with 409.
+func (h *Handler) handleTransitionSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := sessionIDFromRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	h.limitBody(w, r)
+	var req TransitionSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if isMaxBytesError(err) {
+			writeError(w, ErrBodyTooLarge)
+			return
+		}
+		writeError(w, ErrMissingBody)
+		return
+	}
+	if !validSessionStatus(req.Status) {
+		writeError(w, ErrInvalidStatus)
+		return
+	}
+
+	log := h.requestLog(r.Context())
+	prev, err := h.service.TransitionSession(r.Context(), sessionID, req.Status)
+	if err != nil {
+		if !errors.Is(err, session.ErrSessionNotFound) && !errors.Is(err, session.ErrInvalidTransition) {
+			log.Error(err, "TransitionSession failed", "sessionID", sessionID)
+		}
+		writeError(w, err)
+		return
+	}
+
+	log.V(1).Info("session transitioned", "sessionID", sessionID, "from", prev, "to", req.Status)
+	writeJSON(w, TransitionSessionResponse{SessionID: sessionID, Status: req.Status, PreviousStatus: prev})
+}
+
+// handleGetMessageLanguages breaks a namespace's messages down by detected
+// language.
+// GET /api/v1/messages/languages?namespace=X[&agentName=Y&from=RFC3339&to=RFC3339]
+func (h *Handler) handleGetMessageLanguages(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := providers.MessageLanguageFilter{
+		Namespace: q.Get("namespace"),
+		AgentName: q.Get("agentName"),
+	}
+	for param, dst := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		if v := q.Get(param); v != "" {
+			t, err := parseTimeParam(v)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			*dst = t
+		}
+	}
+
+	languages, err := h.service.LanguageBreakdown(r.Context(), filter)
+	if err != nil {
+		if !errors.Is(err, ErrMissingNamespace) {
+			h.requestLog(r.Context()).Error(err, "LanguageBreakdown failed", "namespace", filter.Namespace)
+		}
+		writeError(w, err)
+		return
+	}
+	if languages == nil {
+		languages = []providers.MessageLanguageCount{}
+	}
+	writeJSON(w, MessageLanguagesResponse{Languages: languages})
+}
@@ -218,6 +218,40 @@ func (h *Handler) handleCreateSession(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(SessionResponse{Session: sess})
 }
 
+// handleForkSession creates a session that continues another from the message
+// named by ?fromMessage= (the whole history when omitted), leaving the
+// original untouched.
+func (h *Handler) handleForkSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := sessionIDFromRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	fromMessage := r.URL.Query().Get("fromMessage")
+	if fromMessage != "" {
+		if _, err := uuid.Parse(fromMessage); err != nil {
+			writeError(w, ErrInvalidMessageID)
+			return
+		}
+	}
+
+	ctx := withRequestContext(r.Context(), extractRequestContext(r))
+	log := h.requestLog(r.Context())
+	fork, err := h.service.ForkSession(ctx, sessionID, fromMessage)
+	if err != nil {
+		if !errors.Is(err, session.ErrSessionNotFound) && !errors.Is(err, session.ErrMessageNotFound) {
+			log.Error(err, "ForkSession failed", "sessionID", sessionID)
+		}
+		writeError(w, err)
+		return
+	}
+
+	log.V(1).Info("session forked", "sessionID", fork.ID, "parent", sessionID, "fromMessage", fromMessage)
+	w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(SessionResponse{Session: fork})
+}
+
 // handleDecorateSession merges tags and state into an existing session without
 // touching counters or lifecycle status. Used to label a facade-recorded session
 // with arena context after the fact.
@@ -341,9 +375,7 @@ func (h *Handler) handleBulkDeleteSessions(w http.ResponseWriter, r *http.Reques
 	if b := q.Get("before"); b != "" {
 		t, err := time.Parse(time.RFC3339, b)
 		if err != nil {
-			w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
-			w.WriteHeader(http.StatusBadRequest)
-			_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "before must be an RFC3339 timestamp"})
+			writeBadRequest(w, "before must be an RFC3339 timestamp")
 			return
 		}
 		scope.Before = t
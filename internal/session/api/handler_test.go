@@ -302,8 +302,14 @@ func (m *mockWarmStore) SaveArtifact(_ context.Context, _ *session.Artifact) err
 func (m *mockWarmStore) GetArtifacts(_ context.Context, _ string) ([]*session.Artifact, error) {
 	return []*session.Artifact{}, nil
 }
-func (m *mockWarmStore) GetSessionArtifacts(_ context.Context, _ string) ([]*session.Artifact, error) {
-	return []*session.Artifact{}, nil
+func (m *mockWarmStore) GetSessionArtifacts(_ context.Context, sessionID string) ([]*session.Artifact, error) {
+	artifacts := []*session.Artifact{}
+	for _, msg := range append(m.messages[sessionID], m.appendedMsgs[sessionID]...) {
+		for i := range msg.Attachments {
+			artifacts = append(artifacts, &msg.Attachments[i])
+		}
+	}
+	return artifacts, nil
 }
 func (m *mockWarmStore) DeleteSessionArtifacts(_ context.Context, _ string) error { return nil }
 func (m *mockWarmStore) Ping(_ context.Context) error                             { return nil }
@@ -2952,13 +2958,7 @@ func TestTruncateParam(t *testing.T) {
 }
 
 func TestValidSessionStatus(t *testing.T) {
-	valid := []session.SessionStatus{
-		session.SessionStatusActive,
-		session.SessionStatusCompleted,
-		session.SessionStatusError,
-		session.SessionStatusExpired,
-	}
-	for _, s := range valid {
+	for _, s := range session.AllStatuses() {
 		if !validSessionStatus(s) {
 			t.Errorf("expected %q to be valid", s)
 		}
@@ -24,6 +24,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/altairalabs/omnia/pkg/metrics"
 )
 
 // uuidRegex matches UUID-like path segments (8-4-4-4-12 hex pattern).
@@ -123,6 +125,8 @@ func (s *statusCapture) Flush() {
 }
 
 // MetricsMiddleware returns HTTP middleware that records request metrics.
+// When the request context carries a trace, its ID is attached to the
+// observation as an exemplar, so it must run inside the tracing middleware.
 func MetricsMiddleware(m *HTTPMetrics, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -134,8 +138,9 @@ func MetricsMiddleware(m *HTTPMetrics, next http.Handler) http.Handler {
 		route := normalizeRoute(r)
 		status := strconv.Itoa(sc.code)
 
-		m.RequestDuration.WithLabelValues(r.Method, route, status).Observe(duration)
-		m.RequestsTotal.WithLabelValues(r.Method, route, status).Inc()
+		exemplar := metrics.TraceExemplar(r.Context())
+		metrics.ObserveWithExemplar(m.RequestDuration.WithLabelValues(r.Method, route, status), duration, exemplar)
+		metrics.IncWithExemplar(m.RequestsTotal.WithLabelValues(r.Method, route, status), exemplar)
 	})
 }
 
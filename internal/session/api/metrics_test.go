@@ -24,6 +24,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestNewHTTPMetrics_DefaultBuckets(t *testing.T) {
@@ -98,6 +99,36 @@ func TestMetricsMiddleware_RecordsRequestMetrics(t *testing.T) {
 	assert.True(t, durationFound, "request_duration_seconds not found")
 }
 
+func TestMetricsMiddleware_AttachesTraceExemplar(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := newHTTPMetricsWithRegistry(reg, nil)
+	handler := MetricsMiddleware(m, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	traceID, err := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{0x01}})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/sessions", nil)
+	req = req.WithContext(trace.ContextWithSpanContext(req.Context(), sc))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	var exemplarTraceID string
+	for _, fam := range families {
+		if fam.GetName() != metricRequestDuration {
+			continue
+		}
+		for _, b := range fam.GetMetric()[0].GetHistogram().GetBucket() {
+			if ex := b.GetExemplar(); ex != nil {
+				exemplarTraceID = ex.GetLabel()[0].GetValue()
+			}
+		}
+	}
+	assert.Equal(t, traceID.String(), exemplarTraceID)
+}
+
 func TestMetricsMiddleware_RecordsStatusCode(t *testing.T) {
 	reg := prometheus.NewRegistry()
 	m := newHTTPMetricsWithRegistry(reg, nil)
@@ -113,19 +113,27 @@ func TestOpenAPISchemaMatchesGoTypes(t *testing.T) {
 		"ProviderCall":  reflect.TypeOf(session.ProviderCall{}),
 		"ProviderUsage": reflect.TypeOf(ProviderUsage{}),
 		"RuntimeEvent":  reflect.TypeOf(session.RuntimeEvent{}),
+		"Attachment":    reflect.TypeOf(session.Artifact{}),
 
 		// Request/response types (internal/session/api/)
 		"CreateSessionRequest":      reflect.TypeOf(CreateSessionRequest{}),
 		"RefreshTTLRequest":         reflect.TypeOf(RefreshTTLRequest{}),
+		"TransitionSessionRequest":  reflect.TypeOf(TransitionSessionRequest{}),
+		"TransitionSessionResponse": reflect.TypeOf(TransitionSessionResponse{}),
 		"SessionStatusUpdate":       reflect.TypeOf(session.SessionStatusUpdate{}),
 		"SessionResponse":           reflect.TypeOf(SessionResponse{}),
 		"SessionListResponse":       reflect.TypeOf(SessionListResponse{}),
 		"MessagesResponse":          reflect.TypeOf(MessagesResponse{}),
+		"AttachmentsResponse":       reflect.TypeOf(AttachmentsResponse{}),
+		"AttachmentURLResponse":     reflect.TypeOf(AttachmentURLResponse{}),
 		"ErrorResponse":             reflect.TypeOf(ErrorResponse{}),
 		"EvalResult":                reflect.TypeOf(EvalResult{}),
 		"EvalResultListResponse":    reflect.TypeOf(EvalResultListResponse{}),
 		"EvalResultSessionResponse": reflect.TypeOf(EvalResultSessionResponse{}),
 		"EvaluateAcceptedResponse":  reflect.TypeOf(EvaluateAcceptedResponse{}),
+		"MessageAnnotation":         reflect.TypeOf(MessageAnnotation{}),
+		"AnnotationResponse":        reflect.TypeOf(AnnotationResponse{}),
+		"AnnotationListResponse":    reflect.TypeOf(AnnotationListResponse{}),
 	}
 
 	for name, goType := range schemaTypes {
@@ -166,9 +174,12 @@ func TestOpenAPIEnumsMatchGoConstants(t *testing.T) {
 	enumTypes := map[string][]string{
 		"SessionStatus": {
 			string(session.SessionStatusActive),
+			string(session.SessionStatusIdle),
+			string(session.SessionStatusEscalated),
 			string(session.SessionStatusCompleted),
 			string(session.SessionStatusError),
 			string(session.SessionStatusExpired),
+			string(session.SessionStatusArchived),
 		},
 		"MessageRole": {
 			string(session.RoleUser),
@@ -226,7 +237,11 @@ func TestOpenAPIRoutesMatchHandler(t *testing.T) {
 		"GET /api/v1/sessions/{sessionID}/messages",
 		"POST /api/v1/sessions",
 		"POST /api/v1/sessions/{sessionID}/messages",
+		"GET /api/v1/sessions/{sessionID}/attachments",
+		"GET /api/v1/sessions/{sessionID}/attachments/{attachmentID}/url",
 		"PATCH /api/v1/sessions/{sessionID}/status",
+		"POST /api/v1/sessions/{sessionID}/transition",
+		"POST /api/v1/sessions/{sessionID}/fork",
 		"POST /api/v1/sessions/{sessionID}/ttl",
 		"DELETE /api/v1/sessions/{sessionID}",
 		"POST /api/v1/sessions/{sessionID}/tool-calls",
@@ -240,6 +255,9 @@ func TestOpenAPIRoutesMatchHandler(t *testing.T) {
 		"POST /api/v1/eval-results",
 		"GET /api/v1/eval-results",
 		"POST /api/v1/provider-usage",
+		"POST /api/v1/sessions/{sessionID}/messages/{messageID}/annotations",
+		"GET /api/v1/sessions/{sessionID}/annotations",
+		"GET /api/v1/annotations",
 		"GET /api/v1/privacy-policy",
 	}
 
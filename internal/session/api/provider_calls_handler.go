@@ -216,9 +216,7 @@ func writeProviderCallsAggregateError(w http.ResponseWriter, err error) {
 		errors.Is(err, errAggregateBadFrom),
 		errors.Is(err, errAggregateBadTo),
 		errors.Is(err, errAggregateMissingNamespace):
-		w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()})
+		writeBadRequest(w, err.Error())
 	default:
 		writeError(w, err)
 	}
@@ -228,9 +226,7 @@ func writeProviderCallsAggregateError(w http.ResponseWriter, err error) {
 // else falls through to writeError.
 func writeProviderCallsError(w http.ResponseWriter, err error) {
 	if errors.Is(err, ErrMissingProviderCallsStore) {
-		w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
-		w.WriteHeader(http.StatusServiceUnavailable)
-		_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "provider calls store not configured"})
+		writeNotConfigured(w, "provider calls store not configured")
 		return
 	}
 	writeError(w, err)
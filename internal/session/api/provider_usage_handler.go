@@ -10,8 +10,6 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
-
-	"github.com/altairalabs/omnia/internal/httputil"
 )
 
 // handleRecordProviderUsage persists one or more workspace-scoped provider
@@ -46,13 +44,9 @@ func (h *Handler) handleRecordProviderUsage(w http.ResponseWriter, r *http.Reque
 func writeProviderUsageError(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, ErrMissingProviderUsageStore):
-		w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
-		w.WriteHeader(http.StatusServiceUnavailable)
-		_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "provider usage store not configured"})
+		writeNotConfigured(w, "provider usage store not configured")
 	case errors.Is(err, ErrInvalidProviderUsage):
-		w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()})
+		writeBadRequest(w, err.Error())
 	default:
 		writeError(w, err)
 	}
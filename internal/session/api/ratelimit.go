@@ -25,7 +25,7 @@ import (
 
 	"golang.org/x/time/rate"
 
-	"github.com/altairalabs/omnia/internal/httputil"
+	"github.com/altairalabs/omnia/pkg/apierror"
 	"github.com/altairalabs/omnia/pkg/ratelimit"
 )
 
@@ -76,9 +76,7 @@ func NewRateLimitMiddleware(cfg RateLimitConfig) (func(http.Handler) http.Handle
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ip := clientIP(r)
 			if !limiter.Allow(ip) {
-				w.Header().Set(httputil.HeaderContentType, httputil.ContentTypeJSON)
-				w.WriteHeader(http.StatusTooManyRequests)
-				_, _ = w.Write([]byte(`{"error":"rate limit exceeded"}`))
+				apierror.WriteHTTP(w, apierror.New(apierror.CodeRateLimited, "rate limit exceeded"))
 				return
 			}
 			next.ServeHTTP(w, r)
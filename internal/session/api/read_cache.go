@@ -0,0 +1,311 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// Read cache metric name constants.
+const (
+	metricReadCacheLookups       = "omnia_session_api_read_cache_lookups_total"
+	metricReadCacheEntries       = "omnia_session_api_read_cache_entries"
+	metricReadCacheEvictions     = "omnia_session_api_read_cache_evictions_total"
+	metricReadCacheInvalidations = "omnia_session_api_read_cache_invalidations_total"
+)
+
+// Read cache lookup kinds and results, used as metric label values.
+const (
+	readCacheKindSession  = "session"
+	readCacheKindMessages = "messages"
+	readCacheResultHit    = "hit"
+	readCacheResultMiss   = "miss"
+)
+
+// DefaultReadCacheTTL bounds how long an entry is served without being
+// invalidated. It is the staleness ceiling when an invalidation is missed
+// (e.g. while the Redis stream is unreachable).
+const DefaultReadCacheTTL = 30 * time.Second
+
+// maxMessageWindows caps the distinct message limits cached per session, so a
+// client paging with varying limits cannot grow one entry without bound.
+const maxMessageWindows = 4
+
+// ReadCacheMetrics holds Prometheus metrics for the read cache. The hit ratio
+// is the hit share of omnia_session_api_read_cache_lookups_total.
+type ReadCacheMetrics struct {
+	// Lookups counts cache lookups by kind (session, messages) and result (hit, miss).
+	Lookups *prometheus.CounterVec
+
+	// Entries is the number of sessions currently cached.
+	Entries prometheus.Gauge
+
+	// Evictions counts entries dropped to stay within the size bound.
+	Evictions prometheus.Counter
+
+	// Invalidations counts invalidations by source (local, remote).
+	Invalidations *prometheus.CounterVec
+}
+
+// NewReadCacheMetrics creates the read cache metrics and registers them with reg.
+func NewReadCacheMetrics(reg prometheus.Registerer) *ReadCacheMetrics {
+	factory := promauto.With(reg)
+	return &ReadCacheMetrics{
+		Lookups: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: metricReadCacheLookups,
+			Help: "Total session-api read cache lookups by kind and result",
+		}, []string{"kind", "result"}),
+		Entries: factory.NewGauge(prometheus.GaugeOpts{
+			Name: metricReadCacheEntries,
+			Help: "Number of sessions held in the session-api read cache",
+		}),
+		Evictions: factory.NewCounter(prometheus.CounterOpts{
+			Name: metricReadCacheEvictions,
+			Help: "Total session-api read cache entries evicted to stay within the size bound",
+		}),
+		Invalidations: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: metricReadCacheInvalidations,
+			Help: "Total session-api read cache invalidations by source",
+		}, []string{"source"}),
+	}
+}
+
+// ReadCache is a process-local LRU of session metadata and recent messages
+// that sits in front of the hot and warm tiers. Dashboards and eval workers
+// re-read the same active sessions many times a minute; serving those reads
+// from memory takes them off Redis and Postgres.
+//
+// Entries are dropped on every local write to the session and, across
+// replicas, by the invalidations RedisCacheInvalidator delivers. Values are
+// copied on the way in and out because handlers decrypt messages in place.
+type ReadCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	ll         *list.List
+	items      map[string]*list.Element
+	metrics    *ReadCacheMetrics
+	now        func() time.Time
+
+	// epoch increases on every invalidation. A reader records it before
+	// querying the tiers and its fill is dropped if an invalidation raced the
+	// query, so a pre-write read can't repopulate a just-invalidated entry.
+	epoch uint64
+}
+
+// readCacheEntry is the cached state of one session.
+type readCacheEntry struct {
+	sessionID string
+	expiresAt time.Time
+	session   *session.Session
+	// messages holds hot-eligible message reads keyed by their limit.
+	messages map[int][]*session.Message
+}
+
+// NewReadCache creates a read cache holding at most maxEntries sessions, each
+// for at most ttl (DefaultReadCacheTTL when zero). metrics may be nil.
+func NewReadCache(maxEntries int, ttl time.Duration, metrics *ReadCacheMetrics) *ReadCache {
+	if ttl <= 0 {
+		ttl = DefaultReadCacheTTL
+	}
+	return &ReadCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		metrics:    metrics,
+		now:        time.Now,
+	}
+}
+
+// Epoch returns the current invalidation epoch, to be passed to the put
+// methods after the tiers have been queried.
+func (c *ReadCache) Epoch() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.epoch
+}
+
+// GetSession returns a copy of the cached session, if present.
+func (c *ReadCache) GetSession(sessionID string) (*session.Session, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.lookup(sessionID)
+	if e == nil || e.session == nil {
+		c.observe(readCacheKindSession, readCacheResultMiss)
+		return nil, false
+	}
+	c.observe(readCacheKindSession, readCacheResultHit)
+	cp := *e.session
+	return &cp, true
+}
+
+// PutSession caches a copy of sess unless an invalidation happened after epoch.
+func (c *ReadCache) PutSession(sess *session.Session, epoch uint64) {
+	if sess == nil {
+		return
+	}
+	cp := *sess
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e := c.entryForPut(sess.ID, epoch); e != nil {
+		e.session = &cp
+	}
+}
+
+// GetMessages returns copies of the messages cached for the given limit.
+func (c *ReadCache) GetMessages(sessionID string, limit int) ([]*session.Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.lookup(sessionID)
+	if e == nil {
+		c.observe(readCacheKindMessages, readCacheResultMiss)
+		return nil, false
+	}
+	msgs, ok := e.messages[limit]
+	if !ok {
+		c.observe(readCacheKindMessages, readCacheResultMiss)
+		return nil, false
+	}
+	c.observe(readCacheKindMessages, readCacheResultHit)
+	return copyMessages(msgs), true
+}
+
+// PutMessages caches copies of msgs for the given limit unless an
+// invalidation happened after epoch.
+func (c *ReadCache) PutMessages(sessionID string, limit int, msgs []*session.Message, epoch uint64) {
+	cp := copyMessages(msgs)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.entryForPut(sessionID, epoch)
+	if e == nil {
+		return
+	}
+	if e.messages == nil || len(e.messages) >= maxMessageWindows {
+		e.messages = make(map[int][]*session.Message, 1)
+	}
+	e.messages[limit] = cp
+}
+
+// Invalidate drops the cached state of one session.
+func (c *ReadCache) Invalidate(sessionID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch++
+	if el, ok := c.items[sessionID]; ok {
+		c.remove(el)
+	}
+}
+
+// Purge drops every cached session. It is used for bulk deletes and when
+// invalidations may have been missed.
+func (c *ReadCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch++
+	c.ll.Init()
+	c.items = make(map[string]*list.Element)
+	c.setEntriesGauge()
+}
+
+// noteInvalidation counts an invalidation from source (local or remote).
+func (c *ReadCache) noteInvalidation(source string) {
+	if c.metrics != nil {
+		c.metrics.Invalidations.WithLabelValues(source).Inc()
+	}
+}
+
+// Len returns the number of cached sessions.
+func (c *ReadCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// lookup returns the live entry for sessionID and marks it most recently
+// used, dropping it if it has expired. Callers hold c.mu.
+func (c *ReadCache) lookup(sessionID string) *readCacheEntry {
+	el, ok := c.items[sessionID]
+	if !ok {
+		return nil
+	}
+	e := el.Value.(*readCacheEntry)
+	if c.now().After(e.expiresAt) {
+		c.remove(el)
+		return nil
+	}
+	c.ll.MoveToFront(el)
+	return e
+}
+
+// entryForPut returns the entry to fill for sessionID, creating it and
+// evicting the least recently used entry as needed. It returns nil when the
+// fill is stale. Callers hold c.mu.
+func (c *ReadCache) entryForPut(sessionID string, epoch uint64) *readCacheEntry {
+	if epoch != c.epoch || c.maxEntries <= 0 {
+		return nil
+	}
+	if e := c.lookup(sessionID); e != nil {
+		return e
+	}
+	e := &readCacheEntry{sessionID: sessionID, expiresAt: c.now().Add(c.ttl)}
+	c.items[sessionID] = c.ll.PushFront(e)
+	for c.ll.Len() > c.maxEntries {
+		c.remove(c.ll.Back())
+		if c.metrics != nil {
+			c.metrics.Evictions.Inc()
+		}
+	}
+	c.setEntriesGauge()
+	return e
+}
+
+// remove unlinks el. Callers hold c.mu.
+func (c *ReadCache) remove(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*readCacheEntry).sessionID)
+	c.setEntriesGauge()
+}
+
+func (c *ReadCache) observe(kind, result string) {
+	if c.metrics != nil {
+		c.metrics.Lookups.WithLabelValues(kind, result).Inc()
+	}
+}
+
+func (c *ReadCache) setEntriesGauge() {
+	if c.metrics != nil {
+		c.metrics.Entries.Set(float64(c.ll.Len()))
+	}
+}
+
+// copyMessages returns a slice of shallow message copies.
+func copyMessages(msgs []*session.Message) []*session.Message {
+	out := make([]*session.Message, len(msgs))
+	for i, m := range msgs {
+		cp := *m
+		out[i] = &cp
+	}
+	return out
+}
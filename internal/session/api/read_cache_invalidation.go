@@ -0,0 +1,148 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Redis Stream used to fan read cache invalidations out to every replica.
+const (
+	invalidationStreamKey          = "omnia:session-api:cache-invalidations"
+	invalidationStreamMaxLen int64 = 10000
+	invalidationReadBlock          = 5 * time.Second
+	invalidationRetryDelay         = time.Second
+)
+
+// Read cache invalidation sources, used as metric label values.
+const (
+	invalidationSourceLocal  = "local"
+	invalidationSourceRemote = "remote"
+)
+
+// CacheInvalidation names what another replica must drop from its read cache:
+// one session, or everything when Purge is set (bulk deletes).
+type CacheInvalidation struct {
+	SessionID string `json:"sessionId,omitempty"`
+	Purge     bool   `json:"purge,omitempty"`
+	// Origin identifies the publishing replica so it can skip its own entries.
+	Origin string `json:"origin"`
+}
+
+// CacheInvalidationPublisher tells other replicas to drop cached reads.
+type CacheInvalidationPublisher interface {
+	PublishInvalidation(ctx context.Context, inv CacheInvalidation) error
+}
+
+// RedisCacheInvalidator publishes read cache invalidations to a Redis Stream
+// and applies the ones other replicas publish. Every replica reads the whole
+// stream (no consumer group), since each holds its own cache.
+type RedisCacheInvalidator struct {
+	client goredis.UniversalClient
+	cache  *ReadCache
+	origin string
+	log    logr.Logger
+}
+
+// NewRedisCacheInvalidator creates an invalidator for cache. The caller
+// retains ownership of the Redis client.
+func NewRedisCacheInvalidator(client goredis.UniversalClient, cache *ReadCache, log logr.Logger) *RedisCacheInvalidator {
+	return &RedisCacheInvalidator{
+		client: client,
+		cache:  cache,
+		origin: uuid.NewString(),
+		log:    log.WithName("cache-invalidator"),
+	}
+}
+
+// PublishInvalidation appends inv to the invalidation stream.
+func (r *RedisCacheInvalidator) PublishInvalidation(ctx context.Context, inv CacheInvalidation) error {
+	inv.Origin = r.origin
+	payload, err := json.Marshal(inv)
+	if err != nil {
+		return fmt.Errorf("marshal invalidation: %w", err)
+	}
+	return r.client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: invalidationStreamKey,
+		MaxLen: invalidationStreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{"payload": string(payload)},
+	}).Err()
+}
+
+// Run applies invalidations from other replicas until ctx is cancelled. It
+// starts at the tail of the stream. After a read error the cache is purged,
+// because invalidations may have been missed while Redis was unreachable.
+func (r *RedisCacheInvalidator) Run(ctx context.Context) {
+	lastID := "$"
+	for ctx.Err() == nil {
+		streams, err := r.client.XRead(ctx, &goredis.XReadArgs{
+			Streams: []string{invalidationStreamKey, lastID},
+			Block:   invalidationReadBlock,
+		}).Result()
+		if errors.Is(err, goredis.Nil) {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			r.log.Error(err, "reading cache invalidations failed; purging read cache")
+			r.cache.Purge()
+			lastID = "$"
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(invalidationRetryDelay):
+			}
+			continue
+		}
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				lastID = msg.ID
+				r.apply(msg)
+			}
+		}
+	}
+}
+
+// apply drops the cached reads named by one stream entry.
+func (r *RedisCacheInvalidator) apply(msg goredis.XMessage) {
+	payload, _ := msg.Values["payload"].(string)
+	var inv CacheInvalidation
+	if err := json.Unmarshal([]byte(payload), &inv); err != nil {
+		r.log.V(1).Info("skipping malformed cache invalidation", "id", msg.ID, "error", err.Error())
+		return
+	}
+	if inv.Origin == r.origin {
+		return
+	}
+	r.cache.noteInvalidation(invalidationSourceRemote)
+	if inv.Purge {
+		r.cache.Purge()
+		return
+	}
+	r.cache.Invalidate(inv.SessionID)
+}
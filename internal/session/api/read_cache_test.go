@@ -0,0 +1,184 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+func TestReadCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	m := NewReadCacheMetrics(prometheus.NewRegistry())
+	c := NewReadCache(2, time.Minute, m)
+
+	c.PutSession(&session.Session{ID: "a"}, c.Epoch())
+	c.PutSession(&session.Session{ID: "b"}, c.Epoch())
+	_, ok := c.GetSession("a") // a is now the most recently used
+	require.True(t, ok)
+	c.PutSession(&session.Session{ID: "c"}, c.Epoch())
+
+	_, ok = c.GetSession("b")
+	assert.False(t, ok, "b was least recently used")
+	_, ok = c.GetSession("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.Evictions))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.Entries))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.Lookups.WithLabelValues(readCacheKindSession, readCacheResultHit)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.Lookups.WithLabelValues(readCacheKindSession, readCacheResultMiss)))
+}
+
+func TestReadCache_Expires(t *testing.T) {
+	c := NewReadCache(10, time.Minute, nil)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	c.PutSession(&session.Session{ID: "a"}, c.Epoch())
+
+	now = now.Add(2 * time.Minute)
+	_, ok := c.GetSession("a")
+	assert.False(t, ok)
+	assert.Zero(t, c.Len())
+}
+
+func TestReadCache_DropsFillRacingInvalidation(t *testing.T) {
+	c := NewReadCache(10, time.Minute, nil)
+	epoch := c.Epoch()
+	c.Invalidate("a") // a write lands while the read is in flight
+	c.PutSession(&session.Session{ID: "a", Status: session.SessionStatusActive}, epoch)
+
+	_, ok := c.GetSession("a")
+	assert.False(t, ok, "a read that started before the write must not be cached")
+}
+
+func TestReadCache_MessagesAreCopied(t *testing.T) {
+	c := NewReadCache(10, time.Minute, nil)
+	c.PutMessages("a", 50, []*session.Message{{ID: "m1", Content: "hello"}}, c.Epoch())
+
+	got, ok := c.GetMessages("a", 50)
+	require.True(t, ok)
+	got[0].Content = "decrypted in place"
+
+	again, ok := c.GetMessages("a", 50)
+	require.True(t, ok)
+	assert.Equal(t, "hello", again[0].Content)
+
+	_, ok = c.GetMessages("a", 10)
+	assert.False(t, ok, "a different limit is a different window")
+}
+
+func TestReadCache_Disabled(t *testing.T) {
+	c := NewReadCache(0, 0, nil)
+	c.PutSession(&session.Session{ID: "a"}, c.Epoch())
+	_, ok := c.GetSession("a")
+	assert.False(t, ok)
+}
+
+func TestSessionService_ReadCache(t *testing.T) {
+	warm := newMockWarmStore()
+	warm.sessions["s1"] = &session.Session{ID: "s1", Namespace: "ns", Status: session.SessionStatusActive}
+	warm.messages["s1"] = []*session.Message{{ID: "m1", Content: "hi"}}
+	registry := providers.NewRegistry()
+	registry.SetWarmStore(warm)
+
+	cache := NewReadCache(10, time.Minute, nil)
+	svc := NewSessionService(registry, ServiceConfig{ReadCache: cache}, logr.Discard())
+	ctx := context.Background()
+
+	_, err := svc.GetSession(ctx, "s1")
+	require.NoError(t, err)
+	_, err = svc.GetMessages(ctx, "s1", providers.MessageQueryOpts{Limit: 50})
+	require.NoError(t, err)
+
+	// Served from memory even after the warm store changes underneath.
+	delete(warm.sessions, "s1")
+	got, err := svc.GetSession(ctx, "s1")
+	require.NoError(t, err)
+	assert.Equal(t, "s1", got.ID)
+	msgs, err := svc.GetMessages(ctx, "s1", providers.MessageQueryOpts{Limit: 50})
+	require.NoError(t, err)
+	assert.Len(t, msgs, 1)
+
+	// Filtered reads bypass the cache.
+	_, ok := cache.GetMessages("s1", 0)
+	assert.False(t, ok)
+
+	// A write through the service invalidates.
+	warm.sessions["s1"] = &session.Session{ID: "s1", Namespace: "ns", Status: session.SessionStatusActive}
+	require.NoError(t, svc.AppendMessage(ctx, "s1", &session.Message{ID: "m2", Role: session.RoleUser}))
+	_, ok = cache.GetSession("s1")
+	assert.False(t, ok)
+}
+
+func TestRedisCacheInvalidator(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+
+	m := NewReadCacheMetrics(prometheus.NewRegistry())
+	local := NewReadCache(10, time.Minute, nil)
+	remote := NewReadCache(10, time.Minute, m)
+	publisher := NewRedisCacheInvalidator(client, local, logr.Discard())
+	subscriber := NewRedisCacheInvalidator(client, remote, logr.Discard())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		subscriber.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		_ = client.Close() // unblocks the pending XREAD
+		<-done
+	})
+
+	remote.PutSession(&session.Session{ID: "s1"}, remote.Epoch())
+	remote.PutSession(&session.Session{ID: "s2"}, remote.Epoch())
+
+	// The subscriber starts reading at the stream tail, so keep publishing
+	// until its first XREAD is in place.
+	require.Eventually(t, func() bool {
+		require.NoError(t, publisher.PublishInvalidation(ctx, CacheInvalidation{SessionID: "s1"}))
+		_, ok := remote.GetSession("s1")
+		return !ok
+	}, 5*time.Second, 50*time.Millisecond)
+	_, ok := remote.GetSession("s2")
+	assert.True(t, ok, "only the named session is invalidated")
+
+	require.NoError(t, publisher.PublishInvalidation(ctx, CacheInvalidation{Purge: true}))
+	require.Eventually(t, func() bool { return remote.Len() == 0 }, 5*time.Second, 20*time.Millisecond)
+	assert.GreaterOrEqual(t, testutil.ToFloat64(m.Invalidations.WithLabelValues(invalidationSourceRemote)), float64(2))
+
+	// A replica ignores its own invalidations.
+	remote.PutSession(&session.Session{ID: "s3"}, remote.Epoch())
+	subscriber.apply(goredis.XMessage{ID: "1-0", Values: map[string]interface{}{
+		"payload": `{"sessionId":"s3","origin":"` + subscriber.origin + `"}`,
+	}})
+	_, ok = remote.GetSession("s3")
+	assert.True(t, ok)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+// StaleTolerantRoutes lists the read endpoints that may be served from a
+// warm-store read replica. They back the dashboard's list, search and
+// analytics views, where results a few seconds behind the primary are fine.
+//
+// Per-session reads (a session, its messages and attachments, tool calls,
+// provider calls, events and eval results) are deliberately absent: the
+// facade, the eval worker and the dashboard read those right after writing
+// them, and a lagging replica would hide the write.
+var StaleTolerantRoutes = []string{
+	"GET /api/v1/sessions",
+	"GET /api/v1/sessions/search",
+	"GET /api/v1/eval-results",
+	"GET /api/v1/eval-results/aggregate",
+	"GET /api/v1/eval-results/discover",
+	"GET /api/v1/provider-calls/aggregate",
+	"GET /api/v1/provider-calls/discover",
+	"GET /api/v1/annotations",
+	"GET /api/v1/annotations/aggregate",
+	"GET /api/v1/messages/languages",
+}
+
+// ReadRoutingMiddleware marks requests to StaleTolerantRoutes with
+// providers.WithStaleReads so the warm store may answer them from its read
+// replica. Every other request keeps reading from the primary.
+func ReadRoutingMiddleware(next http.Handler) http.Handler {
+	// Matching with a ServeMux keeps the table in the same pattern syntax as
+	// RegisterRoutes, including the exact-path and method rules.
+	tolerant := http.NewServeMux()
+	for _, pattern := range StaleTolerantRoutes {
+		tolerant.Handle(pattern, http.NotFoundHandler())
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, pattern := tolerant.Handler(r); pattern != "" {
+			r = r.WithContext(providers.WithStaleReads(r.Context()))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+func TestReadRoutingMiddleware(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		stale  bool
+	}{
+		{http.MethodGet, "/api/v1/sessions", true},
+		{http.MethodGet, "/api/v1/sessions?agent=a&limit=20", true},
+		{http.MethodGet, "/api/v1/sessions/search?q=refund", true},
+		{http.MethodGet, "/api/v1/eval-results", true},
+		{http.MethodGet, "/api/v1/eval-results/aggregate", true},
+		{http.MethodGet, "/api/v1/eval-results/discover", true},
+		{http.MethodGet, "/api/v1/provider-calls/aggregate", true},
+		{http.MethodGet, "/api/v1/provider-calls/discover", true},
+		{http.MethodGet, "/api/v1/annotations?namespace=default&label=wrong-answer", true},
+		{http.MethodGet, "/api/v1/annotations/aggregate", true},
+		{http.MethodGet, "/api/v1/messages/languages?namespace=default", true},
+		{http.MethodGet, "/api/v1/sessions/s-1/annotations", false},
+		{http.MethodGet, "/api/v1/sessions/s-1", false},
+		{http.MethodGet, "/api/v1/sessions/s-1/messages", false},
+		{http.MethodGet, "/api/v1/sessions/s-1/eval-results", false},
+		{http.MethodPost, "/api/v1/sessions", false},
+		{http.MethodPost, "/api/v1/eval-results", false},
+		{http.MethodDelete, "/api/v1/sessions", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			var got bool
+			handler := ReadRoutingMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = providers.StaleReadsAllowed(r.Context())
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+			if got != tt.stale {
+				t.Errorf("stale reads = %v, want %v", got, tt.stale)
+			}
+		})
+	}
+}
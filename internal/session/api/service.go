@@ -38,6 +38,7 @@ var (
 	ErrMissingQuery         = errors.New("search query parameter is required")
 	ErrMissingSessionID     = errors.New("session ID is required")
 	ErrInvalidSessionID     = errors.New("session ID must be a valid UUID")
+	ErrInvalidMessageID     = errors.New("message ID must be a valid UUID")
 	ErrMissingBody          = errors.New("request body is required")
 	ErrMissingAgentName     = errors.New("agentName is required")
 	ErrMissingNamespace     = errors.New("namespace parameter is required")
@@ -69,6 +70,24 @@ type ServiceConfig struct {
 	// When non-nil, events are published asynchronously after message appends and
 	// session completions. Publishing failures are logged but never block the caller.
 	EventPublisher EventPublisher
+
+	// UsageMetrics is optional. When non-nil, every created session and
+	// recorded provider call updates the per-agent usage metrics.
+	UsageMetrics *UsageMetrics
+
+	// ReadCache is an optional process-local cache of session metadata and
+	// recent messages, consulted before the hot cache. Writes through this
+	// service invalidate it.
+	ReadCache *ReadCache
+
+	// CacheInvalidator is optional. When non-nil, every read cache
+	// invalidation is also published so other replicas drop their copies.
+	CacheInvalidator CacheInvalidationPublisher
+
+	// AttachmentResolver is optional. When non-nil, attachment storage URIs
+	// that are not plain http(s) URLs are resolved to download URLs through
+	// it; without one only http(s) attachments can be resolved.
+	AttachmentResolver AttachmentResolver
 }
 
 // maxHotCacheGoroutines is the maximum number of concurrent hot cache push operations.
@@ -83,6 +102,10 @@ type SessionService struct {
 	cacheTTL       time.Duration
 	auditLogger    AuditLogger
 	eventPublisher EventPublisher
+	usageMetrics   *UsageMetrics
+	readCache      *ReadCache
+	invalidator    CacheInvalidationPublisher
+	attachments    AttachmentResolver
 	log            logr.Logger
 	hotCacheSem    chan struct{}
 }
@@ -98,6 +121,10 @@ func NewSessionService(registry *providers.Registry, cfg ServiceConfig, log logr
 		cacheTTL:       ttl,
 		auditLogger:    cfg.AuditLogger,
 		eventPublisher: cfg.EventPublisher,
+		usageMetrics:   cfg.UsageMetrics,
+		readCache:      cfg.ReadCache,
+		invalidator:    cfg.CacheInvalidator,
+		attachments:    cfg.AttachmentResolver,
 		log:            log.WithName("session-service"),
 		hotCacheSem:    make(chan struct{}, maxHotCacheGoroutines),
 	}
@@ -108,7 +135,8 @@ func (s *SessionService) requestLog(ctx context.Context) logr.Logger {
 	return logctx.LoggerWithContext(s.log, ctx)
 }
 
-// GetSession retrieves a session by ID using tiered fallback: hot → warm → cold.
+// GetSession retrieves a session by ID using tiered fallback: read cache →
+// hot → warm → cold.
 func (s *SessionService) GetSession(ctx context.Context, sessionID string) (*session.Session, error) {
 	if sessionID == "" {
 		return nil, ErrMissingSessionID
@@ -116,10 +144,19 @@ func (s *SessionService) GetSession(ctx context.Context, sessionID string) (*ses
 
 	log := s.requestLog(ctx)
 
-	// Try hot cache first.
+	// Try the process-local read cache first.
+	sess, epoch, ok := s.getFromReadCache(sessionID)
+	if ok {
+		log.V(2).Info(logSessionRetrieved, "sessionID", sessionID, "tier", "local")
+		s.auditSessionAccess(ctx, sess)
+		return sess, nil
+	}
+
+	// Try hot cache.
 	sess, err := s.getFromHot(ctx, sessionID)
 	if err == nil {
 		log.V(2).Info(logSessionRetrieved, "sessionID", sessionID, "tier", "hot")
+		s.populateReadCache(sess, epoch)
 		s.auditSessionAccess(ctx, sess)
 		return sess, nil
 	}
@@ -129,6 +166,7 @@ func (s *SessionService) GetSession(ctx context.Context, sessionID string) (*ses
 	if err == nil {
 		log.V(2).Info(logSessionRetrieved, "sessionID", sessionID, "tier", "warm")
 		s.populateHotCache(ctx, sess)
+		s.populateReadCache(sess, epoch)
 		s.auditSessionAccess(ctx, sess)
 		return sess, nil
 	}
@@ -138,6 +176,7 @@ func (s *SessionService) GetSession(ctx context.Context, sessionID string) (*ses
 	if err == nil {
 		log.V(2).Info(logSessionRetrieved, "sessionID", sessionID, "tier", "cold")
 		s.populateHotCache(ctx, sess)
+		s.populateReadCache(sess, epoch)
 		s.auditSessionAccess(ctx, sess)
 		return sess, nil
 	}
@@ -147,7 +186,7 @@ func (s *SessionService) GetSession(ctx context.Context, sessionID string) (*ses
 
 // GetMessages retrieves messages for a session with tiered fallback.
 // Hot-eligible queries (no BeforeSeq/AfterSeq/Roles filter, ascending sort, no offset)
-// are served from the hot cache when available.
+// are served from the read cache or the hot cache when available.
 func (s *SessionService) GetMessages(ctx context.Context, sessionID string, opts providers.MessageQueryOpts) ([]*session.Message, error) {
 	if sessionID == "" {
 		return nil, ErrMissingSessionID
@@ -155,14 +194,25 @@ func (s *SessionService) GetMessages(ctx context.Context, sessionID string, opts
 
 	log := s.requestLog(ctx)
 
+	hotEligible := isHotEligible(opts)
+	var epoch uint64
+	if hotEligible && s.readCache != nil {
+		epoch = s.readCache.Epoch()
+		if msgs, ok := s.readCache.GetMessages(sessionID, opts.Limit); ok {
+			s.auditMessagesAccess(ctx, sessionID, len(msgs))
+			return msgs, nil
+		}
+	}
+
 	// Try hot cache for simple queries.
 	// Only trust the hot cache result if it actually contains messages;
 	// an empty list may indicate the messages key expired or was never
 	// populated while the session key still exists.
-	if isHotEligible(opts) {
+	if hotEligible {
 		if hot, err := s.registry.HotCache(); err == nil {
 			msgs, err := hot.GetRecentMessages(ctx, sessionID, opts.Limit)
 			if err == nil && len(msgs) > 0 {
+				s.populateReadCacheMessages(sessionID, opts.Limit, msgs, epoch)
 				s.auditMessagesAccess(ctx, sessionID, len(msgs))
 				return msgs, nil
 			}
@@ -176,6 +226,9 @@ func (s *SessionService) GetMessages(ctx context.Context, sessionID string, opts
 	if warm, err := s.registry.WarmStore(); err == nil {
 		msgs, err := warm.GetMessages(ctx, sessionID, opts)
 		if err == nil {
+			if hotEligible {
+				s.populateReadCacheMessages(sessionID, opts.Limit, msgs, epoch)
+			}
 			s.auditMessagesAccess(ctx, sessionID, len(msgs))
 			return msgs, nil
 		}
@@ -242,6 +295,7 @@ func (s *SessionService) CreateSession(ctx context.Context, sess *session.Sessio
 			s.log.Error(err, "hot cache write-through failed", "sessionID", sess.ID, "op", "create")
 		}
 	})
+	s.usageMetrics.ObserveSessionCreated(sess)
 	s.auditSessionCreated(ctx, sess)
 	return nil
 }
@@ -269,6 +323,7 @@ func (s *SessionService) DeleteSession(ctx context.Context, sessionID, namespace
 	if err := warm.DeleteSession(ctx, sessionID); err != nil {
 		return err
 	}
+	s.invalidateReadCache(sessionID)
 	// Invalidate hot cache so stale data isn't served.
 	s.pushToHotCache(func(ctx context.Context, hot providers.HotCacheProvider) {
 		if err := hot.Invalidate(ctx, sessionID); err != nil {
@@ -295,6 +350,10 @@ func (s *SessionService) DeleteSessionsByScope(ctx context.Context, scope provid
 	if err != nil {
 		return 0, err
 	}
+	// The read cache is not indexed by scope, so it is dropped entirely.
+	if n > 0 {
+		s.purgeReadCache()
+	}
 	// KNOWN LIMITATION (SEC-8): hot-cache entries for the purged sessions are NOT
 	// proactively invalidated — they are left to expire by TTL (DefaultCacheTTL,
 	// 15m). The warm store is authoritative (the rows are gone), so the cache
@@ -331,6 +390,10 @@ func (s *SessionService) AppendMessage(ctx context.Context, sessionID string, ms
 	if sessionID == "" {
 		return ErrMissingSessionID
 	}
+	if err := normalizeAttachments(sessionID, msg); err != nil {
+		return err
+	}
+	tagMessageLanguage(msg)
 	warm, err := s.registry.WarmStore()
 	if err != nil {
 		return ErrWarmStoreRequired
@@ -338,6 +401,7 @@ func (s *SessionService) AppendMessage(ctx context.Context, sessionID string, ms
 	if err := warm.AppendMessage(ctx, sessionID, msg); err != nil {
 		return err
 	}
+	s.invalidateReadCache(sessionID)
 
 	// Counter auto-increment is handled by the warm store's AppendMessage implementation.
 
@@ -391,6 +455,7 @@ func (s *SessionService) DecorateSession(ctx context.Context, sessionID string,
 	if err := warm.DecorateSession(ctx, sessionID, opts); err != nil {
 		return err
 	}
+	s.invalidateReadCache(sessionID)
 
 	// Invalidate the hot cache so a stale (un-decorated) copy isn't served.
 	s.pushToHotCache(func(ctx context.Context, hot providers.HotCacheProvider) {
@@ -408,6 +473,7 @@ func (s *SessionService) updateStatusOptimized(ctx context.Context, sessionID st
 	if err != nil {
 		return err
 	}
+	s.invalidateReadCache(sessionID)
 
 	s.refreshHotCacheTTL(sessionID)
 
@@ -436,6 +502,7 @@ func (s *SessionService) updateStatusFallback(ctx context.Context, sessionID str
 	if err := warm.UpdateSessionStatus(ctx, sessionID, update); err != nil {
 		return err
 	}
+	s.invalidateReadCache(sessionID)
 
 	s.refreshHotCacheTTL(sessionID)
 
@@ -459,7 +526,11 @@ func (s *SessionService) RefreshTTL(ctx context.Context, sessionID string, ttl t
 	}
 
 	expiresAt := time.Now().Add(ttl)
-	return warm.RefreshTTL(ctx, sessionID, expiresAt)
+	if err := warm.RefreshTTL(ctx, sessionID, expiresAt); err != nil {
+		return err
+	}
+	s.invalidateReadCache(sessionID)
+	return nil
 }
 
 // RecordToolCall records a tool call via the warm store.
@@ -474,6 +545,7 @@ func (s *SessionService) RecordToolCall(ctx context.Context, sessionID string, t
 	if err := warm.RecordToolCall(ctx, sessionID, tc); err != nil {
 		return err
 	}
+	s.invalidateReadCache(sessionID)
 	// Refresh the cached session blob so its tool_call_count aggregate stays in
 	// sync with the warm store's increment.
 	s.refreshHotCacheSession(sessionID)
@@ -492,6 +564,8 @@ func (s *SessionService) RecordProviderCall(ctx context.Context, sessionID strin
 	if err := warm.RecordProviderCall(ctx, sessionID, pc); err != nil {
 		return err
 	}
+	s.invalidateReadCache(sessionID)
+	s.usageMetrics.Observe(pc)
 	// Refresh the cached session blob so its token/cost aggregates stay in sync
 	// with the warm store's increment.
 	s.refreshHotCacheSession(sessionID)
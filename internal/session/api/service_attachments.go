@@ -0,0 +1,231 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/altairalabs/omnia/internal/media"
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// Attachment errors.
+var (
+	// ErrInvalidAttachment is returned when a message carries an attachment
+	// reference that is missing a storage URI or MIME type, or names an
+	// unknown type.
+	ErrInvalidAttachment = errors.New("invalid attachment")
+	// ErrInvalidAttachmentID is returned when an attachment ID is not a UUID.
+	ErrInvalidAttachmentID = errors.New("attachment ID must be a valid UUID")
+	// ErrAttachmentResolverMissing is returned when an attachment stored in
+	// media storage is resolved but no media storage is configured.
+	ErrAttachmentResolverMissing = errors.New("media storage not configured")
+	// ErrAttachmentUnavailable is returned by an AttachmentResolver when the
+	// referenced file no longer exists or has expired.
+	ErrAttachmentUnavailable = errors.New("attachment content is no longer available")
+)
+
+// AttachmentResolver turns the storage URI of an attachment into a URL the
+// caller can download it from, typically a short-lived presigned URL.
+type AttachmentResolver interface {
+	GetDownloadURL(ctx context.Context, storageURI string) (string, error)
+}
+
+// attachmentTypes are the artifact categories accepted on an attachment.
+var attachmentTypes = []string{"image", "audio", "video", "document", "file"}
+
+// normalizeAttachments validates the attachment references on a message of
+// sessionID, defaults each one's type from its MIME type, and marks the
+// message as carrying media of those types. Media storage references must
+// name sessionID, so a message cannot expose another session's media.
+func normalizeAttachments(sessionID string, msg *session.Message) error {
+	for i := range msg.Attachments {
+		a := &msg.Attachments[i]
+		if strings.TrimSpace(a.StorageURI) == "" {
+			return fmt.Errorf("%w: attachment %d: storageUri is required", ErrInvalidAttachment, i)
+		}
+		owner, err := mediaSession(a.StorageURI)
+		if err != nil {
+			return fmt.Errorf("%w: attachment %d: %w", ErrInvalidAttachment, i, err)
+		}
+		if owner != "" && owner != sessionID {
+			return fmt.Errorf("%w: attachment %d: storageUri references another session's media", ErrInvalidAttachment, i)
+		}
+		if strings.TrimSpace(a.MIMEType) == "" {
+			return fmt.Errorf("%w: attachment %d: mimeType is required", ErrInvalidAttachment, i)
+		}
+		if a.SizeBytes < 0 {
+			return fmt.Errorf("%w: attachment %d: sizeBytes must not be negative", ErrInvalidAttachment, i)
+		}
+		if a.Type == "" {
+			a.Type = attachmentType(a.MIMEType)
+		} else if !slices.Contains(attachmentTypes, a.Type) {
+			return fmt.Errorf("%w: attachment %d: type must be one of %s",
+				ErrInvalidAttachment, i, strings.Join(attachmentTypes, ", "))
+		}
+
+		msg.HasMedia = true
+		if !slices.Contains(msg.MediaTypes, a.Type) {
+			msg.MediaTypes = append(msg.MediaTypes, a.Type)
+		}
+	}
+	return nil
+}
+
+// isLinkURI reports whether storageURI is an http(s) URL, served as it is
+// rather than from media storage.
+func isLinkURI(storageURI string) bool {
+	return strings.HasPrefix(storageURI, "https://") || strings.HasPrefix(storageURI, "http://")
+}
+
+// mediaSession returns the session whose media storageURI references, or ""
+// for an http(s) URL. Anything else is not a valid reference.
+func mediaSession(storageURI string) (string, error) {
+	if isLinkURI(storageURI) {
+		return "", nil
+	}
+	ref, err := media.ParseStorageRef(storageURI)
+	if err != nil {
+		return "", err
+	}
+	return ref.SessionID, nil
+}
+
+// attachmentType maps a MIME type onto an attachment type.
+func attachmentType(mimeType string) string {
+	major, _, _ := strings.Cut(strings.ToLower(mimeType), "/")
+	switch {
+	case major == "image" || major == "audio" || major == "video":
+		return major
+	case major == "text" || strings.EqualFold(mimeType, "application/pdf"):
+		return "document"
+	default:
+		return "file"
+	}
+}
+
+// ListAttachments returns the attachment references of a session's messages,
+// in the order they were attached. Sessions that have been compacted out of
+// the warm store are served from the cold archive, whose transcripts carry
+// the same references.
+func (s *SessionService) ListAttachments(ctx context.Context, sessionID string) ([]*session.Artifact, error) {
+	if sessionID == "" {
+		return nil, ErrMissingSessionID
+	}
+
+	if warm, err := s.registry.WarmStore(); err == nil {
+		_, err := warm.GetSession(ctx, sessionID)
+		if err == nil {
+			return warm.GetSessionArtifacts(ctx, sessionID)
+		}
+		if !errors.Is(err, session.ErrSessionNotFound) {
+			return nil, err
+		}
+	}
+
+	if cold, err := s.registry.ColdArchive(); err == nil {
+		sess, err := cold.GetSession(ctx, sessionID)
+		if err == nil {
+			attachments := []*session.Artifact{}
+			for _, m := range sess.Messages {
+				for i := range m.Attachments {
+					attachments = append(attachments, &m.Attachments[i])
+				}
+			}
+			return attachments, nil
+		}
+		if !errors.Is(err, session.ErrSessionNotFound) {
+			return nil, err
+		}
+	}
+	return nil, session.ErrSessionNotFound
+}
+
+// ResolveAttachmentURL returns an attachment of a session together with a
+// URL to download it from. http(s) storage URIs are returned as they are;
+// media storage references are resolved through the configured
+// AttachmentResolver, and only when they name the session or one it was
+// forked from, whose attachments a fork copies. An attachment referencing any
+// other session's media is reported as not found.
+func (s *SessionService) ResolveAttachmentURL(ctx context.Context, sessionID, attachmentID string) (*session.Artifact, string, error) {
+	attachments, err := s.ListAttachments(ctx, sessionID)
+	if err != nil {
+		return nil, "", err
+	}
+	idx := slices.IndexFunc(attachments, func(a *session.Artifact) bool { return a.ID == attachmentID })
+	if idx < 0 {
+		return nil, "", session.ErrArtifactNotFound
+	}
+	attachment := attachments[idx]
+
+	if isLinkURI(attachment.StorageURI) {
+		return attachment, attachment.StorageURI, nil
+	}
+	owner, err := mediaSession(attachment.StorageURI)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: attachment %s: %w", session.ErrArtifactNotFound, attachmentID, err)
+	}
+	if owner != sessionID && !slices.Contains(s.forkAncestors(ctx, sessionID), owner) {
+		return nil, "", fmt.Errorf("%w: attachment %s references another session's media",
+			session.ErrArtifactNotFound, attachmentID)
+	}
+	if s.attachments == nil {
+		return nil, "", ErrAttachmentResolverMissing
+	}
+	url, err := s.attachments.GetDownloadURL(ctx, attachment.StorageURI)
+	if err != nil {
+		return nil, "", fmt.Errorf("resolve attachment %s: %w", attachmentID, err)
+	}
+	return attachment, url, nil
+}
+
+// maxForkDepth bounds the fork chain forkAncestors follows.
+const maxForkDepth = 32
+
+// forkAncestors returns the sessions sessionID was forked from, nearest
+// first. A session that cannot be read ends the chain.
+func (s *SessionService) forkAncestors(ctx context.Context, sessionID string) []string {
+	var ancestors []string
+	for id := sessionID; len(ancestors) < maxForkDepth; {
+		sess, err := s.storedSession(ctx, id)
+		if err != nil || sess.ParentSessionID == "" || slices.Contains(ancestors, sess.ParentSessionID) {
+			break
+		}
+		id = sess.ParentSessionID
+		ancestors = append(ancestors, id)
+	}
+	return ancestors
+}
+
+// storedSession reads a session from the warm store, or from the cold
+// archive once it has been compacted out of it.
+func (s *SessionService) storedSession(ctx context.Context, sessionID string) (*session.Session, error) {
+	if warm, err := s.registry.WarmStore(); err == nil {
+		sess, err := warm.GetSession(ctx, sessionID)
+		if !errors.Is(err, session.ErrSessionNotFound) {
+			return sess, err
+		}
+	}
+	if cold, err := s.registry.ColdArchive(); err == nil {
+		return cold.GetSession(ctx, sessionID)
+	}
+	return nil, session.ErrSessionNotFound
+}
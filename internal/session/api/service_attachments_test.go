@@ -0,0 +1,230 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+const (
+	testAttachmentID    = "00000000-0000-0000-0000-0000000000b1"
+	testLinkAttachment  = "00000000-0000-0000-0000-0000000000b2"
+	testColdAttachment  = "00000000-0000-0000-0000-0000000000b3"
+	testMediaStorageURI = "omnia://sessions/" + testSessionID + "/media/m-1"
+	testColdStorageURI  = "omnia://sessions/" + testSessionIDOther + "/media/m-2"
+	testForeignMediaID  = "00000000-0000-0000-0000-0000000000b4"
+	testForkAttachment  = "00000000-0000-0000-0000-0000000000b5"
+	testForkSessionID   = "00000000-0000-0000-0000-000000000003"
+)
+
+// fakeAttachmentResolver resolves storage URIs to a fixed host, or fails
+// with err.
+type fakeAttachmentResolver struct {
+	err error
+}
+
+func (f fakeAttachmentResolver) GetDownloadURL(_ context.Context, storageURI string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	return "https://media.example/" + strings.TrimPrefix(storageURI, "omnia://"), nil
+}
+
+func setupAttachmentHandler(t *testing.T, resolver AttachmentResolver) (http.Handler, *mockWarmStore) {
+	t.Helper()
+	warm := newMockWarmStore()
+	warm.sessions[testSessionID] = testSession(testSessionID)
+	warm.messages[testSessionID] = []*session.Message{{
+		ID: testMessageID, Role: session.RoleUser, HasMedia: true,
+		Attachments: []session.Artifact{
+			{ID: testAttachmentID, Type: "image", MIMEType: "image/png", StorageURI: testMediaStorageURI},
+			{ID: testLinkAttachment, Type: "file", MIMEType: "text/csv", StorageURI: "https://files.example/a.csv"},
+			{ID: testForeignMediaID, Type: "image", MIMEType: "image/png", StorageURI: testColdStorageURI},
+		},
+	}}
+	// A fork of the archived session keeps referencing its parent's media.
+	fork := testSession(testForkSessionID)
+	fork.ParentSessionID = testSessionIDOther
+	warm.sessions[testForkSessionID] = fork
+	warm.messages[testForkSessionID] = []*session.Message{{
+		ID: testMessageID, Role: session.RoleUser, HasMedia: true,
+		Attachments: []session.Artifact{
+			{ID: testForkAttachment, Type: "audio", MIMEType: "audio/wav", StorageURI: testColdStorageURI},
+		},
+	}}
+	archived := testSession(testSessionIDOther)
+	archived.Messages = []session.Message{{
+		ID: testMessageID, Role: session.RoleUser, HasMedia: true,
+		Attachments: []session.Artifact{
+			{ID: testColdAttachment, Type: "audio", MIMEType: "audio/wav", StorageURI: testColdStorageURI},
+		},
+	}}
+	cold := newMockColdArchive()
+	cold.sessions[testSessionIDOther] = archived
+
+	reg := providers.NewRegistry()
+	reg.SetWarmStore(warm)
+	reg.SetColdArchive(cold)
+	svc := NewSessionService(reg, ServiceConfig{AttachmentResolver: resolver}, logr.Discard())
+	mux := http.NewServeMux()
+	NewHandler(svc, logr.Discard()).RegisterRoutes(mux)
+	return mux, warm
+}
+
+func TestNormalizeAttachments(t *testing.T) {
+	msg := &session.Message{Attachments: []session.Artifact{
+		{MIMEType: "image/png", StorageURI: testMediaStorageURI},
+		{MIMEType: "application/pdf", StorageURI: "https://files.example/b.pdf"},
+		{MIMEType: "image/jpeg", StorageURI: testMediaStorageURI},
+		{MIMEType: "application/zip", StorageURI: testMediaStorageURI},
+	}}
+	require.NoError(t, normalizeAttachments(testSessionID, msg))
+
+	assert.True(t, msg.HasMedia)
+	assert.Equal(t, []string{"image", "document", "file"}, msg.MediaTypes)
+	assert.Equal(t, "document", msg.Attachments[1].Type)
+	assert.Equal(t, "file", msg.Attachments[3].Type)
+
+	plain := &session.Message{Content: "hi"}
+	require.NoError(t, normalizeAttachments(testSessionID, plain))
+	assert.False(t, plain.HasMedia)
+}
+
+func TestNormalizeAttachments_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		attachment session.Artifact
+	}{
+		{"missing storage URI", session.Artifact{MIMEType: "image/png"}},
+		{"missing MIME type", session.Artifact{StorageURI: testMediaStorageURI}},
+		{"unknown type", session.Artifact{Type: "hologram", MIMEType: "image/png", StorageURI: testMediaStorageURI}},
+		{"negative size", session.Artifact{MIMEType: "image/png", StorageURI: testMediaStorageURI, SizeBytes: -1}},
+		{"malformed storage reference", session.Artifact{MIMEType: "image/png", StorageURI: "omnia://a"}},
+		{"unknown scheme", session.Artifact{MIMEType: "image/png", StorageURI: "s3://bucket/key"}},
+		{"another session's media", session.Artifact{MIMEType: "image/png", StorageURI: testColdStorageURI}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &session.Message{Attachments: []session.Artifact{tt.attachment}}
+			assert.ErrorIs(t, normalizeAttachments(testSessionID, msg), ErrInvalidAttachment)
+		})
+	}
+}
+
+func TestHandleAppendMessage_Attachments(t *testing.T) {
+	mux, warm := setupAttachmentHandler(t, nil)
+
+	body := `{"role":"user","content":"see attached","attachments":[{"mimeType":"audio/mpeg","storageUri":"` +
+		testMediaStorageURI + `"}]}`
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/sessions/"+testSessionID+"/messages", strings.NewReader(body)))
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	appended := warm.appendedMsgs[testSessionID]
+	require.Len(t, appended, 1)
+	assert.True(t, appended[0].HasMedia)
+	assert.Equal(t, []string{"audio"}, appended[0].MediaTypes)
+
+	body = `{"role":"user","content":"x","attachments":[{"storageUri":"` + testMediaStorageURI + `"}]}`
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/sessions/"+testSessionID+"/messages", strings.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+}
+
+func TestHandleGetAttachments(t *testing.T) {
+	mux, _ := setupAttachmentHandler(t, nil)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/sessions/"+testSessionID+"/attachments", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Len(t, decodeJSON[AttachmentsResponse](t, rec).Attachments, 3)
+
+	// A session that only exists in the cold archive keeps its attachments.
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/sessions/"+testSessionIDOther+"/attachments", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	got := decodeJSON[AttachmentsResponse](t, rec).Attachments
+	require.Len(t, got, 1)
+	assert.Equal(t, testColdAttachment, got[0].ID)
+}
+
+func TestHandleGetAttachmentURL(t *testing.T) {
+	mux, _ := setupAttachmentHandler(t, fakeAttachmentResolver{})
+
+	get := func(sessionID, attachmentID string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+			"/api/v1/sessions/"+sessionID+"/attachments/"+attachmentID+"/url", nil))
+		return rec
+	}
+
+	rec := get(testSessionID, testAttachmentID)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	resp := decodeJSON[AttachmentURLResponse](t, rec)
+	assert.Equal(t, "https://media.example/sessions/"+testSessionID+"/media/m-1", resp.URL)
+	assert.Equal(t, "image/png", resp.Attachment.MIMEType)
+
+	rec = get(testSessionID, testLinkAttachment)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "https://files.example/a.csv", decodeJSON[AttachmentURLResponse](t, rec).URL)
+
+	rec = get(testSessionIDOther, testColdAttachment)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = get(testForkSessionID, testForkAttachment)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "https://media.example/sessions/"+testSessionIDOther+"/media/m-2",
+		decodeJSON[AttachmentURLResponse](t, rec).URL, "a fork resolves the media it copied from its parent")
+}
+
+func TestHandleGetAttachmentURL_Errors(t *testing.T) {
+	unknownSession := "00000000-0000-0000-0000-000000000009"
+	tests := []struct {
+		name     string
+		resolver AttachmentResolver
+		session  string
+		id       string
+		want     int
+	}{
+		{"malformed attachment ID", fakeAttachmentResolver{}, testSessionID, "nope", http.StatusBadRequest},
+		{"unknown attachment", fakeAttachmentResolver{}, testSessionID, testColdAttachment, http.StatusNotFound},
+		{"unknown session", fakeAttachmentResolver{}, unknownSession, testAttachmentID, http.StatusNotFound},
+		{"media gone", fakeAttachmentResolver{err: ErrAttachmentUnavailable}, testSessionID, testAttachmentID, http.StatusNotFound},
+		{"no media storage", nil, testSessionID, testAttachmentID, http.StatusServiceUnavailable},
+		{"another session's media", fakeAttachmentResolver{}, testSessionID, testForeignMediaID, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, _ := setupAttachmentHandler(t, tt.resolver)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+				"/api/v1/sessions/"+tt.session+"/attachments/"+tt.id+"/url", nil))
+			assert.Equal(t, tt.want, rec.Code, rec.Body.String())
+		})
+	}
+}
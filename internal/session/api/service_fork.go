@@ -0,0 +1,89 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+// ErrForkUnsupported is returned by ForkSession when the warm store cannot
+// fork sessions.
+var ErrForkUnsupported = errors.New("warm store does not support forking sessions")
+
+// ForkSession creates an active session that continues sessionID from
+// fromMessageID: it starts with copies of the parent's messages up to and
+// including that message (all of them when fromMessageID is empty) and takes
+// the parent's agent, workspace, PromptPack, rollout cohort, user, tags and
+// state. The parent is not modified.
+func (s *SessionService) ForkSession(ctx context.Context, sessionID, fromMessageID string) (*session.Session, error) {
+	if sessionID == "" {
+		return nil, ErrMissingSessionID
+	}
+	warm, err := s.registry.WarmStore()
+	if err != nil {
+		return nil, ErrWarmStoreRequired
+	}
+	forker, ok := warm.(providers.SessionForker)
+	if !ok {
+		return nil, ErrForkUnsupported
+	}
+	parent, err := warm.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	fork := &session.Session{
+		ID:                  uuid.New().String(),
+		AgentName:           parent.AgentName,
+		Namespace:           parent.Namespace,
+		WorkspaceName:       parent.WorkspaceName,
+		Status:              session.SessionStatusActive,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+		Tags:                slices.Clone(parent.Tags),
+		State:               maps.Clone(parent.State),
+		PromptPackName:      parent.PromptPackName,
+		PromptPackVersion:   parent.PromptPackVersion,
+		CohortID:            parent.CohortID,
+		Variant:             parent.Variant,
+		VirtualUserID:       parent.VirtualUserID,
+		ParentSessionID:     parent.ID,
+		ForkedFromMessageID: fromMessageID,
+	}
+	if err := forker.ForkSession(ctx, fork, fromMessageID); err != nil {
+		return nil, err
+	}
+
+	s.pushToHotCache(func(ctx context.Context, hot providers.HotCacheProvider) {
+		if err := hot.SetSession(ctx, fork, s.cacheTTL); err != nil {
+			s.log.Error(err, "hot cache write-through failed", "sessionID", fork.ID, "op", "fork")
+		}
+	})
+	s.usageMetrics.ObserveSessionCreated(fork)
+	s.auditSessionCreated(ctx, fork)
+	return fork, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+const testMessageID = "00000000-0000-0000-0000-0000000000a2"
+
+// mockForkWarmStore adds SessionForker to mockWarmStore.
+type mockForkWarmStore struct {
+	*mockWarmStore
+}
+
+func (m *mockForkWarmStore) ForkSession(_ context.Context, fork *session.Session, throughMessageID string) error {
+	if _, ok := m.sessions[fork.ParentSessionID]; !ok {
+		return session.ErrSessionNotFound
+	}
+	var copied []*session.Message
+	found := throughMessageID == ""
+	for _, msg := range m.messages[fork.ParentSessionID] {
+		c := *msg
+		copied = append(copied, &c)
+		if msg.ID == throughMessageID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return session.ErrMessageNotFound
+	}
+	fork.MessageCount = int32(len(copied))
+	m.sessions[fork.ID] = fork
+	m.messages[fork.ID] = copied
+	return nil
+}
+
+func setupForkHandler(t *testing.T) (http.Handler, *mockForkWarmStore) {
+	t.Helper()
+	warm := &mockForkWarmStore{mockWarmStore: newMockWarmStore()}
+	warm.sessions[testSessionID] = &session.Session{
+		ID: testSessionID, AgentName: "a", Namespace: "ns", WorkspaceName: "ws",
+		Status: session.SessionStatusCompleted, Tags: []string{"t"}, VirtualUserID: "vu",
+	}
+	warm.messages[testSessionID] = []*session.Message{
+		{ID: "00000000-0000-0000-0000-0000000000a1", Role: session.RoleUser},
+		{ID: testMessageID, Role: session.RoleAssistant},
+		{ID: "00000000-0000-0000-0000-0000000000a3", Role: session.RoleUser},
+	}
+	reg := providers.NewRegistry()
+	reg.SetWarmStore(warm)
+	h := NewHandler(NewSessionService(reg, ServiceConfig{}, logr.Discard()), logr.Discard())
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+	return mux, warm
+}
+
+func TestHandleForkSession(t *testing.T) {
+	mux, warm := setupForkHandler(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/sessions/"+testSessionID+"/fork?fromMessage="+testMessageID, nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	fork := decodeJSON[SessionResponse](t, rec).Session
+	assert.NotEqual(t, testSessionID, fork.ID)
+	assert.Equal(t, testSessionID, fork.ParentSessionID)
+	assert.Equal(t, testMessageID, fork.ForkedFromMessageID)
+	assert.Equal(t, session.SessionStatusActive, fork.Status)
+	assert.Equal(t, "a", fork.AgentName)
+	assert.Equal(t, "vu", fork.VirtualUserID)
+	assert.Equal(t, int32(2), fork.MessageCount)
+	assert.Len(t, warm.messages[fork.ID], 2)
+	assert.Equal(t, session.SessionStatusCompleted, warm.sessions[testSessionID].Status, "the parent is untouched")
+}
+
+func TestHandleForkSession_Errors(t *testing.T) {
+	mux, _ := setupForkHandler(t)
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"unknown session", "/api/v1/sessions/" + testSessionIDOther + "/fork", http.StatusNotFound},
+		{"unknown message", "/api/v1/sessions/" + testSessionID + "/fork?fromMessage=" + testSessionIDOther, http.StatusNotFound},
+		{"malformed message", "/api/v1/sessions/" + testSessionID + "/fork?fromMessage=nope", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.path, nil))
+			assert.Equal(t, tt.want, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestForkSession_Unsupported(t *testing.T) {
+	reg := providers.NewRegistry()
+	reg.SetWarmStore(newMockWarmStore())
+	svc := NewSessionService(reg, ServiceConfig{}, logr.Discard())
+
+	_, err := svc.ForkSession(context.Background(), testSessionID, "")
+	assert.ErrorIs(t, err, ErrForkUnsupported)
+}
+
+func TestParseListParams_ParentSessionID(t *testing.T) {
+	opts, err := parseListParams(httptest.NewRequest(http.MethodGet, "/api/v1/sessions?parentSessionId="+testSessionID, nil))
+	require.NoError(t, err)
+	assert.Equal(t, testSessionID, opts.ParentSessionID)
+
+	_, err = parseListParams(httptest.NewRequest(http.MethodGet, "/api/v1/sessions?parentSessionId=x", nil))
+	assert.ErrorIs(t, err, ErrInvalidSessionID)
+}
@@ -23,7 +23,7 @@ import (
 	"github.com/altairalabs/omnia/internal/session/providers"
 )
 
-// This file holds the tiered-store accessors and hot-cache write-through
+// This file holds the tiered-store accessors and the hot-cache and read-cache
 // helpers for SessionService. They are split out of service.go so each file
 // keeps to a single responsibility (see issue #1325).
 
@@ -121,3 +121,65 @@ func (s *SessionService) refreshHotCacheSession(sessionID string) {
 		}
 	})
 }
+
+// getFromReadCache looks sessionID up in the read cache. It also returns the
+// cache epoch to pass to populateReadCache once a lower tier has answered.
+func (s *SessionService) getFromReadCache(sessionID string) (*session.Session, uint64, bool) {
+	if s.readCache == nil {
+		return nil, 0, false
+	}
+	epoch := s.readCache.Epoch()
+	sess, ok := s.readCache.GetSession(sessionID)
+	return sess, epoch, ok
+}
+
+// populateReadCache stores a session read from a lower tier in the read cache.
+func (s *SessionService) populateReadCache(sess *session.Session, epoch uint64) {
+	if s.readCache != nil {
+		s.readCache.PutSession(sess, epoch)
+	}
+}
+
+// populateReadCacheMessages stores a hot-eligible message read in the read cache.
+func (s *SessionService) populateReadCacheMessages(sessionID string, limit int, msgs []*session.Message, epoch uint64) {
+	if s.readCache != nil {
+		s.readCache.PutMessages(sessionID, limit, msgs, epoch)
+	}
+}
+
+// invalidateReadCache drops a session from the local read cache after a write
+// and tells the other replicas to drop it too.
+func (s *SessionService) invalidateReadCache(sessionID string) {
+	if s.readCache == nil {
+		return
+	}
+	s.readCache.Invalidate(sessionID)
+	s.readCache.noteInvalidation(invalidationSourceLocal)
+	s.publishInvalidation(CacheInvalidation{SessionID: sessionID})
+}
+
+// purgeReadCache drops every session from the local read cache and tells the
+// other replicas to do the same.
+func (s *SessionService) purgeReadCache() {
+	if s.readCache == nil {
+		return
+	}
+	s.readCache.Purge()
+	s.readCache.noteInvalidation(invalidationSourceLocal)
+	s.publishInvalidation(CacheInvalidation{Purge: true})
+}
+
+// publishInvalidation publishes inv in the background so the write path is
+// never blocked on Redis. A lost invalidation is bounded by the read cache TTL.
+func (s *SessionService) publishInvalidation(inv CacheInvalidation) {
+	if s.invalidator == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		defer cancel()
+		if err := s.invalidator.PublishInvalidation(ctx, inv); err != nil {
+			s.log.Error(err, "failed to publish read cache invalidation", "sessionID", inv.SessionID)
+		}
+	}()
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/langdetect"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+// MetadataKeyLanguage is the message metadata key holding the BCP 47 code of
+// the language a message is written in.
+const MetadataKeyLanguage = "language"
+
+// ErrLanguageBreakdownUnsupported is returned by LanguageBreakdown when the
+// warm store cannot aggregate messages by language.
+var ErrLanguageBreakdownUnsupported = errors.New("warm store does not support language breakdowns")
+
+// tagMessageLanguage records the detected language of msg's content under
+// MetadataKeyLanguage. A language the caller already set is kept, and
+// encrypted or undetectable content is left untagged. Only user and
+// assistant messages are tagged.
+func tagMessageLanguage(msg *session.Message) {
+	if msg.Role != session.RoleUser && msg.Role != session.RoleAssistant {
+		return
+	}
+	if msg.Metadata[MetadataKeyLanguage] != "" || strings.HasPrefix(msg.Content, errMsgEncPrefix) {
+		return
+	}
+	lang := langdetect.Detect(msg.Content)
+	if lang == langdetect.Undetermined {
+		return
+	}
+	if msg.Metadata == nil {
+		msg.Metadata = make(map[string]string, 1)
+	}
+	msg.Metadata[MetadataKeyLanguage] = lang
+}
+
+// LanguageBreakdown counts a namespace's user and assistant messages, and
+// the sessions they belong to, per detected language.
+func (s *SessionService) LanguageBreakdown(ctx context.Context, filter providers.MessageLanguageFilter) ([]providers.MessageLanguageCount, error) {
+	if filter.Namespace == "" {
+		return nil, ErrMissingNamespace
+	}
+	warm, err := s.registry.WarmStore()
+	if err != nil {
+		return nil, ErrWarmStoreRequired
+	}
+	aggregator, ok := warm.(providers.MessageLanguageAggregator)
+	if !ok {
+		return nil, ErrLanguageBreakdownUnsupported
+	}
+	return aggregator.MessageLanguageBreakdown(ctx, filter)
+}
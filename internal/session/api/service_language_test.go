@@ -0,0 +1,136 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+// languageWarmStore is a mock warm store that can break messages down by
+// language, recording the filter it was asked for.
+type languageWarmStore struct {
+	*mockWarmStore
+	filter providers.MessageLanguageFilter
+}
+
+func (s *languageWarmStore) MessageLanguageBreakdown(
+	_ context.Context, filter providers.MessageLanguageFilter,
+) ([]providers.MessageLanguageCount, error) {
+	s.filter = filter
+	return []providers.MessageLanguageCount{
+		{Language: "es", Messages: 12, Sessions: 3},
+		{Language: "en", Messages: 4, Sessions: 2},
+	}, nil
+}
+
+func TestTagMessageLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  session.Message
+		want string
+	}{
+		{"user message", session.Message{Role: session.RoleUser, Content: "¿Dónde está mi pedido? Lo necesito para el lunes."}, "es"},
+		{"assistant message", session.Message{Role: session.RoleAssistant, Content: "Your order is on the way and will arrive today."}, "en"},
+		{"caller-set language is kept", session.Message{
+			Role: session.RoleUser, Content: "Where is the order and when will it arrive?",
+			Metadata: map[string]string{MetadataKeyLanguage: "en-GB"},
+		}, "en-GB"},
+		{"system message", session.Message{Role: session.RoleSystem, Content: "You are a helpful assistant and you answer in English."}, ""},
+		{"encrypted content", session.Message{Role: session.RoleUser, Content: errMsgEncPrefix + "dGhlIGFuZCBpcw=="}, ""},
+		{"undetermined", session.Message{Role: session.RoleUser, Content: "ok"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := tt.msg
+			tagMessageLanguage(&msg)
+			assert.Equal(t, tt.want, msg.Metadata[MetadataKeyLanguage])
+		})
+	}
+}
+
+func TestHandleAppendMessage_TagsLanguageBeforeEncryption(t *testing.T) {
+	h, warm := setupEncryptionHandler(t)
+	warm.sessions[encSessionA] = testSession(encSessionA)
+	h.SetEncryptorResolver(encResolverFor(encSessionA, mockEncryptor{key: 0x5A}))
+
+	body := `{"role":"user","content":"Bonjour, pourquoi ma commande n'est pas encore arrivée ?"}`
+	rec := httptest.NewRecorder()
+	serveMux(h).ServeHTTP(rec, newEncRequest(http.MethodPost, "/api/v1/sessions/"+encSessionA+"/messages", body))
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	stored := warm.appendedMsgs[encSessionA]
+	require.Len(t, stored, 1)
+	assert.True(t, strings.HasPrefix(stored[0].Content, errMsgEncPrefix))
+	assert.Equal(t, "fr", stored[0].Metadata[MetadataKeyLanguage])
+}
+
+func TestHandleGetMessageLanguages(t *testing.T) {
+	warm := &languageWarmStore{mockWarmStore: newMockWarmStore()}
+	reg := providers.NewRegistry()
+	reg.SetWarmStore(warm)
+	mux := http.NewServeMux()
+	NewHandler(NewSessionService(reg, ServiceConfig{}, logr.Discard()), logr.Discard()).RegisterRoutes(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/api/v1/messages/languages?namespace=default&agentName=support&from=2026-01-01T00:00:00Z", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	resp := decodeJSON[MessageLanguagesResponse](t, rec)
+	require.Len(t, resp.Languages, 2)
+	assert.Equal(t, "es", resp.Languages[0].Language)
+	assert.Equal(t, int64(3), resp.Languages[0].Sessions)
+	assert.Equal(t, "default", warm.filter.Namespace)
+	assert.Equal(t, "support", warm.filter.AgentName)
+	assert.Equal(t, 2026, warm.filter.From.Year())
+	assert.True(t, warm.filter.To.IsZero())
+}
+
+func TestHandleGetMessageLanguages_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		warm  providers.WarmStoreProvider
+		query string
+		want  int
+	}{
+		{"missing namespace", &languageWarmStore{mockWarmStore: newMockWarmStore()}, "", http.StatusBadRequest},
+		{"bad from", &languageWarmStore{mockWarmStore: newMockWarmStore()}, "namespace=default&from=yesterday", http.StatusBadRequest},
+		{"unsupported warm store", newMockWarmStore(), "namespace=default", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := providers.NewRegistry()
+			reg.SetWarmStore(tt.warm)
+			mux := http.NewServeMux()
+			NewHandler(NewSessionService(reg, ServiceConfig{}, logr.Discard()), logr.Discard()).RegisterRoutes(mux)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/messages/languages?"+tt.query, nil))
+			assert.Equal(t, tt.want, rec.Code, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+// ErrReaperUnsupported is returned by ReapIdleSessions when the warm store
+// cannot move sessions through the lifecycle in bulk.
+var ErrReaperUnsupported = errors.New("warm store does not support idle session reaping")
+
+// TransitionSession moves a session to status to and returns the status it
+// moved from. Unlike UpdateSessionStatus, which ignores a status the session
+// cannot move to, it fails with session.ErrInvalidTransition. Moving to
+// completed, error or expired ends the session now.
+func (s *SessionService) TransitionSession(ctx context.Context, sessionID string, to session.SessionStatus) (session.SessionStatus, error) {
+	if sessionID == "" {
+		return "", ErrMissingSessionID
+	}
+	if !session.IsValidStatus(to) {
+		return "", ErrInvalidStatus
+	}
+	warm, err := s.registry.WarmStore()
+	if err != nil {
+		return "", ErrWarmStoreRequired
+	}
+
+	update := session.SessionStatusUpdate{SetStatus: to}
+	if to != session.SessionStatusArchived && session.IsTerminalStatus(to) {
+		update.SetEndedAt = time.Now()
+	}
+
+	var result *providers.StatusUpdateResult
+	if updater, ok := warm.(providers.StatusUpdaterWithResult); ok {
+		result, err = updater.UpdateSessionStatusReturning(ctx, sessionID, update)
+	} else {
+		result, err = transitionFallback(ctx, warm, sessionID, update)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !result.Applied {
+		return result.PreviousStatus, fmt.Errorf("%w: %s to %s", session.ErrInvalidTransition, result.PreviousStatus, to)
+	}
+
+	s.invalidateReadCache(sessionID)
+	s.refreshHotCacheSession(sessionID)
+	if to == session.SessionStatusCompleted {
+		s.publishSessionCompleted(ctx, &session.Session{
+			ID:                sessionID,
+			AgentName:         result.AgentName,
+			Namespace:         result.Namespace,
+			PromptPackName:    result.PromptPackName,
+			PromptPackVersion: result.PromptPackVersion,
+		})
+	}
+	return result.PreviousStatus, nil
+}
+
+// transitionFallback checks and applies a transition with separate queries,
+// for warm stores without StatusUpdaterWithResult.
+func transitionFallback(
+	ctx context.Context, warm providers.WarmStoreProvider, sessionID string, update session.SessionStatusUpdate,
+) (*providers.StatusUpdateResult, error) {
+	prev, err := warm.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	result := &providers.StatusUpdateResult{
+		PreviousStatus:    prev.Status,
+		AgentName:         prev.AgentName,
+		Namespace:         prev.Namespace,
+		PromptPackName:    prev.PromptPackName,
+		PromptPackVersion: prev.PromptPackVersion,
+	}
+	if !session.CanTransition(prev.Status, update.SetStatus) {
+		return result, nil
+	}
+	if err := warm.UpdateSessionStatus(ctx, sessionID, update); err != nil {
+		return nil, err
+	}
+	result.Applied = true
+	return result, nil
+}
+
+// ReapIdleSessions moves active sessions last active before idleBefore to
+// idle, then idle sessions last active before completeBefore to completed,
+// publishing a session.completed event for each. A zero time skips that step.
+// Each step moves at most limit sessions; it returns how many each moved.
+func (s *SessionService) ReapIdleSessions(ctx context.Context, idleBefore, completeBefore time.Time, limit int) (idled, completed int, err error) {
+	warm, err := s.registry.WarmStore()
+	if err != nil {
+		return 0, 0, ErrWarmStoreRequired
+	}
+	reaper, ok := warm.(providers.IdleSessionReaper)
+	if !ok {
+		return 0, 0, ErrReaperUnsupported
+	}
+
+	if !idleBefore.IsZero() {
+		if idled, err = reaper.MarkIdleSessions(ctx, idleBefore, limit); err != nil {
+			return 0, 0, err
+		}
+	}
+	if !completeBefore.IsZero() {
+		done, err := reaper.CompleteIdleSessions(ctx, completeBefore, limit)
+		if err != nil {
+			return idled, 0, err
+		}
+		for _, sess := range done {
+			s.invalidateReadCache(sess.ID)
+			s.publishSessionCompleted(ctx, sess)
+		}
+		completed = len(done)
+	}
+	return idled, completed, nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+// mockReaperWarmStore adds IdleSessionReaper to mockWarmStore.
+type mockReaperWarmStore struct {
+	*mockWarmStore
+	idleBefore, completeBefore time.Time
+}
+
+func (m *mockReaperWarmStore) MarkIdleSessions(_ context.Context, before time.Time, limit int) (int, error) {
+	m.idleBefore = before
+	n := 0
+	for _, s := range m.sessions {
+		if n < limit && s.Status == session.SessionStatusActive && s.UpdatedAt.Before(before) {
+			s.Status = session.SessionStatusIdle
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *mockReaperWarmStore) CompleteIdleSessions(_ context.Context, before time.Time, limit int) ([]*session.Session, error) {
+	m.completeBefore = before
+	var done []*session.Session
+	for _, s := range m.sessions {
+		if len(done) < limit && s.Status == session.SessionStatusIdle && s.UpdatedAt.Before(before) {
+			s.Status = session.SessionStatusCompleted
+			done = append(done, s)
+		}
+	}
+	return done, nil
+}
+
+func TestTransitionSession(t *testing.T) {
+	warm := newMockWarmStore()
+	warm.sessions["s1"] = &session.Session{ID: "s1", AgentName: "a", Namespace: "ns", Status: session.SessionStatusActive}
+	registry := providers.NewRegistry()
+	registry.SetWarmStore(warm)
+	pub := &mockEventPublisher{}
+	svc := newServiceWithPublisher(registry, pub)
+	ctx := context.Background()
+
+	prev, err := svc.TransitionSession(ctx, "s1", session.SessionStatusEscalated)
+	require.NoError(t, err)
+	assert.Equal(t, session.SessionStatusActive, prev)
+
+	_, err = svc.TransitionSession(ctx, "s1", session.SessionStatusIdle)
+	require.ErrorIs(t, err, session.ErrInvalidTransition)
+	assert.Equal(t, session.SessionStatusEscalated, warm.sessions["s1"].Status)
+
+	prev, err = svc.TransitionSession(ctx, "s1", session.SessionStatusCompleted)
+	require.NoError(t, err)
+	assert.Equal(t, session.SessionStatusEscalated, prev)
+	events := pub.waitForEvents(t, 1, 2*time.Second)
+	assert.Equal(t, "session.completed", events[0].EventType)
+	assert.Equal(t, "a", events[0].AgentName)
+
+	_, err = svc.TransitionSession(ctx, "s1", "paused")
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+	_, err = svc.TransitionSession(ctx, "missing", session.SessionStatusIdle)
+	assert.ErrorIs(t, err, session.ErrSessionNotFound)
+}
+
+func TestHandleTransitionSession(t *testing.T) {
+	h, _, warm := setupHandler(t)
+	warm.sessions[testSessionID] = &session.Session{ID: testSessionID, Status: session.SessionStatusCompleted}
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"archive", `{"status":"archived"}`, http.StatusOK},
+		{"reopen completed", `{"status":"active"}`, http.StatusConflict},
+		{"unknown status", `{"status":"paused"}`, http.StatusBadRequest},
+		{"malformed", `{`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/sessions/"+testSessionID+"/transition", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			require.Equal(t, tt.want, rec.Code, rec.Body.String())
+			if tt.want == http.StatusOK {
+				resp := decodeJSON[TransitionSessionResponse](t, rec)
+				assert.Equal(t, session.SessionStatusArchived, resp.Status)
+				assert.Equal(t, session.SessionStatusCompleted, resp.PreviousStatus)
+			}
+		})
+	}
+}
+
+func TestReapIdleSessions(t *testing.T) {
+	now := time.Now()
+	warm := &mockReaperWarmStore{mockWarmStore: newMockWarmStore()}
+	warm.sessions["quiet"] = &session.Session{ID: "quiet", Status: session.SessionStatusActive, UpdatedAt: now.Add(-time.Hour)}
+	warm.sessions["busy"] = &session.Session{ID: "busy", Status: session.SessionStatusActive, UpdatedAt: now}
+	warm.sessions["stale"] = &session.Session{ID: "stale", AgentName: "a", Status: session.SessionStatusIdle, UpdatedAt: now.Add(-48 * time.Hour)}
+	registry := providers.NewRegistry()
+	registry.SetWarmStore(warm)
+	pub := &mockEventPublisher{}
+	svc := newServiceWithPublisher(registry, pub)
+
+	idled, completed, err := svc.ReapIdleSessions(context.Background(), now.Add(-30*time.Minute), now.Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, idled)
+	assert.Equal(t, 1, completed)
+	assert.Equal(t, session.SessionStatusIdle, warm.sessions["quiet"].Status)
+	assert.Equal(t, session.SessionStatusActive, warm.sessions["busy"].Status)
+	assert.Equal(t, session.SessionStatusCompleted, warm.sessions["stale"].Status)
+	events := pub.waitForEvents(t, 1, 2*time.Second)
+	assert.Equal(t, "stale", events[0].SessionID)
+
+	// A zero time skips the step.
+	warm.idleBefore = time.Time{}
+	_, _, err = svc.ReapIdleSessions(context.Background(), time.Time{}, now, 10)
+	require.NoError(t, err)
+	assert.True(t, warm.idleBefore.IsZero())
+}
+
+func TestReapIdleSessions_Unsupported(t *testing.T) {
+	registry := providers.NewRegistry()
+	registry.SetWarmStore(newMockWarmStore())
+	svc := newServiceWithPublisher(registry, nil)
+
+	_, _, err := svc.ReapIdleSessions(context.Background(), time.Now(), time.Now(), 10)
+	assert.ErrorIs(t, err, ErrReaperUnsupported)
+}
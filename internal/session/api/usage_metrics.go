@@ -0,0 +1,179 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// Provider usage metric name constants.
+const (
+	metricProviderCalls        = "omnia_session_api_provider_calls_total"
+	metricProviderInputTokens  = "omnia_session_api_provider_input_tokens_total"
+	metricProviderOutputTokens = "omnia_session_api_provider_output_tokens_total"
+	metricProviderCost         = "omnia_session_api_provider_cost_usd_total"
+	metricProviderCallDuration = "omnia_session_api_provider_call_duration_seconds"
+	metricSessionsCreated      = "omnia_session_api_sessions_created_total"
+	metricEvalResults          = "omnia_session_api_eval_results_total"
+)
+
+// Eval result label values.
+const (
+	evalResultPassed = "passed"
+	evalResultFailed = "failed"
+)
+
+// sourceAgent is the ProviderCall.Source of calls made by the agent itself.
+const sourceAgent = "agent"
+
+// usageLabels are the labels of the provider usage metrics.
+var usageLabels = []string{"agent", "namespace", "provider", "model"}
+
+// DefaultProviderDurationBuckets are histogram buckets for LLM provider call
+// durations, which run from sub-second cache hits to multi-minute generations.
+var DefaultProviderDurationBuckets = []float64{
+	0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120, 300,
+}
+
+// UsageMetrics holds Prometheus metrics for the sessions, provider calls and
+// eval results recorded through session-api, so session volume, token, cost,
+// latency and eval outcomes can be charted per agent without querying the warm
+// store.
+type UsageMetrics struct {
+	// ProviderCalls counts recorded provider calls by agent, namespace, provider, model, and status.
+	ProviderCalls *prometheus.CounterVec
+
+	// InputTokens counts input (prompt) tokens of completed provider calls.
+	InputTokens *prometheus.CounterVec
+
+	// OutputTokens counts output (completion) tokens of completed provider calls.
+	OutputTokens *prometheus.CounterVec
+
+	// Cost sums the estimated cost in USD of completed provider calls.
+	Cost *prometheus.CounterVec
+
+	// CallDuration tracks provider call duration in seconds.
+	CallDuration *prometheus.HistogramVec
+
+	// SessionsCreated counts created sessions by agent and namespace.
+	SessionsCreated *prometheus.CounterVec
+
+	// EvalResults counts recorded eval results by agent, namespace, and result
+	// (passed or failed).
+	EvalResults *prometheus.CounterVec
+}
+
+// NewUsageMetrics creates the usage metrics and registers them with reg.
+func NewUsageMetrics(reg prometheus.Registerer) *UsageMetrics {
+	factory := promauto.With(reg)
+	return &UsageMetrics{
+		ProviderCalls: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: metricProviderCalls,
+			Help: "Total recorded LLM provider calls by agent, namespace, provider, model, and status",
+		}, []string{"agent", "namespace", "provider", "model", "status"}),
+
+		InputTokens: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: metricProviderInputTokens,
+			Help: "Total input (prompt) tokens of completed LLM provider calls",
+		}, usageLabels),
+
+		OutputTokens: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: metricProviderOutputTokens,
+			Help: "Total output (completion) tokens of completed LLM provider calls",
+		}, usageLabels),
+
+		Cost: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: metricProviderCost,
+			Help: "Total estimated cost in USD of completed LLM provider calls",
+		}, usageLabels),
+
+		CallDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    metricProviderCallDuration,
+			Help:    "LLM provider call duration in seconds",
+			Buckets: DefaultProviderDurationBuckets,
+		}, usageLabels),
+
+		SessionsCreated: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: metricSessionsCreated,
+			Help: "Total sessions created by agent and namespace",
+		}, []string{"agent", "namespace"}),
+
+		EvalResults: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: metricEvalResults,
+			Help: "Total recorded eval results by agent, namespace, and result (passed or failed)",
+		}, []string{"agent", "namespace", "result"}),
+	}
+}
+
+// Observe records a provider call. Only agent calls are counted, matching the
+// session token and cost totals; judge and self-play calls are excluded.
+// Tokens and cost are taken from completed calls only, while call counts and
+// durations include failures.
+func (m *UsageMetrics) Observe(pc *session.ProviderCall) {
+	if m == nil || (pc.Source != "" && pc.Source != sourceAgent) {
+		return
+	}
+	labels := prometheus.Labels{
+		"agent":     pc.AgentName,
+		"namespace": pc.Namespace,
+		"provider":  pc.Provider,
+		"model":     pc.Model,
+	}
+	m.ProviderCalls.WithLabelValues(pc.AgentName, pc.Namespace, pc.Provider, pc.Model, string(pc.Status)).Inc()
+	if pc.DurationMs > 0 {
+		m.CallDuration.With(labels).Observe(float64(pc.DurationMs) / 1000)
+	}
+	if pc.Status != session.ProviderCallStatusCompleted {
+		return
+	}
+	addNonNegative(m.InputTokens.With(labels), float64(pc.InputTokens))
+	addNonNegative(m.OutputTokens.With(labels), float64(pc.OutputTokens))
+	addNonNegative(m.Cost.With(labels), pc.CostUSD)
+}
+
+// ObserveSessionCreated counts a created session.
+func (m *UsageMetrics) ObserveSessionCreated(sess *session.Session) {
+	if m == nil {
+		return
+	}
+	m.SessionsCreated.WithLabelValues(sess.AgentName, sess.Namespace).Inc()
+}
+
+// ObserveEvalResults counts recorded eval results by outcome.
+func (m *UsageMetrics) ObserveEvalResults(results []*EvalResult) {
+	if m == nil {
+		return
+	}
+	for _, r := range results {
+		result := evalResultFailed
+		if r.Passed {
+			result = evalResultPassed
+		}
+		m.EvalResults.WithLabelValues(r.AgentName, r.Namespace, result).Inc()
+	}
+}
+
+// addNonNegative adds v to c, ignoring negative values from malformed records
+// since Counter.Add panics on them.
+func addNonNegative(c prometheus.Counter, v float64) {
+	if v > 0 {
+		c.Add(v)
+	}
+}
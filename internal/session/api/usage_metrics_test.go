@@ -0,0 +1,146 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+func TestUsageMetrics_Observe(t *testing.T) {
+	m := NewUsageMetrics(prometheus.NewRegistry())
+
+	completed := &session.ProviderCall{
+		Namespace:    "ns1",
+		AgentName:    "agent1",
+		Provider:     "openai",
+		Model:        "gpt-4o",
+		Status:       session.ProviderCallStatusCompleted,
+		InputTokens:  100,
+		OutputTokens: 20,
+		CostUSD:      0.5,
+		DurationMs:   1500,
+	}
+	m.Observe(completed)
+	m.Observe(completed)
+	m.Observe(&session.ProviderCall{
+		Namespace:  "ns1",
+		AgentName:  "agent1",
+		Provider:   "openai",
+		Model:      "gpt-4o",
+		Status:     session.ProviderCallStatusFailed,
+		DurationMs: 300,
+	})
+
+	labels := []string{"agent1", "ns1", "openai", "gpt-4o"}
+	assert.Equal(t, float64(200), testutil.ToFloat64(m.InputTokens.WithLabelValues(labels...)))
+	assert.Equal(t, float64(40), testutil.ToFloat64(m.OutputTokens.WithLabelValues(labels...)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.Cost.WithLabelValues(labels...)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.ProviderCalls.WithLabelValues(append(labels, "completed")...)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.ProviderCalls.WithLabelValues(append(labels, "failed")...)))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.CallDuration))
+}
+
+func TestUsageMetrics_Observe_SkipsNonAgentSources(t *testing.T) {
+	m := NewUsageMetrics(prometheus.NewRegistry())
+
+	m.Observe(&session.ProviderCall{
+		AgentName:   "agent1",
+		Provider:    "openai",
+		Status:      session.ProviderCallStatusCompleted,
+		InputTokens: 100,
+		Source:      "judge",
+	})
+
+	assert.Equal(t, 0, testutil.CollectAndCount(m.ProviderCalls))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.InputTokens))
+}
+
+func TestUsageMetrics_Observe_NilIsNoop(t *testing.T) {
+	var m *UsageMetrics
+	m.Observe(&session.ProviderCall{Status: session.ProviderCallStatusCompleted, InputTokens: 1})
+}
+
+func TestRecordProviderCall_ObservesUsageMetrics(t *testing.T) {
+	warm := newMockWarmStore()
+	warm.sessions["s1"] = &session.Session{ID: "s1"}
+	registry := providers.NewRegistry()
+	registry.SetWarmStore(warm)
+	m := NewUsageMetrics(prometheus.NewRegistry())
+	svc := NewSessionService(registry, ServiceConfig{UsageMetrics: m}, logr.Discard())
+
+	err := svc.RecordProviderCall(context.Background(), "s1", &session.ProviderCall{
+		Namespace:    "ns1",
+		AgentName:    "agent1",
+		Provider:     "anthropic",
+		Model:        "claude",
+		Status:       session.ProviderCallStatusCompleted,
+		InputTokens:  10,
+		OutputTokens: 5,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(10), testutil.ToFloat64(m.InputTokens.WithLabelValues("agent1", "ns1", "anthropic", "claude")))
+}
+
+func TestCreateSession_ObservesSessionsCreated(t *testing.T) {
+	registry := providers.NewRegistry()
+	registry.SetWarmStore(newMockWarmStore())
+	m := NewUsageMetrics(prometheus.NewRegistry())
+	svc := NewSessionService(registry, ServiceConfig{UsageMetrics: m}, logr.Discard())
+
+	err := svc.CreateSession(context.Background(), &session.Session{ID: "s1", AgentName: "agent1", Namespace: "ns1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.SessionsCreated.WithLabelValues("agent1", "ns1")))
+}
+
+func TestCreateEvalResults_ObservesEvalResults(t *testing.T) {
+	m := NewUsageMetrics(prometheus.NewRegistry())
+	svc := NewEvalService(&mockEvalStore{}, logr.Discard())
+	svc.SetUsageMetrics(m)
+
+	err := svc.CreateEvalResults(context.Background(), []*EvalResult{
+		{AgentName: "agent1", Namespace: "ns1", Passed: true},
+		{AgentName: "agent1", Namespace: "ns1", Passed: true},
+		{AgentName: "agent1", Namespace: "ns1"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.EvalResults.WithLabelValues("agent1", "ns1", evalResultPassed)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.EvalResults.WithLabelValues("agent1", "ns1", evalResultFailed)))
+}
+
+func TestCreateEvalResults_StoreErrorNotCounted(t *testing.T) {
+	m := NewUsageMetrics(prometheus.NewRegistry())
+	svc := NewEvalService(&mockEvalStore{insertErr: errors.New("db down")}, logr.Discard())
+	svc.SetUsageMetrics(m)
+
+	err := svc.CreateEvalResults(context.Background(), []*EvalResult{{AgentName: "agent1", Namespace: "ns1", Passed: true}})
+	require.Error(t, err)
+	assert.Equal(t, 0, testutil.CollectAndCount(m.EvalResults))
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import "sync"
+
+// defaultAffinityCapacity bounds how many session-to-cluster mappings the
+// gateway remembers. A forgotten session costs one lookup across clusters.
+const defaultAffinityCapacity = 50000
+
+// affinityCache remembers which cluster holds a session, evicting the
+// oldest mapping when full. Sessions never move between clusters, so
+// mappings do not expire.
+type affinityCache struct {
+	mu       sync.Mutex
+	capacity int
+	clusters map[string]string
+	order    []string // insertion order, oldest first
+}
+
+func newAffinityCache(capacity int) *affinityCache {
+	return &affinityCache{capacity: capacity, clusters: make(map[string]string, capacity)}
+}
+
+func (a *affinityCache) get(sessionID string) (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	cluster, ok := a.clusters[sessionID]
+	return cluster, ok
+}
+
+func (a *affinityCache) put(sessionID, cluster string) {
+	if sessionID == "" {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.clusters[sessionID]; ok {
+		a.clusters[sessionID] = cluster
+		return
+	}
+	if len(a.order) >= a.capacity {
+		delete(a.clusters, a.order[0])
+		a.order = a.order[1:]
+	}
+	a.clusters[sessionID] = cluster
+	a.order = append(a.order, sessionID)
+}
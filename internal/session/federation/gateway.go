@@ -0,0 +1,490 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/pkg/apierror"
+	"github.com/altairalabs/omnia/pkg/logctx"
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+// HeaderCluster names the cluster a request is for. It pins session-scoped
+// requests and creates to that cluster, and sends any other request, such as
+// an aggregate, to that cluster unchanged.
+const HeaderCluster = "X-Omnia-Cluster"
+
+// Paging limits, matching the session-api's own list endpoints.
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+	maxOffsetLimit   = 10000
+)
+
+// defaultClusterTimeout bounds each call the gateway makes to a cluster while
+// fanning out or locating a session.
+const defaultClusterTimeout = 10 * time.Second
+
+// maxCreateBodySize bounds the create-session body the gateway buffers to
+// learn the new session's ID.
+const maxCreateBodySize = 1 << 20
+
+// ClusterSession is a session in a federated list, tagged with its cluster.
+type ClusterSession struct {
+	*session.Session
+	Cluster string `json:"cluster"`
+}
+
+// ClusterStatus reports a cluster that failed during a fan-out.
+type ClusterStatus struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// ListResponse is the federated session list/search response: the session
+// API's list response, with each session's cluster and the clusters that
+// could not be read. Total sums the clusters that answered.
+type ListResponse struct {
+	Sessions []ClusterSession `json:"sessions"`
+	Total    int64            `json:"total"`
+	HasMore  bool             `json:"hasMore"`
+	Failed   []ClusterStatus  `json:"failedClusters,omitempty"`
+}
+
+// ClustersResponse is the response of GET /api/v1/clusters.
+type ClustersResponse struct {
+	Clusters []ClusterInfo `json:"clusters"`
+}
+
+// ClusterInfo describes a federated cluster.
+type ClusterInfo struct {
+	Name    string `json:"name"`
+	Default bool   `json:"default,omitempty"`
+}
+
+// listResponse is a cluster's session list/search response.
+type listResponse struct {
+	Sessions []*session.Session `json:"sessions"`
+	Total    int64              `json:"total"`
+	HasMore  bool               `json:"hasMore"`
+}
+
+// Gateway serves the session API over the session-apis in a Registry.
+type Gateway struct {
+	registry *Registry
+	client   *http.Client
+	affinity *affinityCache
+	timeout  time.Duration
+	proxies  map[string]*httputil.ReverseProxy
+	log      logr.Logger
+}
+
+// NewGateway creates a Gateway. client makes the fan-out and lookup calls;
+// nil uses http.DefaultClient.
+func NewGateway(registry *Registry, client *http.Client, log logr.Logger) *Gateway {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	g := &Gateway{
+		registry: registry,
+		client:   client,
+		affinity: newAffinityCache(defaultAffinityCapacity),
+		timeout:  defaultClusterTimeout,
+		proxies:  make(map[string]*httputil.ReverseProxy, len(registry.Clusters())),
+		log:      log.WithName("federation-gateway"),
+	}
+	for _, c := range registry.Clusters() {
+		g.proxies[c.Name] = g.newProxy(c)
+	}
+	return g
+}
+
+// RegisterRoutes registers the gateway's routes on mux. Lists and searches
+// fan out; session-scoped routes and creates are proxied to one cluster; any
+// other route is proxied only when the caller names a cluster.
+func (g *Gateway) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /api/v1/clusters", g.handleClusters)
+	mux.HandleFunc("GET /api/v1/sessions", g.handleFanOutList)
+	mux.HandleFunc("GET /api/v1/sessions/search", g.handleFanOutList)
+	mux.HandleFunc("POST /api/v1/sessions", g.handleCreateSession)
+	mux.HandleFunc("/api/v1/sessions/{sessionID}", g.handleSessionScoped)
+	mux.HandleFunc("/api/v1/sessions/{sessionID}/{rest...}", g.handleSessionScoped)
+	mux.HandleFunc("/", g.handlePinned)
+}
+
+func (g *Gateway) handleClusters(w http.ResponseWriter, _ *http.Request) {
+	resp := ClustersResponse{Clusters: []ClusterInfo{}}
+	for _, c := range g.registry.Clusters() {
+		resp.Clusters = append(resp.Clusters, ClusterInfo{Name: c.Name, Default: c == g.registry.Default()})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleFanOutList serves a session list or search across clusters: the
+// optional cluster query parameter (comma-separated), or X-Omnia-Cluster,
+// narrows the set. Each cluster is read up to offset+limit sessions deep, the
+// results are merged newest first — the order every cluster lists in — and
+// the page is cut from the merge. A cluster that fails is reported in failedClusters rather than
+// failing the request, unless every cluster fails.
+func (g *Gateway) handleFanOutList(w http.ResponseWriter, r *http.Request) {
+	names := r.URL.Query().Get("cluster")
+	if names == "" {
+		names = r.Header.Get(HeaderCluster)
+	}
+	clusters, err := g.selectClusters(names)
+	if err != nil {
+		writeError(w, apierror.CodeInvalidRequest, err.Error())
+		return
+	}
+	limit := min(queryInt(r, "limit", defaultListLimit), maxListLimit)
+	offset := min(queryInt(r, "offset", 0), maxOffsetLimit)
+
+	query := r.URL.Query()
+	query.Del("cluster")
+	pages := make([]clusterPage, len(clusters))
+	var wg sync.WaitGroup
+	for i, c := range clusters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pages[i] = g.fetchTop(r.Context(), c, r.URL.Path, query, offset+limit)
+		}()
+	}
+	wg.Wait()
+
+	resp := ListResponse{Sessions: []ClusterSession{}}
+	var merged []ClusterSession
+	for i, p := range pages {
+		if p.err != nil {
+			g.requestLog(r.Context()).Error(p.err, "federated list failed", "cluster", clusters[i].Name)
+			resp.Failed = append(resp.Failed, ClusterStatus{Name: clusters[i].Name, Error: p.err.Error()})
+			continue
+		}
+		resp.Total += p.total
+		resp.HasMore = resp.HasMore || p.hasMore
+		for _, s := range p.sessions {
+			merged = append(merged, ClusterSession{Session: s, Cluster: clusters[i].Name})
+		}
+	}
+	if len(resp.Failed) == len(clusters) {
+		writeError(w, apierror.CodeUnavailable, "no cluster could be read")
+		return
+	}
+
+	slices.SortStableFunc(merged, func(a, b ClusterSession) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	if len(merged) > offset+limit {
+		resp.HasMore = true
+	}
+	if offset < len(merged) {
+		resp.Sessions = merged[offset:min(offset+limit, len(merged))]
+	}
+	for _, s := range resp.Sessions {
+		g.affinity.put(s.ID, s.Cluster)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// clusterPage is the head of one cluster's session list.
+type clusterPage struct {
+	sessions []*session.Session
+	total    int64
+	hasMore  bool
+	err      error
+}
+
+// fetchTop reads the first n sessions of a cluster's list at path, paging
+// within the cluster's own limit.
+func (g *Gateway) fetchTop(ctx context.Context, c *Cluster, path string, query url.Values, n int) clusterPage {
+	var page clusterPage
+	q := cloneValues(query)
+	for len(page.sessions) < n {
+		q.Set("limit", strconv.Itoa(min(n-len(page.sessions), maxListLimit)))
+		q.Set("offset", strconv.Itoa(len(page.sessions)))
+		var resp listResponse
+		if _, err := g.getJSON(ctx, c, path+"?"+q.Encode(), &resp); err != nil {
+			return clusterPage{err: err}
+		}
+		if len(page.sessions) == 0 {
+			page.total = resp.Total
+		}
+		page.sessions = append(page.sessions, resp.Sessions...)
+		page.hasMore = resp.HasMore
+		if !resp.HasMore || len(resp.Sessions) == 0 {
+			break
+		}
+	}
+	return page
+}
+
+// handleCreateSession proxies a create to the cluster named by
+// X-Omnia-Cluster, or to the default cluster, and remembers where the new
+// session lives.
+func (g *Gateway) handleCreateSession(w http.ResponseWriter, r *http.Request) {
+	c, err := g.pinnedCluster(r)
+	if err != nil {
+		writeError(w, apierror.CodeInvalidRequest, err.Error())
+		return
+	}
+	if c == nil {
+		c = g.registry.Default()
+	}
+	if c == nil {
+		writeError(w, apierror.CodeInvalidRequest,
+			HeaderCluster+" is required to create a session: no default cluster is configured")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCreateBodySize))
+	if err != nil {
+		writeError(w, apierror.CodeMessageTooLarge, "request body too large")
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	var req struct {
+		ID string `json:"id"`
+	}
+	_ = json.Unmarshal(body, &req)
+	if req.ID != "" {
+		g.affinity.put(req.ID, c.Name)
+	}
+	g.proxies[c.Name].ServeHTTP(w, r)
+}
+
+// handleSessionScoped proxies a request for one session to the cluster that
+// holds it: the one named by X-Omnia-Cluster, the one it was last seen in,
+// or the one that answers a lookup.
+func (g *Gateway) handleSessionScoped(w http.ResponseWriter, r *http.Request) {
+	c, err := g.pinnedCluster(r)
+	if err != nil {
+		writeError(w, apierror.CodeInvalidRequest, err.Error())
+		return
+	}
+	if c == nil {
+		c, err = g.locate(r.Context(), r.PathValue("sessionID"))
+		if err != nil {
+			if errors.Is(err, session.ErrSessionNotFound) {
+				writeError(w, apierror.CodeSessionNotFound, "session not found")
+				return
+			}
+			g.requestLog(r.Context()).Error(err, "locating session failed", "sessionID", r.PathValue("sessionID"))
+			writeError(w, apierror.CodeUnavailable, "session could not be located")
+			return
+		}
+	}
+	g.proxies[c.Name].ServeHTTP(w, r)
+}
+
+// handlePinned proxies any other request to the cluster the caller names.
+// Routes that would need merging across clusters, such as aggregates, are
+// not federated.
+func (g *Gateway) handlePinned(w http.ResponseWriter, r *http.Request) {
+	c, err := g.pinnedCluster(r)
+	if err != nil {
+		writeError(w, apierror.CodeInvalidRequest, err.Error())
+		return
+	}
+	if c == nil {
+		writeError(w, apierror.CodeNotImplemented,
+			r.URL.Path+" is not federated; set "+HeaderCluster+" to send it to one cluster")
+		return
+	}
+	g.proxies[c.Name].ServeHTTP(w, r)
+}
+
+// locate returns the cluster holding sessionID, from the affinity cache or
+// by asking every cluster. It returns session.ErrSessionNotFound when every
+// cluster answers that it does not hold the session.
+func (g *Gateway) locate(ctx context.Context, sessionID string) (*Cluster, error) {
+	if name, ok := g.affinity.get(sessionID); ok {
+		if c, ok := g.registry.Get(name); ok {
+			return c, nil
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		cluster *Cluster
+		err     error
+	}
+	clusters := g.registry.Clusters()
+	results := make(chan result, len(clusters))
+	for _, c := range clusters {
+		go func() {
+			status, err := g.getJSON(ctx, c, "/api/v1/sessions/"+url.PathEscape(sessionID), nil)
+			if status == http.StatusNotFound {
+				err = session.ErrSessionNotFound
+			}
+			results <- result{cluster: c, err: err}
+		}()
+	}
+	var lookupErr error
+	for range clusters {
+		res := <-results
+		if res.err == nil {
+			g.affinity.put(sessionID, res.cluster.Name)
+			return res.cluster, nil
+		}
+		if !errors.Is(res.err, session.ErrSessionNotFound) {
+			lookupErr = fmt.Errorf("cluster %s: %w", res.cluster.Name, res.err)
+		}
+	}
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+	return nil, session.ErrSessionNotFound
+}
+
+// pinnedCluster returns the cluster named by X-Omnia-Cluster, or nil when
+// the header is unset.
+func (g *Gateway) pinnedCluster(r *http.Request) (*Cluster, error) {
+	name := r.Header.Get(HeaderCluster)
+	if name == "" {
+		return nil, nil
+	}
+	c, ok := g.registry.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster %q", name)
+	}
+	return c, nil
+}
+
+// selectClusters returns the clusters named in a comma-separated list, or
+// every cluster when the list is empty.
+func (g *Gateway) selectClusters(names string) ([]*Cluster, error) {
+	if names == "" {
+		return g.registry.Clusters(), nil
+	}
+	var out []*Cluster
+	for _, name := range strings.Split(names, ",") {
+		c, ok := g.registry.Get(strings.TrimSpace(name))
+		if !ok {
+			return nil, fmt.Errorf("unknown cluster %q", name)
+		}
+		if !slices.Contains(out, c) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// getJSON GETs path from cluster c and decodes a 200 response into out (nil
+// discards it). It returns the response status alongside any error.
+func (g *Gateway) getJSON(ctx context.Context, c *Cluster, path string, out any) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(c.URL.String(), "/")+path, nil)
+	if err != nil {
+		return 0, err
+	}
+	if err := c.authorize(req); err != nil {
+		return 0, fmt.Errorf("authorizing request: %w", err)
+	}
+	if id := logctx.RequestID(ctx); id != "" {
+		req.Header.Set(policy.HeaderRequestID, id)
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if out == nil {
+		return resp.StatusCode, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("decoding response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// newProxy returns the reverse proxy for cluster c. The caller's token is
+// swapped for the cluster's, and HeaderCluster is dropped.
+func (g *Gateway) newProxy(c *Cluster) *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(c.URL)
+			pr.Out.Header.Del(HeaderCluster)
+			if err := c.authorize(pr.Out); err != nil {
+				g.log.Error(err, "authorizing proxied request", "cluster", c.Name)
+			}
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			g.requestLog(r.Context()).Error(err, "proxying to cluster failed", "cluster", c.Name)
+			writeError(w, apierror.CodeUnavailable, "cluster "+c.Name+" is unavailable")
+		},
+	}
+}
+
+func (g *Gateway) requestLog(ctx context.Context) logr.Logger {
+	return logctx.LoggerWithContext(g.log, ctx)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code, message string) {
+	apierror.WriteHTTP(w, apierror.New(code, message))
+}
+
+// queryInt returns a non-negative integer query parameter, or def when it is
+// absent or invalid.
+func queryInt(r *http.Request, name string, def int) int {
+	v, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil || v < 0 {
+		return def
+	}
+	return v
+}
+
+func cloneValues(v url.Values) url.Values {
+	out := make(url.Values, len(v))
+	for k, vals := range v {
+		out[k] = slices.Clone(vals)
+	}
+	return out
+}
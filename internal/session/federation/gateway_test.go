@@ -0,0 +1,316 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/pkg/apierror"
+)
+
+// fakeCluster is a minimal cluster session-api: it lists its sessions newest
+// first with limit/offset paging, serves them by ID, answers 201 to anything
+// else, and records every request it receives.
+type fakeCluster struct {
+	*httptest.Server
+	sessions []*session.Session // newest first
+
+	mu       sync.Mutex
+	requests []*http.Request
+	down     bool
+}
+
+func newFakeCluster(t *testing.T, sessions ...*session.Session) *fakeCluster {
+	t.Helper()
+	fc := &fakeCluster{sessions: sessions}
+	fc.Server = httptest.NewServer(http.HandlerFunc(fc.serve))
+	t.Cleanup(fc.Close)
+	return fc
+}
+
+func (fc *fakeCluster) serve(w http.ResponseWriter, r *http.Request) {
+	fc.mu.Lock()
+	fc.requests = append(fc.requests, r.Clone(r.Context()))
+	down := fc.down
+	fc.mu.Unlock()
+	if down {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && (r.URL.Path == "/api/v1/sessions" || r.URL.Path == "/api/v1/sessions/search"):
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := min(offset+limit, len(fc.sessions))
+		page := []*session.Session{}
+		if offset < end {
+			page = fc.sessions[offset:end]
+		}
+		writeJSON(w, http.StatusOK, listResponse{
+			Sessions: page, Total: int64(len(fc.sessions)), HasMore: end < len(fc.sessions),
+		})
+	case r.Method == http.MethodGet && strings.Count(r.URL.Path, "/") == 4 &&
+		strings.HasPrefix(r.URL.Path, "/api/v1/sessions/"):
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/sessions/")
+		i := slices.IndexFunc(fc.sessions, func(s *session.Session) bool { return s.ID == id })
+		if i < 0 {
+			writeError(w, apierror.CodeSessionNotFound, "session not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"session": fc.sessions[i]})
+	default:
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func (fc *fakeCluster) received() []*http.Request {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return slices.Clone(fc.requests)
+}
+
+func (fc *fakeCluster) reset() {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.requests = nil
+}
+
+func (fc *fakeCluster) setDown(down bool) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.down = down
+}
+
+// sessionsAt returns sessions named prefix-0..n-1, created hourly from
+// start + offset hours, newest first.
+func sessionsAt(prefix string, n int, start time.Time, offset int) []*session.Session {
+	var out []*session.Session
+	for i := n - 1; i >= 0; i-- {
+		out = append(out, &session.Session{
+			ID:        prefix + "-" + strconv.Itoa(i),
+			Namespace: "support",
+			CreatedAt: start.Add(time.Duration(offset+2*i) * time.Hour),
+		})
+	}
+	return out
+}
+
+func newTestGateway(t *testing.T, clusters map[string]*fakeCluster, defaultCluster string) http.Handler {
+	t.Helper()
+	var cfg Config
+	for _, name := range []string{"us-east", "eu-west"} {
+		fc, ok := clusters[name]
+		if !ok {
+			continue
+		}
+		cc := ClusterConfig{Name: name, URL: fc.URL, Default: name == defaultCluster}
+		if name == "us-east" {
+			tokenFile := filepath.Join(t.TempDir(), "token")
+			require.NoError(t, os.WriteFile(tokenFile, []byte("us-east-token"), 0o600))
+			cc.TokenFile = tokenFile
+		}
+		cfg.Clusters = append(cfg.Clusters, cc)
+	}
+	reg, err := NewRegistry(&cfg)
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	NewGateway(reg, nil, logr.Discard()).RegisterRoutes(mux)
+	return mux
+}
+
+func serveGateway(t *testing.T, h http.Handler, method, target string, header map[string]string,
+	body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer caller-token")
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeList(t *testing.T, rec *httptest.ResponseRecorder) ListResponse {
+	t.Helper()
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp ListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
+
+func listIDs(resp ListResponse) []string {
+	var ids []string
+	for _, s := range resp.Sessions {
+		ids = append(ids, s.Cluster+"/"+s.ID)
+	}
+	return ids
+}
+
+func TestGateway_ListMergesClustersNewestFirst(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	// us-east sessions at even hours, eu-west at odd hours, so they interleave.
+	us := newFakeCluster(t, sessionsAt("us", 60, start, 0)...)
+	eu := newFakeCluster(t, sessionsAt("eu", 60, start, 1)...)
+	h := newTestGateway(t, map[string]*fakeCluster{"us-east": us, "eu-west": eu}, "")
+
+	resp := decodeList(t, serveGateway(t, h, http.MethodGet, "/api/v1/sessions?namespace=support&limit=4", nil, ""))
+	assert.Equal(t, []string{"eu-west/eu-59", "us-east/us-59", "eu-west/eu-58", "us-east/us-58"}, listIDs(resp))
+	assert.Equal(t, int64(120), resp.Total)
+	assert.True(t, resp.HasMore)
+	assert.Empty(t, resp.Failed)
+
+	// A page deeper than one cluster call pages within each cluster.
+	resp = decodeList(t, serveGateway(t, h, http.MethodGet,
+		"/api/v1/sessions/search?q=refund&namespace=support&limit=3&offset=116", nil, ""))
+	assert.Equal(t, []string{"eu-west/eu-1", "us-east/us-1", "eu-west/eu-0"}, listIDs(resp))
+	assert.True(t, resp.HasMore)
+
+	// Filters pass through, the cluster's token replaces the caller's, and
+	// the gateway's own parameters are not forwarded.
+	reqs := us.received()
+	require.NotEmpty(t, reqs)
+	last := reqs[len(reqs)-1]
+	assert.Equal(t, "refund", last.URL.Query().Get("q"))
+	assert.Equal(t, "Bearer us-east-token", last.Header.Get("Authorization"))
+	assert.Empty(t, eu.received()[0].Header.Get("Authorization"))
+}
+
+func TestGateway_ListClusterFilterAndPartialFailure(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	us := newFakeCluster(t, sessionsAt("us", 2, start, 0)...)
+	eu := newFakeCluster(t, sessionsAt("eu", 2, start, 1)...)
+	h := newTestGateway(t, map[string]*fakeCluster{"us-east": us, "eu-west": eu}, "")
+
+	resp := decodeList(t, serveGateway(t, h, http.MethodGet, "/api/v1/sessions?namespace=support&cluster=eu-west", nil, ""))
+	assert.Equal(t, []string{"eu-west/eu-1", "eu-west/eu-0"}, listIDs(resp))
+	assert.Empty(t, us.received())
+
+	eu.setDown(true)
+	resp = decodeList(t, serveGateway(t, h, http.MethodGet, "/api/v1/sessions?namespace=support", nil, ""))
+	assert.Equal(t, []string{"us-east/us-1", "us-east/us-0"}, listIDs(resp))
+	require.Len(t, resp.Failed, 1)
+	assert.Equal(t, "eu-west", resp.Failed[0].Name)
+
+	us.setDown(true)
+	rec := serveGateway(t, h, http.MethodGet, "/api/v1/sessions?namespace=support", nil, "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	rec = serveGateway(t, h, http.MethodGet, "/api/v1/sessions?namespace=support&cluster=ap-south", nil, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGateway_SessionScopedRoutesToOwningCluster(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	us := newFakeCluster(t, sessionsAt("us", 1, start, 0)...)
+	eu := newFakeCluster(t, sessionsAt("eu", 1, start, 1)...)
+	h := newTestGateway(t, map[string]*fakeCluster{"us-east": us, "eu-west": eu}, "")
+
+	// First request locates the session by asking every cluster.
+	rec := serveGateway(t, h, http.MethodPost, "/api/v1/sessions/eu-0/messages", nil, `{"content":"hi"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	euReqs := eu.received()
+	require.NotEmpty(t, euReqs)
+	write := euReqs[len(euReqs)-1]
+	assert.Equal(t, "/api/v1/sessions/eu-0/messages", write.URL.Path)
+	assert.Empty(t, write.Header.Get("Authorization"), "caller token must not be forwarded")
+	for _, r := range us.received() {
+		assert.Equal(t, http.MethodGet, r.Method, "writes must only reach the owning cluster")
+	}
+
+	// Later requests use the remembered cluster without a lookup.
+	us.reset()
+	eu.reset()
+	rec = serveGateway(t, h, http.MethodGet, "/api/v1/sessions/eu-0", nil, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, us.received())
+	assert.Len(t, eu.received(), 1)
+
+	// Unknown everywhere is a 404.
+	rec = serveGateway(t, h, http.MethodGet, "/api/v1/sessions/missing", nil, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// X-Omnia-Cluster pins the cluster without a lookup.
+	us.reset()
+	rec = serveGateway(t, h, http.MethodDelete, "/api/v1/sessions/other", map[string]string{HeaderCluster: "us-east"}, "")
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	reqs := us.received()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, http.MethodDelete, reqs[0].Method)
+	assert.Empty(t, reqs[0].Header.Get(HeaderCluster))
+	assert.Equal(t, "Bearer us-east-token", reqs[0].Header.Get("Authorization"))
+}
+
+func TestGateway_CreateUsesPinnedOrDefaultCluster(t *testing.T) {
+	us := newFakeCluster(t)
+	eu := newFakeCluster(t)
+
+	h := newTestGateway(t, map[string]*fakeCluster{"us-east": us, "eu-west": eu}, "")
+	rec := serveGateway(t, h, http.MethodPost, "/api/v1/sessions", nil, `{"id":"new-1"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "no cluster and no default")
+
+	rec = serveGateway(t, h, http.MethodPost, "/api/v1/sessions", map[string]string{HeaderCluster: "eu-west"},
+		`{"id":"new-1","agentName":"support-bot"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	require.Len(t, eu.received(), 1)
+
+	// The created session's cluster is remembered for follow-up writes.
+	eu.reset()
+	rec = serveGateway(t, h, http.MethodPatch, "/api/v1/sessions/new-1/status", nil, `{"status":"completed"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Empty(t, us.received())
+	assert.Len(t, eu.received(), 1)
+
+	h = newTestGateway(t, map[string]*fakeCluster{"us-east": us, "eu-west": eu}, "us-east")
+	rec = serveGateway(t, h, http.MethodPost, "/api/v1/sessions", nil, `{"id":"new-2"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Len(t, us.received(), 1)
+}
+
+func TestGateway_UnfederatedRoutesNeedCluster(t *testing.T) {
+	us := newFakeCluster(t)
+	h := newTestGateway(t, map[string]*fakeCluster{"us-east": us}, "")
+
+	rec := serveGateway(t, h, http.MethodGet, "/api/v1/eval-results/aggregate?namespace=support", nil, "")
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	assert.Empty(t, us.received())
+
+	rec = serveGateway(t, h, http.MethodGet, "/api/v1/eval-results/aggregate?namespace=support",
+		map[string]string{HeaderCluster: "us-east"}, "")
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "/api/v1/eval-results/aggregate", us.received()[0].URL.Path)
+
+	rec = serveGateway(t, h, http.MethodGet, "/api/v1/clusters", nil, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"clusters":[{"name":"us-east"}]}`, rec.Body.String())
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package federation implements the session-api federation gateway: one
+// session-api that serves the session API over the session-apis of several
+// clusters. Lists and searches fan out to every cluster and are merged;
+// requests for one session are proxied to the cluster that holds it.
+package federation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	"github.com/altairalabs/omnia/internal/serviceauth"
+)
+
+// Config is the federation config file: the clusters whose session-apis the
+// gateway federates.
+//
+//	clusters:
+//	  - name: us-east
+//	    url: https://session-api.us-east.example.com
+//	    tokenFile: /var/run/secrets/omnia/us-east/token
+//	    default: true
+//	  - name: eu-west
+//	    url: https://session-api.eu-west.example.com
+type Config struct {
+	Clusters []ClusterConfig `json:"clusters"`
+}
+
+// ClusterConfig is one federated cluster.
+type ClusterConfig struct {
+	// Name identifies the cluster in responses and in the X-Omnia-Cluster
+	// header. Must be a DNS label.
+	Name string `json:"name"`
+	// URL is the base URL of the cluster's session-api.
+	URL string `json:"url"`
+	// TokenFile holds the bearer token sent to the cluster's session-api,
+	// re-read as it rotates. Empty sends no token. The caller's own token
+	// is never forwarded: it is only valid in the gateway's cluster.
+	TokenFile string `json:"tokenFile,omitempty"`
+	// Default marks the cluster that creates sessions when the caller names
+	// none. At most one cluster may set it.
+	Default bool `json:"default,omitempty"`
+}
+
+// LoadConfig reads and validates a federation config file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading federation config: %w", err)
+	}
+	var cfg Config
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing federation config %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("federation config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Validate checks that the config names at least one cluster, that names are
+// unique DNS labels with http(s) URLs, and that at most one is the default.
+func (c *Config) Validate() error {
+	if len(c.Clusters) == 0 {
+		return errors.New("no clusters configured")
+	}
+	var names []string
+	defaults := 0
+	for i, cl := range c.Clusters {
+		if errs := validation.IsDNS1123Label(cl.Name); len(errs) > 0 {
+			return fmt.Errorf("clusters[%d]: invalid name %q: %s", i, cl.Name, errs[0])
+		}
+		if slices.Contains(names, cl.Name) {
+			return fmt.Errorf("clusters[%d]: duplicate name %q", i, cl.Name)
+		}
+		names = append(names, cl.Name)
+		u, err := url.Parse(cl.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("clusters[%d] %s: url must be an http(s) URL, got %q", i, cl.Name, cl.URL)
+		}
+		if cl.Default {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		return errors.New("more than one cluster is marked default")
+	}
+	return nil
+}
+
+// Cluster is a federated cluster's session-api.
+type Cluster struct {
+	// Name identifies the cluster.
+	Name string
+	// URL is the base URL of the cluster's session-api.
+	URL *url.URL
+
+	tokens *serviceauth.TokenSource // nil sends no token
+}
+
+// authorize replaces the request's Authorization header with the cluster's
+// token, or removes it when the cluster has none.
+func (c *Cluster) authorize(r *http.Request) error {
+	r.Header.Del("Authorization")
+	if c.tokens == nil {
+		return nil
+	}
+	return c.tokens.Authorize(r)
+}
+
+// Registry holds the federated clusters.
+type Registry struct {
+	clusters       []*Cluster
+	byName         map[string]*Cluster
+	defaultCluster *Cluster
+}
+
+// NewRegistry builds a Registry from a validated Config.
+func NewRegistry(cfg *Config) (*Registry, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	reg := &Registry{byName: make(map[string]*Cluster, len(cfg.Clusters))}
+	for _, cc := range cfg.Clusters {
+		u, _ := url.Parse(cc.URL) // validated above
+		c := &Cluster{Name: cc.Name, URL: u}
+		if cc.TokenFile != "" {
+			c.tokens = serviceauth.NewTokenSource(cc.TokenFile, 0)
+		}
+		reg.clusters = append(reg.clusters, c)
+		reg.byName[c.Name] = c
+		if cc.Default {
+			reg.defaultCluster = c
+		}
+	}
+	return reg, nil
+}
+
+// Clusters returns the clusters in config order.
+func (r *Registry) Clusters() []*Cluster {
+	return r.clusters
+}
+
+// Get returns the named cluster.
+func (r *Registry) Get(name string) (*Cluster, bool) {
+	c, ok := r.byName[name]
+	return c, ok
+}
+
+// Default returns the default cluster, or nil when none is configured.
+func (r *Registry) Default() *Cluster {
+	return r.defaultCluster
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "federation.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`clusters:
+  - name: us-east
+    url: https://session-api.us-east.example.com
+    tokenFile: /var/run/secrets/us-east/token
+    default: true
+  - name: eu-west
+    url: http://session-api.eu-west:8080
+`), 0o600))
+
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	reg, err := NewRegistry(cfg)
+	require.NoError(t, err)
+
+	require.Len(t, reg.Clusters(), 2)
+	assert.Equal(t, "us-east", reg.Default().Name)
+	eu, ok := reg.Get("eu-west")
+	require.True(t, ok)
+	assert.Equal(t, "session-api.eu-west:8080", eu.URL.Host)
+	assert.Nil(t, eu.tokens)
+	_, ok = reg.Get("ap-south")
+	assert.False(t, ok)
+}
+
+func TestLoadConfig_UnknownField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "federation.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("clusters:\n  - name: a\n    url: http://a\n    region: x\n"), 0o600))
+	_, err := LoadConfig(path)
+	assert.Error(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		clusters []ClusterConfig
+		wantErr  string
+	}{
+		{name: "empty", wantErr: "no clusters"},
+		{
+			name:     "invalid name",
+			clusters: []ClusterConfig{{Name: "US_East", URL: "http://a"}},
+			wantErr:  "invalid name",
+		},
+		{
+			name:     "duplicate name",
+			clusters: []ClusterConfig{{Name: "a", URL: "http://a"}, {Name: "a", URL: "http://b"}},
+			wantErr:  "duplicate name",
+		},
+		{
+			name:     "bad url",
+			clusters: []ClusterConfig{{Name: "a", URL: "session-api:8080"}},
+			wantErr:  "http(s) URL",
+		},
+		{
+			name: "two defaults",
+			clusters: []ClusterConfig{
+				{Name: "a", URL: "http://a", Default: true},
+				{Name: "b", URL: "http://b", Default: true},
+			},
+			wantErr: "more than one",
+		},
+		{
+			name:     "valid without default",
+			clusters: []ClusterConfig{{Name: "a", URL: "http://a"}, {Name: "b", URL: "https://b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Config{Clusters: tt.clusters}).Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestAffinityCache_EvictsOldest(t *testing.T) {
+	c := newAffinityCache(2)
+	c.put("s1", "a")
+	c.put("s2", "b")
+	c.put("s1", "c") // update, no eviction
+	c.put("s3", "a")
+
+	_, ok := c.get("s1")
+	assert.False(t, ok, "oldest mapping should be evicted")
+	got, ok := c.get("s2")
+	assert.True(t, ok)
+	assert.Equal(t, "b", got)
+	got, _ = c.get("s3")
+	assert.Equal(t, "a", got)
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// naiveTimeLayouts are the zone-less timestamp layouts some exports use
+// (LangSmith writes "2024-05-01T12:00:00.123456"). They are read as UTC.
+var naiveTimeLayouts = []string{"2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999"}
+
+// textTypes are the content part types that carry text; "" is a part that
+// does not say.
+var textTypes = map[string]bool{
+	"": true, "text": true, "input_text": true, "output_text": true, "multimodal_text": true,
+}
+
+// decodeValues calls fn for each top-level value of r, which holds either a
+// JSON array or a stream of JSON values (JSONL). n is the value's position.
+func decodeValues(r io.Reader, fn func(n int, raw json.RawMessage) error) error {
+	br := bufio.NewReader(r)
+	first, err := peekNonSpace(br)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(br)
+	if first == '[' {
+		if _, err := dec.Token(); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+	}
+	for n := 0; ; n++ {
+		if first == '[' && !dec.More() {
+			return nil
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) && first != '[' {
+				return nil
+			}
+			return fmt.Errorf("invalid JSON at record %d: %w", n+1, err)
+		}
+		if err := fn(n, raw); err != nil {
+			return fmt.Errorf("record %d: %w", n+1, err)
+		}
+	}
+}
+
+// peekNonSpace returns the first non-whitespace byte of br without consuming it.
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = br.ReadByte()
+		default:
+			return b[0], nil
+		}
+	}
+}
+
+// textContent returns the text of a message content value: a plain string, an
+// array of content parts ({"type": "text", "text": ...} or strings), or an
+// object carrying "parts" or "text".
+func textContent(raw json.RawMessage) string {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	switch raw[0] {
+	case '"':
+		var s string
+		_ = json.Unmarshal(raw, &s)
+		return s
+	case '[':
+		var parts []json.RawMessage
+		if json.Unmarshal(raw, &parts) != nil {
+			return ""
+		}
+		texts := make([]string, 0, len(parts))
+		for _, p := range parts {
+			if t := textContent(p); t != "" {
+				texts = append(texts, t)
+			}
+		}
+		return strings.Join(texts, "\n")
+	case '{':
+		var obj struct {
+			Type        string          `json:"type"`
+			ContentType string          `json:"content_type"`
+			Text        json.RawMessage `json:"text"`
+			Parts       json.RawMessage `json:"parts"`
+		}
+		if json.Unmarshal(raw, &obj) != nil {
+			return ""
+		}
+		if !textTypes[obj.Type] || !textTypes[obj.ContentType] {
+			return ""
+		}
+		if len(obj.Parts) > 0 {
+			return textContent(obj.Parts)
+		}
+		return textContent(obj.Text)
+	default:
+		return ""
+	}
+}
+
+// parseTime reads a timestamp given as an RFC 3339 string, a zone-less string
+// (taken as UTC), or a number of Unix seconds (or milliseconds, when too large
+// to be seconds). It returns the zero time for anything else.
+func parseTime(raw json.RawMessage) time.Time {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return time.Time{}
+	}
+	if raw[0] == '"' {
+		var s string
+		if json.Unmarshal(raw, &s) != nil || s == "" {
+			return time.Time{}
+		}
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			return t
+		}
+		for _, layout := range naiveTimeLayouts {
+			if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+				return t
+			}
+		}
+		return time.Time{}
+	}
+	var f float64
+	if json.Unmarshal(raw, &f) != nil || f <= 0 {
+		return time.Time{}
+	}
+	if f > 1e12 {
+		return time.UnixMilli(int64(f)).UTC()
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*1e9)).UTC()
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	"github.com/altairalabs/omnia/pkg/apierror"
+)
+
+// DefaultMaxBodySize is the largest export accepted in one request (64 MB).
+// Larger exports can be split and sent in several requests.
+const DefaultMaxBodySize = 64 * 1024 * 1024
+
+// Handler serves the session import endpoint.
+type Handler struct {
+	importer    *Importer
+	log         logr.Logger
+	maxBodySize int64
+}
+
+// NewHandler creates a new import handler. A maxBodySize of 0 uses
+// DefaultMaxBodySize.
+func NewHandler(importer *Importer, log logr.Logger, maxBodySize int64) *Handler {
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+	return &Handler{
+		importer:    importer,
+		log:         log.WithName("import-handler"),
+		maxBodySize: maxBodySize,
+	}
+}
+
+// RegisterRoutes registers the import endpoint on the given mux.
+func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("POST /api/v1/import", h)
+}
+
+// ServeHTTP handles POST /api/v1/import. The export is the request body
+// (optionally gzip-encoded); the query names its format and where the
+// sessions go:
+//
+//	format     langsmith, openai or jsonl (required)
+//	namespace  workspace namespace (required)
+//	agent      agent the sessions are attributed to (required)
+//	workspace  workspace display name
+//	tag        extra session tag; may repeat
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format, err := ParseFormat(q.Get("format"))
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+	opts := Options{
+		Namespace:     q.Get("namespace"),
+		AgentName:     q.Get("agent"),
+		WorkspaceName: q.Get("workspace"),
+		Tags:          q["tag"],
+	}
+
+	body := io.Reader(http.MaxBytesReader(w, r.Body, h.maxBodySize))
+	if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+		gz, gzErr := gzip.NewReader(body)
+		if gzErr != nil {
+			writeBadRequest(w, "invalid gzip encoding")
+			return
+		}
+		defer func() { _ = gz.Close() }()
+		body = gz
+	}
+
+	res, err := h.importer.Import(r.Context(), format, body, opts)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// writeError maps an import error to the error model. Anything that is not a
+// storage failure is a problem with the request or its body.
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		apierror.WriteHTTP(w, apierror.New(apierror.CodeMessageTooLarge, "import body too large"))
+	case errors.Is(err, ErrMissingNamespace), errors.Is(err, ErrMissingAgent),
+		errors.Is(err, ErrUnknownFormat), errors.Is(err, ErrInvalidExport):
+		writeBadRequest(w, err.Error())
+	default:
+		h.log.Error(err, "import failed")
+		apierror.WriteHTTP(w, apierror.New(apierror.CodeInternalError, "internal server error"))
+	}
+}
+
+func writeBadRequest(w http.ResponseWriter, msg string) {
+	apierror.WriteHTTP(w, apierror.New(apierror.CodeInvalidRequest, msg))
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/pkg/apierror"
+)
+
+func serveImport(t *testing.T, w *mockWriter, maxBody int64, query string, body []byte, gz bool) *httptest.ResponseRecorder {
+	t.Helper()
+	mux := http.NewServeMux()
+	NewHandler(NewImporter(w, logr.Discard()), logr.Discard(), maxBody).RegisterRoutes(mux)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/import?"+query, bytes.NewReader(body))
+	if gz {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_Import(t *testing.T) {
+	w := newMockWriter()
+	rec := serveImport(t, w, 0, "format=jsonl&namespace=team-a&agent=support&tag=a&tag=b",
+		[]byte(jsonlConversations), false)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var res Result
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, 2, res.Imported)
+	assert.Equal(t, 5, res.Messages)
+	assert.Len(t, res.SessionIDs, 2)
+	sess := w.sessions[SessionID(FormatJSONL, "team-a", "c1")]
+	require.NotNil(t, sess)
+	assert.Equal(t, []string{TagImported, "import:jsonl", "a", "b"}, sess.Tags)
+}
+
+func TestHandler_Gzip(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte(openAIExport))
+	require.NoError(t, gz.Close())
+
+	w := newMockWriter()
+	rec := serveImport(t, w, 0, "format=openai&namespace=ns&agent=a", buf.Bytes(), true)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Len(t, w.messages[SessionID(FormatOpenAI, "ns", "conv-1")], 2)
+}
+
+func TestHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		body    string
+		maxBody int64
+		code    string
+	}{
+		{name: "unknown format", query: "format=csv&namespace=ns&agent=a", code: apierror.CodeInvalidRequest},
+		{name: "missing namespace", query: "format=jsonl&agent=a", code: apierror.CodeInvalidRequest},
+		{name: "missing agent", query: "format=jsonl&namespace=ns", code: apierror.CodeInvalidRequest},
+		{name: "invalid body", query: "format=jsonl&namespace=ns&agent=a", body: "not json",
+			code: apierror.CodeInvalidRequest},
+		{name: "too large", query: "format=jsonl&namespace=ns&agent=a", body: jsonlConversations, maxBody: 16,
+			code: apierror.CodeMessageTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newMockWriter()
+			rec := serveImport(t, w, tt.maxBody, tt.query, []byte(tt.body), false)
+			assert.Equal(t, apierror.HTTPStatus(tt.code), rec.Code)
+			var body apierror.Body
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.code, body.Code)
+		})
+	}
+}
+
+func TestHandler_StorageErrorsAreReportedPerConversation(t *testing.T) {
+	w := newMockWriter()
+	w.getErr = errors.New("db down")
+	rec := serveImport(t, w, 0, "format=jsonl&namespace=ns&agent=a", []byte(jsonlConversations), false)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var res Result
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Len(t, res.Errors, 2)
+	assert.True(t, strings.HasPrefix(res.Errors[0], "c1: "))
+}
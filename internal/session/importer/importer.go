@@ -0,0 +1,332 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package importer maps conversations exported from other tools (LangSmith
+// runs, OpenAI conversation dumps, generic JSONL transcripts) into Omnia
+// sessions, so history recorded before a migration can be browsed and
+// evaluated like any other session.
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/pkg/identity"
+	"github.com/altairalabs/omnia/pkg/intconv"
+)
+
+// Format names an export format the importer understands.
+type Format string
+
+const (
+	// FormatLangSmith is a LangSmith run export: a JSON array or JSONL of runs.
+	FormatLangSmith Format = "langsmith"
+	// FormatOpenAI is a ChatGPT data export (conversations.json).
+	FormatOpenAI Format = "openai"
+	// FormatJSONL is one JSON object per line, either a whole conversation
+	// ({"id", "messages"}) or a single message keyed by "session_id".
+	FormatJSONL Format = "jsonl"
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{FormatLangSmith, FormatOpenAI, FormatJSONL}
+
+// Session state keys recording where an imported session came from.
+const (
+	StateKeyFormat   = "import.format"
+	StateKeySourceID = "import.source_id"
+	StateKeyTitle    = "import.title"
+)
+
+// TagImported marks every imported session; a second tag names the format
+// ("import:langsmith").
+const TagImported = "source:import"
+
+// roleTool is the role the runtime records tool results under.
+const roleTool session.MessageRole = "tool"
+
+// Errors returned for a request the importer cannot act on.
+var (
+	ErrUnknownFormat    = errors.New("unknown import format")
+	ErrMissingNamespace = errors.New("namespace is required")
+	ErrMissingAgent     = errors.New("agent name is required")
+	ErrInvalidExport    = errors.New("invalid export")
+)
+
+// importNamespace seeds the deterministic session IDs, so importing the same
+// export twice finds the sessions it created the first time.
+var importNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://omnia.altairalabs.ai/session-import"))
+
+// SessionWriter is the subset of SessionService used by the importer.
+type SessionWriter interface {
+	GetSession(ctx context.Context, sessionID string) (*session.Session, error)
+	CreateSession(ctx context.Context, sess *session.Session) error
+	AppendMessage(ctx context.Context, sessionID string, msg *session.Message) error
+	UpdateSessionStatus(ctx context.Context, sessionID string, update session.SessionStatusUpdate) error
+}
+
+// Options sets where imported sessions land.
+type Options struct {
+	// Namespace is the workspace namespace the sessions belong to. Required.
+	Namespace string
+	// AgentName is the agent the sessions are attributed to. Required.
+	AgentName string
+	// WorkspaceName is the optional workspace display name.
+	WorkspaceName string
+	// Tags are added to every imported session alongside the import tags.
+	Tags []string
+}
+
+// Conversation is one parsed conversation, before it is written as a session.
+type Conversation struct {
+	// SourceID identifies the conversation in the source system.
+	SourceID string
+	// Title is the source's title for the conversation, if it has one.
+	Title string
+	// CreatedAt is when the conversation started; zero falls back to the
+	// first message's timestamp.
+	CreatedAt time.Time
+	// Messages are the conversation's messages in order.
+	Messages []*session.Message
+}
+
+// Result summarises an import.
+type Result struct {
+	// Imported is the number of sessions created.
+	Imported int `json:"imported"`
+	// Skipped is the number of conversations already imported or with no
+	// messages.
+	Skipped int `json:"skipped"`
+	// Messages is the number of messages written.
+	Messages int `json:"messages"`
+	// SessionIDs are the IDs of the sessions created.
+	SessionIDs []string `json:"sessionIds,omitempty"`
+	// Errors describe conversations that failed to import.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ParseFormat returns the Format named by s.
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if strings.EqualFold(s, string(f)) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q (supported: langsmith, openai, jsonl)", ErrUnknownFormat, s)
+}
+
+// Parse reads an export in the given format.
+func Parse(format Format, r io.Reader) ([]Conversation, error) {
+	switch format {
+	case FormatLangSmith:
+		return parseLangSmith(r)
+	case FormatOpenAI:
+		return parseOpenAI(r)
+	case FormatJSONL:
+		return parseJSONL(r)
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownFormat, format)
+	}
+}
+
+// Importer writes parsed conversations as sessions.
+type Importer struct {
+	writer SessionWriter
+	log    logr.Logger
+	now    func() time.Time
+}
+
+// NewImporter creates a new Importer.
+func NewImporter(writer SessionWriter, log logr.Logger) *Importer {
+	return &Importer{
+		writer: writer,
+		log:    log.WithName("session-importer"),
+		now:    time.Now,
+	}
+}
+
+// Import parses r in the given format and writes each conversation as a
+// completed session. Conversations whose session already exists are skipped,
+// so an interrupted import can be re-run. A conversation that fails to write
+// is recorded in the result and does not stop the rest.
+func (im *Importer) Import(ctx context.Context, format Format, r io.Reader, opts Options) (*Result, error) {
+	if opts.Namespace == "" {
+		return nil, ErrMissingNamespace
+	}
+	if opts.AgentName == "" {
+		return nil, ErrMissingAgent
+	}
+	convs, err := Parse(format, r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+
+	res := &Result{}
+	for _, conv := range convs {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		sessionID := SessionID(format, opts.Namespace, conv.SourceID)
+		created, err := im.importConversation(ctx, sessionID, format, conv, opts)
+		switch {
+		case err != nil:
+			im.log.Error(err, "conversation import failed", "sourceID", conv.SourceID, "sessionID", sessionID)
+			res.Errors = append(res.Errors, fmt.Sprintf("%s: %v", conv.SourceID, err))
+		case created:
+			res.Imported++
+			res.Messages += len(conv.Messages)
+			res.SessionIDs = append(res.SessionIDs, sessionID)
+		default:
+			res.Skipped++
+		}
+	}
+	im.log.Info("import finished", "format", format, "namespace", opts.Namespace, "agent", opts.AgentName,
+		"imported", res.Imported, "skipped", res.Skipped, "errors", len(res.Errors))
+	return res, nil
+}
+
+// SessionID returns the session ID a conversation is imported under. It is
+// derived from the format, namespace and source ID, so it is stable across
+// re-imports.
+func SessionID(format Format, namespace, sourceID string) string {
+	return uuid.NewSHA1(importNamespace, []byte(string(format)+"/"+namespace+"/"+sourceID)).String()
+}
+
+// importConversation writes one conversation and reports whether it created
+// a session.
+func (im *Importer) importConversation(ctx context.Context, sessionID string, format Format, conv Conversation, opts Options) (bool, error) {
+	if len(conv.Messages) == 0 {
+		return false, nil
+	}
+	_, err := im.writer.GetSession(ctx, sessionID)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, session.ErrSessionNotFound) {
+		return false, err
+	}
+
+	fillTimestamps(conv.Messages, conv.CreatedAt, im.now())
+	createdAt := conv.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = conv.Messages[0].Timestamp
+	}
+	endedAt := conv.Messages[len(conv.Messages)-1].Timestamp
+
+	state := map[string]string{
+		StateKeyFormat:   string(format),
+		StateKeySourceID: conv.SourceID,
+	}
+	if conv.Title != "" {
+		state[StateKeyTitle] = conv.Title
+	}
+	tags := append([]string{TagImported, "import:" + string(format)}, opts.Tags...)
+
+	sess := &session.Session{
+		ID:            sessionID,
+		AgentName:     opts.AgentName,
+		Namespace:     opts.Namespace,
+		WorkspaceName: opts.WorkspaceName,
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+		Status:        session.SessionStatusActive,
+		State:         state,
+		Tags:          tags,
+		// Exports carry no Omnia identity, so attribute the session to a
+		// deterministic pseudonym of its id, as the OTLP path does.
+		VirtualUserID: identity.PseudonymizeID(sessionID),
+	}
+	if err := im.writer.CreateSession(ctx, sess); err != nil {
+		return false, fmt.Errorf("create session: %w", err)
+	}
+
+	base := uuid.MustParse(sessionID)
+	for i, msg := range conv.Messages {
+		msg.ID = uuid.NewSHA1(base, []byte(fmt.Sprint(i))).String()
+		msg.SequenceNum = intconv.ClampInt32(int64(i) + 1)
+		if err := im.writer.AppendMessage(ctx, sessionID, msg); err != nil {
+			return false, fmt.Errorf("append message %d: %w", i, err)
+		}
+	}
+
+	return true, im.writer.UpdateSessionStatus(ctx, sessionID, session.SessionStatusUpdate{
+		SetStatus:  session.SessionStatusCompleted,
+		SetEndedAt: endedAt,
+	})
+}
+
+// fillTimestamps gives messages without a timestamp the previous message's,
+// starting from the conversation's creation time (or now), so they keep
+// their order.
+func fillTimestamps(msgs []*session.Message, createdAt, now time.Time) {
+	last := createdAt
+	if last.IsZero() {
+		for _, m := range msgs {
+			if !m.Timestamp.IsZero() {
+				last = m.Timestamp
+				break
+			}
+		}
+	}
+	if last.IsZero() {
+		last = now
+	}
+	for _, m := range msgs {
+		if m.Timestamp.IsZero() {
+			m.Timestamp = last
+		}
+		last = m.Timestamp
+	}
+}
+
+// toRole maps the role names used across export formats to a session role.
+// It returns "" for roles that carry no conversation content.
+func toRole(role string) session.MessageRole {
+	switch strings.ToLower(role) {
+	case "user", "human":
+		return session.RoleUser
+	case "assistant", "ai", "bot", "model":
+		return session.RoleAssistant
+	case "system", "developer":
+		return session.RoleSystem
+	case "tool", "function":
+		return roleTool
+	default:
+		return ""
+	}
+}
+
+// newMessage builds a message, returning nil when it has no usable role or
+// content. Tool results are marked the way the runtime records them.
+func newMessage(role, content string, ts time.Time) *session.Message {
+	r := toRole(role)
+	if r == "" || strings.TrimSpace(content) == "" {
+		return nil
+	}
+	msg := &session.Message{Role: r, Content: content, Timestamp: ts}
+	if r == roleTool {
+		msg.Metadata = map[string]string{"type": "tool_result"}
+	}
+	return msg
+}
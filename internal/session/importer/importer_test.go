@@ -0,0 +1,208 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/pkg/identity"
+)
+
+// mockWriter is a test double for SessionWriter.
+type mockWriter struct {
+	sessions map[string]*session.Session
+	messages map[string][]*session.Message
+	statuses map[string]session.SessionStatusUpdate
+
+	getErr    error
+	appendErr error
+}
+
+func newMockWriter() *mockWriter {
+	return &mockWriter{
+		sessions: make(map[string]*session.Session),
+		messages: make(map[string][]*session.Message),
+		statuses: make(map[string]session.SessionStatusUpdate),
+	}
+}
+
+func (m *mockWriter) GetSession(_ context.Context, sessionID string) (*session.Session, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	if s, ok := m.sessions[sessionID]; ok {
+		return s, nil
+	}
+	return nil, session.ErrSessionNotFound
+}
+
+func (m *mockWriter) CreateSession(_ context.Context, sess *session.Session) error {
+	m.sessions[sess.ID] = sess
+	return nil
+}
+
+func (m *mockWriter) AppendMessage(_ context.Context, sessionID string, msg *session.Message) error {
+	if m.appendErr != nil {
+		return m.appendErr
+	}
+	m.messages[sessionID] = append(m.messages[sessionID], msg)
+	return nil
+}
+
+func (m *mockWriter) UpdateSessionStatus(_ context.Context, sessionID string, update session.SessionStatusUpdate) error {
+	m.statuses[sessionID] = update
+	return nil
+}
+
+var testOpts = Options{Namespace: "team-a", AgentName: "support", Tags: []string{"migrated"}}
+
+const jsonlConversations = `{"id":"c1","title":"Refund","created_at":"2025-03-01T10:00:00Z","messages":[
+{"role":"system","content":"Be brief."},
+{"role":"user","content":"Where is my refund?","timestamp":"2025-03-01T10:00:01Z"},
+{"role":"assistant","content":[{"type":"text","text":"It was sent today."}]}]}
+{"session_id":"c2","role":"user","content":"hi","timestamp":1740823200}
+{"session_id":"c2","role":"assistant","content":"hello"}
+`
+
+func TestImport_JSONL(t *testing.T) {
+	w := newMockWriter()
+	res, err := NewImporter(w, logr.Discard()).Import(context.Background(), FormatJSONL,
+		strings.NewReader(jsonlConversations), testOpts)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Imported)
+	assert.Equal(t, 5, res.Messages)
+	assert.Empty(t, res.Errors)
+
+	id := SessionID(FormatJSONL, "team-a", "c1")
+	sess := w.sessions[id]
+	require.NotNil(t, sess)
+	assert.Equal(t, "support", sess.AgentName)
+	assert.Equal(t, "team-a", sess.Namespace)
+	assert.Equal(t, identity.PseudonymizeID(id), sess.VirtualUserID)
+	assert.Equal(t, []string{TagImported, "import:jsonl", "migrated"}, sess.Tags)
+	assert.Equal(t, "c1", sess.State[StateKeySourceID])
+	assert.Equal(t, "Refund", sess.State[StateKeyTitle])
+	assert.Equal(t, time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), sess.CreatedAt)
+
+	msgs := w.messages[id]
+	require.Len(t, msgs, 3)
+	assert.Equal(t, session.RoleSystem, msgs[0].Role)
+	assert.Equal(t, "It was sent today.", msgs[2].Content)
+	assert.Equal(t, int32(3), msgs[2].SequenceNum)
+	// A message without a timestamp takes the previous one's.
+	assert.Equal(t, msgs[1].Timestamp, msgs[2].Timestamp)
+
+	status := w.statuses[id]
+	assert.Equal(t, session.SessionStatusCompleted, status.SetStatus)
+	assert.Equal(t, msgs[2].Timestamp, status.SetEndedAt)
+
+	c2 := w.messages[SessionID(FormatJSONL, "team-a", "c2")]
+	require.Len(t, c2, 2)
+	assert.Equal(t, time.Unix(1740823200, 0).UTC(), c2[0].Timestamp)
+}
+
+func TestImport_SkipsExistingSessions(t *testing.T) {
+	w := newMockWriter()
+	im := NewImporter(w, logr.Discard())
+	_, err := im.Import(context.Background(), FormatJSONL, strings.NewReader(jsonlConversations), testOpts)
+	require.NoError(t, err)
+
+	res, err := im.Import(context.Background(), FormatJSONL, strings.NewReader(jsonlConversations), testOpts)
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Imported)
+	assert.Equal(t, 2, res.Skipped)
+	assert.Len(t, w.messages[SessionID(FormatJSONL, "team-a", "c1")], 3)
+}
+
+func TestImport_Validation(t *testing.T) {
+	im := NewImporter(newMockWriter(), logr.Discard())
+	_, err := im.Import(context.Background(), FormatJSONL, strings.NewReader(""), Options{AgentName: "a"})
+	assert.ErrorIs(t, err, ErrMissingNamespace)
+	_, err = im.Import(context.Background(), FormatJSONL, strings.NewReader(""), Options{Namespace: "ns"})
+	assert.ErrorIs(t, err, ErrMissingAgent)
+	_, err = im.Import(context.Background(), FormatJSONL, strings.NewReader(`{"role":"user","content":"x"}`), testOpts)
+	assert.ErrorIs(t, err, ErrInvalidExport)
+	_, err = im.Import(context.Background(), FormatJSONL, strings.NewReader(`{"id":`), testOpts)
+	assert.ErrorIs(t, err, ErrInvalidExport)
+}
+
+func TestImport_WriteFailureIsReported(t *testing.T) {
+	w := newMockWriter()
+	w.appendErr = errors.New("db down")
+	res, err := NewImporter(w, logr.Discard()).Import(context.Background(), FormatJSONL,
+		strings.NewReader(jsonlConversations), testOpts)
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Imported)
+	require.Len(t, res.Errors, 2)
+	assert.Contains(t, res.Errors[0], "db down")
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("LangSmith")
+	require.NoError(t, err)
+	assert.Equal(t, FormatLangSmith, f)
+	_, err = ParseFormat("csv")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestSessionID_StablePerNamespace(t *testing.T) {
+	assert.Equal(t, SessionID(FormatOpenAI, "a", "x"), SessionID(FormatOpenAI, "a", "x"))
+	assert.NotEqual(t, SessionID(FormatOpenAI, "a", "x"), SessionID(FormatOpenAI, "b", "x"))
+	assert.NotEqual(t, SessionID(FormatOpenAI, "a", "x"), SessionID(FormatJSONL, "a", "x"))
+}
+
+func TestParseJSONL_ConversationWithoutID(t *testing.T) {
+	line := `{"messages":[{"role":"user","content":"q"},{"role":"assistant","content":"a"}]}`
+	first, err := parseJSONL(strings.NewReader(line))
+	require.NoError(t, err)
+	second, err := parseJSONL(strings.NewReader(line + "\n"))
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	assert.NotEmpty(t, first[0].SourceID)
+	assert.Equal(t, first[0].SourceID, second[0].SourceID)
+}
+
+func TestTextContent(t *testing.T) {
+	tests := map[string]string{
+		`"plain"`: "plain",
+		`[{"type":"text","text":"a"},{"type":"image_url","image_url":{}},"b"]`: "a\nb",
+		`{"content_type":"text","parts":["x","y"]}`:                            "x\ny",
+		`{"content_type":"code","text":"print(1)"}`:                            "",
+		`null`: "",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, textContent([]byte(in)), in)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	want := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, want, parseTime([]byte(`"2025-03-01T10:00:00Z"`)))
+	assert.Equal(t, want, parseTime([]byte(`"2025-03-01T10:00:00.000000"`)))
+	assert.Equal(t, want, parseTime([]byte(`1740823200`)))
+	assert.Equal(t, want, parseTime([]byte(`1740823200000`)))
+	assert.True(t, parseTime([]byte(`"yesterday"`)).IsZero())
+}
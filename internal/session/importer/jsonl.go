@@ -0,0 +1,116 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// jsonlMessage is one message of a generic transcript.
+type jsonlMessage struct {
+	Role       string          `json:"role"`
+	Content    json.RawMessage `json:"content"`
+	Timestamp  json.RawMessage `json:"timestamp"`
+	ToolCallID string          `json:"tool_call_id"`
+}
+
+// jsonlRecord is one line of a generic transcript. A line with "messages" is
+// a whole conversation (the OpenAI chat fine-tuning layout is one); any other
+// line is a single message grouped by its session or conversation ID.
+type jsonlRecord struct {
+	jsonlMessage
+	ID             string          `json:"id"`
+	SessionID      string          `json:"session_id"`
+	ConversationID string          `json:"conversation_id"`
+	Title          string          `json:"title"`
+	CreatedAt      json.RawMessage `json:"created_at"`
+	Messages       []jsonlMessage  `json:"messages"`
+}
+
+// conversationID returns the first ID the record carries.
+func (r *jsonlRecord) conversationID() string {
+	for _, id := range []string{r.ID, r.SessionID, r.ConversationID} {
+		if id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// parseJSONL reads a generic JSONL transcript. Conversations keep the order
+// in which they first appear. A whole-conversation line without an ID is
+// identified by a hash of its content, so re-importing the file is idempotent.
+func parseJSONL(r io.Reader) ([]Conversation, error) {
+	var convs []Conversation
+	index := map[string]int{}
+
+	err := decodeValues(r, func(_ int, raw json.RawMessage) error {
+		var rec jsonlRecord
+		if err := json.Unmarshal(raw, &rec); err != nil {
+			return fmt.Errorf("invalid record: %w", err)
+		}
+
+		if rec.Messages != nil {
+			id := rec.conversationID()
+			if id == "" {
+				sum := sha256.Sum256(raw)
+				id = hex.EncodeToString(sum[:8])
+			}
+			conv := Conversation{SourceID: id, Title: rec.Title, CreatedAt: parseTime(rec.CreatedAt)}
+			for _, m := range rec.Messages {
+				if msg := m.toMessage(); msg != nil {
+					conv.Messages = append(conv.Messages, msg)
+				}
+			}
+			convs = append(convs, conv)
+			return nil
+		}
+
+		id := rec.SessionID
+		if id == "" {
+			id = rec.ConversationID
+		}
+		if id == "" {
+			return fmt.Errorf("message record needs a session_id or conversation_id")
+		}
+		i, ok := index[id]
+		if !ok {
+			i = len(convs)
+			index[id] = i
+			convs = append(convs, Conversation{SourceID: id})
+		}
+		if msg := rec.toMessage(); msg != nil {
+			convs[i].Messages = append(convs[i].Messages, msg)
+		}
+		return nil
+	})
+	return convs, err
+}
+
+func (m *jsonlMessage) toMessage() *session.Message {
+	msg := newMessage(m.Role, textContent(m.Content), parseTime(m.Timestamp))
+	if msg != nil {
+		msg.ToolCallID = m.ToolCallID
+	}
+	return msg
+}
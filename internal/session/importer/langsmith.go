@@ -0,0 +1,299 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/pkg/intconv"
+)
+
+// LangSmith run types that carry conversation content.
+const (
+	lsRunTypeLLM = "llm"
+)
+
+// lsThreadKeys are the run metadata keys LangSmith groups runs into threads
+// by, in the order they are checked.
+var lsThreadKeys = []string{"session_id", "thread_id", "conversation_id"}
+
+// lsRun is the subset of a LangSmith run export the importer reads.
+type lsRun struct {
+	ID               string          `json:"id"`
+	TraceID          string          `json:"trace_id"`
+	ParentRunID      string          `json:"parent_run_id"`
+	RunType          string          `json:"run_type"`
+	StartTime        json.RawMessage `json:"start_time"`
+	EndTime          json.RawMessage `json:"end_time"`
+	Inputs           json.RawMessage `json:"inputs"`
+	Outputs          json.RawMessage `json:"outputs"`
+	PromptTokens     int64           `json:"prompt_tokens"`
+	CompletionTokens int64           `json:"completion_tokens"`
+	TotalCost        json.Number     `json:"total_cost"`
+	Extra            struct {
+		Metadata map[string]any `json:"metadata"`
+	} `json:"extra"`
+
+	start time.Time
+}
+
+// threadID returns the conversation the run belongs to: its thread metadata
+// when set, otherwise its trace.
+func (r *lsRun) threadID() string {
+	for _, key := range lsThreadKeys {
+		if v, ok := r.Extra.Metadata[key].(string); ok && v != "" {
+			return v
+		}
+	}
+	if r.TraceID != "" {
+		return r.TraceID
+	}
+	return r.ID
+}
+
+// lsMessage is a chat message in any of the shapes LangSmith records: OpenAI
+// style ({"role", "content"}), LangChain dicts ({"type": "human", "content"}
+// or {"type", "data": {...}}) and serialized LangChain objects
+// ({"id": [..., "HumanMessage"], "kwargs": {...}}).
+type lsMessage struct {
+	Role       string          `json:"role"`
+	Type       string          `json:"type"`
+	Content    json.RawMessage `json:"content"`
+	ToolCallID string          `json:"tool_call_id"`
+	ID         json.RawMessage `json:"id"`
+	Data       *lsMessage      `json:"data"`
+	Kwargs     *lsMessage      `json:"kwargs"`
+}
+
+// parseLangSmith reads a LangSmith run export (a JSON array or JSONL of runs).
+// Runs are grouped into conversations by thread metadata, or by trace when a
+// run has none. Within a conversation the LLM runs are replayed in start
+// order: each run's prompt repeats the history, so only the messages beyond
+// what the conversation already holds are appended, followed by the run's
+// completion. A conversation with no LLM runs falls back to the inputs and
+// outputs of its root runs.
+func parseLangSmith(r io.Reader) ([]Conversation, error) {
+	var order []string
+	threads := map[string][]*lsRun{}
+	err := decodeValues(r, func(_ int, raw json.RawMessage) error {
+		var run lsRun
+		if err := json.Unmarshal(raw, &run); err != nil {
+			return fmt.Errorf("invalid run: %w", err)
+		}
+		if run.ID == "" {
+			return fmt.Errorf("run has no id")
+		}
+		run.start = parseTime(run.StartTime)
+		id := run.threadID()
+		if _, ok := threads[id]; !ok {
+			order = append(order, id)
+		}
+		threads[id] = append(threads[id], &run)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	convs := make([]Conversation, 0, len(order))
+	for _, id := range order {
+		runs := threads[id]
+		sort.SliceStable(runs, func(i, j int) bool { return runs[i].start.Before(runs[j].start) })
+		conv := Conversation{SourceID: id, CreatedAt: runs[0].start}
+		conv.Messages = lsReplayLLMRuns(runs)
+		if len(conv.Messages) == 0 {
+			conv.Messages = lsRootRunMessages(runs)
+		}
+		convs = append(convs, conv)
+	}
+	return convs, nil
+}
+
+// lsReplayLLMRuns rebuilds a conversation from its LLM runs.
+func lsReplayLLMRuns(runs []*lsRun) []*session.Message {
+	var msgs []*session.Message
+	for _, run := range runs {
+		if run.RunType != lsRunTypeLLM {
+			continue
+		}
+		var inputs struct {
+			Messages json.RawMessage `json:"messages"`
+		}
+		_ = json.Unmarshal(run.Inputs, &inputs)
+		prompt := lsMessages(inputs.Messages, run.start)
+		if len(prompt) > len(msgs) {
+			msgs = append(msgs, prompt[len(msgs):]...)
+		}
+		if out := lsCompletion(run); out != nil {
+			msgs = append(msgs, out)
+		}
+	}
+	return msgs
+}
+
+// lsRootRunMessages reads the plain text inputs and outputs of root runs,
+// as chains and agents record them ({"input": ...} / {"output": ...}).
+func lsRootRunMessages(runs []*lsRun) []*session.Message {
+	var msgs []*session.Message
+	for _, run := range runs {
+		if run.ParentRunID != "" {
+			continue
+		}
+		if msg := newMessage(string(session.RoleUser), lsFirstText(run.Inputs, "input", "question", "query"), run.start); msg != nil {
+			msgs = append(msgs, msg)
+		}
+		end := parseTime(run.EndTime)
+		if end.IsZero() {
+			end = run.start
+		}
+		if msg := newMessage(string(session.RoleAssistant), lsFirstText(run.Outputs, "output", "answer", "result"), end); msg != nil {
+			lsAddUsage(msg, run)
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
+// lsMessages reads a prompt's messages. LLM runs record a batch of prompts as
+// a list of lists; only the first prompt of a batch is used.
+func lsMessages(raw json.RawMessage, ts time.Time) []*session.Message {
+	var batch [][]lsMessage
+	if json.Unmarshal(raw, &batch) == nil && len(batch) > 0 {
+		return lsToMessages(batch[0], ts)
+	}
+	var list []lsMessage
+	if json.Unmarshal(raw, &list) == nil {
+		return lsToMessages(list, ts)
+	}
+	return nil
+}
+
+func lsToMessages(list []lsMessage, ts time.Time) []*session.Message {
+	msgs := make([]*session.Message, 0, len(list))
+	for i := range list {
+		if msg := list[i].toMessage(ts); msg != nil {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
+// toMessage resolves the message's role and content across its shapes.
+func (m *lsMessage) toMessage(ts time.Time) *session.Message {
+	inner := m
+	if m.Kwargs != nil {
+		inner = m.Kwargs
+	} else if m.Data != nil {
+		inner = m.Data
+	}
+	role := inner.Role
+	if role == "" {
+		role = inner.Type
+	}
+	if role == "" && m.Type != "constructor" {
+		role = m.Type
+	}
+	if role == "" {
+		role = lsClassRole(m.ID)
+	}
+	msg := newMessage(role, textContent(inner.Content), ts)
+	if msg != nil {
+		msg.ToolCallID = inner.ToolCallID
+	}
+	return msg
+}
+
+// lsClassRole derives a role from a serialized LangChain class path such as
+// ["langchain", "schema", "messages", "HumanMessage"].
+func lsClassRole(raw json.RawMessage) string {
+	var path []string
+	if json.Unmarshal(raw, &path) != nil || len(path) == 0 {
+		return ""
+	}
+	class := path[len(path)-1]
+	class = strings.TrimSuffix(class, "Chunk")
+	return strings.ToLower(strings.TrimSuffix(class, "Message"))
+}
+
+// lsCompletion reads an LLM run's completion from its outputs: LangChain
+// generations or an OpenAI-style choices list.
+func lsCompletion(run *lsRun) *session.Message {
+	var outputs struct {
+		Generations [][]struct {
+			Text    string     `json:"text"`
+			Message *lsMessage `json:"message"`
+		} `json:"generations"`
+		Choices []struct {
+			Message *lsMessage `json:"message"`
+		} `json:"choices"`
+	}
+	if json.Unmarshal(run.Outputs, &outputs) != nil {
+		return nil
+	}
+	ts := parseTime(run.EndTime)
+	if ts.IsZero() {
+		ts = run.start
+	}
+
+	var msg *session.Message
+	switch {
+	case len(outputs.Generations) > 0 && len(outputs.Generations[0]) > 0:
+		gen := outputs.Generations[0][0]
+		if gen.Message != nil {
+			msg = gen.Message.toMessage(ts)
+		}
+		if msg == nil {
+			msg = newMessage(string(session.RoleAssistant), gen.Text, ts)
+		}
+	case len(outputs.Choices) > 0 && outputs.Choices[0].Message != nil:
+		msg = outputs.Choices[0].Message.toMessage(ts)
+	}
+	if msg != nil {
+		lsAddUsage(msg, run)
+	}
+	return msg
+}
+
+// lsAddUsage copies a run's token counts and cost onto its completion.
+func lsAddUsage(msg *session.Message, run *lsRun) {
+	msg.InputTokens = intconv.ClampInt32(run.PromptTokens)
+	msg.OutputTokens = intconv.ClampInt32(run.CompletionTokens)
+	if cost, err := run.TotalCost.Float64(); err == nil {
+		msg.CostUSD = cost
+	}
+}
+
+// lsFirstText returns the text of the first of keys present in a run's
+// inputs or outputs object.
+func lsFirstText(raw json.RawMessage, keys ...string) string {
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(raw, &obj) != nil {
+		return ""
+	}
+	for _, key := range keys {
+		if t := textContent(obj[key]); t != "" {
+			return t
+		}
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// Two turns of one thread, recorded as separate traces. The second LLM run's
+// prompt repeats the first turn.
+const langSmithThread = `[
+{"id":"r2","trace_id":"t2","run_type":"llm","start_time":"2025-03-01T10:01:00.000000",
+ "extra":{"metadata":{"thread_id":"th-1"}},
+ "inputs":{"messages":[[
+   {"lc":1,"type":"constructor","id":["langchain","schema","messages","SystemMessage"],"kwargs":{"content":"Be brief."}},
+   {"lc":1,"type":"constructor","id":["langchain","schema","messages","HumanMessage"],"kwargs":{"content":"Hi"}},
+   {"lc":1,"type":"constructor","id":["langchain","schema","messages","AIMessage"],"kwargs":{"content":"Hello!"}},
+   {"lc":1,"type":"constructor","id":["langchain","schema","messages","HumanMessage"],"kwargs":{"content":"Bye"}}]]},
+ "outputs":{"generations":[[{"text":"Goodbye!"}]]},
+ "prompt_tokens":40,"completion_tokens":3,"total_cost":"0.0002"},
+{"id":"r1","trace_id":"t1","run_type":"llm","start_time":"2025-03-01T10:00:00.000000",
+ "extra":{"metadata":{"thread_id":"th-1"}},
+ "inputs":{"messages":[{"role":"system","content":"Be brief."},{"role":"user","content":"Hi"}]},
+ "outputs":{"choices":[{"message":{"role":"assistant","content":"Hello!"}}]},
+ "prompt_tokens":20,"completion_tokens":2},
+{"id":"r1-parent","trace_id":"t1","run_type":"chain","start_time":"2025-03-01T10:00:00.000000",
+ "extra":{"metadata":{"thread_id":"th-1"}},"inputs":{"input":"Hi"},"outputs":{"output":"Hello!"}}
+]`
+
+func TestParseLangSmith_ReplaysThread(t *testing.T) {
+	convs, err := parseLangSmith(strings.NewReader(langSmithThread))
+	require.NoError(t, err)
+	require.Len(t, convs, 1)
+	conv := convs[0]
+	assert.Equal(t, "th-1", conv.SourceID)
+
+	var got []string
+	for _, m := range conv.Messages {
+		got = append(got, string(m.Role)+": "+m.Content)
+	}
+	assert.Equal(t, []string{
+		"system: Be brief.",
+		"user: Hi",
+		"assistant: Hello!",
+		"user: Bye",
+		"assistant: Goodbye!",
+	}, got)
+
+	last := conv.Messages[4]
+	assert.Equal(t, int32(40), last.InputTokens)
+	assert.Equal(t, int32(3), last.OutputTokens)
+	assert.InDelta(t, 0.0002, last.CostUSD, 1e-9)
+}
+
+func TestParseLangSmith_RootRunFallback(t *testing.T) {
+	runs := `{"id":"a","trace_id":"t","run_type":"chain","inputs":{"question":"What is 2+2?"},"outputs":{"answer":"4"}}
+{"id":"b","trace_id":"t","parent_run_id":"a","run_type":"tool","inputs":{"input":"ignored"},"outputs":{"output":"ignored"}}`
+	convs, err := parseLangSmith(strings.NewReader(runs))
+	require.NoError(t, err)
+	require.Len(t, convs, 1)
+	require.Len(t, convs[0].Messages, 2)
+	assert.Equal(t, session.RoleUser, convs[0].Messages[0].Role)
+	assert.Equal(t, "What is 2+2?", convs[0].Messages[0].Content)
+	assert.Equal(t, "4", convs[0].Messages[1].Content)
+}
+
+func TestParseLangSmith_ToolMessages(t *testing.T) {
+	runs := `{"id":"a","trace_id":"t","run_type":"llm","inputs":{"messages":[
+{"type":"human","data":{"content":"weather?"}},
+{"type":"tool","data":{"content":"sunny","tool_call_id":"call-1"}}]},
+"outputs":{"generations":[[{"message":{"type":"ai","data":{"content":"It is sunny."}}}]]}}`
+	convs, err := parseLangSmith(strings.NewReader(runs))
+	require.NoError(t, err)
+	require.Len(t, convs[0].Messages, 3)
+	tool := convs[0].Messages[1]
+	assert.Equal(t, roleTool, tool.Role)
+	assert.Equal(t, "call-1", tool.ToolCallID)
+	assert.Equal(t, "tool_result", tool.Metadata["type"])
+	assert.Equal(t, "It is sunny.", convs[0].Messages[2].Content)
+}
+
+func TestParseLangSmith_RunWithoutID(t *testing.T) {
+	_, err := parseLangSmith(strings.NewReader(`[{"run_type":"llm"}]`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// openAIConversation is one conversation of a ChatGPT data export. Messages
+// form a tree in Mapping (edits and regenerations branch it); CurrentNode is
+// the leaf of the branch the user last saw.
+type openAIConversation struct {
+	ID             string                `json:"id"`
+	ConversationID string                `json:"conversation_id"`
+	Title          string                `json:"title"`
+	CreateTime     json.RawMessage       `json:"create_time"`
+	CurrentNode    string                `json:"current_node"`
+	Mapping        map[string]openAINode `json:"mapping"`
+}
+
+type openAINode struct {
+	Parent  string         `json:"parent"`
+	Message *openAIMessage `json:"message"`
+}
+
+type openAIMessage struct {
+	Author struct {
+		Role string `json:"role"`
+	} `json:"author"`
+	Content    json.RawMessage `json:"content"`
+	CreateTime json.RawMessage `json:"create_time"`
+	Metadata   struct {
+		IsVisuallyHiddenFromConversation bool `json:"is_visually_hidden_from_conversation"`
+	} `json:"metadata"`
+}
+
+// parseOpenAI reads a ChatGPT export (conversations.json). Only the current
+// branch of each conversation is imported, root first; hidden system
+// scaffolding and non-text content are dropped.
+func parseOpenAI(r io.Reader) ([]Conversation, error) {
+	var convs []Conversation
+	err := decodeValues(r, func(_ int, raw json.RawMessage) error {
+		var oc openAIConversation
+		if err := json.Unmarshal(raw, &oc); err != nil {
+			return fmt.Errorf("invalid conversation: %w", err)
+		}
+		id := oc.ID
+		if id == "" {
+			id = oc.ConversationID
+		}
+		if id == "" {
+			return fmt.Errorf("conversation has no id")
+		}
+		conv := Conversation{SourceID: id, Title: oc.Title, CreatedAt: parseTime(oc.CreateTime)}
+		for _, m := range oc.branch() {
+			if m.Metadata.IsVisuallyHiddenFromConversation {
+				continue
+			}
+			if msg := newMessage(m.Author.Role, textContent(m.Content), parseTime(m.CreateTime)); msg != nil {
+				conv.Messages = append(conv.Messages, msg)
+			}
+		}
+		convs = append(convs, conv)
+		return nil
+	})
+	return convs, err
+}
+
+// branch returns the messages from the root to CurrentNode. The walk is
+// bounded by the size of the mapping so a malformed cycle cannot loop.
+func (oc *openAIConversation) branch() []*openAIMessage {
+	var msgs []*openAIMessage
+	node := oc.CurrentNode
+	for range len(oc.Mapping) {
+		n, ok := oc.Mapping[node]
+		if !ok {
+			break
+		}
+		if n.Message != nil {
+			msgs = append(msgs, n.Message)
+		}
+		node = n.Parent
+	}
+	slices.Reverse(msgs)
+	return msgs
+}
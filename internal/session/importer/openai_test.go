@@ -0,0 +1,76 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A conversation whose first answer was regenerated: node "a1" is the
+// abandoned branch, "a2" the current one.
+const openAIExport = `[{
+  "id": "conv-1",
+  "title": "Trip ideas",
+  "create_time": 1740823200.5,
+  "current_node": "a2",
+  "mapping": {
+    "root": {"parent": null, "message": null},
+    "sys": {"parent": "root", "message": {"author": {"role": "system"},
+      "content": {"content_type": "text", "parts": [""]},
+      "metadata": {"is_visually_hidden_from_conversation": true}}},
+    "u1": {"parent": "sys", "message": {"author": {"role": "user"}, "create_time": 1740823201,
+      "content": {"content_type": "text", "parts": ["Where should I go?"]}}},
+    "a1": {"parent": "u1", "message": {"author": {"role": "assistant"},
+      "content": {"content_type": "text", "parts": ["Paris."]}}},
+    "a2": {"parent": "u1", "message": {"author": {"role": "assistant"}, "create_time": 1740823205,
+      "content": {"content_type": "text", "parts": ["Lisbon."]}}}
+  }
+}]`
+
+func TestParseOpenAI_CurrentBranch(t *testing.T) {
+	convs, err := parseOpenAI(strings.NewReader(openAIExport))
+	require.NoError(t, err)
+	require.Len(t, convs, 1)
+	conv := convs[0]
+	assert.Equal(t, "conv-1", conv.SourceID)
+	assert.Equal(t, "Trip ideas", conv.Title)
+	assert.Equal(t, time.Unix(1740823200, 5e8).UTC(), conv.CreatedAt)
+
+	require.Len(t, conv.Messages, 2)
+	assert.Equal(t, "Where should I go?", conv.Messages[0].Content)
+	assert.Equal(t, "Lisbon.", conv.Messages[1].Content)
+	assert.Equal(t, time.Unix(1740823205, 0).UTC(), conv.Messages[1].Timestamp)
+}
+
+func TestParseOpenAI_CycleTerminates(t *testing.T) {
+	export := `[{"id":"c","current_node":"a","mapping":{
+"a":{"parent":"b","message":{"author":{"role":"user"},"content":{"content_type":"text","parts":["x"]}}},
+"b":{"parent":"a","message":{"author":{"role":"assistant"},"content":{"content_type":"text","parts":["y"]}}}}}]`
+	convs, err := parseOpenAI(strings.NewReader(export))
+	require.NoError(t, err)
+	assert.Len(t, convs[0].Messages, 2)
+}
+
+func TestParseOpenAI_MissingID(t *testing.T) {
+	_, err := parseOpenAI(strings.NewReader(`[{"title":"x"}]`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package langdetect guesses the language of short conversational text.
+//
+// Detection is deliberately cheap so it can run on every ingested message:
+// text written in a script used by a single major language (Japanese kana,
+// Hangul, Thai, ...) is classified by script, and Latin-script text is
+// classified by counting common function words. Text that gives no clear
+// signal is reported as undetermined rather than guessed.
+package langdetect
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Undetermined is returned by Detect when the language cannot be told.
+const Undetermined = ""
+
+// minScriptLetters is the number of letters a non-Latin script must
+// contribute before the text is attributed to it.
+const minScriptLetters = 2
+
+// minStopwordHits is the number of function words a Latin-script text must
+// contain before it is attributed to a language.
+const minStopwordHits = 2
+
+// maxScanRunes bounds the work done on very long messages. The opening of a
+// message is representative of its language.
+const maxScanRunes = 2000
+
+// scriptLanguages maps scripts that are (nearly) exclusive to one language
+// onto its BCP 47 code. Han and Cyrillic are handled separately because they
+// are shared.
+var scriptLanguages = []struct {
+	table *unicode.RangeTable
+	lang  string
+}{
+	{unicode.Hangul, "ko"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Greek, "el"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+}
+
+// Detect returns the BCP 47 base language of text, or Undetermined.
+func Detect(text string) string {
+	var (
+		latin, han, kana, cyrillic, ukrainian int
+		scripts                               = make([]int, len(scriptLanguages))
+		letters                               int
+	)
+
+	n := 0
+	for _, r := range text {
+		if n++; n > maxScanRunes {
+			break
+		}
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+			if strings.ContainsRune("ієїґІЄЇҐ", r) {
+				ukrainian++
+			}
+		default:
+			for i, s := range scriptLanguages {
+				if unicode.Is(s.table, r) {
+					scripts[i]++
+					break
+				}
+			}
+		}
+	}
+	if letters == 0 {
+		return Undetermined
+	}
+
+	// Japanese mixes kana with Han; any kana tips Han text to Japanese.
+	if kana+han > letters/2 && kana+han >= minScriptLetters {
+		if kana > 0 {
+			return "ja"
+		}
+		return "zh"
+	}
+	if cyrillic > letters/2 && cyrillic >= minScriptLetters {
+		if ukrainian > 0 {
+			return "uk"
+		}
+		return "ru"
+	}
+	for i, count := range scripts {
+		if count > letters/2 && count >= minScriptLetters {
+			return scriptLanguages[i].lang
+		}
+	}
+	if latin > letters/2 {
+		return detectLatin(text)
+	}
+	return Undetermined
+}
+
+// detectLatin scores Latin-script text against each language's function
+// words and returns the clear winner, if any.
+func detectLatin(text string) string {
+	scores := make(map[string]int, len(stopwords))
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) > maxScanRunes/4 {
+		words = words[:maxScanRunes/4]
+	}
+	for _, w := range words {
+		for _, lang := range stopwordIndex[w] {
+			scores[lang]++
+		}
+	}
+
+	best, bestScore, runnerUp := Undetermined, 0, 0
+	for _, lang := range latinLanguages {
+		switch score := scores[lang]; {
+		case score > bestScore:
+			best, bestScore, runnerUp = lang, score, bestScore
+		case score > runnerUp:
+			runnerUp = score
+		}
+	}
+	if bestScore < minStopwordHits || bestScore == runnerUp {
+		return Undetermined
+	}
+	return best
+}
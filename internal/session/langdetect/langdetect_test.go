@@ -0,0 +1,66 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package langdetect
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english", "Can you tell me where my order is? It was supposed to arrive today.", "en"},
+		{"spanish", "Hola, ¿dónde está mi pedido? Debía llegar hoy por la mañana.", "es"},
+		{"french", "Bonjour, pourquoi ma commande n'est pas encore arrivée ? Merci.", "fr"},
+		{"german", "Ich kann mein Passwort nicht ändern, bitte hilf mir.", "de"},
+		{"italian", "Ciao, non riesco a trovare il mio ordine. Grazie per l'aiuto.", "it"},
+		{"portuguese", "Olá, você pode me ajudar? Não consigo acessar a minha conta.", "pt"},
+		{"dutch", "Hallo, ik kan mijn wachtwoord niet vinden. Waar staat het?", "nl"},
+		{"japanese", "注文はいつ届きますか？", "ja"},
+		{"chinese", "我的订单什么时候到？", "zh"},
+		{"korean", "주문이 언제 도착하나요?", "ko"},
+		{"russian", "Где мой заказ? Он должен был прийти сегодня.", "ru"},
+		{"ukrainian", "Де моє замовлення? Воно мало прийти сьогодні.", "uk"},
+		{"arabic", "أين طلبي؟ كان من المفترض أن يصل اليوم.", "ar"},
+		{"hebrew", "איפה ההזמנה שלי?", "he"},
+		{"greek", "Πού είναι η παραγγελία μου;", "el"},
+		{"thai", "คำสั่งซื้อของฉันอยู่ที่ไหน", "th"},
+		{"hindi", "मेरा ऑर्डर कहाँ है?", "hi"},
+		{"empty", "", Undetermined},
+		{"no letters", "1234 !!! :)", Undetermined},
+		{"too short", "ok", Undetermined},
+		{"no function words", "Kubernetes Prometheus Grafana", Undetermined},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.text); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetect_LongTextIsBounded(t *testing.T) {
+	text := strings.Repeat("where is the order and what is the status ", 1000)
+	if got := Detect(text); got != "en" {
+		t.Errorf("Detect(long) = %q, want en", got)
+	}
+}
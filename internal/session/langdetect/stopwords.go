@@ -0,0 +1,58 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package langdetect
+
+import "strings"
+
+// latinLanguages fixes the order Latin-script candidates are scored in, so
+// results do not depend on map iteration.
+var latinLanguages = []string{"en", "es", "fr", "de", "it", "pt", "nl"}
+
+// stopwords holds frequent function words of each Latin-script language.
+// Words shared between languages score for all of them.
+var stopwords = map[string]string{
+	"en": "the and is are was were be been to of in that it for on with as " +
+		"you your this have has had not but what can will would do does don't " +
+		"i i'm my we our they their there please thanks thank how why when where",
+	"es": "el la los las es son está están que de del en y por para con una un " +
+		"no pero como mi tu su yo usted nosotros qué cómo cuándo dónde gracias " +
+		"hola también muy porque",
+	"fr": "le la les est sont et que qui de des du en une un pour avec pas je " +
+		"vous nous ils elle mon ton votre c'est merci bonjour pourquoi comment " +
+		"quand où très aussi mais",
+	"de": "der die das ist sind und nicht ein eine mit für auf zu den dem ich du " +
+		"sie wir ihr mein dein bitte danke warum wie wann wo auch sehr aber kann",
+	"it": "il lo gli la le è sono e che di del della per con una un non ma io tu " +
+		"lui noi voi mio grazie ciao perché come quando dove anche molto",
+	"pt": "o os a as é são e que de do da em um uma para com não mas eu você " +
+		"nós meu seu obrigado obrigada olá porque como quando onde também muito",
+	"nl": "de het een is zijn en niet met voor op te van dat ik je jij wij we " +
+		"mijn bedankt dank hallo waarom hoe wanneer waar ook heel maar",
+}
+
+// stopwordIndex maps each function word to the languages it belongs to.
+var stopwordIndex = buildStopwordIndex()
+
+func buildStopwordIndex() map[string][]string {
+	index := make(map[string][]string)
+	for _, lang := range latinLanguages {
+		for _, w := range strings.Fields(stopwords[lang]) {
+			index[w] = append(index[w], lang)
+		}
+	}
+	return index
+}
@@ -18,6 +18,7 @@ package otlp
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"sort"
@@ -35,6 +36,14 @@ import (
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
 
+// Metadata the transformer stamps on every message it writes, so consumers
+// such as the eval worker can recognise OTLP transcripts.
+const (
+	MetadataKeySource   = "source"
+	MetadataValueSource = "otlp"
+	MetadataKeySpanID   = "otel.span_id"
+)
+
 // SessionWriter is the subset of SessionService used by the transformer.
 type SessionWriter interface {
 	GetSession(ctx context.Context, sessionID string) (*session.Session, error)
@@ -154,18 +163,19 @@ func (t *Transformer) processSpan(ctx context.Context, sc spanContext, span *tra
 	timestamp := spanTimestamp(span)
 
 	// Route enriched spans by name prefix.
+	var msgs []*session.Message
 	switch {
 	case strings.HasPrefix(spanName, "tool."):
-		return t.processToolSpan(ctx, sessionID, attrs, timestamp)
+		msgs = []*session.Message{toolSpanMessage(attrs, timestamp)}
 	case spanName == "workflow.transition":
-		return t.processWorkflowTransition(ctx, sessionID, attrs, timestamp)
+		msgs = []*session.Message{workflowTransitionMessage(attrs, timestamp)}
 	case spanName == "workflow.completed":
-		return t.processWorkflowCompleted(ctx, sessionID, attrs, timestamp)
+		msgs = []*session.Message{workflowCompletedMessage(attrs, timestamp)}
+	default:
+		// GenAI conversation messages + token usage.
+		msgs = t.resolveMessages(attrs, span.GetEvents(), timestamp, extractModel(attrs))
 	}
-
-	// Default: GenAI conversation messages + token usage.
-	model := extractModel(attrs)
-	msgs := t.resolveMessages(attrs, span.GetEvents(), timestamp, model)
+	stampSpanOrigin(msgs, span.GetSpanId())
 
 	for _, msg := range msgs {
 		if err := t.writer.AppendMessage(ctx, sessionID, msg); err != nil {
@@ -178,6 +188,22 @@ func (t *Transformer) processSpan(ctx context.Context, sc spanContext, span *tra
 	return nil
 }
 
+// stampSpanOrigin marks msgs as written by this transformer from the span
+// with the given id. A GenAI span replays the conversation so far as its
+// input messages, so consumers need the span id to tell one call's messages
+// from the next.
+func stampSpanOrigin(msgs []*session.Message, spanID []byte) {
+	for _, msg := range msgs {
+		if msg.Metadata == nil {
+			msg.Metadata = make(map[string]string, 2)
+		}
+		msg.Metadata[MetadataKeySource] = MetadataValueSource
+		if len(spanID) > 0 {
+			msg.Metadata[MetadataKeySpanID] = hex.EncodeToString(spanID)
+		}
+	}
+}
+
 // ensureSession creates the session if it does not already exist.
 func (t *Transformer) ensureSession(ctx context.Context, sessionID string, sc spanContext, spanAttrs []*commonpb.KeyValue) error {
 	_, err := t.writer.GetSession(ctx, sessionID)
@@ -353,10 +379,10 @@ func applyMessageDefaults(msgs []*session.Message, model string) {
 // updateTokenUsage is no longer needed — token counters are auto-derived
 // from Message.InputTokens/OutputTokens in AppendMessage.
 
-// processToolSpan converts a tool.* span into a session message matching
+// toolSpanMessage converts a tool.* span into a session message matching
 // the metadata format used by event_store.go handleToolCallCompleted.
-func (t *Transformer) processToolSpan(ctx context.Context, sessionID string, attrs []*commonpb.KeyValue, ts time.Time) error {
-	msg := &session.Message{
+func toolSpanMessage(attrs []*commonpb.KeyValue, ts time.Time) *session.Message {
+	return &session.Message{
 		ID:         uuid.New().String(),
 		Role:       session.RoleSystem,
 		Timestamp:  ts,
@@ -369,13 +395,12 @@ func (t *Transformer) processToolSpan(ctx context.Context, sessionID string, att
 			"duration_ms": strconv.FormatInt(getIntAttr(attrs, AttrToolDurationMs), 10),
 		},
 	}
-	return t.writer.AppendMessage(ctx, sessionID, msg)
 }
 
-// processWorkflowTransition converts a workflow.transition span into a session
+// workflowTransitionMessage converts a workflow.transition span into a session
 // message matching the metadata format used by event_store.go handleWorkflowTransitioned.
-func (t *Transformer) processWorkflowTransition(ctx context.Context, sessionID string, attrs []*commonpb.KeyValue, ts time.Time) error {
-	msg := &session.Message{
+func workflowTransitionMessage(attrs []*commonpb.KeyValue, ts time.Time) *session.Message {
+	return &session.Message{
 		ID:        uuid.New().String(),
 		Role:      session.RoleSystem,
 		Timestamp: ts,
@@ -387,13 +412,12 @@ func (t *Transformer) processWorkflowTransition(ctx context.Context, sessionID s
 			"prompt_task": getStringAttr(attrs, AttrWorkflowPromptTask),
 		},
 	}
-	return t.writer.AppendMessage(ctx, sessionID, msg)
 }
 
-// processWorkflowCompleted converts a workflow.completed span into a session
+// workflowCompletedMessage converts a workflow.completed span into a session
 // message matching the metadata format used by event_store.go handleWorkflowCompleted.
-func (t *Transformer) processWorkflowCompleted(ctx context.Context, sessionID string, attrs []*commonpb.KeyValue, ts time.Time) error {
-	msg := &session.Message{
+func workflowCompletedMessage(attrs []*commonpb.KeyValue, ts time.Time) *session.Message {
+	return &session.Message{
 		ID:        uuid.New().String(),
 		Role:      session.RoleSystem,
 		Timestamp: ts,
@@ -403,7 +427,6 @@ func (t *Transformer) processWorkflowCompleted(ctx context.Context, sessionID st
 			"transition_count": strconv.FormatInt(getIntAttr(attrs, AttrWorkflowTransitionCount), 10),
 		},
 	}
-	return t.writer.AppendMessage(ctx, sessionID, msg)
 }
 
 // parseMessageValue extracts role and content from a kvlist AnyValue.
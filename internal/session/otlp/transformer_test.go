@@ -602,6 +602,8 @@ func TestProcessExport_ToolSpan(t *testing.T) {
 	assert.Equal(t, `{"query":"test"}`, msg.Metadata["tool_args"])
 	assert.Equal(t, "success", msg.Metadata["status"])
 	assert.Equal(t, "150", msg.Metadata["duration_ms"])
+	assert.Equal(t, MetadataValueSource, msg.Metadata[MetadataKeySource])
+	assert.Equal(t, "01", msg.Metadata[MetadataKeySpanID])
 	assert.NotEmpty(t, msg.ID)
 }
 
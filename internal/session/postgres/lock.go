@@ -0,0 +1,187 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Advisory lock names, hashed with hashtext() into the lock key. They are
+// scoped to the database, so every session-api replica sharing a database
+// contends for the same locks.
+const (
+	// MigrationLockName serializes UpLocked across replicas.
+	MigrationLockName = "omnia-session-api:migrations"
+	// LeaderLockName is held by the replica elected to run background tasks.
+	LeaderLockName = "omnia-session-api:leader"
+)
+
+// unlockTimeout bounds the pg_advisory_unlock on release, so a dead database
+// cannot hang shutdown. The lock is dropped with the connection anyway.
+const unlockTimeout = 5 * time.Second
+
+// lockConn takes the blocking session-level advisory lock name on a connection
+// pinned for the lock's lifetime, and returns the function that releases it.
+//
+// Session-level advisory locks belong to the connection that took them, so
+// the unlock must run on the same connection; unlocking through the pool could
+// land on another connection and strand the lock.
+func lockConn(ctx context.Context, pool *pgxpool.Pool, name string) (func(), error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire conn for advisory lock: %w", err)
+	}
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock(hashtext($1))`, name); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("advisory lock %q: %w", name, err)
+	}
+	return func() { unlockConn(conn, name) }, nil
+}
+
+// unlockConn releases the advisory lock name on the connection that holds it
+// and returns the connection to the pool.
+func unlockConn(conn *pgxpool.Conn, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), unlockTimeout)
+	defer cancel()
+	_, _ = conn.Exec(ctx, `SELECT pg_advisory_unlock(hashtext($1))`, name)
+	conn.Release()
+}
+
+// UpLocked runs Up while holding the MigrationLockName advisory lock, waiting
+// for any other replica's run to finish first. Replicas that start together
+// (a scale-up, or the overlap of a rolling update) must not interleave: the
+// dirty-state recovery in Up would otherwise force back a migration another
+// replica is still applying. The wait is bounded by ctx.
+func (mg *Migrator) UpLocked(ctx context.Context, pool *pgxpool.Pool) error {
+	mg.logger.V(1).Info("waiting for migration lock")
+	unlock, err := lockConn(ctx, pool, MigrationLockName)
+	if err != nil {
+		return fmt.Errorf("taking migration lock: %w", err)
+	}
+	defer unlock()
+	return mg.Up()
+}
+
+// LeaderElector elects one of the replicas sharing a database to run work
+// that must not run concurrently, by holding the LeaderLockName advisory
+// lock. There is no lease to renew: Postgres drops the lock when the holder's
+// connection closes, so a crashed or partitioned leader is replaced once its
+// connection is gone.
+type LeaderElector struct {
+	pool     *pgxpool.Pool
+	name     string
+	interval time.Duration
+	logger   logr.Logger
+	leading  atomic.Bool
+}
+
+// NewLeaderElector creates a LeaderElector for the LeaderLockName lock.
+// interval is how often a follower retries the lock and the leader checks
+// its lock connection.
+func NewLeaderElector(pool *pgxpool.Pool, interval time.Duration, logger logr.Logger) *LeaderElector {
+	return &LeaderElector{pool: pool, name: LeaderLockName, interval: interval, logger: logger}
+}
+
+// IsLeader reports whether this replica currently holds leadership.
+func (e *LeaderElector) IsLeader() bool {
+	return e.leading.Load()
+}
+
+// Run campaigns for leadership until ctx is cancelled. While this replica
+// leads, lead runs with a context that is cancelled when leadership is lost
+// or ctx ends; lead should block until then. If lead returns early, the lock
+// is released so another replica can take over.
+//
+// A leader that loses its database connection notices within interval, so
+// the old and new leader can overlap for up to that long; lead's work must
+// tolerate a brief double run.
+func (e *LeaderElector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	for {
+		if err := e.campaign(ctx, lead); err != nil {
+			e.logger.Error(err, "leader election failed", "lock", e.name)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+// campaign tries the lock once and, if it is taken, leads until leadership
+// ends. It returns nil when another replica holds the lock.
+func (e *LeaderElector) campaign(ctx context.Context, lead func(ctx context.Context)) error {
+	conn, err := e.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire conn for advisory lock: %w", err)
+	}
+	var ok bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, e.name).Scan(&ok); err != nil {
+		conn.Release()
+		return fmt.Errorf("advisory lock %q: %w", e.name, err)
+	}
+	if !ok {
+		conn.Release()
+		return nil
+	}
+
+	e.leading.Store(true)
+	e.logger.Info("acquired leadership", "lock", e.name)
+	leadCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lead(leadCtx)
+	}()
+	stop := func() {
+		cancel()
+		<-done
+		e.leading.Store(false)
+	}
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			stop()
+			unlockConn(conn, e.name)
+			e.logger.Info("released leadership", "lock", e.name)
+			return nil
+		case <-done:
+			stop()
+			unlockConn(conn, e.name)
+			e.logger.Info("released leadership", "lock", e.name, "reason", "lead returned")
+			return nil
+		case <-ticker.C:
+			if err := conn.Ping(ctx); err != nil && ctx.Err() == nil {
+				stop()
+				// The lock went with the connection; close it so the pool
+				// discards it rather than handing it out again.
+				_ = conn.Conn().Close(context.Background())
+				conn.Release()
+				return fmt.Errorf("lost leadership: lock connection: %w", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPool(t *testing.T, connStr string) *pgxpool.Pool {
+	t.Helper()
+	pool, err := pgxpool.New(context.Background(), connStr)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestMigrator_UpLockedConcurrentReplicas(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	_, connStr := freshDB(t)
+	pool := newTestPool(t, connStr)
+
+	// Replicas starting together must all succeed and leave a clean schema.
+	const replicas = 3
+	errs := make([]error, replicas)
+	var wg sync.WaitGroup
+	for i := range replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mg, err := NewMigrator(connStr, logr.Discard())
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = mg.Close() }()
+			errs[i] = mg.UpLocked(context.Background(), pool)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	mg, err := NewMigrator(connStr, logr.Discard())
+	require.NoError(t, err)
+	defer func() { _ = mg.Close() }()
+	_, dirty, err := mg.Version()
+	require.NoError(t, err)
+	assert.False(t, dirty)
+}
+
+func TestMigrator_UpLockedWaitBoundedByContext(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	_, connStr := freshDB(t)
+	pool := newTestPool(t, connStr)
+	unlock, err := lockConn(context.Background(), pool, MigrationLockName)
+	require.NoError(t, err)
+	defer unlock()
+
+	mg, err := NewMigrator(connStr, logr.Discard())
+	require.NoError(t, err)
+	defer func() { _ = mg.Close() }()
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	assert.Error(t, mg.UpLocked(ctx, pool))
+}
+
+func TestLeaderElector_SingleLeaderAndFailover(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	_, connStr := freshDB(t)
+	interval := 20 * time.Millisecond
+	first := NewLeaderElector(newTestPool(t, connStr), interval, logr.Discard())
+	second := NewLeaderElector(newTestPool(t, connStr), interval, logr.Discard())
+	blockUntilDone := func(ctx context.Context) { <-ctx.Done() }
+
+	firstCtx, stopFirst := context.WithCancel(context.Background())
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		first.Run(firstCtx, blockUntilDone)
+	}()
+	require.Eventually(t, first.IsLeader, 5*time.Second, interval)
+
+	secondCtx, stopSecond := context.WithCancel(context.Background())
+	defer stopSecond()
+	go second.Run(secondCtx, blockUntilDone)
+
+	// The second replica must not lead while the first holds the lock.
+	time.Sleep(5 * interval)
+	assert.False(t, second.IsLeader())
+
+	// Stopping the leader releases the lock to the follower.
+	stopFirst()
+	<-firstDone
+	assert.False(t, first.IsLeader())
+	require.Eventually(t, second.IsLeader, 5*time.Second, interval)
+}
+
+func TestLeaderElector_ReleasesWhenLeadReturns(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	_, connStr := freshDB(t)
+	pool := newTestPool(t, connStr)
+	interval := 20 * time.Millisecond
+	e := NewLeaderElector(pool, interval, logr.Discard())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mu sync.Mutex
+	runs := 0
+	go e.Run(ctx, func(context.Context) {
+		mu.Lock()
+		runs++
+		mu.Unlock()
+	})
+
+	// An early return gives up the lock, so the next campaign wins it again.
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return runs >= 2
+	}, 5*time.Second, interval)
+}
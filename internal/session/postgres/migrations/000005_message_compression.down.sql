@@ -0,0 +1,6 @@
+-- Reverses 000005. Compressed message bodies cannot be decompressed in SQL, so
+-- rolling back discards them: run session-api with message compression disabled
+-- and decompress first if that content must be kept.
+DROP TABLE IF EXISTS message_compression_dictionaries;
+ALTER TABLE messages DROP COLUMN IF EXISTS content_dict_id;
+ALTER TABLE messages DROP COLUMN IF EXISTS content_zstd;
@@ -0,0 +1,26 @@
+-- Message compression. Large tool outputs dominate warm-store size, so session-api
+-- stores them zstd-compressed in content_zstd and leaves content empty.
+-- content_dict_id names the per-namespace dictionary the frame was compressed
+-- with (NULL = no dictionary). Readers decompress transparently; rows written
+-- before this migration keep plain content until the background recompression
+-- pass rewrites them.
+--
+-- messages is partitioned by "timestamp"; ALTER TABLE ADD COLUMN on the parent
+-- cascades to every partition.
+ALTER TABLE messages ADD COLUMN content_zstd    BYTEA;
+ALTER TABLE messages ADD COLUMN content_dict_id BIGINT;
+
+-- Trained zstd dictionaries, one series per tenant namespace. id is the
+-- dictionary ID embedded in the dictionary and in every frame compressed with
+-- it. Rows are never deleted while messages may reference them.
+CREATE TABLE message_compression_dictionaries (
+    id           BIGINT      NOT NULL,
+    namespace    TEXT        NOT NULL,
+    dict         BYTEA       NOT NULL,
+    sample_count INTEGER     NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_message_compression_dictionaries_namespace
+    ON message_compression_dictionaries (namespace, created_at DESC);
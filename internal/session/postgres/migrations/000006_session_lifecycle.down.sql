@@ -0,0 +1,8 @@
+-- Reverses 000006. Sessions in the new statuses are folded back into the
+-- closest old one before the old constraint is restored.
+DROP INDEX IF EXISTS idx_sessions_status_updated;
+UPDATE sessions SET status = 'active' WHERE status IN ('idle', 'escalated');
+UPDATE sessions SET status = 'completed' WHERE status = 'archived';
+ALTER TABLE sessions DROP CONSTRAINT sessions_status_check;
+ALTER TABLE sessions ADD CONSTRAINT sessions_status_check
+    CHECK (status IN ('active', 'completed', 'error', 'expired'));
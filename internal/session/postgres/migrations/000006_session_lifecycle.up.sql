@@ -0,0 +1,14 @@
+-- Explicit session lifecycle. Sessions go idle after a quiet period and are
+-- completed by session-api's idle reaper; an agent can escalate a session to a
+-- human, and ended sessions can be archived. See session.CanTransition for the
+-- allowed transitions.
+--
+-- sessions is partitioned by created_at; constraints and indexes on the parent
+-- cascade to every partition.
+ALTER TABLE sessions DROP CONSTRAINT sessions_status_check;
+ALTER TABLE sessions ADD CONSTRAINT sessions_status_check
+    CHECK (status IN ('active', 'idle', 'escalated', 'completed', 'error', 'expired', 'archived'));
+
+-- Covers the reaper's hot queries: open sessions ordered by last activity.
+CREATE INDEX idx_sessions_status_updated ON sessions (status, updated_at)
+    WHERE status IN ('active', 'idle');
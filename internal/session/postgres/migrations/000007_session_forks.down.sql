@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_sessions_parent;
+ALTER TABLE sessions DROP COLUMN IF EXISTS forked_from_message_id;
+ALTER TABLE sessions DROP COLUMN IF EXISTS parent_session_id;
@@ -0,0 +1,14 @@
+-- Conversation forks. A fork is a new session that starts with a copy of
+-- another session's messages up to a chosen message; parent_session_id and
+-- forked_from_message_id record where it branched off. No foreign key: sessions
+-- is partitioned and keyed by (id, created_at), and a parent may be dropped by
+-- retention before its forks.
+--
+-- sessions is partitioned by created_at; ALTER TABLE ADD COLUMN and indexes on
+-- the parent cascade to every partition.
+ALTER TABLE sessions ADD COLUMN parent_session_id      UUID;
+ALTER TABLE sessions ADD COLUMN forked_from_message_id UUID;
+
+-- Covers listing a session's forks.
+CREATE INDEX idx_sessions_parent ON sessions (parent_session_id, created_at DESC)
+    WHERE parent_session_id IS NOT NULL;
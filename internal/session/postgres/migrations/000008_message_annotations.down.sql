@@ -0,0 +1,42 @@
+CREATE OR REPLACE FUNCTION cascade_delete_session() RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM message_artifacts WHERE session_id = OLD.id;
+    DELETE FROM tool_calls       WHERE session_id = OLD.id;
+    DELETE FROM provider_calls   WHERE session_id = OLD.id;
+    DELETE FROM runtime_events   WHERE session_id = OLD.id;
+    DELETE FROM eval_results     WHERE session_id = OLD.id;
+    DELETE FROM messages         WHERE session_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION manage_session_partitions(
+    retention_days  INTEGER DEFAULT 30,
+    lookahead_weeks INTEGER DEFAULT 2
+) RETURNS TABLE(table_name TEXT, partitions_created INTEGER, partitions_dropped INTEGER) AS $$
+DECLARE
+    tables TEXT[] := ARRAY[
+        'sessions', 'messages', 'tool_calls', 'provider_calls',
+        'provider_usage', 'runtime_events', 'message_artifacts',
+        'audit_log', 'eval_results'
+    ];
+    tbl        TEXT;
+    created    INTEGER;
+    dropped    INTEGER;
+    start_date DATE;
+    end_date   DATE;
+BEGIN
+    start_date := CURRENT_DATE - (retention_days || ' days')::INTERVAL;
+    end_date   := CURRENT_DATE + (lookahead_weeks * 7 || ' days')::INTERVAL;
+    FOREACH tbl IN ARRAY tables LOOP
+        created := create_weekly_partitions(tbl, start_date, end_date);
+        dropped := drop_old_partitions(tbl, retention_days);
+        table_name := tbl;
+        partitions_created := created;
+        partitions_dropped := dropped;
+        RETURN NEXT;
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS message_annotations;
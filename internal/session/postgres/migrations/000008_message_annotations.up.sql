@@ -0,0 +1,72 @@
+-- Message annotations: end-user feedback (thumbs up/down) and reviewer labels
+-- and suggested corrections on individual messages. They feed the annotation
+-- aggregates and eval dataset generation. namespace and agent_name are
+-- denormalized from sessions (immutable per session) so those queries filter
+-- without a JOIN, as on provider_calls.
+CREATE TABLE message_annotations (
+    id          UUID        NOT NULL DEFAULT gen_random_uuid(),
+    session_id  UUID        NOT NULL,
+    message_id  UUID        NOT NULL,
+    namespace   TEXT        NOT NULL,
+    agent_name  TEXT        NOT NULL,
+    source      TEXT        NOT NULL,
+    author      TEXT,
+    score       SMALLINT,
+    labels      TEXT[]      NOT NULL DEFAULT '{}'::text[],
+    correction  TEXT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT message_annotations_source_check CHECK (source IN ('user', 'reviewer')),
+    CONSTRAINT message_annotations_score_check CHECK (score IN (-1, 1)),
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE INDEX idx_message_annotations_session           ON message_annotations (session_id, created_at);
+CREATE INDEX idx_message_annotations_namespace_created ON message_annotations (namespace, created_at DESC);
+CREATE INDEX idx_message_annotations_labels            ON message_annotations USING GIN (labels);
+
+-- Rotate message_annotations partitions with the other session tables.
+CREATE OR REPLACE FUNCTION manage_session_partitions(
+    retention_days  INTEGER DEFAULT 30,
+    lookahead_weeks INTEGER DEFAULT 2
+) RETURNS TABLE(table_name TEXT, partitions_created INTEGER, partitions_dropped INTEGER) AS $$
+DECLARE
+    tables TEXT[] := ARRAY[
+        'sessions', 'messages', 'tool_calls', 'provider_calls',
+        'provider_usage', 'runtime_events', 'message_artifacts',
+        'audit_log', 'eval_results', 'message_annotations'
+    ];
+    tbl        TEXT;
+    created    INTEGER;
+    dropped    INTEGER;
+    start_date DATE;
+    end_date   DATE;
+BEGIN
+    start_date := CURRENT_DATE - (retention_days || ' days')::INTERVAL;
+    end_date   := CURRENT_DATE + (lookahead_weeks * 7 || ' days')::INTERVAL;
+    FOREACH tbl IN ARRAY tables LOOP
+        created := create_weekly_partitions(tbl, start_date, end_date);
+        dropped := drop_old_partitions(tbl, retention_days);
+        table_name := tbl;
+        partitions_created := created;
+        partitions_dropped := dropped;
+        RETURN NEXT;
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Delete a session's annotations with it.
+CREATE OR REPLACE FUNCTION cascade_delete_session() RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM message_artifacts   WHERE session_id = OLD.id;
+    DELETE FROM message_annotations WHERE session_id = OLD.id;
+    DELETE FROM tool_calls          WHERE session_id = OLD.id;
+    DELETE FROM provider_calls      WHERE session_id = OLD.id;
+    DELETE FROM runtime_events      WHERE session_id = OLD.id;
+    DELETE FROM eval_results        WHERE session_id = OLD.id;
+    DELETE FROM messages            WHERE session_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Same window the initial migration seeds: 4 weeks back + 2 weeks ahead.
+SELECT create_weekly_partitions('message_annotations', (CURRENT_DATE - 28), (CURRENT_DATE + 14));
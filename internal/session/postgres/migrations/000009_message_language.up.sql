@@ -0,0 +1,8 @@
+-- Message language. session-api detects the language of each message on
+-- ingest and records it under metadata.language (a BCP 47 base code); the
+-- per-language breakdown groups on it. Older messages simply lack the key.
+--
+-- messages is partitioned by "timestamp"; indexes on the parent cascade to
+-- every partition.
+CREATE INDEX idx_messages_language ON messages ((metadata ->> 'language'), "timestamp")
+    WHERE metadata ? 'language';
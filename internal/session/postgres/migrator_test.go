@@ -141,8 +141,12 @@ func TestMigrationFS_ContainsMigrations(t *testing.T) {
 	require.NoError(t, err)
 	// 000001: consolidated initial schema; 000002: drop user_privacy_preferences;
 	// 000003: audit_log.forwarded_at for the privacy-api audit drain-forwarder (#1673);
-	// 000004: drop deletion_requests (DSAR moved to privacy-api, #1676).
-	assert.Len(t, entries, 8, "should have exactly 8 migration files (4 up + 4 down)")
+	// 000004: drop deletion_requests (DSAR moved to privacy-api, #1676);
+	// 000005: message compression columns and dictionaries;
+	// 000006: session lifecycle statuses and the idle reaper index;
+	// 000007: session fork parent links; 000008: message annotations;
+	// 000009: message language index.
+	assert.Len(t, entries, 18, "should have exactly 18 migration files (9 up + 9 down)")
 
 	// Verify expected migration files exist
 	expected := []string{
@@ -154,6 +158,16 @@ func TestMigrationFS_ContainsMigrations(t *testing.T) {
 		"000003_audit_forwarded_at.down.sql",
 		"000004_drop_deletion_requests.up.sql",
 		"000004_drop_deletion_requests.down.sql",
+		"000005_message_compression.up.sql",
+		"000005_message_compression.down.sql",
+		"000006_session_lifecycle.up.sql",
+		"000006_session_lifecycle.down.sql",
+		"000007_session_forks.up.sql",
+		"000007_session_forks.down.sql",
+		"000008_message_annotations.up.sql",
+		"000008_message_annotations.down.sql",
+		"000009_message_language.up.sql",
+		"000009_message_language.down.sql",
 	}
 	names := make(map[string]bool)
 	for _, e := range entries {
@@ -567,6 +581,7 @@ func TestMigrator_PartitionOrchestratorCoversAllPartitionedTables(t *testing.T)
 		"message_artifacts",
 		"audit_log",
 		"eval_results",
+		"message_annotations",
 	}
 	for _, name := range want {
 		_, ok := got[name]
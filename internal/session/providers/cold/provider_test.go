@@ -381,6 +381,45 @@ func TestWriteGetSession(t *testing.T) {
 	}
 }
 
+// TestWriteGetSession_Attachments verifies attachment references survive
+// archiving, so archived transcripts can still resolve their files.
+func TestWriteGetSession_Attachments(t *testing.T) {
+	ctx := context.Background()
+	p, _ := newTestProvider(t)
+
+	now := time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC)
+	s := makeSession("sess-1", "agent-a", "default", now)
+	s.Messages[0].HasMedia = true
+	s.Messages[0].Attachments = []session.Artifact{{
+		ID:         "att-1",
+		MessageID:  "msg-1",
+		SessionID:  "sess-1",
+		Type:       "document",
+		MIMEType:   "application/pdf",
+		StorageURI: "omnia://sessions/sess-1/media/m-1",
+		SizeBytes:  2048,
+		Checksum:   "abc123",
+		CreatedAt:  now,
+	}}
+
+	if err := p.WriteParquet(ctx, []*session.Session{s}, providers.WriteOpts{}); err != nil {
+		t.Fatalf("WriteParquet: %v", err)
+	}
+	got, err := p.GetSession(ctx, "sess-1")
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+
+	if len(got.Messages[0].Attachments) != 1 {
+		t.Fatalf("Attachments: got %d, want 1", len(got.Messages[0].Attachments))
+	}
+	a := got.Messages[0].Attachments[0]
+	if a.StorageURI != "omnia://sessions/sess-1/media/m-1" || a.MIMEType != "application/pdf" ||
+		a.SizeBytes != 2048 || a.Checksum != "abc123" {
+		t.Errorf("Attachment: got %+v", a)
+	}
+}
+
 // TestWriteParquet_MarshalMessagesError verifies WriteParquet fails (rather
 // than silently archiving an empty history) when messages cannot be
 // serialized.
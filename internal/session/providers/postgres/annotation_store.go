@@ -0,0 +1,297 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/altairalabs/omnia/internal/pgutil"
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/api"
+)
+
+// Compile-time interface check.
+var _ api.AnnotationStore = (*AnnotationStoreImpl)(nil)
+
+// AnnotationStoreImpl implements api.AnnotationStore using PostgreSQL.
+// namespace + agent_name are denormalized onto message_annotations, so list
+// and aggregate queries filter directly without JOINing sessions.
+type AnnotationStoreImpl struct {
+	pool    *pgxpool.Pool
+	replica *pgxpool.Pool
+}
+
+// NewAnnotationStore creates a new AnnotationStoreImpl from an existing
+// connection pool.
+func NewAnnotationStore(pool *pgxpool.Pool) *AnnotationStoreImpl {
+	return &AnnotationStoreImpl{pool: pool}
+}
+
+const annotationColumns = `id, session_id, message_id, namespace, agent_name, source,
+	author, score, labels, correction, created_at`
+
+// Filter fragments. The QueryBuilder substitutes the `$?` placeholder with
+// the appropriate positional argument index.
+const (
+	maFilterNamespace     = "ma.namespace=$?"
+	maFilterAgentName     = "ma.agent_name=$?"
+	maFilterSource        = "ma.source=$?"
+	maFilterLabel         = "$? = ANY(ma.labels)"
+	maFilterHasCorrection = "ma.correction IS NOT NULL"
+	maFilterCreatedAfter  = "ma.created_at >= $?"
+	maFilterCreatedBefore = "ma.created_at < $?"
+)
+
+// RecordAnnotation stores a against its message. The session supplies the
+// namespace and agent; the INSERT only happens when the message belongs to
+// the session.
+func (s *AnnotationStoreImpl) RecordAnnotation(ctx context.Context, a *api.MessageAnnotation) error {
+	err := s.pool.QueryRow(ctx, "SELECT namespace, agent_name FROM sessions WHERE id=$1", a.SessionID).
+		Scan(&a.Namespace, &a.AgentName)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return session.ErrSessionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("postgres: record annotation: %w", err)
+	}
+
+	labels := a.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	err = s.pool.QueryRow(ctx, `
+		INSERT INTO message_annotations (
+			session_id, message_id, namespace, agent_name, source, author, score, labels, correction)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9
+		WHERE EXISTS (SELECT 1 FROM messages WHERE session_id=$1 AND id=$2)
+		RETURNING id, created_at`,
+		a.SessionID, a.MessageID, a.Namespace, a.AgentName, a.Source,
+		pgutil.NullString(a.Author), a.Score, labels, pgutil.NullString(a.Correction),
+	).Scan(&a.ID, &a.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return session.ErrMessageNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("postgres: record annotation: %w", err)
+	}
+	return nil
+}
+
+// ListSessionAnnotations returns a session's annotations, oldest first.
+func (s *AnnotationStoreImpl) ListSessionAnnotations(ctx context.Context, sessionID string) ([]*api.MessageAnnotation, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+annotationColumns+` FROM message_annotations WHERE session_id=$1 ORDER BY created_at, id`,
+		sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list session annotations: %w", err)
+	}
+	return collectAnnotations(rows)
+}
+
+// ListAnnotations returns a page of a namespace's annotations, newest first,
+// and the total number matching opts.
+func (s *AnnotationStoreImpl) ListAnnotations(ctx context.Context, opts api.AnnotationListOpts) ([]*api.MessageAnnotation, int64, error) {
+	if opts.Namespace == "" {
+		return nil, 0, fmt.Errorf("postgres: list annotations: namespace is required")
+	}
+	qb := buildAnnotationFilters(opts.Namespace, opts.AgentName, opts.Source, opts.From, opts.To)
+	if opts.Label != "" {
+		qb.Add(maFilterLabel, opts.Label)
+	}
+	if opts.HasCorrection {
+		qb.AddRaw(maFilterHasCorrection)
+	}
+
+	var total int64
+	countQuery := `SELECT count(*) FROM message_annotations ma WHERE 1=1` + qb.Where()
+	if err := s.reader(ctx).QueryRow(ctx, countQuery, qb.Args()...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("postgres: count annotations: %w", err)
+	}
+
+	query := `SELECT ` + annotationColumns + ` FROM message_annotations ma WHERE 1=1` + qb.Where() +
+		` ORDER BY created_at DESC, id`
+	query = qb.AppendPagination(query, opts.Limit, opts.Offset)
+	rows, err := s.reader(ctx).Query(ctx, query, qb.Args()...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("postgres: list annotations: %w", err)
+	}
+	annotations, err := collectAnnotations(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return annotations, total, nil
+}
+
+// AggregateAnnotations runs a namespace-scoped GROUP BY over
+// message_annotations. Powers /api/v1/annotations/aggregate: feedback rates
+// and label counts per agent or over time.
+func (s *AnnotationStoreImpl) AggregateAnnotations(
+	ctx context.Context, opts api.AnnotationAggregateOpts,
+) ([]*api.AnnotationAggregateRow, error) {
+	if opts.Namespace == "" {
+		return nil, fmt.Errorf("postgres: aggregate annotations: namespace is required")
+	}
+
+	keyExpr, orderClause, from, err := annotationGroupByFragments(opts.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+	valueExpr, err := annotationMetricExpression(opts.Metric)
+	if err != nil {
+		return nil, err
+	}
+
+	qb := buildAnnotationFilters(opts.Namespace, opts.AgentName, opts.Source, opts.From, opts.To)
+	query := fmt.Sprintf(`
+		SELECT %s AS key, %s AS value, COUNT(*) AS count
+		FROM %s
+		WHERE 1=1%s
+		GROUP BY 1
+		%s
+		LIMIT %d`,
+		keyExpr, valueExpr, from, qb.Where(), orderClause, clampAnnotationAggregateLimit(opts.Limit))
+
+	rows, err := s.reader(ctx).Query(ctx, query, qb.Args()...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: aggregate annotations: %w", err)
+	}
+	defer rows.Close()
+
+	out := []*api.AnnotationAggregateRow{}
+	for rows.Next() {
+		var r api.AnnotationAggregateRow
+		var v *float64
+		if err := rows.Scan(&r.Key, &v, &r.Count); err != nil {
+			return nil, fmt.Errorf("postgres: scan annotation aggregate row: %w", err)
+		}
+		if v != nil {
+			r.Value = *v
+		}
+		out = append(out, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: iterate annotation aggregate rows: %w", err)
+	}
+	return out, nil
+}
+
+// buildAnnotationFilters seeds a QueryBuilder with the required namespace
+// filter plus the optional filters shared by list and aggregate.
+func buildAnnotationFilters(namespace, agentName, source string, from, to time.Time) *pgutil.QueryBuilder {
+	qb := &pgutil.QueryBuilder{}
+	qb.Add(maFilterNamespace, namespace)
+	if agentName != "" {
+		qb.Add(maFilterAgentName, agentName)
+	}
+	if source != "" {
+		qb.Add(maFilterSource, source)
+	}
+	if !from.IsZero() {
+		qb.Add(maFilterCreatedAfter, from)
+	}
+	if !to.IsZero() {
+		qb.Add(maFilterCreatedBefore, to)
+	}
+	return qb
+}
+
+// clampAnnotationAggregateLimit applies defaults / ceiling to a
+// caller-supplied limit.
+func clampAnnotationAggregateLimit(limit int) int {
+	if limit <= 0 {
+		return api.DefaultAnnotationAggregateLimit
+	}
+	return min(limit, api.MaxAnnotationAggregateLimit)
+}
+
+// annotationGroupByFragments builds the composite key expression, ORDER BY
+// clause and FROM clause for one or more groupBy dimensions. Grouping by
+// label unnests the labels array, so each label of an annotation counts.
+func annotationGroupByFragments(gs []api.AnnotationAggregateGroupBy) (keyExpr, orderClause, from string, err error) {
+	if len(gs) == 0 {
+		return "", "", "", fmt.Errorf("postgres: groupBy is required")
+	}
+	from = "message_annotations ma"
+	segments := make([]string, 0, len(gs))
+	anyTime := false
+	for _, g := range gs {
+		switch g {
+		case api.AnnotationAggregateGroupByAgent:
+			segments = append(segments, "ma.agent_name")
+		case api.AnnotationAggregateGroupBySource:
+			segments = append(segments, "ma.source")
+		case api.AnnotationAggregateGroupByLabel:
+			segments = append(segments, "l.label")
+			from = "message_annotations ma CROSS JOIN LATERAL unnest(ma.labels) AS l(label)"
+		case api.AnnotationAggregateGroupByTimeHour:
+			segments = append(segments, "to_char(date_trunc('hour', ma.created_at) AT TIME ZONE 'UTC', 'YYYY-MM-DD\"T\"HH24:00:00\"Z\"')")
+			anyTime = true
+		case api.AnnotationAggregateGroupByTimeDay:
+			segments = append(segments, "to_char(date_trunc('day', ma.created_at)::date, 'YYYY-MM-DD')")
+			anyTime = true
+		default:
+			return "", "", "", fmt.Errorf("postgres: invalid groupBy %q", g)
+		}
+	}
+	keyExpr = strings.Join(segments, " || '|' || ")
+	if anyTime {
+		return keyExpr, pcOrderByKeyAsc, from, nil
+	}
+	return keyExpr, pcOrderByValueDesc, from, nil
+}
+
+// annotationMetricExpression returns the SQL value expression for a metric.
+// Counts are cast to float so all metrics scan into the same *float64 type.
+func annotationMetricExpression(m api.AnnotationAggregateMetric) (string, error) {
+	switch m {
+	case api.AnnotationAggregateMetricCount:
+		return "COUNT(*)::float", nil
+	case api.AnnotationAggregateMetricThumbsUp:
+		return "(COUNT(*) FILTER (WHERE ma.score = 1))::float", nil
+	case api.AnnotationAggregateMetricThumbsDown:
+		return "(COUNT(*) FILTER (WHERE ma.score = -1))::float", nil
+	case api.AnnotationAggregateMetricAvgScore:
+		return "AVG(ma.score)::float", nil
+	case api.AnnotationAggregateMetricCorrections:
+		return "(COUNT(*) FILTER (WHERE ma.correction IS NOT NULL))::float", nil
+	default:
+		return "", fmt.Errorf("postgres: invalid metric %q", m)
+	}
+}
+
+// collectAnnotations scans annotation rows and closes rows.
+func collectAnnotations(rows pgx.Rows) ([]*api.MessageAnnotation, error) {
+	defer rows.Close()
+	var out []*api.MessageAnnotation
+	for rows.Next() {
+		var a api.MessageAnnotation
+		var author, correction *string
+		var score *int16
+		if err := rows.Scan(&a.ID, &a.SessionID, &a.MessageID, &a.Namespace, &a.AgentName, &a.Source,
+			&author, &score, &a.Labels, &correction, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("postgres: scan annotation: %w", err)
+		}
+		a.Author = pgutil.DerefString(author)
+		a.Correction = pgutil.DerefString(correction)
+		if score != nil {
+			v := int(*score)
+			a.Score = &v
+		}
+		out = append(out, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: iterate annotations: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 Altaira Labs.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/api"
+)
+
+func TestAnnotationStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	pool := freshDB(t)
+	p := NewFromPool(pool)
+	store := NewAnnotationStore(pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	s := makeSession(uuid.NewString(), now)
+	require.NoError(t, p.CreateSession(ctx, s))
+	msg := makeMessage(uuid.NewString(), 1, now)
+	require.NoError(t, p.AppendMessage(ctx, s.ID, msg))
+
+	up, down := 1, -1
+	thumbsUp := &api.MessageAnnotation{SessionID: s.ID, MessageID: msg.ID, Source: api.AnnotationSourceUser, Score: &up}
+	require.NoError(t, store.RecordAnnotation(ctx, thumbsUp))
+	assert.NotEmpty(t, thumbsUp.ID)
+	assert.Equal(t, s.Namespace, thumbsUp.Namespace)
+	assert.Equal(t, s.AgentName, thumbsUp.AgentName)
+
+	review := &api.MessageAnnotation{
+		SessionID: s.ID, MessageID: msg.ID, Source: api.AnnotationSourceReviewer, Author: "qa",
+		Score: &down, Labels: []string{"wrong-answer", "tone"}, Correction: "Refunds take 30 days.",
+	}
+	require.NoError(t, store.RecordAnnotation(ctx, review))
+
+	got, err := store.ListSessionAnnotations(ctx, s.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, thumbsUp.ID, got[0].ID)
+	assert.Equal(t, []string{"wrong-answer", "tone"}, got[1].Labels)
+	assert.Equal(t, "Refunds take 30 days.", got[1].Correction)
+	require.NotNil(t, got[1].Score)
+	assert.Equal(t, -1, *got[1].Score)
+
+	// Eval dataset generation: annotations carrying a correction.
+	list, total, err := store.ListAnnotations(ctx, api.AnnotationListOpts{
+		Namespace: s.Namespace, Label: "wrong-answer", HasCorrection: true, Limit: 10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, list, 1)
+	assert.Equal(t, review.ID, list[0].ID)
+
+	rows, err := store.AggregateAnnotations(ctx, api.AnnotationAggregateOpts{
+		Namespace: s.Namespace,
+		GroupBy:   []api.AnnotationAggregateGroupBy{api.AnnotationAggregateGroupByAgent},
+		Metric:    api.AnnotationAggregateMetricAvgScore,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, s.AgentName, rows[0].Key)
+	assert.InDelta(t, 0, rows[0].Value, 1e-9)
+	assert.Equal(t, int64(2), rows[0].Count)
+
+	rows, err = store.AggregateAnnotations(ctx, api.AnnotationAggregateOpts{
+		Namespace: s.Namespace,
+		GroupBy:   []api.AnnotationAggregateGroupBy{api.AnnotationAggregateGroupByLabel},
+		Metric:    api.AnnotationAggregateMetricCount,
+	})
+	require.NoError(t, err)
+	assert.Len(t, rows, 2, "one row per label; unlabelled annotations are left out")
+
+	// Deleting the session deletes its annotations.
+	require.NoError(t, p.DeleteSession(ctx, s.ID))
+	got, err = store.ListSessionAnnotations(ctx, s.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestAnnotationStore_RecordNotFound(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	pool := freshDB(t)
+	p := NewFromPool(pool)
+	store := NewAnnotationStore(pool)
+
+	up := 1
+	a := &api.MessageAnnotation{SessionID: uuid.NewString(), MessageID: uuid.NewString(), Source: api.AnnotationSourceUser, Score: &up}
+	assert.ErrorIs(t, store.RecordAnnotation(ctx, a), session.ErrSessionNotFound)
+
+	s := makeSession(uuid.NewString(), time.Now().UTC())
+	require.NoError(t, p.CreateSession(ctx, s))
+	a.SessionID = s.ID
+	assert.ErrorIs(t, store.RecordAnnotation(ctx, a), session.ErrMessageNotFound)
+}
@@ -0,0 +1,376 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/klauspost/compress/dict"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/altairalabs/omnia/internal/session"
+)
+
+// Message compression tuning.
+const (
+	// DefaultCompressionMinBytes is the smallest tool output compressed when
+	// compression is enabled without an explicit threshold.
+	DefaultCompressionMinBytes = 4 << 10
+
+	// compressionDictMaxBytes caps the size of a trained dictionary.
+	compressionDictMaxBytes = 64 << 10
+	// compressionDictHashBytes is the shortest match the dictionary builder indexes.
+	compressionDictHashBytes = 6
+	// compressionDictSamples is how many recent tool outputs a namespace's
+	// dictionary is trained on.
+	compressionDictSamples = 1000
+	// compressionDictMinSamples is the fewest samples worth training on; a
+	// namespace with fewer compresses without a dictionary.
+	compressionDictMinSamples = 20
+	// compressionDictMaxAge is how old a namespace's newest dictionary gets
+	// before TrainCompressionDictionaries replaces it.
+	compressionDictMaxAge = 7 * 24 * time.Hour
+	// compressionDictRefresh bounds how long a replica keeps compressing with
+	// a dictionary after another replica trained a newer one.
+	compressionDictRefresh = 10 * time.Minute
+
+	// encryptionMetadataKey marks messages encrypted by session-api. Their
+	// content is ciphertext, which does not compress.
+	encryptionMetadataKey = "_encryption"
+)
+
+// messageCodec compresses and decompresses message bodies. Decompression is
+// always available, so compressed rows stay readable after compression is
+// switched off; compression only happens once minBytes is set.
+type messageCodec struct {
+	pool     *pgxpool.Pool
+	minBytes int
+
+	mu       sync.Mutex
+	encoders map[string]*namespaceEncoder
+	decoders map[int64]*zstd.Decoder
+}
+
+// namespaceEncoder is the encoder for a namespace's newest dictionary.
+// dictID is nil when the namespace has no dictionary yet.
+type namespaceEncoder struct {
+	dictID   *int64
+	enc      *zstd.Encoder
+	loadedAt time.Time
+}
+
+func newMessageCodec(pool *pgxpool.Pool) *messageCodec {
+	return &messageCodec{
+		pool:     pool,
+		encoders: map[string]*namespaceEncoder{},
+		decoders: map[int64]*zstd.Decoder{},
+	}
+}
+
+// SetMessageCompression stores tool outputs of at least minBytes compressed
+// with zstd, using the session namespace's trained dictionary when it has
+// one. Zero or less disables compression of new messages; compressed rows
+// are decompressed on read either way.
+func (p *Provider) SetMessageCompression(minBytes int) {
+	p.codec.minBytes = minBytes
+}
+
+// compressible reports whether msg is stored compressed: a tool output at or
+// above the threshold that session-api has not encrypted. Only messages with a
+// tool call ID qualify, since the session's last-message preview is never
+// taken from them.
+func (c *messageCodec) compressible(msg *session.Message) bool {
+	if c.minBytes <= 0 || msg.ToolCallID == "" || len(msg.Content) < c.minBytes {
+		return false
+	}
+	_, encrypted := msg.Metadata[encryptionMetadataKey]
+	return !encrypted
+}
+
+// compress returns content as a zstd frame and the dictionary it used.
+func (c *messageCodec) compress(ctx context.Context, namespace, content string) ([]byte, *int64, error) {
+	ne, err := c.encoder(ctx, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ne.enc.EncodeAll([]byte(content), nil), ne.dictID, nil
+}
+
+// decompress reverses compress. A nil dictID means the frame was compressed
+// without a dictionary.
+func (c *messageCodec) decompress(ctx context.Context, data []byte, dictID *int64) (string, error) {
+	var id int64
+	if dictID != nil {
+		id = *dictID
+	}
+	dec, err := c.decoder(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	out, err := dec.DecodeAll(data, nil)
+	if err != nil {
+		return "", fmt.Errorf("postgres: decompress message: %w", err)
+	}
+	return string(out), nil
+}
+
+// encoder returns the encoder for namespace's newest dictionary, reloading it
+// once compressionDictRefresh has passed.
+func (c *messageCodec) encoder(ctx context.Context, namespace string) (*namespaceEncoder, error) {
+	c.mu.Lock()
+	ne, ok := c.encoders[namespace]
+	c.mu.Unlock()
+	if ok && time.Since(ne.loadedAt) < compressionDictRefresh {
+		return ne, nil
+	}
+
+	var id int64
+	var raw []byte
+	err := c.pool.QueryRow(ctx, `SELECT id, dict FROM message_compression_dictionaries
+		WHERE namespace = $1 ORDER BY created_at DESC LIMIT 1`, namespace).Scan(&id, &raw)
+	opts := []zstd.EOption{zstd.WithEncoderConcurrency(1)}
+	fresh := &namespaceEncoder{loadedAt: time.Now()}
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		return nil, fmt.Errorf("postgres: load compression dictionary: %w", err)
+	default:
+		fresh.dictID = &id
+		opts = append(opts, zstd.WithEncoderDict(raw))
+	}
+	if ok && sameDict(ne.dictID, fresh.dictID) {
+		fresh.enc = ne.enc
+	} else if fresh.enc, err = zstd.NewWriter(nil, opts...); err != nil {
+		return nil, fmt.Errorf("postgres: compression encoder: %w", err)
+	}
+
+	c.mu.Lock()
+	c.encoders[namespace] = fresh
+	c.mu.Unlock()
+	return fresh, nil
+}
+
+// decoder returns a decoder for dictionary id, loading the dictionary on
+// first use. Dictionaries never change once stored, so decoders are kept.
+func (c *messageCodec) decoder(ctx context.Context, id int64) (*zstd.Decoder, error) {
+	c.mu.Lock()
+	dec, ok := c.decoders[id]
+	c.mu.Unlock()
+	if ok {
+		return dec, nil
+	}
+
+	opts := []zstd.DOption{zstd.WithDecoderConcurrency(0)}
+	if id != 0 {
+		var raw []byte
+		err := c.pool.QueryRow(ctx,
+			`SELECT dict FROM message_compression_dictionaries WHERE id = $1`, id).Scan(&raw)
+		if err != nil {
+			return nil, fmt.Errorf("postgres: load compression dictionary %d: %w", id, err)
+		}
+		opts = append(opts, zstd.WithDecoderDicts(raw))
+	}
+	dec, err := zstd.NewReader(nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: compression decoder: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.decoders[id]; ok {
+		dec.Close()
+		return existing, nil
+	}
+	c.decoders[id] = dec
+	return dec, nil
+}
+
+// forget drops namespace's cached encoder so the next write loads its newest
+// dictionary.
+func (c *messageCodec) forget(namespace string) {
+	c.mu.Lock()
+	delete(c.encoders, namespace)
+	c.mu.Unlock()
+}
+
+func sameDict(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// buildDictionary trains a zstd dictionary on samples. The dictionary's ID is
+// chosen by the builder and read back for storage.
+func buildDictionary(samples [][]byte) ([]byte, int64, error) {
+	raw, err := dict.BuildZstdDict(samples, dict.Options{
+		MaxDictSize: compressionDictMaxBytes,
+		HashBytes:   compressionDictHashBytes,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("postgres: build compression dictionary: %w", err)
+	}
+	info, err := zstd.InspectDictionary(raw)
+	if err != nil {
+		return nil, 0, fmt.Errorf("postgres: inspect compression dictionary: %w", err)
+	}
+	return raw, int64(info.ID()), nil
+}
+
+// TrainCompressionDictionaries trains a dictionary for every namespace whose
+// newest dictionary is missing or older than a week, from its most recent
+// tool outputs at or above the compression threshold. It returns the number
+// of dictionaries stored. Older dictionaries are kept, because messages
+// compressed with them still reference them.
+func (p *Provider) TrainCompressionDictionaries(ctx context.Context) (int, error) {
+	if p.codec.minBytes <= 0 {
+		return 0, nil
+	}
+	rows, err := p.pool.Query(ctx, `SELECT DISTINCT s.namespace FROM sessions s
+		WHERE s.created_at > now() - make_interval(secs => $1)
+			AND NOT EXISTS (SELECT 1 FROM message_compression_dictionaries d
+				WHERE d.namespace = s.namespace AND d.created_at > now() - make_interval(secs => $1))`,
+		compressionDictMaxAge.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("postgres: list namespaces for compression: %w", err)
+	}
+	namespaces, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, fmt.Errorf("postgres: list namespaces for compression: %w", err)
+	}
+
+	trained := 0
+	for _, ns := range namespaces {
+		ok, err := p.trainNamespaceDictionary(ctx, ns)
+		if err != nil {
+			return trained, err
+		}
+		if ok {
+			trained++
+		}
+	}
+	return trained, nil
+}
+
+// trainNamespaceDictionary trains and stores one namespace's dictionary. It
+// returns false when the namespace has too few tool outputs to train on.
+func (p *Provider) trainNamespaceDictionary(ctx context.Context, namespace string) (bool, error) {
+	rows, err := p.pool.Query(ctx, `SELECT m.content, m.content_zstd, m.content_dict_id
+		FROM messages m JOIN sessions s ON s.id = m.session_id
+		WHERE s.namespace = $1 AND m.tool_call_id IS NOT NULL
+			AND NOT m.metadata ? '`+encryptionMetadataKey+`'
+			AND (m.content_zstd IS NOT NULL OR octet_length(m.content) >= $2)
+		ORDER BY m."timestamp" DESC
+		LIMIT $3`, namespace, p.codec.minBytes, compressionDictSamples)
+	if err != nil {
+		return false, fmt.Errorf("postgres: sample tool outputs: %w", err)
+	}
+	type sample struct {
+		content string
+		data    []byte
+		dictID  *int64
+	}
+	stored, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (sample, error) {
+		var s sample
+		err := row.Scan(&s.content, &s.data, &s.dictID)
+		return s, err
+	})
+	if err != nil {
+		return false, fmt.Errorf("postgres: scan tool output samples: %w", err)
+	}
+
+	samples := make([][]byte, 0, len(stored))
+	for _, s := range stored {
+		if s.data != nil {
+			if s.content, err = p.codec.decompress(ctx, s.data, s.dictID); err != nil {
+				return false, err
+			}
+		}
+		samples = append(samples, []byte(s.content))
+	}
+	if len(samples) < compressionDictMinSamples {
+		return false, nil
+	}
+
+	raw, id, err := buildDictionary(samples)
+	if err != nil {
+		return false, err
+	}
+	if _, err := p.pool.Exec(ctx, `INSERT INTO message_compression_dictionaries
+		(id, namespace, dict, sample_count) VALUES ($1, $2, $3, $4)`,
+		id, namespace, raw, len(samples)); err != nil {
+		return false, fmt.Errorf("postgres: store compression dictionary: %w", err)
+	}
+	p.codec.forget(namespace)
+	return true, nil
+}
+
+// RecompressMessages compresses up to batchSize stored tool outputs that are
+// still plain text, such as those written before compression was enabled,
+// and returns how many it rewrote. Callers repeat it until it returns fewer
+// than batchSize.
+func (p *Provider) RecompressMessages(ctx context.Context, batchSize int) (int, error) {
+	if p.codec.minBytes <= 0 {
+		return 0, nil
+	}
+	rows, err := p.pool.Query(ctx, `SELECT m.id, m."timestamp", m.content, s.namespace
+		FROM messages m JOIN sessions s ON s.id = m.session_id
+		WHERE m.content_zstd IS NULL AND m.tool_call_id IS NOT NULL
+			AND NOT m.metadata ? '`+encryptionMetadataKey+`'
+			AND octet_length(m.content) >= $1
+		LIMIT $2`, p.codec.minBytes, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: select messages to compress: %w", err)
+	}
+	type plainMessage struct {
+		id        string
+		ts        time.Time
+		content   string
+		namespace string
+	}
+	msgs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (plainMessage, error) {
+		var m plainMessage
+		err := row.Scan(&m.id, &m.ts, &m.content, &m.namespace)
+		return m, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("postgres: scan messages to compress: %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	for _, m := range msgs {
+		data, dictID, err := p.codec.compress(ctx, m.namespace, m.content)
+		if err != nil {
+			return 0, err
+		}
+		batch.Queue(`UPDATE messages SET content = '', content_zstd = $1, content_dict_id = $2
+			WHERE id = $3 AND "timestamp" = $4 AND content_zstd IS NULL`, data, dictID, m.id, m.ts)
+	}
+	if batch.Len() == 0 {
+		return 0, nil
+	}
+	if err := p.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return 0, fmt.Errorf("postgres: compress messages: %w", err)
+	}
+	return len(msgs), nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+// toolOutput is a verbose, repetitive tool result like those that dominate
+// the warm store.
+func toolOutput(i int) string {
+	var b strings.Builder
+	for j := 0; j < 60; j++ {
+		fmt.Fprintf(&b, `{"order_id":"ord-%05d","status":"shipped","carrier":"ups","line":%d},`, i*100+j, j)
+	}
+	return b.String()
+}
+
+func TestMessageCodec_Compressible(t *testing.T) {
+	c := newMessageCodec(nil)
+	big := strings.Repeat("x", 100)
+	tool := &session.Message{Role: session.RoleAssistant, ToolCallID: "call-1", Content: big}
+
+	assert.False(t, c.compressible(tool), "compression is off until a threshold is set")
+
+	c.minBytes = 100
+	assert.True(t, c.compressible(tool))
+	assert.False(t, c.compressible(&session.Message{ToolCallID: "call-1", Content: big[:99]}), "below the threshold")
+	assert.False(t, c.compressible(&session.Message{Role: session.RoleAssistant, Content: big}), "not a tool output")
+	assert.False(t, c.compressible(&session.Message{
+		ToolCallID: "call-1", Content: big, Metadata: map[string]string{encryptionMetadataKey: "{}"},
+	}), "ciphertext is not compressed")
+}
+
+func TestMessageCodec_DecompressWithoutDictionary(t *testing.T) {
+	enc, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	frame := enc.EncodeAll([]byte(toolOutput(1)), nil)
+
+	got, err := newMessageCodec(nil).decompress(context.Background(), frame, nil)
+	require.NoError(t, err)
+	assert.Equal(t, toolOutput(1), got)
+}
+
+func TestBuildDictionary(t *testing.T) {
+	var samples [][]byte
+	for i := 0; i < compressionDictMinSamples; i++ {
+		samples = append(samples, []byte(toolOutput(i)))
+	}
+	raw, id, err := buildDictionary(samples)
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderDict(raw))
+	require.NoError(t, err)
+	plain, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	input := []byte(toolOutput(999))
+	frame := enc.EncodeAll(input, nil)
+	assert.Less(t, len(frame), len(plain.EncodeAll(input, nil)), "the dictionary should beat plain zstd")
+
+	dec, err := zstd.NewReader(nil, zstd.WithDecoderDicts(raw))
+	require.NoError(t, err)
+	out, err := dec.DecodeAll(frame, nil)
+	require.NoError(t, err)
+	assert.Equal(t, input, out)
+}
+
+func TestMessageCompression_RoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	p := newProvider(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	s := makeSession(uuid.New().String(), now)
+	require.NoError(t, p.CreateSession(ctx, s))
+
+	appendTool := func(seq int32) *session.Message {
+		msg := &session.Message{
+			ID: uuid.New().String(), Role: session.RoleAssistant, ToolCallID: fmt.Sprintf("call-%d", seq),
+			Content: toolOutput(int(seq)), Timestamp: now, SequenceNum: seq,
+		}
+		require.NoError(t, p.AppendMessage(ctx, s.ID, msg))
+		return msg
+	}
+
+	// Written before compression is enabled: stays plain until recompressed.
+	old := appendTool(1)
+
+	p.SetMessageCompression(1024)
+	for i := int32(2); i <= compressionDictMinSamples+1; i++ {
+		appendTool(i)
+	}
+	var plainRows int
+	require.NoError(t, p.pool.QueryRow(ctx,
+		`SELECT count(*) FROM messages WHERE content_zstd IS NULL`).Scan(&plainRows))
+	assert.Equal(t, 1, plainRows, "only the message written before compression is plain")
+
+	n, err := p.RecompressMessages(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	trained, err := p.TrainCompressionDictionaries(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, trained)
+	trained, err = p.TrainCompressionDictionaries(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, trained, "a fresh dictionary is not retrained")
+
+	latest := appendTool(compressionDictMinSamples + 2)
+	var dictID *int64
+	require.NoError(t, p.pool.QueryRow(ctx,
+		`SELECT content_dict_id FROM messages WHERE id = $1`, latest.ID).Scan(&dictID))
+	require.NotNil(t, dictID, "messages after training use the namespace dictionary")
+
+	// A provider that never enabled compression still reads every row.
+	reader := NewFromPool(p.pool)
+	msgs, err := reader.GetMessages(ctx, s.ID, providers.MessageQueryOpts{})
+	require.NoError(t, err)
+	require.Len(t, msgs, compressionDictMinSamples+2)
+	assert.Equal(t, old.Content, msgs[0].Content)
+	assert.Equal(t, latest.Content, msgs[len(msgs)-1].Content)
+}
@@ -37,7 +37,8 @@ var _ api.EvalStore = (*EvalStoreImpl)(nil)
 
 // EvalStoreImpl implements api.EvalStore using PostgreSQL.
 type EvalStoreImpl struct {
-	pool *pgxpool.Pool
+	pool    *pgxpool.Pool
+	replica *pgxpool.Pool
 }
 
 // NewEvalStore creates a new EvalStoreImpl from an existing connection pool.
@@ -109,7 +110,7 @@ func (s *EvalStoreImpl) InsertEvalResults(ctx context.Context, results []*api.Ev
 func (s *EvalStoreImpl) GetSessionEvalResults(ctx context.Context, sessionID string) ([]*api.EvalResult, error) {
 	query := `SELECT ` + evalResultColumns + ` FROM eval_results WHERE session_id=$1 ORDER BY created_at ASC`
 
-	rows, err := s.pool.Query(ctx, query, sessionID)
+	rows, err := s.reader(ctx).Query(ctx, query, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: get session eval results: %w", err)
 	}
@@ -123,7 +124,7 @@ func (s *EvalStoreImpl) ListEvalResults(ctx context.Context, opts api.EvalResult
 
 	countQuery := `SELECT count(*) FROM eval_results WHERE 1=1` + qb.Where()
 	var total int64
-	if err := s.pool.QueryRow(ctx, countQuery, qb.Args()...).Scan(&total); err != nil {
+	if err := s.reader(ctx).QueryRow(ctx, countQuery, qb.Args()...).Scan(&total); err != nil {
 		return nil, 0, fmt.Errorf("postgres: count eval results: %w", err)
 	}
 
@@ -131,7 +132,7 @@ func (s *EvalStoreImpl) ListEvalResults(ctx context.Context, opts api.EvalResult
 		` ORDER BY created_at DESC`
 	query = qb.AppendPagination(query, opts.Limit, opts.Offset)
 
-	rows, err := s.pool.Query(ctx, query, qb.Args()...)
+	rows, err := s.reader(ctx).Query(ctx, query, qb.Args()...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("postgres: list eval results: %w", err)
 	}
@@ -161,7 +162,7 @@ func (s *EvalStoreImpl) GetEvalResultSummary(ctx context.Context, opts api.EvalR
 	FROM eval_results WHERE 1=1` + qb.Where() +
 		` GROUP BY eval_id, eval_type ORDER BY eval_id`
 
-	rows, err := s.pool.Query(ctx, query, qb.Args()...)
+	rows, err := s.reader(ctx).Query(ctx, query, qb.Args()...)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: get eval result summary: %w", err)
 	}
@@ -216,7 +217,7 @@ func (s *EvalStoreImpl) AggregateEvalResults(ctx context.Context, opts api.EvalA
 		LIMIT %d`,
 		keyExpr, valueExpr, qb.Where(), orderClause, clampEvalAggregateLimit(opts.Limit))
 
-	rows, err := s.pool.Query(ctx, query, qb.Args()...)
+	rows, err := s.reader(ctx).Query(ctx, query, qb.Args()...)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: aggregate eval results: %w", err)
 	}
@@ -367,7 +368,7 @@ func (s *EvalStoreImpl) EvalDiscovery(ctx context.Context, namespace string) (*a
 
 // distinctEvals reads the (eval_id, eval_type) pairs for a namespace.
 func (s *EvalStoreImpl) distinctEvals(ctx context.Context, namespace string) ([]api.EvalDescriptor, error) {
-	rows, err := s.pool.Query(ctx, `
+	rows, err := s.reader(ctx).Query(ctx, `
 		SELECT DISTINCT eval_id, eval_type
 		FROM eval_results
 		WHERE namespace = $1
@@ -401,7 +402,7 @@ func (s *EvalStoreImpl) distinctStringColumn(ctx context.Context, namespace, col
 		FROM eval_results
 		WHERE namespace = $1 AND %s <> ''
 		ORDER BY 1`, column, column)
-	rows, err := s.pool.Query(ctx, q, namespace)
+	rows, err := s.reader(ctx).Query(ctx, q, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: distinct %s: %w", column, err)
 	}
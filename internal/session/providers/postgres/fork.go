@@ -0,0 +1,112 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/altairalabs/omnia/internal/pgutil"
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+var _ providers.SessionForker = (*Provider)(nil)
+
+// ForkSession creates fork with copies of its parent's messages up to and
+// including throughMessageID, and of their attachment references, in one
+// transaction. Copied messages get new IDs but keep their timestamps,
+// sequence numbers and stored (possibly compressed or encrypted) content.
+func (p *Provider) ForkSession(ctx context.Context, fork *session.Session, throughMessageID string) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres: fork session: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var exists bool
+	if err := tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM sessions WHERE id=$1)", fork.ParentSessionID).Scan(&exists); err != nil {
+		return fmt.Errorf("postgres: fork session: %w", err)
+	}
+	if !exists {
+		return session.ErrSessionNotFound
+	}
+
+	throughSeq := int32(math.MaxInt32)
+	if throughMessageID != "" {
+		err := tx.QueryRow(ctx, "SELECT sequence_num FROM messages WHERE session_id=$1 AND id=$2",
+			fork.ParentSessionID, throughMessageID).Scan(&throughSeq)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return session.ErrMessageNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("postgres: fork session: find message: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, insertSessionQuery, sessionInsertArgs(fork)...); err != nil {
+		return fmt.Errorf("postgres: fork session: %w", err)
+	}
+	// A copied message's ID is derived from the fork and the original ID, so
+	// its attachment references can be re-pointed without a lookup table.
+	if _, err := tx.Exec(ctx, `INSERT INTO messages (
+			id, session_id, role, content, "timestamp", input_tokens, output_tokens, cost_usd,
+			tool_call_id, metadata, sequence_num, has_media, media_types, content_zstd, content_dict_id)
+		SELECT md5($1::uuid::text || id::text)::uuid, $1, role, content, "timestamp", input_tokens, output_tokens, cost_usd,
+			tool_call_id, metadata, sequence_num, has_media, media_types, content_zstd, content_dict_id
+		FROM messages WHERE session_id = $2 AND sequence_num <= $3`,
+		fork.ID, fork.ParentSessionID, throughSeq); err != nil {
+		return fmt.Errorf("postgres: fork session: copy messages: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO message_artifacts (
+			id, message_id, session_id, artifact_type, mime_type, storage_uri, size_bytes, filename,
+			checksum_sha256, metadata, created_at, width, height, duration_ms, channels, sample_rate)
+		SELECT gen_random_uuid(), md5($1::uuid::text || a.message_id::text)::uuid, $1, a.artifact_type, a.mime_type,
+			a.storage_uri, a.size_bytes, a.filename, a.checksum_sha256, a.metadata, a.created_at,
+			a.width, a.height, a.duration_ms, a.channels, a.sample_rate
+		FROM message_artifacts a
+		JOIN messages m ON m.session_id = a.session_id AND m.id = a.message_id
+		WHERE a.session_id = $2 AND m.sequence_num <= $3`,
+		fork.ID, fork.ParentSessionID, throughSeq); err != nil {
+		return fmt.Errorf("postgres: fork session: copy attachments: %w", err)
+	}
+
+	// Mirror AppendMessage: messages with a tool call ID are not counted and
+	// do not set the preview.
+	var preview *string
+	err = tx.QueryRow(ctx, `UPDATE sessions SET
+			message_count = (SELECT count(*) FROM messages
+				WHERE session_id = $1 AND (tool_call_id IS NULL OR tool_call_id = '')),
+			last_message_preview = (SELECT LEFT(content, 200) FROM messages
+				WHERE session_id = $1 AND (tool_call_id IS NULL OR tool_call_id = '')
+				ORDER BY sequence_num DESC LIMIT 1)
+		WHERE id = $1
+		RETURNING message_count, last_message_preview`, fork.ID).Scan(&fork.MessageCount, &preview)
+	if err != nil {
+		return fmt.Errorf("postgres: fork session: count messages: %w", err)
+	}
+	fork.LastMessagePreview = pgutil.DerefString(preview)
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("postgres: fork session: %w", err)
+	}
+	return nil
+}
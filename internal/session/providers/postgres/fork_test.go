@@ -0,0 +1,104 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+func TestForkSession(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	p := newProvider(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	parent := makeSession(uuid.NewString(), now)
+	require.NoError(t, p.CreateSession(ctx, parent))
+	var ids []string
+	for i := int32(1); i <= 3; i++ {
+		msg := makeMessage(uuid.NewString(), i, now.Add(time.Duration(i)*time.Second))
+		msg.Content = fmt.Sprintf("turn %d", i)
+		require.NoError(t, p.AppendMessage(ctx, parent.ID, msg))
+		ids = append(ids, msg.ID)
+	}
+
+	fork := makeSession(uuid.NewString(), now)
+	fork.ParentSessionID = parent.ID
+	fork.ForkedFromMessageID = ids[1]
+	require.NoError(t, p.ForkSession(ctx, fork, ids[1]))
+	assert.Equal(t, int32(2), fork.MessageCount)
+	assert.Equal(t, "turn 2", fork.LastMessagePreview)
+
+	got, err := p.GetSession(ctx, fork.ID)
+	require.NoError(t, err)
+	assert.Equal(t, parent.ID, got.ParentSessionID)
+	assert.Equal(t, ids[1], got.ForkedFromMessageID)
+	assert.Equal(t, int32(2), got.MessageCount)
+
+	msgs, err := p.GetMessages(ctx, fork.ID, providers.MessageQueryOpts{})
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, "turn 1", msgs[0].Content)
+	assert.NotEqual(t, ids[0], msgs[0].ID, "copied messages get new IDs")
+
+	// The parent is untouched and lists the fork as a child.
+	parentMsgs, err := p.GetMessages(ctx, parent.ID, providers.MessageQueryOpts{})
+	require.NoError(t, err)
+	assert.Len(t, parentMsgs, 3)
+	page, err := p.ListSessions(ctx, providers.SessionListOpts{ParentSessionID: parent.ID})
+	require.NoError(t, err)
+	require.Len(t, page.Sessions, 1)
+	assert.Equal(t, fork.ID, page.Sessions[0].ID)
+
+	// Without a message the whole history is copied.
+	whole := makeSession(uuid.NewString(), now)
+	whole.ParentSessionID = parent.ID
+	require.NoError(t, p.ForkSession(ctx, whole, ""))
+	assert.Equal(t, int32(3), whole.MessageCount)
+}
+
+func TestForkSession_NotFound(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	p := newProvider(t)
+	now := time.Now().UTC()
+
+	fork := makeSession(uuid.NewString(), now)
+	fork.ParentSessionID = uuid.NewString()
+	assert.ErrorIs(t, p.ForkSession(ctx, fork, ""), session.ErrSessionNotFound)
+
+	parent := makeSession(uuid.NewString(), now)
+	require.NoError(t, p.CreateSession(ctx, parent))
+	fork.ParentSessionID = parent.ID
+	assert.ErrorIs(t, p.ForkSession(ctx, fork, uuid.NewString()), session.ErrMessageNotFound)
+	_, err := p.GetSession(ctx, fork.ID)
+	assert.ErrorIs(t, err, session.ErrSessionNotFound, "a failed fork leaves nothing behind")
+}
@@ -0,0 +1,328 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/altairalabs/omnia/pkg/logctx"
+)
+
+// Warm store statement and pool metric name constants.
+const (
+	metricQueryDuration       = "omnia_session_api_warm_store_query_duration_seconds"
+	metricSlowQueries         = "omnia_session_api_warm_store_slow_queries_total"
+	metricPoolMaxConns        = "omnia_session_api_warm_store_pool_max_connections"
+	metricPoolConns           = "omnia_session_api_warm_store_pool_connections"
+	metricPoolAcquires        = "omnia_session_api_warm_store_pool_acquires_total"
+	metricPoolEmptyAcquires   = "omnia_session_api_warm_store_pool_empty_acquires_total"
+	metricPoolCanceled        = "omnia_session_api_warm_store_pool_canceled_acquires_total"
+	metricPoolEmptyAcquireSec = "omnia_session_api_warm_store_pool_empty_acquire_wait_seconds_total"
+)
+
+// Query status label values.
+const (
+	queryStatusSuccess = "success"
+	queryStatusError   = "error"
+)
+
+// familyOther is the family of statements whose verb is not recognised.
+const familyOther = "other"
+
+// maxLoggedSQLLen caps the statement text in slow-query log lines.
+const maxLoggedSQLLen = 1024
+
+// DefaultQueryDurationBuckets are histogram buckets for warm store statement
+// durations, from index lookups to full partition scans.
+var DefaultQueryDurationBuckets = []float64{
+	0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10,
+}
+
+// familyVerbs are the leading SQL keywords kept as a family verb. Anything
+// else is reported as familyOther so the label set stays bounded.
+var familyVerbs = map[string]bool{
+	"select": true, "insert": true, "update": true, "delete": true, "with": true,
+	"create": true, "drop": true, "alter": true, "truncate": true, "copy": true,
+	"lock": true, "begin": true, "commit": true, "rollback": true, "set": true,
+	"show": true, "vacuum": true, "analyze": true,
+}
+
+var (
+	// verbRe matches the leading keyword of a statement.
+	verbRe = regexp.MustCompile(`^\s*([a-zA-Z]+)`)
+	// tableRe matches the first table a statement reads or writes.
+	tableRe = regexp.MustCompile(`(?i)\b(?:from|into|update|table)\s+(?:if\s+(?:not\s+)?exists\s+)?(?:only\s+)?` +
+		`"?([a-zA-Z_][a-zA-Z0-9_.]*)"?`)
+	// partitionSuffixRe matches the weekly partition suffix (<table>_wYYYY_WW)
+	// so partitions report under their parent table.
+	partitionSuffixRe = regexp.MustCompile(`_w\d{4}_\d{2}$`)
+	// whitespaceRe collapses runs of whitespace in logged statements.
+	whitespaceRe = regexp.MustCompile(`\s+`)
+)
+
+// QueryFamily reduces a statement to a low-cardinality "<verb>_<table>" label,
+// e.g. "select_sessions" or "insert_messages". Parameters are never part of
+// the family because statements carry them as $n placeholders.
+func QueryFamily(sql string) string {
+	m := verbRe.FindStringSubmatch(sql)
+	if m == nil {
+		return familyOther
+	}
+	verb := strings.ToLower(m[1])
+	if !familyVerbs[verb] {
+		return familyOther
+	}
+	t := tableRe.FindStringSubmatch(sql)
+	if t == nil {
+		return verb
+	}
+	table := strings.ToLower(t[1])
+	if i := strings.LastIndexByte(table, '.'); i >= 0 {
+		table = table[i+1:]
+	}
+	return verb + "_" + partitionSuffixRe.ReplaceAllString(table, "")
+}
+
+// QueryMetrics holds Prometheus metrics for warm store statements.
+type QueryMetrics struct {
+	// QueryDuration tracks statement duration in seconds by family and status.
+	QueryDuration *prometheus.HistogramVec
+
+	// SlowQueries counts statements that exceeded the slow-query threshold, by family.
+	SlowQueries *prometheus.CounterVec
+}
+
+// NewQueryMetrics creates the warm store statement metrics and registers them with reg.
+func NewQueryMetrics(reg prometheus.Registerer) *QueryMetrics {
+	factory := promauto.With(reg)
+	return &QueryMetrics{
+		QueryDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    metricQueryDuration,
+			Help:    "Warm store statement duration in seconds by query family and status",
+			Buckets: DefaultQueryDurationBuckets,
+		}, []string{"family", "status"}),
+
+		SlowQueries: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: metricSlowQueries,
+			Help: "Warm store statements slower than the slow-query threshold, by query family",
+		}, []string{"family"}),
+	}
+}
+
+// queryTraceKey is the context key carrying a statement's start data from
+// TraceQueryStart to TraceQueryEnd.
+type queryTraceKey struct{}
+
+// queryTrace is the per-statement state kept between start and end.
+type queryTrace struct {
+	start time.Time
+	sql   string
+	args  []any
+}
+
+// QueryTracer is a pgx.QueryTracer that records per-family statement latency
+// and logs statements slower than a threshold. Set it as the pool's
+// ConnConfig.Tracer. Both metrics and logging are optional.
+type QueryTracer struct {
+	metrics       *QueryMetrics
+	slowThreshold time.Duration
+	log           logr.Logger
+}
+
+var _ pgx.QueryTracer = (*QueryTracer)(nil)
+
+// NewQueryTracer creates a QueryTracer. A zero slowThreshold disables
+// slow-query logging; a nil metrics disables the metrics.
+func NewQueryTracer(metrics *QueryMetrics, slowThreshold time.Duration, log logr.Logger) *QueryTracer {
+	return &QueryTracer{metrics: metrics, slowThreshold: slowThreshold, log: log}
+}
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryTraceKey{}, &queryTrace{start: time.Now(), sql: data.SQL, args: data.Args})
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	qt, ok := ctx.Value(queryTraceKey{}).(*queryTrace)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(qt.start)
+	family := QueryFamily(qt.sql)
+	status := queryStatusSuccess
+	if data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) {
+		status = queryStatusError
+	}
+	if t.metrics != nil {
+		t.metrics.QueryDuration.WithLabelValues(family, status).Observe(elapsed.Seconds())
+	}
+	if t.slowThreshold <= 0 || elapsed < t.slowThreshold {
+		return
+	}
+	if t.metrics != nil {
+		t.metrics.SlowQueries.WithLabelValues(family).Inc()
+	}
+	kv := []any{
+		"family", family,
+		"durationMs", elapsed.Milliseconds(),
+		"thresholdMs", t.slowThreshold.Milliseconds(),
+		"status", status,
+		"rowsAffected", data.CommandTag.RowsAffected(),
+		"sql", loggedSQL(qt.sql),
+		"args", SanitizeArgs(qt.args),
+	}
+	if status == queryStatusError {
+		kv = append(kv, "error", data.Err.Error())
+	}
+	logctx.LoggerWithContext(t.log, ctx).Info("slow warm store query", kv...)
+}
+
+// loggedSQL collapses whitespace and truncates a statement for logging.
+func loggedSQL(sql string) string {
+	s := strings.TrimSpace(whitespaceRe.ReplaceAllString(sql, " "))
+	if len(s) > maxLoggedSQLLen {
+		return s[:maxLoggedSQLLen] + "..."
+	}
+	return s
+}
+
+// SanitizeArgs renders statement parameters for logging without leaking
+// content. Numbers, booleans and timestamps are kept because they are limits,
+// offsets and time ranges that explain a slow plan; strings, byte slices and
+// anything else (IDs, message bodies, JSON) are replaced with their type and
+// length.
+func SanitizeArgs(args []any) []string {
+	out := make([]string, len(args))
+	for i, a := range args {
+		out[i] = sanitizeArg(a)
+	}
+	return out
+}
+
+func sanitizeArg(a any) string {
+	switch v := a.(type) {
+	case nil:
+		return "NULL"
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case *time.Time:
+		if v == nil {
+			return "NULL"
+		}
+		return v.UTC().Format(time.RFC3339Nano)
+	case string:
+		return fmt.Sprintf("<string len=%d>", len(v))
+	case []byte:
+		return fmt.Sprintf("<bytes len=%d>", len(v))
+	case []string:
+		return fmt.Sprintf("<[]string len=%d>", len(v))
+	default:
+		return fmt.Sprintf("<%T>", v)
+	}
+}
+
+// poolStatter is the part of *pgxpool.Pool the pool collector reads.
+type poolStatter interface {
+	Stat() *pgxpool.Stat
+}
+
+// PoolCollector exports pgxpool statistics so pool saturation (every
+// connection acquired, callers waiting for one) is visible apart from query
+// latency.
+type PoolCollector struct {
+	pool poolStatter
+
+	maxConns        *prometheus.Desc
+	conns           *prometheus.Desc
+	acquires        *prometheus.Desc
+	emptyAcquires   *prometheus.Desc
+	canceled        *prometheus.Desc
+	emptyAcquireSec *prometheus.Desc
+}
+
+var _ prometheus.Collector = (*PoolCollector)(nil)
+
+// Pool names for the collector's pool label.
+const (
+	// PoolPrimary labels the read-write primary pool.
+	PoolPrimary = "primary"
+	// PoolReplica labels the read replica pool.
+	PoolReplica = "replica"
+)
+
+// NewPoolCollector creates a collector for pool, labelling its series with
+// pool=name so a primary and a read replica pool can be registered together.
+// Register it once per pool.
+func NewPoolCollector(pool *pgxpool.Pool, name string) *PoolCollector {
+	return newPoolCollector(pool, name)
+}
+
+func newPoolCollector(pool poolStatter, name string) *PoolCollector {
+	labels := prometheus.Labels{"pool": name}
+	return &PoolCollector{
+		pool: pool,
+		maxConns: prometheus.NewDesc(metricPoolMaxConns,
+			"Maximum size of the warm store connection pool", nil, labels),
+		conns: prometheus.NewDesc(metricPoolConns,
+			"Warm store pool connections by state (acquired, idle, constructing)", []string{"state"}, labels),
+		acquires: prometheus.NewDesc(metricPoolAcquires,
+			"Successful warm store pool connection acquires", nil, labels),
+		emptyAcquires: prometheus.NewDesc(metricPoolEmptyAcquires,
+			"Warm store pool acquires that waited because no idle connection was available", nil, labels),
+		canceled: prometheus.NewDesc(metricPoolCanceled,
+			"Warm store pool acquires canceled by their context while waiting", nil, labels),
+		emptyAcquireSec: prometheus.NewDesc(metricPoolEmptyAcquireSec,
+			"Total time spent waiting in warm store pool acquires that found no idle connection", nil, labels),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxConns
+	ch <- c.conns
+	ch <- c.acquires
+	ch <- c.emptyAcquires
+	ch <- c.canceled
+	ch <- c.emptyAcquireSec
+}
+
+// Collect implements prometheus.Collector.
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.conns, prometheus.GaugeValue, float64(s.AcquiredConns()), "acquired")
+	ch <- prometheus.MustNewConstMetric(c.conns, prometheus.GaugeValue, float64(s.IdleConns()), "idle")
+	ch <- prometheus.MustNewConstMetric(c.conns, prometheus.GaugeValue, float64(s.ConstructingConns()), "constructing")
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceled, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireSec, prometheus.CounterValue, s.EmptyAcquireWaitTime().Seconds())
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryFamily(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{"SELECT " + sessionColumns + " FROM sessions WHERE id = $1", "select_sessions"},
+		{"\n\t  insert into messages (id, session_id) values ($1, $2) ON CONFLICT DO UPDATE SET x = 1", "insert_messages"},
+		{"UPDATE sessions SET status = $1 WHERE id = $2", "update_sessions"},
+		{"DELETE FROM public.tool_calls WHERE session_id = $1", "delete_tool_calls"},
+		{`CREATE TABLE IF NOT EXISTS messages_w2026_07 PARTITION OF messages`, "create_messages"},
+		{"DROP TABLE IF EXISTS sessions_w2025_52", "drop_sessions"},
+		{"WITH recent AS (SELECT id FROM sessions) SELECT * FROM recent", "with_sessions"},
+		{"SELECT 1", "select"},
+		{"BEGIN", "begin"},
+		{"EXPLAIN SELECT * FROM sessions", familyOther},
+		{"", familyOther},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, QueryFamily(tt.sql), tt.sql)
+	}
+}
+
+func TestSanitizeArgs(t *testing.T) {
+	ts := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	got := SanitizeArgs([]any{
+		nil, 42, int64(7), true, 0.5, ts, &ts, (*time.Time)(nil),
+		"user@example.com", []byte("secret"), []string{"a", "b"}, map[string]any{"k": "v"},
+	})
+	assert.Equal(t, []string{
+		"NULL", "42", "7", "true", "0.5", "2026-03-04T05:06:07Z", "2026-03-04T05:06:07Z", "NULL",
+		"<string len=16>", "<bytes len=6>", "<[]string len=2>", "<map[string]interface {}>",
+	}, got)
+}
+
+// runTracedQuery drives a QueryTracer through one statement that takes d.
+func runTracedQuery(tr *QueryTracer, sql string, args []any, d time.Duration, err error) {
+	ctx := tr.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: sql, Args: args})
+	ctx.Value(queryTraceKey{}).(*queryTrace).start = time.Now().Add(-d)
+	tr.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 3"), Err: err})
+}
+
+func TestQueryTracer(t *testing.T) {
+	m := NewQueryMetrics(prometheus.NewRegistry())
+	var logs []string
+	log := funcr.New(func(prefix, args string) { logs = append(logs, args) }, funcr.Options{})
+	tr := NewQueryTracer(m, 100*time.Millisecond, log)
+
+	runTracedQuery(tr, "SELECT * FROM sessions WHERE id = $1", []any{"sess-1"}, time.Millisecond, nil)
+	runTracedQuery(tr, "SELECT * FROM sessions WHERE id = $1", []any{"sess-2"}, time.Millisecond, pgx.ErrNoRows)
+	assert.Equal(t, 1, testutil.CollectAndCount(m.QueryDuration), "no rows is not an error")
+	assert.Empty(t, logs, "fast statements are not logged")
+
+	runTracedQuery(tr, "SELECT  *\n FROM messages WHERE session_id = $1 LIMIT $2", []any{"sess-1", 500},
+		250*time.Millisecond, errors.New("canceling statement due to statement timeout"))
+
+	assert.InDelta(t, 1, testutil.ToFloat64(m.SlowQueries.WithLabelValues("select_messages")), 0)
+	require.Len(t, logs, 1)
+	line := logs[0]
+	assert.Contains(t, line, `"msg"="slow warm store query"`)
+	assert.Contains(t, line, `"family"="select_messages"`)
+	assert.Contains(t, line, `"status"="error"`)
+	assert.Contains(t, line, `"sql"="SELECT * FROM messages WHERE session_id = $1 LIMIT $2"`)
+	assert.Contains(t, line, `"args"=["<string len=6>" "500"]`)
+	assert.Contains(t, line, "statement timeout")
+	assert.NotContains(t, line, "sess-1", "string parameters are never logged")
+}
+
+func TestQueryTracer_Disabled(t *testing.T) {
+	var logged bool
+	log := funcr.New(func(string, string) { logged = true }, funcr.Options{})
+	tr := NewQueryTracer(nil, 0, log)
+
+	runTracedQuery(tr, "SELECT 1", nil, time.Hour, nil)
+	assert.False(t, logged, "a zero threshold disables slow-query logging")
+
+	// An end without a matching start is ignored.
+	tr.TraceQueryEnd(context.Background(), nil, pgx.TraceQueryEndData{})
+}
+
+func TestLoggedSQLTruncates(t *testing.T) {
+	s := loggedSQL("SELECT " + strings.Repeat("x", 2*maxLoggedSQLLen))
+	assert.Len(t, s, maxLoggedSQLLen+len("..."))
+	assert.True(t, strings.HasSuffix(s, "..."))
+}
+
+func TestPoolCollector(t *testing.T) {
+	cfg, err := pgxpool.ParseConfig("postgres://user@127.0.0.1:1/db?pool_max_conns=6")
+	require.NoError(t, err)
+	// The pool connects lazily, so no server is needed to read its stats.
+	pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	replicaCfg, err := pgxpool.ParseConfig("postgres://user@127.0.0.1:1/db?pool_max_conns=3")
+	require.NoError(t, err)
+	replica, err := pgxpool.NewWithConfig(context.Background(), replicaCfg)
+	require.NoError(t, err)
+	defer replica.Close()
+
+	reg := prometheus.NewRegistry()
+	require.NoError(t, reg.Register(NewPoolCollector(pool, PoolPrimary)))
+	require.NoError(t, reg.Register(NewPoolCollector(replica, PoolReplica)), "a replica pool registers alongside the primary")
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP omnia_session_api_warm_store_pool_max_connections Maximum size of the warm store connection pool
+# TYPE omnia_session_api_warm_store_pool_max_connections gauge
+omnia_session_api_warm_store_pool_max_connections{pool="primary"} 6
+omnia_session_api_warm_store_pool_max_connections{pool="replica"} 3
+`), metricPoolMaxConns))
+	count, err := testutil.GatherAndCount(reg)
+	require.NoError(t, err)
+	assert.Equal(t, 16, count, "per pool: max, three connection states and four acquire counters")
+}
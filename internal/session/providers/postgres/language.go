@@ -0,0 +1,69 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/altairalabs/omnia/internal/pgutil"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+var _ providers.MessageLanguageAggregator = (*Provider)(nil)
+
+// MessageLanguageBreakdown counts a namespace's messages and the sessions
+// they belong to per detected language. System and tool messages are left
+// out: their language is the agent author's, not the conversation's.
+func (p *Provider) MessageLanguageBreakdown(
+	ctx context.Context, filter providers.MessageLanguageFilter,
+) ([]providers.MessageLanguageCount, error) {
+	qb := &pgutil.QueryBuilder{}
+	qb.Add("s.namespace = $?", filter.Namespace)
+	if filter.AgentName != "" {
+		qb.Add("s.agent_name = $?", filter.AgentName)
+	}
+	if !filter.From.IsZero() {
+		qb.Add(`m."timestamp" >= $?`, filter.From)
+	}
+	if !filter.To.IsZero() {
+		qb.Add(`m."timestamp" < $?`, filter.To)
+	}
+
+	query := `SELECT COALESCE(m.metadata ->> 'language', '') AS language,
+			count(*), count(DISTINCT m.session_id)
+		FROM messages m
+		JOIN sessions s ON s.id = m.session_id
+		WHERE m.role IN ('user', 'assistant')` + qb.Where() + `
+		GROUP BY 1
+		ORDER BY 2 DESC, 1`
+	rows, err := p.reader(ctx).Query(ctx, query, qb.Args()...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: message language breakdown: %w", err)
+	}
+	counts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (providers.MessageLanguageCount, error) {
+		var c providers.MessageLanguageCount
+		err := row.Scan(&c.Language, &c.Messages, &c.Sessions)
+		return c, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postgres: scan message language breakdown: %w", err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+func TestMessageLanguageBreakdown(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	p := newProvider(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	namespace := "lang-" + uuid.NewString()[:8]
+
+	seed := func(agent string, languages ...string) {
+		sess := makeSession(uuid.NewString(), now)
+		sess.Namespace = namespace
+		sess.AgentName = agent
+		require.NoError(t, p.CreateSession(ctx, sess))
+		for i, lang := range languages {
+			msg := makeMessage(uuid.NewString(), int32(i+1), now.Add(time.Duration(i)*time.Second))
+			if lang != "" {
+				msg.Metadata = map[string]string{"language": lang}
+			}
+			require.NoError(t, p.AppendMessage(ctx, sess.ID, msg))
+		}
+	}
+	seed("support", "es", "es", "en")
+	seed("support", "es", "")
+	seed("sales", "fr")
+
+	counts, err := p.MessageLanguageBreakdown(ctx, providers.MessageLanguageFilter{Namespace: namespace})
+	require.NoError(t, err)
+	assert.Equal(t, []providers.MessageLanguageCount{
+		{Language: "es", Messages: 3, Sessions: 2},
+		{Language: "", Messages: 1, Sessions: 1},
+		{Language: "en", Messages: 1, Sessions: 1},
+		{Language: "fr", Messages: 1, Sessions: 1},
+	}, counts)
+
+	counts, err = p.MessageLanguageBreakdown(ctx, providers.MessageLanguageFilter{
+		Namespace: namespace, AgentName: "sales",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []providers.MessageLanguageCount{{Language: "fr", Messages: 1, Sessions: 1}}, counts)
+
+	// System messages do not count.
+	sys := makeSession(uuid.NewString(), now)
+	sys.Namespace = namespace
+	require.NoError(t, p.CreateSession(ctx, sys))
+	msg := makeMessage(uuid.NewString(), 1, now)
+	msg.Role = session.RoleSystem
+	msg.Metadata = map[string]string{"language": "de"}
+	require.NoError(t, p.AppendMessage(ctx, sys.ID, msg))
+	counts, err = p.MessageLanguageBreakdown(ctx, providers.MessageLanguageFilter{
+		Namespace: namespace, From: now.Add(-time.Minute), To: now.Add(time.Minute),
+	})
+	require.NoError(t, err)
+	assert.Len(t, counts, 4)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/altairalabs/omnia/internal/pgutil"
+	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+var _ providers.IdleSessionReaper = (*Provider)(nil)
+
+// MarkIdleSessions moves up to limit active sessions whose last activity is
+// before lastActiveBefore to idle, and returns how many it moved. updated_at
+// is left alone so it keeps recording the last activity.
+func (p *Provider) MarkIdleSessions(ctx context.Context, lastActiveBefore time.Time, limit int) (int, error) {
+	res, err := p.pool.Exec(ctx, `UPDATE sessions SET status = 'idle'
+		WHERE id IN (
+			SELECT id FROM sessions
+			WHERE status = 'active' AND updated_at < $1
+			ORDER BY updated_at
+			LIMIT $2
+		) AND status = 'active'`, lastActiveBefore, limit)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: mark idle sessions: %w", err)
+	}
+	return int(res.RowsAffected()), nil
+}
+
+// CompleteIdleSessions moves up to limit idle sessions whose last activity is
+// before lastActiveBefore to completed, ending them now. It returns the
+// completed sessions with their ID, agent, namespace and PromptPack set, so
+// the caller can publish completion events.
+func (p *Provider) CompleteIdleSessions(ctx context.Context, lastActiveBefore time.Time, limit int) ([]*session.Session, error) {
+	rows, err := p.pool.Query(ctx, `UPDATE sessions SET status = 'completed', ended_at = $3
+		WHERE id IN (
+			SELECT id FROM sessions
+			WHERE status = 'idle' AND updated_at < $1
+			ORDER BY updated_at
+			LIMIT $2
+		) AND status = 'idle'
+		RETURNING id, agent_name, namespace, prompt_pack_name, prompt_pack_version, ended_at`,
+		lastActiveBefore, limit, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("postgres: complete idle sessions: %w", err)
+	}
+	sessions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*session.Session, error) {
+		var s session.Session
+		var promptPackName, promptPackVersion *string
+		if err := row.Scan(&s.ID, &s.AgentName, &s.Namespace, &promptPackName, &promptPackVersion, &s.EndedAt); err != nil {
+			return nil, err
+		}
+		s.Status = session.SessionStatusCompleted
+		s.PromptPackName = pgutil.DerefString(promptPackName)
+		s.PromptPackVersion = pgutil.DerefString(promptPackVersion)
+		return &s, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postgres: scan completed sessions: %w", err)
+	}
+	return sessions, nil
+}
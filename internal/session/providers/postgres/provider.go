@@ -41,6 +41,8 @@ const sourceAgent = "agent"
 // Provider implements providers.WarmStoreProvider using PostgreSQL.
 type Provider struct {
 	pool     *pgxpool.Pool
+	replica  *pgxpool.Pool
+	codec    *messageCodec
 	ownsPool bool
 }
 
@@ -78,13 +80,13 @@ func New(cfg Config) (*Provider, error) {
 		return nil, fmt.Errorf("postgres: ping failed: %w", err)
 	}
 
-	return &Provider{pool: pool, ownsPool: true}, nil
+	return &Provider{pool: pool, codec: newMessageCodec(pool), ownsPool: true}, nil
 }
 
 // NewFromPool wraps an existing connection pool. Close is a no-op because the
 // caller retains ownership of the pool.
 func NewFromPool(pool *pgxpool.Pool) *Provider {
-	return &Provider{pool: pool, ownsPool: false}
+	return &Provider{pool: pool, codec: newMessageCodec(pool), ownsPool: false}
 }
 
 // sessionColumns is the SELECT column list for sessions (no trailing comma).
@@ -93,7 +95,14 @@ const sessionColumns = `id, agent_name, namespace, workspace_name, status,
 	message_count, tool_call_count, total_input_tokens, total_output_tokens,
 	estimated_cost_usd, tags, state, last_message_preview,
 	prompt_pack_name, prompt_pack_version,
-	cohort_id, variant, virtual_user_id`
+	cohort_id, variant, virtual_user_id,
+	parent_session_id, forked_from_message_id`
+
+// artifactColumns is the SELECT column list for message_artifacts, in
+// scanArtifact order.
+const artifactColumns = `id, message_id, session_id, artifact_type, mime_type, storage_uri,
+	size_bytes, filename, checksum_sha256, metadata, created_at,
+	width, height, duration_ms, channels, sample_rate`
 
 // nullableSessionFields groups nullable columns scanned from a session row.
 type nullableSessionFields struct {
@@ -103,6 +112,8 @@ type nullableSessionFields struct {
 	promptPackVersion *string
 	cohortID          *string
 	variant           *string
+	parentSessionID   *string
+	forkedFromMsgID   *string
 	expiresAt         *time.Time
 	endedAt           *time.Time
 	stateJSON         []byte
@@ -111,7 +122,7 @@ type nullableSessionFields struct {
 // Compile-time interface check for the optional StatusUpdaterWithResult.
 var _ providers.StatusUpdaterWithResult = (*Provider)(nil)
 
-var partitionTables = []string{"sessions", "messages", "tool_calls", "provider_calls", "runtime_events", "message_artifacts", "audit_log"}
+var partitionTables = []string{"sessions", "messages", "tool_calls", "provider_calls", "runtime_events", "message_artifacts", "message_annotations", "audit_log"}
 
 // partBoundRe matches partition range expressions like:
 // FOR VALUES FROM ('2025-01-06 00:00:00+00') TO ('2025-01-13 00:00:00+00')
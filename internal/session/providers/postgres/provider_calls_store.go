@@ -25,7 +25,8 @@ var _ api.ProviderCallsStore = (*ProviderCallsStoreImpl)(nil)
 // namespace + agent_name are denormalized onto provider_calls, so these queries
 // filter directly without JOINing sessions.
 type ProviderCallsStoreImpl struct {
-	pool *pgxpool.Pool
+	pool    *pgxpool.Pool
+	replica *pgxpool.Pool
 }
 
 // NewProviderCallsStore creates a new ProviderCallsStoreImpl from an existing
@@ -80,7 +81,7 @@ func (s *ProviderCallsStoreImpl) AggregateProviderCalls(
 		LIMIT %d`,
 		keyExpr, valueExpr, qb.Where(), orderClause, clampProviderCallAggregateLimit(opts.Limit))
 
-	rows, err := s.pool.Query(ctx, query, qb.Args()...)
+	rows, err := s.reader(ctx).Query(ctx, query, qb.Args()...)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: aggregate provider calls: %w", err)
 	}
@@ -263,7 +264,7 @@ func (s *ProviderCallsStoreImpl) distinctProviderCallColumn(
 		FROM provider_calls pc
 		WHERE pc.namespace = $1 AND pc.%s <> ''
 		ORDER BY 1`, column, column)
-	rows, err := s.pool.Query(ctx, q, namespace)
+	rows, err := s.reader(ctx).Query(ctx, q, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: distinct provider_calls.%s: %w", column, err)
 	}
@@ -101,7 +101,7 @@ func (p *Provider) DropPartition(ctx context.Context, date time.Time) error {
 	}
 
 	// Drop all table partitions in reverse dependency order.
-	for _, table := range []string{"audit_log", "message_artifacts", "runtime_events", "provider_calls", "tool_calls", "messages", "sessions"} {
+	for _, table := range []string{"audit_log", "message_annotations", "message_artifacts", "runtime_events", "provider_calls", "tool_calls", "messages", "sessions"} {
 		name := pgx.Identifier{table + "_" + suffix}.Sanitize()
 		_, err := tx.Exec(ctx, "DROP TABLE IF EXISTS "+name)
 		if err != nil {
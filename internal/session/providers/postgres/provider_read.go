@@ -28,7 +28,7 @@ import (
 
 func (p *Provider) sessionExists(ctx context.Context, sessionID string) error {
 	var exists bool
-	err := p.pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM sessions WHERE id=$1)", sessionID).Scan(&exists)
+	err := p.reader(ctx).QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM sessions WHERE id=$1)", sessionID).Scan(&exists)
 	if err != nil {
 		return fmt.Errorf("postgres: check session: %w", err)
 	}
@@ -40,7 +40,7 @@ func (p *Provider) sessionExists(ctx context.Context, sessionID string) error {
 
 func (p *Provider) GetSession(ctx context.Context, sessionID string) (*session.Session, error) {
 	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE id=$1 LIMIT 1`
-	return scanSession(p.pool.QueryRow(ctx, query, sessionID))
+	return scanSession(p.reader(ctx).QueryRow(ctx, query, sessionID))
 }
 
 func (p *Provider) GetMessages(ctx context.Context, sessionID string, opts providers.MessageQueryOpts) ([]*session.Message, error) {
@@ -66,33 +66,81 @@ func (p *Provider) GetMessages(ctx context.Context, sessionID string, opts provi
 		sort = "DESC"
 	}
 
-	query := `SELECT id, role, content, timestamp, input_tokens, output_tokens, cost_usd, tool_call_id, metadata, sequence_num, has_media, media_types
+	query := `SELECT id, role, content, timestamp, input_tokens, output_tokens, cost_usd, tool_call_id, metadata, sequence_num, has_media, media_types, content_zstd, content_dict_id
 		FROM messages WHERE 1=1` + qb.Where() + ` ORDER BY sequence_num ` + sort
 	query = qb.AppendPagination(query, opts.Limit, opts.Offset)
 
-	rows, err := p.pool.Query(ctx, query, qb.Args()...)
+	rows, err := p.reader(ctx).Query(ctx, query, qb.Args()...)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: get messages: %w", err)
 	}
 	defer rows.Close()
 
 	var msgs []*session.Message
+	var compressed []compressedContent
 	for rows.Next() {
-		m, err := scanMessage(rows)
+		m, cc, err := scanMessage(rows)
 		if err != nil {
 			return nil, err
 		}
+		if cc.data != nil {
+			cc.msg = m
+			compressed = append(compressed, cc)
+		}
 		msgs = append(msgs, m)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("postgres: iterate messages: %w", err)
 	}
+	rows.Close()
+	// Decompress after the rows are drained: loading a dictionary needs a
+	// connection of its own.
+	for _, cc := range compressed {
+		if cc.msg.Content, err = p.codec.decompress(ctx, cc.data, cc.dictID); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.loadAttachments(ctx, sessionID, msgs); err != nil {
+		return nil, err
+	}
 	if msgs == nil {
 		msgs = []*session.Message{}
 	}
 	return msgs, nil
 }
 
+// loadAttachments fills in the attachment references of the media-bearing
+// messages in msgs.
+func (p *Provider) loadAttachments(ctx context.Context, sessionID string, msgs []*session.Message) error {
+	byID := make(map[string]*session.Message)
+	ids := make([]string, 0)
+	for _, m := range msgs {
+		if m.HasMedia {
+			byID[m.ID] = m
+			ids = append(ids, m.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := p.reader(ctx).Query(ctx, `SELECT `+artifactColumns+` FROM message_artifacts
+		WHERE session_id=$1 AND message_id = ANY($2) ORDER BY created_at ASC, id ASC`, sessionID, ids)
+	if err != nil {
+		return fmt.Errorf("postgres: get message attachments: %w", err)
+	}
+	artifacts, err := collectArtifacts(rows)
+	if err != nil {
+		return err
+	}
+	for _, a := range artifacts {
+		if m := byID[a.MessageID]; m != nil {
+			m.Attachments = append(m.Attachments, *a)
+		}
+	}
+	return nil
+}
+
 func (p *Provider) ListSessions(ctx context.Context, opts providers.SessionListOpts) (*providers.SessionPage, error) {
 	qb := &pgutil.QueryBuilder{}
 	p.applySessionFilters(qb, opts)
@@ -112,7 +160,7 @@ func (p *Provider) ListSessions(ctx context.Context, opts providers.SessionListO
 		` ORDER BY created_at ` + sort
 	dataQuery = qb.AppendPagination(dataQuery, fetchLimit, opts.Offset)
 
-	rows, err := p.pool.Query(ctx, dataQuery, qb.Args()...)
+	rows, err := p.reader(ctx).Query(ctx, dataQuery, qb.Args()...)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: list sessions: %w", err)
 	}
@@ -163,7 +211,7 @@ func (p *Provider) SearchSessions(ctx context.Context, query string, opts provid
 		` ORDER BY s.created_at ` + sort
 	dataSQL = qb.AppendPagination(dataSQL, fetchLimit, opts.Offset)
 
-	rows, err := p.pool.Query(ctx, dataSQL, qb.Args()...)
+	rows, err := p.reader(ctx).Query(ctx, dataSQL, qb.Args()...)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: search sessions: %w", err)
 	}
@@ -198,7 +246,7 @@ func (p *Provider) countSessions(ctx context.Context, opts providers.SessionList
 	p.applySessionFilters(countQB, opts)
 	countQuery := `SELECT count(*) FROM sessions WHERE 1=1` + countQB.Where()
 	var total int64
-	if err := p.pool.QueryRow(ctx, countQuery, countQB.Args()...).Scan(&total); err != nil {
+	if err := p.reader(ctx).QueryRow(ctx, countQuery, countQB.Args()...).Scan(&total); err != nil {
 		return 0, fmt.Errorf("postgres: count sessions: %w", err)
 	}
 	return total, nil
@@ -211,7 +259,7 @@ func (p *Provider) countSearchSessions(ctx context.Context, query string, opts p
 	p.applySessionFilters(countQB, opts)
 	countSQL := `SELECT count(*) FROM sessions s WHERE 1=1` + countQB.Where()
 	var total int64
-	if err := p.pool.QueryRow(ctx, countSQL, countQB.Args()...).Scan(&total); err != nil {
+	if err := p.reader(ctx).QueryRow(ctx, countSQL, countQB.Args()...).Scan(&total); err != nil {
 		return 0, fmt.Errorf("postgres: count search sessions: %w", err)
 	}
 	return total, nil
@@ -236,6 +284,9 @@ func (p *Provider) applySessionFilters(qb *pgutil.QueryBuilder, opts providers.S
 	if len(opts.Tags) > 0 {
 		qb.Add("tags @> $?", opts.Tags)
 	}
+	if opts.ParentSessionID != "" {
+		qb.Add("parent_session_id=$?", opts.ParentSessionID)
+	}
 	if !opts.CreatedAfter.IsZero() {
 		qb.Add("created_at >= $?", opts.CreatedAfter)
 	}
@@ -256,7 +307,7 @@ func (p *Provider) GetToolCalls(ctx context.Context, sessionID string, opts prov
 		FROM tool_calls WHERE 1=1` + qb.Where() + ` ORDER BY created_at ASC`
 	query = qb.AppendPagination(query, opts.Limit, opts.Offset)
 
-	rows, err := p.pool.Query(ctx, query, qb.Args()...)
+	rows, err := p.reader(ctx).Query(ctx, query, qb.Args()...)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: get tool calls: %w", err)
 	}
@@ -291,7 +342,7 @@ func (p *Provider) GetProviderCalls(ctx context.Context, sessionID string, opts
 		FROM provider_calls WHERE 1=1` + qb.Where() + ` ORDER BY created_at ASC`
 	query = qb.AppendPagination(query, opts.Limit, opts.Offset)
 
-	rows, err := p.pool.Query(ctx, query, qb.Args()...)
+	rows, err := p.reader(ctx).Query(ctx, query, qb.Args()...)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: get provider calls: %w", err)
 	}
@@ -326,7 +377,7 @@ func (p *Provider) GetRuntimeEvents(ctx context.Context, sessionID string, opts
 		FROM runtime_events WHERE 1=1` + qb.Where() + ` ORDER BY timestamp ASC`
 	query = qb.AppendPagination(query, opts.Limit, opts.Offset)
 
-	rows, err := p.pool.Query(ctx, query, qb.Args()...)
+	rows, err := p.reader(ctx).Query(ctx, query, qb.Args()...)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: get runtime events: %w", err)
 	}
@@ -350,12 +401,9 @@ func (p *Provider) GetRuntimeEvents(ctx context.Context, sessionID string, opts
 }
 
 func (p *Provider) GetArtifacts(ctx context.Context, messageID string) ([]*session.Artifact, error) {
-	query := `SELECT id, message_id, session_id, artifact_type, mime_type, storage_uri,
-		size_bytes, filename, checksum_sha256, metadata, created_at,
-		width, height, duration_ms, channels, sample_rate
-		FROM message_artifacts WHERE message_id=$1 ORDER BY created_at ASC`
+	query := `SELECT ` + artifactColumns + ` FROM message_artifacts WHERE message_id=$1 ORDER BY created_at ASC`
 
-	rows, err := p.pool.Query(ctx, query, messageID)
+	rows, err := p.reader(ctx).Query(ctx, query, messageID)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: get artifacts: %w", err)
 	}
@@ -364,12 +412,9 @@ func (p *Provider) GetArtifacts(ctx context.Context, messageID string) ([]*sessi
 
 func (p *Provider) GetSessionArtifacts(ctx context.Context, sessionID string) ([]*session.Artifact, error) {
 	const maxSessionArtifacts = 1000
-	query := `SELECT id, message_id, session_id, artifact_type, mime_type, storage_uri,
-		size_bytes, filename, checksum_sha256, metadata, created_at,
-		width, height, duration_ms, channels, sample_rate
-		FROM message_artifacts WHERE session_id=$1 ORDER BY created_at ASC LIMIT $2`
+	query := `SELECT ` + artifactColumns + ` FROM message_artifacts WHERE session_id=$1 ORDER BY created_at ASC LIMIT $2`
 
-	rows, err := p.pool.Query(ctx, query, sessionID, maxSessionArtifacts)
+	rows, err := p.reader(ctx).Query(ctx, query, sessionID, maxSessionArtifacts)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: get session artifacts: %w", err)
 	}
@@ -379,7 +424,7 @@ func (p *Provider) GetSessionArtifacts(ctx context.Context, sessionID string) ([
 func (p *Provider) GetSessionsOlderThan(ctx context.Context, cutoff time.Time, batchSize int) ([]*session.Session, error) {
 	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE updated_at < $1 ORDER BY updated_at ASC LIMIT $2`
 
-	rows, err := p.pool.Query(ctx, query, cutoff, batchSize)
+	rows, err := p.reader(ctx).Query(ctx, query, cutoff, batchSize)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: get sessions older than: %w", err)
 	}
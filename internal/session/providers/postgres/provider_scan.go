@@ -38,6 +38,8 @@ func populateSession(s *session.Session, n nullableSessionFields) {
 	s.PromptPackVersion = pgutil.DerefString(n.promptPackVersion)
 	s.CohortID = pgutil.DerefString(n.cohortID)
 	s.Variant = pgutil.DerefString(n.variant)
+	s.ParentSessionID = pgutil.DerefString(n.parentSessionID)
+	s.ForkedFromMessageID = pgutil.DerefString(n.forkedFromMsgID)
 	if s.Tags == nil {
 		s.Tags = []string{}
 	}
@@ -54,6 +56,7 @@ func scanSession(row pgx.Row) (*session.Session, error) {
 		&s.EstimatedCostUSD, &s.Tags, &n.stateJSON, &n.lastMsgPreview,
 		&n.promptPackName, &n.promptPackVersion,
 		&n.cohortID, &n.variant, &s.VirtualUserID,
+		&n.parentSessionID, &n.forkedFromMsgID,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -66,20 +69,30 @@ func scanSession(row pgx.Row) (*session.Session, error) {
 	return &s, nil
 }
 
-func scanMessage(row pgx.Row) (*session.Message, error) {
+// compressedContent is a message body stored in content_zstd, decompressed
+// into msg once the rows are drained.
+type compressedContent struct {
+	msg    *session.Message
+	data   []byte
+	dictID *int64
+}
+
+func scanMessage(row pgx.Row) (*session.Message, compressedContent, error) {
 	var m session.Message
 	var toolCallID *string
 	var inputTokens, outputTokens *int32
 	var metadataJSON []byte
+	var cc compressedContent
 
 	err := row.Scan(
 		&m.ID, &m.Role, &m.Content, &m.Timestamp,
 		&inputTokens, &outputTokens, &m.CostUSD,
 		&toolCallID, &metadataJSON, &m.SequenceNum,
 		&m.HasMedia, &m.MediaTypes,
+		&cc.data, &cc.dictID,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("postgres: scan message: %w", err)
+		return nil, cc, fmt.Errorf("postgres: scan message: %w", err)
 	}
 
 	m.ToolCallID = pgutil.DerefString(toolCallID)
@@ -93,7 +106,7 @@ func scanMessage(row pgx.Row) (*session.Message, error) {
 	if m.MediaTypes == nil {
 		m.MediaTypes = []string{}
 	}
-	return &m, nil
+	return &m, cc, nil
 }
 
 func scanToolCall(row pgx.Row) (*session.ToolCall, error) {
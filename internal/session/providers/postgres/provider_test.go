@@ -27,6 +27,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/stretchr/testify/assert"
@@ -1253,7 +1254,7 @@ func TestClose_OwnsPool(t *testing.T) {
 	}
 
 	pool := freshDB(t)
-	p := &Provider{pool: pool, ownsPool: true}
+	p := &Provider{pool: pool, codec: newMessageCodec(pool), ownsPool: true}
 	assert.NoError(t, p.Close())
 
 	// Pool should be closed — Ping should fail.
@@ -1267,7 +1268,7 @@ func TestClose_SharedPool(t *testing.T) {
 	}
 
 	pool := freshDB(t)
-	p := &Provider{pool: pool, ownsPool: false}
+	p := &Provider{pool: pool, codec: newMessageCodec(pool), ownsPool: false}
 	assert.NoError(t, p.Close())
 
 	// Pool should still be usable.
@@ -1750,6 +1751,56 @@ func TestArtifacts_SaveGetDelete(t *testing.T) {
 	assert.Empty(t, afterDelete)
 }
 
+func TestAppendMessage_Attachments(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	p := newProvider(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	sess := makeSession(uuid.NewString(), now)
+	require.NoError(t, p.CreateSession(ctx, sess))
+
+	msg := makeMessage(uuid.NewString(), 1, now)
+	msg.HasMedia = true
+	msg.MediaTypes = []string{"image", "document"}
+	msg.Attachments = []session.Artifact{
+		{Type: "image", MIMEType: "image/png", StorageURI: "omnia://sessions/s/media/1", SizeBytes: 10},
+		{Type: "document", MIMEType: "application/pdf", StorageURI: "omnia://sessions/s/media/2", Checksum: "abc"},
+	}
+	require.NoError(t, p.AppendMessage(ctx, sess.ID, msg))
+	require.NoError(t, p.AppendMessage(ctx, sess.ID, makeMessage(uuid.NewString(), 2, now.Add(time.Second))))
+
+	msgs, err := p.GetMessages(ctx, sess.ID, providers.MessageQueryOpts{})
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	require.Len(t, msgs[0].Attachments, 2)
+	assert.Empty(t, msgs[1].Attachments)
+	got := msgs[0].Attachments
+	assert.Equal(t, "image/png", got[0].MIMEType, "attachments keep their order")
+	assert.Equal(t, "abc", got[1].Checksum)
+	assert.Equal(t, msg.ID, got[0].MessageID)
+	assert.Equal(t, sess.ID, got[0].SessionID)
+	assert.NotEmpty(t, got[0].ID)
+
+	bySession, err := p.GetSessionArtifacts(ctx, sess.ID)
+	require.NoError(t, err)
+	assert.Len(t, bySession, 2)
+
+	// Forks carry the attachments of the messages they copy.
+	fork := makeSession(uuid.NewString(), now)
+	fork.ParentSessionID = sess.ID
+	require.NoError(t, p.ForkSession(ctx, fork, msg.ID))
+	forked, err := p.GetMessages(ctx, fork.ID, providers.MessageQueryOpts{})
+	require.NoError(t, err)
+	require.Len(t, forked, 1)
+	require.Len(t, forked[0].Attachments, 2)
+	assert.Equal(t, forked[0].ID, forked[0].Attachments[0].MessageID)
+	assert.NotEqual(t, got[0].ID, forked[0].Attachments[0].ID)
+}
+
 func TestArtifacts_GetEmpty(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
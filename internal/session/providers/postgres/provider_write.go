@@ -31,30 +31,38 @@ import (
 	"github.com/altairalabs/omnia/internal/session/providers"
 )
 
-func (p *Provider) CreateSession(ctx context.Context, s *session.Session) error {
-	query := `INSERT INTO sessions (
-		id, agent_name, namespace, workspace_name, status,
-		created_at, updated_at, expires_at, ended_at,
-		message_count, tool_call_count, total_input_tokens, total_output_tokens,
-		estimated_cost_usd, tags, state, last_message_preview,
-		prompt_pack_name, prompt_pack_version,
-		cohort_id, variant, virtual_user_id
-	) SELECT $1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22
-	WHERE NOT EXISTS (SELECT 1 FROM sessions WHERE id=$1)`
-
+// insertSessionQuery inserts a session row unless one with the same ID
+// exists; its arguments are sessionInsertArgs.
+const insertSessionQuery = `INSERT INTO sessions (
+	id, agent_name, namespace, workspace_name, status,
+	created_at, updated_at, expires_at, ended_at,
+	message_count, tool_call_count, total_input_tokens, total_output_tokens,
+	estimated_cost_usd, tags, state, last_message_preview,
+	prompt_pack_name, prompt_pack_version,
+	cohort_id, variant, virtual_user_id,
+	parent_session_id, forked_from_message_id
+) SELECT $1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24
+WHERE NOT EXISTS (SELECT 1 FROM sessions WHERE id=$1)`
+
+// sessionInsertArgs returns the arguments for insertSessionQuery.
+func sessionInsertArgs(s *session.Session) []any {
 	tags := s.Tags
 	if tags == nil {
 		tags = []string{}
 	}
-
-	_, err := p.pool.Exec(ctx, query,
+	return []any{
 		s.ID, s.AgentName, s.Namespace, pgutil.NullString(s.WorkspaceName), s.Status,
 		s.CreatedAt, s.UpdatedAt, pgutil.NullTime(s.ExpiresAt), pgutil.NullTime(s.EndedAt),
 		s.MessageCount, s.ToolCallCount, s.TotalInputTokens, s.TotalOutputTokens,
 		s.EstimatedCostUSD, tags, pgutil.MarshalJSONB(s.State), pgutil.NullString(s.LastMessagePreview),
 		pgutil.NullString(s.PromptPackName), pgutil.NullString(s.PromptPackVersion),
 		pgutil.NullString(s.CohortID), pgutil.NullString(s.Variant), s.VirtualUserID,
-	)
+		pgutil.NullString(s.ParentSessionID), pgutil.NullString(s.ForkedFromMessageID),
+	}
+}
+
+func (p *Provider) CreateSession(ctx context.Context, s *session.Session) error {
+	_, err := p.pool.Exec(ctx, insertSessionQuery, sessionInsertArgs(s)...)
 	if err != nil {
 		return fmt.Errorf("postgres: create session: %w", err)
 	}
@@ -178,40 +186,108 @@ func (p *Provider) AppendMessage(ctx context.Context, sessionID string, msg *ses
 		mediaTypes = []string{}
 	}
 
+	// Large tool outputs are stored compressed, with content left empty.
+	content := msg.Content
+	var compressed []byte
+	var dictID *int64
+	if p.codec.compressible(msg) {
+		var namespace string
+		err := p.pool.QueryRow(ctx, "SELECT namespace FROM sessions WHERE id = $1", sessionID).Scan(&namespace)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return session.ErrSessionNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("postgres: append message: %w", err)
+		}
+		if compressed, dictID, err = p.codec.compress(ctx, namespace, msg.Content); err != nil {
+			return err
+		}
+		content = ""
+	}
+
 	// Use a CTE to atomically verify the session exists, insert the message,
 	// and update message_count in a single round trip.
 	query := `WITH sess AS (
 		SELECT id FROM sessions WHERE id = $2
 	), ins AS (
-		INSERT INTO messages (id, session_id, role, content, timestamp, input_tokens, output_tokens, cost_usd, tool_call_id, metadata, sequence_num, has_media, media_types)
-		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+		INSERT INTO messages (id, session_id, role, content, timestamp, input_tokens, output_tokens, cost_usd, tool_call_id, metadata, sequence_num, has_media, media_types, content_zstd, content_dict_id)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $16, $17
 		WHERE EXISTS (SELECT 1 FROM sess)
 		RETURNING session_id
 	)
 	UPDATE sessions SET
 		message_count = message_count + $14,
 		updated_at = $15,
+		status = CASE WHEN status = 'idle' THEN 'active' ELSE status END,
 		last_message_preview = CASE WHEN $9 IS NULL OR $9 = '' THEN LEFT($4, 200) ELSE last_message_preview END
 	WHERE id = (SELECT session_id FROM ins)`
 
-	res, err := p.pool.Exec(ctx, query,
-		msg.ID, sessionID, msg.Role, msg.Content, msg.Timestamp,
+	args := []any{
+		msg.ID, sessionID, msg.Role, content, msg.Timestamp,
 		pgutil.NullInt32(msg.InputTokens), pgutil.NullInt32(msg.OutputTokens),
 		msg.CostUSD,
 		pgutil.NullString(msg.ToolCallID), pgutil.MarshalJSONB(msg.Metadata), msg.SequenceNum,
 		msg.HasMedia, mediaTypes,
 		messageIncr,
 		time.Now(),
-	)
+		compressed, dictID,
+	}
+	if len(msg.Attachments) == 0 {
+		res, err := p.pool.Exec(ctx, query, args...)
+		return appendMessageResult(res.RowsAffected(), err)
+	}
+
+	// Attachment references are written in the same transaction as their
+	// message, so a reader never sees one without the other.
+	tx, err := p.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("postgres: append message: %w", err)
 	}
-	if res.RowsAffected() == 0 {
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	res, err := tx.Exec(ctx, query, args...)
+	if err := appendMessageResult(res.RowsAffected(), err); err != nil {
+		return err
+	}
+	for i := range msg.Attachments {
+		a := &msg.Attachments[i]
+		prepareAttachment(a, sessionID, msg, i)
+		if _, err := tx.Exec(ctx, insertArtifactQuery, artifactInsertArgs(a)...); err != nil {
+			return fmt.Errorf("postgres: append message: save attachment: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("postgres: append message: %w", err)
+	}
+	return nil
+}
+
+// appendMessageResult maps the outcome of the append-message statement: no
+// affected row means the session does not exist.
+func appendMessageResult(rowsAffected int64, err error) error {
+	if err != nil {
+		return fmt.Errorf("postgres: append message: %w", err)
+	}
+	if rowsAffected == 0 {
 		return session.ErrSessionNotFound
 	}
 	return nil
 }
 
+// prepareAttachment fills in the identifiers of the index-th attachment of
+// msg. Attachments default to the message timestamp, offset by a microsecond
+// each so they read back in the order they were attached.
+func prepareAttachment(a *session.Artifact, sessionID string, msg *session.Message, index int) {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	a.MessageID = msg.ID
+	a.SessionID = sessionID
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = msg.Timestamp.Add(time.Duration(index) * time.Microsecond)
+	}
+}
+
 // UpdateSessionStatus atomically updates session status and ended_at.
 // Delegates to UpdateSessionStatusReturning and discards the result metadata.
 func (p *Provider) UpdateSessionStatus(ctx context.Context, sessionID string, update session.SessionStatusUpdate) error {
@@ -266,18 +342,25 @@ func (p *Provider) DecorateSession(ctx context.Context, sessionID string, opts s
 // returning the previous status and session metadata in the same query via a
 // CTE + RETURNING clause. This eliminates the need for separate pre-check and
 // post-read GetSession queries when detecting status transitions.
+//
+// The status only changes when session.CanTransition allows the move from the
+// current status; otherwise the row keeps its status and ended_at, and the
+// result reports Applied false.
 func (p *Provider) UpdateSessionStatusReturning(ctx context.Context, sessionID string, update session.SessionStatusUpdate) (*providers.StatusUpdateResult, error) {
 	query := `WITH prev AS (
 		SELECT id, status, agent_name, namespace, prompt_pack_name, prompt_pack_version
 		FROM sessions WHERE id = $1 FOR UPDATE
+	), allowed AS (
+		SELECT $2::text = '' OR (SELECT status FROM prev) = ANY($5::text[]) AS ok
 	)
 	UPDATE sessions SET
 		status = CASE
-			WHEN (SELECT status FROM prev) IN ('completed','error','expired') THEN status
-			WHEN $2::text = '' THEN status
+			WHEN $2::text = '' OR NOT (SELECT ok FROM allowed) THEN status
 			ELSE $2::text END,
 		updated_at = $3,
-		ended_at = CASE WHEN $4::timestamptz IS NULL THEN ended_at ELSE $4::timestamptz END
+		ended_at = CASE
+			WHEN $4::timestamptz IS NULL OR NOT (SELECT ok FROM allowed) THEN ended_at
+			ELSE $4::timestamptz END
 	WHERE id = $1
 	RETURNING
 		(SELECT status FROM prev) AS previous_status,
@@ -292,6 +375,7 @@ func (p *Provider) UpdateSessionStatusReturning(ctx context.Context, sessionID s
 		string(update.SetStatus),
 		time.Now(),
 		pgutil.NullTime(update.SetEndedAt),
+		statusStrings(session.TransitionSources(update.SetStatus)),
 	).Scan(&prevStatus, &agentName, &namespace, &promptPackName, &promptPackVersion)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -300,7 +384,7 @@ func (p *Provider) UpdateSessionStatusReturning(ctx context.Context, sessionID s
 		return nil, fmt.Errorf("postgres: update session status: %w", err)
 	}
 
-	applied := !session.IsTerminalStatus(session.SessionStatus(prevStatus)) && update.SetStatus != ""
+	applied := update.SetStatus != "" && session.CanTransition(session.SessionStatus(prevStatus), update.SetStatus)
 	return &providers.StatusUpdateResult{
 		Applied:           applied,
 		PreviousStatus:    session.SessionStatus(prevStatus),
@@ -311,6 +395,15 @@ func (p *Provider) UpdateSessionStatusReturning(ctx context.Context, sessionID s
 	}, nil
 }
 
+// statusStrings converts statuses to the text[] form bound into queries.
+func statusStrings(statuses []session.SessionStatus) []string {
+	out := make([]string, len(statuses))
+	for i, s := range statuses {
+		out[i] = string(s)
+	}
+	return out
+}
+
 func (p *Provider) RecordToolCall(ctx context.Context, sessionID string, tc *session.ToolCall) error {
 	// Default the partition-key timestamp to now() when zero, so a zero-value
 	// (0001-01-01) doesn't fall outside every partition and trip
@@ -448,13 +541,16 @@ func (p *Provider) RecordRuntimeEvent(ctx context.Context, sessionID string, evt
 	return nil
 }
 
-func (p *Provider) SaveArtifact(ctx context.Context, artifact *session.Artifact) error {
-	query := `INSERT INTO message_artifacts (id, message_id, session_id, artifact_type, mime_type,
-		storage_uri, size_bytes, filename, checksum_sha256, metadata, created_at,
-		width, height, duration_ms, channels, sample_rate)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+// insertArtifactQuery inserts a message_artifacts row; its arguments are
+// artifactInsertArgs.
+const insertArtifactQuery = `INSERT INTO message_artifacts (id, message_id, session_id, artifact_type, mime_type,
+	storage_uri, size_bytes, filename, checksum_sha256, metadata, created_at,
+	width, height, duration_ms, channels, sample_rate)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
 
-	_, err := p.pool.Exec(ctx, query,
+// artifactInsertArgs returns the insertArtifactQuery arguments for artifact.
+func artifactInsertArgs(artifact *session.Artifact) []any {
+	return []any{
 		artifact.ID, artifact.MessageID, artifact.SessionID,
 		artifact.Type, artifact.MIMEType, artifact.StorageURI,
 		pgutil.NullInt64(artifact.SizeBytes), pgutil.NullString(artifact.Filename),
@@ -463,7 +559,11 @@ func (p *Provider) SaveArtifact(ctx context.Context, artifact *session.Artifact)
 		pgutil.NullInt32(artifact.Width), pgutil.NullInt32(artifact.Height),
 		pgutil.NullInt32(artifact.DurationMs), pgutil.NullInt32(artifact.Channels),
 		pgutil.NullInt32(artifact.SampleRate),
-	)
+	}
+}
+
+func (p *Provider) SaveArtifact(ctx context.Context, artifact *session.Artifact) error {
+	_, err := p.pool.Exec(ctx, insertArtifactQuery, artifactInsertArgs(artifact)...)
 	if err != nil {
 		return fmt.Errorf("postgres: save artifact: %w", err)
 	}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+// readPool picks the pool a read runs on: the replica when one is configured
+// and ctx was marked with providers.WithStaleReads, the primary otherwise.
+func readPool(ctx context.Context, primary, replica *pgxpool.Pool) *pgxpool.Pool {
+	if replica != nil && providers.StaleReadsAllowed(ctx) {
+		return replica
+	}
+	return primary
+}
+
+// SetReadReplica routes stale-tolerant reads to replica. Writes, and reads
+// whose context is not marked, stay on the primary pool. The caller keeps
+// ownership of replica.
+func (p *Provider) SetReadReplica(replica *pgxpool.Pool) {
+	p.replica = replica
+}
+
+// reader returns the pool a read with ctx runs on.
+func (p *Provider) reader(ctx context.Context) *pgxpool.Pool {
+	return readPool(ctx, p.pool, p.replica)
+}
+
+// SetReadReplica routes stale-tolerant eval result reads to replica.
+func (s *EvalStoreImpl) SetReadReplica(replica *pgxpool.Pool) {
+	s.replica = replica
+}
+
+func (s *EvalStoreImpl) reader(ctx context.Context) *pgxpool.Pool {
+	return readPool(ctx, s.pool, s.replica)
+}
+
+// SetReadReplica routes stale-tolerant provider call aggregates to replica.
+func (s *ProviderCallsStoreImpl) SetReadReplica(replica *pgxpool.Pool) {
+	s.replica = replica
+}
+
+func (s *ProviderCallsStoreImpl) reader(ctx context.Context) *pgxpool.Pool {
+	return readPool(ctx, s.pool, s.replica)
+}
+
+// SetReadReplica routes stale-tolerant annotation listings and aggregates to
+// replica.
+func (s *AnnotationStoreImpl) SetReadReplica(replica *pgxpool.Pool) {
+	s.replica = replica
+}
+
+func (s *AnnotationStoreImpl) reader(ctx context.Context) *pgxpool.Pool {
+	return readPool(ctx, s.pool, s.replica)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/altairalabs/omnia/internal/session/providers"
+)
+
+// lazyPool returns a pool that never connects; routing only compares pools.
+func lazyPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	pool, err := pgxpool.New(context.Background(), "postgres://user@127.0.0.1:1/db")
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestReadRouting(t *testing.T) {
+	primary, replica := lazyPool(t), lazyPool(t)
+	strict := context.Background()
+	stale := providers.WithStaleReads(strict)
+
+	p := NewFromPool(primary)
+	assert.Same(t, primary, p.reader(stale), "without a replica every read uses the primary")
+
+	p.SetReadReplica(replica)
+	assert.Same(t, primary, p.reader(strict))
+	assert.Same(t, replica, p.reader(stale))
+
+	evals := NewEvalStore(primary)
+	evals.SetReadReplica(replica)
+	assert.Same(t, primary, evals.reader(strict))
+	assert.Same(t, replica, evals.reader(stale))
+
+	calls := NewProviderCallsStore(primary)
+	calls.SetReadReplica(replica)
+	assert.Same(t, primary, calls.reader(strict))
+	assert.Same(t, replica, calls.reader(stale))
+}
@@ -94,6 +94,8 @@ type SessionListOpts struct {
 	CreatedBefore time.Time
 	// Tags filters sessions that have all of the specified tags.
 	Tags []string
+	// ParentSessionID filters sessions to the forks of this session.
+	ParentSessionID string
 	// IncludeCount, when true, runs a separate COUNT(*) query to populate
 	// SessionPage.TotalCount. When false, TotalCount is set to -1.
 	IncludeCount bool
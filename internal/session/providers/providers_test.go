@@ -1455,3 +1455,13 @@ func TestRegistry_CloseWithPartialErrors(t *testing.T) {
 		t.Error("all providers should be closed")
 	}
 }
+
+func TestStaleReads(t *testing.T) {
+	ctx := context.Background()
+	if StaleReadsAllowed(ctx) {
+		t.Error("an unmarked context should read from the primary")
+	}
+	if !StaleReadsAllowed(WithStaleReads(ctx)) {
+		t.Error("a marked context should allow stale reads")
+	}
+}
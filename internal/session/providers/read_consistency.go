@@ -0,0 +1,35 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import "context"
+
+// staleReadsKey is the context key marking a read as tolerant of replica lag.
+type staleReadsKey struct{}
+
+// WithStaleReads marks ctx as tolerating results that lag the latest writes,
+// which lets a warm store serve its reads from a read replica. Reads without
+// the mark go to the primary, so a caller always sees its own writes.
+func WithStaleReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, staleReadsKey{}, true)
+}
+
+// StaleReadsAllowed reports whether ctx was marked with WithStaleReads.
+func StaleReadsAllowed(ctx context.Context) bool {
+	ok, _ := ctx.Value(staleReadsKey{}).(bool)
+	return ok
+}
@@ -168,3 +168,54 @@ type StatusUpdateResult struct {
 type StatusUpdaterWithResult interface {
 	UpdateSessionStatusReturning(ctx context.Context, sessionID string, update session.SessionStatusUpdate) (*StatusUpdateResult, error)
 }
+
+// IdleSessionReaper is an optional interface that WarmStoreProvider
+// implementations can satisfy to move quiet sessions through the idle and
+// completed statuses in bulk. Both methods measure quiet time from a
+// session's last activity (UpdatedAt).
+type IdleSessionReaper interface {
+	// MarkIdleSessions moves up to limit active sessions last active before
+	// lastActiveBefore to idle and returns how many it moved.
+	MarkIdleSessions(ctx context.Context, lastActiveBefore time.Time, limit int) (int, error)
+	// CompleteIdleSessions moves up to limit idle sessions last active before
+	// lastActiveBefore to completed and returns them.
+	CompleteIdleSessions(ctx context.Context, lastActiveBefore time.Time, limit int) ([]*session.Session, error)
+}
+
+// SessionForker is an optional interface that WarmStoreProvider
+// implementations can satisfy to fork a session. ForkSession creates fork,
+// whose ParentSessionID names the session to fork, together with copies of the
+// parent's messages up to and including throughMessageID (all of them when
+// throughMessageID is empty), in one transaction. It sets fork.MessageCount
+// and fork.LastMessagePreview from the copied messages. Tool calls, provider
+// calls, runtime events and eval results stay with the parent. It returns
+// session.ErrSessionNotFound when the parent does not exist and
+// session.ErrMessageNotFound when throughMessageID is not one of its messages.
+type SessionForker interface {
+	ForkSession(ctx context.Context, fork *session.Session, throughMessageID string) error
+}
+
+// MessageLanguageFilter scopes a MessageLanguageBreakdown. Namespace is
+// required; a zero From or To leaves that end of the time range open.
+type MessageLanguageFilter struct {
+	Namespace string
+	AgentName string
+	From      time.Time
+	To        time.Time
+}
+
+// MessageLanguageCount is one row of a per-language breakdown. Language is
+// the BCP 47 code recorded under the message's "language" metadata key, or
+// empty for messages whose language was not detected.
+type MessageLanguageCount struct {
+	Language string `json:"language"`
+	Messages int64  `json:"messages"`
+	Sessions int64  `json:"sessions"`
+}
+
+// MessageLanguageAggregator is an optional interface that WarmStoreProvider
+// implementations can satisfy to break a namespace's messages down by
+// detected language. Rows are ordered by message count, largest first.
+type MessageLanguageAggregator interface {
+	MessageLanguageBreakdown(ctx context.Context, filter MessageLanguageFilter) ([]MessageLanguageCount, error)
+}
@@ -225,6 +225,9 @@ func (m *Store) AppendMessage(ctx context.Context, sessionID string, msg Message
 	if msg.ToolCallID == "" {
 		session.MessageCount++
 	}
+	if session.Status == sessionpkg.SessionStatusIdle {
+		session.Status = sessionpkg.SessionStatusActive
+	}
 	session.UpdatedAt = time.Now()
 
 	return nil
@@ -321,7 +324,16 @@ func (m *Store) UpdateSessionStatus(ctx context.Context, sessionID string, updat
 		return ErrSessionExpired
 	}
 
-	if update.SetStatus != "" && !sessionpkg.IsTerminalStatus(session.Status) {
+	// Sessions created here have no status until one is set; treat that as active.
+	current := session.Status
+	if current == "" {
+		current = sessionpkg.SessionStatusActive
+	}
+	if update.SetStatus != "" && !sessionpkg.CanTransition(current, update.SetStatus) {
+		session.UpdatedAt = time.Now()
+		return nil
+	}
+	if update.SetStatus != "" {
 		session.Status = update.SetStatus
 	}
 
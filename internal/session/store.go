@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"slices"
 	"time"
 )
 
@@ -34,6 +35,11 @@ var (
 	ErrInvalidSessionID = errors.New("invalid session ID")
 	// ErrArtifactNotFound is returned when a requested artifact does not exist.
 	ErrArtifactNotFound = errors.New("artifact not found")
+	// ErrMessageNotFound is returned when a message does not exist in a session.
+	ErrMessageNotFound = errors.New("message not found")
+	// ErrInvalidTransition is returned when a session cannot move from its
+	// current status to the requested one.
+	ErrInvalidTransition = errors.New("invalid session status transition")
 )
 
 // MessageRole represents the role of a message sender.
@@ -49,23 +55,99 @@ const (
 )
 
 // SessionStatus represents the lifecycle state of a session.
+//
+// A session is active while it is in use and idle once it has been quiet for
+// a while; a new message makes an idle session active again. An escalated
+// session has been handed to a human. Completed, error and expired sessions
+// have ended, and an ended session can only be archived. Archived is final.
 type SessionStatus string
 
 const (
 	// SessionStatusActive indicates the session is currently in use.
 	SessionStatusActive SessionStatus = "active"
+	// SessionStatusIdle indicates the session has had no activity for a while
+	// but has not ended.
+	SessionStatusIdle SessionStatus = "idle"
+	// SessionStatusEscalated indicates the session was handed off to a human.
+	SessionStatusEscalated SessionStatus = "escalated"
 	// SessionStatusCompleted indicates the session ended normally.
 	SessionStatusCompleted SessionStatus = "completed"
 	// SessionStatusError indicates the session ended due to an error.
 	SessionStatusError SessionStatus = "error"
 	// SessionStatusExpired indicates the session was expired by TTL.
 	SessionStatusExpired SessionStatus = "expired"
+	// SessionStatusArchived indicates an ended session was archived.
+	SessionStatusArchived SessionStatus = "archived"
 )
 
-// IsTerminalStatus returns true if the status is a terminal state
-// that should not be overwritten by subsequent transitions.
+// sessionTransitions lists the statuses each status may move to.
+var sessionTransitions = map[SessionStatus][]SessionStatus{
+	SessionStatusActive: {
+		SessionStatusIdle, SessionStatusEscalated,
+		SessionStatusCompleted, SessionStatusError, SessionStatusExpired,
+	},
+	SessionStatusIdle: {
+		SessionStatusActive, SessionStatusEscalated,
+		SessionStatusCompleted, SessionStatusError, SessionStatusExpired,
+	},
+	SessionStatusEscalated: {
+		SessionStatusActive,
+		SessionStatusCompleted, SessionStatusError, SessionStatusExpired,
+	},
+	SessionStatusCompleted: {SessionStatusArchived},
+	SessionStatusError:     {SessionStatusArchived},
+	SessionStatusExpired:   {SessionStatusArchived},
+}
+
+// IsValidStatus returns true if s is a known session status.
+func IsValidStatus(s SessionStatus) bool {
+	return slices.Contains(AllStatuses(), s)
+}
+
+// IsTerminalStatus returns true if the session has ended. An ended session
+// only moves on to archived.
 func IsTerminalStatus(s SessionStatus) bool {
-	return s == SessionStatusCompleted || s == SessionStatusError || s == SessionStatusExpired
+	switch s {
+	case SessionStatusCompleted, SessionStatusError, SessionStatusExpired, SessionStatusArchived:
+		return true
+	}
+	return false
+}
+
+// CanTransition returns true if a session in status from may move to status
+// to. Staying in the same status is allowed until the session has ended.
+func CanTransition(from, to SessionStatus) bool {
+	if from == to {
+		return IsValidStatus(from) && !IsTerminalStatus(from)
+	}
+	for _, next := range sessionTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionSources returns every status a session may move to status to
+// from, including to itself when that is allowed. Stores use it to apply a
+// transition in a single conditional write.
+func TransitionSources(to SessionStatus) []SessionStatus {
+	var sources []SessionStatus
+	for _, from := range AllStatuses() {
+		if CanTransition(from, to) {
+			sources = append(sources, from)
+		}
+	}
+	return sources
+}
+
+// AllStatuses returns every session status, in lifecycle order.
+func AllStatuses() []SessionStatus {
+	return []SessionStatus{
+		SessionStatusActive, SessionStatusIdle, SessionStatusEscalated,
+		SessionStatusCompleted, SessionStatusError, SessionStatusExpired,
+		SessionStatusArchived,
+	}
 }
 
 // Message represents a single message in a conversation.
@@ -94,6 +176,10 @@ type Message struct {
 	HasMedia bool `json:"hasMedia,omitempty"`
 	// MediaTypes lists the distinct media types (e.g., ["image", "audio"]).
 	MediaTypes []string `json:"mediaTypes,omitempty"`
+	// Attachments references the files carried by this message. Only the
+	// references are stored with the transcript; the bytes live in media
+	// storage and are fetched through a resolved download URL.
+	Attachments []Artifact `json:"attachments,omitempty"`
 }
 
 // Artifact represents a binary attachment (image, audio, video, etc.) associated
@@ -182,6 +268,11 @@ type Session struct {
 	// VirtualUserID attributes the session to a virtual (synthetic) user. It is
 	// persisted as a NOT NULL column and must be non-empty when a session is created.
 	VirtualUserID string `json:"virtualUserId"`
+	// ParentSessionID is the session this one was forked from, if any.
+	ParentSessionID string `json:"parentSessionId,omitempty"`
+	// ForkedFromMessageID is the last parent message copied into this fork;
+	// empty when the fork copied the parent's whole history.
+	ForkedFromMessageID string `json:"forkedFromMessageId,omitempty"`
 }
 
 // IsExpired returns true if the session has expired.
@@ -95,3 +95,56 @@ func TestCommonErrors(t *testing.T) {
 		t.Errorf("ErrInvalidSessionID.Error() = %q, want %q", ErrInvalidSessionID.Error(), "invalid session ID")
 	}
 }
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to SessionStatus
+		want     bool
+	}{
+		{SessionStatusActive, SessionStatusIdle, true},
+		{SessionStatusIdle, SessionStatusActive, true},
+		{SessionStatusActive, SessionStatusEscalated, true},
+		{SessionStatusEscalated, SessionStatusIdle, false},
+		{SessionStatusIdle, SessionStatusCompleted, true},
+		{SessionStatusActive, SessionStatusActive, true},
+		{SessionStatusCompleted, SessionStatusActive, false},
+		{SessionStatusCompleted, SessionStatusCompleted, false},
+		{SessionStatusError, SessionStatusArchived, true},
+		{SessionStatusActive, SessionStatusArchived, false},
+		{SessionStatusArchived, SessionStatusCompleted, false},
+		{SessionStatusActive, "paused", false},
+	}
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestTransitionSources(t *testing.T) {
+	for _, to := range AllStatuses() {
+		sources := TransitionSources(to)
+		for _, from := range AllStatuses() {
+			want := CanTransition(from, to)
+			got := false
+			for _, s := range sources {
+				if s == from {
+					got = true
+				}
+			}
+			if got != want {
+				t.Errorf("TransitionSources(%q) includes %q = %v, want %v", to, from, got, want)
+			}
+		}
+	}
+}
+
+func TestIsTerminalStatus(t *testing.T) {
+	for _, s := range AllStatuses() {
+		want := s == SessionStatusCompleted || s == SessionStatusError ||
+			s == SessionStatusExpired || s == SessionStatusArchived
+		if got := IsTerminalStatus(s); got != want {
+			t.Errorf("IsTerminalStatus(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
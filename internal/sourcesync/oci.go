@@ -20,8 +20,8 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
-	"github.com/google/go-containerregistry/pkg/v1/tarball"
 )
 
 // OCICredentials contains authentication credentials for OCI registries.
@@ -132,33 +132,18 @@ func (f *OCIFetcher) Fetch(ctx context.Context, revision string) (*Artifact, err
 		return nil, fmt.Errorf("failed to pull image: %w", err)
 	}
 
-	// Create temporary file for the OCI tarball (required by go-containerregistry API)
-	tmpFile, err := os.CreateTemp(f.config.Options.WorkDir, "oci-artifact-*.tar")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temp file: %w", err)
-	}
-	tmpTarPath := tmpFile.Name()
-	defer func() { _ = os.Remove(tmpTarPath) }() // Clean up temp tarball
-
-	// Write image as tarball (go-containerregistry API constraint)
-	if err := tarball.Write(ref, img, tmpFile); err != nil {
-		_ = tmpFile.Close()
-		return nil, fmt.Errorf("failed to write tarball: %w", err)
-	}
-
-	if err := tmpFile.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close temp file: %w", err)
-	}
-
-	// Extract tarball to output directory
+	// Extract the image's flattened filesystem to the output directory
 	outputDir, err := os.MkdirTemp(f.config.Options.WorkDir, "artifact-*")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	if err := f.extractOCITarToDir(tmpTarPath, outputDir); err != nil {
+	layers := mutate.Extract(img)
+	err = f.extractTar(layers, outputDir)
+	_ = layers.Close()
+	if err != nil {
 		_ = os.RemoveAll(outputDir)
-		return nil, fmt.Errorf("failed to extract OCI tarball: %w", err)
+		return nil, fmt.Errorf("failed to extract OCI artifact: %w", err)
 	}
 
 	// Calculate checksum of output directory
@@ -194,8 +179,7 @@ func (f *OCIFetcher) Fetch(ctx context.Context, revision string) (*Artifact, err
 	}, nil
 }
 
-// extractOCITarToDir extracts an OCI image tarball to the destination directory.
-// OCI tarballs have a specific structure with manifest.json and layer blobs.
+// extractOCITarToDir extracts the tar archive at tarPath to the destination directory.
 func (f *OCIFetcher) extractOCITarToDir(tarPath, destDir string) error {
 	file, err := os.Open(tarPath)
 	if err != nil {
@@ -203,7 +187,13 @@ func (f *OCIFetcher) extractOCITarToDir(tarPath, destDir string) error {
 	}
 	defer func() { _ = file.Close() }()
 
-	tr := tar.NewReader(file)
+	return f.extractTar(file, destDir)
+}
+
+// extractTar extracts a tar stream, such as an image's flattened layers, to
+// the destination directory.
+func (f *OCIFetcher) extractTar(r io.Reader, destDir string) error {
+	tr := tar.NewReader(r)
 
 	for {
 		header, err := tr.Next()
@@ -8,6 +8,7 @@ package sourcesync
 
 import (
 	"archive/tar"
+	"bytes"
 	"context"
 	"os"
 	"path/filepath"
@@ -20,6 +21,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -422,9 +424,32 @@ func TestOCIFetcher_LatestRevision_WithMock(t *testing.T) {
 	assert.Equal(t, expectedDigest, revision)
 }
 
+// testLayerImage builds an image with a single layer holding the given files.
+func testLayerImage(t *testing.T, files map[string]string) v1.Image {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for fileName, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: fileName, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	layer, err := tarball.LayerFromReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	img, err := mutate.AppendLayers(mutate.MediaType(empty.Image, types.OCIManifestSchema1), layer)
+	require.NoError(t, err)
+	return img
+}
+
 func TestOCIFetcher_Fetch_WithMock(t *testing.T) {
-	// Create a minimal valid image for testing
-	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img := testLayerImage(t, map[string]string{
+		"pack.json":         `{"version": "1.0.0"}`,
+		"prompts/greet.txt": "hello",
+	})
 
 	fetcher := NewOCIFetcher(OCIFetcherConfig{
 		URL: "oci://ghcr.io/example/repo:v1.0.0",
@@ -446,6 +471,13 @@ func TestOCIFetcher_Fetch_WithMock(t *testing.T) {
 	assert.NotEmpty(t, artifact.Revision)
 	assert.True(t, strings.HasPrefix(artifact.Checksum, "sha256:"))
 	assert.Greater(t, artifact.Size, int64(0))
+
+	// The artifact holds the layer contents, not the image tarball
+	data, err := os.ReadFile(filepath.Join(artifact.Path, "pack.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": "1.0.0"}`, string(data))
+	assert.FileExists(t, filepath.Join(artifact.Path, "prompts", "greet.txt"))
+	assert.NoFileExists(t, filepath.Join(artifact.Path, "manifest.json"))
 }
 
 func TestOCIFetcher_Fetch_WithDigestRevision_Mock(t *testing.T) {
@@ -241,3 +241,37 @@ func TestApplyEntryAuth_UnknownType_NoOp(t *testing.T) {
 		t.Fatalf("unknown auth type should be a silent no-op; warn=%q err=%v", warn, err)
 	}
 }
+
+func TestHandlerEntries_SkipsHandlersWithoutUsableAuth(t *testing.T) {
+	tester := newAuthTester(t, bearerSecret())
+	registry := &omniav1alpha1.ToolRegistry{
+		ObjectMeta: metav1.ObjectMeta{Name: "reg", Namespace: "default"},
+		Spec: omniav1alpha1.ToolRegistrySpec{Handlers: []omniav1alpha1.HandlerDefinition{
+			{Name: "g", Type: omniav1alpha1.HandlerTypeGRPC,
+				GRPCConfig: &omniav1alpha1.GRPCConfig{Endpoint: "svc:50051"},
+				Auth: &omniav1alpha1.ToolAuth{Type: omniav1alpha1.ToolAuthTypeBearer,
+					SecretRef: &omniav1alpha1.SecretKeySelector{Name: "cred", Key: "token"}}},
+			{Name: "sa", Type: omniav1alpha1.HandlerTypeGRPC,
+				GRPCConfig: &omniav1alpha1.GRPCConfig{Endpoint: "svc:50052"},
+				Auth:       &omniav1alpha1.ToolAuth{Type: omniav1alpha1.ToolAuthTypeServiceAccount}},
+			{Name: "missing", Type: omniav1alpha1.HandlerTypeGRPC,
+				GRPCConfig: &omniav1alpha1.GRPCConfig{Endpoint: "svc:50053"},
+				Auth: &omniav1alpha1.ToolAuth{Type: omniav1alpha1.ToolAuthTypeBearer,
+					SecretRef: &omniav1alpha1.SecretKeySelector{Name: "nope", Key: "token"}}},
+		}},
+	}
+
+	entries, skipped := tester.HandlerEntries(context.Background(), registry)
+	if len(entries) != 1 || entries[0].Name != "g" {
+		t.Fatalf("entries = %+v, want only g", entries)
+	}
+	if entries[0].GRPCConfig.AuthToken != "secret-tok" {
+		t.Errorf("gRPC auth token = %q, want secret-tok", entries[0].GRPCConfig.AuthToken)
+	}
+	if _, ok := skipped["sa"]; !ok {
+		t.Error("serviceAccount handler not skipped")
+	}
+	if _, ok := skipped["missing"]; !ok {
+		t.Error("handler with a missing secret not skipped")
+	}
+}
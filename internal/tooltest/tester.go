@@ -259,6 +259,36 @@ func (t *Tester) ListTools(
 	return &ListToolsResponse{Tools: listed, SpecURL: specURL}
 }
 
+// HandlerEntries converts every handler in registry into a runtime entry with
+// its secret-backed auth applied, the same preparation Test gives a single
+// handler. Handlers that cannot be called faithfully from outside an agent pod
+// — a missing secret, or auth the test path only warns about — are left out
+// and returned in skipped, keyed by handler name, with the reason.
+func (t *Tester) HandlerEntries(
+	ctx context.Context,
+	registry *omniav1alpha1.ToolRegistry,
+) (entries []tools.HandlerEntry, skipped map[string]string) {
+	skipped = make(map[string]string)
+	for i := range registry.Spec.Handlers {
+		handler := registry.Spec.Handlers[i].DeepCopy()
+		if err := t.resolveAuthSecrets(ctx, registry.Namespace, handler); err != nil {
+			skipped[handler.Name] = err.Error()
+			continue
+		}
+		entry := t.buildHandlerConfig(handler)
+		warning, err := t.applyEntryAuth(ctx, registry.Namespace, handler, &entry)
+		switch {
+		case err != nil:
+			skipped[handler.Name] = err.Error()
+		case warning != "":
+			skipped[handler.Name] = warning
+		default:
+			entries = append(entries, entry)
+		}
+	}
+	return entries, skipped
+}
+
 func (t *Tester) findHandler(
 	registry *omniav1alpha1.ToolRegistry,
 	handlerName string,
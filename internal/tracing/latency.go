@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span names of the model and store hops.
+const (
+	spanModelRequest = "genai.model.request"
+	spanStoreCall    = "omnia.store.call"
+)
+
+// Store span attribute keys.
+const (
+	AttrStoreName      = "omnia.store.name"
+	AttrStoreOperation = "omnia.store.operation"
+)
+
+// ModelTransport wraps next so every provider HTTP request is recorded as a
+// genai.model.request span with the GenAI request attributes. The span ends
+// once the response body is read to EOF or closed, so for streamed responses
+// it covers generation time, not just time to first byte.
+func (p *Provider) ModelTransport(next http.RoundTripper, model, system string) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &modelTransport{next: next, tracer: p.tracer, model: model, system: system}
+}
+
+// modelTransport is the http.RoundTripper returned by ModelTransport.
+type modelTransport struct {
+	next   http.RoundTripper
+	tracer trace.Tracer
+	model  string
+	system string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *modelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), spanModelRequest,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String(AttrGenAISystem, t.system),
+			attribute.String(AttrGenAIOperationName, "chat"),
+			attribute.String(AttrGenAIRequestModel, t.model),
+			attribute.String(AttrLatencyKind, LatencyKindModel),
+			semconv.HTTPRequestMethodKey.String(req.Method),
+		),
+	)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		RecordError(span, err)
+		span.End()
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	if resp.Body == nil {
+		span.End()
+		return resp, nil
+	}
+	resp.Body = &spanBody{ReadCloser: resp.Body, span: span}
+	return resp, nil
+}
+
+// spanBody ends its span when the wrapped body is exhausted or closed.
+type spanBody struct {
+	io.ReadCloser
+	span trace.Span
+	once sync.Once
+}
+
+// Read implements io.Reader.
+func (b *spanBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.end()
+	}
+	return n, err
+}
+
+// Close implements io.Closer.
+func (b *spanBody) Close() error {
+	b.end()
+	return b.ReadCloser.Close()
+}
+
+func (b *spanBody) end() {
+	b.once.Do(func() { b.span.End() })
+}
+
+// StartStoreSpan starts a span for a call to a backing store such as
+// session-api. It uses the global TracerProvider, as store clients are shared
+// across components that do not hold a Provider.
+func StartStoreSpan(ctx context.Context, store, operation string) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, spanStoreCall,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String(AttrStoreName, store),
+			attribute.String(AttrStoreOperation, operation),
+			attribute.String(AttrLatencyKind, LatencyKindStore),
+		),
+	)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestProvider_ModelTransport(t *testing.T) {
+	provider, exporter := newTestProvider(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("data: done\n\n"))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: provider.ModelTransport(nil, "gpt-4o", "openai")}
+	parentCtx, parent := provider.Tracer().Start(context.Background(), "parent")
+	req, _ := http.NewRequestWithContext(parentCtx, http.MethodPost, srv.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := len(exporter.GetSpans()); n != 0 {
+		t.Fatalf("expected the span to stay open until the body is read, got %d ended spans", n)
+	}
+	if _, err := io.ReadAll(resp.Body); err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	_ = resp.Body.Close()
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	s := spans[0]
+	if s.Name != "genai.model.request" {
+		t.Errorf("expected span name 'genai.model.request', got %q", s.Name)
+	}
+	if s.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Error("expected the model span to be a child of the caller's span")
+	}
+	for key, want := range map[string]string{
+		AttrGenAISystem:        "openai",
+		AttrGenAIRequestModel:  "gpt-4o",
+		AttrGenAIOperationName: "chat",
+		AttrLatencyKind:        LatencyKindModel,
+	} {
+		if val, ok := findAttr(s, key); !ok || val.AsString() != want {
+			t.Errorf("expected %s=%q, got %q", key, want, val.AsString())
+		}
+	}
+	if val, ok := findAttr(s, "http.response.status_code"); !ok || val.AsInt64() != http.StatusOK {
+		t.Errorf("expected http.response.status_code=200, got %v", val.AsInt64())
+	}
+}
+
+func TestProvider_ModelTransport_Errors(t *testing.T) {
+	provider, exporter := newTestProvider(t)
+
+	failing := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+	req, _ := http.NewRequest(http.MethodPost, "http://provider.invalid", nil)
+	if _, err := provider.ModelTransport(failing, "m", "s").RoundTrip(req); err == nil {
+		t.Fatal("expected the transport error to be returned")
+	}
+
+	throttled := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Status:     "429 Too Many Requests",
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	})
+	resp, err := provider.ModelTransport(throttled, "m", "s").RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	_ = resp.Body.Close()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	for _, s := range spans {
+		if s.Status.Code != codes.Error {
+			t.Errorf("expected error status, got %v", s.Status.Code)
+		}
+	}
+}
+
+func TestStartStoreSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	_, span := StartStoreSpan(context.Background(), "session-api", "POST /api/v1/sessions")
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	s := spans[0]
+	if s.Name != "omnia.store.call" {
+		t.Errorf("expected span name 'omnia.store.call', got %q", s.Name)
+	}
+	for key, want := range map[string]string{
+		AttrStoreName:      "session-api",
+		AttrStoreOperation: "POST /api/v1/sessions",
+		AttrLatencyKind:    LatencyKindStore,
+	} {
+		if val, ok := findAttr(s, key); !ok || val.AsString() != want {
+			t.Errorf("expected %s=%q, got %q", key, want, val.AsString())
+		}
+	}
+}
@@ -55,6 +55,18 @@ const (
 	AttrGenAIUsageCost         = "gen_ai.usage.cost"
 	AttrGenAIPromptLength      = "gen_ai.prompt.length"
 	AttrGenAIResponseLength    = "gen_ai.response.length"
+	AttrGenAIToolName          = "gen_ai.tool.name"
+)
+
+// AttrLatencyKind classifies a span as one of the hops a turn's latency is
+// spent in, so a slow turn can be broken down by model, tool and store time.
+const AttrLatencyKind = "omnia.latency.kind"
+
+// Values of AttrLatencyKind.
+const (
+	LatencyKindModel = "model"
+	LatencyKindTool  = "tool"
+	LatencyKindStore = "store"
 )
 
 // Config holds tracing configuration.
@@ -296,6 +308,9 @@ type ToolSpanMeta struct {
 func (p *Provider) StartToolSpan(ctx context.Context, toolName string, meta ToolSpanMeta) (context.Context, trace.Span) {
 	attrs := []attribute.KeyValue{
 		attribute.String("tool.name", toolName),
+		attribute.String(AttrGenAIOperationName, "execute_tool"),
+		attribute.String(AttrGenAIToolName, toolName),
+		attribute.String(AttrLatencyKind, LatencyKindTool),
 	}
 	if meta.RegistryName != "" {
 		attrs = append(attrs,
@@ -237,6 +237,15 @@ func TestProvider_StartToolSpan(t *testing.T) {
 	if val.AsString() != "get_weather" {
 		t.Errorf("expected tool.name='get_weather', got %q", val.AsString())
 	}
+	if val, _ := findAttr(s, AttrGenAIToolName); val.AsString() != "get_weather" {
+		t.Errorf("expected gen_ai.tool.name='get_weather', got %q", val.AsString())
+	}
+	if val, _ := findAttr(s, AttrGenAIOperationName); val.AsString() != "execute_tool" {
+		t.Errorf("expected gen_ai.operation.name='execute_tool', got %q", val.AsString())
+	}
+	if val, _ := findAttr(s, AttrLatencyKind); val.AsString() != LatencyKindTool {
+		t.Errorf("expected omnia.latency.kind='tool', got %q", val.AsString())
+	}
 }
 
 func TestProvider_StartToolSpan_WithMeta(t *testing.T) {
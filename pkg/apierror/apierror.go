@@ -0,0 +1,264 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apierror defines the error model Omnia returns to clients: a
+// machine-readable code, whether retrying may succeed, a message safe to
+// show a user, and the correlation ID that ties the error to server logs.
+// The same model is carried by WebSocket error frames, HTTP error bodies and
+// gRPC statuses, so a client handles an error the same way whichever API
+// raised it.
+package apierror
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Codes for errors any API may return.
+const (
+	// CodeInvalidRequest is returned for a malformed or incomplete request.
+	CodeInvalidRequest = "INVALID_REQUEST"
+	// CodeUnauthenticated is returned when the caller's credentials are
+	// missing or invalid.
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	// CodePermissionDenied is returned when the caller is authenticated but
+	// not allowed to make the request.
+	CodePermissionDenied = "PERMISSION_DENIED"
+	// CodeNotFound is returned when the requested resource does not exist.
+	CodeNotFound = "NOT_FOUND"
+	// CodeConflict is returned when the request conflicts with the current
+	// state of the resource.
+	CodeConflict = "CONFLICT"
+	// CodeFailedPrecondition is returned when the system is not in a state
+	// the request requires.
+	CodeFailedPrecondition = "FAILED_PRECONDITION"
+	// CodeMessageTooLarge is returned when a request or message is larger
+	// than the server accepts.
+	CodeMessageTooLarge = "MESSAGE_TOO_LARGE"
+	// CodeRateLimited is returned when the caller sent too many requests.
+	CodeRateLimited = "RATE_LIMITED"
+	// CodeCancelled is returned when the caller cancelled the request.
+	CodeCancelled = "CANCELLED"
+	// CodeDeadlineExceeded is returned when the request timed out.
+	CodeDeadlineExceeded = "DEADLINE_EXCEEDED"
+	// CodeUnavailable is returned when a service the request needs is
+	// temporarily unavailable.
+	CodeUnavailable = "UNAVAILABLE"
+	// CodeNotImplemented is returned for an operation the server does not
+	// support.
+	CodeNotImplemented = "NOT_IMPLEMENTED"
+	// CodeInternalError is returned for an unexpected server failure. Its
+	// message never describes the failure.
+	CodeInternalError = "INTERNAL_ERROR"
+)
+
+// Codes for errors raised while running an agent's turns.
+const (
+	// CodeInvalidMessage is returned for a malformed WebSocket message.
+	CodeInvalidMessage = "INVALID_MESSAGE"
+	// CodeSessionNotFound is returned when the session does not exist.
+	CodeSessionNotFound = "SESSION_NOT_FOUND"
+	// CodeSessionExpired is returned when the session's TTL passed.
+	CodeSessionExpired = "SESSION_EXPIRED"
+	// CodeSessionTerminated is returned when an operator ended the session.
+	CodeSessionTerminated = "SESSION_TERMINATED"
+	// CodeAgentUnavailable is returned when the agent cannot take the turn.
+	CodeAgentUnavailable = "AGENT_UNAVAILABLE"
+	// CodeServerShutdown is returned when a turn is cut off because the
+	// server shut down before it completed.
+	CodeServerShutdown = "SERVER_SHUTDOWN"
+	// CodeToolFailed is returned when a tool the turn called failed.
+	CodeToolFailed = "TOOL_FAILED"
+	// CodeUploadFailed is returned when a media upload could not be prepared.
+	CodeUploadFailed = "UPLOAD_FAILED"
+	// CodeMediaNotEnabled is returned when the agent does not accept media.
+	CodeMediaNotEnabled = "MEDIA_NOT_ENABLED"
+	// CodeUnsatisfiableFormat is returned when the runtime cannot produce
+	// audio in any format the client offered.
+	CodeUnsatisfiableFormat = "UNSATISFIABLE_FORMAT"
+	// CodeStructuredOutputInvalid is returned when the model's response
+	// failed the agent's output schema.
+	CodeStructuredOutputInvalid = "STRUCTURED_OUTPUT_INVALID"
+	// CodeGuardrailRejected is returned when an input or output guardrail
+	// rejected the turn.
+	CodeGuardrailRejected = "GUARDRAIL_REJECTED"
+	// CodeBudgetExceeded is returned when a turn was refused or stopped
+	// because it exceeded the agent's budget.
+	CodeBudgetExceeded = "BUDGET_EXCEEDED"
+)
+
+// Domain identifies Omnia errors in gRPC ErrorInfo details.
+const Domain = "omnia.altairalabs.ai"
+
+// internalMessage is the message of an internal error, which must not
+// describe the failure to the client.
+const internalMessage = "internal server error"
+
+// kind describes how a code is carried over HTTP and gRPC, and whether the
+// request may succeed if retried unchanged.
+type kind struct {
+	httpStatus int
+	grpcCode   codes.Code
+	retryable  bool
+}
+
+// kinds holds every code this package defines.
+var kinds = map[string]kind{
+	CodeInvalidRequest:          {http.StatusBadRequest, codes.InvalidArgument, false},
+	CodeUnauthenticated:         {http.StatusUnauthorized, codes.Unauthenticated, false},
+	CodePermissionDenied:        {http.StatusForbidden, codes.PermissionDenied, false},
+	CodeNotFound:                {http.StatusNotFound, codes.NotFound, false},
+	CodeConflict:                {http.StatusConflict, codes.AlreadyExists, false},
+	CodeFailedPrecondition:      {http.StatusBadRequest, codes.FailedPrecondition, false},
+	CodeMessageTooLarge:         {http.StatusRequestEntityTooLarge, codes.InvalidArgument, false},
+	CodeRateLimited:             {http.StatusTooManyRequests, codes.ResourceExhausted, true},
+	CodeCancelled:               {499, codes.Canceled, false},
+	CodeDeadlineExceeded:        {http.StatusGatewayTimeout, codes.DeadlineExceeded, true},
+	CodeUnavailable:             {http.StatusServiceUnavailable, codes.Unavailable, true},
+	CodeNotImplemented:          {http.StatusNotImplemented, codes.Unimplemented, false},
+	CodeInternalError:           {http.StatusInternalServerError, codes.Internal, false},
+	CodeInvalidMessage:          {http.StatusBadRequest, codes.InvalidArgument, false},
+	CodeSessionNotFound:         {http.StatusNotFound, codes.NotFound, false},
+	CodeSessionExpired:          {http.StatusGone, codes.FailedPrecondition, false},
+	CodeSessionTerminated:       {http.StatusGone, codes.FailedPrecondition, false},
+	CodeAgentUnavailable:        {http.StatusServiceUnavailable, codes.Unavailable, true},
+	CodeServerShutdown:          {http.StatusServiceUnavailable, codes.Unavailable, true},
+	CodeToolFailed:              {http.StatusUnprocessableEntity, codes.Aborted, false},
+	CodeUploadFailed:            {http.StatusInternalServerError, codes.Internal, false},
+	CodeMediaNotEnabled:         {http.StatusBadRequest, codes.FailedPrecondition, false},
+	CodeUnsatisfiableFormat:     {http.StatusBadRequest, codes.FailedPrecondition, false},
+	CodeStructuredOutputInvalid: {http.StatusBadGateway, codes.Internal, false},
+	CodeGuardrailRejected:       {http.StatusUnprocessableEntity, codes.FailedPrecondition, false},
+	CodeBudgetExceeded:          {http.StatusTooManyRequests, codes.ResourceExhausted, false},
+}
+
+// unknownKind describes a code this package does not define, such as one a
+// third-party runtime or a provider raised.
+var unknownKind = kind{http.StatusBadGateway, codes.Unknown, false}
+
+func kindOf(code string) kind {
+	if k, ok := kinds[code]; ok {
+		return k
+	}
+	return unknownKind
+}
+
+// Retryable reports whether a request that failed with code may succeed if
+// sent again unchanged, after a backoff. Codes this package does not define
+// are not retryable.
+func Retryable(code string) bool { return kindOf(code).retryable }
+
+// HTTPStatus returns the HTTP status an error with code is returned with.
+// Codes this package does not define were relayed from an upstream and map
+// to 502 Bad Gateway.
+func HTTPStatus(code string) int { return kindOf(code).httpStatus }
+
+// GRPCCode returns the gRPC status code an error with code is returned with.
+func GRPCCode(code string) codes.Code { return kindOf(code).grpcCode }
+
+// CodeFromGRPC returns the code for a gRPC status code that carries no
+// ErrorInfo.
+func CodeFromGRPC(c codes.Code) string {
+	switch c {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return CodeInvalidRequest
+	case codes.Unauthenticated:
+		return CodeUnauthenticated
+	case codes.PermissionDenied:
+		return CodePermissionDenied
+	case codes.NotFound:
+		return CodeNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return CodeConflict
+	case codes.FailedPrecondition:
+		return CodeFailedPrecondition
+	case codes.ResourceExhausted:
+		return CodeRateLimited
+	case codes.Canceled:
+		return CodeCancelled
+	case codes.DeadlineExceeded:
+		return CodeDeadlineExceeded
+	case codes.Unavailable:
+		return CodeUnavailable
+	case codes.Unimplemented:
+		return CodeNotImplemented
+	default:
+		return CodeInternalError
+	}
+}
+
+// Error is an error returned to a client.
+type Error struct {
+	// Code is the machine-readable error code.
+	Code string `json:"code"`
+	// Message describes the error in terms safe to show the user.
+	Message string `json:"message"`
+	// Retryable reports whether the request may succeed if sent again
+	// unchanged, after a backoff.
+	Retryable bool `json:"retryable"`
+	// CorrelationID identifies the request in server logs and traces.
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+// New returns an error with code and message, retryable as the code is.
+func New(code, message string) *Error {
+	return &Error{Code: code, Message: message, Retryable: Retryable(code)}
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// WithCorrelationID returns a copy of e with its correlation ID set to id.
+// e is returned as is when it already has one.
+func (e *Error) WithCorrelationID(id string) *Error {
+	if e.CorrelationID != "" || id == "" {
+		return e
+	}
+	c := *e
+	c.CorrelationID = id
+	return &c
+}
+
+// FromError returns the client error err describes. An *Error in err's
+// chain is returned as is, and a gRPC status is converted (see FromStatus).
+// Any other error is reported as an internal error, whose message does not
+// repeat err's text.
+func FromError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	if st, ok := status.FromError(err); ok {
+		return FromStatus(st)
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return New(CodeDeadlineExceeded, "the request timed out")
+	case errors.Is(err, context.Canceled):
+		return New(CodeCancelled, "the request was cancelled")
+	default:
+		return New(CodeInternalError, internalMessage)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apierror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/altairalabs/omnia/pkg/logctx"
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+func TestTaxonomy(t *testing.T) {
+	assert.True(t, Retryable(CodeRateLimited))
+	assert.True(t, Retryable(CodeAgentUnavailable))
+	assert.False(t, Retryable(CodeBudgetExceeded))
+	assert.False(t, Retryable("PROVIDER_SPECIFIC"))
+
+	assert.Equal(t, http.StatusNotFound, HTTPStatus(CodeSessionNotFound))
+	assert.Equal(t, http.StatusBadGateway, HTTPStatus("PROVIDER_SPECIFIC"))
+	assert.Equal(t, codes.Unavailable, GRPCCode(CodeServerShutdown))
+	assert.Equal(t, codes.Unknown, GRPCCode("PROVIDER_SPECIFIC"))
+
+	for code, k := range kinds {
+		assert.NotZero(t, k.httpStatus, code)
+		assert.Contains(t, kinds, CodeFromGRPC(k.grpcCode), "%s: its gRPC code maps back to a defined code", code)
+	}
+}
+
+func TestFromError(t *testing.T) {
+	e := New(CodeSessionExpired, "session expired")
+	assert.Same(t, e, FromError(fmt.Errorf("wrapped: %w", e)))
+
+	got := FromError(errors.New("dial tcp 10.0.0.1: secret detail"))
+	assert.Equal(t, CodeInternalError, got.Code)
+	assert.NotContains(t, got.Message, "secret")
+
+	got = FromError(status.Error(codes.Unavailable, "runtime restarting"))
+	assert.Equal(t, &Error{Code: CodeUnavailable, Message: "runtime restarting", Retryable: true}, got)
+
+	got = FromError(status.Error(codes.Internal, "failed: api key sk-123"))
+	assert.Equal(t, CodeInternalError, got.Code)
+	assert.NotContains(t, got.Message, "sk-123")
+
+	assert.Equal(t, CodeDeadlineExceeded, FromError(context.DeadlineExceeded).Code)
+	assert.Nil(t, FromError(nil))
+}
+
+func TestGRPCStatusRoundTrip(t *testing.T) {
+	e := &Error{Code: CodeBudgetExceeded, Message: "budget exceeded", CorrelationID: "req-1"}
+	st := status.Convert(e)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Equal(t, e, FromStatus(st))
+}
+
+func TestAnnotate(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(policy.HeaderRequestID, "req-7"))
+
+	got := FromError(Annotate(ctx, status.Error(codes.InvalidArgument, "texts is required")))
+	assert.Equal(t, &Error{Code: CodeInvalidRequest, Message: "texts is required", CorrelationID: "req-7"}, got)
+
+	got = FromError(Annotate(ctx, errors.New("boom: internal detail")))
+	assert.Equal(t, &Error{Code: CodeInternalError, Message: internalMessage, CorrelationID: "req-7"}, got)
+
+	got = FromError(Annotate(ctx, New(CodeGuardrailRejected, "blocked")))
+	assert.Equal(t, &Error{Code: CodeGuardrailRejected, Message: "blocked", CorrelationID: "req-7"}, got)
+
+	assert.NoError(t, Annotate(ctx, nil))
+}
+
+func TestWriteHTTP(t *testing.T) {
+	var body Body
+	var seenRequestID string
+	h := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenRequestID = logctx.RequestID(r.Context())
+		WriteHTTP(w, New(CodeRateLimited, "slow down"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(policy.HeaderRequestID, "client-id")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "client-id", rec.Header().Get(policy.HeaderRequestID))
+	assert.Equal(t, "client-id", seenRequestID)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, Body{Error: "slow down", Code: CodeRateLimited, Retryable: true, CorrelationID: "client-id"}, body)
+
+	// An unusable caller ID is replaced.
+	req.Header.Set(policy.HeaderRequestID, strings.Repeat("x", maxCorrelationIDLen+1))
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Len(t, rec.Header().Get(policy.HeaderRequestID), 36)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apierror
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+// ErrorInfo metadata keys.
+const (
+	metadataRetryable     = "retryable"
+	metadataCorrelationID = "correlation_id"
+)
+
+// GRPCStatus returns e as a gRPC status: the code's gRPC status code and
+// e's message, with an ErrorInfo detail whose reason is e's code. It lets
+// status.FromError and status.Code read an *Error directly.
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(GRPCCode(e.Code), e.Message)
+	md := map[string]string{metadataRetryable: strconv.FormatBool(e.Retryable)}
+	if e.CorrelationID != "" {
+		md[metadataCorrelationID] = e.CorrelationID
+	}
+	withInfo, err := st.WithDetails(&errdetails.ErrorInfo{Reason: e.Code, Domain: Domain, Metadata: md})
+	if err != nil {
+		return st
+	}
+	return withInfo
+}
+
+// FromStatus returns the client error st describes. A status carrying an
+// Omnia ErrorInfo is read back exactly; any other status is mapped by its
+// code (see CodeFromGRPC), keeping its message unless the code is one whose
+// message may describe an internal failure.
+func FromStatus(st *status.Status) *Error {
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != Domain {
+			continue
+		}
+		md := info.GetMetadata()
+		return &Error{
+			Code:          info.GetReason(),
+			Message:       st.Message(),
+			Retryable:     md[metadataRetryable] == "true",
+			CorrelationID: md[metadataCorrelationID],
+		}
+	}
+	code := CodeFromGRPC(st.Code())
+	message := st.Message()
+	if code == CodeInternalError {
+		message = internalMessage
+	}
+	return New(code, message)
+}
+
+// Annotate returns err with an Omnia ErrorInfo attached, for a gRPC server
+// to return. The status code and message of err are kept, except that an
+// internal error's message is replaced so it cannot leak the failure; the
+// correlation ID is the request ID the caller sent in its metadata. err is
+// returned as is when it is nil or already carries details.
+func Annotate(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	e, ok := err.(*Error)
+	if !ok {
+		st, isStatus := status.FromError(err)
+		if !isStatus {
+			st = status.New(codes.Unknown, err.Error())
+		}
+		if len(st.Details()) > 0 {
+			return err
+		}
+		e = New(CodeFromGRPC(st.Code()), st.Message())
+		if e.Code == CodeInternalError {
+			e.Message = internalMessage
+		}
+	}
+	return e.WithCorrelationID(incomingRequestID(ctx))
+}
+
+// incomingRequestID returns the request ID in ctx's incoming gRPC metadata.
+func incomingRequestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if v := md.Get(policy.HeaderRequestID); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor that annotates the
+// errors unary handlers return (see Annotate).
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, Annotate(ctx, err)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor that annotates the
+// errors stream handlers return (see Annotate).
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return Annotate(ss.Context(), handler(srv, ss))
+	}
+}
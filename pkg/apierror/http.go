@@ -0,0 +1,95 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/altairalabs/omnia/pkg/logctx"
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+// maxCorrelationIDLen bounds a correlation ID a caller supplies.
+const maxCorrelationIDLen = 128
+
+// Body is the JSON body of an HTTP error response. Error holds the message,
+// as the plain error string HTTP clients read before codes were added.
+type Body struct {
+	// Error is the message, safe to show the user.
+	Error string `json:"error"`
+	// Code is the machine-readable error code.
+	Code string `json:"code"`
+	// Retryable reports whether the request may succeed if sent again.
+	Retryable bool `json:"retryable"`
+	// CorrelationID identifies the request in server logs and traces.
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+// WriteHTTP writes e as a JSON error response with the code's HTTP status.
+// An error without a correlation ID takes the one the response carries in
+// its X-Omnia-Request-ID header (see Middleware).
+func WriteHTTP(w http.ResponseWriter, e *Error) {
+	e = e.WithCorrelationID(w.Header().Get(policy.HeaderRequestID))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatus(e.Code))
+	_ = json.NewEncoder(w).Encode(Body{
+		Error:         e.Message,
+		Code:          e.Code,
+		Retryable:     e.Retryable,
+		CorrelationID: e.CorrelationID,
+	})
+}
+
+// CorrelationID returns the correlation ID for r: the X-Omnia-Request-ID
+// header the caller sent, or a new ID when it sent none or one that is
+// unusable.
+func CorrelationID(r *http.Request) string {
+	if id := r.Header.Get(policy.HeaderRequestID); validCorrelationID(id) {
+		return id
+	}
+	return uuid.NewString()
+}
+
+// validCorrelationID reports whether id is short printable ASCII, so a
+// caller-supplied ID can be logged and echoed in a header as is.
+func validCorrelationID(id string) bool {
+	if id == "" || len(id) > maxCorrelationIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// Middleware assigns each request a correlation ID (see CorrelationID),
+// echoes it in the X-Omnia-Request-ID response header and stores it in the
+// request context as the request ID, for logs and for WriteHTTP.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := CorrelationID(r)
+		w.Header().Set(policy.HeaderRequestID, id)
+		ctx := logctx.WithRequestID(r.Context(), id)
+		ctx = policy.WithRequestID(ctx, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
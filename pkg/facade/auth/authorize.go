@@ -0,0 +1,135 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+// ErrForbidden signals the credential was valid but the caller may not
+// use this agent. The HTTP layers translate it to 403 rather than 401 —
+// presenting a different credential of the same caller will not help.
+var ErrForbidden = errors.New("auth: forbidden")
+
+// Authorizer decides whether an admitted identity may use the agent a
+// facade serves. It runs after a validator admits, so it only ever sees
+// verified identities. Return nil to allow; any error refuses the
+// request, and wrapping ErrForbidden makes the refusal a 403.
+type Authorizer interface {
+	Authorize(ctx context.Context, id *policy.AuthenticatedIdentity) error
+}
+
+// AuthorizerFunc adapts a function to the Authorizer interface.
+type AuthorizerFunc func(ctx context.Context, id *policy.AuthenticatedIdentity) error
+
+// Authorize implements Authorizer.
+func (f AuthorizerFunc) Authorize(ctx context.Context, id *policy.AuthenticatedIdentity) error {
+	return f(ctx, id)
+}
+
+// Authorized wraps v so that every identity it admits must also pass
+// authz. Absence (ErrNoCredential) and rejection pass through unchanged,
+// so the wrapped validator keeps its place and semantics in a Chain; a
+// refusal short-circuits the chain like any other rejection.
+//
+// Wrap the data-plane validators only. The management-plane validator
+// admits the dashboard's own callers, which are authorized by the
+// dashboard before they reach the facade.
+func Authorized(v Validator, authz Authorizer) Validator {
+	return &authorizedValidator{next: v, authz: authz}
+}
+
+type authorizedValidator struct {
+	next  Validator
+	authz Authorizer
+}
+
+func (a *authorizedValidator) Validate(ctx context.Context, r *http.Request) (*policy.AuthenticatedIdentity, error) {
+	id, err := a.next.Validate(ctx, r)
+	if err != nil || id == nil {
+		return id, err
+	}
+	if err := a.authz.Authorize(ctx, id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// AgentScopeAuthorizer admits identities whose scope claim names the
+// agent it protects. The claim lists entries separated by commas or
+// spaces — OIDC list claims arrive comma-joined (see extractExtraClaims),
+// OAuth-style scope strings space-separated. Each entry is one of:
+//
+//   - "*": every agent
+//   - "<namespace>/*": every agent in the namespace
+//   - "<namespace>/<agent>": one agent
+//   - "<agent>": the agent of that name in any namespace
+//
+// An identity without the claim is refused: once an operator scopes
+// tokens to agents, an unscoped token is not a token for this agent.
+type AgentScopeAuthorizer struct {
+	claim     string
+	agent     string
+	namespace string
+}
+
+// NewAgentScopeAuthorizer returns an authorizer for the agent
+// namespace/agent that reads the caller's scope from claim.
+func NewAgentScopeAuthorizer(claim, namespace, agent string) (*AgentScopeAuthorizer, error) {
+	if claim == "" {
+		return nil, errors.New("auth: agent scope claim name is empty")
+	}
+	if agent == "" || namespace == "" {
+		return nil, errors.New("auth: agent scope authorizer needs the agent name and namespace")
+	}
+	return &AgentScopeAuthorizer{claim: claim, agent: agent, namespace: namespace}, nil
+}
+
+// Authorize implements Authorizer.
+func (a *AgentScopeAuthorizer) Authorize(_ context.Context, id *policy.AuthenticatedIdentity) error {
+	if id == nil {
+		return fmt.Errorf("%w: no identity", ErrForbidden)
+	}
+	scope, ok := id.Claims[a.claim]
+	if !ok || scope == "" {
+		return fmt.Errorf("%w: identity has no %q claim", ErrForbidden, a.claim)
+	}
+	entries := strings.FieldsFunc(scope, func(r rune) bool { return r == ',' || r == ' ' })
+	for _, entry := range entries {
+		if a.matches(entry) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q claim does not include %s/%s", ErrForbidden, a.claim, a.namespace, a.agent)
+}
+
+func (a *AgentScopeAuthorizer) matches(entry string) bool {
+	if entry == "*" {
+		return true
+	}
+	ns, agent, scoped := strings.Cut(entry, "/")
+	if !scoped {
+		return entry == a.agent
+	}
+	return ns == a.namespace && (agent == "*" || agent == a.agent)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/altairalabs/omnia/pkg/facade/auth"
+	"github.com/altairalabs/omnia/pkg/policy"
+)
+
+func TestAgentScopeAuthorizer(t *testing.T) {
+	t.Parallel()
+	authz, err := auth.NewAgentScopeAuthorizer("agents", "support", "concierge")
+	if err != nil {
+		t.Fatalf("NewAgentScopeAuthorizer: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		claims map[string]string
+		allow  bool
+	}{
+		{"wildcard", map[string]string{"agents": "*"}, true},
+		{"namespace wildcard", map[string]string{"agents": "support/*"}, true},
+		{"qualified agent", map[string]string{"agents": "billing/ledger,support/concierge"}, true},
+		{"bare agent name", map[string]string{"agents": "ledger concierge"}, true},
+		{"other namespace", map[string]string{"agents": "billing/*,billing/concierge"}, false},
+		{"other agent", map[string]string{"agents": "support/ledger"}, false},
+		{"missing claim", map[string]string{"email": "a@example.com"}, false},
+		{"no claims", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := authz.Authorize(context.Background(), &policy.AuthenticatedIdentity{Claims: tt.claims})
+			if tt.allow && err != nil {
+				t.Errorf("Authorize = %v, want allowed", err)
+			}
+			if !tt.allow && !errors.Is(err, auth.ErrForbidden) {
+				t.Errorf("Authorize = %v, want ErrForbidden", err)
+			}
+		})
+	}
+}
+
+func TestNewAgentScopeAuthorizer_RequiresConfig(t *testing.T) {
+	t.Parallel()
+	if _, err := auth.NewAgentScopeAuthorizer("", "ns", "agent"); err == nil {
+		t.Error("empty claim: want error")
+	}
+	if _, err := auth.NewAgentScopeAuthorizer("agents", "ns", ""); err == nil {
+		t.Error("empty agent: want error")
+	}
+}
+
+func TestAuthorized(t *testing.T) {
+	t.Parallel()
+	id := &policy.AuthenticatedIdentity{Origin: policy.OriginOIDC, Subject: "app"}
+	allow := auth.AuthorizerFunc(func(context.Context, *policy.AuthenticatedIdentity) error { return nil })
+	deny := auth.AuthorizerFunc(func(context.Context, *policy.AuthenticatedIdentity) error { return auth.ErrForbidden })
+
+	got, err := auth.Authorized(&stubValidator{id: id}, allow).Validate(context.Background(), newReq())
+	if err != nil || got != id {
+		t.Errorf("allowed: got (%v, %v), want the admitted identity", got, err)
+	}
+
+	// A refusal short-circuits the chain: the later validator never runs.
+	later := &stubValidator{id: id}
+	chain := auth.Chain{auth.Authorized(&stubValidator{id: id}, deny), later}
+	if _, err := chain.Run(context.Background(), newReq()); !errors.Is(err, auth.ErrForbidden) {
+		t.Errorf("denied: err = %v, want ErrForbidden", err)
+	}
+	if later.called != 0 {
+		t.Errorf("later validator ran %d times after a refusal, want 0", later.called)
+	}
+
+	// Absence passes through, so the chain still falls through to the next style.
+	absent := auth.Authorized(&stubValidator{err: auth.ErrNoCredential}, deny)
+	if _, err := absent.Validate(context.Background(), newReq()); !errors.Is(err, auth.ErrNoCredential) {
+		t.Errorf("absent: err = %v, want ErrNoCredential", err)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package auth
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/go-logr/logr"
@@ -35,7 +36,7 @@ type middlewareConfig struct {
 
 // WithMiddlewareLogger binds a logr.Logger for rejection telemetry. The
 // middleware never logs admits — that's expected traffic — only 401s
-// so operators can debug misconfigured validators.
+// and 403s so operators can debug misconfigured validators.
 func WithMiddlewareLogger(log logr.Logger) MiddlewareOption {
 	return func(c *middlewareConfig) { c.log = log }
 }
@@ -93,6 +94,10 @@ func Middleware(chain Chain, next http.Handler, opts ...MiddlewareOption) http.H
 			return
 		}
 		id, err := chain.Run(r.Context(), r)
+		if errors.Is(err, ErrForbidden) {
+			rejectForbidden(w, r, cfg, err.Error())
+			return
+		}
 		if err != nil {
 			// ErrNoCredential / ErrInvalidCredential / ErrExpired /
 			// anything else → reject. PR 3 flipped the ErrNoCredential
@@ -118,3 +123,14 @@ func reject401(w http.ResponseWriter, r *http.Request, cfg *middlewareConfig, re
 	}
 	http.Error(w, "unauthorized", http.StatusUnauthorized)
 }
+
+// rejectForbidden answers an authenticated caller an Authorizer refused.
+// onReject is skipped: it advertises how to authenticate, and this caller
+// already has.
+func rejectForbidden(w http.ResponseWriter, r *http.Request, cfg *middlewareConfig, reason string) {
+	cfg.log.V(1).Info("auth middleware refused request",
+		"reason", reason,
+		"path", r.URL.Path,
+		"method", r.Method)
+	http.Error(w, "forbidden", http.StatusForbidden)
+}
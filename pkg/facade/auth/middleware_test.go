@@ -18,6 +18,7 @@ package auth_test
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -156,6 +157,29 @@ func TestMiddleware_ExpiredReturns401(t *testing.T) {
 	}
 }
 
+func TestMiddleware_ForbiddenReturns403(t *testing.T) {
+	// An Authorizer refusal is a 403, and bypasses onReject: the caller
+	// authenticated, so a WWW-Authenticate challenge would mislead it.
+	t.Parallel()
+	chain := auth.Chain{&stubMwValidator{err: fmt.Errorf("%w: not this agent", auth.ErrForbidden)}}
+	next := &observingHandler{}
+	rejected := 0
+	onReject := func(w http.ResponseWriter, _ *http.Request) {
+		rejected++
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}
+	mw := auth.Middleware(chain, next, auth.WithMiddlewareOnReject(onReject))
+	rec := httptest.NewRecorder()
+	mw.ServeHTTP(rec, newMwRequest())
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", rec.Code)
+	}
+	if next.called != 0 || rejected != 0 {
+		t.Errorf("next called %d, onReject called %d, want 0 and 0", next.called, rejected)
+	}
+}
+
 func TestMiddleware_ChainOrderAdmitsFirstMatch(t *testing.T) {
 	// A shared-token chain followed by mgmt-plane: the shared-token
 	// admit should arrive at next.ServeHTTP even if a later validator
@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExemplarTraceIDLabel is the exemplar label carrying the trace ID. Grafana's
+// Prometheus data source links exemplars to traces through this label.
+const ExemplarTraceIDLabel = "trace_id"
+
+// TraceExemplar returns a trace_id exemplar for the span in ctx, or nil when
+// ctx carries no valid trace.
+func TraceExemplar(ctx context.Context) prometheus.Labels {
+	tid := trace.SpanContextFromContext(ctx).TraceID()
+	if !tid.IsValid() {
+		return nil
+	}
+	return prometheus.Labels{ExemplarTraceIDLabel: tid.String()}
+}
+
+// ObserveWithExemplar records a histogram observation, attaching exemplar when
+// it is non-nil and the observer supports exemplars.
+func ObserveWithExemplar(observer prometheus.Observer, value float64, exemplar prometheus.Labels) {
+	if exemplar != nil {
+		if eo, ok := observer.(prometheus.ExemplarObserver); ok {
+			eo.ObserveWithExemplar(value, exemplar)
+			return
+		}
+	}
+	observer.Observe(value)
+}
+
+// IncWithExemplar increments a counter, attaching exemplar when it is non-nil
+// and the counter supports exemplars.
+func IncWithExemplar(counter prometheus.Counter, exemplar prometheus.Labels) {
+	if exemplar != nil {
+		if ea, ok := counter.(prometheus.ExemplarAdder); ok {
+			ea.AddWithExemplar(1, exemplar)
+			return
+		}
+	}
+	counter.Inc()
+}
+
+// Handler serves the default gatherer like promhttp.Handler, but negotiates the
+// OpenMetrics format. Exemplars are only exposed in OpenMetrics, so a scraper
+// that asks for it (Prometheus with exemplar storage enabled) receives them;
+// other scrapers still get the text format.
+func Handler() http.Handler {
+	return promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}))
+}
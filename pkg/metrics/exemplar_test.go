@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func tracedContext(t *testing.T) context.Context {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func TestTraceExemplar(t *testing.T) {
+	assert.Nil(t, TraceExemplar(context.Background()))
+
+	labels := TraceExemplar(tracedContext(t))
+	require.NotNil(t, labels)
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", labels[ExemplarTraceIDLabel])
+}
+
+func TestObserveAndIncWithExemplar(t *testing.T) {
+	hist := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "h", Buckets: []float64{1}})
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "c"})
+	exemplar := TraceExemplar(tracedContext(t))
+
+	ObserveWithExemplar(hist, 0.5, exemplar)
+	ObserveWithExemplar(hist, 2, nil)
+	IncWithExemplar(counter, exemplar)
+	IncWithExemplar(counter, nil)
+
+	var hm dto.Metric
+	require.NoError(t, hist.Write(&hm))
+	assert.Equal(t, uint64(2), hm.GetHistogram().GetSampleCount())
+	require.NotNil(t, hm.GetHistogram().GetBucket()[0].GetExemplar())
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c",
+		hm.GetHistogram().GetBucket()[0].GetExemplar().GetLabel()[0].GetValue())
+
+	var cm dto.Metric
+	require.NoError(t, counter.Write(&cm))
+	assert.InDelta(t, 2, cm.GetCounter().GetValue(), 0)
+	assert.NotNil(t, cm.GetCounter().GetExemplar())
+}
+
+func TestHandlerNegotiatesOpenMetrics(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	rec := httptest.NewRecorder()
+
+	Handler().ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/openmetrics-text")
+	assert.Contains(t, rec.Body.String(), "# EOF")
+
+	// Scrapers that do not ask for OpenMetrics keep the text format.
+	rec = httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+}
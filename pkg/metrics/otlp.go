@@ -0,0 +1,117 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	otelprom "go.opentelemetry.io/contrib/bridges/prometheus"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// Standard OpenTelemetry environment variables that select OTLP metric export.
+// Endpoint details, headers, TLS, timeouts, the export interval and resource
+// attributes are read by the OTel SDK itself.
+const (
+	envMetricsExporter     = "OTEL_METRICS_EXPORTER"
+	envOTLPEndpoint        = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	envOTLPMetricsEndpoint = "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"
+	envOTLPProtocol        = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	envOTLPMetricsProtocol = "OTEL_EXPORTER_OTLP_METRICS_PROTOCOL"
+)
+
+// OTLP protocols, as spelled in OTEL_EXPORTER_OTLP_PROTOCOL.
+const (
+	protocolGRPC         = "grpc"
+	protocolHTTPProtobuf = "http/protobuf"
+)
+
+// OTLPExportEnabled reports whether the environment asks for OTLP metric push:
+// OTEL_METRICS_EXPORTER=otlp, or OTEL_METRICS_EXPORTER unset and an OTLP
+// endpoint configured. Any other exporter, such as none or prometheus, keeps
+// the binary scrape-only.
+func OTLPExportEnabled() bool {
+	switch os.Getenv(envMetricsExporter) {
+	case "otlp":
+		return true
+	case "":
+		return os.Getenv(envOTLPMetricsEndpoint) != "" || os.Getenv(envOTLPEndpoint) != ""
+	default:
+		return false
+	}
+}
+
+// otlpProtocol returns the configured OTLP metric protocol, defaulting to
+// http/protobuf as the OpenTelemetry specification does.
+func otlpProtocol() string {
+	if p := os.Getenv(envOTLPMetricsProtocol); p != "" {
+		return p
+	}
+	if p := os.Getenv(envOTLPProtocol); p != "" {
+		return p
+	}
+	return protocolHTTPProtobuf
+}
+
+// StartOTLPExport periodically pushes every metric gatherer serves on /metrics
+// to an OTLP collector, alongside the Prometheus scrape endpoint. It is
+// configured entirely by the standard OTEL_* environment variables; serviceName
+// is used unless OTEL_SERVICE_NAME overrides it. When export is not enabled it
+// returns a no-op shutdown. The returned shutdown flushes a final export, so
+// short-lived binaries should call it before exiting.
+func StartOTLPExport(ctx context.Context, serviceName string, gatherer prometheus.Gatherer) (func(context.Context) error, error) {
+	if !OTLPExportEnabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var exporter sdkmetric.Exporter
+	var err error
+	switch protocol := otlpProtocol(); protocol {
+	case protocolGRPC:
+		exporter, err = otlpmetricgrpc.New(ctx)
+	case protocolHTTPProtobuf:
+		exporter, err = otlpmetrichttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP metrics protocol %q", protocol)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+
+	// WithFromEnv comes last so OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES
+	// win over the binary's default service name.
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics resource: %w", err)
+	}
+
+	reader := sdkmetric.NewPeriodicReader(exporter,
+		sdkmetric.WithProducer(otelprom.NewMetricProducer(otelprom.WithGatherer(gatherer))))
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithResource(res))
+	return mp.Shutdown, nil
+}
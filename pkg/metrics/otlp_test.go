@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestOTLPExportEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		endpoint string
+		want     bool
+	}{
+		{name: "unset", want: false},
+		{name: "endpoint only", endpoint: "http://collector:4318", want: true},
+		{name: "otlp exporter", exporter: "otlp", want: true},
+		{name: "none", exporter: "none", endpoint: "http://collector:4318", want: false},
+		{name: "prometheus", exporter: "prometheus", endpoint: "http://collector:4318", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envMetricsExporter, tt.exporter)
+			t.Setenv(envOTLPEndpoint, tt.endpoint)
+			t.Setenv(envOTLPMetricsEndpoint, "")
+			if got := OTLPExportEnabled(); got != tt.want {
+				t.Errorf("OTLPExportEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartOTLPExport_Disabled(t *testing.T) {
+	t.Setenv(envMetricsExporter, "none")
+
+	shutdown, err := StartOTLPExport(context.Background(), "test", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown: %v", err)
+	}
+}
+
+func TestStartOTLPExport_UnsupportedProtocol(t *testing.T) {
+	t.Setenv(envMetricsExporter, "otlp")
+	t.Setenv(envOTLPProtocol, "http/json")
+	t.Setenv(envOTLPMetricsProtocol, "")
+
+	if _, err := StartOTLPExport(context.Background(), "test", prometheus.NewRegistry()); err == nil {
+		t.Fatal("expected an error for an unsupported protocol")
+	}
+}
+
+func TestStartOTLPExport_PushesGatheredMetrics(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		body = append(body, data...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	defer srv.Close()
+
+	t.Setenv(envMetricsExporter, "")
+	t.Setenv(envOTLPEndpoint, srv.URL)
+	t.Setenv(envOTLPMetricsEndpoint, "")
+	t.Setenv(envOTLPProtocol, "")
+	t.Setenv(envOTLPMetricsProtocol, "")
+	t.Setenv("OTEL_SERVICE_NAME", "")
+
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "omnia_test_requests_total",
+		Help: "Test counter",
+	})
+	reg.MustRegister(counter)
+	counter.Inc()
+
+	shutdown, err := StartOTLPExport(context.Background(), "omnia-test", reg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) == 0 || paths[0] != "/v1/metrics" {
+		t.Fatalf("expected a push to /v1/metrics on shutdown, got %v", paths)
+	}
+	for _, want := range []string{"omnia_test_requests_total", "omnia-test"} {
+		if !bytes.Contains(body, []byte(want)) {
+			t.Errorf("expected the export to contain %q", want)
+		}
+	}
+}
@@ -61,6 +61,7 @@ type DecisionResponse struct {
 	Message         string            `json:"message"`
 	Mode            string            `json:"mode"`
 	WouldDeny       bool              `json:"wouldDeny"`
+	Policy          string            `json:"policy,omitempty"`
 	InjectedHeaders map[string]string `json:"injectedHeaders"`
 }
 
@@ -32,6 +32,9 @@ const (
 	OriginClientKey       = "client-key"
 	OriginOIDC            = "oidc"
 	OriginEdgeTrust       = "edge-trust"
+	// OriginMCPGateway marks tool calls made through the operator's MCP
+	// gateway on behalf of a dashboard-authenticated caller.
+	OriginMCPGateway = "mcp-gateway"
 )
 
 // Role strings are conventional example values for identity.claims.role,
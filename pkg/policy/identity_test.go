@@ -34,6 +34,7 @@ func TestOriginConstants(t *testing.T) {
 		OriginClientKey:       "client-key",
 		OriginOIDC:            "oidc",
 		OriginEdgeTrust:       "edge-trust",
+		OriginMCPGateway:      "mcp-gateway",
 	}
 	for got, expected := range want {
 		if got != expected {
@@ -0,0 +1,197 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package profiling exposes Go pprof endpoints on an internal port and,
+// optionally, pushes continuous profiles to a Pyroscope server, so CPU and
+// memory hot spots can be diagnosed in production. Parca and other pull-based
+// profilers scrape the pprof endpoints directly.
+package profiling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"time"
+
+	"github.com/grafana/pyroscope-go"
+)
+
+// Environment variables that configure profiling.
+const (
+	// EnvPprofAddr overrides the pprof listen address. Setting it to an empty
+	// value disables the pprof server.
+	EnvPprofAddr = "PPROF_ADDR"
+
+	// Pyroscope push is enabled by PYROSCOPE_SERVER_ADDRESS; the remaining
+	// variables follow the names used by the Pyroscope SDKs.
+	envPyroscopeServerAddress = "PYROSCOPE_SERVER_ADDRESS"
+	envPyroscopeAppName       = "PYROSCOPE_APPLICATION_NAME"
+	envPyroscopeBasicAuthUser = "PYROSCOPE_BASIC_AUTH_USER"
+	envPyroscopeBasicAuthPass = "PYROSCOPE_BASIC_AUTH_PASSWORD"
+	envPyroscopeTenantID      = "PYROSCOPE_TENANT_ID"
+
+	envPodNamespace   = "POD_NAMESPACE"
+	envOmniaNamespace = "OMNIA_NAMESPACE"
+	envHostname       = "HOSTNAME"
+)
+
+// DefaultPprofAddr is the pprof listen address used by most binaries. It binds
+// loopback only, so profiles are reachable through kubectl port-forward but
+// not from the pod network unless PPROF_ADDR widens it.
+const DefaultPprofAddr = "localhost:6060"
+
+// pprofReadHeaderTimeout bounds request headers on the pprof server. There is
+// no write timeout because CPU profiles and traces stream for their duration.
+const pprofReadHeaderTimeout = 10 * time.Second
+
+// Options configures Start.
+type Options struct {
+	// ServiceName is the Pyroscope application name unless
+	// PYROSCOPE_APPLICATION_NAME overrides it, e.g. "omnia-session-api".
+	ServiceName string
+
+	// PprofAddr is the default pprof listen address, used unless PPROF_ADDR
+	// is set. Containers that share a pod network namespace need distinct
+	// defaults. Empty disables the pprof server by default.
+	PprofAddr string
+
+	// Tags are extra Pyroscope labels, e.g. the agent name. The namespace and
+	// pod are added from the environment when known.
+	Tags map[string]string
+}
+
+// Start starts the pprof server and, when PYROSCOPE_SERVER_ADDRESS is set,
+// continuous profile push. The returned stop function shuts both down and is
+// never nil: when Start returns an error, whatever did start keeps running
+// until stop is called, so callers can log the error and carry on.
+func Start(opts Options) (func(context.Context) error, error) {
+	var stops []func(context.Context) error
+	stop := func(ctx context.Context) error {
+		var errs []error
+		for _, s := range stops {
+			errs = append(errs, s(ctx))
+		}
+		return errors.Join(errs...)
+	}
+
+	var errs []error
+	if addr := pprofAddr(opts.PprofAddr); addr != "" {
+		srv, err := startPprofServer(addr)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			stops = append(stops, srv.Shutdown)
+		}
+	}
+
+	if cfg, ok := pyroscopeConfig(opts); ok {
+		profiler, err := pyroscope.Start(cfg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to start Pyroscope profiler: %w", err))
+		} else {
+			stops = append(stops, func(context.Context) error { return profiler.Stop() })
+		}
+	}
+	return stop, errors.Join(errs...)
+}
+
+// pprofAddr returns the pprof listen address: PPROF_ADDR when set, even to
+// an empty value, otherwise def.
+func pprofAddr(def string) string {
+	if addr, ok := os.LookupEnv(EnvPprofAddr); ok {
+		return addr
+	}
+	return def
+}
+
+// NewPprofMux returns a mux serving the standard pprof endpoints under
+// /debug/pprof/.
+func NewPprofMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
+// startPprofServer binds addr synchronously, so a port clash is reported to
+// the caller, and serves the pprof mux in the background.
+func startPprofServer(addr string) (*http.Server, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for pprof on %s: %w", addr, err)
+	}
+	srv := &http.Server{Handler: NewPprofMux(), ReadHeaderTimeout: pprofReadHeaderTimeout}
+	go func() { _ = srv.Serve(ln) }()
+	return srv, nil
+}
+
+// pyroscopeConfig builds the Pyroscope configuration from the environment.
+// It reports false when PYROSCOPE_SERVER_ADDRESS is unset.
+func pyroscopeConfig(opts Options) (pyroscope.Config, bool) {
+	server := os.Getenv(envPyroscopeServerAddress)
+	if server == "" {
+		return pyroscope.Config{}, false
+	}
+	appName := opts.ServiceName
+	if name := os.Getenv(envPyroscopeAppName); name != "" {
+		appName = name
+	}
+	return pyroscope.Config{
+		ApplicationName:   appName,
+		ServerAddress:     server,
+		BasicAuthUser:     os.Getenv(envPyroscopeBasicAuthUser),
+		BasicAuthPassword: os.Getenv(envPyroscopeBasicAuthPass),
+		TenantID:          os.Getenv(envPyroscopeTenantID),
+		Tags:              serviceTags(opts.Tags),
+		ProfileTypes: []pyroscope.ProfileType{
+			pyroscope.ProfileCPU,
+			pyroscope.ProfileAllocObjects,
+			pyroscope.ProfileAllocSpace,
+			pyroscope.ProfileInuseObjects,
+			pyroscope.ProfileInuseSpace,
+			pyroscope.ProfileGoroutines,
+		},
+	}, true
+}
+
+// serviceTags returns extra merged with the namespace and pod the binary runs
+// in. Entries in extra win.
+func serviceTags(extra map[string]string) map[string]string {
+	tags := map[string]string{}
+	namespace := os.Getenv(envPodNamespace)
+	if namespace == "" {
+		namespace = os.Getenv(envOmniaNamespace)
+	}
+	if namespace != "" {
+		tags["namespace"] = namespace
+	}
+	if pod := os.Getenv(envHostname); pod != "" {
+		tags["pod"] = pod
+	}
+	for k, v := range extra {
+		if v != "" {
+			tags[k] = v
+		}
+	}
+	return tags
+}
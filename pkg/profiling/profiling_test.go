@@ -0,0 +1,136 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiling
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPprofAddr(t *testing.T) {
+	assert.Equal(t, DefaultPprofAddr, pprofAddr(DefaultPprofAddr))
+
+	t.Setenv(EnvPprofAddr, ":6061")
+	assert.Equal(t, ":6061", pprofAddr(DefaultPprofAddr))
+
+	t.Setenv(EnvPprofAddr, "")
+	assert.Empty(t, pprofAddr(DefaultPprofAddr), "an empty PPROF_ADDR disables the server")
+}
+
+func TestNewPprofMux(t *testing.T) {
+	srv := httptest.NewServer(NewPprofMux())
+	defer srv.Close()
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/goroutine?debug=1", "/debug/pprof/cmdline"} {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, path)
+	}
+}
+
+func TestStart_ServesPprof(t *testing.T) {
+	addr := freeAddr(t)
+	t.Setenv(EnvPprofAddr, addr)
+
+	stop, err := Start(Options{ServiceName: "omnia-test", PprofAddr: DefaultPprofAddr})
+	require.NoError(t, err)
+
+	resp, err := http.Get("http://" + addr + "/debug/pprof/")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NoError(t, stop(context.Background()))
+	_, err = http.Get("http://" + addr + "/debug/pprof/")
+	assert.Error(t, err, "server stops with stop")
+}
+
+func TestStart_PortInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+
+	stop, err := Start(Options{PprofAddr: ln.Addr().String()})
+	assert.ErrorContains(t, err, "failed to listen for pprof")
+	require.NotNil(t, stop)
+	assert.NoError(t, stop(context.Background()))
+}
+
+func TestStart_Disabled(t *testing.T) {
+	stop, err := Start(Options{ServiceName: "omnia-test"})
+	require.NoError(t, err)
+	assert.NoError(t, stop(context.Background()))
+}
+
+func TestStart_PyroscopePush(t *testing.T) {
+	ingest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	defer ingest.Close()
+	t.Setenv(envPyroscopeServerAddress, ingest.URL)
+
+	stop, err := Start(Options{ServiceName: "omnia-test"})
+	require.NoError(t, err)
+	assert.NoError(t, stop(context.Background()))
+}
+
+func TestPyroscopeConfig(t *testing.T) {
+	_, ok := pyroscopeConfig(Options{ServiceName: "omnia-session-api"})
+	assert.False(t, ok, "push is disabled without PYROSCOPE_SERVER_ADDRESS")
+
+	t.Setenv(envPyroscopeServerAddress, "http://pyroscope.observability:4040")
+	t.Setenv(envPyroscopeTenantID, "tenant-a")
+	t.Setenv(envPodNamespace, "agents")
+	t.Setenv(envHostname, "support-bot-7d9f")
+
+	cfg, ok := pyroscopeConfig(Options{ServiceName: "omnia-runtime", Tags: map[string]string{"agent": "support-bot"}})
+	require.True(t, ok)
+	assert.Equal(t, "omnia-runtime", cfg.ApplicationName)
+	assert.Equal(t, "http://pyroscope.observability:4040", cfg.ServerAddress)
+	assert.Equal(t, "tenant-a", cfg.TenantID)
+	assert.Equal(t, map[string]string{
+		"namespace": "agents",
+		"pod":       "support-bot-7d9f",
+		"agent":     "support-bot",
+	}, cfg.Tags)
+
+	t.Setenv(envPyroscopeAppName, "custom")
+	cfg, _ = pyroscopeConfig(Options{ServiceName: "omnia-runtime"})
+	assert.Equal(t, "custom", cfg.ApplicationName)
+}
+
+func TestServiceTags_OmniaNamespaceFallback(t *testing.T) {
+	t.Setenv(envPodNamespace, "")
+	t.Setenv(envHostname, "")
+	t.Setenv(envOmniaNamespace, "tenant-ns")
+	assert.Equal(t, map[string]string{"namespace": "tenant-ns"}, serviceTags(map[string]string{"agent": ""}))
+}
+
+// freeAddr returns a loopback address with a port that was free a moment ago.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+	return addr
+}
@@ -16,6 +16,8 @@ limitations under the License.
 
 package provider
 
+import v1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+
 // HuggingFaceAdditionalConfig returns the PromptKit AdditionalConfig for a
 // HuggingFace inference provider. A non-empty baseURL means a dedicated
 // Inference Endpoint (dedicated=true); empty means the shared serverless
@@ -26,3 +28,44 @@ func HuggingFaceAdditionalConfig(baseURL string) map[string]any {
 	}
 	return map[string]any{"dedicated": true}
 }
+
+// OpenAICompatibleSettings is the PromptKit request shaping derived from an
+// openai-compatible Provider.
+type OpenAICompatibleSettings struct {
+	UnsupportedParams []string
+	Capabilities      []string
+}
+
+// OpenAICompatible derives the PromptKit request shaping for an
+// openai-compatible Provider from spec.openAICompatible and
+// spec.capabilities. Self-hosted models vary widely in what they accept, so
+// the declared capabilities replace PromptKit's OpenAI model defaults.
+func OpenAICompatible(spec *v1alpha1.ProviderSpec) OpenAICompatibleSettings {
+	var tokenLimitParam v1alpha1.TokenLimitParam
+	var unsupported []string
+	if oc := spec.OpenAICompatible; oc != nil {
+		tokenLimitParam = oc.TokenLimitParam
+		unsupported = oc.UnsupportedParams
+	}
+	settings := OpenAICompatibleSettings{
+		UnsupportedParams: openAICompatibleUnsupportedParams(tokenLimitParam, unsupported),
+	}
+	for _, c := range spec.Capabilities {
+		settings.Capabilities = append(settings.Capabilities, string(c))
+	}
+	return settings
+}
+
+// openAICompatibleUnsupportedParams returns the PromptKit unsupported_params
+// list for an openai-compatible provider. PromptKit sends the output token
+// limit as max_completion_tokens unless that field is listed, so it is listed
+// for the default ("" or max_tokens) token-limit param, which makes the
+// client fall back to the max_tokens field self-hosted servers accept.
+// Rejected sampling params are appended as-is.
+func openAICompatibleUnsupportedParams(tokenLimitParam v1alpha1.TokenLimitParam, unsupported []string) []string {
+	params := make([]string, 0, len(unsupported)+1)
+	if tokenLimitParam != v1alpha1.TokenLimitParamMaxCompletionTokens {
+		params = append(params, "max_completion_tokens")
+	}
+	return append(params, unsupported...)
+}
@@ -16,7 +16,12 @@ limitations under the License.
 
 package provider
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	v1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
+)
 
 func TestHuggingFaceAdditionalConfig(t *testing.T) {
 	if got := HuggingFaceAdditionalConfig(""); got != nil {
@@ -27,3 +32,36 @@ func TestHuggingFaceAdditionalConfig(t *testing.T) {
 		t.Fatalf("baseURL set: got %v, want dedicated=true", got)
 	}
 }
+
+func TestOpenAICompatible(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v1alpha1.ProviderSpec
+		want []string
+	}{
+		{"default sends max_tokens", v1alpha1.ProviderSpec{}, []string{"max_completion_tokens"}},
+		{"explicit max_tokens", v1alpha1.ProviderSpec{OpenAICompatible: &v1alpha1.OpenAICompatibleConfig{
+			TokenLimitParam: v1alpha1.TokenLimitParamMaxTokens, UnsupportedParams: []string{"top_p"},
+		}}, []string{"max_completion_tokens", "top_p"}},
+		{"max_completion_tokens", v1alpha1.ProviderSpec{OpenAICompatible: &v1alpha1.OpenAICompatibleConfig{
+			TokenLimitParam: v1alpha1.TokenLimitParamMaxCompletionTokens, UnsupportedParams: []string{"temperature"},
+		}}, []string{"temperature"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OpenAICompatible(&tt.spec)
+			if strings.Join(got.UnsupportedParams, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("UnsupportedParams = %v, want %v", got.UnsupportedParams, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenAICompatible_Capabilities(t *testing.T) {
+	spec := v1alpha1.ProviderSpec{Capabilities: []v1alpha1.ProviderCapability{
+		v1alpha1.ProviderCapabilityVision, v1alpha1.ProviderCapabilityTools,
+	}}
+	if got := OpenAICompatible(&spec).Capabilities; strings.Join(got, ",") != "vision,tools" {
+		t.Errorf("Capabilities = %v, want [vision tools]", got)
+	}
+}
@@ -23,7 +23,7 @@ func APIKeyEnvVarName(providerType string) string {
 	switch Type(providerType) {
 	case TypeClaude:
 		return "ANTHROPIC_API_KEY"
-	case TypeOpenAI:
+	case TypeOpenAI, TypeOpenAICompatible:
 		return "OPENAI_API_KEY"
 	case TypeGemini:
 		return "GEMINI_API_KEY"
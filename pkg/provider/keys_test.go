@@ -29,6 +29,7 @@ func TestAPIKeyEnvVarName(t *testing.T) {
 		{"ollama", ""},
 		{"mock", ""},
 		{"vllm", ""},
+		{"openai-compatible", "OPENAI_API_KEY"},
 		{"voyageai", "VOYAGE_API_KEY"},
 		{"unknown", ""},
 		{"", ""},
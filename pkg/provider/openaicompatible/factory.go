@@ -0,0 +1,67 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package openaicompatible registers the "openai-compatible" PromptKit
+// provider factory: PromptKit's OpenAI chat completions client pointed at a
+// self-hosted server (vLLM, Ollama's /v1, LM Studio, TGI...). Blank-import it
+// wherever Provider CRDs are turned into PromptKit providers, alongside the
+// PromptKit provider packages.
+package openaicompatible
+
+import (
+	"github.com/AltairaLabs/PromptKit/runtime/credentials"
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/PromptKit/runtime/providers/openai"
+
+	pkgprovider "github.com/altairalabs/omnia/pkg/provider"
+)
+
+//nolint:gochecknoinits // init required for provider factory registration, as in PromptKit
+func init() {
+	providers.RegisterProviderFactory(string(pkgprovider.TypeOpenAICompatible), NewProvider)
+}
+
+// NewProvider builds an OpenAI chat completions client for spec.BaseURL.
+// Self-hosted servers implement chat completions but not the Responses API,
+// so the API mode is pinned rather than inferred from the model name. A nil
+// credential becomes a no-op one: the OpenAI client would otherwise fall back
+// to OPENAI_API_KEY from the process env and send it to the self-hosted
+// server.
+//
+//nolint:gocritic // spec size matches providers.ProviderFactory
+func NewProvider(spec providers.ProviderSpec) (providers.Provider, error) {
+	var cred providers.Credential = &credentials.NoOpCredential{}
+	if spec.Credential != nil {
+		cred = spec.Credential
+	}
+	additional := map[string]any{"api_mode": "completions"}
+	for k, v := range spec.AdditionalConfig {
+		if k != "api_mode" {
+			additional[k] = v
+		}
+	}
+	return &openai.ToolProvider{Provider: openai.NewProviderFromConfig(&openai.ProviderConfig{
+		ID:                spec.ID,
+		Model:             spec.Model,
+		BaseURL:           spec.BaseURL,
+		Defaults:          spec.Defaults,
+		IncludeRawOutput:  spec.IncludeRawOutput,
+		Credential:        cred,
+		AdditionalConfig:  additional,
+		UnsupportedParams: spec.UnsupportedParams,
+		Capabilities:      spec.Capabilities,
+	})}, nil
+}
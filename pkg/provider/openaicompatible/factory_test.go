@@ -0,0 +1,82 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openaicompatible
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AltairaLabs/PromptKit/runtime/credentials"
+	"github.com/AltairaLabs/PromptKit/runtime/providers"
+	"github.com/AltairaLabs/PromptKit/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateProviderFromSpec(t *testing.T) {
+	// A key in the process env must never reach a keyless self-hosted server.
+	t.Setenv("OPENAI_API_KEY", "sk-real-openai-key")
+
+	tests := []struct {
+		name       string
+		credential providers.Credential
+		wantAuth   string
+	}{
+		{"keyless", nil, ""},
+		{"with key", credentials.NewAPIKeyCredential("local-token"), "Bearer local-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotAuth, gotPath string
+			var body map[string]any
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAuth = r.Header.Get("Authorization")
+				gotPath = r.URL.Path
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"hi"},` +
+					`"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1}}`))
+			}))
+			defer srv.Close()
+
+			provider, err := providers.CreateProviderFromSpec(providers.ProviderSpec{
+				ID:                "local",
+				Type:              "openai-compatible",
+				Model:             "gpt-5-local",
+				BaseURL:           srv.URL + "/v1",
+				Credential:        tt.credential,
+				UnsupportedParams: []string{"max_completion_tokens", "top_p"},
+				Defaults:          providers.ProviderDefaults{MaxTokens: 64, TopP: 0.9},
+			})
+			require.NoError(t, err)
+
+			_, err = provider.Predict(context.Background(), providers.PredictionRequest{
+				Messages: []types.Message{{Role: "user", Content: "hello"}},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "/v1/chat/completions", gotPath, "chat completions even for gpt-5-like names")
+			assert.Equal(t, tt.wantAuth, gotAuth)
+			assert.Contains(t, body, "max_tokens")
+			assert.NotContains(t, body, "max_completion_tokens")
+			assert.NotContains(t, body, "top_p")
+			assert.NotContains(t, body, "logprobs")
+		})
+	}
+}
@@ -46,6 +46,9 @@ const (
 	// TypeVLLM uses a vLLM-served OpenAI-compatible endpoint.
 	// Requires baseURL. Auth is typically via custom headers.
 	TypeVLLM Type = "vllm"
+	// TypeOpenAICompatible speaks the OpenAI chat completions protocol to a
+	// self-hosted server. Requires baseURL; API credentials are optional.
+	TypeOpenAICompatible Type = "openai-compatible"
 	// TypeVoyageAI uses Voyage AI embedding models. Embedding-role only.
 	// Requires an API key (VOYAGE_API_KEY).
 	TypeVoyageAI Type = "voyageai"
@@ -68,6 +71,7 @@ var ValidTypes = []Type{
 	TypeOllama,
 	TypeMock,
 	TypeVLLM,
+	TypeOpenAICompatible,
 	TypeVoyageAI,
 	TypeCartesia,
 	TypeElevenLabs,
@@ -92,7 +96,7 @@ func (t Type) String() string {
 // before calling this.
 func (t Type) RequiresCredentials() bool {
 	switch t {
-	case TypeOllama, TypeMock, TypeVLLM:
+	case TypeOllama, TypeMock, TypeVLLM, TypeOpenAICompatible:
 		return false
 	default:
 		return true
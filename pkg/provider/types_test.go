@@ -41,6 +41,7 @@ func TestType_IsValid(t *testing.T) {
 		{"ollama", TypeOllama, true},
 		{"mock", TypeMock, true},
 		{"vllm", TypeVLLM, true},
+		{"openai-compatible", TypeOpenAICompatible, true},
 		{"voyageai", TypeVoyageAI, true},
 		{"invalid", Type("invalid"), false},
 		{"empty", Type(""), false},
@@ -70,6 +71,7 @@ func TestType_RequiresCredentials(t *testing.T) {
 		{"ollama", TypeOllama, false},
 		{"mock", TypeMock, false},
 		{"vllm", TypeVLLM, false},
+		{"openai-compatible", TypeOpenAICompatible, false},
 		{"voyageai", TypeVoyageAI, true},
 		{"cartesia", TypeCartesia, true},
 		{"elevenlabs", TypeElevenLabs, true},
@@ -87,7 +89,7 @@ func TestType_RequiresCredentials(t *testing.T) {
 func TestValidTypes_Complete(t *testing.T) {
 	// Ensure ValidTypes contains all defined constants
 	expected := []Type{
-		TypeClaude, TypeOpenAI, TypeGemini, TypeOllama, TypeMock, TypeVLLM,
+		TypeClaude, TypeOpenAI, TypeGemini, TypeOllama, TypeMock, TypeVLLM, TypeOpenAICompatible,
 		TypeVoyageAI, TypeCartesia, TypeElevenLabs, TypeImagen, TypeHuggingFace,
 	}
 	assert.ElementsMatch(t, expected, ValidTypes)
@@ -0,0 +1,203 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package readiness implements the /readyz endpoint shared by Omnia binaries.
+// Every configured dependency is checked on each probe and reported in a JSON
+// body. A failing critical dependency makes the binary not ready (503); a
+// failing optional one leaves it degraded but still serving (200).
+package readiness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Overall and per-dependency statuses reported in the /readyz body.
+const (
+	// StatusOK means every dependency is reachable.
+	StatusOK = "ok"
+	// StatusDegraded means an optional dependency failed but the binary can
+	// still serve, for example without its cache.
+	StatusDegraded = "degraded"
+	// StatusUnavailable means a critical dependency failed.
+	StatusUnavailable = "unavailable"
+)
+
+// DefaultTimeout bounds each dependency check. Checks run concurrently, so it
+// also bounds the whole probe.
+const DefaultTimeout = 3 * time.Second
+
+// Check is a single dependency check.
+type Check struct {
+	// Name identifies the dependency in the report, e.g. "postgres".
+	Name string
+	// Critical marks a dependency the binary cannot serve without. When a
+	// critical check fails /readyz returns 503; when an optional one fails
+	// it returns 200 with status degraded.
+	Critical bool
+	// Run returns nil when the dependency is usable.
+	Run func(ctx context.Context) error
+}
+
+// Critical returns a critical Check.
+func Critical(name string, run func(ctx context.Context) error) Check {
+	return Check{Name: name, Critical: true, Run: run}
+}
+
+// Optional returns a non-critical Check.
+func Optional(name string, run func(ctx context.Context) error) Check {
+	return Check{Name: name, Run: run}
+}
+
+// Dependency is the outcome of one Check.
+type Dependency struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Critical  bool   `json:"critical"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latencyMs"`
+}
+
+// Report is the /readyz response body.
+type Report struct {
+	Status       string       `json:"status"`
+	Dependencies []Dependency `json:"dependencies"`
+}
+
+// HTTPStatus returns the probe status code for the report: 503 when a critical
+// dependency failed, otherwise 200.
+func (r Report) HTTPStatus() int {
+	if r.Status == StatusUnavailable {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+// Checker runs a set of dependency checks. Checks can be added after the
+// health server has started, for binaries that serve probes while they are
+// still connecting to their dependencies. It is safe for concurrent use.
+type Checker struct {
+	mu      sync.RWMutex
+	checks  []Check
+	timeout time.Duration
+}
+
+// NewChecker returns a Checker for checks, each bounded by DefaultTimeout.
+func NewChecker(checks ...Check) *Checker {
+	return &Checker{checks: checks, timeout: DefaultTimeout}
+}
+
+// Add registers more checks.
+func (c *Checker) Add(checks ...Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, checks...)
+}
+
+// Evaluate runs every check concurrently and returns the combined report.
+// Dependencies are reported in registration order.
+func (c *Checker) Evaluate(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := append([]Check(nil), c.checks...)
+	c.mu.RUnlock()
+
+	deps := make([]Dependency, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			deps[i] = c.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Dependencies: deps}
+	for _, dep := range deps {
+		switch {
+		case dep.Status == StatusOK:
+		case dep.Critical:
+			report.Status = StatusUnavailable
+		case report.Status == StatusOK:
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+// run executes one check under the checker's timeout.
+func (c *Checker) run(ctx context.Context, check Check) Dependency {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	dep := Dependency{Name: check.Name, Status: StatusOK, Critical: check.Critical}
+	start := time.Now()
+	err := check.Run(ctx)
+	dep.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		dep.Error = err.Error()
+		dep.Status = StatusDegraded
+		if check.Critical {
+			dep.Status = StatusUnavailable
+		}
+	}
+	return dep
+}
+
+// ServeHTTP implements http.Handler, writing the report as JSON.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	WriteReport(w, c.Evaluate(r.Context()))
+}
+
+// WriteReport writes report as a JSON /readyz response with its HTTP status,
+// for handlers that add their own gates or logging around Evaluate.
+func WriteReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(report.HTTPStatus())
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// HTTPGet returns a check function that GETs url and expects a 2xx response,
+// for dependencies that expose a health endpoint. A nil client uses
+// http.DefaultClient; the check's context bounds the request.
+func HTTPGet(client *http.Client, url string) func(ctx context.Context) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ok(context.Context) error   { return nil }
+func fail(context.Context) error { return errors.New("connection refused") }
+
+func TestChecker_Evaluate(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     []Check
+		wantStatus string
+		wantCode   int
+	}{
+		{name: "no checks", wantStatus: StatusOK, wantCode: http.StatusOK},
+		{
+			name:       "all ok",
+			checks:     []Check{Critical("postgres", ok), Optional("redis", ok)},
+			wantStatus: StatusOK,
+			wantCode:   http.StatusOK,
+		},
+		{
+			name:       "optional failure degrades",
+			checks:     []Check{Critical("postgres", ok), Optional("redis", fail)},
+			wantStatus: StatusDegraded,
+			wantCode:   http.StatusOK,
+		},
+		{
+			name:       "critical failure is unavailable",
+			checks:     []Check{Critical("postgres", fail), Optional("redis", fail)},
+			wantStatus: StatusUnavailable,
+			wantCode:   http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewChecker(tt.checks...).Evaluate(context.Background())
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Equal(t, tt.wantCode, report.HTTPStatus())
+			require.Len(t, report.Dependencies, len(tt.checks))
+			for i, check := range tt.checks {
+				assert.Equal(t, check.Name, report.Dependencies[i].Name)
+			}
+		})
+	}
+}
+
+func TestChecker_DependencyDetail(t *testing.T) {
+	report := NewChecker(Critical("postgres", fail), Optional("redis", fail)).Evaluate(context.Background())
+
+	assert.Equal(t, Dependency{Name: "postgres", Status: StatusUnavailable, Critical: true,
+		Error: "connection refused", LatencyMs: report.Dependencies[0].LatencyMs}, report.Dependencies[0])
+	assert.Equal(t, StatusDegraded, report.Dependencies[1].Status)
+	assert.False(t, report.Dependencies[1].Critical)
+}
+
+func TestChecker_Timeout(t *testing.T) {
+	c := NewChecker(Critical("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	c.timeout = 10 * time.Millisecond
+
+	report := c.Evaluate(context.Background())
+	assert.Equal(t, StatusUnavailable, report.Status)
+	assert.Contains(t, report.Dependencies[0].Error, "deadline exceeded")
+}
+
+func TestChecker_Add(t *testing.T) {
+	c := NewChecker()
+	assert.Equal(t, StatusOK, c.Evaluate(context.Background()).Status)
+
+	c.Add(Critical("redis", fail))
+	assert.Equal(t, StatusUnavailable, c.Evaluate(context.Background()).Status)
+}
+
+func TestChecker_ServeHTTP(t *testing.T) {
+	c := NewChecker(Critical("postgres", ok), Optional("cold-archive", fail))
+	w := httptest.NewRecorder()
+	c.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var report Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, "cold-archive", report.Dependencies[1].Name)
+	assert.Equal(t, "connection refused", report.Dependencies[1].Error)
+}
+
+func TestHTTPGet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	assert.NoError(t, HTTPGet(nil, srv.URL+"/healthz")(context.Background()))
+	assert.ErrorContains(t, HTTPGet(srv.Client(), srv.URL+"/other")(context.Background()), "unexpected status 500")
+	assert.Error(t, HTTPGet(nil, "http://127.0.0.1:1/healthz")(context.Background()))
+}
@@ -28,6 +28,8 @@ const (
 	CapabilityConsentGrants = "consent_grants"    // consent grant propagation
 	CapabilityMediaStorage  = "media_storage_ref" // storage_ref attachment resolution
 	CapabilityInterruption  = "interruption"      // realtime voice interruption
+	CapabilityEmbed         = "embed"             // Embed RPC (embedding vectors)
+	CapabilityProgress      = "progress"          // Progress frames during a turn
 )
 
 // KnownCapabilities returns the capability names this contract build defines.
@@ -41,5 +43,7 @@ func KnownCapabilities() []string {
 		CapabilityConsentGrants,
 		CapabilityMediaStorage,
 		CapabilityInterruption,
+		CapabilityEmbed,
+		CapabilityProgress,
 	}
 }
@@ -30,7 +30,8 @@ func TestKnownCapabilities_ContainsWellKnownNames(t *testing.T) {
 	}
 	for _, want := range []string{
 		CapabilityInvoke, CapabilityDuplexAudio, CapabilityClientTools,
-		CapabilityConsentGrants, CapabilityMediaStorage, CapabilityInterruption,
+		CapabilityConsentGrants, CapabilityMediaStorage, CapabilityInterruption, CapabilityEmbed,
+		CapabilityProgress,
 	} {
 		if !found[want] {
 			t.Errorf("KnownCapabilities missing %q", want)
@@ -30,6 +30,13 @@ type ConversationProber interface {
 	HasConversation(ctx context.Context, sessionID string) ResumeState
 }
 
+// Embedder is an optional Handler extension enabling the Embed RPC. A Handler
+// that implements Embedder MUST advertise contract.CapabilityEmbed; one that
+// does not MUST NOT advertise it.
+type Embedder interface {
+	Embed(ctx context.Context, req EmbedRequest) (EmbedResponse, error)
+}
+
 // Emitter is the clean output surface the SDK marshals to ServerMessage frames.
 type Emitter interface {
 	// Chunk streams a partial-text fragment. Empty strings are dropped.
@@ -42,3 +49,12 @@ type Emitter interface {
 	// Done ends the turn.
 	Done(d Done) error
 }
+
+// ProgressEmitter is an optional Emitter extension reporting what a turn is
+// doing, so clients can show more than a spinner. The SDK's Emitter
+// implements it and drops progress for a turn whose Turn.Progress is false,
+// so a Handler may report unconditionally. A runtime that sends progress
+// should advertise contract.CapabilityProgress.
+type ProgressEmitter interface {
+	Progress(p Progress) error
+}
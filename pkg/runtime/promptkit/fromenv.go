@@ -19,6 +19,7 @@ package promptkit
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"go.opentelemetry.io/otel"
@@ -28,6 +29,7 @@ import (
 
 	pkruntime "github.com/altairalabs/omnia/internal/runtime"
 	"github.com/altairalabs/omnia/pkg/k8s"
+	"github.com/altairalabs/omnia/pkg/profiling"
 )
 
 // FromEnv loads the operator-injected OMNIA_* configuration and constructs a
@@ -71,9 +73,37 @@ func FromEnv(opts ...Option) (*Runtime, error) {
 		return nil, err
 	}
 	rt.reportStartup(context.Background())
+	rt.startProfiling()
 	return rt, nil
 }
 
+// runtimePprofAddr is the runtime container's default pprof address. It
+// differs from the facade's because both containers share the agent pod's
+// network namespace.
+const runtimePprofAddr = "localhost:6061"
+
+// startProfiling serves pprof and, when configured, pushes profiles to
+// Pyroscope. It is part of FromEnv rather than New because the pprof port is
+// per-container: runtimes embedded in tests or other binaries skip it.
+// Profiling is diagnostic, so a failure logs and the runtime still serves.
+func (r *Runtime) startProfiling() {
+	stop, err := profiling.Start(profiling.Options{
+		ServiceName: "omnia-runtime",
+		PprofAddr:   runtimePprofAddr,
+		Tags:        map[string]string{"agent": r.cfg.AgentName},
+	})
+	if err != nil {
+		r.log.Error(err, "failed to start profiling")
+	}
+	r.cleanups = append(r.cleanups, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := stop(ctx); err != nil {
+			r.log.Error(err, "failed to stop profiling")
+		}
+	})
+}
+
 // reportStartup validates pack content and, when the runtime is operator-managed
 // (agent name + namespace known), self-reports pack-validation and capabilities
 // to the AgentRuntime status. It is part of the FromEnv (in-cluster) entry point
@@ -25,6 +25,7 @@ import (
 
 	pkruntime "github.com/altairalabs/omnia/internal/runtime"
 	"github.com/altairalabs/omnia/internal/tracing"
+	"github.com/altairalabs/omnia/pkg/apierror"
 	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
 )
 
@@ -32,15 +33,15 @@ import (
 // images fit on the facade→runtime channel.
 const maxGRPCMsgSize = 16 * 1024 * 1024
 
-// buildGRPCServer constructs the runtime gRPC server with the policy interceptors
-// and, optionally, the OpenTelemetry stats handler. Factored out so wiring tests
+// buildGRPCServer constructs the runtime gRPC server with the policy and error
+// model interceptors and, optionally, the OpenTelemetry stats handler. Factored out so wiring tests
 // can assert that the interceptors are installed on the real server (#714).
 func buildGRPCServer(tracingProvider *tracing.Provider) *grpc.Server {
 	opts := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(maxGRPCMsgSize),
 		grpc.MaxSendMsgSize(maxGRPCMsgSize),
-		grpc.ChainUnaryInterceptor(pkruntime.PolicyUnaryServerInterceptor()),
-		grpc.ChainStreamInterceptor(pkruntime.PolicyStreamServerInterceptor()),
+		grpc.ChainUnaryInterceptor(pkruntime.PolicyUnaryServerInterceptor(), apierror.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(pkruntime.PolicyStreamServerInterceptor(), apierror.StreamServerInterceptor()),
 	}
 	if tracingProvider != nil {
 		opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler(
@@ -17,14 +17,19 @@ limitations under the License.
 package promptkit
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 
+	pkruntime "github.com/altairalabs/omnia/internal/runtime"
 	"github.com/altairalabs/omnia/internal/schema"
+	pkgprovider "github.com/altairalabs/omnia/pkg/provider"
+	"github.com/altairalabs/omnia/pkg/readiness"
 )
 
 // okBody is the response body for a passing health/readiness probe.
@@ -50,23 +55,72 @@ func packReadyError(validator *schema.SchemaValidator, packPath string) error {
 	return nil
 }
 
+// providerCredentialError returns an error when the default provider needs an
+// API key and neither the resolved key nor its env var is set. It checks
+// presence only: whether the provider accepts the key is reported by the
+// Provider's CredentialValid condition, and probing the provider on every
+// readiness check would spend quota. Platform-hosted providers authenticate
+// through the cloud SDK chain and openai-compatible servers are often keyless,
+// so both are skipped.
+func providerCredentialError(cfg *pkruntime.Config) error {
+	if cfg.PlatformType != "" || cfg.ProviderType == string(pkgprovider.TypeOpenAICompatible) {
+		return nil
+	}
+	env := pkgprovider.APIKeyEnvVarName(cfg.ProviderType)
+	if env == "" || cfg.ProviderAPIKey != "" || os.Getenv(env) != "" {
+		return nil
+	}
+	return fmt.Errorf("no API key for %s provider (%s unset)", cfg.ProviderType, env)
+}
+
+// newReadinessChecker returns the dependencies /readyz checks. The mounted pack
+// and, when conversation context lives in Redis, the context store are
+// critical. A missing provider credential only degrades the runtime: it keeps
+// serving, since a fallback provider or a cached response may still answer.
+// The returned cleanup closes the context-store client, which is separate from
+// the state store's so a probe never competes with conversation traffic.
+func newReadinessChecker(cfg *pkruntime.Config, validator *schema.SchemaValidator) (*readiness.Checker, func(), error) {
+	checker := readiness.NewChecker(
+		readiness.Critical("promptpack", func(context.Context) error {
+			return packReadyError(validator, cfg.PromptPackPath)
+		}),
+	)
+	cleanup := func() {}
+	if cfg.ContextType == pkruntime.ContextTypeRedis {
+		opts, err := redis.ParseURL(cfg.ContextURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse context store URL: %w", err)
+		}
+		client := redis.NewClient(opts)
+		cleanup = func() { _ = client.Close() }
+		checker.Add(readiness.Critical("context-store", func(ctx context.Context) error {
+			return client.Ping(ctx).Err()
+		}))
+	}
+	if cfg.ProviderType != "" {
+		checker.Add(readiness.Optional("provider-credential", func(context.Context) error {
+			return providerCredentialError(cfg)
+		}))
+	}
+	return checker, cleanup, nil
+}
+
 // healthMux builds the runtime's HTTP health/metrics handler: a liveness probe
-// (/healthz), a readiness probe (/readyz) that re-validates the mounted pack on
-// every call, and /metrics served from the merged default + collector gatherers.
+// (/healthz), a readiness probe (/readyz) that checks the mounted pack and the
+// runtime's dependencies on every call, and /metrics served from the merged
+// default + collector gatherers.
 func (r *Runtime) healthMux() *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte(okBody))
 	})
-	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
-		if err := packReadyError(r.readyValidator, r.cfg.PromptPackPath); err != nil {
-			r.log.V(1).Info("readiness check failed", "error", err.Error())
-			http.Error(w, err.Error(), http.StatusServiceUnavailable)
-			return
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, req *http.Request) {
+		report := r.ready.Evaluate(req.Context())
+		if report.Status != readiness.StatusOK {
+			r.log.V(1).Info("readiness check failed", "status", report.Status, "dependencies", report.Dependencies)
 		}
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(okBody))
+		readiness.WriteReport(w, report)
 	})
 	mux.Handle("/metrics", promhttp.HandlerFor(r.gatherers, promhttp.HandlerOpts{}))
 	return mux
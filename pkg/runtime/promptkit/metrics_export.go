@@ -0,0 +1,57 @@
+/*
+Copyright 2026 Altaira Labs.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promptkit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+
+	pkruntime "github.com/altairalabs/omnia/internal/runtime"
+	"github.com/altairalabs/omnia/pkg/metrics"
+)
+
+// metricsExportShutdownTimeout bounds the final OTLP metric push on Close.
+const metricsExportShutdownTimeout = 5 * time.Second
+
+// startMetricsExport pushes the metrics served on /metrics to an OTLP
+// collector when the standard OTEL_* env vars enable it, and returns the
+// cleanup that flushes and stops the export. Like tracing, export is
+// optional: a failure logs and returns nil so the runtime still serves.
+func startMetricsExport(cfg *pkruntime.Config, gatherer prometheus.Gatherer, log logr.Logger) func() {
+	if !metrics.OTLPExportEnabled() {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	shutdown, err := metrics.StartOTLPExport(ctx, fmt.Sprintf("omnia-runtime-%s", cfg.AgentName), gatherer)
+	if err != nil {
+		log.Error(err, "failed to initialize OTLP metric export")
+		return nil
+	}
+	log.Info("OTLP metric export initialized")
+	return func() {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), metricsExportShutdownTimeout)
+		defer shutdownCancel()
+		if err := shutdown(shutdownCtx); err != nil {
+			log.Error(err, "failed to shutdown OTLP metric export")
+		}
+	}
+}
@@ -29,11 +29,16 @@ import (
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
+	"github.com/AltairaLabs/PromptKit/runtime/events"
 	"github.com/AltairaLabs/PromptKit/sdk"
 
 	pkruntime "github.com/altairalabs/omnia/internal/runtime"
+	"github.com/altairalabs/omnia/internal/runtime/budget"
+	"github.com/altairalabs/omnia/internal/runtime/delegation"
+	"github.com/altairalabs/omnia/internal/runtime/resilience"
 )
 
 // freePort returns an OS-assigned free TCP port. A tiny race window exists
@@ -99,6 +104,19 @@ func TestNew_StateStoreError(t *testing.T) {
 	require.Error(t, err)
 }
 
+// TestNew_StructuredOutputWithoutSchema proves structured output fails
+// construction when the prompt declares no response schema, rather than
+// serving free text to clients that expect JSON.
+func TestNew_StructuredOutputWithoutSchema(t *testing.T) {
+	t.Setenv("OMNIA_MEDIA_STORAGE_TYPE", "")
+	cfg := mockConfig(t)
+	cfg.StructuredOutputEnabled = true
+	cfg.StructuredOutputMaxRepairs = 1
+
+	_, err := New(cfg, WithLogger(logr.Discard()))
+	require.ErrorContains(t, err, "structured output")
+}
+
 func TestNewStateStore(t *testing.T) {
 	log := logr.Discard()
 
@@ -133,6 +151,156 @@ func TestNewStateStore(t *testing.T) {
 	})
 }
 
+func TestResponseCacheServerOpts(t *testing.T) {
+	log := logr.Discard()
+
+	t.Run("disabled yields nil", func(t *testing.T) {
+		require.Nil(t, responseCacheServerOpts(&pkruntime.Config{}, prometheus.NewRegistry(), log))
+	})
+
+	t.Run("memory context store caches in process", func(t *testing.T) {
+		opts := responseCacheServerOpts(&pkruntime.Config{
+			ContextType:          pkruntime.ContextTypeMemory,
+			ResponseCacheEnabled: true,
+		}, prometheus.NewRegistry(), log)
+		require.Len(t, opts, 1)
+	})
+
+	t.Run("redis context store shares the cache", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		opts := responseCacheServerOpts(&pkruntime.Config{
+			ContextType:          pkruntime.ContextTypeRedis,
+			ContextURL:           "redis://" + mr.Addr(),
+			ResponseCacheEnabled: true,
+		}, prometheus.NewRegistry(), log)
+		require.Len(t, opts, 1)
+	})
+
+	t.Run("unreachable redis disables the cache", func(t *testing.T) {
+		require.Nil(t, responseCacheServerOpts(&pkruntime.Config{
+			ContextType:          pkruntime.ContextTypeRedis,
+			ContextURL:           "redis://127.0.0.1:1", // nothing listens on port 1
+			ResponseCacheEnabled: true,
+		}, prometheus.NewRegistry(), log))
+	})
+}
+
+func TestKnowledgeServerOpts(t *testing.T) {
+	log := logr.Discard()
+	ctx := context.Background()
+
+	opts, err := knowledgeServerOpts(ctx, &pkruntime.Config{}, log)
+	require.NoError(t, err)
+	require.Nil(t, opts, "disabled yields nil")
+
+	opts, err = knowledgeServerOpts(ctx, &pkruntime.Config{
+		KnowledgeEnabled:    true,
+		KnowledgeStoreType:  "qdrant",
+		KnowledgeCollection: "docs",
+		KnowledgeURL:        "http://qdrant:6333",
+		KnowledgeTopK:       4,
+	}, log)
+	require.NoError(t, err)
+	require.Len(t, opts, 1)
+
+	_, err = knowledgeServerOpts(ctx, &pkruntime.Config{
+		KnowledgeEnabled:   true,
+		KnowledgeStoreType: "qdrant",
+	}, log)
+	require.ErrorContains(t, err, "no URL configured", "a missing secret fails startup")
+}
+
+func TestGuardrailServerOpts(t *testing.T) {
+	require.Len(t, guardrailServerOpts(&pkruntime.Config{}, prometheus.NewRegistry()), 1,
+		"wired without spec.guardrails for the pack's own hooks")
+}
+
+func TestBudgetServerOpts(t *testing.T) {
+	log := logr.Discard()
+	opts, err := budgetServerOpts(&pkruntime.Config{}, prometheus.NewRegistry(), log)
+	require.NoError(t, err)
+	require.Nil(t, opts)
+
+	opts, err = budgetServerOpts(&pkruntime.Config{
+		AgentName:     "faq",
+		Namespace:     "test",
+		BudgetSession: budget.Limit{MaxTokens: 1000},
+	}, prometheus.NewRegistry(), log)
+	require.NoError(t, err)
+	require.Len(t, opts, 1)
+
+	opts, err = budgetServerOpts(&pkruntime.Config{
+		BudgetAgent: budget.Limit{MaxCostUSD: 10},
+		ContextType: pkruntime.ContextTypeRedis,
+		ContextURL:  "not a redis url",
+	}, prometheus.NewRegistry(), log)
+	require.NoError(t, err)
+	require.Len(t, opts, 1, "an unreachable Redis leaves the agent budget counted per replica")
+
+	_, err = budgetServerOpts(&pkruntime.Config{
+		WorkspaceName:        "team-a",
+		BudgetWorkspaceDaily: budget.Limit{MaxCostUSD: 50},
+		ContextType:          pkruntime.ContextTypeRedis,
+		ContextURL:           "not a redis url",
+	}, prometheus.NewRegistry(), log)
+	require.Error(t, err, "a workspace budget is never counted per replica")
+}
+
+func TestBudgetLedger(t *testing.T) {
+	log := logr.Discard()
+	mr := miniredis.RunT(t)
+	session := budget.Config{Session: budget.Limit{MaxTokens: 1000}}
+	agent := budget.Config{Agent: budget.Limit{MaxCostUSD: 10}}
+	workspace := budget.Config{Workspace: "team-a", WorkspaceMonthly: budget.Limit{MaxCostUSD: 500}}
+	ledger := func(cfg *pkruntime.Config, bc budget.Config) budget.Ledger {
+		l, err := budgetLedger(cfg, bc, log)
+		require.NoError(t, err)
+		return l
+	}
+
+	require.IsType(t, &budget.MemoryLedger{}, ledger(&pkruntime.Config{BudgetRedisURL: "redis://" + mr.Addr()}, session),
+		"session budgets never need a shared ledger")
+	require.IsType(t, &budget.RedisLedger{}, ledger(&pkruntime.Config{BudgetRedisURL: "redis://" + mr.Addr()}, agent),
+		"the budget Redis is used even with an in-memory context store")
+	require.IsType(t, &budget.RedisLedger{}, ledger(&pkruntime.Config{
+		ContextType: pkruntime.ContextTypeRedis,
+		ContextURL:  "redis://" + mr.Addr(),
+	}, workspace), "the context store's Redis is the fallback")
+	require.IsType(t, &budget.MemoryLedger{}, ledger(&pkruntime.Config{}, agent))
+
+	_, err := budgetLedger(&pkruntime.Config{}, workspace, log)
+	require.ErrorContains(t, err, "OMNIA_BUDGET_REDIS_URL")
+	_, err = budgetLedger(&pkruntime.Config{BudgetRedisURL: "redis://127.0.0.1:1"}, workspace, log)
+	require.Error(t, err, "an unreachable budget Redis fails workspace budgets closed")
+}
+
+func TestDelegationServerOpts(t *testing.T) {
+	log := logr.Discard()
+	require.Nil(t, delegationServerOpts(&pkruntime.Config{}, log))
+	require.Len(t, delegationServerOpts(&pkruntime.Config{
+		AgentName: "concierge",
+		Delegates: []delegation.Delegate{{Name: "researcher", Agent: "research-agent", URL: "http://research-agent:8080"}},
+	}, log), 1)
+}
+
+func TestProviderResilienceServerOpts(t *testing.T) {
+	log := logr.Discard()
+	require.Nil(t, providerResilienceServerOpts(&pkruntime.Config{}, prometheus.NewRegistry(), log))
+
+	reg := prometheus.NewRegistry()
+	opts := providerResilienceServerOpts(&pkruntime.Config{
+		AgentName:          "faq",
+		Namespace:          "test",
+		ProviderRefName:    "claude-prod",
+		ProviderResilience: &resilience.Config{FailureThreshold: 5},
+	}, reg, log)
+	require.Len(t, opts, 1)
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1, "the breaker state gauge is exported from the start")
+	require.Equal(t, "omnia_runtime_provider_circuit_breaker_state", families[0].GetName())
+}
+
 func TestLoadEvalDefs(t *testing.T) {
 	log := logr.Discard()
 
@@ -230,6 +398,69 @@ func TestNewTracingProvider(t *testing.T) {
 	})
 }
 
+func TestStartMetricsExport(t *testing.T) {
+	t.Run("disabled yields no cleanup", func(t *testing.T) {
+		t.Setenv("OTEL_METRICS_EXPORTER", "none")
+		require.Nil(t, startMetricsExport(&pkruntime.Config{AgentName: "a"}, prometheus.NewRegistry(), logr.Discard()))
+	})
+
+	t.Run("enabled yields a cleanup", func(t *testing.T) {
+		t.Setenv("OTEL_METRICS_EXPORTER", "otlp")
+		t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "grpc")
+		t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4317")
+		t.Setenv("OTEL_EXPORTER_OTLP_TIMEOUT", "100")
+		cleanup := startMetricsExport(&pkruntime.Config{AgentName: "a"}, prometheus.NewRegistry(), logr.Discard())
+		require.NotNil(t, cleanup)
+		cleanup()
+	})
+
+	t.Run("bad protocol is logged and swallowed", func(t *testing.T) {
+		t.Setenv("OTEL_METRICS_EXPORTER", "otlp")
+		t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/json")
+		require.Nil(t, startMetricsExport(&pkruntime.Config{AgentName: "a"}, prometheus.NewRegistry(), logr.Discard()))
+	})
+}
+
+// TestNewCollector_ProviderUsageLabels pins the per-agent labels on the LLM
+// token, cost and latency metrics, which dashboards group by.
+func TestNewCollector_ProviderUsageLabels(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	collector := newCollector(&pkruntime.Config{AgentName: "a1", Namespace: "ns1", PromptPackName: "pack"}, reg)
+	collector.Bind(nil).OnEvent(&events.Event{
+		Type: events.EventProviderCallCompleted,
+		Data: &events.ProviderCallCompletedData{
+			Provider: "openai", Model: "gpt-4o", Duration: time.Second,
+			InputTokens: 100, OutputTokens: 20, Cost: 0.5, Source: "agent",
+		},
+	})
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	want := map[string]bool{
+		"omnia_provider_input_tokens_total":       false,
+		"omnia_provider_output_tokens_total":      false,
+		"omnia_provider_cost_total":               false,
+		"omnia_provider_request_duration_seconds": false,
+	}
+	for _, fam := range families {
+		if _, ok := want[fam.GetName()]; !ok {
+			continue
+		}
+		want[fam.GetName()] = true
+		labels := map[string]string{}
+		for _, l := range fam.GetMetric()[0].GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		require.Equal(t, "a1", labels["agent"], fam.GetName())
+		require.Equal(t, "ns1", labels["namespace"], fam.GetName())
+		require.Equal(t, "openai", labels["provider"], fam.GetName())
+		require.Equal(t, "gpt-4o", labels["model"], fam.GetName())
+	}
+	for name, found := range want {
+		require.True(t, found, "missing %s", name)
+	}
+}
+
 // TestReportStartup_NoAgentIdentitySkipsK8s covers reportStartup's guard: with
 // no agent name/namespace it validates the pack and returns without touching
 // Kubernetes.
@@ -7,14 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package promptkit
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
+	"github.com/alicebob/miniredis/v2"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	pkruntime "github.com/altairalabs/omnia/internal/runtime"
 	"github.com/altairalabs/omnia/internal/schema"
+	"github.com/altairalabs/omnia/pkg/readiness"
 )
 
 const promptID = "default"
@@ -78,3 +83,66 @@ func TestPackReadyError(t *testing.T) {
 		}
 	})
 }
+
+func TestProviderCredentialError(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	tests := []struct {
+		name    string
+		cfg     pkruntime.Config
+		wantErr bool
+	}{
+		{name: "keyless provider", cfg: pkruntime.Config{ProviderType: "ollama"}},
+		{name: "resolved key", cfg: pkruntime.Config{ProviderType: "claude", ProviderAPIKey: "sk-test"}},
+		{name: "platform hosted", cfg: pkruntime.Config{ProviderType: "claude", PlatformType: "bedrock"}},
+		{name: "openai-compatible", cfg: pkruntime.Config{ProviderType: "openai-compatible"}},
+		{name: "missing key", cfg: pkruntime.Config{ProviderType: "claude"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := providerCredentialError(&tt.cfg); (err != nil) != tt.wantErr {
+				t.Fatalf("providerCredentialError() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("key from env", func(t *testing.T) {
+		t.Setenv("ANTHROPIC_API_KEY", "sk-env")
+		if err := providerCredentialError(&pkruntime.Config{ProviderType: "claude"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestNewReadinessChecker(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	mr := miniredis.RunT(t)
+	cfg := &pkruntime.Config{
+		PromptPackPath: writePackFile(t, true),
+		ContextType:    pkruntime.ContextTypeRedis,
+		ContextURL:     "redis://" + mr.Addr(),
+		ProviderType:   "claude",
+	}
+	checker, cleanup, err := newReadinessChecker(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cleanup()
+
+	report := checker.Evaluate(context.Background())
+	if report.Status != readiness.StatusDegraded {
+		t.Fatalf("missing credential should degrade, got %+v", report)
+	}
+	names := make([]string, 0, len(report.Dependencies))
+	for _, dep := range report.Dependencies {
+		names = append(names, dep.Name)
+	}
+	if want := []string{"promptpack", "context-store", "provider-credential"}; !slices.Equal(names, want) {
+		t.Fatalf("dependencies = %v, want %v", names, want)
+	}
+
+	mr.Close()
+	if report := checker.Evaluate(context.Background()); report.Status != readiness.StatusUnavailable {
+		t.Fatalf("unreachable context store should be unavailable, got %+v", report)
+	}
+}
@@ -48,6 +48,7 @@ import (
 	"github.com/altairalabs/omnia/internal/schema"
 	"github.com/altairalabs/omnia/internal/session"
 	"github.com/altairalabs/omnia/internal/tracing"
+	"github.com/altairalabs/omnia/pkg/readiness"
 	"github.com/altairalabs/omnia/pkg/session/httpclient"
 )
 
@@ -72,15 +73,15 @@ const (
 // with New (explicit config) or FromEnv (operator-injected config), then call
 // Serve to run it and Close to release its resources.
 type Runtime struct {
-	server         *pkruntime.Server
-	cfg            *pkruntime.Config
-	log            logr.Logger
-	tracing        *tracing.Provider
-	gatherers      prometheus.Gatherers
-	readyValidator *schema.SchemaValidator
-	evalDefs       []pkevals.EvalDef
-	cleanups       []func()
-	logCleanup     func()
+	server     *pkruntime.Server
+	cfg        *pkruntime.Config
+	log        logr.Logger
+	tracing    *tracing.Provider
+	gatherers  prometheus.Gatherers
+	ready      *readiness.Checker
+	evalDefs   []pkevals.EvalDef
+	cleanups   []func()
+	logCleanup func()
 }
 
 // buildDeps groups the constructed dependencies buildServerOpts folds into the
@@ -91,6 +92,20 @@ type buildDeps struct {
 	store     statestore.Store
 	tracing   *tracing.Provider
 	mediaOpts []pkruntime.ServerOption
+	cacheOpts []pkruntime.ServerOption
+	// resilienceOpts wires the provider resilience policy, whose metrics
+	// register on the runtime's collector registry.
+	resilienceOpts []pkruntime.ServerOption
+	// tlsOpts wires the provider client certificate.
+	tlsOpts []pkruntime.ServerOption
+	// knowledgeOpts wires the knowledge vector store, closed with the server.
+	knowledgeOpts []pkruntime.ServerOption
+	// guardrailOpts wires spec.guardrails, whose check metrics register on
+	// the runtime's collector registry.
+	guardrailOpts []pkruntime.ServerOption
+	// budgetOpts wires spec.budget, whose metrics register on the runtime's
+	// collector registry.
+	budgetOpts []pkruntime.ServerOption
 }
 
 // New constructs a Runtime from an explicit config. It performs no process-wide
@@ -124,34 +139,92 @@ func newFromBuilder(cfg *pkruntime.Config, b *builder, logCleanup func()) (*Runt
 		return nil, fmt.Errorf("state store: %w", err)
 	}
 
+	knowledgeOpts, err := knowledgeServerOpts(context.Background(), cfg, log)
+	if err != nil {
+		runCleanup(logCleanup)
+		return nil, fmt.Errorf("knowledge: %w", err)
+	}
+
+	budgetOpts, err := budgetServerOpts(cfg, collectorRegistry, log)
+	if err != nil {
+		runCleanup(logCleanup)
+		return nil, fmt.Errorf("budget: %w", err)
+	}
+
+	tlsOpts, tlsCleanup, err := providerTLSServerOpts(context.Background(), cfg, log)
+	if err != nil {
+		runCleanup(logCleanup)
+		return nil, fmt.Errorf("provider TLS: %w", err)
+	}
+
 	tracingProvider := newTracingProvider(cfg, log)
 	mediaOpts, mediaCleanup := mediaStorageServerOpts(log)
 
 	serverOpts := buildServerOpts(cfg, b, buildDeps{
-		collector: collector,
-		evalDefs:  evalDefs,
-		store:     store,
-		tracing:   tracingProvider,
-		mediaOpts: mediaOpts,
+		collector:      collector,
+		evalDefs:       evalDefs,
+		store:          store,
+		tracing:        tracingProvider,
+		mediaOpts:      mediaOpts,
+		cacheOpts:      responseCacheServerOpts(cfg, collectorRegistry, log),
+		resilienceOpts: providerResilienceServerOpts(cfg, collectorRegistry, log),
+		tlsOpts:        tlsOpts,
+		knowledgeOpts:  knowledgeOpts,
+		guardrailOpts:  guardrailServerOpts(cfg, collectorRegistry),
+		budgetOpts:     budgetOpts,
 	})
 	warnIfCustomTruncation(log, cfg.TruncationStrategy)
 
 	server := pkruntime.NewServer(serverOpts...)
+	// Before initTools and the other steps that stage the pack; see
+	// InitializePackVersion.
+	server.InitializePackVersion()
 	initTools(cfg, server, log)
 
 	rt := &Runtime{
-		server:         server,
-		cfg:            cfg,
-		log:            log,
-		tracing:        tracingProvider,
-		gatherers:      mergedGatherers(collectorRegistry),
-		readyValidator: schema.NewSchemaValidatorWithOptions(log, nil, 0),
-		evalDefs:       evalDefs,
-		logCleanup:     logCleanup,
+		server:     server,
+		cfg:        cfg,
+		log:        log,
+		tracing:    tracingProvider,
+		gatherers:  mergedGatherers(collectorRegistry),
+		evalDefs:   evalDefs,
+		logCleanup: logCleanup,
 	}
 	if mediaCleanup != nil {
 		rt.cleanups = append(rt.cleanups, mediaCleanup)
 	}
+	if tlsCleanup != nil {
+		rt.cleanups = append(rt.cleanups, tlsCleanup)
+	}
+	if metricsCleanup := startMetricsExport(cfg, rt.gatherers, log); metricsCleanup != nil {
+		rt.cleanups = append(rt.cleanups, metricsCleanup)
+	}
+	ready, readyCleanup, err := newReadinessChecker(cfg, schema.NewSchemaValidatorWithOptions(log, nil, 0))
+	if err != nil {
+		_ = rt.Close()
+		return nil, fmt.Errorf("readiness: %w", err)
+	}
+	rt.ready = ready
+	rt.cleanups = append(rt.cleanups, readyCleanup)
+	// After initTools: both may stage a rewritten pack, and structured output
+	// must read the pack the conversations will open.
+	if err := server.InitializeStructuredOutput(); err != nil {
+		_ = rt.Close()
+		return nil, fmt.Errorf("structured output: %w", err)
+	}
+	// After InitializeStructuredOutput, for the same reason.
+	if err := server.InitializeKnowledge(); err != nil {
+		_ = rt.Close()
+		return nil, fmt.Errorf("knowledge: %w", err)
+	}
+	if err := server.InitializeSystemPromptPrefix(); err != nil {
+		_ = rt.Close()
+		return nil, fmt.Errorf("system prompt prefix: %w", err)
+	}
+	if err := server.InitializeGuardrails(); err != nil {
+		_ = rt.Close()
+		return nil, fmt.Errorf("guardrails: %w", err)
+	}
 	return rt, nil
 }
 
@@ -184,6 +257,13 @@ func buildServerOpts(cfg *pkruntime.Config, b *builder, d buildDeps) []pkruntime
 	}
 	opts = append(opts, d.mediaOpts...)
 	opts = append(opts, memoryServerOpts(cfg, b.log)...)
+	opts = append(opts, d.cacheOpts...)
+	opts = append(opts, d.resilienceOpts...)
+	opts = append(opts, d.tlsOpts...)
+	opts = append(opts, d.knowledgeOpts...)
+	opts = append(opts, d.guardrailOpts...)
+	opts = append(opts, d.budgetOpts...)
+	opts = append(opts, delegationServerOpts(cfg, b.log)...)
 	opts = append(opts, pkruntime.WithEvalCollector(d.collector))
 	if len(d.evalDefs) > 0 {
 		opts = append(opts, pkruntime.WithEvalDefs(d.evalDefs))
@@ -250,8 +330,9 @@ func registerRuntimeInfoGauge(cfg *pkruntime.Config, reg *prometheus.Registry) {
 }
 
 // Serve runs the runtime: it starts the gRPC server (policy interceptors +
-// health) on cfg.GRPCPort and the HTTP health/metrics server on cfg.HealthPort,
-// then blocks until ctx is cancelled, at which point it gracefully shuts both
+// health) on cfg.GRPCPort, the HTTP health/metrics server on cfg.HealthPort and,
+// unless cfg.PromptPackReloadInterval is zero, the pack hot-reload watcher, then
+// blocks until ctx is cancelled, at which point it gracefully shuts both
 // down (health first, then gRPC with a hard-stop fallback). Serve returns only
 // after shutdown completes; a nil return is a clean shutdown.
 func (r *Runtime) Serve(ctx context.Context) error {
@@ -279,6 +360,11 @@ func (r *Runtime) Serve(ctx context.Context) error {
 		}
 	}()
 
+	if r.cfg.PromptPackReloadInterval > 0 {
+		go r.server.WatchPack(ctx, r.cfg.PromptPackReloadInterval)
+		r.log.Info("promptpack hot reload enabled", "interval", r.cfg.PromptPackReloadInterval)
+	}
+
 	<-ctx.Done()
 	r.log.Info("shutting down...")
 	r.shutdown(grpcServer, httpServer)
@@ -18,10 +18,13 @@ package promptkit
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 
@@ -30,7 +33,14 @@ import (
 
 	omniav1alpha1 "github.com/altairalabs/omnia/api/v1alpha1"
 	pkruntime "github.com/altairalabs/omnia/internal/runtime"
+	"github.com/altairalabs/omnia/internal/runtime/budget"
+	"github.com/altairalabs/omnia/internal/runtime/delegation"
+	"github.com/altairalabs/omnia/internal/runtime/guardrails"
+	"github.com/altairalabs/omnia/internal/runtime/mtls"
+	"github.com/altairalabs/omnia/internal/runtime/resilience"
+	"github.com/altairalabs/omnia/internal/runtime/responsecache"
 	"github.com/altairalabs/omnia/internal/runtime/tools"
+	"github.com/altairalabs/omnia/internal/runtime/vectorstore"
 )
 
 // envPromptPackManifestPath points at the operator-emitted skill manifest. An
@@ -60,11 +70,16 @@ func configDerivedServerOpts(cfg *pkruntime.Config) []pkruntime.ServerOption {
 		pkruntime.WithExtraProviders(cfg.ExtraProviders),
 		pkruntime.WithBaseURL(cfg.BaseURL),
 		pkruntime.WithHeaders(cfg.Headers),
+		pkruntime.WithUnsupportedParams(cfg.ProviderUnsupportedParams),
+		pkruntime.WithProviderCapabilities(cfg.ProviderCapabilities),
+		pkruntime.WithSystemPromptPrefix(cfg.SystemPromptPrefix),
 		pkruntime.WithPlatform(pkruntime.PlatformConfig{
-			Type:     cfg.PlatformType,
-			Region:   cfg.PlatformRegion,
-			Project:  cfg.PlatformProject,
-			Endpoint: cfg.PlatformEndpoint,
+			Type:       cfg.PlatformType,
+			Region:     cfg.PlatformRegion,
+			Project:    cfg.PlatformProject,
+			Endpoint:   cfg.PlatformEndpoint,
+			Deployment: cfg.PlatformDeployment,
+			APIVersion: cfg.PlatformAPIVersion,
 		}),
 		pkruntime.WithAuth(pkruntime.AuthConfig{
 			Type:                       cfg.AuthType,
@@ -82,6 +97,7 @@ func configDerivedServerOpts(cfg *pkruntime.Config) []pkruntime.ServerOption {
 		pkruntime.WithMediaBasePath(cfg.MediaBasePath),
 		pkruntime.WithMemoryRetrieval(cfg.MemoryStrategy, cfg.MemoryDenyCEL, cfg.MemoryLimit),
 		pkruntime.WithFunctionOutputFormat(cfg.Mode, cfg.OutputFormat, cfg.OutputSchemaJSON),
+		pkruntime.WithStructuredOutput(cfg.StructuredOutputEnabled, cfg.StructuredOutputMaxRepairs),
 		pkruntime.WithDuplexAudio(cfg.DuplexAudio),
 	}
 }
@@ -102,10 +118,28 @@ func newStateStore(cfg *pkruntime.Config, log logr.Logger) (statestore.Store, er
 	}
 }
 
-// newRedisStore parses cfg.ContextURL, connects, instruments tracing, and pings
-// before returning a Redis-backed state store.
+// newRedisStore connects to cfg.ContextURL and returns a Redis-backed state
+// store.
 func newRedisStore(cfg *pkruntime.Config, log logr.Logger) (statestore.Store, error) {
-	opts, err := redis.ParseURL(cfg.ContextURL)
+	client, err := newRedisClient(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("using Redis state store", "url", cfg.ContextURL, "contextTTL", cfg.ContextTTL)
+	return statestore.NewRedisStore(client, redisStoreOptions(cfg.ContextTTL)...), nil
+}
+
+// newRedisClient parses cfg.ContextURL, connects, instruments tracing, and
+// pings before returning the client.
+func newRedisClient(cfg *pkruntime.Config, log logr.Logger) (*redis.Client, error) {
+	return connectRedis(cfg.ContextURL, log)
+}
+
+// connectRedis parses url, connects, instruments tracing, and pings before
+// returning the client.
+func connectRedis(url string, log logr.Logger) (*redis.Client, error) {
+	opts, err := redis.ParseURL(url)
 	if err != nil {
 		return nil, err
 	}
@@ -119,9 +153,200 @@ func newRedisStore(cfg *pkruntime.Config, log logr.Logger) (statestore.Store, er
 	if err := client.Ping(ctx).Err(); err != nil {
 		return nil, err
 	}
+	return client, nil
+}
 
-	log.Info("using Redis state store", "url", cfg.ContextURL, "contextTTL", cfg.ContextTTL)
-	return statestore.NewRedisStore(client, redisStoreOptions(cfg.ContextTTL)...), nil
+// responseCacheServerOpts wires the response cache when spec.responseCache is
+// enabled, returning nil otherwise. Entries live in the context store's Redis
+// when there is one, so every replica shares them, and in process memory
+// otherwise. Hit-rate counters register on reg. A Redis connection failure
+// disables the cache rather than the runtime.
+func responseCacheServerOpts(cfg *pkruntime.Config, reg prometheus.Registerer, log logr.Logger) []pkruntime.ServerOption {
+	if !cfg.ResponseCacheEnabled {
+		return nil
+	}
+	var backend responsecache.Backend
+	if cfg.ContextType == pkruntime.ContextTypeRedis {
+		client, err := newRedisClient(cfg, log)
+		if err != nil {
+			log.Error(err, "response cache disabled: cannot connect to the context store's Redis")
+			return nil
+		}
+		backend = responsecache.NewRedisBackend(client)
+	} else {
+		backend = responsecache.NewMemoryBackend(0)
+	}
+	log.Info("response cache enabled",
+		"backend", cfg.ContextType, "ttl", cfg.ResponseCacheTTL, "similarityThreshold", cfg.ResponseCacheSimilarity)
+	return []pkruntime.ServerOption{pkruntime.WithResponseCache(backend, responsecache.Config{
+		TTL:                 cfg.ResponseCacheTTL,
+		SimilarityThreshold: cfg.ResponseCacheSimilarity,
+		Metrics: responsecache.NewMetrics(reg, prometheus.Labels{
+			"agent":     cfg.AgentName,
+			"namespace": cfg.Namespace,
+		}),
+	})}
+}
+
+// knowledgeServerOpts connects to the vector store when spec.knowledge is
+// enabled, returning nil otherwise. Unlike the response cache, a store that
+// cannot be configured fails startup: an agent grounded in knowledge should
+// not quietly answer without it.
+func knowledgeServerOpts(ctx context.Context, cfg *pkruntime.Config, log logr.Logger) ([]pkruntime.ServerOption, error) {
+	if !cfg.KnowledgeEnabled {
+		return nil, nil
+	}
+	store, err := vectorstore.New(ctx, vectorstore.Config{
+		Type:       cfg.KnowledgeStoreType,
+		Collection: cfg.KnowledgeCollection,
+		URL:        cfg.KnowledgeURL,
+		APIKey:     cfg.KnowledgeAPIKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Info("knowledge vector store configured",
+		"type", cfg.KnowledgeStoreType, "collection", cfg.KnowledgeCollection)
+	return []pkruntime.ServerOption{
+		pkruntime.WithKnowledge(store, cfg.KnowledgeTopK, cfg.KnowledgeMinScore),
+	}, nil
+}
+
+// guardrailServerOpts passes spec.guardrails to the server with check metrics
+// registered on reg. The metrics are registered even when the AgentRuntime
+// declares no hooks, as the PromptPack may declare its own.
+func guardrailServerOpts(cfg *pkruntime.Config, reg prometheus.Registerer) []pkruntime.ServerOption {
+	return []pkruntime.ServerOption{pkruntime.WithGuardrails(cfg.Guardrails, guardrails.NewMetrics(reg, prometheus.Labels{
+		"agent":     cfg.AgentName,
+		"namespace": cfg.Namespace,
+	}))}
+}
+
+// budgetServerOpts wires spec.budget and the workspace's cost budgets,
+// returning nil when they cap nothing. Agent and workspace usage is counted in
+// the ledger budgetLedger picks.
+func budgetServerOpts(cfg *pkruntime.Config, reg prometheus.Registerer, log logr.Logger) ([]pkruntime.ServerOption, error) {
+	bc := budget.Config{
+		Session: cfg.BudgetSession,
+		Agent:   cfg.BudgetAgent,
+		Window:  cfg.BudgetWindow,
+
+		Workspace:        cfg.WorkspaceName,
+		WorkspaceDaily:   cfg.BudgetWorkspaceDaily,
+		WorkspaceMonthly: cfg.BudgetWorkspaceMonthly,
+		Log:              log,
+	}
+	if bc.Empty() {
+		return nil, nil
+	}
+	ledger, err := budgetLedger(cfg, bc, log)
+	if err != nil {
+		return nil, err
+	}
+	bc.Metrics = budget.NewMetrics(reg, prometheus.Labels{
+		"agent":     cfg.AgentName,
+		"namespace": cfg.Namespace,
+	})
+	log.Info("budget enabled",
+		"sessionMaxTokens", bc.Session.MaxTokens, "sessionMaxCostUSD", bc.Session.MaxCostUSD,
+		"agentMaxTokens", bc.Agent.MaxTokens, "agentMaxCostUSD", bc.Agent.MaxCostUSD,
+		"window", bc.Window, "summarize", cfg.BudgetSummarize,
+		"workspaceDailyCostUSD", bc.WorkspaceDaily.MaxCostUSD,
+		"workspaceMonthlyCostUSD", bc.WorkspaceMonthly.MaxCostUSD)
+	tracker := budget.New(bc, cfg.Namespace+"/"+cfg.AgentName, ledger)
+	return []pkruntime.ServerOption{pkruntime.WithBudget(tracker, cfg.BudgetSummarize)}, nil
+}
+
+// budgetLedger returns the ledger agent and workspace budgets are counted in.
+// OMNIA_BUDGET_REDIS_URL names a Redis every agent of the workspace shares;
+// without it the context store's Redis is used, which only agents on the same
+// store share. Workspace budgets cap spend across every agent and replica, so
+// without a shared Redis they fail startup rather than count per replica. An
+// agent budget alone falls back to process memory, where it can be overspent
+// by up to one budget per replica, and that fallback is logged as an error.
+func budgetLedger(cfg *pkruntime.Config, bc budget.Config, log logr.Logger) (budget.Ledger, error) {
+	workspace := !bc.WorkspaceDaily.Empty() || !bc.WorkspaceMonthly.Empty()
+	if !workspace && bc.Agent.Empty() {
+		return budget.NewMemoryLedger(), nil
+	}
+	url := cfg.BudgetRedisURL
+	if url == "" && cfg.ContextType == pkruntime.ContextTypeRedis {
+		url = cfg.ContextURL
+	}
+	if url == "" {
+		if workspace {
+			return nil, errors.New("workspace budgets need a shared Redis: set OMNIA_BUDGET_REDIS_URL")
+		}
+		log.Error(nil, "agent budget counted per replica: no shared Redis configured (set OMNIA_BUDGET_REDIS_URL)")
+		return budget.NewMemoryLedger(), nil
+	}
+	client, err := connectRedis(url, log)
+	if err != nil {
+		if workspace {
+			return nil, fmt.Errorf("workspace budgets need a shared Redis: %w", err)
+		}
+		log.Error(err, "agent budget counted per replica: cannot connect to the budget Redis")
+		return budget.NewMemoryLedger(), nil
+	}
+	return budget.NewRedisLedger(client), nil
+}
+
+// delegationServerOpts offers spec.delegates to the agent's conversations as
+// tools, returning nil when it has none.
+func delegationServerOpts(cfg *pkruntime.Config, log logr.Logger) []pkruntime.ServerOption {
+	if len(cfg.Delegates) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(cfg.Delegates))
+	for _, d := range cfg.Delegates {
+		names = append(names, d.Name)
+	}
+	log.Info("agent delegation enabled", "delegates", names)
+	agent := delegation.Agent{Name: cfg.AgentName, Namespace: cfg.Namespace, WorkspaceName: cfg.WorkspaceName}
+	return []pkruntime.ServerOption{pkruntime.WithDelegator(delegation.New(agent, cfg.Delegates, log))}
+}
+
+// providerResilienceServerOpts wires the default provider's spec.resilience
+// policy, returning nil when it has none. Retry, hedge and circuit breaker
+// metrics register on reg, labelled with the Provider's name.
+func providerResilienceServerOpts(cfg *pkruntime.Config, reg prometheus.Registerer, log logr.Logger) []pkruntime.ServerOption {
+	if cfg.ProviderResilience == nil {
+		return nil
+	}
+	rc := *cfg.ProviderResilience
+	rc.Metrics = resilience.NewMetrics(reg, prometheus.Labels{
+		"agent":     cfg.AgentName,
+		"namespace": cfg.Namespace,
+	})
+	name := cfg.ProviderRefName
+	if name == "" {
+		name = cfg.ProviderType
+	}
+	log.Info("provider resilience enabled", "provider", name,
+		"maxAttempts", rc.MaxAttempts, "hedgeAfter", rc.HedgeAfter, "failureThreshold", rc.FailureThreshold,
+		"failover", rc.Failover != nil)
+	return []pkruntime.ServerOption{pkruntime.WithProviderResilience(resilience.New(name, rc))}
+}
+
+// providerTLSServerOpts builds the client certificate configuration from the
+// default provider's spec.tls, returning nil when it has none. The cleanup
+// closes the SPIFFE Workload API stream, if any. Like the knowledge store,
+// a certificate that cannot be loaded fails startup rather than calling the
+// provider without it.
+func providerTLSServerOpts(ctx context.Context, cfg *pkruntime.Config, log logr.Logger) ([]pkruntime.ServerOption, func(), error) {
+	if cfg.ProviderTLS == nil {
+		return nil, nil, nil
+	}
+	tlsCfg, closer, err := mtls.ClientConfig(ctx, *cfg.ProviderTLS)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Info("provider client certificate configured", "spiffe", cfg.ProviderTLS.SPIFFESocket != "")
+	var cleanup func()
+	if closer != nil {
+		cleanup = func() { _ = closer.Close() }
+	}
+	return []pkruntime.ServerOption{pkruntime.WithProviderTLS(tlsCfg)}, cleanup, nil
 }
 
 // memoryStoreOptions and redisStoreOptions translate spec.context.ttl — how
@@ -3,6 +3,7 @@ package runtime
 import (
 	"net"
 
+	"github.com/altairalabs/omnia/pkg/apierror"
 	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
@@ -34,17 +35,20 @@ func WithServerOptions(o ...grpc.ServerOption) Option {
 
 // Serve registers h as an omnia.runtime.v1 RuntimeService on a new gRPC server
 // and serves it on lis until lis is closed or a fatal error occurs. It also
-// registers the standard grpc health service in SERVING state. Serve blocks.
+// registers the standard grpc health service in SERVING state. Errors the RPCs
+// return carry an Omnia ErrorInfo (see apierror.Annotate). Serve blocks.
 func Serve(lis net.Listener, h Handler, opts ...Option) error {
 	cfg := serveConfig{maxMessageSize: defaultMaxMessageSize}
 	for _, o := range opts {
 		o(&cfg)
 	}
 
-	serverOpts := make([]grpc.ServerOption, 0, 2+len(cfg.serverOptions))
+	serverOpts := make([]grpc.ServerOption, 0, 4+len(cfg.serverOptions))
 	serverOpts = append(serverOpts,
 		grpc.MaxRecvMsgSize(cfg.maxMessageSize),
 		grpc.MaxSendMsgSize(cfg.maxMessageSize),
+		grpc.ChainUnaryInterceptor(apierror.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(apierror.StreamServerInterceptor()),
 	)
 	serverOpts = append(serverOpts, cfg.serverOptions...)
 
@@ -2,8 +2,10 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"io"
 
+	"github.com/altairalabs/omnia/pkg/apierror"
 	"github.com/altairalabs/omnia/pkg/runtime/contract"
 	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
 	"google.golang.org/grpc/codes"
@@ -30,10 +32,7 @@ func (a *serviceAdapter) Health(_ context.Context, _ *runtimev1.HealthRequest) (
 	}, nil
 }
 
-const (
-	codeInternal = "INTERNAL_ERROR"
-	codeDuplex   = "DUPLEX_UNSUPPORTED"
-)
+const codeDuplex = "DUPLEX_UNSUPPORTED"
 
 // Converse drives one bidi stream: it sends RuntimeHello as the first frame,
 // then routes each inbound turn through the Handler. Duplex sessions are refused
@@ -59,7 +58,7 @@ func (a *serviceAdapter) Converse(stream runtimev1.RuntimeService_ConverseServer
 			helloSent = true
 		}
 		if err := a.handleTurn(ctx, stream, msg, id); err != nil {
-			_ = stream.Send(errorFrame(codeInternal, "an internal error occurred while processing the message"))
+			_ = stream.Send(turnErrorFrame(err))
 		}
 	}
 }
@@ -84,7 +83,7 @@ func (a *serviceAdapter) Invoke(
 		Identity:     parseIdentity(ctx),
 	})
 	if err != nil {
-		return nil, status.Error(codes.Internal, "invocation failed")
+		return nil, handlerErr(err, "invocation failed")
 	}
 	return &runtimev1.InvocationResponse{
 		OutputJson:   resp.OutputJSON,
@@ -116,6 +115,41 @@ func (a *serviceAdapter) HasConversation(
 	}, nil
 }
 
+// Embed returns embedding vectors. Requires the Handler to implement Embedder;
+// otherwise it reports Unimplemented (so advertising honesty holds).
+func (a *serviceAdapter) Embed(
+	ctx context.Context,
+	req *runtimev1.EmbedRequest,
+) (*runtimev1.EmbedResponse, error) {
+	embedder, ok := a.handler.(Embedder)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "embed is not supported by this runtime")
+	}
+	if len(req.GetTexts()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "texts is required")
+	}
+	resp, err := embedder.Embed(ctx, EmbedRequest{Texts: req.GetTexts(), Model: req.GetModel()})
+	if err != nil {
+		return nil, handlerErr(err, "embed failed")
+	}
+	if len(resp.Embeddings) != len(req.GetTexts()) {
+		return nil, status.Errorf(codes.Internal, "embed returned %d vectors for %d texts",
+			len(resp.Embeddings), len(req.GetTexts()))
+	}
+	out := &runtimev1.EmbedResponse{
+		Embeddings:  make([]*runtimev1.Embedding, len(resp.Embeddings)),
+		Model:       resp.Model,
+		TotalTokens: resp.TotalTokens,
+	}
+	for i, v := range resp.Embeddings {
+		out.Embeddings[i] = &runtimev1.Embedding{Values: v}
+	}
+	if len(resp.Embeddings) > 0 {
+		out.Dimensions = int32(len(resp.Embeddings[0])) //nolint:gosec // vector lengths are small
+	}
+	return out, nil
+}
+
 func mapResumeState(s ResumeState) runtimev1.ResumeState {
 	switch s {
 	case ResumeResumable:
@@ -140,7 +174,7 @@ func (a *serviceAdapter) handleTurn(
 	msg *runtimev1.ClientMessage,
 	id Identity,
 ) error {
-	emit := &streamEmitter{stream: stream}
+	emit := &streamEmitter{stream: stream, progress: msg.GetProgress()}
 	if err := a.handler.Converse(ctx, buildTurn(msg, id), emit); err != nil {
 		return err
 	}
@@ -158,6 +192,28 @@ func (a *serviceAdapter) sendHello(stream runtimev1.RuntimeService_ConverseServe
 	})
 }
 
+// handlerErr returns the status for an error a Handler returned from a
+// unary call: an *apierror.Error as is, anything else as an internal error
+// with message.
+func handlerErr(err error, message string) error {
+	var e *apierror.Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return status.Error(codes.Internal, message)
+}
+
+// turnErrorFrame reports a failed turn. An *apierror.Error the Handler
+// returned is sent as is; any other error is sent as a generic internal
+// error, since its text may hold details the client must not see.
+func turnErrorFrame(err error) *runtimev1.ServerMessage {
+	var e *apierror.Error
+	if errors.As(err, &e) {
+		return errorFrame(e.Code, e.Message)
+	}
+	return errorFrame(apierror.CodeInternalError, "an internal error occurred while processing the message")
+}
+
 func errorFrame(code, msg string) *runtimev1.ServerMessage {
 	return &runtimev1.ServerMessage{
 		Message: &runtimev1.ServerMessage_Error{Error: &runtimev1.Error{Code: code, Message: msg}},
@@ -171,6 +227,7 @@ func buildTurn(msg *runtimev1.ClientMessage, id Identity) Turn {
 		Parts:         mapPartsFromProto(msg.GetParts()),
 		Metadata:      msg.GetMetadata(),
 		ConsentGrants: msg.GetConsentGrants(),
+		Progress:      msg.GetProgress(),
 		Identity:      id,
 	}
 }
@@ -196,6 +253,7 @@ func mapPartsFromProto(parts []*runtimev1.ContentPart) []ContentPart {
 // streamEmitter marshals Emitter calls to ServerMessage frames on one stream.
 type streamEmitter struct {
 	stream   runtimev1.RuntimeService_ConverseServer
+	progress bool
 	doneSent bool
 }
 
@@ -251,6 +309,23 @@ func (e *streamEmitter) ToolCall(call ClientToolCall) (ClientToolResult, error)
 	}, nil
 }
 
+// Progress sends a Progress frame if the facade asked for them. Progress after
+// Done is dropped.
+func (e *streamEmitter) Progress(p Progress) error {
+	if !e.progress || e.doneSent {
+		return nil
+	}
+	return e.stream.Send(&runtimev1.ServerMessage{
+		Message: &runtimev1.ServerMessage_Progress{Progress: &runtimev1.Progress{
+			Stage:     p.Stage,
+			Status:    p.Status,
+			ToolName:  p.ToolName,
+			CallId:    p.CallID,
+			ElapsedMs: p.Elapsed.Milliseconds(),
+		}},
+	})
+}
+
 func (e *streamEmitter) Media(chunk MediaChunk) error {
 	return e.stream.Send(&runtimev1.ServerMessage{
 		Message: &runtimev1.ServerMessage_MediaChunk{MediaChunk: &runtimev1.MediaChunk{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/altairalabs/omnia/pkg/runtime/contract"
 	runtimev1 "github.com/altairalabs/omnia/pkg/runtime/v1"
@@ -255,6 +256,47 @@ func TestEmitter_Media(t *testing.T) {
 	assert.Equal(t, []byte{1, 2, 3}, mc.GetData())
 }
 
+func TestEmitter_ProgressOnlyWhenRequested(t *testing.T) {
+	h := &stubHandler{
+		caps: []string{contract.CapabilityProgress},
+		converse: func(_ context.Context, _ Turn, emit Emitter) error {
+			pe, ok := emit.(ProgressEmitter)
+			require.True(t, ok, "the SDK emitter reports progress")
+			if err := pe.Progress(Progress{Stage: "tool", Status: "running", ToolName: "search", CallID: "c1", Elapsed: 2 * time.Second}); err != nil {
+				return err
+			}
+			if err := emit.Done(Done{}); err != nil {
+				return err
+			}
+			return pe.Progress(Progress{Stage: "tool", Status: "completed"})
+		},
+	}
+	client := runtimev1.NewRuntimeServiceClient(newTestConn(t, h))
+
+	progressFrames := func(requested bool) []*runtimev1.Progress {
+		stream, err := client.Converse(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&runtimev1.ClientMessage{SessionId: "s1", Content: "find", Progress: requested}))
+		require.NoError(t, stream.CloseSend())
+		var progress []*runtimev1.Progress
+		for {
+			msg, recvErr := stream.Recv()
+			if recvErr != nil {
+				return progress
+			}
+			if p := msg.GetProgress(); p != nil {
+				progress = append(progress, p)
+			}
+		}
+	}
+
+	assert.Empty(t, progressFrames(false))
+	got := progressFrames(true)
+	require.Len(t, got, 1, "progress after done is dropped")
+	assert.Equal(t, "search", got[0].GetToolName())
+	assert.Equal(t, int64(2000), got[0].GetElapsedMs())
+}
+
 // invokerStub is a Handler that also implements Invoker.
 type invokerStub struct {
 	stubHandler
@@ -489,3 +531,56 @@ func TestServe_Options(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, resp.GetHealthy())
 }
+
+type embedderStub struct {
+	stubHandler
+	embed func(ctx context.Context, req EmbedRequest) (EmbedResponse, error)
+}
+
+func (e *embedderStub) Embed(ctx context.Context, req EmbedRequest) (EmbedResponse, error) {
+	return e.embed(ctx, req)
+}
+
+func TestEmbed_UnimplementedWithoutEmbedder(t *testing.T) {
+	h := &stubHandler{caps: []string{contract.CapabilityClientTools}}
+	client := runtimev1.NewRuntimeServiceClient(newTestConn(t, h))
+
+	_, err := client.Embed(context.Background(), &runtimev1.EmbedRequest{Texts: []string{"hello"}})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestEmbed_DelegatesToEmbedder(t *testing.T) {
+	h := &embedderStub{
+		stubHandler: stubHandler{caps: []string{contract.CapabilityEmbed}},
+		embed: func(_ context.Context, req EmbedRequest) (EmbedResponse, error) {
+			assert.Equal(t, []string{"a", "b"}, req.Texts)
+			assert.Equal(t, "small", req.Model)
+			return EmbedResponse{Embeddings: [][]float32{{1, 0}, {0, 1}}, Model: "small", TotalTokens: 2}, nil
+		},
+	}
+	client := runtimev1.NewRuntimeServiceClient(newTestConn(t, h))
+
+	resp, err := client.Embed(context.Background(), &runtimev1.EmbedRequest{Texts: []string{"a", "b"}, Model: "small"})
+	require.NoError(t, err)
+	require.Len(t, resp.GetEmbeddings(), 2)
+	assert.Equal(t, []float32{0, 1}, resp.GetEmbeddings()[1].GetValues())
+	assert.Equal(t, int32(2), resp.GetDimensions())
+	assert.Equal(t, "small", resp.GetModel())
+	assert.Equal(t, int32(2), resp.GetTotalTokens())
+}
+
+func TestEmbed_ValidatesInputAndOutput(t *testing.T) {
+	h := &embedderStub{
+		stubHandler: stubHandler{caps: []string{contract.CapabilityEmbed}},
+		embed: func(_ context.Context, _ EmbedRequest) (EmbedResponse, error) {
+			return EmbedResponse{Embeddings: [][]float32{{1}}}, nil
+		},
+	}
+	client := runtimev1.NewRuntimeServiceClient(newTestConn(t, h))
+
+	_, err := client.Embed(context.Background(), &runtimev1.EmbedRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Embed(context.Background(), &runtimev1.EmbedRequest{Texts: []string{"a", "b"}})
+	assert.Equal(t, codes.Internal, status.Code(err), "one vector per text")
+}
@@ -1,5 +1,7 @@
 package runtime
 
+import "time"
+
 // Identity is the caller identity parsed from x-omnia-* gRPC metadata attached
 // by the facade. The raw bearer token is deliberately NOT propagated by the
 // facade and is therefore absent here; downstream identity travels via UserID,
@@ -37,6 +39,7 @@ type Turn struct {
 	Parts         []ContentPart     // multimodal input
 	Metadata      map[string]string // app-level ClientMessage.metadata (e.g. mock scenarios)
 	ConsentGrants []string          // per-message consent overrides
+	Progress      bool              // the facade asked for progress (see ProgressEmitter)
 	Identity      Identity
 }
 
@@ -66,6 +69,15 @@ type MediaChunk struct {
 	Data     []byte
 }
 
+// Progress reports a step of a turn, such as a tool call, while it runs.
+type Progress struct {
+	Stage    string // "thinking" | "tool" | "retrieval"
+	Status   string // "started" | "running" | "completed" | "failed"
+	ToolName string // set for the tool stage
+	CallID   string // set for the tool stage
+	Elapsed  time.Duration
+}
+
 // Usage is token/cost accounting for a completed turn or invocation.
 type Usage struct {
 	InputTokens  int32
@@ -96,6 +108,19 @@ type InvocationResponse struct {
 	DurationMS int32
 }
 
+// EmbedRequest asks for one embedding vector per text.
+type EmbedRequest struct {
+	Texts []string
+	Model string // optional model override
+}
+
+// EmbedResponse carries one vector per request text, in request order.
+type EmbedResponse struct {
+	Embeddings  [][]float32
+	Model       string
+	TotalTokens int32 // optional accounting
+}
+
 // ResumeState is the SDK-facing resume classification for HasConversation.
 type ResumeState int
 
@@ -161,7 +161,10 @@ type ClientMessage struct {
 	// the stream into bidirectional audio mode (OSS duplex transport).
 	DuplexStart *DuplexStart `protobuf:"bytes,7,opt,name=duplex_start,json=duplexStart,proto3" json:"duplex_start,omitempty"`
 	// audio_input carries one inbound audio frame during a duplex session.
-	AudioInput    *AudioInputChunk `protobuf:"bytes,8,opt,name=audio_input,json=audioInput,proto3" json:"audio_input,omitempty"`
+	AudioInput *AudioInputChunk `protobuf:"bytes,8,opt,name=audio_input,json=audioInput,proto3" json:"audio_input,omitempty"`
+	// progress asks the runtime to send Progress frames during this turn. The
+	// facade sets it only when its client displays them.
+	Progress      bool `protobuf:"varint,9,opt,name=progress,proto3" json:"progress,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -252,6 +255,13 @@ func (x *ClientMessage) GetAudioInput() *AudioInputChunk {
 	return nil
 }
 
+func (x *ClientMessage) GetProgress() bool {
+	if x != nil {
+		return x.Progress
+	}
+	return false
+}
+
 // ClientToolResult carries the client's response to a client-side tool call.
 type ClientToolResult struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -338,6 +348,7 @@ type ServerMessage struct {
 	//	*ServerMessage_MediaChunk
 	//	*ServerMessage_Interruption
 	//	*ServerMessage_RuntimeHello
+	//	*ServerMessage_Progress
 	Message       isServerMessage_Message `protobuf_oneof:"message"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -443,6 +454,15 @@ func (x *ServerMessage) GetRuntimeHello() *RuntimeHello {
 	return nil
 }
 
+func (x *ServerMessage) GetProgress() *Progress {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
 type isServerMessage_Message interface {
 	isServerMessage_Message()
 }
@@ -492,6 +512,12 @@ type ServerMessage_RuntimeHello struct {
 	RuntimeHello *RuntimeHello `protobuf:"bytes,8,opt,name=runtime_hello,json=runtimeHello,proto3,oneof"`
 }
 
+type ServerMessage_Progress struct {
+	// progress reports what the runtime is doing during a turn. Sent only
+	// when the turn's ClientMessage set progress.
+	Progress *Progress `protobuf:"bytes,9,opt,name=progress,proto3,oneof"`
+}
+
 func (*ServerMessage_Chunk) isServerMessage_Message() {}
 
 func (*ServerMessage_ToolCall) isServerMessage_Message() {}
@@ -506,6 +532,8 @@ func (*ServerMessage_Interruption) isServerMessage_Message() {}
 
 func (*ServerMessage_RuntimeHello) isServerMessage_Message() {}
 
+func (*ServerMessage_Progress) isServerMessage_Message() {}
+
 // Chunk contains a partial text response for streaming output.
 type Chunk struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1167,6 +1195,92 @@ func (x *MediaChunk) GetData() []byte {
 	return nil
 }
 
+// Progress reports a step of a turn, so clients can show what the agent is
+// doing instead of a spinner. It is informational: the outcome of the turn is
+// still carried by chunk, done and error.
+type Progress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// stage is the kind of step: "thinking" (waiting on the model), "tool"
+	// (running a server-side tool) or "retrieval" (querying knowledge).
+	Stage string `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	// status is "started", "running" (sent periodically while a tool runs),
+	// "completed" or "failed".
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// tool_name names the tool, for the tool stage.
+	ToolName string `protobuf:"bytes,3,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`
+	// call_id identifies the tool call, for the tool stage.
+	CallId string `protobuf:"bytes,4,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`
+	// elapsed_ms is how long the step has run, in milliseconds.
+	ElapsedMs     int64 `protobuf:"varint,5,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Progress) Reset() {
+	*x = Progress{}
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Progress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Progress) ProtoMessage() {}
+
+func (x *Progress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
+func (*Progress) Descriptor() ([]byte, []int) {
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Progress) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *Progress) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Progress) GetToolName() string {
+	if x != nil {
+		return x.ToolName
+	}
+	return ""
+}
+
+func (x *Progress) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+func (x *Progress) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
 // InvocationRequest carries a Function call from the facade.
 type InvocationRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1186,7 +1300,7 @@ type InvocationRequest struct {
 
 func (x *InvocationRequest) Reset() {
 	*x = InvocationRequest{}
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[13]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1198,7 +1312,7 @@ func (x *InvocationRequest) String() string {
 func (*InvocationRequest) ProtoMessage() {}
 
 func (x *InvocationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[13]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1211,7 +1325,7 @@ func (x *InvocationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvocationRequest.ProtoReflect.Descriptor instead.
 func (*InvocationRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{13}
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *InvocationRequest) GetInputJson() string {
@@ -1256,7 +1370,7 @@ type InvocationResponse struct {
 
 func (x *InvocationResponse) Reset() {
 	*x = InvocationResponse{}
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[14]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1268,7 +1382,7 @@ func (x *InvocationResponse) String() string {
 func (*InvocationResponse) ProtoMessage() {}
 
 func (x *InvocationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[14]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1281,7 +1395,7 @@ func (x *InvocationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvocationResponse.ProtoReflect.Descriptor instead.
 func (*InvocationResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{14}
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *InvocationResponse) GetOutputJson() string {
@@ -1321,7 +1435,7 @@ type HealthRequest struct {
 
 func (x *HealthRequest) Reset() {
 	*x = HealthRequest{}
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[15]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1333,7 +1447,7 @@ func (x *HealthRequest) String() string {
 func (*HealthRequest) ProtoMessage() {}
 
 func (x *HealthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[15]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1346,7 +1460,7 @@ func (x *HealthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
 func (*HealthRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{15}
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{16}
 }
 
 // HealthResponse contains the health status of the runtime.
@@ -1372,7 +1486,7 @@ type HealthResponse struct {
 
 func (x *HealthResponse) Reset() {
 	*x = HealthResponse{}
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[16]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1384,7 +1498,7 @@ func (x *HealthResponse) String() string {
 func (*HealthResponse) ProtoMessage() {}
 
 func (x *HealthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[16]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1397,7 +1511,7 @@ func (x *HealthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
 func (*HealthResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{16}
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *HealthResponse) GetHealthy() bool {
@@ -1439,7 +1553,7 @@ type HasConversationRequest struct {
 
 func (x *HasConversationRequest) Reset() {
 	*x = HasConversationRequest{}
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[17]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1451,7 +1565,7 @@ func (x *HasConversationRequest) String() string {
 func (*HasConversationRequest) ProtoMessage() {}
 
 func (x *HasConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[17]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1464,7 +1578,7 @@ func (x *HasConversationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HasConversationRequest.ProtoReflect.Descriptor instead.
 func (*HasConversationRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{17}
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *HasConversationRequest) GetSessionId() string {
@@ -1488,7 +1602,7 @@ type HasConversationResponse struct {
 
 func (x *HasConversationResponse) Reset() {
 	*x = HasConversationResponse{}
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[18]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1500,7 +1614,7 @@ func (x *HasConversationResponse) String() string {
 func (*HasConversationResponse) ProtoMessage() {}
 
 func (x *HasConversationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[18]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1513,7 +1627,7 @@ func (x *HasConversationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HasConversationResponse.ProtoReflect.Descriptor instead.
 func (*HasConversationResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{18}
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *HasConversationResponse) GetState() ResumeState {
@@ -1547,7 +1661,7 @@ type DuplexStart struct {
 
 func (x *DuplexStart) Reset() {
 	*x = DuplexStart{}
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[19]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1559,7 +1673,7 @@ func (x *DuplexStart) String() string {
 func (*DuplexStart) ProtoMessage() {}
 
 func (x *DuplexStart) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[19]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1572,7 +1686,7 @@ func (x *DuplexStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DuplexStart.ProtoReflect.Descriptor instead.
 func (*DuplexStart) Descriptor() ([]byte, []int) {
-	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{19}
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DuplexStart) GetCodec() string {
@@ -1620,7 +1734,7 @@ type RuntimeHello struct {
 
 func (x *RuntimeHello) Reset() {
 	*x = RuntimeHello{}
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[20]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1632,7 +1746,7 @@ func (x *RuntimeHello) String() string {
 func (*RuntimeHello) ProtoMessage() {}
 
 func (x *RuntimeHello) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[20]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1645,7 +1759,7 @@ func (x *RuntimeHello) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RuntimeHello.ProtoReflect.Descriptor instead.
 func (*RuntimeHello) Descriptor() ([]byte, []int) {
-	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{20}
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *RuntimeHello) GetCapabilities() []string {
@@ -1682,7 +1796,7 @@ type MediaNegotiation struct {
 
 func (x *MediaNegotiation) Reset() {
 	*x = MediaNegotiation{}
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[21]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1694,7 +1808,7 @@ func (x *MediaNegotiation) String() string {
 func (*MediaNegotiation) ProtoMessage() {}
 
 func (x *MediaNegotiation) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[21]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1707,7 +1821,7 @@ func (x *MediaNegotiation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MediaNegotiation.ProtoReflect.Descriptor instead.
 func (*MediaNegotiation) Descriptor() ([]byte, []int) {
-	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{21}
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *MediaNegotiation) GetCodec() string {
@@ -1760,7 +1874,7 @@ type AudioInputChunk struct {
 
 func (x *AudioInputChunk) Reset() {
 	*x = AudioInputChunk{}
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[22]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1772,7 +1886,7 @@ func (x *AudioInputChunk) String() string {
 func (*AudioInputChunk) ProtoMessage() {}
 
 func (x *AudioInputChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[22]
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1785,7 +1899,7 @@ func (x *AudioInputChunk) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AudioInputChunk.ProtoReflect.Descriptor instead.
 func (*AudioInputChunk) Descriptor() ([]byte, []int) {
-	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{22}
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *AudioInputChunk) GetData() []byte {
@@ -1809,11 +1923,185 @@ func (x *AudioInputChunk) GetIsLast() bool {
 	return false
 }
 
+// EmbedRequest asks for one embedding vector per text.
+type EmbedRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// texts to embed. Must be non-empty; the runtime batches to the provider's
+	// limits itself.
+	Texts []string `protobuf:"bytes,1,rep,name=texts,proto3" json:"texts,omitempty"`
+	// model optionally overrides the embedding provider's configured model.
+	Model         string `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbedRequest) Reset() {
+	*x = EmbedRequest{}
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedRequest) ProtoMessage() {}
+
+func (x *EmbedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedRequest.ProtoReflect.Descriptor instead.
+func (*EmbedRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *EmbedRequest) GetTexts() []string {
+	if x != nil {
+		return x.Texts
+	}
+	return nil
+}
+
+func (x *EmbedRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+// Embedding is one embedding vector.
+type Embedding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float32              `protobuf:"fixed32,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Embedding) Reset() {
+	*x = Embedding{}
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Embedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Embedding) ProtoMessage() {}
+
+func (x *Embedding) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Embedding.ProtoReflect.Descriptor instead.
+func (*Embedding) Descriptor() ([]byte, []int) {
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Embedding) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// EmbedResponse carries the vectors for an EmbedRequest.
+type EmbedResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// embeddings holds one vector per request text, in request order.
+	Embeddings []*Embedding `protobuf:"bytes,1,rep,name=embeddings,proto3" json:"embeddings,omitempty"`
+	// model is the embedding model that produced the vectors.
+	Model string `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	// dimensions is the length of each vector.
+	Dimensions int32 `protobuf:"varint,3,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	// total_tokens is the provider-reported token usage, when available.
+	TotalTokens   int32 `protobuf:"varint,4,opt,name=total_tokens,json=totalTokens,proto3" json:"total_tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbedResponse) Reset() {
+	*x = EmbedResponse{}
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedResponse) ProtoMessage() {}
+
+func (x *EmbedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_runtime_v1_runtime_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedResponse.ProtoReflect.Descriptor instead.
+func (*EmbedResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_runtime_v1_runtime_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *EmbedResponse) GetEmbeddings() []*Embedding {
+	if x != nil {
+		return x.Embeddings
+	}
+	return nil
+}
+
+func (x *EmbedResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *EmbedResponse) GetDimensions() int32 {
+	if x != nil {
+		return x.Dimensions
+	}
+	return 0
+}
+
+func (x *EmbedResponse) GetTotalTokens() int32 {
+	if x != nil {
+		return x.TotalTokens
+	}
+	return 0
+}
+
 var File_api_proto_runtime_v1_runtime_proto protoreflect.FileDescriptor
 
 const file_api_proto_runtime_v1_runtime_proto_rawDesc = "" +
 	"\n" +
-	"\"api/proto/runtime/v1/runtime.proto\x12\x10omnia.runtime.v1\"\x84\x04\n" +
+	"\"api/proto/runtime/v1/runtime.proto\x12\x10omnia.runtime.v1\"\xa0\x04\n" +
 	"\rClientMessage\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x18\n" +
@@ -1824,7 +2112,8 @@ const file_api_proto_runtime_v1_runtime_proto_rawDesc = "" +
 	"\x0econsent_grants\x18\x06 \x03(\tR\rconsentGrants\x12@\n" +
 	"\fduplex_start\x18\a \x01(\v2\x1d.omnia.runtime.v1.DuplexStartR\vduplexStart\x12B\n" +
 	"\vaudio_input\x18\b \x01(\v2!.omnia.runtime.v1.AudioInputChunkR\n" +
-	"audioInput\x1a;\n" +
+	"audioInput\x12\x1a\n" +
+	"\bprogress\x18\t \x01(\bR\bprogress\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x98\x01\n" +
@@ -1834,7 +2123,7 @@ const file_api_proto_runtime_v1_runtime_proto_rawDesc = "" +
 	"resultJson\x12\x1f\n" +
 	"\vis_rejected\x18\x03 \x01(\bR\n" +
 	"isRejected\x12)\n" +
-	"\x10rejection_reason\x18\x04 \x01(\tR\x0frejectionReason\"\xb3\x03\n" +
+	"\x10rejection_reason\x18\x04 \x01(\tR\x0frejectionReason\"\xed\x03\n" +
 	"\rServerMessage\x12/\n" +
 	"\x05chunk\x18\x01 \x01(\v2\x17.omnia.runtime.v1.ChunkH\x00R\x05chunk\x129\n" +
 	"\ttool_call\x18\x02 \x01(\v2\x1a.omnia.runtime.v1.ToolCallH\x00R\btoolCall\x12,\n" +
@@ -1843,7 +2132,8 @@ const file_api_proto_runtime_v1_runtime_proto_rawDesc = "" +
 	"\vmedia_chunk\x18\x06 \x01(\v2\x1c.omnia.runtime.v1.MediaChunkH\x00R\n" +
 	"mediaChunk\x12D\n" +
 	"\finterruption\x18\a \x01(\v2\x1e.omnia.runtime.v1.InterruptionH\x00R\finterruption\x12E\n" +
-	"\rruntime_hello\x18\b \x01(\v2\x1e.omnia.runtime.v1.RuntimeHelloH\x00R\fruntimeHelloB\t\n" +
+	"\rruntime_hello\x18\b \x01(\v2\x1e.omnia.runtime.v1.RuntimeHelloH\x00R\fruntimeHello\x128\n" +
+	"\bprogress\x18\t \x01(\v2\x1a.omnia.runtime.v1.ProgressH\x00R\bprogressB\t\n" +
 	"\amessage\"5\n" +
 	"\x05Chunk\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x12\x12\n" +
@@ -1891,7 +2181,14 @@ const file_api_proto_runtime_v1_runtime_proto_rawDesc = "" +
 	"\bsequence\x18\x02 \x01(\x05R\bsequence\x12\x17\n" +
 	"\ais_last\x18\x03 \x01(\bR\x06isLast\x12\x1b\n" +
 	"\tmime_type\x18\x04 \x01(\tR\bmimeType\x12\x12\n" +
-	"\x04data\x18\x05 \x01(\fR\x04data\"\xe3\x01\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\"\x8d\x01\n" +
+	"\bProgress\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1b\n" +
+	"\ttool_name\x18\x03 \x01(\tR\btoolName\x12\x17\n" +
+	"\acall_id\x18\x04 \x01(\tR\x06callId\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x05 \x01(\x03R\telapsedMs\"\xe3\x01\n" +
 	"\x11InvocationRequest\x12\x1d\n" +
 	"\n" +
 	"input_json\x18\x01 \x01(\tR\tinputJson\x12#\n" +
@@ -1941,7 +2238,21 @@ const file_api_proto_runtime_v1_runtime_proto_rawDesc = "" +
 	"\x0fAudioInputChunk\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1a\n" +
 	"\bsequence\x18\x02 \x01(\rR\bsequence\x12\x17\n" +
-	"\ais_last\x18\x03 \x01(\bR\x06isLast*E\n" +
+	"\ais_last\x18\x03 \x01(\bR\x06isLast\":\n" +
+	"\fEmbedRequest\x12\x14\n" +
+	"\x05texts\x18\x01 \x03(\tR\x05texts\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\"#\n" +
+	"\tEmbedding\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x02R\x06values\"\xa5\x01\n" +
+	"\rEmbedResponse\x12;\n" +
+	"\n" +
+	"embeddings\x18\x01 \x03(\v2\x1b.omnia.runtime.v1.EmbeddingR\n" +
+	"embeddings\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12\x1e\n" +
+	"\n" +
+	"dimensions\x18\x03 \x01(\x05R\n" +
+	"dimensions\x12!\n" +
+	"\ftotal_tokens\x18\x04 \x01(\x05R\vtotalTokens*E\n" +
 	"\rToolExecution\x12\x19\n" +
 	"\x15TOOL_EXECUTION_SERVER\x10\x00\x12\x19\n" +
 	"\x15TOOL_EXECUTION_CLIENT\x10\x01*\x81\x01\n" +
@@ -1949,12 +2260,13 @@ const file_api_proto_runtime_v1_runtime_proto_rawDesc = "" +
 	"\x18RESUME_STATE_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16RESUME_STATE_RESUMABLE\x10\x01\x12\x1a\n" +
 	"\x16RESUME_STATE_NOT_FOUND\x10\x02\x12\x1c\n" +
-	"\x18RESUME_STATE_UNAVAILABLE\x10\x032\xec\x02\n" +
+	"\x18RESUME_STATE_UNAVAILABLE\x10\x032\xb6\x03\n" +
 	"\x0eRuntimeService\x12P\n" +
 	"\bConverse\x12\x1f.omnia.runtime.v1.ClientMessage\x1a\x1f.omnia.runtime.v1.ServerMessage(\x010\x01\x12S\n" +
 	"\x06Invoke\x12#.omnia.runtime.v1.InvocationRequest\x1a$.omnia.runtime.v1.InvocationResponse\x12K\n" +
 	"\x06Health\x12\x1f.omnia.runtime.v1.HealthRequest\x1a .omnia.runtime.v1.HealthResponse\x12f\n" +
-	"\x0fHasConversation\x12(.omnia.runtime.v1.HasConversationRequest\x1a).omnia.runtime.v1.HasConversationResponseB7Z5github.com/altairalabs/omnia/pkg/runtime/v1;runtimev1b\x06proto3"
+	"\x0fHasConversation\x12(.omnia.runtime.v1.HasConversationRequest\x1a).omnia.runtime.v1.HasConversationResponse\x12H\n" +
+	"\x05Embed\x12\x1e.omnia.runtime.v1.EmbedRequest\x1a\x1f.omnia.runtime.v1.EmbedResponseB7Z5github.com/altairalabs/omnia/pkg/runtime/v1;runtimev1b\x06proto3"
 
 var (
 	file_api_proto_runtime_v1_runtime_proto_rawDescOnce sync.Once
@@ -1969,7 +2281,7 @@ func file_api_proto_runtime_v1_runtime_proto_rawDescGZIP() []byte {
 }
 
 var file_api_proto_runtime_v1_runtime_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_proto_runtime_v1_runtime_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_api_proto_runtime_v1_runtime_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_api_proto_runtime_v1_runtime_proto_goTypes = []any{
 	(ToolExecution)(0),              // 0: omnia.runtime.v1.ToolExecution
 	(ResumeState)(0),                // 1: omnia.runtime.v1.ResumeState
@@ -1986,53 +2298,61 @@ var file_api_proto_runtime_v1_runtime_proto_goTypes = []any{
 	(*Error)(nil),                   // 12: omnia.runtime.v1.Error
 	(*Interruption)(nil),            // 13: omnia.runtime.v1.Interruption
 	(*MediaChunk)(nil),              // 14: omnia.runtime.v1.MediaChunk
-	(*InvocationRequest)(nil),       // 15: omnia.runtime.v1.InvocationRequest
-	(*InvocationResponse)(nil),      // 16: omnia.runtime.v1.InvocationResponse
-	(*HealthRequest)(nil),           // 17: omnia.runtime.v1.HealthRequest
-	(*HealthResponse)(nil),          // 18: omnia.runtime.v1.HealthResponse
-	(*HasConversationRequest)(nil),  // 19: omnia.runtime.v1.HasConversationRequest
-	(*HasConversationResponse)(nil), // 20: omnia.runtime.v1.HasConversationResponse
-	(*DuplexStart)(nil),             // 21: omnia.runtime.v1.DuplexStart
-	(*RuntimeHello)(nil),            // 22: omnia.runtime.v1.RuntimeHello
-	(*MediaNegotiation)(nil),        // 23: omnia.runtime.v1.MediaNegotiation
-	(*AudioInputChunk)(nil),         // 24: omnia.runtime.v1.AudioInputChunk
-	nil,                             // 25: omnia.runtime.v1.ClientMessage.MetadataEntry
-	nil,                             // 26: omnia.runtime.v1.InvocationRequest.MetadataEntry
+	(*Progress)(nil),                // 15: omnia.runtime.v1.Progress
+	(*InvocationRequest)(nil),       // 16: omnia.runtime.v1.InvocationRequest
+	(*InvocationResponse)(nil),      // 17: omnia.runtime.v1.InvocationResponse
+	(*HealthRequest)(nil),           // 18: omnia.runtime.v1.HealthRequest
+	(*HealthResponse)(nil),          // 19: omnia.runtime.v1.HealthResponse
+	(*HasConversationRequest)(nil),  // 20: omnia.runtime.v1.HasConversationRequest
+	(*HasConversationResponse)(nil), // 21: omnia.runtime.v1.HasConversationResponse
+	(*DuplexStart)(nil),             // 22: omnia.runtime.v1.DuplexStart
+	(*RuntimeHello)(nil),            // 23: omnia.runtime.v1.RuntimeHello
+	(*MediaNegotiation)(nil),        // 24: omnia.runtime.v1.MediaNegotiation
+	(*AudioInputChunk)(nil),         // 25: omnia.runtime.v1.AudioInputChunk
+	(*EmbedRequest)(nil),            // 26: omnia.runtime.v1.EmbedRequest
+	(*Embedding)(nil),               // 27: omnia.runtime.v1.Embedding
+	(*EmbedResponse)(nil),           // 28: omnia.runtime.v1.EmbedResponse
+	nil,                             // 29: omnia.runtime.v1.ClientMessage.MetadataEntry
+	nil,                             // 30: omnia.runtime.v1.InvocationRequest.MetadataEntry
 }
 var file_api_proto_runtime_v1_runtime_proto_depIdxs = []int32{
-	25, // 0: omnia.runtime.v1.ClientMessage.metadata:type_name -> omnia.runtime.v1.ClientMessage.MetadataEntry
+	29, // 0: omnia.runtime.v1.ClientMessage.metadata:type_name -> omnia.runtime.v1.ClientMessage.MetadataEntry
 	9,  // 1: omnia.runtime.v1.ClientMessage.parts:type_name -> omnia.runtime.v1.ContentPart
 	3,  // 2: omnia.runtime.v1.ClientMessage.client_tool_result:type_name -> omnia.runtime.v1.ClientToolResult
-	21, // 3: omnia.runtime.v1.ClientMessage.duplex_start:type_name -> omnia.runtime.v1.DuplexStart
-	24, // 4: omnia.runtime.v1.ClientMessage.audio_input:type_name -> omnia.runtime.v1.AudioInputChunk
+	22, // 3: omnia.runtime.v1.ClientMessage.duplex_start:type_name -> omnia.runtime.v1.DuplexStart
+	25, // 4: omnia.runtime.v1.ClientMessage.audio_input:type_name -> omnia.runtime.v1.AudioInputChunk
 	5,  // 5: omnia.runtime.v1.ServerMessage.chunk:type_name -> omnia.runtime.v1.Chunk
 	6,  // 6: omnia.runtime.v1.ServerMessage.tool_call:type_name -> omnia.runtime.v1.ToolCall
 	8,  // 7: omnia.runtime.v1.ServerMessage.done:type_name -> omnia.runtime.v1.Done
 	12, // 8: omnia.runtime.v1.ServerMessage.error:type_name -> omnia.runtime.v1.Error
 	14, // 9: omnia.runtime.v1.ServerMessage.media_chunk:type_name -> omnia.runtime.v1.MediaChunk
 	13, // 10: omnia.runtime.v1.ServerMessage.interruption:type_name -> omnia.runtime.v1.Interruption
-	22, // 11: omnia.runtime.v1.ServerMessage.runtime_hello:type_name -> omnia.runtime.v1.RuntimeHello
-	0,  // 12: omnia.runtime.v1.ToolCall.execution:type_name -> omnia.runtime.v1.ToolExecution
-	11, // 13: omnia.runtime.v1.Done.usage:type_name -> omnia.runtime.v1.Usage
-	9,  // 14: omnia.runtime.v1.Done.parts:type_name -> omnia.runtime.v1.ContentPart
-	10, // 15: omnia.runtime.v1.ContentPart.media:type_name -> omnia.runtime.v1.MediaContent
-	26, // 16: omnia.runtime.v1.InvocationRequest.metadata:type_name -> omnia.runtime.v1.InvocationRequest.MetadataEntry
-	11, // 17: omnia.runtime.v1.InvocationResponse.usage:type_name -> omnia.runtime.v1.Usage
-	1,  // 18: omnia.runtime.v1.HasConversationResponse.state:type_name -> omnia.runtime.v1.ResumeState
-	23, // 19: omnia.runtime.v1.RuntimeHello.media:type_name -> omnia.runtime.v1.MediaNegotiation
-	2,  // 20: omnia.runtime.v1.RuntimeService.Converse:input_type -> omnia.runtime.v1.ClientMessage
-	15, // 21: omnia.runtime.v1.RuntimeService.Invoke:input_type -> omnia.runtime.v1.InvocationRequest
-	17, // 22: omnia.runtime.v1.RuntimeService.Health:input_type -> omnia.runtime.v1.HealthRequest
-	19, // 23: omnia.runtime.v1.RuntimeService.HasConversation:input_type -> omnia.runtime.v1.HasConversationRequest
-	4,  // 24: omnia.runtime.v1.RuntimeService.Converse:output_type -> omnia.runtime.v1.ServerMessage
-	16, // 25: omnia.runtime.v1.RuntimeService.Invoke:output_type -> omnia.runtime.v1.InvocationResponse
-	18, // 26: omnia.runtime.v1.RuntimeService.Health:output_type -> omnia.runtime.v1.HealthResponse
-	20, // 27: omnia.runtime.v1.RuntimeService.HasConversation:output_type -> omnia.runtime.v1.HasConversationResponse
-	24, // [24:28] is the sub-list for method output_type
-	20, // [20:24] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	23, // 11: omnia.runtime.v1.ServerMessage.runtime_hello:type_name -> omnia.runtime.v1.RuntimeHello
+	15, // 12: omnia.runtime.v1.ServerMessage.progress:type_name -> omnia.runtime.v1.Progress
+	0,  // 13: omnia.runtime.v1.ToolCall.execution:type_name -> omnia.runtime.v1.ToolExecution
+	11, // 14: omnia.runtime.v1.Done.usage:type_name -> omnia.runtime.v1.Usage
+	9,  // 15: omnia.runtime.v1.Done.parts:type_name -> omnia.runtime.v1.ContentPart
+	10, // 16: omnia.runtime.v1.ContentPart.media:type_name -> omnia.runtime.v1.MediaContent
+	30, // 17: omnia.runtime.v1.InvocationRequest.metadata:type_name -> omnia.runtime.v1.InvocationRequest.MetadataEntry
+	11, // 18: omnia.runtime.v1.InvocationResponse.usage:type_name -> omnia.runtime.v1.Usage
+	1,  // 19: omnia.runtime.v1.HasConversationResponse.state:type_name -> omnia.runtime.v1.ResumeState
+	24, // 20: omnia.runtime.v1.RuntimeHello.media:type_name -> omnia.runtime.v1.MediaNegotiation
+	27, // 21: omnia.runtime.v1.EmbedResponse.embeddings:type_name -> omnia.runtime.v1.Embedding
+	2,  // 22: omnia.runtime.v1.RuntimeService.Converse:input_type -> omnia.runtime.v1.ClientMessage
+	16, // 23: omnia.runtime.v1.RuntimeService.Invoke:input_type -> omnia.runtime.v1.InvocationRequest
+	18, // 24: omnia.runtime.v1.RuntimeService.Health:input_type -> omnia.runtime.v1.HealthRequest
+	20, // 25: omnia.runtime.v1.RuntimeService.HasConversation:input_type -> omnia.runtime.v1.HasConversationRequest
+	26, // 26: omnia.runtime.v1.RuntimeService.Embed:input_type -> omnia.runtime.v1.EmbedRequest
+	4,  // 27: omnia.runtime.v1.RuntimeService.Converse:output_type -> omnia.runtime.v1.ServerMessage
+	17, // 28: omnia.runtime.v1.RuntimeService.Invoke:output_type -> omnia.runtime.v1.InvocationResponse
+	19, // 29: omnia.runtime.v1.RuntimeService.Health:output_type -> omnia.runtime.v1.HealthResponse
+	21, // 30: omnia.runtime.v1.RuntimeService.HasConversation:output_type -> omnia.runtime.v1.HasConversationResponse
+	28, // 31: omnia.runtime.v1.RuntimeService.Embed:output_type -> omnia.runtime.v1.EmbedResponse
+	27, // [27:32] is the sub-list for method output_type
+	22, // [22:27] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_runtime_v1_runtime_proto_init() }
@@ -2048,6 +2368,7 @@ func file_api_proto_runtime_v1_runtime_proto_init() {
 		(*ServerMessage_MediaChunk)(nil),
 		(*ServerMessage_Interruption)(nil),
 		(*ServerMessage_RuntimeHello)(nil),
+		(*ServerMessage_Progress)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2055,7 +2376,7 @@ func file_api_proto_runtime_v1_runtime_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_runtime_v1_runtime_proto_rawDesc), len(file_api_proto_runtime_v1_runtime_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   25,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
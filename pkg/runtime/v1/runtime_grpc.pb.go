@@ -26,6 +26,7 @@ const (
 	RuntimeService_Invoke_FullMethodName          = "/omnia.runtime.v1.RuntimeService/Invoke"
 	RuntimeService_Health_FullMethodName          = "/omnia.runtime.v1.RuntimeService/Health"
 	RuntimeService_HasConversation_FullMethodName = "/omnia.runtime.v1.RuntimeService/HasConversation"
+	RuntimeService_Embed_FullMethodName           = "/omnia.runtime.v1.RuntimeService/Embed"
 )
 
 // RuntimeServiceClient is the client API for RuntimeService service.
@@ -66,6 +67,13 @@ type RuntimeServiceClient interface {
 	// when the state store returns a non-nil state for the id, so this RPC
 	// performs the same load against the same store.
 	HasConversation(ctx context.Context, in *HasConversationRequest, opts ...grpc.CallOption) (*HasConversationResponse, error)
+	// Embed returns embedding vectors for a batch of texts using the runtime's
+	// embedding provider, so RAG pipelines and the response cache reuse the
+	// credentials and configuration the runtime already holds instead of
+	// configuring a provider of their own. A runtime without an embedding
+	// provider answers FAILED_PRECONDITION; one that does not implement the RPC
+	// answers UNIMPLEMENTED and must not advertise the "embed" capability.
+	Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error)
 }
 
 type runtimeServiceClient struct {
@@ -119,6 +127,16 @@ func (c *runtimeServiceClient) HasConversation(ctx context.Context, in *HasConve
 	return out, nil
 }
 
+func (c *runtimeServiceClient) Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmbedResponse)
+	err := c.cc.Invoke(ctx, RuntimeService_Embed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RuntimeServiceServer is the server API for RuntimeService service.
 // All implementations must embed UnimplementedRuntimeServiceServer
 // for forward compatibility.
@@ -157,6 +175,13 @@ type RuntimeServiceServer interface {
 	// when the state store returns a non-nil state for the id, so this RPC
 	// performs the same load against the same store.
 	HasConversation(context.Context, *HasConversationRequest) (*HasConversationResponse, error)
+	// Embed returns embedding vectors for a batch of texts using the runtime's
+	// embedding provider, so RAG pipelines and the response cache reuse the
+	// credentials and configuration the runtime already holds instead of
+	// configuring a provider of their own. A runtime without an embedding
+	// provider answers FAILED_PRECONDITION; one that does not implement the RPC
+	// answers UNIMPLEMENTED and must not advertise the "embed" capability.
+	Embed(context.Context, *EmbedRequest) (*EmbedResponse, error)
 	mustEmbedUnimplementedRuntimeServiceServer()
 }
 
@@ -179,6 +204,9 @@ func (UnimplementedRuntimeServiceServer) Health(context.Context, *HealthRequest)
 func (UnimplementedRuntimeServiceServer) HasConversation(context.Context, *HasConversationRequest) (*HasConversationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HasConversation not implemented")
 }
+func (UnimplementedRuntimeServiceServer) Embed(context.Context, *EmbedRequest) (*EmbedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Embed not implemented")
+}
 func (UnimplementedRuntimeServiceServer) mustEmbedUnimplementedRuntimeServiceServer() {}
 func (UnimplementedRuntimeServiceServer) testEmbeddedByValue()                        {}
 
@@ -261,6 +289,24 @@ func _RuntimeService_HasConversation_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeService_Embed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).Embed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RuntimeService_Embed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).Embed(ctx, req.(*EmbedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RuntimeService_ServiceDesc is the grpc.ServiceDesc for RuntimeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -280,6 +326,10 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HasConversation",
 			Handler:    _RuntimeService_HasConversation_Handler,
 		},
+		{
+			MethodName: "Embed",
+			Handler:    _RuntimeService_Embed_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
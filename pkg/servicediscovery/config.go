@@ -29,10 +29,17 @@ import (
 // secretKeyPostgresConn is the key in the database Secret that holds the PostgreSQL connection string.
 const secretKeyPostgresConn = "POSTGRES_CONN"
 
+// secretKeyPostgresReadConn is the optional key in the session database Secret
+// that holds a read replica's connection string.
+const secretKeyPostgresReadConn = "POSTGRES_READ_CONN"
+
 // SessionConfig holds the resolved configuration for a session-api instance.
 type SessionConfig struct {
 	// PostgresConn is the PostgreSQL connection string.
 	PostgresConn string
+	// PostgresReadConn is the read replica connection string. Empty means
+	// every read goes to PostgresConn.
+	PostgresReadConn string
 }
 
 // MemoryConfig holds the resolved configuration for a memory-api instance.
@@ -73,12 +80,15 @@ func (r *ConfigResolver) ResolveSessionConfig(
 	if group.Session == nil {
 		return nil, fmt.Errorf("service group %q in workspace %q has no session configuration", serviceGroup, workspace)
 	}
-	conn, err := r.readPostgresConn(ctx, group.Session.Database.SecretRef.Name, namespace)
+	data, err := r.readDatabaseSecret(ctx, group.Session.Database.SecretRef.Name, namespace)
 	if err != nil {
 		return nil, err
 	}
 
-	return &SessionConfig{PostgresConn: conn}, nil
+	return &SessionConfig{
+		PostgresConn:     string(data[secretKeyPostgresConn]),
+		PostgresReadConn: string(data[secretKeyPostgresReadConn]),
+	}, nil
 }
 
 // ResolveMemoryConfig reads the Workspace CRD and returns memory-api configuration
@@ -143,14 +153,22 @@ func (r *ConfigResolver) findServiceGroup(
 
 // readPostgresConn reads the POSTGRES_CONN key from a Secret.
 func (r *ConfigResolver) readPostgresConn(ctx context.Context, secretName, namespace string) (string, error) {
+	data, err := r.readDatabaseSecret(ctx, secretName, namespace)
+	if err != nil {
+		return "", err
+	}
+	return string(data[secretKeyPostgresConn]), nil
+}
+
+// readDatabaseSecret reads a database Secret, requiring its POSTGRES_CONN key.
+func (r *ConfigResolver) readDatabaseSecret(ctx context.Context, secretName, namespace string) (map[string][]byte, error) {
 	var secret corev1.Secret
 	key := client.ObjectKey{Name: secretName, Namespace: namespace}
 	if err := r.client.Get(ctx, key, &secret); err != nil {
-		return "", fmt.Errorf("get secret %q in namespace %q: %w", secretName, namespace, err)
+		return nil, fmt.Errorf("get secret %q in namespace %q: %w", secretName, namespace, err)
 	}
-	conn := string(secret.Data[secretKeyPostgresConn])
-	if conn == "" {
-		return "", fmt.Errorf("secret %q in namespace %q has no %q key", secretName, namespace, secretKeyPostgresConn)
+	if len(secret.Data[secretKeyPostgresConn]) == 0 {
+		return nil, fmt.Errorf("secret %q in namespace %q has no %q key", secretName, namespace, secretKeyPostgresConn)
 	}
-	return conn, nil
+	return secret.Data, nil
 }
@@ -86,6 +86,38 @@ func TestResolveSessionConfig(t *testing.T) {
 	if cfg.PostgresConn != "postgres://host/db" {
 		t.Errorf("unexpected postgres conn: %s", cfg.PostgresConn)
 	}
+	if cfg.PostgresReadConn != "" {
+		t.Errorf("expected no read replica, got %s", cfg.PostgresReadConn)
+	}
+}
+
+func TestResolveSessionConfig_ReadReplica(t *testing.T) {
+	ws := makeWorkspaceWithServices(testWorkspaceName, []omniav1alpha1.WorkspaceServiceGroup{
+		{
+			Name: "default",
+			Session: &omniav1alpha1.SessionServiceConfig{
+				Database: omniav1alpha1.DatabaseConfig{
+					SecretRef: corev1.LocalObjectReference{Name: "session-db-secret"},
+				},
+			},
+		},
+	})
+	secret := makeSecret("session-db-secret", "test-ns", "postgres://host/db")
+	secret.Data[secretKeyPostgresReadConn] = []byte("postgres://replica/db")
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithObjects(ws, secret).
+		Build()
+
+	cr := NewConfigResolver(fakeClient)
+	cfg, err := cr.ResolveSessionConfig(context.Background(), testWorkspaceName, "default", "test-ns")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.PostgresReadConn != "postgres://replica/db" {
+		t.Errorf("unexpected read conn: %s", cfg.PostgresReadConn)
+	}
 }
 
 func TestResolveSessionConfig_WorkspaceNotFound(t *testing.T) {
@@ -29,15 +29,18 @@ import (
 	neturl "net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 	"github.com/sony/gobreaker/v2"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 
 	"github.com/altairalabs/omnia/internal/serviceauth"
 	"github.com/altairalabs/omnia/internal/session"
+	"github.com/altairalabs/omnia/internal/tracing"
 	"github.com/altairalabs/omnia/pkg/sessionapi"
 )
 
@@ -49,6 +52,9 @@ const tokenPathEnv = "SESSION_API_TOKEN_PATH"
 // ErrNotImplemented is returned for Store methods not needed by the facade.
 var ErrNotImplemented = errors.New("not implemented by HTTP session client")
 
+// storeName names session-api on circuit breaker state changes and store spans.
+const storeName = "session-api"
+
 // Default timeout for HTTP requests to the session-api.
 const DefaultHTTPTimeout = 30 * time.Second
 
@@ -184,7 +190,7 @@ func NewStore(baseURL string, log logr.Logger, opts ...StoreOption) *Store {
 	// This injects traceparent into outbound requests to session-api.
 	s.httpClient.Transport = otelhttp.NewTransport(s.httpClient.Transport)
 	s.cb = gobreaker.NewCircuitBreaker[*http.Response](gobreaker.Settings{
-		Name:        "session-api",
+		Name:        storeName,
 		MaxRequests: cbMaxRequests,
 		Interval:    cbInterval,
 		Timeout:     cbTimeout,
@@ -554,9 +560,66 @@ func (s *Store) DeleteSession(_ context.Context, _ string) error {
 	return ErrNotImplemented
 }
 
-// GetMessages is not used by the facade.
-func (s *Store) GetMessages(_ context.Context, _ string) ([]session.Message, error) {
-	return nil, ErrNotImplemented
+// messagesPageSize is the page size used when walking a session's messages.
+// It matches session-api's maximum so a full read takes as few round trips
+// as possible.
+const messagesPageSize = 500
+
+// messagesPage is the wire shape of GET /api/v1/sessions/{sessionID}/messages.
+type messagesPage struct {
+	Messages []session.Message `json:"messages"`
+	HasMore  bool              `json:"hasMore"`
+}
+
+// GetMessages retrieves every message in a session via
+// GET /api/v1/sessions/{sessionID}/messages, following the sequence cursor
+// until session-api reports no more pages. Not used by the facade; the dev
+// console reads messages back to build replay timelines.
+func (s *Store) GetMessages(ctx context.Context, sessionID string) ([]session.Message, error) {
+	var all []session.Message
+	after := int32(0)
+	for {
+		path := fmt.Sprintf("/api/v1/sessions/%s/messages?limit=%d", sessionID, messagesPageSize)
+		if after > 0 {
+			path += "&after=" + strconv.Itoa(int(after))
+		}
+		page, err := s.getMessagesPage(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Messages...)
+		if !page.HasMore || len(page.Messages) == 0 {
+			return all, nil
+		}
+		next := page.Messages[len(page.Messages)-1].SequenceNum
+		if next <= after {
+			// A cursor that does not advance would loop forever.
+			return all, nil
+		}
+		after = next
+	}
+}
+
+// getMessagesPage fetches and decodes a single page of messages.
+func (s *Store) getMessagesPage(ctx context.Context, path string) (*messagesPage, error) {
+	resp, err := s.doWithRetry(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get messages: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, session.ErrSessionNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, s.readError(resp)
+	}
+
+	var page messagesPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("decode messages: %w", err)
+	}
+	return &page, nil
 }
 
 // GetToolCalls retrieves tool calls via GET /api/v1/sessions/{sessionID}/tool-calls.
@@ -692,10 +755,34 @@ func (s *Store) doJSON(ctx context.Context, method, path string, body any) (*htt
 // doWithRetry executes an HTTP request with retry for transient failures,
 // wrapped in a circuit breaker. When the breaker is open, requests fail
 // immediately without hitting session-api.
+//
+// The whole call, retries and backoff included, is recorded as a store span so
+// session-api latency shows up as its own hop in a turn's trace.
 func (s *Store) doWithRetry(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
-	return s.cb.Execute(func() (*http.Response, error) {
+	ctx, span := tracing.StartStoreSpan(ctx, storeName, method+" "+routeTemplate(path))
+	defer span.End()
+	resp, err := s.cb.Execute(func() (*http.Response, error) {
 		return s.doWithRetryInner(ctx, method, path, body)
 	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	return resp, nil
+}
+
+// routeTemplate replaces the session ID in a session-api path with a
+// placeholder and drops the query, keeping span operation names low-cardinality.
+func routeTemplate(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i-1] == "sessions" {
+			segments[i] = "{sessionID}"
+		}
+	}
+	return strings.Join(segments, "/")
 }
 
 // doWithRetryInner contains the retry loop. Called within the circuit breaker.
@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
@@ -594,8 +595,49 @@ func TestNotImplementedMethods(t *testing.T) {
 	if err := store.DeleteSession(ctx, "x"); err != ErrNotImplemented {
 		t.Fatalf("DeleteSession: expected ErrNotImplemented, got %v", err)
 	}
-	if _, err := store.GetMessages(ctx, "x"); err != ErrNotImplemented {
-		t.Fatalf("GetMessages: expected ErrNotImplemented, got %v", err)
+}
+
+func TestGetMessages_FollowsSequenceCursor(t *testing.T) {
+	var afters []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/sessions/s1/messages" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		after := r.URL.Query().Get("after")
+		afters = append(afters, after)
+		w.Header().Set("Content-Type", "application/json")
+		if after == "" {
+			_, _ = w.Write([]byte(`{"messages":[{"id":"m1","sequenceNum":1},{"id":"m2","sequenceNum":2}],"hasMore":true}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"messages":[{"id":"m3","sequenceNum":3}],"hasMore":false}`))
+	}))
+	defer srv.Close()
+
+	store := NewStore(srv.URL, logr.Discard())
+	t.Cleanup(func() { _ = store.Close() })
+	msgs, err := store.GetMessages(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("GetMessages: %v", err)
+	}
+	if len(msgs) != 3 || msgs[2].ID != "m3" {
+		t.Fatalf("expected 3 messages ending in m3, got %+v", msgs)
+	}
+	if len(afters) != 2 || afters[1] != "2" {
+		t.Fatalf("expected second page after=2, got %v", afters)
+	}
+}
+
+func TestGetMessages_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	store := NewStore(srv.URL, logr.Discard())
+	t.Cleanup(func() { _ = store.Close() })
+	if _, err := store.GetMessages(context.Background(), "missing"); err != session.ErrSessionNotFound {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
 	}
 }
 
@@ -1228,6 +1270,48 @@ func TestNewStore_TransportPropagatesTraceContext(t *testing.T) {
 	}
 }
 
+func TestAppendMessage_RecordsStoreSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		_ = tp.Shutdown(context.Background())
+		otel.SetTracerProvider(tracenoop.NewTracerProvider())
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+
+	store := NewStore(srv.URL, logr.Discard())
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx, turn := tp.Tracer("test").Start(context.Background(), "turn")
+	require.NoError(t, store.AppendMessage(ctx, "abc-123", session.Message{ID: "m1", Role: session.RoleUser}))
+	turn.End()
+
+	var storeSpan *tracetest.SpanStub
+	spans := exporter.GetSpans()
+	for i := range spans {
+		if spans[i].Name == "omnia.store.call" {
+			storeSpan = &spans[i]
+		}
+	}
+	require.NotNil(t, storeSpan)
+	assert.Equal(t, turn.SpanContext().SpanID(), storeSpan.Parent.SpanID())
+	assert.Contains(t, storeSpan.Attributes,
+		attribute.String("omnia.store.operation", "POST /api/v1/sessions/{sessionID}/messages"))
+	assert.Contains(t, storeSpan.Attributes, attribute.String("omnia.latency.kind", "store"))
+}
+
+func TestRouteTemplate(t *testing.T) {
+	assert.Equal(t, "/api/v1/sessions", routeTemplate("/api/v1/sessions"))
+	assert.Equal(t, "/api/v1/sessions/{sessionID}", routeTemplate("/api/v1/sessions/abc"))
+	assert.Equal(t, "/api/v1/sessions/{sessionID}/messages", routeTemplate("/api/v1/sessions/abc/messages?limit=5"))
+	assert.Equal(t, "/api/v1/privacy-policy", routeTemplate("/api/v1/privacy-policy?namespace=ns"))
+}
+
 // --- getPaginatedDetail error tests ---
 
 func TestGetToolCalls_ServerError(t *testing.T) {
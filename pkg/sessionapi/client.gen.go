@@ -35,9 +35,12 @@ const (
 // Defines values for SessionStatus.
 const (
 	SessionStatusActive    SessionStatus = "active"
+	SessionStatusArchived  SessionStatus = "archived"
 	SessionStatusCompleted SessionStatus = "completed"
 	SessionStatusError     SessionStatus = "error"
+	SessionStatusEscalated SessionStatus = "escalated"
 	SessionStatusExpired   SessionStatus = "expired"
+	SessionStatusIdle      SessionStatus = "idle"
 )
 
 // Defines values for ToolCallStatus.
@@ -47,6 +50,58 @@ const (
 	Success ToolCallStatus = "success"
 )
 
+// AnnotationListResponse defines model for AnnotationListResponse.
+type AnnotationListResponse struct {
+	Annotations *[]MessageAnnotation `json:"annotations,omitempty"`
+	HasMore     *bool                `json:"hasMore,omitempty"`
+
+	// Total Matching annotations (namespace listing only)
+	Total *int64 `json:"total,omitempty"`
+}
+
+// AnnotationResponse defines model for AnnotationResponse.
+type AnnotationResponse struct {
+	Annotation *MessageAnnotation `json:"annotation,omitempty"`
+}
+
+// Attachment defines model for Attachment.
+type Attachment struct {
+	Channels *int32 `json:"channels,omitempty"`
+
+	// Checksum SHA-256 checksum of the content
+	Checksum   *string             `json:"checksum,omitempty"`
+	CreatedAt  *time.Time          `json:"createdAt,omitempty"`
+	DurationMs *int32              `json:"durationMs,omitempty"`
+	Filename   *string             `json:"filename,omitempty"`
+	Height     *int32              `json:"height,omitempty"`
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+	MessageId  *openapi_types.UUID `json:"messageId,omitempty"`
+	Metadata   *map[string]string  `json:"metadata,omitempty"`
+	MimeType   string              `json:"mimeType"`
+	SampleRate *int32              `json:"sampleRate,omitempty"`
+	SessionId  *openapi_types.UUID `json:"sessionId,omitempty"`
+	SizeBytes  *int64              `json:"sizeBytes,omitempty"`
+
+	// StorageUri Media storage reference (omnia://...) or http(s) URL
+	StorageUri string `json:"storageUri"`
+
+	// Type One of image, audio, video, document or file. Derived from
+	// mimeType when omitted.
+	Type  *string `json:"type,omitempty"`
+	Width *int32  `json:"width,omitempty"`
+}
+
+// AttachmentURLResponse defines model for AttachmentURLResponse.
+type AttachmentURLResponse struct {
+	Attachment *Attachment `json:"attachment,omitempty"`
+	Url        *string     `json:"url,omitempty"`
+}
+
+// AttachmentsResponse defines model for AttachmentsResponse.
+type AttachmentsResponse struct {
+	Attachments *[]Attachment `json:"attachments,omitempty"`
+}
+
 // CreateSessionRequest defines model for CreateSessionRequest.
 type CreateSessionRequest struct {
 	AgentName *string `json:"agentName,omitempty"`
@@ -79,7 +134,17 @@ type CreateSessionRequest struct {
 
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
+	// Code Machine-readable error code (e.g. SESSION_NOT_FOUND, INVALID_REQUEST, RATE_LIMITED)
+	Code string `json:"code"`
+
+	// CorrelationId Identifies the request in server logs; echoed in the X-Omnia-Request-ID response header
+	CorrelationId *string `json:"correlation_id,omitempty"`
+
+	// Error Error message, safe to show a user
 	Error string `json:"error"`
+
+	// Retryable Whether sending the request again, after a backoff, may succeed
+	Retryable bool `json:"retryable"`
 }
 
 // EvalResult defines model for EvalResult.
@@ -122,6 +187,9 @@ type EvaluateAcceptedResponse struct {
 
 // Message defines model for Message.
 type Message struct {
+	// Attachments References to files carried by the message. Only the reference
+	// is stored; resolve it with the attachment URL endpoint.
+	Attachments  *[]Attachment      `json:"attachments,omitempty"`
 	Content      *string            `json:"content,omitempty"`
 	CostUsd      *float64           `json:"costUsd,omitempty"`
 	HasMedia     *bool              `json:"hasMedia,omitempty"`
@@ -136,6 +204,27 @@ type Message struct {
 	ToolCallId   *string            `json:"toolCallId,omitempty"`
 }
 
+// MessageAnnotation defines model for MessageAnnotation.
+type MessageAnnotation struct {
+	AgentName *string `json:"agentName,omitempty"`
+	Author    *string `json:"author,omitempty"`
+
+	// Correction Suggested replacement for the message content
+	Correction *string             `json:"correction,omitempty"`
+	CreatedAt  *time.Time          `json:"createdAt,omitempty"`
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+	Labels     *[]string           `json:"labels,omitempty"`
+	MessageId  *openapi_types.UUID `json:"messageId,omitempty"`
+	Namespace  *string             `json:"namespace,omitempty"`
+
+	// Score Thumbs down (-1) or up (1)
+	Score     *int                `json:"score,omitempty"`
+	SessionId *openapi_types.UUID `json:"sessionId,omitempty"`
+
+	// Source user (end-user feedback, the default) or reviewer
+	Source *string `json:"source,omitempty"`
+}
+
 // MessageRole defines model for MessageRole.
 type MessageRole string
 
@@ -230,25 +319,31 @@ type Session struct {
 	AgentName *string `json:"agentName,omitempty"`
 
 	// CohortId Rollout cohort identifier
-	CohortId           *string             `json:"cohortId,omitempty"`
-	CreatedAt          *time.Time          `json:"createdAt,omitempty"`
-	EndedAt            *time.Time          `json:"endedAt,omitempty"`
-	EstimatedCostUSD   *float64            `json:"estimatedCostUSD,omitempty"`
-	ExpiresAt          *time.Time          `json:"expiresAt,omitempty"`
-	Id                 *openapi_types.UUID `json:"id,omitempty"`
-	LastMessagePreview *string             `json:"lastMessagePreview,omitempty"`
-	MessageCount       *int32              `json:"messageCount,omitempty"`
-	Messages           *[]Message          `json:"messages,omitempty"`
-	Namespace          *string             `json:"namespace,omitempty"`
-	PromptPackName     *string             `json:"promptPackName,omitempty"`
-	PromptPackVersion  *string             `json:"promptPackVersion,omitempty"`
-	State              *map[string]string  `json:"state,omitempty"`
-	Status             *SessionStatus      `json:"status,omitempty"`
-	Tags               *[]string           `json:"tags,omitempty"`
-	ToolCallCount      *int32              `json:"toolCallCount,omitempty"`
-	TotalInputTokens   *int64              `json:"totalInputTokens,omitempty"`
-	TotalOutputTokens  *int64              `json:"totalOutputTokens,omitempty"`
-	UpdatedAt          *time.Time          `json:"updatedAt,omitempty"`
+	CohortId         *string    `json:"cohortId,omitempty"`
+	CreatedAt        *time.Time `json:"createdAt,omitempty"`
+	EndedAt          *time.Time `json:"endedAt,omitempty"`
+	EstimatedCostUSD *float64   `json:"estimatedCostUSD,omitempty"`
+	ExpiresAt        *time.Time `json:"expiresAt,omitempty"`
+
+	// ForkedFromMessageId Last parent message copied into this fork; absent when the whole history was copied
+	ForkedFromMessageId *string             `json:"forkedFromMessageId,omitempty"`
+	Id                  *openapi_types.UUID `json:"id,omitempty"`
+	LastMessagePreview  *string             `json:"lastMessagePreview,omitempty"`
+	MessageCount        *int32              `json:"messageCount,omitempty"`
+	Messages            *[]Message          `json:"messages,omitempty"`
+	Namespace           *string             `json:"namespace,omitempty"`
+
+	// ParentSessionId Session this one was forked from
+	ParentSessionId   *string            `json:"parentSessionId,omitempty"`
+	PromptPackName    *string            `json:"promptPackName,omitempty"`
+	PromptPackVersion *string            `json:"promptPackVersion,omitempty"`
+	State             *map[string]string `json:"state,omitempty"`
+	Status            *SessionStatus     `json:"status,omitempty"`
+	Tags              *[]string          `json:"tags,omitempty"`
+	ToolCallCount     *int32             `json:"toolCallCount,omitempty"`
+	TotalInputTokens  *int64             `json:"totalInputTokens,omitempty"`
+	TotalOutputTokens *int64             `json:"totalOutputTokens,omitempty"`
+	UpdatedAt         *time.Time         `json:"updatedAt,omitempty"`
 
 	// Variant Rollout variant (e.g., stable, canary)
 	Variant *string `json:"variant,omitempty"`
@@ -298,6 +393,18 @@ type ToolCall struct {
 // ToolCallStatus defines model for ToolCallStatus.
 type ToolCallStatus string
 
+// TransitionSessionRequest defines model for TransitionSessionRequest.
+type TransitionSessionRequest struct {
+	Status SessionStatus `json:"status"`
+}
+
+// TransitionSessionResponse defines model for TransitionSessionResponse.
+type TransitionSessionResponse struct {
+	PreviousStatus *SessionStatus      `json:"previousStatus,omitempty"`
+	SessionId      *openapi_types.UUID `json:"sessionId,omitempty"`
+	Status         *SessionStatus      `json:"status,omitempty"`
+}
+
 // Agent defines model for Agent.
 type Agent = string
 
@@ -313,6 +420,9 @@ type NamespaceQuery = string
 // Offset defines model for Offset.
 type Offset = int
 
+// ParentSessionFilter defines model for ParentSessionFilter.
+type ParentSessionFilter = openapi_types.UUID
+
 // SessionID defines model for SessionID.
 type SessionID = openapi_types.UUID
 
@@ -331,12 +441,45 @@ type BadRequest = ErrorResponse
 // BodyTooLarge defines model for BodyTooLarge.
 type BodyTooLarge = ErrorResponse
 
+// Conflict defines model for Conflict.
+type Conflict = ErrorResponse
+
 // InternalError defines model for InternalError.
 type InternalError = ErrorResponse
 
 // NotFound defines model for NotFound.
 type NotFound = ErrorResponse
 
+// ListAnnotationsParams defines parameters for ListAnnotations.
+type ListAnnotationsParams struct {
+	// Namespace Kubernetes namespace
+	Namespace string `form:"namespace" json:"namespace"`
+
+	// AgentName Filter by agent name
+	AgentName *string `form:"agentName,omitempty" json:"agentName,omitempty"`
+
+	// Source Filter by annotation source (user or reviewer)
+	Source *string `form:"source,omitempty" json:"source,omitempty"`
+
+	// Label Only annotations carrying this label
+	Label *string `form:"label,omitempty" json:"label,omitempty"`
+
+	// HasCorrection Only annotations with a suggested correction
+	HasCorrection *bool `form:"hasCorrection,omitempty" json:"hasCorrection,omitempty"`
+
+	// From Filter annotations created at or after this time (RFC3339)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Filter annotations created before this time (RFC3339)
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit Maximum items to return (default 20, max 100)
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip (max 10000)
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // ListEvalResultsParams defines parameters for ListEvalResults.
 type ListEvalResultsParams struct {
 	// AgentName Filter by agent name
@@ -390,6 +533,9 @@ type ListSessionsParams struct {
 	// Status Filter by session status
 	Status *StatusFilter `form:"status,omitempty" json:"status,omitempty"`
 
+	// ParentSessionId List only the forks of this session
+	ParentSessionId *ParentSessionFilter `form:"parentSessionId,omitempty" json:"parentSessionId,omitempty"`
+
 	// From Filter sessions created after this time (RFC3339)
 	From *From `form:"from,omitempty" json:"from,omitempty"`
 
@@ -433,6 +579,12 @@ type SearchSessionsParams struct {
 	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
+// ForkSessionParams defines parameters for ForkSession.
+type ForkSessionParams struct {
+	// FromMessage ID of the last message to copy into the fork
+	FromMessage *openapi_types.UUID `form:"fromMessage,omitempty" json:"fromMessage,omitempty"`
+}
+
 // GetMessagesParams defines parameters for GetMessages.
 type GetMessagesParams struct {
 	// Limit Max messages to return (default 50, max 500)
@@ -460,6 +612,9 @@ type RecordRuntimeEventJSONRequestBody = RuntimeEvent
 // AppendMessageJSONRequestBody defines body for AppendMessage for application/json ContentType.
 type AppendMessageJSONRequestBody = Message
 
+// RecordAnnotationJSONRequestBody defines body for RecordAnnotation for application/json ContentType.
+type RecordAnnotationJSONRequestBody = MessageAnnotation
+
 // RecordProviderCallJSONRequestBody defines body for RecordProviderCall for application/json ContentType.
 type RecordProviderCallJSONRequestBody = ProviderCall
 
@@ -469,6 +624,9 @@ type UpdateStatusJSONRequestBody = SessionStatusUpdate
 // RecordToolCallJSONRequestBody defines body for RecordToolCall for application/json ContentType.
 type RecordToolCallJSONRequestBody = ToolCall
 
+// TransitionStatusJSONRequestBody defines body for TransitionStatus for application/json ContentType.
+type TransitionStatusJSONRequestBody = TransitionSessionRequest
+
 // RefreshTTLJSONRequestBody defines body for RefreshTTL for application/json ContentType.
 type RefreshTTLJSONRequestBody = RefreshTTLRequest
 
@@ -545,6 +703,9 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// ListAnnotations request
+	ListAnnotations(ctx context.Context, params *ListAnnotationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListEvalResults request
 	ListEvalResults(ctx context.Context, params *ListEvalResultsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -578,6 +739,15 @@ type ClientInterface interface {
 	// GetSession request
 	GetSession(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetSessionAnnotations request
+	GetSessionAnnotations(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListAttachments request
+	ListAttachments(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAttachmentURL request
+	GetAttachmentURL(ctx context.Context, sessionID SessionID, attachmentID openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetSessionEvalResults request
 	GetSessionEvalResults(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -592,6 +762,9 @@ type ClientInterface interface {
 
 	RecordRuntimeEvent(ctx context.Context, sessionID SessionID, body RecordRuntimeEventJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ForkSession request
+	ForkSession(ctx context.Context, sessionID SessionID, params *ForkSessionParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetMessages request
 	GetMessages(ctx context.Context, sessionID SessionID, params *GetMessagesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -600,6 +773,11 @@ type ClientInterface interface {
 
 	AppendMessage(ctx context.Context, sessionID SessionID, body AppendMessageJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// RecordAnnotationWithBody request with any body
+	RecordAnnotationWithBody(ctx context.Context, sessionID SessionID, messageID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RecordAnnotation(ctx context.Context, sessionID SessionID, messageID openapi_types.UUID, body RecordAnnotationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetProviderCalls request
 	GetProviderCalls(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -621,6 +799,11 @@ type ClientInterface interface {
 
 	RecordToolCall(ctx context.Context, sessionID SessionID, body RecordToolCallJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// TransitionStatusWithBody request with any body
+	TransitionStatusWithBody(ctx context.Context, sessionID SessionID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TransitionStatus(ctx context.Context, sessionID SessionID, body TransitionStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// RefreshTTLWithBody request with any body
 	RefreshTTLWithBody(ctx context.Context, sessionID SessionID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -630,6 +813,18 @@ type ClientInterface interface {
 	HealthCheck(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
+func (c *Client) ListAnnotations(ctx context.Context, params *ListAnnotationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAnnotationsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ListEvalResults(ctx context.Context, params *ListEvalResultsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewListEvalResultsRequest(c.Server, params)
 	if err != nil {
@@ -774,6 +969,42 @@ func (c *Client) GetSession(ctx context.Context, sessionID SessionID, reqEditors
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetSessionAnnotations(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSessionAnnotationsRequest(c.Server, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListAttachments(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAttachmentsRequest(c.Server, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAttachmentURL(ctx context.Context, sessionID SessionID, attachmentID openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAttachmentURLRequest(c.Server, sessionID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetSessionEvalResults(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetSessionEvalResultsRequest(c.Server, sessionID)
 	if err != nil {
@@ -834,6 +1065,18 @@ func (c *Client) RecordRuntimeEvent(ctx context.Context, sessionID SessionID, bo
 	return c.Client.Do(req)
 }
 
+func (c *Client) ForkSession(ctx context.Context, sessionID SessionID, params *ForkSessionParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewForkSessionRequest(c.Server, sessionID, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetMessages(ctx context.Context, sessionID SessionID, params *GetMessagesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetMessagesRequest(c.Server, sessionID, params)
 	if err != nil {
@@ -870,6 +1113,30 @@ func (c *Client) AppendMessage(ctx context.Context, sessionID SessionID, body Ap
 	return c.Client.Do(req)
 }
 
+func (c *Client) RecordAnnotationWithBody(ctx context.Context, sessionID SessionID, messageID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRecordAnnotationRequestWithBody(c.Server, sessionID, messageID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RecordAnnotation(ctx context.Context, sessionID SessionID, messageID openapi_types.UUID, body RecordAnnotationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRecordAnnotationRequest(c.Server, sessionID, messageID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetProviderCalls(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetProviderCallsRequest(c.Server, sessionID)
 	if err != nil {
@@ -966,6 +1233,30 @@ func (c *Client) RecordToolCall(ctx context.Context, sessionID SessionID, body R
 	return c.Client.Do(req)
 }
 
+func (c *Client) TransitionStatusWithBody(ctx context.Context, sessionID SessionID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTransitionStatusRequestWithBody(c.Server, sessionID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TransitionStatus(ctx context.Context, sessionID SessionID, body TransitionStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTransitionStatusRequest(c.Server, sessionID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) RefreshTTLWithBody(ctx context.Context, sessionID SessionID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewRefreshTTLRequestWithBody(c.Server, sessionID, contentType, body)
 	if err != nil {
@@ -1002,8 +1293,8 @@ func (c *Client) HealthCheck(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-// NewListEvalResultsRequest generates requests for ListEvalResults
-func NewListEvalResultsRequest(server string, params *ListEvalResultsParams) (*http.Request, error) {
+// NewListAnnotationsRequest generates requests for ListAnnotations
+func NewListAnnotationsRequest(server string, params *ListAnnotationsParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1011,7 +1302,7 @@ func NewListEvalResultsRequest(server string, params *ListEvalResultsParams) (*h
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/eval-results")
+	operationPath := fmt.Sprintf("/api/v1/annotations")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1024,6 +1315,18 @@ func NewListEvalResultsRequest(server string, params *ListEvalResultsParams) (*h
 	if params != nil {
 		queryValues := queryURL.Query()
 
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "namespace", runtime.ParamLocationQuery, params.Namespace); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
 		if params.AgentName != nil {
 
 			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "agentName", runtime.ParamLocationQuery, *params.AgentName); err != nil {
@@ -1040,9 +1343,9 @@ func NewListEvalResultsRequest(server string, params *ListEvalResultsParams) (*h
 
 		}
 
-		if params.Namespace != nil {
+		if params.Source != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "namespace", runtime.ParamLocationQuery, *params.Namespace); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "source", runtime.ParamLocationQuery, *params.Source); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -1056,9 +1359,9 @@ func NewListEvalResultsRequest(server string, params *ListEvalResultsParams) (*h
 
 		}
 
-		if params.EvalId != nil {
+		if params.Label != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "evalId", runtime.ParamLocationQuery, *params.EvalId); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "label", runtime.ParamLocationQuery, *params.Label); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -1072,9 +1375,9 @@ func NewListEvalResultsRequest(server string, params *ListEvalResultsParams) (*h
 
 		}
 
-		if params.EvalType != nil {
+		if params.HasCorrection != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "evalType", runtime.ParamLocationQuery, *params.EvalType); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "hasCorrection", runtime.ParamLocationQuery, *params.HasCorrection); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -1088,9 +1391,25 @@ func NewListEvalResultsRequest(server string, params *ListEvalResultsParams) (*h
 
 		}
 
-		if params.Passed != nil {
+		if params.From != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "passed", runtime.ParamLocationQuery, *params.Passed); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -1147,19 +1466,8 @@ func NewListEvalResultsRequest(server string, params *ListEvalResultsParams) (*h
 	return req, nil
 }
 
-// NewCreateEvalResultsRequest calls the generic CreateEvalResults builder with application/json body
-func NewCreateEvalResultsRequest(server string, body CreateEvalResultsJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewCreateEvalResultsRequestWithBody(server, "application/json", bodyReader)
-}
-
-// NewCreateEvalResultsRequestWithBody generates requests for CreateEvalResults with any type of body
-func NewCreateEvalResultsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewListEvalResultsRequest generates requests for ListEvalResults
+func NewListEvalResultsRequest(server string, params *ListEvalResultsParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1177,33 +1485,189 @@ func NewCreateEvalResultsRequestWithBody(server string, contentType string, body
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
-		return nil, err
-	}
+	if params != nil {
+		queryValues := queryURL.Query()
 
-	req.Header.Add("Content-Type", contentType)
+		if params.AgentName != nil {
 
-	return req, nil
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "agentName", runtime.ParamLocationQuery, *params.AgentName); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-// NewGetPrivacyPolicyRequest generates requests for GetPrivacyPolicy
-func NewGetPrivacyPolicyRequest(server string, params *GetPrivacyPolicyParams) (*http.Request, error) {
-	var err error
+		}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+		if params.Namespace != nil {
 
-	operationPath := fmt.Sprintf("/api/v1/privacy-policy")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "namespace", runtime.ParamLocationQuery, *params.Namespace); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
+		}
+
+		if params.EvalId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "evalId", runtime.ParamLocationQuery, *params.EvalId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.EvalType != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "evalType", runtime.ParamLocationQuery, *params.EvalType); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Passed != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "passed", runtime.ParamLocationQuery, *params.Passed); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateEvalResultsRequest calls the generic CreateEvalResults builder with application/json body
+func NewCreateEvalResultsRequest(server string, body CreateEvalResultsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateEvalResultsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateEvalResultsRequestWithBody generates requests for CreateEvalResults with any type of body
+func NewCreateEvalResultsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/eval-results")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetPrivacyPolicyRequest generates requests for GetPrivacyPolicy
+func NewGetPrivacyPolicyRequest(server string, params *GetPrivacyPolicyParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/privacy-policy")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
 
 	if params != nil {
@@ -1370,6 +1834,22 @@ func NewListSessionsRequest(server string, params *ListSessionsParams) (*http.Re
 
 		}
 
+		if params.ParentSessionId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "parentSessionId", runtime.ParamLocationQuery, *params.ParentSessionId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
 		if params.From != nil {
 
 			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
@@ -1726,8 +2206,8 @@ func NewGetSessionRequest(server string, sessionID SessionID) (*http.Request, er
 	return req, nil
 }
 
-// NewGetSessionEvalResultsRequest generates requests for GetSessionEvalResults
-func NewGetSessionEvalResultsRequest(server string, sessionID SessionID) (*http.Request, error) {
+// NewGetSessionAnnotationsRequest generates requests for GetSessionAnnotations
+func NewGetSessionAnnotationsRequest(server string, sessionID SessionID) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -1742,7 +2222,7 @@ func NewGetSessionEvalResultsRequest(server string, sessionID SessionID) (*http.
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/sessions/%s/eval-results", pathParam0)
+	operationPath := fmt.Sprintf("/api/v1/sessions/%s/annotations", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1760,8 +2240,8 @@ func NewGetSessionEvalResultsRequest(server string, sessionID SessionID) (*http.
 	return req, nil
 }
 
-// NewEvaluateSessionRequest generates requests for EvaluateSession
-func NewEvaluateSessionRequest(server string, sessionID SessionID) (*http.Request, error) {
+// NewListAttachmentsRequest generates requests for ListAttachments
+func NewListAttachmentsRequest(server string, sessionID SessionID) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -1776,7 +2256,7 @@ func NewEvaluateSessionRequest(server string, sessionID SessionID) (*http.Reques
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/sessions/%s/evaluate", pathParam0)
+	operationPath := fmt.Sprintf("/api/v1/sessions/%s/attachments", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1786,7 +2266,7 @@ func NewEvaluateSessionRequest(server string, sessionID SessionID) (*http.Reques
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1794,8 +2274,8 @@ func NewEvaluateSessionRequest(server string, sessionID SessionID) (*http.Reques
 	return req, nil
 }
 
-// NewGetRuntimeEventsRequest generates requests for GetRuntimeEvents
-func NewGetRuntimeEventsRequest(server string, sessionID SessionID) (*http.Request, error) {
+// NewGetAttachmentURLRequest generates requests for GetAttachmentURL
+func NewGetAttachmentURLRequest(server string, sessionID SessionID, attachmentID openapi_types.UUID) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -1805,12 +2285,19 @@ func NewGetRuntimeEventsRequest(server string, sessionID SessionID) (*http.Reque
 		return nil, err
 	}
 
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "attachmentID", runtime.ParamLocationPath, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/sessions/%s/events", pathParam0)
+	operationPath := fmt.Sprintf("/api/v1/sessions/%s/attachments/%s/url", pathParam0, pathParam1)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1828,19 +2315,42 @@ func NewGetRuntimeEventsRequest(server string, sessionID SessionID) (*http.Reque
 	return req, nil
 }
 
-// NewRecordRuntimeEventRequest calls the generic RecordRuntimeEvent builder with application/json body
-func NewRecordRuntimeEventRequest(server string, sessionID SessionID, body RecordRuntimeEventJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+// NewGetSessionEvalResultsRequest generates requests for GetSessionEvalResults
+func NewGetSessionEvalResultsRequest(server string, sessionID SessionID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "sessionID", runtime.ParamLocationPath, sessionID)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewRecordRuntimeEventRequestWithBody(server, sessionID, "application/json", bodyReader)
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/sessions/%s/eval-results", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
 }
 
-// NewRecordRuntimeEventRequestWithBody generates requests for RecordRuntimeEvent with any type of body
-func NewRecordRuntimeEventRequestWithBody(server string, sessionID SessionID, contentType string, body io.Reader) (*http.Request, error) {
+// NewEvaluateSessionRequest generates requests for EvaluateSession
+func NewEvaluateSessionRequest(server string, sessionID SessionID) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -1855,7 +2365,7 @@ func NewRecordRuntimeEventRequestWithBody(server string, sessionID SessionID, co
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/sessions/%s/events", pathParam0)
+	operationPath := fmt.Sprintf("/api/v1/sessions/%s/evaluate", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1865,18 +2375,16 @@ func NewRecordRuntimeEventRequestWithBody(server string, sessionID SessionID, co
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-// NewGetMessagesRequest generates requests for GetMessages
-func NewGetMessagesRequest(server string, sessionID SessionID, params *GetMessagesParams) (*http.Request, error) {
+// NewGetRuntimeEventsRequest generates requests for GetRuntimeEvents
+func NewGetRuntimeEventsRequest(server string, sessionID SessionID) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -1891,7 +2399,7 @@ func NewGetMessagesRequest(server string, sessionID SessionID, params *GetMessag
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/sessions/%s/messages", pathParam0)
+	operationPath := fmt.Sprintf("/api/v1/sessions/%s/events", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1901,26 +2409,163 @@ func NewGetMessagesRequest(server string, sessionID SessionID, params *GetMessag
 		return nil, err
 	}
 
-	if params != nil {
-		queryValues := queryURL.Query()
-
-		if params.Limit != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
-		if params.Before != nil {
+	return req, nil
+}
+
+// NewRecordRuntimeEventRequest calls the generic RecordRuntimeEvent builder with application/json body
+func NewRecordRuntimeEventRequest(server string, sessionID SessionID, body RecordRuntimeEventJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRecordRuntimeEventRequestWithBody(server, sessionID, "application/json", bodyReader)
+}
+
+// NewRecordRuntimeEventRequestWithBody generates requests for RecordRuntimeEvent with any type of body
+func NewRecordRuntimeEventRequestWithBody(server string, sessionID SessionID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "sessionID", runtime.ParamLocationPath, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/sessions/%s/events", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewForkSessionRequest generates requests for ForkSession
+func NewForkSessionRequest(server string, sessionID SessionID, params *ForkSessionParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "sessionID", runtime.ParamLocationPath, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/sessions/%s/fork", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.FromMessage != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "fromMessage", runtime.ParamLocationQuery, *params.FromMessage); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetMessagesRequest generates requests for GetMessages
+func NewGetMessagesRequest(server string, sessionID SessionID, params *GetMessagesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "sessionID", runtime.ParamLocationPath, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/sessions/%s/messages", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Before != nil {
 
 			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "before", runtime.ParamLocationQuery, *params.Before); err != nil {
 				return nil, err
@@ -2010,6 +2655,60 @@ func NewAppendMessageRequestWithBody(server string, sessionID SessionID, content
 	return req, nil
 }
 
+// NewRecordAnnotationRequest calls the generic RecordAnnotation builder with application/json body
+func NewRecordAnnotationRequest(server string, sessionID SessionID, messageID openapi_types.UUID, body RecordAnnotationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRecordAnnotationRequestWithBody(server, sessionID, messageID, "application/json", bodyReader)
+}
+
+// NewRecordAnnotationRequestWithBody generates requests for RecordAnnotation with any type of body
+func NewRecordAnnotationRequestWithBody(server string, sessionID SessionID, messageID openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "sessionID", runtime.ParamLocationPath, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "messageID", runtime.ParamLocationPath, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/sessions/%s/messages/%s/annotations", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewGetProviderCallsRequest generates requests for GetProviderCalls
 func NewGetProviderCallsRequest(server string, sessionID SessionID) (*http.Request, error) {
 	var err error
@@ -2219,6 +2918,53 @@ func NewRecordToolCallRequestWithBody(server string, sessionID SessionID, conten
 	return req, nil
 }
 
+// NewTransitionStatusRequest calls the generic TransitionStatus builder with application/json body
+func NewTransitionStatusRequest(server string, sessionID SessionID, body TransitionStatusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTransitionStatusRequestWithBody(server, sessionID, "application/json", bodyReader)
+}
+
+// NewTransitionStatusRequestWithBody generates requests for TransitionStatus with any type of body
+func NewTransitionStatusRequestWithBody(server string, sessionID SessionID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "sessionID", runtime.ParamLocationPath, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/sessions/%s/transition", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewRefreshTTLRequest calls the generic RefreshTTL builder with application/json body
 func NewRefreshTTLRequest(server string, sessionID SessionID, body RefreshTTLJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -2336,6 +3082,9 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// ListAnnotationsWithResponse request
+	ListAnnotationsWithResponse(ctx context.Context, params *ListAnnotationsParams, reqEditors ...RequestEditorFn) (*ListAnnotationsResponse, error)
+
 	// ListEvalResultsWithResponse request
 	ListEvalResultsWithResponse(ctx context.Context, params *ListEvalResultsParams, reqEditors ...RequestEditorFn) (*ListEvalResultsResponse, error)
 
@@ -2369,6 +3118,15 @@ type ClientWithResponsesInterface interface {
 	// GetSessionWithResponse request
 	GetSessionWithResponse(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*GetSessionResponse, error)
 
+	// GetSessionAnnotationsWithResponse request
+	GetSessionAnnotationsWithResponse(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*GetSessionAnnotationsResponse, error)
+
+	// ListAttachmentsWithResponse request
+	ListAttachmentsWithResponse(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*ListAttachmentsResponse, error)
+
+	// GetAttachmentURLWithResponse request
+	GetAttachmentURLWithResponse(ctx context.Context, sessionID SessionID, attachmentID openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetAttachmentURLResponse, error)
+
 	// GetSessionEvalResultsWithResponse request
 	GetSessionEvalResultsWithResponse(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*GetSessionEvalResultsResponse, error)
 
@@ -2383,6 +3141,9 @@ type ClientWithResponsesInterface interface {
 
 	RecordRuntimeEventWithResponse(ctx context.Context, sessionID SessionID, body RecordRuntimeEventJSONRequestBody, reqEditors ...RequestEditorFn) (*RecordRuntimeEventResponse, error)
 
+	// ForkSessionWithResponse request
+	ForkSessionWithResponse(ctx context.Context, sessionID SessionID, params *ForkSessionParams, reqEditors ...RequestEditorFn) (*ForkSessionResponse, error)
+
 	// GetMessagesWithResponse request
 	GetMessagesWithResponse(ctx context.Context, sessionID SessionID, params *GetMessagesParams, reqEditors ...RequestEditorFn) (*GetMessagesResponse, error)
 
@@ -2391,6 +3152,11 @@ type ClientWithResponsesInterface interface {
 
 	AppendMessageWithResponse(ctx context.Context, sessionID SessionID, body AppendMessageJSONRequestBody, reqEditors ...RequestEditorFn) (*AppendMessageResponse, error)
 
+	// RecordAnnotationWithBodyWithResponse request with any body
+	RecordAnnotationWithBodyWithResponse(ctx context.Context, sessionID SessionID, messageID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RecordAnnotationResponse, error)
+
+	RecordAnnotationWithResponse(ctx context.Context, sessionID SessionID, messageID openapi_types.UUID, body RecordAnnotationJSONRequestBody, reqEditors ...RequestEditorFn) (*RecordAnnotationResponse, error)
+
 	// GetProviderCallsWithResponse request
 	GetProviderCallsWithResponse(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*GetProviderCallsResponse, error)
 
@@ -2412,6 +3178,11 @@ type ClientWithResponsesInterface interface {
 
 	RecordToolCallWithResponse(ctx context.Context, sessionID SessionID, body RecordToolCallJSONRequestBody, reqEditors ...RequestEditorFn) (*RecordToolCallResponse, error)
 
+	// TransitionStatusWithBodyWithResponse request with any body
+	TransitionStatusWithBodyWithResponse(ctx context.Context, sessionID SessionID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TransitionStatusResponse, error)
+
+	TransitionStatusWithResponse(ctx context.Context, sessionID SessionID, body TransitionStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*TransitionStatusResponse, error)
+
 	// RefreshTTLWithBodyWithResponse request with any body
 	RefreshTTLWithBodyWithResponse(ctx context.Context, sessionID SessionID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RefreshTTLResponse, error)
 
@@ -2421,6 +3192,30 @@ type ClientWithResponsesInterface interface {
 	HealthCheckWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*HealthCheckResponse, error)
 }
 
+type ListAnnotationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AnnotationListResponse
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAnnotationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAnnotationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type ListEvalResultsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -2572,7 +3367,80 @@ type SearchSessionsResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r SearchSessionsResponse) Status() string {
+func (r SearchSessionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SearchSessionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SessionResponse
+	JSON400      *BadRequest
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSessionAnnotationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AnnotationListResponse
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSessionAnnotationsResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -2580,23 +3448,24 @@ func (r SearchSessionsResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r SearchSessionsResponse) StatusCode() int {
+func (r GetSessionAnnotationsResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type DeleteSessionResponse struct {
+type ListAttachmentsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
+	JSON200      *AttachmentsResponse
 	JSON400      *BadRequest
 	JSON404      *NotFound
 	JSON500      *InternalError
 }
 
 // Status returns HTTPResponse.Status
-func (r DeleteSessionResponse) Status() string {
+func (r ListAttachmentsResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -2604,24 +3473,24 @@ func (r DeleteSessionResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r DeleteSessionResponse) StatusCode() int {
+func (r ListAttachmentsResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetSessionResponse struct {
+type GetAttachmentURLResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *SessionResponse
+	JSON200      *AttachmentURLResponse
 	JSON400      *BadRequest
 	JSON404      *NotFound
 	JSON500      *InternalError
 }
 
 // Status returns HTTPResponse.Status
-func (r GetSessionResponse) Status() string {
+func (r GetAttachmentURLResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -2629,7 +3498,7 @@ func (r GetSessionResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetSessionResponse) StatusCode() int {
+func (r GetAttachmentURLResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
@@ -2737,6 +3606,31 @@ func (r RecordRuntimeEventResponse) StatusCode() int {
 	return 0
 }
 
+type ForkSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *SessionResponse
+	JSON400      *BadRequest
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r ForkSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ForkSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetMessagesResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -2787,6 +3681,31 @@ func (r AppendMessageResponse) StatusCode() int {
 	return 0
 }
 
+type RecordAnnotationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *AnnotationResponse
+	JSON400      *BadRequest
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r RecordAnnotationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RecordAnnotationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetProviderCallsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -2912,6 +3831,33 @@ func (r RecordToolCallResponse) StatusCode() int {
 	return 0
 }
 
+type TransitionStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransitionSessionResponse
+	JSON400      *BadRequest
+	JSON404      *NotFound
+	JSON409      *Conflict
+	JSON413      *BodyTooLarge
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r TransitionStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TransitionStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type RefreshTTLResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -2958,6 +3904,15 @@ func (r HealthCheckResponse) StatusCode() int {
 	return 0
 }
 
+// ListAnnotationsWithResponse request returning *ListAnnotationsResponse
+func (c *ClientWithResponses) ListAnnotationsWithResponse(ctx context.Context, params *ListAnnotationsParams, reqEditors ...RequestEditorFn) (*ListAnnotationsResponse, error) {
+	rsp, err := c.ListAnnotations(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAnnotationsResponse(rsp)
+}
+
 // ListEvalResultsWithResponse request returning *ListEvalResultsResponse
 func (c *ClientWithResponses) ListEvalResultsWithResponse(ctx context.Context, params *ListEvalResultsParams, reqEditors ...RequestEditorFn) (*ListEvalResultsResponse, error) {
 	rsp, err := c.ListEvalResults(ctx, params, reqEditors...)
@@ -3063,6 +4018,33 @@ func (c *ClientWithResponses) GetSessionWithResponse(ctx context.Context, sessio
 	return ParseGetSessionResponse(rsp)
 }
 
+// GetSessionAnnotationsWithResponse request returning *GetSessionAnnotationsResponse
+func (c *ClientWithResponses) GetSessionAnnotationsWithResponse(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*GetSessionAnnotationsResponse, error) {
+	rsp, err := c.GetSessionAnnotations(ctx, sessionID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSessionAnnotationsResponse(rsp)
+}
+
+// ListAttachmentsWithResponse request returning *ListAttachmentsResponse
+func (c *ClientWithResponses) ListAttachmentsWithResponse(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*ListAttachmentsResponse, error) {
+	rsp, err := c.ListAttachments(ctx, sessionID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAttachmentsResponse(rsp)
+}
+
+// GetAttachmentURLWithResponse request returning *GetAttachmentURLResponse
+func (c *ClientWithResponses) GetAttachmentURLWithResponse(ctx context.Context, sessionID SessionID, attachmentID openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetAttachmentURLResponse, error) {
+	rsp, err := c.GetAttachmentURL(ctx, sessionID, attachmentID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAttachmentURLResponse(rsp)
+}
+
 // GetSessionEvalResultsWithResponse request returning *GetSessionEvalResultsResponse
 func (c *ClientWithResponses) GetSessionEvalResultsWithResponse(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*GetSessionEvalResultsResponse, error) {
 	rsp, err := c.GetSessionEvalResults(ctx, sessionID, reqEditors...)
@@ -3107,6 +4089,15 @@ func (c *ClientWithResponses) RecordRuntimeEventWithResponse(ctx context.Context
 	return ParseRecordRuntimeEventResponse(rsp)
 }
 
+// ForkSessionWithResponse request returning *ForkSessionResponse
+func (c *ClientWithResponses) ForkSessionWithResponse(ctx context.Context, sessionID SessionID, params *ForkSessionParams, reqEditors ...RequestEditorFn) (*ForkSessionResponse, error) {
+	rsp, err := c.ForkSession(ctx, sessionID, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseForkSessionResponse(rsp)
+}
+
 // GetMessagesWithResponse request returning *GetMessagesResponse
 func (c *ClientWithResponses) GetMessagesWithResponse(ctx context.Context, sessionID SessionID, params *GetMessagesParams, reqEditors ...RequestEditorFn) (*GetMessagesResponse, error) {
 	rsp, err := c.GetMessages(ctx, sessionID, params, reqEditors...)
@@ -3133,6 +4124,23 @@ func (c *ClientWithResponses) AppendMessageWithResponse(ctx context.Context, ses
 	return ParseAppendMessageResponse(rsp)
 }
 
+// RecordAnnotationWithBodyWithResponse request with arbitrary body returning *RecordAnnotationResponse
+func (c *ClientWithResponses) RecordAnnotationWithBodyWithResponse(ctx context.Context, sessionID SessionID, messageID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RecordAnnotationResponse, error) {
+	rsp, err := c.RecordAnnotationWithBody(ctx, sessionID, messageID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRecordAnnotationResponse(rsp)
+}
+
+func (c *ClientWithResponses) RecordAnnotationWithResponse(ctx context.Context, sessionID SessionID, messageID openapi_types.UUID, body RecordAnnotationJSONRequestBody, reqEditors ...RequestEditorFn) (*RecordAnnotationResponse, error) {
+	rsp, err := c.RecordAnnotation(ctx, sessionID, messageID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRecordAnnotationResponse(rsp)
+}
+
 // GetProviderCallsWithResponse request returning *GetProviderCallsResponse
 func (c *ClientWithResponses) GetProviderCallsWithResponse(ctx context.Context, sessionID SessionID, reqEditors ...RequestEditorFn) (*GetProviderCallsResponse, error) {
 	rsp, err := c.GetProviderCalls(ctx, sessionID, reqEditors...)
@@ -3202,6 +4210,23 @@ func (c *ClientWithResponses) RecordToolCallWithResponse(ctx context.Context, se
 	return ParseRecordToolCallResponse(rsp)
 }
 
+// TransitionStatusWithBodyWithResponse request with arbitrary body returning *TransitionStatusResponse
+func (c *ClientWithResponses) TransitionStatusWithBodyWithResponse(ctx context.Context, sessionID SessionID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TransitionStatusResponse, error) {
+	rsp, err := c.TransitionStatusWithBody(ctx, sessionID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTransitionStatusResponse(rsp)
+}
+
+func (c *ClientWithResponses) TransitionStatusWithResponse(ctx context.Context, sessionID SessionID, body TransitionStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*TransitionStatusResponse, error) {
+	rsp, err := c.TransitionStatus(ctx, sessionID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTransitionStatusResponse(rsp)
+}
+
 // RefreshTTLWithBodyWithResponse request with arbitrary body returning *RefreshTTLResponse
 func (c *ClientWithResponses) RefreshTTLWithBodyWithResponse(ctx context.Context, sessionID SessionID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RefreshTTLResponse, error) {
 	rsp, err := c.RefreshTTLWithBody(ctx, sessionID, contentType, body, reqEditors...)
@@ -3225,29 +4250,175 @@ func (c *ClientWithResponses) HealthCheckWithResponse(ctx context.Context, reqEd
 	if err != nil {
 		return nil, err
 	}
-	return ParseHealthCheckResponse(rsp)
+	return ParseHealthCheckResponse(rsp)
+}
+
+// ParseListAnnotationsResponse parses an HTTP response from a ListAnnotationsWithResponse call
+func ParseListAnnotationsResponse(rsp *http.Response) (*ListAnnotationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAnnotationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AnnotationListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListEvalResultsResponse parses an HTTP response from a ListEvalResultsWithResponse call
+func ParseListEvalResultsResponse(rsp *http.Response) (*ListEvalResultsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListEvalResultsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EvalResultListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateEvalResultsResponse parses an HTTP response from a CreateEvalResultsWithResponse call
+func ParseCreateEvalResultsResponse(rsp *http.Response) (*CreateEvalResultsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateEvalResultsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPrivacyPolicyResponse parses an HTTP response from a GetPrivacyPolicyWithResponse call
+func ParseGetPrivacyPolicyResponse(rsp *http.Response) (*GetPrivacyPolicyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPrivacyPolicyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PrivacyPolicyResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
 }
 
-// ParseListEvalResultsResponse parses an HTTP response from a ListEvalResultsWithResponse call
-func ParseListEvalResultsResponse(rsp *http.Response) (*ListEvalResultsResponse, error) {
+// ParseRecordProviderUsageResponse parses an HTTP response from a RecordProviderUsageWithResponse call
+func ParseRecordProviderUsageResponse(rsp *http.Response) (*RecordProviderUsageResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListEvalResultsResponse{
+	response := &RecordProviderUsageResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest EvalResultListResponse
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON200 = &dest
+		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest InternalError
@@ -3261,20 +4432,27 @@ func ParseListEvalResultsResponse(rsp *http.Response) (*ListEvalResultsResponse,
 	return response, nil
 }
 
-// ParseCreateEvalResultsResponse parses an HTTP response from a CreateEvalResultsWithResponse call
-func ParseCreateEvalResultsResponse(rsp *http.Response) (*CreateEvalResultsResponse, error) {
+// ParseListSessionsResponse parses an HTTP response from a ListSessionsWithResponse call
+func ParseListSessionsResponse(rsp *http.Response) (*ListSessionsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &CreateEvalResultsResponse{
+	response := &ListSessionsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SessionListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest BadRequest
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -3294,26 +4472,26 @@ func ParseCreateEvalResultsResponse(rsp *http.Response) (*CreateEvalResultsRespo
 	return response, nil
 }
 
-// ParseGetPrivacyPolicyResponse parses an HTTP response from a GetPrivacyPolicyWithResponse call
-func ParseGetPrivacyPolicyResponse(rsp *http.Response) (*GetPrivacyPolicyResponse, error) {
+// ParseCreateSessionResponse parses an HTTP response from a CreateSessionWithResponse call
+func ParseCreateSessionResponse(rsp *http.Response) (*CreateSessionResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetPrivacyPolicyResponse{
+	response := &CreateSessionResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest PrivacyPolicyResponse
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest SessionResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON200 = &dest
+		response.JSON201 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest BadRequest
@@ -3322,6 +4500,13 @@ func ParseGetPrivacyPolicyResponse(rsp *http.Response) (*GetPrivacyPolicyRespons
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 413:
+		var dest BodyTooLarge
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON413 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest InternalError
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -3334,20 +4519,27 @@ func ParseGetPrivacyPolicyResponse(rsp *http.Response) (*GetPrivacyPolicyRespons
 	return response, nil
 }
 
-// ParseRecordProviderUsageResponse parses an HTTP response from a RecordProviderUsageWithResponse call
-func ParseRecordProviderUsageResponse(rsp *http.Response) (*RecordProviderUsageResponse, error) {
+// ParseSearchSessionsResponse parses an HTTP response from a SearchSessionsWithResponse call
+func ParseSearchSessionsResponse(rsp *http.Response) (*SearchSessionsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &RecordProviderUsageResponse{
+	response := &SearchSessionsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SessionListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest BadRequest
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -3367,33 +4559,33 @@ func ParseRecordProviderUsageResponse(rsp *http.Response) (*RecordProviderUsageR
 	return response, nil
 }
 
-// ParseListSessionsResponse parses an HTTP response from a ListSessionsWithResponse call
-func ParseListSessionsResponse(rsp *http.Response) (*ListSessionsResponse, error) {
+// ParseDeleteSessionResponse parses an HTTP response from a DeleteSessionWithResponse call
+func ParseDeleteSessionResponse(rsp *http.Response) (*DeleteSessionResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListSessionsResponse{
+	response := &DeleteSessionResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest SessionListResponse
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON200 = &dest
+		response.JSON400 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest BadRequest
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.JSON404 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest InternalError
@@ -3407,26 +4599,26 @@ func ParseListSessionsResponse(rsp *http.Response) (*ListSessionsResponse, error
 	return response, nil
 }
 
-// ParseCreateSessionResponse parses an HTTP response from a CreateSessionWithResponse call
-func ParseCreateSessionResponse(rsp *http.Response) (*CreateSessionResponse, error) {
+// ParseGetSessionResponse parses an HTTP response from a GetSessionWithResponse call
+func ParseGetSessionResponse(rsp *http.Response) (*GetSessionResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &CreateSessionResponse{
+	response := &GetSessionResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
 		var dest SessionResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON201 = &dest
+		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest BadRequest
@@ -3435,12 +4627,12 @@ func ParseCreateSessionResponse(rsp *http.Response) (*CreateSessionResponse, err
 		}
 		response.JSON400 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 413:
-		var dest BodyTooLarge
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON413 = &dest
+		response.JSON404 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest InternalError
@@ -3454,22 +4646,22 @@ func ParseCreateSessionResponse(rsp *http.Response) (*CreateSessionResponse, err
 	return response, nil
 }
 
-// ParseSearchSessionsResponse parses an HTTP response from a SearchSessionsWithResponse call
-func ParseSearchSessionsResponse(rsp *http.Response) (*SearchSessionsResponse, error) {
+// ParseGetSessionAnnotationsResponse parses an HTTP response from a GetSessionAnnotationsWithResponse call
+func ParseGetSessionAnnotationsResponse(rsp *http.Response) (*GetSessionAnnotationsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &SearchSessionsResponse{
+	response := &GetSessionAnnotationsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest SessionListResponse
+		var dest AnnotationListResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -3494,20 +4686,27 @@ func ParseSearchSessionsResponse(rsp *http.Response) (*SearchSessionsResponse, e
 	return response, nil
 }
 
-// ParseDeleteSessionResponse parses an HTTP response from a DeleteSessionWithResponse call
-func ParseDeleteSessionResponse(rsp *http.Response) (*DeleteSessionResponse, error) {
+// ParseListAttachmentsResponse parses an HTTP response from a ListAttachmentsWithResponse call
+func ParseListAttachmentsResponse(rsp *http.Response) (*ListAttachmentsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteSessionResponse{
+	response := &ListAttachmentsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AttachmentsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest BadRequest
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -3534,22 +4733,22 @@ func ParseDeleteSessionResponse(rsp *http.Response) (*DeleteSessionResponse, err
 	return response, nil
 }
 
-// ParseGetSessionResponse parses an HTTP response from a GetSessionWithResponse call
-func ParseGetSessionResponse(rsp *http.Response) (*GetSessionResponse, error) {
+// ParseGetAttachmentURLResponse parses an HTTP response from a GetAttachmentURLWithResponse call
+func ParseGetAttachmentURLResponse(rsp *http.Response) (*GetAttachmentURLResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetSessionResponse{
+	response := &GetAttachmentURLResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest SessionResponse
+		var dest AttachmentURLResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -3776,6 +4975,53 @@ func ParseRecordRuntimeEventResponse(rsp *http.Response) (*RecordRuntimeEventRes
 	return response, nil
 }
 
+// ParseForkSessionResponse parses an HTTP response from a ForkSessionWithResponse call
+func ParseForkSessionResponse(rsp *http.Response) (*ForkSessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ForkSessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest SessionResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetMessagesResponse parses an HTTP response from a GetMessagesWithResponse call
 func ParseGetMessagesResponse(rsp *http.Response) (*GetMessagesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -3870,6 +5116,53 @@ func ParseAppendMessageResponse(rsp *http.Response) (*AppendMessageResponse, err
 	return response, nil
 }
 
+// ParseRecordAnnotationResponse parses an HTTP response from a RecordAnnotationWithResponse call
+func ParseRecordAnnotationResponse(rsp *http.Response) (*RecordAnnotationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RecordAnnotationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest AnnotationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetProviderCallsResponse parses an HTTP response from a GetProviderCallsWithResponse call
 func ParseGetProviderCallsResponse(rsp *http.Response) (*GetProviderCallsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -4105,6 +5398,67 @@ func ParseRecordToolCallResponse(rsp *http.Response) (*RecordToolCallResponse, e
 	return response, nil
 }
 
+// ParseTransitionStatusResponse parses an HTTP response from a TransitionStatusWithResponse call
+func ParseTransitionStatusResponse(rsp *http.Response) (*TransitionStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TransitionStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransitionSessionResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 413:
+		var dest BodyTooLarge
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON413 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseRefreshTTLResponse parses an HTTP response from a RefreshTTLWithResponse call
 func ParseRefreshTTLResponse(rsp *http.Response) (*RefreshTTLResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
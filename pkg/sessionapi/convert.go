@@ -270,27 +270,29 @@ func SessionFromAPI(s *Session) *session.Session {
 		return nil
 	}
 	out := &session.Session{
-		ID:                 uuidToString(s.Id),
-		AgentName:          deref(s.AgentName),
-		Namespace:          deref(s.Namespace),
-		CreatedAt:          deref(s.CreatedAt),
-		UpdatedAt:          deref(s.UpdatedAt),
-		ExpiresAt:          deref(s.ExpiresAt),
-		WorkspaceName:      deref(s.WorkspaceName),
-		EndedAt:            deref(s.EndedAt),
-		MessageCount:       deref(s.MessageCount),
-		ToolCallCount:      deref(s.ToolCallCount),
-		TotalInputTokens:   deref(s.TotalInputTokens),
-		TotalOutputTokens:  deref(s.TotalOutputTokens),
-		EstimatedCostUSD:   deref(s.EstimatedCostUSD),
-		LastMessagePreview: deref(s.LastMessagePreview),
-		PromptPackName:     deref(s.PromptPackName),
-		PromptPackVersion:  deref(s.PromptPackVersion),
-		CohortID:           deref(s.CohortId),
-		Variant:            deref(s.Variant),
-		Tags:               derefSlice(s.Tags),
-		State:              derefMap(s.State),
-		Messages:           MessagesFromAPI(s.Messages),
+		ID:                  uuidToString(s.Id),
+		AgentName:           deref(s.AgentName),
+		Namespace:           deref(s.Namespace),
+		CreatedAt:           deref(s.CreatedAt),
+		UpdatedAt:           deref(s.UpdatedAt),
+		ExpiresAt:           deref(s.ExpiresAt),
+		WorkspaceName:       deref(s.WorkspaceName),
+		EndedAt:             deref(s.EndedAt),
+		MessageCount:        deref(s.MessageCount),
+		ToolCallCount:       deref(s.ToolCallCount),
+		TotalInputTokens:    deref(s.TotalInputTokens),
+		TotalOutputTokens:   deref(s.TotalOutputTokens),
+		EstimatedCostUSD:    deref(s.EstimatedCostUSD),
+		LastMessagePreview:  deref(s.LastMessagePreview),
+		PromptPackName:      deref(s.PromptPackName),
+		PromptPackVersion:   deref(s.PromptPackVersion),
+		CohortID:            deref(s.CohortId),
+		Variant:             deref(s.Variant),
+		ParentSessionID:     deref(s.ParentSessionId),
+		ForkedFromMessageID: deref(s.ForkedFromMessageId),
+		Tags:                derefSlice(s.Tags),
+		State:               derefMap(s.State),
+		Messages:            MessagesFromAPI(s.Messages),
 	}
 	if s.Status != nil {
 		out.Status = session.SessionStatus(*s.Status)
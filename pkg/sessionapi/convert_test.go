@@ -208,6 +208,18 @@ func TestSessionFromAPI_WithCohortFields(t *testing.T) {
 	assert.Equal(t, "canary", result.Variant)
 }
 
+func TestSessionFromAPI_WithForkFields(t *testing.T) {
+	sid := uuid.New()
+	s := &Session{
+		Id:                  &sid,
+		ParentSessionId:     ptr("parent-1"),
+		ForkedFromMessageId: ptr("msg-7"),
+	}
+	result := SessionFromAPI(s)
+	assert.Equal(t, "parent-1", result.ParentSessionID)
+	assert.Equal(t, "msg-7", result.ForkedFromMessageID)
+}
+
 func TestSessionFromAPI_NilCohortFields(t *testing.T) {
 	sid := uuid.New()
 	s := &Session{